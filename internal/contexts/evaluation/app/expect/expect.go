@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"encoding/json"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
 type Finding struct {
@@ -113,7 +115,7 @@ func expectAttempt(attemptDir string, strict bool) (Result, error) {
 	if err != nil {
 		return Result{}, err
 	}
-	er := suite.Evaluate(sf, a.MissionID, fb, tf)
+	er := suite.EvaluateInWorkspace(sf, a.MissionID, fb, tf, suite.WorkspaceDirForAttempt(attemptDir))
 	return finalizeExpectationResult(res, er, feedbackPath), nil
 }
 
@@ -213,7 +215,11 @@ func finalizeExpectationResult(res Result, er suite.ExpectationResult, feedbackP
 	}
 	res.OK = false
 	for _, f := range er.Failures {
-		res.Failures = append(res.Failures, Finding{Code: "ZCL_E_EXPECTATION_FAILED", Message: f.Code + ": " + f.Message, Path: feedbackPath})
+		msg := f.Code + ": " + f.Message
+		if f.Pointer != "" {
+			msg += " (at resultJson pointer " + f.Pointer + ")"
+		}
+		res.Failures = append(res.Failures, Finding{Code: "ZCL_E_EXPECTATION_FAILED", Message: msg, Path: feedbackPath})
 	}
 	return res
 }
@@ -241,11 +247,26 @@ func traceFactsForAttempt(attemptDir string, strict bool) (*suite.TraceFacts, er
 	if !acc.seenNonEmpty {
 		return nil, nil
 	}
-	return acc.facts(), nil
+	facts := acc.facts()
+	facts.ToolCallsTotal += sampledOutCalls(attemptDir)
+	return facts, nil
+}
+
+// sampledOutCalls returns successful calls omitted by trace sampling so call budgets stay honest.
+func sampledOutCalls(attemptDir string) int64 {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.TraceSamplingJSON))
+	if err != nil {
+		return 0
+	}
+	var st schema.TraceSamplingJSONV1
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return 0
+	}
+	return st.OmittedTotal()
 }
 
-func openAttemptTrace(tracePath string, strict bool) (*os.File, bool, error) {
-	f, err := os.Open(tracePath)
+func openAttemptTrace(tracePath string, strict bool) (io.ReadCloser, bool, error) {
+	f, err := store.OpenArtifact(tracePath)
 	if err == nil {
 		return f, false, nil
 	}
@@ -280,7 +301,7 @@ func newTraceFactsAccumulator() *traceFactsAccumulator {
 	}
 }
 
-func scanAttemptTrace(f *os.File, strict bool, acc *traceFactsAccumulator) error {
+func scanAttemptTrace(f io.Reader, strict bool, acc *traceFactsAccumulator) error {
 	sc := bufio.NewScanner(f)
 	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 	for sc.Scan() {
@@ -3,6 +3,7 @@ package expect
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -80,3 +81,207 @@ func TestExpect_ResultRequiredJSONPointers_FailsWhenMissing(t *testing.T) {
 		t.Fatalf("expected ZCL_E_EXPECTATION_FAILED, got: %+v", res.Failures)
 	}
 }
+
+func TestExpect_ResultRegexMatchers_ReportMatchDetails(t *testing.T) {
+	attemptDir := writeExpectAttempt(t,
+		`{"version":1,"suiteId":"s","missions":[{"missionId":"m","expects":{"resultMatches":"^TITLE=","resultNotMatches":"(?i)lorem ipsum"}}]}`,
+		`"ok":true,"result":"TITLE=Lorem Ipsum draft"`,
+	)
+
+	res, err := ExpectPath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("ExpectPath: %v", err)
+	}
+	if res.OK || len(res.Failures) != 1 {
+		t.Fatalf("expected exactly one failure, got: %+v", res.Failures)
+	}
+	msg := res.Failures[0].Message
+	if !strings.HasPrefix(msg, "ZCL_E_EXPECT_RESULT_NOT_MATCHES") || !strings.Contains(msg, "offset 6") || !strings.Contains(msg, `"Lorem Ipsum"`) {
+		t.Fatalf("expected match details in failure, got: %q", msg)
+	}
+}
+
+func TestExpect_MetricsComparisonsWithTolerance(t *testing.T) {
+	attemptDir := writeExpectAttempt(t,
+		`{"version":1,"suiteId":"s","missions":[{"missionId":"m","expects":{"metrics":[
+  {"path":"resultJson.count","gte":3},
+  {"path":"resultJson.stats[0].ratio","equals":0.333,"tolerance":0.001},
+  {"path":"resultJson.stats[0].ratio","lt":0.3},
+  {"path":"resultJson.missing","gt":0}
+]}}]}`,
+		`"ok":true,"resultJson":{"count":3,"stats":[{"ratio":0.33333}]}`,
+	)
+
+	res, err := ExpectPath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("ExpectPath: %v", err)
+	}
+	if res.OK || len(res.Failures) != 2 {
+		t.Fatalf("expected lt and missing failures, got: %+v", res.Failures)
+	}
+	if !strings.Contains(res.Failures[0].Message, "got 0.33333, want < 0.3") {
+		t.Fatalf("unexpected comparison failure: %q", res.Failures[0].Message)
+	}
+	if !strings.HasPrefix(res.Failures[1].Message, "ZCL_E_EXPECT_METRIC_MISSING") {
+		t.Fatalf("unexpected missing failure: %q", res.Failures[1].Message)
+	}
+}
+
+func TestExpect_JSONPathAssertionsOverNestedResultJSON(t *testing.T) {
+	attemptDir := writeExpectAttempt(t,
+		`{"version":1,"suiteId":"s","missions":[{"missionId":"m","expects":{"jsonPath":[
+  {"path":"$.proof.items[1].name","equals":"beta"},
+  {"path":"$..tags","contains":"blog"},
+  {"path":"$.proof.items[*].id","equals":2},
+  {"path":"$.proof['draft']","exists":false},
+  {"path":"$.proof.items[0].name","equals":"gamma"}
+]}}]}`,
+		`"ok":true,"resultJson":{"proof":{"items":[{"id":1,"name":"alpha","tags":["news"]},{"id":2,"name":"beta","tags":["blog"]}]}}`,
+	)
+
+	res, err := ExpectPath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("ExpectPath: %v", err)
+	}
+	if res.OK || len(res.Failures) != 1 {
+		t.Fatalf("expected one failure, got: %+v", res.Failures)
+	}
+	if !strings.Contains(res.Failures[0].Message, `$.proof.items[0].name: got "alpha", want equals "gamma"`) {
+		t.Fatalf("unexpected failure: %q", res.Failures[0].Message)
+	}
+}
+
+func TestExpect_SchemaViolationsCarryPointers(t *testing.T) {
+	attemptDir := writeExpectAttempt(t,
+		`{"version":1,"suiteId":"s","missions":[{"missionId":"m","expects":{"schema":{
+  "type":"object",
+  "required":["title","links"],
+  "additionalProperties":false,
+  "properties":{
+    "title":{"type":"string","minLength":1},
+    "links":{"type":"array","items":{"$ref":"#/$defs/link"}},
+    "count":{"type":"integer"}
+  },
+  "$defs":{"link":{"type":"object","required":["href"],"properties":{"href":{"type":"string","pattern":"^https://"}}}}
+}}}]}`,
+		`"ok":true,"resultJson":{"title":"","links":[{"href":"https://a"},{"href":"http://b"},{}],"extra":1}`,
+	)
+
+	res, err := ExpectPath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("ExpectPath: %v", err)
+	}
+	want := []string{
+		"/extra)",
+		"/links/1/href)",
+		"/links/2/href)",
+		"/title)",
+	}
+	if res.OK || len(res.Failures) != len(want) {
+		t.Fatalf("expected %d schema failures, got: %+v", len(want), res.Failures)
+	}
+	for i, suffix := range want {
+		if msg := res.Failures[i].Message; !strings.HasPrefix(msg, "ZCL_E_EXPECT_SCHEMA") || !strings.HasSuffix(msg, suffix) {
+			t.Fatalf("failure %d: expected pointer suffix %q, got %q", i, suffix, msg)
+		}
+	}
+}
+
+func TestExpect_FilesVerifiedInAttemptWorkspace(t *testing.T) {
+	workspace := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workspace, "report.md"), []byte("# Report\nTotal: 42\n"), 0o644); err != nil {
+		t.Fatalf("write report.md: %v", err)
+	}
+	attemptDir := writeExpectAttempt(t,
+		`{"version":1,"suiteId":"s","missions":[{"missionId":"m","expects":{"files":[
+  {"path":"report.md","contains":"Total: 42"},
+  {"path":"report.md","sha256":"0000000000000000000000000000000000000000000000000000000000000000"},
+  {"path":"scratch.tmp","exists":false},
+  {"path":"summary.json"}
+]}}]}`,
+		`"ok":true,"result":"created report.md"`,
+	)
+	if err := os.WriteFile(filepath.Join(attemptDir, "attempt.runtime.env.json"), []byte(`{"schemaVersion":1,"runtime":{"startCwd":"`+workspace+`"}}`), 0o644); err != nil {
+		t.Fatalf("write attempt.runtime.env.json: %v", err)
+	}
+
+	res, err := ExpectPath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("ExpectPath: %v", err)
+	}
+	if res.OK || len(res.Failures) != 2 {
+		t.Fatalf("expected sha256 and missing-file failures, got: %+v", res.Failures)
+	}
+	if !strings.Contains(res.Failures[0].Message, "report.md: sha256") || !strings.Contains(res.Failures[1].Message, "summary.json: file does not exist") {
+		t.Fatalf("unexpected failures: %+v", res.Failures)
+	}
+}
+
+func TestExpect_GoldenMismatchThenUpdateGoldens(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "m.golden.json")
+	if err := os.WriteFile(golden, []byte("{\n  \"title\": \"Old\"\n}\n"), 0o644); err != nil {
+		t.Fatalf("write golden: %v", err)
+	}
+	attemptDir := writeExpectAttempt(t,
+		`{"version":1,"suiteId":"s","missions":[{"missionId":"m","expects":{"golden":"`+golden+`"}}]}`,
+		`"ok":true,"resultJson":{"title":"New","count":2}`,
+	)
+
+	res, err := ExpectPath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("ExpectPath: %v", err)
+	}
+	if res.OK || len(res.Failures) != 1 || !strings.HasPrefix(res.Failures[0].Message, "ZCL_E_EXPECT_GOLDEN: ") {
+		t.Fatalf("expected golden mismatch, got: %+v", res.Failures)
+	}
+
+	upd, err := UpdateGoldens(filepath.Dir(filepath.Dir(attemptDir)))
+	if err != nil {
+		t.Fatalf("UpdateGoldens: %v", err)
+	}
+	if !upd.OK || upd.Target != "run" || len(upd.Updated) != 1 || !upd.Updated[0].Changed {
+		t.Fatalf("unexpected update result: %+v", upd)
+	}
+	raw, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if want := "{\n  \"count\": 2,\n  \"title\": \"New\"\n}\n"; string(raw) != want {
+		t.Fatalf("unexpected golden contents:\n%s", raw)
+	}
+
+	res, err = ExpectPath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("ExpectPath: %v", err)
+	}
+	if !res.OK {
+		t.Fatalf("expected golden to match after update, got: %+v", res.Failures)
+	}
+}
+
+// writeExpectAttempt lays out runs/<runId>/{run.json,suite.json} plus one attempt with the given
+// feedback fields (a JSON object body without braces) and returns the attemptDir.
+func writeExpectAttempt(t *testing.T, suiteJSON string, feedbackFields string) string {
+	t.Helper()
+	dir := t.TempDir()
+	runID := "20260215-180012Z-09c5a6"
+	runDir := filepath.Join(dir, "runs", runID)
+	attemptID := "001-m-r1"
+	attemptDir := filepath.Join(runDir, "attempts", attemptID)
+	if err := os.MkdirAll(attemptDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(runDir, "suite.json"), []byte(suiteJSON), 0o644); err != nil {
+		t.Fatalf("write suite.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(runDir, "run.json"), []byte(`{"schemaVersion":1,"artifactLayoutVersion":1,"runId":"`+runID+`","suiteId":"s","createdAt":"2026-02-15T18:00:00Z"}`), 0o644); err != nil {
+		t.Fatalf("write run.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(attemptDir, "attempt.json"), []byte(`{"schemaVersion":1,"runId":"`+runID+`","suiteId":"s","missionId":"m","attemptId":"`+attemptID+`","mode":"ci","startedAt":"2026-02-15T18:00:00Z"}`), 0o644); err != nil {
+		t.Fatalf("write attempt.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(attemptDir, "feedback.json"), []byte(`{"schemaVersion":1,"runId":"`+runID+`","suiteId":"s","missionId":"m","attemptId":"`+attemptID+`",`+feedbackFields+`,"createdAt":"2026-02-15T18:00:02Z"}`), 0o644); err != nil {
+		t.Fatalf("write feedback.json: %v", err)
+	}
+	return attemptDir
+}
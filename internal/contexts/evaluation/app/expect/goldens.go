@@ -0,0 +1,147 @@
+package expect
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+type GoldenUpdate struct {
+	MissionID  string `json:"missionId"`
+	AttemptID  string `json:"attemptId"`
+	GoldenPath string `json:"goldenPath"`
+	Changed    bool   `json:"changed"`
+}
+
+type UpdateGoldensResult struct {
+	OK       bool           `json:"ok"`
+	Target   string         `json:"target"` // attempt|run
+	Path     string         `json:"path"`
+	Updated  []GoldenUpdate `json:"updated,omitempty"`
+	Failures []Finding      `json:"failures,omitempty"`
+}
+
+type goldenCandidate struct {
+	update GoldenUpdate
+	data   []byte
+}
+
+// UpdateGoldens rewrites expects.golden files from attempt feedback. It is the intentional
+// counterpart of golden evaluation: attempts of the same mission must agree, otherwise the
+// golden is left untouched and a conflict is reported.
+func UpdateGoldens(targetDir string) (UpdateGoldensResult, error) {
+	abs, err := filepath.Abs(targetDir)
+	if err != nil {
+		return UpdateGoldensResult{}, err
+	}
+	res := UpdateGoldensResult{OK: true, Path: abs}
+	var attemptDirs []string
+	switch {
+	case fileExists(filepath.Join(abs, artifacts.AttemptJSON)):
+		res.Target = "attempt"
+		attemptDirs = []string{abs}
+	case fileExists(filepath.Join(abs, artifacts.RunJSON)):
+		res.Target = "run"
+		entries, err := os.ReadDir(filepath.Join(abs, "attempts"))
+		if err != nil && !os.IsNotExist(err) {
+			return UpdateGoldensResult{}, err
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				attemptDirs = append(attemptDirs, filepath.Join(abs, "attempts", e.Name()))
+			}
+		}
+	default:
+		res.Target = "unknown"
+		res.OK = false
+		res.Failures = append(res.Failures, Finding{Code: "ZCL_E_USAGE", Message: "target does not look like an attemptDir or runDir", Path: abs})
+		return res, nil
+	}
+
+	byPath := map[string][]goldenCandidate{}
+	for _, dir := range attemptDirs {
+		c, ok := goldenCandidateForAttempt(dir, &res)
+		if ok {
+			byPath[c.update.GoldenPath] = append(byPath[c.update.GoldenPath], c)
+		}
+	}
+	paths := make([]string, 0, len(byPath))
+	for p := range byPath {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if err := writeGoldenCandidates(p, byPath[p], &res); err != nil {
+			return UpdateGoldensResult{}, err
+		}
+	}
+	return res, nil
+}
+
+func goldenCandidateForAttempt(attemptDir string, res *UpdateGoldensResult) (goldenCandidate, bool) {
+	var scratch Result
+	a, feedbackPath, fb, done, err := loadAttemptEvidence(attemptDir, true, &scratch)
+	if err == nil && !done {
+		var sf suite.SuiteFileV1
+		sf, done, err = loadSuiteSnapshot(attemptDir, true, &scratch)
+		if err == nil && !done {
+			m := suite.FindMission(sf, a.MissionID)
+			if m == nil || m.Expects == nil || m.Expects.Golden == "" {
+				return goldenCandidate{}, false
+			}
+			data, err := suite.NormalizeGoldenOutput(fb)
+			if err != nil {
+				res.OK = false
+				res.Failures = append(res.Failures, Finding{Code: "ZCL_E_INVALID_JSON", Message: err.Error(), Path: feedbackPath})
+				return goldenCandidate{}, false
+			}
+			return goldenCandidate{
+				update: GoldenUpdate{MissionID: a.MissionID, AttemptID: a.AttemptID, GoldenPath: m.Expects.Golden},
+				data:   data,
+			}, true
+		}
+	}
+	if err != nil {
+		scratch.Failures = append(scratch.Failures, Finding{Code: "ZCL_E_IO", Message: err.Error(), Path: attemptDir})
+	}
+	if len(scratch.Failures) > 0 {
+		res.OK = false
+		res.Failures = append(res.Failures, scratch.Failures...)
+	}
+	return goldenCandidate{}, false
+}
+
+func writeGoldenCandidates(path string, cands []goldenCandidate, res *UpdateGoldensResult) error {
+	for _, c := range cands[1:] {
+		if !bytes.Equal(c.data, cands[0].data) {
+			res.OK = false
+			res.Failures = append(res.Failures, Finding{
+				Code:    "ZCL_E_EXPECT_GOLDEN_CONFLICT",
+				Message: "attempts " + cands[0].update.AttemptID + " and " + c.update.AttemptID + " disagree; golden not updated",
+				Path:    path,
+			})
+			return nil
+		}
+	}
+	prev, err := os.ReadFile(path)
+	changed := err != nil || !bytes.Equal(prev, cands[0].data)
+	if changed {
+		if err := store.WriteFileAtomic(path, cands[0].data); err != nil {
+			return err
+		}
+	}
+	u := cands[0].update
+	u.Changed = changed
+	res.Updated = append(res.Updated, u)
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
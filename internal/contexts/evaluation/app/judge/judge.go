@@ -0,0 +1,192 @@
+package judge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/domain/oracle"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// PromptVersion is part of the cache key; bump it whenever BuildPrompt changes meaning.
+const PromptVersion = "llm-judge-v1"
+
+const cacheSchemaV1 = 1
+
+const maxResponseRunes = 4096
+
+// Request is everything that determines a judge verdict. Identical requests share a cache entry.
+type Request struct {
+	Model        string `json:"model"`
+	Strategy     string `json:"strategy"`
+	Instructions string `json:"instructions,omitempty"`
+	Oracle       any    `json:"oracle"`
+	Answer       any    `json:"answer"`
+}
+
+type Verdict struct {
+	OK         bool              `json:"ok"`
+	Reason     string            `json:"reason,omitempty"`
+	Mismatches []oracle.Mismatch `json:"mismatches,omitempty"`
+	CacheKey   string            `json:"cacheKey,omitempty"`
+	Cached     bool              `json:"cached"`
+}
+
+// AskFunc sends one prompt to the configured model and returns its final answer text.
+type AskFunc func(ctx context.Context, prompt string) (string, error)
+
+type cacheRecordV1 struct {
+	SchemaVersion int     `json:"schemaVersion"`
+	Key           string  `json:"key"`
+	PromptVersion string  `json:"promptVersion"`
+	Model         string  `json:"model"`
+	Strategy      string  `json:"strategy"`
+	Verdict       Verdict `json:"verdict"`
+	Response      string  `json:"response,omitempty"`
+}
+
+// Evaluate returns the judge verdict for req, asking the model only on a cache miss.
+// Unparseable model answers are errors and are not cached.
+func Evaluate(ctx context.Context, req Request, cacheDir string, ask AskFunc) (Verdict, error) {
+	key, err := CacheKey(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+	if v, ok := loadCached(cacheDir, key); ok {
+		v.CacheKey = key
+		v.Cached = true
+		return v, nil
+	}
+	prompt, err := BuildPrompt(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+	resp, err := ask(ctx, prompt)
+	if err != nil {
+		return Verdict{}, err
+	}
+	v, err := ParseVerdict(resp)
+	if err != nil {
+		return Verdict{}, err
+	}
+	v.CacheKey = key
+	if strings.TrimSpace(cacheDir) != "" {
+		rec := cacheRecordV1{
+			SchemaVersion: cacheSchemaV1,
+			Key:           key,
+			PromptVersion: PromptVersion,
+			Model:         req.Model,
+			Strategy:      req.Strategy,
+			Verdict:       v,
+			Response:      truncateRunes(resp, maxResponseRunes),
+		}
+		if err := store.WriteJSONAtomic(filepath.Join(cacheDir, key+".json"), rec); err != nil {
+			return Verdict{}, err
+		}
+	}
+	return v, nil
+}
+
+// CacheKey hashes the canonical JSON of the request together with PromptVersion.
+func CacheKey(req Request) (string, error) {
+	b, err := json.Marshal(struct {
+		PromptVersion string  `json:"promptVersion"`
+		Request       Request `json:"request"`
+	}{PromptVersion, req})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func BuildPrompt(req Request) (string, error) {
+	oracleJSON, err := json.MarshalIndent(req.Oracle, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("oracle is not json-encodable: %w", err)
+	}
+	answerJSON, err := json.MarshalIndent(req.Answer, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("answer is not json-encodable: %w", err)
+	}
+	var b strings.Builder
+	b.WriteString("You are grading an agent's answer against a reference oracle. Do not run tools.\n")
+	b.WriteString("Decide whether the answer satisfies the oracle. Ignore formatting differences that do not change meaning.\n")
+	if s := strings.TrimSpace(req.Instructions); s != "" {
+		b.WriteString("\nAdditional grading instructions:\n")
+		b.WriteString(s)
+		b.WriteString("\n")
+	}
+	b.WriteString("\nOracle:\n")
+	b.Write(oracleJSON)
+	b.WriteString("\n\nAnswer:\n")
+	b.Write(answerJSON)
+	b.WriteString("\n\nReply with a single JSON object and nothing else:\n")
+	b.WriteString(`{"ok": true|false, "reason": "<one sentence>", "mismatches": [{"field": "<field>", "mismatchClass": "format|type|semantic", "message": "<what differs>"}]}`)
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// ParseVerdict extracts the verdict object from a model answer, tolerating prose or code fences around it.
+func ParseVerdict(text string) (Verdict, error) {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return Verdict{}, fmt.Errorf("judge answer contains no json object")
+	}
+	var raw struct {
+		OK         *bool             `json:"ok"`
+		Reason     string            `json:"reason"`
+		Mismatches []oracle.Mismatch `json:"mismatches"`
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), &raw); err != nil {
+		return Verdict{}, fmt.Errorf("judge answer is not valid json: %w", err)
+	}
+	if raw.OK == nil {
+		return Verdict{}, fmt.Errorf("judge answer is missing ok")
+	}
+	v := Verdict{OK: *raw.OK, Reason: strings.TrimSpace(raw.Reason)}
+	for _, mm := range raw.Mismatches {
+		switch strings.ToLower(strings.TrimSpace(mm.MismatchClass)) {
+		case oracle.MismatchFormat, oracle.MismatchType:
+			mm.MismatchClass = strings.ToLower(strings.TrimSpace(mm.MismatchClass))
+		default:
+			mm.MismatchClass = oracle.MismatchSemantic
+		}
+		mm.Op = "llm_judge"
+		v.Mismatches = append(v.Mismatches, mm)
+	}
+	if !v.OK && len(v.Mismatches) == 0 {
+		v.Mismatches = []oracle.Mismatch{{Op: "llm_judge", MismatchClass: oracle.MismatchSemantic, Message: v.Reason}}
+	}
+	return v, nil
+}
+
+func loadCached(cacheDir, key string) (Verdict, bool) {
+	if strings.TrimSpace(cacheDir) == "" {
+		return Verdict{}, false
+	}
+	raw, err := os.ReadFile(filepath.Join(cacheDir, key+".json"))
+	if err != nil {
+		return Verdict{}, false
+	}
+	var rec cacheRecordV1
+	if err := json.Unmarshal(raw, &rec); err != nil || rec.SchemaVersion != cacheSchemaV1 || rec.Key != key {
+		return Verdict{}, false
+	}
+	return rec.Verdict, true
+}
+
+func truncateRunes(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max])
+}
@@ -0,0 +1,48 @@
+package judge
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEvaluate_CachesVerdictByContentHash(t *testing.T) {
+	cacheDir := t.TempDir()
+	calls := 0
+	ask := func(ctx context.Context, prompt string) (string, error) {
+		calls++
+		return "Verdict:\n```json\n{\"ok\": false, \"reason\": \"wrong title\", \"mismatches\": [{\"field\": \"title\", \"mismatchClass\": \"Semantic\", \"message\": \"Foo vs Bar\"}]}\n```", nil
+	}
+	req := Request{Model: "gpt-5", Strategy: "codex_app_server", Oracle: map[string]any{"title": "Foo"}, Answer: map[string]any{"title": "Bar"}}
+
+	first, err := Evaluate(context.Background(), req, cacheDir, ask)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if first.OK || first.Cached || len(first.Mismatches) != 1 || first.Mismatches[0].MismatchClass != "semantic" || first.Mismatches[0].Field != "title" {
+		t.Fatalf("unexpected first verdict: %+v", first)
+	}
+	second, err := Evaluate(context.Background(), req, cacheDir, ask)
+	if err != nil {
+		t.Fatalf("Evaluate (cached): %v", err)
+	}
+	if calls != 1 || !second.Cached || second.CacheKey != first.CacheKey || second.Reason != "wrong title" {
+		t.Fatalf("expected cached verdict without a second model call (calls=%d), got %+v", calls, second)
+	}
+
+	req.Answer = map[string]any{"title": "Foo"}
+	if _, err := Evaluate(context.Background(), req, cacheDir, ask); err != nil {
+		t.Fatalf("Evaluate (changed answer): %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected changed answer to miss the cache, calls=%d", calls)
+	}
+}
+
+func TestParseVerdict_RejectsAnswersWithoutOK(t *testing.T) {
+	if _, err := ParseVerdict("looks fine to me"); err == nil {
+		t.Fatalf("expected error for prose answer")
+	}
+	if _, err := ParseVerdict(`{"reason":"no decision"}`); err == nil {
+		t.Fatalf("expected error for missing ok")
+	}
+}
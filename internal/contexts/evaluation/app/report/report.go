@@ -2,9 +2,13 @@ package report
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"hash/fnv"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -17,6 +21,9 @@ import (
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
+// traceLineMaxBytes bounds a single tool.calls.jsonl line held in memory while streaming.
+const traceLineMaxBytes = 8 * 1024 * 1024
+
 type CliError struct {
 	Code    string
 	Message string
@@ -25,7 +32,11 @@ type CliError struct {
 func (e *CliError) Error() string { return e.Message }
 
 func BuildAttemptReport(now time.Time, attemptDir string, strict bool) (schema.AttemptReportJSONV1, error) {
-	tracePath := filepath.Join(attemptDir, artifacts.ToolCallsJSONL)
+	// A finish that crashed after its commit point would later overwrite whatever is built here.
+	if err := store.RollForwardDirTx(attemptDir, artifacts.AttemptFinishTx); err != nil {
+		return schema.AttemptReportJSONV1{}, err
+	}
+	tracePath := store.ResolveArtifactPath(filepath.Join(attemptDir, artifacts.ToolCallsJSONL))
 	feedbackPath := filepath.Join(attemptDir, artifacts.FeedbackJSON)
 	attempt, enforce, err := loadAttemptForReport(attemptDir, strict)
 	if err != nil {
@@ -35,30 +46,44 @@ func BuildAttemptReport(now time.Time, attemptDir string, strict bool) (schema.A
 	if err != nil {
 		return schema.AttemptReportJSONV1{}, err
 	}
-	metrics, signals, err := computeMetricsAndSignals(tracePath, enforce)
+	override, err := loadVerdictOverrideForReport(attemptDir, okPtr)
 	if err != nil {
 		return schema.AttemptReportJSONV1{}, err
 	}
-	traceSummary, err := scanTraceSummary(tracePath)
+	if override != nil {
+		ok := override.OK
+		okPtr = &ok
+	}
+	scan, err := scanTraceForReport(tracePath, enforce)
 	if err != nil {
-		if enforce {
-			return schema.AttemptReportJSONV1{}, err
-		}
+		return schema.AttemptReportJSONV1{}, err
 	}
+	metrics, signals, traceSummary := scan.metrics, scan.signals, scan.summary
+	applySampledOutCalls(attemptDir, &metrics)
+	applyDiskUsage(attemptDir, &metrics)
+	applyResources(attemptDir, &metrics)
 	tracePresent, traceNonEmpty, err := tracePresenceAndNonEmpty(tracePath, enforce)
 	if err != nil {
 		return schema.AttemptReportJSONV1{}, err
 	}
+	if err := requireDecryptable(attemptDir); err != nil {
+		return schema.AttemptReportJSONV1{}, err
+	}
 
 	promptContaminationTerms := promptContaminationTerms(attemptDir, attempt.BlindTerms)
 	timedOutBeforeFirstToolCall := classifyTimedOutBeforeFirstToolCall(attempt, traceSummary)
 	integrity := buildAttemptIntegrity(tracePresent, traceNonEmpty, feedbackPresent, promptContaminationTerms)
+	integrity.TraceChainHead = scan.chainHead
+	if attempt.Blind {
+		applyOutputContamination(integrity, attemptDir, attempt.BlindTerms, fb)
+	}
 	artifacts := discoverAttemptArtifacts(attemptDir)
+	integrity.PromptRewritten = artifacts.PromptContaminationJSON != ""
 
 	startedAt := attempt.StartedAt
-	endedAt := resolveAttemptEndedAt(feedbackPresent, fb.CreatedAt, traceNonEmpty, tracePath)
+	endedAt := resolveAttemptEndedAt(feedbackPresent, fb.CreatedAt, traceNonEmpty, scan.maxTSRaw)
 	failureCodeHistogram := cloneCountMap(metrics.FailuresByCode)
-	tokenEstimates := tokenEstimatesForAttempt(attemptDir, tracePath, metrics)
+	tokenEstimates := tokenEstimatesForAttempt(attemptDir, traceSummary, metrics)
 	decisionTags := deriveDecisionTags(fb.DecisionTags, okPtr, metrics, integrity, timedOutBeforeFirstToolCall)
 
 	expects, err := buildExpectationsForReport(attemptDir, attempt.MissionID, fb, feedbackPresent, metrics, signals, enforce)
@@ -76,10 +101,14 @@ func BuildAttemptReport(now time.Time, attemptDir string, strict bool) (schema.A
 		StartedAt:                   startedAt,
 		EndedAt:                     endedAt,
 		OK:                          okPtr,
+		VerdictOverride:             override,
 		Result:                      fb.Result,
 		ResultJSON:                  fb.ResultJSON,
 		Classification:              fb.Classification,
 		DecisionTags:                decisionTags,
+		Confidence:                  fb.Confidence,
+		FeedbackChurn:               feedbackChurn(attemptDir),
+		Progress:                    feedbackProgress(attemptDir),
 		NativeResult:                cloneNativeResultProvenance(attempt.NativeResult),
 		Metrics:                     metrics,
 		FailureCodeHistogram:        failureCodeHistogram,
@@ -127,6 +156,37 @@ func loadFeedbackForReport(feedbackPath string, enforce bool) (schema.FeedbackJS
 	return schema.FeedbackJSONV1{}, nil, false, nil
 }
 
+// loadVerdictOverrideForReport returns the adjudicated verdict from verdict.override.json, if any.
+// OriginalOK falls back to the feedback verdict when the override did not capture one.
+func loadVerdictOverrideForReport(attemptDir string, feedbackOK *bool) (*schema.VerdictOverrideReportV1, error) {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.VerdictOverrideJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var v schema.VerdictOverrideJSONV1
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	if v.SchemaVersion != schema.VerdictOverrideSchemaV1 {
+		return nil, &CliError{Code: "ZCL_E_SCHEMA_UNSUPPORTED", Message: "unsupported verdict.override.json schemaVersion"}
+	}
+	orig := v.OriginalOK
+	if orig == nil && feedbackOK != nil {
+		fo := *feedbackOK
+		orig = &fo
+	}
+	return &schema.VerdictOverrideReportV1{
+		OK:         v.OK,
+		OriginalOK: orig,
+		Reason:     v.Reason,
+		By:         v.By,
+		CreatedAt:  v.CreatedAt,
+	}, nil
+}
+
 func tracePresenceAndNonEmpty(tracePath string, enforce bool) (bool, bool, error) {
 	if _, err := os.Stat(tracePath); err != nil {
 		return false, false, nil
@@ -153,38 +213,40 @@ func buildAttemptIntegrity(tracePresent, traceNonEmpty, feedbackPresent bool, te
 }
 
 func discoverAttemptArtifacts(attemptDir string) schema.AttemptArtifactsV1 {
-	artifacts := schema.AttemptArtifactsV1{
+	out := schema.AttemptArtifactsV1{
 		AttemptJSON:  artifacts.AttemptJSON,
 		TraceJSONL:   artifacts.ToolCallsJSONL,
 		FeedbackJSON: artifacts.FeedbackJSON,
 	}
-	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.NotesJSONL), &artifacts.NotesJSONL, artifacts.NotesJSONL)
-	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.PromptTXT), &artifacts.PromptTXT, artifacts.PromptTXT)
-	setArtifactIfPresent(filepath.Join(attemptDir, schema.AttemptEnvShFileNameV1), &artifacts.AttemptEnvSH, schema.AttemptEnvShFileNameV1)
-	setArtifactIfPresent(filepath.Join(attemptDir, schema.AttemptRuntimeEnvFileNameV1), &artifacts.AttemptRuntimeEnvJSON, schema.AttemptRuntimeEnvFileNameV1)
-	setArtifactIfPresent(filepath.Join(attemptDir, "runner.command.txt"), &artifacts.RunnerCommandTXT, "runner.command.txt")
-	setArtifactIfPresent(filepath.Join(attemptDir, "runner.stdout.log"), &artifacts.RunnerStdoutLOG, "runner.stdout.log")
-	setArtifactIfPresent(filepath.Join(attemptDir, "runner.stderr.log"), &artifacts.RunnerStderrLOG, "runner.stderr.log")
-	return artifacts
+	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.NotesJSONL), &out.NotesJSONL, artifacts.NotesJSONL)
+	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.FeedbackHistoryJSONL), &out.FeedbackHistoryJSONL, artifacts.FeedbackHistoryJSONL)
+	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.FeedbackProgressJSONL), &out.FeedbackProgressJSONL, artifacts.FeedbackProgressJSONL)
+	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.PromptTXT), &out.PromptTXT, artifacts.PromptTXT)
+	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.PromptOriginalTXT), &out.PromptOriginalTXT, artifacts.PromptOriginalTXT)
+	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.PromptContaminationJSON), &out.PromptContaminationJSON, artifacts.PromptContaminationJSON)
+	setArtifactIfPresent(filepath.Join(attemptDir, artifacts.PurgeJSONL), &out.PurgeJSONL, artifacts.PurgeJSONL)
+	setArtifactIfPresent(filepath.Join(attemptDir, schema.AttemptEnvShFileNameV1), &out.AttemptEnvSH, schema.AttemptEnvShFileNameV1)
+	setArtifactIfPresent(filepath.Join(attemptDir, schema.AttemptRuntimeEnvFileNameV1), &out.AttemptRuntimeEnvJSON, schema.AttemptRuntimeEnvFileNameV1)
+	setArtifactIfPresent(filepath.Join(attemptDir, "runner.command.txt"), &out.RunnerCommandTXT, "runner.command.txt")
+	setArtifactIfPresent(filepath.Join(attemptDir, "runner.stdout.log"), &out.RunnerStdoutLOG, "runner.stdout.log")
+	setArtifactIfPresent(filepath.Join(attemptDir, "runner.stderr.log"), &out.RunnerStderrLOG, "runner.stderr.log")
+	return out
 }
 
 func setArtifactIfPresent(path string, out *string, name string) {
-	if _, err := os.Stat(path); err == nil {
+	if _, err := os.Stat(store.ResolveArtifactPath(path)); err == nil {
 		*out = name
 	}
 }
 
-func resolveAttemptEndedAt(feedbackPresent bool, feedbackCreatedAt string, traceNonEmpty bool, tracePath string) string {
+func resolveAttemptEndedAt(feedbackPresent bool, feedbackCreatedAt string, traceNonEmpty bool, maxTraceTS string) string {
 	if feedbackPresent && feedbackCreatedAt != "" {
 		return feedbackCreatedAt
 	}
 	if !traceNonEmpty {
 		return ""
 	}
-	if ts, ok := maxTraceTS(tracePath); ok {
-		return ts
-	}
-	return ""
+	return maxTraceTS
 }
 
 func buildExpectationsForReport(attemptDir, missionID string, fb schema.FeedbackJSONV1, feedbackPresent bool, metrics schema.AttemptMetricsV1, signals *schema.AttemptSignalsV1, enforce bool) (*schema.ExpectationResultV1, error) {
@@ -199,7 +261,7 @@ func buildExpectationsForReport(attemptDir, missionID string, fb schema.Feedback
 		return nil, nil
 	}
 	tf := buildSuiteTraceFacts(metrics, signals)
-	er := suite.Evaluate(sf, missionID, fb, &tf)
+	er := suite.EvaluateInWorkspace(sf, missionID, fb, &tf, suite.WorkspaceDirForAttempt(attemptDir))
 	expects := &schema.ExpectationResultV1{
 		Evaluated: er.Evaluated,
 		OK:        er.OK,
@@ -209,7 +271,7 @@ func buildExpectationsForReport(attemptDir, missionID string, fb schema.Feedback
 	}
 	expects.Failures = make([]schema.ExpectationFailureV1, 0, len(er.Failures))
 	for _, f := range er.Failures {
-		expects.Failures = append(expects.Failures, schema.ExpectationFailureV1{Code: f.Code, Message: f.Message})
+		expects.Failures = append(expects.Failures, schema.ExpectationFailureV1{Code: f.Code, Message: f.Message, Pointer: f.Pointer})
 	}
 	return expects, nil
 }
@@ -249,57 +311,69 @@ type traceSummary struct {
 	InputBytes int64
 }
 
-func scanTraceSummary(tracePath string) (traceSummary, error) {
-	f, err := os.Open(tracePath)
+func promptContaminationTerms(attemptDir string, configured []string) []string {
+	promptPath := filepath.Join(attemptDir, artifacts.PromptTXT)
+	b, err := store.ReadArtifactFile(promptPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return traceSummary{}, nil
-		}
-		return traceSummary{}, err
+		return nil
 	}
-	defer func() { _ = f.Close() }()
+	terms := configured
+	if len(terms) == 0 {
+		terms = blind.DefaultHarnessTermsV1()
+	}
+	return blind.FindContaminationTerms(string(b), terms)
+}
 
-	sc := bufio.NewScanner(f)
-	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
-	out := traceSummary{}
-	for sc.Scan() {
-		line := sc.Bytes()
-		if len(line) == 0 {
+// applyOutputContamination scans what the runner produced (stdout/stderr tails and the feedback
+// result) for harness terms, so leaks through shims or prompts show up even when prompt.txt is clean.
+func applyOutputContamination(integrity *schema.AttemptIntegrityV1, attemptDir string, configured []string, fb schema.FeedbackJSONV1) {
+	sources := []struct {
+		name string
+		text string
+	}{
+		{name: "runner.stdout.log", text: readFileText(filepath.Join(attemptDir, "runner.stdout.log"))},
+		{name: "runner.stderr.log", text: readFileText(filepath.Join(attemptDir, "runner.stderr.log"))},
+		{name: "feedback.result", text: fb.Result + "\n" + string(fb.ResultJSON)},
+	}
+	seen := map[string]bool{}
+	for _, src := range sources {
+		found := blind.FindContaminationTerms(src.text, configured)
+		if len(found) == 0 {
 			continue
 		}
-		var ev schema.TraceEventV1
-		if err := json.Unmarshal(line, &ev); err != nil {
-			return traceSummary{}, err
+		integrity.OutputContaminationSources = append(integrity.OutputContaminationSources, src.name)
+		for _, t := range found {
+			if !seen[t] {
+				seen[t] = true
+				integrity.OutputContaminationTerms = append(integrity.OutputContaminationTerms, t)
+			}
 		}
-		out.HasEvent = true
-		out.InputBytes += int64(len(ev.Input))
-		ts, err := time.Parse(time.RFC3339Nano, ev.TS)
-		if err != nil {
+	}
+	sort.Strings(integrity.OutputContaminationTerms)
+	integrity.OutputContaminated = len(integrity.OutputContaminationTerms) > 0
+}
+
+// requireDecryptable fails the report when the prompt or runner IO is encrypted at rest and no
+// configured identity opens it; contamination checks would otherwise run on empty text.
+func requireDecryptable(attemptDir string) error {
+	for _, name := range []string{artifacts.PromptTXT, "runner.stdout.log", "runner.stderr.log"} {
+		p := filepath.Join(attemptDir, name)
+		if store.ResolveArtifactPath(p) != p+store.EncryptedSuffix {
 			continue
 		}
-		if out.FirstTS.IsZero() || ts.Before(out.FirstTS) {
-			out.FirstTS = ts
-			out.FirstTSRaw = ev.TS
-			out.FirstCode = strings.TrimSpace(ev.Result.Code)
+		if _, err := store.ReadArtifactFile(p); err != nil {
+			return &CliError{Code: "ZCL_E_DECRYPT", Message: name + ": " + err.Error()}
 		}
 	}
-	if err := sc.Err(); err != nil {
-		return traceSummary{}, err
-	}
-	return out, nil
+	return nil
 }
 
-func promptContaminationTerms(attemptDir string, configured []string) []string {
-	promptPath := filepath.Join(attemptDir, artifacts.PromptTXT)
-	b, err := os.ReadFile(promptPath)
+func readFileText(path string) string {
+	b, err := store.ReadArtifactFile(path)
 	if err != nil {
-		return nil
-	}
-	terms := configured
-	if len(terms) == 0 {
-		terms = blind.DefaultHarnessTermsV1()
+		return ""
 	}
-	return blind.FindContaminationTerms(string(b), terms)
+	return string(b)
 }
 
 func classifyTimedOutBeforeFirstToolCall(a schema.AttemptJSONV1, s traceSummary) bool {
@@ -321,12 +395,11 @@ func classifyTimedOutBeforeFirstToolCall(a schema.AttemptJSONV1, s traceSummary)
 	return (s.FirstTS.Equal(deadline) || s.FirstTS.After(deadline)) && s.FirstCode == "ZCL_E_TIMEOUT"
 }
 
-func tokenEstimatesForAttempt(attemptDir string, tracePath string, metrics schema.AttemptMetricsV1) *schema.TokenEstimatesV1 {
+func tokenEstimatesForAttempt(attemptDir string, s traceSummary, metrics schema.AttemptMetricsV1) *schema.TokenEstimatesV1 {
 	if m, ok := loadRunnerTokenEstimates(filepath.Join(attemptDir, artifacts.RunnerMetricsJSON)); ok {
 		return m
 	}
-	s, err := scanTraceSummary(tracePath)
-	if err != nil || !s.HasEvent {
+	if !s.HasEvent {
 		return nil
 	}
 	in := approxTokens(s.InputBytes)
@@ -411,6 +484,9 @@ func deriveDecisionTags(fromFeedback []string, okPtr *bool, metrics schema.Attem
 	if integrity != nil && integrity.PromptContaminated {
 		out = append(out, schema.DecisionTagContaminatedPrompt)
 	}
+	if integrity != nil && integrity.OutputContaminated {
+		out = append(out, schema.DecisionTagContaminatedOutput)
+	}
 	if integrity != nil && !integrity.FeedbackPresent {
 		out = append(out, schema.DecisionTagMissingEvidence)
 	}
@@ -421,67 +497,47 @@ func WriteAttemptReportAtomic(path string, report schema.AttemptReportJSONV1) er
 	return store.WriteJSONAtomic(path, report)
 }
 
-func maxTraceTS(tracePath string) (string, bool) {
-	f, err := os.Open(tracePath)
-	if err != nil {
-		return "", false
-	}
-	defer func() { _ = f.Close() }()
-
-	sc := bufio.NewScanner(f)
-	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
-	var (
-		maxTS  time.Time
-		maxStr string
-	)
-	for sc.Scan() {
-		line := sc.Bytes()
-		if len(line) == 0 {
-			continue
-		}
-		var ev schema.TraceEventV1
-		if err := json.Unmarshal(line, &ev); err != nil {
-			continue
-		}
-		ts, err := time.Parse(time.RFC3339Nano, ev.TS)
-		if err != nil {
-			continue
-		}
-		if maxTS.IsZero() || ts.After(maxTS) {
-			maxTS = ts
-			maxStr = ev.TS
-		}
-	}
-	if maxTS.IsZero() {
-		return "", false
-	}
-	return maxStr, true
+// traceScan is everything the attempt report needs from tool.calls.jsonl, gathered in one
+// streaming pass. Memory is bounded by distinct signatures/durations, not by event count.
+type traceScan struct {
+	metrics  schema.AttemptMetricsV1
+	signals  *schema.AttemptSignalsV1
+	summary  traceSummary
+	maxTSRaw string
+	// chainHead is the link over the last event when the trace is hash-chained.
+	chainHead string
 }
 
-func computeMetricsAndSignals(tracePath string, strict bool) (schema.AttemptMetricsV1, *schema.AttemptSignalsV1, error) {
+func scanTraceForReport(tracePath string, strict bool) (traceScan, error) {
 	f, missing, err := openTraceForMetrics(tracePath, strict)
 	if err != nil {
-		return schema.AttemptMetricsV1{}, nil, err
+		return traceScan{}, err
 	}
 	if missing {
-		return schema.AttemptMetricsV1{}, nil, nil
+		return traceScan{}, nil
 	}
 	defer func() { _ = f.Close() }()
 
 	acc := newTraceMetricsAccumulator()
 	if err := scanTraceMetrics(f, strict, acc); err != nil {
-		return schema.AttemptMetricsV1{}, nil, err
+		return traceScan{}, err
 	}
 	if acc.metrics.ToolCallsTotal == 0 {
-		return emptyMetricsResult(strict)
+		metrics, signals, err := emptyMetricsResult(strict)
+		return traceScan{metrics: metrics, signals: signals}, err
 	}
 	acc.finalizeMetrics()
-	signals := acc.buildSignals()
-	return acc.metrics, signals, nil
+	return traceScan{
+		metrics:   acc.metrics,
+		signals:   acc.buildSignals(),
+		summary:   acc.summary,
+		maxTSRaw:  acc.maxTSRaw,
+		chainHead: acc.chainHead,
+	}, nil
 }
 
-func openTraceForMetrics(tracePath string, strict bool) (*os.File, bool, error) {
-	f, err := os.Open(tracePath)
+func openTraceForMetrics(tracePath string, strict bool) (io.ReadCloser, bool, error) {
+	f, err := store.OpenArtifact(tracePath)
 	if err == nil {
 		return f, false, nil
 	}
@@ -495,6 +551,130 @@ func openTraceForMetrics(tracePath string, strict bool) (*os.File, bool, error)
 	return nil, false, err
 }
 
+// applySampledOutCalls folds calls omitted by trace sampling back into the totals.
+func applySampledOutCalls(attemptDir string, metrics *schema.AttemptMetricsV1) {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.TraceSamplingJSON))
+	if err != nil {
+		return
+	}
+	var st schema.TraceSamplingJSONV1
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return
+	}
+	metrics.SampledOutTotal = st.OmittedTotal()
+	metrics.ToolCallsTotal += metrics.SampledOutTotal
+}
+
+// applyDiskUsage copies the disk footprint suite run measured around the runner (disk.usage.json).
+func applyDiskUsage(attemptDir string, metrics *schema.AttemptMetricsV1) {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.DiskUsageJSON))
+	if err != nil {
+		return
+	}
+	var u schema.DiskUsageJSONV1
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return
+	}
+	metrics.DiskBytes = u.TotalBytes
+	metrics.DiskBytesPeak = u.PeakBytes
+	metrics.DiskQuotaExceeded = u.QuotaExceeded
+}
+
+// applyResources summarizes the runner process-tree samples suite run took (resources.jsonl).
+// Unparseable lines are skipped: telemetry never fails a report.
+func applyResources(attemptDir string, metrics *schema.AttemptMetricsV1) {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.ResourcesJSONL))
+	if err != nil {
+		return
+	}
+	var out schema.AttemptResourcesV1
+	var cpuSum float64
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		var s schema.ResourceSampleV1
+		if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &s) != nil {
+			continue
+		}
+		out.Samples++
+		cpuSum += s.CPUPercent
+		out.CPUSeconds = max(out.CPUSeconds, s.CPUSeconds)
+		out.CPUPercentPeak = max(out.CPUPercentPeak, s.CPUPercent)
+		out.RSSBytesPeak = max(out.RSSBytesPeak, s.RSSBytes)
+		out.ProcsPeak = max(out.ProcsPeak, s.Procs)
+		out.HostLoad1Peak = max(out.HostLoad1Peak, s.HostLoad1)
+		if s.HostMemAvailableBytes > 0 && (out.HostMemAvailableBytesMin == 0 || s.HostMemAvailableBytes < out.HostMemAvailableBytesMin) {
+			out.HostMemAvailableBytesMin = s.HostMemAvailableBytes
+		}
+	}
+	if out.Samples == 0 {
+		return
+	}
+	out.CPUPercentAvg = math.Round(cpuSum/float64(out.Samples)*100) / 100
+	metrics.Resources = &out
+}
+
+// feedbackChurn counts how often the agent revised its feedback (feedback.history.jsonl); unparseable
+// lines are skipped here and reported by validate.
+func feedbackChurn(attemptDir string) *schema.FeedbackChurnV1 {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.FeedbackHistoryJSONL))
+	if err != nil {
+		return nil
+	}
+	var out schema.FeedbackChurnV1
+	var prev *schema.FeedbackJSONV1
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		var rev schema.FeedbackRevisionV1
+		if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &rev) != nil {
+			continue
+		}
+		out.Revisions++
+		fb := rev.Feedback
+		if prev != nil {
+			if fb.OK != prev.OK {
+				out.OKFlips++
+			}
+			if fb.Result != prev.Result || !sameJSON(fb.ResultJSON, prev.ResultJSON) {
+				out.ResultChanges++
+			}
+		}
+		prev = &fb
+	}
+	if out.Revisions == 0 {
+		return nil
+	}
+	return &out
+}
+
+// feedbackProgress reports how far the runner got (feedback.progress.jsonl), which stays meaningful
+// when feedback.json is missing.
+func feedbackProgress(attemptDir string) *schema.FeedbackProgressV1 {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.FeedbackProgressJSONL))
+	if err != nil {
+		return nil
+	}
+	var out schema.FeedbackProgressV1
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		var ev schema.FeedbackProgressEventV1
+		if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &ev) != nil {
+			continue
+		}
+		out.Milestones++
+		out.Last = ev.Milestone
+		out.LastAt = ev.TS
+	}
+	if out.Milestones == 0 {
+		return nil
+	}
+	return &out
+}
+
+func sameJSON(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
 func emptyMetricsResult(strict bool) (schema.AttemptMetricsV1, *schema.AttemptSignalsV1, error) {
 	if strict {
 		return schema.AttemptMetricsV1{}, nil, &CliError{Code: "ZCL_E_MISSING_EVIDENCE", Message: "tool.calls.jsonl is empty"}
@@ -509,19 +689,24 @@ type retryMetric struct {
 
 type traceMetricsAccumulator struct {
 	metrics schema.AttemptMetricsV1
+	summary traceSummary
 
-	minTS time.Time
-	maxTS time.Time
-	durs  []int64
+	minTS    time.Time
+	maxTS    time.Time
+	maxTSRaw string
+	durs     durationHistogram
 
-	retryStats map[string]retryMetric
+	retryStats map[uint64]retryMetric
 
-	lastSig   string
+	lastSig   uint64
+	hasLast   bool
 	streak    int64
 	maxStreak int64
 
-	distinctSigs map[string]bool
+	distinctSigs map[uint64]struct{}
 	cmdNames     map[string]bool
+
+	chainHead string
 }
 
 func newTraceMetricsAccumulator() *traceMetricsAccumulator {
@@ -531,14 +716,16 @@ func newTraceMetricsAccumulator() *traceMetricsAccumulator {
 			ToolCallsByTool: map[string]int64{},
 			ToolCallsByOp:   map[string]int64{},
 		},
-		retryStats:   map[string]retryMetric{},
-		distinctSigs: map[string]bool{},
+		durs:         durationHistogram{},
+		retryStats:   map[uint64]retryMetric{},
+		distinctSigs: map[uint64]struct{}{},
 		cmdNames:     map[string]bool{},
 	}
 }
 
-func scanTraceMetrics(f *os.File, strict bool, acc *traceMetricsAccumulator) error {
+func scanTraceMetrics(f io.Reader, strict bool, acc *traceMetricsAccumulator) error {
 	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), traceLineMaxBytes)
 	for sc.Scan() {
 		line := sc.Bytes()
 		if len(line) == 0 {
@@ -551,30 +738,36 @@ func scanTraceMetrics(f *os.File, strict bool, acc *traceMetricsAccumulator) err
 		if err := acc.observeEvent(ev, strict); err != nil {
 			return err
 		}
+		acc.chainHead = ""
+		if ev.PrevHash != "" {
+			acc.chainHead = schema.TraceChainLinkV1(ev.PrevHash, bytes.TrimSpace(line))
+		}
 	}
 	return sc.Err()
 }
 
 func (a *traceMetricsAccumulator) observeEvent(ev schema.TraceEventV1, strict bool) error {
-	a.observeCounts(ev)
+	sig := eventSignature(ev)
+	a.observeCounts(ev, sig)
 	a.observeFailures(ev)
 	a.observeTruncation(ev)
 	a.observeIO(ev)
 	if err := a.observeTimestamp(ev, strict); err != nil {
 		return err
 	}
-	a.observeSignals(ev)
+	a.observeSignals(sig)
 	a.observeCommandNames(ev)
 	return nil
 }
 
-func (a *traceMetricsAccumulator) observeCounts(ev schema.TraceEventV1) {
+func (a *traceMetricsAccumulator) observeCounts(ev schema.TraceEventV1, key uint64) {
 	a.metrics.ToolCallsTotal++
 	a.metrics.ToolCallsByTool[ev.Tool]++
 	a.metrics.ToolCallsByOp[ev.Op]++
-	a.durs = append(a.durs, ev.Result.DurationMs)
+	a.durs[ev.Result.DurationMs]++
+	a.summary.HasEvent = true
+	a.summary.InputBytes += int64(len(ev.Input))
 
-	key := eventSignature(ev)
 	st := a.retryStats[key]
 	st.count++
 	if !ev.Result.OK {
@@ -627,20 +820,24 @@ func (a *traceMetricsAccumulator) observeTimestamp(ev schema.TraceEventV1, stric
 	}
 	if a.minTS.IsZero() || ts.Before(a.minTS) {
 		a.minTS = ts
+		a.summary.FirstTS = ts
+		a.summary.FirstTSRaw = ev.TS
+		a.summary.FirstCode = strings.TrimSpace(ev.Result.Code)
 	}
 	if a.maxTS.IsZero() || ts.After(a.maxTS) {
 		a.maxTS = ts
+		a.maxTSRaw = ev.TS
 	}
 	return nil
 }
 
-func (a *traceMetricsAccumulator) observeSignals(ev schema.TraceEventV1) {
-	sig := eventSignature(ev)
-	a.distinctSigs[sig] = true
-	if sig == a.lastSig {
+func (a *traceMetricsAccumulator) observeSignals(sig uint64) {
+	a.distinctSigs[sig] = struct{}{}
+	if a.hasLast && sig == a.lastSig {
 		a.streak++
 	} else {
 		a.lastSig = sig
+		a.hasLast = true
 		a.streak = 1
 	}
 	if a.streak > a.maxStreak {
@@ -676,7 +873,7 @@ func (a *traceMetricsAccumulator) finalizeMetrics() {
 	normalizeMetricMaps(&a.metrics)
 }
 
-func retriesTotal(stats map[string]retryMetric) int64 {
+func retriesTotal(stats map[uint64]retryMetric) int64 {
 	var retries int64
 	for _, st := range stats {
 		if st.count > 1 && st.failures > 0 {
@@ -708,8 +905,15 @@ func (a *traceMetricsAccumulator) buildSignals() *schema.AttemptSignalsV1 {
 	}
 }
 
-func eventSignature(ev schema.TraceEventV1) string {
-	return ev.Tool + "\x1f" + ev.Op + "\x1f" + string(ev.Input)
+// eventSignature hashes tool/op/input so per-signature state stays small on huge traces.
+func eventSignature(ev schema.TraceEventV1) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(ev.Tool))
+	_, _ = h.Write([]byte{0x1f})
+	_, _ = h.Write([]byte(ev.Op))
+	_, _ = h.Write([]byte{0x1f})
+	_, _ = h.Write(ev.Input)
+	return h.Sum64()
 }
 
 func sortedKeys(in map[string]bool) []string {
@@ -751,37 +955,47 @@ func IsCliError(err error, code string) bool {
 	return false
 }
 
-func summarizeDurations(durs []int64) (total, min, max, avg, p50, p95 int64) {
+// durationHistogram counts events per durationMs value. Distinct durations are few compared
+// to event counts, so exact quantiles stay cheap even for 1M+ event traces.
+type durationHistogram map[int64]int64
+
+func summarizeDurations(durs durationHistogram) (total, min, max, avg, p50, p95 int64) {
 	if len(durs) == 0 {
 		return 0, 0, 0, 0, 0, 0
 	}
-	total = 0
-	min, max = durs[0], durs[0]
-	for _, d := range durs {
-		total += d
-		if d < min {
-			min = d
-		}
-		if d > max {
-			max = d
+	keys := make([]int64, 0, len(durs))
+	var n int64
+	for d, c := range durs {
+		keys = append(keys, d)
+		total += d * c
+		n += c
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	min, max = keys[0], keys[len(keys)-1]
+	avg = total / n
+	p50 = quantileMillis(keys, durs, n, 0.50)
+	p95 = quantileMillis(keys, durs, n, 0.95)
+	return total, min, max, avg, p50, p95
+}
+
+// rankValue returns the value at 0-based rank r of the expanded, sorted durations.
+func rankValue(keys []int64, durs durationHistogram, r int64) int64 {
+	var seen int64
+	for _, k := range keys {
+		seen += durs[k]
+		if r < seen {
+			return k
 		}
 	}
-	avg = total / int64(len(durs))
-
-	sorted := append([]int64(nil), durs...)
-	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
-	p50 = quantileMillis(sorted, 0.50)
-	p95 = quantileMillis(sorted, 0.95)
-	return total, min, max, avg, p50, p95
+	return keys[len(keys)-1]
 }
 
-func quantileMillis(sorted []int64, q float64) int64 {
-	n := len(sorted)
+func quantileMillis(keys []int64, durs durationHistogram, n int64, q float64) int64 {
 	if n == 0 {
 		return 0
 	}
 	if n == 1 {
-		return sorted[0]
+		return keys[0]
 	}
 	if q < 0 {
 		q = 0
@@ -792,13 +1006,15 @@ func quantileMillis(sorted []int64, q float64) int64 {
 
 	// Linear interpolation between closest ranks.
 	pos := q * float64(n-1)
-	lo := int(pos)
+	lo := int64(pos)
 	hi := lo + 1
 	if hi >= n {
-		return sorted[n-1]
+		return keys[len(keys)-1]
 	}
 	frac := pos - float64(lo)
-	v := float64(sorted[lo]) + (float64(sorted[hi])-float64(sorted[lo]))*frac
+	loV := rankValue(keys, durs, lo)
+	hiV := rankValue(keys, durs, hi)
+	v := float64(loV) + (float64(hiV)-float64(loV))*frac
 	if v < 0 {
 		return 0
 	}
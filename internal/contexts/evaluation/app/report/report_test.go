@@ -1,10 +1,12 @@
 package report
 
 import (
+	"bufio"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -58,6 +60,210 @@ func TestBuildAttemptReport_StrictMissingRequiredArtifacts(t *testing.T) {
 	}
 }
 
+func TestBuildAttemptReport_BlindFlagsOutputContamination(t *testing.T) {
+	t.Parallel()
+
+	attemptDir := t.TempDir()
+	ids := `"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"001-m-r1"`
+	writeReportInput(t, attemptDir, "attempt.json", `{"schemaVersion":1,`+ids+`,"mode":"discovery","startedAt":"2026-02-15T18:00:00Z","blind":true,"blindTerms":["zcl","harness"]}`)
+	writeReportInput(t, attemptDir, "feedback.json", `{"schemaVersion":1,`+ids+`,"ok":true,"result":"done via the zcl harness","createdAt":"2026-02-15T18:00:05Z"}`)
+	writeReportInput(t, attemptDir, "runner.stdout.log", "calling zcl feedback now\n")
+	writeReportInput(t, attemptDir, "runner.stderr.log", "clean\n")
+
+	got, err := BuildAttemptReport(time.Date(2026, 2, 15, 18, 0, 10, 0, time.UTC), attemptDir, false)
+	if err != nil {
+		t.Fatalf("BuildAttemptReport: %v", err)
+	}
+	in := got.Integrity
+	if in == nil || !in.OutputContaminated || in.PromptContaminated {
+		t.Fatalf("expected output-only contamination, got %+v", in)
+	}
+	if !reflect.DeepEqual(in.OutputContaminationTerms, []string{"harness", "zcl"}) {
+		t.Fatalf("unexpected terms: %v", in.OutputContaminationTerms)
+	}
+	if !reflect.DeepEqual(in.OutputContaminationSources, []string{"runner.stdout.log", "feedback.result"}) {
+		t.Fatalf("unexpected sources: %v", in.OutputContaminationSources)
+	}
+	if !containsTag(got.DecisionTags, schema.DecisionTagContaminatedOutput) {
+		t.Fatalf("expected contaminated_output tag, got %v", got.DecisionTags)
+	}
+}
+
+func TestBuildAttemptReport_HonorsVerdictOverride(t *testing.T) {
+	t.Parallel()
+
+	attemptDir := t.TempDir()
+	ids := `"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"001-m-r1"`
+	writeReportInput(t, attemptDir, "attempt.json", `{"schemaVersion":1,`+ids+`,"mode":"discovery","startedAt":"2026-02-15T18:00:00Z"}`)
+	writeReportInput(t, attemptDir, "feedback.json", `{"schemaVersion":1,`+ids+`,"ok":true,"result":"done","createdAt":"2026-02-15T18:00:05Z"}`)
+	writeReportInput(t, attemptDir, "verdict.override.json", `{"schemaVersion":1,`+ids+`,"ok":false,"reason":"wrong answer","by":"alice","createdAt":"2026-02-16T09:00:00Z"}`)
+
+	got, err := BuildAttemptReport(time.Date(2026, 2, 16, 10, 0, 0, 0, time.UTC), attemptDir, false)
+	if err != nil {
+		t.Fatalf("BuildAttemptReport: %v", err)
+	}
+	if got.OK == nil || *got.OK {
+		t.Fatalf("expected override to force ok=false, got %v", got.OK)
+	}
+	ov := got.VerdictOverride
+	if ov == nil || ov.By != "alice" || ov.Reason != "wrong answer" || ov.OriginalOK == nil || !*ov.OriginalOK {
+		t.Fatalf("unexpected verdictOverride: %+v", ov)
+	}
+	if !containsTag(got.DecisionTags, schema.DecisionTagBlocked) || containsTag(got.DecisionTags, schema.DecisionTagSuccess) {
+		t.Fatalf("expected decision tags to follow the override, got %v", got.DecisionTags)
+	}
+}
+
+func TestBuildAttemptReport_SummarizesFeedbackChurn(t *testing.T) {
+	t.Parallel()
+
+	attemptDir := t.TempDir()
+	ids := `"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"001-m-r1"`
+	writeReportInput(t, attemptDir, "attempt.json", `{"schemaVersion":1,`+ids+`,"mode":"discovery","startedAt":"2026-02-15T18:00:00Z"}`)
+	writeReportInput(t, attemptDir, "feedback.json", `{"schemaVersion":1,`+ids+`,"ok":true,"resultJson":{"a":2},"revision":3,"createdAt":"2026-02-15T18:00:05Z"}`)
+	writeReportInput(t, attemptDir, "feedback.history.jsonl",
+		`{"v":1,"revision":1,"op":"write","feedback":{"ok":false,"resultJson":{"a":1}}}`+"\n"+
+			`{"v":1,"revision":2,"op":"update","feedback":{"ok":true,"resultJson":{ "a": 1 }}}`+"\n"+
+			`{"v":1,"revision":3,"op":"write","feedback":{"ok":true,"resultJson":{"a":2}}}`+"\n")
+
+	got, err := BuildAttemptReport(time.Date(2026, 2, 15, 18, 0, 10, 0, time.UTC), attemptDir, false)
+	if err != nil {
+		t.Fatalf("BuildAttemptReport: %v", err)
+	}
+	want := schema.FeedbackChurnV1{Revisions: 3, OKFlips: 1, ResultChanges: 1}
+	if got.FeedbackChurn == nil || *got.FeedbackChurn != want {
+		t.Fatalf("expected churn %+v, got %+v", want, got.FeedbackChurn)
+	}
+	if got.Artifacts.FeedbackHistoryJSONL != "feedback.history.jsonl" {
+		t.Fatalf("expected history artifact to be listed, got %+v", got.Artifacts)
+	}
+}
+
+func TestBuildAttemptReport_SummarizesProgressWithoutFeedback(t *testing.T) {
+	t.Parallel()
+
+	attemptDir := t.TempDir()
+	ids := `"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"001-m-r1"`
+	writeReportInput(t, attemptDir, "attempt.json", `{"schemaVersion":1,`+ids+`,"mode":"discovery","startedAt":"2026-02-15T18:00:00Z"}`)
+	writeReportInput(t, attemptDir, "feedback.progress.jsonl",
+		`{"v":1,"ts":"2026-02-15T18:00:01Z",`+ids+`,"seq":1,"milestone":"opened site"}`+"\n"+
+			`{"v":1,"ts":"2026-02-15T18:00:04Z",`+ids+`,"seq":2,"milestone":"logged in"}`+"\n")
+
+	got, err := BuildAttemptReport(time.Date(2026, 2, 15, 18, 0, 10, 0, time.UTC), attemptDir, false)
+	if err != nil {
+		t.Fatalf("BuildAttemptReport: %v", err)
+	}
+	want := schema.FeedbackProgressV1{Milestones: 2, Last: "logged in", LastAt: "2026-02-15T18:00:04Z"}
+	if got.OK != nil || got.Progress == nil || *got.Progress != want {
+		t.Fatalf("expected progress %+v without an outcome, got ok=%v progress=%+v", want, got.OK, got.Progress)
+	}
+	if got.Artifacts.FeedbackProgressJSONL != "feedback.progress.jsonl" {
+		t.Fatalf("expected progress artifact to be listed, got %+v", got.Artifacts)
+	}
+}
+
+func writeReportInput(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func containsTag(tags []string, want string) bool {
+	for _, t := range tags {
+		if t == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSummarizeDurations_MatchesExpandedQuantiles(t *testing.T) {
+	t.Parallel()
+
+	durs := durationHistogram{}
+	for _, d := range []int64{5, 1, 9, 1, 3, 7, 7, 7, 2, 100} {
+		durs[d]++
+	}
+	total, min, max, avg, p50, p95 := summarizeDurations(durs)
+	// Sorted: 1 1 2 3 5 7 7 7 9 100 -> p50 interpolates ranks 4/5, p95 ranks 8/9.
+	if total != 142 || min != 1 || max != 100 || avg != 14 || p50 != 6 || p95 != 59 {
+		t.Fatalf("unexpected summary total=%d min=%d max=%d avg=%d p50=%d p95=%d", total, min, max, avg, p50, p95)
+	}
+}
+
+func BenchmarkBuildAttemptReport_LargeTrace(b *testing.B) {
+	attemptDir := b.TempDir()
+	writeBenchAttempt(b, attemptDir, 200000)
+	now := time.Date(2026, 2, 15, 18, 0, 10, 0, time.UTC)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := BuildAttemptReport(now, attemptDir, true); err != nil {
+			b.Fatalf("BuildAttemptReport: %v", err)
+		}
+	}
+}
+
+func writeBenchAttempt(b *testing.B, attemptDir string, events int) {
+	b.Helper()
+	ids := schema.AttemptJSONV1{
+		SchemaVersion: schema.AttemptSchemaV1,
+		RunID:         "20260215-180012Z-09c5a6",
+		SuiteID:       "heftiweb-smoke",
+		MissionID:     "latest-blog-title",
+		AttemptID:     "001-latest-blog-title-r1",
+		Mode:          "ci",
+		StartedAt:     "2026-02-15T18:00:00Z",
+	}
+	attemptRaw, _ := json.Marshal(ids)
+	if err := os.WriteFile(filepath.Join(attemptDir, "attempt.json"), attemptRaw, 0o644); err != nil {
+		b.Fatalf("write attempt.json: %v", err)
+	}
+	feedbackRaw, _ := json.Marshal(schema.FeedbackJSONV1{
+		SchemaVersion: schema.FeedbackSchemaV1,
+		RunID:         ids.RunID,
+		SuiteID:       ids.SuiteID,
+		MissionID:     ids.MissionID,
+		AttemptID:     ids.AttemptID,
+		OK:            true,
+		Result:        "ok",
+		CreatedAt:     "2026-02-15T18:00:09Z",
+	})
+	if err := os.WriteFile(filepath.Join(attemptDir, "feedback.json"), feedbackRaw, 0o644); err != nil {
+		b.Fatalf("write feedback.json: %v", err)
+	}
+
+	f, err := os.Create(filepath.Join(attemptDir, "tool.calls.jsonl"))
+	if err != nil {
+		b.Fatalf("create trace: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	w := bufio.NewWriter(f)
+	base := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	for i := 0; i < events; i++ {
+		ev := schema.TraceEventV1{
+			V:         schema.TraceSchemaV1,
+			TS:        base.Add(time.Duration(i) * time.Microsecond).Format(time.RFC3339Nano),
+			RunID:     ids.RunID,
+			SuiteID:   ids.SuiteID,
+			MissionID: ids.MissionID,
+			AttemptID: ids.AttemptID,
+			Tool:      "cli",
+			Op:        "exec",
+			Input:     json.RawMessage(`{"argv":["cat","file-` + strconv.Itoa(i%1000) + `.txt"]}`),
+			Result:    schema.TraceResultV1{OK: i%50 != 0, DurationMs: int64(i % 200)},
+			IO:        schema.TraceIOV1{OutBytes: 64, OutPreview: "hello"},
+		}
+		line, _ := json.Marshal(ev)
+		_, _ = w.Write(append(line, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		b.Fatalf("flush trace: %v", err)
+	}
+}
+
 func repoRoot(t *testing.T) string {
 	t.Helper()
 	wd, err := os.Getwd()
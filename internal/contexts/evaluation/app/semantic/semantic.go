@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -15,18 +16,19 @@ import (
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 	"golang.org/x/sys/execabs"
 	"gopkg.in/yaml.v3"
 )
 
-type RulePackV1 struct {
-	SchemaVersion int                                 `json:"schemaVersion" yaml:"schemaVersion"`
-	Default       *suite.SemanticExpectsV1            `json:"default,omitempty" yaml:"default,omitempty"`
-	Missions      map[string]*suite.SemanticExpectsV1 `json:"missions,omitempty" yaml:"missions,omitempty"`
-}
+type RulePackV1 = suite.SemanticRulePackV1
 
 type Options struct {
 	RulesPath string
+	// Rules are inline rules (campaign semantic.rules); they take precedence over RulesPath.
+	Rules *RulePackV1
+	// SnapshotRules writes the effective rule pack to semantic.rules.json in every evaluated attempt dir.
+	SnapshotRules bool
 }
 
 type Finding struct {
@@ -66,26 +68,44 @@ func ValidatePath(targetDir string, opts Options) (Result, error) {
 		return invalidSemanticTarget(abs, "target must be a directory"), nil
 	}
 
-	var pack *RulePackV1
-	if strings.TrimSpace(opts.RulesPath) != "" {
-		rp, err := loadRulePack(strings.TrimSpace(opts.RulesPath))
-		if err != nil {
-			return Result{}, err
-		}
-		pack = &rp
+	pack, err := resolveRulePack(opts)
+	if err != nil {
+		return Result{}, err
+	}
+	var snapshot *RulePackV1
+	if opts.SnapshotRules {
+		snapshot = pack
 	}
 
 	target := detectSemanticTarget(abs)
 	switch target {
 	case "attempt":
-		return evaluateSemanticAttemptTarget(abs, strings.TrimSpace(opts.RulesPath), pack)
+		return evaluateSemanticAttemptTarget(abs, strings.TrimSpace(opts.RulesPath), pack, snapshot)
 	case "run":
-		return evaluateSemanticRunTarget(abs, strings.TrimSpace(opts.RulesPath), pack)
+		return evaluateSemanticRunTarget(abs, strings.TrimSpace(opts.RulesPath), pack, snapshot)
 	default:
 		return invalidSemanticTarget(abs, "target does not look like an attemptDir or runDir"), nil
 	}
 }
 
+func resolveRulePack(opts Options) (*RulePackV1, error) {
+	if opts.Rules != nil {
+		rp := *opts.Rules
+		if err := suite.NormalizeSemanticRulePack(&rp); err != nil {
+			return nil, err
+		}
+		return &rp, nil
+	}
+	if strings.TrimSpace(opts.RulesPath) == "" {
+		return nil, nil
+	}
+	rp, err := loadRulePack(strings.TrimSpace(opts.RulesPath))
+	if err != nil {
+		return nil, err
+	}
+	return &rp, nil
+}
+
 func requireSemanticTargetDir(path string) error {
 	info, err := os.Stat(path)
 	if err != nil {
@@ -120,8 +140,8 @@ func detectSemanticTarget(abs string) string {
 	return ""
 }
 
-func evaluateSemanticAttemptTarget(attemptDir, rulePath string, pack *RulePackV1) (Result, error) {
-	ar, err := evaluateAttempt(attemptDir, pack)
+func evaluateSemanticAttemptTarget(attemptDir, rulePath string, pack, snapshot *RulePackV1) (Result, error) {
+	ar, err := evaluateAttempt(attemptDir, pack, snapshot)
 	if err != nil {
 		return Result{}, err
 	}
@@ -141,7 +161,7 @@ func evaluateSemanticAttemptTarget(attemptDir, rulePath string, pack *RulePackV1
 	return res, nil
 }
 
-func evaluateSemanticRunTarget(runDir, rulePath string, pack *RulePackV1) (Result, error) {
+func evaluateSemanticRunTarget(runDir, rulePath string, pack, snapshot *RulePackV1) (Result, error) {
 	attemptsDir := filepath.Join(runDir, "attempts")
 	entries, err := os.ReadDir(attemptsDir)
 	if err != nil {
@@ -157,7 +177,7 @@ func evaluateSemanticRunTarget(runDir, rulePath string, pack *RulePackV1) (Resul
 		if !e.IsDir() {
 			continue
 		}
-		ar, err := evaluateAttempt(filepath.Join(attemptsDir, e.Name()), pack)
+		ar, err := evaluateAttempt(filepath.Join(attemptsDir, e.Name()), pack, snapshot)
 		if err != nil {
 			return Result{}, err
 		}
@@ -179,7 +199,7 @@ func fileExists(path string) bool {
 	return err == nil
 }
 
-func evaluateAttempt(attemptDir string, pack *RulePackV1) (AttemptResult, error) {
+func evaluateAttempt(attemptDir string, pack, snapshot *RulePackV1) (AttemptResult, error) {
 	out := AttemptResult{
 		AttemptDir: attemptDir,
 		OK:         true,
@@ -219,6 +239,11 @@ func evaluateAttempt(attemptDir string, pack *RulePackV1) (AttemptResult, error)
 		return out, nil
 	}
 
+	if snapshot != nil {
+		if err := store.WriteJSONAtomic(filepath.Join(attemptDir, artifacts.SemanticRulesJSON), snapshot); err != nil {
+			return out, err
+		}
+	}
 	rules, source, err := selectRules(attemptDir, a.MissionID, pack)
 	if err != nil {
 		return out, err
@@ -253,15 +278,23 @@ func evaluateAttempt(attemptDir string, pack *RulePackV1) (AttemptResult, error)
 	return out, nil
 }
 
+// selectRules prefers explicit rules, then the attempt's semantic.rules.json snapshot, then suite.json mission expects.
 func selectRules(attemptDir string, missionID string, pack *RulePackV1) (*suite.SemanticExpectsV1, string, error) {
 	if pack != nil {
-		if m, ok := pack.Missions[missionID]; ok && m != nil {
-			return m, "rulepack:missions." + missionID, nil
+		rules, source := selectPackRules(pack, missionID)
+		return rules, source, nil
+	}
+	snapshotPath := filepath.Join(attemptDir, artifacts.SemanticRulesJSON)
+	if fileExists(snapshotPath) {
+		rp, err := loadRulePack(snapshotPath)
+		if err != nil {
+			return nil, "", err
 		}
-		if pack.Default != nil {
-			return pack.Default, "rulepack:default", nil
+		rules, source := selectPackRules(&rp, missionID)
+		if rules != nil {
+			source = artifacts.SemanticRulesJSON + ":" + strings.TrimPrefix(source, "rulepack:")
 		}
-		return nil, "", nil
+		return rules, source, nil
 	}
 
 	runDir := filepath.Dir(filepath.Dir(attemptDir))
@@ -284,6 +317,16 @@ func selectRules(attemptDir string, missionID string, pack *RulePackV1) (*suite.
 	return m.Expects.Semantic, "suite.json:mission." + missionID, nil
 }
 
+func selectPackRules(pack *RulePackV1, missionID string) (*suite.SemanticExpectsV1, string) {
+	if m, ok := pack.Missions[missionID]; ok && m != nil {
+		return m, "rulepack:missions." + missionID
+	}
+	if pack.Default != nil {
+		return pack.Default, "rulepack:default"
+	}
+	return nil, ""
+}
+
 func loadRulePack(path string) (RulePackV1, error) {
 	raw, err := os.ReadFile(path)
 	if err != nil {
@@ -301,17 +344,14 @@ func loadRulePack(path string) (RulePackV1, error) {
 			return RulePackV1{}, err
 		}
 	}
-	if rp.SchemaVersion == 0 {
-		rp.SchemaVersion = 1
-	}
-	if rp.SchemaVersion != 1 {
-		return RulePackV1{}, fmt.Errorf("unsupported semantic rule pack schemaVersion (expected 1)")
+	if err := suite.NormalizeSemanticRulePack(&rp); err != nil {
+		return RulePackV1{}, err
 	}
 	return rp, nil
 }
 
 func traceFacts(tracePath string) (*suite.TraceFacts, error) {
-	f, err := os.Open(tracePath)
+	f, err := store.OpenArtifact(tracePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -353,7 +393,7 @@ func newSemanticTraceFactsAccumulator() *semanticTraceFactsAccumulator {
 	}
 }
 
-func scanSemanticTraceFacts(f *os.File, acc *semanticTraceFactsAccumulator) error {
+func scanSemanticTraceFacts(f io.Reader, acc *semanticTraceFactsAccumulator) error {
 	sc := bufio.NewScanner(f)
 	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 	for sc.Scan() {
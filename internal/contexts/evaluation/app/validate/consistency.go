@@ -0,0 +1,190 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+const codeRunInconsistent = "ZCL_E_RUN_INCONSISTENT"
+
+// reAttemptID matches ids.NewAttemptID output: <index>-<mission>-r<retry>.
+var reAttemptID = regexp.MustCompile(`^(\d+)-(.+)-r(\d+)$`)
+
+type consistencyAttempt struct {
+	dir       string
+	attempt   schema.AttemptJSONV1
+	startedAt time.Time
+	endedAt   time.Time
+	sessionID string
+	threadID  string
+}
+
+// ValidateRunConsistency checks invariants that only hold across all attempts of a run:
+// unique attempt ids, monotonic timestamps, per-attempt scratch dirs and fresh native sessions
+// (see CheckSessionFreshness).
+// Per-attempt artifact validity is left to ValidatePath.
+func ValidateRunConsistency(runDir string) (Result, error) {
+	abs, err := filepath.Abs(runDir)
+	if err != nil {
+		return Result{OK: false, Target: "unknown", Path: runDir, Errors: []Finding{{Code: "ZCL_E_IO", Message: err.Error(), Path: runDir}}}, nil
+	}
+	if _, err := os.Stat(filepath.Join(abs, artifacts.RunJSON)); err != nil {
+		return Result{OK: false, Target: "unknown", Path: abs, Errors: []Finding{{Code: "ZCL_E_USAGE", Message: "consistency checks require a runDir", Path: abs}}}, nil
+	}
+	res := Result{OK: true, Target: "run", Path: abs, Errors: CheckRunConsistency(abs)}
+	return finalize(res), nil
+}
+
+// CheckRunConsistency returns one finding per cross-attempt invariant violation under runDir.
+// Attempts whose attempt.json cannot be read are skipped.
+func CheckRunConsistency(runDir string) []Finding {
+	var out []Finding
+	runCreated := readRunCreatedAt(runDir)
+	attempts := loadConsistencyAttempts(runDir)
+
+	seenIDs := map[string]string{}
+	seenScratch := map[string]string{}
+	claim := func(seen map[string]string, key, dir, what string) {
+		if key == "" {
+			return
+		}
+		if prev, ok := seen[key]; ok {
+			out = append(out, Finding{
+				Code:    codeRunInconsistent,
+				Message: fmt.Sprintf("%s %q is shared with %s", what, key, filepath.Base(prev)),
+				Path:    dir,
+			})
+			return
+		}
+		seen[key] = dir
+	}
+	for _, a := range attempts {
+		claim(seenIDs, strings.TrimSpace(a.attempt.AttemptID), a.dir, "attemptId")
+		if s := strings.TrimSpace(a.attempt.ScratchDir); s != "" {
+			claim(seenScratch, filepath.Clean(s), a.dir, "scratchDir")
+		}
+		out = append(out, attemptTimelineFindings(a, runCreated)...)
+	}
+	out = append(out, retryOrderFindings(attempts)...)
+	out = append(out, sessionFreshnessFindings(attempts)...)
+	return out
+}
+
+func attemptTimelineFindings(a consistencyAttempt, runCreated time.Time) []Finding {
+	var out []Finding
+	if !runCreated.IsZero() && !a.startedAt.IsZero() && a.startedAt.Before(runCreated) {
+		out = append(out, Finding{Code: codeRunInconsistent, Message: "attempt startedAt is before run createdAt", Path: a.dir})
+	}
+	if !a.startedAt.IsZero() && !a.endedAt.IsZero() && a.endedAt.Before(a.startedAt) {
+		out = append(out, Finding{Code: codeRunInconsistent, Message: "attempt report endedAt is before attempt startedAt", Path: a.dir})
+	}
+	return out
+}
+
+// retryOrderFindings requires retries of the same mission slot to start in retry order.
+func retryOrderFindings(attempts []consistencyAttempt) []Finding {
+	type retry struct {
+		n int
+		a consistencyAttempt
+	}
+	slots := map[string][]retry{}
+	for _, a := range attempts {
+		// Key by directory name: it is unique on disk even when attempt.json ids collide.
+		m := reAttemptID.FindStringSubmatch(filepath.Base(a.dir))
+		if m == nil || a.startedAt.IsZero() {
+			continue
+		}
+		n, err := strconv.Atoi(m[3])
+		if err != nil {
+			continue
+		}
+		slot := m[1] + "-" + m[2]
+		slots[slot] = append(slots[slot], retry{n: n, a: a})
+	}
+	keys := make([]string, 0, len(slots))
+	for k := range slots {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var out []Finding
+	for _, k := range keys {
+		rs := slots[k]
+		sort.Slice(rs, func(i, j int) bool { return rs[i].n < rs[j].n })
+		for i := 1; i < len(rs); i++ {
+			if rs[i].a.startedAt.Before(rs[i-1].a.startedAt) {
+				out = append(out, Finding{
+					Code:    codeRunInconsistent,
+					Message: fmt.Sprintf("retry r%d started before retry r%d", rs[i].n, rs[i-1].n),
+					Path:    rs[i].a.dir,
+				})
+			}
+		}
+	}
+	return out
+}
+
+func loadConsistencyAttempts(runDir string) []consistencyAttempt {
+	attemptsDir := filepath.Join(runDir, "attempts")
+	entries, err := os.ReadDir(attemptsDir)
+	if err != nil {
+		return nil
+	}
+	var out []consistencyAttempt
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(attemptsDir, e.Name())
+		var a consistencyAttempt
+		if !readJSONFile(filepath.Join(dir, artifacts.AttemptJSON), &a.attempt) {
+			continue
+		}
+		a.dir = dir
+		a.startedAt = parseConsistencyTime(a.attempt.StartedAt)
+		var report schema.AttemptReportJSONV1
+		if readJSONFile(filepath.Join(dir, artifacts.AttemptReportJSON), &report) {
+			a.endedAt = parseConsistencyTime(report.EndedAt)
+		}
+		var ref schema.RunnerRefJSONV1
+		if readJSONFile(filepath.Join(dir, artifacts.RunnerRefJSON), &ref) {
+			a.sessionID = strings.TrimSpace(ref.SessionID)
+			a.threadID = strings.TrimSpace(ref.ThreadID)
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+func readRunCreatedAt(runDir string) time.Time {
+	var run schema.RunJSONV1
+	if !readJSONFile(filepath.Join(runDir, artifacts.RunJSON), &run) {
+		return time.Time{}
+	}
+	return parseConsistencyTime(run.CreatedAt)
+}
+
+func readJSONFile(path string, v any) bool {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+func parseConsistencyTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateRunConsistency_ReportsCrossAttemptViolations(t *testing.T) {
+	runID := "20260215-180012Z-09c5a6"
+	runDir := filepath.Join(t.TempDir(), runID)
+	writeConsistencyFile(t, filepath.Join(runDir, "run.json"), `{"schemaVersion":1,"artifactLayoutVersion":1,"runId":"`+runID+`","suiteId":"s","createdAt":"2026-02-15T18:00:12Z"}`)
+	writeAttempt := func(dir, attemptID, startedAt, scratch, sessionID string) {
+		attemptDir := filepath.Join(runDir, "attempts", dir)
+		writeConsistencyFile(t, filepath.Join(attemptDir, "attempt.json"), `{"schemaVersion":1,"runId":"`+runID+`","suiteId":"s","missionId":"m1","attemptId":"`+attemptID+`","mode":"discovery","startedAt":"`+startedAt+`","scratchDir":"`+scratch+`"}`)
+		if sessionID != "" {
+			writeConsistencyFile(t, filepath.Join(attemptDir, "runner.ref.json"), `{"schemaVersion":1,"runner":"codex_app_server","runId":"`+runID+`","suiteId":"s","missionId":"m1","attemptId":"`+attemptID+`","sessionId":"`+sessionID+`"}`)
+		}
+	}
+	writeAttempt("001-m1-r1", "001-m1-r1", "2026-02-15T18:00:20Z", "tmp/run/001-m1-r1", "sess-1")
+	writeAttempt("001-m1-r2", "001-m1-r2", "2026-02-15T18:00:15Z", "tmp/run/001-m1-r1", "sess-1")
+	writeAttempt("002-m1-r1", "001-m1-r1", "2026-02-15T18:00:00Z", "tmp/run/002-m1-r1", "")
+
+	res, err := ValidateRunConsistency(runDir)
+	if err != nil {
+		t.Fatalf("ValidateRunConsistency: %v", err)
+	}
+	if res.OK || res.Target != "run" {
+		t.Fatalf("expected failing run result, got %+v", res)
+	}
+	want := []struct {
+		message string
+		code    string
+	}{
+		{`attemptId "001-m1-r1" is shared`, "ZCL_E_RUN_INCONSISTENT"},
+		{`scratchDir "tmp/run/001-m1-r1" is shared`, "ZCL_E_RUN_INCONSISTENT"},
+		{`native sessionId "sess-1" is shared`, "ZCL_E_SESSION_NOT_FRESH"},
+		{"attempt startedAt is before run createdAt", "ZCL_E_RUN_INCONSISTENT"},
+		{"retry r2 started before retry r1", "ZCL_E_RUN_INCONSISTENT"},
+	}
+	for _, w := range want {
+		found := false
+		for _, f := range res.Errors {
+			if strings.Contains(f.Message, w.message) {
+				found = true
+				if f.Code != w.code {
+					t.Fatalf("violation %q: expected code %s, got %+v", w.message, w.code, f)
+				}
+			}
+		}
+		if !found {
+			t.Fatalf("expected violation %q, got %+v", w.message, res.Errors)
+		}
+	}
+	if len(res.Errors) != len(want) {
+		t.Fatalf("expected exactly %d violations, got %+v", len(want), res.Errors)
+	}
+}
+
+func TestValidateRunConsistency_RequiresRunDir(t *testing.T) {
+	res, err := ValidateRunConsistency(t.TempDir())
+	if err != nil {
+		t.Fatalf("ValidateRunConsistency: %v", err)
+	}
+	if res.OK || !hasCode(res.Errors, "ZCL_E_USAGE") {
+		t.Fatalf("expected usage error, got %+v", res)
+	}
+}
+
+func writeConsistencyFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func hasMessage(findings []Finding, substr string) bool {
+	for _, f := range findings {
+		if strings.Contains(f.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// CheckSessionFreshness asserts the zero-context guarantee for native attempts: each one ran in a
+// session and thread of its own. runner.ref.json ids must be unique across attempts, and every
+// native event in an attempt's trace must carry its runner.ref.json sessionId/threadId.
+func CheckSessionFreshness(runDir string) []Finding {
+	return sessionFreshnessFindings(loadConsistencyAttempts(runDir))
+}
+
+func sessionFreshnessFindings(attempts []consistencyAttempt) []Finding {
+	var out []Finding
+	seenSessions := map[string]string{}
+	seenThreads := map[string]string{}
+	claim := func(seen map[string]string, key, dir, what string) {
+		if key == "" {
+			return
+		}
+		if prev, ok := seen[key]; ok {
+			out = append(out, Finding{
+				Code:    codes.SessionNotFresh,
+				Message: fmt.Sprintf("%s %q is shared with %s", what, key, filepath.Base(prev)),
+				Path:    dir,
+			})
+			return
+		}
+		seen[key] = dir
+	}
+	for _, a := range attempts {
+		claim(seenSessions, a.sessionID, a.dir, "native sessionId")
+		claim(seenThreads, a.threadID, a.dir, "native threadId")
+		if a.attempt.IsolationModel == schema.IsolationModelNativeSpawnV1 {
+			out = append(out, nativeTraceFreshnessFindings(a)...)
+		}
+	}
+	return out
+}
+
+// nativeTraceFreshnessFindings compares the ids on the attempt's native trace events with its
+// runner.ref.json. A trace without native events (the session never started) proves nothing and
+// is skipped.
+func nativeTraceFreshnessFindings(a consistencyAttempt) []Finding {
+	sessions, threads := nativeTraceIDs(filepath.Join(a.dir, artifacts.ToolCallsJSONL))
+	if len(sessions) == 0 && len(threads) == 0 {
+		return nil
+	}
+	refPath := filepath.Join(a.dir, artifacts.RunnerRefJSON)
+	if len(threads) > 0 && a.threadID == "" {
+		return []Finding{{
+			Code:    codes.SessionNotFresh,
+			Message: "native trace has thread events but runner.ref.json records no threadId",
+			Path:    refPath,
+		}}
+	}
+	var out []Finding
+	mismatch := func(ids []string, want, what string) {
+		for _, id := range ids {
+			if id != want {
+				out = append(out, Finding{
+					Code:    codes.SessionNotFresh,
+					Message: fmt.Sprintf("native trace %s %q differs from runner.ref.json %s %q", what, id, what, want),
+					Path:    filepath.Join(a.dir, artifacts.ToolCallsJSONL),
+				})
+			}
+		}
+	}
+	if a.sessionID != "" {
+		mismatch(sessions, a.sessionID, "sessionId")
+	}
+	mismatch(threads, a.threadID, "threadId")
+	return out
+}
+
+// nativeTraceIDs returns the distinct sessionIds and threadIds on tool=native trace events.
+func nativeTraceIDs(tracePath string) ([]string, []string) {
+	f, err := store.OpenArtifact(tracePath)
+	if err != nil {
+		return nil, nil
+	}
+	defer func() { _ = f.Close() }()
+	sessions, threads := map[string]bool{}, map[string]bool{}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var ev schema.TraceEventV1
+		if json.Unmarshal(sc.Bytes(), &ev) != nil || ev.Tool != "native" {
+			continue
+		}
+		var in struct {
+			SessionID string `json:"sessionId"`
+			ThreadID  string `json:"threadId"`
+		}
+		// Truncated inputs are not JSON objects; they carry no usable ids.
+		if json.Unmarshal(ev.Input, &in) != nil {
+			continue
+		}
+		if s := strings.TrimSpace(in.SessionID); s != "" {
+			sessions[s] = true
+		}
+		if t := strings.TrimSpace(in.ThreadID); t != "" {
+			threads[t] = true
+		}
+	}
+	return sortedKeys(sessions), sortedKeys(threads)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
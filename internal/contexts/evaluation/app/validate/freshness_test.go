@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckSessionFreshness_VerifiesTraceAgainstRunnerRef(t *testing.T) {
+	runID := "20260215-180012Z-09c5a6"
+	runDir := filepath.Join(t.TempDir(), runID)
+	writeConsistencyFile(t, filepath.Join(runDir, "run.json"), `{"schemaVersion":1,"artifactLayoutVersion":1,"runId":"`+runID+`","suiteId":"s","createdAt":"2026-02-15T18:00:12Z"}`)
+	writeAttempt := func(attemptID, ref string, threads ...string) {
+		attemptDir := filepath.Join(runDir, "attempts", attemptID)
+		writeConsistencyFile(t, filepath.Join(attemptDir, "attempt.json"), `{"schemaVersion":1,"runId":"`+runID+`","suiteId":"s","missionId":"m1","attemptId":"`+attemptID+`","mode":"discovery","isolationModel":"native_spawn","startedAt":"2026-02-15T18:00:20Z"}`)
+		if ref != "" {
+			writeConsistencyFile(t, filepath.Join(attemptDir, "runner.ref.json"), ref)
+		}
+		trace := ""
+		for _, th := range threads {
+			trace += `{"v":1,"ts":"2026-02-15T18:00:21Z","runId":"` + runID + `","missionId":"m1","attemptId":"` + attemptID + `","tool":"native","op":"turn_started","input":{"sessionId":"sess-` + attemptID + `","threadId":"` + th + `"},"result":{"ok":true},"io":{}}` + "\n"
+		}
+		writeConsistencyFile(t, filepath.Join(attemptDir, "tool.calls.jsonl"), trace)
+	}
+	ref := func(attemptID, threadID string) string {
+		return `{"schemaVersion":1,"runner":"codex_app_server","runId":"` + runID + `","suiteId":"s","missionId":"m1","attemptId":"` + attemptID + `","sessionId":"sess-` + attemptID + `","threadId":"` + threadID + `"}`
+	}
+	writeAttempt("001-m1-r1", ref("001-m1-r1", "th-1"), "th-1")
+	writeAttempt("002-m1-r1", ref("002-m1-r1", "th-2"), "th-2", "th-1")
+	writeAttempt("003-m1-r1", "", "th-3")
+	// Native attempts whose session never started have nothing to verify.
+	writeAttempt("004-m1-r1", "")
+
+	findings := CheckSessionFreshness(runDir)
+	for _, want := range []string{
+		`native trace threadId "th-1" differs from runner.ref.json threadId "th-2"`,
+		"native trace has thread events but runner.ref.json records no threadId",
+	} {
+		if !hasMessage(findings, want) {
+			t.Fatalf("expected finding %q, got %+v", want, findings)
+		}
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected exactly two findings, got %+v", findings)
+	}
+	for _, f := range findings {
+		if f.Code != "ZCL_E_SESSION_NOT_FRESH" {
+			t.Fatalf("unexpected code: %+v", f)
+		}
+	}
+}
@@ -2,15 +2,23 @@ package validate
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
@@ -170,7 +178,25 @@ func validateRunAttempts(runDir string, strict bool, res *Result) {
 	}
 }
 
+// ValidateAttemptStaged validates an attempt against a pending attempt.report.json at reportPath
+// (staged by a finish transaction) instead of the published one.
+func ValidateAttemptStaged(attemptDir string, strict bool, reportPath string) (Result, error) {
+	abs, err := filepath.Abs(attemptDir)
+	if err != nil {
+		return Result{}, err
+	}
+	return validateAttemptWithReport(abs, reportPath, strict), nil
+}
+
 func validateAttempt(attemptDir string, strict bool) Result {
+	// Publish a finish that crashed after its commit point before judging its report.
+	if err := store.RollForwardDirTx(attemptDir, artifacts.AttemptFinishTx); err != nil {
+		return finalize(Result{OK: false, Strict: strict, Target: "attempt", Path: attemptDir, Errors: []Finding{{Code: "ZCL_E_IO", Message: err.Error(), Path: attemptDir}}})
+	}
+	return validateAttemptWithReport(attemptDir, filepath.Join(attemptDir, artifacts.AttemptReportJSON), strict)
+}
+
+func validateAttemptWithReport(attemptDir, reportPath string, strict bool) Result {
 	res := Result{OK: true, Strict: strict, Target: "attempt", Path: attemptDir}
 	attempt, enforce, ok := loadAndValidateAttemptHeader(attemptDir, strict, &res)
 	if !ok {
@@ -180,7 +206,7 @@ func validateAttempt(attemptDir string, strict bool) Result {
 		return finalize(res)
 	}
 	validateAttemptOptionalArtifacts(attemptDir, attempt, enforce, &res)
-	if !validateAttemptReportArtifact(attemptDir, attempt, enforce, &res) {
+	if !validateAttemptReportArtifact(attemptDir, reportPath, attempt, enforce, &res) {
 		return finalize(res)
 	}
 	return finalize(res)
@@ -314,7 +340,7 @@ func validateAttemptDirMatch(attemptDir, attemptID, path string, res *Result) {
 }
 
 func validateAttemptPrimaryArtifacts(attemptDir string, attempt schema.AttemptJSONV1, enforce bool, res *Result) bool {
-	tracePath := filepath.Join(attemptDir, artifacts.ToolCallsJSONL)
+	tracePath := store.ResolveArtifactPath(filepath.Join(attemptDir, artifacts.ToolCallsJSONL))
 	feedbackPath := filepath.Join(attemptDir, artifacts.FeedbackJSON)
 	if !validateFunnelBypass(attemptDir, tracePath, feedbackPath, enforce, res) {
 		return false
@@ -367,14 +393,21 @@ func validateAttemptOptionalArtifacts(attemptDir string, attempt schema.AttemptJ
 	if _, err := os.Stat(notesPath); err == nil && requireContained(attemptDir, notesPath, res) {
 		validateNotes(notesPath, attempt, enforce, res)
 	}
+	progressPath := filepath.Join(attemptDir, artifacts.FeedbackProgressJSONL)
+	if _, err := os.Stat(progressPath); err == nil && requireContained(attemptDir, progressPath, res) {
+		validateFeedbackProgress(progressPath, attempt, res)
+	}
+	contaminationPath := filepath.Join(attemptDir, artifacts.PromptContaminationJSON)
+	if _, err := os.Stat(contaminationPath); err == nil && requireContained(attemptDir, contaminationPath, res) {
+		validatePromptContamination(contaminationPath, attemptDir, attempt, res)
+	}
 	capturesPath := filepath.Join(attemptDir, artifacts.CapturesJSONL)
 	if _, err := os.Stat(capturesPath); err == nil && requireContained(attemptDir, capturesPath, res) {
 		validateCaptures(capturesPath, attemptDir, attempt, enforce, res)
 	}
 }
 
-func validateAttemptReportArtifact(attemptDir string, attempt schema.AttemptJSONV1, enforce bool, res *Result) bool {
-	reportPath := filepath.Join(attemptDir, artifacts.AttemptReportJSON)
+func validateAttemptReportArtifact(attemptDir, reportPath string, attempt schema.AttemptJSONV1, enforce bool, res *Result) bool {
 	if _, err := os.Stat(reportPath); err != nil {
 		return true
 	}
@@ -386,19 +419,22 @@ func validateAttemptReportArtifact(attemptDir string, attempt schema.AttemptJSON
 		addErr(res, "ZCL_E_IO", err.Error(), reportPath)
 		return false
 	}
-	var rep schema.AttemptReportJSONV1
-	if err := json.Unmarshal(raw, &rep); err != nil {
+	rep, err := schema.DecodeAttemptReport(raw)
+	if err != nil {
 		addErr(res, "ZCL_E_INVALID_JSON", "attempt.report.json is not valid json", reportPath)
 		return false
 	}
 	if !validateAttemptReportContract(rep, attempt, enforce, reportPath, res) {
 		return false
 	}
+	if !validateReportTraceChainHead(attemptDir, rep, reportPath, res) {
+		return false
+	}
 	return validateNativeResultProvenance(rep.NativeResult, enforce, res, reportPath, artifacts.AttemptReportJSON)
 }
 
 func validateAttemptReportContract(rep schema.AttemptReportJSONV1, attempt schema.AttemptJSONV1, enforce bool, reportPath string, res *Result) bool {
-	if rep.SchemaVersion != schema.AttemptReportSchemaV1 {
+	if !schema.SupportedAttemptReportSchema(rep.SchemaVersion) {
 		addErr(res, "ZCL_E_SCHEMA_UNSUPPORTED", "unsupported attempt.report.json schemaVersion", reportPath)
 		return false
 	}
@@ -413,11 +449,12 @@ func validateAttemptReportContract(rep schema.AttemptReportJSONV1, attempt schem
 		addErr(res, "ZCL_E_CONTRACT", "attempt.report.json classification is invalid", reportPath)
 		return false
 	}
+	custom := declaredDecisionTags(filepath.Dir(reportPath))
 	for _, tag := range rep.DecisionTags {
-		if schema.IsValidDecisionTagV1(tag) {
+		if schema.IsDeclaredDecisionTagV1(tag, custom) {
 			continue
 		}
-		addErr(res, "ZCL_E_CONTRACT", "attempt.report.json decisionTags contains invalid tag", reportPath)
+		addErr(res, "ZCL_E_CONTRACT", "attempt.report.json decisionTags contains undeclared tag "+strconv.Quote(tag), reportPath)
 		return false
 	}
 	if hasAttemptReportArtifacts(rep) {
@@ -431,6 +468,24 @@ func validateAttemptReportContract(rep schema.AttemptReportJSONV1, attempt schem
 	return true
 }
 
+// validateReportTraceChainHead catches a trace that was truncated or re-chained after the
+// report anchored its head.
+func validateReportTraceChainHead(attemptDir string, rep schema.AttemptReportJSONV1, reportPath string, res *Result) bool {
+	if rep.Integrity == nil || strings.TrimSpace(rep.Integrity.TraceChainHead) == "" {
+		return true
+	}
+	head, ok := traceChainHead(filepath.Join(attemptDir, artifacts.ToolCallsJSONL))
+	if !ok {
+		// The chain itself is broken; validateTrace already reported it.
+		return true
+	}
+	if head != rep.Integrity.TraceChainHead {
+		addErr(res, "ZCL_E_TRACE_CHAIN_BROKEN", "attempt.report.json integrity.traceChainHead does not match tool.calls.jsonl", reportPath)
+		return false
+	}
+	return true
+}
+
 func hasAttemptReportArtifacts(rep schema.AttemptReportJSONV1) bool {
 	return strings.TrimSpace(rep.Artifacts.AttemptJSON) != "" &&
 		strings.TrimSpace(rep.Artifacts.TraceJSONL) != "" &&
@@ -467,7 +522,7 @@ func validateNativeResultProvenance(v *schema.NativeResultProvenanceV1, strict b
 }
 
 func validateTrace(path string, attemptDir string, attempt schema.AttemptJSONV1, strict bool, res *Result) {
-	f, err := os.Open(path)
+	f, err := store.OpenArtifact(path)
 	if err != nil {
 		addErr(res, "ZCL_E_IO", err.Error(), path)
 		return
@@ -483,10 +538,11 @@ func validateTrace(path string, attemptDir string, attempt schema.AttemptJSONV1,
 	}
 }
 
-func scanAndValidateTraceEvents(f *os.File, path, attemptDir string, attempt schema.AttemptJSONV1, strict bool, res *Result) (int, bool) {
+func scanAndValidateTraceEvents(f io.Reader, path, attemptDir string, attempt schema.AttemptJSONV1, strict bool, res *Result) (int, bool) {
 	sc := bufio.NewScanner(f)
 	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 	count := 0
+	var chain traceChainVerifier
 	for sc.Scan() {
 		line := sc.Bytes()
 		if !validateNonEmptyJSONLLine(line, strict, artifacts.ToolCallsJSONL, path, res) {
@@ -498,6 +554,9 @@ func scanAndValidateTraceEvents(f *os.File, path, attemptDir string, attempt sch
 		if !validateTraceLine(line, path, attemptDir, attempt, strict, res) {
 			return 0, false
 		}
+		if !chain.observe(line, count+1, path, res) {
+			return 0, false
+		}
 		count++
 	}
 	if err := sc.Err(); err != nil {
@@ -507,6 +566,76 @@ func scanAndValidateTraceEvents(f *os.File, path, attemptDir string, attempt sch
 	return count, true
 }
 
+// traceChainVerifier replays the prevHash chain written by the trace funnels. Legacy events
+// without prevHash are accepted until the chain starts; after that every event must link.
+type traceChainVerifier struct {
+	active   bool
+	prevHash string
+	prevLine []byte
+}
+
+func (c *traceChainVerifier) observe(line []byte, lineNo int, path string, res *Result) bool {
+	var ev struct {
+		PrevHash string `json:"prevHash"`
+	}
+	_ = json.Unmarshal(line, &ev)
+	line = bytesTrim(line)
+	if ev.PrevHash == "" {
+		if c.active {
+			addErr(res, "ZCL_E_TRACE_CHAIN_BROKEN", fmt.Sprintf("trace hash chain broken at line %d: prevHash is missing", lineNo), path)
+			return false
+		}
+	} else {
+		want := schema.TraceChainGenesisV1
+		if c.prevLine != nil {
+			want = schema.TraceChainLinkV1(c.prevHash, c.prevLine)
+		}
+		if ev.PrevHash != want {
+			addErr(res, "ZCL_E_TRACE_CHAIN_BROKEN", fmt.Sprintf("trace hash chain broken at line %d: prevHash does not match previous event", lineNo), path)
+			return false
+		}
+		c.active = true
+	}
+	c.prevHash = ev.PrevHash
+	c.prevLine = append(c.prevLine[:0], line...)
+	return true
+}
+
+// head mirrors the report's integrity.traceChainHead: empty unless the last event is chained.
+func (c *traceChainVerifier) head() string {
+	if c.prevHash == "" || c.prevLine == nil {
+		return ""
+	}
+	return schema.TraceChainLinkV1(c.prevHash, c.prevLine)
+}
+
+// traceChainHead rescans tool.calls.jsonl for the chain head; errors are reported by validateTrace.
+func traceChainHead(tracePath string) (string, bool) {
+	f, err := store.OpenArtifact(tracePath)
+	if err != nil {
+		return "", false
+	}
+	defer func() { _ = f.Close() }()
+	var chain traceChainVerifier
+	var scratch Result
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	n := 0
+	for sc.Scan() {
+		if len(bytesTrim(sc.Bytes())) == 0 {
+			continue
+		}
+		n++
+		if !chain.observe(sc.Bytes(), n, tracePath, &scratch) {
+			return "", false
+		}
+	}
+	if sc.Err() != nil {
+		return "", false
+	}
+	return chain.head(), true
+}
+
 func validateNonEmptyJSONLLine(line []byte, strict bool, artifact string, path string, res *Result) bool {
 	if len(bytesTrim(line)) != 0 {
 		return true
@@ -710,7 +839,145 @@ func validateFeedback(path string, attempt schema.AttemptJSONV1, strict bool, re
 	if !validateFeedbackResultShape(fb, strict, path, res) {
 		return
 	}
-	validateFeedbackClassificationAndTags(fb, path, res)
+	validateFeedbackClassificationAndTags(fb, declaredDecisionTags(filepath.Dir(path)), path, res)
+	validateFeedbackSignature(fb, attempt, path, res)
+	validateFeedbackHistory(filepath.Join(filepath.Dir(path), artifacts.FeedbackHistoryJSONL), fb, res)
+}
+
+// validateFeedbackSignature verifies signed feedback and enforces the key pinned in attempt.json,
+// so an answer replaced after the runner recorded it does not pass as the runner's.
+func validateFeedbackSignature(fb schema.FeedbackJSONV1, attempt schema.AttemptJSONV1, path string, res *Result) {
+	if fb.Signature == nil {
+		if attempt.FeedbackKeyID != "" {
+			addErr(res, "ZCL_E_SIGNATURE_INVALID", "feedback.json is unsigned but attempt.json pins feedbackKeyId "+attempt.FeedbackKeyID, path)
+		}
+		return
+	}
+	keyID, err := sigkey.VerifyFeedback(fb)
+	if err != nil {
+		addErr(res, "ZCL_E_SIGNATURE_INVALID", err.Error(), path)
+		return
+	}
+	if attempt.FeedbackKeyID != "" && keyID != attempt.FeedbackKeyID {
+		addErr(res, "ZCL_E_SIGNATURE_INVALID", "feedback.json is signed by "+keyID+" but attempt.json pins "+attempt.FeedbackKeyID, path)
+	}
+}
+
+// validateFeedbackHistory checks that revisions are consecutive and that feedback.json is the
+// last one (a replaced feedback.json no longer matches its recorded revision).
+func validateFeedbackHistory(path string, fb schema.FeedbackJSONV1, res *Result) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			addErr(res, "ZCL_E_IO", err.Error(), path)
+		}
+		return
+	}
+	var last schema.FeedbackRevisionV1
+	n := 0
+	for _, line := range strings.Split(string(raw), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var rev schema.FeedbackRevisionV1
+		if err := json.Unmarshal([]byte(line), &rev); err != nil {
+			addErr(res, "ZCL_E_INVALID_JSONL", "feedback.history.jsonl contains invalid json", path)
+			return
+		}
+		n++
+		if rev.V != schema.FeedbackHistorySchemaV1 || rev.Revision != n {
+			addErr(res, "ZCL_E_CONTRACT", "feedback.history.jsonl revisions are not consecutive", path)
+			return
+		}
+		last = rev
+	}
+	if n == 0 {
+		return
+	}
+	if fb.Revision != last.Revision || fb.OK != last.Feedback.OK || fb.Result != last.Feedback.Result || !sameJSON(fb.ResultJSON, last.Feedback.ResultJSON) {
+		addErr(res, "ZCL_E_CONTRACT", "feedback.json does not match the last feedback.history.jsonl revision", path)
+	}
+}
+
+// validateFeedbackProgress checks milestone events belong to the attempt, are bounded and keep
+// consecutive seq numbers.
+func validateFeedbackProgress(path string, attempt schema.AttemptJSONV1, res *Result) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		addErr(res, "ZCL_E_IO", err.Error(), path)
+		return
+	}
+	n := 0
+	for _, line := range strings.Split(string(raw), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var ev schema.FeedbackProgressEventV1
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			addErr(res, "ZCL_E_INVALID_JSONL", "invalid jsonl line in feedback.progress.jsonl", path)
+			return
+		}
+		n++
+		switch {
+		case ev.V != schema.FeedbackProgressSchemaV1:
+			addErr(res, "ZCL_E_SCHEMA_UNSUPPORTED", "unsupported feedback progress event version", path)
+		case ev.RunID != attempt.RunID || ev.AttemptID != attempt.AttemptID || ev.MissionID != attempt.MissionID:
+			addErr(res, "ZCL_E_ID_MISMATCH", "feedback progress ids do not match attempt.json", path)
+		case ev.Seq != n:
+			addErr(res, "ZCL_E_CONTRACT", "feedback.progress.jsonl seq numbers are not consecutive", path)
+		case strings.TrimSpace(ev.Milestone) == "":
+			addErr(res, "ZCL_E_CONTRACT", "feedback progress milestone is missing", path)
+		case len([]byte(ev.Milestone)) > schema.FeedbackMilestoneMaxBytesV1 || len(ev.Data) > schema.NoteDataMaxBytesV1:
+			addErr(res, "ZCL_E_BOUNDS", "feedback progress event exceeds bounds", path)
+		default:
+			continue
+		}
+		return
+	}
+}
+
+// validatePromptContamination checks a blind prompt rewrite: prompt.txt must be the sanitized
+// prompt it recorded and prompt.original.txt the prompt it replaced.
+func validatePromptContamination(path, attemptDir string, attempt schema.AttemptJSONV1, res *Result) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		addErr(res, "ZCL_E_IO", err.Error(), path)
+		return
+	}
+	var rep schema.PromptContaminationJSONV1
+	if err := json.Unmarshal(raw, &rep); err != nil {
+		addErr(res, "ZCL_E_INVALID_JSON", "prompt.contamination.json is not valid json", path)
+		return
+	}
+	switch {
+	case rep.SchemaVersion != schema.PromptContaminationSchemaV1:
+		addErr(res, "ZCL_E_SCHEMA_UNSUPPORTED", "unsupported prompt.contamination.json schemaVersion", path)
+		return
+	case rep.RunID != attempt.RunID || rep.AttemptID != attempt.AttemptID:
+		addErr(res, "ZCL_E_ID_MISMATCH", "prompt contamination ids do not match attempt.json", path)
+		return
+	}
+	for _, f := range []struct{ name, sum string }{
+		{artifacts.PromptTXT, rep.SanitizedSHA256},
+		{artifacts.PromptOriginalTXT, rep.OriginalSHA256},
+	} {
+		b, err := store.ReadArtifactFile(filepath.Join(attemptDir, f.name))
+		if err != nil {
+			addErr(res, "ZCL_E_CONTRACT", "prompt.contamination.json requires "+f.name, path)
+			continue
+		}
+		if sum := sha256.Sum256(b); hex.EncodeToString(sum[:]) != f.sum {
+			addErr(res, "ZCL_E_CONTRACT", f.name+" does not match its sha256 in prompt.contamination.json", path)
+		}
+	}
+}
+
+func sameJSON(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
 }
 
 func readFeedbackArtifact(path string, res *Result) (schema.FeedbackJSONV1, bool) {
@@ -782,18 +1049,34 @@ func validateFeedbackResultShape(fb schema.FeedbackJSONV1, strict bool, path str
 	return true
 }
 
-func validateFeedbackClassificationAndTags(fb schema.FeedbackJSONV1, path string, res *Result) {
+// declaredDecisionTags returns the custom decision tags registered by the run's suite.json snapshot.
+func declaredDecisionTags(attemptDir string) []string {
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(attemptDir)), artifacts.SuiteJSON))
+	if err != nil {
+		return nil
+	}
+	var sf suite.SuiteFileV1
+	if json.Unmarshal(b, &sf) != nil {
+		return nil
+	}
+	return sf.CustomDecisionTags()
+}
+
+func validateFeedbackClassificationAndTags(fb schema.FeedbackJSONV1, custom []string, path string, res *Result) {
 	if strings.TrimSpace(fb.Classification) != "" && !schema.IsValidClassificationV1(fb.Classification) {
 		addErr(res, "ZCL_E_CONTRACT", "feedback classification is invalid", path)
 		return
 	}
 	for _, tag := range fb.DecisionTags {
-		if schema.IsValidDecisionTagV1(tag) {
+		if schema.IsDeclaredDecisionTagV1(tag, custom) {
 			continue
 		}
-		addErr(res, "ZCL_E_CONTRACT", "feedback decisionTags contains invalid tag", path)
+		addErr(res, "ZCL_E_CONTRACT", "feedback decisionTags contains undeclared tag "+strconv.Quote(tag), path)
 		return
 	}
+	if fb.Confidence != nil && (*fb.Confidence < 0 || *fb.Confidence > 1) {
+		addErr(res, "ZCL_E_CONTRACT", "feedback confidence must be within 0..1", path)
+	}
 }
 
 func validateNotes(path string, attempt schema.AttemptJSONV1, strict bool, res *Result) {
@@ -1055,7 +1338,9 @@ func validateCapturePath(attemptDir, rel string, maxBytes int64, strict bool, pa
 		}
 		return true
 	}
-	if info.Size() > maxBytes {
+	// Compacted or encrypted copies do not have the captured size on disk.
+	sealed := strings.HasSuffix(rel, store.GzipSuffix) || strings.HasSuffix(rel, store.EncryptedSuffix)
+	if !sealed && info.Size() > maxBytes {
 		addErr(res, "ZCL_E_BOUNDS", "capture file exceeds maxBytes", abs)
 		return false
 	}
@@ -1,11 +1,16 @@
 package validate
 
 import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
 )
 
 func TestValidate_MissingArtifact_Strict(t *testing.T) {
@@ -108,6 +113,50 @@ func TestValidate_FunnelBypass_Strict(t *testing.T) {
 	}
 }
 
+func TestValidate_TraceHashChainDetectsEdits(t *testing.T) {
+	attemptDir := t.TempDir()
+	attemptID := filepath.Base(attemptDir)
+	if err := os.WriteFile(filepath.Join(attemptDir, "attempt.json"), []byte(`{"schemaVersion":1,"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"`+attemptID+`","mode":"ci","startedAt":"2026-02-15T18:00:00Z"}`), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(attemptDir, "feedback.json"), []byte(`{"schemaVersion":1,"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"`+attemptID+`","ok":true,"result":"x","createdAt":"2026-02-15T18:00:00Z"}`), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var lines []string
+	prevHash, prevLine := schema.TraceChainGenesisV1, ""
+	for i, out := range []string{"one", "two", "three"} {
+		if i > 0 {
+			prevHash = schema.TraceChainLinkV1(prevHash, []byte(prevLine))
+		}
+		line := `{"v":1,"ts":"2026-02-15T18:00:01Z","runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"` + attemptID + `","tool":"cli","op":"exec","input":{"argv":["echo"]},"result":{"ok":true,"durationMs":1},"io":{"outBytes":3,"errBytes":0,"outPreview":"` + out + `"},"prevHash":"` + prevHash + `"}`
+		lines = append(lines, line)
+		prevLine = line
+	}
+	tracePath := filepath.Join(attemptDir, "tool.calls.jsonl")
+	if err := os.WriteFile(tracePath, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	res, err := ValidatePath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.OK {
+		t.Fatalf("expected intact chain to validate, got: %+v", res.Errors)
+	}
+
+	lines[1] = strings.Replace(lines[1], `"outPreview":"two"`, `"outPreview":"TWO"`, 1)
+	if err := os.WriteFile(tracePath, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	res, err = ValidatePath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.OK || !hasCode(res.Errors, "ZCL_E_TRACE_CHAIN_BROKEN") {
+		t.Fatalf("expected ZCL_E_TRACE_CHAIN_BROKEN, got: %+v", res.Errors)
+	}
+}
+
 func TestValidate_CaptureRawInCIMode_StrictFails(t *testing.T) {
 	attemptDir := t.TempDir()
 	attemptID := filepath.Base(attemptDir)
@@ -179,3 +228,142 @@ func hasCode(fs []Finding, code string) bool {
 	}
 	return false
 }
+
+func TestValidate_FeedbackHistoryMustEndWithCanonicalFeedback(t *testing.T) {
+	attemptDir := t.TempDir()
+	attemptID := filepath.Base(attemptDir)
+	if err := os.WriteFile(filepath.Join(attemptDir, "attempt.json"), []byte(`{"schemaVersion":1,"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"`+attemptID+`","mode":"discovery","startedAt":"2026-02-15T18:00:00Z"}`), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	line := `{"v":1,"ts":"2026-02-15T18:00:01Z","runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"` + attemptID + `","tool":"cli","op":"exec","input":{"argv":["echo"]},"result":{"ok":true,"durationMs":1},"io":{"outBytes":0,"errBytes":0}}`
+	if err := os.WriteFile(filepath.Join(attemptDir, "tool.calls.jsonl"), []byte(line+"\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	feedback := func(ok bool, result string, revision int) string {
+		return fmt.Sprintf(`{"schemaVersion":1,"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"%s","ok":%t,"result":"%s","revision":%d,"createdAt":"2026-02-15T18:00:00Z"}`, attemptID, ok, result, revision)
+	}
+	history := `{"v":1,"revision":1,"op":"write","feedback":` + feedback(false, "x", 1) + "}\n" +
+		`{"v":1,"revision":2,"op":"write","feedback":` + feedback(true, "y", 2) + "}\n"
+	if err := os.WriteFile(filepath.Join(attemptDir, "feedback.history.jsonl"), []byte(history), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for _, tc := range []struct {
+		feedback string
+		ok       bool
+	}{
+		{feedback(true, "y", 2), true},
+		{feedback(true, "forged", 2), false},
+		{feedback(false, "x", 1), false},
+	} {
+		if err := os.WriteFile(filepath.Join(attemptDir, "feedback.json"), []byte(tc.feedback), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		res, err := ValidatePath(attemptDir, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.OK != tc.ok || (!tc.ok && !hasCode(res.Errors, "ZCL_E_CONTRACT")) {
+			t.Fatalf("feedback %s: expected ok=%v, got %+v", tc.feedback, tc.ok, res.Errors)
+		}
+	}
+}
+
+func TestValidate_FeedbackSignatureAndPinnedKey(t *testing.T) {
+	attemptDir := t.TempDir()
+	attemptID := filepath.Base(attemptDir)
+	line := `{"v":1,"ts":"2026-02-15T18:00:01Z","runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"` + attemptID + `","tool":"cli","op":"exec","input":{"argv":["echo"]},"result":{"ok":true,"durationMs":1},"io":{"outBytes":0,"errBytes":0}}`
+	if err := os.WriteFile(filepath.Join(attemptDir, "tool.calls.jsonl"), []byte(line+"\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	_, runnerKey, _ := ed25519.GenerateKey(nil)
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+	pinned := sigkey.KeyID(runnerKey.Public().(ed25519.PublicKey))
+	base := schema.FeedbackJSONV1{
+		SchemaVersion: schema.FeedbackSchemaV1,
+		RunID:         "20260215-180012Z-09c5a6",
+		SuiteID:       "s",
+		MissionID:     "m",
+		AttemptID:     attemptID,
+		OK:            true,
+		Result:        "DONE",
+		CreatedAt:     "2026-02-15T18:00:02Z",
+	}
+	signed := func(priv ed25519.PrivateKey, edit func(*schema.FeedbackJSONV1)) schema.FeedbackJSONV1 {
+		fb, err := sigkey.SignFeedback(base, priv)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		if edit != nil {
+			edit(&fb)
+		}
+		return fb
+	}
+	for _, tc := range []struct {
+		name     string
+		pin      string
+		feedback schema.FeedbackJSONV1
+		ok       bool
+	}{
+		{"unsigned without pin", "", base, true},
+		{"signed with pin", pinned, signed(runnerKey, nil), true},
+		{"edited after signing", "", signed(runnerKey, func(fb *schema.FeedbackJSONV1) { fb.Result = "FORGED" }), false},
+		{"unsigned with pin", pinned, base, false},
+		{"signed by another key", pinned, signed(otherKey, nil), false},
+	} {
+		attempt := fmt.Sprintf(`{"schemaVersion":1,"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"%s","mode":"discovery","startedAt":"2026-02-15T18:00:00Z","feedbackKeyId":"%s"}`, attemptID, tc.pin)
+		if err := os.WriteFile(filepath.Join(attemptDir, "attempt.json"), []byte(attempt), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		b, err := json.Marshal(tc.feedback)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(attemptDir, "feedback.json"), b, 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		res, err := ValidatePath(attemptDir, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.OK != tc.ok || (!tc.ok && !hasCode(res.Errors, "ZCL_E_SIGNATURE_INVALID")) {
+			t.Fatalf("%s: expected ok=%v, got errors=%+v", tc.name, tc.ok, res.Errors)
+		}
+	}
+}
+
+func TestValidate_FeedbackDecisionTagsMustBeDeclared(t *testing.T) {
+	runDir := filepath.Join(t.TempDir(), "20260215-180012Z-09c5a6")
+	attemptDir := filepath.Join(runDir, "attempts", "001-m-r1")
+	if err := os.MkdirAll(attemptDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	ids := `"runId":"20260215-180012Z-09c5a6","suiteId":"s","missionId":"m","attemptId":"001-m-r1"`
+	files := map[string]string{
+		"attempt.json":     `{"schemaVersion":1,` + ids + `,"mode":"discovery","startedAt":"2026-02-15T18:00:00Z"}`,
+		"tool.calls.jsonl": `{"v":1,"ts":"2026-02-15T18:00:01Z",` + ids + `,"tool":"cli","op":"exec","input":{"argv":["echo"]},"result":{"ok":true,"durationMs":1},"io":{"outBytes":0,"errBytes":0}}` + "\n",
+		"feedback.json":    `{"schemaVersion":1,` + ids + `,"ok":false,"result":"STUCK","decisionTags":["blocked","captcha"],"createdAt":"2026-02-15T18:00:02Z"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(attemptDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	res, err := ValidatePath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.OK || !hasCode(res.Errors, "ZCL_E_CONTRACT") {
+		t.Fatalf("expected undeclared tag to fail, got %+v", res.Errors)
+	}
+
+	if err := os.WriteFile(filepath.Join(runDir, "suite.json"), []byte(`{"version":1,"suiteId":"s","decisionTags":[{"name":"captcha"}],"missions":[{"missionId":"m"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite.json: %v", err)
+	}
+	res, err = ValidatePath(attemptDir, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.OK {
+		t.Fatalf("expected suite-declared tag to validate, got %+v", res.Errors)
+	}
+}
@@ -0,0 +1,116 @@
+package oracle
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	ClaimConfirmed    = "confirmed"
+	ClaimRefuted      = "refuted"
+	ClaimUnverifiable = "unverifiable"
+)
+
+// Claim is the verification status of one top-level field of the attempt's answer.
+type Claim struct {
+	Field         string `json:"field"`
+	Status        string `json:"status"` // confirmed|refuted|unverifiable
+	Claimed       any    `json:"claimed,omitempty"`
+	Expected      any    `json:"expected,omitempty"`
+	MismatchClass string `json:"mismatchClass,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// CheckedFields returns the top-level proof fields the builtin evaluator checks: rule fields and
+// collectFields, or the expected answer's keys for rule-less oracles.
+func CheckedFields(file FileV1) []string {
+	seen := map[string]bool{}
+	for _, f := range file.CollectFields {
+		seen[f] = true
+	}
+	var walk func(rules []RuleV1)
+	walk = func(rules []RuleV1) {
+		for _, r := range rules {
+			if f := strings.TrimSpace(r.Field); f != "" {
+				seen[f] = true
+			}
+			walk(r.AllOf)
+			walk(r.AnyOf)
+		}
+	}
+	walk(file.Rules)
+	if len(file.Rules) == 0 {
+		if expected, ok := ExpectedAnswer(file); ok {
+			if obj, ok := expected.(map[string]any); ok {
+				for k := range obj {
+					seen[k] = true
+				}
+			} else {
+				seen[expectedFieldName("")] = true
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for f := range seen {
+		out = append(out, f)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// DiffClaims classifies every top-level field of answer (or the whole answer as "result" when it is
+// not an object): refuted when a mismatch names it, confirmed when checked lists it, unverifiable
+// otherwise. Mismatches on fields the answer does not claim are left to oracle.verdict.json.
+func DiffClaims(answer any, checked []string, mismatches []Mismatch) []Claim {
+	claimed := map[string]any{}
+	switch a := answer.(type) {
+	case map[string]any:
+		claimed = a
+	case nil:
+	default:
+		claimed[expectedFieldName("")] = a
+	}
+	checkedSet := map[string]bool{}
+	for _, f := range checked {
+		checkedSet[f] = true
+	}
+	refuted := map[string]Mismatch{}
+	for _, mm := range mismatches {
+		f := strings.TrimSpace(mm.Field)
+		if _, ok := claimed[f]; !ok {
+			f = claimField(f)
+		}
+		if _, ok := refuted[f]; !ok && f != "" {
+			refuted[f] = mm
+		}
+	}
+	fields := make([]string, 0, len(claimed))
+	for f := range claimed {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	out := make([]Claim, 0, len(fields))
+	for _, f := range fields {
+		c := Claim{Field: f, Claimed: claimed[f], Status: ClaimUnverifiable}
+		if mm, ok := refuted[f]; ok {
+			c.Status = ClaimRefuted
+			c.Expected = mm.Expected
+			c.MismatchClass = mm.MismatchClass
+			c.Message = mm.Message
+		} else if checkedSet[f] {
+			c.Status = ClaimConfirmed
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// claimField maps a nested comparator field ("a.b", "a[0]") to its top-level proof field; rule
+// fields already name top-level proof fields.
+func claimField(field string) string {
+	field = strings.TrimSpace(field)
+	if i := strings.IndexAny(field, ".["); i >= 0 {
+		return field[:i]
+	}
+	return field
+}
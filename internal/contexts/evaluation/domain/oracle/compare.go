@@ -0,0 +1,139 @@
+package oracle
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const OpFieldPresent = "field_present"
+
+// oracleMetadataKeys are oracle file keys that describe the oracle itself, not the expected answer.
+var oracleMetadataKeys = map[string]bool{
+	"schemaVersion": true,
+	"missionId":     true,
+	"collectFields": true,
+	"rules":         true,
+}
+
+// ExpectedAnswer returns the answer a rule-less oracle expects: the `expected` value when present,
+// otherwise every non-metadata top-level field of the oracle file.
+func ExpectedAnswer(file FileV1) (any, bool) {
+	if v, ok := file.root["expected"]; ok {
+		return v, true
+	}
+	out := map[string]any{}
+	for k, v := range file.root {
+		if !oracleMetadataKeys[k] {
+			out[k] = v
+		}
+	}
+	return out, len(out) > 0
+}
+
+// EvaluateExpected compares proof against an expected answer without authored rules.
+// Objects are compared field by field (extra proof fields are ignored), arrays element-wise,
+// URLs loosely (scheme/host case, trailing slash) and other strings with whitespace collapsed.
+// Anything still different falls through to eq semantics under policyMode.
+func EvaluateExpected(expected any, proof any, policyMode string) Verdict {
+	policyMode = strings.TrimSpace(strings.ToLower(policyMode))
+	if policyMode == "" {
+		policyMode = PolicyModeStrict
+	}
+	var mismatches []Mismatch
+	compareExpected("", expected, proof, policyMode, &mismatches)
+	return Verdict{
+		OK:         len(mismatches) == 0,
+		Message:    composeMessage(mismatches),
+		Mismatches: mismatches,
+	}
+}
+
+func compareExpected(field string, expected any, actual any, policyMode string, out *[]Mismatch) {
+	switch e := expected.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			*out = append(*out, *inferPairMismatch(expectedFieldName(field), OpEQ, expected, actual, expectedFieldName(field)+" must be an object", policyMode))
+			return
+		}
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sub := joinExpectedField(field, k)
+			av, present := a[k]
+			if !present {
+				*out = append(*out, Mismatch{
+					Field:         sub,
+					Op:            OpFieldPresent,
+					MismatchClass: MismatchSemantic,
+					Message:       "missing proof field: " + sub,
+					Expected:      e[k],
+				})
+				continue
+			}
+			compareExpected(sub, e[k], av, policyMode, out)
+		}
+	case []any:
+		a, ok := actual.([]any)
+		if !ok {
+			*out = append(*out, *inferPairMismatch(expectedFieldName(field), OpEQ, expected, actual, expectedFieldName(field)+" must be an array", policyMode))
+			return
+		}
+		if len(a) != len(e) {
+			*out = append(*out, *inferPairMismatch(expectedFieldName(field), OpEQ, expected, actual, fmt.Sprintf("%s expected %d items got %d", expectedFieldName(field), len(e), len(a)), policyMode))
+			return
+		}
+		for i := range e {
+			compareExpected(field+"["+strconv.Itoa(i)+"]", e[i], a[i], policyMode, out)
+		}
+	default:
+		f := expectedFieldName(field)
+		if ok, mm := evaluateSingleExpectedEQ(f, OpEQ, expected, actual, normalizeAnswerLeaf(expected), normalizeAnswerLeaf(actual), policyMode); !ok {
+			*out = append(*out, *mm)
+		}
+	}
+}
+
+// normalizeAnswerLeaf applies the builtin evaluator's always-on normalization to scalar answers.
+func normalizeAnswerLeaf(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	if isAbsoluteHTTPURL(s) {
+		if canon, ok := canonicalLooseURL(s); ok {
+			return canon
+		}
+	}
+	return s
+}
+
+func isAbsoluteHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return scheme == "http" || scheme == "https"
+}
+
+func joinExpectedField(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func expectedFieldName(field string) string {
+	if field == "" {
+		return "result"
+	}
+	return field
+}
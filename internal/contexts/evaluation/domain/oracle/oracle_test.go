@@ -1,6 +1,9 @@
 package oracle
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestEvaluateProof_FormatToleranceByPolicy(t *testing.T) {
 	file := FileV1{
@@ -118,3 +121,75 @@ func TestEvaluateProof_AdversarialNoOverPermissivePass(t *testing.T) {
 		}
 	}
 }
+
+func TestEvaluateExpected_NormalizesAndReportsFieldPaths(t *testing.T) {
+	file := FileV1{root: map[string]any{
+		"schemaVersion": 1,
+		"missionId":     "m",
+		"title":         "Hello   World",
+		"links":         []any{map[string]any{"href": "https://Blog.heftiweb.ch/"}},
+		"count":         3.0,
+		"status":        "ok",
+	}}
+	expected, ok := ExpectedAnswer(file)
+	if !ok {
+		t.Fatalf("expected answer from oracle root")
+	}
+	answer := map[string]any{
+		"title": " Hello World\n",
+		"links": []any{map[string]any{"href": "https://example.com/"}},
+		"count": 3,
+		"extra": true,
+	}
+	got := EvaluateExpected(expected, answer, PolicyModeStrict)
+	if got.OK || len(got.Mismatches) != 2 {
+		t.Fatalf("expected href and status mismatches, got %+v", got)
+	}
+	if mm := got.Mismatches[0]; mm.Field != "links[0].href" || mm.MismatchClass != MismatchSemantic {
+		t.Fatalf("expected semantic mismatch for links[0].href, got %+v", mm)
+	}
+	if mm := got.Mismatches[1]; mm.Field != "status" || mm.Op != OpFieldPresent || mm.MismatchClass != MismatchSemantic {
+		t.Fatalf("expected missing status field, got %+v", mm)
+	}
+
+	answer["status"] = "ok"
+	answer["links"] = []any{map[string]any{"href": "https://blog.heftiweb.ch"}}
+	if got := EvaluateExpected(expected, answer, PolicyModeStrict); !got.OK {
+		t.Fatalf("expected whitespace/url-normalized answer to pass, got %+v", got)
+	}
+}
+
+func TestDiffClaims_ClassifiesTopLevelFields(t *testing.T) {
+	file := FileV1{root: map[string]any{
+		"title":  "Hello World",
+		"links":  []any{map[string]any{"href": "https://blog.heftiweb.ch"}},
+		"status": "ok",
+	}}
+	expected, _ := ExpectedAnswer(file)
+	answer := map[string]any{
+		"title": "Hello World",
+		"links": []any{map[string]any{"href": "https://example.com/"}},
+		"notes": "found via sitemap",
+	}
+	verdict := EvaluateExpected(expected, answer, PolicyModeStrict)
+	claims := DiffClaims(answer, CheckedFields(file), verdict.Mismatches)
+	got := map[string]string{}
+	for _, c := range claims {
+		got[c.Field] = c.Status
+	}
+	want := map[string]string{"links": ClaimRefuted, "notes": ClaimUnverifiable, "title": ClaimConfirmed}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected claim statuses: %+v", claims)
+	}
+	if claims[0].Field != "links" || claims[0].Message == "" {
+		t.Fatalf("expected refuted claim to carry the mismatch, got %+v", claims[0])
+	}
+
+	if got := DiffClaims("42", nil, nil); len(got) != 1 || got[0].Field != "result" || got[0].Status != ClaimUnverifiable {
+		t.Fatalf("expected a single unverifiable result claim, got %+v", got)
+	}
+	rules := FileV1{CollectFields: []string{"a.b"}, Rules: []RuleV1{{AnyOf: []RuleV1{{Field: "c", Op: OpNonEmpty}}}}}
+	if got := CheckedFields(rules); !reflect.DeepEqual(got, []string{"a.b", "c"}) {
+		t.Fatalf("expected literal rule fields, got %v", got)
+	}
+}
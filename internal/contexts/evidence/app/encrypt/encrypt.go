@@ -0,0 +1,119 @@
+package encrypt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// sensitiveAttemptFiles hold the prompt and raw runner IO; capture files listed in captures.jsonl
+// are encrypted as well.
+var sensitiveAttemptFiles = []string{
+	artifacts.PromptTXT,
+	"runner.stdout.log",
+	"runner.stderr.log",
+}
+
+// FileV1 paths are relative to the attempt directory and name the encrypted (.enc) copy.
+type FileV1 struct {
+	Path        string `json:"path"`
+	BytesBefore int64  `json:"bytesBefore"`
+	BytesAfter  int64  `json:"bytesAfter"`
+}
+
+type Result struct {
+	AttemptDir  string   `json:"attemptDir"`
+	RecipientID string   `json:"recipientId"`
+	Files       []FileV1 `json:"files,omitempty"`
+}
+
+// Attempt encrypts the sensitive artifacts of a finished attempt for rcpt. Plaintext originals are
+// removed, captures.jsonl is rewritten to the .enc paths and already-encrypted files are left alone,
+// so calling it again is a no-op. outRoot is used to drop CAS blobs only the prompt still linked.
+func Attempt(outRoot, attemptDir string, rcpt age.Recipient) (Result, error) {
+	if rcpt == nil {
+		return Result{}, fmt.Errorf("missing encryption recipient")
+	}
+	res := Result{AttemptDir: attemptDir, RecipientID: store.RecipientID(rcpt)}
+	if err := encryptCaptures(outRoot, attemptDir, rcpt, &res); err != nil {
+		return res, err
+	}
+	for _, name := range sensitiveAttemptFiles {
+		if err := encryptInto(outRoot, attemptDir, name, rcpt, &res); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func encryptCaptures(outRoot, attemptDir string, rcpt age.Recipient, res *Result) error {
+	indexPath := filepath.Join(attemptDir, artifacts.CapturesJSONL)
+	raw, err := os.ReadFile(indexPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var out bytes.Buffer
+	changed := false
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var ev schema.CaptureEventV1
+		if err := json.Unmarshal(line, &ev); err != nil {
+			// Keep lines we cannot interpret verbatim; validate reports them.
+			out.Write(line)
+			out.WriteByte('\n')
+			continue
+		}
+		for _, p := range []*string{&ev.StdoutPath, &ev.StderrPath} {
+			rel := strings.TrimSpace(*p)
+			if rel == "" || strings.HasSuffix(rel, store.EncryptedSuffix) || filepath.IsAbs(rel) || strings.Contains(rel, "..") {
+				continue
+			}
+			if err := encryptInto(outRoot, attemptDir, rel, rcpt, res); err != nil {
+				return err
+			}
+			if _, err := os.Stat(filepath.Join(attemptDir, rel+store.EncryptedSuffix)); err == nil {
+				*p = rel + store.EncryptedSuffix
+				changed = true
+			}
+		}
+		b, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		out.Write(b)
+		out.WriteByte('\n')
+	}
+	if !changed {
+		return nil
+	}
+	return store.WriteFileAtomic(indexPath, out.Bytes())
+}
+
+func encryptInto(outRoot, attemptDir, rel string, rcpt age.Recipient, res *Result) error {
+	path := filepath.Join(attemptDir, rel)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	before, after, err := store.EncryptFile(outRoot, path, rcpt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rel, err)
+	}
+	res.Files = append(res.Files, FileV1{Path: filepath.ToSlash(rel) + store.EncryptedSuffix, BytesBefore: before, BytesAfter: after})
+	return nil
+}
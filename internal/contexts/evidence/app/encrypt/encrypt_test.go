@@ -0,0 +1,66 @@
+package encrypt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+func TestAttempt_EncryptsSensitiveFilesAndCaptureIndex(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, body string) {
+		t.Helper()
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	write("prompt.txt", "secret prompt")
+	write("runner.stdout.log", "out")
+	write("captures/c1.stdout.log", "captured")
+	write("captures.jsonl", `{"v":1,"ts":"2026-02-22T12:00:00Z","runId":"r","missionId":"m","attemptId":"a","tool":"cli","op":"exec","stdoutPath":"captures/c1.stdout.log","redacted":true}`+"\n")
+	write("tool.calls.jsonl", "{}\n")
+
+	id, err := store.GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity: %v", err)
+	}
+	res, err := Attempt("", dir, id.Recipient())
+	if err != nil {
+		t.Fatalf("Attempt: %v", err)
+	}
+	if len(res.Files) != 3 {
+		t.Fatalf("expected prompt, stdout and capture encrypted, got %+v", res.Files)
+	}
+	for _, rel := range []string{"prompt.txt", "runner.stdout.log", "captures/c1.stdout.log"} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s plaintext removed", rel)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tool.calls.jsonl")); err != nil {
+		t.Fatalf("trace must stay readable: %v", err)
+	}
+	idx, _ := os.ReadFile(filepath.Join(dir, "captures.jsonl"))
+	if !strings.Contains(string(idx), `"stdoutPath":"captures/c1.stdout.log.enc"`) {
+		t.Fatalf("expected capture index to point at .enc, got %s", idx)
+	}
+
+	again, err := Attempt("", dir, id.Recipient())
+	if err != nil || len(again.Files) != 0 {
+		t.Fatalf("expected second pass to be a no-op, got %+v err=%v", again.Files, err)
+	}
+
+	store.SetIdentityLoader(func() ([]age.Identity, error) { return []age.Identity{id}, nil })
+	t.Cleanup(func() { store.SetIdentityLoader(nil) })
+	got, err := store.ReadArtifactFile(filepath.Join(dir, "prompt.txt"))
+	if err != nil || string(got) != "secret prompt" {
+		t.Fatalf("expected transparent decrypt, got %q err=%v", got, err)
+	}
+}
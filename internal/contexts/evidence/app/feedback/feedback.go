@@ -1,9 +1,12 @@
 package feedback
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +16,7 @@ import (
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
@@ -22,23 +26,69 @@ type WriteOpts struct {
 	ResultJSON     string
 	Classification string
 	DecisionTags   []string
+	// Confidence is the claimed probability (0..1) that the outcome is correct; nil leaves it unset.
+	Confidence *float64
 	// SkipSuiteResultShape skips suite expects.result type/shape enforcement.
 	// Use only for synthetic infra-failure feedback written by orchestration.
 	SkipSuiteResultShape bool
 }
 
+// FieldError is one rejected feedback field. Field names the feedback.json field
+// (decisionTags[1], classification, resultJson/<pointer>, ...).
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError collects every field problem found in one pass so runner scripts can fix them
+// all at once instead of failing `zcl validate` later.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+	for _, f := range e.Errors {
+		parts = append(parts, f.Field+": "+f.Message)
+	}
+	return "invalid feedback: " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) add(field, format string, args ...any) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e *ValidationError) err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
 func Write(now time.Time, env trace.Env, opts WriteOpts) error {
-	classification, decisionTags, err := validateWriteOpts(opts)
+	if opts.Result != "" && opts.ResultJSON != "" {
+		return fmt.Errorf("provide only one of --result or --result-json")
+	}
+	if opts.Result == "" && opts.ResultJSON == "" {
+		return fmt.Errorf("missing --result or --result-json")
+	}
+	sf, err := readRunSuite(env.OutDirAbs)
 	if err != nil {
 		return err
 	}
-
-	attemptMeta, err := requireEvidenceForMode(env)
+	var verr ValidationError
+	classification := validateClassification(opts.Classification, &verr)
+	decisionTags := validateDecisionTags(opts.DecisionTags, sf, &verr)
+	validateConfidence(opts.Confidence, &verr)
+	resultText, resultRaw, applied, err := normalizeFeedbackResult(opts, &verr)
 	if err != nil {
 		return err
 	}
+	if err := verr.err(); err != nil {
+		return err
+	}
 
-	resultText, resultRaw, applied, err := normalizeFeedbackResult(opts)
+	attemptMeta, err := requireEvidenceForMode(env)
 	if err != nil {
 		return err
 	}
@@ -54,70 +104,320 @@ func Write(now time.Time, env trace.Env, opts WriteOpts) error {
 		ResultJSON:        resultRaw,
 		Classification:    classification,
 		DecisionTags:      decisionTags,
+		Confidence:        opts.Confidence,
 		CreatedAt:         now.UTC().Format(time.RFC3339Nano),
 		RedactionsApplied: applied,
 	}
 	if !opts.SkipSuiteResultShape {
-		if err := enforceSuiteResultShape(env, attemptMeta, payload); err != nil {
+		if err := enforceSuiteResultShape(sf, attemptMeta, payload); err != nil {
 			return err
 		}
 	}
 
-	path := filepath.Join(env.OutDirAbs, artifacts.FeedbackJSON)
-	return store.WriteJSONAtomic(path, payload)
+	return persist(env, attemptMeta, payload, schema.FeedbackRevisionOpWrite)
 }
 
-func requireEvidenceForMode(env trace.Env) (schema.AttemptJSONV1, error) {
-	attemptMeta, err := readAttemptMetadata(env.OutDirAbs)
+// persist appends payload to feedback.history.jsonl as the next revision and then makes it the
+// canonical feedback.json, so agents may revise their answer while reports still see the churn.
+// Writers of one attempt are serialized by feedbackLockName so the canonical file always holds the
+// newest revision; the revision itself is derived from the history tail under the append lock.
+// Both are refused once the attempt is finalized, so finish/report never see feedback change.
+func persist(env trace.Env, attemptMeta schema.AttemptJSONV1, payload schema.FeedbackJSONV1, op string) error {
+	outDir := env.OutDirAbs
+	return store.WithDirLock(filepath.Join(outDir, feedbackLockName), 5*time.Second, func() error {
+		if _, err := os.Stat(filepath.Join(outDir, artifacts.AttemptFinishJSON)); err == nil {
+			return errFinalized
+		}
+		err := store.AppendJSONLLinked(filepath.Join(outDir, artifacts.FeedbackHistoryJSONL), func(last []byte) (any, error) {
+			prev, err := lastRevision(last)
+			if err != nil {
+				return nil, err
+			}
+			payload.Revision = prev + 1
+			if payload, err = sign(env.FeedbackSigningKey, attemptMeta.FeedbackKeyID, payload); err != nil {
+				return nil, err
+			}
+			return schema.FeedbackRevisionV1{
+				V:        schema.FeedbackHistorySchemaV1,
+				Revision: payload.Revision,
+				Op:       op,
+				Feedback: payload,
+			}, nil
+		})
+		if err != nil {
+			return err
+		}
+		return store.WriteJSONAtomic(filepath.Join(outDir, artifacts.FeedbackJSON), payload)
+	})
+}
+
+// feedbackLockName is the attempt-dir lock held while a feedback revision is persisted.
+const feedbackLockName = ".feedback.lock"
+
+var errFinalized = errors.New("attempt already finalized (attempt.finish.json exists); feedback can no longer be written or updated")
+
+// lastRevision is the revision recorded on the last feedback.history.jsonl line (0 when empty).
+func lastRevision(last []byte) (int, error) {
+	if len(last) == 0 {
+		return 0, nil
+	}
+	var rev schema.FeedbackRevisionV1
+	if err := json.Unmarshal(last, &rev); err != nil {
+		return 0, fmt.Errorf("invalid %s tail: %w", artifacts.FeedbackHistoryJSONL, err)
+	}
+	return rev.Revision, nil
+}
+
+// sign signs payload with the runner's key. When attempt.json pins a key, unsigned or differently
+// signed feedback is refused here rather than surfacing later in `zcl validate`.
+func sign(keyPath, pinnedKeyID string, payload schema.FeedbackJSONV1) (schema.FeedbackJSONV1, error) {
+	payload.Signature = nil
+	if strings.TrimSpace(keyPath) == "" {
+		if pinnedKeyID != "" {
+			return payload, fmt.Errorf("attempt requires feedback signed by %s (set %s)", pinnedKeyID, sigkey.FeedbackKeyEnv)
+		}
+		return payload, nil
+	}
+	priv, err := sigkey.LoadFeedbackKey(keyPath)
 	if err != nil {
-		return schema.AttemptJSONV1{}, err
+		return payload, err
 	}
-	if err := requireNonEmptyTrace(filepath.Join(env.OutDirAbs, artifacts.ToolCallsJSONL)); err != nil {
-		return schema.AttemptJSONV1{}, err
+	if id := sigkey.KeyID(priv.Public().(ed25519.PublicKey)); pinnedKeyID != "" && id != pinnedKeyID {
+		return payload, fmt.Errorf("%s is key %s but the attempt pins %s", sigkey.FeedbackKeyEnv, id, pinnedKeyID)
 	}
-	return attemptMeta, nil
+	return sigkey.SignFeedback(payload, priv)
 }
 
-func validateWriteOpts(opts WriteOpts) (string, []string, error) {
-	if opts.Result != "" && opts.ResultJSON != "" {
-		return "", nil, fmt.Errorf("provide only one of --result or --result-json")
+func countLines(path string) (int, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
 	}
-	if opts.Result == "" && opts.ResultJSON == "" {
-		return "", nil, fmt.Errorf("missing --result or --result-json")
+	n := 0
+	for _, line := range strings.Split(string(raw), "\n") {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
 	}
-	classification := strings.TrimSpace(opts.Classification)
+	return n, nil
+}
+
+// UpdateOpts amends an existing feedback.json; zero values leave fields unchanged.
+type UpdateOpts struct {
+	OK             *bool
+	Classification string
+	AppendTags     []string
+	Confidence     *float64
+	// MergeJSON is a JSON merge patch (RFC 7386) applied to the resultJson object.
+	MergeJSON string
+}
+
+// Update rewrites feedback.json with opts applied. Like Write, it is refused once the attempt is
+// finalized (attempt.finish.json exists).
+func Update(now time.Time, env trace.Env, opts UpdateOpts) error {
+	path := filepath.Join(env.OutDirAbs, artifacts.FeedbackJSON)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("missing feedback.json to update (write it first with --ok|--fail)")
+		}
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(env.OutDirAbs, artifacts.AttemptFinishJSON)); err == nil {
+		return errFinalized
+	}
+	var fb schema.FeedbackJSONV1
+	if err := json.Unmarshal(raw, &fb); err != nil {
+		return fmt.Errorf("invalid feedback.json: %w", err)
+	}
+
+	sf, err := readRunSuite(env.OutDirAbs)
+	if err != nil {
+		return err
+	}
+	var verr ValidationError
+	if opts.OK != nil {
+		fb.OK = *opts.OK
+	}
+	if strings.TrimSpace(opts.Classification) != "" {
+		fb.Classification = validateClassification(opts.Classification, &verr)
+	}
+	if opts.Confidence != nil {
+		validateConfidence(opts.Confidence, &verr)
+		fb.Confidence = opts.Confidence
+	}
+	fb.DecisionTags = validateDecisionTags(append(append([]string(nil), fb.DecisionTags...), opts.AppendTags...), sf, &verr)
+	if opts.MergeJSON != "" {
+		merged, err := mergeResultJSON(fb, opts.MergeJSON, &verr)
+		if err != nil {
+			return err
+		}
+		fb.ResultJSON = merged
+	}
+	if err := verr.err(); err != nil {
+		return err
+	}
+
+	attemptMeta, err := requireEvidenceForMode(env)
+	if err != nil {
+		return err
+	}
+	fb.CreatedAt = now.UTC().Format(time.RFC3339Nano)
+	if err := enforceSuiteResultShape(sf, attemptMeta, fb); err != nil {
+		return err
+	}
+	return persist(env, attemptMeta, fb, schema.FeedbackRevisionOpUpdate)
+}
+
+func validateClassification(raw string, verr *ValidationError) string {
+	classification := strings.TrimSpace(raw)
 	if classification != "" && !schema.IsValidClassificationV1(classification) {
-		return "", nil, fmt.Errorf("invalid --classification (expected missing_primitive|naming_ux|output_shape|already_possible_better_way)")
+		verr.add("classification", "unknown value %q (expected missing_primitive|naming_ux|output_shape|already_possible_better_way)", classification)
+		return ""
+	}
+	return classification
+}
+
+// validateDecisionTags accepts built-in tags plus the custom tags declared by the run's suite.
+func validateDecisionTags(raw []string, sf *suite.SuiteFileV1, verr *ValidationError) []string {
+	var custom []string
+	if sf != nil {
+		custom = sf.CustomDecisionTags()
 	}
-	decisionTags := schema.NormalizeDecisionTagsV1(opts.DecisionTags)
-	for _, tag := range decisionTags {
-		if !schema.IsValidDecisionTagV1(tag) {
-			return "", nil, fmt.Errorf("invalid --decision-tag %q", tag)
+	decisionTags := schema.NormalizeDecisionTagsV1(raw)
+	for i, tag := range decisionTags {
+		if !schema.IsDeclaredDecisionTagV1(tag, custom) {
+			verr.add(fmt.Sprintf("decisionTags[%d]", i), "unknown tag %q (expected %s; suites may declare more under decisionTags)", tag, strings.Join(append(schema.DecisionTagsV1(), custom...), "|"))
 		}
 	}
-	return classification, decisionTags, nil
+	return decisionTags
 }
 
-func normalizeFeedbackResult(opts WriteOpts) (string, json.RawMessage, []string, error) {
+func validateConfidence(c *float64, verr *ValidationError) {
+	if c != nil && (math.IsNaN(*c) || *c < 0 || *c > 1) {
+		verr.add("confidence", "must be within 0..1, got %v", *c)
+	}
+}
+
+func normalizeFeedbackResult(opts WriteOpts, verr *ValidationError) (string, json.RawMessage, []string, error) {
 	if opts.Result != "" {
 		red, applied := redact.Text(opts.Result)
 		if len([]byte(red)) > schema.FeedbackMaxBytesV1 {
-			return "", nil, nil, fmt.Errorf("result exceeds max bytes (%d)", schema.FeedbackMaxBytesV1)
+			verr.add("result", "exceeds max bytes (%d)", schema.FeedbackMaxBytesV1)
+			return "", nil, nil, nil
 		}
 		return red, nil, applied.Names, nil
 	}
+	v, ok := decodeJSONField("resultJson", opts.ResultJSON, verr)
+	if !ok {
+		return "", nil, nil, nil
+	}
+	b, err := canonicalResultJSON(v, verr)
+	return "", b, nil, err
+}
+
+// decodeJSONField parses raw, reporting syntax errors with their line and column.
+func decodeJSONField(field, raw string, verr *ValidationError) (any, bool) {
 	var v any
-	if err := json.Unmarshal([]byte(opts.ResultJSON), &v); err != nil {
-		return "", nil, nil, fmt.Errorf("invalid --result-json: %w", err)
+	err := json.Unmarshal([]byte(raw), &v)
+	if err == nil {
+		return v, true
+	}
+	var syn *json.SyntaxError
+	if errors.As(err, &syn) {
+		line, col := lineCol(raw, syn.Offset)
+		verr.add(field, "invalid json at line %d, column %d: %s", line, col, syn.Error())
+	} else {
+		verr.add(field, "invalid json: %s", err.Error())
+	}
+	return nil, false
+}
+
+func lineCol(raw string, offset int64) (int, int) {
+	line, col := 1, 1
+	for i := 0; i < len(raw) && int64(i) < offset-1; i++ {
+		if raw[i] == '\n' {
+			line, col = line+1, 1
+			continue
+		}
+		col++
 	}
+	return line, col
+}
+
+func canonicalResultJSON(v any, verr *ValidationError) (json.RawMessage, error) {
 	b, err := store.CanonicalJSON(v)
 	if err != nil {
-		return "", nil, nil, err
+		return nil, err
 	}
 	if len(b) > schema.FeedbackMaxBytesV1 {
-		return "", nil, nil, fmt.Errorf("resultJson exceeds max bytes (%d)", schema.FeedbackMaxBytesV1)
+		verr.add("resultJson", "exceeds max bytes (%d)", schema.FeedbackMaxBytesV1)
+		return nil, nil
+	}
+	return b, nil
+}
+
+// mergeResultJSON applies the merge patch to fb's resultJson object.
+func mergeResultJSON(fb schema.FeedbackJSONV1, patchRaw string, verr *ValidationError) (json.RawMessage, error) {
+	patch, ok := decodeJSONField("mergeJson", patchRaw, verr)
+	if !ok {
+		return fb.ResultJSON, nil
+	}
+	if _, isObj := patch.(map[string]any); !isObj {
+		verr.add("mergeJson", "expected a json object")
+		return fb.ResultJSON, nil
+	}
+	if len(fb.ResultJSON) == 0 {
+		verr.add("resultJson", "feedback has a string result; --merge-json needs a resultJson object")
+		return fb.ResultJSON, nil
+	}
+	var target any
+	if err := json.Unmarshal(fb.ResultJSON, &target); err != nil {
+		return nil, fmt.Errorf("invalid feedback.json resultJson: %w", err)
+	}
+	if _, isObj := target.(map[string]any); !isObj {
+		verr.add("resultJson", "not a json object; --merge-json needs a resultJson object")
+		return fb.ResultJSON, nil
+	}
+	b, err := canonicalResultJSON(mergePatch(target, patch), verr)
+	if err != nil || b == nil {
+		return fb.ResultJSON, err
+	}
+	return b, nil
+}
+
+// mergePatch is RFC 7386: objects merge recursively, null deletes a key, anything else replaces.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+func requireEvidenceForMode(env trace.Env) (schema.AttemptJSONV1, error) {
+	attemptMeta, err := readAttemptMetadata(env.OutDirAbs)
+	if err != nil {
+		return schema.AttemptJSONV1{}, err
+	}
+	if err := requireNonEmptyTrace(filepath.Join(env.OutDirAbs, artifacts.ToolCallsJSONL)); err != nil {
+		return schema.AttemptJSONV1{}, err
 	}
-	return "", b, nil, nil
+	return attemptMeta, nil
 }
 
 func readAttemptMetadata(attemptDir string) (schema.AttemptJSONV1, error) {
@@ -168,27 +468,35 @@ func fileHasNonWhitespace(r *os.File) bool {
 	}
 }
 
-func enforceSuiteResultShape(env trace.Env, attemptMeta schema.AttemptJSONV1, payload schema.FeedbackJSONV1) error {
-	runDir := filepath.Dir(filepath.Dir(env.OutDirAbs))
+// readRunSuite loads the suite.json snapshot of the attempt's run; nil when the attempt is not part
+// of a run with a suite snapshot.
+func readRunSuite(attemptDir string) (*suite.SuiteFileV1, error) {
+	runDir := filepath.Dir(filepath.Dir(attemptDir))
 	if _, err := os.Stat(filepath.Join(runDir, artifacts.RunJSON)); err != nil {
 		if os.IsNotExist(err) {
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
-	suitePath := filepath.Join(runDir, artifacts.SuiteJSON)
-	b, err := os.ReadFile(suitePath)
+	b, err := os.ReadFile(filepath.Join(runDir, artifacts.SuiteJSON))
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
 	var sf suite.SuiteFileV1
 	if err := json.Unmarshal(b, &sf); err != nil {
-		return fmt.Errorf("invalid suite.json while enforcing feedback shape: %w", err)
+		return nil, fmt.Errorf("invalid suite.json while checking feedback: %w", err)
+	}
+	return &sf, nil
+}
+
+func enforceSuiteResultShape(sf *suite.SuiteFileV1, attemptMeta schema.AttemptJSONV1, payload schema.FeedbackJSONV1) error {
+	if sf == nil {
+		return nil
 	}
-	m := suite.FindMission(sf, attemptMeta.MissionID)
+	m := suite.FindMission(*sf, attemptMeta.MissionID)
 	if m == nil || m.Expects == nil || m.Expects.Result == nil {
 		return nil
 	}
@@ -196,9 +504,9 @@ func enforceSuiteResultShape(env trace.Env, attemptMeta schema.AttemptJSONV1, pa
 	if len(failures) == 0 {
 		return nil
 	}
-	msgs := make([]string, 0, len(failures))
+	var verr ValidationError
 	for _, f := range failures {
-		msgs = append(msgs, f.Code+": "+f.Message)
+		verr.add("resultJson"+f.Pointer, "%s: %s", f.Code, f.Message)
 	}
-	return fmt.Errorf("feedback result shape violates suite expects for mission %q: %s", attemptMeta.MissionID, strings.Join(msgs, "; "))
+	return fmt.Errorf("feedback result shape violates suite expects for mission %q: %w", attemptMeta.MissionID, &verr)
 }
@@ -1,15 +1,23 @@
 package feedback
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
 )
 
 func TestWrite_ResultStringRedactsAndBounds(t *testing.T) {
@@ -142,6 +150,36 @@ func TestWrite_EnforcesSuiteResultShapeWhenConfigured(t *testing.T) {
 	}
 }
 
+func TestWrite_AcceptsSuiteDeclaredDecisionTags(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	runDir := filepath.Join(base, "runs", "20260215-180012Z-09c5a6")
+	outDir := filepath.Join(runDir, "attempts", "001-m-r1")
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		t.Fatalf("mkdir attempt dir: %v", err)
+	}
+	env := trace.Env{RunID: "20260215-180012Z-09c5a6", SuiteID: "s", MissionID: "m", AttemptID: "001-m-r1", OutDirAbs: outDir}
+	writeAttemptJSON(t, outDir, env, "discovery")
+	writeDummyTrace(t, outDir, env)
+	if err := os.WriteFile(filepath.Join(runDir, "run.json"), []byte(`{"schemaVersion":1,"artifactLayoutVersion":1,"runId":"20260215-180012Z-09c5a6","suiteId":"s","createdAt":"2026-02-15T18:00:00Z"}`), 0o644); err != nil {
+		t.Fatalf("write run.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(runDir, "suite.json"), []byte(`{"version":1,"suiteId":"s","decisionTags":[{"name":"captcha"}],"missions":[{"missionId":"m"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite.json: %v", err)
+	}
+
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	var verr *ValidationError
+	err := Write(now, env, WriteOpts{OK: false, Result: "STUCK", DecisionTags: []string{"blocked", "rate_limited"}})
+	if !errors.As(err, &verr) || len(verr.Errors) != 1 || verr.Errors[0].Field != "decisionTags[1]" || !strings.Contains(verr.Errors[0].Message, "captcha") {
+		t.Fatalf("expected undeclared tag error listing suite tags, got %v", err)
+	}
+	if err := Write(now, env, WriteOpts{OK: false, Result: "STUCK", DecisionTags: []string{"blocked", "Captcha"}}); err != nil {
+		t.Fatalf("expected suite-declared tag to be accepted: %v", err)
+	}
+}
+
 func TestWrite_SkipSuiteResultShapeForSyntheticFeedback(t *testing.T) {
 	t.Parallel()
 
@@ -210,3 +248,300 @@ func writeDummyTrace(t *testing.T, outDir string, env trace.Env) {
 		t.Fatalf("write tool.calls.jsonl: %v", err)
 	}
 }
+
+func TestWrite_ReportsFieldErrorsBeforeEvidenceChecks(t *testing.T) {
+	t.Parallel()
+
+	// No attempt.json/trace: field validation must fail first.
+	env := trace.Env{RunID: "r", SuiteID: "s", MissionID: "m", AttemptID: "a", OutDirAbs: t.TempDir()}
+	confidence := 1.5
+	err := Write(time.Now(), env, WriteOpts{
+		OK:             true,
+		ResultJSON:     "{\n  \"a\": 1,\n  \"b\": }",
+		Classification: "vibes",
+		DecisionTags:   []string{"success", "great"},
+		Confidence:     &confidence,
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	fields := map[string]string{}
+	for _, f := range verr.Errors {
+		fields[f.Field] = f.Message
+	}
+	if !strings.Contains(fields["resultJson"], "line 3, column 8") {
+		t.Fatalf("expected resultJson syntax position, got %+v", verr.Errors)
+	}
+	if !strings.Contains(fields["classification"], `"vibes"`) {
+		t.Fatalf("expected classification error, got %+v", verr.Errors)
+	}
+	if !strings.Contains(fields["decisionTags[0]"], `"great"`) || !strings.Contains(fields["decisionTags[0]"], "missing_evidence") {
+		t.Fatalf("expected decisionTags[0] error listing the taxonomy, got %+v", verr.Errors)
+	}
+	if !strings.Contains(fields["confidence"], "0..1") {
+		t.Fatalf("expected confidence range error, got %+v", verr.Errors)
+	}
+}
+
+func TestUpdate_AppendsTagsAndMergesResultJSON(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	env := trace.Env{RunID: "r", SuiteID: "s", MissionID: "m", AttemptID: "a", OutDirAbs: outDir}
+	writeAttemptJSON(t, outDir, env, "discovery")
+	writeDummyTrace(t, outDir, env)
+
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	if err := Update(now, env, UpdateOpts{AppendTags: []string{"blocked"}}); err == nil || !strings.Contains(err.Error(), "missing feedback.json") {
+		t.Fatalf("expected missing feedback.json error, got %v", err)
+	}
+	if err := Write(now, env, WriteOpts{OK: true, ResultJSON: `{"answer":"x","proof":{"a":1,"b":2}}`, DecisionTags: []string{"success"}}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	fail := false
+	later := now.Add(time.Minute)
+	if err := Update(later, env, UpdateOpts{
+		OK:         &fail,
+		AppendTags: []string{"Blocked", "success"},
+		MergeJSON:  `{"proof":{"b":null,"c":3},"extra":true}`,
+	}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	var fb schema.FeedbackJSONV1
+	raw, err := os.ReadFile(filepath.Join(outDir, "feedback.json"))
+	if err != nil {
+		t.Fatalf("read feedback.json: %v", err)
+	}
+	if err := json.Unmarshal(raw, &fb); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if fb.OK || strings.Join(fb.DecisionTags, ",") != "blocked,success" {
+		t.Fatalf("unexpected ok/tags: %+v", fb)
+	}
+	var merged bytes.Buffer
+	if err := json.Compact(&merged, fb.ResultJSON); err != nil {
+		t.Fatalf("compact resultJson: %v", err)
+	}
+	if got := merged.String(); got != `{"answer":"x","extra":true,"proof":{"a":1,"c":3}}` {
+		t.Fatalf("unexpected merged resultJson: %s", got)
+	}
+	if fb.CreatedAt != later.Format(time.RFC3339Nano) {
+		t.Fatalf("expected createdAt to move to the update time, got %q", fb.CreatedAt)
+	}
+	history, err := os.ReadFile(filepath.Join(outDir, "feedback.history.jsonl"))
+	if err != nil {
+		t.Fatalf("read feedback.history.jsonl: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(history)), "\n")
+	var first, last schema.FeedbackRevisionV1
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[0]), &first) != nil || json.Unmarshal([]byte(lines[1]), &last) != nil {
+		t.Fatalf("expected two history revisions, got %q", history)
+	}
+	if first.Revision != 1 || first.Op != "write" || !first.Feedback.OK || last.Revision != 2 || last.Op != "update" || fb.Revision != 2 {
+		t.Fatalf("unexpected history: first=%+v last=%+v canonical revision=%d", first, last, fb.Revision)
+	}
+
+	var verr *ValidationError
+	if err := Update(later, env, UpdateOpts{AppendTags: []string{"nope"}, MergeJSON: `[1]`}); !errors.As(err, &verr) || len(verr.Errors) != 2 {
+		t.Fatalf("expected tag and mergeJson field errors, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outDir, "attempt.finish.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatalf("write attempt.finish.json: %v", err)
+	}
+	if err := Update(later, env, UpdateOpts{AppendTags: []string{"timeout"}}); err == nil || !strings.Contains(err.Error(), "already finalized") {
+		t.Fatalf("expected finalized error, got %v", err)
+	}
+}
+
+func TestWrite_ConcurrentRevisionsStayConsecutiveAndStopAtFinalization(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	env := trace.Env{RunID: "r", SuiteID: "s", MissionID: "m", AttemptID: "a", OutDirAbs: outDir}
+	writeAttemptJSON(t, outDir, env, "discovery")
+	writeDummyTrace(t, outDir, env)
+
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- Write(now, env, WriteOpts{OK: true, Result: fmt.Sprintf("answer-%d", i)})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	history, err := os.ReadFile(filepath.Join(outDir, "feedback.history.jsonl"))
+	if err != nil {
+		t.Fatalf("read feedback.history.jsonl: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(history)), "\n")
+	if len(lines) != writers {
+		t.Fatalf("expected %d revisions, got %q", writers, history)
+	}
+	var last schema.FeedbackRevisionV1
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &last); err != nil || last.Revision != i+1 {
+			t.Fatalf("expected revision %d on line %d, got %q (%v)", i+1, i+1, line, err)
+		}
+	}
+	var fb schema.FeedbackJSONV1
+	raw, err := os.ReadFile(filepath.Join(outDir, "feedback.json"))
+	if err != nil || json.Unmarshal(raw, &fb) != nil {
+		t.Fatalf("read feedback.json: %v", err)
+	}
+	if fb.Revision != writers || fb.Result != last.Feedback.Result {
+		t.Fatalf("expected feedback.json to hold the last revision %+v, got %+v", last.Feedback, fb)
+	}
+
+	if err := os.WriteFile(filepath.Join(outDir, "attempt.finish.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatalf("write attempt.finish.json: %v", err)
+	}
+	if err := Write(now, env, WriteOpts{OK: false, Result: "late"}); err == nil || !strings.Contains(err.Error(), "already finalized") {
+		t.Fatalf("expected finalized error, got %v", err)
+	}
+	if after, _ := os.ReadFile(filepath.Join(outDir, "feedback.json")); !bytes.Equal(after, raw) {
+		t.Fatalf("expected feedback.json unchanged after finalization, got %s", after)
+	}
+}
+
+func TestWrite_SignsFeedbackWithPinnedKey(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	env := trace.Env{RunID: "r", SuiteID: "s", MissionID: "m", AttemptID: "a", OutDirAbs: outDir}
+	writeAttemptJSON(t, outDir, env, "discovery")
+	writeDummyTrace(t, outDir, env)
+	keyPath, keyID := writeSigningKey(t, outDir, "runner.pem")
+	otherPath, _ := writeSigningKey(t, outDir, "other.pem")
+
+	raw, err := os.ReadFile(filepath.Join(outDir, "attempt.json"))
+	if err != nil {
+		t.Fatalf("read attempt.json: %v", err)
+	}
+	var meta schema.AttemptJSONV1
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		t.Fatalf("unmarshal attempt.json: %v", err)
+	}
+	meta.FeedbackKeyID = keyID
+	if b, err := json.Marshal(meta); err != nil || os.WriteFile(filepath.Join(outDir, "attempt.json"), b, 0o644) != nil {
+		t.Fatalf("rewrite attempt.json: %v", err)
+	}
+
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	if err := Write(now, env, WriteOpts{OK: true, Result: "DONE"}); err == nil || !strings.Contains(err.Error(), "requires feedback signed by "+keyID) {
+		t.Fatalf("expected unsigned feedback to be refused, got %v", err)
+	}
+	env.FeedbackSigningKey = otherPath
+	if err := Write(now, env, WriteOpts{OK: true, Result: "DONE"}); err == nil || !strings.Contains(err.Error(), "pins "+keyID) {
+		t.Fatalf("expected other key to be refused, got %v", err)
+	}
+	env.FeedbackSigningKey = keyPath
+	if err := Write(now, env, WriteOpts{OK: true, Result: "DONE"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := Update(now, env, UpdateOpts{AppendTags: []string{"success"}}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	var fb schema.FeedbackJSONV1
+	raw, err = os.ReadFile(filepath.Join(outDir, "feedback.json"))
+	if err != nil || json.Unmarshal(raw, &fb) != nil {
+		t.Fatalf("read feedback.json: %v", err)
+	}
+	if got, err := sigkey.VerifyFeedback(fb); err != nil || got != keyID {
+		t.Fatalf("expected feedback signed by %s, got %q err=%v", keyID, got, err)
+	}
+	fb.Result = "FORGED"
+	if _, err := sigkey.VerifyFeedback(fb); err == nil {
+		t.Fatalf("expected edited feedback to fail verification")
+	}
+}
+
+func TestAppendProgress_NumbersMilestonesUntilFinalized(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	env := trace.Env{RunID: "r", SuiteID: "s", MissionID: "m", AttemptID: "a", OutDirAbs: outDir}
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	if err := AppendProgress(now, env, ProgressOpts{Milestone: "logged in"}); err == nil || !strings.Contains(err.Error(), "missing attempt.json") {
+		t.Fatalf("expected attempt context to be required, got %v", err)
+	}
+	writeAttemptJSON(t, outDir, env, "discovery")
+
+	var verr *ValidationError
+	if err := AppendProgress(now, env, ProgressOpts{Milestone: " ", DataJSON: `{"a":`}); !errors.As(err, &verr) || len(verr.Errors) != 2 {
+		t.Fatalf("expected milestone and dataJson field errors, got %v", err)
+	}
+	if err := AppendProgress(now, env, ProgressOpts{Milestone: "logged in"}); err != nil {
+		t.Fatalf("AppendProgress: %v", err)
+	}
+	if err := AppendProgress(now, env, ProgressOpts{Milestone: "token ghp_ABCDEF1234567890", DataJSON: `{"b":1, "a":2}`}); err != nil {
+		t.Fatalf("AppendProgress: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(outDir, "feedback.progress.jsonl"))
+	if err != nil {
+		t.Fatalf("read feedback.progress.jsonl: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	var first, second schema.FeedbackProgressEventV1
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[0]), &first) != nil || json.Unmarshal([]byte(lines[1]), &second) != nil {
+		t.Fatalf("expected two progress events, got %q", raw)
+	}
+	if first.Seq != 1 || first.Milestone != "logged in" || second.Seq != 2 || string(second.Data) != `{"a":2,"b":1}` {
+		t.Fatalf("unexpected progress events: %+v %+v", first, second)
+	}
+	if second.Milestone != "token [REDACTED:GITHUB_TOKEN]" || len(second.RedactionsApplied) == 0 {
+		t.Fatalf("expected redacted milestone, got %+v", second)
+	}
+
+	if err := os.WriteFile(filepath.Join(outDir, "attempt.finish.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatalf("write attempt.finish.json: %v", err)
+	}
+	if err := AppendProgress(now, env, ProgressOpts{Milestone: "late"}); err == nil || !strings.Contains(err.Error(), "already finalized") {
+		t.Fatalf("expected finalized error, got %v", err)
+	}
+}
+
+func writeSigningKey(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	return path, sigkey.KeyID(pub)
+}
+
+func TestUpdate_MergeJSONRequiresResultJSONObject(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	env := trace.Env{RunID: "r", SuiteID: "s", MissionID: "m", AttemptID: "a", OutDirAbs: outDir}
+	writeAttemptJSON(t, outDir, env, "discovery")
+	writeDummyTrace(t, outDir, env)
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	if err := Write(now, env, WriteOpts{OK: true, Result: "DONE"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	err := Update(now, env, UpdateOpts{MergeJSON: `{"a":1}`})
+	if err == nil || !strings.Contains(err.Error(), "resultJson: feedback has a string result") {
+		t.Fatalf("expected string result error, got %v", err)
+	}
+}
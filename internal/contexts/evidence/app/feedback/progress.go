@@ -0,0 +1,76 @@
+package feedback
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// ProgressOpts is one `zcl feedback progress` milestone.
+type ProgressOpts struct {
+	Milestone string
+	DataJSON  string
+}
+
+// AppendProgress appends a milestone to feedback.progress.jsonl. Unlike feedback it does not need a
+// trace yet, but it is refused once the attempt is finalized.
+func AppendProgress(now time.Time, env trace.Env, opts ProgressOpts) error {
+	var verr ValidationError
+	milestone, applied := redact.Text(strings.TrimSpace(opts.Milestone))
+	switch {
+	case milestone == "":
+		verr.add("milestone", "is required")
+	case len([]byte(milestone)) > schema.FeedbackMilestoneMaxBytesV1:
+		verr.add("milestone", "exceeds max bytes (%d)", schema.FeedbackMilestoneMaxBytesV1)
+	}
+	var data json.RawMessage
+	if strings.TrimSpace(opts.DataJSON) != "" {
+		if v, ok := decodeJSONField("dataJson", opts.DataJSON, &verr); ok {
+			b, err := store.CanonicalJSON(v)
+			if err != nil {
+				return err
+			}
+			if len(b) > schema.NoteDataMaxBytesV1 {
+				verr.add("dataJson", "exceeds max bytes (%d)", schema.NoteDataMaxBytesV1)
+			}
+			data = b
+		}
+	}
+	if err := verr.err(); err != nil {
+		return err
+	}
+
+	if _, err := readAttemptMetadata(env.OutDirAbs); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(env.OutDirAbs, artifacts.AttemptFinishJSON)); err == nil {
+		return fmt.Errorf("attempt already finalized (attempt.finish.json exists); progress can no longer be recorded")
+	}
+	path := filepath.Join(env.OutDirAbs, artifacts.FeedbackProgressJSONL)
+	prev, err := countLines(path)
+	if err != nil {
+		return err
+	}
+	return store.AppendJSONL(path, schema.FeedbackProgressEventV1{
+		V:                 schema.FeedbackProgressSchemaV1,
+		TS:                now.UTC().Format(time.RFC3339Nano),
+		RunID:             env.RunID,
+		SuiteID:           env.SuiteID,
+		MissionID:         env.MissionID,
+		AttemptID:         env.AttemptID,
+		AgentID:           env.AgentID,
+		Seq:               prev + 1,
+		Milestone:         milestone,
+		Data:              data,
+		RedactionsApplied: applied.Names,
+	})
+}
@@ -0,0 +1,205 @@
+package httpproxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// EgressOp is the trace op of an egress violation (tool "http").
+const EgressOp = "egress"
+
+// NormalizeAllowHosts lowercases hosts and drops ports and duplicates. A leading "*." or "."
+// allows every subdomain of the rest.
+func NormalizeAllowHosts(hosts []string) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if strings.HasPrefix(h, "*.") {
+			h = h[1:]
+		}
+		if host, _, err := net.SplitHostPort(h); err == nil {
+			h = host
+		}
+		if h == "" || h == "." || seen[h] {
+			continue
+		}
+		seen[h] = true
+		out = append(out, h)
+	}
+	return out
+}
+
+// HostAllowed reports whether host (port optional) matches a normalized allowlist entry.
+func HostAllowed(allow []string, host string) bool {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(host, ".")
+	for _, a := range allow {
+		if host == a || (strings.HasPrefix(a, ".") && strings.HasSuffix(host, a)) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartEgress starts a forward proxy (plain HTTP and CONNECT) that only lets requests to allow
+// through. Every refused request gets a 403 and one failed http/egress trace event carrying
+// ZCL_E_EGRESS_DENIED; allowed traffic is tunneled without being traced.
+func StartEgress(ctx context.Context, env trace.Env, allow []string) (*Handle, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	e := &egressProxy{
+		env:       env,
+		allow:     NormalizeAllowHosts(allow),
+		transport: &http.Transport{Proxy: nil, DialContext: (&net.Dialer{Timeout: 30 * time.Second}).DialContext},
+	}
+	srv := &http.Server{ReadHeaderTimeout: 10 * time.Second, Handler: e}
+	done := make(chan error, 1)
+	go func() {
+		err := srv.Serve(ln)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			done <- err
+			return
+		}
+		done <- nil
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	return &Handle{
+		ListenAddr: ln.Addr().String(),
+		done:       done,
+		closeFn: func() error {
+			err := srv.Close()
+			e.tunnels.Wait()
+			e.transport.CloseIdleConnections()
+			return err
+		},
+	}, nil
+}
+
+type egressProxy struct {
+	env       trace.Env
+	allow     []string
+	transport *http.Transport
+	tunnels   sync.WaitGroup
+	traceMu   sync.Mutex
+}
+
+func (e *egressProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	target := r.URL.String()
+	if r.Method == http.MethodConnect {
+		target = "https://" + r.Host
+	} else if r.URL.Host == "" {
+		http.Error(w, "egress proxy expects absolute-form requests", http.StatusBadRequest)
+		return
+	} else {
+		host = r.URL.Host
+	}
+	if !HostAllowed(e.allow, host) {
+		e.recordViolation(r.Method, target, host)
+		http.Error(w, "egress to "+host+" blocked by zcl allowlist", http.StatusForbidden)
+		return
+	}
+	if r.Method == http.MethodConnect {
+		e.tunnel(w, r)
+		return
+	}
+	e.forward(w, r)
+}
+
+func (e *egressProxy) forward(w http.ResponseWriter, r *http.Request) {
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Header.Del("Proxy-Connection")
+	out.Header.Del("Proxy-Authorization")
+	resp, err := e.transport.RoundTrip(out)
+	if err != nil {
+		http.Error(w, "upstream error", http.StatusBadGateway)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+	copyResponseHeaders(w, resp.Header)
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+func (e *egressProxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	up, err := net.DialTimeout("tcp", r.Host, 30*time.Second)
+	if err != nil {
+		http.Error(w, "upstream error", http.StatusBadGateway)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		_ = up.Close()
+		http.Error(w, "tunneling not supported", http.StatusInternalServerError)
+		return
+	}
+	client, buf, err := hj.Hijack()
+	if err != nil {
+		_ = up.Close()
+		return
+	}
+	_, _ = client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+	e.tunnels.Add(1)
+	go func() {
+		defer e.tunnels.Done()
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); _, _ = io.Copy(up, buf); closeWrite(up) }()
+		go func() { defer wg.Done(); _, _ = io.Copy(client, up); closeWrite(client) }()
+		wg.Wait()
+		_ = up.Close()
+		_ = client.Close()
+	}()
+}
+
+func closeWrite(c net.Conn) {
+	if tc, ok := c.(*net.TCPConn); ok {
+		_ = tc.CloseWrite()
+	}
+}
+
+func (e *egressProxy) recordViolation(method, target, host string) {
+	in, err := store.CanonicalJSON(map[string]any{"method": method, "url": target})
+	if err != nil {
+		return
+	}
+	en, _ := store.CanonicalJSON(map[string]any{"egress": map[string]any{"host": host, "allowHosts": e.allow}})
+	ev := schema.TraceEventV1{
+		V:          schema.TraceSchemaV1,
+		TS:         time.Now().UTC().Format(time.RFC3339Nano),
+		RunID:      e.env.RunID,
+		SuiteID:    e.env.SuiteID,
+		MissionID:  e.env.MissionID,
+		AttemptID:  e.env.AttemptID,
+		AgentID:    e.env.AgentID,
+		Tool:       "http",
+		Op:         EgressOp,
+		Input:      in,
+		Result:     schema.TraceResultV1{OK: false, Code: codes.EgressDenied},
+		Enrichment: en,
+	}
+	e.traceMu.Lock()
+	defer e.traceMu.Unlock()
+	_ = trace.AppendEvent(e.env, ev)
+}
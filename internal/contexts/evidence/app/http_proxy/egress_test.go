@@ -0,0 +1,91 @@
+package httpproxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
+)
+
+func TestHostAllowed(t *testing.T) {
+	allow := NormalizeAllowHosts([]string{" API.example.com:443 ", "*.cdn.test", "api.example.com"})
+	if len(allow) != 2 || allow[0] != "api.example.com" || allow[1] != ".cdn.test" {
+		t.Fatalf("unexpected normalized allowlist: %v", allow)
+	}
+	for host, want := range map[string]bool{
+		"api.example.com":     true,
+		"api.example.com:443": true,
+		"evil.example.com":    false,
+		"a.cdn.test":          true,
+		"cdn.test":            false,
+		"xcdn.test":           false,
+	} {
+		if got := HostAllowed(allow, host); got != want {
+			t.Fatalf("HostAllowed(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestEgress_ForwardsAllowedAndTracesBlockedHosts(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer up.Close()
+
+	outDir := t.TempDir()
+	env := trace.Env{RunID: "20260215-180012Z-09c5a6", SuiteID: "s", MissionID: "m", AttemptID: "001-m-r1", OutDirAbs: outDir}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	h, err := StartEgress(ctx, env, []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatalf("StartEgress: %v", err)
+	}
+	defer func() { _ = h.Close() }()
+
+	proxyURL, _ := url.Parse("http://" + h.ListenAddr)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}, Timeout: 5 * time.Second}
+	resp, err := client.Get(up.URL + "/x")
+	if err != nil {
+		t.Fatalf("allowed GET: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "ok" {
+		t.Fatalf("allowed GET: status=%d body=%q", resp.StatusCode, body)
+	}
+
+	resp, err = client.Get("http://blocked.invalid/secret")
+	if err != nil {
+		t.Fatalf("blocked GET: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for blocked host, got %d", resp.StatusCode)
+	}
+	if _, err := client.Get("https://blocked.invalid/"); err == nil {
+		t.Fatalf("expected CONNECT to blocked host to fail")
+	}
+
+	raw, err := os.ReadFile(filepath.Join(outDir, "tool.calls.jsonl"))
+	if err != nil {
+		t.Fatalf("read trace: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 violation events (allowed traffic is not traced), got:\n%s", raw)
+	}
+	for _, want := range []string{`"op":"egress"`, codes.EgressDenied, `http://blocked.invalid/secret`, `https://blocked.invalid:443`} {
+		if !strings.Contains(string(raw), want) {
+			t.Fatalf("trace missing %q:\n%s", want, raw)
+		}
+	}
+}
@@ -241,7 +241,7 @@ func (p *proxyServer) newUpstreamRequest(r *http.Request) (upstreamRequestContex
 	reqURL := *p.up
 	reqURL.Path = singleJoiningSlash(p.up.Path, r.URL.Path)
 	reqURL.RawQuery = r.URL.RawQuery
-	_, urlApplied := redact.Text(reqURL.String())
+	_, urlApplied := redact.Preview(reqURL.String())
 	outReq, err := http.NewRequestWithContext(r.Context(), r.Method, reqURL.String(), reqBody)
 	if err != nil {
 		return upstreamRequestContext{}, err
@@ -285,7 +285,7 @@ func (p *proxyServer) streamResponse(w http.ResponseWriter, resp *http.Response)
 	_, _ = io.Copy(w, tee)
 
 	prev, total, trunc := cap.snapshot()
-	prevRed, prevApplied := redact.Text(prev)
+	prevRed, prevApplied := redact.Preview(prev)
 	prevRed, capped := capStringBytes(prevRed, p.maxPreviewBytes)
 	return streamedResponse{
 		preview:           prevRed,
@@ -339,7 +339,7 @@ func capStringBytes(s string, maxBytes int) (string, bool) {
 }
 
 func writeHTTPEvent(start time.Time, env trace.Env, tracePath string, method string, rawURL string, reqBytes int64, respBytes int64, outPreview string, outTruncated bool, status *int, maxPreviewBytes int, callErr error, redactions []string) {
-	urlRed, _ := redact.Text(rawURL)
+	urlRed, _ := redact.Preview(rawURL)
 
 	inputAny := map[string]any{
 		"method": method,
@@ -409,5 +409,5 @@ func writeHTTPEvent(start time.Time, env trace.Env, tracePath string, method str
 	if enRaw != nil {
 		ev.Enrichment = enRaw
 	}
-	_ = store.AppendJSONL(tracePath, ev)
+	_ = trace.AppendEvent(env, ev)
 }
@@ -17,6 +17,8 @@ import (
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/vcr"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
@@ -27,6 +29,9 @@ type reqInfo struct {
 	input          json.RawMessage
 	inputTruncated bool
 	wait           chan struct{}
+
+	vcrKey   string
+	vcrInput json.RawMessage
 }
 
 type boundedCapture struct {
@@ -74,6 +79,13 @@ type Options struct {
 	IdleTimeoutMs      int64
 	ShutdownOnComplete bool
 	SequentialRequests bool
+	// ToolCallAllowed, when set, is consulted for every tools/call request. Refused calls are
+	// answered with a JSON-RPC error and recorded in trace without reaching the server.
+	ToolCallAllowed func(toolName string) bool
+	// VCR, when set, records tools/call responses to the attempt cassette (record mode) or answers
+	// tools/call from the replay source without reaching the server (replay mode). Other methods
+	// (initialize, tools/list, ...) always reach the server.
+	VCR *vcr.Session
 }
 
 type proxySession struct {
@@ -87,6 +99,9 @@ type proxyRuntimeState struct {
 	mu       sync.Mutex
 	inflight map[string]reqInfo
 
+	clientMu sync.Mutex
+	traceErr error
+
 	activityMu    sync.Mutex
 	lastActivity  time.Time
 	idleTimedOut  atomic.Bool
@@ -132,10 +147,13 @@ func ProxyWithOptions(ctx context.Context, env trace.Env, serverArgv []string, c
 	}
 
 	errCap := startStderrCapture(session.serverErr, maxPreviewBytes)
-	reqDone := startRequestForwarder(proxyCtx, clientIn, session.serverIn, opts, state)
+	reqDone := startRequestForwarder(proxyCtx, clientIn, session.serverIn, clientOut, tracePath, env, opts, state)
 	if err := processServerResponses(proxyCtx, session.serverOut, clientOut, tracePath, env, maxPreviewBytes, opts, state, reqDone, cancelProxy); err != nil {
 		return err
 	}
+	if err := state.firstTraceErr(); err != nil {
+		return err
+	}
 
 	waitErr := waitForProxyExit(proxyCtx, reqDone, session.cmd)
 	waited = true
@@ -233,6 +251,26 @@ func (s *proxyRuntimeState) inflightCount() int {
 	return len(s.inflight)
 }
 
+func (s *proxyRuntimeState) writeClient(clientOut io.Writer, line []byte) {
+	s.clientMu.Lock()
+	_, _ = clientOut.Write(append(line, '\n'))
+	s.clientMu.Unlock()
+}
+
+func (s *proxyRuntimeState) setTraceErr(err error) {
+	s.mu.Lock()
+	if s.traceErr == nil {
+		s.traceErr = err
+	}
+	s.mu.Unlock()
+}
+
+func (s *proxyRuntimeState) firstTraceErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.traceErr
+}
+
 func (s *proxyRuntimeState) incToolCallsSeen() int64 {
 	s.toolCallsSeen++
 	return s.toolCallsSeen
@@ -286,7 +324,7 @@ func appendSpawnTraceEvent(tracePath string, env trace.Env, redServerArgv, argvA
 		},
 		RedactionsApplied: argvApplied,
 	}
-	return store.AppendJSONL(tracePath, ev)
+	return trace.AppendChained(tracePath, ev)
 }
 
 func startStderrCapture(serverErr io.Reader, maxPreviewBytes int) *boundedCapture {
@@ -296,7 +334,16 @@ func startStderrCapture(serverErr io.Reader, maxPreviewBytes int) *boundedCaptur
 	return errCap
 }
 
-func startRequestForwarder(proxyCtx context.Context, clientIn io.Reader, serverIn io.WriteCloser, opts Options, state *proxyRuntimeState) <-chan struct{} {
+func startRequestForwarder(
+	proxyCtx context.Context,
+	clientIn io.Reader,
+	serverIn io.WriteCloser,
+	clientOut io.Writer,
+	tracePath string,
+	env trace.Env,
+	opts Options,
+	state *proxyRuntimeState,
+) <-chan struct{} {
 	reqDone := make(chan struct{})
 	go func() {
 		defer close(reqDone)
@@ -308,6 +355,12 @@ func startRequestForwarder(proxyCtx context.Context, clientIn io.Reader, serverI
 				continue
 			}
 			state.touch()
+			if refuseDisallowedToolCall(line, clientOut, tracePath, env, opts, state) {
+				continue
+			}
+			if replayToolCallFromVCR(line, clientOut, tracePath, env, opts, state) {
+				continue
+			}
 			wait := trackInflightRequest(line, opts, state)
 			_, _ = serverIn.Write(append(line, '\n'))
 			if wait == nil {
@@ -324,6 +377,149 @@ func startRequestForwarder(proxyCtx context.Context, clientIn io.Reader, serverI
 	return reqDone
 }
 
+// refuseDisallowedToolCall answers policy-denied tools/call requests locally so the server never sees them.
+func refuseDisallowedToolCall(line []byte, clientOut io.Writer, tracePath string, env trace.Env, opts Options, state *proxyRuntimeState) bool {
+	if opts.ToolCallAllowed == nil {
+		return false
+	}
+	var msg map[string]any
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return false
+	}
+	if method, _ := msg["method"].(string); method != "tools/call" {
+		return false
+	}
+	params, _ := msg["params"].(map[string]any)
+	name, _ := params["name"].(string)
+	if opts.ToolCallAllowed(name) {
+		return false
+	}
+	message := fmt.Sprintf("tool policy: refusing mcp tool %q", name)
+	if id, ok := msg["id"]; ok && id != nil {
+		resp, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"error": map[string]any{
+				"code":    -32001,
+				"message": message,
+			},
+		})
+		state.writeClient(clientOut, resp)
+	}
+	inputRaw, truncated := boundedJSONRPCInput(msg, schema.ToolInputMaxBytesV1)
+	input, inApplied, inCapped := redactTraceInput(inputRaw)
+	ev := schema.TraceEventV1{
+		V:         schema.TraceSchemaV1,
+		TS:        time.Now().UTC().Format(time.RFC3339Nano),
+		RunID:     env.RunID,
+		SuiteID:   env.SuiteID,
+		MissionID: env.MissionID,
+		AttemptID: env.AttemptID,
+		AgentID:   env.AgentID,
+		Tool:      "mcp",
+		Op:        "tools/call",
+		Input:     input,
+		Result: schema.TraceResultV1{
+			OK:         false,
+			Code:       codes.ToolPolicyDenied,
+			DurationMs: 0,
+		},
+		IO: schema.TraceIOV1{
+			ErrBytes:   int64(len(message)),
+			ErrPreview: message,
+		},
+		RedactionsApplied: inApplied,
+		Warnings:          responseWarnings(truncated, inCapped, false),
+		Integrity: &schema.TraceIntegrityV1{
+			Truncated: truncated || inCapped,
+		},
+	}
+	if err := trace.AppendChained(tracePath, ev); err != nil {
+		state.setTraceErr(err)
+	}
+	return true
+}
+
+// replayToolCallFromVCR answers tools/call requests from the VCR replay source. Misses are answered
+// with a JSON-RPC error (ZCL_E_VCR_MISS in trace) rather than falling through to the live tool.
+func replayToolCallFromVCR(line []byte, clientOut io.Writer, tracePath string, env trace.Env, opts Options, state *proxyRuntimeState) bool {
+	if opts.VCR == nil || opts.VCR.Mode != vcr.ModeReplay {
+		return false
+	}
+	var msg map[string]any
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return false
+	}
+	if method, _ := msg["method"].(string); method != "tools/call" {
+		return false
+	}
+	start := time.Now()
+	vcrInput, _ := vcr.RedactedJSON(msg["params"])
+	e, hit, err := opts.VCR.Replay(start.UTC().Format(time.RFC3339Nano), vcr.Key("mcp", "tools/call", vcrInput))
+	if err != nil {
+		state.setTraceErr(err)
+		return true
+	}
+	resp := map[string]any{"jsonrpc": "2.0", "id": msg["id"]}
+	switch {
+	case !hit:
+		params, _ := msg["params"].(map[string]any)
+		name, _ := params["name"].(string)
+		resp["error"] = map[string]any{
+			"code":    -32002,
+			"message": fmt.Sprintf("vcr replay: no recording for mcp tool %q", name),
+		}
+	case len(e.Error) > 0:
+		resp["error"] = e.Error
+	default:
+		resp["result"] = e.Result
+	}
+	out, _ := json.Marshal(resp)
+	if id, ok := msg["id"]; ok && id != nil {
+		state.writeClient(clientOut, out)
+	}
+
+	var traced map[string]any
+	_ = json.Unmarshal(out, &traced)
+	inputRaw, truncated := boundedJSONRPCInput(msg, schema.ToolInputMaxBytesV1)
+	ev, _ := buildResponseTraceEvent(env, trackedResponse{
+		info: reqInfo{start: start, method: "tools/call", input: inputRaw, inputTruncated: truncated},
+		msg:  traced,
+	}, out, opts.MaxPreviewBytes)
+	if hit {
+		ev.Warnings = append(ev.Warnings, schema.TraceWarningV1{Code: "ZCL_W_VCR_REPLAYED", Message: "served from vcr cassette; the tool was not executed"})
+	} else {
+		ev.Result.Code = "ZCL_E_VCR_MISS"
+	}
+	if err := trace.AppendChained(tracePath, ev); err != nil {
+		state.setTraceErr(err)
+	}
+	return true
+}
+
+// recordToolCallToVCR appends a live tools/call response to the attempt cassette (record mode).
+func recordToolCallToVCR(opts Options, resp trackedResponse) error {
+	if opts.VCR == nil || resp.info.vcrKey == "" {
+		return nil
+	}
+	e := schema.ToolCassetteEntryV1{
+		TS:         resp.info.start.UTC().Format(time.RFC3339Nano),
+		Key:        resp.info.vcrKey,
+		Tool:       "mcp",
+		Op:         "tools/call",
+		Input:      resp.info.vcrInput,
+		DurationMs: time.Since(resp.info.start).Milliseconds(),
+	}
+	var applied []string
+	if errObj, ok := resp.msg["error"]; ok && errObj != nil {
+		e.Error, applied = vcr.RedactedJSON(errObj)
+	} else {
+		e.Result, applied = vcr.RedactedJSON(resp.msg["result"])
+	}
+	e.RedactionsApplied = unionStrings(applied)
+	return opts.VCR.Record(e)
+}
+
 func trackInflightRequest(line []byte, opts Options, state *proxyRuntimeState) chan struct{} {
 	var msg map[string]any
 	if err := json.Unmarshal(line, &msg); err != nil {
@@ -339,13 +535,18 @@ func trackInflightRequest(line []byte, opts Options, state *proxyRuntimeState) c
 	if opts.SequentialRequests {
 		wait = make(chan struct{})
 	}
-	state.setInflight(id, reqInfo{
+	info := reqInfo{
 		start:          time.Now(),
 		method:         method,
 		input:          inputRaw,
 		inputTruncated: truncated,
 		wait:           wait,
-	})
+	}
+	if opts.VCR != nil && opts.VCR.Mode == vcr.ModeRecord && method == "tools/call" {
+		info.vcrInput, _ = vcr.RedactedJSON(msg["params"])
+		info.vcrKey = vcr.Key("mcp", "tools/call", info.vcrInput)
+	}
+	state.setInflight(id, info)
 	return wait
 }
 
@@ -391,14 +592,17 @@ func handleServerResponseLine(
 		return false, nil
 	}
 	state.touch()
-	_, _ = clientOut.Write(append(line, '\n'))
+	state.writeClient(clientOut, line)
 
 	resp, ok := parseTrackedResponse(line, state)
 	if !ok {
 		return false, nil
 	}
 	ev, op := buildResponseTraceEvent(env, resp, line, maxPreviewBytes)
-	if err := store.AppendJSONL(tracePath, ev); err != nil {
+	if err := trace.AppendEvent(env, ev); err != nil {
+		return false, err
+	}
+	if err := recordToolCallToVCR(opts, resp); err != nil {
 		return false, err
 	}
 	if shouldStopAfterResponse(op, opts, state, reqDone, cancelProxy) {
@@ -503,7 +707,7 @@ func withMCPMethod(enrichment any, method string) any {
 }
 
 func redactTraceInput(input json.RawMessage) ([]byte, []string, bool) {
-	inStr, inApplied := redact.Text(string(input))
+	inStr, inApplied := redact.Preview(string(input))
 	out := []byte(inStr)
 	if len(out) <= schema.ToolInputMaxBytesV1 {
 		return out, inApplied.Names, false
@@ -519,7 +723,7 @@ func redactTraceOutput(line []byte, maxPreviewBytes int) (string, []string, bool
 		outPreview = outPreview[:maxPreviewBytes]
 		outTruncated = true
 	}
-	outStr, a := redact.Text(string(outPreview))
+	outStr, a := redact.Preview(string(outPreview))
 	outStr, outCapped := capStringBytes(outStr, maxPreviewBytes)
 	return outStr, a.Names, outTruncated, outCapped
 }
@@ -643,7 +847,7 @@ func appendTimeoutTraceEvent(tracePath string, env trace.Env, redServerArgv, arg
 			Message: msg,
 		}},
 	}
-	return store.AppendJSONL(tracePath, ev)
+	return trace.AppendChained(tracePath, ev)
 }
 
 func appendMaxToolCallsTraceEvent(tracePath string, env trace.Env, redServerArgv, argvApplied []string, maxCallsHit bool) error {
@@ -678,7 +882,7 @@ func appendMaxToolCallsTraceEvent(tracePath string, env trace.Env, redServerArgv
 			Message: "mcp max tool calls reached",
 		}},
 	}
-	return store.AppendJSONL(tracePath, ev)
+	return trace.AppendChained(tracePath, ev)
 }
 
 func appendStderrTraceEvent(
@@ -692,7 +896,7 @@ func appendStderrTraceEvent(
 	if total == 0 && prev == "" {
 		return nil
 	}
-	prevRed, applied := redact.Text(prev)
+	prevRed, applied := redact.Preview(prev)
 	prevRed, capped := capStringBytes(prevRed, maxPreviewBytes)
 	in := map[string]any{"argv": redServerArgv}
 	inRaw, _ := store.CanonicalJSON(in)
@@ -724,7 +928,7 @@ func appendStderrTraceEvent(
 	if trunc || capped {
 		ev.Warnings = []schema.TraceWarningV1{{Code: "ZCL_W_STDERR_TRUNCATED", Message: "mcp server stderr preview truncated to fit bounds"}}
 	}
-	return store.AppendJSONL(tracePath, ev)
+	return trace.AppendChained(tracePath, ev)
 }
 
 func finalizeProxyResult(waitErr error, state *proxyRuntimeState) error {
@@ -859,7 +1063,7 @@ func redactStrings(in []string) ([]string, []string) {
 	out := make([]string, 0, len(in))
 	var applied []string
 	for _, s := range in {
-		red, a := redact.Text(s)
+		red, a := redact.Preview(s)
 		out = append(out, red)
 		applied = append(applied, a.Names...)
 	}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/vcr"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
 )
 
@@ -192,6 +193,141 @@ func TestProxyWithOptions_MaxToolCallsStopsProxy(t *testing.T) {
 	}
 }
 
+func TestProxyWithOptions_ToolCallAllowedRefusesDisallowedCalls(t *testing.T) {
+	outDir := t.TempDir()
+	env := trace.Env{
+		RunID:     "20260215-180012Z-09c5a6",
+		SuiteID:   "heftiweb-smoke",
+		MissionID: "latest-blog-title",
+		AttemptID: "001-latest-blog-title-r1",
+		OutDirAbs: outDir,
+	}
+
+	reqs := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"shell","arguments":{"cmd":"rm -rf /"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"text":"ok"}}}`,
+	}, "\n") + "\n"
+
+	t.Setenv("GO_WANT_MCP_SERVER_HELPER", "1")
+
+	var clientOut bytes.Buffer
+	serverArgv := []string{os.Args[0], "-test.run=TestMCPServerHelper"}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := ProxyWithOptions(ctx, env, serverArgv, bytes.NewBufferString(reqs), &clientOut, Options{
+		MaxPreviewBytes:    16 * 1024,
+		SequentialRequests: true,
+		ToolCallAllowed:    func(name string) bool { return name == "echo" },
+	}); err != nil {
+		t.Fatalf("ProxyWithOptions: %v", err)
+	}
+	if got := clientOut.String(); !strings.Contains(got, `"id":2`) || !strings.Contains(got, "tool policy") {
+		t.Fatalf("expected local policy error response for id=2, got: %q", got)
+	}
+
+	events := readAllTraceEvents(t, filepath.Join(outDir, "tool.calls.jsonl"))
+	denied, allowed := 0, 0
+	for _, ev := range events {
+		if ev.Op != "tools/call" {
+			continue
+		}
+		if ev.Result.Code == "ZCL_E_TOOL_POLICY_DENIED" {
+			denied++
+			if ev.Result.OK || !strings.Contains(string(ev.Input), "shell") {
+				t.Fatalf("unexpected denied event: %+v", ev)
+			}
+			continue
+		}
+		if ev.Result.OK {
+			allowed++
+		}
+	}
+	if denied != 1 || allowed != 1 {
+		t.Fatalf("expected one denied and one allowed tools/call, got denied=%d allowed=%d events=%+v", denied, allowed, events)
+	}
+}
+
+func TestProxyWithOptions_VCRRecordsThenReplaysToolCalls(t *testing.T) {
+	t.Setenv("GO_WANT_MCP_SERVER_HELPER", "1")
+	serverArgv := []string{os.Args[0], "-test.run=TestMCPServerHelper"}
+	newEnv := func(outDir string) trace.Env {
+		return trace.Env{
+			RunID:     "20260215-180012Z-09c5a6",
+			SuiteID:   "heftiweb-smoke",
+			MissionID: "latest-blog-title",
+			AttemptID: "001-latest-blog-title-r1",
+			OutDirAbs: outDir,
+		}
+	}
+	proxy := func(outDir string, session *vcr.Session, reqs ...string) string {
+		t.Helper()
+		var clientOut bytes.Buffer
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := ProxyWithOptions(ctx, newEnv(outDir), serverArgv, bytes.NewBufferString(strings.Join(reqs, "\n")+"\n"), &clientOut, Options{
+			MaxPreviewBytes:    16 * 1024,
+			SequentialRequests: true,
+			VCR:                session,
+		}); err != nil {
+			t.Fatalf("ProxyWithOptions: %v", err)
+		}
+		return clientOut.String()
+	}
+
+	recDir := t.TempDir()
+	rec, err := vcr.Open(recDir, "latest-blog-title", vcr.ModeRecord, "")
+	if err != nil {
+		t.Fatalf("vcr.Open record: %v", err)
+	}
+	proxy(recDir, rec,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"text":"a"}}}`,
+	)
+	cassettePath := filepath.Join(recDir, "tool.cassette.jsonl")
+	recorded, err := os.ReadFile(cassettePath)
+	if err != nil || !strings.Contains(string(recorded), `"text":"a"`) {
+		t.Fatalf("expected recorded tools/call result, err=%v cassette=%s", err, recorded)
+	}
+	// Prove replay serves the cassette rather than the live server.
+	if err := os.WriteFile(cassettePath, []byte(strings.Replace(string(recorded), `"text":"a","type"`, `"text":"from-cassette","type"`, 1)), 0o644); err != nil {
+		t.Fatalf("rewrite cassette: %v", err)
+	}
+
+	repDir := t.TempDir()
+	rep, err := vcr.Open(repDir, "latest-blog-title", vcr.ModeReplay, recDir)
+	if err != nil {
+		t.Fatalf("vcr.Open replay: %v", err)
+	}
+	got := proxy(repDir, rep,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"text":"a"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"text":"b"}}}`,
+	)
+	if !strings.Contains(got, "from-cassette") || !strings.Contains(got, "vcr replay: no recording") {
+		t.Fatalf("expected replayed result and miss error, got: %q", got)
+	}
+	replayed, missed := 0, 0
+	for _, ev := range readAllTraceEvents(t, filepath.Join(repDir, "tool.calls.jsonl")) {
+		if ev.Op != "tools/call" {
+			continue
+		}
+		if ev.Result.Code == "ZCL_E_VCR_MISS" {
+			missed++
+			continue
+		}
+		for _, w := range ev.Warnings {
+			if w.Code == "ZCL_W_VCR_REPLAYED" && ev.Result.OK {
+				replayed++
+			}
+		}
+	}
+	if replayed != 1 || missed != 1 {
+		t.Fatalf("expected one replayed and one missed tools/call, got replayed=%d missed=%d", replayed, missed)
+	}
+}
+
 func TestProxyWithOptions_IdleTimeoutStopsProxy(t *testing.T) {
 	outDir := t.TempDir()
 	env := trace.Env{
@@ -0,0 +1,312 @@
+package purge
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// DefaultReplacement is written in place of every purged match.
+const DefaultReplacement = "[PURGED]"
+
+type Opts struct {
+	Pattern     string
+	Replacement string
+	Reason      string
+	// Confirm rewrites the artifacts and appends the purge record; without it Run only previews.
+	Confirm bool
+}
+
+type Result struct {
+	OK         bool                 `json:"ok"`
+	Confirmed  bool                 `json:"confirmed"`
+	AttemptDir string               `json:"attemptDir"`
+	Record     schema.PurgeRecordV1 `json:"record"`
+	Skipped    []string             `json:"skipped,omitempty"` // binary or encrypted files (relative paths)
+}
+
+type rewrite struct {
+	rel     string
+	path    string
+	gz      bool
+	matches int
+	before  []byte
+	after   []byte
+}
+
+var (
+	rePrevHash       = regexp.MustCompile(`"prevHash":"[0-9a-f]*"`)
+	reTraceChainHead = regexp.MustCompile(`"traceChainHead":\s*"[0-9a-f]*"`)
+)
+
+// Run removes every match of opts.Pattern from the stored artifacts of one attempt. JSON and JSONL
+// artifacts must stay parseable, a rewritten tool.calls.jsonl is re-chained (and the report's
+// traceChainHead moved with it), and each confirmed purge appends a record to purge.jsonl.
+func Run(now time.Time, attemptDir string, opts Opts) (Result, error) {
+	reason := strings.TrimSpace(opts.Reason)
+	if reason == "" {
+		return Result{}, fmt.Errorf("missing --reason")
+	}
+	if strings.TrimSpace(opts.Pattern) == "" {
+		return Result{}, fmt.Errorf("missing --pattern")
+	}
+	re, err := regexp.Compile(opts.Pattern)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid --pattern: %w", err)
+	}
+	if re.MatchString("") {
+		return Result{}, fmt.Errorf("--pattern must not match the empty string")
+	}
+	repl := opts.Replacement
+	if repl == "" {
+		repl = DefaultReplacement
+	}
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.AttemptJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Result{}, fmt.Errorf("missing attempt.json in %s", attemptDir)
+		}
+		return Result{}, err
+	}
+	var a schema.AttemptJSONV1
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return Result{}, fmt.Errorf("invalid attempt.json: %w", err)
+	}
+
+	res := Result{AttemptDir: attemptDir}
+	rewrites, err := collect(attemptDir, re, repl, &res)
+	if err != nil {
+		return Result{}, err
+	}
+	rechained, err := rechain(attemptDir, rewrites)
+	if err != nil {
+		return Result{}, err
+	}
+	if rechained.report != nil {
+		rewrites = append(rewrites, rechained.report)
+	}
+
+	rec := schema.PurgeRecordV1{
+		V:              schema.PurgeSchemaV1,
+		RunID:          a.RunID,
+		AttemptID:      a.AttemptID,
+		Reason:         reason,
+		PatternSHA256:  sha256Hex([]byte(opts.Pattern)),
+		Replacement:    repl,
+		Files:          []schema.PurgeFileV1{},
+		TraceRechained: rechained.trace,
+		PurgedAt:       now.UTC().Format(time.RFC3339Nano),
+	}
+	sort.Slice(rewrites, func(i, j int) bool { return rewrites[i].rel < rewrites[j].rel })
+	for _, rw := range rewrites {
+		rec.Files = append(rec.Files, schema.PurgeFileV1{
+			Path:         rw.rel,
+			Matches:      rw.matches,
+			SHA256Before: sha256Hex(rw.before),
+			SHA256After:  sha256Hex(rw.after),
+		})
+		rec.Matches += rw.matches
+	}
+	res.Record = rec
+	res.OK = true
+	if !opts.Confirm || rec.Matches == 0 {
+		return res, nil
+	}
+	for _, rw := range rewrites {
+		if err := writeBack(rw); err != nil {
+			return res, err
+		}
+	}
+	if err := store.AppendJSONL(filepath.Join(attemptDir, artifacts.PurgeJSONL), rec); err != nil {
+		return res, err
+	}
+	res.Confirmed = true
+	return res, nil
+}
+
+// collect plans every rewrite before anything is written, so a pattern that would corrupt a JSON
+// artifact aborts the purge without touching the attempt.
+func collect(attemptDir string, re *regexp.Regexp, repl string, res *Result) ([]*rewrite, error) {
+	var out []*rewrite
+	err := filepath.WalkDir(attemptDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(attemptDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == artifacts.PurgeJSONL {
+			return nil
+		}
+		if strings.HasSuffix(rel, store.EncryptedSuffix) {
+			res.Skipped = append(res.Skipped, rel)
+			return nil
+		}
+		gz := strings.HasSuffix(rel, store.GzipSuffix)
+		var data []byte
+		if gz {
+			data, err = store.ReadArtifactFile(path)
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return err
+		}
+		if isBinary(data) {
+			res.Skipped = append(res.Skipped, rel)
+			return nil
+		}
+		n := len(re.FindAllIndex(data, -1))
+		if n == 0 {
+			return nil
+		}
+		after := re.ReplaceAllLiteral(data, []byte(repl))
+		if err := checkStructured(strings.TrimSuffix(rel, store.GzipSuffix), after); err != nil {
+			return err
+		}
+		out = append(out, &rewrite{rel: rel, path: path, gz: gz, matches: n, before: data, after: after})
+		return nil
+	})
+	return out, err
+}
+
+func isBinary(data []byte) bool {
+	sniff := data
+	if len(sniff) > 8000 {
+		sniff = sniff[:8000]
+	}
+	return bytes.IndexByte(sniff, 0) >= 0
+}
+
+func checkStructured(name string, b []byte) error {
+	switch {
+	case strings.HasSuffix(name, ".json"):
+		if !json.Valid(b) {
+			return fmt.Errorf("purge would leave %s unparseable; narrow --pattern", name)
+		}
+	case strings.HasSuffix(name, ".jsonl"):
+		for i, line := range bytes.Split(b, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) > 0 && !json.Valid(line) {
+				return fmt.Errorf("purge would leave %s line %d unparseable; narrow --pattern", name, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+type rechainResult struct {
+	trace  bool
+	report *rewrite
+}
+
+// rechain re-links a rewritten tool.calls.jsonl and moves attempt.report.json's traceChainHead to
+// the new head; the purge record keeps both hashes, so the edit stays accounted for.
+func rechain(attemptDir string, rewrites []*rewrite) (rechainResult, error) {
+	var trace, report *rewrite
+	for _, rw := range rewrites {
+		switch strings.TrimSuffix(rw.rel, store.GzipSuffix) {
+		case artifacts.ToolCallsJSONL:
+			trace = rw
+		case artifacts.AttemptReportJSON:
+			report = rw
+		}
+	}
+	if trace == nil {
+		return rechainResult{}, nil
+	}
+	var head string
+	trace.after, head = relinkTrace(trace.after)
+	out := rechainResult{trace: true}
+	if head == "" {
+		return out, nil
+	}
+	if report == nil {
+		path := filepath.Join(attemptDir, artifacts.AttemptReportJSON)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return out, nil
+			}
+			return out, err
+		}
+		report = &rewrite{rel: artifacts.AttemptReportJSON, path: path, before: data, after: data}
+		out.report = report
+	}
+	report.after = reTraceChainHead.ReplaceAllLiteral(report.after, []byte(`"traceChainHead": "`+head+`"`))
+	if out.report != nil && bytes.Equal(report.before, report.after) {
+		out.report = nil
+	}
+	return out, nil
+}
+
+// relinkTrace recomputes every prevHash the way validate walks the chain and returns the new head.
+func relinkTrace(b []byte) ([]byte, string) {
+	lines := bytes.Split(b, []byte("\n"))
+	prevHash := ""
+	var prevLine []byte
+	for i, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if loc := rePrevHash.FindIndex(line); loc != nil {
+			want := schema.TraceChainGenesisV1
+			if prevLine != nil {
+				want = schema.TraceChainLinkV1(prevHash, prevLine)
+			}
+			next := append([]byte{}, line[:loc[0]]...)
+			next = append(next, `"prevHash":"`+want+`"`...)
+			line = append(next, line[loc[1]:]...)
+			prevHash = want
+		} else {
+			prevHash = ""
+		}
+		lines[i] = line
+		prevLine = line
+	}
+	if prevHash == "" || prevLine == nil {
+		return bytes.Join(lines, []byte("\n")), ""
+	}
+	return bytes.Join(lines, []byte("\n")), schema.TraceChainLinkV1(prevHash, prevLine)
+}
+
+// writeBack replaces the artifact atomically; a fresh inode also detaches it from any shared CAS blob.
+func writeBack(rw *rewrite) error {
+	data := rw.after
+	if rw.gz {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+	return store.WriteFileAtomic(rw.path, data)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,65 @@
+package purge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+func writeAttempt(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files["attempt.json"] = `{"schemaVersion":1,"runId":"20260222-120000Z-abc123","attemptId":"001-m1-r1"}`
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestRun_RewritesCompactedArtifactsAndSkipsBinary(t *testing.T) {
+	dir := writeAttempt(t, map[string]string{
+		"runner.stdout.log": "user alice@example.com logged in\n",
+		"blob.bin":          "alice@example.com\x00",
+	})
+	if _, _, err := store.GzipFile(filepath.Join(dir, "runner.stdout.log")); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	now := time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC)
+	res, err := Run(now, dir, Opts{Pattern: `alice@example\.com`, Reason: "erasure request", Confirm: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !res.Confirmed || res.Record.Matches != 1 || len(res.Record.Files) != 1 || res.Record.Files[0].Path != "runner.stdout.log.gz" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if len(res.Skipped) != 1 || res.Skipped[0] != "blob.bin" {
+		t.Fatalf("expected binary file to be skipped, got %v", res.Skipped)
+	}
+	got, err := store.ReadArtifactFile(filepath.Join(dir, "runner.stdout.log.gz"))
+	if err != nil || string(got) != "user [PURGED] logged in\n" {
+		t.Fatalf("unexpected purged log %q err=%v", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "purge.jsonl")); err != nil {
+		t.Fatalf("expected purge.jsonl: %v", err)
+	}
+}
+
+func TestRun_RefusesToCorruptJSON(t *testing.T) {
+	dir := writeAttempt(t, map[string]string{"feedback.json": `{"ok":true,"result":"alice"}`})
+	_, err := Run(time.Now(), dir, Opts{Pattern: `"result":"alice"`, Replacement: "x", Reason: "r", Confirm: true})
+	if err == nil || !strings.Contains(err.Error(), "feedback.json") {
+		t.Fatalf("expected unparseable JSON to abort, got %v", err)
+	}
+	if raw, _ := os.ReadFile(filepath.Join(dir, "feedback.json")); string(raw) != `{"ok":true,"result":"alice"}` {
+		t.Fatalf("aborted purge must not write, got %s", raw)
+	}
+	if _, err := Run(time.Now(), dir, Opts{Pattern: `a*`, Reason: "r"}); err == nil {
+		t.Fatalf("expected empty-matching pattern to be rejected")
+	}
+}
@@ -0,0 +1,134 @@
+package redact
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// High-entropy detection masks credentials that have no known prefix. A base64-like run must mix
+// upper, lower and digits, switch character class often (identifiers, paths and ids do not), keep
+// a long stretch without '-' or '_' (joined ids such as <runId>-<attemptId> are short segments)
+// and carry enough Shannon entropy; '/' splits candidates so file paths are never masked. Bare hex
+// is left alone because digests are all over the evidence; hex is only flagged as the value of a
+// secret-looking key.
+const (
+	entropyRuleName    = "high_entropy"
+	entropyReplacement = "[REDACTED:HIGH_ENTROPY]"
+
+	minBase64EntropyBits = 3.8
+	minBase64ClassSwitch = 0.4
+	minBase64Segment     = 16
+	minHexEntropyBits    = 3.0
+)
+
+var (
+	reBase64Candidate = regexp.MustCompile(`[A-Za-z0-9+_-]{24,}={0,2}`)
+	reHexSecretValue  = regexp.MustCompile(`(?i)(?:secret|token|api[_-]?key|password|passwd|credential|auth)[a-z0-9_-]*["']?\s*[:=]\s*["']?([0-9a-f]{32,})\b`)
+)
+
+// entropyLocs returns the byte spans of high-entropy values in s, ordered by offset.
+func entropyLocs(s string) [][]int {
+	var out [][]int
+	for _, loc := range reBase64Candidate.FindAllStringIndex(s, -1) {
+		if looksRandomBase64(trimPadding(s[loc[0]:loc[1]])) {
+			out = append(out, loc)
+		}
+	}
+	for _, m := range reHexSecretValue.FindAllStringSubmatchIndex(s, -1) {
+		loc := []int{m[2], m[3]}
+		if shannonEntropy(s[loc[0]:loc[1]]) < minHexEntropyBits || overlapsAny(out, loc) {
+			continue
+		}
+		out = append(out, loc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+func trimPadding(s string) string {
+	for len(s) > 0 && s[len(s)-1] == '=' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func looksRandomBase64(s string) bool {
+	var upper, lower, digit bool
+	switches := 0
+	prev := -1
+	for i, r := range s {
+		c := charClass(r)
+		switch c {
+		case 0:
+			upper = true
+		case 1:
+			lower = true
+		case 2:
+			digit = true
+		}
+		if i > 0 && c != prev {
+			switches++
+		}
+		prev = c
+	}
+	if !upper || !lower || !digit || longestSegment(s) < minBase64Segment {
+		return false
+	}
+	if float64(switches)/float64(len(s)-1) < minBase64ClassSwitch {
+		return false
+	}
+	return shannonEntropy(s) >= minBase64EntropyBits
+}
+
+// longestSegment is the length of the longest run of s without '-' or '_'.
+func longestSegment(s string) int {
+	longest := 0
+	for _, seg := range strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' }) {
+		longest = max(longest, len(seg))
+	}
+	return longest
+}
+
+func charClass(r rune) int {
+	switch {
+	case unicode.IsUpper(r):
+		return 0
+	case unicode.IsLower(r):
+		return 1
+	case unicode.IsDigit(r):
+		return 2
+	default:
+		return 3
+	}
+}
+
+// shannonEntropy is the per-character Shannon entropy of s in bits.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := map[rune]int{}
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	h := 0.0
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+func overlapsAny(locs [][]int, loc []int) bool {
+	for _, l := range locs {
+		if loc[0] < l[1] && l[0] < loc[1] {
+			return true
+		}
+	}
+	return false
+}
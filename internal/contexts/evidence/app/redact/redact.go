@@ -1,7 +1,11 @@
 package redact
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
@@ -31,86 +35,275 @@ type compiledExtraRule struct {
 	replacement string
 }
 
+type compiledAllowlist struct {
+	patterns []*regexp.Regexp
+	rules    map[string]bool // empty: every rule
+}
+
+// policy is the configured redaction on top of builtinRules: extra rules (config extraRules and
+// rules files) plus allowlists that exempt known-safe matches.
+type policy struct {
+	extraRules []compiledExtraRule
+	allowlists []compiledAllowlist
+	ruleset    Ruleset
+}
+
+// Ruleset identifies the active rule set. SHA256 covers every rule pattern and replacement plus the
+// allowlists, so a verification recorded under one rule set can be told apart from the current one.
+type Ruleset struct {
+	Rules      []string `json:"rules"`
+	Allowlists []string `json:"allowlists,omitempty"`
+	SHA256     string   `json:"sha256"`
+}
+
 var (
 	loadOnce   sync.Once
-	extraRules []compiledExtraRule
+	configured policy
 )
 
-func loadExtraRulesOnce() {
+func loadPolicyOnce() {
 	loadOnce.Do(func() {
-		rules, err := config.LoadRedactionMerged()
-		if err != nil || len(rules) == 0 {
-			return
-		}
-		out := make([]compiledExtraRule, 0, len(rules))
-		for _, r := range rules {
-			re, err := regexp.Compile(strings.TrimSpace(r.Regex))
-			if err != nil {
-				continue
-			}
-			repl := strings.TrimSpace(r.Replacement)
-			if repl == "" {
-				repl = "[REDACTED:" + strings.ToUpper(r.ID) + "]"
-			}
-			out = append(out, compiledExtraRule{
-				id:          strings.TrimSpace(r.ID),
-				re:          re,
-				replacement: repl,
-			})
+		p, err := config.LoadRedactionPolicyMerged()
+		if err != nil {
+			p = config.RedactionPolicyV1{}
 		}
-		extraRules = out
+		configured = compilePolicy(p)
 	})
 }
 
-func Text(s string) (string, Applied) {
-	loadExtraRulesOnce()
-
-	applied := Applied{}
-	out := s
+func compilePolicy(p config.RedactionPolicyV1) policy {
+	out := policy{}
+	for _, r := range p.Rules {
+		re, err := regexp.Compile(strings.TrimSpace(r.Regex))
+		if err != nil {
+			continue
+		}
+		repl := strings.TrimSpace(r.Replacement)
+		if repl == "" {
+			repl = "[REDACTED:" + strings.ToUpper(r.ID) + "]"
+		}
+		out.extraRules = append(out.extraRules, compiledExtraRule{
+			id:          strings.TrimSpace(r.ID),
+			re:          re,
+			replacement: repl,
+		})
+	}
+	for _, a := range p.Allowlists {
+		cl := compiledAllowlist{rules: map[string]bool{}}
+		for _, pat := range a.Patterns {
+			// Anchor so an allowlist entry exempts a whole match, never a secret that merely contains it.
+			if re, err := regexp.Compile(`^(?:` + pat + `)$`); err == nil {
+				cl.patterns = append(cl.patterns, re)
+			}
+		}
+		for _, name := range a.Rules {
+			cl.rules[strings.TrimSpace(name)] = true
+		}
+		out.allowlists = append(out.allowlists, cl)
+	}
+	out.ruleset = fingerprint(p)
+	return out
+}
 
-	if reGitHubTokenClassic.MatchString(out) {
-		out = reGitHubTokenClassic.ReplaceAllString(out, "[REDACTED:GITHUB_TOKEN]")
-		applied.Names = append(applied.Names, "github_token")
+func fingerprint(p config.RedactionPolicyV1) Ruleset {
+	rs := Ruleset{}
+	h := sha256.New()
+	seen := map[string]bool{}
+	for _, r := range builtinRules {
+		if !seen[r.name] {
+			seen[r.name] = true
+			rs.Rules = append(rs.Rules, r.name)
+		}
+		h.Write([]byte("builtin\x00" + r.name + "\x00" + r.re.String() + "\x00" + r.replacement + "\n"))
 	}
-	if reGitHubTokenFine.MatchString(out) {
-		out = reGitHubTokenFine.ReplaceAllString(out, "[REDACTED:GITHUB_TOKEN]")
-		applied.Names = append(applied.Names, "github_token")
+	rs.Rules = append(rs.Rules, entropyRuleName)
+	h.Write([]byte(fmt.Sprintf("builtin\x00%s\x00previews\x00%s\x00%s\x00%g/%g/%d/%g\n", entropyRuleName, reBase64Candidate, reHexSecretValue, minBase64EntropyBits, minBase64ClassSwitch, minBase64Segment, minHexEntropyBits)))
+	for _, r := range p.Rules {
+		id := strings.TrimSpace(r.ID)
+		if !seen[id] {
+			seen[id] = true
+			rs.Rules = append(rs.Rules, id)
+		}
+		h.Write([]byte("rule\x00" + id + "\x00" + strings.TrimSpace(r.Regex) + "\x00" + strings.TrimSpace(r.Replacement) + "\n"))
 	}
-	if reGitHubTokenOAuth.MatchString(out) {
-		out = reGitHubTokenOAuth.ReplaceAllString(out, "[REDACTED:GITHUB_TOKEN]")
-		applied.Names = append(applied.Names, "github_token")
+	for _, a := range p.Allowlists {
+		rs.Allowlists = append(rs.Allowlists, a.ID)
+		h.Write([]byte("allowlist\x00" + a.ID + "\x00" + strings.Join(a.Patterns, "\x00") + "\x00" + strings.Join(a.Rules, ",") + "\n"))
 	}
-	if reOpenAIKey.MatchString(out) {
-		out = reOpenAIKey.ReplaceAllString(out, "[REDACTED:OPENAI_KEY]")
-		applied.Names = append(applied.Names, "openai_key")
+	rs.SHA256 = hex.EncodeToString(h.Sum(nil))
+	return rs
+}
+
+// CurrentRuleset reports the rule set Text, Preview and Detect apply in this process.
+func CurrentRuleset() Ruleset {
+	loadPolicyOnce()
+	return configured.ruleset
+}
+
+// allowed reports whether an allowlist exempts this match of rule.
+func (p policy) allowed(rule, match string) bool {
+	for _, a := range p.allowlists {
+		if len(a.rules) > 0 && !a.rules[rule] {
+			continue
+		}
+		for _, re := range a.patterns {
+			if re.MatchString(match) {
+				return true
+			}
+		}
 	}
-	if reSlackToken.MatchString(out) {
-		out = reSlackToken.ReplaceAllString(out, "[REDACTED:SLACK_TOKEN]")
-		applied.Names = append(applied.Names, "slack_token")
+	return false
+}
+
+// replace applies one rule, expanding replacement like ReplaceAllString but keeping allowlisted
+// matches; it reports whether anything was replaced.
+func (p policy) replace(rule string, re *regexp.Regexp, s, replacement string) (string, bool) {
+	if len(p.allowlists) == 0 {
+		if !re.MatchString(s) {
+			return s, false
+		}
+		return re.ReplaceAllString(s, replacement), true
+	}
+	var b []byte
+	last, hit := 0, false
+	for _, m := range re.FindAllStringSubmatchIndex(s, -1) {
+		if p.allowed(rule, s[m[0]:m[1]]) {
+			continue
+		}
+		b = append(b, s[last:m[0]]...)
+		b = re.ExpandString(b, replacement, s, m)
+		last, hit = m[1], true
 	}
-	if reAWSAccessKeyID.MatchString(out) {
-		out = reAWSAccessKeyID.ReplaceAllString(out, "[REDACTED:AWS_ACCESS_KEY_ID]")
-		applied.Names = append(applied.Names, "aws_access_key_id")
+	if !hit {
+		return s, false
 	}
-	if reJWT.MatchString(out) {
-		out = reJWT.ReplaceAllString(out, "[REDACTED:JWT]")
-		applied.Names = append(applied.Names, "jwt")
+	return string(append(b, s[last:]...)), true
+}
+
+// replaceLocs replaces the ordered, non-overlapping spans locs of s with replacement, keeping
+// allowlisted matches; it reports whether anything was replaced.
+func (p policy) replaceLocs(rule, s string, locs [][]int, replacement string) (string, bool) {
+	var b strings.Builder
+	last, hit := 0, false
+	for _, loc := range locs {
+		if p.allowed(rule, s[loc[0]:loc[1]]) {
+			continue
+		}
+		b.WriteString(s[last:loc[0]])
+		b.WriteString(replacement)
+		last, hit = loc[1], true
 	}
-	if reAuthBearerHeader.MatchString(out) {
-		out = reAuthBearerHeader.ReplaceAllString(out, "Authorization: Bearer [REDACTED:BEARER_TOKEN]")
-		applied.Names = append(applied.Names, "bearer_token")
+	if !hit {
+		return s, false
 	}
-	if rePrivateKeyBlock.MatchString(out) {
-		out = rePrivateKeyBlock.ReplaceAllString(out, "[REDACTED:PRIVATE_KEY]")
-		applied.Names = append(applied.Names, "private_key")
+	b.WriteString(s[last:])
+	return b.String(), true
+}
+
+type builtinRule struct {
+	name        string
+	re          *regexp.Regexp
+	replacement string
+}
+
+// builtinRules run in order; several patterns may share one applied name.
+var builtinRules = []builtinRule{
+	{name: "github_token", re: reGitHubTokenClassic, replacement: "[REDACTED:GITHUB_TOKEN]"},
+	{name: "github_token", re: reGitHubTokenFine, replacement: "[REDACTED:GITHUB_TOKEN]"},
+	{name: "github_token", re: reGitHubTokenOAuth, replacement: "[REDACTED:GITHUB_TOKEN]"},
+	{name: "openai_key", re: reOpenAIKey, replacement: "[REDACTED:OPENAI_KEY]"},
+	{name: "slack_token", re: reSlackToken, replacement: "[REDACTED:SLACK_TOKEN]"},
+	{name: "aws_access_key_id", re: reAWSAccessKeyID, replacement: "[REDACTED:AWS_ACCESS_KEY_ID]"},
+	{name: "jwt", re: reJWT, replacement: "[REDACTED:JWT]"},
+	{name: "bearer_token", re: reAuthBearerHeader, replacement: "Authorization: Bearer [REDACTED:BEARER_TOKEN]"},
+	{name: "private_key", re: rePrivateKeyBlock, replacement: "[REDACTED:PRIVATE_KEY]"},
+}
+
+// Text redacts known credential formats and configured rules. Agent answers, feedback and notes
+// go through Text: they are graded, and hashes or ids in them must survive.
+func Text(s string) (string, Applied) {
+	loadPolicyOnce()
+	return configured.text(s)
+}
+
+// Preview is Text plus high-entropy masking, for the tool input/output previews recorded in
+// traces, where a prefix-less credential is far more likely than a value anyone grades.
+func Preview(s string) (string, Applied) {
+	loadPolicyOnce()
+	return configured.preview(s)
+}
+
+func (p policy) text(s string) (string, Applied) {
+	applied := Applied{}
+	out := s
+
+	for _, r := range builtinRules {
+		var hit bool
+		if out, hit = p.replace(r.name, r.re, out, r.replacement); hit {
+			applied.Names = append(applied.Names, r.name)
+		}
 	}
 
-	for _, r := range extraRules {
-		if r.re.MatchString(out) {
-			out = r.re.ReplaceAllString(out, r.replacement)
+	for _, r := range p.extraRules {
+		var hit bool
+		if out, hit = p.replace(r.id, r.re, out, r.replacement); hit {
 			applied.Names = append(applied.Names, r.id)
 		}
 	}
 
 	return out, applied
 }
+
+func (p policy) preview(s string) (string, Applied) {
+	out, applied := p.text(s)
+	// Entropy runs last so known formats keep their specific placeholders.
+	var hit bool
+	if out, hit = p.replaceLocs(entropyRuleName, out, entropyLocs(out), entropyReplacement); hit {
+		applied.Names = append(applied.Names, entropyRuleName)
+	}
+	return out, applied
+}
+
+// Match is one detector hit; Start/End are byte offsets into the scanned text.
+type Match struct {
+	Rule  string
+	Start int
+	End   int
+}
+
+// Detect reports every span Preview would redact, without rewriting s.
+// Matches are ordered by offset.
+func Detect(s string) []Match {
+	loadPolicyOnce()
+	return configured.detect(s)
+}
+
+func (p policy) detect(s string) []Match {
+	var out []Match
+	add := func(name string, re *regexp.Regexp) {
+		for _, loc := range re.FindAllStringIndex(s, -1) {
+			if p.allowed(name, s[loc[0]:loc[1]]) {
+				continue
+			}
+			out = append(out, Match{Rule: name, Start: loc[0], End: loc[1]})
+		}
+	}
+	for _, r := range builtinRules {
+		add(r.name, r.re)
+	}
+	for _, r := range p.extraRules {
+		add(r.id, r.re)
+	}
+	known := make([][]int, 0, len(out))
+	for _, m := range out {
+		known = append(known, []int{m.Start, m.End})
+	}
+	for _, loc := range entropyLocs(s) {
+		if overlapsAny(known, loc) || p.allowed(entropyRuleName, s[loc[0]:loc[1]]) {
+			continue
+		}
+		out = append(out, Match{Rule: entropyRuleName, Start: loc[0], End: loc[1]})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start < out[j].Start })
+	return out
+}
@@ -59,7 +59,7 @@ func ReplayAttempt(ctx context.Context, attemptDir string, opts Opts) (Result, e
 		return Result{}, err
 	}
 	tracePath := filepath.Join(abs, artifacts.ToolCallsJSONL)
-	f, err := os.Open(tracePath)
+	f, err := store.OpenArtifact(tracePath)
 	if err != nil {
 		return Result{}, err
 	}
@@ -0,0 +1,62 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// maxNotesBytes bounds reviewer notes, matching the feedback result bound.
+const maxNotesBytes = schema.FeedbackMaxBytesV1
+
+type WriteOpts struct {
+	OK       bool
+	Notes    string
+	Reviewer string
+}
+
+// Write records review.json for attemptDir. A later review replaces an earlier one.
+func Write(now time.Time, attemptDir string, opts WriteOpts) (schema.ReviewJSONV1, error) {
+	reviewer := strings.TrimSpace(opts.Reviewer)
+	if reviewer == "" {
+		return schema.ReviewJSONV1{}, fmt.Errorf("missing --reviewer")
+	}
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.AttemptJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return schema.ReviewJSONV1{}, fmt.Errorf("missing attempt.json in %s", attemptDir)
+		}
+		return schema.ReviewJSONV1{}, err
+	}
+	var a schema.AttemptJSONV1
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return schema.ReviewJSONV1{}, fmt.Errorf("invalid attempt.json: %w", err)
+	}
+	notes, _ := redact.Text(strings.TrimSpace(opts.Notes))
+	if len(notes) > maxNotesBytes {
+		return schema.ReviewJSONV1{}, fmt.Errorf("notes exceed max bytes (%d)", maxNotesBytes)
+	}
+	out := schema.ReviewJSONV1{
+		SchemaVersion: schema.ReviewSchemaV1,
+		RunID:         a.RunID,
+		SuiteID:       a.SuiteID,
+		MissionID:     a.MissionID,
+		AttemptID:     a.AttemptID,
+		OK:            opts.OK,
+		Notes:         notes,
+		Reviewer:      reviewer,
+		ReviewedAt:    now.UTC().Format(time.RFC3339Nano),
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(attemptDir, artifacts.ReviewJSON), out); err != nil {
+		return schema.ReviewJSONV1{}, err
+	}
+	return out, nil
+}
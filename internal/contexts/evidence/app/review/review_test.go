@@ -0,0 +1,36 @@
+package review
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestWrite_RecordsReviewAndRedactsNotes(t *testing.T) {
+	dir := t.TempDir()
+	attempt, _ := json.Marshal(schema.AttemptJSONV1{SchemaVersion: 1, RunID: "20260222-120000Z-aaaaaa", SuiteID: "s", MissionID: "m1", AttemptID: "001-m1-r1"})
+	if err := os.WriteFile(filepath.Join(dir, "attempt.json"), attempt, 0o644); err != nil {
+		t.Fatalf("write attempt.json: %v", err)
+	}
+	now := time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC)
+	rv, err := Write(now, dir, WriteOpts{OK: false, Notes: "leaked sk-1234567890ABCDEF", Reviewer: "alice"})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if rv.AttemptID != "001-m1-r1" || rv.OK || rv.Notes != "leaked [REDACTED:OPENAI_KEY]" {
+		t.Fatalf("unexpected review: %+v", rv)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "review.json")); err != nil {
+		t.Fatalf("expected review.json: %v", err)
+	}
+	if _, err := Write(now, dir, WriteOpts{OK: true}); err == nil {
+		t.Fatalf("expected missing reviewer error")
+	}
+	if _, err := Write(now, t.TempDir(), WriteOpts{OK: true, Reviewer: "alice"}); err == nil {
+		t.Fatalf("expected missing attempt.json error")
+	}
+}
@@ -0,0 +1,142 @@
+package secretscan
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// maxScanBytes bounds how much of a single artifact is scanned; larger files are reported as truncated.
+const maxScanBytes = 64 << 20
+
+// Hit is one detector match. The secret itself is never echoed back.
+type Hit struct {
+	Path string `json:"path"` // relative to the run dir, slash-separated
+	Line int    `json:"line"`
+	Rule string `json:"rule"`
+}
+
+type Result struct {
+	OK           bool     `json:"ok"`
+	RunID        string   `json:"runId"`
+	Path         string   `json:"path"`
+	FilesScanned int      `json:"filesScanned"`
+	Hits         []Hit    `json:"hits,omitempty"`
+	Skipped      []string `json:"skipped,omitempty"` // binary files (relative paths)
+	Truncated    []string `json:"truncated,omitempty"`
+}
+
+type Opts struct {
+	OutRoot string
+	RunID   string
+}
+
+// Run scans every stored artifact of one run (including raw runner IO and captures) with the redaction detectors.
+func Run(opts Opts) (Result, error) {
+	runID, runDir, err := resolveRunDir(opts)
+	if err != nil {
+		return Result{}, err
+	}
+	res, err := Dir(runDir)
+	res.RunID = runID
+	return res, err
+}
+
+func resolveRunDir(opts Opts) (string, string, error) {
+	outRoot := strings.TrimSpace(opts.OutRoot)
+	if outRoot == "" {
+		outRoot = ".zcl"
+	}
+	runID := strings.TrimSpace(opts.RunID)
+	if !ids.IsValidRunID(runID) {
+		return "", "", fmt.Errorf("invalid --run-id (expected format YYYYMMDD-HHMMSSZ-<hex6>)")
+	}
+	runDir := filepath.Join(outRoot, "runs", runID)
+	if _, err := os.Stat(filepath.Join(runDir, artifacts.RunJSON)); err != nil {
+		if os.IsNotExist(err) {
+			return "", "", fmt.Errorf("missing run.json for runId=%s", runID)
+		}
+		return "", "", err
+	}
+	return runID, runDir, nil
+}
+
+// Dir scans every regular file below root. Symlinks are not followed.
+func Dir(root string) (Result, error) {
+	res := Result{OK: true, Path: root}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		return scanFile(path, rel, &res)
+	})
+	if err != nil {
+		return res, err
+	}
+	sort.Slice(res.Hits, func(i, j int) bool {
+		if res.Hits[i].Path != res.Hits[j].Path {
+			return res.Hits[i].Path < res.Hits[j].Path
+		}
+		if res.Hits[i].Line != res.Hits[j].Line {
+			return res.Hits[i].Line < res.Hits[j].Line
+		}
+		return res.Hits[i].Rule < res.Hits[j].Rule
+	})
+	res.OK = len(res.Hits) == 0
+	return res, nil
+}
+
+func scanFile(path, rel string, res *Result) error {
+	// Compacted (.gz) and, with an identity configured, encrypted (.enc) evidence is scanned in plain
+	// form; anything that cannot be opened that way is scanned as-is.
+	f, err := store.OpenArtifact(path)
+	if err != nil {
+		if f, err = os.Open(path); err != nil {
+			return err
+		}
+	}
+	defer func() { _ = f.Close() }()
+	data, err := io.ReadAll(io.LimitReader(f, maxScanBytes+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxScanBytes {
+		data = data[:maxScanBytes]
+		res.Truncated = append(res.Truncated, rel)
+	}
+	sniff := data
+	if len(sniff) > 8000 {
+		sniff = sniff[:8000]
+	}
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		res.Skipped = append(res.Skipped, rel)
+		return nil
+	}
+	res.FilesScanned++
+	text := string(data)
+	line, pos := 1, 0
+	for _, m := range redact.Detect(text) {
+		line += strings.Count(text[pos:m.Start], "\n")
+		pos = m.Start
+		res.Hits = append(res.Hits, Hit{Path: rel, Line: line, Rule: m.Rule})
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package secretscan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+)
+
+func TestDir_ReportsHitsWithFileAndLine(t *testing.T) {
+	root := t.TempDir()
+	attemptDir := filepath.Join(root, "attempts", "001-m1-r1")
+	if err := os.MkdirAll(attemptDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	write := func(rel, body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(root, rel), []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	write("attempts/001-m1-r1/runner.stdout.log", "hello\nworld\ntoken ghp_1234567890abcdef leaked\n")
+	write("attempts/001-m1-r1/feedback.json", `{"ok":true,"result":"fine"}`)
+	write("attempts/001-m1-r1/blob.bin", "sk-1234567890ABCDEF\x00\x01")
+
+	res, err := Dir(root)
+	if err != nil {
+		t.Fatalf("Dir: %v", err)
+	}
+	if res.OK || len(res.Hits) != 1 {
+		t.Fatalf("expected one hit, got %+v", res)
+	}
+	h := res.Hits[0]
+	if h.Path != "attempts/001-m1-r1/runner.stdout.log" || h.Line != 3 || h.Rule != "github_token" {
+		t.Fatalf("unexpected hit: %+v", h)
+	}
+	if len(res.Skipped) != 1 || res.Skipped[0] != "attempts/001-m1-r1/blob.bin" {
+		t.Fatalf("expected binary file to be skipped, got %+v", res.Skipped)
+	}
+	if res.FilesScanned != 2 {
+		t.Fatalf("expected 2 scanned files, got %d", res.FilesScanned)
+	}
+}
+
+func TestRun_RejectsInvalidRunID(t *testing.T) {
+	if _, err := Run(Opts{OutRoot: t.TempDir(), RunID: "nope"}); err == nil {
+		t.Fatalf("expected invalid run id error")
+	}
+}
+
+func TestVerify_RecordsOutcomeAndDetectsStaleArtifacts(t *testing.T) {
+	outRoot := t.TempDir()
+	runID := "20260222-120000Z-abc123"
+	runDir := filepath.Join(outRoot, "runs", runID)
+	if err := os.MkdirAll(filepath.Join(runDir, "attempts", "001-m1-r1"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	logPath := filepath.Join(runDir, "attempts", "001-m1-r1", "runner.stdout.log")
+	for path, body := range map[string]string{filepath.Join(runDir, artifacts.RunJSON): `{"runId":"` + runID + `"}`, logPath: "clean\n"} {
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	opts := Opts{OutRoot: outRoot, RunID: runID}
+
+	rec, err := Verify(opts, time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC))
+	if err != nil || !rec.OK || rec.Ruleset.SHA256 == "" || rec.Hits == nil {
+		t.Fatalf("expected clean verification, got %+v err=%v", rec, err)
+	}
+	if ok, reason, err := Verified(opts); !ok || err != nil {
+		t.Fatalf("expected run to be verified, got reason=%q err=%v", reason, err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(logPath, later, later); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	if ok, reason, _ := Verified(opts); ok || !strings.Contains(reason, "attempts/001-m1-r1/runner.stdout.log") {
+		t.Fatalf("expected stale verification, got ok=%v reason=%q", ok, reason)
+	}
+
+	if err := os.WriteFile(logPath, []byte("token ghp_1234567890abcdef\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if rec, err = Verify(opts, time.Now()); err != nil || rec.OK || len(rec.Hits) != 1 {
+		t.Fatalf("expected one residual hit, got %+v err=%v", rec, err)
+	}
+	if ok, reason, _ := Verified(opts); ok || !strings.Contains(reason, "residual hits") {
+		t.Fatalf("expected failing verification to block, got ok=%v reason=%q", ok, reason)
+	}
+}
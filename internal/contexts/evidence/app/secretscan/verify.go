@@ -0,0 +1,100 @@
+package secretscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// Verify re-scans every stored artifact of one run with the current redaction rule set and records
+// the outcome in redaction.verify.json, so publishing can later require a clean, current verification.
+func Verify(opts Opts, now time.Time) (schema.RedactionVerifyJSONV1, error) {
+	res, err := Run(opts)
+	if err != nil {
+		return schema.RedactionVerifyJSONV1{}, err
+	}
+	rs := redact.CurrentRuleset()
+	out := schema.RedactionVerifyJSONV1{
+		SchemaVersion: schema.RedactionVerifySchemaV1,
+		RunID:         res.RunID,
+		OK:            res.OK,
+		Ruleset:       schema.RedactionRulesetV1{Rules: rs.Rules, Allowlists: rs.Allowlists, SHA256: rs.SHA256},
+		FilesScanned:  res.FilesScanned,
+		Hits:          make([]schema.RedactionVerifyHitV1, 0, len(res.Hits)),
+		Skipped:       res.Skipped,
+		Truncated:     res.Truncated,
+		VerifiedAt:    now.UTC().Format(time.RFC3339Nano),
+	}
+	for _, h := range res.Hits {
+		out.Hits = append(out.Hits, schema.RedactionVerifyHitV1{Path: h.Path, Line: h.Line, Rule: h.Rule})
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(res.Path, artifacts.RedactionVerifyJSON), out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// Verified reports whether a run carries a passing redaction.verify.json that was recorded under the
+// current rule set and after the last change to any other artifact of the run. reason explains a false result.
+func Verified(opts Opts) (ok bool, reason string, err error) {
+	_, runDir, err := resolveRunDir(opts)
+	if err != nil {
+		return false, "", err
+	}
+	path := filepath.Join(runDir, artifacts.RedactionVerifyJSON)
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, "missing " + artifacts.RedactionVerifyJSON, nil
+		}
+		return false, "", err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false, "", err
+	}
+	var rec schema.RedactionVerifyJSONV1
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return false, "", fmt.Errorf("invalid %s: %w", artifacts.RedactionVerifyJSON, err)
+	}
+	if !rec.OK {
+		return false, fmt.Sprintf("verification recorded %d residual hits", len(rec.Hits)), nil
+	}
+	if rec.Ruleset.SHA256 != redact.CurrentRuleset().SHA256 {
+		return false, "redaction rule set changed since verification", nil
+	}
+	var changed string
+	err = filepath.WalkDir(runDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || p == path {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if fi.ModTime().After(info.ModTime()) {
+			rel, _ := filepath.Rel(runDir, p)
+			changed = filepath.ToSlash(rel)
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return false, "", err
+	}
+	if changed != "" {
+		return false, "artifact changed since verification: " + changed, nil
+	}
+	return true, "", nil
+}
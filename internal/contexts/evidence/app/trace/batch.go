@@ -0,0 +1,132 @@
+package trace
+
+import (
+	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const (
+	// DefaultBatchFlushInterval bounds how long a buffered event waits before it is on disk.
+	DefaultBatchFlushInterval = 200 * time.Millisecond
+	// DefaultBatchMaxEvents flushes early when a burst (native deltas) fills the buffer.
+	DefaultBatchMaxEvents = 256
+)
+
+// Batch buffers trace events for one tool.calls.jsonl and appends them in chained groups: one
+// lock, one write and one fsync per flush instead of per event. Set it on Env.Batch to route
+// AppendEvent (and so every in-process funnel) through it. Events are chained at flush time
+// against the file's current tail, so writers from other processes still interleave correctly.
+type Batch struct {
+	path       string
+	maxEvents  int
+	mu         sync.Mutex
+	flushMu    sync.Mutex
+	pending    []schema.TraceEventV1
+	err        error
+	closed     bool
+	stopOnce   sync.Once
+	stop       chan struct{}
+	tickerDone chan struct{}
+}
+
+// NewBatch starts a batch that flushes every interval (<= 0 uses DefaultBatchFlushInterval) or
+// once maxEvents (<= 0 uses DefaultBatchMaxEvents) are pending. Close must be called to flush
+// the tail.
+func NewBatch(tracePath string, interval time.Duration, maxEvents int) *Batch {
+	if interval <= 0 {
+		interval = DefaultBatchFlushInterval
+	}
+	if maxEvents <= 0 {
+		maxEvents = DefaultBatchMaxEvents
+	}
+	b := &Batch{
+		path:       tracePath,
+		maxEvents:  maxEvents,
+		stop:       make(chan struct{}),
+		tickerDone: make(chan struct{}),
+	}
+	go b.loop(interval)
+	return b
+}
+
+func (b *Batch) loop(interval time.Duration) {
+	defer close(b.tickerDone)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			_ = b.Flush()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// Add queues ev. It returns the first flush error seen so far so callers notice a failing disk
+// without waiting for Close. After Close, events are appended directly.
+func (b *Batch) Add(ev schema.TraceEventV1) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		b.flushMu.Lock()
+		defer b.flushMu.Unlock()
+		return AppendChained(b.path, ev)
+	}
+	b.pending = append(b.pending, ev)
+	full := len(b.pending) >= b.maxEvents
+	err := b.err
+	b.mu.Unlock()
+	if full {
+		return b.Flush()
+	}
+	return err
+}
+
+// Flush writes every pending event now.
+func (b *Batch) Flush() error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	return b.flushLocked(false)
+}
+
+// flushLocked drains pending under flushMu; closing marks the batch closed in the same step so
+// direct appends after Close can never land ahead of the final batch.
+func (b *Batch) flushLocked(closing bool) error {
+	b.mu.Lock()
+	if closing {
+		b.closed = true
+	}
+	events := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	err := store.AppendJSONLLinkedBatch(b.path, len(events), func(i int, prev []byte) (any, error) {
+		ev := events[i]
+		ev.PrevHash = nextTraceChainHash(prev)
+		return ev, nil
+	})
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+	return b.err
+}
+
+// Close stops the periodic flush and writes the remaining events. Safe on nil and repeated calls.
+func (b *Batch) Close() error {
+	if b == nil {
+		return nil
+	}
+	b.stopOnce.Do(func() {
+		close(b.stop)
+		<-b.tickerDone
+	})
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	return b.flushLocked(true)
+}
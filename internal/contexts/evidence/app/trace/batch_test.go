@@ -0,0 +1,122 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestBatch_ChainsBufferedEventsWithDirectAppends(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	tracePath := filepath.Join(outDir, artifacts.ToolCallsJSONL)
+	env := Env{
+		RunID:     "20260215-180012Z-09c5a6",
+		SuiteID:   "heftiweb-smoke",
+		MissionID: "latest-blog-title",
+		AttemptID: "001-latest-blog-title-r1",
+		OutDirAbs: outDir,
+	}
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	if err := AppendCLIRunEvent(now, env, []string{"echo", "before"}, ResultForTrace{}); err != nil {
+		t.Fatalf("AppendCLIRunEvent: %v", err)
+	}
+
+	// A long interval: nothing may hit disk before the size trigger or Close.
+	env.Batch = NewBatch(tracePath, time.Hour, 4)
+	for i := 0; i < 3; i++ {
+		if err := AppendNativeRuntimeEvent(now, env, NativeRuntimeEvent{EventName: "codex/event/agent_message_delta"}); err != nil {
+			t.Fatalf("AppendNativeRuntimeEvent: %v", err)
+		}
+	}
+	if n := len(readTraceLines(t, tracePath)); n != 1 {
+		t.Fatalf("expected buffered events to stay pending, got %d lines", n)
+	}
+	if err := AppendNativeRuntimeEvent(now, env, NativeRuntimeEvent{EventName: "codex/event/agent_message_delta"}); err != nil {
+		t.Fatalf("AppendNativeRuntimeEvent: %v", err)
+	}
+	if n := len(readTraceLines(t, tracePath)); n != 5 {
+		t.Fatalf("expected a full batch to flush, got %d lines", n)
+	}
+	if err := AppendNativeRuntimeEvent(now, env, NativeRuntimeEvent{EventName: "codex/event/task_complete"}); err != nil {
+		t.Fatalf("AppendNativeRuntimeEvent: %v", err)
+	}
+	if err := env.Batch.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := AppendNativeRuntimeEvent(now, env, NativeRuntimeEvent{EventName: "codex/event/late"}); err != nil {
+		t.Fatalf("append after Close: %v", err)
+	}
+
+	lines := readTraceLines(t, tracePath)
+	if len(lines) != 7 {
+		t.Fatalf("expected 7 lines, got %d", len(lines))
+	}
+	prev := ""
+	for i, line := range lines {
+		var ev schema.TraceEventV1
+		if err := json.Unmarshal(line, &ev); err != nil {
+			t.Fatalf("line %d: %v", i, err)
+		}
+		want := schema.TraceChainGenesisV1
+		if i > 0 {
+			want = schema.TraceChainLinkV1(prev, lines[i-1])
+		}
+		if ev.PrevHash != want {
+			t.Fatalf("line %d: prevHash=%q want %q", i, ev.PrevHash, want)
+		}
+		prev = ev.PrevHash
+	}
+	var last schema.TraceEventV1
+	_ = json.Unmarshal(lines[6], &last)
+	if last.Op != "late" {
+		t.Fatalf("expected the post-Close event last, got %q", last.Op)
+	}
+}
+
+func TestBatch_StartsOnFreshLineAfterTornTail(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	tracePath := filepath.Join(outDir, artifacts.ToolCallsJSONL)
+	if err := os.WriteFile(tracePath, []byte(`{"v":1,"tool":"cli"`), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	env := Env{RunID: "r", MissionID: "m", AttemptID: "a", OutDirAbs: outDir, Batch: NewBatch(tracePath, time.Hour, 0)}
+	if err := AppendNativeRuntimeEvent(time.Now(), env, NativeRuntimeEvent{EventName: "codex/event/turn_started"}); err != nil {
+		t.Fatalf("AppendNativeRuntimeEvent: %v", err)
+	}
+	if err := env.Batch.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	lines := readTraceLines(t, tracePath)
+	if len(lines) != 2 {
+		t.Fatalf("expected torn line + event, got %q", lines)
+	}
+	var ev schema.TraceEventV1
+	if err := json.Unmarshal(lines[1], &ev); err != nil || ev.Op != "turn_started" {
+		t.Fatalf("expected an intact event line, got %q (%v)", lines[1], err)
+	}
+}
+
+func readTraceLines(t *testing.T, path string) [][]byte {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read trace: %v", err)
+	}
+	var out [][]byte
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			out = append(out, line)
+		}
+	}
+	return out
+}
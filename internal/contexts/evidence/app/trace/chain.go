@@ -0,0 +1,29 @@
+package trace
+
+import (
+	"encoding/json"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// AppendChained appends ev to tracePath with prevHash linking it to the current last line.
+// Every funnel writes through here so tool.calls.jsonl forms one tamper-evident chain.
+func AppendChained(tracePath string, ev schema.TraceEventV1) error {
+	return store.AppendJSONLLinked(tracePath, func(last []byte) (any, error) {
+		ev.PrevHash = nextTraceChainHash(last)
+		return ev, nil
+	})
+}
+
+func nextTraceChainHash(last []byte) string {
+	if len(last) == 0 {
+		return schema.TraceChainGenesisV1
+	}
+	var prev struct {
+		PrevHash string `json:"prevHash"`
+	}
+	// An unparsable or unchained tail still gets linked; validate reports the mismatch.
+	_ = json.Unmarshal(last, &prev)
+	return schema.TraceChainLinkV1(prev.PrevHash, last)
+}
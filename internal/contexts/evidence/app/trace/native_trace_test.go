@@ -111,3 +111,52 @@ func TestAppendNativeRuntimeEvent_RedactsPayloadSecrets(t *testing.T) {
 		t.Fatalf("expected payload secret to be redacted, got %s", string(inputRaw))
 	}
 }
+
+func TestAppendNativeRuntimeEvent_MasksSensitiveFieldsUnlessRaw(t *testing.T) {
+	payload := json.RawMessage(`{"headers":{"Authorization":"Basic dXNlcjpwYXNz","X-Api-Key":"k1"},"apiKey":"k2","inputTokens":5}`)
+	readRow := func(t *testing.T, raw bool) map[string]any {
+		t.Helper()
+		outDir := t.TempDir()
+		env := Env{RunID: "run", SuiteID: "suite", MissionID: "mission", AttemptID: "attempt", OutDirAbs: outDir}
+		if err := AppendNativeRuntimeEvent(time.Date(2026, 2, 22, 14, 3, 0, 0, time.UTC), env, NativeRuntimeEvent{
+			RuntimeID: "codex_app_server",
+			EventName: "codex/event/request",
+			Payload:   payload,
+			Raw:       raw,
+		}); err != nil {
+			t.Fatalf("append native event: %v", err)
+		}
+		b, err := os.ReadFile(filepath.Join(outDir, "tool.calls.jsonl"))
+		if err != nil {
+			t.Fatalf("read trace: %v", err)
+		}
+		var row map[string]any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(string(b))), &row); err != nil {
+			t.Fatalf("unmarshal trace row: %v", err)
+		}
+		return row
+	}
+
+	row := readRow(t, false)
+	inputRaw, _ := json.Marshal(row["input"])
+	for _, secret := range []string{"dXNlcjpwYXNz", `"k1"`, `"k2"`} {
+		if strings.Contains(string(inputRaw), secret) {
+			t.Fatalf("expected %s to be masked, got %s", secret, string(inputRaw))
+		}
+	}
+	if !strings.Contains(string(inputRaw), `"inputTokens":5`) {
+		t.Fatalf("expected non-sensitive fields to survive, got %s", string(inputRaw))
+	}
+	if redactions, _ := json.Marshal(row["redactionsApplied"]); !strings.Contains(string(redactions), "sensitive_field") {
+		t.Fatalf("expected sensitive_field in redactionsApplied, got %s", string(redactions))
+	}
+
+	row = readRow(t, true)
+	inputRaw, _ = json.Marshal(row["input"])
+	if !strings.Contains(string(inputRaw), "dXNlcjpwYXNz") {
+		t.Fatalf("expected raw payload to be stored verbatim, got %s", string(inputRaw))
+	}
+	if warnings, _ := json.Marshal(row["warnings"]); !strings.Contains(string(warnings), "ZCL_W_PAYLOAD_RAW") {
+		t.Fatalf("expected raw payload warning, got %s", string(warnings))
+	}
+}
@@ -0,0 +1,139 @@
+package trace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// AppendEvent appends ev to the attempt's tool.calls.jsonl, honoring attempt.json traceSampling rules.
+// Sampling state lives in trace.sampling.json so the 1-in-N cadence holds across funnel processes.
+func AppendEvent(env Env, ev schema.TraceEventV1) error {
+	keep, err := sampleTraceEvent(env.OutDirAbs, &ev)
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return nil
+	}
+	if env.Batch != nil {
+		return env.Batch.Add(ev)
+	}
+	return AppendChained(filepath.Join(env.OutDirAbs, artifacts.ToolCallsJSONL), ev)
+}
+
+func sampleTraceEvent(attemptDir string, ev *schema.TraceEventV1) (bool, error) {
+	if !ev.Result.OK {
+		return true, nil
+	}
+	rules := loadTraceSamplingRules(attemptDir)
+	idx := matchTraceSamplingRule(rules, *ev)
+	if idx < 0 || rules[idx].KeepEvery <= 1 {
+		return true, nil
+	}
+	keep := false
+	statePath := filepath.Join(attemptDir, artifacts.TraceSamplingJSON)
+	lockDir := filepath.Join(attemptDir, "."+artifacts.TraceSamplingJSON+".lock")
+	err := store.WithDirLock(lockDir, 5*time.Second, func() error {
+		state := readTraceSamplingState(statePath, rules)
+		r := &state.Rules[idx]
+		keep = r.Seen%r.KeepEvery == 0
+		r.Seen++
+		if keep {
+			r.Kept++
+		} else {
+			r.Omitted++
+		}
+		return store.WriteJSONAtomic(statePath, state)
+	})
+	if err != nil {
+		return false, err
+	}
+	if keep {
+		ev.Warnings = append(ev.Warnings, schema.TraceWarningV1{
+			Code:    "ZCL_W_TRACE_SAMPLED",
+			Message: "event kept by trace sampling; omitted siblings are counted in trace.sampling.json",
+		})
+	}
+	return keep, nil
+}
+
+func loadTraceSamplingRules(attemptDir string) []schema.TraceSamplingRuleV1 {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.AttemptJSON))
+	if err != nil {
+		return nil
+	}
+	var meta struct {
+		TraceSampling []schema.TraceSamplingRuleV1 `json:"traceSampling"`
+	}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil
+	}
+	return meta.TraceSampling
+}
+
+// readTraceSamplingState returns persisted counters aligned to rules; a missing or stale file resets them.
+func readTraceSamplingState(path string, rules []schema.TraceSamplingRuleV1) schema.TraceSamplingJSONV1 {
+	state := schema.TraceSamplingJSONV1{SchemaVersion: schema.TraceSamplingSchemaV1}
+	var prev schema.TraceSamplingJSONV1
+	if raw, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(raw, &prev)
+	}
+	for i, r := range rules {
+		st := schema.TraceSamplingRuleStateV1{TraceSamplingRuleV1: r}
+		if i < len(prev.Rules) && prev.Rules[i].TraceSamplingRuleV1 == r {
+			st = prev.Rules[i]
+		}
+		state.Rules = append(state.Rules, st)
+	}
+	return state
+}
+
+func matchTraceSamplingRule(rules []schema.TraceSamplingRuleV1, ev schema.TraceEventV1) int {
+	for i, r := range rules {
+		if r.Tool != ev.Tool {
+			continue
+		}
+		if r.Op != "" && r.Op != ev.Op {
+			continue
+		}
+		if r.Prefix != "" && !strings.HasPrefix(traceSamplingTarget(ev), r.Prefix) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+func traceSamplingTarget(ev schema.TraceEventV1) string {
+	if len(ev.Input) == 0 {
+		return ""
+	}
+	var in struct {
+		Argv   []string `json:"argv"`
+		URL    string   `json:"url"`
+		Params struct {
+			Name string `json:"name"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(ev.Input, &in); err != nil {
+		return ""
+	}
+	switch ev.Tool {
+	case "cli":
+		if len(in.Argv) > 0 {
+			return in.Argv[0]
+		}
+	case "mcp":
+		return in.Params.Name
+	case "http":
+		return in.URL
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestAppendCLIRunEvent_TraceSamplingKeepsOneInNAndAllFailures(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	env := Env{
+		RunID:     "20260215-180012Z-09c5a6",
+		SuiteID:   "heftiweb-smoke",
+		MissionID: "latest-blog-title",
+		AttemptID: "001-latest-blog-title-r1",
+		OutDirAbs: outDir,
+	}
+	writeSamplingAttempt(t, outDir, []schema.TraceSamplingRuleV1{{Tool: "cli", Prefix: "cat", KeepEvery: 3}})
+
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		if err := AppendCLIRunEvent(now, env, []string{"cat", "README.md"}, ResultForTrace{ExitCode: 0}); err != nil {
+			t.Fatalf("AppendCLIRunEvent: %v", err)
+		}
+	}
+	if err := AppendCLIRunEvent(now, env, []string{"cat", "missing"}, ResultForTrace{ExitCode: 1}); err != nil {
+		t.Fatalf("AppendCLIRunEvent: %v", err)
+	}
+	if err := AppendCLIRunEvent(now, env, []string{"touch", "out.txt"}, ResultForTrace{ExitCode: 0}); err != nil {
+		t.Fatalf("AppendCLIRunEvent: %v", err)
+	}
+
+	// 7 sampled successes -> kept #1,#4,#7; plus the failure and the unmatched write.
+	if got := countTraceLines(t, filepath.Join(outDir, "tool.calls.jsonl")); got != 5 {
+		t.Fatalf("expected 5 trace events, got %d", got)
+	}
+	raw, err := os.ReadFile(filepath.Join(outDir, "trace.sampling.json"))
+	if err != nil {
+		t.Fatalf("read trace.sampling.json: %v", err)
+	}
+	var st schema.TraceSamplingJSONV1
+	if err := json.Unmarshal(raw, &st); err != nil {
+		t.Fatalf("unmarshal trace.sampling.json: %v", err)
+	}
+	if len(st.Rules) != 1 || st.Rules[0].Seen != 7 || st.Rules[0].Kept != 3 || st.Rules[0].Omitted != 4 {
+		t.Fatalf("unexpected sampling state: %+v", st)
+	}
+}
+
+func writeSamplingAttempt(t *testing.T, outDir string, rules []schema.TraceSamplingRuleV1) {
+	t.Helper()
+	b, err := json.Marshal(schema.AttemptJSONV1{
+		SchemaVersion: schema.AttemptSchemaV1,
+		RunID:         "20260215-180012Z-09c5a6",
+		SuiteID:       "heftiweb-smoke",
+		MissionID:     "latest-blog-title",
+		AttemptID:     "001-latest-blog-title-r1",
+		Mode:          "discovery",
+		StartedAt:     "2026-02-15T18:00:00Z",
+		TraceSampling: rules,
+	})
+	if err != nil {
+		t.Fatalf("marshal attempt.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "attempt.json"), b, 0o644); err != nil {
+		t.Fatalf("write attempt.json: %v", err)
+	}
+}
+
+func countTraceLines(t *testing.T, path string) int {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open trace: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	n := 0
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if len(sc.Bytes()) > 0 {
+			n++
+		}
+	}
+	return n
+}
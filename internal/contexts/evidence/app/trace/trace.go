@@ -3,15 +3,14 @@ package trace
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
@@ -23,6 +22,10 @@ type Env struct {
 	AgentID   string
 	OutDirAbs string
 	TmpDirAbs string
+	// FeedbackSigningKey is the PEM ed25519 key path feedback.json is signed with ("" = unsigned).
+	FeedbackSigningKey string
+	// Batch, when set, buffers tool.calls.jsonl appends (see Batch); nil appends each event directly.
+	Batch *Batch
 }
 
 func EnvFromProcess() (Env, error) {
@@ -34,6 +37,8 @@ func EnvFromProcess() (Env, error) {
 		AgentID:   os.Getenv("ZCL_AGENT_ID"),
 		OutDirAbs: os.Getenv("ZCL_OUT_DIR"),
 		TmpDirAbs: os.Getenv("ZCL_TMP_DIR"),
+
+		FeedbackSigningKey: os.Getenv(sigkey.FeedbackKeyEnv),
 	}
 	if e.OutDirAbs == "" {
 		return Env{}, fmt.Errorf("missing ZCL_OUT_DIR")
@@ -58,8 +63,12 @@ type NativeRuntimeEvent struct {
 	Payload   json.RawMessage
 	Code      string
 	Partial   bool
+	// Raw stores Payload without redaction (suite run --native-events-raw; unsafe).
+	Raw bool
 }
 
+// AppendCLIRunEvent traces one funnelled command. It goes through AppendEvent, so it joins
+// env.Batch when the caller has one; `zcl run` appends a single event per process and leaves it nil.
 func AppendCLIRunEvent(now time.Time, env Env, argv []string, res ResultForTrace) error {
 	redArgv, argvApplied := redactStrings(argv)
 	input, inputTruncated, inputWarn, err := boundedToolInputJSON(ToolCallInput{Argv: redArgv}, schema.ToolInputMaxBytesV1)
@@ -70,7 +79,7 @@ func AppendCLIRunEvent(now time.Time, env Env, argv []string, res ResultForTrace
 	result := cliTraceResult(res)
 	outPrev, errPrev, outCapped, errCapped, previewRedactions := redactedPreviews(res)
 	redactions := unionStrings(argvApplied, previewRedactions)
-	warnings := append([]schema.TraceWarningV1(nil), inputWarn...)
+	warnings := append(append([]schema.TraceWarningV1(nil), inputWarn...), res.Warnings...)
 
 	ev := schema.TraceEventV1{
 		V:         schema.TraceSchemaV1,
@@ -107,8 +116,7 @@ func AppendCLIRunEvent(now time.Time, env Env, argv []string, res ResultForTrace
 		ev.Integrity.Truncated = true
 	}
 
-	path := filepath.Join(env.OutDirAbs, artifacts.ToolCallsJSONL)
-	return store.AppendJSONL(path, ev)
+	return AppendEvent(env, ev)
 }
 
 func cliTraceResult(res ResultForTrace) schema.TraceResultV1 {
@@ -130,8 +138,8 @@ func cliTraceResult(res ResultForTrace) schema.TraceResultV1 {
 }
 
 func redactedPreviews(res ResultForTrace) (string, string, bool, bool, []string) {
-	outPrev, outApplied := redact.Text(res.OutPreview)
-	errPrev, errApplied := redact.Text(res.ErrPreview)
+	outPrev, outApplied := redact.Preview(res.OutPreview)
+	errPrev, errApplied := redact.Preview(res.ErrPreview)
 
 	outPrev, outCapped := capStringBytes(outPrev, schema.PreviewMaxBytesV1)
 	errPrev, errCapped := capStringBytes(errPrev, schema.PreviewMaxBytesV1)
@@ -187,12 +195,17 @@ func AppendNativeRuntimeEvent(now time.Time, env Env, evIn NativeRuntimeEvent) e
 	var redactions []string
 	if len(evIn.Payload) > 0 {
 		var decoded any
-		if err := json.Unmarshal(evIn.Payload, &decoded); err == nil {
+		switch err := json.Unmarshal(evIn.Payload, &decoded); {
+		case err == nil && evIn.Raw:
+			payload["payload"] = decoded
+		case err == nil:
 			redacted, applied := redactAny(decoded)
 			payload["payload"] = redacted
 			redactions = unionStrings(redactions, applied)
-		} else {
-			red, applied := redact.Text(strings.TrimSpace(string(evIn.Payload)))
+		case evIn.Raw:
+			payload["payloadRaw"] = strings.TrimSpace(string(evIn.Payload))
+		default:
+			red, applied := redact.Preview(strings.TrimSpace(string(evIn.Payload)))
 			payload["payloadRaw"] = red
 			redactions = unionStrings(redactions, applied.Names)
 		}
@@ -201,6 +214,12 @@ func AppendNativeRuntimeEvent(now time.Time, env Env, evIn NativeRuntimeEvent) e
 	if err != nil {
 		return err
 	}
+	if evIn.Raw && len(evIn.Payload) > 0 {
+		warnings = append(warnings, schema.TraceWarningV1{
+			Code:    "ZCL_W_PAYLOAD_RAW",
+			Message: "native event payload stored without redaction (--native-events-raw)",
+		})
+	}
 	ok := !evIn.Partial
 	code := strings.TrimSpace(evIn.Code)
 	if code != "" {
@@ -235,14 +254,95 @@ func AppendNativeRuntimeEvent(now time.Time, env Env, evIn NativeRuntimeEvent) e
 			Truncated: inputTruncated || evIn.Partial,
 		},
 	}
-	path := filepath.Join(env.OutDirAbs, artifacts.ToolCallsJSONL)
-	return store.AppendJSONL(path, traceEvent)
+	return AppendEvent(env, traceEvent)
+}
+
+// AgentStreamEvent is one message of a streaming agent protocol (runner.type=ws_agent), traced as
+// tool=Tool op=Op with the redacted message as input.
+type AgentStreamEvent struct {
+	Tool    string
+	Op      string
+	Payload json.RawMessage
+	// Code marks a failed message (an agent error or the interrupt at the deadline).
+	Code string
 }
 
+func AppendAgentStreamEvent(now time.Time, env Env, evIn AgentStreamEvent) error {
+	op := strings.ToLower(strings.TrimSpace(evIn.Op))
+	op = strings.NewReplacer("/", "_", " ", "_").Replace(op)
+	if op == "" {
+		op = "unknown"
+	}
+	var payload any
+	var redactions []string
+	if err := json.Unmarshal(evIn.Payload, &payload); err == nil {
+		payload, redactions = redactAny(payload)
+	} else {
+		red, applied := redact.Preview(strings.TrimSpace(string(evIn.Payload)))
+		payload, redactions = map[string]any{"payloadRaw": red}, applied.Names
+	}
+	input, inputTruncated, warnings, err := boundedToolInputJSON(payload, schema.ToolInputMaxBytesV1)
+	if err != nil {
+		return err
+	}
+	code := strings.TrimSpace(evIn.Code)
+	return AppendEvent(env, schema.TraceEventV1{
+		V:                 schema.TraceSchemaV1,
+		TS:                now.UTC().Format(time.RFC3339Nano),
+		RunID:             env.RunID,
+		SuiteID:           env.SuiteID,
+		MissionID:         env.MissionID,
+		AttemptID:         env.AttemptID,
+		AgentID:           env.AgentID,
+		Tool:              evIn.Tool,
+		Op:                op,
+		Input:             input,
+		Result:            schema.TraceResultV1{OK: code == "", Code: code},
+		Warnings:          warnings,
+		RedactionsApplied: redactions,
+		Integrity:         &schema.TraceIntegrityV1{Truncated: inputTruncated},
+	})
+}
+
+const (
+	sensitiveFieldRule        = "sensitive_field"
+	sensitiveFieldPlaceholder = "[REDACTED:SENSITIVE_FIELD]"
+)
+
+// sensitivePayloadKeys name fields whose string value is a credential whatever its shape, e.g. an
+// app-server event echoing request headers. Keys are compared lowercased without '-' and '_', so
+// "X-Api-Key", "api_key" and "apiKey" all match.
+var sensitivePayloadKeys = map[string]bool{
+	"authorization":      true,
+	"proxyauthorization": true,
+	"cookie":             true,
+	"setcookie":          true,
+	"xapikey":            true,
+	"apikey":             true,
+	"xauthtoken":         true,
+	"authtoken":          true,
+	"accesstoken":        true,
+	"refreshtoken":       true,
+	"idtoken":            true,
+	"sessiontoken":       true,
+	"clientsecret":       true,
+	"password":           true,
+	"passwd":             true,
+	"secret":             true,
+	"privatekey":         true,
+}
+
+func isSensitivePayloadKey(k string) bool {
+	k = strings.ToLower(strings.TrimSpace(k))
+	k = strings.NewReplacer("-", "", "_", "").Replace(k)
+	return sensitivePayloadKeys[k]
+}
+
+// redactAny redacts decoded JSON: strings go through redact.Preview and sensitive keys are masked whole.
 func redactAny(v any) (any, []string) {
 	switch x := v.(type) {
 	case string:
-		red, applied := redact.Text(x)
+		red, applied := redact.Preview(x)
 		return red, applied.Names
 	case []any:
 		out := make([]any, len(x))
@@ -257,6 +357,11 @@ func redactAny(v any) (any, []string) {
 		out := make(map[string]any, len(x))
 		var all []string
 		for k, val := range x {
+			if s, ok := val.(string); ok && s != "" && isSensitivePayloadKey(k) {
+				out[k] = sensitiveFieldPlaceholder
+				all = unionStrings(all, []string{sensitiveFieldRule})
+				continue
+			}
 			red, names := redactAny(val)
 			out[k] = red
 			all = unionStrings(all, names)
@@ -288,6 +393,8 @@ type ResultForTrace struct {
 	CapturedStdoutTruncated bool
 	CapturedStderrTruncated bool
 	CaptureMaxBytes         int64
+
+	Warnings []schema.TraceWarningV1
 }
 
 func redactStrings(in []string) ([]string, []string) {
@@ -297,7 +404,7 @@ func redactStrings(in []string) ([]string, []string) {
 	out := make([]string, 0, len(in))
 	var applied []string
 	for _, s := range in {
-		red, a := redact.Text(s)
+		red, a := redact.Preview(s)
 		out = append(out, red)
 		applied = append(applied, a.Names...)
 	}
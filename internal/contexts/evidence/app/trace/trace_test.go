@@ -117,3 +117,40 @@ func hasWarningCode(warnings []schema.TraceWarningV1, code string) bool {
 	}
 	return false
 }
+
+func TestAppendEvent_LinksPrevHashChain(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	env := Env{
+		RunID:     "20260215-180012Z-09c5a6",
+		SuiteID:   "heftiweb-smoke",
+		MissionID: "latest-blog-title",
+		AttemptID: "001-latest-blog-title-r1",
+		OutDirAbs: outDir,
+	}
+	now := time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if err := AppendCLIRunEvent(now, env, []string{"echo", "hi"}, ResultForTrace{ExitCode: 0}); err != nil {
+			t.Fatalf("AppendCLIRunEvent: %v", err)
+		}
+	}
+
+	f, err := os.Open(filepath.Join(outDir, "tool.calls.jsonl"))
+	if err != nil {
+		t.Fatalf("open trace: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	want := schema.TraceChainGenesisV1
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var ev schema.TraceEventV1
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if ev.PrevHash != want {
+			t.Fatalf("prevHash=%q want %q", ev.PrevHash, want)
+		}
+		want = schema.TraceChainLinkV1(ev.PrevHash, sc.Bytes())
+	}
+}
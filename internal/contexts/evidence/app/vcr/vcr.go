@@ -0,0 +1,279 @@
+package vcr
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// Env keys exported to attempts by `zcl suite run --vcr` (or set by hand around `zcl run` / `zcl mcp proxy`).
+const (
+	ModeEnvKey = "ZCL_VCR_MODE"
+	FromEnvKey = "ZCL_VCR_FROM"
+
+	ModeRecord = "record"
+	ModeReplay = "replay"
+)
+
+// ParseMode normalizes a VCR mode; empty means off.
+func ParseMode(raw string) (string, error) {
+	switch m := strings.ToLower(strings.TrimSpace(raw)); m {
+	case "", ModeRecord, ModeReplay:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid vcr mode %q (expected record|replay)", raw)
+	}
+}
+
+// ModeFromEnv returns the VCR mode and replay source exported to the current process.
+func ModeFromEnv() (string, string, error) {
+	mode, err := ParseMode(os.Getenv(ModeEnvKey))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid %s: %w", ModeEnvKey, err)
+	}
+	from := strings.TrimSpace(os.Getenv(FromEnvKey))
+	if mode == ModeReplay && from == "" {
+		return "", "", fmt.Errorf("%s=replay requires %s (cassette file, attempt dir or run dir)", ModeEnvKey, FromEnvKey)
+	}
+	return mode, from, nil
+}
+
+// CLIInput is the redacted canonical input recorded (and keyed) for a cli/exec call.
+func CLIInput(argv []string) (json.RawMessage, []string) {
+	red := make([]string, 0, len(argv))
+	var applied []string
+	for _, s := range argv {
+		r, a := redact.Text(s)
+		red = append(red, r)
+		applied = append(applied, a.Names...)
+	}
+	b, _ := store.CanonicalJSON(map[string]any{"argv": red})
+	return b, applied
+}
+
+// RedactedJSON canonicalizes v and redacts secrets in it; used for mcp tools/call params (the
+// key input) and results. A redaction that breaks JSON structure falls back to a JSON string.
+func RedactedJSON(v any) (json.RawMessage, []string) {
+	b, err := store.CanonicalJSON(v)
+	if err != nil {
+		return nil, nil
+	}
+	red, applied := redact.Text(string(b))
+	if !json.Valid([]byte(red)) {
+		s, _ := store.CanonicalJSON(red)
+		return s, applied.Names
+	}
+	return json.RawMessage(red), applied.Names
+}
+
+// Key identifies a tool call across attempts: sha256 over the canonical {tool, op, input} tuple.
+func Key(tool, op string, input json.RawMessage) string {
+	b, _ := store.CanonicalJSON(map[string]any{"tool": tool, "op": op, "input": input})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Cassette is a loaded tool.cassette.jsonl, indexed by key in recording order.
+type Cassette struct {
+	Path  string
+	byKey map[string][]schema.ToolCassetteEntryV1
+	count int
+}
+
+func Load(path string) (*Cassette, error) {
+	f, err := store.OpenArtifact(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	c := &Cassette{Path: path, byKey: map[string][]schema.ToolCassetteEntryV1{}}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 2*schema.CaptureMaxBytesV1+1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var e schema.ToolCassetteEntryV1
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return nil, fmt.Errorf("%s: invalid cassette line: %w", path, err)
+		}
+		if e.Key == "" {
+			continue
+		}
+		c.byKey[e.Key] = append(c.byKey[e.Key], e)
+		c.count++
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Len returns the number of recorded entries.
+func (c *Cassette) Len() int { return c.count }
+
+// Lookup returns the nth (0-based) recording for key. Calls repeated more often than
+// recorded keep getting the last recording, so polling loops still replay.
+func (c *Cassette) Lookup(key string, occurrence int) (schema.ToolCassetteEntryV1, bool) {
+	entries := c.byKey[key]
+	if len(entries) == 0 {
+		return schema.ToolCassetteEntryV1{}, false
+	}
+	if occurrence < 0 {
+		occurrence = 0
+	}
+	if occurrence >= len(entries) {
+		occurrence = len(entries) - 1
+	}
+	return entries[occurrence], true
+}
+
+// Session is one funnel's view of VCR state for an attempt. Both modes append to the attempt's
+// own tool.cassette.jsonl: recordings in record mode, served entries in replay mode. Replay
+// occurrences are counted from that file, so separate funnel processes agree on ordering.
+type Session struct {
+	Mode       string
+	RecordPath string
+	Source     *Cassette
+
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+// Open prepares a session for attemptDir. In replay mode from is resolved via ResolveSource.
+func Open(attemptDir, missionID, mode, from string) (*Session, error) {
+	mode, err := ParseMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{
+		Mode:       mode,
+		RecordPath: filepath.Join(attemptDir, artifacts.ToolCassetteJSONL),
+		seen:       map[string]int{},
+	}
+	if mode != ModeReplay {
+		return s, nil
+	}
+	src, err := ResolveSource(from, missionID)
+	if err != nil {
+		return nil, err
+	}
+	if sameFile(src, s.RecordPath) {
+		return nil, fmt.Errorf("vcr replay source is the attempt's own cassette (%s)", src)
+	}
+	if s.Source, err = Load(src); err != nil {
+		return nil, err
+	}
+	if fileExists(store.ResolveArtifactPath(s.RecordPath)) {
+		own, err := Load(s.RecordPath)
+		if err != nil {
+			return nil, err
+		}
+		for k, entries := range own.byKey {
+			s.seen[k] = len(entries)
+		}
+	}
+	return s, nil
+}
+
+// Replay serves the next recording for key and logs it to the attempt cassette.
+func (s *Session) Replay(ts string, key string) (schema.ToolCassetteEntryV1, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.Source.Lookup(key, s.seen[key])
+	if !ok {
+		return schema.ToolCassetteEntryV1{}, false, nil
+	}
+	served := e
+	served.TS = ts
+	served.Replayed = true
+	if err := store.AppendJSONL(s.RecordPath, served); err != nil {
+		return schema.ToolCassetteEntryV1{}, false, err
+	}
+	s.seen[key]++
+	return e, true, nil
+}
+
+// Record appends a live recording to the attempt cassette.
+func (s *Session) Record(e schema.ToolCassetteEntryV1) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.V = schema.ToolCassetteSchemaV1
+	return store.AppendJSONL(s.RecordPath, e)
+}
+
+// ResolveSource finds the cassette to replay: a cassette file, an attempt dir holding one, or a
+// run dir (the latest attempt of missionID that recorded a cassette).
+func ResolveSource(from, missionID string) (string, error) {
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return "", fmt.Errorf("missing vcr replay source")
+	}
+	info, err := os.Stat(from)
+	if err != nil {
+		if p := store.ResolveArtifactPath(from); p != from && fileExists(p) {
+			return from, nil
+		}
+		return "", err
+	}
+	if !info.IsDir() {
+		return from, nil
+	}
+	if p := filepath.Join(from, artifacts.ToolCassetteJSONL); fileExists(store.ResolveArtifactPath(p)) {
+		return p, nil
+	}
+	dirs, _ := filepath.Glob(filepath.Join(from, "attempts", "*"))
+	sort.Strings(dirs)
+	for i := len(dirs) - 1; i >= 0; i-- {
+		p := filepath.Join(dirs[i], artifacts.ToolCassetteJSONL)
+		if !fileExists(store.ResolveArtifactPath(p)) {
+			continue
+		}
+		if missionID == "" || attemptMissionID(dirs[i]) == missionID {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("no %s for mission %q under %s", artifacts.ToolCassetteJSONL, missionID, from)
+}
+
+func attemptMissionID(attemptDir string) string {
+	raw, err := store.ReadArtifactFile(filepath.Join(attemptDir, artifacts.AttemptJSON))
+	if err != nil {
+		return ""
+	}
+	var a schema.AttemptJSONV1
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return ""
+	}
+	return a.MissionID
+}
+
+func sameFile(a, b string) bool {
+	ai, err := os.Stat(store.ResolveArtifactPath(a))
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(store.ResolveArtifactPath(b))
+	if err != nil {
+		return false
+	}
+	return os.SameFile(ai, bi)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
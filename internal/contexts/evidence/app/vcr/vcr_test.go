@@ -0,0 +1,92 @@
+package vcr
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestKey_RedactsSecretsBeforeHashing(t *testing.T) {
+	a, _ := CLIInput([]string{"tool", "--token", "ghp_" + "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"})
+	b, applied := CLIInput([]string{"tool", "--token", "ghp_" + "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"})
+	if Key("cli", "exec", a) != Key("cli", "exec", b) {
+		t.Fatalf("expected rotated secrets to share a key: %s vs %s", a, b)
+	}
+	if len(applied) == 0 {
+		t.Fatalf("expected redaction to be reported")
+	}
+	c, _ := CLIInput([]string{"tool", "--other"})
+	if Key("cli", "exec", a) == Key("cli", "exec", c) {
+		t.Fatalf("expected different argv to produce different keys")
+	}
+}
+
+func TestSession_ReplaysInOrderAndResolvesRunDir(t *testing.T) {
+	runDir := t.TempDir()
+	recDir := filepath.Join(runDir, "attempts", "001-m1-r1")
+	writeAttemptJSON(t, recDir, "m1")
+	writeAttemptJSON(t, filepath.Join(runDir, "attempts", "002-m2-r1"), "m2")
+
+	rec, err := Open(recDir, "m1", ModeRecord, "")
+	if err != nil {
+		t.Fatalf("Open record: %v", err)
+	}
+	input, _ := CLIInput([]string{"poll"})
+	key := Key("cli", "exec", input)
+	for _, out := range []string{"pending\n", "done\n"} {
+		code := 0
+		if err := rec.Record(schema.ToolCassetteEntryV1{Key: key, Tool: "cli", Op: "exec", Input: input, ExitCode: &code, Stdout: out}); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	if _, err := ResolveSource(runDir, "m2"); err == nil {
+		t.Fatalf("expected no cassette for m2")
+	}
+	repDir := t.TempDir()
+	writeAttemptJSON(t, repDir, "m1")
+	rep, err := Open(repDir, "m1", ModeReplay, runDir)
+	if err != nil {
+		t.Fatalf("Open replay: %v", err)
+	}
+	var got []string
+	for i := 0; i < 3; i++ {
+		e, ok, err := rep.Replay("2026-03-01T00:00:00Z", key)
+		if err != nil || !ok {
+			t.Fatalf("Replay #%d: ok=%v err=%v", i, ok, err)
+		}
+		got = append(got, e.Stdout)
+	}
+	if got[0] != "pending\n" || got[1] != "done\n" || got[2] != "done\n" {
+		t.Fatalf("unexpected replay order: %q", got)
+	}
+	if _, ok, _ := rep.Replay("2026-03-01T00:00:00Z", Key("cli", "exec", json.RawMessage(`{"argv":["other"]}`))); ok {
+		t.Fatalf("expected miss for unrecorded call")
+	}
+
+	// A fresh session (next funnel process) continues after the entries already served.
+	again, err := Open(repDir, "m1", ModeReplay, runDir)
+	if err != nil {
+		t.Fatalf("Open replay again: %v", err)
+	}
+	if again.seen[key] != 3 {
+		t.Fatalf("expected served count 3 from attempt cassette, got %d", again.seen[key])
+	}
+	if _, err := Open(recDir, "m1", ModeReplay, recDir); err == nil {
+		t.Fatalf("expected replaying an attempt's own cassette to fail")
+	}
+}
+
+func writeAttemptJSON(t *testing.T, dir, missionID string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	b, _ := json.Marshal(schema.AttemptJSONV1{SchemaVersion: schema.AttemptSchemaV1, MissionID: missionID})
+	if err := os.WriteFile(filepath.Join(dir, "attempt.json"), b, 0o644); err != nil {
+		t.Fatalf("write attempt.json: %v", err)
+	}
+}
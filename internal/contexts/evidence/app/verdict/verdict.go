@@ -0,0 +1,105 @@
+package verdict
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// maxReasonBytes bounds the override reason, matching the feedback result bound.
+const maxReasonBytes = schema.FeedbackMaxBytesV1
+
+type OverrideOpts struct {
+	OK     bool
+	Reason string
+	By     string
+}
+
+// Override records verdict.override.json for attemptDir. A later override replaces the verdict in
+// force but the previous one is kept in History, so the file is the full adjudication trail.
+func Override(now time.Time, attemptDir string, opts OverrideOpts) (schema.VerdictOverrideJSONV1, error) {
+	by := strings.TrimSpace(opts.By)
+	if by == "" {
+		return schema.VerdictOverrideJSONV1{}, fmt.Errorf("missing --by")
+	}
+	reason, _ := redact.Text(strings.TrimSpace(opts.Reason))
+	if reason == "" {
+		return schema.VerdictOverrideJSONV1{}, fmt.Errorf("missing --reason")
+	}
+	if len(reason) > maxReasonBytes {
+		return schema.VerdictOverrideJSONV1{}, fmt.Errorf("reason exceeds max bytes (%d)", maxReasonBytes)
+	}
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.AttemptJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return schema.VerdictOverrideJSONV1{}, fmt.Errorf("missing attempt.json in %s", attemptDir)
+		}
+		return schema.VerdictOverrideJSONV1{}, err
+	}
+	var a schema.AttemptJSONV1
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return schema.VerdictOverrideJSONV1{}, fmt.Errorf("invalid attempt.json: %w", err)
+	}
+
+	out := schema.VerdictOverrideJSONV1{
+		SchemaVersion: schema.VerdictOverrideSchemaV1,
+		RunID:         a.RunID,
+		SuiteID:       a.SuiteID,
+		MissionID:     a.MissionID,
+		AttemptID:     a.AttemptID,
+		OK:            opts.OK,
+		Reason:        reason,
+		By:            by,
+		CreatedAt:     now.UTC().Format(time.RFC3339Nano),
+	}
+	prev, ok, err := Load(attemptDir)
+	if err != nil {
+		return schema.VerdictOverrideJSONV1{}, err
+	}
+	if ok {
+		out.OriginalOK = prev.OriginalOK
+		out.History = append(prev.History, schema.VerdictOverrideEntryV1{
+			OK:        prev.OK,
+			Reason:    prev.Reason,
+			By:        prev.By,
+			CreatedAt: prev.CreatedAt,
+		})
+	} else if fb, err := os.ReadFile(filepath.Join(attemptDir, artifacts.FeedbackJSON)); err == nil {
+		var f schema.FeedbackJSONV1
+		if json.Unmarshal(fb, &f) == nil {
+			orig := f.OK
+			out.OriginalOK = &orig
+		}
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(attemptDir, artifacts.VerdictOverrideJSON), out); err != nil {
+		return schema.VerdictOverrideJSONV1{}, err
+	}
+	return out, nil
+}
+
+// Load reads verdict.override.json from attemptDir. ok is false when the file is absent.
+func Load(attemptDir string) (schema.VerdictOverrideJSONV1, bool, error) {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.VerdictOverrideJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return schema.VerdictOverrideJSONV1{}, false, nil
+		}
+		return schema.VerdictOverrideJSONV1{}, false, err
+	}
+	var v schema.VerdictOverrideJSONV1
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return schema.VerdictOverrideJSONV1{}, false, fmt.Errorf("invalid %s: %w", artifacts.VerdictOverrideJSON, err)
+	}
+	if v.SchemaVersion != schema.VerdictOverrideSchemaV1 {
+		return schema.VerdictOverrideJSONV1{}, false, fmt.Errorf("unsupported %s schemaVersion %d", artifacts.VerdictOverrideJSON, v.SchemaVersion)
+	}
+	return v, true, nil
+}
@@ -0,0 +1,55 @@
+package verdict
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestOverride_KeepsHistoryAndOriginalVerdict(t *testing.T) {
+	dir := t.TempDir()
+	attempt, _ := json.Marshal(schema.AttemptJSONV1{SchemaVersion: 1, RunID: "20260222-120000Z-aaaaaa", SuiteID: "s", MissionID: "m1", AttemptID: "001-m1-r1"})
+	if err := os.WriteFile(filepath.Join(dir, "attempt.json"), attempt, 0o644); err != nil {
+		t.Fatalf("write attempt.json: %v", err)
+	}
+	fb, _ := json.Marshal(schema.FeedbackJSONV1{SchemaVersion: 1, OK: true})
+	if err := os.WriteFile(filepath.Join(dir, "feedback.json"), fb, 0o644); err != nil {
+		t.Fatalf("write feedback.json: %v", err)
+	}
+	now := time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC)
+	first, err := Override(now, dir, OverrideOpts{OK: false, Reason: "answer cites wrong file", By: "alice"})
+	if err != nil {
+		t.Fatalf("Override: %v", err)
+	}
+	if first.OK || first.OriginalOK == nil || !*first.OriginalOK || len(first.History) != 0 {
+		t.Fatalf("unexpected first override: %+v", first)
+	}
+	second, err := Override(now.Add(time.Hour), dir, OverrideOpts{OK: true, Reason: "re-checked, file was renamed", By: "bob"})
+	if err != nil {
+		t.Fatalf("Override: %v", err)
+	}
+	if !second.OK || second.By != "bob" || second.OriginalOK == nil || !*second.OriginalOK {
+		t.Fatalf("unexpected second override: %+v", second)
+	}
+	if len(second.History) != 1 || second.History[0].By != "alice" || second.History[0].OK {
+		t.Fatalf("expected first override in history, got %+v", second.History)
+	}
+	got, ok, err := Load(dir)
+	if err != nil || !ok || got.By != "bob" {
+		t.Fatalf("Load: ok=%v err=%v got=%+v", ok, err, got)
+	}
+
+	if _, err := Override(now, dir, OverrideOpts{OK: true, By: "alice"}); err == nil {
+		t.Fatalf("expected missing reason error")
+	}
+	if _, err := Override(now, dir, OverrideOpts{OK: true, Reason: "x"}); err == nil {
+		t.Fatalf("expected missing by error")
+	}
+	if _, err := Override(now, t.TempDir(), OverrideOpts{OK: true, Reason: "x", By: "alice"}); err == nil {
+		t.Fatalf("expected missing attempt.json error")
+	}
+}
@@ -0,0 +1,70 @@
+package attempt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/blind"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// RewritePrompt sanitizes a contaminated prompt.txt in place for blind campaigns whose prompts come
+// from third parties. The untouched prompt is kept as prompt.original.txt and the replacements are
+// recorded in prompt.contamination.json. It reports false when prompt.txt is missing or clean.
+func RewritePrompt(now time.Time, outRoot, attemptDir string, terms []string) (schema.PromptContaminationJSONV1, bool, error) {
+	promptPath := filepath.Join(attemptDir, artifacts.PromptTXT)
+	raw, err := store.ReadArtifactFile(promptPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return schema.PromptContaminationJSONV1{}, false, nil
+		}
+		return schema.PromptContaminationJSONV1{}, false, err
+	}
+	sanitized, counts := blind.SanitizePrompt(string(raw), terms)
+	if len(counts) == 0 {
+		return schema.PromptContaminationJSONV1{}, false, nil
+	}
+	a, err := ReadAttempt(attemptDir)
+	if err != nil {
+		return schema.PromptContaminationJSONV1{}, false, err
+	}
+	rep := schema.PromptContaminationJSONV1{
+		SchemaVersion:   schema.PromptContaminationSchemaV1,
+		RunID:           a.RunID,
+		AttemptID:       a.AttemptID,
+		Action:          blind.ActionRewrite,
+		Terms:           make([]schema.PromptContaminationTermV1, 0, len(counts)),
+		OriginalSHA256:  sha256Hex(raw),
+		SanitizedSHA256: sha256Hex([]byte(sanitized)),
+		CreatedAt:       now.UTC().Format(time.RFC3339Nano),
+	}
+	for term, n := range counts {
+		rep.Terms = append(rep.Terms, schema.PromptContaminationTermV1{Term: term, Count: n})
+		rep.Replacements += n
+	}
+	sort.Slice(rep.Terms, func(i, j int) bool { return rep.Terms[i].Term < rep.Terms[j].Term })
+
+	// Keep the original before replacing prompt.txt so a crash never loses it.
+	if err := store.WriteFileCAS(outRoot, filepath.Join(attemptDir, artifacts.PromptOriginalTXT), raw); err != nil {
+		return schema.PromptContaminationJSONV1{}, false, err
+	}
+	if err := store.WriteFileCAS(outRoot, promptPath, []byte(sanitized)); err != nil {
+		return schema.PromptContaminationJSONV1{}, false, err
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(attemptDir, artifacts.PromptContaminationJSON), rep); err != nil {
+		return schema.PromptContaminationJSONV1{}, false, err
+	}
+	return rep, true, nil
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/provenance"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
@@ -29,7 +30,13 @@ type StartOpts struct {
 	TimeoutStart   string
 	Blind          bool
 	BlindTerms     []string
+	TraceSampling  []schema.TraceSamplingRuleV1
 	SuiteSnapshot  any
+	// ZCLVersion and RuntimeBins feed attempt.json provenance (RuntimeBins: runner/runtime binaries).
+	ZCLVersion  string
+	RuntimeBins []string
+	// FeedbackKeyID pins the key runners must sign feedback.json with (see sigkey.FeedbackKeyEnv).
+	FeedbackKeyID string
 }
 
 type StartResult struct {
@@ -53,7 +60,7 @@ func Start(now time.Time, opts StartOpts) (*StartResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	runID, err := resolveRunID(now, normalized.RunID)
+	runID, err := resolveRunID(now, outRoot, normalized.RunID)
 	if err != nil {
 		return nil, err
 	}
@@ -61,17 +68,24 @@ func Start(now time.Time, opts StartOpts) (*StartResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ensureSuiteSnapshot(runDir, normalized.SuiteSnapshot, runID); err != nil {
-		return nil, err
-	}
-	if err := ensureRunJSON(runDir, runID, normalized.SuiteID, now); err != nil {
-		return nil, err
-	}
-	attemptID, outDir, outDirAbs, err := createAttemptDir(attemptsDir, normalized.MissionID, normalized.Retry)
+	// Other processes may be allocating attempts in the same run (--run-id); the run-level lock
+	// keeps run.json/suite.json creation and attempt numbering from interleaving.
+	var attemptID, outDir, outDirAbs string
+	err = store.WithRunAllocLock(runDir, func() error {
+		if err := ensureSuiteSnapshot(outRoot, runDir, normalized.SuiteSnapshot, runID); err != nil {
+			return err
+		}
+		if err := ensureRunJSON(runDir, runID, normalized.SuiteID, now); err != nil {
+			return err
+		}
+		var err error
+		attemptID, outDir, outDirAbs, err = createAttemptDir(attemptsDir, normalized.MissionID, normalized.Retry)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	if err := writePromptSnapshot(outDir, normalized.Prompt); err != nil {
+	if err := writePromptSnapshot(outRoot, outDir, normalized.Prompt); err != nil {
 		return nil, err
 	}
 	attemptMeta, scratchAbs, err := buildAttemptMeta(now, normalized, runID, attemptID, mode, outRoot)
@@ -143,9 +157,10 @@ func normalizeStartOpts(opts StartOpts) (StartOpts, string, string, error) {
 	return opts, mode, outRoot, nil
 }
 
-func resolveRunID(now time.Time, runID string) (string, error) {
+func resolveRunID(now time.Time, outRoot string, runID string) (string, error) {
 	if runID == "" {
-		return ids.NewRunID(now)
+		runID, _, err := store.AllocateRunDir(outRoot, now)
+		return runID, err
 	}
 	if ids.IsValidRunID(runID) {
 		return runID, nil
@@ -162,7 +177,9 @@ func ensureRunDirs(outRoot string, runID string) (string, string, error) {
 	return runDir, attemptsDir, nil
 }
 
-func ensureSuiteSnapshot(runDir string, suiteSnapshot any, runID string) error {
+// ensureSuiteSnapshot and writePromptSnapshot go through the out-root CAS: every run of a suite and
+// every attempt of a mission would otherwise carry its own identical copy.
+func ensureSuiteSnapshot(outRoot string, runDir string, suiteSnapshot any, runID string) error {
 	if suiteSnapshot == nil {
 		return nil
 	}
@@ -191,7 +208,7 @@ func ensureSuiteSnapshot(runDir string, suiteSnapshot any, runID string) error {
 		return nil
 	}
 	if os.IsNotExist(statErr) {
-		return store.WriteJSONAtomic(suiteJSONPath, v)
+		return store.WriteJSONCAS(outRoot, suiteJSONPath, v)
 	}
 	return statErr
 }
@@ -241,10 +258,18 @@ func createAttemptDir(attemptsDir string, missionID string, retry int) (string,
 	if err != nil {
 		return "", "", "", err
 	}
-	attemptID := ids.NewAttemptID(count+1, missionID, retry)
-	outDir := filepath.Join(attemptsDir, attemptID)
-	if err := os.MkdirAll(outDir, 0o755); err != nil {
-		return "", "", "", err
+	// Exclusive mkdir: an existing dir is never reused, the next index is taken instead.
+	var attemptID, outDir string
+	for index := count + 1; ; index++ {
+		attemptID = ids.NewAttemptID(index, missionID, retry)
+		outDir = filepath.Join(attemptsDir, attemptID)
+		err := os.Mkdir(outDir, 0o755)
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) {
+			return "", "", "", err
+		}
 	}
 	outDirAbs, err := filepath.Abs(outDir)
 	if err != nil {
@@ -253,11 +278,11 @@ func createAttemptDir(attemptsDir string, missionID string, retry int) (string,
 	return attemptID, outDir, outDirAbs, nil
 }
 
-func writePromptSnapshot(outDir string, prompt string) error {
+func writePromptSnapshot(outRoot string, outDir string, prompt string) error {
 	if strings.TrimSpace(prompt) == "" {
 		return nil
 	}
-	return store.WriteFileAtomic(filepath.Join(outDir, artifacts.PromptTXT), []byte(prompt))
+	return store.WriteFileCAS(outRoot, filepath.Join(outDir, artifacts.PromptTXT), []byte(prompt))
 }
 
 func buildAttemptMeta(now time.Time, opts StartOpts, runID string, attemptID string, mode string, outRoot string) (schema.AttemptJSONV1, string, error) {
@@ -273,7 +298,10 @@ func buildAttemptMeta(now time.Time, opts StartOpts, runID string, attemptID str
 		StartedAt:      now.UTC().Format(time.RFC3339Nano),
 		Blind:          opts.Blind,
 		BlindTerms:     append([]string(nil), opts.BlindTerms...),
+		TraceSampling:  append([]schema.TraceSamplingRuleV1(nil), opts.TraceSampling...),
 		AttemptEnvSH:   schema.AttemptEnvShFileNameV1,
+		Provenance:     provenance.Collect(opts.ZCLVersion, opts.RuntimeBins),
+		FeedbackKeyID:  strings.TrimSpace(opts.FeedbackKeyID),
 	}
 	if err := applyAttemptTimeouts(&meta, opts.TimeoutMs, opts.TimeoutStart, mode); err != nil {
 		return schema.AttemptJSONV1{}, "", err
@@ -134,3 +134,31 @@ func TestStart_WritesAttemptEnvFileAndMetadata(t *testing.T) {
 		t.Fatalf("expected attemptEnvSh=%q, got %q", schema.AttemptEnvShFileNameV1, a.AttemptEnvSH)
 	}
 }
+
+func TestStart_RecordsProvenance(t *testing.T) {
+	t.Parallel()
+
+	outRoot := filepath.Join(t.TempDir(), ".zcl")
+	now := time.Date(2026, 2, 15, 18, 0, 12, 0, time.UTC)
+	res, err := Start(now, StartOpts{
+		OutRoot:    outRoot,
+		SuiteID:    "heftiweb-smoke",
+		MissionID:  "latest-blog-title",
+		Retry:      1,
+		ZCLVersion: "1.2.3",
+	})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(res.OutDirAbs, "attempt.json"))
+	if err != nil {
+		t.Fatalf("read attempt.json: %v", err)
+	}
+	var a schema.AttemptJSONV1
+	if err := json.Unmarshal(raw, &a); err != nil {
+		t.Fatalf("decode attempt.json: %v", err)
+	}
+	if a.Provenance == nil || a.Provenance.ZCLVersion != "1.2.3" || a.Provenance.OS == "" || a.Provenance.Arch == "" {
+		t.Fatalf("expected provenance block, got %+v", a.Provenance)
+	}
+}
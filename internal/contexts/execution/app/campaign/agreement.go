@@ -0,0 +1,134 @@
+package campaign
+
+import (
+	"math"
+	"sort"
+)
+
+// JudgeAgreementV1 measures how consistently ensemble evaluators agree across graded attempts.
+type JudgeAgreementV1 struct {
+	Attempts      int                    `json:"attempts"`
+	UnanimousRate float64                `json:"unanimousRate"`
+	Pairs         []JudgePairAgreementV1 `json:"pairs,omitempty"`
+}
+
+// JudgePairAgreementV1 compares two evaluators over the attempts where neither errored.
+// CohensKappa is omitted when chance agreement is total (both evaluators gave one constant verdict).
+type JudgePairAgreementV1 struct {
+	A             string   `json:"a"`
+	B             string   `json:"b"`
+	N             int      `json:"n"`
+	AgreementRate float64  `json:"agreementRate"`
+	CohensKappa   *float64 `json:"cohensKappa,omitempty"`
+}
+
+type pairTally struct {
+	n, agree, aOK, bOK int
+}
+
+// BuildJudgeAgreement returns nil when no mission gate attempt carries ensemble votes.
+func BuildJudgeAgreement(gates []MissionGateV1) *JudgeAgreementV1 {
+	out := &JudgeAgreementV1{}
+	tallies := map[[2]string]*pairTally{}
+	unanimousEligible, unanimous := 0, 0
+	for _, mg := range gates {
+		for _, att := range mg.Attempts {
+			if len(att.OracleVotes) == 0 {
+				continue
+			}
+			out.Attempts++
+			voted := make([]OracleVoteV1, 0, len(att.OracleVotes))
+			for _, v := range att.OracleVotes {
+				if !v.Error {
+					voted = append(voted, v)
+				}
+			}
+			if len(voted) >= 2 {
+				unanimousEligible++
+				if allVotesAgree(voted) {
+					unanimous++
+				}
+			}
+			tallyPairs(tallies, voted)
+		}
+	}
+	if out.Attempts == 0 {
+		return nil
+	}
+	if unanimousEligible > 0 {
+		out.UnanimousRate = roundRate(float64(unanimous) / float64(unanimousEligible))
+	}
+	keys := make([][2]string, 0, len(tallies))
+	for k := range tallies {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		t := tallies[k]
+		out.Pairs = append(out.Pairs, JudgePairAgreementV1{
+			A:             k[0],
+			B:             k[1],
+			N:             t.n,
+			AgreementRate: roundRate(float64(t.agree) / float64(t.n)),
+			CohensKappa:   cohensKappa(*t),
+		})
+	}
+	return out
+}
+
+func tallyPairs(tallies map[[2]string]*pairTally, votes []OracleVoteV1) {
+	for i := 0; i < len(votes); i++ {
+		for j := i + 1; j < len(votes); j++ {
+			a, b := votes[i], votes[j]
+			if a.EvaluatorID > b.EvaluatorID {
+				a, b = b, a
+			}
+			key := [2]string{a.EvaluatorID, b.EvaluatorID}
+			t := tallies[key]
+			if t == nil {
+				t = &pairTally{}
+				tallies[key] = t
+			}
+			t.n++
+			if a.OK == b.OK {
+				t.agree++
+			}
+			if a.OK {
+				t.aOK++
+			}
+			if b.OK {
+				t.bOK++
+			}
+		}
+	}
+}
+
+func allVotesAgree(votes []OracleVoteV1) bool {
+	for _, v := range votes[1:] {
+		if v.OK != votes[0].OK {
+			return false
+		}
+	}
+	return true
+}
+
+func cohensKappa(t pairTally) *float64 {
+	n := float64(t.n)
+	po := float64(t.agree) / n
+	pa, pb := float64(t.aOK)/n, float64(t.bOK)/n
+	pe := pa*pb + (1-pa)*(1-pb)
+	if pe >= 1 {
+		return nil
+	}
+	k := roundRate((po - pe) / (1 - pe))
+	return &k
+}
+
+func roundRate(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
@@ -0,0 +1,48 @@
+package campaign
+
+import "testing"
+
+func TestBuildJudgeAgreement_RatesAndKappa(t *testing.T) {
+	votes := func(a, b bool, cErr bool) []OracleVoteV1 {
+		return []OracleVoteV1{
+			{EvaluatorID: "builtin", OK: a},
+			{EvaluatorID: "llm_judge", OK: b},
+			{EvaluatorID: "script", Error: cErr, OK: a},
+		}
+	}
+	gates := []MissionGateV1{
+		{MissionID: "m1", Attempts: []MissionGateAttemptV1{{FlowID: "a", OracleVotes: votes(true, true, false)}}},
+		{MissionID: "m2", Attempts: []MissionGateAttemptV1{{FlowID: "a", OracleVotes: votes(false, false, false)}}},
+		{MissionID: "m3", Attempts: []MissionGateAttemptV1{{FlowID: "a", OracleVotes: votes(true, false, true)}}},
+		{MissionID: "m4", Attempts: []MissionGateAttemptV1{{FlowID: "a", OracleVotes: votes(true, true, false)}}},
+		{MissionID: "m5", Attempts: []MissionGateAttemptV1{{FlowID: "a"}}},
+	}
+	got := BuildJudgeAgreement(gates)
+	if got == nil {
+		t.Fatalf("expected agreement block")
+	}
+	if got.Attempts != 4 || got.UnanimousRate != 0.75 {
+		t.Fatalf("unexpected totals: %+v", got)
+	}
+	if len(got.Pairs) != 3 {
+		t.Fatalf("expected 3 pairs, got %+v", got.Pairs)
+	}
+	// builtin vs llm_judge: 3/4 agree; p(ok)=0.75 vs 0.5 -> pe=0.5, kappa=0.5.
+	p := got.Pairs[0]
+	if p.A != "builtin" || p.B != "llm_judge" || p.N != 4 || p.AgreementRate != 0.75 || p.CohensKappa == nil || *p.CohensKappa != 0.5 {
+		t.Fatalf("unexpected builtin/llm_judge pair: %+v", p)
+	}
+	// builtin vs script only overlap where script voted, and always agree.
+	p = got.Pairs[1]
+	if p.A != "builtin" || p.B != "script" || p.N != 3 || p.AgreementRate != 1 || p.CohensKappa == nil || *p.CohensKappa != 1 {
+		t.Fatalf("unexpected builtin/script pair: %+v", p)
+	}
+
+	if BuildJudgeAgreement([]MissionGateV1{{MissionID: "m1", Attempts: []MissionGateAttemptV1{{FlowID: "a"}}}}) != nil {
+		t.Fatalf("expected no agreement block without votes")
+	}
+	constant := BuildJudgeAgreement([]MissionGateV1{{MissionID: "m1", Attempts: []MissionGateAttemptV1{{FlowID: "a", OracleVotes: votes(true, true, false)}}}})
+	if constant.Pairs[0].CohensKappa != nil {
+		t.Fatalf("expected kappa to be omitted for constant verdicts: %+v", constant.Pairs[0])
+	}
+}
@@ -0,0 +1,270 @@
+package campaign
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const (
+	InTotoStatementTypeV1   = "https://in-toto.io/Statement/v1"
+	SLSAProvenancePredicate = "https://slsa.dev/provenance/v1"
+	AttestationBuildTypeV1  = "https://github.com/marcohefti/zero-context-lab/campaign/v1"
+	AttestationBuilderID    = "https://github.com/marcohefti/zero-context-lab/zcl"
+)
+
+// AttestationV1 is an in-toto Statement whose subject is campaign.summary.json and whose predicate
+// is SLSA provenance v1 describing the inputs (spec, suites) and toolchain that produced it.
+type AttestationV1 struct {
+	Type          string                 `json:"_type"`
+	Subject       []ResourceDescriptorV1 `json:"subject"`
+	PredicateType string                 `json:"predicateType"`
+	Predicate     AttestationPredicateV1 `json:"predicate"`
+}
+
+type ResourceDescriptorV1 struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+type AttestationPredicateV1 struct {
+	BuildDefinition AttestationBuildDefinitionV1 `json:"buildDefinition"`
+	RunDetails      AttestationRunDetailsV1      `json:"runDetails"`
+}
+
+type AttestationBuildDefinitionV1 struct {
+	BuildType            string                 `json:"buildType"`
+	ExternalParameters   AttestationExternalV1  `json:"externalParameters"`
+	InternalParameters   AttestationInternalV1  `json:"internalParameters"`
+	ResolvedDependencies []ResourceDescriptorV1 `json:"resolvedDependencies"`
+}
+
+type AttestationExternalV1 struct {
+	CampaignID    string `json:"campaignId"`
+	SpecPath      string `json:"specPath"`
+	SpecSHA256    string `json:"specSha256"`
+	TotalMissions int    `json:"totalMissions"`
+}
+
+type AttestationInternalV1 struct {
+	Flows []AttestationFlowV1 `json:"flows"`
+}
+
+// AttestationFlowV1 pins one flow: its suite digest, the suite run's comparability key and the
+// native runtimes (with versions when reported) its attempts ran on.
+type AttestationFlowV1 struct {
+	FlowID           string                 `json:"flowId"`
+	RunnerType       string                 `json:"runnerType"`
+	RunID            string                 `json:"runId,omitempty"`
+	SuiteFile        string                 `json:"suiteFile"`
+	SuiteSHA256      string                 `json:"suiteSha256"`
+	ComparabilityKey string                 `json:"comparabilityKey,omitempty"`
+	Runtimes         []AttestationRuntimeV1 `json:"runtimes,omitempty"`
+}
+
+type AttestationRuntimeV1 struct {
+	RuntimeID string `json:"runtimeId"`
+	Version   string `json:"version,omitempty"`
+}
+
+type AttestationRunDetailsV1 struct {
+	Builder    AttestationBuilderV1   `json:"builder"`
+	Metadata   AttestationMetadataV1  `json:"metadata"`
+	Byproducts []ResourceDescriptorV1 `json:"byproducts,omitempty"`
+}
+
+type AttestationBuilderV1 struct {
+	ID string `json:"id"`
+	// Version maps "zcl" and each native runtime id to the version that produced the results.
+	Version map[string]string `json:"version"`
+}
+
+type AttestationMetadataV1 struct {
+	InvocationID string `json:"invocationId"`
+	StartedOn    string `json:"startedOn,omitempty"`
+	FinishedOn   string `json:"finishedOn,omitempty"`
+}
+
+func AttestationPath(outRoot string, campaignID string) string {
+	return filepath.Join(outRoot, "campaigns", campaignID, artifacts.CampaignAttestationJSON)
+}
+
+// BuildAttestation hashes the campaign summary, spec and suite files referenced by st. Missing
+// inputs are an error: an attestation with holes would claim provenance it cannot back.
+func BuildAttestation(st RunStateV1, zclVersion string) (AttestationV1, error) {
+	summaryPath := SummaryPath(st.OutRoot, st.CampaignID)
+	summarySum, err := fileSHA256(summaryPath)
+	if err != nil {
+		return AttestationV1{}, fmt.Errorf("campaign summary: %w", err)
+	}
+	specSum, err := fileSHA256(st.SpecPath)
+	if err != nil {
+		return AttestationV1{}, fmt.Errorf("campaign spec: %w", err)
+	}
+
+	versions := map[string]string{"zcl": strings.TrimSpace(zclVersion)}
+	deps := []ResourceDescriptorV1{fileResource(st.SpecPath, specSum)}
+	seenDeps := map[string]bool{st.SpecPath: true}
+	flows := make([]AttestationFlowV1, 0, len(st.FlowRuns))
+	for _, fr := range st.FlowRuns {
+		suiteSum, err := fileSHA256(fr.SuiteFile)
+		if err != nil {
+			return AttestationV1{}, fmt.Errorf("flow %s suite: %w", fr.FlowID, err)
+		}
+		if !seenDeps[fr.SuiteFile] {
+			seenDeps[fr.SuiteFile] = true
+			deps = append(deps, fileResource(fr.SuiteFile, suiteSum))
+		}
+		flow := AttestationFlowV1{
+			FlowID:           fr.FlowID,
+			RunnerType:       fr.RunnerType,
+			RunID:            fr.RunID,
+			SuiteFile:        fr.SuiteFile,
+			SuiteSHA256:      suiteSum,
+			ComparabilityKey: suiteRunComparabilityKey(st.OutRoot, fr.RunID),
+			Runtimes:         flowRuntimes(fr),
+		}
+		for _, rt := range flow.Runtimes {
+			if rt.Version != "" {
+				versions[rt.RuntimeID] = rt.Version
+			}
+		}
+		flows = append(flows, flow)
+	}
+
+	var byproducts []ResourceDescriptorV1
+	for _, p := range []string{ReportPath(st.OutRoot, st.CampaignID), ResultsMDPath(st.OutRoot, st.CampaignID)} {
+		if sum, err := fileSHA256(p); err == nil {
+			byproducts = append(byproducts, ResourceDescriptorV1{Name: filepath.Base(p), Digest: map[string]string{"sha256": sum}})
+		}
+	}
+
+	return AttestationV1{
+		Type: InTotoStatementTypeV1,
+		Subject: []ResourceDescriptorV1{{
+			Name:   artifacts.CampaignSummaryJSON,
+			Digest: map[string]string{"sha256": summarySum},
+		}},
+		PredicateType: SLSAProvenancePredicate,
+		Predicate: AttestationPredicateV1{
+			BuildDefinition: AttestationBuildDefinitionV1{
+				BuildType: AttestationBuildTypeV1,
+				ExternalParameters: AttestationExternalV1{
+					CampaignID:    st.CampaignID,
+					SpecPath:      st.SpecPath,
+					SpecSHA256:    specSum,
+					TotalMissions: st.TotalMissions,
+				},
+				InternalParameters:   AttestationInternalV1{Flows: flows},
+				ResolvedDependencies: deps,
+			},
+			RunDetails: AttestationRunDetailsV1{
+				Builder: AttestationBuilderV1{ID: AttestationBuilderID, Version: versions},
+				Metadata: AttestationMetadataV1{
+					InvocationID: st.RunID,
+					StartedOn:    st.StartedAt,
+					FinishedOn:   st.CompletedAt,
+				},
+				Byproducts: byproducts,
+			},
+		},
+	}, nil
+}
+
+// WriteAttestation builds the attestation for st and writes it next to the campaign summary.
+func WriteAttestation(st RunStateV1, zclVersion string) (string, AttestationV1, error) {
+	att, err := BuildAttestation(st, zclVersion)
+	if err != nil {
+		return "", AttestationV1{}, err
+	}
+	path := AttestationPath(st.OutRoot, st.CampaignID)
+	if err := store.WriteJSONAtomic(path, att); err != nil {
+		return "", AttestationV1{}, err
+	}
+	return path, att, nil
+}
+
+func suiteRunComparabilityKey(outRoot, runID string) string {
+	if strings.TrimSpace(runID) == "" {
+		return ""
+	}
+	raw, err := os.ReadFile(filepath.Join(outRoot, "runs", runID, artifacts.SuiteRunSummaryJSON))
+	if err != nil {
+		return ""
+	}
+	var sum struct {
+		ComparabilityKey string `json:"comparabilityKey"`
+	}
+	if err := json.Unmarshal(raw, &sum); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(sum.ComparabilityKey)
+}
+
+func flowRuntimes(fr FlowRunV1) []AttestationRuntimeV1 {
+	seen := map[AttestationRuntimeV1]bool{}
+	var out []AttestationRuntimeV1
+	for _, a := range fr.Attempts {
+		if strings.TrimSpace(a.AttemptDir) == "" {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(a.AttemptDir, artifacts.RunnerRefJSON))
+		if err != nil {
+			continue
+		}
+		var ref schema.RunnerRefJSONV1
+		if err := json.Unmarshal(raw, &ref); err != nil {
+			continue
+		}
+		id := strings.TrimSpace(ref.RuntimeID)
+		if id == "" {
+			id = strings.TrimSpace(ref.Runner)
+		}
+		if id == "" {
+			continue
+		}
+		rt := AttestationRuntimeV1{RuntimeID: id, Version: strings.TrimSpace(ref.RuntimeVersion)}
+		if seen[rt] {
+			continue
+		}
+		seen[rt] = true
+		out = append(out, rt)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].RuntimeID != out[j].RuntimeID {
+			return out[i].RuntimeID < out[j].RuntimeID
+		}
+		return out[i].Version < out[j].Version
+	})
+	return out
+}
+
+func fileResource(path, sum string) ResourceDescriptorV1 {
+	return ResourceDescriptorV1{
+		Name:   filepath.Base(path),
+		URI:    "file://" + filepath.ToSlash(path),
+		Digest: map[string]string{"sha256": sum},
+	}
+}
+
+func fileSHA256(path string) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		return "", fmt.Errorf("missing path")
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,72 @@
+package campaign
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildAttestation_PinsInputsAndRuntimes(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	const runID = "20260222-120000Z-a1b2c3"
+	specPath := filepath.Join(root, "spec", "campaign.yaml")
+	suitePath := filepath.Join(root, "spec", "suite.json")
+	attemptDir := filepath.Join(root, "runs", runID, "attempts", "001-m1-r1")
+	write(specPath, "campaignId: cmp\n")
+	write(suitePath, `{"suiteId":"s"}`)
+	write(SummaryPath(root, "cmp"), `{"ok":true}`)
+	write(filepath.Join(root, "runs", runID, "suite.run.summary.json"), `{"comparabilityKey":"ck-1"}`)
+	write(filepath.Join(attemptDir, "runner.ref.json"), `{"schemaVersion":1,"runner":"codex_app_server","runtimeId":"codex_app_server","runtimeVersion":"0.104.0"}`)
+
+	st := RunStateV1{
+		CampaignID: "cmp",
+		RunID:      "20260222-115900Z-77e1d0",
+		SpecPath:   specPath,
+		OutRoot:    root,
+		FlowRuns: []FlowRunV1{{
+			FlowID:     "flow-a",
+			RunnerType: RunnerTypeCodexAppSrv,
+			SuiteFile:  suitePath,
+			RunID:      runID,
+			Attempts:   []AttemptStatusV1{{AttemptDir: attemptDir}},
+		}},
+	}
+	att, err := BuildAttestation(st, "1.2.3")
+	if err != nil {
+		t.Fatalf("BuildAttestation: %v", err)
+	}
+	want := sha256.Sum256([]byte(`{"ok":true}`))
+	if att.Type != InTotoStatementTypeV1 || len(att.Subject) != 1 || att.Subject[0].Digest["sha256"] != hex.EncodeToString(want[:]) {
+		t.Fatalf("unexpected subject: %+v", att.Subject)
+	}
+	bd := att.Predicate.BuildDefinition
+	if len(bd.ResolvedDependencies) != 2 || bd.ExternalParameters.SpecSHA256 == "" {
+		t.Fatalf("expected spec + suite dependencies, got %+v", bd)
+	}
+	flow := bd.InternalParameters.Flows[0]
+	if flow.ComparabilityKey != "ck-1" || len(flow.Runtimes) != 1 || flow.Runtimes[0].Version != "0.104.0" {
+		t.Fatalf("unexpected flow provenance: %+v", flow)
+	}
+	if v := att.Predicate.RunDetails.Builder.Version; v["zcl"] != "1.2.3" || v["codex_app_server"] != "0.104.0" {
+		t.Fatalf("unexpected builder versions: %+v", v)
+	}
+
+	if err := os.Remove(suitePath); err != nil {
+		t.Fatalf("remove suite: %v", err)
+	}
+	if _, err := BuildAttestation(st, "1.2.3"); err == nil || !strings.Contains(err.Error(), "flow-a") {
+		t.Fatalf("expected missing suite to fail attestation, got %v", err)
+	}
+}
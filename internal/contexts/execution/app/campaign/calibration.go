@@ -0,0 +1,107 @@
+package campaign
+
+import "math"
+
+// calibrationBins is the number of equal-width confidence bins in a calibration curve.
+const calibrationBins = 5
+
+// CalibrationV1 compares the confidence agents claimed in feedback with verified mission gate
+// outcomes for one flow. Overconfidence is meanConfidence minus accuracy (negative when the flow
+// undersells itself); ECE is the attempt-weighted mean |confidence - accuracy| over bins.
+type CalibrationV1 struct {
+	Attempts       int                `json:"attempts"`
+	MeanConfidence float64            `json:"meanConfidence"`
+	Accuracy       float64            `json:"accuracy"`
+	Overconfidence float64            `json:"overconfidence"`
+	BrierScore     float64            `json:"brierScore"`
+	ECE            float64            `json:"ece"`
+	Bins           []CalibrationBinV1 `json:"bins"`
+}
+
+// CalibrationBinV1 covers confidences in [lower, upper); the last bin includes 1.
+type CalibrationBinV1 struct {
+	Lower          float64 `json:"lower"`
+	Upper          float64 `json:"upper"`
+	N              int     `json:"n"`
+	MeanConfidence float64 `json:"meanConfidence,omitempty"`
+	Accuracy       float64 `json:"accuracy,omitempty"`
+}
+
+type calibrationPoint struct {
+	confidence float64
+	ok         bool
+}
+
+// applyFlowCalibration sets Calibration on flows whose gated attempts claimed a confidence.
+// Skipped attempts carry no verified outcome and are left out.
+func applyFlowCalibration(byFlow map[string]*FlowReportV1, gates []MissionGateV1) {
+	points := map[string][]calibrationPoint{}
+	for _, mg := range gates {
+		for _, att := range mg.Attempts {
+			if byFlow[att.FlowID] == nil || att.Confidence == nil || att.Status == AttemptStatusSkipped {
+				continue
+			}
+			points[att.FlowID] = append(points[att.FlowID], calibrationPoint{confidence: *att.Confidence, ok: att.OK})
+		}
+	}
+	for id, pts := range points {
+		byFlow[id].Calibration = buildCalibration(pts)
+	}
+}
+
+func buildCalibration(pts []calibrationPoint) *CalibrationV1 {
+	type tally struct {
+		n       int
+		confSum float64
+		okN     int
+	}
+	bins := make([]tally, calibrationBins)
+	var confSum, brier float64
+	okN := 0
+	for _, p := range pts {
+		c := math.Min(math.Max(p.confidence, 0), 1)
+		outcome := 0.0
+		if p.ok {
+			outcome = 1
+			okN++
+		}
+		confSum += c
+		brier += (c - outcome) * (c - outcome)
+		i := int(c * calibrationBins)
+		if i >= calibrationBins {
+			i = calibrationBins - 1
+		}
+		bins[i].n++
+		bins[i].confSum += c
+		if p.ok {
+			bins[i].okN++
+		}
+	}
+	n := float64(len(pts))
+	out := &CalibrationV1{
+		Attempts:       len(pts),
+		MeanConfidence: roundRate(confSum / n),
+		Accuracy:       roundRate(float64(okN) / n),
+		Overconfidence: roundRate((confSum - float64(okN)) / n),
+		BrierScore:     roundRate(brier / n),
+		Bins:           make([]CalibrationBinV1, 0, calibrationBins),
+	}
+	var ece float64
+	for i, b := range bins {
+		bin := CalibrationBinV1{
+			Lower: roundRate(float64(i) / calibrationBins),
+			Upper: roundRate(float64(i+1) / calibrationBins),
+			N:     b.n,
+		}
+		if b.n > 0 {
+			meanConf := b.confSum / float64(b.n)
+			acc := float64(b.okN) / float64(b.n)
+			bin.MeanConfidence = roundRate(meanConf)
+			bin.Accuracy = roundRate(acc)
+			ece += float64(b.n) / n * math.Abs(meanConf-acc)
+		}
+		out.Bins = append(out.Bins, bin)
+	}
+	out.ECE = roundRate(ece)
+	return out
+}
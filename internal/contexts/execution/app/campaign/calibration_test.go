@@ -0,0 +1,41 @@
+package campaign
+
+import "testing"
+
+func TestBuildReport_FlowCalibration(t *testing.T) {
+	conf := func(v float64) *float64 { return &v }
+	st := RunStateV1{
+		FlowRuns: []FlowRunV1{{FlowID: "a"}, {FlowID: "b"}},
+		MissionGates: []MissionGateV1{
+			{MissionID: "m1", Attempts: []MissionGateAttemptV1{
+				{FlowID: "a", Status: AttemptStatusValid, OK: true, Confidence: conf(0.9)},
+				{FlowID: "b", Status: AttemptStatusValid, OK: true},
+			}},
+			{MissionID: "m2", Attempts: []MissionGateAttemptV1{
+				{FlowID: "a", Status: AttemptStatusInvalid, OK: false, Confidence: conf(0.9)},
+			}},
+			{MissionID: "m3", Attempts: []MissionGateAttemptV1{
+				{FlowID: "a", Status: AttemptStatusValid, OK: true, Confidence: conf(0.3)},
+			}},
+			{MissionID: "m4", Attempts: []MissionGateAttemptV1{
+				{FlowID: "a", Status: AttemptStatusSkipped, OK: false, Confidence: conf(1)},
+			}},
+		},
+	}
+	rep := BuildReport(st)
+	if len(rep.Flows) != 2 || rep.Flows[1].Calibration != nil {
+		t.Fatalf("expected calibration only for flow a: %+v", rep.Flows)
+	}
+	c := rep.Flows[0].Calibration
+	if c == nil {
+		t.Fatalf("expected calibration for flow a")
+	}
+	// (0.9,ok) (0.9,fail) (0.3,ok): mean 0.7, accuracy 2/3, brier (0.01+0.81+0.49)/3.
+	if c.Attempts != 3 || c.MeanConfidence != 0.7 || c.Accuracy != 0.6667 || c.Overconfidence != 0.0333 || c.BrierScore != 0.4367 {
+		t.Fatalf("unexpected calibration totals: %+v", c)
+	}
+	// Bin [0.2,0.4): conf 0.3 acc 1; bin [0.8,1): conf 0.9 acc 0.5 -> ece = (1*0.7 + 2*0.4)/3.
+	if len(c.Bins) != 5 || c.Bins[1].N != 1 || c.Bins[4].N != 2 || c.Bins[4].Accuracy != 0.5 || c.Bins[4].Upper != 1 || c.ECE != 0.5 {
+		t.Fatalf("unexpected calibration bins: %+v", c)
+	}
+}
@@ -0,0 +1,38 @@
+package campaign
+
+// ClaimCountsV1 counts an attempt's claimed answer fields by verification status
+// (claim.vs.verified.json).
+type ClaimCountsV1 struct {
+	Confirmed    int `json:"confirmed"`
+	Refuted      int `json:"refuted"`
+	Unverifiable int `json:"unverifiable"`
+}
+
+// FlowClaimsV1 sums ClaimCountsV1 over a flow's gated attempts. RefutedAttempts counts attempts
+// with at least one refuted claim.
+type FlowClaimsV1 struct {
+	Attempts        int `json:"attempts"`
+	RefutedAttempts int `json:"refutedAttempts"`
+	ClaimCountsV1
+}
+
+func applyFlowClaims(byFlow map[string]*FlowReportV1, gates []MissionGateV1) {
+	for _, mg := range gates {
+		for _, att := range mg.Attempts {
+			cur := byFlow[att.FlowID]
+			if cur == nil || att.Claims == nil {
+				continue
+			}
+			if cur.Claims == nil {
+				cur.Claims = &FlowClaimsV1{}
+			}
+			cur.Claims.Attempts++
+			if att.Claims.Refuted > 0 {
+				cur.Claims.RefutedAttempts++
+			}
+			cur.Claims.Confirmed += att.Claims.Confirmed
+			cur.Claims.Refuted += att.Claims.Refuted
+			cur.Claims.Unverifiable += att.Claims.Unverifiable
+		}
+	}
+}
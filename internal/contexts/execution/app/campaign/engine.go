@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/ports/statestore"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
@@ -45,6 +46,10 @@ type EngineOptions struct {
 	WatchdogHardKillContinue bool
 	LockWait                 time.Duration
 	Now                      func() time.Time
+	// EventHook runs the spec's hooks.onEvent commands; nil skips them.
+	EventHook EventHookExecutor
+	// StateStore persists campaign.run.state.json; nil rewrites the file directly.
+	StateStore statestore.Store
 }
 
 type EngineResult struct {
@@ -133,6 +138,10 @@ type lockedEngine struct {
 	pending  []int
 	seenKeys map[string]bool
 	deadline time.Time
+	// startedAt/pendingAtStart feed run_progress: throughput covers this invocation only, so a
+	// resumed run's rate is not skewed by missions completed earlier.
+	startedAt      time.Time
+	pendingAtStart int
 }
 
 func newLockedEngine(parsed ParsedSpec, exec MissionExecutor, evalGate GateEvaluator, runHook HookExecutor, opts EngineOptions) (*lockedEngine, error) {
@@ -171,11 +180,13 @@ func newLockedEngine(parsed ParsedSpec, exec MissionExecutor, evalGate GateEvalu
 	e.plan = plan
 	e.seenKeys = seenKeys
 	e.pending = pendingMissionIndexes(normalizeMissionIndexes(opts.MissionIndexes, parsed), completed)
+	e.startedAt = now
+	e.pendingAtStart = len(e.pending)
 	e.state.TotalMissions = len(normalizeMissionIndexes(opts.MissionIndexes, parsed))
 	if opts.GlobalTimeoutMs > 0 {
 		e.deadline = now.Add(time.Duration(opts.GlobalTimeoutMs) * time.Millisecond)
 	}
-	if err := SaveRunState(e.statePath, e.state); err != nil {
+	if err := saveRunState(e.opts.StateStore, e.statePath, e.state); err != nil {
 		return nil, err
 	}
 	return e, nil
@@ -226,7 +237,7 @@ func (e *lockedEngine) executeMission(missionIndex int) (EngineResult, bool, err
 	if out, done := e.runAfterMissionHooks(missionIndex, mission.MissionID); done {
 		return out, true, nil
 	}
-	if err := SaveRunState(e.statePath, e.state); err != nil {
+	if err := saveRunState(e.opts.StateStore, e.statePath, e.state); err != nil {
 		return EngineResult{}, false, err
 	}
 	if e.parsed.Spec.PairGate.StopOnFirstMissionFailure && e.state.MissionsCompleted == 1 && !gate.OK {
@@ -372,7 +383,7 @@ func timeoutFlowRun(flow FlowSpec, missionIndex int, missionID string) FlowRunV1
 }
 
 func (e *lockedEngine) appendWatchdogHeartbeat(missionIndex int, missionID, flowID string) {
-	_ = AppendProgress(e.progressPath, ProgressEventV1{
+	e.appendProgress(ProgressEventV1{
 		SchemaVersion: 1,
 		CampaignID:    e.parsed.Spec.CampaignID,
 		RunID:         e.state.RunID,
@@ -394,7 +405,7 @@ func (e *lockedEngine) appendAttemptProgress(missionIndex int, missionID string,
 		for j := range result.Attempts {
 			idempotency := progressKey(e.parsed.Spec.CampaignID, flow.FlowID, missionIndex)
 			e.applyAttemptIdempotency(result, j, idempotency)
-			_ = AppendProgress(e.progressPath, ProgressEventV1{
+			e.appendProgress(ProgressEventV1{
 				SchemaVersion:  1,
 				CampaignID:     e.parsed.Spec.CampaignID,
 				RunID:          e.state.RunID,
@@ -428,7 +439,7 @@ func (e *lockedEngine) recordGateResult(missionIndex int, missionID string, gate
 	e.state.MissionGates = append(e.state.MissionGates, gate)
 	e.state.MissionsCompleted++
 	e.state.UpdatedAt = e.opts.Now().Format(time.RFC3339Nano)
-	_ = AppendProgress(e.progressPath, ProgressEventV1{
+	e.appendProgress(ProgressEventV1{
 		SchemaVersion:  1,
 		CampaignID:     e.parsed.Spec.CampaignID,
 		RunID:          e.state.RunID,
@@ -439,13 +450,32 @@ func (e *lockedEngine) recordGateResult(missionIndex int, missionID string, gate
 		IdempotencyKey: gateProgressKey(e.parsed.Spec.CampaignID, missionIndex),
 		CreatedAt:      e.opts.Now().Format(time.RFC3339Nano),
 	})
+	e.appendRunProgress(missionIndex, missionID)
 	if !gate.OK {
 		e.runFailureHooks(missionIndex, missionID, append([]string{ReasonGateFailed}, gate.Reasons...))
 	}
 }
 
+// appendRunProgress checkpoints throughput after each mission; every mission runs one attempt
+// per flow, and missions run one at a time.
+func (e *lockedEngine) appendRunProgress(missionIndex int, missionID string) {
+	flows := len(e.parsed.Spec.Flows)
+	now := e.opts.Now()
+	progress := ComputeRunProgress(e.state.MissionsCompleted*flows, 0, e.pendingAtStart*flows, now.Sub(e.startedAt))
+	e.appendProgress(ProgressEventV1{
+		SchemaVersion: 1,
+		CampaignID:    e.parsed.Spec.CampaignID,
+		RunID:         e.state.RunID,
+		MissionIndex:  missionIndex,
+		MissionID:     missionID,
+		Status:        ProgressStatusRunProgress,
+		Progress:      &progress,
+		CreatedAt:     now.Format(time.RFC3339Nano),
+	})
+}
+
 func (e *lockedEngine) appendLifecycle(missionIndex int, missionID string, status string, reasonCodes []string) {
-	_ = AppendProgress(e.progressPath, ProgressEventV1{
+	e.appendProgress(ProgressEventV1{
 		SchemaVersion: 1,
 		CampaignID:    e.parsed.Spec.CampaignID,
 		RunID:         e.state.RunID,
@@ -474,7 +504,7 @@ func (e *lockedEngine) abort(reasonCodes []string, exit int) EngineResult {
 	e.state.ReasonCodes = normalizeReasonCodes(append(e.state.ReasonCodes, reasonCodes...))
 	e.state.CompletedAt = e.opts.Now().Format(time.RFC3339Nano)
 	e.state.UpdatedAt = e.state.CompletedAt
-	_ = SaveRunState(e.statePath, e.state)
+	_ = saveRunState(e.opts.StateStore, e.statePath, e.state)
 	return EngineResult{State: e.state, Exit: exit}
 }
 
@@ -495,7 +525,7 @@ func (e *lockedEngine) finish() (EngineResult, error) {
 	}
 	e.state.CompletedAt = e.opts.Now().Format(time.RFC3339Nano)
 	e.state.UpdatedAt = e.state.CompletedAt
-	if err := SaveRunState(e.statePath, e.state); err != nil {
+	if err := saveRunState(e.opts.StateStore, e.statePath, e.state); err != nil {
 		return EngineResult{}, err
 	}
 	if e.state.Status == RunStatusValid {
@@ -166,6 +166,19 @@ func TestExecuteMissionEngine_WatchdogTimeoutContinueAndHeartbeat(t *testing.T)
 	if !strings.Contains(string(raw), `"status":"watchdog_heartbeat"`) {
 		t.Fatalf("expected watchdog heartbeat events in progress jsonl: %s", string(raw))
 	}
+	events, err := LoadProgress(progressPath)
+	if err != nil {
+		t.Fatalf("load progress: %v", err)
+	}
+	var last *RunProgressV1
+	for _, ev := range events {
+		if ev.Status == ProgressStatusRunProgress {
+			last = ev.Progress
+		}
+	}
+	if last == nil || last.Completed != 2 || last.Total != 2 || last.EtaMs == nil || *last.EtaMs != 0 || last.AttemptsPerMin <= 0 {
+		t.Fatalf("expected a final run_progress checkpoint, got %+v", last)
+	}
 }
 
 func TestExecuteMissionEngine_NoPendingMissionsRemainValid(t *testing.T) {
@@ -243,3 +256,90 @@ func TestExecuteMissionEngine_NoPendingMissionsRemainValid(t *testing.T) {
 		t.Fatalf("expected selected-window counters for no-op run, got total=%d completed=%d", res.State.TotalMissions, res.State.MissionsCompleted)
 	}
 }
+
+func TestExecuteMissionEngine_EventHooksRunForMatchingProgress(t *testing.T) {
+	outRoot := t.TempDir()
+	parsed := ParsedSpec{
+		SpecPath: filepath.Join(outRoot, "campaign.yaml"),
+		Spec: SpecV1{
+			SchemaVersion: 1,
+			CampaignID:    "cmp-hooks",
+			Execution:     ExecutionSpec{FlowMode: FlowModeSequence},
+			Flows: []FlowSpec{
+				{
+					FlowID: "flow-a",
+					Runner: RunnerAdapterSpec{Type: RunnerTypeProcessCmd},
+				},
+			},
+			Hooks: HooksSpec{OnEvent: []EventHookSpec{
+				{Match: "gate_fail", Command: []string{"notify"}},
+				{Match: "gate_*", Command: []string{"fail"}},
+			}},
+		},
+		BaseSuite: suite.ParsedSuite{
+			Suite: suite.SuiteFileV1{
+				Version: 1,
+				SuiteID: "suite-a",
+				Missions: []suite.MissionV1{
+					{MissionID: "m1", Prompt: "p1"},
+				},
+			},
+		},
+		MissionIndexes: []int{0},
+	}
+
+	var calls []string
+	now := time.Date(2026, 2, 22, 14, 40, 0, 0, time.UTC)
+	res, err := ExecuteMissionEngine(
+		parsed,
+		noopMissionExecutor{},
+		func(_ ParsedSpec, missionIndex int, missionID string, _ []FlowRunV1) (MissionGateV1, error) {
+			return MissionGateV1{MissionIndex: missionIndex, MissionID: missionID, Reasons: []string{codes.CampaignTimeoutGate}}, nil
+		},
+		nil,
+		EngineOptions{
+			OutRoot:        outRoot,
+			RunID:          "run-hooks-1",
+			MissionIndexes: []int{0},
+			EventHook: func(ctx context.Context, command []string, ev ProgressEventV1) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Fatalf("expected event hook deadline")
+				}
+				calls = append(calls, command[0]+":"+ev.Status)
+				if command[0] == "fail" {
+					return context.DeadlineExceeded
+				}
+				return nil
+			},
+			Now: func() time.Time {
+				now = now.Add(5 * time.Millisecond)
+				return now
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("ExecuteMissionEngine: %v", err)
+	}
+	if res.Exit != 2 {
+		t.Fatalf("expected gate failure to stay the campaign outcome, got exit=%d", res.Exit)
+	}
+	if strings.Join(calls, ",") != "notify:gate_fail,fail:gate_fail" {
+		t.Fatalf("unexpected event hook calls: %v", calls)
+	}
+	events, err := LoadProgress(ProgressPath(outRoot, parsed.Spec.CampaignID))
+	if err != nil {
+		t.Fatalf("load progress: %v", err)
+	}
+	failed := 0
+	for _, ev := range events {
+		if ev.Status == ProgressStatusEventHookFail {
+			failed++
+			if len(ev.ReasonCodes) != 1 || ev.ReasonCodes[0] != ReasonHookFailed || ev.MissionID != "m1" {
+				t.Fatalf("unexpected event_hook_fail event: %+v", ev)
+			}
+		}
+	}
+	if failed != 1 {
+		t.Fatalf("expected one event_hook_fail event, got %d", failed)
+	}
+}
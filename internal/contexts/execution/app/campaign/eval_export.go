@@ -0,0 +1,374 @@
+package campaign
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const (
+	EvalExportFormatInspectAI = "inspect-ai"
+	EvalExportFormatHELM      = "helm"
+
+	// evalExportScorer names the ZCL mission gate verdict in exported scores/stats.
+	evalExportScorer = "zcl_gate"
+)
+
+func EvalExportFormats() []string {
+	return []string{EvalExportFormatInspectAI, EvalExportFormatHELM}
+}
+
+func EvalExportDir(outRoot, campaignID, format string) string {
+	return filepath.Join(CampaignDir(outRoot, campaignID), "export", format)
+}
+
+type EvalExportInput struct {
+	State  RunStateV1
+	Format string
+	// Models maps flowId to the runner model named in the spec; flows without one export
+	// "zcl/<runnerType>".
+	Models     map[string]string
+	ZCLVersion string
+	OutDir     string
+}
+
+type EvalExportResult struct {
+	Format  string   `json:"format"`
+	OutDir  string   `json:"outDir"`
+	Files   []string `json:"files"`
+	Flows   int      `json:"flows"`
+	Samples int      `json:"samples"`
+	Passed  int      `json:"passed"`
+}
+
+// evalExportFlow is one campaign flow (one model/runner in the target format).
+type evalExportFlow struct {
+	FlowID     string
+	RunnerType string
+	Model      string
+	RunID      string
+	Samples    []evalExportSample
+}
+
+type evalExportSample struct {
+	MissionIndex int
+	MissionID    string
+	AttemptID    string
+	AttemptDir   string
+	Status       string
+	OK           bool
+	Prompt       string
+	Answer       string
+	Reasons      []string
+	RubricScore  *float64
+}
+
+// WriteEvalExport maps every flow attempt of the campaign run and its gate verdict into the
+// target format under in.OutDir: one Inspect AI eval log per flow, or one HELM run directory
+// (run_spec.json, scenario_state.json, per_instance_stats.json, stats.json) per flow.
+func WriteEvalExport(in EvalExportInput) (EvalExportResult, error) {
+	format := strings.ToLower(strings.TrimSpace(in.Format))
+	if format != EvalExportFormatInspectAI && format != EvalExportFormatHELM {
+		return EvalExportResult{}, fmt.Errorf("unknown format %q (expected %s)", in.Format, strings.Join(EvalExportFormats(), "|"))
+	}
+	flows := collectEvalExportFlows(in)
+	if len(flows) == 0 {
+		return EvalExportResult{}, fmt.Errorf("campaign run has no flow attempts to export")
+	}
+	res := EvalExportResult{Format: format, OutDir: in.OutDir, Flows: len(flows)}
+	write := func(path string, v any) error {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := store.WriteJSONAtomic(path, v); err != nil {
+			return err
+		}
+		res.Files = append(res.Files, path)
+		return nil
+	}
+	for _, f := range flows {
+		res.Samples += len(f.Samples)
+		for _, s := range f.Samples {
+			if s.OK {
+				res.Passed++
+			}
+		}
+		name := in.State.CampaignID + "_" + f.FlowID
+		if format == EvalExportFormatInspectAI {
+			if err := write(filepath.Join(in.OutDir, name+".json"), inspectEvalLog(in, f)); err != nil {
+				return EvalExportResult{}, err
+			}
+			continue
+		}
+		dir := filepath.Join(in.OutDir, name)
+		spec := helmRunSpec(in.State, f)
+		for _, file := range []struct {
+			name string
+			v    any
+		}{
+			{"run_spec.json", spec},
+			{"scenario_state.json", helmScenarioState(spec, f)},
+			{"per_instance_stats.json", helmPerInstanceStats(f)},
+			{"stats.json", helmStats(f)},
+		} {
+			if err := write(filepath.Join(dir, file.name), file.v); err != nil {
+				return EvalExportResult{}, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// collectEvalExportFlows joins each flow's attempts with the mission gate verdict for that flow;
+// attempts that never reached a gate (skipped, infra_failed) export as failed.
+func collectEvalExportFlows(in EvalExportInput) []evalExportFlow {
+	type gateKey struct {
+		flowID string
+		index  int
+	}
+	gates := map[gateKey]MissionGateAttemptV1{}
+	reasons := map[int][]string{}
+	for _, g := range in.State.MissionGates {
+		reasons[g.MissionIndex] = g.Reasons
+		for _, a := range g.Attempts {
+			gates[gateKey{a.FlowID, g.MissionIndex}] = a
+		}
+	}
+	var out []evalExportFlow
+	for _, fr := range in.State.FlowRuns {
+		f := evalExportFlow{FlowID: fr.FlowID, RunnerType: fr.RunnerType, RunID: fr.RunID, Model: strings.TrimSpace(in.Models[fr.FlowID])}
+		if f.Model == "" {
+			f.Model = "zcl/" + fr.RunnerType
+		}
+		for _, a := range fr.Attempts {
+			s := evalExportSample{
+				MissionIndex: a.MissionIndex,
+				MissionID:    a.MissionID,
+				AttemptID:    a.AttemptID,
+				AttemptDir:   a.AttemptDir,
+				Status:       a.Status,
+				Reasons:      a.Errors,
+			}
+			if g, ok := gates[gateKey{fr.FlowID, a.MissionIndex}]; ok {
+				s.OK, s.Status, s.RubricScore = g.OK, g.Status, g.RubricScore
+				s.Reasons = append(append([]string(nil), g.Errors...), reasons[a.MissionIndex]...)
+			}
+			if dir := strings.TrimSpace(a.AttemptDir); dir != "" {
+				prompt, _ := store.ReadArtifactFile(filepath.Join(dir, artifacts.PromptTXT))
+				s.Prompt = strings.TrimSpace(string(prompt))
+				s.Answer = evalExportAnswer(dir)
+			}
+			f.Samples = append(f.Samples, s)
+		}
+		if len(f.Samples) > 0 {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// evalExportAnswer is feedback.result, or compact feedback.resultJson when the attempt reported JSON.
+func evalExportAnswer(attemptDir string) string {
+	raw, err := store.ReadArtifactFile(filepath.Join(attemptDir, artifacts.FeedbackJSON))
+	if err != nil {
+		return ""
+	}
+	var fb struct {
+		Result     string          `json:"result"`
+		ResultJSON json.RawMessage `json:"resultJson"`
+	}
+	if err := json.Unmarshal(raw, &fb); err != nil {
+		return ""
+	}
+	if len(fb.ResultJSON) > 0 {
+		var b bytes.Buffer
+		if err := json.Compact(&b, fb.ResultJSON); err == nil {
+			return b.String()
+		}
+	}
+	return fb.Result
+}
+
+func evalExportSampleID(s evalExportSample) string {
+	if id := ids.SanitizeComponent(s.MissionID); id != "" {
+		return id
+	}
+	return fmt.Sprintf("mission-%d", s.MissionIndex)
+}
+
+func inspectEvalLog(in EvalExportInput, f evalExportFlow) map[string]any {
+	st := in.State
+	sampleIDs := make([]string, 0, len(f.Samples))
+	samples := make([]map[string]any, 0, len(f.Samples))
+	passed := 0
+	for _, s := range f.Samples {
+		value := "I"
+		if s.OK {
+			value = "C"
+			passed++
+		}
+		id := evalExportSampleID(s)
+		sampleIDs = append(sampleIDs, id)
+		samples = append(samples, map[string]any{
+			"id":     id,
+			"epoch":  1,
+			"input":  s.Prompt,
+			"target": "",
+			"messages": []map[string]any{
+				{"role": "user", "content": s.Prompt, "source": "input"},
+				{"role": "assistant", "content": s.Answer},
+			},
+			"output": map[string]any{
+				"model":   f.Model,
+				"choices": []map[string]any{{"message": map[string]any{"role": "assistant", "content": s.Answer}, "stop_reason": "stop"}},
+			},
+			"scores": map[string]any{
+				evalExportScorer: map[string]any{"value": value, "answer": s.Answer, "explanation": strings.Join(s.Reasons, "; ")},
+			},
+			"metadata": evalExportSampleMetadata(f, s),
+		})
+	}
+	status := "started"
+	if st.CompletedAt != "" {
+		status = "success"
+	}
+	return map[string]any{
+		"version": 2,
+		"status":  status,
+		"eval": map[string]any{
+			"run_id":       st.RunID,
+			"created":      st.StartedAt,
+			"task":         st.CampaignID,
+			"task_id":      st.CampaignID + "/" + f.FlowID,
+			"task_version": 0,
+			"dataset":      map[string]any{"name": st.CampaignID, "samples": len(samples), "sample_ids": sampleIDs},
+			"model":        f.Model,
+			"packages":     map[string]any{"zcl": in.ZCLVersion},
+			"metadata":     map[string]any{"campaignId": st.CampaignID, "flowId": f.FlowID, "runnerType": f.RunnerType, "zclRunId": f.RunID},
+		},
+		"plan": map[string]any{"name": "zcl", "steps": []map[string]any{{"solver": f.RunnerType, "params": map[string]any{}}}},
+		"results": map[string]any{
+			"total_samples":     len(samples),
+			"completed_samples": len(samples),
+			"scores": []map[string]any{{
+				"name":    evalExportScorer,
+				"scorer":  evalExportScorer,
+				"metrics": map[string]any{"accuracy": map[string]any{"name": "accuracy", "value": ratio(passed, len(samples))}},
+			}},
+		},
+		"stats":   map[string]any{"started_at": st.StartedAt, "completed_at": st.CompletedAt},
+		"samples": samples,
+	}
+}
+
+func evalExportSampleMetadata(f evalExportFlow, s evalExportSample) map[string]any {
+	md := map[string]any{"flowId": f.FlowID, "missionIndex": s.MissionIndex, "status": s.Status, "attemptId": s.AttemptID, "attemptDir": s.AttemptDir}
+	if s.RubricScore != nil {
+		md["rubricScore"] = *s.RubricScore
+	}
+	return md
+}
+
+func helmRunSpec(st RunStateV1, f evalExportFlow) map[string]any {
+	return map[string]any{
+		"name":          fmt.Sprintf("zcl:campaign=%s,flow=%s,model=%s", st.CampaignID, f.FlowID, f.Model),
+		"scenario_spec": map[string]any{"class_name": "zcl.campaign", "args": map[string]any{"campaign_id": st.CampaignID, "run_id": st.RunID}},
+		"adapter_spec": map[string]any{
+			"method":              "generation",
+			"model":               f.Model,
+			"model_deployment":    f.RunnerType,
+			"max_train_instances": 0,
+			"num_outputs":         1,
+		},
+		"metric_specs": []map[string]any{{"class_name": "zcl." + evalExportScorer, "args": map[string]any{}}},
+		"groups":       []string{st.CampaignID},
+	}
+}
+
+func helmScenarioState(spec map[string]any, f evalExportFlow) map[string]any {
+	states := make([]map[string]any, 0, len(f.Samples))
+	for _, s := range f.Samples {
+		states = append(states, map[string]any{
+			"instance":          map[string]any{"id": evalExportSampleID(s), "input": map[string]any{"text": s.Prompt}, "references": []any{}, "split": "test"},
+			"train_trial_index": 0,
+			"request":           map[string]any{"model": f.Model, "model_deployment": f.RunnerType, "prompt": s.Prompt},
+			"result": map[string]any{
+				"success":     s.Status != AttemptStatusInfraFailed && s.Status != AttemptStatusSkipped,
+				"completions": []map[string]any{{"text": s.Answer, "logprob": 0, "tokens": []any{}}},
+				"cached":      false,
+			},
+			"num_train_instances": 0,
+			"prompt_truncated":    false,
+		})
+	}
+	return map[string]any{"adapter_spec": spec["adapter_spec"], "request_states": states}
+}
+
+func helmPerInstanceStats(f evalExportFlow) []map[string]any {
+	out := make([]map[string]any, 0, len(f.Samples))
+	for _, s := range f.Samples {
+		v := 0.0
+		if s.OK {
+			v = 1
+		}
+		out = append(out, map[string]any{
+			"instance_id":       evalExportSampleID(s),
+			"train_trial_index": 0,
+			"stats":             []map[string]any{helmStat(evalExportScorer+"_ok", []float64{v})},
+		})
+	}
+	return out
+}
+
+func helmStats(f evalExportFlow) []map[string]any {
+	values := make([]float64, 0, len(f.Samples))
+	for _, s := range f.Samples {
+		if s.OK {
+			values = append(values, 1)
+		} else {
+			values = append(values, 0)
+		}
+	}
+	return []map[string]any{
+		helmStat(evalExportScorer+"_ok", values),
+		helmStat("num_instances", []float64{float64(len(values))}),
+	}
+}
+
+// helmStat renders a HELM Stat (count/sum/sum_squared/min/max/mean/variance/stddev).
+func helmStat(name string, values []float64) map[string]any {
+	st := map[string]any{"name": map[string]any{"name": name, "split": "test"}, "count": len(values)}
+	if len(values) == 0 {
+		return st
+	}
+	sum, sq, lo, hi := 0.0, 0.0, values[0], values[0]
+	for _, v := range values {
+		sum += v
+		sq += v * v
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	mean := sum / float64(len(values))
+	variance := sq/float64(len(values)) - mean*mean
+	if variance < 0 {
+		variance = 0
+	}
+	st["sum"], st["sum_squared"], st["min"], st["max"] = sum, sq, lo, hi
+	st["mean"], st["variance"], st["stddev"] = mean, variance, math.Sqrt(variance)
+	return st
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
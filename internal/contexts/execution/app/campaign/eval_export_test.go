@@ -0,0 +1,138 @@
+package campaign
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteEvalExport_InspectAIAndHELM(t *testing.T) {
+	root := t.TempDir()
+	mkAttempt := func(name string, feedback map[string]any) string {
+		t.Helper()
+		dir := filepath.Join(root, name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		fb, _ := json.Marshal(feedback)
+		if err := os.WriteFile(filepath.Join(dir, "feedback.json"), fb, 0o644); err != nil {
+			t.Fatalf("write feedback: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "prompt.txt"), []byte("solve "+name+"\n"), 0o644); err != nil {
+			t.Fatalf("write prompt: %v", err)
+		}
+		return dir
+	}
+	a1 := mkAttempt("a-m1", map[string]any{"ok": true, "result": "42"})
+	a2 := mkAttempt("a-m2", map[string]any{"ok": true, "resultJson": map[string]any{"x": 1}})
+	b1 := mkAttempt("b-m1", map[string]any{"ok": false, "result": "no"})
+	st := RunStateV1{
+		CampaignID:  "cmp",
+		RunID:       "20260222-120000Z-aaaaaa",
+		StartedAt:   "2026-02-22T12:00:00Z",
+		CompletedAt: "2026-02-22T12:10:00Z",
+		FlowRuns: []FlowRunV1{
+			{FlowID: "alpha", RunnerType: RunnerTypeCodexExec, Attempts: []AttemptStatusV1{
+				{MissionIndex: 0, MissionID: "m1", AttemptDir: a1, Status: AttemptStatusValid},
+				{MissionIndex: 1, MissionID: "m2", AttemptDir: a2, Status: AttemptStatusValid},
+			}},
+			{FlowID: "beta", RunnerType: RunnerTypeProcessCmd, Attempts: []AttemptStatusV1{
+				{MissionIndex: 0, MissionID: "m1", AttemptDir: b1, Status: AttemptStatusValid},
+				{MissionIndex: 1, MissionID: "m2", Status: AttemptStatusInfraFailed, Errors: []string{"ZCL_E_RUNTIME_CRASH"}},
+			}},
+		},
+		MissionGates: []MissionGateV1{
+			{MissionIndex: 0, MissionID: "m1", Attempts: []MissionGateAttemptV1{
+				{FlowID: "alpha", AttemptDir: a1, Status: AttemptStatusValid, OK: true},
+				{FlowID: "beta", AttemptDir: b1, Status: AttemptStatusInvalid, Errors: []string{"wrong answer"}},
+			}},
+			{MissionIndex: 1, MissionID: "m2", Attempts: []MissionGateAttemptV1{
+				{FlowID: "alpha", AttemptDir: a2, Status: AttemptStatusValid, OK: true},
+			}},
+		},
+	}
+
+	out := filepath.Join(root, "inspect")
+	res, err := WriteEvalExport(EvalExportInput{State: st, Format: EvalExportFormatInspectAI, Models: map[string]string{"alpha": "gpt-test"}, ZCLVersion: "1.2.3", OutDir: out})
+	if err != nil {
+		t.Fatalf("WriteEvalExport: %v", err)
+	}
+	if res.Flows != 2 || res.Samples != 4 || res.Passed != 2 || len(res.Files) != 2 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	var log struct {
+		Status string `json:"status"`
+		Eval   struct {
+			Model string `json:"model"`
+		} `json:"eval"`
+		Results struct {
+			Scores []struct {
+				Metrics map[string]struct {
+					Value float64 `json:"value"`
+				} `json:"metrics"`
+			} `json:"scores"`
+		} `json:"results"`
+		Samples []struct {
+			ID     string `json:"id"`
+			Input  string `json:"input"`
+			Scores map[string]struct {
+				Value  string `json:"value"`
+				Answer string `json:"answer"`
+			} `json:"scores"`
+		} `json:"samples"`
+	}
+	readJSON(t, filepath.Join(out, "cmp_alpha.json"), &log)
+	if log.Status != "success" || log.Eval.Model != "gpt-test" || log.Results.Scores[0].Metrics["accuracy"].Value != 1 {
+		t.Fatalf("unexpected alpha log: %+v", log)
+	}
+	if s := log.Samples[1]; s.ID != "m2" || s.Input != "solve a-m2" || s.Scores["zcl_gate"].Value != "C" || s.Scores["zcl_gate"].Answer != `{"x":1}` {
+		t.Fatalf("unexpected alpha sample: %+v", s)
+	}
+	readJSON(t, filepath.Join(out, "cmp_beta.json"), &log)
+	if log.Eval.Model != "zcl/process_cmd" || log.Samples[0].Scores["zcl_gate"].Value != "I" || log.Samples[1].Scores["zcl_gate"].Value != "I" {
+		t.Fatalf("unexpected beta log: %+v", log)
+	}
+
+	helmOut := filepath.Join(root, "helm")
+	if _, err := WriteEvalExport(EvalExportInput{State: st, Format: EvalExportFormatHELM, OutDir: helmOut}); err != nil {
+		t.Fatalf("WriteEvalExport helm: %v", err)
+	}
+	var stats []struct {
+		Name struct {
+			Name string `json:"name"`
+		} `json:"name"`
+		Count int     `json:"count"`
+		Mean  float64 `json:"mean"`
+	}
+	readJSON(t, filepath.Join(helmOut, "cmp_beta", "stats.json"), &stats)
+	if stats[0].Name.Name != "zcl_gate_ok" || stats[0].Count != 2 || stats[0].Mean != 0 {
+		t.Fatalf("unexpected helm stats: %+v", stats)
+	}
+	var state struct {
+		RequestStates []struct {
+			Result struct {
+				Success bool `json:"success"`
+			} `json:"result"`
+		} `json:"request_states"`
+	}
+	readJSON(t, filepath.Join(helmOut, "cmp_beta", "scenario_state.json"), &state)
+	if len(state.RequestStates) != 2 || !state.RequestStates[0].Result.Success || state.RequestStates[1].Result.Success {
+		t.Fatalf("unexpected helm scenario state: %+v", state)
+	}
+
+	if _, err := WriteEvalExport(EvalExportInput{State: st, Format: "csv", OutDir: out}); err == nil {
+		t.Fatalf("expected unknown format error")
+	}
+}
+
+func readJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+}
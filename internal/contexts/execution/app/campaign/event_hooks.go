@@ -0,0 +1,108 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ProgressStatusEventHookFail records a hooks.onEvent command that failed or timed out. Event hooks
+// never fire for it, so a failing hook cannot retrigger itself.
+const ProgressStatusEventHookFail = "event_hook_fail"
+
+// progressStatuses are the campaign.progress.jsonl statuses hooks.onEvent can match exactly.
+var progressStatuses = []string{
+	AttemptStatusValid,
+	AttemptStatusInvalid,
+	AttemptStatusSkipped,
+	AttemptStatusInfraFailed,
+	"gate_pass",
+	"gate_fail",
+	ProgressStatusRunProgress,
+	"watchdog_heartbeat",
+	"cleanup_before_mission_start",
+	"cleanup_before_mission_ok",
+	"cleanup_before_mission_fail",
+	"cleanup_after_mission_start",
+	"cleanup_after_mission_ok",
+	"cleanup_after_mission_fail",
+	"cleanup_on_failure_start",
+	"cleanup_on_failure_ok",
+	"cleanup_on_failure_fail",
+}
+
+var eventHookMatchAliases = map[string]string{
+	"gate_failed": "gate_fail",
+	"gate_passed": "gate_pass",
+}
+
+// EventHookExecutor runs one hooks.onEvent command for the progress event that matched it.
+type EventHookExecutor func(ctx context.Context, command []string, ev ProgressEventV1) error
+
+func normalizeEventHookMatch(raw string) (string, error) {
+	m := strings.ToLower(strings.TrimSpace(raw))
+	if alias, ok := eventHookMatchAliases[m]; ok {
+		m = alias
+	}
+	switch {
+	case m == "":
+		return "", fmt.Errorf("required")
+	case strings.HasSuffix(m, "*"):
+		if strings.Contains(strings.TrimSuffix(m, "*"), "*") {
+			return "", fmt.Errorf("%q: only a trailing * is supported", raw)
+		}
+		return m, nil
+	case slices.Contains(progressStatuses, m):
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown event %q (expected a campaign.progress.jsonl status, prefix* or *)", raw)
+}
+
+func eventHookMatches(match string, status string) bool {
+	if prefix, ok := strings.CutSuffix(match, "*"); ok {
+		return strings.HasPrefix(status, prefix)
+	}
+	return match == status
+}
+
+// appendProgress checkpoints ev and then runs the hooks.onEvent commands matching its status.
+// Hooks run synchronously so they observe the campaign as of their event; a failed hook is
+// recorded as event_hook_fail and does not affect the campaign outcome.
+func (e *lockedEngine) appendProgress(ev ProgressEventV1) {
+	_ = AppendProgress(e.progressPath, ev)
+	if e.opts.EventHook == nil || ev.Status == ProgressStatusEventHookFail {
+		return
+	}
+	for _, h := range e.parsed.Spec.Hooks.OnEvent {
+		if !eventHookMatches(h.Match, ev.Status) {
+			continue
+		}
+		timeout := h.TimeoutMs
+		if timeout <= 0 {
+			timeout = e.opts.CleanupHookTimeoutMs
+		}
+		if timeout <= 0 {
+			timeout = 15000
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Millisecond)
+		err := e.opts.EventHook(ctx, h.Command, ev)
+		cancel()
+		if err == nil {
+			continue
+		}
+		_ = AppendProgress(e.progressPath, ProgressEventV1{
+			SchemaVersion: 1,
+			CampaignID:    ev.CampaignID,
+			RunID:         ev.RunID,
+			MissionIndex:  ev.MissionIndex,
+			MissionID:     ev.MissionID,
+			FlowID:        ev.FlowID,
+			AttemptID:     ev.AttemptID,
+			Status:        ProgressStatusEventHookFail,
+			ReasonCodes:   []string{ReasonHookFailed},
+			CreatedAt:     e.opts.Now().Format(time.RFC3339Nano),
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package campaign
+
+import (
+	"bufio"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
+)
+
+// EvaluateEgress reports ReasonEgressViolation when the attempt trace holds a request the
+// `--network advisory-allowlist` egress proxy refused (http/egress events with ZCL_E_EGRESS_DENIED).
+func EvaluateEgress(attemptDir string) ([]string, error) {
+	f, err := openToolPolicyTrace(attemptDir)
+	if err != nil || f == nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		ev, skip, err := parseToolPolicyTraceLine(sc.Text())
+		if err != nil {
+			return nil, err
+		}
+		if !skip && ev.Tool == "http" && ev.Op == "egress" && ev.Result.Code == codes.EgressDenied {
+			return []string{ReasonEgressViolation}, nil
+		}
+	}
+	return nil, sc.Err()
+}
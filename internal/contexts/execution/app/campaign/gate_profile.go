@@ -4,12 +4,14 @@ import (
 	"bufio"
 	"encoding/json"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
 const (
@@ -46,16 +48,19 @@ type traceProfileStats struct {
 	bootstrapOnly bool
 }
 
-func openTraceProfileFile(attemptDir string) (*os.File, error) {
+func openTraceProfileFile(attemptDir string) (io.ReadCloser, error) {
 	path := filepath.Join(strings.TrimSpace(attemptDir), artifacts.ToolCallsJSONL)
-	f, err := os.Open(path)
-	if err != nil && os.IsNotExist(err) {
-		return nil, nil
+	f, err := store.OpenArtifact(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	return f, err
+	return f, nil
 }
 
-func scanTraceProfileStats(f *os.File) (traceProfileStats, error) {
+func scanTraceProfileStats(f io.Reader) (traceProfileStats, error) {
 	stats := traceProfileStats{bootstrapOnly: true}
 	sc := bufio.NewScanner(f)
 	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
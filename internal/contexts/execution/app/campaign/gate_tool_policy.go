@@ -4,15 +4,21 @@ import (
 	"bufio"
 	"encoding/json"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
+// ToolPolicyEnvKey carries a flow tool policy (canonical JSON) into attempt
+// funnels so `zcl run` and `zcl mcp proxy` can refuse disallowed calls at call time.
+const ToolPolicyEnvKey = "ZCL_TOOL_POLICY"
+
 func EvaluateToolPolicy(policy ToolPolicySpec, attemptDir string) ([]string, error) {
-	if !hasToolPolicyRules(policy) {
+	if !HasToolPolicyRules(policy) {
 		return nil, nil
 	}
 	f, err := openToolPolicyTrace(attemptDir)
@@ -26,11 +32,37 @@ func EvaluateToolPolicy(policy ToolPolicySpec, attemptDir string) ([]string, err
 	return scanToolPolicyTrace(policy, f)
 }
 
-func hasToolPolicyRules(policy ToolPolicySpec) bool {
+func HasToolPolicyRules(policy ToolPolicySpec) bool {
 	return len(policy.Allow) > 0 || len(policy.Deny) > 0
 }
 
-func scanToolPolicyTrace(policy ToolPolicySpec, f *os.File) ([]string, error) {
+// ParseToolPolicyJSON decodes and normalizes a policy previously exported via ToolPolicyEnvKey.
+func ParseToolPolicyJSON(raw []byte) (ToolPolicySpec, error) {
+	var policy ToolPolicySpec
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return ToolPolicySpec{}, err
+	}
+	if err := normalizeToolPolicySpec(&policy); err != nil {
+		return ToolPolicySpec{}, err
+	}
+	return policy, nil
+}
+
+// ToolPolicyPermits reports whether a single call (namespace=cli|mcp|http, target=argv[0]|tool name|url)
+// passes the allow/deny rules. It is the call-time counterpart of EvaluateToolPolicy.
+func ToolPolicyPermits(policy ToolPolicySpec, namespace, target string) bool {
+	if !HasToolPolicyRules(policy) {
+		return true
+	}
+	namespace = strings.ToLower(strings.TrimSpace(namespace))
+	target = strings.ToLower(strings.TrimSpace(target))
+	if !toolPolicyAllowed(policy.Allow, namespace, target, policy.Aliases) {
+		return false
+	}
+	return !toolPolicyDenied(policy.Deny, namespace, target, policy.Aliases)
+}
+
+func scanToolPolicyTrace(policy ToolPolicySpec, f io.Reader) ([]string, error) {
 	sc := bufio.NewScanner(f)
 	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 	for sc.Scan() {
@@ -57,19 +89,19 @@ func evaluateToolPolicyTraceLine(policy ToolPolicySpec, line string) (bool, erro
 	if !actionable {
 		return false, nil
 	}
-	if !toolPolicyAllowed(policy.Allow, namespace, target, policy.Aliases) {
-		return true, nil
-	}
-	return toolPolicyDenied(policy.Deny, namespace, target, policy.Aliases), nil
+	return !ToolPolicyPermits(policy, namespace, target), nil
 }
 
-func openToolPolicyTrace(attemptDir string) (*os.File, error) {
+func openToolPolicyTrace(attemptDir string) (io.ReadCloser, error) {
 	path := filepath.Join(strings.TrimSpace(attemptDir), artifacts.ToolCallsJSONL)
-	f, err := os.Open(path)
-	if err != nil && os.IsNotExist(err) {
-		return nil, nil
+	f, err := store.OpenArtifact(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	return f, err
+	return f, nil
 }
 
 func parseToolPolicyTraceLine(line string) (schema.TraceEventV1, bool, error) {
@@ -108,3 +108,27 @@ func mustJSON(t *testing.T, v any) json.RawMessage {
 	}
 	return b
 }
+
+func TestEvaluateEgress_BlockedRequestFails(t *testing.T) {
+	attemptDir := t.TempDir()
+	writeToolPolicyTraceEvent(t, attemptDir, schema.TraceEventV1{
+		V: 1, TS: "2026-02-22T00:00:00Z", RunID: "r", MissionID: "m", AttemptID: "a", Tool: "http", Op: "request",
+		Input:  mustJSON(t, map[string]any{"method": "GET", "url": "http://api.example.com/"}),
+		Result: schema.TraceResultV1{OK: true},
+	})
+	if reasons, err := EvaluateEgress(attemptDir); err != nil || len(reasons) != 0 {
+		t.Fatalf("expected pass, got reasons=%+v err=%v", reasons, err)
+	}
+	writeToolPolicyTraceEvent(t, attemptDir, schema.TraceEventV1{
+		V: 1, TS: "2026-02-22T00:00:01Z", RunID: "r", MissionID: "m", AttemptID: "a", Tool: "http", Op: "egress",
+		Input:  mustJSON(t, map[string]any{"method": "CONNECT", "url": "https://evil.test:443"}),
+		Result: schema.TraceResultV1{OK: false, Code: "ZCL_E_EGRESS_DENIED"},
+	})
+	reasons, err := EvaluateEgress(attemptDir)
+	if err != nil {
+		t.Fatalf("EvaluateEgress: %v", err)
+	}
+	if len(reasons) != 1 || reasons[0] != ReasonEgressViolation {
+		t.Fatalf("expected egress violation, got reasons=%+v", reasons)
+	}
+}
@@ -0,0 +1,146 @@
+package campaign
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// VerdictOverrideRefV1 records an adjudication on a gated attempt. GateOK is the automated
+// gate verdict the override replaced.
+type VerdictOverrideRefV1 struct {
+	GateOK    bool   `json:"gateOk"`
+	Reason    string `json:"reason"`
+	By        string `json:"by"`
+	CreatedAt string `json:"createdAt"`
+	// Revisions counts earlier overrides kept in the artifact history.
+	Revisions int `json:"revisions,omitempty"`
+}
+
+// VerdictOverrideEntryV1 is one overridden attempt as listed in campaign reports.
+type VerdictOverrideEntryV1 struct {
+	MissionIndex int    `json:"missionIndex"`
+	MissionID    string `json:"missionId"`
+	FlowID       string `json:"flowId"`
+	AttemptID    string `json:"attemptId,omitempty"`
+	AttemptDir   string `json:"attemptDir"`
+	GateOK       bool   `json:"gateOk"`
+	OK           bool   `json:"ok"`
+	Reason       string `json:"reason"`
+	By           string `json:"by"`
+	CreatedAt    string `json:"createdAt"`
+	Revisions    int    `json:"revisions,omitempty"`
+}
+
+// LoadVerdictOverride reads verdict.override.json from attemptDir; ok is false when absent or unreadable.
+func LoadVerdictOverride(attemptDir string) (schema.VerdictOverrideJSONV1, bool) {
+	dir := strings.TrimSpace(attemptDir)
+	if dir == "" {
+		return schema.VerdictOverrideJSONV1{}, false
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, artifacts.VerdictOverrideJSON))
+	if err != nil {
+		return schema.VerdictOverrideJSONV1{}, false
+	}
+	var v schema.VerdictOverrideJSONV1
+	if err := json.Unmarshal(raw, &v); err != nil || v.SchemaVersion != schema.VerdictOverrideSchemaV1 {
+		return schema.VerdictOverrideJSONV1{}, false
+	}
+	return v, true
+}
+
+// ApplyVerdictOverrides returns a copy of gates with every verdict.override.json honored.
+// It is idempotent, so gates that already carry overrides can be passed again.
+func ApplyVerdictOverrides(gates []MissionGateV1) []MissionGateV1 {
+	if len(gates) == 0 {
+		return gates
+	}
+	out := make([]MissionGateV1, 0, len(gates))
+	for _, mg := range gates {
+		out = append(out, ApplyMissionVerdictOverrides(mg))
+	}
+	return out
+}
+
+// ApplyMissionVerdictOverrides replaces overridden attempt verdicts and recomputes the mission gate:
+// an override to fail always fails the mission; overrides to pass only clear a failed mission once
+// every attempt in it passes.
+func ApplyMissionVerdictOverrides(mg MissionGateV1) MissionGateV1 {
+	attempts := make([]MissionGateAttemptV1, len(mg.Attempts))
+	copy(attempts, mg.Attempts)
+	overridden, overriddenFail := false, false
+	for i := range attempts {
+		a := &attempts[i]
+		ov, ok := LoadVerdictOverride(a.AttemptDir)
+		if !ok {
+			continue
+		}
+		gateOK := a.OK
+		if a.VerdictOverride != nil {
+			gateOK = a.VerdictOverride.GateOK
+		}
+		a.OK = ov.OK
+		a.VerdictOverride = &VerdictOverrideRefV1{
+			GateOK:    gateOK,
+			Reason:    ov.Reason,
+			By:        ov.By,
+			CreatedAt: ov.CreatedAt,
+			Revisions: len(ov.History),
+		}
+		overridden = true
+		if !ov.OK {
+			overriddenFail = true
+		}
+	}
+	if !overridden {
+		return mg
+	}
+	mg.Attempts = attempts
+	if overriddenFail {
+		mg.OK = false
+		mg.Reasons = normalizeReasonCodes(append(append([]string(nil), mg.Reasons...), ReasonVerdictOverridden))
+		return mg
+	}
+	if mg.OK {
+		return mg
+	}
+	for _, a := range attempts {
+		if !a.OK {
+			return mg
+		}
+	}
+	mg.OK = true
+	mg.Reasons = nil
+	return mg
+}
+
+// ListVerdictOverrides returns the overridden attempts of gates in mission/flow order.
+func ListVerdictOverrides(gates []MissionGateV1) []VerdictOverrideEntryV1 {
+	var out []VerdictOverrideEntryV1
+	for _, mg := range gates {
+		for _, a := range mg.Attempts {
+			ov := a.VerdictOverride
+			if ov == nil {
+				continue
+			}
+			out = append(out, VerdictOverrideEntryV1{
+				MissionIndex: mg.MissionIndex,
+				MissionID:    mg.MissionID,
+				FlowID:       a.FlowID,
+				AttemptID:    a.AttemptID,
+				AttemptDir:   a.AttemptDir,
+				GateOK:       ov.GateOK,
+				OK:           a.OK,
+				Reason:       ov.Reason,
+				By:           ov.By,
+				CreatedAt:    ov.CreatedAt,
+				Revisions:    ov.Revisions,
+			})
+		}
+	}
+	return out
+}
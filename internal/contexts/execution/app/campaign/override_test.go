@@ -0,0 +1,72 @@
+package campaign
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestApplyVerdictOverrides_RecomputesGates(t *testing.T) {
+	root := t.TempDir()
+	dir := func(name string, ov *schema.VerdictOverrideJSONV1) string {
+		d := filepath.Join(root, name)
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if ov != nil {
+			raw, _ := json.Marshal(ov)
+			if err := os.WriteFile(filepath.Join(d, "verdict.override.json"), raw, 0o644); err != nil {
+				t.Fatalf("write override: %v", err)
+			}
+		}
+		return d
+	}
+	failOv := &schema.VerdictOverrideJSONV1{SchemaVersion: 1, OK: false, Reason: "wrong", By: "alice", CreatedAt: "2026-02-22T12:00:00Z"}
+	passOv := &schema.VerdictOverrideJSONV1{SchemaVersion: 1, OK: true, Reason: "oracle too strict", By: "bob", CreatedAt: "2026-02-22T12:00:00Z",
+		History: []schema.VerdictOverrideEntryV1{{OK: false, Reason: "first look", By: "bob"}}}
+	gates := []MissionGateV1{
+		{MissionIndex: 0, MissionID: "m1", OK: true, Attempts: []MissionGateAttemptV1{
+			{FlowID: "a", AttemptDir: dir("m1a", failOv), Status: AttemptStatusValid, OK: true},
+			{FlowID: "b", AttemptDir: dir("m1b", nil), Status: AttemptStatusValid, OK: true},
+		}},
+		{MissionIndex: 1, MissionID: "m2", OK: false, Reasons: []string{"ZCL_E_X"}, Attempts: []MissionGateAttemptV1{
+			{FlowID: "a", AttemptDir: dir("m2a", passOv), Status: AttemptStatusInvalid, OK: false, Errors: []string{"ZCL_E_X"}},
+		}},
+		{MissionIndex: 2, MissionID: "m3", OK: true, Attempts: []MissionGateAttemptV1{
+			{FlowID: "a", AttemptDir: dir("m3a", nil), Status: AttemptStatusValid, OK: true},
+		}},
+	}
+
+	got := ApplyVerdictOverrides(gates)
+	if got[0].OK || len(got[0].Reasons) != 1 || got[0].Reasons[0] != ReasonVerdictOverridden {
+		t.Fatalf("override to fail should fail the mission gate, got %+v", got[0])
+	}
+	if !got[1].OK || len(got[1].Reasons) != 0 || got[1].Attempts[0].VerdictOverride.Revisions != 1 {
+		t.Fatalf("override to pass should clear the mission gate, got %+v", got[1])
+	}
+	if !got[2].OK || got[2].Attempts[0].VerdictOverride != nil {
+		t.Fatalf("unexpected change to non-overridden gate: %+v", got[2])
+	}
+	if !gates[0].OK || gates[0].Attempts[0].VerdictOverride != nil {
+		t.Fatalf("input gates must not be mutated")
+	}
+	again := ApplyVerdictOverrides(got)
+	if again[0].Attempts[0].VerdictOverride.GateOK != true || len(again[0].Reasons) != 1 {
+		t.Fatalf("re-applying must keep the original gate verdict, got %+v", again[0])
+	}
+
+	rep := BuildReport(RunStateV1{MissionGates: gates})
+	if rep.GatesPassed != 2 || rep.GatesFailed != 1 || len(rep.Overrides) != 2 {
+		t.Fatalf("unexpected report: passed=%d failed=%d overrides=%+v", rep.GatesPassed, rep.GatesFailed, rep.Overrides)
+	}
+	if o := rep.Overrides[0]; o.MissionID != "m1" || !o.GateOK || o.OK || o.By != "alice" {
+		t.Fatalf("unexpected override entry: %+v", o)
+	}
+	sum := BuildSummary(RunStateV1{MissionGates: gates})
+	if sum.VerifiedMissionsOK != 2 || len(sum.Overrides) != 2 {
+		t.Fatalf("unexpected summary: verified=%d overrides=%d", sum.VerifiedMissionsOK, len(sum.Overrides))
+	}
+}
@@ -0,0 +1,394 @@
+package campaign
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	mathrand "math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const RegradeSchemaV1 = 1
+
+// minRegradeIdentifierLen keeps very short ids (e.g. "a") from blanking unrelated words.
+const minRegradeIdentifierLen = 3
+
+const regradeRedacted = "[redacted]"
+
+// RegradeItemsV1 is the grader-facing half of a double-blind export: it carries no flow, runner,
+// model, attempt or run identifiers.
+type RegradeItemsV1 struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	ExportID      string          `json:"exportId"`
+	Items         []RegradeItemV1 `json:"items"`
+}
+
+type RegradeItemV1 struct {
+	BlindID   string `json:"blindId"`
+	MissionID string `json:"missionId"`
+	Prompt    string `json:"prompt,omitempty"`
+	Answer    any    `json:"answer"`
+}
+
+// RegradeMappingV1 is the host-only half of an export; it must not be shown to graders.
+type RegradeMappingV1 struct {
+	SchemaVersion int                     `json:"schemaVersion"`
+	ExportID      string                  `json:"exportId"`
+	CampaignID    string                  `json:"campaignId"`
+	RunID         string                  `json:"runId"`
+	Entries       []RegradeMappingEntryV1 `json:"entries"`
+}
+
+type RegradeMappingEntryV1 struct {
+	BlindID    string `json:"blindId"`
+	FlowID     string `json:"flowId"`
+	MissionID  string `json:"missionId"`
+	AttemptID  string `json:"attemptId,omitempty"`
+	AttemptDir string `json:"attemptDir"`
+	OriginalOK bool   `json:"originalOk"`
+}
+
+// RegradeVerdictsV1 is what graders (humans or a judge) hand back, keyed by blindId.
+type RegradeVerdictsV1 struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	ExportID      string             `json:"exportId"`
+	Verdicts      []RegradeVerdictV1 `json:"verdicts"`
+}
+
+type RegradeVerdictV1 struct {
+	BlindID string `json:"blindId"`
+	OK      bool   `json:"ok"`
+	Reason  string `json:"reason,omitempty"`
+	Grader  string `json:"grader,omitempty"`
+}
+
+// RegradeReportV1 is the merged, de-blinded result written to campaign.regrade.json.
+type RegradeReportV1 struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	CampaignID    string `json:"campaignId"`
+	RunID         string `json:"runId"`
+	ExportID      string `json:"exportId"`
+	UpdatedAt     string `json:"updatedAt"`
+
+	Graded    int      `json:"graded"`
+	Ungraded  []string `json:"ungraded,omitempty"`
+	Flips     int      `json:"flips"`
+	Agreement float64  `json:"agreement"`
+
+	Flows    []RegradeFlowV1    `json:"flows"`
+	Attempts []RegradeAttemptV1 `json:"attempts"`
+}
+
+type RegradeFlowV1 struct {
+	FlowID       string `json:"flowId"`
+	Graded       int    `json:"graded"`
+	OriginalPass int    `json:"originalPass"`
+	RegradedPass int    `json:"regradedPass"`
+	Flips        int    `json:"flips"`
+}
+
+type RegradeAttemptV1 struct {
+	BlindID    string `json:"blindId"`
+	FlowID     string `json:"flowId"`
+	MissionID  string `json:"missionId"`
+	AttemptID  string `json:"attemptId,omitempty"`
+	OriginalOK bool   `json:"originalOk"`
+	RegradedOK bool   `json:"regradedOk"`
+	Reason     string `json:"reason,omitempty"`
+	Grader     string `json:"grader,omitempty"`
+}
+
+type RegradeExportInput struct {
+	State RunStateV1
+	// Models are extra identifiers (e.g. runner models from the spec) to strip from prompts and answers.
+	Models []string
+	// Seed fixes the blind order; 0 picks a random seed.
+	Seed int64
+}
+
+type RegradeExportResult struct {
+	Items   RegradeItemsV1
+	Mapping RegradeMappingV1
+	// Skipped lists attemptDirs without a readable answer.
+	Skipped []string
+}
+
+func RegradeMappingPath(outRoot, campaignID, exportID string) string {
+	return filepath.Join(outRoot, "campaigns", campaignID, "regrade", exportID, artifacts.RegradeMappingJSON)
+}
+
+func RegradeReportPath(outRoot, campaignID string) string {
+	return filepath.Join(outRoot, "campaigns", campaignID, artifacts.CampaignRegradeJSON)
+}
+
+// BuildRegradeExport collects every gated attempt of the campaign run, strips identifying strings
+// from its prompt and answer, and assigns shuffled blind ids.
+func BuildRegradeExport(in RegradeExportInput) (RegradeExportResult, error) {
+	exportID, err := newRegradeExportID()
+	if err != nil {
+		return RegradeExportResult{}, err
+	}
+	st := in.State
+	strip := newIdentifierStripper(regradeIdentifiers(st, in.Models))
+
+	type candidate struct {
+		item  RegradeItemV1
+		entry RegradeMappingEntryV1
+	}
+	var cands []candidate
+	var skipped []string
+	for _, gate := range st.MissionGates {
+		for _, a := range gate.Attempts {
+			dir := strings.TrimSpace(a.AttemptDir)
+			if dir == "" {
+				continue
+			}
+			answer, err := loadRegradeAnswer(dir)
+			if err != nil {
+				skipped = append(skipped, dir)
+				continue
+			}
+			prompt, _ := store.ReadArtifactFile(filepath.Join(dir, artifacts.PromptTXT))
+			cands = append(cands, candidate{
+				item: RegradeItemV1{
+					MissionID: gate.MissionID,
+					Prompt:    strip.text(string(prompt)),
+					Answer:    strip.value(answer),
+				},
+				entry: RegradeMappingEntryV1{
+					FlowID:     a.FlowID,
+					MissionID:  gate.MissionID,
+					AttemptID:  a.AttemptID,
+					AttemptDir: dir,
+					OriginalOK: a.OK,
+				},
+			})
+		}
+	}
+
+	seed := in.Seed
+	if seed == 0 {
+		var b [8]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return RegradeExportResult{}, err
+		}
+		seed = int64(binary.LittleEndian.Uint64(b[:]))
+	}
+	rng := mathrand.New(mathrand.NewSource(seed))
+	rng.Shuffle(len(cands), func(i, j int) { cands[i], cands[j] = cands[j], cands[i] })
+
+	out := RegradeExportResult{
+		Items:   RegradeItemsV1{SchemaVersion: RegradeSchemaV1, ExportID: exportID, Items: make([]RegradeItemV1, 0, len(cands))},
+		Mapping: RegradeMappingV1{SchemaVersion: RegradeSchemaV1, ExportID: exportID, CampaignID: st.CampaignID, RunID: st.RunID},
+		Skipped: skipped,
+	}
+	for i, c := range cands {
+		id := fmt.Sprintf("item-%03d", i+1)
+		c.item.BlindID = id
+		c.entry.BlindID = id
+		out.Items.Items = append(out.Items.Items, c.item)
+		out.Mapping.Entries = append(out.Mapping.Entries, c.entry)
+	}
+	sort.Slice(out.Mapping.Entries, func(i, j int) bool { return out.Mapping.Entries[i].BlindID < out.Mapping.Entries[j].BlindID })
+	return out, nil
+}
+
+// MergeRegrade de-blinds verdicts through the mapping. Unknown or duplicate blind ids are errors;
+// mapping entries without a verdict are reported as ungraded.
+func MergeRegrade(mapping RegradeMappingV1, verdicts RegradeVerdictsV1, updatedAt string) (RegradeReportV1, error) {
+	if mapping.SchemaVersion != RegradeSchemaV1 {
+		return RegradeReportV1{}, fmt.Errorf("unsupported regrade mapping schemaVersion=%d", mapping.SchemaVersion)
+	}
+	if verdicts.SchemaVersion != RegradeSchemaV1 {
+		return RegradeReportV1{}, fmt.Errorf("unsupported regrade verdicts schemaVersion=%d", verdicts.SchemaVersion)
+	}
+	if strings.TrimSpace(verdicts.ExportID) != mapping.ExportID {
+		return RegradeReportV1{}, fmt.Errorf("verdicts exportId %q does not match mapping exportId %q", verdicts.ExportID, mapping.ExportID)
+	}
+	entries := map[string]RegradeMappingEntryV1{}
+	for _, e := range mapping.Entries {
+		entries[e.BlindID] = e
+	}
+	byID := map[string]RegradeVerdictV1{}
+	for _, v := range verdicts.Verdicts {
+		id := strings.TrimSpace(v.BlindID)
+		if _, ok := entries[id]; !ok {
+			return RegradeReportV1{}, fmt.Errorf("verdict for unknown blindId %q", id)
+		}
+		if _, dup := byID[id]; dup {
+			return RegradeReportV1{}, fmt.Errorf("duplicate verdict for blindId %q", id)
+		}
+		byID[id] = v
+	}
+
+	rep := RegradeReportV1{
+		SchemaVersion: RegradeSchemaV1,
+		CampaignID:    mapping.CampaignID,
+		RunID:         mapping.RunID,
+		ExportID:      mapping.ExportID,
+		UpdatedAt:     updatedAt,
+		Flows:         []RegradeFlowV1{},
+		Attempts:      []RegradeAttemptV1{},
+	}
+	flows := map[string]*RegradeFlowV1{}
+	for _, e := range mapping.Entries {
+		v, ok := byID[e.BlindID]
+		if !ok {
+			rep.Ungraded = append(rep.Ungraded, e.BlindID)
+			continue
+		}
+		f := flows[e.FlowID]
+		if f == nil {
+			f = &RegradeFlowV1{FlowID: e.FlowID}
+			flows[e.FlowID] = f
+		}
+		f.Graded++
+		if e.OriginalOK {
+			f.OriginalPass++
+		}
+		if v.OK {
+			f.RegradedPass++
+		}
+		if v.OK != e.OriginalOK {
+			f.Flips++
+			rep.Flips++
+		}
+		rep.Graded++
+		rep.Attempts = append(rep.Attempts, RegradeAttemptV1{
+			BlindID:    e.BlindID,
+			FlowID:     e.FlowID,
+			MissionID:  e.MissionID,
+			AttemptID:  e.AttemptID,
+			OriginalOK: e.OriginalOK,
+			RegradedOK: v.OK,
+			Reason:     strings.TrimSpace(v.Reason),
+			Grader:     strings.TrimSpace(v.Grader),
+		})
+	}
+	for _, f := range flows {
+		rep.Flows = append(rep.Flows, *f)
+	}
+	sort.Slice(rep.Flows, func(i, j int) bool { return rep.Flows[i].FlowID < rep.Flows[j].FlowID })
+	sort.Slice(rep.Attempts, func(i, j int) bool {
+		if rep.Attempts[i].FlowID != rep.Attempts[j].FlowID {
+			return rep.Attempts[i].FlowID < rep.Attempts[j].FlowID
+		}
+		return rep.Attempts[i].MissionID < rep.Attempts[j].MissionID
+	})
+	if rep.Graded > 0 {
+		rep.Agreement = float64(rep.Graded-rep.Flips) / float64(rep.Graded)
+	}
+	return rep, nil
+}
+
+func regradeIdentifiers(st RunStateV1, models []string) []string {
+	var out []string
+	out = append(out, st.RunID)
+	out = append(out, models...)
+	for _, fr := range st.FlowRuns {
+		out = append(out, fr.FlowID, fr.RunnerType, fr.RunID)
+		for _, a := range fr.Attempts {
+			out = append(out, a.AttemptID)
+		}
+	}
+	for _, g := range st.MissionGates {
+		for _, a := range g.Attempts {
+			out = append(out, a.FlowID, a.AttemptID)
+		}
+	}
+	return out
+}
+
+type identifierStripper struct {
+	re *regexp.Regexp
+}
+
+func newIdentifierStripper(ids []string) identifierStripper {
+	seen := map[string]bool{}
+	var quoted []string
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if len(id) < minRegradeIdentifierLen || seen[strings.ToLower(id)] {
+			continue
+		}
+		seen[strings.ToLower(id)] = true
+		quoted = append(quoted, regexp.QuoteMeta(id))
+	}
+	if len(quoted) == 0 {
+		return identifierStripper{}
+	}
+	// Longest first so "codex-app" is not half-replaced by "codex".
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return identifierStripper{re: regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))}
+}
+
+func (s identifierStripper) text(in string) string {
+	if s.re == nil {
+		return in
+	}
+	return s.re.ReplaceAllString(in, regradeRedacted)
+}
+
+func (s identifierStripper) value(v any) any {
+	switch x := v.(type) {
+	case string:
+		return s.text(x)
+	case []any:
+		out := make([]any, len(x))
+		for i := range x {
+			out[i] = s.value(x[i])
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for k, val := range x {
+			out[s.text(k)] = s.value(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// loadRegradeAnswer mirrors the oracle evaluator's answer extraction: resultJson wins, then result.
+func loadRegradeAnswer(attemptDir string) (any, error) {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.FeedbackJSON))
+	if err != nil {
+		return nil, err
+	}
+	var fb struct {
+		Result     string          `json:"result"`
+		ResultJSON json.RawMessage `json:"resultJson"`
+	}
+	if err := json.Unmarshal(raw, &fb); err != nil {
+		return nil, fmt.Errorf("feedback json is invalid")
+	}
+	var answer any
+	if len(fb.ResultJSON) > 0 {
+		if err := json.Unmarshal(fb.ResultJSON, &answer); err != nil {
+			return nil, fmt.Errorf("feedback.resultJson must be valid json")
+		}
+		return answer, nil
+	}
+	if strings.TrimSpace(fb.Result) == "" {
+		return nil, fmt.Errorf("feedback.result is empty")
+	}
+	return fb.Result, nil
+}
+
+func newRegradeExportID() (string, error) {
+	var b [6]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return "rg-" + hex.EncodeToString(b[:]), nil
+}
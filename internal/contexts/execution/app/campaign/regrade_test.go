@@ -0,0 +1,84 @@
+package campaign
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildRegradeExport_StripsIdentifiersAndMergesBack(t *testing.T) {
+	root := t.TempDir()
+	mkAttempt := func(name, result string) string {
+		t.Helper()
+		dir := filepath.Join(root, name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		fb, _ := json.Marshal(map[string]any{"ok": true, "result": result})
+		if err := os.WriteFile(filepath.Join(dir, "feedback.json"), fb, 0o644); err != nil {
+			t.Fatalf("write feedback: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "prompt.txt"), []byte("solve m1"), 0o644); err != nil {
+			t.Fatalf("write prompt: %v", err)
+		}
+		return dir
+	}
+	dirA := mkAttempt("001-m1-r1-a", "answer from flow-alpha using gpt-test-1")
+	dirB := mkAttempt("001-m1-r1-b", "plain answer")
+	st := RunStateV1{
+		CampaignID: "cmp",
+		RunID:      "20260222-120000Z-aaaaaa",
+		FlowRuns:   []FlowRunV1{{FlowID: "flow-alpha", RunnerType: RunnerTypeProcessCmd}, {FlowID: "flow-beta", RunnerType: RunnerTypeProcessCmd}},
+		MissionGates: []MissionGateV1{{MissionID: "m1", Attempts: []MissionGateAttemptV1{
+			{FlowID: "flow-alpha", AttemptID: "001-m1-r1", AttemptDir: dirA, OK: true},
+			{FlowID: "flow-beta", AttemptID: "001-m1-r1", AttemptDir: dirB, OK: false},
+			{FlowID: "flow-beta", AttemptID: "002-m2-r1", AttemptDir: filepath.Join(root, "missing")},
+		}}},
+	}
+	exp, err := BuildRegradeExport(RegradeExportInput{State: st, Models: []string{"gpt-test-1"}, Seed: 7})
+	if err != nil {
+		t.Fatalf("BuildRegradeExport: %v", err)
+	}
+	if len(exp.Items.Items) != 2 || len(exp.Skipped) != 1 {
+		t.Fatalf("expected 2 items and 1 skipped, got %+v", exp)
+	}
+	raw, _ := json.Marshal(exp.Items)
+	for _, leak := range []string{"flow-alpha", "flow-beta", "gpt-test-1", "001-m1-r1", st.RunID} {
+		if strings.Contains(string(raw), leak) {
+			t.Fatalf("export leaks identifier %q: %s", leak, raw)
+		}
+	}
+
+	alphaBlind := ""
+	for _, e := range exp.Mapping.Entries {
+		if e.FlowID == "flow-alpha" {
+			alphaBlind = e.BlindID
+		}
+	}
+	verdicts := RegradeVerdictsV1{SchemaVersion: RegradeSchemaV1, ExportID: exp.Items.ExportID}
+	for _, it := range exp.Items.Items {
+		verdicts.Verdicts = append(verdicts.Verdicts, RegradeVerdictV1{BlindID: it.BlindID, OK: it.BlindID != alphaBlind, Grader: "human"})
+	}
+	rep, err := MergeRegrade(exp.Mapping, verdicts, "2026-02-22T12:00:00Z")
+	if err != nil {
+		t.Fatalf("MergeRegrade: %v", err)
+	}
+	if rep.Graded != 2 || rep.Flips != 2 || rep.Agreement != 0 || len(rep.Flows) != 2 {
+		t.Fatalf("unexpected regrade report: %+v", rep)
+	}
+	if rep.Flows[0].FlowID != "flow-alpha" || rep.Flows[0].OriginalPass != 1 || rep.Flows[0].RegradedPass != 0 {
+		t.Fatalf("unexpected alpha flow: %+v", rep.Flows[0])
+	}
+
+	verdicts.Verdicts = append(verdicts.Verdicts, RegradeVerdictV1{BlindID: "item-999"})
+	if _, err := MergeRegrade(exp.Mapping, verdicts, ""); err == nil {
+		t.Fatalf("expected unknown blindId error")
+	}
+	verdicts.Verdicts = verdicts.Verdicts[:1]
+	verdicts.ExportID = "rg-other"
+	if _, err := MergeRegrade(exp.Mapping, verdicts, ""); err == nil {
+		t.Fatalf("expected exportId mismatch error")
+	}
+}
@@ -0,0 +1,157 @@
+package campaign
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// ReviewCandidateV1 is one gated attempt in the manual review queue.
+type ReviewCandidateV1 struct {
+	MissionIndex int      `json:"missionIndex"`
+	MissionID    string   `json:"missionId"`
+	FlowID       string   `json:"flowId"`
+	AttemptID    string   `json:"attemptId,omitempty"`
+	AttemptDir   string   `json:"attemptDir"`
+	Status       string   `json:"status"`
+	GateOK       bool     `json:"gateOk"`
+	Errors       []string `json:"errors,omitempty"`
+	// Why lists the signals that put the attempt in the queue.
+	Why []string `json:"why,omitempty"`
+}
+
+// ReviewSummaryV1 folds recorded review.json verdicts into campaign reporting.
+// Confirmed/Overturned compare the reviewer's verdict with the automated gate verdict.
+type ReviewSummaryV1 struct {
+	Reviewed   int                 `json:"reviewed"`
+	Confirmed  int                 `json:"confirmed"`
+	Overturned int                 `json:"overturned"`
+	Pending    int                 `json:"pending"`
+	Attempts   []ReviewedAttemptV1 `json:"attempts,omitempty"`
+}
+
+type ReviewedAttemptV1 struct {
+	MissionID string `json:"missionId"`
+	FlowID    string `json:"flowId"`
+	AttemptID string `json:"attemptId,omitempty"`
+	GateOK    bool   `json:"gateOk"`
+	ReviewOK  bool   `json:"reviewOk"`
+	Reviewer  string `json:"reviewer"`
+}
+
+// LoadAttemptReview reads review.json from attemptDir; ok is false when absent or unreadable.
+func LoadAttemptReview(attemptDir string) (schema.ReviewJSONV1, bool) {
+	dir := strings.TrimSpace(attemptDir)
+	if dir == "" {
+		return schema.ReviewJSONV1{}, false
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, artifacts.ReviewJSON))
+	if err != nil {
+		return schema.ReviewJSONV1{}, false
+	}
+	var rv schema.ReviewJSONV1
+	if err := json.Unmarshal(raw, &rv); err != nil || rv.SchemaVersion != schema.ReviewSchemaV1 {
+		return schema.ReviewJSONV1{}, false
+	}
+	return rv, true
+}
+
+// ReviewQueue returns unreviewed gated attempts in mission/flow order. Without all, only attempts
+// with a failing gate verdict, gate errors, a non-valid status or split ensemble votes are queued.
+func ReviewQueue(gates []MissionGateV1, all bool) (pending []ReviewCandidateV1, reviewed int) {
+	for _, mg := range gates {
+		for _, a := range mg.Attempts {
+			if strings.TrimSpace(a.AttemptDir) == "" {
+				continue
+			}
+			if _, ok := LoadAttemptReview(a.AttemptDir); ok {
+				reviewed++
+				continue
+			}
+			why := reviewSignals(a)
+			if len(why) == 0 && !all {
+				continue
+			}
+			pending = append(pending, ReviewCandidateV1{
+				MissionIndex: mg.MissionIndex,
+				MissionID:    mg.MissionID,
+				FlowID:       a.FlowID,
+				AttemptID:    a.AttemptID,
+				AttemptDir:   a.AttemptDir,
+				Status:       a.Status,
+				GateOK:       a.OK,
+				Errors:       normalizeReasonCodes(a.Errors),
+				Why:          why,
+			})
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].MissionIndex != pending[j].MissionIndex {
+			return pending[i].MissionIndex < pending[j].MissionIndex
+		}
+		return pending[i].FlowID < pending[j].FlowID
+	})
+	return pending, reviewed
+}
+
+// BuildReviewSummary returns nil until at least one attempt has been reviewed.
+func BuildReviewSummary(gates []MissionGateV1) *ReviewSummaryV1 {
+	out := &ReviewSummaryV1{}
+	for _, mg := range gates {
+		for _, a := range mg.Attempts {
+			rv, ok := LoadAttemptReview(a.AttemptDir)
+			if !ok {
+				if strings.TrimSpace(a.AttemptDir) != "" && len(reviewSignals(a)) > 0 {
+					out.Pending++
+				}
+				continue
+			}
+			out.Reviewed++
+			if rv.OK == a.OK {
+				out.Confirmed++
+			} else {
+				out.Overturned++
+			}
+			out.Attempts = append(out.Attempts, ReviewedAttemptV1{
+				MissionID: mg.MissionID,
+				FlowID:    a.FlowID,
+				AttemptID: a.AttemptID,
+				GateOK:    a.OK,
+				ReviewOK:  rv.OK,
+				Reviewer:  rv.Reviewer,
+			})
+		}
+	}
+	if out.Reviewed == 0 {
+		return nil
+	}
+	return out
+}
+
+func reviewSignals(a MissionGateAttemptV1) []string {
+	var why []string
+	if !a.OK {
+		why = append(why, "gate_failed")
+	}
+	if a.Status != "" && a.Status != AttemptStatusValid {
+		why = append(why, "status_"+a.Status)
+	}
+	if len(a.Errors) > 0 {
+		why = append(why, "gate_errors")
+	}
+	voted := make([]OracleVoteV1, 0, len(a.OracleVotes))
+	for _, v := range a.OracleVotes {
+		if !v.Error {
+			voted = append(voted, v)
+		}
+	}
+	if len(voted) >= 2 && !allVotesAgree(voted) {
+		why = append(why, "split_votes")
+	}
+	return why
+}
@@ -0,0 +1,56 @@
+package campaign
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReviewQueue_SignalsAndSummary(t *testing.T) {
+	root := t.TempDir()
+	dir := func(name string) string {
+		d := filepath.Join(root, name)
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		return d
+	}
+	okDir, failDir, splitDir := dir("ok"), dir("fail"), dir("split")
+	gates := []MissionGateV1{
+		{MissionIndex: 1, MissionID: "m2", Attempts: []MissionGateAttemptV1{
+			{FlowID: "b", AttemptDir: splitDir, Status: AttemptStatusValid, OK: true, OracleVotes: []OracleVoteV1{{EvaluatorID: "x", OK: true}, {EvaluatorID: "y", OK: false}}},
+		}},
+		{MissionIndex: 0, MissionID: "m1", Attempts: []MissionGateAttemptV1{
+			{FlowID: "a", AttemptDir: okDir, Status: AttemptStatusValid, OK: true},
+			{FlowID: "b", AttemptDir: failDir, Status: AttemptStatusInvalid, OK: false, Errors: []string{"ZCL_E_X"}},
+		}},
+	}
+
+	pending, reviewed := ReviewQueue(gates, false)
+	if reviewed != 0 || len(pending) != 2 || pending[0].AttemptDir != failDir || pending[1].AttemptDir != splitDir {
+		t.Fatalf("unexpected queue: reviewed=%d pending=%+v", reviewed, pending)
+	}
+	if got := pending[0].Why; len(got) != 3 || got[0] != "gate_failed" {
+		t.Fatalf("unexpected signals: %v", got)
+	}
+	if all, _ := ReviewQueue(gates, true); len(all) != 3 {
+		t.Fatalf("expected --all to queue every attempt, got %d", len(all))
+	}
+	if BuildReviewSummary(gates) != nil {
+		t.Fatalf("expected no review summary before any review")
+	}
+
+	raw, _ := json.Marshal(map[string]any{"schemaVersion": 1, "ok": true, "reviewer": "alice", "reviewedAt": "2026-02-22T12:00:00Z"})
+	if err := os.WriteFile(filepath.Join(failDir, "review.json"), raw, 0o644); err != nil {
+		t.Fatalf("write review: %v", err)
+	}
+	pending, reviewed = ReviewQueue(gates, false)
+	if reviewed != 1 || len(pending) != 1 {
+		t.Fatalf("expected reviewed attempt to leave the queue, got reviewed=%d pending=%d", reviewed, len(pending))
+	}
+	sum := BuildReviewSummary(gates)
+	if sum == nil || sum.Reviewed != 1 || sum.Overturned != 1 || sum.Pending != 1 || sum.Attempts[0].Reviewer != "alice" {
+		t.Fatalf("unexpected review summary: %+v", sum)
+	}
+}
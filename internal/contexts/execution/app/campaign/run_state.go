@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/ports/statestore"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
@@ -90,6 +91,22 @@ type MissionGateAttemptV1 struct {
 	Status     string   `json:"status"`
 	OK         bool     `json:"ok"`
 	Errors     []string `json:"errors,omitempty"`
+	// OracleVotes holds per-member verdicts when evaluation.evaluators defines an ensemble.
+	OracleVotes []OracleVoteV1 `json:"oracleVotes,omitempty"`
+	// RubricScore is the weighted rubric score in [0,1] when evaluation.rubric is configured.
+	RubricScore *float64 `json:"rubricScore,omitempty"`
+	// Confidence is the claimed feedback confidence in [0,1], when the agent reported one.
+	Confidence *float64 `json:"confidence,omitempty"`
+	// Claims counts claim.vs.verified.json statuses when an oracle evaluated the attempt.
+	Claims *ClaimCountsV1 `json:"claims,omitempty"`
+	// VerdictOverride is set when verdict.override.json adjudicated the attempt; OK then reflects the override.
+	VerdictOverride *VerdictOverrideRefV1 `json:"verdictOverride,omitempty"`
+}
+
+type OracleVoteV1 struct {
+	EvaluatorID string `json:"evaluatorId"`
+	OK          bool   `json:"ok"`
+	Error       bool   `json:"error,omitempty"`
 }
 
 type ReportV1 struct {
@@ -108,6 +125,12 @@ type ReportV1 struct {
 	Flows []FlowReportV1 `json:"flows,omitempty"`
 	// FailureBuckets split failed attempts into mutually-exclusive classes.
 	FailureBuckets FailureBucketsV1 `json:"failureBuckets"`
+	// JudgeAgreement is set when mission gates carry ensemble oracle votes.
+	JudgeAgreement *JudgeAgreementV1 `json:"judgeAgreement,omitempty"`
+	// Reviews is set once any gated attempt carries a review.json.
+	Reviews *ReviewSummaryV1 `json:"reviews,omitempty"`
+	// Overrides lists every gated attempt whose verdict was adjudicated via verdict.override.json.
+	Overrides []VerdictOverrideEntryV1 `json:"overrides,omitempty"`
 
 	UpdatedAt string `json:"updatedAt"`
 }
@@ -129,11 +152,13 @@ type SummaryV1 struct {
 	VerifiedMissionsOK int `json:"verifiedMissionsOk"`
 	MismatchCount      int `json:"mismatchCount"`
 
-	TopFailureCodes []CodeCountV1      `json:"topFailureCodes,omitempty"`
-	FailureBuckets  FailureBucketsV1   `json:"failureBuckets"`
-	Missions        []MissionSummaryV1 `json:"missions,omitempty"`
-	EvidencePaths   SummaryEvidenceV1  `json:"evidencePaths"`
-	Flows           []FlowReportV1     `json:"flows,omitempty"`
+	TopFailureCodes []CodeCountV1            `json:"topFailureCodes,omitempty"`
+	FailureBuckets  FailureBucketsV1         `json:"failureBuckets"`
+	Missions        []MissionSummaryV1       `json:"missions,omitempty"`
+	EvidencePaths   SummaryEvidenceV1        `json:"evidencePaths"`
+	Flows           []FlowReportV1           `json:"flows,omitempty"`
+	Reviews         *ReviewSummaryV1         `json:"reviews,omitempty"`
+	Overrides       []VerdictOverrideEntryV1 `json:"overrides,omitempty"`
 }
 
 type FailureBucketsV1 struct {
@@ -183,6 +208,13 @@ type FlowReportV1 struct {
 	InfraFailed   int    `json:"infraFailed"`
 	OracleFailed  int    `json:"oracleFailed"`
 	MissionFailed int    `json:"missionFailed"`
+	// ScoredAttempts/MeanScore aggregate mission gate rubric scores for the flow.
+	ScoredAttempts int      `json:"scoredAttempts,omitempty"`
+	MeanScore      *float64 `json:"meanScore,omitempty"`
+	// Calibration is set when gated attempts of the flow claimed a feedback confidence.
+	Calibration *CalibrationV1 `json:"calibration,omitempty"`
+	// Claims sums claimed-vs-verified field counts over the flow's oracle-evaluated attempts.
+	Claims *FlowClaimsV1 `json:"claims,omitempty"`
 }
 
 type PlanV1 struct {
@@ -211,7 +243,9 @@ type ProgressEventV1 struct {
 	Status         string   `json:"status"`
 	ReasonCodes    []string `json:"reasonCodes,omitempty"`
 	IdempotencyKey string   `json:"idempotencyKey,omitempty"`
-	CreatedAt      string   `json:"createdAt"`
+	// Progress is set on run_progress events only.
+	Progress  *RunProgressV1 `json:"progress,omitempty"`
+	CreatedAt string         `json:"createdAt"`
 }
 
 func CampaignDir(outRoot string, campaignID string) string {
@@ -265,12 +299,19 @@ func LoadRunState(path string) (RunStateV1, error) {
 }
 
 func SaveRunState(path string, st RunStateV1) error {
+	return saveRunState(nil, path, st)
+}
+
+func saveRunState(s statestore.Store, path string, st RunStateV1) error {
 	if err := validateRunState(path, st); err != nil {
 		return err
 	}
 	st = normalizeRunState(st)
-
-	return store.WriteJSONAtomic(path, st)
+	b, err := store.MarshalJSONIndented(st)
+	if err != nil {
+		return err
+	}
+	return stateStoreOrFile(s).Update(path, func([]byte) ([]byte, error) { return b, nil })
 }
 
 func validateRunState(path string, st RunStateV1) error {
@@ -384,6 +425,10 @@ func BuildReport(st RunStateV1) ReportV1 {
 		}
 		byFlow[fr.FlowID] = cur
 	}
+	applyFlowRubricScores(byFlow, st.MissionGates)
+	applyFlowClaims(byFlow, st.MissionGates)
+	gates := ApplyVerdictOverrides(st.MissionGates)
+	applyFlowCalibration(byFlow, gates)
 	flowIDs := make([]string, 0, len(byFlow))
 	for id := range byFlow {
 		flowIDs = append(flowIDs, id)
@@ -393,17 +438,40 @@ func BuildReport(st RunStateV1) ReportV1 {
 		rep.Flows = append(rep.Flows, *byFlow[id])
 	}
 
-	for _, mg := range st.MissionGates {
+	for _, mg := range gates {
 		if mg.OK {
 			rep.GatesPassed++
 		} else {
 			rep.GatesFailed++
 		}
 	}
+	rep.JudgeAgreement = BuildJudgeAgreement(gates)
+	rep.Reviews = BuildReviewSummary(gates)
+	rep.Overrides = ListVerdictOverrides(gates)
 	return rep
 }
 
+func applyFlowRubricScores(byFlow map[string]*FlowReportV1, gates []MissionGateV1) {
+	sums := map[string]float64{}
+	for _, mg := range gates {
+		for _, att := range mg.Attempts {
+			cur := byFlow[att.FlowID]
+			if cur == nil || att.RubricScore == nil {
+				continue
+			}
+			cur.ScoredAttempts++
+			sums[att.FlowID] += *att.RubricScore
+		}
+	}
+	for id, sum := range sums {
+		cur := byFlow[id]
+		mean := roundRate(sum / float64(cur.ScoredAttempts))
+		cur.MeanScore = &mean
+	}
+}
+
 func BuildSummary(st RunStateV1) SummaryV1 {
+	st.MissionGates = ApplyVerdictOverrides(st.MissionGates)
 	rep := BuildReport(st)
 	out := SummaryV1{
 		SchemaVersion:     1,
@@ -418,6 +486,8 @@ func BuildSummary(st RunStateV1) SummaryV1 {
 		GatesFailed:       rep.GatesFailed,
 		FailureBuckets:    rep.FailureBuckets,
 		Flows:             rep.Flows,
+		Reviews:           rep.Reviews,
+		Overrides:         rep.Overrides,
 		EvidencePaths: SummaryEvidenceV1{
 			RunStatePath:  RunStatePath(st.OutRoot, st.CampaignID),
 			ReportPath:    ReportPath(st.OutRoot, st.CampaignID),
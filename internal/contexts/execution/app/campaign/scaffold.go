@@ -0,0 +1,164 @@
+package campaign
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// ScaffoldAdapters lists the runner types `zcl init suite|campaign` can pre-wire, in prompt order.
+func ScaffoldAdapters() []string {
+	return []string{RunnerTypeProcessCmd, RunnerTypeCodexExec, RunnerTypeCodexSub, RunnerTypeClaudeSub, RunnerTypeCodexAppSrv}
+}
+
+// ScaffoldRunner returns the runner block a new flow of the given adapter type starts from:
+// wrapper-script adapters report through mission.result.json (process_cmd through a stdout
+// marker), while codex_app_server runs natively and fails closed on missing feedback.
+func ScaffoldRunner(adapter string) (RunnerAdapterSpec, error) {
+	fresh := true
+	r := RunnerAdapterSpec{
+		Type:                 strings.TrimSpace(strings.ToLower(adapter)),
+		SessionIsolation:     "process",
+		FeedbackPolicy:       schema.FeedbackPolicyStrictV1,
+		FreshAgentPerAttempt: &fresh,
+		Finalization: FinalizationSpec{
+			Mode:          FinalizationModeAutoFromResultJSON,
+			ResultChannel: ResultChannelSpec{Kind: ResultChannelFileJSON, Path: DefaultResultChannelPath},
+		},
+	}
+	switch r.Type {
+	case RunnerTypeProcessCmd:
+		r.Command = []string{"./scripts/runner.sh"}
+		r.FeedbackPolicy = schema.FeedbackPolicyAutoFailV1
+		r.Finalization.ResultChannel = ResultChannelSpec{Kind: ResultChannelStdoutJSON, Marker: DefaultResultChannelMarker}
+	case RunnerTypeCodexExec:
+		r.Command = []string{"codex", "exec", "--", "./scripts/runner_codex_exec.sh"}
+	case RunnerTypeCodexSub:
+		r.Command = []string{"codex", "agent", "run", "--", "./scripts/runner_codex_subagent.sh"}
+	case RunnerTypeClaudeSub:
+		r.Command = []string{"claude", "--", "./scripts/runner_claude_subagent.sh"}
+	case RunnerTypeCodexAppSrv:
+		r.SessionIsolation = "native"
+		r.RuntimeStrategies = []string{RunnerTypeCodexAppSrv}
+		r.FeedbackPolicy = schema.FeedbackPolicyAutoFailV1
+		r.Finalization = FinalizationSpec{Mode: FinalizationModeAutoFail, ResultChannel: ResultChannelSpec{Kind: ResultChannelNone}}
+	default:
+		return RunnerAdapterSpec{}, fmt.Errorf("unknown adapter %q (expected %s)", adapter, strings.Join(ScaffoldAdapters(), "|"))
+	}
+	return r, nil
+}
+
+// ScaffoldSuiteRunArgs returns the `zcl suite run` flags matching the adapter's runner block, so a
+// scaffolded suite can be run directly with the same finalization and result channel.
+func ScaffoldSuiteRunArgs(r RunnerAdapterSpec) []string {
+	args := []string{"--session-isolation", r.SessionIsolation, "--feedback-policy", r.FeedbackPolicy, "--finalization-mode", r.Finalization.Mode}
+	switch ch := r.Finalization.ResultChannel; ch.Kind {
+	case ResultChannelFileJSON:
+		args = append(args, "--result-channel", ch.Kind, "--result-file", ch.Path)
+	case ResultChannelStdoutJSON:
+		args = append(args, "--result-channel", ch.Kind, "--result-marker", ch.Marker)
+	}
+	return args
+}
+
+// ScaffoldSuite renders a minimal valid suite.json whose defaults match the adapter.
+func ScaffoldSuite(adapter string, suiteID string, missionIDs []string) ([]byte, error) {
+	r, err := ScaffoldRunner(adapter)
+	if err != nil {
+		return nil, err
+	}
+	suiteID = ids.SanitizeComponent(suiteID)
+	if suiteID == "" {
+		return nil, fmt.Errorf("invalid suite id (no usable characters)")
+	}
+	if len(missionIDs) == 0 {
+		missionIDs = []string{"mission-1"}
+	}
+	ok := true
+	s := suite.SuiteFileV1{
+		Version: 1,
+		SuiteID: suiteID,
+		Defaults: suite.DefaultsV1{
+			Mode:           "discovery",
+			TimeoutMs:      300000,
+			FeedbackPolicy: r.FeedbackPolicy,
+		},
+	}
+	for _, raw := range missionIDs {
+		id := ids.SanitizeComponent(raw)
+		if id == "" {
+			return nil, fmt.Errorf("invalid mission id %q (no usable characters)", raw)
+		}
+		s.Missions = append(s.Missions, suite.MissionV1{
+			MissionID: id,
+			Prompt:    "TODO: describe the task for " + id + " and what counts as a correct answer.",
+			Expects:   &suite.ExpectsV1{OK: &ok},
+		})
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// ScaffoldCampaign renders a campaign.yaml with one flow for the adapter, running suiteFile
+// (relative to the campaign file).
+func ScaffoldCampaign(adapter string, campaignID string, suiteFile string) ([]byte, error) {
+	r, err := ScaffoldRunner(adapter)
+	if err != nil {
+		return nil, err
+	}
+	campaignID = ids.SanitizeComponent(campaignID)
+	if campaignID == "" {
+		return nil, fmt.Errorf("invalid campaign id (no usable characters)")
+	}
+	if strings.TrimSpace(suiteFile) == "" {
+		return nil, fmt.Errorf("suite file is required")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by `zcl init campaign --adapter %s`.\n", r.Type)
+	b.WriteString("# Lint with `zcl campaign lint --spec <this file> --json`; see examples/campaign.canonical.yaml for every option.\n")
+	b.WriteString("schemaVersion: 1\n")
+	fmt.Fprintf(&b, "campaignId: %s\n", campaignID)
+	b.WriteString("outRoot: .zcl\npromptMode: default\n\nexecution:\n  flowMode: sequence\n\npairGate:\n  enabled: true\n  traceProfile: none\n\n")
+	b.WriteString("timeouts:\n  defaultAttemptTimeoutMs: 300000\n  timeoutStart: attempt_start\n\nflows:\n")
+	fmt.Fprintf(&b, "  - flowId: %s\n", ids.SanitizeComponent(strings.ReplaceAll(r.Type, "_", "-")))
+	fmt.Fprintf(&b, "    suiteFile: %s\n", strconv.Quote(suiteFile))
+	b.WriteString("    runner:\n")
+	fmt.Fprintf(&b, "      type: %s\n", r.Type)
+	if len(r.Command) > 0 {
+		fmt.Fprintf(&b, "      command: [%s]\n", quotedList(r.Command))
+	}
+	fmt.Fprintf(&b, "      sessionIsolation: %s\n", r.SessionIsolation)
+	if len(r.RuntimeStrategies) > 0 {
+		fmt.Fprintf(&b, "      runtimeStrategies: [%s]\n", quotedList(r.RuntimeStrategies))
+	}
+	fmt.Fprintf(&b, "      feedbackPolicy: %s\n", r.FeedbackPolicy)
+	b.WriteString("      freshAgentPerAttempt: true\n")
+	b.WriteString("      finalization:\n")
+	fmt.Fprintf(&b, "        mode: %s\n", r.Finalization.Mode)
+	b.WriteString("        resultChannel:\n")
+	fmt.Fprintf(&b, "          kind: %s\n", r.Finalization.ResultChannel.Kind)
+	if p := r.Finalization.ResultChannel.Path; p != "" {
+		fmt.Fprintf(&b, "          path: %s\n", p)
+	}
+	if m := r.Finalization.ResultChannel.Marker; m != "" {
+		fmt.Fprintf(&b, "          marker: %s\n", strconv.Quote(m))
+	}
+	return []byte(b.String()), nil
+}
+
+func quotedList(items []string) string {
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		out = append(out, strconv.Quote(it))
+	}
+	return strings.Join(out, ", ")
+}
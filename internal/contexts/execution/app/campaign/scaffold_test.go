@@ -0,0 +1,76 @@
+package campaign
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+)
+
+func TestScaffold_GeneratesValidSuiteAndCampaignForEveryAdapter(t *testing.T) {
+	t.Parallel()
+
+	for _, adapter := range ScaffoldAdapters() {
+		adapter := adapter
+		t.Run(adapter, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			suiteBody, err := ScaffoldSuite(adapter, "Demo Suite", []string{"m1", "m2"})
+			if err != nil {
+				t.Fatalf("ScaffoldSuite: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "suite.json"), suiteBody, 0o644); err != nil {
+				t.Fatalf("write suite: %v", err)
+			}
+			ps, err := suite.ParseFile(filepath.Join(dir, "suite.json"))
+			if err != nil {
+				t.Fatalf("ParseFile: %v", err)
+			}
+			if ps.Suite.SuiteID != "demo-suite" || len(ps.Suite.Missions) != 2 {
+				t.Fatalf("unexpected suite: %+v", ps.Suite)
+			}
+
+			specBody, err := ScaffoldCampaign(adapter, "demo", "suite.json")
+			if err != nil {
+				t.Fatalf("ScaffoldCampaign: %v", err)
+			}
+			specPath := filepath.Join(dir, "campaign.yaml")
+			if err := os.WriteFile(specPath, specBody, 0o644); err != nil {
+				t.Fatalf("write spec: %v", err)
+			}
+			parsed, err := ParseSpecFile(specPath)
+			if err != nil {
+				t.Fatalf("ParseSpecFile: %v\n%s", err, specBody)
+			}
+			want, _ := ScaffoldRunner(adapter)
+			got := parsed.Spec.Flows[0].Runner
+			if got.Type != adapter || got.Finalization.Mode != want.Finalization.Mode || got.Finalization.ResultChannel.Kind != want.Finalization.ResultChannel.Kind {
+				t.Fatalf("runner block not pre-wired: %+v", got)
+			}
+		})
+	}
+}
+
+func TestScaffoldRunner_RejectsUnknownAdapter(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ScaffoldRunner("nope"); err == nil || !strings.Contains(err.Error(), "unknown adapter") {
+		t.Fatalf("expected unknown adapter error, got %v", err)
+	}
+	args := ScaffoldSuiteRunArgs(mustScaffoldRunner(t, RunnerTypeProcessCmd))
+	if !strings.Contains(strings.Join(args, " "), "--result-channel stdout_json --result-marker ZCL_RESULT_JSON:") {
+		t.Fatalf("unexpected suite run args: %q", args)
+	}
+}
+
+func mustScaffoldRunner(t *testing.T, adapter string) RunnerAdapterSpec {
+	t.Helper()
+	r, err := ScaffoldRunner(adapter)
+	if err != nil {
+		t.Fatalf("ScaffoldRunner: %v", err)
+	}
+	return r
+}
@@ -9,10 +9,17 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/claudecli"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/home"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/httpagent"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/remote"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/wsagent"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
@@ -21,32 +28,41 @@ import (
 )
 
 const (
-	RunnerTypeProcessCmd   = "process_cmd"
-	RunnerTypeCodexExec    = "codex_exec"
-	RunnerTypeCodexSub     = "codex_subagent"
-	RunnerTypeClaudeSub    = "claude_subagent"
-	RunnerTypeCodexAppSrv  = "codex_app_server"
-	PromptModeDefault      = "default"
-	PromptModeMissionOnly  = "mission_only"
-	PromptModeExam         = "exam"
-	RunStatusValid         = "valid"
-	RunStatusInvalid       = "invalid"
-	RunStatusAborted       = "aborted"
-	RunStatusRunning       = "running"
-	ReasonGateFailed       = codes.CampaignGateFailed
-	ReasonFirstMissionGate = codes.CampaignFirstMissionGateFailed
-	ReasonFlowFailed       = codes.CampaignFlowFailed
-	ReasonAborted          = codes.CampaignAborted
-	ReasonSemanticFailed   = codes.CampaignSemanticFailed
-	ReasonPromptModePolicy = codes.CampaignPromptModeViolation
-	ReasonExamPromptPolicy = codes.CampaignExamPromptViolation
-	ReasonToolDriverShim   = codes.CampaignToolDriverShimRequired
-	ReasonToolPolicy       = codes.CampaignToolPolicyViolation
-	ReasonToolPolicyConfig = codes.CampaignToolPolicyInvalid
-	ReasonOracleVisibility = codes.CampaignOracleVisibility
-	ReasonOracleEvaluator  = codes.CampaignOracleEvaluatorMissing
-	ReasonOracleEvalFailed = codes.CampaignOracleEvalFailed
-	ReasonOracleEvalError  = codes.CampaignOracleEvalError
+	RunnerTypeProcessCmd      = "process_cmd"
+	RunnerTypeCodexExec       = "codex_exec"
+	RunnerTypeCodexSub        = "codex_subagent"
+	RunnerTypeClaudeSub       = "claude_subagent"
+	RunnerTypeClaudeCLI       = "claude_cli"
+	RunnerTypeHTTPAgent       = "http_agent"
+	RunnerTypeWSAgent         = "ws_agent"
+	RunnerTypeCodexAppSrv     = "codex_app_server"
+	RunnerTypeDocker          = "docker"
+	RunnerTypeSSH             = "ssh"
+	PromptModeDefault         = "default"
+	PromptModeMissionOnly     = "mission_only"
+	PromptModeExam            = "exam"
+	RunStatusValid            = "valid"
+	RunStatusInvalid          = "invalid"
+	RunStatusAborted          = "aborted"
+	RunStatusRunning          = "running"
+	ReasonGateFailed          = codes.CampaignGateFailed
+	ReasonFirstMissionGate    = codes.CampaignFirstMissionGateFailed
+	ReasonFlowFailed          = codes.CampaignFlowFailed
+	ReasonAborted             = codes.CampaignAborted
+	ReasonSemanticFailed      = codes.CampaignSemanticFailed
+	ReasonPromptModePolicy    = codes.CampaignPromptModeViolation
+	ReasonExamPromptPolicy    = codes.CampaignExamPromptViolation
+	ReasonToolDriverShim      = codes.CampaignToolDriverShimRequired
+	ReasonToolPolicy          = codes.CampaignToolPolicyViolation
+	ReasonToolPolicyConfig    = codes.CampaignToolPolicyInvalid
+	ReasonEgressViolation     = codes.CampaignEgressViolation
+	ReasonOracleVisibility    = codes.CampaignOracleVisibility
+	ReasonOracleEvaluator     = codes.CampaignOracleEvaluatorMissing
+	ReasonOracleEvalFailed    = codes.CampaignOracleEvalFailed
+	ReasonOracleEvalError     = codes.CampaignOracleEvalError
+	ReasonSecretLeak          = codes.CampaignSecretLeak
+	ReasonRedactionUnverified = codes.CampaignRedactionUnverified
+	ReasonVerdictOverridden   = codes.CampaignVerdictOverridden
 
 	SelectionModeAll       = "all"
 	SelectionModeMissionID = "mission_id"
@@ -93,10 +109,17 @@ const (
 	OracleVisibilityWorkspace = "workspace"
 	OracleVisibilityHostOnly  = "host_only"
 
-	EvaluationModeNone   = "none"
-	EvaluationModeOracle = "oracle"
-	EvaluatorKindScript  = "script"
-	EvaluatorKindBuiltin = "builtin_rules"
+	EvaluationModeNone          = "none"
+	EvaluationModeOracle        = "oracle"
+	EvaluatorKindScript         = "script"
+	EvaluatorKindBuiltin        = "builtin_rules"
+	EvaluatorKindBuiltinCompare = "builtin_compare"
+	EvaluatorKindLLMJudge       = "llm_judge"
+	EvaluatorKindEnsemble       = "ensemble"
+	EvaluatorKindRubric         = "rubric"
+
+	EnsemblePolicyMajority  = "majority"
+	EnsemblePolicyUnanimous = "unanimous"
 
 	OraclePolicyModeStrict     = "strict"
 	OraclePolicyModeNormalized = "normalized"
@@ -128,6 +151,7 @@ type SpecV1 struct {
 	FlowGate      PairGateSpec      `json:"flowGate,omitempty" yaml:"flowGate,omitempty"`
 	Semantic      SemanticGateSpec  `json:"semantic,omitempty" yaml:"semantic,omitempty"`
 	Cleanup       CleanupSpec       `json:"cleanup,omitempty" yaml:"cleanup,omitempty"`
+	Hooks         HooksSpec         `json:"hooks,omitempty" yaml:"hooks,omitempty"`
 	Timeouts      TimeoutsSpec      `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
 	Output        OutputPolicySpec  `json:"output,omitempty" yaml:"output,omitempty"`
 	NoContext     NoContextSpec     `json:"noContext,omitempty" yaml:"noContext,omitempty"`
@@ -156,14 +180,34 @@ type OracleSourceSpec struct {
 }
 
 type EvaluationSpec struct {
-	Mode         string           `json:"mode,omitempty" yaml:"mode,omitempty"` // none|oracle
-	Evaluator    EvaluatorSpec    `json:"evaluator,omitempty" yaml:"evaluator,omitempty"`
-	OraclePolicy OraclePolicySpec `json:"oraclePolicy,omitempty" yaml:"oraclePolicy,omitempty"`
+	Mode      string        `json:"mode,omitempty" yaml:"mode,omitempty"` // none|oracle
+	Evaluator EvaluatorSpec `json:"evaluator,omitempty" yaml:"evaluator,omitempty"`
+	// Evaluators replaces Evaluator with an ensemble whose member verdicts are combined by EnsemblePolicy.
+	Evaluators     []EvaluatorSpec `json:"evaluators,omitempty" yaml:"evaluators,omitempty"`
+	EnsemblePolicy string          `json:"ensemblePolicy,omitempty" yaml:"ensemblePolicy,omitempty"` // majority|unanimous
+	// Rubric scores each attempt as the weighted share of passing criteria. Without evaluator(s) the
+	// rubric also gates: attempts pass when the score reaches RubricPassScore (default 0: informational).
+	Rubric          []RubricCriterionSpec `json:"rubric,omitempty" yaml:"rubric,omitempty"`
+	RubricPassScore float64               `json:"rubricPassScore,omitempty" yaml:"rubricPassScore,omitempty"`
+	OraclePolicy    OraclePolicySpec      `json:"oraclePolicy,omitempty" yaml:"oraclePolicy,omitempty"`
+}
+
+type RubricCriterionSpec struct {
+	Criterion string        `json:"criterion" yaml:"criterion"`
+	Weight    float64       `json:"weight,omitempty" yaml:"weight,omitempty"` // default 1
+	Evaluator EvaluatorSpec `json:"evaluator" yaml:"evaluator"`
 }
 
 type EvaluatorSpec struct {
-	Kind    string   `json:"kind,omitempty" yaml:"kind,omitempty"` // script|builtin_rules
+	// ID names an ensemble member in verdicts and agreement metrics (default: kind, suffixed when repeated).
+	ID      string   `json:"id,omitempty" yaml:"id,omitempty"`
+	Kind    string   `json:"kind,omitempty" yaml:"kind,omitempty"` // script|builtin_rules|builtin_compare|llm_judge
 	Command []string `json:"command,omitempty" yaml:"command,omitempty"`
+	// Model, RuntimeStrategies and Instructions configure kind=llm_judge; the judge runs through the
+	// native runtime layer (default chain: codex_app_server).
+	Model             string   `json:"model,omitempty" yaml:"model,omitempty"`
+	RuntimeStrategies []string `json:"runtimeStrategies,omitempty" yaml:"runtimeStrategies,omitempty"`
+	Instructions      string   `json:"instructions,omitempty" yaml:"instructions,omitempty"`
 }
 
 type OraclePolicySpec struct {
@@ -201,6 +245,8 @@ type ExecutionSpec struct {
 type SemanticGateSpec struct {
 	Enabled   bool   `json:"enabled" yaml:"enabled"`
 	RulesPath string `json:"rulesPath,omitempty" yaml:"rulesPath,omitempty"`
+	// Rules embeds a rule pack in the spec instead of RulesPath.
+	Rules *suite.SemanticRulePackV1 `json:"rules,omitempty" yaml:"rules,omitempty"`
 }
 
 type CleanupSpec struct {
@@ -213,6 +259,17 @@ type CleanupSpec struct {
 	PostMission []string `json:"postMission,omitempty" yaml:"postMission,omitempty"`
 }
 
+type HooksSpec struct {
+	// OnEvent runs commands as matching campaign.progress.jsonl events are written.
+	OnEvent []EventHookSpec `json:"onEvent,omitempty" yaml:"onEvent,omitempty"`
+}
+
+type EventHookSpec struct {
+	Match     string   `json:"match" yaml:"match"` // progress status, `prefix*` or `*`; gate_failed|gate_passed alias gate_fail|gate_pass
+	Command   []string `json:"command" yaml:"command"`
+	TimeoutMs int64    `json:"timeoutMs,omitempty" yaml:"timeoutMs,omitempty"` // default timeouts.cleanupHookTimeoutMs, else 15000
+}
+
 type TimeoutsSpec struct {
 	CampaignGlobalTimeoutMs  int64  `json:"campaignGlobalTimeoutMs,omitempty" yaml:"campaignGlobalTimeoutMs,omitempty"`
 	DefaultAttemptTimeoutMs  int64  `json:"defaultAttemptTimeoutMs,omitempty" yaml:"defaultAttemptTimeoutMs,omitempty"`
@@ -233,6 +290,9 @@ type OutputPolicySpec struct {
 	ResultsMDPath string `json:"resultsMdPath,omitempty" yaml:"resultsMdPath,omitempty"`
 	PublishCheck  string `json:"publishCheck,omitempty" yaml:"publishCheck,omitempty"`
 	ProgressJSONL string `json:"progressJsonl,omitempty" yaml:"progressJsonl,omitempty"`
+	// RequireRedactionVerify makes publish-check require a passing, current redaction.verify.json
+	// (zcl redact verify) for every flow run; set it for campaigns whose evidence is shared externally.
+	RequireRedactionVerify bool `json:"requireRedactionVerify,omitempty" yaml:"requireRedactionVerify,omitempty"`
 }
 
 type InvalidRunPolicySpec struct {
@@ -297,8 +357,27 @@ type RunnerAdapterSpec struct {
 	ToolDriver       ToolDriverSpec   `json:"toolDriver,omitempty" yaml:"toolDriver,omitempty"`
 	Finalization     FinalizationSpec `json:"finalization,omitempty" yaml:"finalization,omitempty"`
 	Cwd              RunnerCwdSpec    `json:"cwd,omitempty" yaml:"cwd,omitempty"`
+	// Docker is required for runner.type=docker: each attempt runs Command in a fresh container.
+	Docker RunnerDockerSpec `json:"docker,omitempty" yaml:"docker,omitempty"`
+	// SSH is required for runner.type=ssh: each attempt runs Command on a remote host.
+	SSH RunnerSSHSpec `json:"ssh,omitempty" yaml:"ssh,omitempty"`
+	// Claude configures runner.type=claude_cli, whose claude invocation zcl builds (no command).
+	Claude RunnerClaudeSpec `json:"claude,omitempty" yaml:"claude,omitempty"`
+	// HTTP is required for runner.type=http_agent, whose agent is a service zcl talks to (no command).
+	HTTP RunnerHTTPSpec `json:"http,omitempty" yaml:"http,omitempty"`
+	// WS is required for runner.type=ws_agent, whose agent zcl streams the mission to over a WebSocket.
+	WS RunnerWSSpec `json:"ws,omitempty" yaml:"ws,omitempty"`
+	// Limits caps each attempt's runner (transient cgroup scope, or the container for docker flows).
+	Limits RunnerLimitsSpec `json:"limits,omitempty" yaml:"limits,omitempty"`
+	// DiskQuotaMb kills a process runner whose attempt dir + workspace grow past it (0 = no quota).
+	DiskQuotaMb int64 `json:"diskQuotaMb,omitempty" yaml:"diskQuotaMb,omitempty"`
+	// Home gives each attempt a fresh HOME/XDG/tool config dirs (process runners only).
+	Home RunnerHomeSpec `json:"home,omitempty" yaml:"home,omitempty"`
 
 	MCP MCPLifecycleSpec `json:"mcp,omitempty" yaml:"mcp,omitempty"`
+	// MCPServers are registered with native runtime sessions (sessionIsolation=native), on top of
+	// the suite's defaults.mcpServers; a flow server replaces a suite server of the same name.
+	MCPServers map[string]suite.MCPServerV1 `json:"mcpServers,omitempty" yaml:"mcpServers,omitempty"`
 
 	// FreshAgentPerAttempt defaults to true. Hidden session reuse is never implicit.
 	FreshAgentPerAttempt *bool `json:"freshAgentPerAttempt,omitempty" yaml:"freshAgentPerAttempt,omitempty"`
@@ -327,6 +406,63 @@ type RunnerCwdSpec struct {
 	Retain   string `json:"retain,omitempty" yaml:"retain,omitempty"`     // never|on_failure|always
 }
 
+type RunnerDockerSpec struct {
+	Image   string   `json:"image,omitempty" yaml:"image,omitempty"`
+	Mounts  []string `json:"mounts,omitempty" yaml:"mounts,omitempty"`   // host:container[:ro|rw]; relative host paths resolve against the spec dir
+	Network string   `json:"network,omitempty" yaml:"network,omitempty"` // bridge (default)|none|host|<network name>
+	// ContainerEngine is docker (default) or podman; podman runs rootless when zcl is not root.
+	ContainerEngine string           `json:"containerEngine,omitempty" yaml:"containerEngine,omitempty"`
+	Limits          RunnerLimitsSpec `json:"limits,omitempty" yaml:"limits,omitempty"`
+}
+
+type RunnerSSHSpec struct {
+	Host     string `json:"host,omitempty" yaml:"host,omitempty"` // [user@]host or an ssh config alias
+	Port     int    `json:"port,omitempty" yaml:"port,omitempty"`
+	Identity string `json:"identity,omitempty" yaml:"identity,omitempty"` // private key; relative paths resolve against the spec dir
+	WorkDir  string `json:"workDir,omitempty" yaml:"workDir,omitempty"`   // remote root for attempt dirs (default /tmp/zcl-remote)
+	ZCL      string `json:"zcl,omitempty" yaml:"zcl,omitempty"`           // remote zcl binary (default zcl on the remote PATH)
+	// Sync are extra attempt-relative globs pulled back after the runner exits; evidence artifacts
+	// are always pulled and an empty list pulls the whole remote attempt dir.
+	Sync []string `json:"sync,omitempty" yaml:"sync,omitempty"`
+}
+
+type RunnerClaudeSpec struct {
+	Binary             string   `json:"binary,omitempty" yaml:"binary,omitempty"` // default claude on PATH
+	Model              string   `json:"model,omitempty" yaml:"model,omitempty"`
+	MaxTurns           int      `json:"maxTurns,omitempty" yaml:"maxTurns,omitempty"`
+	PermissionMode     string   `json:"permissionMode,omitempty" yaml:"permissionMode,omitempty"` // acceptEdits|bypassPermissions|default|dontAsk|plan
+	AllowedTools       []string `json:"allowedTools,omitempty" yaml:"allowedTools,omitempty"`
+	DisallowedTools    []string `json:"disallowedTools,omitempty" yaml:"disallowedTools,omitempty"`
+	MCPConfig          string   `json:"mcpConfig,omitempty" yaml:"mcpConfig,omitempty"` // relative paths resolve against the spec dir
+	AppendSystemPrompt string   `json:"appendSystemPrompt,omitempty" yaml:"appendSystemPrompt,omitempty"`
+	OutputFormat       string   `json:"outputFormat,omitempty" yaml:"outputFormat,omitempty"` // json (default)|stream-json
+	ExtraArgs          []string `json:"extraArgs,omitempty" yaml:"extraArgs,omitempty"`
+}
+
+type RunnerHTTPSpec struct {
+	URL            string            `json:"url,omitempty" yaml:"url,omitempty"`         // http(s) endpoint the mission is POSTed to
+	Headers        map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // values may reference the attempt env as ${VAR}
+	PollIntervalMs int64             `json:"pollIntervalMs,omitempty" yaml:"pollIntervalMs,omitempty"`
+}
+
+type RunnerWSSpec struct {
+	URL              string            `json:"url,omitempty" yaml:"url,omitempty"`         // ws(s) endpoint
+	Headers          map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // handshake headers; values may reference the attempt env as ${VAR}
+	InterruptGraceMs int64             `json:"interruptGraceMs,omitempty" yaml:"interruptGraceMs,omitempty"`
+}
+
+type RunnerHomeSpec struct {
+	Mode     string `json:"mode,omitempty" yaml:"mode,omitempty"`         // inherit (default)|ephemeral
+	Template string `json:"template,omitempty" yaml:"template,omitempty"` // dir copied into each ephemeral home; relative to the spec dir
+}
+
+// RunnerLimitsSpec caps an attempt's resources (cgroup v2); zero fields are unlimited.
+type RunnerLimitsSpec struct {
+	CPU      float64 `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	MemoryMB int64   `json:"memoryMb,omitempty" yaml:"memoryMb,omitempty"`
+	Pids     int64   `json:"pids,omitempty" yaml:"pids,omitempty"`
+}
+
 type ResultChannelSpec struct {
 	Kind   string `json:"kind,omitempty" yaml:"kind,omitempty"`     // none|file_json|stdout_json
 	Path   string `json:"path,omitempty" yaml:"path,omitempty"`     // required for file_json (relative to attempt dir)
@@ -529,6 +665,9 @@ func normalizeSpecCore(spec *SpecV1, absPath string) error {
 		return err
 	}
 	normalizeSpecOutputAndSemantic(spec, absPath)
+	if err := normalizeSpecSemanticRules(spec); err != nil {
+		return err
+	}
 	if err := normalizeSpecTimeouts(spec); err != nil {
 		return err
 	}
@@ -536,6 +675,9 @@ func normalizeSpecCore(spec *SpecV1, absPath string) error {
 		return err
 	}
 	normalizeSpecCleanup(spec)
+	if err := normalizeSpecHooks(spec); err != nil {
+		return err
+	}
 	if len(spec.Flows) == 0 {
 		return fmt.Errorf("campaign requires at least one flow")
 	}
@@ -621,13 +763,22 @@ func normalizeSpecEvaluation(spec *SpecV1) error {
 		return fmt.Errorf("invalid evaluation.mode (expected %s|%s)", EvaluationModeNone, EvaluationModeOracle)
 	}
 	spec.Evaluation.Evaluator.Kind = strings.ToLower(strings.TrimSpace(spec.Evaluation.Evaluator.Kind))
-	if spec.Evaluation.Mode == EvaluationModeOracle && spec.Evaluation.Evaluator.Kind == "" {
+	if err := normalizeEvaluatorEnsemble(&spec.Evaluation); err != nil {
+		return err
+	}
+	if err := normalizeEvaluationRubric(&spec.Evaluation); err != nil {
+		return err
+	}
+	if spec.Evaluation.Mode == EvaluationModeOracle && spec.Evaluation.Evaluator.Kind == "" && len(spec.Evaluation.Evaluators) == 0 && len(spec.Evaluation.Rubric) == 0 {
 		spec.Evaluation.Evaluator.Kind = EvaluatorKindScript
 	}
 	if spec.Evaluation.Evaluator.Kind != "" && !isValidEvaluatorKind(spec.Evaluation.Evaluator.Kind) {
-		return fmt.Errorf("invalid evaluation.evaluator.kind (expected %s|%s)", EvaluatorKindScript, EvaluatorKindBuiltin)
+		return fmt.Errorf("invalid evaluation.evaluator.kind (expected %s|%s|%s|%s)", EvaluatorKindScript, EvaluatorKindBuiltin, EvaluatorKindBuiltinCompare, EvaluatorKindLLMJudge)
 	}
 	spec.Evaluation.Evaluator.Command = normalizeCommand(spec.Evaluation.Evaluator.Command)
+	if err := normalizeLLMJudgeEvaluator(&spec.Evaluation.Evaluator); err != nil {
+		return err
+	}
 	spec.Evaluation.OraclePolicy.Mode = strings.ToLower(strings.TrimSpace(spec.Evaluation.OraclePolicy.Mode))
 	if spec.Evaluation.OraclePolicy.Mode == "" {
 		spec.Evaluation.OraclePolicy.Mode = OraclePolicyModeStrict
@@ -653,6 +804,19 @@ func normalizeSpecOutputAndSemantic(spec *SpecV1, absPath string) {
 	spec.Output.ProgressJSONL = resolveSpecRelativePath(absPath, spec.Output.ProgressJSONL, true)
 }
 
+func normalizeSpecSemanticRules(spec *SpecV1) error {
+	if spec.Semantic.Rules == nil {
+		return nil
+	}
+	if spec.Semantic.RulesPath != "" {
+		return fmt.Errorf("use either semantic.rulesPath or semantic.rules, not both")
+	}
+	if err := suite.NormalizeSemanticRulePack(spec.Semantic.Rules); err != nil {
+		return fmt.Errorf("semantic.rules: %w", err)
+	}
+	return nil
+}
+
 func normalizeSpecTimeouts(spec *SpecV1) error {
 	if spec.Timeouts.CampaignGlobalTimeoutMs < 0 ||
 		spec.Timeouts.DefaultAttemptTimeoutMs < 0 ||
@@ -702,6 +866,25 @@ func normalizeSpecCleanup(spec *SpecV1) {
 	spec.Cleanup.PostMission = nil
 }
 
+func normalizeSpecHooks(spec *SpecV1) error {
+	for i := range spec.Hooks.OnEvent {
+		h := &spec.Hooks.OnEvent[i]
+		match, err := normalizeEventHookMatch(h.Match)
+		if err != nil {
+			return fmt.Errorf("hooks.onEvent[%d].match: %w", i, err)
+		}
+		h.Match = match
+		h.Command = normalizeCommand(h.Command)
+		if len(h.Command) == 0 {
+			return fmt.Errorf("hooks.onEvent[%d].command is required", i)
+		}
+		if h.TimeoutMs < 0 {
+			return fmt.Errorf("hooks.onEvent[%d].timeoutMs must be >= 0", i)
+		}
+	}
+	return nil
+}
+
 func (p *specParser) detectInlineMissionPackNeeds() {
 	p.allFlowsOmitSuiteFile = true
 	for i := range p.spec.Flows {
@@ -757,7 +940,125 @@ func (p *specParser) validateExamPromptSource() error {
 	return nil
 }
 
+func normalizeEvaluatorEnsemble(ev *EvaluationSpec) error {
+	ev.EnsemblePolicy = strings.ToLower(strings.TrimSpace(ev.EnsemblePolicy))
+	if len(ev.Evaluators) == 0 {
+		if ev.EnsemblePolicy != "" {
+			return fmt.Errorf("evaluation.ensemblePolicy requires evaluation.evaluators")
+		}
+		return nil
+	}
+	if ev.Evaluator.Kind != "" || len(ev.Evaluator.Command) > 0 {
+		return fmt.Errorf("use either evaluation.evaluator or evaluation.evaluators, not both")
+	}
+	if ev.EnsemblePolicy == "" {
+		ev.EnsemblePolicy = EnsemblePolicyMajority
+	}
+	if ev.EnsemblePolicy != EnsemblePolicyMajority && ev.EnsemblePolicy != EnsemblePolicyUnanimous {
+		return fmt.Errorf("invalid evaluation.ensemblePolicy (expected %s|%s)", EnsemblePolicyMajority, EnsemblePolicyUnanimous)
+	}
+	seen := map[string]bool{}
+	kindCount := map[string]int{}
+	for i := range ev.Evaluators {
+		m := &ev.Evaluators[i]
+		if err := normalizeMemberEvaluator(fmt.Sprintf("evaluation.evaluators[%d]", i), m); err != nil {
+			return err
+		}
+		kindCount[m.Kind]++
+		m.ID = strings.TrimSpace(m.ID)
+		if m.ID == "" {
+			m.ID = m.Kind
+			if kindCount[m.Kind] > 1 {
+				m.ID = fmt.Sprintf("%s-%d", m.Kind, kindCount[m.Kind])
+			}
+		}
+		if seen[m.ID] {
+			return fmt.Errorf("evaluation.evaluators[%d]: duplicate id %q", i, m.ID)
+		}
+		seen[m.ID] = true
+	}
+	return nil
+}
+
+// normalizeMemberEvaluator validates an evaluator nested in an ensemble or rubric, where kind has no default.
+func normalizeMemberEvaluator(path string, m *EvaluatorSpec) error {
+	m.Kind = strings.ToLower(strings.TrimSpace(m.Kind))
+	if !isValidEvaluatorKind(m.Kind) {
+		return fmt.Errorf("%s: invalid kind %q", path, m.Kind)
+	}
+	m.Command = normalizeCommand(m.Command)
+	if m.Kind == EvaluatorKindScript && len(m.Command) == 0 {
+		return fmt.Errorf("%s: kind=%s requires command", path, EvaluatorKindScript)
+	}
+	if err := normalizeLLMJudgeEvaluator(m); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func normalizeEvaluationRubric(ev *EvaluationSpec) error {
+	if len(ev.Rubric) == 0 {
+		if ev.RubricPassScore != 0 {
+			return fmt.Errorf("evaluation.rubricPassScore requires evaluation.rubric")
+		}
+		return nil
+	}
+	if ev.RubricPassScore < 0 || ev.RubricPassScore > 1 {
+		return fmt.Errorf("evaluation.rubricPassScore must be within [0,1]")
+	}
+	seen := map[string]bool{}
+	for i := range ev.Rubric {
+		c := &ev.Rubric[i]
+		path := fmt.Sprintf("evaluation.rubric[%d]", i)
+		c.Criterion = strings.TrimSpace(c.Criterion)
+		if c.Criterion == "" {
+			return fmt.Errorf("%s.criterion is required", path)
+		}
+		if seen[c.Criterion] {
+			return fmt.Errorf("%s: duplicate criterion %q", path, c.Criterion)
+		}
+		seen[c.Criterion] = true
+		if c.Weight < 0 {
+			return fmt.Errorf("%s.weight must be > 0", path)
+		}
+		if c.Weight == 0 {
+			c.Weight = 1
+		}
+		if err := normalizeMemberEvaluator(path+".evaluator", &c.Evaluator); err != nil {
+			return err
+		}
+		c.Evaluator.ID = c.Criterion
+	}
+	return nil
+}
+
+func normalizeLLMJudgeEvaluator(ev *EvaluatorSpec) error {
+	ev.Model = strings.TrimSpace(ev.Model)
+	ev.RuntimeStrategies = normalizeLowerTerms(ev.RuntimeStrategies)
+	ev.Instructions = strings.TrimSpace(ev.Instructions)
+	if ev.Kind != EvaluatorKindLLMJudge {
+		if ev.Model != "" || len(ev.RuntimeStrategies) > 0 || ev.Instructions != "" {
+			return fmt.Errorf("evaluation.evaluator.model/runtimeStrategies/instructions are supported only for evaluator.kind=%s", EvaluatorKindLLMJudge)
+		}
+		return nil
+	}
+	// The model is part of the verdict cache key; an implicit runtime default would make cached verdicts ambiguous.
+	if ev.Model == "" {
+		return fmt.Errorf("evaluation.evaluator.model is required for evaluator.kind=%s", EvaluatorKindLLMJudge)
+	}
+	if len(ev.RuntimeStrategies) == 0 {
+		ev.RuntimeStrategies = []string{RunnerTypeCodexAppSrv}
+	}
+	return nil
+}
+
 func validateExamEvaluatorSettings(spec SpecV1) error {
+	if len(spec.Evaluation.Evaluators) > 0 {
+		return nil
+	}
+	if spec.Evaluation.Evaluator.Kind == "" && len(spec.Evaluation.Rubric) > 0 {
+		return nil
+	}
 	if spec.Evaluation.Evaluator.Kind == "" {
 		return newOraclePolicyViolation(ReasonOracleEvaluator, "evaluation.evaluator.kind", spec.PromptMode, "promptMode=exam requires evaluation.evaluator.kind")
 	}
@@ -872,7 +1173,7 @@ func (p *specParser) normalizeFlowRunner(flow *FlowSpec) error {
 		flow.Runner.Type = RunnerTypeProcessCmd
 	}
 	if !isValidRunnerType(flow.Runner.Type) {
-		return fmt.Errorf("flow %q: invalid runner.type (expected %s|%s|%s|%s|%s)", flow.FlowID, RunnerTypeProcessCmd, RunnerTypeCodexExec, RunnerTypeCodexSub, RunnerTypeClaudeSub, RunnerTypeCodexAppSrv)
+		return fmt.Errorf("flow %q: invalid runner.type (expected %s|%s|%s|%s|%s|%s|%s|%s|%s|%s)", flow.FlowID, RunnerTypeProcessCmd, RunnerTypeCodexExec, RunnerTypeCodexSub, RunnerTypeClaudeSub, RunnerTypeClaudeCLI, RunnerTypeHTTPAgent, RunnerTypeWSAgent, RunnerTypeCodexAppSrv, RunnerTypeDocker, RunnerTypeSSH)
 	}
 	if err := normalizeFlowRunnerModel(flow); err != nil {
 		return err
@@ -884,6 +1185,30 @@ func (p *specParser) normalizeFlowRunner(flow *FlowSpec) error {
 	if err := validateFlowRunnerCwd(flow); err != nil {
 		return err
 	}
+	if err := normalizeFlowRunnerDocker(flow, filepath.Dir(p.absPath)); err != nil {
+		return err
+	}
+	if err := normalizeFlowRunnerSSH(flow, filepath.Dir(p.absPath)); err != nil {
+		return err
+	}
+	if err := normalizeFlowRunnerClaude(flow, filepath.Dir(p.absPath)); err != nil {
+		return err
+	}
+	if err := normalizeFlowRunnerHTTPAgent(flow); err != nil {
+		return err
+	}
+	if err := normalizeFlowRunnerWSAgent(flow); err != nil {
+		return err
+	}
+	if err := normalizeFlowRunnerHome(flow, filepath.Dir(p.absPath)); err != nil {
+		return err
+	}
+	if err := normalizeFlowRunnerLimits(flow); err != nil {
+		return err
+	}
+	if err := normalizeFlowRunnerMCPServers(flow); err != nil {
+		return err
+	}
 	if err := normalizeFlowResultChannel(flow); err != nil {
 		return err
 	}
@@ -896,7 +1221,7 @@ func normalizeFlowRunnerModel(flow *FlowSpec) error {
 	flow.Runner.Model = strings.TrimSpace(flow.Runner.Model)
 	flow.Runner.ModelReasoningEffort = strings.ToLower(strings.TrimSpace(flow.Runner.ModelReasoningEffort))
 	flow.Runner.ModelReasoningPolicy = strings.ToLower(strings.TrimSpace(flow.Runner.ModelReasoningPolicy))
-	if len(flow.Runner.Command) == 0 && flow.Runner.Type != RunnerTypeCodexAppSrv {
+	if len(flow.Runner.Command) == 0 && flow.Runner.Type != RunnerTypeCodexAppSrv && flow.Runner.Type != RunnerTypeClaudeCLI && !IsAgentServiceRunner(flow.Runner.Type) {
 		return fmt.Errorf("flow %q: runner.command is required", flow.FlowID)
 	}
 	if flow.Runner.Type != RunnerTypeCodexAppSrv {
@@ -967,6 +1292,10 @@ func normalizeFlowFinalizationDefaults(flow *FlowSpec) {
 	flow.Runner.Finalization.Mode = strings.ToLower(strings.TrimSpace(flow.Runner.Finalization.Mode))
 	if flow.Runner.Finalization.Mode == "" {
 		flow.Runner.Finalization.Mode = normalizedFinalizationMode(flow.Runner.FeedbackPolicy)
+		// An agent service reports through its response, never through `zcl feedback`.
+		if IsAgentServiceRunner(flow.Runner.Type) {
+			flow.Runner.Finalization.Mode = FinalizationModeAutoFromResultJSON
+		}
 	}
 	if flow.Runner.Finalization.MinResultTurn == 0 {
 		flow.Runner.Finalization.MinResultTurn = DefaultMinResultTurn
@@ -974,6 +1303,9 @@ func normalizeFlowFinalizationDefaults(flow *FlowSpec) {
 	flow.Runner.Finalization.ResultChannel.Kind = strings.ToLower(strings.TrimSpace(flow.Runner.Finalization.ResultChannel.Kind))
 	if flow.Runner.Finalization.ResultChannel.Kind == "" {
 		flow.Runner.Finalization.ResultChannel.Kind = defaultResultChannelKind(flow.Runner.Finalization.Mode)
+		if IsAgentServiceRunner(flow.Runner.Type) {
+			flow.Runner.Finalization.ResultChannel.Kind = ResultChannelStdoutJSON
+		}
 	}
 }
 
@@ -1037,6 +1369,274 @@ func validateFlowRunnerBasics(flow *FlowSpec) error {
 	return nil
 }
 
+func normalizeFlowRunnerDocker(flow *FlowSpec, specDir string) error {
+	d := flow.Runner.Docker
+	if flow.Runner.Type != RunnerTypeDocker {
+		if strings.TrimSpace(d.Image) != "" || len(d.Mounts) > 0 || strings.TrimSpace(d.Network) != "" || strings.TrimSpace(d.ContainerEngine) != "" || d.Limits != (RunnerLimitsSpec{}) {
+			return fmt.Errorf("flow %q: runner.docker is supported only for runner.type=%s", flow.FlowID, RunnerTypeDocker)
+		}
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(flow.Runner.SessionIsolation), "native") {
+		return fmt.Errorf("flow %q: runner.type=%s does not support runner.sessionIsolation=native", flow.FlowID, RunnerTypeDocker)
+	}
+	spec, err := container.Normalize(DockerContainerSpec(*flow), specDir)
+	if err != nil {
+		return fmt.Errorf("flow %q: runner.docker: %w", flow.FlowID, err)
+	}
+	flow.Runner.Docker.ContainerEngine = spec.Engine
+	flow.Runner.Docker.Image = spec.Image
+	flow.Runner.Docker.Mounts = spec.Mounts
+	flow.Runner.Docker.Network = spec.Network
+	return nil
+}
+
+// normalizeFlowRunnerSSH validates runner.ssh and resolves its identity file against the spec dir.
+func normalizeFlowRunnerSSH(flow *FlowSpec, specDir string) error {
+	if flow.Runner.Type != RunnerTypeSSH {
+		ssh := flow.Runner.SSH
+		if strings.TrimSpace(ssh.Host) != "" || ssh.Port != 0 || strings.TrimSpace(ssh.Identity) != "" || strings.TrimSpace(ssh.WorkDir) != "" || strings.TrimSpace(ssh.ZCL) != "" || len(ssh.Sync) > 0 {
+			return fmt.Errorf("flow %q: runner.ssh is supported only for runner.type=%s", flow.FlowID, RunnerTypeSSH)
+		}
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(flow.Runner.SessionIsolation), "native") {
+		return fmt.Errorf("flow %q: runner.type=%s does not support runner.sessionIsolation=native", flow.FlowID, RunnerTypeSSH)
+	}
+	if flow.Runner.Limits != (RunnerLimitsSpec{}) {
+		return fmt.Errorf("flow %q: runner.limits is not supported for runner.type=%s", flow.FlowID, RunnerTypeSSH)
+	}
+	spec, err := remote.Normalize(SSHRemoteSpec(*flow), specDir)
+	if err != nil {
+		return fmt.Errorf("flow %q: runner.ssh: %w", flow.FlowID, err)
+	}
+	flow.Runner.SSH = RunnerSSHSpec{Host: spec.Host, Port: spec.Port, Identity: spec.Identity, WorkDir: spec.WorkDir, ZCL: spec.ZCLPath, Sync: spec.Sync}
+	return nil
+}
+
+// normalizeFlowRunnerClaude validates runner.claude and builds a claude_cli flow's command from it.
+func normalizeFlowRunnerClaude(flow *FlowSpec, specDir string) error {
+	if flow.Runner.Type != RunnerTypeClaudeCLI {
+		if c := flow.Runner.Claude; c.Binary != "" || c.Model != "" || c.MaxTurns != 0 || c.PermissionMode != "" || len(c.AllowedTools) > 0 || len(c.DisallowedTools) > 0 || c.MCPConfig != "" || c.AppendSystemPrompt != "" || c.OutputFormat != "" || len(c.ExtraArgs) > 0 {
+			return fmt.Errorf("flow %q: runner.claude is supported only for runner.type=%s", flow.FlowID, RunnerTypeClaudeCLI)
+		}
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(flow.Runner.SessionIsolation), "native") {
+		return fmt.Errorf("flow %q: runner.type=%s does not support runner.sessionIsolation=native", flow.FlowID, RunnerTypeClaudeCLI)
+	}
+	spec, err := claudecli.Normalize(ClaudeCLISpec(*flow), specDir)
+	if err != nil {
+		return fmt.Errorf("flow %q: runner.claude: %w", flow.FlowID, err)
+	}
+	argv := claudecli.Argv(spec)
+	if len(flow.Runner.Command) > 0 && !slices.Equal(flow.Runner.Command, argv) {
+		return fmt.Errorf("flow %q: runner.command is built from runner.claude for runner.type=%s (remove it)", flow.FlowID, RunnerTypeClaudeCLI)
+	}
+	flow.Runner.Claude = RunnerClaudeSpec{
+		Binary:             spec.Binary,
+		Model:              spec.Model,
+		MaxTurns:           spec.MaxTurns,
+		PermissionMode:     spec.PermissionMode,
+		AllowedTools:       spec.AllowedTools,
+		DisallowedTools:    spec.DisallowedTools,
+		MCPConfig:          spec.MCPConfig,
+		AppendSystemPrompt: spec.AppendSystemPrompt,
+		OutputFormat:       spec.OutputFormat,
+		ExtraArgs:          spec.ExtraArgs,
+	}
+	flow.Runner.Command = argv
+	return nil
+}
+
+// ClaudeCLISpec returns the claude invocation of a runner.type=claude_cli flow.
+func ClaudeCLISpec(flow FlowSpec) claudecli.Spec {
+	c := flow.Runner.Claude
+	return claudecli.Spec{
+		Binary:             c.Binary,
+		Model:              c.Model,
+		MaxTurns:           c.MaxTurns,
+		PermissionMode:     c.PermissionMode,
+		AllowedTools:       c.AllowedTools,
+		DisallowedTools:    c.DisallowedTools,
+		MCPConfig:          c.MCPConfig,
+		AppendSystemPrompt: c.AppendSystemPrompt,
+		OutputFormat:       c.OutputFormat,
+		ExtraArgs:          c.ExtraArgs,
+	}
+}
+
+// normalizeFlowRunnerHTTPAgent validates runner.http and builds an http_agent flow's command: zcl's
+// `http agent`, which prints the service's result for the stdout_json result channel.
+func normalizeFlowRunnerHTTPAgent(flow *FlowSpec) error {
+	h := flow.Runner.HTTP
+	if flow.Runner.Type != RunnerTypeHTTPAgent {
+		if strings.TrimSpace(h.URL) != "" || len(h.Headers) > 0 || h.PollIntervalMs != 0 {
+			return fmt.Errorf("flow %q: runner.http is supported only for runner.type=%s", flow.FlowID, RunnerTypeHTTPAgent)
+		}
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(flow.Runner.SessionIsolation), "native") {
+		return fmt.Errorf("flow %q: runner.type=%s does not support runner.sessionIsolation=native", flow.FlowID, RunnerTypeHTTPAgent)
+	}
+	if flow.Runner.Finalization.ResultChannel.Kind != ResultChannelStdoutJSON {
+		return fmt.Errorf("flow %q: runner.type=%s requires runner.finalization.resultChannel.kind=%s", flow.FlowID, RunnerTypeHTTPAgent, ResultChannelStdoutJSON)
+	}
+	spec, err := httpagent.Normalize(httpagent.Spec{URL: h.URL, Headers: h.Headers, PollIntervalMs: h.PollIntervalMs})
+	if err != nil {
+		return fmt.Errorf("flow %q: runner.http: %w", flow.FlowID, err)
+	}
+	marker := strings.TrimSpace(flow.Runner.Finalization.ResultChannel.Marker)
+	if marker == "" {
+		marker = DefaultResultChannelMarker
+	}
+	argv := httpagent.Argv(httpagent.DefaultZCL, spec, marker)
+	if len(flow.Runner.Command) > 0 && !slices.Equal(flow.Runner.Command, argv) {
+		return fmt.Errorf("flow %q: runner.command is built from runner.http for runner.type=%s (remove it)", flow.FlowID, RunnerTypeHTTPAgent)
+	}
+	flow.Runner.HTTP = RunnerHTTPSpec{URL: spec.URL, Headers: spec.Headers, PollIntervalMs: spec.PollIntervalMs}
+	flow.Runner.Command = argv
+	return nil
+}
+
+// normalizeFlowRunnerWSAgent validates runner.ws and builds a ws_agent flow's command: zcl's
+// `ws agent`, which prints the agent's result for the stdout_json result channel.
+func normalizeFlowRunnerWSAgent(flow *FlowSpec) error {
+	w := flow.Runner.WS
+	if flow.Runner.Type != RunnerTypeWSAgent {
+		if strings.TrimSpace(w.URL) != "" || len(w.Headers) > 0 || w.InterruptGraceMs != 0 {
+			return fmt.Errorf("flow %q: runner.ws is supported only for runner.type=%s", flow.FlowID, RunnerTypeWSAgent)
+		}
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(flow.Runner.SessionIsolation), "native") {
+		return fmt.Errorf("flow %q: runner.type=%s does not support runner.sessionIsolation=native", flow.FlowID, RunnerTypeWSAgent)
+	}
+	if flow.Runner.Finalization.ResultChannel.Kind != ResultChannelStdoutJSON {
+		return fmt.Errorf("flow %q: runner.type=%s requires runner.finalization.resultChannel.kind=%s", flow.FlowID, RunnerTypeWSAgent, ResultChannelStdoutJSON)
+	}
+	spec, err := wsagent.Normalize(wsagent.Spec{URL: w.URL, Headers: w.Headers, InterruptGraceMs: w.InterruptGraceMs})
+	if err != nil {
+		return fmt.Errorf("flow %q: runner.ws: %w", flow.FlowID, err)
+	}
+	if flow.Runner.TimeoutMs > 0 && spec.InterruptGraceMs >= flow.Runner.TimeoutMs {
+		return fmt.Errorf("flow %q: runner.ws.interruptGraceMs must be below runner.timeoutMs", flow.FlowID)
+	}
+	marker := strings.TrimSpace(flow.Runner.Finalization.ResultChannel.Marker)
+	if marker == "" {
+		marker = DefaultResultChannelMarker
+	}
+	argv := wsagent.Argv(wsagent.DefaultZCL, spec, marker)
+	if len(flow.Runner.Command) > 0 && !slices.Equal(flow.Runner.Command, argv) {
+		return fmt.Errorf("flow %q: runner.command is built from runner.ws for runner.type=%s (remove it)", flow.FlowID, RunnerTypeWSAgent)
+	}
+	flow.Runner.WS = RunnerWSSpec{URL: spec.URL, Headers: spec.Headers, InterruptGraceMs: spec.InterruptGraceMs}
+	flow.Runner.Command = argv
+	return nil
+}
+
+// IsAgentServiceRunner reports runner types whose agent is a service zcl talks to through its own
+// runner command (http_agent, ws_agent).
+func IsAgentServiceRunner(runnerType string) bool {
+	return runnerType == RunnerTypeHTTPAgent || runnerType == RunnerTypeWSAgent
+}
+
+// SSHRemoteSpec returns the remote policy of a runner.type=ssh flow.
+func SSHRemoteSpec(flow FlowSpec) remote.Spec {
+	s := flow.Runner.SSH
+	return remote.Spec{Host: s.Host, Port: s.Port, Identity: s.Identity, WorkDir: s.WorkDir, ZCLPath: s.ZCL, Sync: s.Sync}
+}
+
+// normalizeFlowRunnerHome validates runner.home and resolves its template against the spec dir.
+func normalizeFlowRunnerHome(flow *FlowSpec, specDir string) error {
+	mode, err := home.ParseMode(flow.Runner.Home.Mode)
+	if err != nil {
+		return fmt.Errorf("flow %q: runner.home: %w", flow.FlowID, err)
+	}
+	flow.Runner.Home.Mode = mode
+	template := strings.TrimSpace(flow.Runner.Home.Template)
+	if mode != home.ModeEphemeral {
+		if template != "" {
+			return fmt.Errorf("flow %q: runner.home.template requires runner.home.mode=%s", flow.FlowID, home.ModeEphemeral)
+		}
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(flow.Runner.SessionIsolation), "native") {
+		return fmt.Errorf("flow %q: runner.home.mode=%s does not support runner.sessionIsolation=native", flow.FlowID, home.ModeEphemeral)
+	}
+	if flow.Runner.Type == RunnerTypeDocker {
+		return fmt.Errorf("flow %q: runner.home.mode=%s is not supported for runner.type=%s (containers start from the image's home)", flow.FlowID, home.ModeEphemeral, RunnerTypeDocker)
+	}
+	if flow.Runner.Type == RunnerTypeSSH {
+		return fmt.Errorf("flow %q: runner.home.mode=%s is not supported for runner.type=%s (remote runners use the remote login's home)", flow.FlowID, home.ModeEphemeral, RunnerTypeSSH)
+	}
+	if template != "" && !filepath.IsAbs(template) {
+		template = filepath.Join(specDir, template)
+	}
+	flow.Runner.Home.Template = template
+	return nil
+}
+
+// normalizeFlowRunnerMCPServers validates runner.mcpServers; only native sessions register them.
+func normalizeFlowRunnerMCPServers(flow *FlowSpec) error {
+	if len(flow.Runner.MCPServers) == 0 {
+		return nil
+	}
+	if err := suite.NormalizeMCPServers(flow.Runner.MCPServers); err != nil {
+		return fmt.Errorf("flow %q: runner.mcpServers: %w", flow.FlowID, err)
+	}
+	if !strings.EqualFold(strings.TrimSpace(flow.Runner.SessionIsolation), "native") {
+		return fmt.Errorf("flow %q: runner.mcpServers requires runner.sessionIsolation=native", flow.FlowID)
+	}
+	return nil
+}
+
+// normalizeFlowRunnerLimits validates runner.limits; docker flows fold them into the container limits.
+func normalizeFlowRunnerLimits(flow *FlowSpec) error {
+	l := flow.Runner.Limits
+	if l.CPU < 0 || l.MemoryMB < 0 || l.Pids < 0 {
+		return fmt.Errorf("flow %q: runner.limits fields must be >= 0", flow.FlowID)
+	}
+	if flow.Runner.DiskQuotaMb < 0 {
+		return fmt.Errorf("flow %q: runner.diskQuotaMb must be >= 0", flow.FlowID)
+	}
+	if l == (RunnerLimitsSpec{}) {
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(flow.Runner.SessionIsolation), "native") {
+		return fmt.Errorf("flow %q: runner.limits does not support runner.sessionIsolation=native", flow.FlowID)
+	}
+	if flow.Runner.Type == RunnerTypeDocker {
+		if flow.Runner.Docker.Limits != (RunnerLimitsSpec{}) {
+			return fmt.Errorf("flow %q: set either runner.limits or runner.docker.limits, not both", flow.FlowID)
+		}
+		flow.Runner.Docker.Limits = l
+	}
+	return nil
+}
+
+// RunnerLimits returns the runner.limits of a process-runner flow (docker flows carry them in
+// their container policy).
+func RunnerLimits(flow FlowSpec) container.Limits {
+	if flow.Runner.Type == RunnerTypeDocker {
+		return container.Limits{}
+	}
+	l := flow.Runner.Limits
+	return container.Limits{CPU: l.CPU, MemoryMB: l.MemoryMB, Pids: l.Pids}
+}
+
+// DockerContainerSpec returns the container policy of a runner.type=docker flow.
+func DockerContainerSpec(flow FlowSpec) container.Spec {
+	d := flow.Runner.Docker
+	return container.Spec{
+		Engine:  d.ContainerEngine,
+		Image:   d.Image,
+		Mounts:  d.Mounts,
+		Network: d.Network,
+		Limits:  container.Limits{CPU: d.Limits.CPU, MemoryMB: d.Limits.MemoryMB, Pids: d.Limits.Pids},
+	}
+}
+
 func validateFlowRunnerCwd(flow *FlowSpec) error {
 	if !isValidRunnerCwdMode(flow.Runner.Cwd.Mode) {
 		return fmt.Errorf("flow %q: invalid runner.cwd.mode (expected %s|%s)", flow.FlowID, RunnerCwdModeInherit, RunnerCwdModeTempEmptyPerAttempt)
@@ -1716,7 +2316,8 @@ func ResolveExecutionMode(parsed ParsedSpec) ExecutionModeSummary {
 		return out
 	}
 	for _, flow := range parsed.Spec.Flows {
-		if len(flow.Runner.Command) == 0 {
+		// claude_cli and agent service commands are built by zcl, not adapter scripts.
+		if len(flow.Runner.Command) == 0 || flow.Runner.Type == RunnerTypeClaudeCLI || IsAgentServiceRunner(flow.Runner.Type) {
 			continue
 		}
 		if flow.Runner.ToolDriver.Kind == ToolDriverShell {
@@ -1934,7 +2535,7 @@ func dedupeStringsStable(in []string) []string {
 
 func isValidRunnerType(v string) bool {
 	switch strings.TrimSpace(strings.ToLower(v)) {
-	case RunnerTypeProcessCmd, RunnerTypeCodexExec, RunnerTypeCodexSub, RunnerTypeClaudeSub, RunnerTypeCodexAppSrv:
+	case RunnerTypeProcessCmd, RunnerTypeCodexExec, RunnerTypeCodexSub, RunnerTypeClaudeSub, RunnerTypeClaudeCLI, RunnerTypeHTTPAgent, RunnerTypeWSAgent, RunnerTypeCodexAppSrv, RunnerTypeDocker, RunnerTypeSSH:
 		return true
 	default:
 		return false
@@ -1970,7 +2571,7 @@ func isValidEvaluationMode(v string) bool {
 
 func isValidEvaluatorKind(v string) bool {
 	switch strings.TrimSpace(strings.ToLower(v)) {
-	case EvaluatorKindScript, EvaluatorKindBuiltin:
+	case EvaluatorKindScript, EvaluatorKindBuiltin, EvaluatorKindBuiltinCompare, EvaluatorKindLLMJudge:
 		return true
 	default:
 		return false
@@ -0,0 +1,8 @@
+package campaign
+
+import _ "embed"
+
+// SpecJSONSchema is the canonical JSON Schema for campaign spec files (exported by `zcl schema export`).
+//
+//go:embed campaign.spec.schema.json
+var SpecJSONSchema []byte
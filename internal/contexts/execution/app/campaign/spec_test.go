@@ -955,6 +955,195 @@ flows:
 	}
 }
 
+func TestParseSpecFile_LLMJudgeRequiresModelAndDefaultsRuntime(t *testing.T) {
+	dir := t.TempDir()
+	suitePath := filepath.Join(dir, "suite.json")
+	if err := os.WriteFile(suitePath, []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	writeSpec := func(evaluator string) string {
+		specPath := filepath.Join(dir, "campaign.yaml")
+		if err := os.WriteFile(specPath, []byte(`
+schemaVersion: 1
+campaignId: cmp-llm-judge
+evaluation:
+  mode: oracle
+  evaluator:
+`+evaluator+`
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["echo","ok"]
+`), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+		return specPath
+	}
+
+	if _, err := ParseSpecFile(writeSpec("    kind: llm_judge")); err == nil || !strings.Contains(err.Error(), "evaluation.evaluator.model") {
+		t.Fatalf("expected missing model error, got %v", err)
+	}
+	if _, err := ParseSpecFile(writeSpec("    kind: builtin_compare\n    model: gpt-5")); err == nil {
+		t.Fatalf("expected model to be rejected for non-judge evaluator")
+	}
+	ps, err := ParseSpecFile(writeSpec("    kind: llm_judge\n    model: gpt-5"))
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	if got := ps.Spec.Evaluation.Evaluator.RuntimeStrategies; len(got) != 1 || got[0] != RunnerTypeCodexAppSrv {
+		t.Fatalf("expected default judge runtime chain, got %v", got)
+	}
+}
+
+func TestParseSpecFile_EvaluatorEnsembleDefaultsIDsAndPolicy(t *testing.T) {
+	dir := t.TempDir()
+	suitePath := filepath.Join(dir, "suite.json")
+	if err := os.WriteFile(suitePath, []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	writeSpec := func(evaluation string) string {
+		specPath := filepath.Join(dir, "campaign.yaml")
+		if err := os.WriteFile(specPath, []byte(`
+schemaVersion: 1
+campaignId: cmp-ensemble
+evaluation:
+  mode: oracle
+`+evaluation+`
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["echo","ok"]
+`), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+		return specPath
+	}
+
+	ps, err := ParseSpecFile(writeSpec(`  evaluators:
+    - kind: builtin_compare
+    - kind: llm_judge
+      model: gpt-5
+    - kind: llm_judge
+      model: other-model`))
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	ev := ps.Spec.Evaluation
+	if ev.EnsemblePolicy != EnsemblePolicyMajority || ev.Evaluator.Kind != "" {
+		t.Fatalf("unexpected ensemble defaults: policy=%q evaluator=%+v", ev.EnsemblePolicy, ev.Evaluator)
+	}
+	if ev.Evaluators[0].ID != "builtin_compare" || ev.Evaluators[1].ID != "llm_judge" || ev.Evaluators[2].ID != "llm_judge-2" {
+		t.Fatalf("unexpected member ids: %+v", ev.Evaluators)
+	}
+
+	if _, err := ParseSpecFile(writeSpec("  evaluator:\n    kind: builtin_compare\n  evaluators:\n    - kind: builtin_compare")); err == nil {
+		t.Fatalf("expected evaluator and evaluators together to be rejected")
+	}
+	if _, err := ParseSpecFile(writeSpec("  ensemblePolicy: quorum\n  evaluators:\n    - kind: builtin_compare")); err == nil {
+		t.Fatalf("expected invalid ensemble policy to be rejected")
+	}
+	if _, err := ParseSpecFile(writeSpec("  evaluators:\n    - id: a\n      kind: builtin_compare\n    - id: a\n      kind: builtin_rules")); err == nil || !strings.Contains(err.Error(), "duplicate id") {
+		t.Fatalf("expected duplicate id error, got %v", err)
+	}
+}
+
+func TestParseSpecFile_RubricDefaultsWeightAndValidatesCriteria(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	writeSpec := func(evaluation string) string {
+		specPath := filepath.Join(dir, "campaign.yaml")
+		if err := os.WriteFile(specPath, []byte(`
+schemaVersion: 1
+campaignId: cmp-rubric
+evaluation:
+  mode: oracle
+`+evaluation+`
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["echo","ok"]
+`), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+		return specPath
+	}
+
+	ps, err := ParseSpecFile(writeSpec(`  rubric:
+    - criterion: answer
+      weight: 2
+      evaluator:
+        kind: builtin_compare
+    - criterion: tone
+      evaluator:
+        kind: llm_judge
+        model: gpt-5`))
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	ev := ps.Spec.Evaluation
+	if ev.Evaluator.Kind != "" {
+		t.Fatalf("rubric-only evaluation must not default an evaluator, got %+v", ev.Evaluator)
+	}
+	if ev.Rubric[0].Weight != 2 || ev.Rubric[1].Weight != 1 || ev.Rubric[1].Evaluator.ID != "tone" {
+		t.Fatalf("unexpected rubric normalization: %+v", ev.Rubric)
+	}
+
+	if _, err := ParseSpecFile(writeSpec("  rubricPassScore: 1.5\n  rubric:\n    - criterion: a\n      evaluator:\n        kind: builtin_compare")); err == nil {
+		t.Fatalf("expected out-of-range rubricPassScore to be rejected")
+	}
+	if _, err := ParseSpecFile(writeSpec("  rubric:\n    - criterion: a\n      evaluator:\n        kind: script")); err == nil || !strings.Contains(err.Error(), "evaluation.rubric[0].evaluator") {
+		t.Fatalf("expected script criterion without command to be rejected, got %v", err)
+	}
+}
+
+func TestParseSpecFile_InlineSemanticRules(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	writeSpec := func(semantic string) string {
+		specPath := filepath.Join(dir, "campaign.yaml")
+		if err := os.WriteFile(specPath, []byte(`
+schemaVersion: 1
+campaignId: cmp-semantic
+semantic:
+  enabled: true
+`+semantic+`
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["echo","ok"]
+`), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+		return specPath
+	}
+
+	ps, err := ParseSpecFile(writeSpec("  rules:\n    missions:\n      m1:\n        requiredJsonPointers: [\"/title\"]"))
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	if ps.Spec.Semantic.Rules == nil || ps.Spec.Semantic.Rules.SchemaVersion != 1 || ps.Spec.Semantic.Rules.Missions["m1"] == nil {
+		t.Fatalf("unexpected inline semantic rules: %+v", ps.Spec.Semantic.Rules)
+	}
+	if _, err := ParseSpecFile(writeSpec("  rulesPath: rules.yaml\n  rules:\n    default:\n      minMeaningfulFields: 1")); err == nil {
+		t.Fatalf("expected rulesPath and rules together to be rejected")
+	}
+	if _, err := ParseSpecFile(writeSpec("  rules:\n    default:\n      checks:\n        - kind: bogus\n          pointer: /x")); err == nil || !strings.Contains(err.Error(), "semantic.rules") {
+		t.Fatalf("expected invalid inline check to be rejected, got %v", err)
+	}
+}
+
 func TestParseSpecFile_ExamModeRejectsPromptOracleLeak(t *testing.T) {
 	dir := t.TempDir()
 	promptDir := filepath.Join(dir, "prompts")
@@ -1331,3 +1520,340 @@ flows:
 		t.Fatalf("expected typed toolPolicy config error, got %v", err)
 	}
 }
+
+func TestParseSpecFile_DockerRunnerNormalizedAndScoped(t *testing.T) {
+	dir := t.TempDir()
+	suitePath := filepath.Join(dir, "suite.json")
+	if err := os.WriteFile(suitePath, []byte(`{
+  "version": 1,
+  "suiteId": "suite-a",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1" }
+  ]
+}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	specPath := filepath.Join(dir, "campaign.yaml")
+	if err := os.WriteFile(specPath, []byte(`
+schemaVersion: 1
+campaignId: cmp-docker
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: DOCKER
+      command: ["./agent.sh"]
+      docker:
+        image: " agent:latest "
+        mounts: ["fixtures:/fixtures:ro"]
+        containerEngine: podman
+        limits: { cpu: 2, memoryMb: 2048 }
+`), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	ps, err := ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	flow := ps.Spec.Flows[0]
+	got := flow.Runner.Docker
+	if flow.Runner.Type != RunnerTypeDocker || flow.Runner.SessionIsolation != "process" || got.Image != "agent:latest" || got.Network != "bridge" {
+		t.Fatalf("unexpected docker runner: type=%q isolation=%q docker=%+v", flow.Runner.Type, flow.Runner.SessionIsolation, got)
+	}
+	if want := filepath.Join(dir, "fixtures") + ":/fixtures:ro"; len(got.Mounts) != 1 || got.Mounts[0] != want {
+		t.Fatalf("expected mount %q, got %v", want, got.Mounts)
+	}
+	if cs := DockerContainerSpec(flow); cs.Engine != "podman" || cs.Limits.CPU != 2 || cs.Limits.MemoryMB != 2048 {
+		t.Fatalf("unexpected container spec: %+v", cs)
+	}
+
+	for _, tc := range []struct{ runner, want string }{
+		{"type: docker\n      command: [\"./agent.sh\"]", "runner.docker: missing container image"},
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      docker:\n        image: agent", "runner.docker is supported only for runner.type=docker"},
+	} {
+		if err := os.WriteFile(specPath, []byte("schemaVersion: 1\ncampaignId: cmp-docker-bad\nflows:\n  - flowId: flow-a\n    suiteFile: suite.json\n    runner:\n      "+tc.runner+"\n"), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+		if _, err := ParseSpecFile(specPath); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestParseSpecFile_RunnerLimits(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	specPath := filepath.Join(dir, "campaign.yaml")
+	write := func(runner string) {
+		t.Helper()
+		if err := os.WriteFile(specPath, []byte("schemaVersion: 1\ncampaignId: cmp-limits\nflows:\n  - flowId: flow-a\n    suiteFile: suite.json\n    runner:\n      "+runner+"\n"), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+	}
+
+	write("type: process_cmd\n      command: [\"./agent.sh\"]\n      limits: { cpu: 2, memoryMb: 2048 }")
+	ps, err := ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	if l := RunnerLimits(ps.Spec.Flows[0]); l.CPU != 2 || l.MemoryMB != 2048 {
+		t.Fatalf("unexpected runner limits: %+v", l)
+	}
+
+	write("type: docker\n      command: [\"./agent.sh\"]\n      docker: { image: agent }\n      limits: { memoryMb: 512 }")
+	if ps, err = ParseSpecFile(specPath); err != nil {
+		t.Fatalf("ParseSpecFile docker: %v", err)
+	}
+	if flow := ps.Spec.Flows[0]; DockerContainerSpec(flow).Limits.MemoryMB != 512 || !RunnerLimits(flow).IsZero() {
+		t.Fatalf("docker flow limits must move into the container policy: %+v", flow.Runner)
+	}
+
+	for _, tc := range []struct{ runner, want string }{
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      limits: { memoryMb: -1 }", "runner.limits fields must be >= 0"},
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      diskQuotaMb: -5", "runner.diskQuotaMb must be >= 0"},
+		{"type: docker\n      command: [\"./agent.sh\"]\n      docker: { image: agent, limits: { cpu: 1 } }\n      limits: { cpu: 2 }", "not both"},
+	} {
+		write(tc.runner)
+		if _, err := ParseSpecFile(specPath); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestParseSpecFile_RunnerHome(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	specPath := filepath.Join(dir, "campaign.yaml")
+	write := func(runner string) {
+		t.Helper()
+		if err := os.WriteFile(specPath, []byte("schemaVersion: 1\ncampaignId: cmp-home\nflows:\n  - flowId: flow-a\n    suiteFile: suite.json\n    runner:\n      "+runner+"\n"), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+	}
+
+	write("type: process_cmd\n      command: [\"./agent.sh\"]\n      home: { mode: ephemeral, template: home-template }")
+	ps, err := ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	if h := ps.Spec.Flows[0].Runner.Home; h.Mode != "ephemeral" || h.Template != filepath.Join(dir, "home-template") {
+		t.Fatalf("unexpected runner home: %+v", h)
+	}
+
+	for _, tc := range []struct{ runner, want string }{
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      home: { mode: shared }", "invalid home mode"},
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      home: { template: t }", "requires runner.home.mode=ephemeral"},
+		{"type: docker\n      command: [\"./agent.sh\"]\n      docker: { image: agent }\n      home: { mode: ephemeral }", "not supported for runner.type=docker"},
+	} {
+		write(tc.runner)
+		if _, err := ParseSpecFile(specPath); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestParseSpecFile_RunnerMCPServers(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	specPath := filepath.Join(dir, "campaign.yaml")
+	write := func(runner string) {
+		t.Helper()
+		if err := os.WriteFile(specPath, []byte("schemaVersion: 1\ncampaignId: cmp-mcp\nflows:\n  - flowId: flow-a\n    suiteFile: suite.json\n    runner:\n      "+runner+"\n"), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+	}
+
+	write("type: codex_app_server\n      mcpServers:\n        docs: { command: [\" docs-mcp \", \"--stdio\"], env: { DOCS_ROOT: /srv/docs } }")
+	ps, err := ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	if srv := ps.Spec.Flows[0].Runner.MCPServers["docs"]; len(srv.Command) != 2 || srv.Command[0] != "docs-mcp" || srv.Env["DOCS_ROOT"] != "/srv/docs" {
+		t.Fatalf("unexpected runner mcpServers: %+v", ps.Spec.Flows[0].Runner.MCPServers)
+	}
+
+	for _, tc := range []struct{ runner, want string }{
+		{"type: codex_app_server\n      mcpServers: { \"bad name\": { command: [x] } }", "server name"},
+		{"type: codex_app_server\n      mcpServers: { docs: { env: { A: b } } }", "docs.command is required"},
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      mcpServers: { docs: { command: [x] } }", "requires runner.sessionIsolation=native"},
+	} {
+		write(tc.runner)
+		if _, err := ParseSpecFile(specPath); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestParseSpecFile_ClaudeCLIRunner(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	specPath := filepath.Join(dir, "campaign.yaml")
+	write := func(runner string) {
+		t.Helper()
+		if err := os.WriteFile(specPath, []byte("schemaVersion: 1\ncampaignId: cmp-claude\nflows:\n  - flowId: flow-a\n    suiteFile: suite.json\n    runner:\n      "+runner+"\n"), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+	}
+
+	write("type: claude_cli\n      claude: { model: sonnet, maxTurns: 5, mcpConfig: mcp.json }")
+	ps, err := ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	flow := ps.Spec.Flows[0]
+	want := []string{"claude", "--print", "--output-format", "json", "--model", "sonnet", "--max-turns", "5", "--mcp-config", filepath.Join(dir, "mcp.json"), "--strict-mcp-config"}
+	if strings.Join(flow.Runner.Command, " ") != strings.Join(want, " ") || flow.Runner.SessionIsolation != "process" || flow.Runner.Claude.OutputFormat != "json" {
+		t.Fatalf("unexpected claude_cli runner: %+v", flow.Runner)
+	}
+
+	for _, tc := range []struct{ runner, want string }{
+		{"type: claude_cli\n      command: [\"./agent.sh\"]", "runner.command is built from runner.claude"},
+		{"type: claude_cli\n      sessionIsolation: native", "does not support runner.sessionIsolation=native"},
+		{"type: claude_cli\n      claude: { permissionMode: yolo }", "runner.claude: invalid permissionMode"},
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      claude: { model: sonnet }", "runner.claude is supported only for runner.type=claude_cli"},
+	} {
+		write(tc.runner)
+		if _, err := ParseSpecFile(specPath); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestParseSpecFile_AgentServiceRunners(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	specPath := filepath.Join(dir, "campaign.yaml")
+	write := func(runner string) {
+		t.Helper()
+		if err := os.WriteFile(specPath, []byte("schemaVersion: 1\ncampaignId: cmp-http\nflows:\n  - flowId: flow-a\n    suiteFile: suite.json\n    runner:\n      "+runner+"\n"), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+	}
+
+	write("type: http_agent\n      http: { url: 'https://agent.example/run', headers: { Authorization: 'Bearer ${AGENT_TOKEN}' } }")
+	ps, err := ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	write("type: ws_agent\n      ws: { url: 'wss://agent.example/ws', interruptGraceMs: 2000 }")
+	ps, err = ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile ws_agent: %v", err)
+	}
+	if got := strings.Join(ps.Spec.Flows[0].Runner.Command, " "); got != "zcl ws agent --url wss://agent.example/ws --interrupt-grace-ms 2000 --result-marker ZCL_RESULT_JSON:" {
+		t.Fatalf("unexpected ws_agent command: %s", got)
+	}
+
+	write("type: http_agent\n      http: { url: 'https://agent.example/run', headers: { Authorization: 'Bearer ${AGENT_TOKEN}' } }")
+	ps, err = ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	r := ps.Spec.Flows[0].Runner
+	want := "zcl http agent --url https://agent.example/run --header Authorization: Bearer ${AGENT_TOKEN} --poll-interval-ms 1000 --result-marker ZCL_RESULT_JSON:"
+	if strings.Join(r.Command, " ") != want || r.Finalization.Mode != FinalizationModeAutoFromResultJSON || r.Finalization.ResultChannel.Kind != ResultChannelStdoutJSON {
+		t.Fatalf("unexpected http_agent runner: %+v", r)
+	}
+
+	for _, tc := range []struct{ runner, want string }{
+		{"type: http_agent", "runner.http: missing url"},
+		{"type: http_agent\n      http: { url: 'http://agent' }\n      command: [\"./agent.sh\"]", "runner.command is built from runner.http"},
+		{"type: http_agent\n      http: { url: 'http://agent' }\n      finalization: { resultChannel: { kind: file_json } }", "requires runner.finalization.resultChannel.kind=stdout_json"},
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      http: { url: 'http://agent' }", "runner.http is supported only for runner.type=http_agent"},
+		{"type: ws_agent\n      ws: { url: 'http://agent' }", "runner.ws: invalid url"},
+		{"type: ws_agent\n      timeoutMs: 3000\n      ws: { url: 'ws://agent' }", "interruptGraceMs must be below runner.timeoutMs"},
+	} {
+		write(tc.runner)
+		if _, err := ParseSpecFile(specPath); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestParseSpecFile_SSHRunner(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	specPath := filepath.Join(dir, "campaign.yaml")
+	write := func(runner string) {
+		t.Helper()
+		if err := os.WriteFile(specPath, []byte("schemaVersion: 1\ncampaignId: cmp-ssh\nflows:\n  - flowId: flow-a\n    suiteFile: suite.json\n    runner:\n      "+runner+"\n"), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+	}
+
+	write("type: SSH\n      command: [\"./agent.sh\"]\n      ssh: { host: \" ci@build-1 \", port: 2222, identity: keys/id_ed25519, sync: [\"out/\", \"*.log\"] }")
+	ps, err := ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	flow := ps.Spec.Flows[0]
+	got := flow.Runner.SSH
+	if flow.Runner.Type != RunnerTypeSSH || got.Host != "ci@build-1" || got.WorkDir != "/tmp/zcl-remote" || got.ZCL != "zcl" || got.Identity != filepath.Join(dir, "keys", "id_ed25519") {
+		t.Fatalf("unexpected ssh runner: type=%q ssh=%+v", flow.Runner.Type, got)
+	}
+	if rs := SSHRemoteSpec(flow); rs.Port != 2222 || len(rs.Sync) != 2 || rs.Sync[0] != "out" {
+		t.Fatalf("unexpected remote spec: %+v", rs)
+	}
+
+	for _, tc := range []struct{ runner, want string }{
+		{"type: ssh\n      command: [\"./agent.sh\"]", "runner.ssh: missing ssh host"},
+		{"type: ssh\n      command: [\"./agent.sh\"]\n      ssh: { host: \"-oProxyCommand=x\" }", "invalid ssh host"},
+		{"type: ssh\n      command: [\"./agent.sh\"]\n      ssh: { host: h, workDir: relative }", "invalid ssh workDir"},
+		{"type: ssh\n      command: [\"./agent.sh\"]\n      ssh: { host: h }\n      limits: { cpu: 1 }", "runner.limits is not supported for runner.type=ssh"},
+		{"type: ssh\n      command: [\"./agent.sh\"]\n      ssh: { host: h }\n      home: { mode: ephemeral }", "not supported for runner.type=ssh"},
+		{"type: process_cmd\n      command: [\"./agent.sh\"]\n      ssh: { host: h }", "runner.ssh is supported only for runner.type=ssh"},
+	} {
+		write(tc.runner)
+		if _, err := ParseSpecFile(specPath); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestParseSpecFile_EventHooks(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "suite.json"), []byte(`{"version":1,"suiteId":"suite-a","missions":[{"missionId":"m1","prompt":"p1"}]}`), 0o644); err != nil {
+		t.Fatalf("write suite: %v", err)
+	}
+	specPath := filepath.Join(dir, "campaign.yaml")
+	write := func(hooks string) {
+		t.Helper()
+		if err := os.WriteFile(specPath, []byte("schemaVersion: 1\ncampaignId: cmp-hooks\nflows:\n  - flowId: flow-a\n    suiteFile: suite.json\n    runner:\n      type: process_cmd\n      command: [\"./agent.sh\"]\nhooks:\n  onEvent:\n"+hooks+"\n"), 0o644); err != nil {
+			t.Fatalf("write spec: %v", err)
+		}
+	}
+
+	write("    - { match: \" Gate_Failed \", command: [\" ./notify.sh \", \"--slack\"], timeoutMs: 2000 }\n    - { match: \"cleanup_*\", command: [\"./log.sh\"] }")
+	ps, err := ParseSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ParseSpecFile: %v", err)
+	}
+	hooks := ps.Spec.Hooks.OnEvent
+	if len(hooks) != 2 || hooks[0].Match != "gate_fail" || hooks[0].Command[0] != "./notify.sh" || hooks[0].TimeoutMs != 2000 || hooks[1].Match != "cleanup_*" {
+		t.Fatalf("unexpected hooks: %+v", hooks)
+	}
+
+	for _, tc := range []struct{ hooks, want string }{
+		{"    - { match: gate_exploded, command: [x] }", "hooks.onEvent[0].match: unknown event"},
+		{"    - { match: \"*gate*\", command: [x] }", "only a trailing * is supported"},
+		{"    - { match: valid }", "hooks.onEvent[0].command is required"},
+		{"    - { match: valid, command: [x], timeoutMs: -1 }", "hooks.onEvent[0].timeoutMs must be >= 0"},
+	} {
+		write(tc.hooks)
+		if _, err := ParseSpecFile(specPath); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
@@ -4,12 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/ports/statestore"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
@@ -53,6 +53,9 @@ type UpdateInput struct {
 	FailFast         bool
 	Passed           int
 	Failed           int
+
+	// Store is the state backend; nil rewrites the JSON file directly.
+	Store statestore.Store
 }
 
 func DefaultStatePath(outRoot string, campaignID string) string {
@@ -71,44 +74,46 @@ func UpdateState(path string, in UpdateInput) (StateV1, error) {
 		return StateV1{}, fmt.Errorf("campaign update requires campaignId, suiteId, runId")
 	}
 
-	st, err := loadCampaignState(path, campaignID, suiteID)
-	if err != nil {
-		return StateV1{}, err
-	}
-	run := toRunSummary(in)
-	upsertRunSummary(&st, run)
-	sort.Slice(st.Runs, func(i, j int) bool {
-		ti, _ := parseTS(st.Runs[i].CreatedAt)
-		tj, _ := parseTS(st.Runs[j].CreatedAt)
-		if !ti.Equal(tj) {
-			return ti.Before(tj)
+	// The read, upsert and write happen inside one backend transaction so concurrent suite runs
+	// sharing a campaign ID cannot drop each other's run summaries (sqlite backend).
+	var st StateV1
+	err := stateStoreOrFile(in.Store).Update(path, func(cur []byte) ([]byte, error) {
+		var err error
+		st, err = decodeCampaignState(cur, campaignID, suiteID)
+		if err != nil {
+			return nil, err
 		}
-		return st.Runs[i].RunID < st.Runs[j].RunID
+		run := toRunSummary(in)
+		upsertRunSummary(&st, run)
+		sort.Slice(st.Runs, func(i, j int) bool {
+			ti, _ := parseTS(st.Runs[i].CreatedAt)
+			tj, _ := parseTS(st.Runs[j].CreatedAt)
+			if !ti.Equal(tj) {
+				return ti.Before(tj)
+			}
+			return st.Runs[i].RunID < st.Runs[j].RunID
+		})
+		st.SchemaVersion = 1
+		st.CampaignID = campaignID
+		st.SuiteID = suiteID
+		st.UpdatedAt = in.Now.UTC().Format(time.RFC3339Nano)
+		st.LatestRunID = latestRunID(st.Runs)
+		return store.MarshalJSONIndented(st)
 	})
-	st.SchemaVersion = 1
-	st.CampaignID = campaignID
-	st.SuiteID = suiteID
-	st.UpdatedAt = in.Now.UTC().Format(time.RFC3339Nano)
-	st.LatestRunID = latestRunID(st.Runs)
-
-	if err := store.WriteJSONAtomic(path, st); err != nil {
+	if err != nil {
 		return StateV1{}, err
 	}
 	return st, nil
 }
 
-func loadCampaignState(path string, campaignID string, suiteID string) (StateV1, error) {
+func decodeCampaignState(raw []byte, campaignID string, suiteID string) (StateV1, error) {
 	st := StateV1{
 		SchemaVersion: 1,
 		CampaignID:    campaignID,
 		SuiteID:       suiteID,
 	}
-	raw, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return st, nil
-		}
-		return StateV1{}, err
+	if raw == nil {
+		return st, nil
 	}
 	if err := json.Unmarshal(raw, &st); err != nil {
 		return StateV1{}, err
@@ -0,0 +1,43 @@
+package campaign
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/ports/statestore"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// StateDBPath is where the sqlite campaign state backend keeps its database.
+func StateDBPath(outRoot string, campaignID string) string {
+	return filepath.Join(CampaignDir(outRoot, campaignID), artifacts.CampaignStateDB)
+}
+
+// fileStore is the default backend: a plain read-modify-write of the JSON file. It is only safe
+// for a single writer; the campaign lock covers the run-state engine, not concurrent suite runs.
+type fileStore struct{}
+
+func (fileStore) Update(path string, fn func(cur []byte) ([]byte, error)) error {
+	cur, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		cur = nil
+	}
+	out, err := fn(cur)
+	if err != nil {
+		return err
+	}
+	return store.WriteFileAtomic(path, out)
+}
+
+func (fileStore) Close() error { return nil }
+
+func stateStoreOrFile(s statestore.Store) statestore.Store {
+	if s == nil {
+		return fileStore{}
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+package campaign
+
+import (
+	"math"
+	"time"
+)
+
+// ProgressStatusRunProgress marks periodic throughput checkpoints in campaign.progress.jsonl; the
+// suite run progress stream uses the same name as an event kind.
+const ProgressStatusRunProgress = "run_progress"
+
+// RunProgressV1 is a throughput snapshot for dashboards: attempts done and running, the observed
+// rate and the time remaining at that rate.
+type RunProgressV1 struct {
+	Completed      int     `json:"completed"`
+	InFlight       int     `json:"inFlight"`
+	Total          int     `json:"total"`
+	ElapsedMs      int64   `json:"elapsedMs"`
+	AttemptsPerMin float64 `json:"attemptsPerMin"`
+	// EtaMs is omitted until at least one attempt completed (no rate to extrapolate from).
+	EtaMs *int64 `json:"etaMs,omitempty"`
+}
+
+// ComputeRunProgress derives rate and ETA from completed attempts over elapsed wall-clock time.
+func ComputeRunProgress(completed, inFlight, total int, elapsed time.Duration) RunProgressV1 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	out := RunProgressV1{
+		Completed: completed,
+		InFlight:  inFlight,
+		Total:     total,
+		ElapsedMs: elapsed.Milliseconds(),
+	}
+	if completed <= 0 || elapsed <= 0 {
+		return out
+	}
+	perMin := float64(completed) / elapsed.Minutes()
+	out.AttemptsPerMin = math.Round(perMin*100) / 100
+	remaining := total - completed
+	if remaining < 0 {
+		remaining = 0
+	}
+	eta := int64(float64(remaining) / perMin * float64(time.Minute/time.Millisecond))
+	out.EtaMs = &eta
+	return out
+}
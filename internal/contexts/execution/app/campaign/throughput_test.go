@@ -0,0 +1,21 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeRunProgress_RateAndETA(t *testing.T) {
+	p := ComputeRunProgress(0, 2, 10, 30*time.Second)
+	if p.EtaMs != nil || p.AttemptsPerMin != 0 || p.InFlight != 2 {
+		t.Fatalf("expected no rate/ETA before the first completion, got %+v", p)
+	}
+
+	p = ComputeRunProgress(4, 2, 10, 2*time.Minute)
+	if p.AttemptsPerMin != 2 || p.ElapsedMs != 120000 {
+		t.Fatalf("unexpected rate: %+v", p)
+	}
+	if p.EtaMs == nil || *p.EtaMs != 180000 {
+		t.Fatalf("expected 6 remaining at 2/min to take 3m, got %+v", p.EtaMs)
+	}
+}
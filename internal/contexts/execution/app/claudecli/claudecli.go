@@ -0,0 +1,141 @@
+package claudecli
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// EnvKey marks a runner.type=claude_cli flow in the attempt env handed to `zcl suite run`, which
+// then appends each attempt's session id and prompt to the runner argv.
+const EnvKey = "ZCL_CLAUDE_CLI"
+
+const (
+	// DefaultBinary is the claude CLI looked up on PATH.
+	DefaultBinary = "claude"
+
+	OutputFormatJSON       = "json"
+	OutputFormatStreamJSON = "stream-json"
+)
+
+var permissionModes = []string{"acceptEdits", "bypassPermissions", "default", "dontAsk", "plan"}
+
+// Spec is the claude invocation of a runner.type=claude_cli flow.
+type Spec struct {
+	Binary             string
+	Model              string
+	MaxTurns           int
+	PermissionMode     string
+	AllowedTools       []string
+	DisallowedTools    []string
+	MCPConfig          string
+	AppendSystemPrompt string
+	OutputFormat       string
+	ExtraArgs          []string
+}
+
+// Normalize fills defaults and validates s; a relative mcpConfig path resolves against baseDir.
+func Normalize(s Spec, baseDir string) (Spec, error) {
+	if s.Binary = strings.TrimSpace(s.Binary); s.Binary == "" {
+		s.Binary = DefaultBinary
+	}
+	s.Model = strings.TrimSpace(s.Model)
+	if s.MaxTurns < 0 {
+		return Spec{}, fmt.Errorf("maxTurns must be >= 0")
+	}
+	s.PermissionMode = strings.TrimSpace(s.PermissionMode)
+	if s.PermissionMode != "" && !validPermissionMode(s.PermissionMode) {
+		return Spec{}, fmt.Errorf("invalid permissionMode %q (expected %s)", s.PermissionMode, strings.Join(permissionModes, "|"))
+	}
+	s.AllowedTools = trimNonEmpty(s.AllowedTools)
+	s.DisallowedTools = trimNonEmpty(s.DisallowedTools)
+	if s.MCPConfig = strings.TrimSpace(s.MCPConfig); s.MCPConfig != "" && !filepath.IsAbs(s.MCPConfig) {
+		s.MCPConfig = filepath.Join(baseDir, s.MCPConfig)
+	}
+	s.AppendSystemPrompt = strings.TrimSpace(s.AppendSystemPrompt)
+	switch s.OutputFormat = strings.ToLower(strings.TrimSpace(s.OutputFormat)); s.OutputFormat {
+	case "":
+		s.OutputFormat = OutputFormatJSON
+	case OutputFormatJSON, OutputFormatStreamJSON:
+	default:
+		return Spec{}, fmt.Errorf("invalid outputFormat %q (expected %s|%s)", s.OutputFormat, OutputFormatJSON, OutputFormatStreamJSON)
+	}
+	s.ExtraArgs = trimNonEmpty(s.ExtraArgs)
+	for _, a := range s.ExtraArgs {
+		// --flag=value sets the flag too.
+		name, _, _ := strings.Cut(a, "=")
+		switch name {
+		case "-p", "--print", "--output-format", "--session-id", "-r", "--resume", "-c", "--continue":
+			return Spec{}, fmt.Errorf("extraArgs must not set %s (zcl sets print mode, output format and a fresh session per attempt)", name)
+		}
+	}
+	return s, nil
+}
+
+// Argv is the flow-level claude invocation: non-interactive print mode with the configured
+// output format and flags. Each attempt appends AttemptArgs.
+func Argv(s Spec) []string {
+	argv := []string{s.Binary, "--print", "--output-format", s.OutputFormat}
+	if s.OutputFormat == OutputFormatStreamJSON {
+		// The CLI refuses stream-json in print mode without --verbose.
+		argv = append(argv, "--verbose")
+	}
+	if s.Model != "" {
+		argv = append(argv, "--model", s.Model)
+	}
+	if s.MaxTurns > 0 {
+		argv = append(argv, "--max-turns", strconv.Itoa(s.MaxTurns))
+	}
+	if s.PermissionMode != "" {
+		argv = append(argv, "--permission-mode", s.PermissionMode)
+	}
+	if len(s.AllowedTools) > 0 {
+		argv = append(argv, "--allowedTools", strings.Join(s.AllowedTools, ","))
+	}
+	if len(s.DisallowedTools) > 0 {
+		argv = append(argv, "--disallowedTools", strings.Join(s.DisallowedTools, ","))
+	}
+	if s.MCPConfig != "" {
+		argv = append(argv, "--mcp-config", s.MCPConfig, "--strict-mcp-config")
+	}
+	if s.AppendSystemPrompt != "" {
+		argv = append(argv, "--append-system-prompt", s.AppendSystemPrompt)
+	}
+	return append(argv, s.ExtraArgs...)
+}
+
+// AttemptArgs pins the attempt to a new session and passes the prompt as the final argument.
+func AttemptArgs(sessionID, prompt string) []string {
+	return []string{"--session-id", sessionID, "--", prompt}
+}
+
+// SessionID derives the attempt's claude session id (a UUID, as the CLI requires) from its run and
+// attempt ids, so it is unique per attempt and reproducible from the attempt dir.
+func SessionID(runID, attemptID string) string {
+	sum := sha256.Sum256([]byte(runID + "/" + attemptID))
+	b := sum[:16]
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func validPermissionMode(v string) bool {
+	for _, m := range permissionModes {
+		if v == m {
+			return true
+		}
+	}
+	return false
+}
+
+func trimNonEmpty(in []string) []string {
+	var out []string
+	for _, s := range in {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package claudecli
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAndArgv(t *testing.T) {
+	s, err := Normalize(Spec{Model: " sonnet ", MaxTurns: 8, PermissionMode: "acceptEdits", AllowedTools: []string{"Bash", " "}, MCPConfig: "mcp.json", OutputFormat: "STREAM-JSON"}, "/spec")
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	want := "claude --print --output-format stream-json --verbose --model sonnet --max-turns 8 --permission-mode acceptEdits --allowedTools Bash --mcp-config /spec/mcp.json --strict-mcp-config"
+	if got := strings.Join(Argv(s), " "); got != want {
+		t.Fatalf("unexpected argv:\n got %s\nwant %s", got, want)
+	}
+	if got := strings.Join(Argv(Spec{Binary: DefaultBinary, OutputFormat: OutputFormatJSON}), " "); got != "claude --print --output-format json" {
+		t.Fatalf("unexpected default argv: %s", got)
+	}
+
+	for _, tc := range []struct {
+		spec Spec
+		want string
+	}{
+		{Spec{MaxTurns: -1}, "maxTurns"},
+		{Spec{PermissionMode: "yolo"}, "invalid permissionMode"},
+		{Spec{OutputFormat: "text"}, "invalid outputFormat"},
+		{Spec{ExtraArgs: []string{"--resume"}}, "extraArgs must not set --resume"},
+		{Spec{ExtraArgs: []string{"--output-format=text"}}, "extraArgs must not set --output-format"},
+		{Spec{ExtraArgs: []string{"--session-id=abc"}}, "extraArgs must not set --session-id"},
+	} {
+		if _, err := Normalize(tc.spec, "/spec"); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestSessionIDIsAStableUUIDPerAttempt(t *testing.T) {
+	a, b := SessionID("run-1", "001-m1-r1"), SessionID("run-1", "002-m1-r1")
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(a) {
+		t.Fatalf("not a v4 uuid: %s", a)
+	}
+	if a == b || a != SessionID("run-1", "001-m1-r1") {
+		t.Fatalf("session ids must be stable and unique per attempt: %s %s", a, b)
+	}
+	if got := AttemptArgs(a, "-x"); strings.Join(got, " ") != "--session-id "+a+" -- -x" {
+		t.Fatalf("unexpected attempt args: %v", got)
+	}
+}
@@ -0,0 +1,287 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+)
+
+// Env keys carrying a flow's container policy from the campaign into `zcl suite run`.
+const (
+	ImageEnvKey   = "ZCL_CONTAINER_IMAGE"
+	NetworkEnvKey = "ZCL_CONTAINER_NETWORK"
+	MountsEnvKey  = "ZCL_CONTAINER_MOUNTS"
+	EngineEnvKey  = "ZCL_CONTAINER_ENGINE"
+	LimitsEnvKey  = "ZCL_CONTAINER_LIMITS"
+
+	EngineDocker = "docker"
+	EnginePodman = "podman"
+
+	NetworkBridge = "bridge"
+	NetworkNone   = "none"
+	NetworkHost   = "host"
+
+	// DefaultPath is the PATH inside the container (host PATH entries mean nothing there).
+	DefaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+)
+
+// cgroupControllersPath exists only on a unified (v2) cgroup hierarchy.
+var cgroupControllersPath = "/sys/fs/cgroup/cgroup.controllers"
+
+// Spec is the per-flow container policy: every attempt runs in a fresh container of Image.
+type Spec struct {
+	Engine  string   `json:"engine"`
+	Image   string   `json:"image"`
+	Network string   `json:"network"`
+	Mounts  []string `json:"mounts,omitempty"`
+	Limits  Limits   `json:"limits"`
+}
+
+// Limits are cgroup limits applied by the engine to the attempt container; zero means unlimited.
+type Limits struct {
+	CPU      float64 `json:"cpu,omitempty"`
+	MemoryMB int64   `json:"memoryMb,omitempty"`
+	Pids     int64   `json:"pids,omitempty"`
+}
+
+func (l Limits) IsZero() bool { return l == Limits{} }
+
+// Mount is one bind mount in `host:container[:ro|rw]` form.
+type Mount struct {
+	Host      string
+	Container string
+	ReadOnly  bool
+}
+
+func (m Mount) String() string {
+	s := m.Host + ":" + m.Container
+	if m.ReadOnly {
+		s += ":ro"
+	}
+	return s
+}
+
+// ParseMount parses `host:container[:ro|rw]`; a missing container path mirrors the host path.
+// Relative host paths resolve against baseDir.
+func ParseMount(raw string, baseDir string) (Mount, error) {
+	parts := strings.Split(strings.TrimSpace(raw), ":")
+	if len(parts) == 0 || len(parts) > 3 || strings.TrimSpace(parts[0]) == "" {
+		return Mount{}, fmt.Errorf("invalid mount %q (expected host:container[:ro|rw])", raw)
+	}
+	m := Mount{Host: strings.TrimSpace(parts[0])}
+	if !filepath.IsAbs(m.Host) {
+		m.Host = filepath.Join(baseDir, m.Host)
+	}
+	m.Host = filepath.Clean(m.Host)
+	m.Container = m.Host
+	if len(parts) >= 2 && strings.TrimSpace(parts[1]) != "" {
+		m.Container = strings.TrimSpace(parts[1])
+	}
+	if !strings.HasPrefix(m.Container, "/") {
+		return Mount{}, fmt.Errorf("invalid mount %q (container path must be absolute)", raw)
+	}
+	if len(parts) == 3 {
+		switch strings.ToLower(strings.TrimSpace(parts[2])) {
+		case "ro":
+			m.ReadOnly = true
+		case "rw":
+		default:
+			return Mount{}, fmt.Errorf("invalid mount %q (mode must be ro|rw)", raw)
+		}
+	}
+	return m, nil
+}
+
+// Normalize fills defaults and canonicalizes mounts (relative host paths against baseDir).
+func Normalize(s Spec, baseDir string) (Spec, error) {
+	s.Engine = strings.ToLower(strings.TrimSpace(s.Engine))
+	if s.Engine == "" {
+		s.Engine = EngineDocker
+	}
+	if s.Engine != EngineDocker && s.Engine != EnginePodman {
+		return Spec{}, fmt.Errorf("invalid container engine %q (expected %s|%s)", s.Engine, EngineDocker, EnginePodman)
+	}
+	if s.Limits.CPU < 0 || s.Limits.MemoryMB < 0 || s.Limits.Pids < 0 {
+		return Spec{}, fmt.Errorf("container limits must be >= 0")
+	}
+	s.Image = strings.TrimSpace(s.Image)
+	if s.Image == "" {
+		return Spec{}, fmt.Errorf("missing container image")
+	}
+	s.Network = strings.TrimSpace(s.Network)
+	if s.Network == "" {
+		s.Network = NetworkBridge
+	}
+	if strings.ContainsAny(s.Network, " \t") {
+		return Spec{}, fmt.Errorf("invalid container network %q", s.Network)
+	}
+	mounts := make([]string, 0, len(s.Mounts))
+	for _, raw := range s.Mounts {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		m, err := ParseMount(raw, baseDir)
+		if err != nil {
+			return Spec{}, err
+		}
+		mounts = append(mounts, m.String())
+	}
+	s.Mounts = mounts
+	return s, nil
+}
+
+// Env encodes s for the attempt env handed to `zcl suite run`.
+func Env(s Spec) map[string]string {
+	env := map[string]string{
+		EngineEnvKey:  s.Engine,
+		ImageEnvKey:   s.Image,
+		NetworkEnvKey: s.Network,
+	}
+	if len(s.Mounts) > 0 {
+		raw, _ := json.Marshal(s.Mounts)
+		env[MountsEnvKey] = string(raw)
+	}
+	if !s.Limits.IsZero() {
+		raw, _ := json.Marshal(s.Limits)
+		env[LimitsEnvKey] = string(raw)
+	}
+	return env
+}
+
+// FromEnv decodes the container policy; ok is false when no image is set.
+func FromEnv(env map[string]string) (Spec, bool, error) {
+	image := strings.TrimSpace(env[ImageEnvKey])
+	if image == "" {
+		return Spec{}, false, nil
+	}
+	s := Spec{Engine: env[EngineEnvKey], Image: image, Network: env[NetworkEnvKey]}
+	if raw := strings.TrimSpace(env[MountsEnvKey]); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &s.Mounts); err != nil {
+			return Spec{}, false, fmt.Errorf("invalid %s: %w", MountsEnvKey, err)
+		}
+	}
+	if raw := strings.TrimSpace(env[LimitsEnvKey]); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &s.Limits); err != nil {
+			return Spec{}, false, fmt.Errorf("invalid %s: %w", LimitsEnvKey, err)
+		}
+	}
+	wd, _ := os.Getwd()
+	s, err := Normalize(s, wd)
+	if err != nil {
+		return Spec{}, false, err
+	}
+	return s, true, nil
+}
+
+// Rootless reports whether the engine runs without root (podman invoked by a non-root user).
+func Rootless(s Spec) bool {
+	return s.Engine == EnginePodman && os.Geteuid() > 0
+}
+
+// Preflight checks host support for s: cgroup limits need the unified (v2) hierarchy, which is
+// also the only one rootless podman can delegate limits on.
+func Preflight(s Spec) error {
+	if s.Limits.IsZero() {
+		return nil
+	}
+	if _, err := os.Stat(cgroupControllersPath); err != nil {
+		return fmt.Errorf("container limits require cgroup v2 (%s not found)", cgroupControllersPath)
+	}
+	return nil
+}
+
+// RunParams describes one attempt's container invocation.
+type RunParams struct {
+	AttemptID  string
+	AttemptDir string
+	TmpDir     string
+	// ZCLExe is mounted read-only at the same path so attempt shims can call back into zcl.
+	ZCLExe string
+	// ShimBinDir is prepended to the container PATH.
+	ShimBinDir string
+	// EnvKeys are forwarded by name (`-e KEY`); values come from the engine client's environment,
+	// so secrets never appear on the command line.
+	EnvKeys []string
+	Argv    []string
+}
+
+// Name is the container name for an attempt, so a timed-out attempt can be removed by name.
+func Name(attemptID string) string {
+	return "zcl-" + ids.SanitizeComponent(attemptID)
+}
+
+// RunArgv returns the engine argv (`docker run --rm ...`) for one attempt. The attempt and tmp dirs
+// are mounted at their host paths so ZCL_OUT_DIR/ZCL_TMP_DIR stay valid inside the container.
+func RunArgv(s Spec, p RunParams) []string {
+	argv := []string{s.Engine, "run", "--rm", "-i", "--name", Name(p.AttemptID), "--network", s.Network}
+	// Keep artifacts written into the mounted attempt dir owned by the host user. Rootless podman
+	// maps the host user into the user namespace instead.
+	if Rootless(s) {
+		argv = append(argv, "--userns=keep-id")
+	} else if uid, gid := os.Getuid(), os.Getgid(); uid >= 0 && gid >= 0 {
+		argv = append(argv, "--user", fmt.Sprintf("%d:%d", uid, gid))
+	}
+	argv = append(argv, limitArgs(s.Limits)...)
+	argv = append(argv, "-v", p.AttemptDir+":"+p.AttemptDir)
+	if p.TmpDir != "" && !within(p.TmpDir, p.AttemptDir) {
+		argv = append(argv, "-v", p.TmpDir+":"+p.TmpDir)
+	}
+	if p.ZCLExe != "" {
+		argv = append(argv, "-v", p.ZCLExe+":"+p.ZCLExe+":ro")
+	}
+	for _, m := range s.Mounts {
+		argv = append(argv, "-v", m)
+	}
+	argv = append(argv, "-w", p.AttemptDir)
+	keys := append([]string(nil), p.EnvKeys...)
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == "PATH" || k == "HOME" {
+			continue
+		}
+		argv = append(argv, "-e", k)
+	}
+	path := DefaultPath
+	if p.ShimBinDir != "" {
+		path = p.ShimBinDir + ":" + path
+	}
+	argv = append(argv, "-e", "PATH="+path, s.Image)
+	return append(argv, p.Argv...)
+}
+
+// InspectArgv prints the local image ID of s.Image (its content digest, also for unpushed images).
+func InspectArgv(s Spec) []string {
+	return []string{s.Engine, "image", "inspect", "--format", "{{.Id}}", s.Image}
+}
+
+// RemoveArgv force-removes an attempt's container (used after the client was killed on timeout).
+func RemoveArgv(s Spec, attemptID string) []string {
+	return []string{s.Engine, "rm", "-f", Name(attemptID)}
+}
+
+func limitArgs(l Limits) []string {
+	var out []string
+	if l.CPU > 0 {
+		out = append(out, "--cpus", strconv.FormatFloat(l.CPU, 'f', -1, 64))
+	}
+	if l.MemoryMB > 0 {
+		// Equal swap limit: the memory cap is not silently extended by swap.
+		mem := strconv.FormatInt(l.MemoryMB, 10) + "m"
+		out = append(out, "--memory", mem, "--memory-swap", mem)
+	}
+	if l.Pids > 0 {
+		out = append(out, "--pids-limit", strconv.FormatInt(l.Pids, 10))
+	}
+	return out
+}
+
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
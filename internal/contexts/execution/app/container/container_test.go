@@ -0,0 +1,120 @@
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseMount(t *testing.T) {
+	m, err := ParseMount("data:/data:ro", "/spec")
+	if err != nil {
+		t.Fatalf("ParseMount: %v", err)
+	}
+	if m.Host != "/spec/data" || m.Container != "/data" || !m.ReadOnly || m.String() != "/spec/data:/data:ro" {
+		t.Fatalf("unexpected mount: %+v", m)
+	}
+	if m, err := ParseMount("/cache", "/spec"); err != nil || m.Container != "/cache" || m.ReadOnly {
+		t.Fatalf("mirror mount: %+v err=%v", m, err)
+	}
+	for _, bad := range []string{"", "/a:rel", "/a:/b:rx", "/a:/b:ro:x"} {
+		if _, err := ParseMount(bad, "/spec"); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestEnvRoundTrip(t *testing.T) {
+	s, err := Normalize(Spec{Image: "alpine:3", Mounts: []string{"/opt/tools:/tools:ro"}}, "/spec")
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if s.Engine != EngineDocker || s.Network != NetworkBridge {
+		t.Fatalf("defaults not applied: %+v", s)
+	}
+	got, ok, err := FromEnv(Env(s))
+	if err != nil || !ok {
+		t.Fatalf("FromEnv: ok=%v err=%v", ok, err)
+	}
+	if got.Image != s.Image || got.Network != s.Network || len(got.Mounts) != 1 || got.Mounts[0] != "/opt/tools:/tools:ro" {
+		t.Fatalf("round trip mismatch: %+v", got)
+	}
+	if _, ok, err := FromEnv(map[string]string{}); ok || err != nil {
+		t.Fatalf("expected no policy without image: ok=%v err=%v", ok, err)
+	}
+	if _, err := Normalize(Spec{}, "/spec"); err == nil {
+		t.Fatalf("expected missing image error")
+	}
+}
+
+func TestRunArgv_MountsAttemptAndForwardsEnvByName(t *testing.T) {
+	s := Spec{Engine: EngineDocker, Image: "alpine:3", Network: NetworkNone, Mounts: []string{"/opt/tools:/tools:ro"}}
+	argv := RunArgv(s, RunParams{
+		AttemptID:  "001-m1-r1",
+		AttemptDir: "/out/runs/r/attempts/001-m1-r1",
+		TmpDir:     "/out/runs/r/attempts/001-m1-r1/tmp",
+		ZCLExe:     "/usr/local/bin/zcl",
+		ShimBinDir: "/out/runs/r/attempts/001-m1-r1/bin",
+		EnvKeys:    []string{"ZCL_OUT_DIR", "PATH", "OPENAI_API_KEY"},
+		Argv:       []string{"sh", "-c", "echo hi"},
+	})
+	joined := strings.Join(argv, " ")
+	for _, want := range []string{
+		"docker run --rm -i --name zcl-001-m1-r1 --network none",
+		"-v /out/runs/r/attempts/001-m1-r1:/out/runs/r/attempts/001-m1-r1",
+		"-v /usr/local/bin/zcl:/usr/local/bin/zcl:ro",
+		"-v /opt/tools:/tools:ro",
+		"-w /out/runs/r/attempts/001-m1-r1",
+		"-e OPENAI_API_KEY -e ZCL_OUT_DIR",
+		"-e PATH=/out/runs/r/attempts/001-m1-r1/bin:" + DefaultPath + " alpine:3 sh -c echo hi",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("argv missing %q:\n%s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "/tmp:/out") || strings.Count(joined, "-v ") != 3 {
+		t.Fatalf("tmp dir inside the attempt dir must not be mounted twice:\n%s", joined)
+	}
+	if rm := strings.Join(RemoveArgv(s, "001-m1-r1"), " "); rm != "docker rm -f zcl-001-m1-r1" {
+		t.Fatalf("unexpected remove argv: %s", rm)
+	}
+}
+
+func TestRunArgv_PodmanLimitsAndPreflight(t *testing.T) {
+	s, err := Normalize(Spec{Engine: " Podman ", Image: "agent", Limits: Limits{CPU: 1.5, MemoryMB: 512, Pids: 256}}, "/spec")
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	joined := strings.Join(RunArgv(s, RunParams{AttemptID: "a1", AttemptDir: "/out/a1", Argv: []string{"true"}}), " ")
+	if !strings.HasPrefix(joined, "podman run --rm") || !strings.Contains(joined, "--cpus 1.5 --memory 512m --memory-swap 512m --pids-limit 256") {
+		t.Fatalf("unexpected podman argv: %s", joined)
+	}
+	if Rootless(s) != (os.Geteuid() > 0) || strings.Contains(joined, "--userns=keep-id") != Rootless(s) {
+		t.Fatalf("rootless podman must map the host user via keep-id: %s", joined)
+	}
+	got, ok, err := FromEnv(Env(s))
+	if err != nil || !ok || got.Engine != EnginePodman || got.Limits != s.Limits {
+		t.Fatalf("round trip lost engine/limits: %+v err=%v", got, err)
+	}
+	if _, err := Normalize(Spec{Engine: "lxc", Image: "agent"}, "/spec"); err == nil {
+		t.Fatalf("expected invalid engine error")
+	}
+
+	orig := cgroupControllersPath
+	t.Cleanup(func() { cgroupControllersPath = orig })
+	cgroupControllersPath = filepath.Join(t.TempDir(), "missing")
+	if err := Preflight(s); err == nil || !strings.Contains(err.Error(), "cgroup v2") {
+		t.Fatalf("expected cgroup v2 preflight error, got %v", err)
+	}
+	if err := Preflight(Spec{Engine: EnginePodman, Image: "agent"}); err != nil {
+		t.Fatalf("no limits needs no cgroup v2: %v", err)
+	}
+	cgroupControllersPath = filepath.Join(t.TempDir(), "cgroup.controllers")
+	if err := os.WriteFile(cgroupControllersPath, []byte("cpu memory pids\n"), 0o644); err != nil {
+		t.Fatalf("write controllers: %v", err)
+	}
+	if err := Preflight(s); err != nil {
+		t.Fatalf("Preflight on cgroup v2: %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package fingerprint
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// ProbeTimeout bounds one environment probe (local sh or a round trip to an ssh runner host).
+const ProbeTimeout = 15 * time.Second
+
+// HostLocal is the Host of a fingerprint probed on the machine running zcl.
+const HostLocal = "local"
+
+// Script is the POSIX sh probe printing key=value lines for the host and, for each binary name, its
+// resolved path and sha256. The same script runs locally and over ssh.
+func Script(bins []string) string {
+	var b strings.Builder
+	b.WriteString(`echo "os=$(uname -s 2>/dev/null)"
+echo "kernel=$(uname -r 2>/dev/null)"
+echo "arch=$(uname -m 2>/dev/null)"
+if [ -r /etc/os-release ]; then (. /etc/os-release; echo "distro=${ID:-} ${VERSION_ID:-}"); elif command -v sw_vers >/dev/null 2>&1; then echo "distro=macos $(sw_vers -productVersion)"; fi
+m=$(grep -m1 'model name' /proc/cpuinfo 2>/dev/null | cut -d: -f2-); [ -n "$m" ] || m=$(sysctl -n machdep.cpu.brand_string 2>/dev/null); echo "cpu=$m"
+echo "cpus=$(getconf _NPROCESSORS_ONLN 2>/dev/null || nproc 2>/dev/null)"
+if [ -r /proc/meminfo ]; then echo "memkb=$(awk '/^MemTotal:/ {print $2}' /proc/meminfo)"; else echo "membytes=$(sysctl -n hw.memsize 2>/dev/null)"; fi
+`)
+	for _, bin := range bins {
+		b.WriteString("n=" + quote(bin) + "\n")
+		b.WriteString(`if p=$(command -v "$n" 2>/dev/null); then h=$( (sha256sum "$p" 2>/dev/null || shasum -a 256 "$p" 2>/dev/null) | cut -d' ' -f1); else p= h=; fi; echo "bin=$n	$p	$h"` + "\n")
+	}
+	return b.String()
+}
+
+// probed caches successful probes per argv: the environment does not change under one zcl process,
+// and campaigns run many suite runs against the same host and binaries.
+var probed sync.Map
+
+// Probe runs argv (the probe script wrapped for sh or ssh) and parses its output.
+func Probe(ctx context.Context, argv []string) (schema.EnvFingerprintJSONV1, error) {
+	key := strings.Join(argv, "\x00")
+	if fp, ok := probed.Load(key); ok {
+		return cloneFingerprint(fp.(schema.EnvFingerprintJSONV1)), nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	fp := Parse(string(out))
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return fp, fmt.Errorf("environment probe: %w", err)
+	}
+	probed.Store(key, cloneFingerprint(fp))
+	return fp, nil
+}
+
+func cloneFingerprint(fp schema.EnvFingerprintJSONV1) schema.EnvFingerprintJSONV1 {
+	fp.Binaries = append([]schema.EnvBinaryV1(nil), fp.Binaries...)
+	return fp
+}
+
+var kernelVersion = regexp.MustCompile(`^(\d+)\.(\d+)`)
+
+// Parse normalizes probe output: lowercase OS/distro, canonical arch names, kernel major.minor,
+// whitespace-collapsed CPU model and memory rounded to GiB.
+func Parse(raw string) schema.EnvFingerprintJSONV1 {
+	fp := schema.EnvFingerprintJSONV1{SchemaVersion: schema.EnvFingerprintSchemaV1}
+	sc := bufio.NewScanner(strings.NewReader(raw))
+	for sc.Scan() {
+		k, v, ok := strings.Cut(sc.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "os":
+			fp.OS = strings.ToLower(strings.TrimSpace(v))
+		case "arch":
+			fp.Arch = normalizeArch(v)
+		case "kernel":
+			if m := kernelVersion.FindStringSubmatch(strings.TrimSpace(v)); m != nil {
+				fp.Kernel = m[1] + "." + m[2]
+			}
+		case "distro":
+			fp.Distro = strings.ToLower(strings.Join(strings.Fields(v), " "))
+		case "cpu":
+			fp.CPUModel = strings.Join(strings.Fields(v), " ")
+		case "cpus":
+			fp.CPUs, _ = strconv.Atoi(strings.TrimSpace(v))
+		case "memkb":
+			if n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				fp.MemoryGiB = roundGiB(n << 10)
+			}
+		case "membytes":
+			if n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				fp.MemoryGiB = roundGiB(n)
+			}
+		case "bin":
+			parts := strings.SplitN(v, "\t", 3)
+			for len(parts) < 3 {
+				parts = append(parts, "")
+			}
+			fp.Binaries = append(fp.Binaries, schema.EnvBinaryV1{Name: parts[0], Path: parts[1], SHA256: strings.TrimSpace(parts[2])})
+		}
+	}
+	return fp
+}
+
+// Hash is the comparability hash of fp: everything but where it was measured (Host, binary paths)
+// and probe errors.
+func Hash(fp schema.EnvFingerprintJSONV1) string {
+	fp.Hash, fp.Host, fp.ProbeError = "", "", ""
+	bins := make([]schema.EnvBinaryV1, 0, len(fp.Binaries))
+	for _, b := range fp.Binaries {
+		bins = append(bins, schema.EnvBinaryV1{Name: b.Name, SHA256: b.SHA256})
+	}
+	fp.Binaries = bins
+	raw, err := store.CanonicalJSON(fp)
+	if err != nil {
+		raw = []byte(fmt.Sprintf("%s|%s|%s|%s|%d", fp.OS, fp.Arch, fp.Kernel, fp.CPUModel, fp.CPUs))
+	}
+	sum := sha256.Sum256(raw)
+	return "ef-" + hex.EncodeToString(sum[:8])
+}
+
+func normalizeArch(raw string) string {
+	switch a := strings.ToLower(strings.TrimSpace(raw)); a {
+	case "x86_64", "amd64":
+		return "amd64"
+	case "aarch64", "arm64":
+		return "arm64"
+	default:
+		return a
+	}
+}
+
+func roundGiB(bytes int64) int {
+	const gib = 1 << 30
+	return int((bytes + gib/2) / gib)
+}
+
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
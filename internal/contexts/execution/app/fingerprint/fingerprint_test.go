@@ -0,0 +1,40 @@
+package fingerprint
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParse_Normalizes(t *testing.T) {
+	fp := Parse("os=Linux\nkernel=6.8.0-45-generic\narch=x86_64\ndistro=ubuntu  24.04\ncpu=  AMD EPYC   7763\ncpus=16\nmemkb=65842000\nbin=gh\t/usr/bin/gh\tabc\nbin=missing\t\t\n")
+	if fp.OS != "linux" || fp.Arch != "amd64" || fp.Kernel != "6.8" || fp.Distro != "ubuntu 24.04" || fp.CPUModel != "AMD EPYC 7763" || fp.CPUs != 16 || fp.MemoryGiB != 63 {
+		t.Fatalf("unexpected fingerprint: %+v", fp)
+	}
+	if len(fp.Binaries) != 2 || fp.Binaries[0].SHA256 != "abc" || fp.Binaries[1].Path != "" {
+		t.Fatalf("unexpected binaries: %+v", fp.Binaries)
+	}
+}
+
+func TestHash_IgnoresWhereItWasMeasured(t *testing.T) {
+	a := Parse("os=Linux\narch=aarch64\nbin=gh\t/usr/bin/gh\tabc\n")
+	b := Parse("os=linux\narch=arm64\nbin=gh\t/opt/bin/gh\tabc\n")
+	b.Host, b.ProbeError = "build-1", "exit status 1"
+	if Hash(a) != Hash(b) || !strings.HasPrefix(Hash(a), "ef-") {
+		t.Fatalf("hash should ignore host and paths: %s vs %s", Hash(a), Hash(b))
+	}
+	b.Binaries[0].SHA256 = "def"
+	if Hash(a) == Hash(b) {
+		t.Fatalf("hash should change with binary content")
+	}
+}
+
+func TestProbe_LocalShell(t *testing.T) {
+	fp, err := Probe(context.Background(), []string{"sh", "-c", Script([]string{"sh", "zcl-no-such-binary"})})
+	if err != nil {
+		t.Fatalf("Probe: %v", err)
+	}
+	if fp.OS == "" || fp.Arch == "" || len(fp.Binaries) != 2 || fp.Binaries[0].SHA256 == "" || fp.Binaries[1].SHA256 != "" {
+		t.Fatalf("unexpected fingerprint: %+v", fp)
+	}
+}
@@ -0,0 +1,156 @@
+package home
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	ModeInherit   = "inherit"
+	ModeEphemeral = "ephemeral"
+
+	// DirName is the ephemeral home under the attempt's tmp dir (ZCL_TMP_DIR).
+	DirName = "home"
+)
+
+// dirEnv are the env keys pointed into the ephemeral home, with the home-relative dir each gets.
+// Besides the XDG base dirs this covers agent CLIs and credential-bearing tools that keep their
+// config outside XDG.
+var dirEnv = map[string]string{
+	"XDG_CONFIG_HOME":   ".config",
+	"XDG_DATA_HOME":     ".local/share",
+	"XDG_STATE_HOME":    ".local/state",
+	"XDG_CACHE_HOME":    ".cache",
+	"CODEX_HOME":        ".codex",
+	"CLAUDE_CONFIG_DIR": ".claude",
+	"GH_CONFIG_DIR":     ".config/gh",
+	"DOCKER_CONFIG":     ".docker",
+	"GNUPGHOME":         ".gnupg",
+}
+
+// fileEnv point tools at config files inside the ephemeral home (the files need not exist).
+var fileEnv = map[string]string{
+	"GIT_CONFIG_GLOBAL":           ".gitconfig",
+	"NPM_CONFIG_USERCONFIG":       ".npmrc",
+	"AWS_CONFIG_FILE":             ".aws/config",
+	"AWS_SHARED_CREDENTIALS_FILE": ".aws/credentials",
+}
+
+func ParseMode(raw string) (string, error) {
+	switch m := strings.ToLower(strings.TrimSpace(raw)); m {
+	case "", ModeInherit:
+		return ModeInherit, nil
+	case ModeEphemeral:
+		return ModeEphemeral, nil
+	default:
+		return "", fmt.Errorf("invalid home mode %q (expected inherit|ephemeral)", raw)
+	}
+}
+
+// CheckTemplate verifies a template is an existing directory.
+func CheckTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	st, err := os.Stat(template)
+	if err != nil {
+		return fmt.Errorf("home template: %w", err)
+	}
+	if !st.IsDir() {
+		return fmt.Errorf("home template %s is not a directory", template)
+	}
+	return nil
+}
+
+// Prepare creates an empty home at dir, copies template (if any) into it and returns the env that
+// points HOME, the XDG dirs and the known tool config dirs at it. Only directories and regular
+// files are copied: template symlinks are skipped so they cannot lead back into the real home.
+func Prepare(dir, template string) (map[string]string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	if template != "" {
+		if err := copyTree(template, dir); err != nil {
+			return nil, fmt.Errorf("copy home template: %w", err)
+		}
+	}
+	env := Env(dir)
+	for k, rel := range dirEnv {
+		if err := os.MkdirAll(env[k], 0o700); err != nil {
+			return nil, fmt.Errorf("create %s: %w", rel, err)
+		}
+	}
+	return env, nil
+}
+
+// Env is the environment that redirects a runner to the home at dir.
+func Env(dir string) map[string]string {
+	env := map[string]string{"HOME": dir}
+	for k, rel := range dirEnv {
+		env[k] = filepath.Join(dir, filepath.FromSlash(rel))
+	}
+	for k, rel := range fileEnv {
+		env[k] = filepath.Join(dir, filepath.FromSlash(rel))
+	}
+	return env
+}
+
+// EnvKeys lists the keys Env sets, sorted.
+func EnvKeys() []string {
+	keys := []string{"HOME"}
+	for k := range dirEnv {
+		keys = append(keys, k)
+	}
+	for k := range fileEnv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0o700)
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return copyFile(path, target, info.Mode().Perm())
+		default:
+			return nil
+		}
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,61 @@
+package home
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrepare_CopiesTemplateAndRedirectsConfigDirs(t *testing.T) {
+	template := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(template, ".codex"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(template, ".codex", "auth.json"), []byte(`{"k":"v"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(t.TempDir(), "id_rsa")
+	if err := os.WriteFile(secret, []byte("real key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(secret, filepath.Join(template, "id_rsa")); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), DirName)
+	env, err := Prepare(dir, template)
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	if env["HOME"] != dir || env["CODEX_HOME"] != filepath.Join(dir, ".codex") || env["XDG_CONFIG_HOME"] != filepath.Join(dir, ".config") {
+		t.Fatalf("unexpected env: %v", env)
+	}
+	if raw, err := os.ReadFile(filepath.Join(dir, ".codex", "auth.json")); err != nil || string(raw) != `{"k":"v"}` {
+		t.Fatalf("template file not copied: %q %v", raw, err)
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "id_rsa")); !os.IsNotExist(err) {
+		t.Fatalf("template symlink must not be copied: %v", err)
+	}
+	if st, err := os.Stat(env["XDG_CACHE_HOME"]); err != nil || !st.IsDir() {
+		t.Fatalf("XDG cache dir not created: %v", err)
+	}
+	if len(EnvKeys()) != len(env) {
+		t.Fatalf("EnvKeys out of sync with Env: %v", EnvKeys())
+	}
+}
+
+func TestParseModeAndCheckTemplate(t *testing.T) {
+	if m, err := ParseMode(""); err != nil || m != ModeInherit {
+		t.Fatalf("default mode: %q %v", m, err)
+	}
+	if _, err := ParseMode("shared"); err == nil {
+		t.Fatalf("expected invalid mode error")
+	}
+	f := filepath.Join(t.TempDir(), "f")
+	if err := os.WriteFile(f, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckTemplate(f); err == nil {
+		t.Fatalf("expected non-directory template error")
+	}
+}
@@ -0,0 +1,291 @@
+package httpagent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultZCL is the zcl binary whose `http agent` subcommand drives the service.
+	DefaultZCL = "zcl"
+	// DefaultPollIntervalMs is the pause between status polls of an accepted (202) mission.
+	DefaultPollIntervalMs = 1000
+
+	// maxErrorBody bounds how much of a failed response is quoted in the error.
+	maxErrorBody = 512
+)
+
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// Spec is the endpoint of a runner.type=http_agent flow. Header values may reference the attempt
+// env as ${VAR}; they are expanded by the agent process, so secrets stay out of the spec and argv.
+type Spec struct {
+	URL            string
+	Headers        map[string]string
+	PollIntervalMs int64
+}
+
+// Normalize fills defaults and validates s.
+func Normalize(s Spec) (Spec, error) {
+	s.URL = strings.TrimSpace(s.URL)
+	if s.URL == "" {
+		return Spec{}, fmt.Errorf("missing url")
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Spec{}, fmt.Errorf("invalid url %q (expected http(s)://...)", s.URL)
+	}
+	headers := map[string]string{}
+	for k, v := range s.Headers {
+		k = strings.TrimSpace(k)
+		if !headerNamePattern.MatchString(k) {
+			return Spec{}, fmt.Errorf("invalid header name %q", k)
+		}
+		if strings.ContainsAny(v, "\r\n") {
+			return Spec{}, fmt.Errorf("invalid header %s: value must be a single line", k)
+		}
+		headers[k] = strings.TrimSpace(v)
+	}
+	s.Headers = nil
+	if len(headers) > 0 {
+		s.Headers = headers
+	}
+	if s.PollIntervalMs < 0 {
+		return Spec{}, fmt.Errorf("pollIntervalMs must be >= 0")
+	}
+	if s.PollIntervalMs == 0 {
+		s.PollIntervalMs = DefaultPollIntervalMs
+	}
+	return s, nil
+}
+
+// Argv is the runner command of an http_agent flow: zcl's `http agent` subcommand, which prints
+// the service's result behind marker for the stdout_json result channel.
+func Argv(zcl string, s Spec, marker string) []string {
+	argv := []string{zcl, "http", "agent", "--url", s.URL}
+	names := make([]string, 0, len(s.Headers))
+	for k := range s.Headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		argv = append(argv, "--header", k+": "+s.Headers[k])
+	}
+	argv = append(argv, "--poll-interval-ms", strconv.FormatInt(s.PollIntervalMs, 10))
+	if marker != "" {
+		argv = append(argv, "--result-marker", marker)
+	}
+	return argv
+}
+
+// ParseHeader splits a "Name: value" flag.
+func ParseHeader(raw string) (string, string, error) {
+	name, value, ok := strings.Cut(raw, ":")
+	name = strings.TrimSpace(name)
+	if !ok || !headerNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid header %q (expected Name: value)", raw)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// MissionRequest is the JSON body POSTed to the service for one attempt.
+type MissionRequest struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	RunID         string `json:"runId"`
+	SuiteID       string `json:"suiteId"`
+	MissionID     string `json:"missionId"`
+	AttemptID     string `json:"attemptId"`
+	AgentID       string `json:"agentId,omitempty"`
+	Prompt        string `json:"prompt"`
+}
+
+// Client runs one mission against an agent service.
+type Client struct {
+	HTTP         *http.Client
+	Header       http.Header
+	PollInterval time.Duration
+	// Output receives the agent's streamed output (runner stdout).
+	Output io.Writer
+}
+
+// Run POSTs the mission and returns the service's mission result object. The service answers
+// with the result itself (200), with a text/event-stream of output events ended by a result
+// event, or with 202 Accepted and a status URL (Location header or statusUrl field) that is
+// polled until it stops answering 202.
+func (c Client) Run(ctx context.Context, endpoint string, req MissionRequest) (json.RawMessage, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case isEventStream(resp):
+		return c.readStream(resp.Body)
+	case resp.StatusCode == http.StatusAccepted:
+		status, err := statusURL(resp)
+		if err != nil {
+			return nil, err
+		}
+		return c.poll(ctx, status)
+	}
+	return readResult(resp)
+}
+
+func (c Client) do(ctx context.Context, method, target string, body []byte) (*http.Response, error) {
+	var rd io.Reader
+	if body != nil {
+		rd = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, rd)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range c.Header {
+		req.Header[k] = append([]string(nil), vs...)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, target, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%s %s: %s: %s", method, target, resp.Status, strings.TrimSpace(string(b)))
+	}
+	return resp, nil
+}
+
+func (c Client) poll(ctx context.Context, target string) (json.RawMessage, error) {
+	for {
+		t := time.NewTimer(c.PollInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+		resp, err := c.do(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusAccepted {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			continue
+		}
+		if isEventStream(resp) {
+			out, err := c.readStream(resp.Body)
+			_ = resp.Body.Close()
+			return out, err
+		}
+		out, err := readResult(resp)
+		_ = resp.Body.Close()
+		return out, err
+	}
+}
+
+// readStream copies output events to Output until the result (or error) event.
+func (c Client) readStream(r io.Reader) (json.RawMessage, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	event, data := "", []string{}
+	for sc.Scan() {
+		line := sc.Text()
+		if line != "" {
+			switch field, value, _ := strings.Cut(line, ":"); field {
+			case "event":
+				event = strings.TrimSpace(value)
+			case "data":
+				data = append(data, strings.TrimPrefix(value, " "))
+			}
+			continue
+		}
+		payload := strings.Join(data, "\n")
+		switch event {
+		case "result":
+			return resultObject([]byte(payload))
+		case "error":
+			return nil, fmt.Errorf("agent error event: %s", payload)
+		default:
+			if len(data) > 0 && c.Output != nil {
+				_, _ = io.WriteString(c.Output, payload+"\n")
+			}
+		}
+		event, data = "", data[:0]
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if event == "result" && len(data) > 0 {
+		return resultObject([]byte(strings.Join(data, "\n")))
+	}
+	return nil, fmt.Errorf("event stream ended without a result event")
+}
+
+func isEventStream(resp *http.Response) bool {
+	mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mt == "text/event-stream"
+}
+
+func statusURL(resp *http.Response) (string, error) {
+	loc := strings.TrimSpace(resp.Header.Get("Location"))
+	if loc == "" {
+		var body struct {
+			StatusURL string `json:"statusUrl"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body)
+		loc = strings.TrimSpace(body.StatusURL)
+	}
+	if loc == "" {
+		return "", fmt.Errorf("202 Accepted without a Location header or statusUrl")
+	}
+	u, err := resp.Request.URL.Parse(loc)
+	if err != nil {
+		return "", fmt.Errorf("invalid status url %q: %w", loc, err)
+	}
+	return u.String(), nil
+}
+
+func readResult(resp *http.Response) (json.RawMessage, error) {
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, err
+	}
+	return resultObject(b)
+}
+
+func resultObject(b []byte) (json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, fmt.Errorf("agent result is not a JSON object: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
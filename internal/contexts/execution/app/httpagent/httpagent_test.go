@@ -0,0 +1,97 @@
+package httpagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNormalizeAndArgv(t *testing.T) {
+	s, err := Normalize(Spec{URL: " https://agent.example/run ", Headers: map[string]string{"X-Team": " qa ", "Authorization": "Bearer ${TOKEN}"}})
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	want := "zcl http agent --url https://agent.example/run --header Authorization: Bearer ${TOKEN} --header X-Team: qa --poll-interval-ms 1000 --result-marker M:"
+	if got := strings.Join(Argv(DefaultZCL, s, "M:"), " "); got != want {
+		t.Fatalf("unexpected argv:\n got %s\nwant %s", got, want)
+	}
+	for _, tc := range []struct {
+		spec Spec
+		want string
+	}{
+		{Spec{}, "missing url"},
+		{Spec{URL: "ftp://agent"}, "invalid url"},
+		{Spec{URL: "http://agent", Headers: map[string]string{"Bad Name": "x"}}, "invalid header name"},
+		{Spec{URL: "http://agent", PollIntervalMs: -1}, "pollIntervalMs"},
+	} {
+		if _, err := Normalize(tc.spec); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestClientRunSyncStreamAndPoll(t *testing.T) {
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/sync":
+			var req MissionRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			fmt.Fprintf(w, `{"ok": true, "result": %q}`, req.MissionID+":"+req.Prompt)
+		case "/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: thinking\n\nevent: output\ndata: line 1\ndata: line 2\n\nevent: result\ndata: {\"ok\":true,\"result\":\"streamed\"}\n\n")
+		case "/accept":
+			w.Header().Set("Location", "/status/1")
+			w.WriteHeader(http.StatusAccepted)
+		case "/status/1":
+			if polls.Add(1) < 3 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			fmt.Fprint(w, `{"ok":false,"result":"polled"}`)
+		case "/broken":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: error\ndata: model overloaded\n\n")
+		}
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	c := Client{Header: http.Header{"Authorization": {"Bearer t0k"}}, PollInterval: time.Millisecond, Output: &out}
+	req := MissionRequest{SchemaVersion: 1, MissionID: "m1", Prompt: "p1"}
+	for path, want := range map[string]string{
+		"/sync":   `{"ok":true,"result":"m1:p1"}`,
+		"/stream": `{"ok":true,"result":"streamed"}`,
+		"/accept": `{"ok":false,"result":"polled"}`,
+	} {
+		got, err := c.Run(context.Background(), srv.URL+path, req)
+		if err != nil || string(got) != want {
+			t.Fatalf("%s: got %s, %v; want %s", path, got, err, want)
+		}
+	}
+	if out.String() != "thinking\nline 1\nline 2\n" {
+		t.Fatalf("unexpected streamed output %q", out.String())
+	}
+	if polls.Load() != 3 {
+		t.Fatalf("expected 3 status polls, got %d", polls.Load())
+	}
+	if _, err := c.Run(context.Background(), srv.URL+"/broken", req); err == nil || !strings.Contains(err.Error(), "model overloaded") {
+		t.Fatalf("expected agent error event, got %v", err)
+	}
+	c.Header = nil
+	if _, err := c.Run(context.Background(), srv.URL+"/sync", req); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected 401 error, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package limits
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// DiskPollInterval is how often a disk quota is sampled while the runner runs.
+const DiskPollInterval = time.Second
+
+// DirBytes sums the sizes of regular files under root without following symlinks. Entries that
+// vanish or cannot be read mid-walk are skipped; a missing root is 0.
+func DirBytes(root string) int64 {
+	if root == "" {
+		return 0
+	}
+	var n int64
+	_ = filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			n += info.Size()
+		}
+		return nil
+	})
+	return n
+}
+
+// DiskWatch tracks the bytes an attempt keeps on disk (attempt dir + workspace). Under a quota it
+// polls while the runner runs and calls onExceed once when the total goes over it.
+type DiskWatch struct {
+	attemptDir string
+	workspace  string
+	quota      int64
+	onExceed   func()
+
+	mu       sync.Mutex
+	peak     int64
+	exceeded bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// WatchDisk starts watching; quotaBytes <= 0 measures only at Stop.
+func WatchDisk(attemptDir, workspace string, quotaBytes int64, interval time.Duration, onExceed func()) *DiskWatch {
+	w := &DiskWatch{attemptDir: attemptDir, workspace: workspace, quota: quotaBytes, onExceed: onExceed}
+	if quotaBytes <= 0 {
+		return w
+	}
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-t.C:
+				if w.sample() {
+					return
+				}
+			}
+		}
+	}()
+	return w
+}
+
+// sample records one measurement and reports whether the quota was just exceeded.
+func (w *DiskWatch) sample() bool {
+	total := DirBytes(w.attemptDir) + DirBytes(w.workspace)
+	w.mu.Lock()
+	if total > w.peak {
+		w.peak = total
+	}
+	over := w.quota > 0 && total > w.quota && !w.exceeded
+	if over {
+		w.exceeded = true
+	}
+	w.mu.Unlock()
+	if over && w.onExceed != nil {
+		w.onExceed()
+	}
+	return over
+}
+
+// Stop ends polling and returns the final usage (measured now) with the peak seen.
+func (w *DiskWatch) Stop() schema.DiskUsageJSONV1 {
+	if w.stop != nil {
+		close(w.stop)
+		<-w.done
+	}
+	dirBytes, wsBytes := DirBytes(w.attemptDir), DirBytes(w.workspace)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	total := dirBytes + wsBytes
+	if total > w.peak {
+		w.peak = total
+	}
+	return schema.DiskUsageJSONV1{
+		SchemaVersion:   schema.DiskUsageSchemaV1,
+		AttemptDirBytes: dirBytes,
+		WorkspaceBytes:  wsBytes,
+		TotalBytes:      total,
+		PeakBytes:       w.peak,
+		QuotaBytes:      max(w.quota, 0),
+		QuotaExceeded:   w.exceeded,
+	}
+}
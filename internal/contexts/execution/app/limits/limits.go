@@ -0,0 +1,90 @@
+package limits
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+)
+
+// EnvKey carries a flow's runner.limits (JSON) from the campaign into `zcl suite run`. Container
+// runners get the same limits through the container policy instead.
+const EnvKey = "ZCL_RUNNER_LIMITS"
+
+// oomExitCode is what a shell or engine client reports for a child killed with SIGKILL (128+9).
+const oomExitCode = 137
+
+// Env encodes l for the attempt env handed to `zcl suite run`; zero limits export nothing.
+func Env(l container.Limits) map[string]string {
+	if l.IsZero() {
+		return nil
+	}
+	raw, _ := json.Marshal(l)
+	return map[string]string{EnvKey: string(raw)}
+}
+
+// FromEnv decodes the runner limits; ok is false when none are set.
+func FromEnv(env map[string]string) (container.Limits, bool, error) {
+	raw := env[EnvKey]
+	if raw == "" {
+		return container.Limits{}, false, nil
+	}
+	var l container.Limits
+	if err := json.Unmarshal([]byte(raw), &l); err != nil {
+		return container.Limits{}, false, fmt.Errorf("invalid %s: %w", EnvKey, err)
+	}
+	if l.CPU < 0 || l.MemoryMB < 0 || l.Pids < 0 {
+		return container.Limits{}, false, fmt.Errorf("invalid %s: limits must be >= 0", EnvKey)
+	}
+	return l, !l.IsZero(), nil
+}
+
+// Preflight checks the host can apply l to a process runner: a transient systemd scope on the
+// unified (v2) cgroup hierarchy.
+func Preflight(l container.Limits) error {
+	if l.IsZero() {
+		return nil
+	}
+	if err := container.Preflight(container.Spec{Limits: l}); err != nil {
+		return err
+	}
+	if _, err := exec.LookPath("systemd-run"); err != nil {
+		return fmt.Errorf("runner limits require systemd-run on PATH (process runners run in a transient cgroup scope)")
+	}
+	return nil
+}
+
+// ScopeArgv runs argv in a transient systemd scope carrying l as cgroup properties. Swap is capped
+// at zero so the memory limit is not silently extended. Non-root callers use their user manager.
+func ScopeArgv(l container.Limits, attemptID string, argv []string) []string {
+	out := []string{"systemd-run"}
+	if os.Geteuid() != 0 {
+		out = append(out, "--user")
+	}
+	out = append(out, "--scope", "--quiet", "--collect", "--unit", "zcl-"+ids.SanitizeComponent(attemptID))
+	if l.CPU > 0 {
+		out = append(out, "-p", "CPUQuota="+strconv.FormatFloat(l.CPU*100, 'f', -1, 64)+"%")
+	}
+	if l.MemoryMB > 0 {
+		out = append(out, "-p", "MemoryMax="+strconv.FormatInt(l.MemoryMB, 10)+"M", "-p", "MemorySwapMax=0")
+	}
+	if l.Pids > 0 {
+		out = append(out, "-p", "TasksMax="+strconv.FormatInt(l.Pids, 10))
+	}
+	out = append(out, "--")
+	return append(out, argv...)
+}
+
+// KilledByLimit reports whether a runner exit looks like the memory limit's OOM kill: SIGKILL
+// seen directly (-1) or through a shell/engine client (137). CPU and pids limits throttle or fail
+// forks rather than kill, so only a memory limit can explain it.
+func KilledByLimit(l container.Limits, exitCode *int) bool {
+	if l.MemoryMB <= 0 || exitCode == nil {
+		return false
+	}
+	return *exitCode == -1 || *exitCode == oomExitCode
+}
@@ -0,0 +1,72 @@
+package limits
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+)
+
+func TestEnvRoundTripAndScopeArgv(t *testing.T) {
+	l := container.Limits{CPU: 2, MemoryMB: 2048}
+	got, ok, err := FromEnv(Env(l))
+	if err != nil || !ok || got != l {
+		t.Fatalf("round trip: %+v ok=%v err=%v", got, ok, err)
+	}
+	if _, ok, err := FromEnv(nil); ok || err != nil {
+		t.Fatalf("expected no limits: ok=%v err=%v", ok, err)
+	}
+	if _, _, err := FromEnv(map[string]string{EnvKey: `{"memoryMb":-1}`}); err == nil {
+		t.Fatalf("expected negative limit error")
+	}
+	joined := strings.Join(ScopeArgv(l, "001-m1-r1", []string{"sh", "-c", "run"}), " ")
+	for _, want := range []string{"--scope --quiet --collect --unit zcl-001-m1-r1", "-p CPUQuota=200%", "-p MemoryMax=2048M -p MemorySwapMax=0", "-- sh -c run"} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("scope argv missing %q: %s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "TasksMax") {
+		t.Fatalf("unset pids limit must not be applied: %s", joined)
+	}
+}
+
+func TestKilledByLimit(t *testing.T) {
+	exit := func(n int) *int { return &n }
+	mem := container.Limits{MemoryMB: 512}
+	if !KilledByLimit(mem, exit(137)) || !KilledByLimit(mem, exit(-1)) {
+		t.Fatalf("SIGKILL under a memory limit must be classified as a limit kill")
+	}
+	if KilledByLimit(mem, exit(1)) || KilledByLimit(mem, nil) || KilledByLimit(container.Limits{CPU: 1}, exit(137)) {
+		t.Fatalf("unexpected limit kill classification")
+	}
+}
+
+func TestDiskWatch_QuotaExceededAndUsage(t *testing.T) {
+	attemptDir, workspace := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(attemptDir, "a.log"), make([]byte, 100), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	hit := make(chan struct{})
+	w := WatchDisk(attemptDir, workspace, 1000, 10*time.Millisecond, func() { close(hit) })
+	if err := os.WriteFile(filepath.Join(workspace, "big.bin"), make([]byte, 2000), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-hit:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("quota was not reported as exceeded")
+	}
+	_ = os.Remove(filepath.Join(workspace, "big.bin"))
+	u := w.Stop()
+	if !u.QuotaExceeded || u.QuotaBytes != 1000 || u.PeakBytes < 2100 || u.TotalBytes != 100 || u.AttemptDirBytes != 100 || u.WorkspaceBytes != 0 {
+		t.Fatalf("unexpected usage: %+v", u)
+	}
+
+	u = WatchDisk(attemptDir, "", 0, DiskPollInterval, nil).Stop()
+	if u.QuotaExceeded || u.QuotaBytes != 0 || u.TotalBytes != 100 || u.PeakBytes != 100 {
+		t.Fatalf("unexpected unquoted usage: %+v", u)
+	}
+}
@@ -0,0 +1,54 @@
+package planner
+
+import (
+	"sort"
+	"time"
+)
+
+// MissionEstimate is a mission's predicted wall-clock duration from past attempts.
+type MissionEstimate struct {
+	Predicted time.Duration
+	Samples   int
+}
+
+// LongestFirst orders mission slots (indexes into missionIDs) for longest-processing-time-first
+// dispatch: with workers that take the next mission as soon as they free up, starting the longest
+// missions first keeps one long straggler from extending the run's tail. Missions without history
+// go first in suite order (they may be the longest); ties keep suite order.
+func LongestFirst(missionIDs []string, estimates map[string]MissionEstimate) []int {
+	order := make([]int, len(missionIDs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ea, eb := estimates[missionIDs[order[a]]], estimates[missionIDs[order[b]]]
+		if (ea.Samples == 0) != (eb.Samples == 0) {
+			return ea.Samples == 0
+		}
+		return ea.Predicted > eb.Predicted
+	})
+	return order
+}
+
+// PredictMakespan simulates greedy dispatch of durations in order onto workers and returns when
+// the last one finishes.
+func PredictMakespan(order []int, durations []time.Duration, workers int) time.Duration {
+	if workers <= 0 {
+		workers = 1
+	}
+	free := make([]time.Duration, workers)
+	var makespan time.Duration
+	for _, idx := range order {
+		w := 0
+		for i := 1; i < len(free); i++ {
+			if free[i] < free[w] {
+				w = i
+			}
+		}
+		free[w] += durations[idx]
+		if free[w] > makespan {
+			makespan = free[w]
+		}
+	}
+	return makespan
+}
@@ -16,6 +16,9 @@ type SuitePlanOpts struct {
 	TimeoutStart string
 	Blind        *bool
 	BlindTerms   []string
+	// BlindTermsPack overrides the suite's defaults.blindTermsPack; BlindTerms are added to it.
+	BlindTermsPack string
+	ZCLVersion     string
 }
 
 type PlannedMission struct {
@@ -60,9 +63,9 @@ func PlanSuite(now time.Time, opts SuitePlanOpts) (SuitePlanResult, error) {
 	if opts.Blind != nil {
 		blind = *opts.Blind
 	}
-	blindTerms := append([]string(nil), parsed.Suite.Defaults.BlindTerms...)
-	if len(opts.BlindTerms) > 0 {
-		blindTerms = append([]string(nil), opts.BlindTerms...)
+	blindTerms, err := parsed.Suite.ResolveBlindTerms(opts.BlindTermsPack, opts.BlindTerms)
+	if err != nil {
+		return SuitePlanResult{}, err
 	}
 
 	rid := opts.RunID
@@ -80,7 +83,9 @@ func PlanSuite(now time.Time, opts SuitePlanOpts) (SuitePlanResult, error) {
 			TimeoutStart:  timeoutStart,
 			Blind:         blind,
 			BlindTerms:    blindTerms,
+			TraceSampling: parsed.Suite.Defaults.AttemptTraceSampling(),
 			SuiteSnapshot: parsed.CanonicalJSON,
+			ZCLVersion:    opts.ZCLVersion,
 		})
 		if err != nil {
 			return SuitePlanResult{}, err
@@ -0,0 +1,219 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+)
+
+// Env keys carrying a runner.type=ssh flow's remote policy from the campaign into `zcl suite run`.
+const (
+	HostEnvKey     = "ZCL_SSH_HOST"
+	PortEnvKey     = "ZCL_SSH_PORT"
+	IdentityEnvKey = "ZCL_SSH_IDENTITY"
+	WorkDirEnvKey  = "ZCL_SSH_WORKDIR"
+	ZCLEnvKey      = "ZCL_SSH_ZCL"
+	SyncEnvKey     = "ZCL_SSH_SYNC"
+
+	// DefaultWorkDir is the remote root for attempt dirs (<workDir>/<runId>/<attemptId>).
+	DefaultWorkDir = "/tmp/zcl-remote"
+	// DefaultZCL is the zcl binary the remote runner and shims call back into.
+	DefaultZCL = "zcl"
+
+	// envFileName carries the attempt env to the remote side. It is sourced and deleted before the
+	// runner starts, so values never appear on a command line and are never synced back.
+	envFileName = ".zcl-ssh.env"
+)
+
+// Spec is the per-flow remote policy: every attempt runs the runner command on Host over ssh.
+type Spec struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port,omitempty"`
+	Identity string `json:"identity,omitempty"`
+	WorkDir  string `json:"workDir"`
+	ZCLPath  string `json:"zclPath"`
+	// Sync are extra attempt-relative globs pulled back after the runner exits (evidence artifacts
+	// are always pulled); empty pulls the whole remote attempt dir.
+	Sync []string `json:"sync,omitempty"`
+}
+
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Normalize fills defaults and validates s; a relative identity file resolves against baseDir.
+func Normalize(s Spec, baseDir string) (Spec, error) {
+	s.Host = strings.TrimSpace(s.Host)
+	if s.Host == "" {
+		return Spec{}, fmt.Errorf("missing ssh host")
+	}
+	if strings.HasPrefix(s.Host, "-") || strings.ContainsAny(s.Host, " \t\n'\"") {
+		return Spec{}, fmt.Errorf("invalid ssh host %q", s.Host)
+	}
+	if s.Port < 0 || s.Port > 65535 {
+		return Spec{}, fmt.Errorf("invalid ssh port %d", s.Port)
+	}
+	if s.Identity = strings.TrimSpace(s.Identity); s.Identity != "" && !filepath.IsAbs(s.Identity) {
+		s.Identity = filepath.Join(baseDir, s.Identity)
+	}
+	s.WorkDir = strings.TrimSpace(s.WorkDir)
+	if s.WorkDir == "" {
+		s.WorkDir = DefaultWorkDir
+	}
+	if !path.IsAbs(s.WorkDir) || path.Clean(s.WorkDir) == "/" {
+		return Spec{}, fmt.Errorf("invalid ssh workDir %q (must be an absolute path below /)", s.WorkDir)
+	}
+	s.WorkDir = path.Clean(s.WorkDir)
+	if s.ZCLPath = strings.TrimSpace(s.ZCLPath); s.ZCLPath == "" {
+		s.ZCLPath = DefaultZCL
+	}
+	sync := make([]string, 0, len(s.Sync))
+	for _, p := range s.Sync {
+		p = strings.Trim(strings.TrimSpace(p), "/")
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil || strings.HasPrefix(p, "..") {
+			return Spec{}, fmt.Errorf("invalid ssh sync pattern %q", p)
+		}
+		sync = append(sync, p)
+	}
+	s.Sync = sync
+	return s, nil
+}
+
+// Env encodes s for the attempt env handed to `zcl suite run`.
+func Env(s Spec) map[string]string {
+	env := map[string]string{
+		HostEnvKey:    s.Host,
+		WorkDirEnvKey: s.WorkDir,
+		ZCLEnvKey:     s.ZCLPath,
+	}
+	if s.Port > 0 {
+		env[PortEnvKey] = strconv.Itoa(s.Port)
+	}
+	if s.Identity != "" {
+		env[IdentityEnvKey] = s.Identity
+	}
+	if len(s.Sync) > 0 {
+		raw, _ := json.Marshal(s.Sync)
+		env[SyncEnvKey] = string(raw)
+	}
+	return env
+}
+
+// FromEnv decodes the remote policy; ok is false when no host is set.
+func FromEnv(env map[string]string) (Spec, bool, error) {
+	host := strings.TrimSpace(env[HostEnvKey])
+	if host == "" {
+		return Spec{}, false, nil
+	}
+	s := Spec{Host: host, Identity: env[IdentityEnvKey], WorkDir: env[WorkDirEnvKey], ZCLPath: env[ZCLEnvKey]}
+	if raw := strings.TrimSpace(env[PortEnvKey]); raw != "" {
+		port, err := strconv.Atoi(raw)
+		if err != nil {
+			return Spec{}, false, fmt.Errorf("invalid %s: %w", PortEnvKey, err)
+		}
+		s.Port = port
+	}
+	if raw := strings.TrimSpace(env[SyncEnvKey]); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &s.Sync); err != nil {
+			return Spec{}, false, fmt.Errorf("invalid %s: %w", SyncEnvKey, err)
+		}
+	}
+	wd, _ := os.Getwd()
+	s, err := Normalize(s, wd)
+	if err != nil {
+		return Spec{}, false, err
+	}
+	return s, true, nil
+}
+
+// Dirs are an attempt's remote attempt and tmp dirs.
+type Dirs struct {
+	Attempt string
+	Tmp     string
+}
+
+func AttemptDirs(s Spec, runID, attemptID string) Dirs {
+	run, attempt := ids.SanitizeComponent(runID), ids.SanitizeComponent(attemptID)
+	return Dirs{
+		Attempt: path.Join(s.WorkDir, run, attempt),
+		Tmp:     path.Join(s.WorkDir, "tmp", run, attempt),
+	}
+}
+
+// SSHArgv is the ssh client invocation running script with the remote POSIX sh (the login shell
+// only sees `sh -c '<script>'`). BatchMode keeps a missing key from hanging on a password prompt.
+func SSHArgv(s Spec, script string) []string {
+	argv := []string{"ssh", "-T", "-o", "BatchMode=yes"}
+	if s.Port > 0 {
+		argv = append(argv, "-p", strconv.Itoa(s.Port))
+	}
+	if s.Identity != "" {
+		argv = append(argv, "-i", s.Identity)
+	}
+	return append(argv, s.Host, "sh", "-c", ShellQuote(script))
+}
+
+// RemoteEnv rewrites the attempt env for the remote side: local attempt/tmp dir prefixes become
+// the remote ones and shims call the remote zcl. PATH and HOME stay the remote login's own.
+func RemoteEnv(s Spec, env map[string]string, localAttempt, localTmp string, dirs Dirs) map[string]string {
+	out := make(map[string]string, len(env))
+	for k, v := range env {
+		if k == "PATH" || k == "HOME" || !envKeyPattern.MatchString(k) {
+			continue
+		}
+		out[k] = rewritePrefix(rewritePrefix(v, localAttempt, dirs.Attempt), localTmp, dirs.Tmp)
+	}
+	out["ZCL_SHIM_ZCL_PATH"] = s.ZCLPath
+	return out
+}
+
+func rewritePrefix(v, local, remote string) string {
+	if local == "" {
+		return v
+	}
+	if v == local {
+		return remote
+	}
+	if strings.HasPrefix(v, local+string(filepath.Separator)) {
+		return remote + "/" + filepath.ToSlash(strings.TrimPrefix(v, local+string(filepath.Separator)))
+	}
+	return v
+}
+
+// EnvFile renders env as a sourceable file (`KEY='value'` lines, sorted).
+func EnvFile(env map[string]string) []byte {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k + "=" + ShellQuote(env[k]) + "\n")
+	}
+	return []byte(b.String())
+}
+
+// RunScript is the remote script for one attempt: load and delete the env file, put attempt shims
+// first on PATH and exec argv inside the remote attempt dir.
+func RunScript(dirs Dirs, argv []string) string {
+	quoted := make([]string, 0, len(argv))
+	for _, a := range argv {
+		quoted = append(quoted, ShellQuote(a))
+	}
+	return "cd " + ShellQuote(dirs.Attempt) + " && set -a && . ./" + envFileName + " && set +a && rm -f ./" + envFileName +
+		` && if [ -n "${ZCL_SHIM_BIN_DIR:-}" ]; then PATH="$ZCL_SHIM_BIN_DIR:$PATH"; export PATH; fi && exec ` + strings.Join(quoted, " ")
+}
+
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,143 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSSH puts an `ssh` on PATH that runs the remote command locally, the way sshd hands it to the
+// login shell.
+func fakeSSH(t *testing.T) {
+	t.Helper()
+	bin := t.TempDir()
+	script := `#!/bin/sh
+while [ $# -gt 0 ]; do
+  case "$1" in
+    -T) shift ;;
+    -o|-p|-i) shift 2 ;;
+    *) break ;;
+  esac
+done
+shift
+exec sh -c "$*"
+`
+	if err := os.WriteFile(filepath.Join(bin, "ssh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestNormalize(t *testing.T) {
+	s, err := Normalize(Spec{Host: " u@h ", Identity: "id", Sync: []string{"/out/", " "}}, "/spec")
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if s.Host != "u@h" || s.Identity != "/spec/id" || s.WorkDir != DefaultWorkDir || s.ZCLPath != DefaultZCL || len(s.Sync) != 1 || s.Sync[0] != "out" {
+		t.Fatalf("unexpected spec: %+v", s)
+	}
+	for _, bad := range []Spec{{}, {Host: "-oProxyCommand=x"}, {Host: "a b"}, {Host: "h", Port: 70000}, {Host: "h", WorkDir: "/"}, {Host: "h", Sync: []string{"[x"}}} {
+		if _, err := Normalize(bad, "/spec"); err == nil {
+			t.Fatalf("expected error for %+v", bad)
+		}
+	}
+	back, ok, err := FromEnv(Env(s))
+	if err != nil || !ok || back.Host != s.Host || back.Identity != s.Identity || len(back.Sync) != 1 {
+		t.Fatalf("env round trip: %+v ok=%v err=%v", back, ok, err)
+	}
+}
+
+func TestPushRunPull_RoundTripsAttemptDir(t *testing.T) {
+	fakeSSH(t)
+	ctx := context.Background()
+	local := t.TempDir()
+	if err := os.WriteFile(filepath.Join(local, "prompt.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := Spec{Host: "h", WorkDir: filepath.Join(t.TempDir(), "remote"), ZCLPath: DefaultZCL, Sync: []string{"out"}}
+	dirs := AttemptDirs(s, "run-1", "001-m1-r1")
+	env := RemoteEnv(s, map[string]string{"ZCL_OUT_DIR": local, "ZCL_PROMPT_PATH": filepath.Join(local, "prompt.txt"), "PATH": "/nope", "SECRET": "it's"}, local, "", dirs)
+	if env["ZCL_OUT_DIR"] != dirs.Attempt || env["ZCL_PROMPT_PATH"] != dirs.Attempt+"/prompt.txt" || env["PATH"] != "" {
+		t.Fatalf("unexpected remote env: %v", env)
+	}
+	if err := Push(ctx, s, local, dirs, env); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	script := RunScript(dirs, []string{"sh", "-c", `test ! -e .zcl-ssh.env && test "$SECRET" = "it's" && mkdir -p out && cat "$ZCL_PROMPT_PATH" > out/echo.txt && echo x > feedback.json && echo y > scratch.txt`})
+	if err := runSSH(ctx, s, script, nil, nil); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	pulled, err := Pull(ctx, s, dirs, local, SyncMatcher([]string{"feedback.json"}, s.Sync), nil)
+	if err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if got := strings.Join(pulled, ","); got != "feedback.json,out/echo.txt" && got != "out/echo.txt,feedback.json" {
+		t.Fatalf("unexpected pulled files: %v", pulled)
+	}
+	if raw, err := os.ReadFile(filepath.Join(local, "out", "echo.txt")); err != nil || string(raw) != "hi" {
+		t.Fatalf("synced file: %q %v", raw, err)
+	}
+	if _, err := os.Stat(filepath.Join(local, "scratch.txt")); !os.IsNotExist(err) {
+		t.Fatalf("unsynced file must stay remote: %v", err)
+	}
+
+	if err := Cleanup(ctx, s, dirs); err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+	if _, err := os.Stat(dirs.Attempt); !os.IsNotExist(err) {
+		t.Fatalf("remote attempt dir not removed: %v", err)
+	}
+}
+
+func TestPull_NeverWritesHarnessInputsAndReplacesByRename(t *testing.T) {
+	fakeSSH(t)
+	ctx := context.Background()
+	local := t.TempDir()
+	for name, body := range map[string]string{"prompt.txt": "hi", "attempt.json": "{}", "tool.calls.jsonl": "{\"a\":1}\n", "out.txt": "old"} {
+		if err := os.WriteFile(filepath.Join(local, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// out.txt shares its inode with a CAS blob; pulling over it must not rewrite the blob.
+	blob := filepath.Join(t.TempDir(), "blob")
+	if err := os.Link(filepath.Join(local, "out.txt"), blob); err != nil {
+		t.Skipf("hardlinks unsupported: %v", err)
+	}
+	s := Spec{Host: "h", WorkDir: filepath.Join(t.TempDir(), "remote"), ZCLPath: DefaultZCL}
+	dirs := AttemptDirs(s, "run-1", "001-m1-r1")
+	if err := Push(ctx, s, local, dirs, nil); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	script := RunScript(dirs, []string{"sh", "-c", `echo evil > prompt.txt && echo evil > attempt.json && echo '{"b":2}' >> tool.calls.jsonl && echo new > out.txt`})
+	if err := runSSH(ctx, s, script, nil, nil); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	var merged string
+	_, err := Pull(ctx, s, dirs, local, SyncMatcher(nil, nil), map[string]func(io.Reader) error{
+		"tool.calls.jsonl": func(r io.Reader) error {
+			b, err := io.ReadAll(r)
+			merged = string(b)
+			return err
+		},
+	})
+	if err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	for name, want := range map[string]string{"prompt.txt": "hi", "attempt.json": "{}", "tool.calls.jsonl": "{\"a\":1}\n", "out.txt": "new\n"} {
+		if raw, err := os.ReadFile(filepath.Join(local, name)); err != nil || string(raw) != want {
+			t.Fatalf("%s: got %q %v, want %q", name, raw, err, want)
+		}
+	}
+	if merged != "{\"a\":1}\n{\"b\":2}\n" {
+		t.Fatalf("expected the remote trace handed to merge, got %q", merged)
+	}
+	if raw, err := os.ReadFile(blob); err != nil || string(raw) != "old" {
+		t.Fatalf("hardlinked blob rewritten in place: %q %v", raw, err)
+	}
+}
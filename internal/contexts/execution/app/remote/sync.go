@@ -0,0 +1,235 @@
+package remote
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// Push recreates the remote attempt/tmp dirs and uploads the local attempt dir plus the env file as
+// one tar stream over ssh (no rsync/scp needed on either side).
+func Push(ctx context.Context, s Spec, localAttempt string, dirs Dirs, env map[string]string) error {
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(writeTar(pw, localAttempt, EnvFile(env)))
+	}()
+	script := "rm -rf " + ShellQuote(dirs.Attempt) + " " + ShellQuote(dirs.Tmp) +
+		" && mkdir -p " + ShellQuote(dirs.Attempt) + " " + ShellQuote(dirs.Tmp) +
+		" && tar -C " + ShellQuote(dirs.Attempt) + " -xf -"
+	if err := runSSH(ctx, s, script, pr, nil); err != nil {
+		_ = pr.CloseWithError(err)
+		return fmt.Errorf("push attempt dir to %s: %w", s.Host, err)
+	}
+	return nil
+}
+
+// Pull streams the remote attempt dir back and writes the files keep accepts into the local
+// attempt dir. Only regular files are extracted; entries escaping the attempt dir are rejected.
+// Harness-owned inputs (attempt.json, prompt*.txt, tool.calls.jsonl) are never written: an entry
+// named in merge is handed to its func instead, any other one is skipped. Files are replaced by
+// rename, so a local file that is a hardlink (e.g. into the CAS) is never rewritten in place.
+func Pull(ctx context.Context, s Spec, dirs Dirs, localAttempt string, keep func(rel string) bool, merge map[string]func(io.Reader) error) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	out, wait, err := startSSH(ctx, s, "cd "+ShellQuote(dirs.Attempt)+" && tar -cf - .")
+	if err != nil {
+		return nil, fmt.Errorf("pull attempt dir from %s: %w", s.Host, err)
+	}
+	pulled, err := extractTar(tar.NewReader(out), localAttempt, keep, merge)
+	if err != nil {
+		// Stop the remote tar instead of draining what is left of the stream.
+		cancel()
+		_ = wait()
+		return pulled, fmt.Errorf("pull attempt dir from %s: %w", s.Host, err)
+	}
+	// Consume the archive's trailing block padding so the remote tar exits cleanly.
+	_, _ = io.Copy(io.Discard, out)
+	if err := wait(); err != nil {
+		return pulled, fmt.Errorf("pull attempt dir from %s: %w", s.Host, err)
+	}
+	return pulled, nil
+}
+
+func extractTar(tr *tar.Reader, localAttempt string, keep func(rel string) bool, merge map[string]func(io.Reader) error) ([]string, error) {
+	var pulled []string
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return pulled, nil
+		}
+		if err != nil {
+			return pulled, err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		rel := path.Clean(strings.TrimPrefix(h.Name, "./"))
+		if rel == "." || path.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+			return pulled, fmt.Errorf("unsafe path %q", h.Name)
+		}
+		if rel == envFileName {
+			continue
+		}
+		if harnessOwned(rel) {
+			if fn := merge[rel]; fn != nil {
+				if err := fn(tr); err != nil {
+					return pulled, fmt.Errorf("merge %s: %w", rel, err)
+				}
+				pulled = append(pulled, rel)
+			}
+			continue
+		}
+		if !keep(rel) {
+			continue
+		}
+		perm := fs.FileMode(h.Mode).Perm()
+		if perm == 0 {
+			perm = 0o644
+		}
+		if err := store.WriteFileAtomicFrom(filepath.Join(localAttempt, filepath.FromSlash(rel)), tr, perm); err != nil {
+			return pulled, err
+		}
+		pulled = append(pulled, rel)
+	}
+}
+
+// harnessOwned reports whether rel is an input the harness wrote before the push; the remote
+// copies are stale at best and must not replace the local ones.
+func harnessOwned(rel string) bool {
+	if rel == artifacts.AttemptJSON || rel == artifacts.ToolCallsJSONL {
+		return true
+	}
+	ok, _ := path.Match("prompt*.txt", rel)
+	return ok
+}
+
+// Cleanup removes the attempt's remote dirs (best effort).
+func Cleanup(ctx context.Context, s Spec, dirs Dirs) error {
+	return runSSH(ctx, s, "rm -rf "+ShellQuote(dirs.Attempt)+" "+ShellQuote(dirs.Tmp), nil, nil)
+}
+
+// SyncMatcher accepts the evidence artifacts (always) plus files matching the sync patterns; with
+// no patterns every file is accepted. A pattern naming a directory accepts everything below it.
+func SyncMatcher(always []string, patterns []string) func(rel string) bool {
+	if len(patterns) == 0 {
+		return func(string) bool { return true }
+	}
+	set := map[string]bool{}
+	for _, a := range always {
+		set[a] = true
+	}
+	return func(rel string) bool {
+		if set[rel] {
+			return true
+		}
+		for p := rel; p != "." && p != "/"; p = path.Dir(p) {
+			for _, pat := range patterns {
+				if ok, _ := path.Match(pat, p); ok {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func runSSH(ctx context.Context, s Spec, script string, stdin io.Reader, stdout io.Writer) error {
+	argv := SSHArgv(s, script)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	return sshError(cmd.Run(), &stderr)
+}
+
+// startSSH runs script and returns its stdout as a stream; wait reaps the ssh client and must be
+// called once the stream is consumed (or abandoned).
+func startSSH(ctx context.Context, s Spec, script string) (io.Reader, func() error, error) {
+	argv := SSHArgv(s, script)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	return out, func() error { return sshError(cmd.Wait(), &stderr) }, nil
+}
+
+func sshError(err error, stderr *bytes.Buffer) error {
+	if err == nil {
+		return nil
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("%w: %s", err, msg)
+	}
+	return err
+}
+
+func writeTar(w io.Writer, root string, envFile []byte) error {
+	tw := tar.NewWriter(w)
+	if err := tw.WriteHeader(&tar.Header{Name: envFileName, Mode: 0o600, Size: int64(len(envFile)), Typeflag: tar.TypeReg}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(envFile); err != nil {
+		return err
+	}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+		h, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		h.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			h.Name += "/"
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		// Runner logs may still grow while we read; copy exactly the size the header announced.
+		_, err = io.CopyN(tw, f, h.Size)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
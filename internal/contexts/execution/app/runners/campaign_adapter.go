@@ -33,7 +33,12 @@ func NewCampaignExecutor(invoker FlowMissionInvoker) (*CampaignExecutor, error)
 		campaign.RunnerTypeCodexExec:   mk(campaign.RunnerTypeCodexExec),
 		campaign.RunnerTypeCodexSub:    mk(campaign.RunnerTypeCodexSub),
 		campaign.RunnerTypeClaudeSub:   mk(campaign.RunnerTypeClaudeSub),
+		campaign.RunnerTypeClaudeCLI:   mk(campaign.RunnerTypeClaudeCLI),
+		campaign.RunnerTypeHTTPAgent:   mk(campaign.RunnerTypeHTTPAgent),
+		campaign.RunnerTypeWSAgent:     mk(campaign.RunnerTypeWSAgent),
 		campaign.RunnerTypeCodexAppSrv: mk(campaign.RunnerTypeCodexAppSrv),
+		campaign.RunnerTypeDocker:      mk(campaign.RunnerTypeDocker),
+		campaign.RunnerTypeSSH:         mk(campaign.RunnerTypeSSH),
 	}}, nil
 }
 
@@ -0,0 +1,177 @@
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	KindNone  = "none"
+	KindBwrap = "bwrap"
+
+	NetworkHost = "host"
+	NetworkNone = "none"
+	// NetworkAdvisoryAllowlist routes egress through a proxy that refuses and logs hosts outside
+	// the allowlist. Nothing isolates the runner, so a client that ignores the proxy env bypasses it.
+	NetworkAdvisoryAllowlist = "advisory-allowlist"
+)
+
+// ParseKind normalizes a --sandbox value; empty means none.
+func ParseKind(raw string) (string, error) {
+	switch k := strings.ToLower(strings.TrimSpace(raw)); k {
+	case "", KindNone:
+		return KindNone, nil
+	case KindBwrap:
+		return k, nil
+	default:
+		return "", fmt.Errorf("invalid sandbox %q (expected %s|%s)", raw, KindNone, KindBwrap)
+	}
+}
+
+// ParseNetwork normalizes a --network value; empty means host (no enforcement).
+func ParseNetwork(raw string) (string, error) {
+	switch n := strings.ToLower(strings.TrimSpace(raw)); n {
+	case "", NetworkHost:
+		return NetworkHost, nil
+	case NetworkNone, NetworkAdvisoryAllowlist:
+		return n, nil
+	case "allowlist":
+		// Refuse rather than alias: callers asking for allowlist expect enforcement it cannot give.
+		return "", fmt.Errorf("network allowlist is not enforced and was renamed %s (the egress proxy is advisory); use %s for an isolated runner", NetworkAdvisoryAllowlist, NetworkNone)
+	default:
+		return "", fmt.Errorf("invalid network %q (expected %s|%s|%s)", raw, NetworkHost, NetworkNone, NetworkAdvisoryAllowlist)
+	}
+}
+
+// Profile is what a sandboxed runner can see: the host filesystem read-only, Hidden paths replaced
+// by empty tmpfs, ReadOnly paths re-exposed inside them, and only Writable paths writable.
+type Profile struct {
+	Kind     string   `json:"kind"`
+	Repo     string   `json:"repo,omitempty"`
+	ReadOnly []string `json:"readOnly,omitempty"`
+	Writable []string `json:"writable"`
+	Hidden   []string `json:"hidden,omitempty"`
+	Cwd      string   `json:"cwd,omitempty"`
+	// NoNetwork runs the runner in an empty network namespace (loopback only).
+	NoNetwork bool `json:"noNetwork,omitempty"`
+}
+
+// ProfileParams is the attempt context a profile is built from.
+type ProfileParams struct {
+	AttemptDir string
+	TmpDir     string
+	Cwd        string
+	Home       string
+	// Executables must stay reachable even when they live under a hidden path (zcl for shims, the runner).
+	Executables []string
+}
+
+// BwrapProfile confines the runner to the attempt dir (and tmp dir), with a read-only view of the
+// repo the runner starts in and an empty $HOME and /tmp.
+func BwrapProfile(p ProfileParams) Profile {
+	prof := Profile{Kind: KindBwrap, Cwd: p.Cwd}
+	if p.Cwd != "" {
+		prof.Repo = RepoRoot(p.Cwd)
+		prof.ReadOnly = append(prof.ReadOnly, prof.Repo)
+	}
+	hidden := []string{"/tmp"}
+	if home := strings.TrimSpace(p.Home); home != "" && home != "/" {
+		hidden = append(hidden, filepath.Clean(home))
+	}
+	prof.Hidden = hidden
+	for _, exe := range p.Executables {
+		if exe = strings.TrimSpace(exe); exe != "" && filepath.IsAbs(exe) && underAny(exe, hidden) && !underAny(exe, prof.ReadOnly) {
+			prof.ReadOnly = append(prof.ReadOnly, exe)
+		}
+	}
+	prof.Writable = []string{p.AttemptDir}
+	if p.TmpDir != "" && !underAny(p.TmpDir, prof.Writable) {
+		prof.Writable = append(prof.Writable, p.TmpDir)
+	}
+	return prof
+}
+
+// Argv wraps argv in bwrap. Mount order matters: hidden tmpfs first, then the read-only and
+// writable binds on top, so an attempt dir under /tmp or $HOME stays reachable.
+func (p Profile) Argv(argv []string) []string {
+	out := []string{"bwrap", "--die-with-parent", "--unshare-pid", "--unshare-ipc", "--unshare-uts",
+		"--ro-bind", "/", "/", "--dev", "/dev", "--proc", "/proc"}
+	if p.NoNetwork {
+		out = append(out, "--unshare-net")
+	}
+	for _, h := range p.Hidden {
+		out = append(out, "--tmpfs", h)
+	}
+	for _, ro := range p.ReadOnly {
+		out = append(out, "--ro-bind", ro, ro)
+	}
+	for _, w := range p.Writable {
+		out = append(out, "--bind", w, w)
+	}
+	if p.Cwd != "" {
+		out = append(out, "--chdir", p.Cwd)
+	}
+	out = append(out, "--")
+	return append(out, argv...)
+}
+
+// NetworkDenyArgv wraps argv in a fresh network namespace and nothing else: the filesystem stays
+// the host's (writable), only network access is removed. Used for --network none without --sandbox.
+func NetworkDenyArgv(argv []string) []string {
+	out := []string{"bwrap", "--die-with-parent", "--dev-bind", "/", "/", "--unshare-net"}
+	if cwd, err := os.Getwd(); err == nil {
+		out = append(out, "--chdir", cwd)
+	}
+	out = append(out, "--")
+	return append(out, argv...)
+}
+
+// Preflight checks the sandbox tool is installed.
+func Preflight(kind string) error {
+	if kind != KindBwrap {
+		return nil
+	}
+	if _, err := exec.LookPath("bwrap"); err != nil {
+		return fmt.Errorf("--sandbox bwrap requires bubblewrap (bwrap) on PATH")
+	}
+	return nil
+}
+
+// PreflightNetwork checks that --network none can be enforced for host process runners.
+func PreflightNetwork(network string) error {
+	if network != NetworkNone {
+		return nil
+	}
+	if _, err := exec.LookPath("bwrap"); err != nil {
+		return fmt.Errorf("--network none requires bubblewrap (bwrap) on PATH to unshare the network namespace")
+	}
+	return nil
+}
+
+// RepoRoot is the nearest ancestor of dir holding .git, or dir itself.
+func RepoRoot(dir string) string {
+	dir = filepath.Clean(dir)
+	for cur := dir; ; {
+		if _, err := os.Stat(filepath.Join(cur, ".git")); err == nil {
+			return cur
+		}
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			return dir
+		}
+		cur = parent
+	}
+}
+
+func underAny(path string, roots []string) bool {
+	for _, r := range roots {
+		rel, err := filepath.Rel(r, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBwrapProfile_HidesHomeAndTmpButKeepsAttemptWritable(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "home", "op", "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cwd := filepath.Join(repo, "sub")
+	attemptDir := filepath.Join(repo, ".zcl", "runs", "r", "attempts", "a1")
+	prof := BwrapProfile(ProfileParams{
+		AttemptDir:  attemptDir,
+		TmpDir:      filepath.Join(attemptDir, "tmp"),
+		Cwd:         cwd,
+		Home:        filepath.Join(root, "home", "op"),
+		Executables: []string{"/tmp/go-build/zcl", filepath.Join(repo, "runner.sh"), "relative"},
+	})
+	if prof.Repo != repo || len(prof.Writable) != 1 || prof.Writable[0] != attemptDir {
+		t.Fatalf("unexpected profile: %+v", prof)
+	}
+	if strings.Join(prof.ReadOnly, ",") != repo+",/tmp/go-build/zcl" {
+		t.Fatalf("only hidden executables outside the repo are re-bound: %v", prof.ReadOnly)
+	}
+	argv := strings.Join(prof.Argv([]string{"./runner.sh", "--", "x"}), " ")
+	tmpfs := strings.Index(argv, "--tmpfs "+filepath.Join(root, "home", "op"))
+	roRepo := strings.Index(argv, "--ro-bind "+repo+" "+repo)
+	rw := strings.Index(argv, "--bind "+attemptDir+" "+attemptDir)
+	if !strings.HasPrefix(argv, "bwrap --die-with-parent") || tmpfs < 0 || roRepo < tmpfs || rw < roRepo {
+		t.Fatalf("binds must be layered tmpfs -> ro repo -> writable attempt:\n%s", argv)
+	}
+	if !strings.HasSuffix(argv, "--chdir "+cwd+" -- ./runner.sh -- x") {
+		t.Fatalf("unexpected argv tail:\n%s", argv)
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	if k, err := ParseKind(""); err != nil || k != KindNone {
+		t.Fatalf("empty: %q %v", k, err)
+	}
+	if k, err := ParseKind(" BWRAP "); err != nil || k != KindBwrap {
+		t.Fatalf("bwrap: %q %v", k, err)
+	}
+	if _, err := ParseKind("nsjail"); err == nil {
+		t.Fatalf("expected error for unsupported sandbox")
+	}
+}
+
+func TestNetworkNone_UnsharesNetworkNamespace(t *testing.T) {
+	if n, err := ParseNetwork(""); err != nil || n != NetworkHost {
+		t.Fatalf("empty: %q %v", n, err)
+	}
+	if _, err := ParseNetwork("proxy"); err == nil {
+		t.Fatalf("expected error for unsupported network mode")
+	}
+	if _, err := ParseNetwork("allowlist"); err == nil || !strings.Contains(err.Error(), NetworkAdvisoryAllowlist) {
+		t.Fatalf("expected allowlist refused with a pointer to %s, got %v", NetworkAdvisoryAllowlist, err)
+	}
+	prof := Profile{Kind: KindBwrap, Writable: []string{"/out/a1"}, NoNetwork: true}
+	if joined := strings.Join(prof.Argv([]string{"true"}), " "); !strings.Contains(joined, "--proc /proc --unshare-net") {
+		t.Fatalf("profile argv missing --unshare-net: %s", joined)
+	}
+	joined := strings.Join(NetworkDenyArgv([]string{"sh", "-c", "curl x"}), " ")
+	if !strings.HasPrefix(joined, "bwrap --die-with-parent --dev-bind / / --unshare-net") || !strings.HasSuffix(joined, "-- sh -c curl x") {
+		t.Fatalf("unexpected network-deny argv: %s", joined)
+	}
+}
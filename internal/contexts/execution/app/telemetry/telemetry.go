@@ -0,0 +1,238 @@
+package telemetry
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// SampleInterval is how often a runner's process tree is sampled.
+const SampleInterval = time.Second
+
+// clockTicks is USER_HZ, the unit of /proc/<pid>/stat CPU times (100 on every Linux ABI zcl runs on).
+const clockTicks = 100
+
+// procRoot is where process stats are read from; hosts without it yield no samples.
+var procRoot = "/proc"
+
+// Sampler records CPU, RSS and process counts of a runner's process tree (the root pid plus its
+// descendants) from /proc while the runner runs.
+type Sampler struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	samples []schema.ResourceSampleV1
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewSampler(interval time.Duration) *Sampler {
+	return &Sampler{interval: interval}
+}
+
+// Start samples pid right away (before the runner can exit and be reaped) and then every interval
+// until Stop. Only the first call starts.
+func (s *Sampler) Start(pid int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil || pid <= 0 {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	start := time.Now()
+	prev := s.sampleLocked(pid, start, start, 0)
+	go s.loop(pid, start, prev)
+}
+
+type mark struct {
+	at    time.Time
+	ticks int64
+}
+
+func (s *Sampler) loop(pid int, start time.Time, prev mark) {
+	defer close(s.done)
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-t.C:
+			s.mu.Lock()
+			prev = s.sampleLocked(pid, start, prev.at, prev.ticks)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// sampleLocked appends one sample of pid's tree and returns the new CPU baseline (unchanged once
+// the runner is gone).
+func (s *Sampler) sampleLocked(pid int, start, prevAt time.Time, prevTicks int64) mark {
+	now := time.Now()
+	tr, ok := readTree(pid)
+	if !ok {
+		return mark{at: prevAt, ticks: prevTicks}
+	}
+	s.samples = append(s.samples, tr.sample(now, start, prevAt, prevTicks))
+	return mark{at: now, ticks: tr.ticks}
+}
+
+// Stop ends sampling and returns the samples taken (none when sampling never started or the host
+// has no /proc).
+func (s *Sampler) Stop() []schema.ResourceSampleV1 {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]schema.ResourceSampleV1(nil), s.samples...)
+}
+
+// procStat is what one /proc/<pid>/stat line contributes to a sample.
+type procStat struct {
+	ppid    int
+	ticks   int64 // utime+stime+cutime+cstime
+	threads int
+	rss     int64 // pages
+}
+
+// parseStat parses /proc/<pid>/stat. The command name may contain spaces and parens, so fields
+// are counted from the last ')'.
+func parseStat(raw string) (procStat, bool) {
+	i := strings.LastIndexByte(raw, ')')
+	if i < 0 {
+		return procStat{}, false
+	}
+	f := strings.Fields(raw[i+1:])
+	// f[0] is field 3 (state): ppid=4, utime..cstime=14..17, num_threads=20, rss=24.
+	if len(f) < 22 {
+		return procStat{}, false
+	}
+	num := func(n int) int64 {
+		v, _ := strconv.ParseInt(f[n-3], 10, 64)
+		return v
+	}
+	return procStat{
+		ppid:    int(num(4)),
+		ticks:   num(14) + num(15) + num(16) + num(17),
+		threads: int(num(20)),
+		rss:     num(24),
+	}, true
+}
+
+type tree struct {
+	procs   int
+	threads int
+	ticks   int64
+	rss     int64
+}
+
+// readTree sums the stats of root and every descendant; ok is false once root is gone.
+func readTree(root int) (tree, bool) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return tree{}, false
+	}
+	stats := map[int]procStat{}
+	children := map[int][]int{}
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(procRoot, e.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		st, ok := parseStat(string(raw))
+		if !ok {
+			continue
+		}
+		stats[pid] = st
+		children[st.ppid] = append(children[st.ppid], pid)
+	}
+	if _, ok := stats[root]; !ok {
+		return tree{}, false
+	}
+	var t tree
+	queue := []int{root}
+	seen := map[int]bool{}
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		st := stats[pid]
+		t.procs++
+		t.threads += st.threads
+		t.ticks += st.ticks
+		t.rss += st.rss
+		queue = append(queue, children[pid]...)
+	}
+	return t, true
+}
+
+func (t tree) sample(now, start, prevAt time.Time, prevTicks int64) schema.ResourceSampleV1 {
+	cpuPct := 0.0
+	if wall := now.Sub(prevAt).Seconds(); wall > 0 && t.ticks > prevTicks {
+		// Descendants exiting unreaped take their ticks with them, so deltas are clamped at 0.
+		cpuPct = round2(float64(t.ticks-prevTicks) / clockTicks / wall * 100)
+	}
+	load1, memAvail := hostLoad()
+	return schema.ResourceSampleV1{
+		V:                     schema.ResourcesSchemaV1,
+		TS:                    now.UTC().Format(time.RFC3339Nano),
+		ElapsedMs:             now.Sub(start).Milliseconds(),
+		CPUPercent:            cpuPct,
+		CPUSeconds:            round2(float64(t.ticks) / clockTicks),
+		RSSBytes:              t.rss * int64(os.Getpagesize()),
+		Procs:                 t.procs,
+		Threads:               t.threads,
+		HostLoad1:             load1,
+		HostMemAvailableBytes: memAvail,
+	}
+}
+
+// hostLoad reads the 1-minute load average and MemAvailable of the host.
+func hostLoad() (float64, int64) {
+	var load1 float64
+	if raw, err := os.ReadFile(filepath.Join(procRoot, "loadavg")); err == nil {
+		if f := strings.Fields(string(raw)); len(f) > 0 {
+			load1, _ = strconv.ParseFloat(f[0], 64)
+		}
+	}
+	var memAvail int64
+	if f, err := os.Open(filepath.Join(procRoot, "meminfo")); err == nil {
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			if rest, ok := strings.CutPrefix(sc.Text(), "MemAvailable:"); ok {
+				if f := strings.Fields(rest); len(f) > 0 {
+					kb, _ := strconv.ParseInt(f[0], 10, 64)
+					memAvail = kb << 10
+				}
+				break
+			}
+		}
+		_ = f.Close()
+	}
+	return load1, memAvail
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,92 @@
+package telemetry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func statLine(pid, ppid int, comm string, utime, stime, cutime, cstime, threads, rss int64) string {
+	// pid (comm) state ppid pgrp session tty tpgid flags minflt cminflt majflt cmajflt utime stime
+	// cutime cstime priority nice num_threads itrealvalue starttime vsize rss ...
+	return fmt.Sprintf("%d (%s) S %d 1 1 0 -1 0 0 0 0 0 %d %d %d %d 20 0 %d 0 100 1000 %d 0 0\n",
+		pid, comm, ppid, utime, stime, cutime, cstime, threads, rss)
+}
+
+func TestParseStat_CommWithParensAndSpaces(t *testing.T) {
+	st, ok := parseStat(statLine(42, 7, "evil) S 1 (x", 10, 5, 3, 2, 4, 250))
+	if !ok {
+		t.Fatalf("expected parse ok")
+	}
+	if st.ppid != 7 || st.ticks != 20 || st.threads != 4 || st.rss != 250 {
+		t.Fatalf("unexpected stat: %+v", st)
+	}
+	if _, ok := parseStat("42 (short) S 1"); ok {
+		t.Fatalf("expected truncated stat to be rejected")
+	}
+}
+
+func TestReadTree_SumsRootAndDescendants(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(root, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("10/stat", statLine(10, 1, "runner", 100, 50, 0, 0, 2, 1000))
+	write("11/stat", statLine(11, 10, "node", 30, 20, 0, 0, 8, 3000))
+	write("12/stat", statLine(12, 11, "sh", 1, 1, 0, 0, 1, 100))
+	write("20/stat", statLine(20, 1, "unrelated", 999, 999, 0, 0, 1, 99999))
+	write("self/stat", "not a pid dir")
+	write("loadavg", "1.50 0.80 0.40 2/300 12345\n")
+	write("meminfo", "MemTotal:       16000000 kB\nMemAvailable:    8000000 kB\n")
+
+	prev := procRoot
+	procRoot = root
+	t.Cleanup(func() { procRoot = prev })
+
+	tr, ok := readTree(10)
+	if !ok {
+		t.Fatalf("expected root to be found")
+	}
+	if tr.procs != 3 || tr.threads != 11 || tr.ticks != 202 || tr.rss != 4100 {
+		t.Fatalf("unexpected tree: %+v", tr)
+	}
+	if _, ok := readTree(99); ok {
+		t.Fatalf("expected missing root to report !ok")
+	}
+
+	start := time.Unix(0, 0)
+	s := tr.sample(start.Add(2*time.Second), start, start, 2)
+	if s.CPUPercent != 100 || s.CPUSeconds != 2.02 || s.Procs != 3 {
+		t.Fatalf("unexpected sample: %+v", s)
+	}
+	if s.HostLoad1 != 1.5 || s.HostMemAvailableBytes != 8000000<<10 {
+		t.Fatalf("unexpected host fields: %+v", s)
+	}
+}
+
+func TestSampler_SamplesOwnProcess(t *testing.T) {
+	if _, err := os.Stat(filepath.Join(procRoot, "self", "stat")); err != nil {
+		t.Skip("no /proc on this host")
+	}
+	s := NewSampler(10 * time.Millisecond)
+	s.Start(os.Getpid())
+	time.Sleep(50 * time.Millisecond)
+	samples := s.Stop()
+	if len(samples) < 2 {
+		t.Fatalf("expected an initial and periodic samples, got %d", len(samples))
+	}
+	if samples[0].Procs < 1 || samples[0].RSSBytes <= 0 || samples[0].V != 1 {
+		t.Fatalf("unexpected first sample: %+v", samples[0])
+	}
+	if got := len(NewSampler(time.Second).Stop()); got != 0 {
+		t.Fatalf("expected no samples without Start, got %d", got)
+	}
+}
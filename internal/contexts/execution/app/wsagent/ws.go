@@ -0,0 +1,244 @@
+package wsagent
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The RFC 6455 subset the agent protocol needs: a client connection exchanging text messages,
+// answering pings and closing cleanly. Extensions and subprotocols are not negotiated.
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+
+	// maxMessageBytes bounds one reassembled message from the agent.
+	maxMessageBytes = 16 << 20
+
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+// errClosed is returned by readMessage once the peer sent a close frame.
+var errClosed = errors.New("websocket closed by peer")
+
+type conn struct {
+	nc  net.Conn
+	br  *bufio.Reader
+	wmu sync.Mutex
+	// mask is true on the client side, which must mask every frame it sends.
+	mask bool
+}
+
+// dial opens a client connection to a ws:// or wss:// endpoint.
+func dial(ctx context.Context, endpoint string, header http.Header) (*conn, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "wss" {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "wss" {
+		tc := tls.Client(nc, &tls.Config{ServerName: u.Hostname()})
+		if err := tc.HandshakeContext(ctx); err != nil {
+			_ = nc.Close()
+			return nil, err
+		}
+		nc = tc
+	}
+	if dl, ok := ctx.Deadline(); ok {
+		_ = nc.SetDeadline(dl)
+	}
+	c, err := handshake(nc, u, header)
+	if err != nil {
+		_ = nc.Close()
+		return nil, err
+	}
+	_ = nc.SetDeadline(time.Time{})
+	return c, nil
+}
+
+func handshake(nc net.Conn, u *url.URL, header http.Header) (*conn, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+	hu := *u
+	hu.Scheme = strings.Replace(hu.Scheme, "ws", "http", 1)
+	req := &http.Request{Method: http.MethodGet, URL: &hu, Host: u.Host, Header: http.Header{}, Proto: "HTTP/1.1", ProtoMajor: 1, ProtoMinor: 1}
+	for k, vs := range header {
+		req.Header[k] = append([]string(nil), vs...)
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	if err := req.Write(nc); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(nc)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("websocket handshake: %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		return nil, fmt.Errorf("websocket handshake: invalid Sec-WebSocket-Accept")
+	}
+	return &conn{nc: nc, br: br, mask: true}, nil
+}
+
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (c *conn) writeText(b []byte) error { return c.writeFrame(opText, b) }
+
+func (c *conn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	hdr := make([]byte, 2, 14)
+	hdr[0] = 0x80 | op
+	switch n := len(payload); {
+	case n < 126:
+		hdr[1] = byte(n)
+	case n <= 0xFFFF:
+		hdr[1] = 126
+		hdr = binary.BigEndian.AppendUint16(hdr, uint16(n))
+	default:
+		hdr[1] = 127
+		hdr = binary.BigEndian.AppendUint64(hdr, uint64(n))
+	}
+	body := payload
+	if c.mask {
+		hdr[1] |= 0x80
+		var key [4]byte
+		if _, err := rand.Read(key[:]); err != nil {
+			return err
+		}
+		hdr = append(hdr, key[:]...)
+		body = make([]byte, len(payload))
+		for i := range payload {
+			body[i] = payload[i] ^ key[i%4]
+		}
+	}
+	if _, err := c.nc.Write(hdr); err != nil {
+		return err
+	}
+	_, err := c.nc.Write(body)
+	return err
+}
+
+// readMessage returns the next text or binary message, answering pings on the way.
+func (c *conn) readMessage() ([]byte, error) {
+	var msg []byte
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case opPing:
+			// A failed pong surfaces on the next read; messages already received stay readable.
+			_ = c.writeFrame(opPong, payload)
+			continue
+		case opPong:
+			continue
+		case opClose:
+			_ = c.writeFrame(opClose, payload)
+			return nil, errClosed
+		case opText, opBinary, opContinuation:
+		default:
+			return nil, fmt.Errorf("websocket: unknown opcode %#x", op)
+		}
+		if len(msg)+len(payload) > maxMessageBytes {
+			return nil, fmt.Errorf("websocket: message exceeds %d bytes", maxMessageBytes)
+		}
+		msg = append(msg, payload...)
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+func (c *conn) readFrame() (bool, byte, []byte, error) {
+	var h [2]byte
+	if _, err := io.ReadFull(c.br, h[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, op, masked := h[0]&0x80 != 0, h[0]&0x0F, h[1]&0x80 != 0
+	n := uint64(h[1] & 0x7F)
+	switch n {
+	case 126:
+		var b [2]byte
+		if _, err := io.ReadFull(c.br, b[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(b[:]))
+	case 127:
+		var b [8]byte
+		if _, err := io.ReadFull(c.br, b[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(b[:])
+	}
+	if n > maxMessageBytes {
+		return false, 0, nil, fmt.Errorf("websocket: frame exceeds %d bytes", maxMessageBytes)
+	}
+	var key [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, key[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= key[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+// close sends a normal-closure frame and closes the connection.
+func (c *conn) close() error {
+	_ = c.writeFrame(opClose, []byte{0x03, 0xE8})
+	return c.nc.Close()
+}
@@ -0,0 +1,275 @@
+package wsagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultZCL is the zcl binary whose `ws agent` subcommand drives the agent.
+	DefaultZCL = "zcl"
+	// DefaultInterruptGraceMs is how long before the attempt deadline the agent is asked to stop,
+	// and so how long it has to wrap up before the runner is killed.
+	DefaultInterruptGraceMs = 5000
+)
+
+// Message types of the agent protocol. The client sends mission and interrupt; the agent sends
+// output, event, result and error.
+const (
+	TypeMission   = "mission"
+	TypeInterrupt = "interrupt"
+	TypeOutput    = "output"
+	TypeEvent     = "event"
+	TypeResult    = "result"
+	TypeError     = "error"
+)
+
+// ErrInterrupted reports that the attempt ran out of time and the agent was interrupted.
+var ErrInterrupted = errors.New("agent interrupted at the attempt deadline")
+
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// Spec is the endpoint of a runner.type=ws_agent flow. Header values may reference the attempt
+// env as ${VAR}; they are expanded by the agent process, so secrets stay out of the spec and argv.
+type Spec struct {
+	URL              string
+	Headers          map[string]string
+	InterruptGraceMs int64
+}
+
+// Normalize fills defaults and validates s.
+func Normalize(s Spec) (Spec, error) {
+	s.URL = strings.TrimSpace(s.URL)
+	if s.URL == "" {
+		return Spec{}, fmt.Errorf("missing url")
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "ws" && u.Scheme != "wss") || u.Host == "" {
+		return Spec{}, fmt.Errorf("invalid url %q (expected ws(s)://...)", s.URL)
+	}
+	headers := map[string]string{}
+	for k, v := range s.Headers {
+		k = strings.TrimSpace(k)
+		if !headerNamePattern.MatchString(k) {
+			return Spec{}, fmt.Errorf("invalid header name %q", k)
+		}
+		if strings.ContainsAny(v, "\r\n") {
+			return Spec{}, fmt.Errorf("invalid header %s: value must be a single line", k)
+		}
+		headers[k] = strings.TrimSpace(v)
+	}
+	s.Headers = nil
+	if len(headers) > 0 {
+		s.Headers = headers
+	}
+	if s.InterruptGraceMs < 0 {
+		return Spec{}, fmt.Errorf("interruptGraceMs must be >= 0")
+	}
+	if s.InterruptGraceMs == 0 {
+		s.InterruptGraceMs = DefaultInterruptGraceMs
+	}
+	return s, nil
+}
+
+// Argv is the runner command of a ws_agent flow: zcl's `ws agent` subcommand, which prints the
+// agent's result behind marker for the stdout_json result channel.
+func Argv(zcl string, s Spec, marker string) []string {
+	argv := []string{zcl, "ws", "agent", "--url", s.URL}
+	names := make([]string, 0, len(s.Headers))
+	for k := range s.Headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		argv = append(argv, "--header", k+": "+s.Headers[k])
+	}
+	argv = append(argv, "--interrupt-grace-ms", strconv.FormatInt(s.InterruptGraceMs, 10))
+	if marker != "" {
+		argv = append(argv, "--result-marker", marker)
+	}
+	return argv
+}
+
+// ParseHeader splits a "Name: value" flag.
+func ParseHeader(raw string) (string, string, error) {
+	name, value, ok := strings.Cut(raw, ":")
+	name = strings.TrimSpace(name)
+	if !ok || !headerNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid header %q (expected Name: value)", raw)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// MissionRequest is the first message on the connection.
+type MissionRequest struct {
+	Type          string `json:"type"`
+	SchemaVersion int    `json:"schemaVersion"`
+	RunID         string `json:"runId"`
+	SuiteID       string `json:"suiteId"`
+	MissionID     string `json:"missionId"`
+	AttemptID     string `json:"attemptId"`
+	AgentID       string `json:"agentId,omitempty"`
+	Prompt        string `json:"prompt"`
+}
+
+// Message is one agent message: output text, a named event, the mission result or an error.
+type Message struct {
+	Type    string          `json:"type"`
+	Text    string          `json:"text,omitempty"`
+	Name    string          `json:"name,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Message string          `json:"message,omitempty"`
+	// Raw is the message as received.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Client runs one mission against a WebSocket agent.
+type Client struct {
+	Header http.Header
+	// InterruptGrace is how long the agent may take to answer an interrupt before the connection
+	// is dropped.
+	InterruptGrace time.Duration
+	// Output receives output messages (runner stdout).
+	Output io.Writer
+	// OnMessage sees every agent message except output, and the interrupt zcl sends.
+	OnMessage func(Message)
+}
+
+// Run connects, sends the mission and relays agent messages until the result. When ctx ends first
+// the agent is sent an interrupt and given InterruptGrace to send its result or close; Run then
+// returns ErrInterrupted (with the result when the agent sent one).
+func (c Client) Run(ctx context.Context, endpoint string, req MissionRequest) (json.RawMessage, error) {
+	if ctx.Err() != nil {
+		return nil, ErrInterrupted
+	}
+	conn, err := dial(ctx, endpoint, c.Header)
+	if err != nil {
+		return nil, fmt.Errorf("connect %s: %w", endpoint, err)
+	}
+	defer func() { _ = conn.close() }()
+	req.Type = TypeMission
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.writeText(b); err != nil {
+		return nil, err
+	}
+
+	type read struct {
+		msg Message
+		err error
+	}
+	msgs := make(chan read)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			var r read
+			raw, err := conn.readMessage()
+			switch {
+			case err != nil:
+				r.err = err
+			case json.Unmarshal(raw, &r.msg) != nil:
+				r.err = fmt.Errorf("invalid agent message: %s", truncate(raw, 200))
+			default:
+				r.msg.Raw = raw
+			}
+			select {
+			case msgs <- r:
+			case <-stop:
+				return
+			}
+			if r.err != nil {
+				return
+			}
+		}
+	}()
+
+	done := ctx.Done()
+	interrupted := false
+	var grace <-chan time.Time
+	for {
+		select {
+		case <-done:
+			done, interrupted = nil, true
+			interrupt := Message{Type: TypeInterrupt, Message: "attempt deadline"}
+			raw, _ := json.Marshal(map[string]string{"type": TypeInterrupt, "reason": "timeout"})
+			interrupt.Raw = raw
+			c.emit(interrupt)
+			if err := conn.writeText(raw); err != nil {
+				return nil, ErrInterrupted
+			}
+			t := time.NewTimer(c.InterruptGrace)
+			defer t.Stop()
+			grace = t.C
+		case <-grace:
+			return nil, ErrInterrupted
+		case r := <-msgs:
+			if r.err != nil {
+				if interrupted {
+					return nil, ErrInterrupted
+				}
+				if errors.Is(r.err, errClosed) || errors.Is(r.err, io.EOF) {
+					return nil, fmt.Errorf("agent closed the connection without a result")
+				}
+				return nil, r.err
+			}
+			m := r.msg
+			if m.Type == TypeOutput {
+				if c.Output != nil && m.Text != "" {
+					_, _ = io.WriteString(c.Output, strings.TrimSuffix(m.Text, "\n")+"\n")
+				}
+				continue
+			}
+			c.emit(m)
+			switch m.Type {
+			case TypeResult:
+				res, err := resultObject(m.Result)
+				if err == nil && interrupted {
+					err = ErrInterrupted
+				}
+				return res, err
+			case TypeError:
+				return nil, fmt.Errorf("agent error: %s", m.Message)
+			}
+		}
+	}
+}
+
+func (c Client) emit(m Message) {
+	if c.OnMessage != nil {
+		c.OnMessage(m)
+	}
+}
+
+func resultObject(b []byte) (json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, fmt.Errorf("agent result is not a JSON object")
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func truncate(b []byte, n int) string {
+	if len(b) > n {
+		return string(b[:n]) + "..."
+	}
+	return string(b)
+}
@@ -0,0 +1,112 @@
+package wsagent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// agentServer upgrades each request and hands the server side of the connection to script.
+func agentServer(t *testing.T, script func(c *conn, mission MissionRequest)) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		nc, brw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("hijack: %v", err)
+			return
+		}
+		defer func() { _ = nc.Close() }()
+		_, _ = brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: " + acceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n")
+		_ = brw.Flush()
+		c := &conn{nc: nc, br: bufio.NewReader(brw)}
+		raw, err := c.readMessage()
+		if err != nil {
+			t.Errorf("read mission: %v", err)
+			return
+		}
+		var m MissionRequest
+		_ = json.Unmarshal(raw, &m)
+		script(c, m)
+	}))
+}
+
+func send(c *conn, v string) { _ = c.writeText([]byte(v)) }
+
+func wsURL(srv *httptest.Server) string { return "ws" + strings.TrimPrefix(srv.URL, "http") }
+
+func TestClientRunRelaysOutputAndEvents(t *testing.T) {
+	srv := agentServer(t, func(c *conn, m MissionRequest) {
+		if m.Type != TypeMission || m.Prompt != "p1" {
+			send(c, `{"type":"error","message":"bad mission"}`)
+			return
+		}
+		_ = c.writeFrame(opPing, []byte("hi"))
+		send(c, `{"type":"output","text":"thinking"}`)
+		send(c, `{"type":"event","name":"tool/call","data":{"tool":"grep"}}`)
+		send(c, `{"type":"result","result":{ "ok": true, "result": "42" }}`)
+	})
+	defer srv.Close()
+
+	var out bytes.Buffer
+	var seen []string
+	c := Client{Header: http.Header{"Authorization": {"Bearer t0k"}}, InterruptGrace: time.Second, Output: &out, OnMessage: func(m Message) { seen = append(seen, m.Type+":"+m.Name) }}
+	res, err := c.Run(context.Background(), wsURL(srv), MissionRequest{MissionID: "m1", Prompt: "p1"})
+	if err != nil || string(res) != `{"ok":true,"result":"42"}` {
+		t.Fatalf("got %s, %v", res, err)
+	}
+	if out.String() != "thinking\n" || strings.Join(seen, ",") != "event:tool/call,result:" {
+		t.Fatalf("unexpected relay: out=%q seen=%v", out.String(), seen)
+	}
+
+	c.Header = nil
+	if _, err := c.Run(context.Background(), wsURL(srv), MissionRequest{}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected handshake 401, got %v", err)
+	}
+}
+
+func TestClientRunInterruptsAtDeadline(t *testing.T) {
+	srv := agentServer(t, func(c *conn, _ MissionRequest) {
+		raw, err := c.readMessage()
+		if err == nil && strings.Contains(string(raw), `"interrupt"`) {
+			send(c, `{"type":"result","result":{"ok":false,"result":"stopped early"}}`)
+		}
+	})
+	defer srv.Close()
+
+	var seen []string
+	c := Client{Header: http.Header{"Authorization": {"Bearer t0k"}}, InterruptGrace: time.Second, OnMessage: func(m Message) { seen = append(seen, m.Type) }}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	res, err := c.Run(ctx, wsURL(srv), MissionRequest{Prompt: "p"})
+	if !errors.Is(err, ErrInterrupted) || !strings.Contains(string(res), "stopped early") {
+		t.Fatalf("expected interrupted result, got %s, %v", res, err)
+	}
+	if strings.Join(seen, ",") != "interrupt,result" {
+		t.Fatalf("unexpected messages: %v", seen)
+	}
+}
+
+func TestNormalizeAndArgv(t *testing.T) {
+	s, err := Normalize(Spec{URL: "wss://agent.example/ws", Headers: map[string]string{"Authorization": "Bearer ${TOKEN}"}})
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	want := "zcl ws agent --url wss://agent.example/ws --header Authorization: Bearer ${TOKEN} --interrupt-grace-ms 5000 --result-marker M:"
+	if got := strings.Join(Argv(DefaultZCL, s, "M:"), " "); got != want {
+		t.Fatalf("unexpected argv:\n got %s\nwant %s", got, want)
+	}
+	if _, err := Normalize(Spec{URL: "https://agent"}); err == nil || !strings.Contains(err.Error(), "expected ws(s)") {
+		t.Fatalf("expected scheme error, got %v", err)
+	}
+}
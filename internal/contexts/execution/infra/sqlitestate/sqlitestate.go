@@ -0,0 +1,115 @@
+package sqlitestate
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/ports/statestore"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+
+	_ "modernc.org/sqlite"
+)
+
+// busyTimeoutMs bounds how long a writer waits for another process's transaction to commit.
+const busyTimeoutMs = 30000
+
+// DB keeps campaign state documents in one SQLite file. Every Update runs in a BEGIN IMMEDIATE
+// transaction, so concurrent writers (suite runs sharing a campaign ID, possibly in different
+// processes) are serialized instead of overwriting each other. After each update the document is
+// also written to its JSON path, so readers of campaign.state.json / campaign.run.state.json are
+// unchanged.
+type DB struct {
+	db  *sql.DB
+	dir string
+}
+
+var _ statestore.Store = (*DB)(nil)
+
+// Open creates the database (and its parent dir) when missing.
+func Open(path string) (*DB, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+		return nil, err
+	}
+	dsn := fmt.Sprintf("%s?_txlock=immediate&_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)", abs, busyTimeoutMs)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// One connection per process; cross-process exclusion is SQLite's write lock.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS documents (
+	name TEXT PRIMARY KEY,
+	body BLOB NOT NULL
+)`); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("campaign state db %s: %w", abs, err)
+	}
+	return &DB{db: db, dir: filepath.Dir(abs)}, nil
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// Update implements statestore.Store. A document not yet in the database is seeded from its JSON
+// file, so switching an existing campaign from the file backend keeps its history.
+func (d *DB) Update(path string, fn func(cur []byte) ([]byte, error)) error {
+	name, err := d.key(path)
+	if err != nil {
+		return err
+	}
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var cur []byte
+	err = tx.QueryRow(`SELECT body FROM documents WHERE name = ?`, name).Scan(&cur)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		cur, err = os.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			cur = nil
+		}
+	case err != nil:
+		return err
+	}
+	out, err := fn(cur)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO documents (name, body) VALUES (?, ?)
+ON CONFLICT(name) DO UPDATE SET body = excluded.body`, name, out); err != nil {
+		return err
+	}
+	// Mirror while still holding the write lock so JSON snapshots land in commit order.
+	if err := store.WriteFileAtomic(path, out); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// key names documents relative to the database dir, so a campaign dir can be moved or synced.
+func (d *DB) key(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(d.dir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(abs), nil
+	}
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,94 @@
+package sqlitestate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+)
+
+func TestUpdateState_ConcurrentWritersKeepEveryRun(t *testing.T) {
+	outRoot := t.TempDir()
+	statePath := campaign.DefaultStatePath(outRoot, "cmp")
+	dbPath := campaign.StateDBPath(outRoot, "cmp")
+	now := time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC)
+
+	const writers = 12
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Separate handles, as separate suite run processes would have.
+			db, err := Open(dbPath)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer func() { _ = db.Close() }()
+			_, err = campaign.UpdateState(statePath, campaign.UpdateInput{
+				Now:        now,
+				CampaignID: "cmp",
+				SuiteID:    "suite",
+				RunID:      fmt.Sprintf("run-%02d", i),
+				CreatedAt:  now.Add(time.Duration(i) * time.Second).Format(time.RFC3339Nano),
+				Store:      db,
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateState: %v", err)
+		}
+	}
+
+	var st campaign.StateV1
+	raw, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("read mirror: %v", err)
+	}
+	if err := json.Unmarshal(raw, &st); err != nil {
+		t.Fatalf("decode mirror: %v", err)
+	}
+	if len(st.Runs) != writers || st.LatestRunID != fmt.Sprintf("run-%02d", writers-1) {
+		t.Fatalf("expected %d runs with latest run-%02d, got %d latest=%s", writers, writers-1, len(st.Runs), st.LatestRunID)
+	}
+}
+
+func TestUpdate_SeedsFromExistingJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "campaign.state.json")
+	if err := os.WriteFile(path, []byte("seed"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	db, err := Open(filepath.Join(dir, "campaign.state.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var seen []string
+	for _, next := range []string{"a", "b"} {
+		if err := db.Update(path, func(cur []byte) ([]byte, error) {
+			seen = append(seen, string(cur))
+			return []byte(next), nil
+		}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+	}
+	if fmt.Sprint(seen) != "[seed a]" {
+		t.Fatalf("unexpected documents seen: %v", seen)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "b" {
+		t.Fatalf("expected JSON mirror to hold the last write, got %q", b)
+	}
+}
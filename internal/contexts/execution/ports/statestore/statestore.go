@@ -0,0 +1,11 @@
+package statestore
+
+// Store persists campaign state documents (campaign.state.json, campaign.run.state.json).
+// Implementations must make Update atomic with respect to other writers of the same path,
+// including writers in other processes.
+type Store interface {
+	// Update passes the current document at path (nil when absent) to fn and stores the returned
+	// bytes in the same transaction. When fn fails nothing is written.
+	Update(path string, fn func(cur []byte) ([]byte, error)) error
+	Close() error
+}
@@ -0,0 +1,521 @@
+package archive
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/bundle"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const IndexSchemaV1 = 1
+
+const (
+	SkippedPinned     = "pinned"
+	SkippedActive     = "active"
+	SkippedIncomplete = "incomplete"
+)
+
+const indexLockWait = 10 * time.Second
+
+// EntryV1 describes one archived run. Path is relative to the out-root; the attempt counts are
+// taken at archive time so listings do not need to open the archive.
+type EntryV1 struct {
+	RunID                string `json:"runId"`
+	SuiteID              string `json:"suiteId,omitempty"`
+	CreatedAt            string `json:"createdAt"`
+	ArchivedAt           string `json:"archivedAt"`
+	Path                 string `json:"path"`
+	Compression          string `json:"compression"`
+	SHA256               string `json:"sha256"`
+	Files                int    `json:"files"`
+	BytesBefore          int64  `json:"bytesBefore"`
+	BytesAfter           int64  `json:"bytesAfter"`
+	AttemptsTotal        int    `json:"attemptsTotal"`
+	OKTotal              int    `json:"okTotal"`
+	FailTotal            int    `json:"failTotal"`
+	MissingFeedbackTotal int    `json:"missingFeedbackTotal"`
+}
+
+// IndexV1 is <outRoot>/archive/archive.index.json.
+type IndexV1 struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	Runs          []EntryV1 `json:"runs"`
+}
+
+type SkippedV1 struct {
+	RunID  string `json:"runId"`
+	Reason string `json:"reason"`
+}
+
+type Result struct {
+	OK         bool        `json:"ok"`
+	OutRoot    string      `json:"outRoot"`
+	OlderThan  string      `json:"olderThan"`
+	DryRun     bool        `json:"dryRun,omitempty"`
+	Archived   []EntryV1   `json:"archived,omitempty"`
+	Skipped    []SkippedV1 `json:"skipped,omitempty"`
+	Errors     []string    `json:"errors,omitempty"`
+	BytesFreed int64       `json:"bytesFreed"`
+}
+
+type Opts struct {
+	OutRoot     string
+	OlderThan   time.Duration
+	Compression string
+	DryRun      bool
+	Now         time.Time
+}
+
+// Dir is where archives and their index live below an out-root.
+func Dir(outRoot string) string {
+	return filepath.Join(outRoot, "archive")
+}
+
+// ParseAge accepts Go durations ("36h") plus whole days ("14d").
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q (want e.g. 14d or 72h)", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q (want e.g. 14d or 72h)", s)
+	}
+	return d, nil
+}
+
+// ExtensionFor maps a compression to the archive file extension.
+func ExtensionFor(compression string) (string, error) {
+	switch compression {
+	case bundle.CompressionZstd:
+		return ".tar.zst", nil
+	case bundle.CompressionGzip:
+		return ".tar.gz", nil
+	default:
+		return "", fmt.Errorf("unsupported archive compression %q (want zstd or gzip)", compression)
+	}
+}
+
+// Run moves completed runs older than OlderThan into <outRoot>/archive/<runId>.tar.<ext> and
+// records them in the archive index. Pinned runs, runs another process is writing and runs with an
+// attempt that has no attempt.report.json yet are left in place. Each run is archived while holding
+// its owner lock, and its directory is only removed after the archive and index entry are durable.
+func Run(opts Opts) (Result, error) {
+	outRoot := strings.TrimSpace(opts.OutRoot)
+	if outRoot == "" {
+		outRoot = ".zcl"
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	compression := opts.Compression
+	if compression == "" {
+		compression = bundle.CompressionZstd
+	}
+	ext, err := ExtensionFor(compression)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{OK: true, OutRoot: outRoot, OlderThan: opts.OlderThan.String(), DryRun: opts.DryRun}
+	runsDir := filepath.Join(outRoot, "runs")
+	entries, err := os.ReadDir(runsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return res, nil
+		}
+		return Result{}, err
+	}
+	cutoff := now.Add(-opts.OlderThan)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		runDir := filepath.Join(runsDir, e.Name())
+		meta, createdAt, ok := readRunMeta(runDir)
+		if !ok || !createdAt.Before(cutoff) {
+			continue
+		}
+		if meta.Pinned {
+			res.Skipped = append(res.Skipped, SkippedV1{RunID: meta.RunID, Reason: SkippedPinned})
+			continue
+		}
+		counts, complete := attemptCounts(runDir)
+		if !complete {
+			res.Skipped = append(res.Skipped, SkippedV1{RunID: meta.RunID, Reason: SkippedIncomplete})
+			continue
+		}
+		entry := counts
+		entry.RunID = meta.RunID
+		entry.SuiteID = meta.SuiteID
+		entry.CreatedAt = meta.CreatedAt
+		entry.ArchivedAt = now.UTC().Format(time.RFC3339Nano)
+		entry.Path = filepath.ToSlash(filepath.Join("archive", meta.RunID+ext))
+		entry.Compression = compression
+		if opts.DryRun {
+			entry.BytesBefore, _ = dirSize(runDir)
+			res.Archived = append(res.Archived, entry)
+			continue
+		}
+		archived, err := archiveRun(outRoot, runDir, entry)
+		if err != nil {
+			var owned store.RunOwnedError
+			if errors.As(err, &owned) {
+				res.Skipped = append(res.Skipped, SkippedV1{RunID: meta.RunID, Reason: SkippedActive})
+				continue
+			}
+			res.OK = false
+			res.Errors = append(res.Errors, fmt.Sprintf("%s: %s", meta.RunID, err.Error()))
+			continue
+		}
+		res.Archived = append(res.Archived, archived)
+		res.BytesFreed += archived.BytesBefore - archived.BytesAfter
+	}
+	return res, nil
+}
+
+func readRunMeta(runDir string) (schema.RunJSONV1, time.Time, bool) {
+	raw, err := os.ReadFile(filepath.Join(runDir, artifacts.RunJSON))
+	if err != nil {
+		return schema.RunJSONV1{}, time.Time{}, false
+	}
+	var meta schema.RunJSONV1
+	if json.Unmarshal(raw, &meta) != nil || meta.RunID == "" {
+		return schema.RunJSONV1{}, time.Time{}, false
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, meta.CreatedAt)
+	if err != nil {
+		return schema.RunJSONV1{}, time.Time{}, false
+	}
+	return meta, createdAt, true
+}
+
+// attemptCounts reports feedback totals and whether every attempt has been finished (has
+// attempt.report.json). A run without attempts is not complete.
+func attemptCounts(runDir string) (EntryV1, bool) {
+	var out EntryV1
+	entries, err := os.ReadDir(filepath.Join(runDir, "attempts"))
+	if err != nil {
+		return out, false
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(runDir, "attempts", e.Name())
+		if _, err := os.Stat(filepath.Join(dir, artifacts.AttemptReportJSON)); err != nil {
+			return out, false
+		}
+		out.AttemptsTotal++
+		raw, err := os.ReadFile(filepath.Join(dir, artifacts.FeedbackJSON))
+		if err != nil {
+			out.MissingFeedbackTotal++
+			continue
+		}
+		var fb schema.FeedbackJSONV1
+		switch {
+		case json.Unmarshal(raw, &fb) != nil:
+			out.MissingFeedbackTotal++
+		case fb.OK:
+			out.OKTotal++
+		default:
+			out.FailTotal++
+		}
+	}
+	return out, out.AttemptsTotal > 0
+}
+
+func archiveRun(outRoot, runDir string, entry EntryV1) (EntryV1, error) {
+	release, err := store.AcquireRunOwner(runDir)
+	if err != nil {
+		return EntryV1{}, err
+	}
+	defer func() { _ = release() }()
+
+	files, err := collectFiles(runDir)
+	if err != nil {
+		return EntryV1{}, err
+	}
+	archiveDir := Dir(outRoot)
+	if err := os.MkdirAll(archiveDir, 0o755); err != nil {
+		return EntryV1{}, err
+	}
+	tmp, err := os.CreateTemp(archiveDir, ".zcl-archive-*")
+	if err != nil {
+		return EntryV1{}, err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	h := sha256.New()
+	sink, err := bundle.NewSink(io.MultiWriter(tmp, h), entry.Compression)
+	if err != nil {
+		_ = tmp.Close()
+		return EntryV1{}, err
+	}
+	tw := tar.NewWriter(sink)
+	writeErr := func() error {
+		for _, rel := range files {
+			f, err := bundle.WriteFileEntry(tw, entry.RunID+"/"+rel, filepath.Join(runDir, filepath.FromSlash(rel)))
+			if err != nil {
+				return err
+			}
+			entry.BytesBefore += f.Bytes
+		}
+		return tw.Close()
+	}()
+	if err := sink.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if err := tmp.Sync(); writeErr == nil {
+		writeErr = err
+	}
+	if err := tmp.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		return EntryV1{}, writeErr
+	}
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		return EntryV1{}, err
+	}
+	entry.Files = len(files)
+	entry.BytesAfter = info.Size()
+	entry.SHA256 = hex.EncodeToString(h.Sum(nil))
+	if err := os.Rename(tmpPath, filepath.Join(outRoot, filepath.FromSlash(entry.Path))); err != nil {
+		return EntryV1{}, err
+	}
+	if err := updateIndex(outRoot, func(idx *IndexV1) {
+		idx.Runs = append(removeEntry(idx.Runs, entry.RunID), entry)
+	}); err != nil {
+		return EntryV1{}, err
+	}
+	if err := os.RemoveAll(runDir); err != nil {
+		return EntryV1{}, err
+	}
+	return entry, nil
+}
+
+// collectFiles lists regular files relative to runDir, skipping the run's own lock dirs.
+func collectFiles(runDir string) ([]string, error) {
+	var out []string
+	err := filepath.WalkDir(runDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && p != runDir && strings.HasPrefix(d.Name(), ".run.") && strings.HasSuffix(d.Name(), ".lock") {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(runDir, p)
+		if err != nil {
+			return err
+		}
+		out = append(out, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(out)
+	return out, err
+}
+
+// ReadIndex returns an empty index when nothing was archived yet.
+func ReadIndex(outRoot string) (IndexV1, error) {
+	idx := IndexV1{SchemaVersion: IndexSchemaV1}
+	raw, err := os.ReadFile(filepath.Join(Dir(outRoot), artifacts.ArchiveIndexJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return idx, nil
+		}
+		return IndexV1{}, err
+	}
+	if err := json.Unmarshal(raw, &idx); err != nil {
+		return IndexV1{}, fmt.Errorf("%s: %w", artifacts.ArchiveIndexJSON, err)
+	}
+	return idx, nil
+}
+
+func updateIndex(outRoot string, fn func(idx *IndexV1)) error {
+	dir := Dir(outRoot)
+	return store.WithDirLock(filepath.Join(dir, ".index.lock"), indexLockWait, func() error {
+		idx, err := ReadIndex(outRoot)
+		if err != nil {
+			return err
+		}
+		fn(&idx)
+		sort.Slice(idx.Runs, func(i, j int) bool { return idx.Runs[i].RunID < idx.Runs[j].RunID })
+		return store.WriteJSONAtomic(filepath.Join(dir, artifacts.ArchiveIndexJSON), idx)
+	})
+}
+
+func removeEntry(in []EntryV1, runID string) []EntryV1 {
+	out := in[:0]
+	for _, e := range in {
+		if e.RunID != runID {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+type RestoreResult struct {
+	OK     bool   `json:"ok"`
+	RunID  string `json:"runId"`
+	RunDir string `json:"runDir"`
+	Files  int    `json:"files"`
+}
+
+// Restore extracts an archived run back into runs/<runId> after checking the archive digest, then
+// drops the archive and its index entry.
+func Restore(outRoot, runID string) (RestoreResult, error) {
+	if strings.TrimSpace(outRoot) == "" {
+		outRoot = ".zcl"
+	}
+	if !ids.IsValidRunID(runID) {
+		return RestoreResult{}, fmt.Errorf("invalid --run-id (expected format YYYYMMDD-HHMMSSZ-<hex6>)")
+	}
+	idx, err := ReadIndex(outRoot)
+	if err != nil {
+		return RestoreResult{}, err
+	}
+	var entry *EntryV1
+	for i := range idx.Runs {
+		if idx.Runs[i].RunID == runID {
+			entry = &idx.Runs[i]
+		}
+	}
+	if entry == nil {
+		return RestoreResult{}, fmt.Errorf("run %s is not archived", runID)
+	}
+	runDir := filepath.Join(outRoot, "runs", runID)
+	if _, err := os.Stat(runDir); err == nil {
+		return RestoreResult{}, fmt.Errorf("run dir %s already exists", runDir)
+	}
+	archivePath := filepath.Join(outRoot, filepath.FromSlash(entry.Path))
+	if sum, err := fileSHA256(archivePath); err != nil {
+		return RestoreResult{}, err
+	} else if sum != entry.SHA256 {
+		return RestoreResult{}, fmt.Errorf("%s does not match the archive index (sha256 mismatch)", entry.Path)
+	}
+
+	staging, err := os.MkdirTemp(filepath.Join(outRoot, "runs"), ".restore-"+runID+"-*")
+	if err != nil {
+		return RestoreResult{}, err
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+	n, err := extract(archivePath, entry.Compression, runID, staging)
+	if err != nil {
+		return RestoreResult{}, err
+	}
+	if err := os.Rename(staging, runDir); err != nil {
+		return RestoreResult{}, err
+	}
+	if err := updateIndex(outRoot, func(idx *IndexV1) { idx.Runs = removeEntry(idx.Runs, runID) }); err != nil {
+		return RestoreResult{}, err
+	}
+	if err := os.Remove(archivePath); err != nil && !os.IsNotExist(err) {
+		return RestoreResult{}, err
+	}
+	return RestoreResult{OK: true, RunID: runID, RunDir: runDir, Files: n}, nil
+}
+
+func extract(archivePath, compression, runID, dst string) (int, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+	src, err := bundle.NewSource(f, compression)
+	if err != nil {
+		return 0, err
+	}
+	n, err := extractTar(tar.NewReader(src), runID+"/", dst)
+	if cerr := src.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+func extractTar(tr *tar.Reader, prefix, dst string) (int, error) {
+	n := 0
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		rel, ok := strings.CutPrefix(hdr.Name, prefix)
+		if !ok || hdr.Typeflag != tar.TypeReg || !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return n, fmt.Errorf("unexpected archive entry %q", hdr.Name)
+		}
+		path := filepath.Join(dst, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return n, err
+		}
+		out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return n, err
+		}
+		_, err = io.Copy(out, tr)
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return n, err
+		}
+		_ = os.Chtimes(path, hdr.ModTime, hdr.ModTime)
+		n++
+	}
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func dirSize(root string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(root, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total, err
+}
@@ -0,0 +1,127 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/bundle"
+)
+
+const (
+	oldRunID    = "20260201-120000Z-0a0b0c"
+	pinnedRunID = "20260201-120001Z-0a0b0d"
+	liveRunID   = "20260201-120002Z-0a0b0e"
+	newRunID    = "20260228-120000Z-0a0b0f"
+)
+
+func writeRun(t *testing.T, outRoot, runID, createdAt string, pinned, finished bool) string {
+	t.Helper()
+	runDir := filepath.Join(outRoot, "runs", runID)
+	pin := "false"
+	if pinned {
+		pin = "true"
+	}
+	mustWrite(t, filepath.Join(runDir, "run.json"), `{"schemaVersion":1,"artifactLayoutVersion":1,"runId":"`+runID+`","suiteId":"s","createdAt":"`+createdAt+`","pinned":`+pin+`}`)
+	attemptDir := filepath.Join(runDir, "attempts", "001-m-r1")
+	mustWrite(t, filepath.Join(attemptDir, "feedback.json"), `{"ok":true}`)
+	mustWrite(t, filepath.Join(attemptDir, "runner.stdout.log"), "output\n")
+	if finished {
+		mustWrite(t, filepath.Join(attemptDir, "attempt.report.json"), `{}`)
+	}
+	return runDir
+}
+
+func mustWrite(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestRun_ArchivesOldCompletedRunsAndRestores(t *testing.T) {
+	outRoot := t.TempDir()
+	oldDir := writeRun(t, outRoot, oldRunID, "2026-02-01T12:00:00Z", false, true)
+	writeRun(t, outRoot, pinnedRunID, "2026-02-01T12:00:01Z", true, true)
+	writeRun(t, outRoot, liveRunID, "2026-02-01T12:00:02Z", false, false)
+	writeRun(t, outRoot, newRunID, "2026-02-28T12:00:00Z", false, true)
+
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	res, err := Run(Opts{OutRoot: outRoot, OlderThan: 14 * 24 * time.Hour, Compression: bundle.CompressionGzip, Now: now})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !res.OK || len(res.Archived) != 1 || res.Archived[0].RunID != oldRunID {
+		t.Fatalf("unexpected archived: %+v", res)
+	}
+	skipped := map[string]string{}
+	for _, s := range res.Skipped {
+		skipped[s.RunID] = s.Reason
+	}
+	if skipped[pinnedRunID] != SkippedPinned || skipped[liveRunID] != SkippedIncomplete || len(skipped) != 2 {
+		t.Fatalf("unexpected skipped: %+v", res.Skipped)
+	}
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Fatalf("archived run dir should be gone, got %v", err)
+	}
+	e := res.Archived[0]
+	if e.Path != "archive/"+oldRunID+".tar.gz" || e.Files != 4 || e.OKTotal != 1 || e.SHA256 == "" {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	idx, err := ReadIndex(outRoot)
+	if err != nil || len(idx.Runs) != 1 || idx.Runs[0].SHA256 != e.SHA256 {
+		t.Fatalf("unexpected index: %+v err=%v", idx, err)
+	}
+
+	// A second pass finds nothing new.
+	res, err = Run(Opts{OutRoot: outRoot, OlderThan: 14 * 24 * time.Hour, Compression: bundle.CompressionGzip, Now: now})
+	if err != nil || len(res.Archived) != 0 {
+		t.Fatalf("expected no-op, got %+v err=%v", res, err)
+	}
+
+	rr, err := Restore(outRoot, oldRunID)
+	if err != nil || rr.Files != 4 {
+		t.Fatalf("Restore: %+v err=%v", rr, err)
+	}
+	got, err := os.ReadFile(filepath.Join(oldDir, "attempts", "001-m-r1", "runner.stdout.log"))
+	if err != nil || string(got) != "output\n" {
+		t.Fatalf("restored file mismatch: %q err=%v", got, err)
+	}
+	if idx, _ := ReadIndex(outRoot); len(idx.Runs) != 0 {
+		t.Fatalf("restore should drop the index entry: %+v", idx)
+	}
+	if _, err := os.Stat(filepath.Join(outRoot, filepath.FromSlash(e.Path))); !os.IsNotExist(err) {
+		t.Fatalf("restore should remove the archive, got %v", err)
+	}
+}
+
+func TestRestore_RejectsTamperedArchive(t *testing.T) {
+	outRoot := t.TempDir()
+	writeRun(t, outRoot, oldRunID, "2026-02-01T12:00:00Z", false, true)
+	res, err := Run(Opts{OutRoot: outRoot, OlderThan: time.Hour, Compression: bundle.CompressionGzip, Now: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil || len(res.Archived) != 1 {
+		t.Fatalf("Run: %+v err=%v", res, err)
+	}
+	mustWrite(t, filepath.Join(outRoot, filepath.FromSlash(res.Archived[0].Path)), "garbage")
+	if _, err := Restore(outRoot, oldRunID); err == nil {
+		t.Fatalf("expected sha256 mismatch")
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	for in, want := range map[string]time.Duration{"14d": 14 * 24 * time.Hour, "36h": 36 * time.Hour, "0d": 0} {
+		got, err := ParseAge(in)
+		if err != nil || got != want {
+			t.Fatalf("ParseAge(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "d", "-1d", "2w"} {
+		if _, err := ParseAge(in); err == nil {
+			t.Fatalf("ParseAge(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package artifactsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const manifestSchemaV1 = 1
+
+// uploadTimeout bounds a single object upload through the cloud CLI.
+const uploadTimeout = 10 * time.Minute
+
+// ManifestV1 is written to <outRoot>/campaigns/<campaignId>/sync.manifest.json and uploaded last,
+// so a complete manifest at the destination means every listed object landed.
+type ManifestV1 struct {
+	SchemaVersion int      `json:"schemaVersion"`
+	CampaignID    string   `json:"campaignId"`
+	RunIDs        []string `json:"runIds,omitempty"`
+	Dest          string   `json:"dest"`
+	SyncedAt      string   `json:"syncedAt"`
+	Files         []FileV1 `json:"files"`
+}
+
+// FileV1 paths are relative to the out-root, using forward slashes (also the object key suffix).
+type FileV1 struct {
+	Path   string `json:"path"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+type Result struct {
+	OK           bool     `json:"ok"`
+	CampaignID   string   `json:"campaignId"`
+	Dest         string   `json:"dest"`
+	ManifestPath string   `json:"manifestPath"`
+	Files        int      `json:"files"`
+	Uploaded     int      `json:"uploaded"`
+	Unchanged    int      `json:"unchanged"`
+	Bytes        int64    `json:"bytes"`
+	MissingRuns  []string `json:"missingRuns,omitempty"`
+}
+
+// Uploader copies one local file to key below the destination prefix.
+type Uploader interface {
+	Upload(localPath, key string) error
+}
+
+type Opts struct {
+	OutRoot    string
+	CampaignID string
+	RunIDs     []string
+	Dest       string
+	Now        time.Time
+	// Uploader overrides the backend derived from Dest.
+	Uploader Uploader
+}
+
+// Run uploads the campaign directory and every referenced run directory to Dest. Files whose hash
+// matches the previous manifest for the same Dest are skipped.
+func Run(opts Opts) (Result, error) {
+	cid := ids.SanitizeComponent(strings.TrimSpace(opts.CampaignID))
+	if cid == "" {
+		return Result{}, fmt.Errorf("missing/invalid campaign id")
+	}
+	dest := strings.TrimRight(strings.TrimSpace(opts.Dest), "/")
+	if err := config.ValidateSyncDest(dest); err != nil {
+		return Result{}, err
+	}
+	up := opts.Uploader
+	if up == nil {
+		var err error
+		if up, err = NewUploader(dest); err != nil {
+			return Result{}, err
+		}
+	}
+	campaignDir := filepath.Join(opts.OutRoot, "campaigns", cid)
+	if fi, err := os.Stat(campaignDir); err != nil || !fi.IsDir() {
+		return Result{}, fmt.Errorf("campaign dir not found: %s", campaignDir)
+	}
+	manifestPath := filepath.Join(campaignDir, artifacts.SyncManifestJSON)
+	prev := loadPreviousHashes(manifestPath, dest)
+
+	res := Result{OK: true, CampaignID: cid, Dest: dest, ManifestPath: manifestPath}
+	roots := []string{campaignDir}
+	runIDs := normalizeRunIDs(opts.RunIDs)
+	for _, runID := range runIDs {
+		dir := filepath.Join(opts.OutRoot, "runs", runID)
+		if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+			res.MissingRuns = append(res.MissingRuns, runID)
+			continue
+		}
+		roots = append(roots, dir)
+	}
+	files, err := CollectFiles(opts.OutRoot, roots, artifacts.SyncManifestJSON, CampaignLockName)
+	if err != nil {
+		return Result{}, err
+	}
+	for _, f := range files {
+		res.Files++
+		if prev[f.Path] == f.SHA256 {
+			res.Unchanged++
+			continue
+		}
+		if err := up.Upload(filepath.Join(opts.OutRoot, filepath.FromSlash(f.Path)), f.Path); err != nil {
+			return Result{}, fmt.Errorf("upload %s: %w", f.Path, err)
+		}
+		res.Uploaded++
+		res.Bytes += f.Bytes
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	m := ManifestV1{
+		SchemaVersion: manifestSchemaV1,
+		CampaignID:    cid,
+		RunIDs:        runIDs,
+		Dest:          dest,
+		SyncedAt:      now.UTC().Format(time.RFC3339Nano),
+		Files:         files,
+	}
+	if err := store.WriteJSONAtomic(manifestPath, m); err != nil {
+		return Result{}, err
+	}
+	manifestRel, err := relSlash(opts.OutRoot, manifestPath)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := up.Upload(manifestPath, manifestRel); err != nil {
+		return Result{}, fmt.Errorf("upload %s: %w", manifestRel, err)
+	}
+	return res, nil
+}
+
+func normalizeRunIDs(in []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, id := range in {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] || !ids.IsValidRunID(id) {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// CampaignLockName is host-local and never leaves the out-root.
+const CampaignLockName = "campaign.lock"
+
+// CollectFiles hashes regular files under roots, skipping files named in exclude. Sync excludes its
+// own manifest (uploaded last) and the campaign lock.
+func CollectFiles(outRoot string, roots []string, exclude ...string) ([]FileV1, error) {
+	skip := map[string]bool{}
+	for _, name := range exclude {
+		skip[name] = true
+	}
+	var out []FileV1
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			if skip[d.Name()] {
+				return nil
+			}
+			rel, err := relSlash(outRoot, p)
+			if err != nil {
+				return err
+			}
+			sum, n, err := hashFile(p)
+			if err != nil {
+				return err
+			}
+			out = append(out, FileV1{Path: rel, Bytes: n, SHA256: sum})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+func relSlash(base, p string) (string, error) {
+	rel, err := filepath.Rel(base, p)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func hashFile(p string) (string, int64, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+func loadPreviousHashes(manifestPath, dest string) map[string]string {
+	out := map[string]string{}
+	raw, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return out
+	}
+	var m ManifestV1
+	if json.Unmarshal(raw, &m) != nil || m.SchemaVersion != manifestSchemaV1 || m.Dest != dest {
+		return out
+	}
+	for _, f := range m.Files {
+		out[f.Path] = f.SHA256
+	}
+	return out
+}
+
+// NewUploader picks the backend for dest: file:// copies locally (shared mounts), s3:// shells out
+// to the aws CLI and gs:// to the gcloud CLI, so credentials follow the operator's normal setup.
+func NewUploader(dest string) (Uploader, error) {
+	switch {
+	case strings.HasPrefix(dest, "file://"):
+		return fileUploader{root: strings.TrimPrefix(dest, "file://")}, nil
+	case strings.HasPrefix(dest, "s3://"):
+		return newCommandUploader(dest, "aws", func(local, remote string) []string {
+			return []string{"s3", "cp", "--only-show-errors", local, remote}
+		})
+	case strings.HasPrefix(dest, "gs://"):
+		return newCommandUploader(dest, "gcloud", func(local, remote string) []string {
+			return []string{"storage", "cp", "--quiet", local, remote}
+		})
+	}
+	return nil, config.ValidateSyncDest(dest)
+}
+
+type fileUploader struct {
+	root string
+}
+
+func (u fileUploader) Upload(localPath, key string) error {
+	dst := filepath.Join(u.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	src, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	tmp := dst + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+type commandUploader struct {
+	dest string
+	bin  string
+	args func(local, remote string) []string
+}
+
+func newCommandUploader(dest, name string, args func(local, remote string) []string) (Uploader, error) {
+	bin, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s requires the %s CLI on PATH", dest, name)
+	}
+	return commandUploader{dest: dest, bin: bin, args: args}, nil
+}
+
+func (u commandUploader) Upload(localPath, key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+	remote := u.dest + "/" + path.Clean(key)
+	out, err := exec.CommandContext(ctx, u.bin, u.args(localPath, remote)...).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if len(msg) > 512 {
+			msg = msg[:512]
+		}
+		return fmt.Errorf("%s: %w: %s", filepath.Base(u.bin), err, msg)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package artifactsync
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRun_FileDestUploadsCampaignAndRunsIncrementally(t *testing.T) {
+	outRoot := t.TempDir()
+	destRoot := t.TempDir()
+	const runID = "20260222-120000Z-aaaaaa"
+	write := func(rel, content string) {
+		p := filepath.Join(outRoot, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	write("campaigns/cmp/campaign.run.state.json", `{}`)
+	write("campaigns/cmp/campaign.lock", `lock`)
+	write("runs/"+runID+"/run.json", `{}`)
+	write("runs/"+runID+"/attempts/001-m1-r1/feedback.json", `{"ok":true}`)
+	write("runs/20260222-120000Z-bbbbbb/run.json", `{}`)
+
+	opts := Opts{
+		OutRoot:    outRoot,
+		CampaignID: "cmp",
+		RunIDs:     []string{runID, "20260222-120000Z-cccccc", runID},
+		Dest:       "file://" + destRoot + "/team/",
+		Now:        time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC),
+	}
+	res, err := Run(opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Files != 3 || res.Uploaded != 3 || res.Unchanged != 0 || len(res.MissingRuns) != 1 {
+		t.Fatalf("unexpected first sync: %+v", res)
+	}
+	for _, rel := range []string{"campaigns/cmp/campaign.run.state.json", "runs/" + runID + "/attempts/001-m1-r1/feedback.json", "campaigns/cmp/sync.manifest.json"} {
+		if _, err := os.Stat(filepath.Join(destRoot, "team", filepath.FromSlash(rel))); err != nil {
+			t.Fatalf("expected %s at dest: %v", rel, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(destRoot, "team", "campaigns", "cmp", "campaign.lock")); err == nil {
+		t.Fatalf("campaign.lock must not be synced")
+	}
+	if _, err := os.Stat(filepath.Join(destRoot, "team", "runs", "20260222-120000Z-bbbbbb")); err == nil {
+		t.Fatalf("unreferenced runs must not be synced")
+	}
+	raw, err := os.ReadFile(res.ManifestPath)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var m ManifestV1
+	if err := json.Unmarshal(raw, &m); err != nil || len(m.Files) != 3 || m.Files[0].SHA256 == "" {
+		t.Fatalf("unexpected manifest: err=%v %+v", err, m)
+	}
+
+	write("runs/"+runID+"/attempts/001-m1-r1/feedback.json", `{"ok":false}`)
+	res, err = Run(opts)
+	if err != nil {
+		t.Fatalf("Run (second): %v", err)
+	}
+	if res.Uploaded != 1 || res.Unchanged != 2 {
+		t.Fatalf("expected only the changed file to upload, got %+v", res)
+	}
+}
+
+func TestRun_RejectsUnsupportedDest(t *testing.T) {
+	if _, err := Run(Opts{OutRoot: t.TempDir(), CampaignID: "cmp", Dest: "ftp://host/x"}); err == nil {
+		t.Fatalf("expected unsupported dest error")
+	}
+	if _, err := Run(Opts{OutRoot: t.TempDir(), CampaignID: "cmp", Dest: "s3://"}); err == nil {
+		t.Fatalf("expected missing bucket error")
+	}
+}
@@ -0,0 +1,338 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/artifactsync"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+const ManifestSchemaV1 = 1
+
+const (
+	CompressionZstd = "zstd"
+	CompressionGzip = "gzip"
+	CompressionNone = "none"
+)
+
+// Bundle layout: attempt/<file> for everything in the attempt dir, run/<file> for the run-level
+// inputs needed to reproduce it, and the manifest as the last entry.
+const (
+	attemptPrefix = "attempt/"
+	runPrefix     = "run/"
+)
+
+// runContextFiles are copied from the parent run dir when present.
+var runContextFiles = []string{artifacts.RunJSON, artifacts.SuiteJSON}
+
+// ManifestV1 is stored as attempt.bundle.manifest.json inside the archive. File paths are archive
+// entry names; sha256/bytes describe the bytes as stored (encrypted .enc files stay sealed).
+type ManifestV1 struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	RunID         string                `json:"runId"`
+	SuiteID       string                `json:"suiteId"`
+	MissionID     string                `json:"missionId"`
+	AttemptID     string                `json:"attemptId"`
+	ZCLVersion    string                `json:"zclVersion,omitempty"`
+	ExportedAt    string                `json:"exportedAt"`
+	Files         []artifactsync.FileV1 `json:"files"`
+}
+
+type Opts struct {
+	AttemptDir string
+	OutPath    string
+	ZCLVersion string
+	Now        time.Time
+}
+
+type Result struct {
+	OK          bool   `json:"ok"`
+	AttemptDir  string `json:"attemptDir"`
+	AttemptID   string `json:"attemptId"`
+	OutPath     string `json:"outPath"`
+	Compression string `json:"compression"`
+	Files       int    `json:"files"`
+	Bytes       int64  `json:"bytes"`
+}
+
+// CompressionFor picks the archive compression from the output extension.
+func CompressionFor(outPath string) (string, error) {
+	lower := strings.ToLower(outPath)
+	switch {
+	case strings.HasSuffix(lower, ".tar.zst"), strings.HasSuffix(lower, ".tzst"):
+		return CompressionZstd, nil
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return CompressionGzip, nil
+	case strings.HasSuffix(lower, ".tar"):
+		return CompressionNone, nil
+	default:
+		return "", fmt.Errorf("unsupported bundle extension %q (want .tar.zst, .tar.gz or .tar)", filepath.Base(outPath))
+	}
+}
+
+// Export writes the attempt's artifacts, its run.json/suite.json and a manifest into one archive.
+// The archive is written to a temp file next to OutPath and renamed into place.
+func Export(opts Opts) (Result, error) {
+	attemptDir := filepath.Clean(opts.AttemptDir)
+	compression, err := CompressionFor(opts.OutPath)
+	if err != nil {
+		return Result{}, err
+	}
+	var a schema.AttemptJSONV1
+	raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.AttemptJSON))
+	if err != nil {
+		return Result{}, err
+	}
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return Result{}, fmt.Errorf("%s: %w", artifacts.AttemptJSON, err)
+	}
+	entries, err := collectEntries(attemptDir)
+	if err != nil {
+		return Result{}, err
+	}
+
+	outAbs, err := filepath.Abs(opts.OutPath)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(outAbs), 0o755); err != nil {
+		return Result{}, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(outAbs), ".zcl-bundle-*")
+	if err != nil {
+		return Result{}, err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	sink, err := NewSink(tmp, compression)
+	if err != nil {
+		_ = tmp.Close()
+		return Result{}, err
+	}
+	manifest := ManifestV1{
+		SchemaVersion: ManifestSchemaV1,
+		RunID:         a.RunID,
+		SuiteID:       a.SuiteID,
+		MissionID:     a.MissionID,
+		AttemptID:     a.AttemptID,
+		ZCLVersion:    opts.ZCLVersion,
+		ExportedAt:    opts.Now.UTC().Format(time.RFC3339Nano),
+	}
+	tw := tar.NewWriter(sink)
+	writeErr := func() error {
+		for _, e := range entries {
+			f, err := WriteFileEntry(tw, e.name, e.path)
+			if err != nil {
+				return err
+			}
+			manifest.Files = append(manifest.Files, f)
+		}
+		b, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return err
+		}
+		b = append(b, '\n')
+		if err := tw.WriteHeader(&tar.Header{Name: artifacts.AttemptBundleManifestJSON, Mode: 0o644, Size: int64(len(b)), ModTime: opts.Now.UTC(), Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		_, err = tw.Write(b)
+		return err
+	}()
+	if writeErr == nil {
+		writeErr = tw.Close()
+	}
+	if err := sink.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if err := tmp.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		return Result{}, writeErr
+	}
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := os.Rename(tmpPath, outAbs); err != nil {
+		return Result{}, err
+	}
+	return Result{
+		OK:          true,
+		AttemptDir:  attemptDir,
+		AttemptID:   a.AttemptID,
+		OutPath:     outAbs,
+		Compression: compression,
+		Files:       len(manifest.Files),
+		Bytes:       info.Size(),
+	}, nil
+}
+
+type entry struct {
+	name string
+	path string
+}
+
+func collectEntries(attemptDir string) ([]entry, error) {
+	var out []entry
+	err := filepath.WalkDir(attemptDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(attemptDir, p)
+		if err != nil {
+			return err
+		}
+		out = append(out, entry{name: attemptPrefix + filepath.ToSlash(rel), path: p})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	// <runDir>/attempts/<attemptId>
+	runDir := filepath.Dir(filepath.Dir(attemptDir))
+	for _, name := range runContextFiles {
+		p := filepath.Join(runDir, name)
+		if info, err := os.Stat(p); err == nil && info.Mode().IsRegular() {
+			out = append(out, entry{name: runPrefix + name, path: p})
+		}
+	}
+	return out, nil
+}
+
+// WriteFileEntry copies path into tw as a regular file named name and returns its digest.
+func WriteFileEntry(tw *tar.Writer, name, path string) (artifactsync.FileV1, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return artifactsync.FileV1{}, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return artifactsync.FileV1{}, err
+	}
+	hdr := &tar.Header{Name: name, Mode: 0o644, Size: info.Size(), ModTime: info.ModTime().UTC(), Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return artifactsync.FileV1{}, err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tw, h), f)
+	if err != nil {
+		return artifactsync.FileV1{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return artifactsync.FileV1{Path: name, Bytes: n, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// NewSink wraps dst with the requested compression. zstd is not in the standard library, so it is
+// piped through the zstd CLI (same approach as sync uses for cloud CLIs).
+func NewSink(dst io.Writer, compression string) (io.WriteCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewWriter(dst), nil
+	case CompressionNone:
+		return nopCloser{dst}, nil
+	case CompressionZstd:
+		bin, err := exec.LookPath("zstd")
+		if err != nil {
+			return nil, fmt.Errorf(".tar.zst requires the zstd CLI on PATH (or use --out <file>.tar.gz)")
+		}
+		cmd := exec.Command(bin, "-q", "-c", "-")
+		cmd.Stdout = dst
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		in, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		return &cmdSink{in: in, cmd: cmd, stderr: &stderr}, nil
+	default:
+		return nil, fmt.Errorf("unknown compression %q", compression)
+	}
+}
+
+// NewSource is the reading side of NewSink.
+func NewSource(src io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(src)
+	case CompressionNone:
+		return io.NopCloser(src), nil
+	case CompressionZstd:
+		bin, err := exec.LookPath("zstd")
+		if err != nil {
+			return nil, fmt.Errorf(".tar.zst requires the zstd CLI on PATH")
+		}
+		cmd := exec.Command(bin, "-q", "-d", "-c", "-")
+		cmd.Stdin = src
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		out, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		return &cmdSource{out: out, cmd: cmd, stderr: &stderr}, nil
+	default:
+		return nil, fmt.Errorf("unknown compression %q", compression)
+	}
+}
+
+type cmdSource struct {
+	out    io.ReadCloser
+	cmd    *exec.Cmd
+	stderr *strings.Builder
+}
+
+func (s *cmdSource) Read(p []byte) (int, error) { return s.out.Read(p) }
+
+// Close drains the decompressor so a truncated archive surfaces as an error.
+func (s *cmdSource) Close() error {
+	_, _ = io.Copy(io.Discard, s.out)
+	if err := s.cmd.Wait(); err != nil {
+		return fmt.Errorf("zstd: %w: %s", err, strings.TrimSpace(s.stderr.String()))
+	}
+	return nil
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+type cmdSink struct {
+	in     io.WriteCloser
+	cmd    *exec.Cmd
+	stderr *strings.Builder
+}
+
+func (s *cmdSink) Write(p []byte) (int, error) { return s.in.Write(p) }
+
+func (s *cmdSink) Close() error {
+	cerr := s.in.Close()
+	if err := s.cmd.Wait(); err != nil {
+		return fmt.Errorf("zstd: %w: %s", err, strings.TrimSpace(s.stderr.String()))
+	}
+	return cerr
+}
@@ -0,0 +1,92 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExport_TarGzHasAttemptRunContextAndManifest(t *testing.T) {
+	root := t.TempDir()
+	runDir := filepath.Join(root, "runs", "20260222-120000Z-a1b2c3")
+	attemptDir := filepath.Join(runDir, "attempts", "001-m1-r1")
+	write := func(path, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	write(filepath.Join(attemptDir, "attempt.json"), `{"schemaVersion":1,"runId":"20260222-120000Z-a1b2c3","suiteId":"s","missionId":"m1","attemptId":"001-m1-r1"}`)
+	write(filepath.Join(attemptDir, "tool.calls.jsonl"), "{}\n")
+	write(filepath.Join(attemptDir, "captures", "c1.stdout.log"), "out")
+	write(filepath.Join(runDir, "suite.json"), `{"suiteId":"s"}`)
+	write(filepath.Join(runDir, "attempts", "002-m2-r1", "attempt.json"), `{}`)
+
+	out := filepath.Join(t.TempDir(), "attempt.tar.gz")
+	res, err := Export(Opts{AttemptDir: attemptDir, OutPath: out, ZCLVersion: "1.2.3", Now: time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if res.Compression != CompressionGzip || res.Files != 4 || res.AttemptID != "001-m1-r1" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	sums := map[string]string{}
+	var manifest ManifestV1
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		b, _ := io.ReadAll(tr)
+		if hdr.Name == "attempt.bundle.manifest.json" {
+			if err := json.Unmarshal(b, &manifest); err != nil {
+				t.Fatalf("manifest: %v", err)
+			}
+			continue
+		}
+		sum := sha256.Sum256(b)
+		sums[hdr.Name] = hex.EncodeToString(sum[:])
+	}
+	if manifest.AttemptID != "001-m1-r1" || manifest.ZCLVersion != "1.2.3" || len(manifest.Files) != 4 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	for _, fe := range manifest.Files {
+		if sums[fe.Path] != fe.SHA256 {
+			t.Fatalf("manifest digest mismatch for %s", fe.Path)
+		}
+	}
+	if _, ok := sums["run/suite.json"]; !ok {
+		t.Fatalf("expected run context in bundle, got %v", sums)
+	}
+	if _, ok := sums["attempt/captures/c1.stdout.log"]; !ok {
+		t.Fatalf("expected nested capture in bundle, got %v", sums)
+	}
+
+	if _, err := Export(Opts{AttemptDir: attemptDir, OutPath: filepath.Join(t.TempDir(), "attempt.zip")}); err == nil {
+		t.Fatalf("expected unsupported extension to fail")
+	}
+}
@@ -0,0 +1,286 @@
+package compact
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const (
+	ActionCompressed = "compressed"
+	ActionDropped    = "dropped"
+)
+
+// compressedAttemptFiles are gzip-compressed in place; readers resolve the .gz sibling transparently.
+var compressedAttemptFiles = []string{
+	"runner.stdout.log",
+	"runner.stderr.log",
+	artifacts.ToolCallsJSONL,
+}
+
+// FileV1 paths are relative to the run directory.
+type FileV1 struct {
+	Path        string `json:"path"`
+	Action      string `json:"action"`
+	BytesBefore int64  `json:"bytesBefore"`
+	BytesAfter  int64  `json:"bytesAfter"`
+}
+
+type Result struct {
+	OK          bool     `json:"ok"`
+	RunID       string   `json:"runId"`
+	RunDir      string   `json:"runDir"`
+	CompactedAt string   `json:"compactedAt"`
+	Attempts    int      `json:"attempts"`
+	Files       []FileV1 `json:"files,omitempty"`
+	// Skipped lists attempts without attempt.report.json; they may still be running and are left untouched.
+	Skipped     []string `json:"skipped,omitempty"`
+	BytesBefore int64    `json:"bytesBefore"`
+	BytesAfter  int64    `json:"bytesAfter"`
+	BytesFreed  int64    `json:"bytesFreed"`
+}
+
+type Opts struct {
+	OutRoot string
+	RunID   string
+	Now     time.Time
+}
+
+// Run compacts a finished run for long-term evidence: runner logs, traces and captures are
+// gzip-compressed, raw captures whose redacted output is fully preserved in the trace are dropped,
+// captures.jsonl is rewritten to the new paths and run.json records compactedAt. Re-running is a no-op
+// for files that are already compressed.
+func Run(opts Opts) (Result, error) {
+	outRoot := strings.TrimSpace(opts.OutRoot)
+	if outRoot == "" {
+		outRoot = ".zcl"
+	}
+	runID := strings.TrimSpace(opts.RunID)
+	if !ids.IsValidRunID(runID) {
+		return Result{}, fmt.Errorf("invalid --run-id (expected format YYYYMMDD-HHMMSSZ-<hex6>)")
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	runDir := filepath.Join(outRoot, "runs", runID)
+	runJSONPath := filepath.Join(runDir, artifacts.RunJSON)
+	raw, err := os.ReadFile(runJSONPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Result{}, fmt.Errorf("missing run.json for runId=%s", runID)
+		}
+		return Result{}, err
+	}
+	var meta schema.RunJSONV1
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return Result{}, fmt.Errorf("invalid run.json: %w", err)
+	}
+	if meta.RunID != runID {
+		return Result{}, fmt.Errorf("run.json mismatch: expected runId=%s", runID)
+	}
+
+	res := Result{OK: true, RunID: runID, RunDir: runDir}
+	entries, err := os.ReadDir(filepath.Join(runDir, "attempts"))
+	if err != nil && !os.IsNotExist(err) {
+		return Result{}, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		attemptDir := filepath.Join(runDir, "attempts", e.Name())
+		if _, err := os.Stat(filepath.Join(attemptDir, artifacts.AttemptReportJSON)); err != nil {
+			res.Skipped = append(res.Skipped, e.Name())
+			continue
+		}
+		res.Attempts++
+		if err := compactAttempt(runDir, attemptDir, &res); err != nil {
+			return res, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+	}
+	sort.SliceStable(res.Files, func(i, j int) bool { return res.Files[i].Path < res.Files[j].Path })
+	for _, f := range res.Files {
+		res.BytesBefore += f.BytesBefore
+		res.BytesAfter += f.BytesAfter
+	}
+	res.BytesFreed = res.BytesBefore - res.BytesAfter
+
+	meta.CompactedAt = now.UTC().Format(time.RFC3339Nano)
+	if err := store.WriteJSONAtomic(runJSONPath, meta); err != nil {
+		return res, err
+	}
+	res.CompactedAt = meta.CompactedAt
+	return res, nil
+}
+
+func compactAttempt(runDir, attemptDir string, res *Result) error {
+	covered, err := capturesCoveredByTrace(attemptDir)
+	if err != nil {
+		return err
+	}
+	if err := compactCaptures(runDir, attemptDir, covered, res); err != nil {
+		return err
+	}
+	for _, name := range compressedAttemptFiles {
+		if err := gzipInto(runDir, filepath.Join(attemptDir, name), res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// capturesCoveredByTrace returns capture paths whose trace event carries the complete (untruncated)
+// redacted stdout/stderr preview, i.e. a redacted copy of the captured bytes already exists.
+func capturesCoveredByTrace(attemptDir string) (map[string]bool, error) {
+	f, err := store.OpenArtifact(filepath.Join(attemptDir, artifacts.ToolCallsJSONL))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	covered := map[string]bool{}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
+	for sc.Scan() {
+		var ev schema.TraceEventV1
+		if json.Unmarshal(sc.Bytes(), &ev) != nil || len(ev.Enrichment) == 0 {
+			continue
+		}
+		if ev.Integrity != nil && ev.Integrity.Truncated {
+			continue
+		}
+		var enr struct {
+			Capture struct {
+				StdoutPath string `json:"stdoutPath"`
+				StderrPath string `json:"stderrPath"`
+			} `json:"capture"`
+		}
+		if json.Unmarshal(ev.Enrichment, &enr) != nil {
+			continue
+		}
+		for _, p := range []string{enr.Capture.StdoutPath, enr.Capture.StderrPath} {
+			if p = strings.TrimSpace(p); p != "" {
+				covered[filepath.ToSlash(p)] = true
+			}
+		}
+	}
+	return covered, sc.Err()
+}
+
+// compactCaptures compresses or drops every file referenced by captures.jsonl and rewrites the index.
+func compactCaptures(runDir, attemptDir string, covered map[string]bool, res *Result) error {
+	indexPath := filepath.Join(attemptDir, artifacts.CapturesJSONL)
+	raw, err := os.ReadFile(indexPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var out bytes.Buffer
+	changed := false
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var ev schema.CaptureEventV1
+		if err := json.Unmarshal(line, &ev); err != nil {
+			// Keep lines we cannot interpret verbatim; validate reports them.
+			out.Write(line)
+			out.WriteByte('\n')
+			continue
+		}
+		for _, s := range []struct {
+			path    *string
+			dropped *bool
+		}{{&ev.StdoutPath, &ev.StdoutDropped}, {&ev.StderrPath, &ev.StderrDropped}} {
+			c, err := compactCaptureFile(runDir, attemptDir, s.path, s.dropped, !ev.Redacted && covered[filepath.ToSlash(*s.path)], res)
+			if err != nil {
+				return err
+			}
+			changed = changed || c
+		}
+		b, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		out.Write(b)
+		out.WriteByte('\n')
+	}
+	if !changed {
+		return nil
+	}
+	return store.WriteFileAtomic(indexPath, out.Bytes())
+}
+
+func compactCaptureFile(runDir, attemptDir string, rel *string, dropped *bool, drop bool, res *Result) (bool, error) {
+	r := strings.TrimSpace(*rel)
+	if r == "" || strings.HasSuffix(r, store.GzipSuffix) || strings.HasSuffix(r, store.EncryptedSuffix) || filepath.IsAbs(r) || strings.Contains(r, "..") {
+		return false, nil
+	}
+	abs := filepath.Join(attemptDir, r)
+	info, err := os.Stat(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// An interrupted compaction may already have produced the .gz copy.
+			if _, gzErr := os.Stat(abs + store.GzipSuffix); gzErr == nil {
+				*rel = r + store.GzipSuffix
+				return true, nil
+			}
+			return false, nil
+		}
+		return false, err
+	}
+	if drop {
+		if err := os.Remove(abs); err != nil {
+			return false, err
+		}
+		res.Files = append(res.Files, FileV1{Path: relToRun(runDir, abs), Action: ActionDropped, BytesBefore: info.Size()})
+		*rel = ""
+		*dropped = true
+		return true, nil
+	}
+	if err := gzipInto(runDir, abs, res); err != nil {
+		return false, err
+	}
+	*rel = r + store.GzipSuffix
+	return true, nil
+}
+
+func gzipInto(runDir, path string, res *Result) error {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	before, after, err := store.GzipFile(path)
+	if err != nil {
+		return err
+	}
+	res.Files = append(res.Files, FileV1{Path: relToRun(runDir, path) + store.GzipSuffix, Action: ActionCompressed, BytesBefore: before, BytesAfter: after})
+	return nil
+}
+
+func relToRun(runDir, path string) string {
+	rel, err := filepath.Rel(runDir, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,109 @@
+package compact
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const testRunID = "20260215-180012Z-09c5a6"
+
+func TestRun_CompressesLogsAndDropsCoveredRawCaptures(t *testing.T) {
+	outRoot := t.TempDir()
+	runDir := filepath.Join(outRoot, "runs", testRunID)
+	attemptDir := filepath.Join(runDir, "attempts", "001-m-r1")
+	mustWrite(t, filepath.Join(runDir, "run.json"), `{"schemaVersion":1,"artifactLayoutVersion":1,"runId":"`+testRunID+`","suiteId":"s","createdAt":"2026-02-15T18:00:12Z"}`)
+	mustWrite(t, filepath.Join(attemptDir, "attempt.report.json"), `{}`)
+	stdoutLog := strings.Repeat("runner output line\n", 200)
+	mustWrite(t, filepath.Join(attemptDir, "runner.stdout.log"), stdoutLog)
+	mustWrite(t, filepath.Join(attemptDir, "tool.calls.jsonl"),
+		`{"v":1,"tool":"cli","op":"exec","enrichment":{"capture":{"stdoutPath":"captures/cli/1.stdout.log"}},"integrity":{}}`+"\n"+
+			`{"v":1,"tool":"cli","op":"exec","enrichment":{"capture":{"stdoutPath":"captures/cli/2.stdout.log"}},"integrity":{"truncated":true}}`+"\n")
+	mustWrite(t, filepath.Join(attemptDir, "captures", "cli", "1.stdout.log"), "raw one")
+	mustWrite(t, filepath.Join(attemptDir, "captures", "cli", "2.stdout.log"), "raw two, too large for the preview")
+	mustWrite(t, filepath.Join(attemptDir, "captures.jsonl"),
+		`{"v":1,"ts":"2026-02-15T18:00:41Z","runId":"`+testRunID+`","missionId":"m","attemptId":"001-m-r1","tool":"cli","op":"exec","stdoutPath":"captures/cli/1.stdout.log","maxBytes":100}`+"\n"+
+			`{"v":1,"ts":"2026-02-15T18:00:42Z","runId":"`+testRunID+`","missionId":"m","attemptId":"001-m-r1","tool":"cli","op":"exec","stdoutPath":"captures/cli/2.stdout.log","maxBytes":100}`+"\n")
+	// No attempt.report.json: still running, must be left alone.
+	mustWrite(t, filepath.Join(runDir, "attempts", "002-m-r1", "runner.stdout.log"), "live")
+
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	res, err := Run(Opts{OutRoot: outRoot, RunID: testRunID, Now: now})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if res.Attempts != 1 || len(res.Skipped) != 1 || res.Skipped[0] != "002-m-r1" {
+		t.Fatalf("unexpected attempts/skipped: %+v", res)
+	}
+	if res.BytesFreed <= 0 {
+		t.Fatalf("expected reclaimed bytes, got %+v", res)
+	}
+
+	for _, name := range []string{"runner.stdout.log", "tool.calls.jsonl"} {
+		if _, err := os.Stat(filepath.Join(attemptDir, name)); !os.IsNotExist(err) {
+			t.Fatalf("%s should have been replaced by its .gz copy", name)
+		}
+	}
+	got, err := store.ReadArtifactFile(filepath.Join(attemptDir, "runner.stdout.log"))
+	if err != nil || string(got) != stdoutLog {
+		t.Fatalf("compressed runner log does not round-trip: %v", err)
+	}
+	if nonEmpty, err := store.JSONLHasNonEmptyLine(filepath.Join(attemptDir, "tool.calls.jsonl")); err != nil || !nonEmpty {
+		t.Fatalf("compressed trace must stay readable: nonEmpty=%v err=%v", nonEmpty, err)
+	}
+	if _, err := os.Stat(filepath.Join(attemptDir, "captures", "cli", "1.stdout.log")); !os.IsNotExist(err) {
+		t.Fatalf("raw capture covered by an untruncated trace preview should be dropped")
+	}
+	if _, err := os.Stat(filepath.Join(attemptDir, "captures", "cli", "2.stdout.log.gz")); err != nil {
+		t.Fatalf("raw capture with truncated preview must be kept compressed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(runDir, "attempts", "002-m-r1", "runner.stdout.log")); err != nil {
+		t.Fatalf("unfinished attempt was touched: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(attemptDir, "captures.jsonl"))
+	if err != nil {
+		t.Fatalf("read captures.jsonl: %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(raw), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 index lines, got %d", len(lines))
+	}
+	var first, second schema.CaptureEventV1
+	_ = json.Unmarshal(lines[0], &first)
+	_ = json.Unmarshal(lines[1], &second)
+	if first.StdoutPath != "" || !first.StdoutDropped {
+		t.Fatalf("dropped capture index entry not rewritten: %+v", first)
+	}
+	if second.StdoutPath != "captures/cli/2.stdout.log.gz" || second.StdoutDropped {
+		t.Fatalf("compressed capture index entry not rewritten: %+v", second)
+	}
+
+	var meta schema.RunJSONV1
+	b, _ := os.ReadFile(filepath.Join(runDir, "run.json"))
+	if err := json.Unmarshal(b, &meta); err != nil || meta.CompactedAt != "2026-03-01T00:00:00Z" {
+		t.Fatalf("run.json compactedAt not recorded: %+v err=%v", meta, err)
+	}
+
+	again, err := Run(Opts{OutRoot: outRoot, RunID: testRunID, Now: now})
+	if err != nil || len(again.Files) != 0 {
+		t.Fatalf("second compaction should be a no-op, got %+v err=%v", again, err)
+	}
+}
+
+func mustWrite(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
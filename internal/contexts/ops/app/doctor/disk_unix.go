@@ -0,0 +1,14 @@
+//go:build !windows
+
+package doctor
+
+import "syscall"
+
+// freeBytes is the space available to unprivileged writers on the filesystem holding path.
+func freeBytes(path string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
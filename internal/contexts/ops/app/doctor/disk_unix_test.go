@@ -0,0 +1,16 @@
+//go:build !windows
+
+package doctor
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckDiskSpace_FailsWithTheStatfsError(t *testing.T) {
+	c := checkDiskSpace(filepath.Join(t.TempDir(), "missing"))
+	if c.OK || !strings.Contains(c.Message, "no such file or directory") {
+		t.Fatalf("expected failing disk_space with the statfs error, got %+v", c)
+	}
+}
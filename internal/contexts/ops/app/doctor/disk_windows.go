@@ -0,0 +1,10 @@
+//go:build windows
+
+package doctor
+
+// Free space is not probed on Windows to avoid extra platform dependencies; disk_space passes
+// with a note.
+func freeBytes(path string) (uint64, error) {
+	_ = path
+	return 0, errFreeSpaceNotMeasurable
+}
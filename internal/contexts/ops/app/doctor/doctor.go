@@ -2,14 +2,29 @@ package doctor
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
 )
 
+const (
+	// MinFreeBytes is the free space under the out-root below which disk_space fails.
+	MinFreeBytes = 1 << 30
+	// MaxClockSkew is how far a file's mtime on the out-root may drift from the local clock.
+	// Stale lock breaking and heartbeat staleness compare the two.
+	MaxClockSkew = 5 * time.Second
+)
+
+// errFreeSpaceNotMeasurable is returned by freeBytes on platforms without a free-space probe.
+var errFreeSpaceNotMeasurable = errors.New("free space not measurable on this platform")
+
 type Check struct {
 	ID      string `json:"id"`
 	OK      bool   `json:"ok"`
@@ -19,6 +34,7 @@ type Check struct {
 type Result struct {
 	OK      bool    `json:"ok"`
 	OutRoot string  `json:"outRoot"`
+	Profile string  `json:"profile,omitempty"`
 	Checks  []Check `json:"checks"`
 }
 
@@ -27,18 +43,23 @@ type Opts struct {
 	NativeRuntimes []native.Runtime
 }
 
+// binaries zcl shells out to. Required ones fail the doctor when missing; the others only
+// matter for the feature named in why.
+var binaries = []struct {
+	name     string
+	required bool
+	why      string
+}{
+	{name: "sh", required: true, why: "env fingerprint probes and encryption identityCommand"},
+	{name: "bash", why: "campaign cleanup hooks"},
+	{name: "git", why: "attempt provenance"},
+}
+
 func Run(ctx context.Context, opts Opts) (Result, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-
-	m, err := config.LoadMerged(opts.OutRootFlag)
-	if err != nil {
-		return Result{}, err
-	}
-	outRoot := m.OutRoot
-
-	res := Result{OK: true, OutRoot: outRoot}
+	res := Result{OK: true}
 	add := func(check Check) {
 		if !check.OK {
 			res.OK = false
@@ -46,9 +67,25 @@ func Run(ctx context.Context, opts Opts) (Result, error) {
 		res.Checks = append(res.Checks, check)
 	}
 
+	// A config that does not load is reported by config_lint; the remaining checks still run
+	// against the flag or default out-root.
+	outRoot := strings.TrimSpace(opts.OutRootFlag)
+	if m, err := config.LoadMerged(opts.OutRootFlag); err == nil {
+		outRoot, res.Profile = m.OutRoot, m.Profile
+	} else if outRoot == "" {
+		outRoot = ".zcl"
+	}
+	res.OutRoot = outRoot
+
 	add(checkWriteAccess(outRoot))
 	add(checkProjectConfig())
+	add(checkConfigLint())
 	add(checkRedactionConfig())
+	add(checkDiskSpace(outRoot))
+	add(checkClockSkew(outRoot))
+	for _, b := range binaries {
+		add(checkBinary(b.name, b.required, b.why))
+	}
 	add(checkCodexRunner())
 	for _, runtime := range opts.NativeRuntimes {
 		if runtime == nil {
@@ -88,6 +125,31 @@ func checkProjectConfig() Check {
 	return Check{ID: "project_config", OK: true}
 }
 
+// checkConfigLint runs `zcl config lint` over the project and global config: errors fail the
+// check, warnings (e.g. unknown keys) are reported but pass.
+func checkConfigLint() Check {
+	lint := config.Lint()
+	var errs, warns []string
+	for _, issue := range lint.Issues {
+		msg := issue.Path + ": " + issue.Message
+		if issue.Key != "" {
+			msg = issue.Path + ": " + issue.Key + ": " + issue.Message
+		}
+		if issue.Severity == config.LintSeverityError {
+			errs = append(errs, msg)
+		} else {
+			warns = append(warns, msg)
+		}
+	}
+	if len(errs) > 0 {
+		return Check{ID: "config_lint", OK: false, Message: strings.Join(errs, "; ")}
+	}
+	if len(warns) > 0 {
+		return Check{ID: "config_lint", OK: true, Message: strings.Join(warns, "; ")}
+	}
+	return Check{ID: "config_lint", OK: true}
+}
+
 func checkRedactionConfig() Check {
 	if _, err := config.LoadRedactionMerged(); err != nil {
 		return Check{ID: "redaction_config", OK: false, Message: err.Error()}
@@ -95,6 +157,61 @@ func checkRedactionConfig() Check {
 	return Check{ID: "redaction_config", OK: true}
 }
 
+func checkDiskSpace(outRoot string) Check {
+	free, err := freeBytes(outRoot)
+	if errors.Is(err, errFreeSpaceNotMeasurable) {
+		return Check{ID: "disk_space", OK: true, Message: err.Error()}
+	}
+	if err != nil {
+		return Check{ID: "disk_space", OK: false, Message: err.Error()}
+	}
+	msg := fmt.Sprintf("%d MiB free under %s", free>>20, outRoot)
+	if free < MinFreeBytes {
+		return Check{ID: "disk_space", OK: false, Message: fmt.Sprintf("%s (need at least %d MiB)", msg, MinFreeBytes>>20)}
+	}
+	return Check{ID: "disk_space", OK: true, Message: msg}
+}
+
+// checkClockSkew compares the mtime the out-root's filesystem stamps on a fresh file with the
+// local clock (the wall clock, not an injected one); network filesystems with a drifting server
+// clock make locks look stale early.
+func checkClockSkew(outRoot string) Check {
+	tmp := filepath.Join(outRoot, ".doctor.clock.tmp")
+	before := time.Now()
+	if err := os.WriteFile(tmp, []byte("ok\n"), 0o600); err != nil {
+		return Check{ID: "clock_skew", OK: false, Message: err.Error()}
+	}
+	after := time.Now()
+	info, err := os.Stat(tmp)
+	_ = os.Remove(tmp)
+	if err != nil {
+		return Check{ID: "clock_skew", OK: false, Message: err.Error()}
+	}
+	var skew time.Duration
+	switch mt := info.ModTime(); {
+	case mt.Before(before):
+		skew = before.Sub(mt)
+	case mt.After(after):
+		skew = mt.Sub(after)
+	}
+	// Filesystems with coarse timestamps truncate mtime; allow for one second of that.
+	if skew > MaxClockSkew+time.Second {
+		return Check{ID: "clock_skew", OK: false, Message: fmt.Sprintf("out-root file times differ from the local clock by %s (max %s)", skew.Round(time.Second), MaxClockSkew)}
+	}
+	return Check{ID: "clock_skew", OK: true}
+}
+
+func checkBinary(name string, required bool, why string) Check {
+	prefix := "optional_binary_"
+	if required {
+		prefix = "required_binary_"
+	}
+	if _, err := exec.LookPath(name); err != nil {
+		return Check{ID: prefix + name, OK: !required, Message: fmt.Sprintf("%s not on PATH (needed for %s)", name, why)}
+	}
+	return Check{ID: prefix + name, OK: true}
+}
+
 func checkCodexRunner() Check {
 	if _, err := exec.LookPath("codex"); err == nil {
 		return Check{ID: "runner_codex", OK: true}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
 type RunInfo struct {
@@ -17,8 +18,20 @@ type RunInfo struct {
 	CreatedAt time.Time `json:"createdAt"`
 	Pinned    bool      `json:"pinned"`
 	Bytes     int64     `json:"bytes"`
+	// Failed is set when any attempt has feedback ok=false or no feedback at all.
+	Failed bool `json:"failed,omitempty"`
+	// Campaigns lists published campaigns (campaign.report.json present) whose flow runs reference this run.
+	Campaigns []string `json:"campaigns,omitempty"`
+	// Protected explains why no policy may delete the run: pinned, campaign or failed.
+	Protected string `json:"protected,omitempty"`
 }
 
+const (
+	ProtectedPinned   = "pinned"
+	ProtectedCampaign = "campaign"
+	ProtectedFailed   = "failed"
+)
+
 type Result struct {
 	OK          bool      `json:"ok"`
 	OutRoot     string    `json:"outRoot"`
@@ -28,6 +41,9 @@ type Result struct {
 	Errors      []string  `json:"errors,omitempty"`
 	TotalBefore int64     `json:"totalBeforeBytes"`
 	TotalAfter  int64     `json:"totalAfterBytes"`
+	// CAS reports content-addressed blobs no run links to anymore. In dry-run it only counts blobs
+	// that are already unreferenced, not ones the planned deletions would orphan.
+	CAS *store.CASPruneResult `json:"cas,omitempty"`
 }
 
 type Opts struct {
@@ -35,7 +51,12 @@ type Opts struct {
 	Now           time.Time
 	MaxAgeDays    int
 	MaxTotalBytes int64
-	DryRun        bool
+	// KeepRuns keeps the newest N runs regardless of age; with MaxAgeDays a run is deleted only
+	// when it is both older than MaxAgeDays and outside the newest KeepRuns. 0 disables.
+	KeepRuns int
+	// KeepFailed protects the newest N runs with failed attempts (config.KeepFailedAll: every one).
+	KeepFailed int
+	DryRun     bool
 }
 
 func Run(opts Opts) (Result, error) {
@@ -58,6 +79,7 @@ func Run(opts Opts) (Result, error) {
 	}
 
 	runs := collectRuns(entries, runsDir)
+	markCampaignRuns(runs, outRoot)
 
 	sort.Slice(runs, func(i, j int) bool {
 		if runs[i].CreatedAt.Equal(runs[j].CreatedAt) {
@@ -73,6 +95,7 @@ func Run(opts Opts) (Result, error) {
 	res := Result{OK: true, OutRoot: outRoot, DryRun: opts.DryRun, TotalBefore: total, TotalAfter: total}
 	shouldDelete := planDeletion(runs, now, opts, total)
 	applyDeletion(&res, runs, shouldDelete, opts.DryRun)
+	pruneCAS(&res, outRoot, opts.DryRun)
 	return res, nil
 }
 
@@ -115,28 +138,116 @@ func loadRunInfo(runDir string) (RunInfo, bool) {
 		CreatedAt: createdAt,
 		Pinned:    meta.Pinned,
 		Bytes:     size,
+		Failed:    runHasFailedAttempt(runDir),
 	}, true
 }
 
+func runHasFailedAttempt(runDir string) bool {
+	entries, err := os.ReadDir(filepath.Join(runDir, "attempts"))
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(runDir, "attempts", e.Name(), artifacts.FeedbackJSON))
+		if err != nil {
+			return true
+		}
+		var fb schema.FeedbackJSONV1
+		if json.Unmarshal(raw, &fb) != nil || !fb.OK {
+			return true
+		}
+	}
+	return false
+}
+
+// markCampaignRuns attaches published campaigns to the runs their flow runs reference. Only the
+// runId fields of campaign.run.state.json are read, keeping ops independent of the campaign context.
+func markCampaignRuns(runs []RunInfo, outRoot string) {
+	campaignsDir := filepath.Join(outRoot, "campaigns")
+	entries, err := os.ReadDir(campaignsDir)
+	if err != nil {
+		return
+	}
+	byRun := map[string][]string{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(campaignsDir, e.Name())
+		if _, err := os.Stat(filepath.Join(dir, artifacts.CampaignReportJSON)); err != nil {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, artifacts.CampaignRunStateJSON))
+		if err != nil {
+			continue
+		}
+		var st struct {
+			FlowRuns []struct {
+				RunID string `json:"runId"`
+			} `json:"flowRuns"`
+		}
+		if json.Unmarshal(raw, &st) != nil {
+			continue
+		}
+		for _, fr := range st.FlowRuns {
+			if fr.RunID != "" {
+				byRun[fr.RunID] = append(byRun[fr.RunID], e.Name())
+			}
+		}
+	}
+	for i := range runs {
+		if cs := byRun[runs[i].RunID]; len(cs) > 0 {
+			sort.Strings(cs)
+			runs[i].Campaigns = cs
+		}
+	}
+}
+
 func planDeletion(runs []RunInfo, now time.Time, opts Opts, total int64) map[string]bool {
-	shouldDelete := selectAgeBasedRuns(runs, now, opts.MaxAgeDays)
+	markProtected(runs, opts.KeepFailed)
+	shouldDelete := selectRetentionRuns(runs, now, opts.MaxAgeDays, opts.KeepRuns)
 	applySizeBasedSelection(runs, opts.MaxTotalBytes, total, shouldDelete)
 	return shouldDelete
 }
 
-func selectAgeBasedRuns(runs []RunInfo, now time.Time, maxAgeDays int) map[string]bool {
+// markProtected sets Protected on runs (sorted oldest first) that no policy may delete.
+func markProtected(runs []RunInfo, keepFailed int) {
+	failedKept := 0
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := &runs[i]
+		switch {
+		case r.Pinned:
+			r.Protected = ProtectedPinned
+		case len(r.Campaigns) > 0:
+			r.Protected = ProtectedCampaign
+		case r.Failed && (keepFailed < 0 || failedKept < keepFailed):
+			r.Protected = ProtectedFailed
+			failedKept++
+		}
+	}
+}
+
+// selectRetentionRuns picks runs (sorted oldest first) outside every configured keep window.
+func selectRetentionRuns(runs []RunInfo, now time.Time, maxAgeDays, keepRuns int) map[string]bool {
 	shouldDelete := make(map[string]bool)
-	if maxAgeDays <= 0 {
+	if maxAgeDays <= 0 && keepRuns <= 0 {
 		return shouldDelete
 	}
 	cutoff := now.Add(-time.Duration(maxAgeDays) * 24 * time.Hour)
-	for _, r := range runs {
-		if r.Pinned {
+	for i, r := range runs {
+		if r.Protected != "" {
+			continue
+		}
+		if maxAgeDays > 0 && (r.CreatedAt.IsZero() || !r.CreatedAt.Before(cutoff)) {
 			continue
 		}
-		if !r.CreatedAt.IsZero() && r.CreatedAt.Before(cutoff) {
-			shouldDelete[r.RunID] = true
+		if keepRuns > 0 && len(runs)-i <= keepRuns {
+			continue
 		}
+		shouldDelete[r.RunID] = true
 	}
 	return shouldDelete
 }
@@ -149,7 +260,7 @@ func applySizeBasedSelection(runs []RunInfo, maxTotalBytes int64, total int64, s
 		if total <= maxTotalBytes {
 			return
 		}
-		if r.Pinned || shouldDelete[r.RunID] {
+		if r.Protected != "" || shouldDelete[r.RunID] {
 			continue
 		}
 		shouldDelete[r.RunID] = true
@@ -171,6 +282,17 @@ func applyDeletion(res *Result, runs []RunInfo, shouldDelete map[string]bool, dr
 	}
 }
 
+func pruneCAS(res *Result, outRoot string, dryRun bool) {
+	cas, err := store.PruneCAS(outRoot, dryRun)
+	if err != nil {
+		res.Errors = append(res.Errors, "cas: "+err.Error())
+		return
+	}
+	if cas.Blobs > 0 {
+		res.CAS = &cas
+	}
+}
+
 func dirSize(root string) (int64, error) {
 	var total int64
 	err := filepath.WalkDir(root, func(_ string, d os.DirEntry, err error) error {
@@ -1,10 +1,14 @@
 package gc
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
 func TestGC_RespectsPinnedAndAge(t *testing.T) {
@@ -49,3 +53,86 @@ func writeRun(t *testing.T, runsDir, runID, createdAt string, pinned bool) {
 		t.Fatalf("write run.json: %v", err)
 	}
 }
+
+func TestGC_PrunesCASBlobsOfDeletedRuns(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hardlink counts are not available on windows")
+	}
+	outRoot := filepath.Join(t.TempDir(), ".zcl")
+	runsDir := filepath.Join(outRoot, "runs")
+	writeRun(t, runsDir, "r1", "2026-01-01T00:00:00Z", false)
+	writeRun(t, runsDir, "r2", "2026-02-14T00:00:00Z", false)
+	shared := bytes.Repeat([]byte("p"), store.CASMinBytes)
+	only := bytes.Repeat([]byte("q"), store.CASMinBytes)
+	for _, w := range []struct {
+		run string
+		b   []byte
+	}{{"r1", shared}, {"r2", shared}, {"r1", only}} {
+		p := filepath.Join(runsDir, w.run, "attempts", string(w.b[:1]), "prompt.txt")
+		if err := store.WriteFileCAS(outRoot, p, w.b); err != nil {
+			t.Fatalf("WriteFileCAS: %v", err)
+		}
+	}
+
+	res, err := Run(Opts{OutRoot: outRoot, Now: time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), MaxAgeDays: 30})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(res.Deleted) != 1 || res.CAS == nil || res.CAS.Blobs != 2 || res.CAS.Pruned != 1 {
+		t.Fatalf("expected only the blob unique to the deleted run to be pruned, got deleted=%d cas=%+v", len(res.Deleted), res.CAS)
+	}
+}
+
+func TestGC_RetentionKeepsNewestFailedAndCampaignRuns(t *testing.T) {
+	outRoot := filepath.Join(t.TempDir(), ".zcl")
+	runsDir := filepath.Join(outRoot, "runs")
+	writeRun(t, runsDir, "r1", "2026-01-01T00:00:00Z", false) // old, referenced by published campaign
+	writeRun(t, runsDir, "r2", "2026-01-02T00:00:00Z", false) // old, failed attempt
+	writeRun(t, runsDir, "r3", "2026-01-03T00:00:00Z", false) // old, passing -> delete
+	writeRun(t, runsDir, "r4", "2026-01-04T00:00:00Z", false) // old but within keepRuns
+	writeRun(t, runsDir, "r5", "2026-02-14T00:00:00Z", false)
+	writeFile := func(rel, body string) {
+		p := filepath.Join(outRoot, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	writeFile("runs/r2/attempts/001-m1-r1/feedback.json", `{"schemaVersion":1,"ok":false}`)
+	writeFile("runs/r3/attempts/001-m1-r1/feedback.json", `{"schemaVersion":1,"ok":true}`)
+	writeFile("campaigns/cmp/campaign.run.state.json", `{"flowRuns":[{"flowId":"a","runId":"r1"}]}`)
+	writeFile("campaigns/cmp/campaign.report.json", `{}`)
+	writeFile("campaigns/draft/campaign.run.state.json", `{"flowRuns":[{"flowId":"a","runId":"r4"}]}`)
+
+	res, err := Run(Opts{
+		OutRoot:    outRoot,
+		Now:        time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC),
+		MaxAgeDays: 30,
+		KeepRuns:   2,
+		KeepFailed: -1,
+		DryRun:     true,
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(res.Deleted) != 1 || res.Deleted[0].RunID != "r3" {
+		t.Fatalf("expected only r3 to be deleted, got %+v", res.Deleted)
+	}
+	protected := map[string]string{}
+	for _, k := range res.Kept {
+		protected[k.RunID] = k.Protected
+	}
+	if protected["r1"] != ProtectedCampaign || protected["r2"] != ProtectedFailed || protected["r4"] != "" {
+		t.Fatalf("unexpected protections: %+v", protected)
+	}
+
+	res, err = Run(Opts{OutRoot: outRoot, Now: time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), MaxAgeDays: 30, DryRun: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(res.Deleted) != 3 {
+		t.Fatalf("without keepRuns/keepFailed all unprotected old runs go, got %+v", res.Deleted)
+	}
+}
@@ -0,0 +1,147 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const (
+	ActionMigrated = "migrated"
+	ActionCurrent  = "current"
+)
+
+// step upgrades one per-attempt artifact between adjacent schema versions. Artifacts evolve
+// independently, so a schema hop only lists the artifacts whose shape changed.
+type step struct {
+	artifact string
+	from     int
+	to       int
+	apply    func(raw []byte) ([]byte, error)
+}
+
+var steps = []step{
+	{artifact: artifacts.AttemptReportJSON, from: schema.AttemptReportSchemaV1, to: schema.AttemptReportSchemaV2, apply: schema.UpgradeAttemptReportV1ToV2},
+}
+
+// FileV1 paths are relative to the run directory.
+type FileV1 struct {
+	Path   string `json:"path"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Action string `json:"action"`
+}
+
+type Result struct {
+	OK     bool     `json:"ok"`
+	RunID  string   `json:"runId"`
+	RunDir string   `json:"runDir"`
+	From   int      `json:"from"`
+	To     int      `json:"to"`
+	DryRun bool     `json:"dryRun,omitempty"`
+	Files  []FileV1 `json:"files,omitempty"`
+}
+
+type Opts struct {
+	OutRoot string
+	RunID   string
+	From    int
+	To      int
+	DryRun  bool
+}
+
+// Supported reports whether a from->to hop has registered steps.
+func Supported(from, to int) bool {
+	for _, s := range steps {
+		if s.from == from && s.to == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Run rewrites a run's attempt artifacts from one schema version to the next. Files already at the
+// target version are reported as current, so re-running is a no-op. A file at any other version
+// fails the migration before anything is written.
+func Run(opts Opts) (Result, error) {
+	outRoot := strings.TrimSpace(opts.OutRoot)
+	if outRoot == "" {
+		outRoot = ".zcl"
+	}
+	runID := strings.TrimSpace(opts.RunID)
+	if !ids.IsValidRunID(runID) {
+		return Result{}, fmt.Errorf("invalid --run-id (expected format YYYYMMDD-HHMMSSZ-<hex6>)")
+	}
+	if !Supported(opts.From, opts.To) {
+		return Result{}, fmt.Errorf("no migration from schema %d to %d", opts.From, opts.To)
+	}
+	runDir := filepath.Join(outRoot, "runs", runID)
+	if _, err := os.Stat(filepath.Join(runDir, artifacts.RunJSON)); err != nil {
+		return Result{}, err
+	}
+	attemptDirs, err := filepath.Glob(filepath.Join(runDir, "attempts", "*"))
+	if err != nil {
+		return Result{}, err
+	}
+	sort.Strings(attemptDirs)
+
+	type pending struct {
+		path string
+		out  []byte
+	}
+	var writes []pending
+	res := Result{OK: true, RunID: runID, RunDir: runDir, From: opts.From, To: opts.To, DryRun: opts.DryRun}
+	for _, dir := range attemptDirs {
+		for _, s := range steps {
+			if s.from != opts.From || s.to != opts.To {
+				continue
+			}
+			path := filepath.Join(dir, s.artifact)
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return Result{}, err
+			}
+			rel, _ := filepath.Rel(runDir, path)
+			rel = filepath.ToSlash(rel)
+			var head struct {
+				SchemaVersion int `json:"schemaVersion"`
+			}
+			if err := json.Unmarshal(raw, &head); err != nil {
+				return Result{}, fmt.Errorf("%s: %w", rel, err)
+			}
+			switch head.SchemaVersion {
+			case s.to:
+				res.Files = append(res.Files, FileV1{Path: rel, From: head.SchemaVersion, To: s.to, Action: ActionCurrent})
+			case s.from:
+				out, err := s.apply(raw)
+				if err != nil {
+					return Result{}, fmt.Errorf("%s: %w", rel, err)
+				}
+				writes = append(writes, pending{path: path, out: append(out, '\n')})
+				res.Files = append(res.Files, FileV1{Path: rel, From: s.from, To: s.to, Action: ActionMigrated})
+			default:
+				return Result{}, fmt.Errorf("%s: schemaVersion %d is neither %d nor %d", rel, head.SchemaVersion, s.from, s.to)
+			}
+		}
+	}
+	if opts.DryRun {
+		return res, nil
+	}
+	for _, w := range writes {
+		if err := store.WriteFileAtomic(w.path, w.out); err != nil {
+			return Result{}, err
+		}
+	}
+	return res, nil
+}
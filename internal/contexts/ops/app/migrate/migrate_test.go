@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+const testRunID = "20260215-180012Z-09c5a6"
+
+func TestRun_UpgradesAttemptReportsAndIsIdempotent(t *testing.T) {
+	outRoot := t.TempDir()
+	runDir := filepath.Join(outRoot, "runs", testRunID)
+	write := func(path, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	write(filepath.Join(runDir, "run.json"), `{"schemaVersion":1,"runId":"`+testRunID+`"}`)
+	reportPath := filepath.Join(runDir, "attempts", "001-m-r1", "attempt.report.json")
+	write(reportPath, `{"schemaVersion":1,"attemptId":"001-m-r1","metrics":{"toolCallsTotal":2},"failureCodeHistogram":{"ZCL_E_TIMEOUT":1},"customField":"kept"}`)
+	// Attempts without a report (still running) are not touched.
+	write(filepath.Join(runDir, "attempts", "002-m-r1", "attempt.json"), `{}`)
+
+	dry, err := Run(Opts{OutRoot: outRoot, RunID: testRunID, From: 1, To: 2, DryRun: true})
+	if err != nil || len(dry.Files) != 1 || dry.Files[0].Action != ActionMigrated {
+		t.Fatalf("unexpected dry run: %+v err=%v", dry, err)
+	}
+	if raw, _ := os.ReadFile(reportPath); !strings.Contains(string(raw), `"schemaVersion":1`) {
+		t.Fatalf("dry run must not write, got %s", raw)
+	}
+
+	if _, err := Run(Opts{OutRoot: outRoot, RunID: testRunID, From: 1, To: 2}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	raw, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if doc["schemaVersion"] != float64(2) || doc["failureCodeHistogram"] != nil || doc["customField"] != "kept" {
+		t.Fatalf("unexpected v2 document: %s", raw)
+	}
+	rep, err := schema.DecodeAttemptReport(raw)
+	if err != nil || rep.FailureCodeHistogram["ZCL_E_TIMEOUT"] != 1 || rep.Metrics.ToolCallsTotal != 2 {
+		t.Fatalf("v2 reader lost data: %+v err=%v", rep, err)
+	}
+
+	again, err := Run(Opts{OutRoot: outRoot, RunID: testRunID, From: 1, To: 2})
+	if err != nil || len(again.Files) != 1 || again.Files[0].Action != ActionCurrent {
+		t.Fatalf("expected re-run to report current, got %+v err=%v", again, err)
+	}
+	if _, err := Run(Opts{OutRoot: outRoot, RunID: testRunID, From: 2, To: 3}); err == nil {
+		t.Fatalf("expected unsupported hop to fail")
+	}
+}
@@ -0,0 +1,402 @@
+package repro
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/artifactsync"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const ManifestSchemaV1 = 1
+
+// Bundle layout. The manifest is written last, so a directory without repro.json is incomplete.
+const (
+	ManifestFile  = "repro.json"
+	ConfigFile    = "config.json"
+	EnvPolicyFile = "env.policy.json"
+	campaignDir   = "campaign"
+)
+
+// ConfigSnapshotV1 is the part of the merged config that changes how a suite run executes.
+type ConfigSnapshotV1 struct {
+	OutRoot              string   `json:"outRoot"`
+	Profile              string   `json:"profile,omitempty"`
+	RuntimeStrategyChain []string `json:"runtimeStrategyChain,omitempty"`
+	CampaignState        string   `json:"campaignState,omitempty"`
+	EncryptionRecipient  string   `json:"encryptionRecipient,omitempty"`
+	RedactionRules       []string `json:"redactionRules,omitempty"`
+}
+
+// EnvPolicySnapshotV1 records the native runtime env allow/block lists in effect at bundle time.
+type EnvPolicySnapshotV1 struct {
+	AllowedExact    []string `json:"allowedExact"`
+	AllowedPrefixes []string `json:"allowedPrefixes"`
+	BlockedExact    []string `json:"blockedExact"`
+	BlockedPrefixes []string `json:"blockedPrefixes"`
+	RedactNameHints []string `json:"redactNameHints"`
+}
+
+// ManifestV1 is repro.json. File paths are relative to the bundle dir.
+type ManifestV1 struct {
+	SchemaVersion int                        `json:"schemaVersion"`
+	RunID         string                     `json:"runId"`
+	CampaignID    string                     `json:"campaignId,omitempty"`
+	ZCLVersion    string                     `json:"zclVersion,omitempty"`
+	CreatedAt     string                     `json:"createdAt"`
+	Invocation    schema.RunInvocationJSONV1 `json:"invocation"`
+	Files         []artifactsync.FileV1      `json:"files"`
+}
+
+type Opts struct {
+	OutRoot    string
+	RunID      string
+	OutDir     string
+	Config     ConfigSnapshotV1
+	EnvPolicy  native.EnvPolicy
+	ZCLVersion string
+	Now        time.Time
+}
+
+type Result struct {
+	OK         bool   `json:"ok"`
+	RunID      string `json:"runId"`
+	BundleDir  string `json:"bundleDir"`
+	CampaignID string `json:"campaignId,omitempty"`
+	Files      int    `json:"files"`
+}
+
+// SnapshotEnvPolicy flattens p into sorted lists.
+func SnapshotEnvPolicy(p native.EnvPolicy) EnvPolicySnapshotV1 {
+	keys := func(m map[string]bool) []string {
+		out := make([]string, 0, len(m))
+		for k := range m {
+			out = append(out, k)
+		}
+		sort.Strings(out)
+		return out
+	}
+	sorted := func(in []string) []string {
+		out := append([]string{}, in...)
+		sort.Strings(out)
+		return out
+	}
+	return EnvPolicySnapshotV1{
+		AllowedExact:    keys(p.AllowedExact),
+		AllowedPrefixes: sorted(p.AllowedPrefixes),
+		BlockedExact:    keys(p.BlockedExact),
+		BlockedPrefixes: sorted(p.BlockedPrefixes),
+		RedactNameHints: sorted(p.RedactNameHints),
+	}
+}
+
+// Bundle packages everything needed to re-run a suite run into OutDir: the suite snapshot, the
+// recorded invocation, the campaign spec (when the run belongs to a campaign), the merged config and
+// the env policy. Only runs started by `zcl suite run` record an invocation and can be bundled.
+func Bundle(opts Opts) (Result, error) {
+	outRoot := strings.TrimSpace(opts.OutRoot)
+	if outRoot == "" {
+		outRoot = ".zcl"
+	}
+	runID := strings.TrimSpace(opts.RunID)
+	if !ids.IsValidRunID(runID) {
+		return Result{}, fmt.Errorf("invalid --run-id (expected format YYYYMMDD-HHMMSSZ-<hex6>)")
+	}
+	outDir := strings.TrimSpace(opts.OutDir)
+	if outDir == "" {
+		return Result{}, fmt.Errorf("missing --out")
+	}
+	runDir := filepath.Join(outRoot, "runs", runID)
+	var inv schema.RunInvocationJSONV1
+	raw, err := os.ReadFile(filepath.Join(runDir, artifacts.RunInvocationJSON))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Result{}, fmt.Errorf("run %s has no %s (only `zcl suite run` records its invocation)", runID, artifacts.RunInvocationJSON)
+		}
+		return Result{}, err
+	}
+	if err := json.Unmarshal(raw, &inv); err != nil {
+		return Result{}, fmt.Errorf("%s: %w", artifacts.RunInvocationJSON, err)
+	}
+	if _, err := os.Stat(filepath.Join(runDir, artifacts.SuiteJSON)); err != nil {
+		return Result{}, fmt.Errorf("run %s has no suite snapshot: %w", runID, err)
+	}
+	if entries, err := os.ReadDir(outDir); err == nil && len(entries) > 0 {
+		return Result{}, fmt.Errorf("bundle dir %s is not empty", outDir)
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return Result{}, err
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	m := ManifestV1{
+		SchemaVersion: ManifestSchemaV1,
+		RunID:         runID,
+		ZCLVersion:    strings.TrimSpace(opts.ZCLVersion),
+		CreatedAt:     now.UTC().Format(time.RFC3339Nano),
+		Invocation:    inv,
+	}
+	add := func(rel string) error {
+		f, err := describe(outDir, rel)
+		if err != nil {
+			return err
+		}
+		m.Files = append(m.Files, f)
+		return nil
+	}
+	for _, name := range []string{artifacts.SuiteJSON, artifacts.RunInvocationJSON} {
+		if err := copyFile(filepath.Join(runDir, name), filepath.Join(outDir, name)); err != nil {
+			return Result{}, err
+		}
+		if err := add(name); err != nil {
+			return Result{}, err
+		}
+	}
+	if id, spec := findCampaignSpec(outRoot, runID); spec != "" {
+		rel := campaignDir + "/" + filepath.Base(spec)
+		if err := copyFile(spec, filepath.Join(outDir, filepath.FromSlash(rel))); err != nil {
+			return Result{}, err
+		}
+		if err := add(rel); err != nil {
+			return Result{}, err
+		}
+		m.CampaignID = id
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(outDir, ConfigFile), opts.Config); err != nil {
+		return Result{}, err
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(outDir, EnvPolicyFile), SnapshotEnvPolicy(opts.EnvPolicy)); err != nil {
+		return Result{}, err
+	}
+	for _, name := range []string{ConfigFile, EnvPolicyFile} {
+		if err := add(name); err != nil {
+			return Result{}, err
+		}
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(outDir, ManifestFile), m); err != nil {
+		return Result{}, err
+	}
+	return Result{OK: true, RunID: runID, BundleDir: outDir, CampaignID: m.CampaignID, Files: len(m.Files)}, nil
+}
+
+// findCampaignSpec returns the campaign whose run state references runID and its spec path. Only
+// the fields needed are decoded, keeping ops independent of the campaign context.
+func findCampaignSpec(outRoot, runID string) (string, string) {
+	campaignsDir := filepath.Join(outRoot, "campaigns")
+	entries, err := os.ReadDir(campaignsDir)
+	if err != nil {
+		return "", ""
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(campaignsDir, e.Name(), artifacts.CampaignRunStateJSON))
+		if err != nil {
+			continue
+		}
+		var st struct {
+			CampaignID string `json:"campaignId"`
+			SpecPath   string `json:"specPath"`
+			FlowRuns   []struct {
+				RunID string `json:"runId"`
+			} `json:"flowRuns"`
+		}
+		if json.Unmarshal(raw, &st) != nil || st.SpecPath == "" {
+			continue
+		}
+		for _, fr := range st.FlowRuns {
+			if fr.RunID != runID {
+				continue
+			}
+			if _, err := os.Stat(st.SpecPath); err != nil {
+				return "", ""
+			}
+			id := st.CampaignID
+			if id == "" {
+				id = e.Name()
+			}
+			return id, st.SpecPath
+		}
+	}
+	return "", ""
+}
+
+// RunPlan is a bundle resolved against the current environment.
+type RunPlan struct {
+	Manifest ManifestV1
+	// Args are `zcl suite run` arguments with --file pointing into the bundle, --out-root set to the
+	// target out-root and --run-id/--campaign-state dropped, so the re-run always gets a fresh run and
+	// never touches the original campaign's state.
+	Args []string
+	// Env is the recorded extra attempt env without redacted values.
+	Env      map[string]string
+	Warnings []string
+}
+
+// Plan verifies the bundle and rewrites its invocation for a re-run. Drift between the bundled and
+// current config is reported as warnings; a drifted runtime strategy chain is pinned back to the
+// bundled one unless the invocation already sets --runtime-strategies.
+func Plan(bundleDir string, outRoot string, current ConfigSnapshotV1) (RunPlan, error) {
+	var m ManifestV1
+	raw, err := os.ReadFile(filepath.Join(bundleDir, ManifestFile))
+	if err != nil {
+		return RunPlan{}, err
+	}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return RunPlan{}, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	if m.SchemaVersion != ManifestSchemaV1 {
+		return RunPlan{}, fmt.Errorf("%s: unsupported schemaVersion %d", ManifestFile, m.SchemaVersion)
+	}
+	for _, f := range m.Files {
+		got, err := describe(bundleDir, f.Path)
+		if err != nil {
+			return RunPlan{}, err
+		}
+		if got.SHA256 != f.SHA256 {
+			return RunPlan{}, fmt.Errorf("bundle file %s does not match %s (sha256 mismatch)", f.Path, ManifestFile)
+		}
+	}
+	argv := m.Invocation.Argv
+	if len(argv) < 2 || argv[0] != "suite" || argv[1] != "run" {
+		return RunPlan{}, fmt.Errorf("bundle invocation is not a suite run: %q", strings.Join(argv, " "))
+	}
+	var bundled ConfigSnapshotV1
+	if raw, err := os.ReadFile(filepath.Join(bundleDir, ConfigFile)); err == nil {
+		if err := json.Unmarshal(raw, &bundled); err != nil {
+			return RunPlan{}, fmt.Errorf("%s: %w", ConfigFile, err)
+		}
+	}
+	suitePath, err := filepath.Abs(filepath.Join(bundleDir, artifacts.SuiteJSON))
+	if err != nil {
+		return RunPlan{}, err
+	}
+
+	plan := RunPlan{Manifest: m}
+	args, runnerCmd, hasStrategies := rewriteArgs(argv[2:], suitePath, outRoot)
+	if !reflect.DeepEqual(bundled.RuntimeStrategyChain, current.RuntimeStrategyChain) {
+		if !hasStrategies && len(bundled.RuntimeStrategyChain) > 0 {
+			args = append(args, "--runtime-strategies", strings.Join(bundled.RuntimeStrategyChain, ","))
+		} else {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("runtime strategy chain differs from bundle (bundle=%s current=%s)", strings.Join(bundled.RuntimeStrategyChain, ","), strings.Join(current.RuntimeStrategyChain, ",")))
+		}
+	}
+	if bundled.Profile != current.Profile {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("config profile differs from bundle (bundle=%q current=%q)", bundled.Profile, current.Profile))
+	}
+	if bundled.CampaignState != current.CampaignState {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("campaign state backend differs from bundle (bundle=%s current=%s)", bundled.CampaignState, current.CampaignState))
+	}
+	if bundled.EncryptionRecipient != current.EncryptionRecipient {
+		plan.Warnings = append(plan.Warnings, "encryption recipient differs from bundle")
+	}
+	if !reflect.DeepEqual(bundled.RedactionRules, current.RedactionRules) {
+		plan.Warnings = append(plan.Warnings, "redaction rules differ from bundle")
+	}
+	if runnerCmd != "" {
+		if _, err := exec.LookPath(runnerCmd); err != nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("runner command %q is not resolvable here", runnerCmd))
+		}
+	}
+	plan.Args = args
+	for k, v := range m.Invocation.Env {
+		if v == "[REDACTED]" {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("env %s was redacted in the bundle and is not replayed", k))
+			continue
+		}
+		if plan.Env == nil {
+			plan.Env = map[string]string{}
+		}
+		plan.Env[k] = v
+	}
+	sort.Strings(plan.Warnings)
+	return plan, nil
+}
+
+// rewriteArgs handles both `--flag value` and `--flag=value`. Everything after the `--` separator
+// is the runner command and is kept verbatim.
+func rewriteArgs(in []string, suitePath, outRoot string) (args []string, runnerCmd string, hasStrategies bool) {
+	sawOutRoot := false
+	i := 0
+	for ; i < len(in); i++ {
+		a := in[i]
+		if a == "--" {
+			break
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(a, "-"), "=")
+		if !strings.HasPrefix(a, "-") {
+			args = append(args, a)
+			continue
+		}
+		skipValue := !hasValue && i+1 < len(in)
+		switch name {
+		case "file":
+			args = append(args, "--file", suitePath)
+		case "out-root":
+			sawOutRoot = true
+			if outRoot == "" {
+				args = append(args, a)
+				continue
+			}
+			args = append(args, "--out-root", outRoot)
+		case "run-id", "campaign-state":
+		case "runtime-strategies":
+			hasStrategies = true
+			args = append(args, a)
+			continue
+		default:
+			args = append(args, a)
+			continue
+		}
+		if skipValue {
+			i++
+		}
+	}
+	if !sawOutRoot && outRoot != "" {
+		args = append(args, "--out-root", outRoot)
+	}
+	if i < len(in) {
+		args = append(args, in[i:]...)
+		if i+1 < len(in) {
+			runnerCmd = in[i+1]
+		}
+	}
+	return args, runnerCmd, hasStrategies
+}
+
+func describe(root, rel string) (artifactsync.FileV1, error) {
+	b, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+	if err != nil {
+		return artifactsync.FileV1{}, err
+	}
+	sum := sha256.Sum256(b)
+	return artifactsync.FileV1{Path: rel, Bytes: int64(len(b)), SHA256: hex.EncodeToString(sum[:])}, nil
+}
+
+func copyFile(src, dst string) error {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	return store.WriteFileAtomic(dst, b)
+}
@@ -0,0 +1,27 @@
+package repro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRewriteArgs_PointsAtBundleAndDropsRunID(t *testing.T) {
+	in := []string{
+		"--file", "suite.yaml", "--run-id=20260222-120000Z-a1b2c3", "--out-root", "/old", "--campaign-state", "/old/state.json",
+		"--json", "--", "node", "agent.mjs", "--file", "x",
+	}
+	got, runner, hasStrategies := rewriteArgs(in, "/b/suite.json", "/new")
+	want := []string{"--file", "/b/suite.json", "--out-root", "/new", "--json", "--", "node", "agent.mjs", "--file", "x"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("args: got %q want %q", got, want)
+	}
+	if runner != "node" || hasStrategies {
+		t.Fatalf("runner=%q hasStrategies=%v", runner, hasStrategies)
+	}
+
+	got, _, hasStrategies = rewriteArgs([]string{"--file=s.json", "--runtime-strategies", "codex_app_server"}, "/b/suite.json", "/new")
+	want = []string{"--file", "/b/suite.json", "--runtime-strategies", "codex_app_server", "--out-root", "/new"}
+	if !reflect.DeepEqual(got, want) || !hasStrategies {
+		t.Fatalf("args: got %q want %q (hasStrategies=%v)", got, want, hasStrategies)
+	}
+}
@@ -0,0 +1,272 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/artifactsync"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const (
+	SignatureSchemaV1 = 1
+	AlgorithmEd25519  = sigkey.Algorithm
+)
+
+// SignatureV1 is written to <outRoot>/campaigns/<campaignId>/campaign.signature.json. Signature is
+// the ed25519 signature over the canonical JSON of the document with signature left empty.
+type SignatureV1 struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	CampaignID    string                `json:"campaignId"`
+	RunIDs        []string              `json:"runIds,omitempty"`
+	SignedAt      string                `json:"signedAt"`
+	Algorithm     string                `json:"algorithm"`
+	PublicKey     string                `json:"publicKey"`
+	KeyID         string                `json:"keyId"`
+	Files         []artifactsync.FileV1 `json:"files"`
+	Signature     string                `json:"signature,omitempty"`
+}
+
+type SignResult struct {
+	OK            bool   `json:"ok"`
+	CampaignID    string `json:"campaignId"`
+	KeyID         string `json:"keyId"`
+	SignaturePath string `json:"signaturePath"`
+	Files         int    `json:"files"`
+	// MissingRuns are referenced run ids without a run directory; they are not covered by the signature.
+	MissingRuns []string `json:"missingRuns,omitempty"`
+}
+
+type VerifyResult struct {
+	OK            bool   `json:"ok"`
+	CampaignID    string `json:"campaignId"`
+	KeyID         string `json:"keyId"`
+	SignaturePath string `json:"signaturePath"`
+	SignedAt      string `json:"signedAt,omitempty"`
+	// KeyPinned is true when the caller supplied the expected public key and it matched.
+	KeyPinned      bool     `json:"keyPinned"`
+	SignatureValid bool     `json:"signatureValid"`
+	Files          int      `json:"files"`
+	Mismatched     []string `json:"mismatched,omitempty"`
+	Missing        []string `json:"missing,omitempty"`
+	// Unsigned lists files added below the signed roots after signing.
+	Unsigned []string `json:"unsigned,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+type SignOpts struct {
+	OutRoot    string
+	CampaignID string
+	RunIDs     []string
+	Key        ed25519.PrivateKey
+	Now        time.Time
+}
+
+type VerifyOpts struct {
+	OutRoot    string
+	CampaignID string
+	// PublicKey pins the expected signer; when nil only the embedded key is checked.
+	PublicKey ed25519.PublicKey
+}
+
+// excludedFiles are never covered: the signature itself, the per-destination sync manifest and the lock.
+var excludedFiles = []string{artifacts.CampaignSignatureJSON, artifacts.SyncManifestJSON, artifactsync.CampaignLockName}
+
+func SignaturePath(outRoot, campaignID string) string {
+	return filepath.Join(outRoot, "campaigns", campaignID, artifacts.CampaignSignatureJSON)
+}
+
+// Sign hashes the campaign directory and every referenced run directory and signs the manifest.
+func Sign(opts SignOpts) (SignResult, error) {
+	cid := ids.SanitizeComponent(strings.TrimSpace(opts.CampaignID))
+	if cid == "" {
+		return SignResult{}, fmt.Errorf("missing/invalid campaign id")
+	}
+	if len(opts.Key) != ed25519.PrivateKeySize {
+		return SignResult{}, fmt.Errorf("missing ed25519 private key")
+	}
+	runIDs := normalizeRunIDs(opts.RunIDs)
+	roots, missing, err := signedRoots(opts.OutRoot, cid, runIDs)
+	if err != nil {
+		return SignResult{}, err
+	}
+	files, err := artifactsync.CollectFiles(opts.OutRoot, roots, excludedFiles...)
+	if err != nil {
+		return SignResult{}, err
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	pub := opts.Key.Public().(ed25519.PublicKey)
+	doc := SignatureV1{
+		SchemaVersion: SignatureSchemaV1,
+		CampaignID:    cid,
+		RunIDs:        runIDs,
+		SignedAt:      now.UTC().Format(time.RFC3339Nano),
+		Algorithm:     AlgorithmEd25519,
+		PublicKey:     base64.StdEncoding.EncodeToString(pub),
+		KeyID:         KeyID(pub),
+		Files:         files,
+	}
+	payload, err := signedPayload(doc)
+	if err != nil {
+		return SignResult{}, err
+	}
+	doc.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(opts.Key, payload))
+	path := SignaturePath(opts.OutRoot, cid)
+	if err := store.WriteJSONAtomic(path, doc); err != nil {
+		return SignResult{}, err
+	}
+	return SignResult{OK: true, CampaignID: cid, KeyID: doc.KeyID, SignaturePath: path, Files: len(files), MissingRuns: missing}, nil
+}
+
+// Verify checks the signature over the manifest, then re-hashes every listed file. Files added below
+// the signed roots after signing are reported as unsigned and fail verification.
+func Verify(opts VerifyOpts) (VerifyResult, error) {
+	cid := ids.SanitizeComponent(strings.TrimSpace(opts.CampaignID))
+	if cid == "" {
+		return VerifyResult{}, fmt.Errorf("missing/invalid campaign id")
+	}
+	path := SignaturePath(opts.OutRoot, cid)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	var doc SignatureV1
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return VerifyResult{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if doc.SchemaVersion != SignatureSchemaV1 || doc.Algorithm != AlgorithmEd25519 {
+		return VerifyResult{}, fmt.Errorf("unsupported signature schemaVersion=%d algorithm=%s", doc.SchemaVersion, doc.Algorithm)
+	}
+	res := VerifyResult{CampaignID: cid, KeyID: doc.KeyID, SignaturePath: path, SignedAt: doc.SignedAt, Files: len(doc.Files)}
+	if doc.CampaignID != cid {
+		res.Errors = append(res.Errors, "signature campaignId does not match")
+	}
+
+	pub, err := base64.StdEncoding.DecodeString(doc.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		res.Errors = append(res.Errors, "embedded public key is invalid")
+		return res, nil
+	}
+	if KeyID(pub) != doc.KeyID {
+		res.Errors = append(res.Errors, "keyId does not match the embedded public key")
+	}
+	if opts.PublicKey != nil {
+		res.KeyPinned = opts.PublicKey.Equal(ed25519.PublicKey(pub))
+		if !res.KeyPinned {
+			res.Errors = append(res.Errors, "signed with key "+doc.KeyID+", expected "+KeyID(opts.PublicKey))
+		}
+	}
+	sig, err := base64.StdEncoding.DecodeString(doc.Signature)
+	if err != nil {
+		res.Errors = append(res.Errors, "signature is not valid base64")
+		return res, nil
+	}
+	unsigned := doc
+	unsigned.Signature = ""
+	payload, err := signedPayload(unsigned)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	res.SignatureValid = ed25519.Verify(ed25519.PublicKey(pub), payload, sig)
+	if !res.SignatureValid {
+		res.Errors = append(res.Errors, "signature does not match the manifest")
+	}
+
+	roots, missingRuns, err := signedRoots(opts.OutRoot, cid, doc.RunIDs)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	for _, runID := range missingRuns {
+		res.Errors = append(res.Errors, "signed run directory is missing: "+runID)
+	}
+	current, err := artifactsync.CollectFiles(opts.OutRoot, roots, excludedFiles...)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	compareFiles(doc.Files, current, &res)
+	res.OK = len(res.Errors) == 0 && res.SignatureValid && len(res.Mismatched) == 0 && len(res.Missing) == 0 && len(res.Unsigned) == 0
+	return res, nil
+}
+
+func compareFiles(signed, current []artifactsync.FileV1, res *VerifyResult) {
+	have := make(map[string]string, len(current))
+	for _, f := range current {
+		have[f.Path] = f.SHA256
+	}
+	listed := make(map[string]bool, len(signed))
+	for _, f := range signed {
+		listed[f.Path] = true
+		sum, ok := have[f.Path]
+		switch {
+		case !ok:
+			res.Missing = append(res.Missing, f.Path)
+		case sum != f.SHA256:
+			res.Mismatched = append(res.Mismatched, f.Path)
+		}
+	}
+	for _, f := range current {
+		if !listed[f.Path] {
+			res.Unsigned = append(res.Unsigned, f.Path)
+		}
+	}
+}
+
+func signedRoots(outRoot, cid string, runIDs []string) ([]string, []string, error) {
+	campaignDir := filepath.Join(outRoot, "campaigns", cid)
+	if fi, err := os.Stat(campaignDir); err != nil || !fi.IsDir() {
+		return nil, nil, fmt.Errorf("campaign dir not found: %s", campaignDir)
+	}
+	roots := []string{campaignDir}
+	var missing []string
+	for _, runID := range runIDs {
+		dir := filepath.Join(outRoot, "runs", runID)
+		if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+			missing = append(missing, runID)
+			continue
+		}
+		roots = append(roots, dir)
+	}
+	return roots, missing, nil
+}
+
+func signedPayload(doc SignatureV1) ([]byte, error) {
+	doc.Signature = ""
+	return store.CanonicalJSON(doc)
+}
+
+func normalizeRunIDs(in []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, id := range in {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] || !ids.IsValidRunID(id) {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// KeyID is a short, stable fingerprint of a public key: "ed25519:" + the first 16 hex chars of its sha256.
+func KeyID(pub ed25519.PublicKey) string { return sigkey.KeyID(pub) }
+
+// LoadPrivateKey reads a PEM "PRIVATE KEY" (PKCS#8) ed25519 key.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) { return sigkey.LoadPrivateKey(path) }
+
+// LoadPublicKey reads a PEM "PUBLIC KEY" (PKIX) ed25519 key.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) { return sigkey.LoadPublicKey(path) }
@@ -0,0 +1,85 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testRunID = "20260222-120000Z-a1b2c3"
+
+func TestSignVerify_DetectsTamperingAndWrongKey(t *testing.T) {
+	outRoot := t.TempDir()
+	writeFile(t, filepath.Join(outRoot, "campaigns", "cmp", "campaign.summary.json"), `{"ok":true}`)
+	writeFile(t, filepath.Join(outRoot, "campaigns", "cmp", "campaign.lock"), `host-local`)
+	writeFile(t, filepath.Join(outRoot, "runs", testRunID, "run.json"), `{"runId":"`+testRunID+`"}`)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+	writeFile(t, keyPath, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+	pubPath := filepath.Join(t.TempDir(), "key.pub.pem")
+	pubDER, _ := x509.MarshalPKIXPublicKey(pub)
+	writeFile(t, pubPath, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})))
+
+	key, err := LoadPrivateKey(keyPath)
+	if err != nil {
+		t.Fatalf("LoadPrivateKey: %v", err)
+	}
+	signed, err := Sign(SignOpts{OutRoot: outRoot, CampaignID: "cmp", RunIDs: []string{testRunID}, Key: key, Now: time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if signed.Files != 2 || signed.KeyID != KeyID(pub) {
+		t.Fatalf("unexpected sign result (lock must be excluded): %+v", signed)
+	}
+
+	pinned, err := LoadPublicKey(pubPath)
+	if err != nil {
+		t.Fatalf("LoadPublicKey: %v", err)
+	}
+	res, err := Verify(VerifyOpts{OutRoot: outRoot, CampaignID: "cmp", PublicKey: pinned})
+	if err != nil || !res.OK || !res.KeyPinned || !res.SignatureValid {
+		t.Fatalf("expected clean verify, got %+v err=%v", res, err)
+	}
+
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	if res, _ := Verify(VerifyOpts{OutRoot: outRoot, CampaignID: "cmp", PublicKey: otherPub}); res.OK || res.KeyPinned {
+		t.Fatalf("verify must fail against a different pinned key, got %+v", res)
+	}
+
+	writeFile(t, filepath.Join(outRoot, "runs", testRunID, "run.json"), `{"runId":"tampered"}`)
+	writeFile(t, filepath.Join(outRoot, "campaigns", "cmp", "extra.json"), `{}`)
+	res, err = Verify(VerifyOpts{OutRoot: outRoot, CampaignID: "cmp"})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if res.OK || !res.SignatureValid || len(res.Mismatched) != 1 || len(res.Unsigned) != 1 {
+		t.Fatalf("expected mismatched + unsigned file, got %+v", res)
+	}
+
+	// Editing the manifest itself breaks the signature.
+	raw, _ := os.ReadFile(signed.SignaturePath)
+	writeFile(t, signed.SignaturePath, string(raw[:len(raw)-2])+",\"runIds\":[]}")
+	if res, err := Verify(VerifyOpts{OutRoot: outRoot, CampaignID: "cmp"}); err != nil || res.SignatureValid {
+		t.Fatalf("expected edited manifest to fail signature check, got %+v err=%v", res, err)
+	}
+}
+
+func writeFile(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
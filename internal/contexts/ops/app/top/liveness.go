@@ -0,0 +1,71 @@
+package top
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// DefaultStaleAfter is how old an attempt's heartbeat may get before the attempt is reported as
+// likely hung. Suite runs beat every few seconds, so this tolerates a stalled disk or a paused VM.
+const DefaultStaleAfter = 60 * time.Second
+
+// StaleAttemptV1 is an unfinished attempt whose attempt.heartbeat.json stopped being refreshed:
+// the suite run driving it is likely hung or was killed.
+type StaleAttemptV1 struct {
+	RunID          string `json:"runId"`
+	MissionID      string `json:"missionId,omitempty"`
+	AttemptID      string `json:"attemptId"`
+	AttemptDir     string `json:"attemptDir"`
+	PID            int    `json:"pid,omitempty"`
+	HeartbeatAt    string `json:"heartbeatAt"`
+	HeartbeatAgeMs int64  `json:"heartbeatAgeMs"`
+}
+
+// StaleAttempts lists the attempts of runDir that have no attempt.report.json yet and whose
+// heartbeat is older than staleAfter. Attempts without a heartbeat (started outside `zcl suite
+// run`) or whose heartbeat was stopped are never stale.
+func StaleAttempts(runDir string, now time.Time, staleAfter time.Duration) []StaleAttemptV1 {
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+	entries, err := os.ReadDir(filepath.Join(runDir, "attempts"))
+	if err != nil {
+		return nil
+	}
+	var out []StaleAttemptV1
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(runDir, "attempts", e.Name())
+		if _, err := os.Stat(filepath.Join(dir, artifacts.AttemptReportJSON)); err == nil {
+			continue
+		}
+		var hb schema.AttemptHeartbeatJSONV1
+		if !readJSON(filepath.Join(dir, artifacts.AttemptHeartbeatJSON), &hb) || hb.StoppedAt != "" {
+			continue
+		}
+		beat := parseTS(hb.BeatAt)
+		if beat.IsZero() || now.Sub(beat) <= staleAfter {
+			continue
+		}
+		var aj schema.AttemptJSONV1
+		_ = readJSON(filepath.Join(dir, artifacts.AttemptJSON), &aj)
+		out = append(out, StaleAttemptV1{
+			RunID:          filepath.Base(runDir),
+			MissionID:      aj.MissionID,
+			AttemptID:      e.Name(),
+			AttemptDir:     dir,
+			PID:            hb.PID,
+			HeartbeatAt:    hb.BeatAt,
+			HeartbeatAgeMs: now.Sub(beat).Milliseconds(),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].AttemptID < out[j].AttemptID })
+	return out
+}
@@ -0,0 +1,473 @@
+package top
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const SnapshotSchemaV1 = 1
+
+// progressTailBytes bounds how much of each progress JSONL file is read per refresh.
+const progressTailBytes = 4 << 20
+
+// Native attempt states read from suite run progress events (`attempt_native_state`).
+const (
+	nativeStateQueued = "queued"
+	nativeStateFailed = "failed"
+)
+
+type Opts struct {
+	OutRoot string
+	// ProgressPaths are extra suite run progress JSONL files; progress files named by an active
+	// run's run.invocation.json (`--progress-jsonl`) are picked up automatically.
+	ProgressPaths []string
+	// RecentFailures caps the failure list (default 10).
+	RecentFailures int
+	Now            time.Time
+}
+
+// SnapshotV1 is one `zcl top` refresh: what is running under the out-root right now.
+type SnapshotV1 struct {
+	SchemaVersion  int          `json:"schemaVersion"`
+	GeneratedAt    string       `json:"generatedAt"`
+	OutRoot        string       `json:"outRoot"`
+	Runs           []RunV1      `json:"runs"`
+	Campaigns      []CampaignV1 `json:"campaigns"`
+	Strategies     []StrategyV1 `json:"strategies"`
+	RecentFailures []FailureV1  `json:"recentFailures"`
+	ProgressFiles  []string     `json:"progressFiles,omitempty"`
+}
+
+// RunV1 is a run whose owner lock is held by a live process.
+type RunV1 struct {
+	RunID      string      `json:"runId"`
+	SuiteID    string      `json:"suiteId,omitempty"`
+	PID        int         `json:"pid,omitempty"`
+	CampaignID string      `json:"campaignId,omitempty"`
+	Finished   int         `json:"finished"`
+	InFlight   []AttemptV1 `json:"inFlight"`
+}
+
+// AttemptV1 is an attempt that has attempt.json but no attempt.report.json yet.
+type AttemptV1 struct {
+	AttemptID   string `json:"attemptId"`
+	MissionID   string `json:"missionId,omitempty"`
+	StartedAt   string `json:"startedAt,omitempty"`
+	ElapsedMs   int64  `json:"elapsedMs"`
+	NativeState string `json:"nativeState,omitempty"`
+	Strategy    string `json:"strategy,omitempty"`
+	// WaitingMs is how long the attempt has been queued for a native scheduler slot
+	// (per-strategy in-flight cap, start spacing or rate-limit backoff).
+	WaitingMs int64 `json:"waitingMs,omitempty"`
+}
+
+type CampaignV1 struct {
+	CampaignID        string `json:"campaignId"`
+	RunID             string `json:"runId,omitempty"`
+	Status            string `json:"status"`
+	MissionsCompleted int    `json:"missionsCompleted"`
+	TotalMissions     int    `json:"totalMissions"`
+	UpdatedAt         string `json:"updatedAt,omitempty"`
+}
+
+// StrategyV1 aggregates native runtime health per strategy from progress events.
+type StrategyV1 struct {
+	Strategy    string `json:"strategy"`
+	InFlight    int    `json:"inFlight"`
+	Waiting     int    `json:"waiting"`
+	Failures    int    `json:"failures"`
+	RateLimited int    `json:"rateLimited"`
+	LastCode    string `json:"lastCode,omitempty"`
+}
+
+type FailureV1 struct {
+	At         string `json:"at"`
+	Source     string `json:"source"` // progress|campaign
+	RunID      string `json:"runId,omitempty"`
+	CampaignID string `json:"campaignId,omitempty"`
+	MissionID  string `json:"missionId,omitempty"`
+	AttemptID  string `json:"attemptId,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+type progressEvent struct {
+	TS         string         `json:"ts"`
+	Kind       string         `json:"kind"`
+	RunID      string         `json:"runId"`
+	MissionID  string         `json:"missionId"`
+	AttemptID  string         `json:"attemptId"`
+	CampaignID string         `json:"campaignId"`
+	Details    map[string]any `json:"details"`
+}
+
+type attemptKey struct{ runID, attemptID string }
+
+type nativeView struct {
+	state    string
+	strategy string
+	since    time.Time
+}
+
+// Snapshot reads the out-root once. Everything is derived from artifacts on disk, so it works for
+// runs started by other processes and never takes a lock.
+func Snapshot(opts Opts) (SnapshotV1, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	limit := opts.RecentFailures
+	if limit <= 0 {
+		limit = 10
+	}
+	snap := SnapshotV1{
+		SchemaVersion:  SnapshotSchemaV1,
+		GeneratedAt:    now.UTC().Format(time.RFC3339Nano),
+		OutRoot:        opts.OutRoot,
+		Runs:           []RunV1{},
+		Campaigns:      []CampaignV1{},
+		Strategies:     []StrategyV1{},
+		RecentFailures: []FailureV1{},
+	}
+
+	runs, progressPaths, err := activeRuns(opts.OutRoot, now)
+	if err != nil {
+		return SnapshotV1{}, err
+	}
+	progressPaths = append(progressPaths, opts.ProgressPaths...)
+	snap.ProgressFiles = dedupe(progressPaths)
+
+	native := map[attemptKey]nativeView{}
+	strategies := map[string]*StrategyV1{}
+	strategyOf := func(id string) *StrategyV1 {
+		s, ok := strategies[id]
+		if !ok {
+			s = &StrategyV1{Strategy: id}
+			strategies[id] = s
+		}
+		return s
+	}
+	var failures []FailureV1
+	for _, p := range snap.ProgressFiles {
+		for _, ev := range readProgressTail(p) {
+			key := attemptKey{ev.RunID, ev.AttemptID}
+			switch ev.Kind {
+			case "attempt_native_state":
+				state, _ := ev.Details["state"].(string)
+				strategy, _ := ev.Details["strategy"].(string)
+				prev := native[key]
+				since := parseTS(ev.TS)
+				if prev.state == state && !prev.since.IsZero() {
+					since = prev.since
+				}
+				native[key] = nativeView{state: state, strategy: strategy, since: since}
+				if state != nativeStateFailed || strategy == "" {
+					continue
+				}
+				code, _ := ev.Details["code"].(string)
+				reason, _ := ev.Details["reason"].(string)
+				s := strategyOf(strategy)
+				s.Failures++
+				s.LastCode = code
+				if code == codes.RuntimeRateLimit {
+					s.RateLimited++
+				}
+				failures = append(failures, FailureV1{At: ev.TS, Source: "progress", RunID: ev.RunID, MissionID: ev.MissionID, AttemptID: ev.AttemptID, Code: code, Reason: reason})
+			case "attempt_finished":
+				if ok, _ := ev.Details["ok"].(bool); ok {
+					continue
+				}
+				if native[key].state == nativeStateFailed {
+					continue // already reported with its native failure reason
+				}
+				code, _ := ev.Details["runnerErrorCode"].(string)
+				if code == "" {
+					code, _ = ev.Details["autoFeedbackCode"].(string)
+				}
+				failures = append(failures, FailureV1{At: ev.TS, Source: "progress", RunID: ev.RunID, MissionID: ev.MissionID, AttemptID: ev.AttemptID, Code: code, Reason: "attempt_failed"})
+			}
+		}
+	}
+
+	for i := range runs {
+		for j := range runs[i].InFlight {
+			a := &runs[i].InFlight[j]
+			nv, ok := native[attemptKey{runs[i].RunID, a.AttemptID}]
+			if !ok {
+				continue
+			}
+			a.NativeState, a.Strategy = nv.state, nv.strategy
+			if nv.strategy == "" {
+				continue
+			}
+			s := strategyOf(nv.strategy)
+			if nv.state == nativeStateQueued {
+				s.Waiting++
+				if !nv.since.IsZero() {
+					a.WaitingMs = now.Sub(nv.since).Milliseconds()
+				}
+			} else {
+				s.InFlight++
+			}
+		}
+	}
+	snap.Runs = runs
+
+	campaigns, campaignFailures := runningCampaigns(opts.OutRoot)
+	snap.Campaigns = campaigns
+	failures = append(failures, campaignFailures...)
+	sort.SliceStable(failures, func(i, j int) bool { return failures[i].At > failures[j].At })
+	if len(failures) > limit {
+		failures = failures[:limit]
+	}
+	if failures != nil {
+		snap.RecentFailures = failures
+	}
+
+	ids := make([]string, 0, len(strategies))
+	for id := range strategies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		snap.Strategies = append(snap.Strategies, *strategies[id])
+	}
+	return snap, nil
+}
+
+// activeRuns lists runs held by a live owner and their in-flight attempts, plus the progress
+// JSONL paths those runs were started with.
+func activeRuns(outRoot string, now time.Time) ([]RunV1, []string, error) {
+	runsDir := filepath.Join(outRoot, "runs")
+	entries, err := os.ReadDir(runsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []RunV1{}, nil, nil
+		}
+		return nil, nil, err
+	}
+	out := []RunV1{}
+	var progress []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		runDir := filepath.Join(runsDir, e.Name())
+		pid, live := store.RunOwner(runDir)
+		if !live {
+			continue
+		}
+		run := RunV1{RunID: e.Name(), PID: pid, InFlight: []AttemptV1{}}
+		var rj struct {
+			SuiteID string `json:"suiteId"`
+		}
+		if readJSON(filepath.Join(runDir, artifacts.RunJSON), &rj) {
+			run.SuiteID = rj.SuiteID
+		}
+		run.CampaignID = RunCampaignID(runDir)
+		if p := ProgressPath(runDir); p != "" {
+			progress = append(progress, p)
+		}
+		attempts, _ := os.ReadDir(filepath.Join(runDir, "attempts"))
+		for _, a := range attempts {
+			if !a.IsDir() {
+				continue
+			}
+			dir := filepath.Join(runDir, "attempts", a.Name())
+			if _, err := os.Stat(filepath.Join(dir, artifacts.AttemptReportJSON)); err == nil {
+				run.Finished++
+				continue
+			}
+			var aj schema.AttemptJSONV1
+			if !readJSON(filepath.Join(dir, artifacts.AttemptJSON), &aj) {
+				continue
+			}
+			att := AttemptV1{AttemptID: a.Name(), MissionID: aj.MissionID, StartedAt: aj.StartedAt}
+			if t := parseTS(aj.StartedAt); !t.IsZero() {
+				att.ElapsedMs = now.Sub(t).Milliseconds()
+			}
+			run.InFlight = append(run.InFlight, att)
+		}
+		out = append(out, run)
+	}
+	return out, progress, nil
+}
+
+// runningCampaigns reads campaign.run.state.json mirrors (written by both state backends) and the
+// failed checkpoints of running campaigns from campaign.progress.jsonl.
+func runningCampaigns(outRoot string) ([]CampaignV1, []FailureV1) {
+	dir := filepath.Join(outRoot, "campaigns")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []CampaignV1{}, nil
+	}
+	out := []CampaignV1{}
+	var failures []FailureV1
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		var st CampaignV1
+		if !readJSON(filepath.Join(dir, e.Name(), artifacts.CampaignRunStateJSON), &st) || st.Status != "running" {
+			continue
+		}
+		if st.CampaignID == "" {
+			st.CampaignID = e.Name()
+		}
+		out = append(out, st)
+		for _, line := range tailLines(filepath.Join(dir, e.Name(), artifacts.CampaignProgressJSONL)) {
+			var ev struct {
+				CampaignID  string   `json:"campaignId"`
+				RunID       string   `json:"runId"`
+				MissionID   string   `json:"missionId"`
+				AttemptID   string   `json:"attemptId"`
+				Status      string   `json:"status"`
+				ReasonCodes []string `json:"reasonCodes"`
+				CreatedAt   string   `json:"createdAt"`
+			}
+			if json.Unmarshal(line, &ev) != nil {
+				continue
+			}
+			switch ev.Status {
+			case "invalid", "infra_failed", "gate_fail":
+			default:
+				continue
+			}
+			f := FailureV1{At: ev.CreatedAt, Source: "campaign", CampaignID: st.CampaignID, RunID: ev.RunID, MissionID: ev.MissionID, AttemptID: ev.AttemptID, Reason: ev.Status}
+			if len(ev.ReasonCodes) > 0 {
+				f.Code = ev.ReasonCodes[0]
+			}
+			failures = append(failures, f)
+		}
+	}
+	return out, failures
+}
+
+// RunCampaignID is the campaign a run belongs to per its run.invocation.json (`--campaign-id`, else
+// ZCL_CAMPAIGN_ID), or "" for a standalone run.
+func RunCampaignID(runDir string) string {
+	var inv schema.RunInvocationJSONV1
+	if !readJSON(filepath.Join(runDir, artifacts.RunInvocationJSON), &inv) {
+		return ""
+	}
+	if id := argValue(inv.Argv, "--campaign-id"); id != "" {
+		return id
+	}
+	return inv.Env["ZCL_CAMPAIGN_ID"]
+}
+
+// ProgressPath is the suite run progress JSONL named by the run's run.invocation.json
+// (`--progress-jsonl`), or "" when the run wrote none or wrote it to stderr.
+func ProgressPath(runDir string) string {
+	var inv schema.RunInvocationJSONV1
+	if !readJSON(filepath.Join(runDir, artifacts.RunInvocationJSON), &inv) {
+		return ""
+	}
+	p := argValue(inv.Argv, "--progress-jsonl")
+	if p == "" || p == "-" {
+		return ""
+	}
+	if !filepath.IsAbs(p) && inv.Cwd != "" {
+		p = filepath.Join(inv.Cwd, p)
+	}
+	return p
+}
+
+// argValue returns the value of a `--name v` / `--name=v` flag in a recorded zcl argv, ignoring
+// the runner command after `--`.
+func argValue(argv []string, name string) string {
+	for i, a := range argv {
+		if a == "--" {
+			return ""
+		}
+		if v, ok := strings.CutPrefix(a, name+"="); ok {
+			return v
+		}
+		if a == name && i+1 < len(argv) {
+			return argv[i+1]
+		}
+	}
+	return ""
+}
+
+func readProgressTail(path string) []progressEvent {
+	var out []progressEvent
+	for _, line := range tailLines(path) {
+		var ev progressEvent
+		if json.Unmarshal(line, &ev) == nil && ev.Kind != "" {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// tailLines returns the complete lines in the last progressTailBytes of path.
+func tailLines(path string) [][]byte {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+	skipFirst := false
+	if info, err := f.Stat(); err == nil && info.Size() > progressTailBytes {
+		if _, err := f.Seek(-progressTailBytes, io.SeekEnd); err != nil {
+			return nil
+		}
+		skipFirst = true
+	}
+	var out [][]byte
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), progressTailBytes)
+	for sc.Scan() {
+		if skipFirst {
+			skipFirst = false
+			continue
+		}
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		out = append(out, append([]byte(nil), sc.Bytes()...))
+	}
+	return out
+}
+
+func readJSON(path string, v any) bool {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+func parseTS(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func dedupe(in []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, p := range in {
+		p = filepath.Clean(p)
+		if p == "." || seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out
+}
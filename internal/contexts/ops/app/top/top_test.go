@@ -0,0 +1,101 @@
+package top
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const runID = "20260222-120000Z-0a0b0c"
+
+func mustWrite(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestSnapshot_ActiveRunsStrategiesAndFailures(t *testing.T) {
+	outRoot := t.TempDir()
+	cwd := t.TempDir()
+	runDir := filepath.Join(outRoot, "runs", runID)
+	mustWrite(t, filepath.Join(runDir, "run.json"), `{"schemaVersion":1,"runId":"`+runID+`","suiteId":"s1"}`)
+	mustWrite(t, filepath.Join(runDir, "run.invocation.json"), `{"schemaVersion":1,"runId":"`+runID+`","cwd":"`+cwd+`","argv":["suite","run","--campaign-id","cmp-a","--progress-jsonl","progress.jsonl","--","--progress-jsonl","ignored"]}`)
+	mustWrite(t, filepath.Join(runDir, "attempts", "001-m1-r1", "attempt.json"), `{"schemaVersion":1,"missionId":"m1","startedAt":"2026-02-22T12:00:00Z"}`)
+	mustWrite(t, filepath.Join(runDir, "attempts", "001-m1-r1", "attempt.report.json"), `{}`)
+	mustWrite(t, filepath.Join(runDir, "attempts", "002-m2-r1", "attempt.json"), `{"schemaVersion":1,"missionId":"m2","startedAt":"2026-02-22T12:00:10Z"}`)
+	mustWrite(t, filepath.Join(runDir, "attempts", "003-m3-r1", "attempt.json"), `{"schemaVersion":1,"missionId":"m3","startedAt":"2026-02-22T12:00:20Z"}`)
+	mustWrite(t, filepath.Join(cwd, "progress.jsonl"), ""+
+		`{"v":1,"ts":"2026-02-22T12:00:05Z","kind":"attempt_native_state","runId":"`+runID+`","missionId":"m1","attemptId":"001-m1-r1","details":{"state":"failed","strategy":"codex_app_server","code":"ZCL_E_RUNTIME_RATE_LIMIT","reason":"turn_failed"}}`+"\n"+
+		`{"v":1,"ts":"2026-02-22T12:00:06Z","kind":"attempt_finished","runId":"`+runID+`","missionId":"m1","attemptId":"001-m1-r1","details":{"ok":false,"runnerErrorCode":"ZCL_E_RUNTIME_RATE_LIMIT"}}`+"\n"+
+		`{"v":1,"ts":"2026-02-22T12:00:10Z","kind":"attempt_native_state","runId":"`+runID+`","attemptId":"002-m2-r1","details":{"state":"turn_started","strategy":"codex_app_server"}}`+"\n"+
+		`{"v":1,"ts":"2026-02-22T12:00:20Z","kind":"attempt_native_state","runId":"`+runID+`","attemptId":"003-m3-r1","details":{"state":"queued","strategy":"codex_app_server"}}`+"\n")
+	mustWrite(t, filepath.Join(outRoot, "campaigns", "cmp-a", "campaign.run.state.json"), `{"schemaVersion":1,"campaignId":"cmp-a","status":"running","totalMissions":3,"missionsCompleted":1}`)
+	mustWrite(t, filepath.Join(outRoot, "campaigns", "cmp-a", "campaign.progress.jsonl"), `{"campaignId":"cmp-a","runId":"`+runID+`","missionId":"m0","status":"gate_fail","reasonCodes":["ZCL_E_CAMPAIGN_TRACE_GATE"],"createdAt":"2026-02-22T11:59:00Z"}`+"\n")
+	mustWrite(t, filepath.Join(outRoot, "campaigns", "cmp-done", "campaign.run.state.json"), `{"schemaVersion":1,"campaignId":"cmp-done","status":"valid"}`)
+	mustWrite(t, filepath.Join(outRoot, "runs", "20260222-110000Z-ffeedd", "run.json"), `{"schemaVersion":1}`)
+
+	release, err := store.AcquireRunOwner(runDir)
+	if err != nil {
+		t.Fatalf("AcquireRunOwner: %v", err)
+	}
+	defer func() { _ = release() }()
+
+	snap, err := Snapshot(Opts{OutRoot: outRoot, Now: time.Date(2026, 2, 22, 12, 0, 50, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if len(snap.Runs) != 1 {
+		t.Fatalf("expected only the owned run, got %+v", snap.Runs)
+	}
+	run := snap.Runs[0]
+	if run.SuiteID != "s1" || run.CampaignID != "cmp-a" || run.Finished != 1 || len(run.InFlight) != 2 || run.PID != os.Getpid() {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	if a := run.InFlight[1]; a.NativeState != "queued" || a.WaitingMs != 30000 || a.ElapsedMs != 30000 {
+		t.Fatalf("unexpected queued attempt: %+v", a)
+	}
+	if len(snap.Strategies) != 1 {
+		t.Fatalf("unexpected strategies: %+v", snap.Strategies)
+	}
+	if s := snap.Strategies[0]; s.InFlight != 1 || s.Waiting != 1 || s.Failures != 1 || s.RateLimited != 1 {
+		t.Fatalf("unexpected strategy health: %+v", s)
+	}
+	if len(snap.Campaigns) != 1 || snap.Campaigns[0].CampaignID != "cmp-a" || snap.Campaigns[0].MissionsCompleted != 1 {
+		t.Fatalf("unexpected campaigns: %+v", snap.Campaigns)
+	}
+	if len(snap.RecentFailures) != 2 || snap.RecentFailures[0].Reason != "turn_failed" || snap.RecentFailures[1].Source != "campaign" {
+		t.Fatalf("unexpected failures: %+v", snap.RecentFailures)
+	}
+}
+
+func TestStaleAttempts_FlagsOldHeartbeatsOfUnfinishedAttempts(t *testing.T) {
+	runDir := filepath.Join(t.TempDir(), "runs", runID)
+	beat := func(attemptID, beatAt, stoppedAt string) {
+		t.Helper()
+		dir := filepath.Join(runDir, "attempts", attemptID)
+		mustWrite(t, filepath.Join(dir, "attempt.json"), `{"schemaVersion":1,"missionId":"m-`+attemptID+`"}`)
+		mustWrite(t, filepath.Join(dir, "attempt.heartbeat.json"), `{"schemaVersion":1,"pid":42,"intervalMs":5000,"beatAt":"`+beatAt+`","stoppedAt":"`+stoppedAt+`"}`)
+	}
+	beat("001-hung", "2026-02-22T12:00:00Z", "")
+	beat("002-fresh", "2026-02-22T12:01:30Z", "")
+	beat("003-stopped", "2026-02-22T12:00:00Z", "2026-02-22T12:00:00Z")
+	beat("004-finished", "2026-02-22T12:00:00Z", "")
+	mustWrite(t, filepath.Join(runDir, "attempts", "004-finished", "attempt.report.json"), `{}`)
+	mustWrite(t, filepath.Join(runDir, "attempts", "005-no-heartbeat", "attempt.json"), `{"schemaVersion":1}`)
+
+	now := time.Date(2026, 2, 22, 12, 2, 0, 0, time.UTC)
+	stale := StaleAttempts(runDir, now, 0)
+	if len(stale) != 1 || stale[0].AttemptID != "001-hung" || stale[0].MissionID != "m-001-hung" || stale[0].HeartbeatAgeMs != 120000 || stale[0].PID != 42 {
+		t.Fatalf("unexpected stale attempts: %+v", stale)
+	}
+	if stale := StaleAttempts(runDir, now, 3*time.Minute); len(stale) != 0 {
+		t.Fatalf("expected no stale attempts under a 3m threshold, got %+v", stale)
+	}
+}
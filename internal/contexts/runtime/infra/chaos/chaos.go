@@ -0,0 +1,293 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+	"gopkg.in/yaml.v3"
+)
+
+// ProfileEnv points suite run at a chaos profile when --chaos is not given (campaign flows set it
+// through runner.env).
+const ProfileEnv = "ZCL_CHAOS_PROFILE"
+
+// Event names injected faults are delivered as; they are the codex names the suite run native
+// path already classifies.
+const (
+	EventStreamDisconnected = "codex/event/stream_disconnected"
+	EventRuntimeCrashed     = "codex/event/runtime_crashed"
+)
+
+// Profile lists the faults to inject into native sessions. Each fault fires independently per
+// session with its probability; rolls are seeded from Seed and the attempt id, so the same
+// profile injects the same faults into the same attempts on every run.
+type Profile struct {
+	SchemaVersion int   `json:"schemaVersion" yaml:"schemaVersion"`
+	Seed          int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+	// StreamDisconnect ends the turn with a stream disconnect DelayMs after it started.
+	StreamDisconnect *Fault `json:"streamDisconnect,omitempty" yaml:"streamDisconnect,omitempty"`
+	// SlowTurn holds turn start for DelayMs (bounded by the attempt deadline).
+	SlowTurn *Fault `json:"slowTurn,omitempty" yaml:"slowTurn,omitempty"`
+	// RateLimit fails turn start with a rate limit error.
+	RateLimit *Fault `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
+	// Crash ends the turn with a runtime crash DelayMs after it started.
+	Crash *Fault `json:"crash,omitempty" yaml:"crash,omitempty"`
+}
+
+type Fault struct {
+	Probability float64 `json:"probability" yaml:"probability"`
+	DelayMs     int64   `json:"delayMs,omitempty" yaml:"delayMs,omitempty"`
+}
+
+// LoadProfile reads a .json/.yaml/.yml chaos profile and validates it.
+func LoadProfile(path string) (Profile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	var p Profile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &p); err != nil {
+			return Profile{}, fmt.Errorf("invalid chaos profile yaml: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return Profile{}, fmt.Errorf("invalid chaos profile json: %w", err)
+		}
+	}
+	if err := p.Validate(); err != nil {
+		return Profile{}, err
+	}
+	return p, nil
+}
+
+func (p Profile) Validate() error {
+	if p.SchemaVersion != 1 {
+		return fmt.Errorf("chaos profile: unsupported schemaVersion %d (expected 1)", p.SchemaVersion)
+	}
+	faults := p.faults()
+	if len(faults) == 0 {
+		return fmt.Errorf("chaos profile: needs at least one of streamDisconnect|slowTurn|rateLimit|crash")
+	}
+	for name, f := range faults {
+		if f.Probability < 0 || f.Probability > 1 {
+			return fmt.Errorf("chaos profile %s: probability must be within [0,1]", name)
+		}
+		if f.DelayMs < 0 {
+			return fmt.Errorf("chaos profile %s: delayMs must be >= 0", name)
+		}
+	}
+	if p.SlowTurn != nil && p.SlowTurn.DelayMs == 0 {
+		return fmt.Errorf("chaos profile slowTurn: delayMs is required")
+	}
+	return nil
+}
+
+func (p Profile) faults() map[string]*Fault {
+	out := map[string]*Fault{}
+	for name, f := range map[string]*Fault{
+		"streamDisconnect": p.StreamDisconnect,
+		"slowTurn":         p.SlowTurn,
+		"rateLimit":        p.RateLimit,
+		"crash":            p.Crash,
+	} {
+		if f != nil {
+			out[name] = f
+		}
+	}
+	return out
+}
+
+// plan is the set of faults rolled for one session.
+type plan struct {
+	rateLimit bool
+	slowTurn  time.Duration
+	// terminal is the event that ends the turn early ("" for none), after terminalAfter.
+	terminal      string
+	terminalKind  native.ErrorKind
+	terminalAfter time.Duration
+}
+
+func (p Profile) roll(attemptID string) plan {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(attemptID))
+	rng := rand.New(rand.NewPCG(uint64(p.Seed), h.Sum64()))
+	hit := func(f *Fault) bool {
+		// Roll even for absent faults so adding one does not shift the others' outcomes.
+		v := rng.Float64()
+		return f != nil && v < f.Probability
+	}
+	var out plan
+	out.rateLimit = hit(p.RateLimit)
+	if hit(p.SlowTurn) {
+		out.slowTurn = time.Duration(p.SlowTurn.DelayMs) * time.Millisecond
+	}
+	crash, disconnect := hit(p.Crash), hit(p.StreamDisconnect)
+	switch {
+	case crash:
+		out.terminal, out.terminalKind = EventRuntimeCrashed, native.ErrorCrash
+		out.terminalAfter = time.Duration(p.Crash.DelayMs) * time.Millisecond
+	case disconnect:
+		out.terminal, out.terminalKind = EventStreamDisconnected, native.ErrorStreamDisconnect
+		out.terminalAfter = time.Duration(p.StreamDisconnect.DelayMs) * time.Millisecond
+	}
+	return out
+}
+
+// Runtime wraps a native runtime and injects the profile's faults into its sessions. It keeps
+// the wrapped runtime's id and capabilities, so selection, health and scheduling are unchanged.
+type Runtime struct {
+	inner   native.Runtime
+	profile Profile
+}
+
+func Wrap(inner native.Runtime, p Profile) *Runtime {
+	return &Runtime{inner: inner, profile: p}
+}
+
+func (r *Runtime) ID() native.StrategyID {
+	return r.inner.ID()
+}
+
+func (r *Runtime) Capabilities() native.Capabilities {
+	return r.inner.Capabilities()
+}
+
+func (r *Runtime) Probe(ctx context.Context) error {
+	return r.inner.Probe(ctx)
+}
+
+func (r *Runtime) StartSession(ctx context.Context, opts native.SessionOptions) (native.Session, error) {
+	sess, err := r.inner.StartSession(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &session{
+		Session:   sess,
+		plan:      r.profile.roll(opts.AttemptID),
+		listeners: map[string]native.EventListener{},
+	}, nil
+}
+
+// session forwards to the wrapped session; once a terminal fault fired, the wrapped session's
+// events are dropped so the injected failure is the last thing listeners see.
+type session struct {
+	native.Session
+	plan plan
+
+	mu        sync.Mutex
+	listeners map[string]native.EventListener
+	fired     bool
+	timer     *time.Timer
+}
+
+func (s *session) RuntimeVersion() string {
+	return native.SessionRuntimeVersion(s.Session)
+}
+
+func (s *session) AddListener(listener native.EventListener) (string, error) {
+	if listener == nil {
+		return s.Session.AddListener(nil)
+	}
+	id, err := s.Session.AddListener(func(ev native.Event) {
+		s.mu.Lock()
+		fired := s.fired
+		s.mu.Unlock()
+		if !fired {
+			listener(ev)
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.listeners[id] = listener
+	s.mu.Unlock()
+	return id, nil
+}
+
+func (s *session) RemoveListener(listenerID string) error {
+	s.mu.Lock()
+	delete(s.listeners, listenerID)
+	s.mu.Unlock()
+	return s.Session.RemoveListener(listenerID)
+}
+
+func (s *session) StartTurn(ctx context.Context, req native.TurnStartRequest) (native.TurnHandle, error) {
+	if s.plan.rateLimit {
+		err := native.NewError(native.ErrorRateLimit, "chaos: injected rate limit")
+		err.Retryable = true
+		return native.TurnHandle{}, err
+	}
+	if s.plan.slowTurn > 0 {
+		t := time.NewTimer(s.plan.slowTurn)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return native.TurnHandle{}, native.WrapError(native.ErrorTimeout, "chaos: slow turn exceeded the attempt deadline", ctx.Err())
+		}
+	}
+	turn, err := s.Session.StartTurn(ctx, req)
+	if err != nil || s.plan.terminal == "" {
+		return turn, err
+	}
+	threadID := turn.ThreadID
+	if threadID == "" {
+		threadID = req.ThreadID
+	}
+	s.mu.Lock()
+	s.timer = time.AfterFunc(s.plan.terminalAfter, func() { s.fire(threadID, turn.TurnID) })
+	s.mu.Unlock()
+	return turn, nil
+}
+
+func (s *session) fire(threadID, turnID string) {
+	payload, _ := json.Marshal(map[string]any{
+		"code":    native.ErrorCodeForKind(s.plan.terminalKind),
+		"message": "chaos: injected " + string(s.plan.terminalKind),
+		"chaos":   true,
+	})
+	ev := native.Event{
+		Name:       s.plan.terminal,
+		ThreadID:   threadID,
+		TurnID:     turnID,
+		ReceivedAt: time.Now().UTC(),
+		Payload:    payload,
+	}
+	s.mu.Lock()
+	if s.fired {
+		s.mu.Unlock()
+		return
+	}
+	s.fired = true
+	listeners := make([]native.EventListener, 0, len(s.listeners))
+	for _, l := range s.listeners {
+		listeners = append(listeners, l)
+	}
+	s.mu.Unlock()
+	// Stop the real turn first; whatever it emits in response is dropped.
+	_ = s.Session.InterruptTurn(context.Background(), native.TurnInterruptRequest{ThreadID: threadID, TurnID: turnID})
+	for _, l := range listeners {
+		l(ev)
+	}
+}
+
+func (s *session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.mu.Unlock()
+	return s.Session.Close(ctx)
+}
@@ -0,0 +1,169 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+)
+
+func TestLoadProfileValidates(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"empty.yaml":  "schemaVersion: 1\n",
+		"prob.yaml":   "schemaVersion: 1\ncrash: {probability: 1.5}\n",
+		"slow.yaml":   "schemaVersion: 1\nslowTurn: {probability: 0.5}\n",
+		"schema.json": `{"schemaVersion":2,"crash":{"probability":0.1}}`,
+	}
+	for name, body := range cases {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, err := LoadProfile(path); err == nil || !strings.Contains(err.Error(), "chaos profile") {
+			t.Fatalf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestRollIsReproduciblePerAttempt(t *testing.T) {
+	p := Profile{SchemaVersion: 1, Seed: 42, Crash: &Fault{Probability: 0.5}, RateLimit: &Fault{Probability: 0.5}}
+	hits := 0
+	for i := 0; i < 64; i++ {
+		id := "attempt-" + strconv.Itoa(i)
+		a, b := p.roll(id), p.roll(id)
+		if a != b {
+			t.Fatalf("roll for %s differs between calls: %+v vs %+v", id, a, b)
+		}
+		if a.terminal != "" {
+			hits++
+		}
+	}
+	if hits == 0 || hits == 64 {
+		t.Fatalf("expected a mix of crash rolls at probability 0.5, got %d/64", hits)
+	}
+}
+
+func TestSessionInjectsRateLimitAndCrash(t *testing.T) {
+	rt := Wrap(&fakeRuntime{}, Profile{SchemaVersion: 1, RateLimit: &Fault{Probability: 1}})
+	sess, err := rt.StartSession(context.Background(), native.SessionOptions{AttemptID: "a1"})
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	_, err = sess.StartTurn(context.Background(), native.TurnStartRequest{ThreadID: "t1"})
+	if nerr, ok := native.AsError(err); !ok || nerr.Kind != native.ErrorRateLimit || !nerr.Retryable {
+		t.Fatalf("expected retryable rate limit, got %v", err)
+	}
+
+	inner := &fakeRuntime{}
+	rt = Wrap(inner, Profile{SchemaVersion: 1, Crash: &Fault{Probability: 1, DelayMs: 5}})
+	sess, err = rt.StartSession(context.Background(), native.SessionOptions{AttemptID: "a2"})
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	events := make(chan native.Event, 4)
+	if _, err := sess.AddListener(func(ev native.Event) { events <- ev }); err != nil {
+		t.Fatalf("add listener: %v", err)
+	}
+	turn, err := sess.StartTurn(context.Background(), native.TurnStartRequest{ThreadID: "t1"})
+	if err != nil {
+		t.Fatalf("start turn: %v", err)
+	}
+	ev := <-events
+	if ev.Name != EventRuntimeCrashed || ev.TurnID != turn.TurnID {
+		t.Fatalf("expected injected crash for turn %s, got %+v", turn.TurnID, ev)
+	}
+	var payload map[string]any
+	_ = json.Unmarshal(ev.Payload, &payload)
+	if payload["code"] != native.ErrorCodeForKind(native.ErrorCrash) || payload["chaos"] != true {
+		t.Fatalf("unexpected crash payload: %s", ev.Payload)
+	}
+	if !inner.session.interrupted() {
+		t.Fatalf("expected the real turn to be interrupted")
+	}
+	inner.session.emit(native.Event{Name: "codex/event/turn_completed"})
+	select {
+	case ev := <-events:
+		t.Fatalf("event after injected crash leaked: %q", ev.Name)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+type fakeRuntime struct {
+	session *fakeSession
+}
+
+func (r *fakeRuntime) ID() native.StrategyID             { return native.StrategyProviderStub }
+func (r *fakeRuntime) Capabilities() native.Capabilities { return native.Capabilities{} }
+func (r *fakeRuntime) Probe(context.Context) error       { return nil }
+func (r *fakeRuntime) StartSession(context.Context, native.SessionOptions) (native.Session, error) {
+	r.session = &fakeSession{listeners: map[string]native.EventListener{}}
+	return r.session, nil
+}
+
+type fakeSession struct {
+	mu         sync.Mutex
+	listeners  map[string]native.EventListener
+	interrupts int
+}
+
+func (s *fakeSession) RuntimeID() native.StrategyID { return native.StrategyProviderStub }
+func (s *fakeSession) SessionID() string            { return "fake-session" }
+func (s *fakeSession) ThreadID() string             { return "t1" }
+func (s *fakeSession) StartThread(context.Context, native.ThreadStartRequest) (native.ThreadHandle, error) {
+	return native.ThreadHandle{ThreadID: "t1"}, nil
+}
+func (s *fakeSession) ResumeThread(context.Context, native.ThreadResumeRequest) (native.ThreadHandle, error) {
+	return native.ThreadHandle{ThreadID: "t1"}, nil
+}
+func (s *fakeSession) StartTurn(_ context.Context, req native.TurnStartRequest) (native.TurnHandle, error) {
+	return native.TurnHandle{TurnID: "turn-1", ThreadID: req.ThreadID}, nil
+}
+func (s *fakeSession) SteerTurn(context.Context, native.TurnSteerRequest) (native.TurnHandle, error) {
+	return native.TurnHandle{}, nil
+}
+func (s *fakeSession) InterruptTurn(context.Context, native.TurnInterruptRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interrupts++
+	return nil
+}
+func (s *fakeSession) AddListener(l native.EventListener) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := "l" + strconv.Itoa(len(s.listeners))
+	s.listeners[id] = l
+	return id, nil
+}
+func (s *fakeSession) RemoveListener(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listeners, id)
+	return nil
+}
+func (s *fakeSession) Close(context.Context) error { return nil }
+
+func (s *fakeSession) interrupted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.interrupts > 0
+}
+
+func (s *fakeSession) emit(ev native.Event) {
+	s.mu.Lock()
+	ls := make([]native.EventListener, 0, len(s.listeners))
+	for _, l := range s.listeners {
+		ls = append(ls, l)
+	}
+	s.mu.Unlock()
+	for _, l := range ls {
+		l(ev)
+	}
+}
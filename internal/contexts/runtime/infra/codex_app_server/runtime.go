@@ -152,6 +152,7 @@ func (r *Runtime) StartSession(ctx context.Context, opts native.SessionOptions)
 		done:            make(chan struct{}),
 		pending:         map[string]chan rpcResponse{},
 		listeners:       map[string]native.EventListener{},
+		mcpServers:      opts.MCPServers,
 	}
 
 	go s.readLoop()
@@ -202,6 +203,8 @@ type session struct {
 
 	initUserAgent string
 
+	mcpServers []native.MCPServer
+
 	closing atomic.Bool
 
 	terminalErrMu sync.RWMutex
@@ -214,6 +217,10 @@ func (s *session) RuntimeID() native.StrategyID {
 	return s.runtimeID
 }
 
+func (s *session) RuntimeVersion() string {
+	return parseVersionFromUserAgent(s.initUserAgent)
+}
+
 func (s *session) SessionID() string {
 	return fmt.Sprintf("pid:%d", s.cmd.Process.Pid)
 }
@@ -410,6 +417,7 @@ func (s *session) StartThread(ctx context.Context, req native.ThreadStartRequest
 	if err := s.applyReasoningEffortHint(ctx, req, params); err != nil {
 		return native.ThreadHandle{}, err
 	}
+	applyMCPServers(params, s.mcpServers)
 
 	res, err := s.callWithDefaultTimeout(ctx, "thread/start", params)
 	if err != nil {
@@ -446,9 +454,7 @@ func (s *session) applyReasoningEffortHint(ctx context.Context, req native.Threa
 		return nil
 	}
 	if supported {
-		params["config"] = map[string]any{
-			"model_reasoning_effort": effort,
-		}
+		threadConfig(params)["model_reasoning_effort"] = effort
 		return nil
 	}
 	if policy == reasoningPolicyRequired {
@@ -464,6 +470,34 @@ func (s *session) applyReasoningEffortHint(ctx context.Context, req native.Threa
 	return nil
 }
 
+// threadConfig returns the thread/start config overrides map, creating it on first use.
+func threadConfig(params map[string]any) map[string]any {
+	if cfg, ok := params["config"].(map[string]any); ok {
+		return cfg
+	}
+	cfg := map[string]any{}
+	params["config"] = cfg
+	return cfg
+}
+
+// applyMCPServers registers each server as an mcp_servers.<name> config override, leaving
+// servers from the user's codex config in place.
+func applyMCPServers(params map[string]any, servers []native.MCPServer) {
+	for _, srv := range servers {
+		if len(srv.Command) == 0 {
+			continue
+		}
+		entry := map[string]any{
+			"command": srv.Command[0],
+			"args":    append([]string{}, srv.Command[1:]...),
+		}
+		if len(srv.Env) > 0 {
+			entry["env"] = srv.Env
+		}
+		threadConfig(params)["mcp_servers."+srv.Name] = entry
+	}
+}
+
 func (s *session) resolveReasoningEffortSupport(ctx context.Context, requestedModel string, effort string) (bool, string, error) {
 	models, err := s.fetchModels(ctx)
 	if err != nil {
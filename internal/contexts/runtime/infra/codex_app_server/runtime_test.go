@@ -135,6 +135,21 @@ func TestDecodeEventMapsIDs(t *testing.T) {
 	}
 }
 
+func TestApplyMCPServersAddsThreadConfigOverrides(t *testing.T) {
+	params := map[string]any{}
+	threadConfig(params)["model_reasoning_effort"] = "high"
+	applyMCPServers(params, []native.MCPServer{{
+		Name:    "docs",
+		Command: []string{"zcl", "mcp", "proxy", "--", "docs-mcp", "--stdio"},
+		Env:     map[string]string{"ZCL_OUT_DIR": "/tmp/a"},
+	}})
+	raw, _ := json.Marshal(params)
+	want := `{"config":{"mcp_servers.docs":{"args":["mcp","proxy","--","docs-mcp","--stdio"],"command":"zcl","env":{"ZCL_OUT_DIR":"/tmp/a"}},"model_reasoning_effort":"high"}}`
+	if string(raw) != want {
+		t.Fatalf("unexpected thread/start params:\n got %s\nwant %s", raw, want)
+	}
+}
+
 func TestCodexAppServerHelperProcess(t *testing.T) {
 	if os.Getenv("ZCL_HELPER_PROCESS") != "1" {
 		return
@@ -0,0 +1,237 @@
+package providerstub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+)
+
+// Event names emitted by replay sessions (codex-compatible so existing listeners understand them).
+const (
+	ReplayEventToolResult    = "item/completed"
+	ReplayEventAgentMessage  = "codex/event/agent_message"
+	ReplayEventTurnCompleted = "codex/event/turn_completed"
+	ReplayEventTurnFailed    = "codex/event/turn_failed"
+)
+
+// ReplayScript is a recorded attempt to re-feed through a stub session: each tool result is
+// emitted as an item event, then the final agent message and a completed turn.
+type ReplayScript struct {
+	ToolResults  []json.RawMessage
+	FinalMessage string
+}
+
+// ReplayRuntime serves a ReplayScript instead of talking to a provider, so recorded attempts can
+// be pushed through the native session surface deterministically.
+type ReplayRuntime struct {
+	script ReplayScript
+}
+
+func NewReplayRuntime(script ReplayScript) *ReplayRuntime {
+	return &ReplayRuntime{script: script}
+}
+
+func (r *ReplayRuntime) ID() native.StrategyID {
+	return native.StrategyProviderStub
+}
+
+func (r *ReplayRuntime) Capabilities() native.Capabilities {
+	return native.Capabilities{
+		SupportsThreadStart: true,
+		SupportsInterrupt:   true,
+		SupportsEventStream: true,
+	}
+}
+
+func (r *ReplayRuntime) Probe(_ context.Context) error {
+	return nil
+}
+
+func (r *ReplayRuntime) StartSession(_ context.Context, opts native.SessionOptions) (native.Session, error) {
+	return newStubSession(opts.AttemptID, replaySteps(r.script)), nil
+}
+
+// replaySteps turns a recorded script into stub session steps.
+func replaySteps(script ReplayScript) []ScenarioStep {
+	steps := make([]ScenarioStep, 0, len(script.ToolResults)+2)
+	for i, raw := range script.ToolResults {
+		steps = append(steps, ScenarioStep{Event: ReplayEventToolResult, ItemID: "stub-item-" + strconv.Itoa(i+1), payload: raw})
+	}
+	msg, _ := json.Marshal(map[string]string{"type": "agent_message", "message": script.FinalMessage})
+	steps = append(steps,
+		ScenarioStep{Event: ReplayEventAgentMessage, payload: msg},
+		ScenarioStep{Event: ReplayEventTurnCompleted},
+	)
+	return steps
+}
+
+func newStubSession(attemptID string, steps []ScenarioStep) *stubSession {
+	return &stubSession{
+		steps:     steps,
+		sessionID: "stub-session-" + attemptID,
+		listeners: map[string]native.EventListener{},
+	}
+}
+
+// stubSession plays scripted steps as a turn; replay and scenario runtimes share it.
+type stubSession struct {
+	steps     []ScenarioStep
+	sessionID string
+
+	mu        sync.Mutex
+	threadID  string
+	turns     int
+	nextLID   int
+	listeners map[string]native.EventListener
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+func (s *stubSession) RuntimeID() native.StrategyID { return native.StrategyProviderStub }
+func (s *stubSession) SessionID() string            { return s.sessionID }
+
+func (s *stubSession) ThreadID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.threadID
+}
+
+func (s *stubSession) StartThread(_ context.Context, _ native.ThreadStartRequest) (native.ThreadHandle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.threadID = "stub-thread-1"
+	return native.ThreadHandle{ThreadID: s.threadID}, nil
+}
+
+func (s *stubSession) ResumeThread(_ context.Context, req native.ThreadResumeRequest) (native.ThreadHandle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.threadID = req.ThreadID
+	return native.ThreadHandle{ThreadID: s.threadID}, nil
+}
+
+// StartTurn plays the steps asynchronously; only one turn may be in flight.
+func (s *stubSession) StartTurn(_ context.Context, req native.TurnStartRequest) (native.TurnHandle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.threadID == "" || req.ThreadID != s.threadID {
+		return native.TurnHandle{}, native.NewError(native.ErrorProtocol, "provider_stub: unknown thread "+strconv.Quote(req.ThreadID))
+	}
+	if s.done != nil {
+		select {
+		case <-s.done:
+		default:
+			return native.TurnHandle{}, native.NewError(native.ErrorProtocol, "provider_stub: a turn is already in flight")
+		}
+	}
+	s.turns++
+	turnID := fmt.Sprintf("stub-turn-%d", s.turns)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.play(ctx, s.done, s.threadID, turnID)
+	return native.TurnHandle{TurnID: turnID, Status: "inProgress", ThreadID: s.threadID}, nil
+}
+
+func (s *stubSession) SteerTurn(_ context.Context, _ native.TurnSteerRequest) (native.TurnHandle, error) {
+	return native.TurnHandle{}, native.NewError(native.ErrorCapabilityUnsupported, "provider_stub cannot steer turns")
+}
+
+func (s *stubSession) InterruptTurn(_ context.Context, _ native.TurnInterruptRequest) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	return nil
+}
+
+func (s *stubSession) AddListener(listener native.EventListener) (string, error) {
+	if listener == nil {
+		return "", native.NewError(native.ErrorProtocol, "provider_stub: nil listener")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextLID++
+	id := "stub-listener-" + strconv.Itoa(s.nextLID)
+	s.listeners[id] = listener
+	return id, nil
+}
+
+func (s *stubSession) RemoveListener(listenerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listeners, listenerID)
+	return nil
+}
+
+func (s *stubSession) Close(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *stubSession) play(ctx context.Context, done chan struct{}, threadID, turnID string) {
+	defer close(done)
+	interrupted := func() {
+		s.emit(native.Event{Name: ReplayEventTurnFailed, ThreadID: threadID, TurnID: turnID, Payload: json.RawMessage(`{"reason":"interrupted"}`)})
+	}
+	for _, step := range s.steps {
+		if ctx.Err() != nil {
+			interrupted()
+			return
+		}
+		if step.DelayMs > 0 {
+			t := time.NewTimer(time.Duration(step.DelayMs) * time.Millisecond)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				interrupted()
+				return
+			case <-t.C:
+			}
+		}
+		ev, ends := step.event(threadID, turnID)
+		if step.Stall {
+			<-ctx.Done()
+			interrupted()
+			return
+		}
+		if ev.Name != "" {
+			s.emit(ev)
+		}
+		if ends {
+			return
+		}
+	}
+	s.emit(native.Event{Name: ReplayEventTurnCompleted, ThreadID: threadID, TurnID: turnID})
+}
+
+func (s *stubSession) emit(ev native.Event) {
+	ev.ReceivedAt = time.Now().UTC()
+	s.mu.Lock()
+	ls := make([]native.EventListener, 0, len(s.listeners))
+	for _, l := range s.listeners {
+		ls = append(ls, l)
+	}
+	s.mu.Unlock()
+	for _, l := range ls {
+		l(ev)
+	}
+}
@@ -2,9 +2,12 @@ package providerstub
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native/conformance"
 )
 
 func TestProviderStubProbeIsCapabilityUnsupported(t *testing.T) {
@@ -21,3 +24,40 @@ func TestProviderStubProbeIsCapabilityUnsupported(t *testing.T) {
 		t.Fatalf("unexpected kind: %q", nerr.Kind)
 	}
 }
+
+func TestReplayRuntimeFeedsScriptAndPassesConformance(t *testing.T) {
+	rt := NewReplayRuntime(ReplayScript{
+		ToolResults:  []json.RawMessage{json.RawMessage(`{"tool":"cli","op":"exec"}`), json.RawMessage(`{"tool":"mcp","op":"tools/call"}`)},
+		FinalMessage: "done",
+	})
+	if err := conformance.RunBasic(context.Background(), rt, conformance.BasicSuiteOptions{}); err != nil {
+		t.Fatalf("conformance: %v", err)
+	}
+
+	sess, err := rt.StartSession(context.Background(), native.SessionOptions{AttemptID: "001-m1-r1"})
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	events := make(chan native.Event, 8)
+	if _, err := sess.AddListener(func(ev native.Event) { events <- ev }); err != nil {
+		t.Fatalf("add listener: %v", err)
+	}
+	th, _ := sess.StartThread(context.Background(), native.ThreadStartRequest{})
+	if _, err := sess.StartTurn(context.Background(), native.TurnStartRequest{ThreadID: th.ThreadID}); err != nil {
+		t.Fatalf("start turn: %v", err)
+	}
+	var names []string
+	for ev := range events {
+		names = append(names, ev.Name)
+		if ev.Name == ReplayEventTurnCompleted {
+			break
+		}
+	}
+	want := []string{ReplayEventToolResult, ReplayEventToolResult, ReplayEventAgentMessage, ReplayEventTurnCompleted}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("events=%v want %v", names, want)
+	}
+	if err := sess.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+}
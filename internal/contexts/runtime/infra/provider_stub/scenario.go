@@ -0,0 +1,227 @@
+package providerstub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+	"gopkg.in/yaml.v3"
+)
+
+// ScenarioEnv points provider_stub at a scenario file; without it the strategy stays the
+// unsupported-capability skeleton.
+const ScenarioEnv = "ZCL_PROVIDER_STUB_SCENARIO"
+
+// Event names scenario steps emit for final answers and injected failures; they are the codex
+// names the suite run native path already classifies.
+const (
+	ScenarioEventTaskComplete       = "codex/event/task_complete"
+	ScenarioEventStreamDisconnected = "codex/event/stream_disconnected"
+	ScenarioEventRuntimeCrashed     = "codex/event/runtime_crashed"
+)
+
+// Scenario scripts provider_stub sessions per mission so integration tests and local dry-runs can
+// simulate agent behavior (events, delays, failures) without a real runtime binary.
+type Scenario struct {
+	SchemaVersion int `json:"schemaVersion" yaml:"schemaVersion"`
+	// Default is played for missions without their own entry.
+	Default  *ScenarioMission           `json:"default,omitempty" yaml:"default,omitempty"`
+	Missions map[string]ScenarioMission `json:"missions,omitempty" yaml:"missions,omitempty"`
+}
+
+type ScenarioMission struct {
+	// StartError fails StartSession instead of playing steps.
+	StartError *ScenarioFailure `json:"startError,omitempty" yaml:"startError,omitempty"`
+	Steps      []ScenarioStep   `json:"steps" yaml:"steps"`
+}
+
+type ScenarioFailure struct {
+	Kind    native.ErrorKind `json:"kind" yaml:"kind"`
+	Message string           `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// ScenarioStep waits DelayMs, then does at most one thing: emit Event (with Payload), finish
+// the turn with Message as the final answer, inject Fail, or Stall until interrupted. A turn
+// whose steps run out without finishing ends with turn_completed.
+type ScenarioStep struct {
+	DelayMs int64            `json:"delayMs,omitempty" yaml:"delayMs,omitempty"`
+	Event   string           `json:"event,omitempty" yaml:"event,omitempty"`
+	ItemID  string           `json:"itemId,omitempty" yaml:"itemId,omitempty"`
+	Payload any              `json:"payload,omitempty" yaml:"payload,omitempty"`
+	Message string           `json:"message,omitempty" yaml:"message,omitempty"`
+	Fail    *ScenarioFailure `json:"fail,omitempty" yaml:"fail,omitempty"`
+	Stall   bool             `json:"stall,omitempty" yaml:"stall,omitempty"`
+
+	payload json.RawMessage
+}
+
+// LoadScenario reads a .json/.yaml/.yml scenario file and validates it.
+func LoadScenario(path string) (Scenario, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	var sc Scenario
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &sc); err != nil {
+			return Scenario{}, fmt.Errorf("invalid scenario yaml: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(raw, &sc); err != nil {
+			return Scenario{}, fmt.Errorf("invalid scenario json: %w", err)
+		}
+	}
+	if err := sc.normalize(); err != nil {
+		return Scenario{}, err
+	}
+	return sc, nil
+}
+
+func (sc *Scenario) normalize() error {
+	if sc.SchemaVersion != 1 {
+		return fmt.Errorf("scenario: unsupported schemaVersion %d (expected 1)", sc.SchemaVersion)
+	}
+	if sc.Default == nil && len(sc.Missions) == 0 {
+		return fmt.Errorf("scenario: needs default or missions")
+	}
+	if sc.Default != nil {
+		if err := sc.Default.normalize("default"); err != nil {
+			return err
+		}
+	}
+	for id, m := range sc.Missions {
+		if err := m.normalize("missions." + id); err != nil {
+			return err
+		}
+		sc.Missions[id] = m
+	}
+	return nil
+}
+
+func (m *ScenarioMission) normalize(where string) error {
+	if m.StartError != nil && strings.TrimSpace(string(m.StartError.Kind)) == "" {
+		return fmt.Errorf("scenario %s: startError.kind is required", where)
+	}
+	for i := range m.Steps {
+		st := &m.Steps[i]
+		actions := 0
+		for _, set := range []bool{st.Event != "", st.Message != "", st.Fail != nil, st.Stall} {
+			if set {
+				actions++
+			}
+		}
+		if actions > 1 {
+			return fmt.Errorf("scenario %s.steps[%d]: set only one of event|message|fail|stall", where, i)
+		}
+		if st.DelayMs < 0 {
+			return fmt.Errorf("scenario %s.steps[%d]: delayMs must be >= 0", where, i)
+		}
+		if st.Fail != nil && strings.TrimSpace(string(st.Fail.Kind)) == "" {
+			return fmt.Errorf("scenario %s.steps[%d]: fail.kind is required", where, i)
+		}
+		if st.Payload != nil {
+			b, err := json.Marshal(st.Payload)
+			if err != nil {
+				return fmt.Errorf("scenario %s.steps[%d]: payload: %w", where, i, err)
+			}
+			st.payload = b
+		}
+	}
+	return nil
+}
+
+// event builds the native event for the step and reports whether it ends the turn.
+func (st ScenarioStep) event(threadID, turnID string) (native.Event, bool) {
+	ev := native.Event{ThreadID: threadID, TurnID: turnID, ItemID: st.ItemID}
+	switch {
+	case st.Message != "":
+		ev.Name = ScenarioEventTaskComplete
+		ev.Payload, _ = json.Marshal(map[string]string{"type": "task_complete", "turn_id": turnID, "last_agent_message": st.Message})
+		return ev, true
+	case st.Fail != nil:
+		switch st.Fail.Kind {
+		case native.ErrorStreamDisconnect:
+			ev.Name = ScenarioEventStreamDisconnected
+		case native.ErrorCrash:
+			ev.Name = ScenarioEventRuntimeCrashed
+		default:
+			ev.Name = ReplayEventTurnFailed
+		}
+		ev.Payload, _ = json.Marshal(map[string]string{"code": native.ErrorCodeForKind(st.Fail.Kind), "message": st.Fail.Message})
+		return ev, true
+	case st.Event != "":
+		ev.Name = st.Event
+		ev.Payload = st.payload
+		switch st.Event {
+		case ReplayEventTurnCompleted, ReplayEventTurnFailed, ScenarioEventTaskComplete:
+			return ev, true
+		}
+	}
+	return ev, false
+}
+
+// ScenarioRuntime is provider_stub driven by a Scenario: sessions play the steps scripted for
+// their mission.
+type ScenarioRuntime struct {
+	scenario Scenario
+	loadErr  error
+}
+
+func NewScenarioRuntime(sc Scenario) *ScenarioRuntime {
+	return &ScenarioRuntime{scenario: sc}
+}
+
+// RuntimeFromEnv returns the scenario runtime when ScenarioEnv is set and the skeleton otherwise.
+// A scenario that fails to load surfaces as a startup error from Probe/StartSession.
+func RuntimeFromEnv() native.Runtime {
+	path := strings.TrimSpace(os.Getenv(ScenarioEnv))
+	if path == "" {
+		return NewRuntime()
+	}
+	sc, err := LoadScenario(path)
+	if err != nil {
+		return &ScenarioRuntime{loadErr: err}
+	}
+	return NewScenarioRuntime(sc)
+}
+
+func (r *ScenarioRuntime) ID() native.StrategyID {
+	return native.StrategyProviderStub
+}
+
+func (r *ScenarioRuntime) Capabilities() native.Capabilities {
+	return native.Capabilities{
+		SupportsThreadStart: true,
+		SupportsInterrupt:   true,
+		SupportsEventStream: true,
+	}
+}
+
+func (r *ScenarioRuntime) Probe(_ context.Context) error {
+	if r.loadErr != nil {
+		return native.WrapError(native.ErrorStartup, "provider_stub scenario", r.loadErr)
+	}
+	return nil
+}
+
+func (r *ScenarioRuntime) StartSession(ctx context.Context, opts native.SessionOptions) (native.Session, error) {
+	if err := r.Probe(ctx); err != nil {
+		return nil, err
+	}
+	m, ok := r.scenario.Missions[opts.MissionID]
+	if !ok {
+		if r.scenario.Default == nil {
+			return nil, native.NewError(native.ErrorProtocol, "provider_stub scenario has no steps for mission "+opts.MissionID)
+		}
+		m = *r.scenario.Default
+	}
+	if m.StartError != nil {
+		return nil, native.NewError(m.StartError.Kind, m.StartError.Message)
+	}
+	return newStubSession(opts.AttemptID, m.Steps), nil
+}
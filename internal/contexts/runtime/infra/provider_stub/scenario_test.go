@@ -0,0 +1,60 @@
+package providerstub
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+)
+
+func TestLoadScenarioRejectsAmbiguousSteps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(path, []byte(`{"schemaVersion":1,"default":{"steps":[{"message":"x","stall":true}]}}`), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := LoadScenario(path); err == nil || !strings.Contains(err.Error(), "only one of") {
+		t.Fatalf("expected ambiguous step error, got %v", err)
+	}
+}
+
+func TestScenarioRuntimeStartErrorAndStall(t *testing.T) {
+	rt := NewScenarioRuntime(Scenario{
+		SchemaVersion: 1,
+		Missions: map[string]ScenarioMission{
+			"auth":  {StartError: &ScenarioFailure{Kind: native.ErrorAuth, Message: "expired"}},
+			"stall": {Steps: []ScenarioStep{{Stall: true}}},
+		},
+	})
+	_, err := rt.StartSession(context.Background(), native.SessionOptions{MissionID: "auth"})
+	if nerr, ok := native.AsError(err); !ok || nerr.Kind != native.ErrorAuth {
+		t.Fatalf("expected auth start error, got %v", err)
+	}
+	if _, err := rt.StartSession(context.Background(), native.SessionOptions{MissionID: "other"}); err == nil {
+		t.Fatalf("expected error for a mission without steps")
+	}
+
+	sess, err := rt.StartSession(context.Background(), native.SessionOptions{MissionID: "stall", AttemptID: "001-stall-r1"})
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	events := make(chan native.Event, 4)
+	_, _ = sess.AddListener(func(ev native.Event) { events <- ev })
+	th, _ := sess.StartThread(context.Background(), native.ThreadStartRequest{})
+	turn, err := sess.StartTurn(context.Background(), native.TurnStartRequest{ThreadID: th.ThreadID})
+	if err != nil {
+		t.Fatalf("start turn: %v", err)
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("stalled turn emitted %q", ev.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	_ = sess.InterruptTurn(context.Background(), native.TurnInterruptRequest{ThreadID: th.ThreadID, TurnID: turn.TurnID})
+	if ev := <-events; ev.Name != ReplayEventTurnFailed {
+		t.Fatalf("expected turn_failed after interrupt, got %q", ev.Name)
+	}
+}
@@ -49,6 +49,15 @@ func RecordHealth(strategy StrategyID, metric HealthMetric) {
 	row[metric]++
 }
 
+// HealthCount returns one metric's counter for a strategy (0 when never recorded).
+func HealthCount(strategy StrategyID, metric HealthMetric) int64 {
+	strategy = StrategyID(strings.TrimSpace(strings.ToLower(string(strategy))))
+	metric = HealthMetric(strings.TrimSpace(strings.ToLower(string(metric))))
+	healthMu.RLock()
+	defer healthMu.RUnlock()
+	return healthStore[strategy][metric]
+}
+
 func HealthSnapshot() []HealthStrategySnapshot {
 	healthMu.RLock()
 	defer healthMu.RUnlock()
@@ -63,6 +63,15 @@ type SessionOptions struct {
 	AttemptID  string
 	AttemptDir string
 	Env        map[string]string
+	// MCPServers are registered with the session's threads by runtimes that host MCP clients.
+	MCPServers []MCPServer
+}
+
+// MCPServer is a stdio MCP server the runtime launches for the agent.
+type MCPServer struct {
+	Name    string
+	Command []string
+	Env     map[string]string
 }
 
 type Session interface {
@@ -82,6 +91,19 @@ type Session interface {
 	Close(ctx context.Context) error
 }
 
+// VersionedSession is implemented by sessions that learn the runtime version during startup.
+type VersionedSession interface {
+	RuntimeVersion() string
+}
+
+// SessionRuntimeVersion returns the runtime version reported by sess, or "" when unknown.
+func SessionRuntimeVersion(sess Session) string {
+	if v, ok := sess.(VersionedSession); ok {
+		return strings.TrimSpace(v.RuntimeVersion())
+	}
+	return ""
+}
+
 type ThreadHandle struct {
 	ThreadID string `json:"threadId"`
 }
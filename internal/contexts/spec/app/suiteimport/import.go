@@ -0,0 +1,363 @@
+// Package suiteimport converts external eval datasets into ZCL suite files, so existing
+// benchmark assets can run under suite run/campaign evidence gates.
+package suiteimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+)
+
+const (
+	FormatOpenAIEvals = "openai-evals"
+	FormatInspectAI   = "inspect-ai"
+	FormatCustomJSONL = "custom-jsonl"
+
+	// MatchIncludes passes when the result contains any expected answer (openai evals "Includes").
+	MatchIncludes = "includes"
+	// MatchExact passes when the trimmed result equals one expected answer.
+	MatchExact = "exact"
+)
+
+// maxLineBytes bounds one dataset line; long few-shot prompts fit, binary junk does not.
+const maxLineBytes = 8 << 20
+
+func Formats() []string {
+	return []string{FormatOpenAIEvals, FormatInspectAI, FormatCustomJSONL}
+}
+
+// Fields names the keys custom-jsonl samples are read from.
+type Fields struct {
+	ID     string
+	Prompt string
+	Answer string
+	Tags   string
+}
+
+func DefaultFields() Fields {
+	return Fields{ID: "id", Prompt: "prompt", Answer: "answer", Tags: "tags"}
+}
+
+type Options struct {
+	Format  string
+	SuiteID string
+	// Match is includes|exact (default includes).
+	Match string
+	// Limit keeps only the first N samples (0 = all).
+	Limit  int
+	Fields Fields
+}
+
+// Result is the converted suite plus what was dropped on the way.
+type Result struct {
+	Suite suite.SuiteFileV1
+	// Samples is the number of dataset samples read (before Limit).
+	Samples int
+	// Unscored lists missions imported without an expected answer (they only check feedback.ok).
+	Unscored []string
+}
+
+// sample is one dataset row normalized across formats.
+type sample struct {
+	line    int
+	id      string
+	prompt  string
+	answers []string
+	tags    []string
+}
+
+// Convert reads a dataset (JSONL, or a JSON array for inspect-ai) and returns a suite with one
+// mission per sample.
+func Convert(r io.Reader, opts Options) (Result, error) {
+	opts.Format = strings.ToLower(strings.TrimSpace(opts.Format))
+	opts.Match = strings.ToLower(strings.TrimSpace(opts.Match))
+	if opts.Match == "" {
+		opts.Match = MatchIncludes
+	}
+	if opts.Match != MatchIncludes && opts.Match != MatchExact {
+		return Result{}, fmt.Errorf("invalid match %q (expected includes|exact)", opts.Match)
+	}
+	if opts.Fields == (Fields{}) {
+		opts.Fields = DefaultFields()
+	}
+	suiteID := ids.SanitizeComponent(opts.SuiteID)
+	if suiteID == "" {
+		return Result{}, fmt.Errorf("invalid suite id (no usable characters)")
+	}
+
+	var decode func(line int, raw map[string]any) (sample, error)
+	switch opts.Format {
+	case FormatOpenAIEvals:
+		decode = decodeOpenAIEvalsSample
+	case FormatInspectAI:
+		decode = decodeInspectAISample
+	case FormatCustomJSONL:
+		decode = func(line int, raw map[string]any) (sample, error) { return decodeCustomSample(line, raw, opts.Fields) }
+	default:
+		return Result{}, fmt.Errorf("unknown format %q (expected %s)", opts.Format, strings.Join(Formats(), "|"))
+	}
+
+	rows, err := readRows(r)
+	if err != nil {
+		return Result{}, err
+	}
+	if len(rows) == 0 {
+		return Result{}, fmt.Errorf("dataset has no samples")
+	}
+	res := Result{Samples: len(rows), Suite: suite.SuiteFileV1{Version: 1, SuiteID: suiteID}}
+	if opts.Limit > 0 && len(rows) > opts.Limit {
+		rows = rows[:opts.Limit]
+	}
+	seen := map[string]bool{}
+	width := len(fmt.Sprint(len(rows)))
+	for i, row := range rows {
+		s, err := decode(row.line, row.obj)
+		if err != nil {
+			return Result{}, err
+		}
+		if strings.TrimSpace(s.prompt) == "" {
+			return Result{}, fmt.Errorf("line %d: empty prompt", row.line)
+		}
+		id := ids.SanitizeComponent(s.id)
+		if id == "" {
+			id = fmt.Sprintf("sample-%0*d", width, i+1)
+		}
+		for base, n := id, 2; seen[id]; n++ {
+			id = fmt.Sprintf("%s-%d", base, n)
+		}
+		seen[id] = true
+		m := suite.MissionV1{MissionID: id, Prompt: s.prompt, Tags: s.tags}
+		if exp := answerExpects(s.answers, opts.Match); exp != nil {
+			m.Expects = exp
+		} else {
+			res.Unscored = append(res.Unscored, id)
+		}
+		res.Suite.Missions = append(res.Suite.Missions, m)
+	}
+	return res, nil
+}
+
+type row struct {
+	line int
+	obj  map[string]any
+}
+
+// readRows accepts JSONL (one object per line, blank lines ignored) or a single JSON array.
+func readRows(r io.Reader) ([]row, error) {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		_, _ = br.Discard(3)
+	}
+	head, _ := br.Peek(512)
+	if t := bytes.TrimLeft(head, " \t\r\n"); len(t) > 0 && t[0] == '[' {
+		var arr []map[string]any
+		if err := json.NewDecoder(br).Decode(&arr); err != nil {
+			return nil, fmt.Errorf("invalid json array: %w", err)
+		}
+		out := make([]row, 0, len(arr))
+		for i, obj := range arr {
+			out = append(out, row{line: i + 1, obj: obj})
+		}
+		return out, nil
+	}
+	sc := bufio.NewScanner(br)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	var out []row
+	for n := 1; sc.Scan(); n++ {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var obj map[string]any
+		if err := json.Unmarshal(line, &obj); err != nil {
+			return nil, fmt.Errorf("line %d: invalid json: %w", n, err)
+		}
+		out = append(out, row{line: n, obj: obj})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decodeOpenAIEvalsSample reads an openai/evals registry sample: input is a string or chat
+// messages, ideal is a string or list of acceptable answers.
+func decodeOpenAIEvalsSample(line int, raw map[string]any) (sample, error) {
+	prompt, err := renderInput(raw["input"])
+	if err != nil {
+		return sample{}, fmt.Errorf("line %d: input: %w", line, err)
+	}
+	answers, err := stringList(raw["ideal"])
+	if err != nil {
+		return sample{}, fmt.Errorf("line %d: ideal: %w", line, err)
+	}
+	return sample{line: line, prompt: prompt, answers: answers}, nil
+}
+
+// decodeInspectAISample reads an Inspect AI dataset sample (input, target, optional id and
+// choices; multiple-choice options are appended to the prompt as lettered lines).
+func decodeInspectAISample(line int, raw map[string]any) (sample, error) {
+	prompt, err := renderInput(raw["input"])
+	if err != nil {
+		return sample{}, fmt.Errorf("line %d: input: %w", line, err)
+	}
+	choices, err := stringList(raw["choices"])
+	if err != nil {
+		return sample{}, fmt.Errorf("line %d: choices: %w", line, err)
+	}
+	if len(choices) > 0 {
+		var b strings.Builder
+		b.WriteString(prompt)
+		b.WriteString("\n")
+		for i, c := range choices {
+			fmt.Fprintf(&b, "\n%c) %s", 'A'+rune(i%26), c)
+		}
+		prompt = b.String()
+	}
+	answers, err := stringList(raw["target"])
+	if err != nil {
+		return sample{}, fmt.Errorf("line %d: target: %w", line, err)
+	}
+	return sample{line: line, id: scalarString(raw["id"]), prompt: prompt, answers: answers}, nil
+}
+
+func decodeCustomSample(line int, raw map[string]any, f Fields) (sample, error) {
+	v, ok := raw[f.Prompt]
+	if !ok {
+		return sample{}, fmt.Errorf("line %d: missing %q field", line, f.Prompt)
+	}
+	prompt, err := renderInput(v)
+	if err != nil {
+		return sample{}, fmt.Errorf("line %d: %s: %w", line, f.Prompt, err)
+	}
+	answers, err := stringList(raw[f.Answer])
+	if err != nil {
+		return sample{}, fmt.Errorf("line %d: %s: %w", line, f.Answer, err)
+	}
+	tags, err := stringList(raw[f.Tags])
+	if err != nil {
+		return sample{}, fmt.Errorf("line %d: %s: %w", line, f.Tags, err)
+	}
+	return sample{line: line, id: scalarString(raw[f.ID]), prompt: prompt, answers: answers, tags: tags}, nil
+}
+
+// renderInput flattens a prompt that is either a string or chat messages ([{role, content}]).
+// A lone user message is used verbatim; otherwise each message is prefixed with its role.
+func renderInput(v any) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t), nil
+	case []any:
+		type msg struct{ role, content string }
+		var msgs []msg
+		for i, it := range t {
+			obj, ok := it.(map[string]any)
+			if !ok {
+				return "", fmt.Errorf("message %d is not an object", i)
+			}
+			content, err := renderContent(obj["content"])
+			if err != nil {
+				return "", fmt.Errorf("message %d: %w", i, err)
+			}
+			msgs = append(msgs, msg{role: scalarString(obj["role"]), content: content})
+		}
+		if len(msgs) == 1 && (msgs[0].role == "" || msgs[0].role == "user") {
+			return msgs[0].content, nil
+		}
+		parts := make([]string, 0, len(msgs))
+		for _, m := range msgs {
+			role := m.role
+			if role == "" {
+				role = "user"
+			}
+			parts = append(parts, role+": "+m.content)
+		}
+		return strings.Join(parts, "\n\n"), nil
+	case nil:
+		return "", fmt.Errorf("missing")
+	default:
+		return "", fmt.Errorf("expected string or message list")
+	}
+}
+
+// renderContent accepts string content or a list of content parts ({type:text,text}).
+func renderContent(v any) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t), nil
+	case []any:
+		var parts []string
+		for _, it := range t {
+			if obj, ok := it.(map[string]any); ok {
+				if s, ok := obj["text"].(string); ok {
+					parts = append(parts, strings.TrimSpace(s))
+					continue
+				}
+			}
+			return "", fmt.Errorf("unsupported content part (only text parts are imported)")
+		}
+		return strings.Join(parts, "\n"), nil
+	default:
+		return "", fmt.Errorf("expected string content")
+	}
+}
+
+func stringList(v any) ([]string, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		out := make([]string, 0, len(t))
+		for _, it := range t {
+			s := scalarString(it)
+			if s == "" {
+				return nil, fmt.Errorf("expected a list of strings")
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		if s := scalarString(t); s != "" {
+			return []string{s}, nil
+		}
+		return nil, fmt.Errorf("expected string or list of strings")
+	}
+}
+
+func scalarString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case float64, bool:
+		return strings.TrimSpace(fmt.Sprint(t))
+	default:
+		return ""
+	}
+}
+
+// answerExpects maps expected answers onto result expectations: includes -> resultMatches
+// (any answer as a literal substring), exact -> an anchored result.pattern alternation.
+func answerExpects(answers []string, match string) *suite.ExpectsV1 {
+	if len(answers) == 0 {
+		return nil
+	}
+	ok := true
+	quoted := make([]string, 0, len(answers))
+	for _, a := range answers {
+		quoted = append(quoted, regexp.QuoteMeta(a))
+	}
+	exp := &suite.ExpectsV1{OK: &ok}
+	if match == MatchExact {
+		exp.Result = &suite.ResultExpectsV1{Type: "string", Pattern: `^\s*(?:` + strings.Join(quoted, "|") + `)\s*$`}
+	} else {
+		exp.ResultMatches = "(?:" + strings.Join(quoted, "|") + ")"
+	}
+	return exp
+}
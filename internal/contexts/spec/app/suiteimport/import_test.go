@@ -0,0 +1,83 @@
+package suiteimport
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvert_OpenAIEvals(t *testing.T) {
+	t.Parallel()
+
+	in := `{"input": [{"role": "system", "content": "Answer tersely."}, {"role": "user", "content": "Capital of France?"}], "ideal": ["Paris", "paris."]}
+
+{"input": [{"role": "user", "content": "2+2?"}], "ideal": "4"}
+{"input": "Say hi"}
+`
+	res, err := Convert(strings.NewReader(in), Options{Format: FormatOpenAIEvals, SuiteID: "Geo QA"})
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	s := res.Suite
+	if s.SuiteID != "geo-qa" || len(s.Missions) != 3 || res.Samples != 3 {
+		t.Fatalf("unexpected suite: %+v", s)
+	}
+	if m := s.Missions[0]; m.MissionID != "sample-1" || m.Prompt != "system: Answer tersely.\n\nuser: Capital of France?" || m.Expects.ResultMatches != `(?:Paris|paris\.)` {
+		t.Fatalf("unexpected first mission: %+v", m)
+	}
+	if m := s.Missions[1]; m.Prompt != "2+2?" || m.Expects.ResultMatches != "(?:4)" {
+		t.Fatalf("unexpected second mission: %+v", m)
+	}
+	if len(res.Unscored) != 1 || res.Unscored[0] != "sample-3" || s.Missions[2].Expects != nil {
+		t.Fatalf("expected sample-3 to be unscored, got %v", res.Unscored)
+	}
+}
+
+func TestConvert_InspectAIArrayWithChoicesAndExactMatch(t *testing.T) {
+	t.Parallel()
+
+	in := `[
+  {"id": "q1", "input": "Pick the prime.", "choices": ["4", "7"], "target": "B"},
+  {"id": "q1", "input": "Again?", "target": ["yes", "y"]}
+]`
+	res, err := Convert(strings.NewReader(in), Options{Format: FormatInspectAI, SuiteID: "mc", Match: MatchExact, Limit: 5})
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	m := res.Suite.Missions
+	if m[0].MissionID != "q1" || m[1].MissionID != "q1-2" {
+		t.Fatalf("expected deduped ids, got %q %q", m[0].MissionID, m[1].MissionID)
+	}
+	if m[0].Prompt != "Pick the prime.\n\nA) 4\nB) 7" || m[0].Expects.Result.Pattern != `^\s*(?:B)\s*$` {
+		t.Fatalf("unexpected choice mission: %+v / %+v", m[0], m[0].Expects.Result)
+	}
+	if m[1].Expects.Result.Pattern != `^\s*(?:yes|y)\s*$` {
+		t.Fatalf("unexpected exact pattern: %+v", m[1].Expects.Result)
+	}
+}
+
+func TestConvert_CustomJSONLFieldsAndErrors(t *testing.T) {
+	t.Parallel()
+
+	in := "{\"key\": \"Task A\", \"q\": \"Do A\", \"a\": \"done\", \"labels\": [\"smoke\"]}\n{\"key\": \"b\", \"q\": \"Do B\"}\n"
+	res, err := Convert(strings.NewReader(in), Options{Format: FormatCustomJSONL, SuiteID: "custom", Limit: 1, Fields: Fields{ID: "key", Prompt: "q", Answer: "a", Tags: "labels"}})
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if len(res.Suite.Missions) != 1 || res.Samples != 2 {
+		t.Fatalf("expected limit to keep one of two samples, got %+v", res)
+	}
+	if m := res.Suite.Missions[0]; m.MissionID != "task-a" || m.Tags[0] != "smoke" || m.Expects.ResultMatches != "(?:done)" {
+		t.Fatalf("unexpected custom mission: %+v", m)
+	}
+
+	for _, tc := range []struct{ format, in, want string }{
+		{FormatCustomJSONL, "{\"prompt\": \"ok\"}\nnot json\n", "line 2: invalid json"},
+		{FormatCustomJSONL, "{\"question\": \"x\"}\n", `line 1: missing "prompt" field`},
+		{FormatOpenAIEvals, "{\"input\": \"  \"}\n", "line 1: empty prompt"},
+		{"helm", "{}\n", "unknown format"},
+	} {
+		if _, err := Convert(strings.NewReader(tc.in), Options{Format: tc.format, SuiteID: "s"}); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected %q, got %v", tc.format, tc.want, err)
+		}
+	}
+}
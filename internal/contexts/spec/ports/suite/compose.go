@@ -0,0 +1,227 @@
+package suite
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+)
+
+// maxMatrixMissions caps how many missions a single matrix may expand into, so a typo in a
+// value list cannot silently schedule thousands of attempts.
+const maxMatrixMissions = 1000
+
+var (
+	matrixKeyRe         = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	matrixPlaceholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+)
+
+// MissionOriginV1 records where a parsed mission came from (its suite file or mission-pack
+// file, and the matrix combination it was expanded for), so `zcl suite lint` can show the
+// composed suite.
+type MissionOriginV1 struct {
+	MissionID string            `json:"missionId"`
+	Source    string            `json:"source"`
+	Template  string            `json:"template,omitempty"`
+	Matrix    map[string]string `json:"matrix,omitempty"`
+}
+
+// composedSuite is one suite file with its includes and matrices resolved.
+type composedSuite struct {
+	suite   SuiteFileV1
+	refs    []FileRefV1
+	origins []MissionOriginV1
+}
+
+// composeSuiteFile decodes path, expands mission matrices, resolves file refs, and prepends
+// the missions of every include (in order). stack holds the including files for cycle checks.
+func composeSuiteFile(path string, stack []string) (composedSuite, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return composedSuite{}, err
+	}
+	for _, p := range stack {
+		if p == abs {
+			return composedSuite{}, fmt.Errorf("include cycle: %s -> %s", strings.Join(stack, " -> "), abs)
+		}
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return composedSuite{}, err
+	}
+	s, err := decodeSuiteFile(path, raw)
+	if err != nil {
+		return composedSuite{}, err
+	}
+	own, ownOrigins, err := expandMissionMatrices(s.Missions, abs)
+	if err != nil {
+		return composedSuite{}, err
+	}
+	s.Missions = own
+	refs, err := resolveMissionFileRefs(filepath.Dir(path), &s)
+	if err != nil {
+		return composedSuite{}, err
+	}
+
+	var missions []MissionV1
+	var origins []MissionOriginV1
+	for i, inc := range s.Include {
+		incPath := strings.TrimSpace(inc)
+		if incPath == "" {
+			return composedSuite{}, fmt.Errorf("include[%d] is empty", i)
+		}
+		if !filepath.IsAbs(incPath) {
+			incPath = filepath.Join(filepath.Dir(abs), incPath)
+		}
+		info, err := os.Stat(incPath)
+		if err != nil {
+			return composedSuite{}, fmt.Errorf("include[%d]: %w", i, err)
+		}
+		var sub composedSuite
+		if info.IsDir() {
+			sub, err = composeMissionPack(incPath)
+		} else {
+			sub, err = composeSuiteFile(incPath, append(stack, abs))
+			refs = append(refs, FileRefV1{Kind: "include", Path: filepath.Clean(incPath)})
+		}
+		if err != nil {
+			return composedSuite{}, fmt.Errorf("include[%d] %q: %w", i, inc, err)
+		}
+		missions = append(missions, sub.suite.Missions...)
+		origins = append(origins, sub.origins...)
+		refs = append(refs, sub.refs...)
+	}
+	s.Missions = append(missions, s.Missions...)
+	s.Include = nil
+	return composedSuite{suite: s, refs: refs, origins: append(origins, ownOrigins...)}, nil
+}
+
+// composeMissionPack loads a directory of .md files as prompt missions, one per file in
+// lexicographic order, with mission ids derived from file names.
+func composeMissionPack(dir string) (composedSuite, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return composedSuite{}, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.ToLower(filepath.Ext(e.Name())) == ".md" {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) == 0 {
+		return composedSuite{}, fmt.Errorf("mission pack has no .md missions")
+	}
+	sort.Strings(names)
+	var out composedSuite
+	for _, name := range names {
+		path := filepath.Clean(filepath.Join(dir, name))
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return composedSuite{}, err
+		}
+		missionID := ids.SanitizeComponent(strings.TrimSuffix(name, filepath.Ext(name)))
+		if missionID == "" {
+			return composedSuite{}, fmt.Errorf("file %q produced empty mission id", name)
+		}
+		prompt := strings.TrimSpace(string(raw))
+		if prompt == "" {
+			return composedSuite{}, fmt.Errorf("file %q has an empty prompt", name)
+		}
+		out.suite.Missions = append(out.suite.Missions, MissionV1{MissionID: missionID, Prompt: prompt})
+		out.refs = append(out.refs, FileRefV1{MissionID: missionID, Kind: "promptFile", Path: path})
+		out.origins = append(out.origins, MissionOriginV1{Source: path})
+	}
+	return out, nil
+}
+
+// expandMissionMatrices replaces every mission with a matrix by one mission per combination
+// of its values (sorted keys, last key varying fastest). Missions whose id has no placeholder
+// get a 1-based combination suffix.
+func expandMissionMatrices(missions []MissionV1, source string) ([]MissionV1, []MissionOriginV1, error) {
+	out := make([]MissionV1, 0, len(missions))
+	origins := make([]MissionOriginV1, 0, len(missions))
+	for _, m := range missions {
+		if len(m.Matrix) == 0 {
+			out = append(out, m)
+			origins = append(origins, MissionOriginV1{Source: source})
+			continue
+		}
+		combos, err := matrixCombinations(m.MissionID, m.Matrix)
+		if err != nil {
+			return nil, nil, err
+		}
+		tmpl := m
+		tmpl.Matrix = nil
+		raw, err := json.Marshal(tmpl)
+		if err != nil {
+			return nil, nil, fmt.Errorf("mission %q: matrix: %w", m.MissionID, err)
+		}
+		suffix := !matrixPlaceholderRe.MatchString(m.MissionID)
+		for n, combo := range combos {
+			var em MissionV1
+			if err := json.Unmarshal([]byte(substituteMatrix(string(raw), combo)), &em); err != nil {
+				return nil, nil, fmt.Errorf("mission %q: matrix: %w", m.MissionID, err)
+			}
+			if suffix {
+				em.MissionID = fmt.Sprintf("%s-%d", strings.TrimSpace(m.MissionID), n+1)
+			}
+			em.MatrixValues = combo
+			out = append(out, em)
+			origins = append(origins, MissionOriginV1{Source: source, Template: m.MissionID, Matrix: combo})
+		}
+	}
+	return out, origins, nil
+}
+
+func matrixCombinations(missionID string, matrix map[string][]string) ([]map[string]string, error) {
+	keys := make([]string, 0, len(matrix))
+	for k, values := range matrix {
+		if !matrixKeyRe.MatchString(k) {
+			return nil, fmt.Errorf("mission %q: invalid matrix key %q (expected [A-Za-z0-9_-]+)", missionID, k)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("mission %q: matrix.%s has no values", missionID, k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	combos := []map[string]string{{}}
+	for _, k := range keys {
+		next := make([]map[string]string, 0, len(combos)*len(matrix[k]))
+		for _, c := range combos {
+			for _, v := range matrix[k] {
+				nc := make(map[string]string, len(c)+1)
+				for ck, cv := range c {
+					nc[ck] = cv
+				}
+				nc[k] = v
+				next = append(next, nc)
+			}
+		}
+		if len(next) > maxMatrixMissions {
+			return nil, fmt.Errorf("mission %q: matrix expands to more than %d missions", missionID, maxMatrixMissions)
+		}
+		combos = next
+	}
+	return combos, nil
+}
+
+// substituteMatrix replaces {{name}} placeholders inside a JSON document with JSON-escaped
+// values; unknown names are left as-is.
+func substituteMatrix(doc string, combo map[string]string) string {
+	return matrixPlaceholderRe.ReplaceAllStringFunc(doc, func(ph string) string {
+		name := matrixPlaceholderRe.FindStringSubmatch(ph)[1]
+		v, ok := combo[name]
+		if !ok {
+			return ph
+		}
+		b, _ := json.Marshal(v)
+		return string(b[1 : len(b)-1])
+	})
+}
@@ -11,6 +11,7 @@ import (
 type ExpectationFailure struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	Pointer string `json:"pointer,omitempty"`
 }
 
 type ExpectationResult struct {
@@ -33,6 +34,12 @@ type TraceFacts struct {
 }
 
 func Evaluate(s SuiteFileV1, missionID string, fb schema.FeedbackJSONV1, tf *TraceFacts) ExpectationResult {
+	return EvaluateInWorkspace(s, missionID, fb, tf, "")
+}
+
+// EvaluateInWorkspace is Evaluate plus expects.files, resolved against workspaceDir
+// (see WorkspaceDirForAttempt).
+func EvaluateInWorkspace(s SuiteFileV1, missionID string, fb schema.FeedbackJSONV1, tf *TraceFacts, workspaceDir string) ExpectationResult {
 	m := FindMission(s, missionID)
 	if m == nil || m.Expects == nil {
 		return ExpectationResult{Evaluated: false, OK: true}
@@ -41,6 +48,12 @@ func Evaluate(s SuiteFileV1, missionID string, fb schema.FeedbackJSONV1, tf *Tra
 	var failures []ExpectationFailure
 	failures = append(failures, evaluateOKExpectation(m.Expects.OK, fb.OK)...)
 	failures = append(failures, evaluateResultExpectation(m.Expects.Result, fb)...)
+	failures = append(failures, evaluateResultRegexExpectation(m.Expects.ResultMatches, m.Expects.ResultNotMatches, fb)...)
+	failures = append(failures, evaluateMetricExpectations(m.Expects.Metrics, fb)...)
+	failures = append(failures, evaluateJSONPathExpectations(m.Expects.JSONPath, fb)...)
+	failures = append(failures, evaluateSchemaExpectation(m.Expects.Schema, fb)...)
+	failures = append(failures, evaluateFileExpectations(m.Expects.Files, workspaceDir)...)
+	failures = append(failures, evaluateGoldenExpectation(m.Expects.Golden, fb)...)
 	failures = append(failures, evaluateTraceExpectation(m.Expects.Trace, tf)...)
 	failures = append(failures, evaluateSemanticExpectation(m.Expects.Semantic, fb, tf)...)
 
@@ -104,6 +117,53 @@ func evaluateStringResultPattern(pattern string, actual string) []ExpectationFai
 	}}
 }
 
+// resultMatchPreviewMaxRunes bounds result/match excerpts quoted in failure messages.
+const resultMatchPreviewMaxRunes = 120
+
+func evaluateResultRegexExpectation(matches string, notMatches string, fb schema.FeedbackJSONV1) []ExpectationFailure {
+	if matches == "" && notMatches == "" {
+		return nil
+	}
+	text := fb.Result
+	if len(fb.ResultJSON) > 0 {
+		text = string(fb.ResultJSON)
+	}
+	var failures []ExpectationFailure
+	if matches != "" {
+		re, err := regexp.Compile(matches)
+		switch {
+		case err != nil:
+			failures = append(failures, ExpectationFailure{Code: "ZCL_E_EXPECT_PATTERN_INVALID", Message: "invalid expects.resultMatches regex"})
+		case !re.MatchString(text):
+			failures = append(failures, ExpectationFailure{
+				Code:    "ZCL_E_EXPECT_RESULT_MATCHES",
+				Message: fmt.Sprintf("feedback result does not match resultMatches %q (result: %q)", matches, previewRunes(text, resultMatchPreviewMaxRunes)),
+			})
+		}
+	}
+	if notMatches != "" {
+		re, err := regexp.Compile(notMatches)
+		if err != nil {
+			return append(failures, ExpectationFailure{Code: "ZCL_E_EXPECT_PATTERN_INVALID", Message: "invalid expects.resultNotMatches regex"})
+		}
+		if loc := re.FindStringIndex(text); loc != nil {
+			failures = append(failures, ExpectationFailure{
+				Code:    "ZCL_E_EXPECT_RESULT_NOT_MATCHES",
+				Message: fmt.Sprintf("feedback result matches resultNotMatches %q at offset %d (match: %q)", notMatches, loc[0], previewRunes(text[loc[0]:loc[1]], resultMatchPreviewMaxRunes)),
+			})
+		}
+	}
+	return failures
+}
+
+func previewRunes(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max]) + "..."
+}
+
 func evaluateTraceExpectation(expects *TraceExpectsV1, tf *TraceFacts) []ExpectationFailure {
 	if expects == nil {
 		return nil
@@ -0,0 +1,111 @@
+package suite
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// FileExpectV1 asserts a file in the attempt workspace (the runner start cwd), so claims like
+// "created report.md" are checked on disk rather than taken from feedback.
+type FileExpectV1 struct {
+	// Path is relative to the workspace and may not escape it.
+	Path     string `json:"path" yaml:"path"`
+	Exists   *bool  `json:"exists,omitempty" yaml:"exists,omitempty"`
+	Contains string `json:"contains,omitempty" yaml:"contains,omitempty"`
+	// SHA256 is the lowercase hex digest of the file contents.
+	SHA256 string `json:"sha256,omitempty" yaml:"sha256,omitempty"`
+}
+
+// WorkspaceDirForAttempt returns the runner start cwd recorded in attempt.runtime.env.json,
+// or "" when the attempt did not record one.
+func WorkspaceDirForAttempt(attemptDir string) string {
+	raw, err := os.ReadFile(filepath.Join(attemptDir, schema.AttemptRuntimeEnvFileNameV1))
+	if err != nil {
+		return ""
+	}
+	var env schema.AttemptRuntimeEnvJSONV1
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(env.Runtime.StartCwd)
+}
+
+func isContainedRelPath(p string) bool {
+	if p == "" || filepath.IsAbs(p) {
+		return false
+	}
+	clean := filepath.Clean(p)
+	return clean != ".." && !strings.HasPrefix(clean, ".."+string(filepath.Separator))
+}
+
+func evaluateFileExpectations(expects []FileExpectV1, workspaceDir string) []ExpectationFailure {
+	if len(expects) == 0 {
+		return nil
+	}
+	if workspaceDir == "" {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_FILE_WORKSPACE", Message: "expects.files requires a recorded attempt workspace (attempt.runtime.env.json runtime.startCwd)"}}
+	}
+	root, err := filepath.EvalSymlinks(workspaceDir)
+	if err != nil {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_FILE_WORKSPACE", Message: "attempt workspace is not available: " + err.Error()}}
+	}
+	var failures []ExpectationFailure
+	for _, fe := range expects {
+		failures = append(failures, evaluateFileExpectation(fe, root)...)
+	}
+	return failures
+}
+
+func evaluateFileExpectation(fe FileExpectV1, root string) []ExpectationFailure {
+	fail := func(format string, args ...any) []ExpectationFailure {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_FILE", Message: fmt.Sprintf("expects.files %s: "+format, append([]any{fe.Path}, args...)...)}}
+	}
+	if !isContainedRelPath(fe.Path) {
+		return fail("path escapes the workspace")
+	}
+	abs := filepath.Join(root, fe.Path)
+	resolved, err := filepath.EvalSymlinks(abs)
+	exists := err == nil
+	if exists {
+		rel, relErr := filepath.Rel(root, resolved)
+		if relErr != nil || (rel != "." && !isContainedRelPath(rel)) {
+			return fail("resolves outside the workspace")
+		}
+	}
+	wantExists := fe.Exists == nil || *fe.Exists
+	if !wantExists {
+		if exists {
+			return fail("expected file to be absent")
+		}
+		return nil
+	}
+	if !exists {
+		return fail("file does not exist")
+	}
+	if fe.Contains == "" && fe.SHA256 == "" {
+		return nil
+	}
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		return fail("read failed: %v", err)
+	}
+	var failures []ExpectationFailure
+	if fe.Contains != "" && !bytes.Contains(data, []byte(fe.Contains)) {
+		failures = append(failures, fail("does not contain %q", previewRunes(fe.Contains, resultMatchPreviewMaxRunes))...)
+	}
+	if fe.SHA256 != "" {
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); got != fe.SHA256 {
+			failures = append(failures, fail("sha256 %s does not match expected %s", got, fe.SHA256)...)
+		}
+	}
+	return failures
+}
@@ -0,0 +1,86 @@
+package suite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// NormalizeGoldenOutput renders the feedback result the way golden files store it:
+// resultJson as sorted-key, 2-space indented JSON; result text with LF line endings and
+// trailing whitespace trimmed. Both end with exactly one newline.
+func NormalizeGoldenOutput(fb schema.FeedbackJSONV1) ([]byte, error) {
+	if len(fb.ResultJSON) > 0 {
+		doc, ok := decodeResultJSON(fb.ResultJSON)
+		if !ok {
+			return nil, fmt.Errorf("feedback resultJson is not valid json")
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return []byte(normalizeGoldenText(fb.Result)), nil
+}
+
+func normalizeGoldenText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+func evaluateGoldenExpectation(goldenPath string, fb schema.FeedbackJSONV1) []ExpectationFailure {
+	if goldenPath == "" {
+		return nil
+	}
+	got, err := NormalizeGoldenOutput(fb)
+	if err != nil {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_RESULT_JSON", Message: err.Error()}}
+	}
+	raw, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return []ExpectationFailure{{
+			Code:    "ZCL_E_EXPECT_GOLDEN_MISSING",
+			Message: fmt.Sprintf("golden file %s is not readable (create it with zcl expect update-goldens): %v", goldenPath, err),
+		}}
+	}
+	want := []byte(normalizeGoldenText(string(raw)))
+	if bytes.Equal(got, want) {
+		return nil
+	}
+	line, gotLine, wantLine := firstDifferentLine(string(got), string(want))
+	return []ExpectationFailure{{
+		Code: "ZCL_E_EXPECT_GOLDEN",
+		Message: fmt.Sprintf("result differs from golden %s at line %d: got %q, want %q",
+			goldenPath, line, previewRunes(gotLine, resultMatchPreviewMaxRunes), previewRunes(wantLine, resultMatchPreviewMaxRunes)),
+	}}
+}
+
+func firstDifferentLine(got, want string) (int, string, string) {
+	g := strings.Split(got, "\n")
+	w := strings.Split(want, "\n")
+	for i := 0; i < len(g) || i < len(w); i++ {
+		var gl, wl string
+		if i < len(g) {
+			gl = g[i]
+		}
+		if i < len(w) {
+			wl = w[i]
+		}
+		if gl != wl || i >= len(g) || i >= len(w) {
+			return i + 1, gl, wl
+		}
+	}
+	return 0, "", ""
+}
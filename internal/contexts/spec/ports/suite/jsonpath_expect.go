@@ -0,0 +1,266 @@
+package suite
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// JSONPathExpectV1 asserts nodes selected from feedback.resultJson by a JSONPath subset:
+// $, .key, ['key'], [index], [*], .* and ..key (recursive descent).
+// When the path selects several nodes, equals/contains pass if any node satisfies them.
+type JSONPathExpectV1 struct {
+	Path     string `json:"path" yaml:"path"`
+	Equals   any    `json:"equals,omitempty" yaml:"equals,omitempty"`
+	Contains any    `json:"contains,omitempty" yaml:"contains,omitempty"`
+	Exists   *bool  `json:"exists,omitempty" yaml:"exists,omitempty"`
+}
+
+type jsonPathStep struct {
+	key       string
+	index     int
+	wildcard  bool
+	recursive bool
+	isIndex   bool
+}
+
+// parseJSONPath compiles the supported JSONPath subset; filters and slices are rejected.
+func parseJSONPath(path string) ([]jsonPathStep, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(path), "$")
+	if !ok {
+		return nil, false
+	}
+	var steps []jsonPathStep
+	for rest != "" {
+		var step jsonPathStep
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			step.recursive = true
+			rest = rest[2:]
+			if strings.HasPrefix(rest, "[") {
+				// $..[0] / $..['k'] read the bracket below.
+				break
+			}
+			name, tail := cutJSONPathName(rest)
+			if name == "" {
+				return nil, false
+			}
+			step.key, step.wildcard = name, name == "*"
+			steps, rest = append(steps, step), tail
+			continue
+		case rest[0] == '.':
+			name, tail := cutJSONPathName(rest[1:])
+			if name == "" {
+				return nil, false
+			}
+			step.key, step.wildcard = name, name == "*"
+			steps, rest = append(steps, step), tail
+			continue
+		case rest[0] != '[':
+			return nil, false
+		}
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return nil, false
+		}
+		inner := strings.TrimSpace(rest[1:end])
+		rest = rest[end+1:]
+		switch {
+		case inner == "*":
+			step.wildcard = true
+		case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+			step.key = inner[1 : len(inner)-1]
+		default:
+			n, err := strconv.Atoi(inner)
+			if err != nil || n < 0 {
+				return nil, false
+			}
+			step.index, step.isIndex = n, true
+		}
+		steps = append(steps, step)
+	}
+	return steps, true
+}
+
+func cutJSONPathName(s string) (string, string) {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		end = len(s)
+	}
+	return s[:end], s[end:]
+}
+
+func selectJSONPath(doc any, steps []jsonPathStep) []any {
+	nodes := []any{doc}
+	for _, st := range steps {
+		var next []any
+		for _, n := range nodes {
+			if st.recursive {
+				walkJSON(n, func(v any) { next = append(next, applyJSONPathStep(v, st)...) })
+				continue
+			}
+			next = append(next, applyJSONPathStep(n, st)...)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func applyJSONPathStep(n any, st jsonPathStep) []any {
+	switch v := n.(type) {
+	case map[string]any:
+		if st.wildcard {
+			out := make([]any, 0, len(v))
+			for _, k := range sortedMapKeys(v) {
+				out = append(out, v[k])
+			}
+			return out
+		}
+		if st.isIndex {
+			return nil
+		}
+		if child, ok := v[st.key]; ok {
+			return []any{child}
+		}
+	case []any:
+		if st.wildcard {
+			return v
+		}
+		if st.isIndex && st.index < len(v) {
+			return []any{v[st.index]}
+		}
+	}
+	return nil
+}
+
+// walkJSON visits n and every descendant in document order (object keys sorted).
+func walkJSON(n any, visit func(any)) {
+	visit(n)
+	switch v := n.(type) {
+	case map[string]any:
+		for _, k := range sortedMapKeys(v) {
+			walkJSON(v[k], visit)
+		}
+	case []any:
+		for _, c := range v {
+			walkJSON(c, visit)
+		}
+	}
+}
+
+func evaluateJSONPathExpectations(expects []JSONPathExpectV1, fb schema.FeedbackJSONV1) []ExpectationFailure {
+	if len(expects) == 0 {
+		return nil
+	}
+	if len(fb.ResultJSON) == 0 {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_RESULT_JSON", Message: "expects.jsonPath requires feedback.resultJson"}}
+	}
+	doc, ok := decodeResultJSON(fb.ResultJSON)
+	if !ok {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_RESULT_JSON", Message: "feedback resultJson is not valid json"}}
+	}
+	var failures []ExpectationFailure
+	for _, e := range expects {
+		steps, ok := parseJSONPath(e.Path)
+		if !ok {
+			failures = append(failures, ExpectationFailure{Code: "ZCL_E_EXPECT_JSONPATH_INVALID", Message: "invalid expects.jsonPath path " + e.Path})
+			continue
+		}
+		failures = append(failures, checkJSONPathNodes(e, selectJSONPath(doc, steps))...)
+	}
+	return failures
+}
+
+func checkJSONPathNodes(e JSONPathExpectV1, nodes []any) []ExpectationFailure {
+	fail := func(format string, args ...any) []ExpectationFailure {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_JSONPATH", Message: fmt.Sprintf("expects.jsonPath %s: "+format, append([]any{e.Path}, args...)...)}}
+	}
+	if e.Exists != nil && *e.Exists != (len(nodes) > 0) {
+		if *e.Exists {
+			return fail("no value at path")
+		}
+		return fail("expected no value, found %d", len(nodes))
+	}
+	if (e.Equals != nil || e.Contains != nil) && len(nodes) == 0 {
+		return fail("no value at path")
+	}
+	var failures []ExpectationFailure
+	if e.Equals != nil && !anyNode(nodes, func(n any) bool { return jsonValuesEqual(n, e.Equals) }) {
+		failures = append(failures, fail("got %s, want equals %s", previewJSON(nodes[0]), previewJSON(e.Equals))...)
+	}
+	if e.Contains != nil && !anyNode(nodes, func(n any) bool { return jsonValueContains(n, e.Contains) }) {
+		failures = append(failures, fail("got %s, want contains %s", previewJSON(nodes[0]), previewJSON(e.Contains))...)
+	}
+	return failures
+}
+
+func anyNode(nodes []any, pred func(any) bool) bool {
+	for _, n := range nodes {
+		if pred(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonValuesEqual compares after a JSON round-trip so YAML ints and JSON float64 agree.
+func jsonValuesEqual(a, b any) bool {
+	return reflect.DeepEqual(normalizeJSONValue(a), normalizeJSONValue(b))
+}
+
+// jsonValueContains: substring for strings, element membership for arrays, key presence for objects.
+func jsonValueContains(node, want any) bool {
+	switch v := node.(type) {
+	case string:
+		s, ok := want.(string)
+		return ok && strings.Contains(v, s)
+	case []any:
+		for _, el := range v {
+			if jsonValuesEqual(el, want) {
+				return true
+			}
+		}
+	case map[string]any:
+		s, ok := want.(string)
+		if !ok {
+			return false
+		}
+		_, exists := v[s]
+		return exists
+	}
+	return false
+}
+
+func normalizeJSONValue(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+func previewJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return previewRunes(string(b), resultMatchPreviewMaxRunes)
+}
+
+func sortedMapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,148 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// MetricExpectV1 asserts a numeric value from the feedback result.
+// Path is "result" (feedback.result parsed as a number) or "resultJson[.key|[index]]...".
+type MetricExpectV1 struct {
+	Path string `json:"path" yaml:"path"`
+
+	// Equals matches when |actual-equals| <= Tolerance (exact when Tolerance is 0).
+	Equals    *float64 `json:"equals,omitempty" yaml:"equals,omitempty"`
+	Tolerance float64  `json:"tolerance,omitempty" yaml:"tolerance,omitempty"`
+
+	Gt  *float64 `json:"gt,omitempty" yaml:"gt,omitempty"`
+	Gte *float64 `json:"gte,omitempty" yaml:"gte,omitempty"`
+	Lt  *float64 `json:"lt,omitempty" yaml:"lt,omitempty"`
+	Lte *float64 `json:"lte,omitempty" yaml:"lte,omitempty"`
+}
+
+func (m MetricExpectV1) hasComparator() bool {
+	return m.Equals != nil || m.Gt != nil || m.Gte != nil || m.Lt != nil || m.Lte != nil
+}
+
+// parseMetricPath splits "resultJson.items[0].score" into its root and lookup tokens.
+func parseMetricPath(path string) (root string, tokens []string, ok bool) {
+	path = strings.TrimSpace(path)
+	if path == "result" {
+		return "result", nil, true
+	}
+	rest, found := strings.CutPrefix(path, "resultJson")
+	if !found {
+		return "", nil, false
+	}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return "", nil, false
+			}
+			tokens = append(tokens, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return "", nil, false
+			}
+			idx := rest[1:end]
+			if n, err := strconv.Atoi(idx); err != nil || n < 0 {
+				return "", nil, false
+			}
+			tokens = append(tokens, idx)
+			rest = rest[end+1:]
+		default:
+			return "", nil, false
+		}
+	}
+	return "resultJson", tokens, true
+}
+
+func evaluateMetricExpectations(expects []MetricExpectV1, fb schema.FeedbackJSONV1) []ExpectationFailure {
+	if len(expects) == 0 {
+		return nil
+	}
+	var doc any
+	var docOK bool
+	if len(fb.ResultJSON) > 0 {
+		doc, docOK = decodeResultJSON(fb.ResultJSON)
+	}
+	var failures []ExpectationFailure
+	for _, m := range expects {
+		actual, ok := lookupMetricValue(m.Path, fb, doc, docOK)
+		if !ok {
+			failures = append(failures, ExpectationFailure{
+				Code:    "ZCL_E_EXPECT_METRIC_MISSING",
+				Message: fmt.Sprintf("expects.metrics path %s is missing or not numeric", m.Path),
+			})
+			continue
+		}
+		failures = append(failures, compareMetric(m, actual)...)
+	}
+	return failures
+}
+
+func lookupMetricValue(path string, fb schema.FeedbackJSONV1, doc any, docOK bool) (float64, bool) {
+	root, tokens, ok := parseMetricPath(path)
+	if !ok {
+		return 0, false
+	}
+	if root == "result" {
+		v, err := strconv.ParseFloat(strings.TrimSpace(fb.Result), 64)
+		return v, err == nil
+	}
+	if !docOK {
+		return 0, false
+	}
+	cur, ok := lookupTokens(doc, tokens)
+	if !ok {
+		return 0, false
+	}
+	v, ok := cur.(float64)
+	return v, ok
+}
+
+func compareMetric(m MetricExpectV1, actual float64) []ExpectationFailure {
+	var failures []ExpectationFailure
+	fail := func(op string, want string) {
+		failures = append(failures, ExpectationFailure{
+			Code:    "ZCL_E_EXPECT_METRIC",
+			Message: fmt.Sprintf("expects.metrics %s: got %s, want %s %s", m.Path, formatMetric(actual), op, want),
+		})
+	}
+	if m.Equals != nil && math.Abs(actual-*m.Equals) > m.Tolerance {
+		want := formatMetric(*m.Equals)
+		if m.Tolerance > 0 {
+			want += " ± " + formatMetric(m.Tolerance)
+		}
+		fail("==", want)
+	}
+	if m.Gt != nil && !(actual > *m.Gt) {
+		fail(">", formatMetric(*m.Gt))
+	}
+	if m.Gte != nil && !(actual >= *m.Gte) {
+		fail(">=", formatMetric(*m.Gte))
+	}
+	if m.Lt != nil && !(actual < *m.Lt) {
+		fail("<", formatMetric(*m.Lt))
+	}
+	if m.Lte != nil && !(actual <= *m.Lte) {
+		fail("<=", formatMetric(*m.Lte))
+	}
+	return failures
+}
+
+func formatMetric(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
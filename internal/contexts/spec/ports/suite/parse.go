@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -18,21 +19,90 @@ type ParsedSuite struct {
 	Suite SuiteFileV1
 	// CanonicalJSON is the normalized JSON form we snapshot to suite.json for diffability.
 	CanonicalJSON any
+	// Refs lists the files the suite pulls in (prompt files, schema refs, goldens) as absolute
+	// paths, so tools like `suite run --watch` can follow them.
+	Refs []FileRefV1
+	// Origins has one entry per mission (same order) naming the file it came from and the
+	// matrix combination it was expanded for.
+	Origins []MissionOriginV1
+}
+
+// FileRefV1 is one file referenced by a mission in the suite file.
+type FileRefV1 struct {
+	MissionID string `json:"missionId"`
+	Kind      string `json:"kind"` // promptFile|schema|golden|include
+	Path      string `json:"path"`
 }
 
 func ParseFile(path string) (ParsedSuite, error) {
-	raw, err := os.ReadFile(path)
-	if err != nil {
-		return ParsedSuite{}, err
-	}
-	s, err := decodeSuiteFile(path, raw)
+	c, err := composeSuiteFile(path, nil)
 	if err != nil {
 		return ParsedSuite{}, err
 	}
+	s := c.suite
 	if err := normalizeSuiteFile(&s); err != nil {
 		return ParsedSuite{}, err
 	}
-	return ParsedSuite{Suite: s, CanonicalJSON: s}, nil
+	for i := range c.origins {
+		c.origins[i].MissionID = s.Missions[i].MissionID
+	}
+	return ParsedSuite{Suite: s, CanonicalJSON: s, Refs: c.refs, Origins: c.origins}, nil
+}
+
+// resolveMissionFileRefs inlines promptFile and expects.schema file refs and makes
+// expects.golden absolute, so the suite.json snapshot works for later zcl expect/report runs
+// from any cwd.
+func resolveMissionFileRefs(baseDir string, s *SuiteFileV1) ([]FileRefV1, error) {
+	var refs []FileRefV1
+	addRef := func(m *MissionV1, kind string, path string) {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		refs = append(refs, FileRefV1{MissionID: ids.SanitizeComponent(m.MissionID), Kind: kind, Path: filepath.Clean(path)})
+	}
+	for i := range s.Missions {
+		m := &s.Missions[i]
+		if pf := strings.TrimSpace(m.PromptFile); pf != "" {
+			if strings.TrimSpace(m.Prompt) != "" {
+				return nil, fmt.Errorf("mission %q: prompt and promptFile are mutually exclusive", m.MissionID)
+			}
+			addRef(m, "promptFile", pf)
+			raw, err := os.ReadFile(refs[len(refs)-1].Path)
+			if err != nil {
+				return nil, fmt.Errorf("mission %q: promptFile: %w", m.MissionID, err)
+			}
+			m.Prompt = strings.TrimRight(string(raw), "\r\n")
+			m.PromptFile = ""
+		}
+		if m.Expects == nil {
+			continue
+		}
+		if g := strings.TrimSpace(m.Expects.Golden); g != "" {
+			if !filepath.IsAbs(g) {
+				abs, err := filepath.Abs(filepath.Join(baseDir, g))
+				if err != nil {
+					return nil, fmt.Errorf("mission %q: expects.golden: %w", m.MissionID, err)
+				}
+				g = abs
+			}
+			m.Expects.Golden = filepath.Clean(g)
+			addRef(m, "golden", m.Expects.Golden)
+		}
+		ref, ok := m.Expects.Schema.(string)
+		if !ok {
+			continue
+		}
+		doc, err := loadExpectsSchemaFile(baseDir, ref)
+		if err != nil {
+			return nil, fmt.Errorf("mission %q: expects.schema: %w", m.MissionID, err)
+		}
+		addRef(m, "schema", strings.TrimSpace(ref))
+		m.Expects.Schema = doc
+	}
+	return refs, nil
 }
 
 func decodeSuiteFile(path string, raw []byte) (SuiteFileV1, error) {
@@ -88,6 +158,102 @@ func normalizeSuiteHeader(s *SuiteFileV1) error {
 	if len(s.Defaults.BlindTerms) > 0 {
 		s.Defaults.BlindTerms = blind.NormalizeTerms(s.Defaults.BlindTerms)
 	}
+	if strings.TrimSpace(s.Defaults.BlindAction) != "" {
+		ba := strings.ToLower(strings.TrimSpace(s.Defaults.BlindAction))
+		if !blind.IsValidAction(ba) {
+			return fmt.Errorf("invalid defaults.blindAction (expected reject|rewrite)")
+		}
+		s.Defaults.BlindAction = ba
+	}
+	if err := normalizeBlindTermPacks(s); err != nil {
+		return err
+	}
+	if err := normalizeDecisionTags(s.DecisionTags); err != nil {
+		return err
+	}
+	if err := NormalizeMCPServers(s.Defaults.MCPServers); err != nil {
+		return fmt.Errorf("invalid defaults.mcpServers: %w", err)
+	}
+	return normalizeTraceSampling(s.Defaults.TraceSampling)
+}
+
+var mcpServerNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// NormalizeMCPServers validates MCP server declarations (suite defaults and campaign flows share
+// the shape): names are [A-Za-z0-9_-]+ and each server needs a command.
+func NormalizeMCPServers(servers map[string]MCPServerV1) error {
+	for name, srv := range servers {
+		if !mcpServerNameRe.MatchString(name) {
+			return fmt.Errorf("server name %q (expected [A-Za-z0-9_-]+)", name)
+		}
+		cmd := make([]string, 0, len(srv.Command))
+		for _, arg := range srv.Command {
+			cmd = append(cmd, strings.TrimSpace(arg))
+		}
+		if len(cmd) == 0 || cmd[0] == "" {
+			return fmt.Errorf("%s.command is required", name)
+		}
+		srv.Command = cmd
+		servers[name] = srv
+	}
+	return nil
+}
+
+func normalizeBlindTermPacks(s *SuiteFileV1) error {
+	if len(s.BlindTermPacks) > 0 {
+		packs := make(map[string][]string, len(s.BlindTermPacks))
+		for name, terms := range s.BlindTermPacks {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if err := blind.ValidateCustomPackName(name); err != nil {
+				return fmt.Errorf("blindTermPacks: %w", err)
+			}
+			if _, dup := packs[name]; dup {
+				return fmt.Errorf("blindTermPacks: duplicate pack %q", name)
+			}
+			terms = blind.NormalizeTerms(terms)
+			if len(terms) == 0 {
+				return fmt.Errorf("blindTermPacks.%s: expected at least one term", name)
+			}
+			packs[name] = terms
+		}
+		s.BlindTermPacks = packs
+	}
+	s.Defaults.BlindTermsPack = strings.ToLower(strings.TrimSpace(s.Defaults.BlindTermsPack))
+	if _, err := s.ResolveBlindTerms("", nil); err != nil {
+		return fmt.Errorf("invalid defaults.blindTermsPack: %w", err)
+	}
+	return nil
+}
+
+func normalizeDecisionTags(defs []schema.DecisionTagDefV1) error {
+	seen := map[string]bool{}
+	for i := range defs {
+		defs[i].Name = strings.ToLower(strings.TrimSpace(defs[i].Name))
+		defs[i].Description = strings.TrimSpace(defs[i].Description)
+		if err := schema.ValidateCustomDecisionTagV1(defs[i].Name); err != nil {
+			return fmt.Errorf("decisionTags[%d]: %w", i, err)
+		}
+		if seen[defs[i].Name] {
+			return fmt.Errorf("decisionTags[%d]: duplicate decision tag %q", i, defs[i].Name)
+		}
+		seen[defs[i].Name] = true
+	}
+	return nil
+}
+
+func normalizeTraceSampling(rules []TraceSamplingRuleV1) error {
+	for i := range rules {
+		r := &rules[i]
+		r.Tool = strings.ToLower(strings.TrimSpace(r.Tool))
+		r.Op = strings.TrimSpace(r.Op)
+		r.Prefix = strings.TrimSpace(r.Prefix)
+		if !schema.IsValidTraceSamplingToolV1(r.Tool) {
+			return fmt.Errorf("invalid defaults.traceSampling[%d].tool (expected cli|mcp|http)", i)
+		}
+		if r.KeepEvery < 1 {
+			return fmt.Errorf("invalid defaults.traceSampling[%d].keepEvery (expected >= 1)", i)
+		}
+	}
 	return nil
 }
 
@@ -130,9 +296,121 @@ func normalizeMissionExpects(m *MissionV1) error {
 	if err := normalizeMissionTraceExpects(m); err != nil {
 		return err
 	}
+	if err := normalizeMissionResultRegexExpects(m); err != nil {
+		return err
+	}
+	if err := normalizeMissionMetricExpects(m); err != nil {
+		return err
+	}
+	if err := normalizeMissionJSONPathExpects(m); err != nil {
+		return err
+	}
+	if err := normalizeMissionSchemaExpects(m); err != nil {
+		return err
+	}
+	if err := normalizeMissionFileExpects(m); err != nil {
+		return err
+	}
 	return normalizeMissionSemanticExpects(m)
 }
 
+func normalizeMissionMetricExpects(m *MissionV1) error {
+	for i := range m.Expects.Metrics {
+		me := &m.Expects.Metrics[i]
+		me.Path = strings.TrimSpace(me.Path)
+		if _, _, ok := parseMetricPath(me.Path); !ok {
+			return fmt.Errorf("mission %q: invalid expects.metrics[%d].path %q (expected result or resultJson.<key>[<index>]...)", m.MissionID, i, me.Path)
+		}
+		if !me.hasComparator() {
+			return fmt.Errorf("mission %q: expects.metrics[%d] requires one of equals|gt|gte|lt|lte", m.MissionID, i)
+		}
+		if me.Tolerance < 0 {
+			return fmt.Errorf("mission %q: expects.metrics[%d].tolerance must be >= 0", m.MissionID, i)
+		}
+		if me.Tolerance > 0 && me.Equals == nil {
+			return fmt.Errorf("mission %q: expects.metrics[%d].tolerance requires equals", m.MissionID, i)
+		}
+	}
+	return nil
+}
+
+func normalizeMissionJSONPathExpects(m *MissionV1) error {
+	for i := range m.Expects.JSONPath {
+		jp := &m.Expects.JSONPath[i]
+		jp.Path = strings.TrimSpace(jp.Path)
+		if _, ok := parseJSONPath(jp.Path); !ok {
+			return fmt.Errorf("mission %q: invalid expects.jsonPath[%d].path %q", m.MissionID, i, jp.Path)
+		}
+		if jp.Equals == nil && jp.Contains == nil && jp.Exists == nil {
+			return fmt.Errorf("mission %q: expects.jsonPath[%d] requires one of equals|contains|exists", m.MissionID, i)
+		}
+	}
+	return nil
+}
+
+func normalizeMissionSchemaExpects(m *MissionV1) error {
+	if m.Expects.Schema == nil {
+		return nil
+	}
+	m.Expects.Schema = normalizeJSONValue(m.Expects.Schema)
+	if err := checkJSONSchema(m.Expects.Schema); err != nil {
+		return fmt.Errorf("mission %q: invalid expects.schema: %w", m.MissionID, err)
+	}
+	return nil
+}
+
+func normalizeMissionFileExpects(m *MissionV1) error {
+	for i := range m.Expects.Files {
+		fe := &m.Expects.Files[i]
+		fe.Path = strings.TrimSpace(fe.Path)
+		if !isContainedRelPath(fe.Path) {
+			return fmt.Errorf("mission %q: expects.files[%d].path must be relative to the workspace", m.MissionID, i)
+		}
+		fe.SHA256 = strings.ToLower(strings.TrimSpace(fe.SHA256))
+		if fe.SHA256 != "" && !isHexSHA256(fe.SHA256) {
+			return fmt.Errorf("mission %q: expects.files[%d].sha256 must be 64 hex characters", m.MissionID, i)
+		}
+		if fe.Exists == nil && fe.Contains == "" && fe.SHA256 == "" {
+			return fmt.Errorf("mission %q: expects.files[%d] requires one of exists|contains|sha256", m.MissionID, i)
+		}
+		if fe.Exists != nil && !*fe.Exists && (fe.Contains != "" || fe.SHA256 != "") {
+			return fmt.Errorf("mission %q: expects.files[%d] cannot combine exists=false with contains/sha256", m.MissionID, i)
+		}
+	}
+	return nil
+}
+
+func isHexSHA256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeMissionResultRegexExpects(m *MissionV1) error {
+	for _, f := range []struct {
+		name string
+		re   *string
+	}{
+		{"resultMatches", &m.Expects.ResultMatches},
+		{"resultNotMatches", &m.Expects.ResultNotMatches},
+	} {
+		*f.re = strings.TrimSpace(*f.re)
+		if *f.re == "" {
+			continue
+		}
+		if _, err := regexp.Compile(*f.re); err != nil {
+			return fmt.Errorf("mission %q: invalid expects.%s regex: %v", m.MissionID, f.name, err)
+		}
+	}
+	return nil
+}
+
 func normalizeMissionResultExpects(m *MissionV1) error {
 	if m.Expects.Result == nil {
 		return nil
@@ -231,6 +509,9 @@ func normalizeMissionSemanticExpects(m *MissionV1) error {
 	if sem.HookTimeoutMs < 0 {
 		return fmt.Errorf("mission %q: expects.semantic.hookTimeoutMs must be >= 0", m.MissionID)
 	}
+	if err := NormalizeSemanticChecks("expects.semantic", sem.Checks); err != nil {
+		return fmt.Errorf("mission %q: %w", m.MissionID, err)
+	}
 	return nil
 }
 
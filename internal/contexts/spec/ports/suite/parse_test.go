@@ -97,3 +97,374 @@ func TestParseFile_NormalizesRequiredJSONPointers(t *testing.T) {
 		t.Fatalf("unexpected normalized pointers: %#v", got)
 	}
 }
+
+func TestParseFile_RejectsInvalidTraceSamplingRule(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+defaults:
+  traceSampling:
+    - tool: MCP
+      op: tools/call
+      keepEvery: 0
+missions:
+  - missionId: m
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	_, err := ParseFile(path)
+	if err == nil || !strings.Contains(err.Error(), "traceSampling[0].keepEvery") {
+		t.Fatalf("expected traceSampling keepEvery error, got: %v", err)
+	}
+}
+
+func TestParseFile_ResolvesSuiteBlindTermPacks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+blindTermPacks:
+  Vendor:
+    - " Vendor Harness "
+defaults:
+  blind: true
+  blindTermsPack: vendor
+  blindTerms: [extra]
+missions:
+  - missionId: m
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	parsed, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	terms, err := parsed.Suite.ResolveBlindTerms("", nil)
+	if err != nil || strings.Join(terms, ",") != "extra,vendor harness" {
+		t.Fatalf("unexpected suite pack terms: %v (%v)", terms, err)
+	}
+	if terms, err := parsed.Suite.ResolveBlindTerms("generic-harness", []string{"only"}); err != nil || len(terms) < 2 || terms[0] != "attempt finish" {
+		t.Fatalf("expected built-in pack override, got %v (%v)", terms, err)
+	}
+
+	bad := strings.Replace(raw, "blindTermsPack: vendor", "blindTermsPack: missing", 1)
+	if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	if _, err := ParseFile(path); err == nil || !strings.Contains(err.Error(), "defaults.blindTermsPack") {
+		t.Fatalf("expected unknown defaults.blindTermsPack error, got: %v", err)
+	}
+}
+
+func TestParseFile_NormalizesAndValidatesDecisionTags(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	parse := func(tags string) (ParsedSuite, error) {
+		t.Helper()
+		path := filepath.Join(dir, "suite.yaml")
+		raw := "version: 1\nsuiteId: s\ndecisionTags:\n" + tags + "missions:\n  - missionId: m\n"
+		if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+			t.Fatalf("write suite file: %v", err)
+		}
+		return ParseFile(path)
+	}
+	parsed, err := parse("  - name: ' Captcha '\n    description: site demanded a captcha\n")
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if got := parsed.Suite.CustomDecisionTags(); len(got) != 1 || got[0] != "captcha" {
+		t.Fatalf("expected normalized custom tag, got %v", got)
+	}
+	for tags, want := range map[string]string{
+		"  - name: blocked\n":                    "is built in",
+		"  - name: has-dash\n":                   "lowercase snake_case",
+		"  - name: captcha\n  - name: CAPTCHA\n": "duplicate decision tag",
+	} {
+		if _, err := parse(tags); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("tags %q: expected %q error, got %v", tags, want, err)
+		}
+	}
+}
+
+func TestParseFile_RejectsInvalidResultMatchesRegex(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+missions:
+  - missionId: m
+    expects:
+      resultNotMatches: "(unclosed"
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	_, err := ParseFile(path)
+	if err == nil || !strings.Contains(err.Error(), "expects.resultNotMatches") {
+		t.Fatalf("expected resultNotMatches regex error, got: %v", err)
+	}
+}
+
+func TestParseFile_RejectsMetricToleranceWithoutEquals(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+missions:
+  - missionId: m
+    expects:
+      metrics:
+        - path: resultJson.count
+          gte: 3
+          tolerance: 0.5
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	_, err := ParseFile(path)
+	if err == nil || !strings.Contains(err.Error(), "expects.metrics[0].tolerance requires equals") {
+		t.Fatalf("expected tolerance error, got: %v", err)
+	}
+}
+
+func TestParseFile_RejectsUnsupportedJSONPath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+missions:
+  - missionId: m
+    expects:
+      jsonPath:
+        - path: "$.items[?(@.id==1)]"
+          exists: true
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	_, err := ParseFile(path)
+	if err == nil || !strings.Contains(err.Error(), "expects.jsonPath[0].path") {
+		t.Fatalf("expected jsonPath error, got: %v", err)
+	}
+}
+
+func TestParseFile_InlinesSchemaFileRef(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "result.schema.json"), []byte(`{"type":"object","required":["title"]}`), 0o644); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+missions:
+  - missionId: m
+    expects:
+      schema: result.schema.json
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	ps, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	got, ok := ps.Suite.Missions[0].Expects.Schema.(map[string]any)
+	if !ok || got["type"] != "object" {
+		t.Fatalf("expected inlined schema, got: %#v", ps.Suite.Missions[0].Expects.Schema)
+	}
+}
+
+func TestParseFile_ResolvesGoldenRelativeToSuiteFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+missions:
+  - missionId: m
+    expects:
+      golden: goldens/m.txt
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	ps, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if got, want := ps.Suite.Missions[0].Expects.Golden, filepath.Join(dir, "goldens", "m.txt"); got != want {
+		t.Fatalf("golden path: got %q want %q", got, want)
+	}
+}
+
+func TestParseFile_InlinesPromptFileAndListsRefs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "m.md"), []byte("Find the title.\n"), 0o644); err != nil {
+		t.Fatalf("write prompt: %v", err)
+	}
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+missions:
+  - missionId: M
+    promptFile: m.md
+    expects:
+      golden: goldens/m.txt
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	ps, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if m := ps.Suite.Missions[0]; m.Prompt != "Find the title." || m.PromptFile != "" {
+		t.Fatalf("expected inlined prompt, got: %+v", m)
+	}
+	if len(ps.Refs) != 2 || ps.Refs[0] != (FileRefV1{MissionID: "m", Kind: "promptFile", Path: filepath.Join(dir, "m.md")}) || ps.Refs[1].Kind != "golden" {
+		t.Fatalf("unexpected refs: %+v", ps.Refs)
+	}
+
+	if err := os.WriteFile(path, []byte(raw+"    prompt: inline\n"), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	if _, err := ParseFile(path); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected prompt/promptFile conflict, got %v", err)
+	}
+}
+
+func TestParseFile_RejectsFileExpectationEscapingWorkspace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+missions:
+  - missionId: m
+    expects:
+      files:
+        - path: ../outside.txt
+          exists: true
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	_, err := ParseFile(path)
+	if err == nil || !strings.Contains(err.Error(), "expects.files[0].path") {
+		t.Fatalf("expected files path error, got: %v", err)
+	}
+}
+
+func TestParseFile_RejectsMCPServerWithoutCommand(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+defaults:
+  mcpServers:
+    docs:
+      env: {DOCS_ROOT: /srv/docs}
+missions:
+  - missionId: m
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	_, err := ParseFile(path)
+	if err == nil || !strings.Contains(err.Error(), "defaults.mcpServers: docs.command is required") {
+		t.Fatalf("expected mcpServers command error, got: %v", err)
+	}
+}
+
+func TestParseFile_ComposesIncludesAndExpandsMatrix(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "pack"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for name, body := range map[string]string{
+		"pack/b.md":         "Second pack mission.\n",
+		"pack/a.md":         "First pack mission.\n",
+		"shared.suite.yaml": "version: 1\nsuiteId: shared\nmissions:\n  - missionId: smoke\n    prompt: smoke\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	path := filepath.Join(dir, "suite.yaml")
+	raw := `version: 1
+suiteId: s
+include:
+  - shared.suite.yaml
+  - pack
+missions:
+  - missionId: "title-{{site}}"
+    matrix:
+      site: [a.test, b.test]
+    prompt: 'Open https://{{site}} and say "{{ site }}"'
+    expects:
+      resultMatches: "{{site}}"
+  - missionId: grid
+    matrix:
+      y: ["1", "2"]
+      x: [p]
+    prompt: "{{x}}/{{y}}/{{unknown}}"
+`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("write suite file: %v", err)
+	}
+	ps, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	var got []string
+	for _, m := range ps.Suite.Missions {
+		got = append(got, m.MissionID)
+	}
+	if want := "smoke,a,b,title-a-test,title-b-test,grid-1,grid-2"; strings.Join(got, ",") != want {
+		t.Fatalf("mission order: got %v want %s", got, want)
+	}
+	m := ps.Suite.Missions[4]
+	if m.Prompt != `Open https://b.test and say "b.test"` || m.Expects.ResultMatches != "b.test" || m.MatrixValues["site"] != "b.test" || m.Matrix != nil {
+		t.Fatalf("unexpected expanded mission: %+v", m)
+	}
+	if p := ps.Suite.Missions[6].Prompt; p != "p/2/{{unknown}}" {
+		t.Fatalf("unexpected grid prompt: %q", p)
+	}
+	if len(ps.Origins) != 7 || ps.Origins[1].Source != filepath.Join(dir, "pack", "a.md") || ps.Origins[3].Template != "title-{{site}}" || ps.Origins[3].MissionID != "title-a-test" {
+		t.Fatalf("unexpected origins: %+v", ps.Origins)
+	}
+	if ps.Suite.Include != nil {
+		t.Fatalf("expected include to be cleared in the canonical suite")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "shared.suite.yaml"), []byte("version: 1\nsuiteId: shared\ninclude: [suite.yaml]\nmissions:\n  - missionId: smoke\n"), 0o644); err != nil {
+		t.Fatalf("write shared: %v", err)
+	}
+	if _, err := ParseFile(path); err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Fatalf("expected include cycle error, got %v", err)
+	}
+}
@@ -79,6 +79,7 @@ func missingJSONPointerFailures(pointers []string, doc any) []ExpectationFailure
 		failures = append(failures, ExpectationFailure{
 			Code:    "ZCL_E_EXPECT_RESULT_JSON_POINTER",
 			Message: "missing required resultJson pointer " + ptr,
+			Pointer: ptr,
 		})
 	}
 	return failures
@@ -133,6 +134,10 @@ func jsonPointerLookup(doc any, pointer string) (any, bool) {
 	if !ok {
 		return nil, false
 	}
+	return lookupTokens(doc, tokens)
+}
+
+func lookupTokens(doc any, tokens []string) (any, bool) {
 	cur := doc
 	for _, tok := range tokens {
 		switch n := cur.(type) {
@@ -143,9 +148,6 @@ func jsonPointerLookup(doc any, pointer string) (any, bool) {
 			}
 			cur = next
 		case []any:
-			if tok == "-" {
-				return nil, false
-			}
 			idx, err := strconv.Atoi(tok)
 			if err != nil || idx < 0 || idx >= len(n) {
 				return nil, false
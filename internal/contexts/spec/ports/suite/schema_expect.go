@@ -0,0 +1,493 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported JSON Schema keywords (draft 2020-12 subset). Unknown keywords such as
+// title/description/format are ignored, matching how validators treat annotations.
+//
+//	type, enum, const, $ref (local "#/..."), allOf, anyOf, oneOf, not,
+//	minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf,
+//	minLength, maxLength, pattern,
+//	items, prefixItems, minItems, maxItems, uniqueItems, contains,
+//	properties, required, additionalProperties, patternProperties, minProperties, maxProperties
+const schemaMaxRefDepth = 64
+
+// loadExpectsSchemaFile reads a JSON/YAML schema referenced from a suite file so the
+// suite.json snapshot carries the schema inline.
+func loadExpectsSchemaFile(baseDir string, ref string) (any, error) {
+	path := strings.TrimSpace(ref)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := DecodeDocument(path, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file %s: %w", ref, err)
+	}
+	return doc, nil
+}
+
+// DecodeDocument decodes a .yaml/.yml (by extension) or JSON document into encoding/json shapes.
+func DecodeDocument(path string, raw []byte) (any, error) {
+	var doc any
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &doc)
+	default:
+		doc, err = decodeJSONDoc(raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return normalizeJSONValue(doc), nil
+}
+
+func decodeJSONDoc(raw []byte) (any, error) {
+	doc, ok := decodeResultJSON(raw)
+	if !ok {
+		return nil, fmt.Errorf("not valid json")
+	}
+	return doc, nil
+}
+
+// checkJSONSchema rejects schemas this validator cannot evaluate faithfully.
+func checkJSONSchema(root any) error {
+	return checkSchemaNode(root, root, "#")
+}
+
+func checkSchemaNode(root any, s any, at string) error {
+	if _, ok := s.(bool); ok {
+		return nil
+	}
+	m, ok := s.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: schema must be an object or boolean", at)
+	}
+	if ref, ok := m["$ref"]; ok {
+		rs, ok := ref.(string)
+		if !ok || !strings.HasPrefix(rs, "#") {
+			return fmt.Errorf("%s: only local $ref (#/...) is supported", at)
+		}
+		if _, ok := resolveSchemaRef(root, rs); !ok {
+			return fmt.Errorf("%s: unresolved $ref %q", at, rs)
+		}
+	}
+	if p, ok := m["pattern"]; ok {
+		ps, ok := p.(string)
+		if !ok {
+			return fmt.Errorf("%s/pattern: must be a string", at)
+		}
+		if _, err := regexp.Compile(ps); err != nil {
+			return fmt.Errorf("%s/pattern: %v", at, err)
+		}
+	}
+	for _, kw := range []string{"not", "additionalProperties", "contains"} {
+		if sub, ok := m[kw]; ok {
+			if err := checkSchemaNode(root, sub, at+"/"+kw); err != nil {
+				return err
+			}
+		}
+	}
+	if items, ok := m["items"]; ok {
+		if arr, isArr := items.([]any); isArr {
+			for i, sub := range arr {
+				if err := checkSchemaNode(root, sub, fmt.Sprintf("%s/items/%d", at, i)); err != nil {
+					return err
+				}
+			}
+		} else if err := checkSchemaNode(root, items, at+"/items"); err != nil {
+			return err
+		}
+	}
+	for _, kw := range []string{"allOf", "anyOf", "oneOf", "prefixItems"} {
+		if raw, ok := m[kw]; ok {
+			arr, ok := raw.([]any)
+			if !ok {
+				return fmt.Errorf("%s/%s: must be an array", at, kw)
+			}
+			for i, sub := range arr {
+				if err := checkSchemaNode(root, sub, fmt.Sprintf("%s/%s/%d", at, kw, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, kw := range []string{"properties", "patternProperties", "$defs", "definitions"} {
+		if raw, ok := m[kw]; ok {
+			props, ok := raw.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s/%s: must be an object", at, kw)
+			}
+			for name, sub := range props {
+				if kw == "patternProperties" {
+					if _, err := regexp.Compile(name); err != nil {
+						return fmt.Errorf("%s/patternProperties: %v", at, err)
+					}
+				}
+				if err := checkSchemaNode(root, sub, at+"/"+kw+"/"+name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func resolveSchemaRef(root any, ref string) (any, bool) {
+	if ref == "#" {
+		return root, true
+	}
+	return jsonPointerLookup(root, strings.TrimPrefix(ref, "#"))
+}
+
+type schemaViolation struct {
+	pointer string
+	message string
+}
+
+// SchemaViolation is one JSON Schema mismatch; Pointer is "" for the document root.
+type SchemaViolation struct {
+	Pointer string
+	Message string
+}
+
+// ValidateJSONSchema checks doc against schemaDoc with the expects.schema validator.
+// Both are normalized through encoding/json first, so Go-typed (generated) schemas work.
+func ValidateJSONSchema(schemaDoc any, doc any) ([]SchemaViolation, error) {
+	root := normalizeJSONValue(schemaDoc)
+	if err := checkJSONSchema(root); err != nil {
+		return nil, err
+	}
+	violations := validateJSONSchema(root, normalizeJSONValue(doc))
+	out := make([]SchemaViolation, 0, len(violations))
+	for _, vi := range violations {
+		out = append(out, SchemaViolation{Pointer: vi.pointer, Message: vi.message})
+	}
+	return out, nil
+}
+
+type schemaValidator struct {
+	root       any
+	violations []schemaViolation
+}
+
+// validateJSONSchema returns every violation of doc against the schema with a JSON pointer
+// to the offending value ("" is the document root).
+func validateJSONSchema(root any, doc any) []schemaViolation {
+	v := &schemaValidator{root: root}
+	v.validate(root, doc, "", 0)
+	return v.violations
+}
+
+func (v *schemaValidator) fail(ptr string, format string, args ...any) {
+	v.violations = append(v.violations, schemaViolation{pointer: ptr, message: fmt.Sprintf(format, args...)})
+}
+
+// check runs a subschema in isolation (for anyOf/oneOf/not/contains).
+func (v *schemaValidator) check(s any, doc any, ptr string, depth int) bool {
+	sub := &schemaValidator{root: v.root}
+	sub.validate(s, doc, ptr, depth)
+	return len(sub.violations) == 0
+}
+
+func (v *schemaValidator) validate(s any, doc any, ptr string, depth int) {
+	if b, ok := s.(bool); ok {
+		if !b {
+			v.fail(ptr, "value is not allowed")
+		}
+		return
+	}
+	m, ok := s.(map[string]any)
+	if !ok {
+		return
+	}
+	if ref, ok := m["$ref"].(string); ok {
+		if depth >= schemaMaxRefDepth {
+			v.fail(ptr, "$ref depth exceeds %d", schemaMaxRefDepth)
+			return
+		}
+		if target, ok := resolveSchemaRef(v.root, ref); ok {
+			v.validate(target, doc, ptr, depth+1)
+		}
+	}
+	if !v.validateType(m, doc, ptr) {
+		return
+	}
+	v.validateEnumConst(m, doc, ptr)
+	v.validateCombinators(m, doc, ptr, depth)
+	switch d := doc.(type) {
+	case float64:
+		v.validateNumber(m, d, ptr)
+	case string:
+		v.validateString(m, d, ptr)
+	case []any:
+		v.validateArray(m, d, ptr, depth)
+	case map[string]any:
+		v.validateObject(m, d, ptr, depth)
+	}
+}
+
+func jsonTypeOf(doc any) string {
+	switch d := doc.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if d == math.Trunc(d) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "unknown"
+}
+
+func (v *schemaValidator) validateType(m map[string]any, doc any, ptr string) bool {
+	raw, ok := m["type"]
+	if !ok {
+		return true
+	}
+	var want []string
+	switch t := raw.(type) {
+	case string:
+		want = []string{t}
+	case []any:
+		for _, x := range t {
+			if s, ok := x.(string); ok {
+				want = append(want, s)
+			}
+		}
+	}
+	got := jsonTypeOf(doc)
+	for _, w := range want {
+		if w == got || (w == "number" && got == "integer") {
+			return true
+		}
+	}
+	v.fail(ptr, "expected type %s, got %s", strings.Join(want, "|"), got)
+	return false
+}
+
+func (v *schemaValidator) validateEnumConst(m map[string]any, doc any, ptr string) {
+	if c, ok := m["const"]; ok && !jsonValuesEqual(doc, c) {
+		v.fail(ptr, "expected const %s, got %s", previewJSON(c), previewJSON(doc))
+	}
+	enum, ok := m["enum"].([]any)
+	if !ok {
+		return
+	}
+	for _, e := range enum {
+		if jsonValuesEqual(doc, e) {
+			return
+		}
+	}
+	v.fail(ptr, "value %s is not one of enum %s", previewJSON(doc), previewJSON(enum))
+}
+
+func (v *schemaValidator) validateCombinators(m map[string]any, doc any, ptr string, depth int) {
+	if all, ok := m["allOf"].([]any); ok {
+		for _, sub := range all {
+			v.validate(sub, doc, ptr, depth)
+		}
+	}
+	if anyOf, ok := m["anyOf"].([]any); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if v.check(sub, doc, ptr, depth) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(ptr, "value does not match any anyOf schema")
+		}
+	}
+	if oneOf, ok := m["oneOf"].([]any); ok {
+		n := 0
+		for _, sub := range oneOf {
+			if v.check(sub, doc, ptr, depth) {
+				n++
+			}
+		}
+		if n != 1 {
+			v.fail(ptr, "value matches %d oneOf schemas (expected exactly 1)", n)
+		}
+	}
+	if not, ok := m["not"]; ok && v.check(not, doc, ptr, depth) {
+		v.fail(ptr, "value must not match the not schema")
+	}
+}
+
+func schemaNumber(m map[string]any, kw string) (float64, bool) {
+	f, ok := normalizeJSONValue(m[kw]).(float64)
+	return f, ok
+}
+
+func (v *schemaValidator) validateNumber(m map[string]any, d float64, ptr string) {
+	if n, ok := schemaNumber(m, "minimum"); ok && d < n {
+		v.fail(ptr, "%s is less than minimum %s", formatMetric(d), formatMetric(n))
+	}
+	if n, ok := schemaNumber(m, "maximum"); ok && d > n {
+		v.fail(ptr, "%s is greater than maximum %s", formatMetric(d), formatMetric(n))
+	}
+	if n, ok := schemaNumber(m, "exclusiveMinimum"); ok && d <= n {
+		v.fail(ptr, "%s is not greater than exclusiveMinimum %s", formatMetric(d), formatMetric(n))
+	}
+	if n, ok := schemaNumber(m, "exclusiveMaximum"); ok && d >= n {
+		v.fail(ptr, "%s is not less than exclusiveMaximum %s", formatMetric(d), formatMetric(n))
+	}
+	if n, ok := schemaNumber(m, "multipleOf"); ok && n > 0 {
+		if q := d / n; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.fail(ptr, "%s is not a multiple of %s", formatMetric(d), formatMetric(n))
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(m map[string]any, d string, ptr string) {
+	n := float64(utf8.RuneCountInString(d))
+	if min, ok := schemaNumber(m, "minLength"); ok && n < min {
+		v.fail(ptr, "string length %s is less than minLength %s", formatMetric(n), formatMetric(min))
+	}
+	if max, ok := schemaNumber(m, "maxLength"); ok && n > max {
+		v.fail(ptr, "string length %s is greater than maxLength %s", formatMetric(n), formatMetric(max))
+	}
+	if p, ok := m["pattern"].(string); ok {
+		if re, err := regexp.Compile(p); err == nil && !re.MatchString(d) {
+			v.fail(ptr, "string does not match pattern %q", p)
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(m map[string]any, d []any, ptr string, depth int) {
+	n := float64(len(d))
+	if min, ok := schemaNumber(m, "minItems"); ok && n < min {
+		v.fail(ptr, "array has %s items, fewer than minItems %s", formatMetric(n), formatMetric(min))
+	}
+	if max, ok := schemaNumber(m, "maxItems"); ok && n > max {
+		v.fail(ptr, "array has %s items, more than maxItems %s", formatMetric(n), formatMetric(max))
+	}
+	prefix, _ := m["prefixItems"].([]any)
+	if legacy, ok := m["items"].([]any); ok {
+		prefix = legacy
+	}
+	for i, el := range d {
+		elPtr := fmt.Sprintf("%s/%d", ptr, i)
+		if i < len(prefix) {
+			v.validate(prefix[i], el, elPtr, depth)
+			continue
+		}
+		if items, ok := m["items"]; ok {
+			if _, isArr := items.([]any); !isArr {
+				v.validate(items, el, elPtr, depth)
+			}
+		}
+	}
+	if unique, _ := m["uniqueItems"].(bool); unique {
+		for i := range d {
+			for j := i + 1; j < len(d); j++ {
+				if jsonValuesEqual(d[i], d[j]) {
+					v.fail(fmt.Sprintf("%s/%d", ptr, j), "duplicate of item %d (uniqueItems)", i)
+				}
+			}
+		}
+	}
+	if c, ok := m["contains"]; ok {
+		for i, el := range d {
+			if v.check(c, el, fmt.Sprintf("%s/%d", ptr, i), depth) {
+				return
+			}
+		}
+		v.fail(ptr, "array does not contain a matching item")
+	}
+}
+
+func (v *schemaValidator) validateObject(m map[string]any, d map[string]any, ptr string, depth int) {
+	n := float64(len(d))
+	if min, ok := schemaNumber(m, "minProperties"); ok && n < min {
+		v.fail(ptr, "object has %s properties, fewer than minProperties %s", formatMetric(n), formatMetric(min))
+	}
+	if max, ok := schemaNumber(m, "maxProperties"); ok && n > max {
+		v.fail(ptr, "object has %s properties, more than maxProperties %s", formatMetric(n), formatMetric(max))
+	}
+	if req, ok := m["required"].([]any); ok {
+		for _, r := range req {
+			if name, ok := r.(string); ok {
+				if _, exists := d[name]; !exists {
+					v.fail(ptr+"/"+escapeJSONPointerToken(name), "missing required property")
+				}
+			}
+		}
+	}
+	props, _ := m["properties"].(map[string]any)
+	patterns, _ := m["patternProperties"].(map[string]any)
+	additional, hasAdditional := m["additionalProperties"]
+	for _, name := range sortedMapKeys(d) {
+		valPtr := ptr + "/" + escapeJSONPointerToken(name)
+		matched := false
+		if sub, ok := props[name]; ok {
+			matched = true
+			v.validate(sub, d[name], valPtr, depth)
+		}
+		for pat, sub := range patterns {
+			if re, err := regexp.Compile(pat); err == nil && re.MatchString(name) {
+				matched = true
+				v.validate(sub, d[name], valPtr, depth)
+			}
+		}
+		if !matched && hasAdditional {
+			if b, ok := additional.(bool); ok && !b {
+				v.fail(valPtr, "additional property is not allowed")
+				continue
+			}
+			v.validate(additional, d[name], valPtr, depth)
+		}
+	}
+}
+
+func escapeJSONPointerToken(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func evaluateSchemaExpectation(s any, fb schema.FeedbackJSONV1) []ExpectationFailure {
+	if s == nil {
+		return nil
+	}
+	if len(fb.ResultJSON) == 0 {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_RESULT_JSON", Message: "expects.schema requires feedback.resultJson"}}
+	}
+	doc, ok := decodeResultJSON(fb.ResultJSON)
+	if !ok {
+		return []ExpectationFailure{{Code: "ZCL_E_EXPECT_RESULT_JSON", Message: "feedback resultJson is not valid json"}}
+	}
+	violations := validateJSONSchema(normalizeJSONValue(s), doc)
+	failures := make([]ExpectationFailure, 0, len(violations))
+	for _, vi := range violations {
+		failures = append(failures, ExpectationFailure{
+			Code:    "ZCL_E_EXPECT_SCHEMA",
+			Message: vi.message,
+			Pointer: vi.pointer,
+		})
+	}
+	return failures
+}
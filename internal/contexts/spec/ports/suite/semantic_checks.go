@@ -0,0 +1,284 @@
+package suite
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SemanticCheckURLEquals     = "url_equals"
+	SemanticCheckNumberWithin  = "number_within"
+	SemanticCheckDateEquals    = "date_equals"
+	SemanticCheckCitesEvidence = "cites_evidence"
+)
+
+var semanticNumberPattern = regexp.MustCompile(`-?\d[\d,]*(?:\.\d+)?`)
+
+// semanticDateLayouts are tried in order; the first layout that parses wins.
+var semanticDateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+	"02.01.2006",
+	"January 2, 2006",
+	"January 2 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// NormalizeSemanticChecks validates checks in place; path prefixes error messages (for example "expects.semantic").
+func NormalizeSemanticChecks(path string, checks []SemanticCheckV1) error {
+	for i := range checks {
+		c := &checks[i]
+		at := fmt.Sprintf("%s.checks[%d]", path, i)
+		c.Kind = strings.ToLower(strings.TrimSpace(c.Kind))
+		c.Pointer = strings.TrimSpace(c.Pointer)
+		if !IsValidJSONPointer(c.Pointer) {
+			return fmt.Errorf("invalid %s.pointer %q", at, c.Pointer)
+		}
+		switch c.Kind {
+		case SemanticCheckURLEquals:
+			s, ok := c.Expected.(string)
+			if _, canon := canonicalSemanticURL(s); !ok || !canon {
+				return fmt.Errorf("%s.expected must be an absolute http(s) url", at)
+			}
+		case SemanticCheckNumberWithin:
+			if _, ok := semanticNumber(c.Expected); !ok {
+				return fmt.Errorf("%s.expected must be a number", at)
+			}
+			if c.Tolerance < 0 {
+				return fmt.Errorf("%s.tolerance must be >= 0", at)
+			}
+		case SemanticCheckDateEquals:
+			if t, ok := c.Expected.(time.Time); ok {
+				// YAML decodes unquoted dates as timestamps.
+				c.Expected = t.Format("2006-01-02")
+			}
+			s, _ := c.Expected.(string)
+			if _, ok := normalizeSemanticDate(s); !ok {
+				return fmt.Errorf("%s.expected must be a date (for example 2026-02-22)", at)
+			}
+		case SemanticCheckCitesEvidence:
+			if c.MinCitations < 0 {
+				return fmt.Errorf("%s.minCitations must be >= 0", at)
+			}
+			if c.MinCitations == 0 {
+				c.MinCitations = 1
+			}
+			c.EvidencePrefixes = normalizeStringList(c.EvidencePrefixes, false)
+		default:
+			return fmt.Errorf("invalid %s.kind %q (expected %s|%s|%s|%s)", at, c.Kind, SemanticCheckURLEquals, SemanticCheckNumberWithin, SemanticCheckDateEquals, SemanticCheckCitesEvidence)
+		}
+	}
+	return nil
+}
+
+// NormalizeSemanticRulePack defaults schemaVersion and validates typed checks.
+func NormalizeSemanticRulePack(rp *SemanticRulePackV1) error {
+	if rp.SchemaVersion == 0 {
+		rp.SchemaVersion = 1
+	}
+	if rp.SchemaVersion != 1 {
+		return fmt.Errorf("unsupported semantic rule pack schemaVersion (expected 1)")
+	}
+	if rp.Default != nil {
+		if err := NormalizeSemanticChecks("default", rp.Default.Checks); err != nil {
+			return err
+		}
+	}
+	for id, m := range rp.Missions {
+		if m == nil {
+			continue
+		}
+		if err := NormalizeSemanticChecks("missions."+id, m.Checks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSemanticChecks(doc any, checks []SemanticCheckV1) []ExpectationFailure {
+	var failures []ExpectationFailure
+	for _, c := range checks {
+		actual, present := jsonPointerLookup(doc, c.Pointer)
+		var f *ExpectationFailure
+		switch c.Kind {
+		case SemanticCheckURLEquals:
+			f = checkSemanticURL(c, actual, present)
+		case SemanticCheckNumberWithin:
+			f = checkSemanticNumber(c, actual, present)
+		case SemanticCheckDateEquals:
+			f = checkSemanticDate(c, actual, present)
+		case SemanticCheckCitesEvidence:
+			f = checkSemanticEvidence(c, actual, present)
+		}
+		if f != nil {
+			failures = append(failures, *f)
+		}
+	}
+	return failures
+}
+
+func checkSemanticURL(c SemanticCheckV1, actual any, present bool) *ExpectationFailure {
+	expected, _ := c.Expected.(string)
+	want, _ := canonicalSemanticURL(expected)
+	s, _ := actual.(string)
+	got, ok := canonicalSemanticURL(s)
+	if present && ok && got == want {
+		return nil
+	}
+	return &ExpectationFailure{
+		Code:    "ZCL_E_EXPECT_SEMANTIC_URL",
+		Message: fmt.Sprintf("%s: expected url %s got %s", c.Pointer, want, semanticActualText(actual, present)),
+	}
+}
+
+func checkSemanticNumber(c SemanticCheckV1, actual any, present bool) *ExpectationFailure {
+	want, _ := semanticNumber(c.Expected)
+	got, ok := semanticNumber(actual)
+	if present && ok && math.Abs(got-want) <= c.Tolerance {
+		return nil
+	}
+	return &ExpectationFailure{
+		Code:    "ZCL_E_EXPECT_SEMANTIC_NUMBER",
+		Message: fmt.Sprintf("%s: expected %v ± %v got %s", c.Pointer, want, c.Tolerance, semanticActualText(actual, present)),
+	}
+}
+
+func checkSemanticDate(c SemanticCheckV1, actual any, present bool) *ExpectationFailure {
+	expected, _ := c.Expected.(string)
+	want, _ := normalizeSemanticDate(expected)
+	s, _ := actual.(string)
+	got, ok := normalizeSemanticDate(s)
+	if present && ok && got == want {
+		return nil
+	}
+	return &ExpectationFailure{
+		Code:    "ZCL_E_EXPECT_SEMANTIC_DATE",
+		Message: fmt.Sprintf("%s: expected date %s got %s", c.Pointer, want, semanticActualText(actual, present)),
+	}
+}
+
+func checkSemanticEvidence(c SemanticCheckV1, actual any, present bool) *ExpectationFailure {
+	var citations []string
+	switch v := actual.(type) {
+	case string:
+		citations = []string{v}
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				citations = append(citations, s)
+			}
+		}
+	}
+	valid := 0
+	for _, cite := range citations {
+		cite = strings.TrimSpace(cite)
+		if cite == "" {
+			continue
+		}
+		if len(c.EvidencePrefixes) > 0 && !hasPrefixMatch([]string{cite}, c.EvidencePrefixes) {
+			return &ExpectationFailure{
+				Code:    "ZCL_E_EXPECT_SEMANTIC_EVIDENCE",
+				Message: fmt.Sprintf("%s: citation %q is outside evidence prefixes %v", c.Pointer, cite, c.EvidencePrefixes),
+			}
+		}
+		valid++
+	}
+	if present && valid >= c.MinCitations {
+		return nil
+	}
+	return &ExpectationFailure{
+		Code:    "ZCL_E_EXPECT_SEMANTIC_EVIDENCE",
+		Message: fmt.Sprintf("%s: expected at least %d evidence citation(s) got %d", c.Pointer, c.MinCitations, valid),
+	}
+}
+
+// canonicalSemanticURL lowercases scheme/host, drops default ports, fragments and trailing slashes,
+// and sorts query parameters. The bool is false for anything but absolute http(s) urls.
+func canonicalSemanticURL(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", false
+	}
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	path := strings.TrimRight(u.EscapedPath(), "/")
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vals := append([]string(nil), query[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	out := scheme + "://" + host + path
+	if len(parts) > 0 {
+		out += "?" + strings.Join(parts, "&")
+	}
+	return out, true
+}
+
+// semanticNumber accepts JSON numbers and extracts the first number from strings ("about 1,234 users").
+func semanticNumber(v any) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case int:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case string:
+		m := semanticNumberPattern.FindString(x)
+		if m == "" {
+			return 0, false
+		}
+		f, err := strconv.ParseFloat(strings.ReplaceAll(m, ",", ""), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func normalizeSemanticDate(raw string) (string, bool) {
+	raw = strings.Join(strings.Fields(raw), " ")
+	for _, layout := range semanticDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
+
+func semanticActualText(actual any, present bool) string {
+	if !present {
+		return "<missing>"
+	}
+	s := fmt.Sprintf("%v", actual)
+	if len(s) > 120 {
+		s = s[:120] + "..."
+	}
+	return strconv.Quote(s)
+}
@@ -0,0 +1,59 @@
+package suite
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestValidateSemantic_TypedChecks(t *testing.T) {
+	checks := []SemanticCheckV1{
+		{Kind: "url_equals", Pointer: "/url", Expected: "https://Blog.Example.com:443/posts/?b=2&a=1#top"},
+		{Kind: "number_within", Pointer: "/users", Expected: 1200, Tolerance: 50},
+		{Kind: "date_equals", Pointer: "/published", Expected: time.Date(2026, 2, 22, 0, 0, 0, 0, time.UTC)},
+		{Kind: "cites_evidence", Pointer: "/sources", EvidencePrefixes: []string{"docs/", "https://"}},
+	}
+	if err := NormalizeSemanticChecks("expects.semantic", checks); err != nil {
+		t.Fatalf("NormalizeSemanticChecks: %v", err)
+	}
+	if checks[2].Expected != "2026-02-22" || checks[3].MinCitations != 1 {
+		t.Fatalf("unexpected normalized checks: %+v", checks)
+	}
+	sem := &SemanticExpectsV1{Checks: checks}
+
+	pass := schema.FeedbackJSONV1{ResultJSON: json.RawMessage(`{
+		"url": "https://blog.example.com/posts?a=1&b=2",
+		"users": "about 1,234 users",
+		"published": "February 22, 2026",
+		"sources": ["docs/api.md", "https://example.com/changelog"]
+	}`)}
+	if got := ValidateSemantic(sem, pass, nil); len(got) != 0 {
+		t.Fatalf("expected typed checks to pass, got %+v", got)
+	}
+
+	fail := schema.FeedbackJSONV1{ResultJSON: json.RawMessage(`{
+		"url": "https://blog.example.com/other",
+		"users": 1500,
+		"published": "22.03.2026",
+		"sources": ["/etc/passwd"]
+	}`)}
+	got := ValidateSemantic(sem, fail, nil)
+	codes := make([]string, 0, len(got))
+	for _, f := range got {
+		codes = append(codes, f.Code)
+	}
+	want := "ZCL_E_EXPECT_SEMANTIC_URL,ZCL_E_EXPECT_SEMANTIC_NUMBER,ZCL_E_EXPECT_SEMANTIC_DATE,ZCL_E_EXPECT_SEMANTIC_EVIDENCE"
+	if strings.Join(codes, ",") != want {
+		t.Fatalf("unexpected failure codes: %v (%+v)", codes, got)
+	}
+
+	if err := NormalizeSemanticChecks("expects.semantic", []SemanticCheckV1{{Kind: "number_within", Pointer: "/n", Expected: "many"}}); err == nil {
+		t.Fatalf("expected non-numeric number_within expected to be rejected")
+	}
+	if err := NormalizeSemanticChecks("expects.semantic", []SemanticCheckV1{{Kind: "regex", Pointer: "/n"}}); err == nil {
+		t.Fatalf("expected unknown check kind to be rejected")
+	}
+}
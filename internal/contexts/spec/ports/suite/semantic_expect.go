@@ -30,6 +30,7 @@ func ValidateSemantic(sem *SemanticExpectsV1, fb schema.FeedbackJSONV1, tf *Trac
 	failures = append(failures, nonEmptyFailures...)
 	meaningfulCount := countMeaningfulValues(doc, placeholders)
 	failures = append(failures, validateSemanticMeaningfulCount(sem.MinMeaningfulFields, meaningfulCount)...)
+	failures = append(failures, validateSemanticChecks(doc, sem.Checks)...)
 	traceFailures := validateSemanticTraceRules(sem, tf, meaningfulCount, nonEmptyCount)
 	return append(failures, traceFailures...)
 }
@@ -1,14 +1,54 @@
 package suite
 
+import (
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/blind"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
 // SuiteFileV1 is the minimal runner-agnostic suite definition format described in CONCEPT.md.
 // It is intentionally small: defaults + missions + optional expectations that validate feedback.json.
 type SuiteFileV1 struct {
-	Version  int         `json:"version" yaml:"version"`
-	SuiteID  string      `json:"suiteId" yaml:"suiteId"`
-	Defaults DefaultsV1  `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	Version  int        `json:"version" yaml:"version"`
+	SuiteID  string     `json:"suiteId" yaml:"suiteId"`
+	Defaults DefaultsV1 `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	// DecisionTags registers suite-specific decision tags on top of the built-in taxonomy;
+	// feedback and validate reject tags that are neither built in nor declared here.
+	DecisionTags []schema.DecisionTagDefV1 `json:"decisionTags,omitempty" yaml:"decisionTags,omitempty"`
+	// BlindTermPacks declares named blind term packs next to the built-in ones (generic-harness,
+	// codex, claude), selectable via defaults.blindTermsPack or --blind-terms-pack.
+	BlindTermPacks map[string][]string `json:"blindTermPacks,omitempty" yaml:"blindTermPacks,omitempty"`
+	// Include pulls missions from other suite files or mission-pack directories (one prompt
+	// mission per .md file), resolved relative to this file. ParseFile inlines them ahead of
+	// Missions (and clears Include); included suites' defaults are ignored.
+	Include  []string    `json:"include,omitempty" yaml:"include,omitempty"`
 	Missions []MissionV1 `json:"missions" yaml:"missions"`
 }
 
+// CustomDecisionTags returns the names of the suite-declared decision tags.
+func (s SuiteFileV1) CustomDecisionTags() []string {
+	out := make([]string, 0, len(s.DecisionTags))
+	for _, d := range s.DecisionTags {
+		out = append(out, d.Name)
+	}
+	return out
+}
+
+// ResolveBlindTerms merges the selected blind term pack (packOverride, else defaults.blindTermsPack)
+// with the explicit terms (termsOverride, else defaults.blindTerms).
+func (s SuiteFileV1) ResolveBlindTerms(packOverride string, termsOverride []string) ([]string, error) {
+	pack := strings.TrimSpace(packOverride)
+	if pack == "" {
+		pack = s.Defaults.BlindTermsPack
+	}
+	terms := termsOverride
+	if len(terms) == 0 {
+		terms = s.Defaults.BlindTerms
+	}
+	return blind.ResolveTerms(pack, s.BlindTermPacks, terms)
+}
+
 type DefaultsV1 struct {
 	TimeoutMs    int64  `json:"timeoutMs,omitempty" yaml:"timeoutMs,omitempty"`
 	TimeoutStart string `json:"timeoutStart,omitempty" yaml:"timeoutStart,omitempty"` // attempt_start|first_tool_call
@@ -18,13 +58,60 @@ type DefaultsV1 struct {
 	FeedbackPolicy string   `json:"feedbackPolicy,omitempty" yaml:"feedbackPolicy,omitempty"`
 	Blind          bool     `json:"blind,omitempty" yaml:"blind,omitempty"`
 	BlindTerms     []string `json:"blindTerms,omitempty" yaml:"blindTerms,omitempty"`
+	// BlindTermsPack names a built-in or suite-declared blind term pack; blindTerms are added to it.
+	BlindTermsPack string `json:"blindTermsPack,omitempty" yaml:"blindTermsPack,omitempty"`
+	// BlindAction is what suite run does with a contaminated prompt: reject (default) fails the
+	// attempt, rewrite underscores the terms and runs the sanitized prompt.
+	BlindAction string `json:"blindAction,omitempty" yaml:"blindAction,omitempty"`
+	// TraceSampling keeps traces of chatty agents usable: matching successful calls are
+	// recorded 1-in-keepEvery, while failures and unmatched calls (e.g. writes) are always kept.
+	TraceSampling []TraceSamplingRuleV1 `json:"traceSampling,omitempty" yaml:"traceSampling,omitempty"`
+	// MCPServers are registered with native runtime sessions, keyed by server name, so native
+	// agents get the tools process-mode adapter scripts start themselves.
+	MCPServers map[string]MCPServerV1 `json:"mcpServers,omitempty" yaml:"mcpServers,omitempty"`
+}
+
+// MCPServerV1 is a stdio MCP server: Command is argv form, Env is added to its environment.
+type MCPServerV1 struct {
+	Command []string          `json:"command" yaml:"command"`
+	Env     map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+}
+
+type TraceSamplingRuleV1 struct {
+	Tool      string `json:"tool" yaml:"tool"` // cli|mcp|http
+	Op        string `json:"op,omitempty" yaml:"op,omitempty"`
+	Prefix    string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	KeepEvery int64  `json:"keepEvery" yaml:"keepEvery"`
+}
+
+// AttemptTraceSampling converts suite sampling rules to the attempt.json shape read by funnels.
+func (d DefaultsV1) AttemptTraceSampling() []schema.TraceSamplingRuleV1 {
+	if len(d.TraceSampling) == 0 {
+		return nil
+	}
+	out := make([]schema.TraceSamplingRuleV1, 0, len(d.TraceSampling))
+	for _, r := range d.TraceSampling {
+		out = append(out, schema.TraceSamplingRuleV1{Tool: r.Tool, Op: r.Op, Prefix: r.Prefix, KeepEvery: r.KeepEvery})
+	}
+	return out
 }
 
 type MissionV1 struct {
-	MissionID string     `json:"missionId" yaml:"missionId"`
-	Prompt    string     `json:"prompt,omitempty" yaml:"prompt,omitempty"`
-	Tags      []string   `json:"tags,omitempty" yaml:"tags,omitempty"`
-	Expects   *ExpectsV1 `json:"expects,omitempty" yaml:"expects,omitempty"`
+	MissionID string `json:"missionId" yaml:"missionId"`
+	Prompt    string `json:"prompt,omitempty" yaml:"prompt,omitempty"`
+	// PromptFile loads the prompt from a file resolved relative to the suite file; ParseFile
+	// inlines it into Prompt (and clears it) so suite.json snapshots stay self-contained.
+	PromptFile string     `json:"promptFile,omitempty" yaml:"promptFile,omitempty"`
+	Tags       []string   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Expects    *ExpectsV1 `json:"expects,omitempty" yaml:"expects,omitempty"`
+	// RequiresNetwork marks missions that cannot succeed offline; `zcl suite run --network none`
+	// blocks them with ZCL_E_NETWORK_REQUIRED instead of running them.
+	RequiresNetwork bool `json:"requiresNetwork,omitempty" yaml:"requiresNetwork,omitempty"`
+	// Matrix expands one mission into one per combination of parameter values; `{{name}}` in
+	// any string field (missionId, prompt, promptFile, tags, expects) is replaced per
+	// combination. ParseFile clears it and records the combination in MatrixValues.
+	Matrix       map[string][]string `json:"matrix,omitempty" yaml:"matrix,omitempty"`
+	MatrixValues map[string]string   `json:"matrixValues,omitempty" yaml:"matrixValues,omitempty"`
 }
 
 type ExpectsV1 struct {
@@ -35,6 +122,24 @@ type ExpectsV1 struct {
 	// Unlike ResultExpectsV1 (shape) and TraceExpectsV1 (counts/patterns), semantic rules
 	// can express non-empty/placeholder/boilerplate constraints.
 	Semantic *SemanticExpectsV1 `json:"semantic,omitempty" yaml:"semantic,omitempty"`
+
+	// ResultMatches / ResultNotMatches are RE2 regexes applied to the feedback result text
+	// (feedback.result, or the raw resultJson when the attempt reported JSON).
+	ResultMatches    string `json:"resultMatches,omitempty" yaml:"resultMatches,omitempty"`
+	ResultNotMatches string `json:"resultNotMatches,omitempty" yaml:"resultNotMatches,omitempty"`
+
+	// Metrics are numeric assertions over the result (see MetricExpectV1).
+	Metrics []MetricExpectV1 `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+	// JSONPath asserts nested resultJson values (see JSONPathExpectV1).
+	JSONPath []JSONPathExpectV1 `json:"jsonPath,omitempty" yaml:"jsonPath,omitempty"`
+	// Schema is a JSON Schema (inline object, or a file path resolved relative to the suite
+	// file at parse time) that feedback.resultJson must satisfy.
+	Schema any `json:"schema,omitempty" yaml:"schema,omitempty"`
+	// Files are checked in the attempt workspace (see FileExpectV1).
+	Files []FileExpectV1 `json:"files,omitempty" yaml:"files,omitempty"`
+	// Golden is a checked-in file the normalized result must equal (see NormalizeGoldenOutput).
+	// Relative paths resolve against the suite file; suite.json records the absolute path.
+	Golden string `json:"golden,omitempty" yaml:"golden,omitempty"`
 }
 
 type ResultExpectsV1 struct {
@@ -107,4 +212,28 @@ type SemanticExpectsV1 struct {
 	HookCommand []string `json:"hookCommand,omitempty" yaml:"hookCommand,omitempty"`
 	// HookTimeoutMs limits HookCommand execution time. Default is 10000ms when unset.
 	HookTimeoutMs int64 `json:"hookTimeoutMs,omitempty" yaml:"hookTimeoutMs,omitempty"`
+
+	// Checks are typed value checks against feedback.resultJson pointers.
+	Checks []SemanticCheckV1 `json:"checks,omitempty" yaml:"checks,omitempty"`
+}
+
+// SemanticRulePackV1 is a semantic rule set outside mission expects: a --semantic-rules file,
+// campaign semantic.rules, or the semantic.rules.json snapshot in an attempt dir.
+type SemanticRulePackV1 struct {
+	SchemaVersion int                           `json:"schemaVersion" yaml:"schemaVersion"`
+	Default       *SemanticExpectsV1            `json:"default,omitempty" yaml:"default,omitempty"`
+	Missions      map[string]*SemanticExpectsV1 `json:"missions,omitempty" yaml:"missions,omitempty"`
+}
+
+type SemanticCheckV1 struct {
+	// Kind is url_equals|number_within|date_equals|cites_evidence.
+	Kind    string `json:"kind" yaml:"kind"`
+	Pointer string `json:"pointer" yaml:"pointer"`
+	// Expected is the url, number or date to compare against (unused for cites_evidence).
+	Expected any `json:"expected,omitempty" yaml:"expected,omitempty"`
+	// Tolerance is the absolute tolerance for number_within.
+	Tolerance float64 `json:"tolerance,omitempty" yaml:"tolerance,omitempty"`
+	// MinCitations (default 1) and EvidencePrefixes constrain cites_evidence citations.
+	MinCitations     int      `json:"minCitations,omitempty" yaml:"minCitations,omitempty"`
+	EvidencePrefixes []string `json:"evidencePrefixes,omitempty" yaml:"evidencePrefixes,omitempty"`
 }
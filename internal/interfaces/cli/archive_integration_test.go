@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchive_RunsListKeepsArchivedRuns(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "archive-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-archive
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	now := time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC)
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return now },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	var res struct {
+		OK       bool `json:"ok"`
+		Archived []struct {
+			RunID string `json:"runId"`
+		} `json:"archived"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"archive", "--older-than", "14d", "--compression", "gzip", "--out-root", outRoot, "--json"}, &res, "archive too young")
+	if len(res.Archived) != 0 {
+		t.Fatalf("fresh run must not be archived: %+v", res)
+	}
+
+	now = now.Add(15 * 24 * time.Hour)
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"archive", "--older-than", "14d", "--compression", "gzip", "--out-root", outRoot, "--json"}, &res, "archive")
+	if !res.OK || len(res.Archived) != 1 {
+		t.Fatalf("expected one archived run, got %+v", res)
+	}
+	runID := res.Archived[0].RunID
+	if _, err := os.Stat(filepath.Join(outRoot, "runs", runID)); !os.IsNotExist(err) {
+		t.Fatalf("expected run dir to be moved into the archive, got %v", err)
+	}
+
+	var runs struct {
+		Runs []runIndexRow `json:"runs"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"runs", "list", "--out-root", outRoot, "--json"}, &runs, "runs list")
+	if len(runs.Runs) != 1 || runs.Runs[0].RunID != runID || runs.Runs[0].ArchivedAt == "" || runs.Runs[0].Status != attemptStatusOK || runs.Runs[0].RunDir != "" {
+		t.Fatalf("expected archived run row, got %+v", runs.Runs)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"archive", "restore", "--run-id", runID, "--out-root", outRoot}, "archive restore")
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"validate", filepath.Join(outRoot, "runs", runID)}, "validate restored run")
+}
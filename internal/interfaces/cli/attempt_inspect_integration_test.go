@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAttemptInspect_MergesArtifactsByDirAndID(t *testing.T) {
+	outRoot := t.TempDir()
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) },
+	}
+	start := startAttemptForQuery(t, r, outRoot, "", "inspect-suite", "m1")
+	runAndFeedbackForQuery(t, r, start.Env, true)
+	attemptDir := start.Env["ZCL_OUT_DIR"]
+	attemptID := start.Env["ZCL_ATTEMPT_ID"]
+	setAttemptEnvForQuery(t, nil)
+
+	type inspectOut struct {
+		OK            bool            `json:"ok"`
+		AttemptDir    string          `json:"attemptDir"`
+		AttemptID     string          `json:"attemptId"`
+		Attempt       json.RawMessage `json:"attempt"`
+		Feedback      json.RawMessage `json:"feedback"`
+		ReportPresent bool            `json:"reportPresent"`
+		ReportSource  string          `json:"reportSource"`
+		Validate      struct {
+			OK bool `json:"ok"`
+		} `json:"validate"`
+		Trace struct {
+			NonEmpty        bool             `json:"nonEmpty"`
+			ToolCallsTotal  int64            `json:"toolCallsTotal"`
+			ToolCallsByTool map[string]int64 `json:"toolCallsByTool"`
+			Tail            []struct {
+				Tool string `json:"tool"`
+			} `json:"tail"`
+		} `json:"trace"`
+	}
+
+	var stdout, stderr bytes.Buffer
+	r.Stdout, r.Stderr = &stdout, &stderr
+	var byDir inspectOut
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"attempt", "inspect", "--json", attemptDir}, &byDir, "attempt inspect <dir>")
+	if !byDir.OK || byDir.AttemptID != attemptID || byDir.Attempt == nil || byDir.Feedback == nil {
+		t.Fatalf("unexpected inspect payload: %s", stdout.String())
+	}
+	if !byDir.ReportPresent || byDir.ReportSource != "computed" || !byDir.Validate.OK {
+		t.Fatalf("expected computed report + valid attempt: %s", stdout.String())
+	}
+	if !byDir.Trace.NonEmpty || byDir.Trace.ToolCallsTotal != 1 || byDir.Trace.ToolCallsByTool["cli"] != 1 || len(byDir.Trace.Tail) != 1 {
+		t.Fatalf("unexpected trace stats: %+v", byDir.Trace)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"attempt", "finish", "--json", attemptDir}, "attempt finish")
+	var byID inspectOut
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"attempt", "inspect", "--out-root", outRoot, "--json", attemptID}, &byID, "attempt inspect <id>")
+	if filepath.Clean(byID.AttemptDir) != filepath.Clean(attemptDir) || byID.ReportSource != "file" {
+		t.Fatalf("attemptId lookup: dir=%q source=%q want dir=%q", byID.AttemptDir, byID.ReportSource, attemptDir)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"attempt", "inspect", "--out-root", outRoot, "--json", "no-such-attempt"}, "attempt inspect unknown id")
+}
+
+func TestAttemptReplay_ReproducesFinishAndFeedsStubRuntime(t *testing.T) {
+	outRoot := t.TempDir()
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC) },
+	}
+	start := startAttemptForQuery(t, r, outRoot, "", "replay-suite", "m1")
+	runAndFeedbackForQuery(t, r, start.Env, true)
+	attemptDir := start.Env["ZCL_OUT_DIR"]
+	setAttemptEnvForQuery(t, nil)
+
+	var stdout, stderr bytes.Buffer
+	r.Stdout, r.Stderr = &stdout, &stderr
+	type replayOut struct {
+		OK              bool `json:"ok"`
+		RecordedPresent bool `json:"recordedPresent"`
+		Reproduced      bool `json:"reproduced"`
+		Diffs           []struct {
+			Field string `json:"field"`
+		} `json:"diffs"`
+		Feed *struct {
+			Status          string `json:"status"`
+			ToolResultsFed  int    `json:"toolResultsFed"`
+			FinalMessage    string `json:"finalMessage"`
+			MatchesRecorded bool   `json:"matchesRecorded"`
+		} `json:"feed"`
+	}
+
+	var before replayOut
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"attempt", "replay", "--json", attemptDir}, &before, "attempt replay before finish")
+	if before.RecordedPresent || !before.OK {
+		t.Fatalf("expected no recorded verdict before finish: %s", stdout.String())
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"attempt", "finish", "--json", attemptDir}, "attempt finish")
+	reportBefore := mustReadFileString(t, filepath.Join(attemptDir, "attempt.report.json"))
+
+	var got replayOut
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"attempt", "replay", "--feed", "--json", attemptDir}, &got, "attempt replay --feed")
+	if !got.OK || !got.RecordedPresent || !got.Reproduced || len(got.Diffs) != 0 {
+		t.Fatalf("expected reproduced verdict: %s", stdout.String())
+	}
+	if got.Feed == nil || got.Feed.Status != "completed" || got.Feed.ToolResultsFed != 1 || got.Feed.FinalMessage != "done" || !got.Feed.MatchesRecorded {
+		t.Fatalf("unexpected feed: %s", stdout.String())
+	}
+	if mustReadFileString(t, filepath.Join(attemptDir, "attempt.report.json")) != reportBefore {
+		t.Fatalf("attempt replay must not rewrite attempt.report.json")
+	}
+
+	// Flip the recorded feedback: the recomputed verdict no longer matches attempt.finish.json.
+	fbPath := filepath.Join(attemptDir, "feedback.json")
+	fb := mustReadFileString(t, fbPath)
+	mustWriteFile(t, fbPath, strings.Replace(fb, `"ok": true`, `"ok": false`, 1))
+	var diverged replayOut
+	runCLICommandJSON(t, &r, &stdout, &stderr, 2, []string{"attempt", "replay", "--json", attemptDir}, &diverged, "attempt replay diverged")
+	if diverged.OK || diverged.Reproduced || len(diverged.Diffs) == 0 || diverged.Diffs[0].Field != "ok" {
+		t.Fatalf("expected diverged verdict: %s", stdout.String())
+	}
+}
+
+func mustReadFileString(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(b)
+}
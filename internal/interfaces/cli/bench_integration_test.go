@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBenchHarness_ReportsPerStageOverhead(t *testing.T) {
+	outRoot := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	var res benchHarnessResult
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"bench", "harness", "--attempts", "3", "--out-root", outRoot, "--json", "--", os.Args[0], "-test.run=^$"}, &res, "bench harness")
+	if !res.OK || res.Attempts != 3 || res.FinishFailed != 0 || !res.Kept {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if len(res.Stages) != len(benchHarnessStages) {
+		t.Fatalf("expected %d stages, got %+v", len(benchHarnessStages), res.Stages)
+	}
+	for i, s := range res.Stages {
+		if s.Name != benchHarnessStages[i] || s.MaxUs < s.P50Us || s.P50Us < s.MinUs {
+			t.Fatalf("unexpected stage summary: %+v", s)
+		}
+	}
+	if res.Total.MaxUs < res.Overhead.MaxUs {
+		t.Fatalf("total below overhead: %+v", res)
+	}
+	reports, err := filepath.Glob(filepath.Join(outRoot, "runs", "*", "attempts", "*", "attempt.report.json"))
+	if err != nil || len(reports) != 3 {
+		t.Fatalf("expected 3 finished attempts, got %v (err=%v)", reports, err)
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+)
+
+func TestCampaignExport_WritesInTotoAttestation(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "export-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-export
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	exportDir := filepath.Join(t.TempDir(), "publish")
+	var res struct {
+		OK              bool     `json:"ok"`
+		AttestationPath string   `json:"attestationPath"`
+		SubjectSHA256   string   `json:"subjectSha256"`
+		Exported        []string `json:"exported"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "export", "--campaign-id", "cmp-export", "--out-root", outRoot, "--out", exportDir, "--json"}, &res, "campaign export")
+	if !res.OK || len(res.Exported) != 4 {
+		t.Fatalf("unexpected export result: %+v", res)
+	}
+
+	summary, err := os.ReadFile(filepath.Join(outRoot, "campaigns", "cmp-export", "campaign.summary.json"))
+	if err != nil {
+		t.Fatalf("read summary: %v", err)
+	}
+	sum := sha256.Sum256(summary)
+	if res.SubjectSHA256 != hex.EncodeToString(sum[:]) {
+		t.Fatalf("subject digest %s does not match campaign.summary.json", res.SubjectSHA256)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(exportDir, "campaign.attestation.intoto.json"))
+	if err != nil {
+		t.Fatalf("read exported attestation: %v", err)
+	}
+	var att campaign.AttestationV1
+	if err := json.Unmarshal(raw, &att); err != nil {
+		t.Fatalf("decode attestation: %v", err)
+	}
+	if att.Type != "https://in-toto.io/Statement/v1" || att.PredicateType != "https://slsa.dev/provenance/v1" {
+		t.Fatalf("unexpected statement header: %s %s", att.Type, att.PredicateType)
+	}
+	if att.Predicate.RunDetails.Builder.Version["zcl"] != "0.0.0-dev" {
+		t.Fatalf("expected zcl version in builder, got %+v", att.Predicate.RunDetails.Builder)
+	}
+	flows := att.Predicate.BuildDefinition.InternalParameters.Flows
+	if len(flows) != 1 || flows[0].SuiteSHA256 == "" || flows[0].ComparabilityKey == "" {
+		t.Fatalf("expected suite digest and comparability key per flow, got %+v", flows)
+	}
+}
@@ -1652,6 +1652,417 @@ flows:
 	if verdict.OK || !strings.Contains(strings.Join(verdict.ReasonCodes, ","), "ZCL_E_CAMPAIGN_ORACLE_EVALUATION_FAILED") {
 		t.Fatalf("unexpected oracle verdict: %+v", verdict)
 	}
+	// A script evaluator without field mismatches cannot confirm or refute individual claims.
+	type claimCounts struct {
+		Confirmed    int `json:"confirmed"`
+		Refuted      int `json:"refuted"`
+		Unverifiable int `json:"unverifiable"`
+	}
+	var claims struct {
+		Counts claimCounts `json:"counts"`
+		Claims []struct {
+			Field  string `json:"field"`
+			Status string `json:"status"`
+		} `json:"claims"`
+	}
+	mustReadJSONFile(t, filepath.Join(attemptDir, "claim.vs.verified.json"), &claims, "claim vs verified")
+	if claims.Counts != (claimCounts{Unverifiable: 1}) || len(claims.Claims) != 1 || claims.Claims[0].Field != "proof" {
+		t.Fatalf("unexpected claim verification: %+v", claims)
+	}
+	var rep struct {
+		Flows []struct {
+			Claims *struct {
+				Attempts     int `json:"attempts"`
+				Unverifiable int `json:"unverifiable"`
+			} `json:"claims"`
+		} `json:"flows"`
+	}
+	mustReadJSONFile(t, filepath.Join(outRoot, "campaigns", "cmp-exam-run", "campaign.report.json"), &rep, "campaign report")
+	if len(rep.Flows) != 1 || rep.Flows[0].Claims == nil || rep.Flows[0].Claims.Attempts != 1 || rep.Flows[0].Claims.Unverifiable != 1 {
+		t.Fatalf("expected per-flow claim counts, got %+v", rep.Flows)
+	}
+}
+
+func TestCampaignRun_ExamModeBuiltinCompareOracleGradesAttemptAnswer(t *testing.T) {
+	cases := []struct {
+		name       string
+		oracle     string
+		wantExit   int
+		wantOK     bool
+		wantReason string
+	}{
+		{name: "match", oracle: `{"expected":{"proof":"file-channel-ok"}}`, wantExit: 0, wantOK: true},
+		{name: "mismatch", oracle: `{"expected":{"proof":"other"}}`, wantExit: 2, wantReason: "ZCL_E_CAMPAIGN_ORACLE_EVALUATION_FAILED"},
+		{name: "no-expected-answer", oracle: `{"schemaVersion":1,"missionId":"m1"}`, wantExit: 2, wantReason: "ZCL_E_CAMPAIGN_ORACLE_EVALUATION_ERROR"},
+		{name: "invalid-oracle", oracle: `expected title: hello`, wantExit: 2, wantReason: "ZCL_E_CAMPAIGN_ORACLE_EVALUATION_ERROR"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			outRoot := t.TempDir()
+			specDir := t.TempDir()
+			promptDir := filepath.Join(specDir, "prompts")
+			oracleDir := filepath.Join(specDir, "oracles")
+			mustMkdirAll(t, promptDir)
+			mustMkdirAll(t, oracleDir)
+			mustWriteFile(t, filepath.Join(promptDir, "m1.md"), "Solve the task and return proof JSON.")
+			mustWriteFile(t, filepath.Join(oracleDir, "m1.md"), tc.oracle)
+			specPath := filepath.Join(specDir, "campaign.yaml")
+			mustWriteFile(t, specPath, `
+schemaVersion: 1
+campaignId: cmp-exam-compare
+promptMode: exam
+missionSource:
+  promptSource:
+    path: prompts
+  oracleSource:
+    path: oracles
+    visibility: workspace
+evaluation:
+  mode: oracle
+  evaluator:
+    kind: builtin_compare
+flows:
+  - flowId: flow-a
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=result-file-ok"]
+      finalization:
+        mode: auto_from_result_json
+        resultChannel:
+          kind: file_json
+`)
+			t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			r := Runner{
+				Version: "0.0.0-dev",
+				Now:     func() time.Time { return time.Date(2026, 2, 22, 20, 20, 0, 0, time.UTC) },
+				Stdout:  &stdout,
+				Stderr:  &stderr,
+			}
+			runCLICommand(t, &r, &stdout, &stderr, tc.wantExit, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+			var st struct {
+				FlowRuns []struct {
+					Attempts []struct {
+						AttemptDir string `json:"attemptDir"`
+					} `json:"attempts"`
+				} `json:"flowRuns"`
+			}
+			mustReadJSONFile(t, filepath.Join(outRoot, "campaigns", "cmp-exam-compare", "campaign.run.state.json"), &st, "campaign run state")
+			if len(st.FlowRuns) == 0 || len(st.FlowRuns[0].Attempts) == 0 || st.FlowRuns[0].Attempts[0].AttemptDir == "" {
+				t.Fatalf("expected flow attempt data in state: %+v", st)
+			}
+			var verdict struct {
+				OK          bool     `json:"ok"`
+				ReasonCodes []string `json:"reasonCodes"`
+			}
+			mustReadJSONFile(t, filepath.Join(st.FlowRuns[0].Attempts[0].AttemptDir, "oracle.verdict.json"), &verdict, "oracle verdict")
+			if verdict.OK != tc.wantOK {
+				t.Fatalf("expected verdict ok=%v, got %+v", tc.wantOK, verdict)
+			}
+			if tc.wantReason != "" && !strings.Contains(strings.Join(verdict.ReasonCodes, ","), tc.wantReason) {
+				t.Fatalf("expected reason %s, got %+v", tc.wantReason, verdict)
+			}
+		})
+	}
+}
+
+func TestCampaignRun_ExamModeEvaluatorEnsembleMajorityRecordsVotes(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	promptDir := filepath.Join(specDir, "prompts")
+	oracleDir := filepath.Join(specDir, "oracles")
+	mustMkdirAll(t, promptDir)
+	mustMkdirAll(t, oracleDir)
+	mustWriteFile(t, filepath.Join(promptDir, "m1.md"), "Solve the task and return proof JSON.")
+	mustWriteFile(t, filepath.Join(oracleDir, "m1.md"), "expected title: hello")
+	evalCmd := func(kind string) string {
+		return `["` + os.Args[0] + `", "-test.run=TestHelperCampaignOracleEvaluator$", "--", "case=` + kind + `"]`
+	}
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, `
+schemaVersion: 1
+campaignId: cmp-exam-ensemble
+promptMode: exam
+missionSource:
+  promptSource:
+    path: prompts
+  oracleSource:
+    path: oracles
+    visibility: workspace
+evaluation:
+  mode: oracle
+  evaluators:
+    - id: strict
+      kind: script
+      command: `+evalCmd("ok")+`
+    - id: lenient
+      kind: script
+      command: `+evalCmd("ok")+`
+    - id: flaky
+      kind: script
+      command: `+evalCmd("error")+`
+flows:
+  - flowId: flow-a
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=result-file-ok"]
+      finalization:
+        mode: auto_from_result_json
+        resultChannel:
+          kind: file_json
+	`)
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+	t.Setenv("ZCL_WANT_CAMPAIGN_ORACLE_EVAL", "1")
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 20, 10, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+	var st struct {
+		MissionGates []struct {
+			OK       bool `json:"ok"`
+			Attempts []struct {
+				AttemptDir  string `json:"attemptDir"`
+				OracleVotes []struct {
+					EvaluatorID string `json:"evaluatorId"`
+					OK          bool   `json:"ok"`
+					Error       bool   `json:"error"`
+				} `json:"oracleVotes"`
+			} `json:"attempts"`
+		} `json:"missionGates"`
+	}
+	mustReadJSONFile(t, filepath.Join(outRoot, "campaigns", "cmp-exam-ensemble", "campaign.run.state.json"), &st, "campaign run state")
+	if len(st.MissionGates) != 1 || !st.MissionGates[0].OK || len(st.MissionGates[0].Attempts) != 1 {
+		t.Fatalf("expected passing mission gate, got %+v", st.MissionGates)
+	}
+	att := st.MissionGates[0].Attempts[0]
+	if len(att.OracleVotes) != 3 || !att.OracleVotes[0].OK || !att.OracleVotes[1].OK || !att.OracleVotes[2].Error {
+		t.Fatalf("unexpected oracle votes: %+v", att.OracleVotes)
+	}
+	var verdict struct {
+		OK            bool   `json:"ok"`
+		EvaluatorKind string `json:"evaluatorKind"`
+		Ensemble      struct {
+			Policy  string `json:"policy"`
+			Passed  int    `json:"passed"`
+			Errored int    `json:"errored"`
+		} `json:"ensemble"`
+	}
+	mustReadJSONFile(t, filepath.Join(att.AttemptDir, "oracle.verdict.json"), &verdict, "oracle verdict")
+	if !verdict.OK || verdict.EvaluatorKind != "ensemble" || verdict.Ensemble.Policy != "majority" || verdict.Ensemble.Passed != 2 || verdict.Ensemble.Errored != 1 {
+		t.Fatalf("unexpected ensemble verdict: %+v", verdict)
+	}
+	var rep struct {
+		JudgeAgreement *struct {
+			Attempts int `json:"attempts"`
+			Pairs    []struct {
+				AgreementRate float64 `json:"agreementRate"`
+			} `json:"pairs"`
+		} `json:"judgeAgreement"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "report", "--campaign-id", "cmp-exam-ensemble", "--out-root", outRoot, "--json"}, &rep, "campaign report")
+	if rep.JudgeAgreement == nil || rep.JudgeAgreement.Attempts != 1 || len(rep.JudgeAgreement.Pairs) != 1 || rep.JudgeAgreement.Pairs[0].AgreementRate != 1 {
+		t.Fatalf("unexpected judge agreement: %+v", rep.JudgeAgreement)
+	}
+}
+
+func TestCampaignRun_ExamModeRubricScoresAttemptAndFlowMean(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	promptDir := filepath.Join(specDir, "prompts")
+	oracleDir := filepath.Join(specDir, "oracles")
+	mustMkdirAll(t, promptDir)
+	mustMkdirAll(t, oracleDir)
+	mustWriteFile(t, filepath.Join(promptDir, "m1.md"), "Solve the task and return proof JSON.")
+	mustWriteFile(t, filepath.Join(oracleDir, "m1.md"), "expected title: hello")
+	evalCmd := func(kind string) string {
+		return `["` + os.Args[0] + `", "-test.run=TestHelperCampaignOracleEvaluator$", "--", "case=` + kind + `"]`
+	}
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, `
+schemaVersion: 1
+campaignId: cmp-exam-rubric
+promptMode: exam
+missionSource:
+  promptSource:
+    path: prompts
+  oracleSource:
+    path: oracles
+    visibility: workspace
+evaluation:
+  mode: oracle
+  rubricPassScore: 0.7
+  rubric:
+    - criterion: title
+      weight: 3
+      evaluator:
+        kind: script
+        command: `+evalCmd("ok")+`
+    - criterion: citations
+      evaluator:
+        kind: script
+        command: `+evalCmd("fail")+`
+flows:
+  - flowId: flow-a
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=result-file-ok"]
+      finalization:
+        mode: auto_from_result_json
+        resultChannel:
+          kind: file_json
+	`)
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+	t.Setenv("ZCL_WANT_CAMPAIGN_ORACLE_EVAL", "1")
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 20, 10, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+	var st struct {
+		MissionGates []struct {
+			OK       bool `json:"ok"`
+			Attempts []struct {
+				AttemptDir  string   `json:"attemptDir"`
+				RubricScore *float64 `json:"rubricScore"`
+			} `json:"attempts"`
+		} `json:"missionGates"`
+	}
+	mustReadJSONFile(t, filepath.Join(outRoot, "campaigns", "cmp-exam-rubric", "campaign.run.state.json"), &st, "campaign run state")
+	if len(st.MissionGates) != 1 || !st.MissionGates[0].OK || len(st.MissionGates[0].Attempts) != 1 {
+		t.Fatalf("expected passing mission gate, got %+v", st.MissionGates)
+	}
+	att := st.MissionGates[0].Attempts[0]
+	if att.RubricScore == nil || *att.RubricScore != 0.75 {
+		t.Fatalf("expected rubric score 0.75, got %v", att.RubricScore)
+	}
+	var verdict struct {
+		OK            bool   `json:"ok"`
+		EvaluatorKind string `json:"evaluatorKind"`
+		Rubric        struct {
+			Earned   float64 `json:"earned"`
+			Total    float64 `json:"total"`
+			Criteria []struct {
+				Criterion string `json:"criterion"`
+				OK        bool   `json:"ok"`
+			} `json:"criteria"`
+		} `json:"rubric"`
+	}
+	mustReadJSONFile(t, filepath.Join(att.AttemptDir, "oracle.verdict.json"), &verdict, "oracle verdict")
+	if !verdict.OK || verdict.EvaluatorKind != "rubric" || verdict.Rubric.Earned != 3 || verdict.Rubric.Total != 4 || len(verdict.Rubric.Criteria) != 2 || verdict.Rubric.Criteria[1].OK {
+		t.Fatalf("unexpected rubric verdict: %+v", verdict)
+	}
+	var rep struct {
+		Flows []struct {
+			FlowID         string   `json:"flowId"`
+			ScoredAttempts int      `json:"scoredAttempts"`
+			MeanScore      *float64 `json:"meanScore"`
+		} `json:"flows"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "report", "--campaign-id", "cmp-exam-rubric", "--out-root", outRoot, "--json"}, &rep, "campaign report")
+	if len(rep.Flows) != 1 || rep.Flows[0].ScoredAttempts != 1 || rep.Flows[0].MeanScore == nil || *rep.Flows[0].MeanScore != 0.75 {
+		t.Fatalf("unexpected flow scores: %+v", rep.Flows)
+	}
+}
+
+func TestCampaignRun_InlineSemanticRulesAreSnapshottedIntoAttempt(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	promptDir := filepath.Join(specDir, "prompts")
+	oracleDir := filepath.Join(specDir, "oracles")
+	mustMkdirAll(t, promptDir)
+	mustMkdirAll(t, oracleDir)
+	mustWriteFile(t, filepath.Join(promptDir, "m1.md"), "Solve the task and return proof JSON.")
+	mustWriteFile(t, filepath.Join(oracleDir, "m1.md"), "expected title: hello")
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, `
+schemaVersion: 1
+campaignId: cmp-inline-semantic
+promptMode: exam
+missionSource:
+  promptSource:
+    path: prompts
+  oracleSource:
+    path: oracles
+    visibility: workspace
+evaluation:
+  mode: oracle
+  evaluator:
+    kind: script
+    command: ["`+os.Args[0]+`", "-test.run=TestHelperCampaignOracleEvaluator$", "--", "case=ok"]
+semantic:
+  enabled: true
+  rules:
+    default:
+      nonEmptyJsonPointers: ["/proof"]
+      placeholderValues: ["n/a"]
+flows:
+  - flowId: flow-a
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=result-file-ok"]
+      finalization:
+        mode: auto_from_result_json
+        resultChannel:
+          kind: file_json
+	`)
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+	t.Setenv("ZCL_WANT_CAMPAIGN_ORACLE_EVAL", "1")
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 20, 10, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+	var st struct {
+		FlowRuns []struct {
+			Attempts []struct {
+				AttemptDir string `json:"attemptDir"`
+			} `json:"attempts"`
+		} `json:"flowRuns"`
+	}
+	mustReadJSONFile(t, filepath.Join(outRoot, "campaigns", "cmp-inline-semantic", "campaign.run.state.json"), &st, "campaign run state")
+	if len(st.FlowRuns) == 0 || len(st.FlowRuns[0].Attempts) == 0 {
+		t.Fatalf("expected flow attempt data in state: %+v", st)
+	}
+	attemptDir := st.FlowRuns[0].Attempts[0].AttemptDir
+	var snapshot struct {
+		SchemaVersion int `json:"schemaVersion"`
+		Default       struct {
+			NonEmptyJSONPointers []string `json:"nonEmptyJsonPointers"`
+		} `json:"default"`
+	}
+	mustReadJSONFile(t, filepath.Join(attemptDir, "semantic.rules.json"), &snapshot, "semantic rules snapshot")
+	if snapshot.SchemaVersion != 1 || len(snapshot.Default.NonEmptyJSONPointers) != 1 {
+		t.Fatalf("unexpected semantic rules snapshot: %+v", snapshot)
+	}
+
+	var res struct {
+		OK       bool `json:"ok"`
+		Attempts []struct {
+			RuleSource string `json:"ruleSource"`
+		} `json:"attempts"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"validate", "--semantic", "--json", attemptDir}, &res, "validate --semantic")
+	if !res.OK || len(res.Attempts) != 1 || res.Attempts[0].RuleSource != "semantic.rules.json:default" {
+		t.Fatalf("expected validate to reuse the attempt rule snapshot, got %+v", res)
+	}
 }
 
 func TestCampaignRun_ExamModeInfraFeedbackSkipsOracleAndBucketsInfra(t *testing.T) {
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCampaignRegrade_ExportStripsFlowsAndMergeDeblinds(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	for _, name := range []string{"suite-a.json", "suite-b.json"} {
+		writeSuiteFile(t, filepath.Join(specDir, name), `{
+  "version": 1,
+  "suiteId": "regrade-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	}
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-regrade
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-alpha
+    suiteFile: suite-a.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+  - flowId: flow-beta
+    suiteFile: suite-b.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	exportDir := filepath.Join(t.TempDir(), "blind")
+	var exp struct {
+		ExportID    string `json:"exportId"`
+		Items       int    `json:"items"`
+		ItemsPath   string `json:"itemsPath"`
+		MappingPath string `json:"mappingPath"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "regrade", "export", "--campaign-id", "cmp-regrade", "--out-root", outRoot, "--out", exportDir, "--seed", "1", "--json"}, &exp, "campaign regrade export")
+	if exp.Items != 2 || !strings.HasPrefix(exp.MappingPath, outRoot) {
+		t.Fatalf("unexpected export summary: %+v", exp)
+	}
+	itemsRaw, err := os.ReadFile(exp.ItemsPath)
+	if err != nil {
+		t.Fatalf("read items: %v", err)
+	}
+	for _, leak := range []string{"flow-alpha", "flow-beta", "process_cmd"} {
+		if strings.Contains(string(itemsRaw), leak) {
+			t.Fatalf("blind export leaks %q: %s", leak, itemsRaw)
+		}
+	}
+	var items struct {
+		Items []struct {
+			BlindID string `json:"blindId"`
+		} `json:"items"`
+	}
+	mustReadJSONFile(t, exp.ItemsPath, &items, "regrade items")
+
+	verdictsPath := filepath.Join(t.TempDir(), "verdicts.json")
+	mustWriteFile(t, verdictsPath, fmt.Sprintf(`{"schemaVersion":1,"exportId":%q,"verdicts":[{"blindId":%q,"ok":false,"reason":"wrong","grader":"reviewer-1"}]}`, exp.ExportID, items.Items[0].BlindID))
+
+	var rep struct {
+		Graded   int      `json:"graded"`
+		Flips    int      `json:"flips"`
+		Ungraded []string `json:"ungraded"`
+		Attempts []struct {
+			FlowID     string `json:"flowId"`
+			OriginalOK bool   `json:"originalOk"`
+			RegradedOK bool   `json:"regradedOk"`
+		} `json:"attempts"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "regrade", "merge", "--campaign-id", "cmp-regrade", "--out-root", outRoot, "--verdicts", verdictsPath, "--json"}, &rep, "campaign regrade merge")
+	if rep.Graded != 1 || rep.Flips != 1 || len(rep.Ungraded) != 1 || len(rep.Attempts) != 1 {
+		t.Fatalf("unexpected regrade report: %+v", rep)
+	}
+	if a := rep.Attempts[0]; !strings.HasPrefix(a.FlowID, "flow-") || !a.OriginalOK || a.RegradedOK {
+		t.Fatalf("unexpected de-blinded attempt: %+v", a)
+	}
+	if _, err := os.Stat(filepath.Join(outRoot, "campaigns", "cmp-regrade", "campaign.regrade.json")); err != nil {
+		t.Fatalf("expected campaign.regrade.json: %v", err)
+	}
+}
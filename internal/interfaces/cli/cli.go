@@ -35,6 +35,7 @@ import (
 	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/runnerid"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
@@ -54,6 +55,28 @@ type Runner struct {
 
 func (r Runner) Run(args []string) int {
 	r = r.withDefaults()
+	g, args, err := splitGlobalFlags(args)
+	if g.errorFormat == errorFormatJSON {
+		return r.runWithErrorEnvelope(args, func(r Runner) int { return r.runMapped(g, args, err) })
+	}
+	return r.runMapped(g, args, err)
+}
+
+// runMapped runs the command and applies the configured exitCodes mapping to its exit code.
+func (r Runner) runMapped(g globalFlags, args []string, globalErr error) int {
+	if len(args) > 0 && exitPassthroughCommands[args[0]] {
+		return r.run(g, args, globalErr)
+	}
+	ew := &errorCodeWriter{w: r.Stderr}
+	r.Stderr = ew
+	return remapExitCode(r.run(g, args, globalErr), ew.code)
+}
+
+func (r Runner) run(g globalFlags, args []string, globalErr error) int {
+	if globalErr != nil {
+		return r.failUsage(globalErr.Error())
+	}
+	config.SelectProfile(g.profile)
 	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
 		printRootHelp(r.Stdout)
 		return 0
@@ -66,6 +89,7 @@ func (r Runner) Run(args []string) int {
 		return exit
 	}
 	r.maybePrintUpdateNotice(args)
+	installIdentityLoader()
 	return r.runRootCommand(args[0], args[1:])
 }
 
@@ -84,27 +108,46 @@ func (r Runner) withDefaults() Runner {
 
 func (r Runner) runRootCommand(command string, args []string) int {
 	handlers := map[string]func([]string) int{
-		"contract": r.runContract,
-		"init":     r.runInit,
-		"update":   r.runUpdate,
-		"feedback": r.runFeedback,
-		"note":     r.runNote,
-		"report":   r.runReport,
-		"validate": r.runValidate,
-		"doctor":   r.runDoctor,
-		"gc":       r.runGC,
-		"pin":      r.runPin,
-		"enrich":   r.runEnrich,
-		"mcp":      r.runMCP,
-		"http":     r.runHTTP,
-		"run":      r.runRun,
-		"attempt":  r.runAttempt,
-		"suite":    r.runSuite,
-		"campaign": r.runCampaign,
-		"mission":  r.runMission,
-		"runs":     r.runRuns,
-		"replay":   r.runReplay,
-		"expect":   r.runExpect,
+		"contract":   r.runContract,
+		"init":       r.runInit,
+		"update":     r.runUpdate,
+		"feedback":   r.runFeedback,
+		"note":       r.runNote,
+		"report":     r.runReport,
+		"validate":   r.runValidate,
+		"doctor":     r.runDoctor,
+		"gc":         r.runGC,
+		"pin":        r.runPin,
+		"enrich":     r.runEnrich,
+		"mcp":        r.runMCP,
+		"http":       r.runHTTP,
+		"ws":         r.runWS,
+		"run":        r.runRun,
+		"attempt":    r.runAttempt,
+		"suite":      r.runSuite,
+		"campaign":   r.runCampaign,
+		"mission":    r.runMission,
+		"runs":       r.runRuns,
+		"replay":     r.runReplay,
+		"expect":     r.runExpect,
+		"schema":     r.runSchema,
+		"scan":       r.runScan,
+		"redact":     r.runRedact,
+		"purge":      r.runPurge,
+		"review":     r.runReview,
+		"verdict":    r.runVerdict,
+		"sync":       r.runSync,
+		"sign":       r.runSign,
+		"verify":     r.runVerify,
+		"encryption": r.runEncryption,
+		"migrate":    r.runMigrate,
+		"repro":      r.runRepro,
+		"archive":    r.runArchive,
+		"config":     r.runConfig,
+		"top":        r.runTop,
+		"serve":      r.runServe,
+		"export":     r.runExport,
+		"bench":      r.runBench,
 	}
 	if handler, ok := handlers[command]; ok {
 		return handler(args)
@@ -142,6 +185,14 @@ func (r Runner) runContract(args []string) int {
 }
 
 func (r Runner) runInit(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "suite":
+			return r.runInitSuite(args[1:])
+		case "campaign":
+			return r.runInitCampaign(args[1:])
+		}
+	}
 	fs := flag.NewFlagSet("init", flag.ContinueOnError)
 	fs.SetOutput(io.Discard)
 
@@ -190,6 +241,12 @@ func (r Runner) runAttempt(args []string) int {
 		return r.runAttemptFinish(args[1:])
 	case "explain":
 		return r.runAttemptExplain(args[1:])
+	case "inspect":
+		return r.runAttemptInspect(args[1:])
+	case "replay":
+		return r.runAttemptReplay(args[1:])
+	case "export":
+		return r.runAttemptExport(args[1:])
 	case "list":
 		return r.runAttemptList(args[1:])
 	case "latest":
@@ -209,6 +266,8 @@ func (r Runner) runRuns(args []string) int {
 	switch args[0] {
 	case "list":
 		return r.runRunsList(args[1:])
+	case "compact":
+		return r.runRunsCompact(args[1:])
 	default:
 		fmt.Fprintf(r.Stderr, codeUsage+": unknown runs subcommand %q\n", args[0])
 		printRunsHelp(r.Stderr)
@@ -224,6 +283,10 @@ func (r Runner) runSuite(args []string) int {
 	switch args[0] {
 	case "plan":
 		return r.runSuitePlan(args[1:])
+	case "lint":
+		return r.runSuiteLint(args[1:])
+	case "import":
+		return r.runSuiteImport(args[1:])
 	case "run":
 		return r.runSuiteRun(args[1:])
 	default:
@@ -244,6 +307,7 @@ func (r Runner) runSuitePlan(args []string) int {
 	timeoutStart := fs.String("timeout-start", "", "optional timeout anchor override: attempt_start|first_tool_call")
 	blindOverride := fs.String("blind", "", "optional blind-mode override: on|off")
 	blindTerms := fs.String("blind-terms", "", "optional comma-separated blind harness terms override")
+	blindTermsPack := fs.String("blind-terms-pack", "", "blind term pack: generic-harness|codex|claude or a suite blindTermPacks name (--blind-terms are added)")
 	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
 	jsonOut := fs.Bool("json", false, "print JSON output")
 	help := fs.Bool("help", false, "show help")
@@ -282,14 +346,16 @@ func (r Runner) runSuitePlan(args []string) int {
 	}
 
 	res, err := planner.PlanSuite(r.Now(), planner.SuitePlanOpts{
-		OutRoot:      m.OutRoot,
-		RunID:        strings.TrimSpace(*runID),
-		SuiteFile:    strings.TrimSpace(*file),
-		Mode:         strings.TrimSpace(*mode),
-		TimeoutMs:    *timeoutMs,
-		TimeoutStart: strings.TrimSpace(*timeoutStart),
-		Blind:        blindPtr,
-		BlindTerms:   blind.ParseTermsCSV(*blindTerms),
+		OutRoot:        m.OutRoot,
+		RunID:          strings.TrimSpace(*runID),
+		SuiteFile:      strings.TrimSpace(*file),
+		Mode:           strings.TrimSpace(*mode),
+		TimeoutMs:      *timeoutMs,
+		TimeoutStart:   strings.TrimSpace(*timeoutStart),
+		Blind:          blindPtr,
+		BlindTerms:     blind.ParseTermsCSV(*blindTerms),
+		BlindTermsPack: strings.ToLower(strings.TrimSpace(*blindTermsPack)),
+		ZCLVersion:     r.Version,
 	})
 	if err != nil {
 		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
@@ -350,6 +416,9 @@ func (r Runner) runReplay(args []string) int {
 }
 
 func (r Runner) runExpect(args []string) int {
+	if len(args) > 0 && args[0] == "update-goldens" {
+		return r.runExpectUpdateGoldens(args[1:])
+	}
 	fs := flag.NewFlagSet("expect", flag.ContinueOnError)
 	fs.SetOutput(io.Discard)
 
@@ -390,7 +459,50 @@ func (r Runner) runExpect(args []string) int {
 	return 0
 }
 
+func (r Runner) runExpectUpdateGoldens(args []string) int {
+	fs := flag.NewFlagSet("expect update-goldens", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("expect update-goldens: invalid flags")
+	}
+	if *help {
+		printExpectHelp(r.Stdout)
+		return 0
+	}
+	if !*jsonOut {
+		printExpectHelp(r.Stderr)
+		return r.failUsage("expect update-goldens: require --json for stable output")
+	}
+
+	paths := fs.Args()
+	if len(paths) != 1 {
+		printExpectHelp(r.Stderr)
+		return r.failUsage("expect update-goldens: require exactly one <attemptDir|runDir>")
+	}
+
+	res, err := expect.UpdateGoldens(paths[0])
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	exit := r.writeJSON(res)
+	if exit != 0 {
+		return exit
+	}
+	if !res.OK {
+		return 2
+	}
+	return 0
+}
+
 func (r Runner) runFeedback(args []string) int {
+	if len(args) > 0 && args[0] == "progress" {
+		return r.runFeedbackProgress(args[1:])
+	}
 	fs := flag.NewFlagSet("feedback", flag.ContinueOnError)
 	fs.SetOutput(io.Discard)
 
@@ -402,6 +514,10 @@ func (r Runner) runFeedback(args []string) int {
 	decisionTagsCSV := fs.String("decision-tags", "", "comma-separated decision tags")
 	var decisionTags stringListFlag
 	fs.Var(&decisionTags, "decision-tag", "decision tag (repeatable)")
+	var appendTags stringListFlag
+	fs.Var(&appendTags, "append-tag", "add a decision tag to the existing feedback.json (repeatable)")
+	mergeJSON := fs.String("merge-json", "", "JSON merge patch applied to the existing feedback.json resultJson object")
+	confidenceRaw := fs.String("confidence", "", "optional claimed probability (0..1) that the outcome is correct")
 	help := fs.Bool("help", false, "show help")
 
 	if err := fs.Parse(args); err != nil {
@@ -411,32 +527,110 @@ func (r Runner) runFeedback(args []string) int {
 		printFeedbackHelp(r.Stdout)
 		return 0
 	}
-	if (*ok && *fail) || (!*ok && !*fail) {
+	update := len(appendTags) > 0 || *mergeJSON != ""
+	if *ok && *fail {
+		printFeedbackHelp(r.Stderr)
+		return r.failUsage("feedback: require exactly one of --ok or --fail")
+	}
+	if update && (*result != "" || *resultJSON != "") {
+		printFeedbackHelp(r.Stderr)
+		return r.failUsage("feedback: --append-tag/--merge-json update an existing feedback.json and cannot be combined with --result/--result-json")
+	}
+	if !update && !*ok && !*fail {
 		printFeedbackHelp(r.Stderr)
 		return r.failUsage("feedback: require exactly one of --ok or --fail")
 	}
+	var confidence *float64
+	if strings.TrimSpace(*confidenceRaw) != "" {
+		v, err := strconv.ParseFloat(strings.TrimSpace(*confidenceRaw), 64)
+		if err != nil {
+			return r.failUsage("feedback: invalid --confidence (expected a number within 0..1)")
+		}
+		confidence = &v
+	}
 	env, hasEnv := loadFeedbackAttemptEnv()
 	if !hasEnv {
 		printFeedbackHelp(r.Stderr)
 		return r.failUsage("feedback: missing ZCL attempt context (need ZCL_* env)")
 	}
 	decisionTags = append(decisionTags, parseDecisionTagsCSV(*decisionTagsCSV)...)
-	if err := feedback.Write(r.Now(), env, feedback.WriteOpts{
-		OK:             *ok,
-		Result:         *result,
-		ResultJSON:     *resultJSON,
-		Classification: *classification,
-		DecisionTags:   []string(decisionTags),
+	var err error
+	if update {
+		var okPtr *bool
+		if *ok || *fail {
+			okPtr = ok
+		}
+		err = feedback.Update(r.Now(), env, feedback.UpdateOpts{
+			OK:             okPtr,
+			Classification: *classification,
+			AppendTags:     append([]string(decisionTags), appendTags...),
+			Confidence:     confidence,
+			MergeJSON:      *mergeJSON,
+		})
+	} else {
+		err = feedback.Write(r.Now(), env, feedback.WriteOpts{
+			OK:             *ok,
+			Result:         *result,
+			ResultJSON:     *resultJSON,
+			Classification: *classification,
+			DecisionTags:   []string(decisionTags),
+			Confidence:     confidence,
+		})
+	}
+	if err != nil {
+		r.printFeedbackError(err)
+		return 2
+	}
+	fmt.Fprintf(r.Stdout, "feedback: OK\n")
+	return 0
+}
+
+func (r Runner) runFeedbackProgress(args []string) int {
+	fs := flag.NewFlagSet("feedback progress", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	milestone := fs.String("milestone", "", "milestone reached (bounded/redacted)")
+	dataJSON := fs.String("data-json", "", "optional structured milestone payload as json (bounded/canonicalized)")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("feedback progress: invalid flags")
+	}
+	if *help {
+		printFeedbackHelp(r.Stdout)
+		return 0
+	}
+	env, ok := loadFeedbackAttemptEnv()
+	if !ok {
+		printFeedbackHelp(r.Stderr)
+		return r.failUsage("feedback progress: missing ZCL attempt context (need ZCL_* env)")
+	}
+	if err := feedback.AppendProgress(r.Now(), env, feedback.ProgressOpts{
+		Milestone: *milestone,
+		DataJSON:  *dataJSON,
 	}); err != nil {
+		r.printFeedbackError(err)
+		return 2
+	}
+	fmt.Fprintf(r.Stdout, "feedback progress: OK\n")
+	return 0
+}
+
+// printFeedbackError prints one line per rejected field so runner scripts can act on each.
+func (r Runner) printFeedbackError(err error) {
+	var verr *feedback.ValidationError
+	if !errors.As(err, &verr) {
 		msg := err.Error()
 		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", msg)
 		if hint := feedbackHint(msg); hint != "" {
 			fmt.Fprintf(r.Stderr, "hint: %s\n", hint)
 		}
-		return 2
+		return
+	}
+	fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
+	for _, f := range verr.Errors {
+		fmt.Fprintf(r.Stderr, "field %s: %s\n", f.Field, f.Message)
 	}
-	fmt.Fprintf(r.Stdout, "feedback: OK\n")
-	return 0
 }
 
 func loadFeedbackAttemptEnv() (trace.Env, bool) {
@@ -523,6 +717,7 @@ func (r Runner) runAttemptStart(args []string) int {
 	timeoutStart := fs.String("timeout-start", "", "timeout anchor: attempt_start|first_tool_call (default: first_tool_call in discovery, attempt_start in ci)")
 	blindMode := fs.Bool("blind", false, "enable zero-context prompt contamination checks")
 	blindTerms := fs.String("blind-terms", "", "comma-separated contamination terms (default harness terms)")
+	blindTermsPack := fs.String("blind-terms-pack", "", "built-in blind term pack: generic-harness|codex|claude (--blind-terms are added)")
 	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
 	retry := fs.Int("retry", 1, "attempt retry number (default 1)")
 	envFile := fs.String("env-file", "", "optional path to write attempt env in sh/dotenv format (does not affect JSON output)")
@@ -558,6 +753,17 @@ func (r Runner) runAttemptStart(args []string) int {
 		suiteSnap = snap
 	}
 
+	feedbackKeyID, err := sigkey.FeedbackKeyID(os.Getenv(sigkey.FeedbackKeyEnv))
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
+		return 2
+	}
+	resolvedBlindTerms, err := blind.ResolveTerms(strings.ToLower(strings.TrimSpace(*blindTermsPack)), nil, blind.ParseTermsCSV(*blindTerms))
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": attempt start: %s\n", err.Error())
+		return 2
+	}
+
 	res, err := attempt.Start(r.Now(), attempt.StartOpts{
 		OutRoot:        m.OutRoot,
 		RunID:          *runID,
@@ -571,8 +777,10 @@ func (r Runner) runAttemptStart(args []string) int {
 		TimeoutMs:      *timeoutMs,
 		TimeoutStart:   strings.TrimSpace(*timeoutStart),
 		Blind:          *blindMode,
-		BlindTerms:     blind.ParseTermsCSV(*blindTerms),
+		BlindTerms:     resolvedBlindTerms,
 		SuiteSnapshot:  suiteSnap,
+		ZCLVersion:     r.Version,
+		FeedbackKeyID:  feedbackKeyID,
 	})
 	if err != nil {
 		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
@@ -747,6 +955,9 @@ func (r Runner) runReportForAttempt(target string, strict bool, jsonOut bool) in
 }
 
 func (r Runner) runValidate(args []string) int {
+	if len(args) > 0 && args[0] == "file" {
+		return r.runValidateFile(args[1:])
+	}
 	opts, exit, ok := r.parseValidateArgs(args)
 	if !ok {
 		return exit
@@ -755,7 +966,7 @@ func (r Runner) runValidate(args []string) int {
 	if opts.semanticMode {
 		return r.runSemanticValidate(opts.path, opts.semanticRules, opts.jsonOut)
 	}
-	return r.runStandardValidate(opts.path, opts.strict, opts.jsonOut)
+	return r.runStandardValidate(opts.path, opts.strict, opts.consistency, opts.jsonOut)
 }
 
 type validateArgs struct {
@@ -763,6 +974,7 @@ type validateArgs struct {
 	strict        bool
 	semanticMode  bool
 	semanticRules string
+	consistency   bool
 	jsonOut       bool
 }
 
@@ -772,6 +984,7 @@ func (r Runner) parseValidateArgs(args []string) (validateArgs, int, bool) {
 	strict := fs.Bool("strict", false, "strict mode (missing required artifacts fails)")
 	semanticMode := fs.Bool("semantic", false, "run semantic validation gates (feedback semantics + trace signals)")
 	semanticRules := fs.String("semantic-rules", "", "optional semantic rules file (.json|.yaml|.yml)")
+	consistency := fs.Bool("consistency", false, "check cross-attempt invariants of a runDir (unique ids, timestamps, scratch dirs, native sessions)")
 	jsonOut := fs.Bool("json", false, "print JSON output")
 	help := fs.Bool("help", false, "show help")
 	if err := fs.Parse(args); err != nil {
@@ -791,6 +1004,7 @@ func (r Runner) parseValidateArgs(args []string) (validateArgs, int, bool) {
 		strict:        *strict,
 		semanticMode:  *semanticMode,
 		semanticRules: strings.TrimSpace(*semanticRules),
+		consistency:   *consistency,
 		jsonOut:       *jsonOut,
 	}, 0, true
 }
@@ -826,12 +1040,22 @@ func (r Runner) runSemanticValidate(path string, rulesPath string, jsonOut bool)
 	return 2
 }
 
-func (r Runner) runStandardValidate(path string, strict bool, jsonOut bool) int {
-	res, err := validate.ValidatePath(path, strict)
+func (r Runner) runStandardValidate(path string, strict bool, consistency bool, jsonOut bool) int {
+	var res validate.Result
+	var err error
+	if consistency {
+		res, err = validate.ValidateRunConsistency(path)
+	} else {
+		res, err = validate.ValidatePath(path, strict)
+	}
 	if err != nil {
 		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
 		return 1
 	}
+	return r.reportValidateResult(res, jsonOut)
+}
+
+func (r Runner) reportValidateResult(res validate.Result, jsonOut bool) int {
 	if jsonOut {
 		exit := r.writeJSON(res)
 		if exit != 0 {
@@ -933,8 +1157,10 @@ func (r Runner) runGC(args []string) int {
 	fs.SetOutput(io.Discard)
 
 	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
-	maxAgeDays := fs.Int("max-age-days", 30, "delete runs older than N days (unpinned only); 0 disables")
-	maxTotalBytes := fs.Int64("max-total-bytes", 0, "delete oldest runs until total size is under this threshold (unpinned only); 0 disables")
+	maxAgeDays := fs.Int("max-age-days", 30, "delete runs older than N days (default config retention.keepDays); 0 disables")
+	keepRuns := fs.Int("keep-runs", 0, "always keep the newest N runs (default config retention.keepRuns); 0 disables")
+	keepFailed := fs.String("keep-failed", "", "keep runs with failed attempts: all|none|N newest (default config retention.keepFailed)")
+	maxTotalBytes := fs.Int64("max-total-bytes", 0, "delete oldest runs until total size is under this threshold (unprotected only); 0 disables")
 	dryRun := fs.Bool("dry-run", false, "print what would be deleted without deleting")
 	jsonOut := fs.Bool("json", false, "print JSON output")
 	help := fs.Bool("help", false, "show help")
@@ -946,18 +1172,39 @@ func (r Runner) runGC(args []string) int {
 		printGCHelp(r.Stdout)
 		return 0
 	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if *maxAgeDays < 0 || *keepRuns < 0 {
+		return r.failUsage("gc: --max-age-days and --keep-runs must be >= 0")
+	}
 
 	m, err := config.LoadMerged(*outRoot)
 	if err != nil {
 		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
 		return 1
 	}
+	ret := m.Retention
+	if set["max-age-days"] || ret.KeepDays <= 0 {
+		ret.KeepDays = *maxAgeDays
+	}
+	if set["keep-runs"] {
+		ret.KeepRuns = *keepRuns
+	}
+	if set["keep-failed"] {
+		kf, err := config.ParseKeepFailed(*keepFailed)
+		if err != nil {
+			return r.failUsage("gc: " + err.Error())
+		}
+		ret.KeepFailed = kf
+	}
 
 	res, err := gc.Run(gc.Opts{
 		OutRoot:       m.OutRoot,
 		Now:           r.Now(),
-		MaxAgeDays:    *maxAgeDays,
+		MaxAgeDays:    ret.KeepDays,
 		MaxTotalBytes: *maxTotalBytes,
+		KeepRuns:      ret.KeepRuns,
+		KeepFailed:    int(ret.KeepFailed),
 		DryRun:        *dryRun,
 	})
 	if err != nil {
@@ -968,6 +1215,9 @@ func (r Runner) runGC(args []string) int {
 		return r.writeJSON(res)
 	}
 	fmt.Fprintf(r.Stdout, "gc: OK deleted=%d kept=%d dryRun=%v\n", len(res.Deleted), len(res.Kept), res.DryRun)
+	if res.CAS != nil {
+		fmt.Fprintf(r.Stdout, "gc: cas blobs=%d pruned=%d bytesFreed=%d\n", res.CAS.Blobs, res.CAS.Pruned, res.CAS.BytesFreed)
+	}
 	return 0
 }
 
@@ -1207,12 +1457,23 @@ func (r Runner) executeMCPProxy(opts mcpProxyArgs) int {
 		fmt.Fprintf(r.Stderr, codeTimeout+": attempt deadline exceeded\n")
 		return 1
 	}
+	toolCallAllowed, err := mcpToolPolicyPredicate()
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
+		return 2
+	}
+	vcrSession, exit, done := r.openRunVCR(opts.env)
+	if done {
+		return exit
+	}
 	if err := mcpproxy.ProxyWithOptions(ctx, opts.env, opts.argv, os.Stdin, r.Stdout, mcpproxy.Options{
 		MaxPreviewBytes:    schema.PreviewMaxBytesV1,
 		MaxToolCalls:       opts.maxToolCalls,
 		IdleTimeoutMs:      opts.idleTimeoutMs,
 		ShutdownOnComplete: opts.shutdownOnComplete,
 		SequentialRequests: opts.sequential,
+		ToolCallAllowed:    toolCallAllowed,
+		VCR:                vcrSession,
 	}); err != nil {
 		if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			fmt.Fprintf(r.Stderr, codeTimeout+": attempt deadline exceeded\n")
@@ -1477,12 +1738,22 @@ func printRootHelp(w io.Writer) {
 
 Usage:
   zcl init [--out-root .zcl] [--config zcl.config.json] [--json]
+  zcl init suite|campaign [--adapter <type>] [--out <file>] [--force] [--json]
+  zcl top [--out-root .zcl] [--interval 2s] [--once] [--json]
+  zcl serve [--addr 127.0.0.1:8080] [--out-root .zcl] [--json]
+  zcl bench harness [--attempts 100] [--keep] [--json] [-- <noop-cmd> [args...]]
+  zcl config get [<key>] [--json] | config set <key> <value> [--global] | config lint [--json]
   zcl update status [--cached] [--json]
   zcl contract --json
   zcl attempt start --suite <suiteId> --mission <missionId> --json
   zcl attempt env [--format sh|dotenv] [--json] [<attemptDir>]
   zcl attempt finish [--strict] [--json] [<attemptDir>]
   zcl attempt explain [--json] [--tail N] [<attemptDir>]
+  zcl attempt inspect [--json] [--tail N] [<attemptDir|attemptId>]
+  zcl attempt replay [--feed] [--json] <attemptDir>
+  zcl attempt export --out <attempt.tar.zst> [--json] [<attemptDir>]
+  zcl suite lint --file <suite.(yaml|yml|json)> [--json]
+  zcl suite import --format openai-evals|inspect-ai|custom-jsonl [--out suite.json] [--json] <path>
   zcl suite plan --file <suite.(yaml|yml|json)> --json
   zcl suite run --file <suite.(yaml|yml|json)> [--session-isolation auto|process|native] [--runtime-strategies <csv>] [--finalization-mode strict|auto_fail|auto_from_result_json] [--result-channel none|file_json|stdout_json] [--result-min-turn N] --json [-- <runner-cmd> [args...]]
   zcl campaign lint --spec <campaign.(yaml|yml|json)> [--json]
@@ -1493,37 +1764,71 @@ Usage:
   zcl campaign report [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--json]
   zcl campaign publish-check [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--json]
   zcl campaign doctor --spec <campaign.(yaml|yml|json)> [--json]
+  zcl campaign regrade export|merge --campaign-id <id> [--out <dir> | --verdicts <path>] [--json]
+  zcl campaign export [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--out <dir>] [--json]
+  zcl export --format inspect-ai|helm [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--out <dir>] [--json]
   zcl runs list --json
+  zcl runs compact --run-id <runId> [--json]
+  zcl archive --older-than 14d [--dry-run] [--json]
+  zcl archive restore --run-id <runId> [--json]
   zcl attempt list [filters...] --json
   zcl attempt latest [filters...] --json
   zcl feedback --ok|--fail --result <string>|--result-json <json>
   zcl note [--kind agent|operator|system] --message <string>|--data-json <json>
   zcl report [--strict] [--json] <attemptDir|runDir>
   zcl validate [--strict] [--semantic] [--semantic-rules <path>] [--json] <attemptDir|runDir>
+  zcl validate --consistency [--json] <runDir>
+  zcl validate file --kind attempt.report|feedback|suite|campaign [--json] <path>
   zcl mission prompts build --spec <campaign.(yaml|yml|json)> --template <template.txt|md> [--json]
   zcl replay --json <attemptDir>
   zcl expect [--strict] --json <attemptDir|runDir>
+  zcl schema export --artifact attempt.report|feedback|suite|campaign --json-schema
   zcl doctor [--json]
   zcl gc [--dry-run] [--json]
   zcl pin --run-id <runId> --on|--off [--json]
+  zcl scan secrets --run-id <runId> [--json]
+  zcl redact verify --run-id <runId> [--json]
+  zcl purge --attempt <attemptDir> --pattern <regex> --reason <text> [--confirm] [--json]
+  zcl review next --campaign-id <id> [--all] [--json]
+  zcl review record --attempt <attemptDir> --ok|--fail [--reviewer <name>] [--notes <text>] [--json]
+  zcl verdict override --attempt <attemptDir> --ok=true|false --reason <text> [--by <name>] [--json]
+  zcl sync --campaign-id <id> [--dest s3://bucket/prefix|gs://bucket/prefix|file:///path] [--json]
+  zcl sign --campaign-id <id> --key <ed25519.pem> [--json]
+  zcl verify --campaign-id <id> [--pubkey <ed25519.pub.pem>] [--json]
+  zcl encryption keygen [--out <identity.key>] [--json]
+  zcl migrate --from 1 --to 2 --run-id <runId> [--dry-run] [--json]
+  zcl repro bundle --run-id <runId> --out <dir> [--json]
+  zcl repro run <bundleDir>
 `)
 	fmt.Fprintf(w, "  %s\n", enrichUsage())
 	fmt.Fprint(w, `  zcl mcp proxy [--max-tool-calls N] [--idle-timeout-ms N] [--shutdown-on-complete] -- <server-cmd> [args...]
   zcl http proxy --upstream <url> [--listen 127.0.0.1:0] [--max-requests N] [--json]
+  zcl http agent --url <url> [--header 'Name: value']... [--poll-interval-ms N] [--result-marker <prefix>]
+  zcl ws agent --url <ws-url> [--header 'Name: value']... [--interrupt-grace-ms N] [--result-marker <prefix>]
   zcl run -- <cmd> [args...]
 
 Commands:
   init            Initialize the project (.zcl output root + zcl.config.json).
+  config          Read (with provenance), set and lint the merged project/global config.
   update status   Check latest release status (manual updates only; no auto-update).
   contract        Print the ZCL surface contract (use --json).
   attempt start   Allocate a run/attempt dir and print canonical IDs + env (use --json).
   attempt env     Print canonical attempt env (or return it as JSON).
   attempt finish  Write attempt.report.json, then validate + expect (use --json for automation).
   attempt explain Fast post-mortem view from artifacts (tail trace + pointers).
+  attempt inspect Merged single-attempt view (attempt, report, validate, expect, feedback, runner ref, trace stats).
+  attempt replay  Re-run the finish pipeline read-only and diff it against the recorded verdict.
+  attempt export  Bundle an attempt's artifacts + manifest into one archive for bug reports.
   suite plan      Allocate attempt dirs for every mission in a suite file (use --json).
   suite run       Run a suite end-to-end with capability-aware isolation selection.
   campaign        First-class campaign orchestration (lint/run/canary/resume/status/report/publish-check/doctor).
+  top             Live dashboard of active runs, attempts in flight, strategy health and failures.
+  serve           Read-only REST API + embedded web dashboard over runs, attempts and campaigns.
+  bench harness   Time attempt start/spawn/trace/finish overhead over no-op attempts.
+  export          Export campaign attempts and gate verdicts as Inspect AI logs or HELM run dirs.
   runs list       List run index rows for automation (use --json).
+  runs compact    Gzip logs/traces of a finished run and drop raw IO that has a redacted copy.
+  archive         Move completed runs older than --older-than into per-run .tar.zst archives.
   attempt list    List attempts with filters (suite/mission/status/tags) as JSON index rows.
   attempt latest  Return latest attempt matching filters as one JSON row.
   feedback        Write the canonical attempt outcome to feedback.json.
@@ -1533,20 +1838,40 @@ Commands:
   mission          Deterministic mission prompt materialization commands.
   replay           Best-effort replay of tool.calls.jsonl (use --json).
   expect           Evaluate suite expectations against feedback.json (use --json).
+  schema export    Print the canonical JSON Schema for an artifact or input file.
   doctor           Check environment/config sanity for running ZCL.
   gc               Retention cleanup under .zcl/runs (supports pinning).
   pin              Pin/unpin a run so gc will keep it.
+  sign             Sign campaign + run artifact hashes with an ed25519 key (campaign.signature.json).
+  verify           Verify campaign.signature.json and re-hash every signed artifact.
+  redact verify    Re-scan a run with the current redaction rules and record redaction.verify.json.
+  purge            Remove matched content from an attempt's artifacts and append a purge.jsonl record.
   enrich           Optional runner enrichment (does not affect scoring).
   mcp proxy        MCP stdio proxy funnel (records initialize/tools/list/tools/call; optional sequential request mode).
   http proxy       HTTP reverse proxy funnel (records method/url/status/latency/bytes).
+  http agent       Runner for agent services: POST the attempt prompt, relay output, print the result.
+  ws agent         Runner for WebSocket agents: stream the mission, trace agent events, interrupt at the deadline.
   run             Run a command through the ZCL CLI funnel.
   version         Print version.
+
+Global flags:
+  --profile <name>          Select a named config profile (also ZCL_PROFILE); must precede the command.
+  --error-format text|json  json: a failing command writes one JSON error envelope to stderr
+                            ({code, message, retryable, hints, paths, ...}) instead of ZCL_E_X lines.
 `)
 }
 
 func printInitHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
   zcl init [--out-root .zcl] [--config zcl.config.json] [--json]
+  zcl init suite [--adapter process_cmd|codex_exec|codex_subagent|claude_subagent|codex_app_server] [--suite-id <id>] [--mission <id>]... [--out suite.json] [--force] [--json]
+  zcl init campaign [--adapter <type>] [--campaign-id <id>] [--suite suite.json] [--out campaign.yaml] [--force] [--json]
+
+Notes:
+  - init suite|campaign write a valid starter file with runner, finalization, and result-channel settings pre-wired for the adapter.
+  - Without --adapter on a terminal (and without --json), missing values are prompted for.
+  - init campaign also scaffolds --suite (resolved relative to --out) when it does not exist.
+  - Existing files are never overwritten without --force.
 `)
 }
 
@@ -1562,6 +1887,9 @@ func printAttemptHelp(w io.Writer) {
   zcl attempt env [--format sh|dotenv] [--json] [<attemptDir>]
   zcl attempt finish [--strict] [--json] [<attemptDir>]
   zcl attempt explain [--json] [--tail N] [<attemptDir>]
+  zcl attempt inspect [--json] [--tail N] [<attemptDir|attemptId>]
+  zcl attempt replay [--feed] [--json] <attemptDir>
+  zcl attempt export --out <attempt.tar.zst> [--json] [<attemptDir>]
   zcl attempt list [--out-root .zcl] [--suite <suiteId>] [--mission <missionId>] [--status any|ok|fail|missing_feedback] [--tag <tag>] [--limit N] --json
   zcl attempt latest [--out-root .zcl] [--suite <suiteId>] [--mission <missionId>] [--status any|ok|fail|missing_feedback] [--tag <tag>] --json
 `)
@@ -1569,13 +1897,18 @@ func printAttemptHelp(w io.Writer) {
 
 func printRunsHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
-  zcl runs list [--out-root .zcl] [--suite <suiteId>] [--status any|ok|fail|missing_feedback] [--limit N] --json
+  zcl runs list [--out-root .zcl] [--suite <suiteId>] [--status any|ok|fail|missing_feedback] [--limit N] [--stale-after-ms N] --json
+  zcl runs compact --run-id <runId> [--out-root .zcl] [--json]
+
+Notes:
+  - runs list reports unfinished attempts whose attempt.heartbeat.json is older than --stale-after-ms
+    (default 60000) under staleAttempts: their suite run is likely hung or was killed.
 `)
 }
 
 func printAttemptStartHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
-	  zcl attempt start --suite <suiteId> --mission <missionId> [--prompt <text>] [--suite-file <path>] [--run-id <runId>] [--agent-id <id>] [--isolation-model process_runner|native_spawn] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--blind] [--blind-terms a,b,c] [--out-root .zcl] [--retry 1] [--env-file <path>] [--env-format sh|dotenv] [--print-env sh|dotenv] --json
+	  zcl attempt start --suite <suiteId> --mission <missionId> [--prompt <text>] [--suite-file <path>] [--run-id <runId>] [--agent-id <id>] [--isolation-model process_runner|native_spawn] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--blind] [--blind-terms a,b,c] [--blind-terms-pack <name>] [--out-root .zcl] [--retry 1] [--env-file <path>] [--env-format sh|dotenv] [--print-env sh|dotenv] --json
 
 	Notes:
 	  - Always writes <attemptDir>/attempt.env.sh and records it in attempt.json.
@@ -1594,6 +1927,8 @@ Notes:
 
 func printSuiteHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
+  zcl suite lint --file <suite.(yaml|yml|json)> [--json]
+  zcl suite import --format openai-evals|inspect-ai|custom-jsonl [--out suite.json] [--json] <path>
   zcl suite plan --file <suite.(yaml|yml|json)> --json
   zcl suite run --file <suite.(yaml|yml|json)> [--session-isolation auto|process|native] [--runtime-strategies <csv>] [--finalization-mode strict|auto_fail|auto_from_result_json] [--result-channel none|file_json|stdout_json] [--result-min-turn N] --json [-- <runner-cmd> [args...]]
 `)
@@ -1601,7 +1936,7 @@ func printSuiteHelp(w io.Writer) {
 
 func printSuitePlanHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
-	  zcl suite plan --file <suite.(yaml|yml|json)> [--run-id <runId>] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--blind on|off] [--blind-terms a,b,c] [--out-root .zcl] --json
+	  zcl suite plan --file <suite.(yaml|yml|json)> [--run-id <runId>] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--blind on|off] [--blind-terms a,b,c] [--blind-terms-pack <name>] [--out-root .zcl] --json
 `)
 }
 
@@ -1618,6 +1953,11 @@ Notes:
 func printExpectHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
   zcl expect [--strict] --json <attemptDir|runDir>
+  zcl expect update-goldens --json <attemptDir|runDir>
+
+Notes:
+  - update-goldens rewrites expects.golden files from attempt feedback; review the diff before committing.
+  - Attempts of the same mission that disagree leave the golden untouched (ZCL_E_EXPECT_GOLDEN_CONFLICT).
 `)
 }
 
@@ -1628,10 +1968,23 @@ func printFeedbackHelp(w io.Writer) {
   zcl feedback --ok|--fail --result <string> --classification <missing_primitive|naming_ux|output_shape|already_possible_better_way>
   zcl feedback --ok|--fail --result <string> --decision-tag blocked --decision-tag timeout
   zcl feedback --ok|--fail --result <string> --decision-tags blocked,timeout
+  zcl feedback --ok|--fail --result <string> --confidence 0.8
+  zcl feedback [--ok|--fail] --append-tag <tag> [--merge-json <json>]
+  zcl feedback progress --milestone <text> [--data-json <json>]
 
 Notes:
   - Requires ZCL attempt context (ZCL_* env from zcl attempt start/suite run).
   - Requires non-empty tool.calls.jsonl before writing feedback (funnel-first evidence).
+  - Decision tags, classification and resultJson are checked before anything is written; each rejected
+    field is printed as "field <name>: <reason>" (resultJson syntax errors include line and column).
+  - --append-tag and --merge-json (JSON merge patch on the resultJson object; null deletes a key) update an
+    existing feedback.json until the attempt is finalized (attempt.finish.json).
+  - --confidence is the claimed probability (0..1) that the outcome is correct; campaign reports compare it
+    with verified outcomes per flow (calibration).
+  - When ZCL_FEEDBACK_SIGNING_KEY names a PEM ed25519 private key, feedback.json is signed with it. Attempts
+    started with the key set pin its id in attempt.json; zcl validate then rejects unsigned or re-signed feedback.
+  - feedback progress appends a milestone to feedback.progress.jsonl (no trace needed yet), so long missions
+    leave checkpoints even when the final feedback is never written.
 `)
 }
 
@@ -1667,18 +2020,28 @@ Notes:
 func printValidateHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
   zcl validate [--strict] [--semantic] [--semantic-rules <path>] [--json] <attemptDir|runDir>
+  zcl validate --consistency [--json] <runDir>
+  zcl validate file --kind attempt.report|feedback|suite|campaign [--json] <path>
 `)
 }
 
 func printDoctorHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
   zcl doctor [--out-root .zcl] [--json]
+
+Checks out-root write access, config (project/global lint, redaction), free disk space under the
+out-root, out-root file-time skew against the local clock, the binaries zcl shells out to
+(sh required; bash, git optional) and native runtime availability. A failed check exits 1;
+with --json the result reports ok=false and exits 0.
 `)
 }
 
 func printGCHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
-  zcl gc [--out-root .zcl] [--max-age-days 30] [--max-total-bytes 0] [--dry-run] [--json]
+  zcl gc [--out-root .zcl] [--max-age-days 30] [--keep-runs N] [--keep-failed all|none|N] [--max-total-bytes 0] [--dry-run] [--json]
+
+Defaults come from config "retention": {"keepRuns": 50, "keepDays": 30, "keepFailed": "all"}.
+Pinned runs and runs referenced by a published campaign are never deleted.
 `)
 }
 
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/archive"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runArchive(args []string) int {
+	if len(args) > 0 && args[0] == "restore" {
+		return r.runArchiveRestore(args[1:])
+	}
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	olderThan := fs.String("older-than", "", "archive completed runs created before this age, e.g. 14d or 72h (required)")
+	compression := fs.String("compression", "zstd", "archive compression: zstd (needs the zstd CLI) or gzip")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	dryRun := fs.Bool("dry-run", false, "list what would be archived without writing")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("archive: invalid flags")
+	}
+	if *help {
+		printArchiveHelp(r.Stdout)
+		return 0
+	}
+	if strings.TrimSpace(*olderThan) == "" {
+		printArchiveHelp(r.Stderr)
+		return r.failUsage("archive: require --older-than")
+	}
+	age, err := archive.ParseAge(*olderThan)
+	if err != nil {
+		return r.failUsage("archive: " + err.Error())
+	}
+	if _, err := archive.ExtensionFor(*compression); err != nil {
+		return r.failUsage("archive: " + err.Error())
+	}
+
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	res, err := archive.Run(archive.Opts{OutRoot: m.OutRoot, OlderThan: age, Compression: *compression, DryRun: *dryRun, Now: r.Now()})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": archive: %s\n", err.Error())
+		return 1
+	}
+	exit := 0
+	if !res.OK {
+		exit = 1
+	}
+	if *jsonOut {
+		if code := r.writeJSON(res); code != 0 {
+			return code
+		}
+		return exit
+	}
+	fmt.Fprintf(r.Stdout, "archive: OK=%v archived=%d skipped=%d freedBytes=%d dryRun=%v\n", res.OK, len(res.Archived), len(res.Skipped), res.BytesFreed, res.DryRun)
+	for _, e := range res.Errors {
+		fmt.Fprintf(r.Stderr, codeIO+": archive: %s\n", e)
+	}
+	return exit
+}
+
+func (r Runner) runArchiveRestore(args []string) int {
+	fs := flag.NewFlagSet("archive restore", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	runID := fs.String("run-id", "", "archived run id to restore (required)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("archive restore: invalid flags")
+	}
+	if *help {
+		printArchiveHelp(r.Stdout)
+		return 0
+	}
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	res, err := archive.Restore(m.OutRoot, strings.TrimSpace(*runID))
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": archive restore: %s\n", err.Error())
+		return 2
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "archive restore: OK runId=%s files=%d\n", res.RunID, res.Files)
+	return 0
+}
+
+func printArchiveHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl archive --older-than 14d [--compression zstd|gzip] [--out-root .zcl] [--dry-run] [--json]
+  zcl archive restore --run-id <runId> [--out-root .zcl] [--json]
+
+Notes:
+  - Moves completed runs (every attempt has attempt.report.json) into .zcl/archive/<runId>.tar.zst
+    and records them in .zcl/archive/archive.index.json; runs list keeps showing them as archived.
+  - Pinned runs, runs another process is writing and unfinished runs are skipped.
+  - archive restore checks the archive sha256 and extracts it back into .zcl/runs/<runId>.
+`)
+}
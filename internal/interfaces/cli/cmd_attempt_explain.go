@@ -16,6 +16,7 @@ import (
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/validate"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/attempt"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
 func (r Runner) runAttemptExplain(args []string) int {
@@ -134,9 +135,8 @@ func loadAttemptExplainIDs(attemptDir string) schema.AttemptJSONV1 {
 }
 
 func (r Runner) loadAttemptExplainReport(attemptDir string, strict bool) (schema.AttemptReportJSONV1, bool) {
-	var rep schema.AttemptReportJSONV1
 	if b, err := os.ReadFile(filepath.Join(attemptDir, artifacts.AttemptReportJSON)); err == nil {
-		if err := json.Unmarshal(b, &rep); err == nil {
+		if rep, err := schema.DecodeAttemptReport(b); err == nil {
 			return rep, true
 		}
 	}
@@ -168,16 +168,22 @@ func buildAttemptExplainOutput(attemptDir string, strict bool, ids schema.Attemp
 			out.AttemptID = rep.AttemptID
 		}
 	}
+	out.RunnerCommandPath, out.RunnerStdoutPath, out.RunnerStderrPath = attemptRunnerLogPaths(attemptDir)
+	return out
+}
+
+// attemptRunnerLogPaths returns the runner command/stdout/stderr files that exist (empty otherwise).
+func attemptRunnerLogPaths(attemptDir string) (command string, stdout string, stderr string) {
 	if p := filepath.Join(attemptDir, "runner.command.txt"); fileExists(p) {
-		out.RunnerCommandPath = p
+		command = p
 	}
-	if p := filepath.Join(attemptDir, "runner.stdout.log"); fileExists(p) {
-		out.RunnerStdoutPath = p
+	if p := store.ResolveArtifactPath(filepath.Join(attemptDir, "runner.stdout.log")); fileExists(p) {
+		stdout = p
 	}
-	if p := filepath.Join(attemptDir, "runner.stderr.log"); fileExists(p) {
-		out.RunnerStderrPath = p
+	if p := store.ResolveArtifactPath(filepath.Join(attemptDir, "runner.stderr.log")); fileExists(p) {
+		stderr = p
 	}
-	return out
+	return command, stdout, stderr
 }
 
 func (r Runner) printAttemptExplainHuman(out attemptExplainOutput) {
@@ -224,7 +230,7 @@ func tailTraceEvents(path string, n int) ([]schema.TraceEventV1, error) {
 	if n <= 0 {
 		return nil, nil
 	}
-	f, err := os.Open(path)
+	f, err := store.OpenArtifact(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
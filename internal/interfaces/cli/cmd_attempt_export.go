@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/bundle"
+)
+
+func (r Runner) runAttemptExport(args []string) int {
+	fs := flag.NewFlagSet("attempt export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	out := fs.String("out", "", "bundle path: .tar.zst (needs zstd on PATH), .tar.gz or .tar (required)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("attempt export: invalid flags")
+	}
+	if *help {
+		printAttemptExportHelp(r.Stdout)
+		return 0
+	}
+	attemptDir := ""
+	switch rest := fs.Args(); len(rest) {
+	case 0:
+		attemptDir = os.Getenv("ZCL_OUT_DIR")
+	case 1:
+		attemptDir = rest[0]
+	default:
+		printAttemptExportHelp(r.Stderr)
+		return r.failUsage("attempt export: require at most one <attemptDir> (or use ZCL_OUT_DIR)")
+	}
+	if strings.TrimSpace(attemptDir) == "" {
+		printAttemptExportHelp(r.Stderr)
+		return r.failUsage("attempt export: missing <attemptDir> (or set ZCL_OUT_DIR)")
+	}
+	if strings.TrimSpace(*out) == "" {
+		printAttemptExportHelp(r.Stderr)
+		return r.failUsage("attempt export: missing --out")
+	}
+	if _, err := bundle.CompressionFor(*out); err != nil {
+		return r.failUsage("attempt export: " + err.Error())
+	}
+	if info, err := os.Stat(attemptDir); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	} else if !info.IsDir() {
+		return r.failUsage("attempt export: target must be a directory")
+	}
+	res, err := bundle.Export(bundle.Opts{
+		AttemptDir: attemptDir,
+		OutPath:    *out,
+		ZCLVersion: r.Version,
+		Now:        r.Now(),
+	})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": attempt export: %s\n", err.Error())
+		return 1
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "attempt export: OK attempt=%s files=%d bytes=%d out=%s\n", res.AttemptID, res.Files, res.Bytes, res.OutPath)
+	return 0
+}
+
+func printAttemptExportHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl attempt export --out <attempt.tar.zst|attempt.tar.gz|attempt.tar> [--json] [<attemptDir>]
+
+Notes:
+  - Bundles every file in the attempt dir (attempt/...), the run's run.json and suite.json (run/...)
+    and attempt.bundle.manifest.json (sha256 + bytes per entry).
+  - .tar.zst pipes through the zstd CLI; .tar.gz needs no external tools.
+  - Encrypted (.enc) artifacts are bundled sealed.
+`)
+}
@@ -8,12 +8,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/expect"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/report"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/validate"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/attempt"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
 func (r Runner) runAttemptFinish(args []string) int {
@@ -100,27 +102,95 @@ func (r Runner) executeAttemptFinish(strict, strictExpect bool, attemptDir strin
 	if err != nil {
 		return schema.AttemptReportJSONV1{}, validate.Result{}, expect.Result{}, false, r.printReportErr(err), true
 	}
-	if err := report.WriteAttemptReportAtomic(filepath.Join(attemptDir, artifacts.AttemptReportJSON), rep); err != nil {
+	valRes, expRes, ok, err := commitAttemptFinish(r.Now(), attemptDir, strict, strictExpect, &rep)
+	if err != nil {
 		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
 		return schema.AttemptReportJSONV1{}, validate.Result{}, expect.Result{}, false, 1, true
 	}
+	if err := encryptFinishedAttempt(attemptDir); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": attempt finish: encrypt attempt artifacts: %s\n", err.Error())
+		return schema.AttemptReportJSONV1{}, validate.Result{}, expect.Result{}, false, 1, true
+	}
+	return rep, valRes, expRes, ok, 0, false
+}
+
+// attemptFinishRecordV1 is attempt.finish.json: the validate/expect verdicts published together with
+// the attempt.report.json they were computed against.
+type attemptFinishRecordV1 struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	FinishedAt    string          `json:"finishedAt"`
+	OK            bool            `json:"ok"`
+	Strict        bool            `json:"strict"`
+	StrictExpect  bool            `json:"strictExpect"`
+	Validate      validate.Result `json:"validate"`
+	Expect        expect.Result   `json:"expect"`
+}
 
-	valRes, err := validate.ValidatePath(attemptDir, strict)
+// commitAttemptFinish stages attempt.report.json (when rep is set), validates against the staged
+// report, runs expectations and publishes report + attempt.finish.json in one directory
+// transaction, so a crash mid-finish never leaves a new report next to stale verdicts.
+func commitAttemptFinish(now time.Time, attemptDir string, strict, strictExpect bool, rep *schema.AttemptReportJSONV1) (validate.Result, expect.Result, bool, error) {
+	tx, err := store.BeginDirTx(attemptDir, artifacts.AttemptFinishTx)
 	if err != nil {
-		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
-		return schema.AttemptReportJSONV1{}, validate.Result{}, expect.Result{}, false, 1, true
+		return validate.Result{}, expect.Result{}, false, err
+	}
+	reportPath := filepath.Join(attemptDir, artifacts.AttemptReportJSON)
+	if rep != nil {
+		if err := tx.WriteJSON(artifacts.AttemptReportJSON, *rep); err != nil {
+			tx.Abort()
+			return validate.Result{}, expect.Result{}, false, err
+		}
+		reportPath = tx.Path(artifacts.AttemptReportJSON)
 	}
+	valRes, err := validate.ValidateAttemptStaged(attemptDir, strict, reportPath)
+	if err != nil {
+		tx.Abort()
+		return validate.Result{}, expect.Result{}, false, err
+	}
+	// Staged findings point into the transaction dir; report the published location.
+	rewriteFindingPaths(&valRes, reportPath, filepath.Join(attemptDir, artifacts.AttemptReportJSON))
 	expRes, err := expect.ExpectPath(attemptDir, strictExpect)
 	if err != nil {
-		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
-		return schema.AttemptReportJSONV1{}, validate.Result{}, expect.Result{}, false, 1, true
+		tx.Abort()
+		return validate.Result{}, expect.Result{}, false, err
 	}
-
 	ok := valRes.OK && expRes.OK
-	if rep.OK != nil && !*rep.OK {
+	if rep != nil && rep.OK != nil && !*rep.OK {
 		ok = false
 	}
-	return rep, valRes, expRes, ok, 0, false
+	record := attemptFinishRecordV1{
+		SchemaVersion: 1,
+		FinishedAt:    now.UTC().Format(time.RFC3339Nano),
+		OK:            ok,
+		Strict:        strict,
+		StrictExpect:  strictExpect,
+		Validate:      valRes,
+		Expect:        expRes,
+	}
+	if err := tx.WriteJSON(artifacts.AttemptFinishJSON, record); err != nil {
+		tx.Abort()
+		return validate.Result{}, expect.Result{}, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return validate.Result{}, expect.Result{}, false, err
+	}
+	return valRes, expRes, ok, nil
+}
+
+func rewriteFindingPaths(res *validate.Result, from, to string) {
+	if from == to {
+		return
+	}
+	for i := range res.Errors {
+		if res.Errors[i].Path == from {
+			res.Errors[i].Path = to
+		}
+	}
+	for i := range res.Warnings {
+		if res.Warnings[i].Path == from {
+			res.Warnings[i].Path = to
+		}
+	}
 }
 
 func (r Runner) writeAttemptFinishJSON(ok, strict, strictExpect bool, attemptDir string, rep schema.AttemptReportJSONV1, valRes validate.Result, expRes expect.Result) int {
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/expect"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/validate"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/attempt"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// attemptInspectOutput is the merged single-attempt view: the raw artifacts plus the derived
+// report/validate/expect verdicts and trace stats.
+type attemptInspectOutput struct {
+	OK         bool   `json:"ok"`
+	AttemptDir string `json:"attemptDir"`
+	Strict     bool   `json:"strict"`
+
+	RunID     string `json:"runId,omitempty"`
+	SuiteID   string `json:"suiteId,omitempty"`
+	MissionID string `json:"missionId,omitempty"`
+	AttemptID string `json:"attemptId,omitempty"`
+
+	Attempt   json.RawMessage `json:"attempt,omitempty"`
+	Feedback  json.RawMessage `json:"feedback,omitempty"`
+	RunnerRef json.RawMessage `json:"runnerRef,omitempty"`
+
+	ReportPresent bool                        `json:"reportPresent"`
+	ReportSource  string                      `json:"reportSource,omitempty"` // file|computed
+	Report        *schema.AttemptReportJSONV1 `json:"report,omitempty"`
+	Validate      validate.Result             `json:"validate"`
+	Expect        expect.Result               `json:"expect"`
+
+	Trace attemptInspectTraceStats `json:"trace"`
+
+	RunnerCommandPath string `json:"runnerCommandPath,omitempty"`
+	RunnerStdoutPath  string `json:"runnerStdoutPath,omitempty"`
+	RunnerStderrPath  string `json:"runnerStderrPath,omitempty"`
+}
+
+type attemptInspectTraceStats struct {
+	Path            string                `json:"path,omitempty"`
+	NonEmpty        bool                  `json:"nonEmpty"`
+	ToolCallsTotal  int64                 `json:"toolCallsTotal"`
+	FailuresTotal   int64                 `json:"failuresTotal"`
+	TimeoutsTotal   int64                 `json:"timeoutsTotal"`
+	RetriesTotal    int64                 `json:"retriesTotal"`
+	WallTimeMs      int64                 `json:"wallTimeMs"`
+	DurationMsP95   int64                 `json:"durationMsP95"`
+	FailuresByCode  map[string]int64      `json:"failuresByCode,omitempty"`
+	ToolCallsByTool map[string]int64      `json:"toolCallsByTool,omitempty"`
+	Tail            []schema.TraceEventV1 `json:"tail,omitempty"`
+}
+
+func (r Runner) runAttemptInspect(args []string) int {
+	fs := flag.NewFlagSet("attempt inspect", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	tailN := fs.Int("tail", 5, "number of tail trace events to include")
+	strict := fs.Bool("strict", false, "strict mode (defaults to true in ci attempts)")
+	outRoot := fs.String("out-root", "", "project output root used to resolve an attemptId (default from config/env, else .zcl)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("attempt inspect: invalid flags")
+	}
+	if *help {
+		printAttemptInspectHelp(r.Stdout)
+		return 0
+	}
+	if *tailN < 0 {
+		printAttemptInspectHelp(r.Stderr)
+		return r.failUsage("attempt inspect: --tail must be >= 0")
+	}
+	target := ""
+	switch rest := fs.Args(); len(rest) {
+	case 0:
+		target = os.Getenv("ZCL_OUT_DIR")
+	case 1:
+		target = strings.TrimSpace(rest[0])
+	default:
+		printAttemptInspectHelp(r.Stderr)
+		return r.failUsage("attempt inspect: require at most one <attemptDir|attemptId> (or use ZCL_OUT_DIR)")
+	}
+	if target == "" {
+		printAttemptInspectHelp(r.Stderr)
+		return r.failUsage("attempt inspect: missing <attemptDir|attemptId> (or set ZCL_OUT_DIR)")
+	}
+	attemptDir, exit, ok := r.resolveAttemptInspectTarget(target, *outRoot)
+	if !ok {
+		return exit
+	}
+
+	eff := attempt.EffectiveStrict(attemptDir, *strict)
+	ids := loadAttemptExplainIDs(attemptDir)
+	out := attemptInspectOutput{
+		OK:         true,
+		AttemptDir: attemptDir,
+		Strict:     eff,
+		RunID:      ids.RunID,
+		SuiteID:    ids.SuiteID,
+		MissionID:  ids.MissionID,
+		AttemptID:  ids.AttemptID,
+		Attempt:    readAttemptInspectRaw(filepath.Join(attemptDir, artifacts.AttemptJSON)),
+		Feedback:   readAttemptInspectRaw(filepath.Join(attemptDir, artifacts.FeedbackJSON)),
+		RunnerRef:  readAttemptInspectRaw(filepath.Join(attemptDir, artifacts.RunnerRefJSON)),
+	}
+	if rep, present := r.loadAttemptExplainReport(attemptDir, eff); present {
+		out.ReportPresent = true
+		out.Report = &rep
+		out.ReportSource = "computed"
+		if fileExists(filepath.Join(attemptDir, artifacts.AttemptReportJSON)) {
+			out.ReportSource = "file"
+		}
+		out.Trace = attemptInspectTraceFromMetrics(rep.Metrics)
+	}
+	out.Validate, _ = validate.ValidatePath(attemptDir, eff)
+	out.Expect, _ = expect.ExpectPath(attemptDir, false)
+
+	tracePath := store.ResolveArtifactPath(filepath.Join(attemptDir, artifacts.ToolCallsJSONL))
+	if fileExists(tracePath) {
+		out.Trace.Path = tracePath
+		if nonEmpty, err := store.JSONLHasNonEmptyLine(filepath.Join(attemptDir, artifacts.ToolCallsJSONL)); err == nil {
+			out.Trace.NonEmpty = nonEmpty
+		}
+	}
+	tail, err := tailTraceEvents(filepath.Join(attemptDir, artifacts.ToolCallsJSONL), *tailN)
+	if err != nil && eff {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	out.Trace.Tail = tail
+
+	out.RunnerCommandPath, out.RunnerStdoutPath, out.RunnerStderrPath = attemptRunnerLogPaths(attemptDir)
+	if out.RunID == "" && out.Report != nil {
+		out.RunID, out.SuiteID, out.MissionID, out.AttemptID = out.Report.RunID, out.Report.SuiteID, out.Report.MissionID, out.Report.AttemptID
+	}
+
+	if *jsonOut {
+		return r.writeJSON(out)
+	}
+	r.printAttemptInspectHuman(out)
+	return 0
+}
+
+// resolveAttemptInspectTarget accepts an attempt dir, or an attemptId looked up under
+// <outRoot>/runs/*/attempts/<attemptId> (newest run wins when the id repeats across runs).
+func (r Runner) resolveAttemptInspectTarget(target string, outRoot string) (string, int, bool) {
+	if info, err := os.Stat(target); err == nil {
+		if !info.IsDir() {
+			return "", r.failUsage("attempt inspect: target must be a directory or attemptId"), false
+		}
+		return target, 0, true
+	}
+	if !isServeComponent(target) {
+		fmt.Fprintf(r.Stderr, codeIO+": attempt inspect: %s does not exist\n", target)
+		return "", 1, false
+	}
+	m, err := config.LoadMerged(outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return "", 1, false
+	}
+	matches, _ := filepath.Glob(filepath.Join(m.OutRoot, "runs", "*", "attempts", target, artifacts.AttemptJSON))
+	if len(matches) == 0 {
+		fmt.Fprintf(r.Stderr, codeUsage+": attempt inspect: no attempt dir or attemptId %q under %s\n", target, m.OutRoot)
+		return "", 2, false
+	}
+	// Run ids start with a UTC timestamp, so the lexically last match is the newest run.
+	sort.Strings(matches)
+	return filepath.Dir(matches[len(matches)-1]), 0, true
+}
+
+func attemptInspectTraceFromMetrics(m schema.AttemptMetricsV1) attemptInspectTraceStats {
+	return attemptInspectTraceStats{
+		ToolCallsTotal:  m.ToolCallsTotal,
+		FailuresTotal:   m.FailuresTotal,
+		TimeoutsTotal:   m.TimeoutsTotal,
+		RetriesTotal:    m.RetriesTotal,
+		WallTimeMs:      m.WallTimeMs,
+		DurationMsP95:   m.DurationMsP95,
+		FailuresByCode:  m.FailuresByCode,
+		ToolCallsByTool: m.ToolCallsByTool,
+	}
+}
+
+// readAttemptInspectRaw returns the artifact as raw JSON (gz/enc aware), or nil when missing or invalid.
+func readAttemptInspectRaw(path string) json.RawMessage {
+	raw, err := store.ReadArtifactFile(path)
+	if err != nil || !json.Valid(raw) {
+		return nil
+	}
+	return json.RawMessage(raw)
+}
+
+func (r Runner) printAttemptInspectHuman(out attemptInspectOutput) {
+	fmt.Fprintf(r.Stdout, "attempt inspect: %s\n", out.AttemptDir)
+	if out.RunID != "" {
+		fmt.Fprintf(r.Stdout, "  ids: run=%s suite=%s mission=%s attempt=%s\n", out.RunID, out.SuiteID, out.MissionID, out.AttemptID)
+	}
+	if out.Report != nil && out.Report.OK != nil {
+		fmt.Fprintf(r.Stdout, "  outcome: ok=%v result=%s (report=%s)\n", *out.Report.OK, strings.TrimSpace(out.Report.Result), out.ReportSource)
+	} else if out.Feedback == nil {
+		fmt.Fprintln(r.Stdout, "  outcome: missing feedback.json")
+	}
+	fmt.Fprintf(r.Stdout, "  validate: ok=%v issues=%d\n", out.Validate.OK, len(out.Validate.Errors))
+	fmt.Fprintf(r.Stdout, "  expect: ok=%v evaluated=%v failures=%d\n", out.Expect.OK, out.Expect.Evaluated, len(out.Expect.Failures))
+	fmt.Fprintf(r.Stdout, "  trace: calls=%d failures=%d timeouts=%d retries=%d wallTimeMs=%d\n",
+		out.Trace.ToolCallsTotal, out.Trace.FailuresTotal, out.Trace.TimeoutsTotal, out.Trace.RetriesTotal, out.Trace.WallTimeMs)
+	if out.RunnerRef != nil {
+		fmt.Fprintf(r.Stdout, "  runner ref: %s\n", filepath.Join(out.AttemptDir, artifacts.RunnerRefJSON))
+	}
+	if out.RunnerCommandPath != "" {
+		fmt.Fprintf(r.Stdout, "  runner: %s\n", out.RunnerCommandPath)
+	}
+	for _, ev := range out.Trace.Tail {
+		fmt.Fprintf(r.Stdout, "    %s %s %s ok=%v code=%s\n", ev.Tool, ev.Op, oneLineInput(ev.Input), ev.Result.OK, ev.Result.Code)
+	}
+}
+
+func printAttemptInspectHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl attempt inspect [--strict] [--tail N] [--out-root .zcl] [--json] [<attemptDir|attemptId>]
+
+Notes:
+  - One merged view of an attempt: attempt.json, feedback.json, runner.ref.json, the attempt report
+    (attempt.report.json, else computed in memory), validate + expect results and key trace stats.
+  - An attemptId is resolved under <outRoot>/runs/*/attempts/ (newest run wins); ZCL_OUT_DIR is used when omitted.
+`)
+}
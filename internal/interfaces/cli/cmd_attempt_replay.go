@@ -0,0 +1,360 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/expect"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/report"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/validate"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/attempt"
+	providerstub "github.com/marcohefti/zero-context-lab/internal/contexts/runtime/infra/provider_stub"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// attemptReplayVerdict is the part of a finish outcome that grading decisions depend on.
+type attemptReplayVerdict struct {
+	OK             bool     `json:"ok"`
+	ReportOK       *bool    `json:"reportOk,omitempty"`
+	Classification string   `json:"classification,omitempty"`
+	DecisionTags   []string `json:"decisionTags,omitempty"`
+	ValidateOK     bool     `json:"validateOk"`
+	ExpectOK       bool     `json:"expectOk"`
+	ExpectFailures []string `json:"expectFailures,omitempty"`
+}
+
+type attemptReplayDiff struct {
+	Field    string `json:"field"`
+	Recorded any    `json:"recorded"`
+	Replayed any    `json:"replayed"`
+}
+
+type attemptReplayFeed struct {
+	Runtime         string `json:"runtime"`
+	ThreadID        string `json:"threadId"`
+	TurnID          string `json:"turnId"`
+	Status          string `json:"status"` // completed|failed|timeout
+	ToolResultsFed  int    `json:"toolResultsFed"`
+	FinalMessage    string `json:"finalMessage,omitempty"`
+	MatchesRecorded bool   `json:"matchesRecorded"`
+}
+
+type attemptReplayOutput struct {
+	OK              bool                       `json:"ok"`
+	AttemptDir      string                     `json:"attemptDir"`
+	Strict          bool                       `json:"strict"`
+	StrictExpect    bool                       `json:"strictExpect"`
+	RecordedPresent bool                       `json:"recordedPresent"`
+	Reproduced      bool                       `json:"reproduced"`
+	Recorded        *attemptReplayVerdict      `json:"recorded,omitempty"`
+	Replayed        attemptReplayVerdict       `json:"replayed"`
+	Diffs           []attemptReplayDiff        `json:"diffs"`
+	Report          schema.AttemptReportJSONV1 `json:"report"`
+	Validate        validate.Result            `json:"validate"`
+	Expect          expect.Result              `json:"expect"`
+	Feed            *attemptReplayFeed         `json:"feed,omitempty"`
+}
+
+func (r Runner) runAttemptReplay(args []string) int {
+	fs := flag.NewFlagSet("attempt replay", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	strict := fs.Bool("strict", false, "strict mode (default: the recorded finish mode, else true in ci attempts)")
+	strictExpect := fs.Bool("strict-expect", false, "strict mode for expect (default: the recorded finish mode)")
+	feed := fs.Bool("feed", false, "re-feed recorded tool results to a new agent turn via the provider_stub runtime")
+	feedTimeout := fs.Duration("feed-timeout", 10*time.Second, "max wait for the re-fed turn to complete")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("attempt replay: invalid flags")
+	}
+	if *help {
+		printAttemptReplayHelp(r.Stdout)
+		return 0
+	}
+	if fs.NArg() != 1 {
+		printAttemptReplayHelp(r.Stderr)
+		return r.failUsage("attempt replay: require exactly one <attemptDir>")
+	}
+	attemptDir := fs.Arg(0)
+	if info, err := os.Stat(attemptDir); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	} else if !info.IsDir() {
+		return r.failUsage("attempt replay: target must be a directory")
+	}
+
+	var recordedFinish attemptFinishRecordV1
+	finishPresent := readJSONIfExists(filepath.Join(attemptDir, artifacts.AttemptFinishJSON), &recordedFinish)
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	if finishPresent && !explicit["strict"] {
+		*strict = recordedFinish.Strict
+	}
+	if finishPresent && !explicit["strict-expect"] {
+		*strictExpect = recordedFinish.StrictExpect
+	}
+	eff := attempt.EffectiveStrict(attemptDir, *strict)
+
+	out, err := r.replayAttemptFinish(attemptDir, eff, *strictExpect)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": attempt replay: %s\n", err.Error())
+		return 1
+	}
+	if rec, ok := recordedAttemptVerdict(attemptDir, recordedFinish, finishPresent); ok {
+		out.RecordedPresent = true
+		out.Recorded = &rec
+		out.Diffs = diffAttemptReplayVerdicts(rec, out.Replayed)
+		out.Reproduced = len(out.Diffs) == 0
+	}
+	if *feed {
+		fr, err := feedAttemptReplay(attemptDir, *feedTimeout)
+		if err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": attempt replay: feed: %s\n", err.Error())
+			return 1
+		}
+		out.Feed = &fr
+	}
+	out.OK = !out.RecordedPresent || out.Reproduced
+	if out.Feed != nil && !out.Feed.MatchesRecorded {
+		out.OK = false
+	}
+
+	exit := 0
+	if !out.OK {
+		exit = 2
+	}
+	if *jsonOut {
+		if code := r.writeJSON(out); code != 0 {
+			return code
+		}
+		return exit
+	}
+	r.printAttemptReplayHuman(out)
+	return exit
+}
+
+// replayAttemptFinish recomputes the finish pipeline (report, validate against that report,
+// expect) without publishing anything into the attempt dir.
+func (r Runner) replayAttemptFinish(attemptDir string, strict, strictExpect bool) (attemptReplayOutput, error) {
+	rep, err := report.BuildAttemptReport(r.Now(), attemptDir, strict)
+	if err != nil {
+		return attemptReplayOutput{}, err
+	}
+	// Stage like finish does, but never commit: published artifacts stay untouched.
+	tx, err := store.BeginDirTx(attemptDir, "replay")
+	if err != nil {
+		return attemptReplayOutput{}, err
+	}
+	defer tx.Abort()
+	if err := tx.WriteJSON(artifacts.AttemptReportJSON, rep); err != nil {
+		return attemptReplayOutput{}, err
+	}
+	stagedReport := tx.Path(artifacts.AttemptReportJSON)
+	valRes, err := validate.ValidateAttemptStaged(attemptDir, strict, stagedReport)
+	if err != nil {
+		return attemptReplayOutput{}, err
+	}
+	rewriteFindingPaths(&valRes, stagedReport, filepath.Join(attemptDir, artifacts.AttemptReportJSON))
+	expRes, err := expect.ExpectPath(attemptDir, strictExpect)
+	if err != nil {
+		return attemptReplayOutput{}, err
+	}
+	ok := valRes.OK && expRes.OK
+	if rep.OK != nil && !*rep.OK {
+		ok = false
+	}
+	v := attemptReplayVerdictFromReport(rep)
+	v.OK, v.ValidateOK, v.ExpectOK, v.ExpectFailures = ok, valRes.OK, expRes.OK, expectFailureCodes(expRes)
+	return attemptReplayOutput{
+		AttemptDir:   attemptDir,
+		Strict:       strict,
+		StrictExpect: strictExpect,
+		Replayed:     v,
+		Diffs:        []attemptReplayDiff{},
+		Report:       rep,
+		Validate:     valRes,
+		Expect:       expRes,
+	}, nil
+}
+
+// recordedAttemptVerdict reads the published attempt.finish.json + attempt.report.json pair.
+func recordedAttemptVerdict(attemptDir string, fin attemptFinishRecordV1, finishPresent bool) (attemptReplayVerdict, bool) {
+	if !finishPresent {
+		return attemptReplayVerdict{}, false
+	}
+	v := attemptReplayVerdict{}
+	if b, err := store.ReadArtifactFile(filepath.Join(attemptDir, artifacts.AttemptReportJSON)); err == nil {
+		if rep, err := schema.DecodeAttemptReport(b); err == nil {
+			v = attemptReplayVerdictFromReport(rep)
+		}
+	}
+	v.OK, v.ValidateOK, v.ExpectOK, v.ExpectFailures = fin.OK, fin.Validate.OK, fin.Expect.OK, expectFailureCodes(fin.Expect)
+	return v, true
+}
+
+func attemptReplayVerdictFromReport(rep schema.AttemptReportJSONV1) attemptReplayVerdict {
+	return attemptReplayVerdict{
+		ReportOK:       rep.OK,
+		Classification: rep.Classification,
+		DecisionTags:   rep.DecisionTags,
+	}
+}
+
+func expectFailureCodes(res expect.Result) []string {
+	var out []string
+	for _, f := range res.Failures {
+		out = append(out, f.Code)
+	}
+	return out
+}
+
+func diffAttemptReplayVerdicts(rec, got attemptReplayVerdict) []attemptReplayDiff {
+	diffs := []attemptReplayDiff{}
+	add := func(field string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			diffs = append(diffs, attemptReplayDiff{Field: field, Recorded: a, Replayed: b})
+		}
+	}
+	add("ok", rec.OK, got.OK)
+	add("report.ok", rec.ReportOK, got.ReportOK)
+	add("report.classification", rec.Classification, got.Classification)
+	add("report.decisionTags", rec.DecisionTags, got.DecisionTags)
+	add("validate.ok", rec.ValidateOK, got.ValidateOK)
+	add("expect.ok", rec.ExpectOK, got.ExpectOK)
+	add("expect.failures", rec.ExpectFailures, got.ExpectFailures)
+	return diffs
+}
+
+// feedAttemptReplay pushes the recorded tool results and final feedback result through a
+// provider_stub replay session and checks the turn reproduces them.
+func feedAttemptReplay(attemptDir string, timeout time.Duration) (attemptReplayFeed, error) {
+	results, err := readRecordedToolResults(filepath.Join(attemptDir, artifacts.ToolCallsJSONL))
+	if err != nil {
+		return attemptReplayFeed{}, err
+	}
+	var fb schema.FeedbackJSONV1
+	final := ""
+	if b, err := store.ReadArtifactFile(filepath.Join(attemptDir, artifacts.FeedbackJSON)); err == nil && json.Unmarshal(b, &fb) == nil {
+		final = fb.Result
+		if final == "" && len(fb.ResultJSON) > 0 {
+			final = string(fb.ResultJSON)
+		}
+	}
+	ids := loadAttemptExplainIDs(attemptDir)
+	prompt := ""
+	if b, err := store.ReadArtifactFile(filepath.Join(attemptDir, artifacts.PromptTXT)); err == nil {
+		prompt = string(b)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	rt := providerstub.NewReplayRuntime(providerstub.ReplayScript{ToolResults: results, FinalMessage: final})
+	sess, err := rt.StartSession(ctx, native.SessionOptions{RunID: ids.RunID, SuiteID: ids.SuiteID, MissionID: ids.MissionID, AttemptID: ids.AttemptID, AttemptDir: attemptDir})
+	if err != nil {
+		return attemptReplayFeed{}, err
+	}
+	defer func() { _ = sess.Close(context.Background()) }()
+	events := make(chan native.Event, len(results)+4)
+	if _, err := sess.AddListener(func(ev native.Event) { events <- ev }); err != nil {
+		return attemptReplayFeed{}, err
+	}
+	th, err := sess.StartThread(ctx, native.ThreadStartRequest{Cwd: attemptDir})
+	if err != nil {
+		return attemptReplayFeed{}, err
+	}
+	turn, err := sess.StartTurn(ctx, native.TurnStartRequest{ThreadID: th.ThreadID, Input: []native.InputItem{{Type: "text", Text: prompt}}})
+	if err != nil {
+		return attemptReplayFeed{}, err
+	}
+	out := attemptReplayFeed{Runtime: string(rt.ID()), ThreadID: th.ThreadID, TurnID: turn.TurnID, Status: "timeout"}
+	for out.Status == "timeout" {
+		select {
+		case ev := <-events:
+			switch ev.Name {
+			case providerstub.ReplayEventToolResult:
+				out.ToolResultsFed++
+			case providerstub.ReplayEventAgentMessage:
+				var msg struct {
+					Message string `json:"message"`
+				}
+				_ = json.Unmarshal(ev.Payload, &msg)
+				out.FinalMessage = msg.Message
+			case providerstub.ReplayEventTurnCompleted:
+				out.Status = "completed"
+			case providerstub.ReplayEventTurnFailed:
+				out.Status = "failed"
+			}
+		case <-ctx.Done():
+			return out, nil
+		}
+	}
+	out.MatchesRecorded = out.Status == "completed" && out.ToolResultsFed == len(results) && out.FinalMessage == final
+	return out, nil
+}
+
+// readRecordedToolResults returns each tool.calls.jsonl event verbatim (empty when no trace).
+func readRecordedToolResults(path string) ([]json.RawMessage, error) {
+	f, err := store.OpenArtifact(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	var out []json.RawMessage
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || !json.Valid([]byte(line)) {
+			continue
+		}
+		out = append(out, json.RawMessage(line))
+	}
+	return out, sc.Err()
+}
+
+func (r Runner) printAttemptReplayHuman(out attemptReplayOutput) {
+	status := "REPRODUCED"
+	switch {
+	case !out.RecordedPresent:
+		status = "NO-RECORD"
+	case !out.Reproduced:
+		status = "DIVERGED"
+	}
+	fmt.Fprintf(r.Stdout, "attempt replay: %s ok=%v validate=%v expect=%v strict=%v\n", status, out.Replayed.OK, out.Replayed.ValidateOK, out.Replayed.ExpectOK, out.Strict)
+	for _, d := range out.Diffs {
+		fmt.Fprintf(r.Stdout, "  %s: recorded=%v replayed=%v\n", d.Field, d.Recorded, d.Replayed)
+	}
+	if out.Feed != nil {
+		fmt.Fprintf(r.Stdout, "  feed: runtime=%s status=%s toolResults=%d matchesRecorded=%v\n", out.Feed.Runtime, out.Feed.Status, out.Feed.ToolResultsFed, out.Feed.MatchesRecorded)
+	}
+}
+
+func printAttemptReplayHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl attempt replay [--strict] [--strict-expect] [--feed] [--feed-timeout 10s] [--json] <attemptDir>
+
+Notes:
+  - Re-runs the attempt finish pipeline (report -> validate -> expect) against the recorded artifacts
+    without writing to the attempt dir, and diffs the verdict against attempt.finish.json + attempt.report.json.
+  - Strict modes default to the ones recorded in attempt.finish.json.
+  - --feed replays the recorded tool results and feedback result as a new agent turn through the
+    provider_stub runtime to check the native event path reproduces them.
+  - Exit 0 when reproduced (or nothing was recorded yet), 2 when the replay diverges.
+  - To re-execute recorded commands, use zcl replay.
+`)
+}
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/feedback"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/attempt"
+	clifunnel "github.com/marcohefti/zero-context-lab/internal/kernel/cli_funnel"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// Harness bench stages, in pipeline order. spawn is the no-op process itself; everything else is
+// harness overhead.
+const (
+	benchStageAttemptStart = "attempt_start"
+	benchStageSpawn        = "spawn"
+	benchStageTraceAppend  = "trace_append"
+	benchStageFeedback     = "feedback"
+	benchStageFinish       = "finish"
+)
+
+var benchHarnessStages = []string{benchStageAttemptStart, benchStageSpawn, benchStageTraceAppend, benchStageFeedback, benchStageFinish}
+
+type benchHarnessResult struct {
+	OK            bool                `json:"ok"`
+	SchemaVersion int                 `json:"schemaVersion"`
+	Attempts      int                 `json:"attempts"`
+	OutRoot       string              `json:"outRoot"`
+	Kept          bool                `json:"kept"`
+	Command       []string            `json:"command"`
+	FinishFailed  int                 `json:"finishFailed"`
+	Stages        []benchStageSummary `json:"stages"`
+	// Overhead sums the per-attempt time of every stage except spawn.
+	Overhead benchStageSummary `json:"overhead"`
+	// Total is the per-attempt wall time of the whole pipeline.
+	Total benchStageSummary `json:"total"`
+}
+
+type benchStageSummary struct {
+	Name   string `json:"name"`
+	MeanUs int64  `json:"meanUs"`
+	MinUs  int64  `json:"minUs"`
+	P50Us  int64  `json:"p50Us"`
+	P95Us  int64  `json:"p95Us"`
+	MaxUs  int64  `json:"maxUs"`
+}
+
+func (r Runner) runBench(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printBenchHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "harness":
+		return r.runBenchHarness(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown bench subcommand %q\n", args[0])
+		printBenchHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runBenchHarness(args []string) int {
+	fs := flag.NewFlagSet("bench harness", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	attempts := fs.Int("attempts", 100, "number of no-op attempts to run")
+	outRoot := fs.String("out-root", "", "output root for the bench attempts (default: a temp dir removed afterwards)")
+	keep := fs.Bool("keep", false, "keep the temp output root")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("bench harness: invalid flags")
+	}
+	if *help {
+		printBenchHelp(r.Stdout)
+		return 0
+	}
+	if *attempts <= 0 {
+		return r.failUsage("bench harness: --attempts must be > 0")
+	}
+	argv := fs.Args()
+	if len(argv) == 0 {
+		exe, err := os.Executable()
+		if err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": bench harness: %s\n", err.Error())
+			return 1
+		}
+		argv = []string{exe, "version"}
+	}
+
+	root := strings.TrimSpace(*outRoot)
+	kept := true
+	if root == "" {
+		dir, err := os.MkdirTemp("", "zcl-bench-")
+		if err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": bench harness: %s\n", err.Error())
+			return 1
+		}
+		root = dir
+		kept = *keep
+		if !kept {
+			defer func() { _ = os.RemoveAll(dir) }()
+		}
+	} else {
+		m, err := config.LoadMerged(root)
+		if err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+			return 1
+		}
+		root = m.OutRoot
+	}
+
+	res, err := r.benchHarness(root, argv, *attempts)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": bench harness: %s\n", err.Error())
+		return 1
+	}
+	res.Kept = kept
+	exit := 0
+	if !res.OK {
+		exit = 1
+	}
+	if *jsonOut {
+		if code := r.writeJSON(res); code != 0 {
+			return code
+		}
+		return exit
+	}
+	renderBenchHarness(r.Stdout, res)
+	return exit
+}
+
+// benchHarness drives attempts through the same steps a process runner attempt takes: attempt start,
+// spawning the runner, the funnel trace append, feedback and the finish pipeline (report, validate,
+// expect). Every stage is timed on its own so a regression points at the stage that got slower.
+func (r Runner) benchHarness(outRoot string, argv []string, attempts int) (benchHarnessResult, error) {
+	res := benchHarnessResult{
+		OK:            true,
+		SchemaVersion: 1,
+		Attempts:      attempts,
+		OutRoot:       outRoot,
+		Command:       argv,
+	}
+	samples := map[string][]time.Duration{}
+	var overhead, total []time.Duration
+	runID := ""
+	for i := 0; i < attempts; i++ {
+		durs := map[string]time.Duration{}
+		timed := func(stage string, fn func() error) error {
+			start := time.Now()
+			err := fn()
+			durs[stage] = time.Since(start)
+			return err
+		}
+
+		var started *attempt.StartResult
+		if err := timed(benchStageAttemptStart, func() error {
+			var err error
+			started, err = attempt.Start(r.Now(), attempt.StartOpts{
+				OutRoot:        outRoot,
+				RunID:          runID,
+				SuiteID:        "bench",
+				MissionID:      "noop",
+				IsolationModel: schema.IsolationModelProcessRunnerV1,
+				ZCLVersion:     r.Version,
+			})
+			return err
+		}); err != nil {
+			return res, err
+		}
+		runID = started.RunID
+		env := suiteRunTraceEnv(started.Env, started.OutDirAbs)
+
+		var spawned clifunnel.Result
+		spawnErr := timed(benchStageSpawn, func() error {
+			var err error
+			spawned, err = clifunnel.Run(context.Background(), argv, strings.NewReader(""), io.Discard, io.Discard, nil, nil, schema.PreviewMaxBytesV1)
+			return err
+		})
+		traceRes := baseRunTraceResult(0, spawned)
+		if spawnErr != nil {
+			traceRes.SpawnError = codeSpawn
+		}
+		if err := timed(benchStageTraceAppend, func() error {
+			return trace.AppendCLIRunEvent(r.Now(), env, argv, traceRes)
+		}); err != nil {
+			return res, err
+		}
+		if err := timed(benchStageFeedback, func() error {
+			return feedback.Write(r.Now(), env, feedback.WriteOpts{OK: true, Result: "noop"})
+		}); err != nil {
+			return res, err
+		}
+		var fin suiteRunFinishResult
+		_ = timed(benchStageFinish, func() error {
+			fin = finishAttempt(r.Now(), started.OutDirAbs, false, false)
+			return nil
+		})
+		if fin.IOError != "" {
+			return res, errors.New(fin.IOError)
+		}
+		if !fin.OK || spawnErr != nil || spawned.ExitCode != 0 {
+			res.FinishFailed++
+			res.OK = false
+		}
+
+		var attemptOverhead, attemptTotal time.Duration
+		for _, stage := range benchHarnessStages {
+			samples[stage] = append(samples[stage], durs[stage])
+			attemptTotal += durs[stage]
+			if stage != benchStageSpawn {
+				attemptOverhead += durs[stage]
+			}
+		}
+		overhead = append(overhead, attemptOverhead)
+		total = append(total, attemptTotal)
+	}
+	for _, stage := range benchHarnessStages {
+		res.Stages = append(res.Stages, summarizeBenchStage(stage, samples[stage]))
+	}
+	res.Overhead = summarizeBenchStage("overhead", overhead)
+	res.Total = summarizeBenchStage("total", total)
+	return res, nil
+}
+
+func summarizeBenchStage(name string, durs []time.Duration) benchStageSummary {
+	out := benchStageSummary{Name: name}
+	if len(durs) == 0 {
+		return out
+	}
+	sorted := append([]time.Duration(nil), durs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+	// Nearest-rank percentiles: small sample counts stay on observed values.
+	rank := func(q float64) time.Duration {
+		idx := int(q*float64(len(sorted))+0.999999) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= len(sorted) {
+			idx = len(sorted) - 1
+		}
+		return sorted[idx]
+	}
+	out.MeanUs = (sum / time.Duration(len(sorted))).Microseconds()
+	out.MinUs = sorted[0].Microseconds()
+	out.P50Us = rank(0.50).Microseconds()
+	out.P95Us = rank(0.95).Microseconds()
+	out.MaxUs = sorted[len(sorted)-1].Microseconds()
+	return out
+}
+
+func renderBenchHarness(w io.Writer, res benchHarnessResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STAGE\tMEAN\tP50\tP95\tMAX")
+	rows := append(append([]benchStageSummary(nil), res.Stages...), res.Overhead, res.Total)
+	for _, s := range rows {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.Name, benchMicros(s.MeanUs), benchMicros(s.P50Us), benchMicros(s.P95Us), benchMicros(s.MaxUs))
+	}
+	_ = tw.Flush()
+	status := "OK"
+	if !res.OK {
+		status = "FAIL"
+	}
+	fmt.Fprintf(w, "bench harness: %s attempts=%d finishFailed=%d outRoot=%s kept=%v\n", status, res.Attempts, res.FinishFailed, res.OutRoot, res.Kept)
+}
+
+func benchMicros(us int64) string {
+	return (time.Duration(us) * time.Microsecond).String()
+}
+
+func printBenchHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl bench harness [--attempts 100] [--out-root <dir>] [--keep] [--json] [-- <noop-cmd> [args...]]
+
+Notes:
+  - Runs no-op attempts through the full pipeline (attempt start, runner spawn, trace append, feedback, finish) and reports mean/p50/p95/max per stage, the harness overhead (everything but spawn) and the total.
+  - The no-op runner defaults to "zcl version"; pass another command after -- to compare spawn costs.
+  - Attempts go to a temp out-root that is removed afterwards unless --keep or --out-root is set.
+  - Exits 1 when any attempt failed to finish cleanly (finishFailed > 0).
+`)
+}
@@ -22,8 +22,18 @@ import (
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/semantic"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/domain/oracle"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/secretscan"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/claudecli"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/home"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/limits"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/remote"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/runners"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/infra/sqlitestate"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/ports/statestore"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/top"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/infra/codex_app_server"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
@@ -59,35 +69,43 @@ type missionPromptArtifactV1 struct {
 const oracleVerdictFileName = artifacts.OracleVerdictJSON
 
 type oracleEvaluatorOutput struct {
-	OK                bool              `json:"ok"`
-	ReasonCodes       []string          `json:"reasonCodes,omitempty"`
-	Message           string            `json:"message,omitempty"`
-	Mismatches        []oracle.Mismatch `json:"mismatches,omitempty"`
-	PolicyDisposition string            `json:"policyDisposition,omitempty"` // fail|warn|ignore
-	Warnings          []string          `json:"warnings,omitempty"`
-	Expected          any               `json:"expected,omitempty"`
-	Actual            any               `json:"actual,omitempty"`
-	Details           any               `json:"details,omitempty"`
+	OK                bool                `json:"ok"`
+	ReasonCodes       []string            `json:"reasonCodes,omitempty"`
+	Message           string              `json:"message,omitempty"`
+	Mismatches        []oracle.Mismatch   `json:"mismatches,omitempty"`
+	PolicyDisposition string              `json:"policyDisposition,omitempty"` // fail|warn|ignore
+	Warnings          []string            `json:"warnings,omitempty"`
+	Expected          any                 `json:"expected,omitempty"`
+	Actual            any                 `json:"actual,omitempty"`
+	Details           any                 `json:"details,omitempty"`
+	Judge             *oracleJudgeInfo    `json:"judge,omitempty"`
+	Ensemble          *oracleEnsembleInfo `json:"ensemble,omitempty"`
+	Rubric            *oracleRubricInfo   `json:"rubric,omitempty"`
+	// Claims is set once claim.vs.verified.json was written; never part of evaluator output.
+	Claims *campaign.ClaimCountsV1 `json:"-"`
 }
 
 type oracleVerdictArtifact struct {
-	SchemaVersion     int               `json:"schemaVersion"`
-	CampaignID        string            `json:"campaignId"`
-	FlowID            string            `json:"flowId"`
-	MissionID         string            `json:"missionId"`
-	AttemptID         string            `json:"attemptId"`
-	AttemptDir        string            `json:"attemptDir"`
-	OraclePath        string            `json:"oraclePath"`
-	EvaluatorKind     string            `json:"evaluatorKind"`
-	EvaluatorCmd      []string          `json:"evaluatorCommand"`
-	PromptMode        string            `json:"promptMode"`
-	OK                bool              `json:"ok"`
-	ReasonCodes       []string          `json:"reasonCodes,omitempty"`
-	Message           string            `json:"message,omitempty"`
-	Mismatches        []oracle.Mismatch `json:"mismatches,omitempty"`
-	PolicyDisposition string            `json:"policyDisposition,omitempty"`
-	Warnings          []string          `json:"warnings,omitempty"`
-	ExecutedAt        string            `json:"executedAt"`
+	SchemaVersion     int                 `json:"schemaVersion"`
+	CampaignID        string              `json:"campaignId"`
+	FlowID            string              `json:"flowId"`
+	MissionID         string              `json:"missionId"`
+	AttemptID         string              `json:"attemptId"`
+	AttemptDir        string              `json:"attemptDir"`
+	OraclePath        string              `json:"oraclePath"`
+	EvaluatorKind     string              `json:"evaluatorKind"`
+	EvaluatorCmd      []string            `json:"evaluatorCommand"`
+	PromptMode        string              `json:"promptMode"`
+	OK                bool                `json:"ok"`
+	ReasonCodes       []string            `json:"reasonCodes,omitempty"`
+	Message           string              `json:"message,omitempty"`
+	Mismatches        []oracle.Mismatch   `json:"mismatches,omitempty"`
+	PolicyDisposition string              `json:"policyDisposition,omitempty"`
+	Warnings          []string            `json:"warnings,omitempty"`
+	Judge             *oracleJudgeInfo    `json:"judge,omitempty"`
+	Ensemble          *oracleEnsembleInfo `json:"ensemble,omitempty"`
+	Rubric            *oracleRubricInfo   `json:"rubric,omitempty"`
+	ExecutedAt        string              `json:"executedAt"`
 }
 
 type attemptFeedbackSummary struct {
@@ -97,6 +115,7 @@ type attemptFeedbackSummary struct {
 	ResultCode    string
 	ResultKind    string
 	HasValidProof bool
+	Confidence    *float64
 }
 
 var oracleExpectedGotRE = regexp.MustCompile(`^\s*([A-Za-z0-9_./-]+)\s+expected\s+(.+)\s+got\s+(.+)\s*$`)
@@ -123,6 +142,10 @@ func (r Runner) runCampaign(args []string) int {
 		return r.runCampaignPublishCheck(args[1:])
 	case "doctor":
 		return r.runCampaignDoctor(args[1:])
+	case "regrade":
+		return r.runCampaignRegrade(args[1:])
+	case "export":
+		return r.runCampaignExport(args[1:])
 	default:
 		fmt.Fprintf(r.Stderr, codeUsage+": unknown campaign subcommand %q\n", args[0])
 		printCampaignHelp(r.Stderr)
@@ -191,6 +214,10 @@ func (r Runner) runCampaignLint(args []string) int {
 				"kind":    parsed.Spec.Evaluation.Evaluator.Kind,
 				"command": parsed.Spec.Evaluation.Evaluator.Command,
 			},
+			"evaluators":      parsed.Spec.Evaluation.Evaluators,
+			"ensemblePolicy":  parsed.Spec.Evaluation.EnsemblePolicy,
+			"rubric":          parsed.Spec.Evaluation.Rubric,
+			"rubricPassScore": parsed.Spec.Evaluation.RubricPassScore,
 			"oraclePolicy": map[string]any{
 				"mode":           parsed.Spec.Evaluation.OraclePolicy.Mode,
 				"formatMismatch": parsed.Spec.Evaluation.OraclePolicy.FormatMismatch,
@@ -209,6 +236,7 @@ func (r Runner) runCampaignLint(args []string) int {
 		"semantic": map[string]any{
 			"enabled":   parsed.Spec.Semantic.Enabled,
 			"rulesPath": parsed.Spec.Semantic.RulesPath,
+			"rules":     parsed.Spec.Semantic.Rules,
 		},
 		"noContext": map[string]any{
 			"forbiddenPromptTerms": parsed.Spec.NoContext.ForbiddenPromptTerms,
@@ -418,6 +446,7 @@ func (r Runner) executeCampaignAndWrite(parsed campaign.ParsedSpec, resolvedOutR
 	if !jsonOut {
 		fmt.Fprintf(r.Stdout, "%s: %s (%s)\n", label, st.Status, st.RunID)
 	}
+	r.autoSyncCampaign(st, label)
 	return exit
 }
 
@@ -523,6 +552,7 @@ func (r Runner) resumeCampaignFromState(jsonOut bool, campaignID string, st camp
 	if !jsonOut {
 		fmt.Fprintf(r.Stdout, "campaign resume: %s (%s)\n", next.Status, next.RunID)
 	}
+	r.autoSyncCampaign(next, "campaign resume")
 	return exit
 }
 
@@ -532,6 +562,7 @@ func (r Runner) runCampaignStatus(args []string) int {
 
 	campaignID := fs.String("campaign-id", "", "campaign id (required)")
 	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	staleAfterMs := fs.Int64("stale-after-ms", top.DefaultStaleAfter.Milliseconds(), "flag unfinished attempts whose heartbeat is older than this as likely hung")
 	jsonOut := fs.Bool("json", false, "print JSON output")
 	help := fs.Bool("help", false, "show help")
 
@@ -547,6 +578,9 @@ func (r Runner) runCampaignStatus(args []string) int {
 		printCampaignStatusHelp(r.Stderr)
 		return r.failUsage("campaign status: missing/invalid --campaign-id")
 	}
+	if *staleAfterMs <= 0 {
+		return r.failUsage("campaign status: --stale-after-ms must be > 0")
+	}
 
 	m, err := config.LoadMerged(*outRoot)
 	if err != nil {
@@ -561,13 +595,37 @@ func (r Runner) runCampaignStatus(args []string) int {
 	if msg, drift := campaignStateDriftMessage(st); drift {
 		return r.writeCampaignStateDrift(*jsonOut, st.CampaignID, st.RunID, msg)
 	}
+	stale := campaignStaleAttempts(m.OutRoot, cid, r.Now(), time.Duration(*staleAfterMs)*time.Millisecond)
 	if *jsonOut {
-		return r.writeJSON(st)
+		return r.writeJSON(struct {
+			campaign.RunStateV1
+			StaleAttempts []top.StaleAttemptV1 `json:"staleAttempts,omitempty"`
+		}{st, stale})
 	}
 	fmt.Fprintf(r.Stdout, "campaign status: %s runId=%s completed=%d/%d\n", st.Status, st.RunID, st.MissionsCompleted, st.TotalMissions)
+	for _, a := range stale {
+		fmt.Fprintf(r.Stdout, "stale attempt (likely hung): runId=%s attemptId=%s missionId=%s heartbeatAge=%s\n", a.RunID, a.AttemptID, a.MissionID, time.Duration(a.HeartbeatAgeMs)*time.Millisecond)
+	}
 	return 0
 }
 
+// campaignStaleAttempts lists the stale attempts of the suite runs started for campaignID.
+func campaignStaleAttempts(outRoot string, campaignID string, now time.Time, staleAfter time.Duration) []top.StaleAttemptV1 {
+	entries, err := os.ReadDir(filepath.Join(outRoot, "runs"))
+	if err != nil {
+		return nil
+	}
+	var out []top.StaleAttemptV1
+	for _, e := range entries {
+		runDir := filepath.Join(outRoot, "runs", e.Name())
+		if !e.IsDir() || top.RunCampaignID(runDir) != campaignID {
+			continue
+		}
+		out = append(out, top.StaleAttempts(runDir, now, staleAfter)...)
+	}
+	return out
+}
+
 func (r Runner) runCampaignReport(args []string) int {
 	fs := flag.NewFlagSet("campaign report", flag.ContinueOnError)
 	fs.SetOutput(io.Discard)
@@ -688,6 +746,16 @@ func (r Runner) evaluateCampaignPublishCheckOutcome(st campaign.RunStateV1, forc
 	if !ok {
 		return campaignPublishCheckOutcome{}, exit, false
 	}
+	secretScan := campaignPublishSecretScan(nextState)
+	if ok, _ := secretScan["ok"].(bool); !ok {
+		publishOK = false
+		nextState.ReasonCodes = dedupeSortedStrings(append(nextState.ReasonCodes, campaign.ReasonSecretLeak))
+	}
+	redactionVerify := campaignPublishRedactionVerify(nextState)
+	if ok, _ := redactionVerify["ok"].(bool); !ok {
+		publishOK = false
+		nextState.ReasonCodes = dedupeSortedStrings(append(nextState.ReasonCodes, campaign.ReasonRedactionUnverified))
+	}
 	if force && !publishOK {
 		publishOK = true
 	}
@@ -700,10 +768,91 @@ func (r Runner) evaluateCampaignPublishCheckOutcome(st campaign.RunStateV1, forc
 		"promptModeCompliance":   promptModeCompliance,
 		"oraclePolicyCompliance": oraclePolicyCompliance,
 		"toolDriverCompliance":   toolDriverCompliance,
+		"secretScan":             secretScan,
+		"redactionVerify":        redactionVerify,
 	}
 	return campaignPublishCheckOutcome{publishOK: publishOK, state: nextState, payload: out}, 0, true
 }
 
+type campaignSecretHit struct {
+	RunID string `json:"runId"`
+	secretscan.Hit
+}
+
+// campaignPublishSecretScan runs the leaked-credential scanner over every flow run of the campaign run.
+// A run that cannot be scanned fails the gate: publish-check must not vouch for unread artifacts.
+// campaignFlowRunIDs returns the distinct run ids recorded by the campaign's flow runs.
+func campaignFlowRunIDs(st campaign.RunStateV1) []string {
+	runIDs := make([]string, 0, len(st.FlowRuns))
+	for _, fr := range st.FlowRuns {
+		if id := strings.TrimSpace(fr.RunID); id != "" {
+			runIDs = append(runIDs, id)
+		}
+	}
+	return dedupeSortedStrings(runIDs)
+}
+
+func campaignPublishSecretScan(st campaign.RunStateV1) map[string]any {
+	runIDs := campaignFlowRunIDs(st)
+	hits := []campaignSecretHit{}
+	var errs []string
+	for _, runID := range runIDs {
+		res, err := secretscan.Run(secretscan.Opts{OutRoot: st.OutRoot, RunID: runID})
+		if err != nil {
+			errs = append(errs, runID+": "+err.Error())
+			continue
+		}
+		for _, h := range res.Hits {
+			hits = append(hits, campaignSecretHit{RunID: runID, Hit: h})
+		}
+	}
+	out := map[string]any{
+		"ok":     len(hits) == 0 && len(errs) == 0,
+		"code":   campaign.ReasonSecretLeak,
+		"runIds": runIDs,
+		"hits":   hits,
+	}
+	if len(errs) > 0 {
+		out["errors"] = errs
+	}
+	return out
+}
+
+type campaignRedactionUnverified struct {
+	RunID  string `json:"runId"`
+	Reason string `json:"reason"`
+}
+
+// campaignPublishRedactionVerify checks each flow run for a passing redaction.verify.json recorded
+// under the current rule set. It only gates when the spec sets output.requireRedactionVerify.
+func campaignPublishRedactionVerify(st campaign.RunStateV1) map[string]any {
+	required := false
+	if strings.TrimSpace(st.SpecPath) != "" {
+		if parsed, err := campaign.ParseSpecFile(st.SpecPath); err == nil {
+			required = parsed.Spec.Output.RequireRedactionVerify
+		}
+	}
+	runIDs := campaignFlowRunIDs(st)
+	unverified := []campaignRedactionUnverified{}
+	for _, runID := range runIDs {
+		ok, reason, err := secretscan.Verified(secretscan.Opts{OutRoot: st.OutRoot, RunID: runID})
+		if err != nil {
+			reason = err.Error()
+		}
+		if !ok {
+			unverified = append(unverified, campaignRedactionUnverified{RunID: runID, Reason: reason})
+		}
+	}
+	return map[string]any{
+		"ok":         !required || len(unverified) == 0,
+		"code":       campaign.ReasonRedactionUnverified,
+		"required":   required,
+		"ruleset":    redact.CurrentRuleset().SHA256,
+		"runIds":     runIDs,
+		"unverified": unverified,
+	}
+}
+
 func campaignPublishStatusOK(policy resolvedInvalidRunPolicy, status string) bool {
 	publishOK := status == campaign.RunStatusValid
 	if !policy.PublishRequiresValid {
@@ -1013,7 +1162,7 @@ func validateCampaignDoctorRunnerCommand(flow campaign.FlowSpec, addCheck func(s
 		addCheck("runner_command_"+flow.FlowID, false, "runner.command is empty")
 		return "", false
 	}
-	cmd0 := strings.TrimSpace(flow.Runner.Command[0])
+	cmd0 := strings.TrimSpace(campaignFlowRunnerCommand(flow)[0])
 	if cmd0 == "" {
 		addCheck("runner_command_"+flow.FlowID, false, "runner.command[0] is empty")
 		return "", false
@@ -1281,6 +1430,19 @@ func (r Runner) executeCampaign(parsed campaign.ParsedSpec, outRoot string, in c
 		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
 		return campaign.RunStateV1{}, 1
 	}
+	m, err := config.LoadMerged(outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
+		return campaign.RunStateV1{}, 2
+	}
+	stateStore, err := openCampaignStateStore(m.CampaignState, outRoot, parsed.Spec.CampaignID)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return campaign.RunStateV1{}, 1
+	}
+	if stateStore != nil {
+		defer func() { _ = stateStore.Close() }()
+	}
 	engineResult, err := campaign.ExecuteMissionEngine(
 		parsed,
 		execAdapter,
@@ -1300,6 +1462,8 @@ func (r Runner) executeCampaign(parsed campaign.ParsedSpec, outRoot string, in c
 			WatchdogHardKillContinue: parsed.Spec.Timeouts.WatchdogHardKillContinue,
 			LockWait:                 750 * time.Millisecond,
 			Now:                      r.Now,
+			StateStore:               stateStore,
+			EventHook:                campaignEventHook(&lockedWriter{mu: &sync.Mutex{}, w: r.Stderr}),
 		},
 	)
 	if err != nil {
@@ -1313,6 +1477,20 @@ func (r Runner) executeCampaign(parsed campaign.ParsedSpec, outRoot string, in c
 	return engineResult.State, engineResult.Exit
 }
 
+// openCampaignStateStore opens the configured campaign state backend. It returns nil for the
+// default file backend, which campaign.UpdateState and the run-state engine treat as "write the
+// JSON files directly".
+func openCampaignStateStore(backend, outRoot, campaignID string) (statestore.Store, error) {
+	if backend != config.CampaignStateSQLite {
+		return nil, nil
+	}
+	db, err := sqlitestate.Open(campaign.StateDBPath(outRoot, campaignID))
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
 func (r Runner) persistCampaignArtifacts(st campaign.RunStateV1) error {
 	rep := campaign.BuildReport(st)
 	sum := campaign.BuildSummary(st)
@@ -1406,6 +1584,43 @@ func (r Runner) runCampaignHook(ctx context.Context, command string) error {
 	return nil
 }
 
+// campaignEventHook runs hooks.onEvent commands (argv, no shell) with the progress event as JSON
+// on stdin and its identifying fields in ZCL_HOOK_* env. Failures are reported on stderr, which
+// parallel flows share.
+func campaignEventHook(stderr io.Writer) campaign.EventHookExecutor {
+	return func(ctx context.Context, command []string, ev campaign.ProgressEventV1) error {
+		return runCampaignEventHook(ctx, stderr, command, ev)
+	}
+}
+
+func runCampaignEventHook(ctx context.Context, stderr io.Writer, command []string, ev campaign.ProgressEventV1) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	execCmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	execCmd.Stdin = bytes.NewReader(append(payload, '\n'))
+	execCmd.Env = append(os.Environ(),
+		"ZCL_HOOK_EVENT="+ev.Status,
+		"ZCL_HOOK_CAMPAIGN_ID="+ev.CampaignID,
+		"ZCL_HOOK_RUN_ID="+ev.RunID,
+		"ZCL_HOOK_MISSION_ID="+ev.MissionID,
+		"ZCL_HOOK_FLOW_ID="+ev.FlowID,
+		"ZCL_HOOK_ATTEMPT_DIR="+ev.AttemptDir,
+		"ZCL_HOOK_REASON_CODES="+strings.Join(ev.ReasonCodes, ","),
+	)
+	out, err := execCmd.CombinedOutput()
+	if err != nil {
+		msg := trimText(strings.TrimSpace(string(out)), 512)
+		if msg == "" {
+			msg = err.Error()
+		}
+		fmt.Fprintf(stderr, "%s: campaign hooks.onEvent %s: %s\n", campaign.ReasonHookFailed, ev.Status, msg)
+		return fmt.Errorf("event hook failed: %s", msg)
+	}
+	return nil
+}
+
 func (r Runner) evaluateCampaignGateForMission(parsed campaign.ParsedSpec, missionIndex int, missionID string, missionFlowRuns []campaign.FlowRunV1) (campaign.MissionGateV1, error) {
 	mg := campaign.MissionGateV1{
 		MissionIndex: missionIndex,
@@ -1424,7 +1639,7 @@ func (r Runner) evaluateCampaignGateForMission(parsed campaign.ParsedSpec, missi
 		}
 	}
 	mg.Reasons = dedupeSortedStrings(mg.Reasons)
-	return mg, nil
+	return campaign.ApplyMissionVerdictOverrides(mg), nil
 }
 
 type missionFlowGateEvaluation struct {
@@ -1446,10 +1661,14 @@ func (r Runner) evaluateMissionFlowGate(parsed campaign.ParsedSpec, missionID st
 	seedMissionGateAttempt(ar, &ma)
 	feedbackSummary := loadAttemptFeedbackSummaryBestEffort(ar.AttemptDir)
 	infraDetected, infraCode := inferAttemptInfraFailure(ar, feedbackSummary)
-	gateErrors, err := r.collectMissionGateErrors(parsed, fr.FlowID, missionID, ar, feedbackSummary, infraDetected, infraCode)
+	gateErrors, evidence, err := r.collectMissionGateErrors(parsed, fr.FlowID, missionID, ar, feedbackSummary, infraDetected, infraCode)
 	if err != nil {
 		return missionFlowGateEvaluation{}, err
 	}
+	ma.OracleVotes = evidence.votes
+	ma.RubricScore = evidence.rubricScore
+	ma.Confidence = feedbackSummary.Confidence
+	ma.Claims = evidence.claims
 	return finalizeMissionFlowGate(parsed, ar, ma, gateErrors, infraDetected), nil
 }
 
@@ -1483,26 +1702,26 @@ func loadAttemptFeedbackSummaryBestEffort(attemptDir string) attemptFeedbackSumm
 	return fb
 }
 
-func (r Runner) collectMissionGateErrors(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, feedbackSummary attemptFeedbackSummary, infraDetected bool, infraCode string) ([]string, error) {
+func (r Runner) collectMissionGateErrors(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, feedbackSummary attemptFeedbackSummary, infraDetected bool, infraCode string) ([]string, oracleGateEvidence, error) {
 	gateErrors := make([]string, 0, 8)
 	gateErrors = append(gateErrors, baseMissionGateErrors(parsed, ar, infraDetected, infraCode)...)
 	extraErrors, err := r.collectMissionAttemptDirGateErrors(parsed, flowID, ar)
 	if err != nil {
-		return nil, err
+		return nil, oracleGateEvidence{}, err
 	}
 	gateErrors = append(gateErrors, extraErrors...)
 	semErrors, err := collectMissionSemanticGateErrors(parsed, ar)
 	if err != nil {
-		return nil, err
+		return nil, oracleGateEvidence{}, err
 	}
 	gateErrors = append(gateErrors, semErrors...)
 	gateErrors = append(gateErrors, collectExamProofGateErrors(parsed, feedbackSummary, infraDetected)...)
-	oracleErrors, err := r.collectOracleGateErrors(parsed, flowID, missionID, ar, feedbackSummary, infraDetected)
+	oracleErrors, evidence, err := r.collectOracleGateErrors(parsed, flowID, missionID, ar, feedbackSummary, infraDetected)
 	if err != nil {
-		return nil, err
+		return nil, oracleGateEvidence{}, err
 	}
 	gateErrors = append(gateErrors, oracleErrors...)
-	return gateErrors, nil
+	return gateErrors, evidence, nil
 }
 
 func baseMissionGateErrors(parsed campaign.ParsedSpec, ar *campaign.AttemptStatusV1, infraDetected bool, infraCode string) []string {
@@ -1542,6 +1761,11 @@ func (r Runner) collectMissionAttemptDirGateErrors(parsed campaign.ParsedSpec, f
 		return nil, err
 	}
 	out = append(out, policyFindings...)
+	egressFindings, err := campaign.EvaluateEgress(ar.AttemptDir)
+	if err != nil {
+		return nil, err
+	}
+	out = append(out, egressFindings...)
 	return out, nil
 }
 
@@ -1579,7 +1803,11 @@ func collectMissionSemanticGateErrors(parsed campaign.ParsedSpec, ar *campaign.A
 	if strings.TrimSpace(ar.AttemptDir) == "" {
 		return []string{campaign.ReasonSemanticFailed}, nil
 	}
-	semRes, err := semantic.ValidatePath(ar.AttemptDir, semantic.Options{RulesPath: parsed.Spec.Semantic.RulesPath})
+	semRes, err := semantic.ValidatePath(ar.AttemptDir, semantic.Options{
+		RulesPath:     parsed.Spec.Semantic.RulesPath,
+		Rules:         parsed.Spec.Semantic.Rules,
+		SnapshotRules: true,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1599,15 +1827,27 @@ func collectExamProofGateErrors(parsed campaign.ParsedSpec, feedbackSummary atte
 	return []string{codeCampaignAttemptNotValid}
 }
 
-func (r Runner) collectOracleGateErrors(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, feedbackSummary attemptFeedbackSummary, infraDetected bool) ([]string, error) {
+// oracleGateEvidence carries oracle results that are recorded on the mission gate attempt.
+type oracleGateEvidence struct {
+	votes       []campaign.OracleVoteV1
+	rubricScore *float64
+	claims      *campaign.ClaimCountsV1
+}
+
+func (r Runner) collectOracleGateErrors(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, feedbackSummary attemptFeedbackSummary, infraDetected bool) ([]string, oracleGateEvidence, error) {
 	if parsed.Spec.PromptMode != campaign.PromptModeExam || infraDetected || !feedbackSummary.HasValidProof {
-		return nil, nil
+		return nil, oracleGateEvidence{}, nil
 	}
 	oracleVerdict, oracleErr := r.evaluateOracleForAttempt(parsed, flowID, missionID, ar)
+	evidence := oracleGateEvidence{votes: oracleEnsembleVotes(oracleVerdict.Ensemble), claims: oracleVerdict.Claims}
+	if oracleVerdict.Rubric != nil {
+		score := oracleVerdict.Rubric.Score
+		evidence.rubricScore = &score
+	}
 	if oracleErr != nil {
-		return []string{campaign.ReasonOracleEvalError}, nil
+		return []string{campaign.ReasonOracleEvalError}, evidence, nil
 	}
-	return oracleFailureReasonCodes(parsed.Spec.Evaluation.OraclePolicy, oracleVerdict), nil
+	return oracleFailureReasonCodes(parsed.Spec.Evaluation.OraclePolicy, oracleVerdict), evidence, nil
 }
 
 func finalizeMissionFlowGate(parsed campaign.ParsedSpec, ar *campaign.AttemptStatusV1, ma campaign.MissionGateAttemptV1, gateErrors []string, infraDetected bool) missionFlowGateEvaluation {
@@ -1661,10 +1901,39 @@ func (r Runner) evaluateOracleForAttempt(parsed campaign.ParsedSpec, flowID stri
 		_, _ = r.writeOracleVerdict(parsed, flowID, missionID, ar, oraclePath, out)
 		return out, nil
 	}
-	if parsed.Spec.Evaluation.Evaluator.Kind == campaign.EvaluatorKindBuiltin {
-		return r.evaluateOracleBuiltinForAttempt(parsed, flowID, missionID, ar, oraclePath)
+	var rubric *oracleRubricInfo
+	if len(parsed.Spec.Evaluation.Rubric) > 0 {
+		rubric = r.evaluateOracleRubricForAttempt(parsed, flowID, missionID, ar, oraclePath)
+	}
+	switch {
+	case len(parsed.Spec.Evaluation.Evaluators) > 0:
+		out = r.evaluateOracleEnsembleForAttempt(parsed, flowID, missionID, ar, oraclePath)
+	case parsed.Spec.Evaluation.Evaluator.Kind == "" && rubric != nil:
+		out = rubricGateOutput(parsed, rubric)
+	default:
+		out = r.evaluateOracleWithEvaluator(parsed, parsed.Spec.Evaluation.Evaluator, flowID, missionID, ar, oraclePath)
+	}
+	out.Rubric = rubric
+	if _, err := r.writeOracleVerdict(parsed, flowID, missionID, ar, oraclePath, out); err != nil {
+		return out, err
+	}
+	claims, err := r.writeClaimVerification(parsed, flowID, missionID, ar, oraclePath, out)
+	if err != nil {
+		return out, err
+	}
+	out.Claims = claims
+	return out, nil
+}
+
+// evaluateOracleWithEvaluator runs a single evaluator; errors are folded into the returned output.
+func (r Runner) evaluateOracleWithEvaluator(parsed campaign.ParsedSpec, ev campaign.EvaluatorSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, oraclePath string) oracleEvaluatorOutput {
+	switch ev.Kind {
+	case campaign.EvaluatorKindBuiltin, campaign.EvaluatorKindBuiltinCompare:
+		return r.evaluateOracleBuiltinForAttempt(parsed, ev, ar, oraclePath)
+	case campaign.EvaluatorKindLLMJudge:
+		return evaluateOracleLLMJudgeForAttempt(parsed, ev, ar, oraclePath)
 	}
-	return r.evaluateOracleCommandForAttempt(parsed, flowID, missionID, ar, oraclePath)
+	return evaluateOracleCommandForAttempt(parsed, ev, flowID, missionID, ar, oraclePath)
 }
 
 func defaultOracleEvaluatorOutput() oracleEvaluatorOutput {
@@ -1683,14 +1952,13 @@ func resolveOraclePathForMission(parsed campaign.ParsedSpec, missionID string) (
 	return oraclePath, oraclePath != ""
 }
 
-func (r Runner) evaluateOracleBuiltinForAttempt(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, oraclePath string) (oracleEvaluatorOutput, error) {
+func (r Runner) evaluateOracleBuiltinForAttempt(parsed campaign.ParsedSpec, ev campaign.EvaluatorSpec, ar *campaign.AttemptStatusV1, oraclePath string) oracleEvaluatorOutput {
 	out := defaultOracleEvaluatorOutput()
-	verdict, err := r.evaluateBuiltinOracle(parsed, missionID, ar, oraclePath)
+	verdict, err := r.evaluateBuiltinOracle(parsed, ev, ar, oraclePath)
 	if err != nil {
 		out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
 		out.Message = trimText(err.Error(), 1024)
-		_, _ = r.writeOracleVerdict(parsed, flowID, missionID, ar, oraclePath, out)
-		return out, nil
+		return out
 	}
 	verdict.PolicyDisposition = parsed.Spec.Evaluation.OraclePolicy.FormatMismatch
 	if !verdict.OK && oracle.AllMismatchesClass(verdict.Mismatches, oracle.MismatchFormat) &&
@@ -1699,29 +1967,24 @@ func (r Runner) evaluateOracleBuiltinForAttempt(parsed campaign.ParsedSpec, flow
 	}
 	verdict.ReasonCodes = dedupeSortedStrings(verdict.ReasonCodes)
 	verdict.Warnings = dedupeSortedStrings(verdict.Warnings)
-	if _, err := r.writeOracleVerdict(parsed, flowID, missionID, ar, oraclePath, verdict); err != nil {
-		return verdict, err
-	}
-	return verdict, nil
+	return verdict
 }
 
-func (r Runner) evaluateOracleCommandForAttempt(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, oraclePath string) (oracleEvaluatorOutput, error) {
+func evaluateOracleCommandForAttempt(parsed campaign.ParsedSpec, ev campaign.EvaluatorSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, oraclePath string) oracleEvaluatorOutput {
 	out := defaultOracleEvaluatorOutput()
-	cmdArgs := normalizedOracleEvaluatorCommand(parsed.Spec.Evaluation.Evaluator.Command)
+	cmdArgs := normalizedOracleEvaluatorCommand(ev.Command)
 	if len(cmdArgs) == 0 {
 		out.ReasonCodes = []string{campaign.ReasonOracleEvaluator}
 		out.Message = "missing evaluation.evaluator.command"
-		_, _ = r.writeOracleVerdict(parsed, flowID, missionID, ar, oraclePath, out)
-		return out, nil
+		return out
 	}
 	stdout, stderr, timedOut, err := runOracleEvaluatorCommand(parsed, flowID, missionID, ar, oraclePath, cmdArgs)
 	if err != nil {
 		out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
 		out.Message = oracleEvaluatorRunErrorMessage(stderr, err, timedOut)
-		_, _ = r.writeOracleVerdict(parsed, flowID, missionID, ar, oraclePath, out)
-		return out, nil
+		return out
 	}
-	return r.parseAndPersistOracleEvaluatorOutput(parsed, flowID, missionID, ar, oraclePath, stdout, out)
+	return parseOracleEvaluatorOutput(parsed, stdout, out)
 }
 
 func normalizedOracleEvaluatorCommand(command []string) []string {
@@ -1775,13 +2038,12 @@ func oracleEvaluatorRunErrorMessage(stderr []byte, runErr error, timedOut bool)
 	return msg
 }
 
-func (r Runner) parseAndPersistOracleEvaluatorOutput(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, oraclePath string, stdout []byte, fallback oracleEvaluatorOutput) (oracleEvaluatorOutput, error) {
+func parseOracleEvaluatorOutput(parsed campaign.ParsedSpec, stdout []byte, fallback oracleEvaluatorOutput) oracleEvaluatorOutput {
 	var evaluatorOut oracleEvaluatorOutput
 	if err := json.Unmarshal(stdout, &evaluatorOut); err != nil {
 		fallback.ReasonCodes = []string{campaign.ReasonOracleEvalError}
 		fallback.Message = "oracle evaluator output must be valid json"
-		_, _ = r.writeOracleVerdict(parsed, flowID, missionID, ar, oraclePath, fallback)
-		return fallback, nil
+		return fallback
 	}
 	evaluatorOut.ReasonCodes = dedupeSortedStrings(evaluatorOut.ReasonCodes)
 	evaluatorOut.Message = trimText(strings.TrimSpace(evaluatorOut.Message), 1024)
@@ -1794,13 +2056,10 @@ func (r Runner) parseAndPersistOracleEvaluatorOutput(parsed campaign.ParsedSpec,
 	if !evaluatorOut.OK && len(evaluatorOut.ReasonCodes) == 0 {
 		evaluatorOut.ReasonCodes = []string{campaign.ReasonOracleEvalFailed}
 	}
-	if _, err := r.writeOracleVerdict(parsed, flowID, missionID, ar, oraclePath, evaluatorOut); err != nil {
-		return evaluatorOut, err
-	}
-	return evaluatorOut, nil
+	return evaluatorOut
 }
 
-func (r Runner) evaluateBuiltinOracle(parsed campaign.ParsedSpec, missionID string, ar *campaign.AttemptStatusV1, oraclePath string) (oracleEvaluatorOutput, error) {
+func (r Runner) evaluateBuiltinOracle(parsed campaign.ParsedSpec, ev campaign.EvaluatorSpec, ar *campaign.AttemptStatusV1, oraclePath string) (oracleEvaluatorOutput, error) {
 	out := oracleEvaluatorOutput{
 		OK:                false,
 		PolicyDisposition: parsed.Spec.Evaluation.OraclePolicy.FormatMismatch,
@@ -1811,13 +2070,30 @@ func (r Runner) evaluateBuiltinOracle(parsed campaign.ParsedSpec, missionID stri
 		out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
 		return out, err
 	}
-	proof, err := loadOracleProofFromAttempt(ar.AttemptDir)
-	if err != nil {
-		out.Message = trimText(err.Error(), 1024)
-		out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
-		return out, nil
+	var verdict oracle.Verdict
+	if ev.Kind == campaign.EvaluatorKindBuiltinCompare {
+		expected, ok := oracle.ExpectedAnswer(file)
+		if !ok {
+			out.Message = "oracle file has no expected answer"
+			out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
+			return out, nil
+		}
+		answer, err := loadOracleAnswerFromAttempt(ar.AttemptDir)
+		if err != nil {
+			out.Message = trimText(err.Error(), 1024)
+			out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
+			return out, nil
+		}
+		verdict = oracle.EvaluateExpected(expected, answer, parsed.Spec.Evaluation.OraclePolicy.Mode)
+	} else {
+		proof, err := loadOracleProofFromAttempt(ar.AttemptDir)
+		if err != nil {
+			out.Message = trimText(err.Error(), 1024)
+			out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
+			return out, nil
+		}
+		verdict = oracle.EvaluateProof(file, proof, parsed.Spec.Evaluation.OraclePolicy.Mode)
 	}
-	verdict := oracle.EvaluateProof(file, proof, parsed.Spec.Evaluation.OraclePolicy.Mode)
 	out.OK = verdict.OK
 	out.Message = trimText(strings.TrimSpace(verdict.Message), 1024)
 	out.Mismatches = verdict.Mismatches
@@ -1859,6 +2135,37 @@ func loadOracleProofFromAttempt(attemptDir string) (map[string]any, error) {
 	return proof, nil
 }
 
+// loadOracleAnswerFromAttempt returns the attempt answer for the builtin comparator: resultJson when
+// present, else result parsed as JSON when it is JSON, else the raw result text.
+func loadOracleAnswerFromAttempt(attemptDir string) (any, error) {
+	raw, err := os.ReadFile(filepath.Join(strings.TrimSpace(attemptDir), artifacts.FeedbackJSON))
+	if err != nil {
+		return nil, err
+	}
+	var fb struct {
+		Result     string          `json:"result"`
+		ResultJSON json.RawMessage `json:"resultJson"`
+	}
+	if err := json.Unmarshal(raw, &fb); err != nil {
+		return nil, fmt.Errorf("feedback json is invalid")
+	}
+	var answer any
+	if len(fb.ResultJSON) > 0 {
+		if err := json.Unmarshal(fb.ResultJSON, &answer); err != nil {
+			return nil, fmt.Errorf("feedback.resultJson must be valid json")
+		}
+		return answer, nil
+	}
+	trimmed := strings.TrimSpace(fb.Result)
+	if trimmed == "" {
+		return nil, fmt.Errorf("feedback.result is empty")
+	}
+	if err := json.Unmarshal([]byte(trimmed), &answer); err == nil {
+		return answer, nil
+	}
+	return fb.Result, nil
+}
+
 func readAttemptFeedbackSummary(attemptDir string) (attemptFeedbackSummary, error) {
 	path := filepath.Join(strings.TrimSpace(attemptDir), artifacts.FeedbackJSON)
 	raw, err := os.ReadFile(path)
@@ -1869,11 +2176,12 @@ func readAttemptFeedbackSummary(attemptDir string) (attemptFeedbackSummary, erro
 		OK         *bool           `json:"ok"`
 		Result     string          `json:"result"`
 		ResultJSON json.RawMessage `json:"resultJson"`
+		Confidence *float64        `json:"confidence"`
 	}
 	if err := json.Unmarshal(raw, &fb); err != nil {
 		return attemptFeedbackSummary{}, err
 	}
-	out := attemptFeedbackSummary{Present: true}
+	out := attemptFeedbackSummary{Present: true, Confidence: fb.Confidence}
 	if fb.OK != nil {
 		out.OKKnown = true
 		out.OK = *fb.OK
@@ -2040,6 +2348,16 @@ func oracleFailureReasonCodes(policy campaign.OraclePolicySpec, verdict oracleEv
 	}
 }
 
+func oracleVerdictEvaluatorKind(parsed campaign.ParsedSpec, out oracleEvaluatorOutput) string {
+	if out.Ensemble != nil {
+		return campaign.EvaluatorKindEnsemble
+	}
+	if parsed.Spec.Evaluation.Evaluator.Kind == "" && out.Rubric != nil {
+		return campaign.EvaluatorKindRubric
+	}
+	return parsed.Spec.Evaluation.Evaluator.Kind
+}
+
 func (r Runner) writeOracleVerdict(parsed campaign.ParsedSpec, flowID string, missionID string, ar *campaign.AttemptStatusV1, oraclePath string, out oracleEvaluatorOutput) (string, error) {
 	if ar == nil || strings.TrimSpace(ar.AttemptDir) == "" {
 		return "", nil
@@ -2056,7 +2374,7 @@ func (r Runner) writeOracleVerdict(parsed campaign.ParsedSpec, flowID string, mi
 		AttemptID:         ar.AttemptID,
 		AttemptDir:        ar.AttemptDir,
 		OraclePath:        oraclePath,
-		EvaluatorKind:     parsed.Spec.Evaluation.Evaluator.Kind,
+		EvaluatorKind:     oracleVerdictEvaluatorKind(parsed, out),
 		EvaluatorCmd:      append([]string{}, parsed.Spec.Evaluation.Evaluator.Command...),
 		PromptMode:        parsed.Spec.PromptMode,
 		OK:                out.OK,
@@ -2065,6 +2383,9 @@ func (r Runner) writeOracleVerdict(parsed campaign.ParsedSpec, flowID string, mi
 		Mismatches:        out.Mismatches,
 		PolicyDisposition: strings.TrimSpace(strings.ToLower(out.PolicyDisposition)),
 		Warnings:          dedupeSortedStrings(out.Warnings),
+		Judge:             out.Judge,
+		Ensemble:          out.Ensemble,
+		Rubric:            out.Rubric,
 		ExecutedAt:        now.Format(time.RFC3339Nano),
 	}
 	path := filepath.Join(ar.AttemptDir, oracleVerdictFileName)
@@ -2135,6 +2456,15 @@ func appendCampaignFlowSuiteOptionalArgs(args []string, flow campaign.FlowSpec)
 	if strings.TrimSpace(flow.Runner.TimeoutStart) != "" {
 		args = append(args, "--timeout-start", strings.TrimSpace(flow.Runner.TimeoutStart))
 	}
+	if flow.Runner.DiskQuotaMb > 0 {
+		args = append(args, "--disk-quota-mb", strconv.FormatInt(flow.Runner.DiskQuotaMb, 10))
+	}
+	if flow.Runner.Home.Mode == home.ModeEphemeral {
+		args = append(args, "--home", home.ModeEphemeral)
+		if flow.Runner.Home.Template != "" {
+			args = append(args, "--home-template", flow.Runner.Home.Template)
+		}
+	}
 	args = appendCampaignFlowSuiteResultChannelArgs(args, flow)
 	if flow.Runner.Strict != nil {
 		args = append(args, "--strict="+strconv.FormatBool(*flow.Runner.Strict))
@@ -2159,11 +2489,24 @@ func appendCampaignFlowSuiteOptionalArgs(args []string, flow campaign.FlowSpec)
 	}
 	if len(flow.Runner.Command) > 0 {
 		args = append(args, "--")
-		args = append(args, flow.Runner.Command...)
+		args = append(args, campaignFlowRunnerCommand(flow)...)
 	}
 	return args
 }
 
+// campaignFlowRunnerCommand is the flow's runner argv; agent service flows run this zcl binary's
+// `http agent`/`ws agent` when it is known instead of whichever zcl is on PATH.
+func campaignFlowRunnerCommand(flow campaign.FlowSpec) []string {
+	if !campaign.IsAgentServiceRunner(flow.Runner.Type) {
+		return flow.Runner.Command
+	}
+	exe := resolveSuiteRunZCLExecutable()
+	if exe == "" {
+		return flow.Runner.Command
+	}
+	return append([]string{exe}, flow.Runner.Command[1:]...)
+}
+
 func appendCampaignFlowSuiteResultChannelArgs(args []string, flow campaign.FlowSpec) []string {
 	switch flow.Runner.Finalization.ResultChannel.Kind {
 	case campaign.ResultChannelFileJSON:
@@ -2197,6 +2540,10 @@ func buildCampaignFlowSuiteEnv(parsed campaign.ParsedSpec, flow campaign.FlowSpe
 	if strings.TrimSpace(flow.Runner.Cwd.Retain) != "" {
 		env[suiteRunEnvRunnerCwdRetain] = strings.TrimSpace(flow.Runner.Cwd.Retain)
 	}
+	if len(flow.Runner.MCPServers) > 0 {
+		raw, _ := json.Marshal(flow.Runner.MCPServers)
+		env[suiteRunEnvMCPServers] = string(raw)
+	}
 	if kind, sourcePath, templatePath := flowPromptMetadata(parsed, flow); kind != "" {
 		env["ZCL_PROMPT_SOURCE_KIND"] = kind
 		if sourcePath != "" {
@@ -2218,6 +2565,27 @@ func buildCampaignFlowSuiteEnv(parsed campaign.ParsedSpec, flow campaign.FlowSpe
 	if flow.Runner.MCP.ShutdownOnComplete {
 		env["ZCL_MCP_SHUTDOWN_ON_COMPLETE"] = "1"
 	}
+	if campaign.HasToolPolicyRules(flow.ToolPolicy) {
+		if raw, err := json.Marshal(flow.ToolPolicy); err == nil {
+			env[campaign.ToolPolicyEnvKey] = string(raw)
+		}
+	}
+	if flow.Runner.Type == campaign.RunnerTypeDocker {
+		for k, v := range container.Env(campaign.DockerContainerSpec(flow)) {
+			env[k] = v
+		}
+	}
+	if flow.Runner.Type == campaign.RunnerTypeSSH {
+		for k, v := range remote.Env(campaign.SSHRemoteSpec(flow)) {
+			env[k] = v
+		}
+	}
+	if flow.Runner.Type == campaign.RunnerTypeClaudeCLI {
+		env[claudecli.EnvKey] = "1"
+	}
+	for k, v := range limits.Env(campaign.RunnerLimits(flow)) {
+		env[k] = v
+	}
 	return env
 }
 
@@ -2403,16 +2771,15 @@ func flowPromptMetadata(parsed campaign.ParsedSpec, flow campaign.FlowSpec) (kin
 }
 
 func readAttemptReport(attemptDir string) (schema.AttemptReportJSONV1, error) {
+	if err := store.RollForwardDirTx(attemptDir, artifacts.AttemptFinishTx); err != nil {
+		return schema.AttemptReportJSONV1{}, err
+	}
 	path := filepath.Join(attemptDir, artifacts.AttemptReportJSON)
 	raw, err := os.ReadFile(path)
 	if err != nil {
 		return schema.AttemptReportJSONV1{}, err
 	}
-	var rep schema.AttemptReportJSONV1
-	if err := json.Unmarshal(raw, &rep); err != nil {
-		return schema.AttemptReportJSONV1{}, err
-	}
-	return rep, nil
+	return schema.DecodeAttemptReport(raw)
 }
 
 func trimText(s string, max int) string {
@@ -2463,7 +2830,11 @@ func formatCampaignResultsMarkdown(sum campaign.SummaryV1) string {
 	fmt.Fprintf(&b, "- verifiedMissionsOk: `%d`\n", sum.VerifiedMissionsOK)
 	fmt.Fprintf(&b, "- mismatchCount: `%d`\n", sum.MismatchCount)
 	fmt.Fprintf(&b, "- gatesPassed: `%d`\n", sum.GatesPassed)
-	fmt.Fprintf(&b, "- gatesFailed: `%d`\n\n", sum.GatesFailed)
+	fmt.Fprintf(&b, "- gatesFailed: `%d`\n", sum.GatesFailed)
+	if len(sum.Overrides) > 0 {
+		fmt.Fprintf(&b, "- verdictOverrides: `%d` (see Verdict Overrides)\n", len(sum.Overrides))
+	}
+	fmt.Fprintf(&b, "\n")
 	fmt.Fprintf(&b, "- failureBuckets: infra_failed=%d oracle_failed=%d mission_failed=%d\n\n",
 		sum.FailureBuckets.InfraFailed,
 		sum.FailureBuckets.OracleFailed,
@@ -2491,6 +2862,26 @@ func formatCampaignResultsMarkdown(sum campaign.SummaryV1) string {
 		for _, f := range sum.Flows {
 			fmt.Fprintf(&b, "- `%s` (%s): attempts=%d valid=%d invalid=%d skipped=%d infra_failed=%d oracle_failed=%d mission_failed=%d\n",
 				f.FlowID, f.RunnerType, f.AttemptsTotal, f.Valid, f.Invalid, f.Skipped, f.InfraFailed, f.OracleFailed, f.MissionFailed)
+			if c := f.Claims; c != nil {
+				fmt.Fprintf(&b, "  - claims: confirmed=%d refuted=%d unverifiable=%d refuted_attempts=%d/%d\n",
+					c.Confirmed, c.Refuted, c.Unverifiable, c.RefutedAttempts, c.Attempts)
+			}
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+	if rv := sum.Reviews; rv != nil {
+		fmt.Fprintf(&b, "## Manual Reviews\n\n")
+		fmt.Fprintf(&b, "- reviewed=%d confirmed=%d overturned=%d pending=%d\n", rv.Reviewed, rv.Confirmed, rv.Overturned, rv.Pending)
+		for _, a := range rv.Attempts {
+			fmt.Fprintf(&b, "  - `%s` `%s` gate=%v review=%v reviewer=%s\n", a.MissionID, a.FlowID, a.GateOK, a.ReviewOK, a.Reviewer)
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+	if len(sum.Overrides) > 0 {
+		fmt.Fprintf(&b, "## Verdict Overrides\n\n")
+		for _, o := range sum.Overrides {
+			fmt.Fprintf(&b, "- `%d:%s` `%s` attempt=%s gate=%v override=%v by=%s at=%s\n", o.MissionIndex, o.MissionID, o.FlowID, o.AttemptID, o.GateOK, o.OK, o.By, o.CreatedAt)
+			fmt.Fprintf(&b, "  - reason: %s\n", strings.Join(strings.Fields(o.Reason), " "))
 		}
 		fmt.Fprintf(&b, "\n")
 	}
@@ -2834,6 +3225,9 @@ func printCampaignHelp(w io.Writer) {
   zcl campaign report [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--format json,md] [--allow-invalid] [--force] [--json]
   zcl campaign publish-check [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--force] [--json]
   zcl campaign doctor --spec <campaign.(yaml|yml|json)> [--json]
+  zcl campaign regrade export --campaign-id <id> --out <dir> [--seed N] [--json]
+  zcl campaign regrade merge --campaign-id <id> --verdicts <path> [--mapping <path>] [--json]
+  zcl campaign export [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--out <dir>] [--json]
 `)
 }
 
@@ -2863,7 +3257,11 @@ func printCampaignResumeHelp(w io.Writer) {
 
 func printCampaignStatusHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
-  zcl campaign status --campaign-id <id> [--out-root .zcl] [--json]
+  zcl campaign status --campaign-id <id> [--out-root .zcl] [--stale-after-ms N] [--json]
+
+Notes:
+  - Unfinished attempts of the campaign's suite runs whose attempt.heartbeat.json is older than
+    --stale-after-ms (default 60000) are reported as likely hung (staleAttempts in JSON).
 `)
 }
 
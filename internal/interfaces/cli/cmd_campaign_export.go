@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+type campaignExportResult struct {
+	OK              bool     `json:"ok"`
+	CampaignID      string   `json:"campaignId"`
+	RunID           string   `json:"runId"`
+	AttestationPath string   `json:"attestationPath"`
+	SubjectSHA256   string   `json:"subjectSha256"`
+	OutDir          string   `json:"outDir,omitempty"`
+	Exported        []string `json:"exported,omitempty"`
+}
+
+func (r Runner) runCampaignExport(args []string) int {
+	fs := flag.NewFlagSet("campaign export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	campaignID := fs.String("campaign-id", "", "campaign id (required unless --spec is provided)")
+	spec := fs.String("spec", "", "campaign spec file (.json|.yaml|.yml) (optional alternative to --campaign-id)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	outDir := fs.String("out", "", "also copy summary, report, RESULTS.md and attestation into this directory")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("campaign export: invalid flags")
+	}
+	if *help {
+		printCampaignExportHelp(r.Stdout)
+		return 0
+	}
+	st, exit, ok := r.resolveCampaignRunState(*campaignID, *spec, *outRoot, *jsonOut, "campaign export", printCampaignExportHelp)
+	if !ok {
+		return exit
+	}
+	attPath, att, err := campaign.WriteAttestation(st, r.Version)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": campaign export: %s\n", err.Error())
+		return 1
+	}
+	res := campaignExportResult{
+		OK:              true,
+		CampaignID:      st.CampaignID,
+		RunID:           st.RunID,
+		AttestationPath: attPath,
+		SubjectSHA256:   att.Subject[0].Digest["sha256"],
+	}
+	if dir := strings.TrimSpace(*outDir); dir != "" {
+		exported, err := exportCampaignFiles(dir, []string{
+			campaign.SummaryPath(st.OutRoot, st.CampaignID),
+			campaign.ReportPath(st.OutRoot, st.CampaignID),
+			campaign.ResultsMDPath(st.OutRoot, st.CampaignID),
+			attPath,
+		})
+		if err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": campaign export: %s\n", err.Error())
+			return 1
+		}
+		res.OutDir = dir
+		res.Exported = exported
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "campaign export: OK campaign=%s attestation=%s summarySha256=%s\n", res.CampaignID, res.AttestationPath, res.SubjectSHA256)
+	for _, p := range res.Exported {
+		fmt.Fprintf(r.Stdout, "exported: %s\n", p)
+	}
+	return 0
+}
+
+// exportCampaignFiles copies the files that exist into dir; absent optional artifacts are skipped.
+func exportCampaignFiles(dir string, paths []string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, src := range paths {
+		raw, err := os.ReadFile(src)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		dst := filepath.Join(dir, filepath.Base(src))
+		if err := store.WriteFileAtomic(dst, raw); err != nil {
+			return nil, err
+		}
+		out = append(out, dst)
+	}
+	return out, nil
+}
+
+func printCampaignExportHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl campaign export [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--out <dir>] [--out-root .zcl] [--json]
+
+Writes an in-toto Statement (SLSA provenance v1 predicate) for campaign.summary.json to
+campaign.attestation.intoto.json, pinning the spec and suite sha256, the zcl and native runtime
+versions, and each flow's comparability key. --out additionally copies the publishable artifacts.
+`)
+}
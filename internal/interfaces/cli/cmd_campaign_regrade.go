@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+func (r Runner) runCampaignRegrade(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printCampaignRegradeHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "export":
+		return r.runCampaignRegradeExport(args[1:])
+	case "merge":
+		return r.runCampaignRegradeMerge(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown campaign regrade subcommand %q\n", args[0])
+		printCampaignRegradeHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runCampaignRegradeExport(args []string) int {
+	fs := flag.NewFlagSet("campaign regrade export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	campaignID := fs.String("campaign-id", "", "campaign id (required unless --spec is provided)")
+	spec := fs.String("spec", "", "campaign spec file (.json|.yaml|.yml) (optional alternative to --campaign-id)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	outDir := fs.String("out", "", "directory for the grader-facing "+artifacts.RegradeItemsJSON+" (required)")
+	seed := fs.Int64("seed", 0, "fix the blind item order (default random)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("campaign regrade export: invalid flags")
+	}
+	if *help {
+		printCampaignRegradeHelp(r.Stdout)
+		return 0
+	}
+	if strings.TrimSpace(*outDir) == "" {
+		printCampaignRegradeHelp(r.Stderr)
+		return r.failUsage("campaign regrade export: missing --out")
+	}
+	st, exit, ok := r.resolveCampaignRunState(*campaignID, *spec, *outRoot, *jsonOut, "campaign regrade export", printCampaignRegradeHelp)
+	if !ok {
+		return exit
+	}
+	exp, err := campaign.BuildRegradeExport(campaign.RegradeExportInput{State: st, Models: campaignRunnerModels(st), Seed: *seed})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	itemsPath := filepath.Join(*outDir, artifacts.RegradeItemsJSON)
+	mappingPath := campaign.RegradeMappingPath(st.OutRoot, st.CampaignID, exp.Items.ExportID)
+	for _, dir := range []string{*outDir, filepath.Dir(mappingPath)} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+			return 1
+		}
+	}
+	if err := store.WriteJSONAtomic(itemsPath, exp.Items); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	if err := store.WriteJSONAtomic(mappingPath, exp.Mapping); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	if *jsonOut {
+		return r.writeJSON(map[string]any{
+			"ok":          true,
+			"campaignId":  st.CampaignID,
+			"runId":       st.RunID,
+			"exportId":    exp.Items.ExportID,
+			"items":       len(exp.Items.Items),
+			"itemsPath":   itemsPath,
+			"mappingPath": mappingPath,
+			"skipped":     exp.Skipped,
+		})
+	}
+	fmt.Fprintf(r.Stdout, "campaign regrade export: OK exportId=%s items=%d skipped=%d\n", exp.Items.ExportID, len(exp.Items.Items), len(exp.Skipped))
+	return 0
+}
+
+func (r Runner) runCampaignRegradeMerge(args []string) int {
+	fs := flag.NewFlagSet("campaign regrade merge", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	campaignID := fs.String("campaign-id", "", "campaign id (required unless --spec is provided)")
+	spec := fs.String("spec", "", "campaign spec file (.json|.yaml|.yml) (optional alternative to --campaign-id)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	verdictsPath := fs.String("verdicts", "", "graded verdicts file keyed by blindId (required)")
+	mappingPath := fs.String("mapping", "", "mapping file (default: the one written by export for the verdicts exportId)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("campaign regrade merge: invalid flags")
+	}
+	if *help {
+		printCampaignRegradeHelp(r.Stdout)
+		return 0
+	}
+	if strings.TrimSpace(*verdictsPath) == "" {
+		printCampaignRegradeHelp(r.Stderr)
+		return r.failUsage("campaign regrade merge: missing --verdicts")
+	}
+	st, exit, ok := r.resolveCampaignRunState(*campaignID, *spec, *outRoot, *jsonOut, "campaign regrade merge", printCampaignRegradeHelp)
+	if !ok {
+		return exit
+	}
+	var verdicts campaign.RegradeVerdictsV1
+	if err := readRegradeJSON(*verdictsPath, &verdicts); err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+			return 1
+		}
+		fmt.Fprintf(r.Stderr, codeInvalidJSON+": %s\n", err.Error())
+		return 2
+	}
+	mp := strings.TrimSpace(*mappingPath)
+	if mp == "" {
+		mp = campaign.RegradeMappingPath(st.OutRoot, st.CampaignID, ids.SanitizeComponent(verdicts.ExportID))
+	}
+	var mapping campaign.RegradeMappingV1
+	if err := readRegradeJSON(mp, &mapping); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	if mapping.CampaignID != st.CampaignID {
+		return r.failUsage("campaign regrade merge: mapping campaignId does not match --campaign-id")
+	}
+	rep, err := campaign.MergeRegrade(mapping, verdicts, r.Now().UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return r.failUsage("campaign regrade merge: " + err.Error())
+	}
+	if err := store.WriteJSONAtomic(campaign.RegradeReportPath(st.OutRoot, st.CampaignID), rep); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	if *jsonOut {
+		return r.writeJSON(rep)
+	}
+	fmt.Fprintf(r.Stdout, "campaign regrade merge: OK exportId=%s graded=%d flips=%d ungraded=%d\n", rep.ExportID, rep.Graded, rep.Flips, len(rep.Ungraded))
+	return 0
+}
+
+// campaignRunnerModels returns the runner models named in the campaign spec, so exports can strip them.
+func campaignRunnerModels(st campaign.RunStateV1) []string {
+	if strings.TrimSpace(st.SpecPath) == "" {
+		return nil
+	}
+	parsed, err := campaign.ParseSpecFile(st.SpecPath)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, f := range parsed.Spec.Flows {
+		if m := strings.TrimSpace(f.Runner.Model); m != "" {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func readRegradeJSON(path string, v any) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func printCampaignRegradeHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl campaign regrade export [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] --out <dir> [--out-root .zcl] [--seed N] [--json]
+  zcl campaign regrade merge [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] --verdicts <path> [--mapping <path>] [--out-root .zcl] [--json]
+`)
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runConfig(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printConfigHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "get":
+		return r.runConfigGet(args[1:])
+	case "set":
+		return r.runConfigSet(args[1:])
+	case "lint":
+		return r.runConfigLint(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown config subcommand %q\n", args[0])
+		printConfigHelp(r.Stderr)
+		return 2
+	}
+}
+
+// splitLeadingPositionals peels positional args off the front so `config get <key> --json` parses
+// the same as `config get --json <key>`.
+func splitLeadingPositionals(args []string, n int) ([]string, []string) {
+	var pos []string
+	for len(args) > 0 && len(pos) < n && !strings.HasPrefix(args[0], "-") {
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+	return pos, args
+}
+
+func (r Runner) runConfigGet(args []string) int {
+	pos, rest := splitLeadingPositionals(args, 1)
+	fs := flag.NewFlagSet("config get", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	outRoot := fs.String("out-root", "", "out-root flag to resolve as the command would")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(rest); err != nil {
+		return r.failUsage("config get: invalid flags")
+	}
+	if *help {
+		printConfigHelp(r.Stdout)
+		return 0
+	}
+	pos = append(pos, fs.Args()...)
+	if len(pos) > 1 {
+		printConfigHelp(r.Stderr)
+		return r.failUsage("config get: at most one key")
+	}
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": config get: %s\n", err.Error())
+		return 1
+	}
+	if len(pos) == 0 {
+		all := config.GetAll(m)
+		if *jsonOut {
+			return r.writeJSON(struct {
+				Values []config.KeyValueV1 `json:"values"`
+			}{all})
+		}
+		for _, kv := range all {
+			fmt.Fprintf(r.Stdout, "%s=%s (%s)\n", kv.Key, formatConfigValue(kv.Value), kv.Source)
+		}
+		return 0
+	}
+	kv, err := config.GetKey(m, pos[0])
+	if err != nil {
+		return r.failUsage("config get: " + err.Error())
+	}
+	if *jsonOut {
+		return r.writeJSON(kv)
+	}
+	fmt.Fprintf(r.Stdout, "%s (%s)\n", formatConfigValue(kv.Value), kv.Source)
+	return 0
+}
+
+func (r Runner) runConfigSet(args []string) int {
+	pos, rest := splitLeadingPositionals(args, 2)
+	fs := flag.NewFlagSet("config set", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	global := fs.Bool("global", false, "write the global config (~/.zcl/config.json) instead of zcl.config.json")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(rest); err != nil {
+		return r.failUsage("config set: invalid flags")
+	}
+	if *help {
+		printConfigHelp(r.Stdout)
+		return 0
+	}
+	pos = append(pos, fs.Args()...)
+	if len(pos) != 2 {
+		printConfigHelp(r.Stderr)
+		return r.failUsage("config set: require <key> <value>")
+	}
+	path := config.DefaultProjectConfigPath
+	if *global {
+		p, err := config.DefaultGlobalConfigPath()
+		if err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": config set: %s\n", err.Error())
+			return 1
+		}
+		path = p
+	}
+	res, err := config.SetKey(path, *global, pos[0], pos[1])
+	if err != nil {
+		return r.failUsage("config set: " + err.Error())
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "config set: OK %s=%s (%s)\n", res.Key, formatConfigValue(res.Value), res.ConfigPath)
+	return 0
+}
+
+func (r Runner) runConfigLint(args []string) int {
+	fs := flag.NewFlagSet("config lint", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("config lint: invalid flags")
+	}
+	if *help {
+		printConfigHelp(r.Stdout)
+		return 0
+	}
+	res := config.Lint()
+	exit := 0
+	if !res.OK {
+		exit = 1
+	}
+	if *jsonOut {
+		if code := r.writeJSON(res); code != 0 {
+			return code
+		}
+		return exit
+	}
+	for _, is := range res.Issues {
+		key := ""
+		if is.Key != "" {
+			key = " " + is.Key
+		}
+		fmt.Fprintf(r.Stdout, "%s: %s%s: %s\n", is.Severity, is.Path, key, is.Message)
+	}
+	fmt.Fprintf(r.Stdout, "config lint: OK=%v issues=%d\n", res.OK, len(res.Issues))
+	return exit
+}
+
+func formatConfigValue(v any) string {
+	switch x := v.(type) {
+	case []string:
+		return strings.Join(x, ",")
+	case string:
+		return x
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func printConfigHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl config get [<key>] [--out-root .zcl] [--json]
+  zcl config set <key> <value> [--global] [--json]
+  zcl config lint [--json]
+
+Keys:
+  `+strings.Join(config.ConfigKeys(), "\n  ")+`
+
+Notes:
+  - get resolves the same merged config commands use (flag > env > profile > project > global >
+    default) and reports the source of each value; origin is one of default|flag|env|profile|file.
+  - set edits zcl.config.json (or ~/.zcl/config.json with --global), keeps unrelated fields and
+    refuses values that would make the file invalid. profile is read-only.
+  - lint checks both config files (unknown keys are warnings) and the merged view; exit 1 on errors.
+`)
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/encrypt"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// installIdentityLoader lets kernel readers decrypt .enc artifacts with the configured identity.
+// Loading is deferred to the first encrypted read so identityCommand (KMS) only runs when needed.
+func installIdentityLoader() {
+	store.SetIdentityLoader(func() ([]age.Identity, error) {
+		m, err := config.LoadMerged("")
+		if err != nil {
+			return nil, err
+		}
+		return m.Encryption.LoadIdentities()
+	})
+}
+
+// encryptFinishedAttempt seals prompt and raw runner IO when encryption.recipient is configured.
+// The out root is derived from the attempt layout (<outRoot>/runs/<runId>/attempts/<attemptId>).
+func encryptFinishedAttempt(attemptDir string) error {
+	m, err := config.LoadMerged("")
+	if err != nil {
+		return err
+	}
+	rcpt, err := m.Encryption.ParsedRecipient()
+	if err != nil || rcpt == nil {
+		return err
+	}
+	outRoot := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(attemptDir))))
+	_, err = encrypt.Attempt(outRoot, attemptDir, rcpt)
+	return err
+}
+
+func (r Runner) runEncryption(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printEncryptionHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "keygen":
+		return r.runEncryptionKeygen(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown encryption subcommand %q\n", args[0])
+		printEncryptionHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runEncryptionKeygen(args []string) int {
+	fs := flag.NewFlagSet("encryption keygen", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	out := fs.String("out", "", "write the identity file here (0600; refuses to overwrite); default prints it to stdout")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("encryption keygen: invalid flags")
+	}
+	if *help {
+		printEncryptionHelp(r.Stdout)
+		return 0
+	}
+	id, err := store.GenerateIdentity()
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": encryption keygen: %s\n", err.Error())
+		return 1
+	}
+	recipient := id.Recipient().String()
+	// The age identity file format, so the file also works with the age CLI.
+	body := fmt.Sprintf("# created: %s\n# public key: %s\n%s\n", r.Now().UTC().Format("2006-01-02T15:04:05Z"), recipient, id.String())
+
+	path := strings.TrimSpace(*out)
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": encryption keygen: %s\n", err.Error())
+			return 1
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": encryption keygen: %s\n", err.Error())
+			return 1
+		}
+		_, werr := f.WriteString(body)
+		if cerr := f.Close(); werr == nil {
+			werr = cerr
+		}
+		if werr != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": encryption keygen: %s\n", werr.Error())
+			return 1
+		}
+	}
+	if *jsonOut {
+		return r.writeJSON(struct {
+			OK           bool   `json:"ok"`
+			Recipient    string `json:"recipient"`
+			RecipientID  string `json:"recipientId"`
+			IdentityFile string `json:"identityFile,omitempty"`
+		}{OK: true, Recipient: recipient, RecipientID: store.RecipientID(id.Recipient()), IdentityFile: path})
+	}
+	if path == "" {
+		fmt.Fprint(r.Stdout, body)
+		return 0
+	}
+	fmt.Fprintf(r.Stdout, "encryption keygen: OK identityFile=%s\nrecipient: %s\n", path, recipient)
+	return 0
+}
+
+func printEncryptionHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl encryption keygen [--out <identity.key>] [--json]
+
+Encryption at rest for prompt.txt, runner.stdout.log, runner.stderr.log and capture files:
+  config:  "encryption": {"recipient": "age1...", "identityFile": "~/.zcl/artifacts.key"}
+           "identityCommand": "<shell command printing an AGE-SECRET-KEY-1 line>" fetches the key from a secret manager instead
+           a plugin recipient ("age1<plugin>1...", identity "AGE-PLUGIN-...") wraps keys via age-plugin-<plugin> (KMS, HSM)
+  env:     ZCL_ENCRYPTION_RECIPIENT, ZCL_ENCRYPTION_IDENTITY (file), ZCL_ENCRYPTION_IDENTITY_CMD
+
+Files are standard age files (age-encryption.org/v1). Writers only need the recipient. report, review and regrade need an identity for encrypted
+attempts (`+codeDecryptFailed+` otherwise); validate checks encrypted files without one.
+`)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+)
+
+type evalExportResultJSON struct {
+	OK         bool   `json:"ok"`
+	CampaignID string `json:"campaignId"`
+	RunID      string `json:"runId"`
+	campaign.EvalExportResult
+}
+
+func (r Runner) runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	format := fs.String("format", "", "target format: "+strings.Join(campaign.EvalExportFormats(), "|")+" (required)")
+	campaignID := fs.String("campaign-id", "", "campaign id (required unless --spec is provided)")
+	spec := fs.String("spec", "", "campaign spec file (.json|.yaml|.yml) (optional alternative to --campaign-id)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	outDir := fs.String("out", "", "output directory (default <outRoot>/campaigns/<campaignId>/export/<format>)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("export: invalid flags")
+	}
+	if *help {
+		printExportHelp(r.Stdout)
+		return 0
+	}
+	f := strings.ToLower(strings.TrimSpace(*format))
+	if f != campaign.EvalExportFormatInspectAI && f != campaign.EvalExportFormatHELM {
+		printExportHelp(r.Stderr)
+		return r.failUsage("export: --format must be " + strings.Join(campaign.EvalExportFormats(), "|"))
+	}
+	st, exit, ok := r.resolveCampaignRunState(*campaignID, *spec, *outRoot, *jsonOut, "export", printExportHelp)
+	if !ok {
+		return exit
+	}
+	dir := strings.TrimSpace(*outDir)
+	if dir == "" {
+		dir = campaign.EvalExportDir(st.OutRoot, st.CampaignID, f)
+	}
+	res, err := campaign.WriteEvalExport(campaign.EvalExportInput{
+		State:      st,
+		Format:     f,
+		Models:     campaignRunnerModelsByFlow(st),
+		ZCLVersion: r.Version,
+		OutDir:     dir,
+	})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": export: %s\n", err.Error())
+		return 1
+	}
+	if *jsonOut {
+		return r.writeJSON(evalExportResultJSON{OK: true, CampaignID: st.CampaignID, RunID: st.RunID, EvalExportResult: res})
+	}
+	fmt.Fprintf(r.Stdout, "export: OK format=%s campaign=%s flows=%d samples=%d passed=%d out=%s\n", res.Format, st.CampaignID, res.Flows, res.Samples, res.Passed, res.OutDir)
+	return 0
+}
+
+// campaignRunnerModelsByFlow maps flowId to the runner model named in the campaign spec.
+func campaignRunnerModelsByFlow(st campaign.RunStateV1) map[string]string {
+	out := map[string]string{}
+	if strings.TrimSpace(st.SpecPath) == "" {
+		return out
+	}
+	parsed, err := campaign.ParseSpecFile(st.SpecPath)
+	if err != nil {
+		return out
+	}
+	for _, f := range parsed.Spec.Flows {
+		if m := strings.TrimSpace(f.Runner.Model); m != "" {
+			out[f.FlowID] = m
+		}
+	}
+	return out
+}
+
+func printExportHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl export --format inspect-ai|helm [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--out <dir>] [--out-root .zcl] [--json]
+
+Maps every flow attempt of the latest campaign run and its mission gate verdict into an external
+eval format, one model per flow (the runner model from the spec, else zcl/<runnerType>):
+  - inspect-ai: <campaignId>_<flowId>.json Inspect AI eval logs (score zcl_gate: C|I, accuracy).
+  - helm: <campaignId>_<flowId>/ HELM run dirs (run_spec, scenario_state, per_instance_stats, stats; stat zcl_gate_ok).
+`)
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/httpagent"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+type httpAgentOptions struct {
+	url          string
+	header       http.Header
+	pollInterval time.Duration
+	marker       string
+}
+
+// runHTTPAgent is the runner of runner.type=http_agent flows: it sends the attempt's prompt to
+// the agent service, copies streamed output to stdout (runner IO) and prints the service's
+// mission result behind the result marker for the stdout_json result channel.
+func (r Runner) runHTTPAgent(args []string) int {
+	opts, exit, done := r.parseHTTPAgentOptions(args)
+	if done {
+		return exit
+	}
+	env, err := trace.EnvFromProcess()
+	if err != nil {
+		printHTTPAgentHelp(r.Stderr)
+		return r.failUsage("http agent: missing ZCL attempt context (need ZCL_* env)")
+	}
+	promptPath := strings.TrimSpace(os.Getenv("ZCL_PROMPT_PATH"))
+	if promptPath == "" {
+		promptPath = filepath.Join(env.OutDirAbs, artifacts.PromptTXT)
+	}
+	prompt, err := os.ReadFile(promptPath)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": http agent: read prompt: %s\n", err.Error())
+		return 1
+	}
+	ctx, cancel, timedOut, exit, done := r.prepareHTTPProxyContext(r.Now(), env.OutDirAbs)
+	if done {
+		return exit
+	}
+	if cancel != nil {
+		defer cancel()
+	}
+	if timedOut {
+		fmt.Fprintf(r.Stderr, codeTimeout+": attempt deadline exceeded\n")
+		return 1
+	}
+
+	client := httpagent.Client{Header: opts.header, PollInterval: opts.pollInterval, Output: r.Stdout}
+	result, err := client.Run(ctx, opts.url, httpagent.MissionRequest{
+		SchemaVersion: schema.ArtifactSchemaV1,
+		RunID:         env.RunID,
+		SuiteID:       env.SuiteID,
+		MissionID:     env.MissionID,
+		AttemptID:     env.AttemptID,
+		AgentID:       env.AgentID,
+		Prompt:        string(prompt),
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Fprintf(r.Stderr, codeTimeout+": attempt deadline exceeded\n")
+			return 1
+		}
+		fmt.Fprintf(r.Stderr, codeIO+": http agent: %s\n", err.Error())
+		return 1
+	}
+	fmt.Fprintf(r.Stdout, "%s%s\n", opts.marker, result)
+	return 0
+}
+
+func (r Runner) parseHTTPAgentOptions(args []string) (httpAgentOptions, int, bool) {
+	fs := flag.NewFlagSet("http agent", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	endpoint := fs.String("url", "", "agent service endpoint (required; http(s)://...)")
+	var headers stringListFlag
+	fs.Var(&headers, "header", "request header `Name: value` (repeatable; ${VAR} expands from env)")
+	pollMs := fs.Int64("poll-interval-ms", httpagent.DefaultPollIntervalMs, "pause between status polls of an accepted mission")
+	marker := fs.String("result-marker", campaign.DefaultResultChannelMarker, "stdout prefix of the mission result line")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return httpAgentOptions{}, r.failUsage("http agent: invalid flags"), true
+	}
+	if *help {
+		printHTTPAgentHelp(r.Stdout)
+		return httpAgentOptions{}, 0, true
+	}
+	spec, err := httpagent.Normalize(httpagent.Spec{URL: *endpoint, PollIntervalMs: *pollMs})
+	if err != nil {
+		printHTTPAgentHelp(r.Stderr)
+		return httpAgentOptions{}, r.failUsage("http agent: " + err.Error()), true
+	}
+	h := http.Header{}
+	for _, raw := range headers {
+		name, value, err := httpagent.ParseHeader(raw)
+		if err != nil {
+			return httpAgentOptions{}, r.failUsage("http agent: " + err.Error()), true
+		}
+		h.Add(name, os.ExpandEnv(value))
+	}
+	return httpAgentOptions{
+		url:          spec.URL,
+		header:       h,
+		pollInterval: time.Duration(spec.PollIntervalMs) * time.Millisecond,
+		marker:       strings.TrimSpace(*marker),
+	}, 0, false
+}
+
+func printHTTPAgentHelp(w io.Writer) {
+	printHTTPHelp(w)
+	fmt.Fprint(w, `
+Notes:
+  - Requires ZCL attempt context (ZCL_* env; the prompt is read from ZCL_PROMPT_PATH).
+  - POSTs {schemaVersion,runId,suiteId,missionId,attemptId,agentId,prompt} to --url.
+  - The service answers with the mission result JSON (200), a text/event-stream of output events
+    ended by "event: result", or 202 Accepted with a status URL (Location or statusUrl) polled
+    until it stops answering 202.
+  - Streamed output goes to stdout; the result is printed as one <result-marker><json> line.
+`)
+}
@@ -23,6 +23,8 @@ func (r Runner) runHTTP(args []string) int {
 	switch args[0] {
 	case "proxy":
 		return r.runHTTPProxy(args[1:])
+	case "agent":
+		return r.runHTTPAgent(args[1:])
 	default:
 		fmt.Fprintf(r.Stderr, codeUsage+": unknown http subcommand %q\n", args[0])
 		printHTTPHelp(r.Stderr)
@@ -156,6 +158,7 @@ func (r Runner) waitHTTPProxy(ctx context.Context, waitFn func() error) int {
 func printHTTPHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
   zcl http proxy --upstream <url> [--listen 127.0.0.1:0] [--max-requests N] [--json]
+  zcl http agent --url <url> [--header 'Name: value']... [--poll-interval-ms N] [--result-marker <prefix>]
 `)
 }
 
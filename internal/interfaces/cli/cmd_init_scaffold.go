@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+type initScaffoldResult struct {
+	OK      bool     `json:"ok"`
+	Kind    string   `json:"kind"` // suite|campaign
+	Adapter string   `json:"adapter"`
+	Path    string   `json:"path"`
+	Created []string `json:"created"`
+	Next    []string `json:"next"`
+}
+
+// initScaffoldPrompter asks for values not given as flags when stdin is a terminal.
+type initScaffoldPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func (p *initScaffoldPrompter) ask(question string, def string) string {
+	if p == nil {
+		return def
+	}
+	fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	line, _ := p.in.ReadString('\n')
+	if v := strings.TrimSpace(line); v != "" {
+		return v
+	}
+	return def
+}
+
+func (r Runner) newInitScaffoldPrompter(interactive bool) *initScaffoldPrompter {
+	if !interactive || !isCharDevice(os.Stdin) {
+		return nil
+	}
+	return &initScaffoldPrompter{in: bufio.NewReader(os.Stdin), out: r.Stderr}
+}
+
+func (r Runner) runInitSuite(args []string) int {
+	fs := flag.NewFlagSet("init suite", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	adapter := fs.String("adapter", "", "runner adapter: "+strings.Join(campaign.ScaffoldAdapters(), "|")+" (default process_cmd)")
+	suiteID := fs.String("suite-id", "", "suite id (default my-suite)")
+	var missions stringListFlag
+	fs.Var(&missions, "mission", "mission id to scaffold (repeatable; default mission-1)")
+	out := fs.String("out", artifacts.SuiteJSON, "suite file to write")
+	force := fs.Bool("force", false, "overwrite an existing file")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("init suite: invalid flags")
+	}
+	if *help {
+		printInitHelp(r.Stdout)
+		return 0
+	}
+	p := r.newInitScaffoldPrompter(!*jsonOut && strings.TrimSpace(*adapter) == "")
+	if strings.TrimSpace(*adapter) == "" {
+		*adapter = p.ask("Runner adapter ("+strings.Join(campaign.ScaffoldAdapters(), "|")+")", campaign.RunnerTypeProcessCmd)
+	}
+	if strings.TrimSpace(*suiteID) == "" {
+		*suiteID = p.ask("Suite id", "my-suite")
+	}
+	if len(missions) == 0 && p != nil {
+		missions = strings.Fields(strings.ReplaceAll(p.ask("Mission ids (comma-separated)", "mission-1"), ",", " "))
+	}
+
+	runner, err := campaign.ScaffoldRunner(*adapter)
+	if err != nil {
+		return r.failUsage("init suite: " + err.Error())
+	}
+	body, err := campaign.ScaffoldSuite(runner.Type, *suiteID, missions)
+	if err != nil {
+		return r.failUsage("init suite: " + err.Error())
+	}
+	path := strings.TrimSpace(*out)
+	if exit, ok := r.writeInitScaffoldFile("init suite", path, body, *force); !ok {
+		return exit
+	}
+	if _, err := suite.ParseFile(path); err != nil {
+		_ = os.Remove(path)
+		fmt.Fprintf(r.Stderr, codeIO+": init suite: generated suite does not parse: %s\n", err.Error())
+		return 1
+	}
+	runCmd := append([]string{"zcl", "suite", "run", "--file", path}, campaign.ScaffoldSuiteRunArgs(runner)...)
+	runCmd = append(runCmd, "--json")
+	if len(runner.Command) > 0 {
+		runCmd = append(append(runCmd, "--"), runner.Command...)
+	}
+	return r.writeInitScaffoldResult(initScaffoldResult{
+		OK:      true,
+		Kind:    "suite",
+		Adapter: runner.Type,
+		Path:    path,
+		Created: []string{path},
+		Next:    []string{"edit the mission prompts in " + path, strings.Join(runCmd, " ")},
+	}, *jsonOut)
+}
+
+func (r Runner) runInitCampaign(args []string) int {
+	fs := flag.NewFlagSet("init campaign", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	adapter := fs.String("adapter", "", "runner adapter: "+strings.Join(campaign.ScaffoldAdapters(), "|")+" (default process_cmd)")
+	campaignID := fs.String("campaign-id", "", "campaign id (default my-campaign)")
+	suiteFile := fs.String("suite", artifacts.SuiteJSON, "suite file the flow runs (relative to --out; scaffolded too when missing)")
+	out := fs.String("out", "campaign.yaml", "campaign spec to write")
+	force := fs.Bool("force", false, "overwrite an existing campaign spec")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("init campaign: invalid flags")
+	}
+	if *help {
+		printInitHelp(r.Stdout)
+		return 0
+	}
+	p := r.newInitScaffoldPrompter(!*jsonOut && strings.TrimSpace(*adapter) == "")
+	if strings.TrimSpace(*adapter) == "" {
+		*adapter = p.ask("Runner adapter ("+strings.Join(campaign.ScaffoldAdapters(), "|")+")", campaign.RunnerTypeProcessCmd)
+	}
+	if strings.TrimSpace(*campaignID) == "" {
+		*campaignID = p.ask("Campaign id", "my-campaign")
+	}
+
+	runner, err := campaign.ScaffoldRunner(*adapter)
+	if err != nil {
+		return r.failUsage("init campaign: " + err.Error())
+	}
+	body, err := campaign.ScaffoldCampaign(runner.Type, *campaignID, strings.TrimSpace(*suiteFile))
+	if err != nil {
+		return r.failUsage("init campaign: " + err.Error())
+	}
+	specPath := strings.TrimSpace(*out)
+	suitePath := strings.TrimSpace(*suiteFile)
+	if !filepath.IsAbs(suitePath) {
+		suitePath = filepath.Join(filepath.Dir(specPath), suitePath)
+	}
+
+	var created []string
+	if _, err := os.Stat(suitePath); os.IsNotExist(err) {
+		suiteBody, err := campaign.ScaffoldSuite(runner.Type, *campaignID, nil)
+		if err != nil {
+			return r.failUsage("init campaign: " + err.Error())
+		}
+		if exit, ok := r.writeInitScaffoldFile("init campaign", suitePath, suiteBody, false); !ok {
+			return exit
+		}
+		created = append(created, suitePath)
+	}
+	if exit, ok := r.writeInitScaffoldFile("init campaign", specPath, body, *force); !ok {
+		return exit
+	}
+	created = append(created, specPath)
+	if _, err := campaign.ParseSpecFile(specPath); err != nil {
+		for _, path := range created {
+			_ = os.Remove(path)
+		}
+		fmt.Fprintf(r.Stderr, codeUsage+": init campaign: generated spec does not lint: %s\n", err.Error())
+		return 2
+	}
+	return r.writeInitScaffoldResult(initScaffoldResult{
+		OK:      true,
+		Kind:    "campaign",
+		Adapter: runner.Type,
+		Path:    specPath,
+		Created: created,
+		Next: []string{
+			"edit the mission prompts in " + suitePath,
+			"zcl campaign lint --spec " + specPath + " --json",
+			"zcl campaign run --spec " + specPath + " --json",
+		},
+	}, *jsonOut)
+}
+
+func (r Runner) writeInitScaffoldFile(cmd string, path string, body []byte, force bool) (int, bool) {
+	if path == "" {
+		return r.failUsage(cmd + ": --out is required"), false
+	}
+	if _, err := os.Stat(path); err == nil && !force {
+		return r.failUsage(fmt.Sprintf("%s: %s already exists (use --force to overwrite)", cmd, path)), false
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1, false
+	}
+	if err := store.WriteFileAtomic(path, body); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1, false
+	}
+	return 0, true
+}
+
+func (r Runner) writeInitScaffoldResult(res initScaffoldResult, jsonOut bool) int {
+	if jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "init %s: OK adapter=%s wrote %s\n", res.Kind, res.Adapter, strings.Join(res.Created, ", "))
+	for _, step := range res.Next {
+		fmt.Fprintf(r.Stdout, "next: %s\n", step)
+	}
+	return 0
+}
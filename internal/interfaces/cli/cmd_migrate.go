@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/migrate"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	from := fs.Int("from", 0, "current artifact schema version (required)")
+	to := fs.Int("to", 0, "target artifact schema version (required)")
+	runID := fs.String("run-id", "", "run id to migrate (required)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	dryRun := fs.Bool("dry-run", false, "list what would change without writing")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("migrate: invalid flags")
+	}
+	if *help {
+		printMigrateHelp(r.Stdout)
+		return 0
+	}
+	if *from == 0 || *to == 0 {
+		printMigrateHelp(r.Stderr)
+		return r.failUsage("migrate: require --from and --to")
+	}
+	if !migrate.Supported(*from, *to) {
+		return r.failUsage(fmt.Sprintf("migrate: no migration from schema %d to %d", *from, *to))
+	}
+
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	res, err := migrate.Run(migrate.Opts{OutRoot: m.OutRoot, RunID: *runID, From: *from, To: *to, DryRun: *dryRun})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": migrate: %s\n", err.Error())
+		return 2
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	migrated := 0
+	for _, f := range res.Files {
+		if f.Action == migrate.ActionMigrated {
+			migrated++
+		}
+	}
+	fmt.Fprintf(r.Stdout, "migrate: OK runId=%s from=%d to=%d migrated=%d current=%d dryRun=%v\n", res.RunID, res.From, res.To, migrated, len(res.Files)-migrated, res.DryRun)
+	return 0
+}
+
+func printMigrateHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl migrate --from 1 --to 2 --run-id <runId> [--out-root .zcl] [--dry-run] [--json]
+
+Migrations:
+  1 -> 2  attempt.report.json: top-level failureCodeHistogram folded into metrics.failuresByCode
+
+Notes:
+  - report/validate read both versions, so migrated and unmigrated runs stay comparable.
+  - Re-running is a no-op for files already at --to. Signed campaigns need re-signing afterwards.
+`)
+}
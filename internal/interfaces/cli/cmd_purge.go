@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/purge"
+)
+
+func (r Runner) runPurge(args []string) int {
+	fs := flag.NewFlagSet("purge", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	attemptDir := fs.String("attempt", "", "attempt directory to purge (required)")
+	pattern := fs.String("pattern", "", "regex of the content to remove (required)")
+	replacement := fs.String("replacement", purge.DefaultReplacement, "text written in place of each match")
+	reason := fs.String("reason", "", "why the content is purged; recorded in purge.jsonl (required)")
+	confirm := fs.Bool("confirm", false, "rewrite the artifacts (without it, only preview the matches)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("purge: invalid flags")
+	}
+	if *help {
+		printPurgeHelp(r.Stdout)
+		return 0
+	}
+	if strings.TrimSpace(*attemptDir) == "" {
+		printPurgeHelp(r.Stderr)
+		return r.failUsage("purge: missing --attempt")
+	}
+	res, err := purge.Run(r.Now(), *attemptDir, purge.Opts{
+		Pattern:     *pattern,
+		Replacement: *replacement,
+		Reason:      *reason,
+		Confirm:     *confirm,
+	})
+	if err != nil {
+		return r.failUsage("purge: " + err.Error())
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	rec := res.Record
+	switch {
+	case rec.Matches == 0:
+		fmt.Fprintf(r.Stdout, "purge: NOTHING attempt=%s (no matches)\n", rec.AttemptID)
+	case !res.Confirmed:
+		for _, f := range rec.Files {
+			fmt.Fprintf(r.Stdout, "%s: %d\n", f.Path, f.Matches)
+		}
+		fmt.Fprintf(r.Stdout, "purge: PREVIEW attempt=%s files=%d matches=%d (rerun with --confirm to rewrite)\n", rec.AttemptID, len(rec.Files), rec.Matches)
+	default:
+		fmt.Fprintf(r.Stdout, "purge: OK attempt=%s files=%d matches=%d traceRechained=%v\n", rec.AttemptID, len(rec.Files), rec.Matches, rec.TraceRechained)
+	}
+	return 0
+}
+
+func printPurgeHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl purge --attempt <attemptDir> --pattern <regex> --reason <text> [--replacement "[PURGED]"] [--confirm] [--json]
+
+Notes:
+  - Without --confirm nothing is written: the matches per file are previewed.
+  - With --confirm every text artifact of the attempt (including .gz copies; binary and .enc files are skipped) is rewritten with each match replaced, and a record (reason, pattern sha256, per-file sha256 before/after) is appended to purge.jsonl. The pattern itself is never stored.
+  - A rewritten tool.calls.jsonl is re-chained and attempt.report.json integrity.traceChainHead moves with it; JSON/JSONL artifacts that would stop parsing abort the purge untouched.
+  - Signatures and sync manifests made before the purge no longer match; re-run zcl sign / zcl sync.
+`)
+}
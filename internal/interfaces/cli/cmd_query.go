@@ -12,6 +12,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/archive"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/top"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
@@ -65,7 +67,13 @@ type runIndexRow struct {
 	LatestAttemptID        string `json:"latestAttemptId,omitempty"`
 	LatestAttemptMission   string `json:"latestAttemptMission,omitempty"`
 	LatestAttemptStartedAt string `json:"latestAttemptStartedAt,omitempty"`
+	CompactedAt            string `json:"compactedAt,omitempty"`
 	RunDir                 string `json:"runDir"`
+	// ArchivedAt/Archive are set for runs moved into cold storage by `zcl archive`; RunDir is empty.
+	ArchivedAt string `json:"archivedAt,omitempty"`
+	Archive    string `json:"archive,omitempty"`
+	// StaleAttempts are unfinished attempts whose heartbeat is older than --stale-after-ms.
+	StaleAttempts []top.StaleAttemptV1 `json:"staleAttempts,omitempty"`
 }
 
 func (r Runner) runAttemptList(args []string) int {
@@ -209,6 +217,7 @@ func (r Runner) runRunsList(args []string) int {
 	suiteID := fs.String("suite", "", "filter by suiteId")
 	status := fs.String("status", attemptStatusAny, "filter by run status: any|ok|fail|missing_feedback")
 	limit := fs.Int("limit", 0, "max rows (0 = all)")
+	staleAfterMs := fs.Int64("stale-after-ms", top.DefaultStaleAfter.Milliseconds(), "flag unfinished attempts whose heartbeat is older than this as likely hung")
 	jsonOut := fs.Bool("json", false, "print JSON output")
 	help := fs.Bool("help", false, "show help")
 
@@ -236,12 +245,21 @@ func (r Runner) runRunsList(args []string) int {
 	if *limit < 0 {
 		return r.failUsage("runs list: --limit must be >= 0")
 	}
+	if *staleAfterMs <= 0 {
+		return r.failUsage("runs list: --stale-after-ms must be > 0")
+	}
 
 	rows, err := collectRunRows(m.OutRoot, strings.TrimSpace(*suiteID))
 	if err != nil {
 		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
 		return 1
 	}
+	now := r.Now()
+	for i := range rows {
+		if rows[i].RunDir != "" {
+			rows[i].StaleAttempts = top.StaleAttempts(rows[i].RunDir, now, time.Duration(*staleAfterMs)*time.Millisecond)
+		}
+	}
 	if statusFilter != attemptStatusAny {
 		filtered := make([]runIndexRow, 0, len(rows))
 		for _, row := range rows {
@@ -308,6 +326,11 @@ func collectRunRows(outRoot string, suiteFilter string) ([]runIndexRow, error) {
 		}
 		rows = append(rows, buildRunRow(runDir, runMeta, attemptsByRun[runMeta.RunID]))
 	}
+	archived, err := archivedRunRows(absOutRoot, suiteFilter)
+	if err != nil {
+		return nil, err
+	}
+	rows = append(rows, archived...)
 
 	sort.Slice(rows, func(i, j int) bool {
 		ti, _ := parseTS(rows[i].CreatedAt)
@@ -320,6 +343,33 @@ func collectRunRows(outRoot string, suiteFilter string) ([]runIndexRow, error) {
 	return rows, nil
 }
 
+func archivedRunRows(absOutRoot string, suiteFilter string) ([]runIndexRow, error) {
+	idx, err := archive.ReadIndex(absOutRoot)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]runIndexRow, 0, len(idx.Runs))
+	for _, e := range idx.Runs {
+		if suiteFilter != "" && e.SuiteID != suiteFilter {
+			continue
+		}
+		row := runIndexRow{
+			RunID:                e.RunID,
+			SuiteID:              e.SuiteID,
+			CreatedAt:            e.CreatedAt,
+			AttemptsTotal:        e.AttemptsTotal,
+			OKTotal:              e.OKTotal,
+			FailTotal:            e.FailTotal,
+			MissingFeedbackTotal: e.MissingFeedbackTotal,
+			ArchivedAt:           e.ArchivedAt,
+			Archive:              filepath.Join(absOutRoot, filepath.FromSlash(e.Path)),
+		}
+		row.Status = runStatus(row)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
 func groupAttemptRowsByRunID(rows []attemptIndexRow) map[string][]attemptIndexRow {
 	grouped := make(map[string][]attemptIndexRow, len(rows))
 	for _, row := range rows {
@@ -330,10 +380,11 @@ func groupAttemptRowsByRunID(rows []attemptIndexRow) map[string][]attemptIndexRo
 
 func buildRunRow(runDir string, runMeta schema.RunJSONV1, attempts []attemptIndexRow) runIndexRow {
 	row := runIndexRow{
-		RunID:     runMeta.RunID,
-		SuiteID:   runMeta.SuiteID,
-		CreatedAt: runMeta.CreatedAt,
-		RunDir:    runDir,
+		RunID:       runMeta.RunID,
+		SuiteID:     runMeta.SuiteID,
+		CreatedAt:   runMeta.CreatedAt,
+		CompactedAt: runMeta.CompactedAt,
+		RunDir:      runDir,
 	}
 	for _, a := range attempts {
 		row.AttemptsTotal++
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/secretscan"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runRedact(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printRedactHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "verify":
+		return r.runRedactVerify(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown redact subcommand %q\n", args[0])
+		printRedactHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runRedactVerify(args []string) int {
+	fs := flag.NewFlagSet("redact verify", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	runID := fs.String("run-id", "", "run id to verify (required)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("redact verify: invalid flags")
+	}
+	if *help {
+		printRedactHelp(r.Stdout)
+		return 0
+	}
+
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	res, err := secretscan.Verify(secretscan.Opts{OutRoot: m.OutRoot, RunID: *runID}, r.Now())
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": redact verify: %s\n", err.Error())
+		return 2
+	}
+	if *jsonOut {
+		if exit := r.writeJSON(res); exit != 0 {
+			return exit
+		}
+	} else if res.OK {
+		fmt.Fprintf(r.Stdout, "redact verify: OK runId=%s files=%d ruleset=%s\n", res.RunID, res.FilesScanned, res.Ruleset.SHA256)
+	} else {
+		for _, h := range res.Hits {
+			fmt.Fprintf(r.Stderr, "%s: %s:%d %s\n", codeSecretLeak, h.Path, h.Line, h.Rule)
+		}
+		fmt.Fprintf(r.Stderr, "redact verify: FAIL runId=%s hits=%d\n", res.RunID, len(res.Hits))
+	}
+	if res.OK {
+		return 0
+	}
+	return 2
+}
+
+func printRedactHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl redact verify --run-id <runId> [--out-root .zcl] [--json]
+
+Notes:
+  - Re-scans every stored artifact of the run with the current redaction rules (built-ins, extraRules, rules files, allowlists) and writes redaction.verify.json with the residual hits and the rule set fingerprint.
+  - Campaigns with output.requireRedactionVerify: true fail publish-check unless every flow run has a passing verification recorded under the current rule set after its last artifact change.
+`)
+}
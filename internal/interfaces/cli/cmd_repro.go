@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/repro"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runRepro(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printReproHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "bundle":
+		return r.runReproBundle(args[1:])
+	case "run":
+		return r.runReproRun(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown repro subcommand %q\n", args[0])
+		printReproHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runReproBundle(args []string) int {
+	fs := flag.NewFlagSet("repro bundle", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	runID := fs.String("run-id", "", "run id to bundle (required)")
+	out := fs.String("out", "", "bundle directory to create (required; must be empty or missing)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("repro bundle: invalid flags")
+	}
+	if *help {
+		printReproHelp(r.Stdout)
+		return 0
+	}
+	if strings.TrimSpace(*runID) == "" || strings.TrimSpace(*out) == "" {
+		printReproHelp(r.Stderr)
+		return r.failUsage("repro bundle: require --run-id and --out")
+	}
+	snap, m, err := reproConfigSnapshot(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": repro bundle: %s\n", err.Error())
+		return 1
+	}
+	res, err := repro.Bundle(repro.Opts{
+		OutRoot:    m.OutRoot,
+		RunID:      *runID,
+		OutDir:     *out,
+		Config:     snap,
+		EnvPolicy:  native.DefaultEnvPolicy(),
+		ZCLVersion: r.Version,
+		Now:        r.Now(),
+	})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": repro bundle: %s\n", err.Error())
+		return 2
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "repro bundle: OK runId=%s bundle=%s files=%d\n", res.RunID, res.BundleDir, res.Files)
+	return 0
+}
+
+// runReproRun re-executes a bundle through the regular suite run path; the re-run always gets a
+// fresh run id, and the JSON summary on stdout is the suite run's own.
+func (r Runner) runReproRun(args []string) int {
+	fs := flag.NewFlagSet("repro run", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	outRoot := fs.String("out-root", "", "project output root for the re-run (default from config/env, else .zcl)")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("repro run: invalid flags")
+	}
+	if *help {
+		printReproHelp(r.Stdout)
+		return 0
+	}
+	if fs.NArg() != 1 {
+		printReproHelp(r.Stderr)
+		return r.failUsage("repro run: require exactly one bundle dir")
+	}
+	snap, m, err := reproConfigSnapshot(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": repro run: %s\n", err.Error())
+		return 1
+	}
+	plan, err := repro.Plan(fs.Arg(0), m.OutRoot, snap)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": repro run: %s\n", err.Error())
+		return 2
+	}
+	for _, w := range plan.Warnings {
+		fmt.Fprintf(r.Stderr, "repro run: warning: %s\n", w)
+	}
+	return r.runSuiteRunWithEnv(plan.Args, plan.Env)
+}
+
+func reproConfigSnapshot(outRoot string) (repro.ConfigSnapshotV1, config.Merged, error) {
+	m, err := config.LoadMerged(outRoot)
+	if err != nil {
+		return repro.ConfigSnapshotV1{}, config.Merged{}, err
+	}
+	redaction, err := config.LoadRedactionPolicyMerged()
+	if err != nil {
+		return repro.ConfigSnapshotV1{}, config.Merged{}, err
+	}
+	snap := repro.ConfigSnapshotV1{
+		OutRoot:              m.OutRoot,
+		Profile:              m.Profile,
+		RuntimeStrategyChain: m.RuntimeStrategyChain,
+		CampaignState:        m.CampaignState,
+		EncryptionRecipient:  m.Encryption.Recipient,
+	}
+	for _, rule := range redaction.Rules {
+		snap.RedactionRules = append(snap.RedactionRules, rule.ID+"="+rule.Regex)
+	}
+	for _, a := range redaction.Allowlists {
+		snap.RedactionRules = append(snap.RedactionRules, "allowlist:"+a.ID+"="+strings.Join(a.Patterns, "|"))
+	}
+	return snap, m, nil
+}
+
+func printReproHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl repro bundle --run-id <runId> --out <dir> [--out-root .zcl] [--json]
+  zcl repro run <bundleDir> [--out-root .zcl]
+
+Bundle contents:
+  suite.json           suite snapshot of the run
+  run.invocation.json  exact suite run argv, cwd and extra attempt env (secrets redacted)
+  campaign/<spec>      campaign spec, when the run belongs to a campaign
+  config.json          merged config that affects execution
+  env.policy.json      native runtime env allow/block policy
+  repro.json           manifest with sha256 per file
+
+Notes:
+  - Only runs started by `+"`zcl suite run`"+` (directly or via a campaign) can be bundled.
+  - repro run verifies hashes, points --file at the bundled suite, drops --run-id and re-runs
+    with a fresh run id. Config drift is reported on stderr; a drifted runtime strategy chain is
+    pinned back to the bundled one.
+`)
+}
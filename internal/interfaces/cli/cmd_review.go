@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/review"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+const reviewPromptMaxChars = 4096
+
+type reviewEvidence struct {
+	Prompt               string                 `json:"prompt,omitempty"`
+	Feedback             *schema.FeedbackJSONV1 `json:"feedback,omitempty"`
+	DecisionTags         []string               `json:"decisionTags,omitempty"`
+	FailureCodeHistogram map[string]int64       `json:"failureCodeHistogram,omitempty"`
+	Files                []string               `json:"files,omitempty"`
+}
+
+type reviewNextOutput struct {
+	OK         bool                        `json:"ok"`
+	CampaignID string                      `json:"campaignId"`
+	RunID      string                      `json:"runId"`
+	Done       bool                        `json:"done"`
+	Pending    int                         `json:"pending"`
+	Reviewed   int                         `json:"reviewed"`
+	Next       *campaign.ReviewCandidateV1 `json:"next,omitempty"`
+	Evidence   *reviewEvidence             `json:"evidence,omitempty"`
+	RecordWith string                      `json:"recordWith,omitempty"`
+}
+
+func (r Runner) runReview(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printReviewHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "next":
+		return r.runReviewNext(args[1:])
+	case "record":
+		return r.runReviewRecord(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown review subcommand %q\n", args[0])
+		printReviewHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runReviewNext(args []string) int {
+	fs := flag.NewFlagSet("review next", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	campaignID := fs.String("campaign-id", "", "campaign id (required unless --spec is provided)")
+	spec := fs.String("spec", "", "campaign spec file (.json|.yaml|.yml) (optional alternative to --campaign-id)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	all := fs.Bool("all", false, "queue every unreviewed attempt, not only ones with failure signals")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("review next: invalid flags")
+	}
+	if *help {
+		printReviewHelp(r.Stdout)
+		return 0
+	}
+	st, exit, ok := r.resolveCampaignRunState(*campaignID, *spec, *outRoot, *jsonOut, "review next", printReviewHelp)
+	if !ok {
+		return exit
+	}
+	pending, reviewed := campaign.ReviewQueue(st.MissionGates, *all)
+	out := reviewNextOutput{
+		OK:         true,
+		CampaignID: st.CampaignID,
+		RunID:      st.RunID,
+		Done:       len(pending) == 0,
+		Pending:    len(pending),
+		Reviewed:   reviewed,
+	}
+	if len(pending) > 0 {
+		next := pending[0]
+		out.Next = &next
+		out.Evidence = loadReviewEvidence(next.AttemptDir)
+		out.RecordWith = fmt.Sprintf("zcl review record --attempt %s --ok|--fail --reviewer <name> [--notes <text>]", next.AttemptDir)
+	}
+	if *jsonOut {
+		return r.writeJSON(out)
+	}
+	if out.Done {
+		fmt.Fprintf(r.Stdout, "review next: DONE campaign=%s reviewed=%d\n", out.CampaignID, out.Reviewed)
+		return 0
+	}
+	n := out.Next
+	fmt.Fprintf(r.Stdout, "review next: %d pending (campaign=%s)\n", out.Pending, out.CampaignID)
+	fmt.Fprintf(r.Stdout, "mission=%s flow=%s attempt=%s status=%s gateOk=%v why=%s\n", n.MissionID, n.FlowID, n.AttemptID, n.Status, n.GateOK, strings.Join(n.Why, ","))
+	fmt.Fprintf(r.Stdout, "attemptDir=%s\n", n.AttemptDir)
+	if ev := out.Evidence; ev != nil {
+		if ev.Prompt != "" {
+			fmt.Fprintf(r.Stdout, "\n--- prompt ---\n%s\n", ev.Prompt)
+		}
+		if ev.Feedback != nil {
+			result := ev.Feedback.Result
+			if len(ev.Feedback.ResultJSON) > 0 {
+				result = string(ev.Feedback.ResultJSON)
+			}
+			fmt.Fprintf(r.Stdout, "\n--- feedback (ok=%v) ---\n%s\n", ev.Feedback.OK, result)
+		}
+	}
+	fmt.Fprintf(r.Stdout, "\nrecord with: %s\n", out.RecordWith)
+	return 0
+}
+
+func (r Runner) runReviewRecord(args []string) int {
+	fs := flag.NewFlagSet("review record", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	attemptDir := fs.String("attempt", "", "attempt directory to record the review for (required)")
+	okFlag := fs.Bool("ok", false, "reviewer verified the attempt as correct")
+	failFlag := fs.Bool("fail", false, "reviewer rejected the attempt")
+	notes := fs.String("notes", "", "free-form reviewer notes")
+	reviewer := fs.String("reviewer", "", "reviewer name (default $USER)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("review record: invalid flags")
+	}
+	if *help {
+		printReviewHelp(r.Stdout)
+		return 0
+	}
+	if *okFlag == *failFlag {
+		printReviewHelp(r.Stderr)
+		return r.failUsage("review record: require exactly one of --ok or --fail")
+	}
+	if strings.TrimSpace(*attemptDir) == "" {
+		printReviewHelp(r.Stderr)
+		return r.failUsage("review record: missing --attempt")
+	}
+	who := strings.TrimSpace(*reviewer)
+	if who == "" {
+		who = strings.TrimSpace(os.Getenv("USER"))
+	}
+	rv, err := review.Write(r.Now(), *attemptDir, review.WriteOpts{OK: *okFlag, Notes: *notes, Reviewer: who})
+	if err != nil {
+		return r.failUsage("review record: " + err.Error())
+	}
+	if *jsonOut {
+		return r.writeJSON(rv)
+	}
+	fmt.Fprintf(r.Stdout, "review record: OK attempt=%s ok=%v reviewer=%s\n", rv.AttemptID, rv.OK, rv.Reviewer)
+	return 0
+}
+
+func loadReviewEvidence(attemptDir string) *reviewEvidence {
+	ev := &reviewEvidence{}
+	if raw, err := store.ReadArtifactFile(filepath.Join(attemptDir, artifacts.PromptTXT)); err == nil {
+		ev.Prompt = trimText(string(raw), reviewPromptMaxChars)
+	}
+	if raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.FeedbackJSON)); err == nil {
+		var fb schema.FeedbackJSONV1
+		if json.Unmarshal(raw, &fb) == nil {
+			ev.Feedback = &fb
+		}
+	}
+	if raw, err := os.ReadFile(filepath.Join(attemptDir, artifacts.AttemptReportJSON)); err == nil {
+		if rep, err := schema.DecodeAttemptReport(raw); err == nil {
+			ev.DecisionTags = rep.DecisionTags
+			ev.FailureCodeHistogram = rep.FailureCodeHistogram
+		}
+	}
+	if entries, err := os.ReadDir(attemptDir); err == nil {
+		for _, e := range entries {
+			if !e.IsDir() {
+				ev.Files = append(ev.Files, e.Name())
+			}
+		}
+	}
+	return ev
+}
+
+func printReviewHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl review next [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--all] [--out-root .zcl] [--json]
+  zcl review record --attempt <attemptDir> --ok|--fail [--reviewer <name>] [--notes <text>] [--json]
+`)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/vcr"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/attempt"
 	clifunnel "github.com/marcohefti/zero-context-lab/internal/kernel/cli_funnel"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
@@ -99,6 +100,16 @@ func (r Runner) runRun(args []string) int {
 	if exit, done := r.applyRepeatGuard(env, opts.argv); done {
 		return exit
 	}
+	if exit, done := r.applyToolPolicyGuard(env, opts.argv); done {
+		return exit
+	}
+	vcrSession, exit, done := r.openRunVCR(env)
+	if done {
+		return exit
+	}
+	if vcrSession != nil && vcrSession.Mode == vcr.ModeReplay {
+		return r.replayRunFromVCR(env, opts, vcrSession)
+	}
 
 	now := r.Now()
 	ctx, cancel, timedOut, exit, done := r.prepareRunContext(now, env.OutDirAbs)
@@ -113,6 +124,7 @@ func (r Runner) runRun(args []string) int {
 	if done {
 		return exit
 	}
+	vcrRec := r.prepareRunVCRRecord(vcrSession, &captureState)
 	res, runErr := r.executeRunCommand(ctx, opts, captureState, timedOut)
 	traceRes := baseRunTraceResult(opts.captureMaxBytes, res)
 	if exit := r.persistRunCaptureArtifacts(now, env, opts, &traceRes, &captureState, res); exit != 0 {
@@ -128,6 +140,9 @@ func (r Runner) runRun(args []string) int {
 	if exit := r.appendRunCaptureEvent(now, env, opts, captureState, traceRes); exit != 0 {
 		return exit
 	}
+	if exit := r.recordRunVCR(now, vcrRec, opts.argv, res, traceRes); exit != 0 {
+		return exit
+	}
 
 	if exit, done := r.handleRunExecutionError(timedOut, runErr, ctx); done {
 		return exit
@@ -431,7 +446,7 @@ func (r Runner) appendRunCaptureEvent(now time.Time, env trace.Env, opts runOpti
 	}
 	redArgv := make([]string, 0, len(opts.argv))
 	for _, s := range opts.argv {
-		red, _ := redact.Text(s)
+		red, _ := redact.Preview(s)
 		redArgv = append(redArgv, red)
 	}
 	ev := schema.CaptureEventV1{
@@ -595,12 +610,18 @@ func countFailedRepeatStreak(events []traceGuardEvent, argv []string) int64 {
 }
 
 func attemptCtxForDeadline(now time.Time, attemptDir string) (context.Context, context.CancelFunc, bool) {
+	return attemptCtxForDeadlineFrom(context.Background(), now, attemptDir)
+}
+
+// attemptCtxForDeadlineFrom derives the attempt deadline context from parent, so callers can also
+// cancel the attempt for other reasons (e.g. a disk quota).
+func attemptCtxForDeadlineFrom(parent context.Context, now time.Time, attemptDir string) (context.Context, context.CancelFunc, bool) {
 	a, err := attempt.ReadAttempt(attemptDir)
 	if err != nil {
-		return context.Background(), nil, false
+		return parent, nil, false
 	}
 	if a.TimeoutMs <= 0 || strings.TrimSpace(a.StartedAt) == "" {
-		return context.Background(), nil, false
+		return parent, nil, false
 	}
 	startAt := strings.TrimSpace(a.StartedAt)
 	timeoutStart := strings.TrimSpace(a.TimeoutStart)
@@ -609,20 +630,20 @@ func attemptCtxForDeadline(now time.Time, attemptDir string) (context.Context, c
 	}
 	if timeoutStart == schema.TimeoutStartFirstToolCallV1 {
 		if strings.TrimSpace(a.TimeoutStartedAt) == "" {
-			return context.Background(), nil, false
+			return parent, nil, false
 		}
 		startAt = strings.TrimSpace(a.TimeoutStartedAt)
 	}
 	start, err := time.Parse(time.RFC3339Nano, startAt)
 	if err != nil {
-		return context.Background(), nil, false
+		return parent, nil, false
 	}
 	deadline := start.Add(time.Duration(a.TimeoutMs) * time.Millisecond)
 	remaining := deadline.Sub(now)
 	if remaining <= 0 {
-		return context.Background(), nil, true
+		return parent, nil, true
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), remaining)
+	ctx, cancel := context.WithTimeout(parent, remaining)
 	return ctx, cancel, false
 }
 
@@ -630,5 +651,10 @@ func printRunHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
   zcl run [--capture [--capture-raw] --capture-max-bytes N] -- <cmd> [args...]
   zcl run --envelope --json [--capture [--capture-raw] --capture-max-bytes N] -- <cmd> [args...]
+
+Notes:
+  - ZCL_VCR_MODE=record appends the (redacted) response to <attemptDir>/tool.cassette.jsonl.
+  - ZCL_VCR_MODE=replay with ZCL_VCR_FROM=<runDir|attemptDir|cassette> serves the recorded response instead of
+    executing <cmd>; calls with no recording fail with ZCL_E_VCR_MISS.
 `)
 }
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/compact"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runRunsCompact(args []string) int {
+	fs := flag.NewFlagSet("runs compact", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	runID := fs.String("run-id", "", "run id to compact (required)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("runs compact: invalid flags")
+	}
+	if *help {
+		printRunsHelp(r.Stdout)
+		return 0
+	}
+
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	res, err := compact.Run(compact.Opts{OutRoot: m.OutRoot, RunID: *runID, Now: r.Now()})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": runs compact: %s\n", err.Error())
+		return 2
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "runs compact: OK runId=%s attempts=%d files=%d freedBytes=%d\n", res.RunID, res.Attempts, len(res.Files), res.BytesFreed)
+	if len(res.Skipped) > 0 {
+		fmt.Fprintf(r.Stdout, "skipped (no attempt.report.json): %d\n", len(res.Skipped))
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/secretscan"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runScan(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printScanHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "secrets":
+		return r.runScanSecrets(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown scan subcommand %q\n", args[0])
+		printScanHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runScanSecrets(args []string) int {
+	fs := flag.NewFlagSet("scan secrets", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	runID := fs.String("run-id", "", "run id to scan (required)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("scan secrets: invalid flags")
+	}
+	if *help {
+		printScanHelp(r.Stdout)
+		return 0
+	}
+
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	res, err := secretscan.Run(secretscan.Opts{OutRoot: m.OutRoot, RunID: *runID})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": scan secrets: %s\n", err.Error())
+		return 2
+	}
+	if *jsonOut {
+		if exit := r.writeJSON(res); exit != 0 {
+			return exit
+		}
+	} else if res.OK {
+		fmt.Fprintf(r.Stdout, "scan secrets: OK runId=%s files=%d\n", res.RunID, res.FilesScanned)
+	} else {
+		for _, h := range res.Hits {
+			fmt.Fprintf(r.Stderr, "%s: %s:%d %s\n", codeSecretLeak, h.Path, h.Line, h.Rule)
+		}
+		fmt.Fprintf(r.Stderr, "scan secrets: FAIL runId=%s hits=%d\n", res.RunID, len(res.Hits))
+	}
+	if res.OK {
+		return 0
+	}
+	return 2
+}
+
+func printScanHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl scan secrets --run-id <runId> [--out-root .zcl] [--json]
+`)
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/validate"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/jsonschema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+type schemaArtifact struct {
+	id    string
+	title string
+	// value is the Go type the schema is generated from; nil means raw is the canonical schema.
+	value any
+	raw   []byte
+	// versionField/version pin the generated schema to the version ZCL reads and writes.
+	versionField string
+	version      int
+}
+
+var schemaArtifacts = map[string]schemaArtifact{
+	"attempt.report": {id: "https://zcl.dev/schema/attempt.report.v1.json", title: "ZCL Attempt Report v1", value: schema.AttemptReportJSONV1{}, versionField: "schemaVersion", version: schema.AttemptReportSchemaV1},
+	"feedback":       {id: "https://zcl.dev/schema/feedback.v1.json", title: "ZCL Feedback v1", value: schema.FeedbackJSONV1{}, versionField: "schemaVersion", version: schema.FeedbackSchemaV1},
+	"suite":          {id: "https://zcl.dev/schema/suite.v1.json", title: "ZCL Suite File v1", value: suite.SuiteFileV1{}, versionField: "version", version: 1},
+	"campaign":       {raw: campaign.SpecJSONSchema, versionField: "schemaVersion", version: 1},
+}
+
+func schemaArtifactNames() []string {
+	names := make([]string, 0, len(schemaArtifacts))
+	for k := range schemaArtifacts {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// artifactJSONSchema returns the canonical JSON Schema document for a named artifact kind.
+func artifactJSONSchema(name string) (map[string]any, error) {
+	a, ok := schemaArtifacts[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown artifact %q (expected %s)", name, strings.Join(schemaArtifactNames(), "|"))
+	}
+	if a.value != nil {
+		doc := jsonschema.Generate(a.value, a.id, a.title)
+		if props, ok := doc["properties"].(map[string]any); ok && a.versionField != "" {
+			props[a.versionField] = map[string]any{"type": "integer", "const": a.version}
+		}
+		return doc, nil
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(a.raw, &doc); err != nil {
+		return nil, fmt.Errorf("embedded %s schema is invalid: %w", name, err)
+	}
+	return doc, nil
+}
+
+func (r Runner) runSchema(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printSchemaHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "export":
+		return r.runSchemaExport(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown schema subcommand %q\n", args[0])
+		printSchemaHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runSchemaExport(args []string) int {
+	fs := flag.NewFlagSet("schema export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	artifact := fs.String("artifact", "", "artifact kind ("+strings.Join(schemaArtifactNames(), "|")+")")
+	jsonSchema := fs.Bool("json-schema", false, "emit JSON Schema (draft 2020-12)")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("schema export: invalid flags")
+	}
+	if *help {
+		printSchemaHelp(r.Stdout)
+		return 0
+	}
+	if !*jsonSchema {
+		printSchemaHelp(r.Stderr)
+		return r.failUsage("schema export: require --json-schema")
+	}
+	doc, err := artifactJSONSchema(strings.TrimSpace(*artifact))
+	if err != nil {
+		printSchemaHelp(r.Stderr)
+		return r.failUsage("schema export: " + err.Error())
+	}
+	return r.writeJSON(doc)
+}
+
+func printSchemaHelp(w io.Writer) {
+	fmt.Fprintf(w, `Usage:
+  zcl schema export --artifact %s --json-schema
+`, strings.Join(schemaArtifactNames(), "|"))
+}
+
+// runValidateFile validates one standalone artifact (outside a live attempt) against its exported schema.
+func (r Runner) runValidateFile(args []string) int {
+	fs := flag.NewFlagSet("validate file", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	kind := fs.String("kind", "", "artifact kind ("+strings.Join(schemaArtifactNames(), "|")+")")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("validate file: invalid flags")
+	}
+	if *help {
+		printValidateHelp(r.Stdout)
+		return 0
+	}
+	if fs.NArg() != 1 {
+		printValidateHelp(r.Stderr)
+		return r.failUsage("validate file: require exactly one <path>")
+	}
+	name := strings.TrimSpace(*kind)
+	doc, err := artifactJSONSchema(name)
+	if err != nil {
+		printValidateHelp(r.Stderr)
+		return r.failUsage("validate file: " + err.Error())
+	}
+	res := validateArtifactFile(fs.Arg(0), schemaArtifacts[name], doc)
+	return r.reportValidateResult(res, *jsonOut)
+}
+
+func validateArtifactFile(path string, a schemaArtifact, schemaDoc map[string]any) validate.Result {
+	res := validate.Result{OK: true, Target: "file", Path: path}
+	fail := func(code, msg string) validate.Result {
+		res.OK = false
+		res.Errors = append(res.Errors, validate.Finding{Code: code, Message: msg, Path: path})
+		return res
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fail(codeIO, err.Error())
+	}
+	inst, err := suite.DecodeDocument(path, raw)
+	if err != nil {
+		return fail(codeInvalidJSON, "file is not valid json/yaml: "+err.Error())
+	}
+	if obj, ok := inst.(map[string]any); ok && a.versionField != "" {
+		if v, present := obj[a.versionField]; present && v != float64(a.version) {
+			return fail(codeSchemaUnsupported, fmt.Sprintf("unsupported %s %v (this zcl supports %d)", a.versionField, v, a.version))
+		}
+	}
+	violations, err := suite.ValidateJSONSchema(schemaDoc, inst)
+	if err != nil {
+		return fail(codeIO, "embedded schema is not usable: "+err.Error())
+	}
+	for _, v := range violations {
+		ptr := v.Pointer
+		if ptr == "" {
+			ptr = "/"
+		}
+		res.Errors = append(res.Errors, validate.Finding{Code: codeContract, Message: ptr + ": " + v.Message, Path: path})
+	}
+	if len(res.Errors) > 0 {
+		res.OK = false
+	}
+	return res
+}
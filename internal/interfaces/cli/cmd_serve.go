@@ -0,0 +1,401 @@
+package cli
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/top"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+)
+
+//go:embed serve_ui/index.html
+var serveIndexHTML []byte
+
+// serveErrorV1 is the JSON body of every non-2xx API response.
+type serveErrorV1 struct {
+	OK      bool   `json:"ok"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type serveRunDetailV1 struct {
+	Run      json.RawMessage   `json:"run"`
+	Summary  json.RawMessage   `json:"summary,omitempty"`
+	Report   json.RawMessage   `json:"report,omitempty"`
+	Attempts []attemptIndexRow `json:"attempts"`
+}
+
+type serveAttemptDetailV1 struct {
+	Attempt  json.RawMessage `json:"attempt"`
+	Report   json.RawMessage `json:"report,omitempty"`
+	Feedback json.RawMessage `json:"feedback,omitempty"`
+}
+
+type serveCampaignRowV1 struct {
+	CampaignID        string `json:"campaignId"`
+	RunID             string `json:"runId,omitempty"`
+	Status            string `json:"status"`
+	StartedAt         string `json:"startedAt,omitempty"`
+	UpdatedAt         string `json:"updatedAt,omitempty"`
+	TotalMissions     int    `json:"totalMissions"`
+	MissionsCompleted int    `json:"missionsCompleted"`
+	ReportPresent     bool   `json:"reportPresent"`
+}
+
+type serveCampaignDetailV1 struct {
+	State   campaign.RunStateV1 `json:"state"`
+	Report  json.RawMessage     `json:"report,omitempty"`
+	Summary json.RawMessage     `json:"summary,omitempty"`
+}
+
+func (r Runner) runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	addr := fs.String("addr", "127.0.0.1:8080", "listen address (use :8080 to listen on all interfaces)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	jsonOut := fs.Bool("json", false, "print the listen address as JSON and keep serving")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("serve: invalid flags")
+	}
+	if *help {
+		printServeHelp(r.Stdout)
+		return 0
+	}
+	if fs.NArg() != 0 {
+		return r.failUsage("serve: unexpected positional arguments")
+	}
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	absOutRoot, err := filepath.Abs(m.OutRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+
+	ln, err := net.Listen("tcp", strings.TrimSpace(*addr))
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": serve: %s\n", err.Error())
+		return 1
+	}
+	srv := &http.Server{Handler: newServeHandler(absOutRoot, r.Now), ReadHeaderTimeout: 10 * time.Second}
+
+	listenURL := "http://" + ln.Addr().String()
+	if *jsonOut {
+		if exit := r.writeJSON(map[string]any{"ok": true, "addr": ln.Addr().String(), "url": listenURL, "outRoot": absOutRoot}); exit != 0 {
+			_ = ln.Close()
+			return exit
+		}
+	} else {
+		fmt.Fprintf(r.Stdout, "zcl serve: %s (outRoot=%s, read-only)\n", listenURL, absOutRoot)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+	select {
+	case err := <-errCh:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(r.Stderr, codeIO+": serve: %s\n", err.Error())
+			return 1
+		}
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}
+	return 0
+}
+
+// newServeHandler builds the read-only API + UI mux over an absolute out-root. Nothing is locked or
+// written; every request re-reads artifacts from disk.
+func newServeHandler(outRoot string, now func() time.Time) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(serveIndexHTML)
+	})
+	mux.HandleFunc("GET /api/runs", func(w http.ResponseWriter, req *http.Request) {
+		rows, err := collectRunRows(outRoot, req.URL.Query().Get("suite"))
+		if err != nil {
+			writeServeError(w, http.StatusInternalServerError, codeIO, err.Error())
+			return
+		}
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].RunID > rows[j].RunID })
+		writeServeJSON(w, limitServeRows(rows, req))
+	})
+	mux.HandleFunc("GET /api/runs/{runId}", func(w http.ResponseWriter, req *http.Request) {
+		runDir, ok := serveRunDir(w, outRoot, req.PathValue("runId"))
+		if !ok {
+			return
+		}
+		out := serveRunDetailV1{Run: readServeRaw(filepath.Join(runDir, artifacts.RunJSON))}
+		out.Summary = readServeRaw(filepath.Join(runDir, artifacts.SuiteRunSummaryJSON))
+		out.Report = readServeRaw(filepath.Join(runDir, artifacts.RunReportJSON))
+		rows, err := serveAttemptRows(outRoot, runDir)
+		if err != nil {
+			writeServeError(w, http.StatusInternalServerError, codeIO, err.Error())
+			return
+		}
+		out.Attempts = rows
+		writeServeJSON(w, out)
+	})
+	mux.HandleFunc("GET /api/runs/{runId}/attempts", func(w http.ResponseWriter, req *http.Request) {
+		runDir, ok := serveRunDir(w, outRoot, req.PathValue("runId"))
+		if !ok {
+			return
+		}
+		rows, err := serveAttemptRows(outRoot, runDir)
+		if err != nil {
+			writeServeError(w, http.StatusInternalServerError, codeIO, err.Error())
+			return
+		}
+		writeServeJSON(w, rows)
+	})
+	// The events stream is also served outside /api so dashboards can subscribe at /runs/<runId>/events.
+	for _, pattern := range []string{"GET /runs/{runId}/events", "GET /api/runs/{runId}/events"} {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, req *http.Request) {
+			serveRunEvents(w, req, outRoot)
+		})
+	}
+	mux.HandleFunc("GET /api/runs/{runId}/attempts/{attemptId}", func(w http.ResponseWriter, req *http.Request) {
+		runDir, ok := serveRunDir(w, outRoot, req.PathValue("runId"))
+		if !ok {
+			return
+		}
+		attemptID := req.PathValue("attemptId")
+		if !isServeComponent(attemptID) {
+			writeServeError(w, http.StatusBadRequest, codeUsage, "invalid attemptId")
+			return
+		}
+		attemptDir := filepath.Join(runDir, "attempts", attemptID)
+		out := serveAttemptDetailV1{Attempt: readServeRaw(filepath.Join(attemptDir, artifacts.AttemptJSON))}
+		if out.Attempt == nil {
+			writeServeError(w, http.StatusNotFound, codeMissingArtifact, "attempt not found")
+			return
+		}
+		out.Report = readServeRaw(filepath.Join(attemptDir, artifacts.AttemptReportJSON))
+		out.Feedback = readServeRaw(filepath.Join(attemptDir, artifacts.FeedbackJSON))
+		writeServeJSON(w, out)
+	})
+	mux.HandleFunc("GET /api/attempts", func(w http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		status := strings.TrimSpace(q.Get("status"))
+		if status == "" {
+			status = attemptStatusAny
+		}
+		rows, err := collectAttemptRows(attemptIndexFilter{
+			SuiteID: strings.TrimSpace(q.Get("suite")),
+			Mission: strings.TrimSpace(q.Get("mission")),
+			Status:  status,
+			Tags:    q["tag"],
+			OutRoot: outRoot,
+		})
+		if err != nil {
+			writeServeError(w, http.StatusInternalServerError, codeIO, err.Error())
+			return
+		}
+		writeServeJSON(w, limitServeRows(rows, req))
+	})
+	mux.HandleFunc("GET /api/campaigns", func(w http.ResponseWriter, _ *http.Request) {
+		rows, err := serveCampaignRows(outRoot)
+		if err != nil {
+			writeServeError(w, http.StatusInternalServerError, codeIO, err.Error())
+			return
+		}
+		writeServeJSON(w, rows)
+	})
+	mux.HandleFunc("GET /api/campaigns/{campaignId}", func(w http.ResponseWriter, req *http.Request) {
+		cid := req.PathValue("campaignId")
+		if !isServeComponent(cid) {
+			writeServeError(w, http.StatusBadRequest, codeUsage, "invalid campaignId")
+			return
+		}
+		st, err := campaign.LoadRunState(campaign.RunStatePath(outRoot, cid))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				writeServeError(w, http.StatusNotFound, codeMissingArtifact, "campaign not found")
+				return
+			}
+			writeServeError(w, http.StatusInternalServerError, codeIO, err.Error())
+			return
+		}
+		writeServeJSON(w, serveCampaignDetailV1{
+			State:   st,
+			Report:  readServeRaw(campaign.ReportPath(outRoot, cid)),
+			Summary: readServeRaw(campaign.SummaryPath(outRoot, cid)),
+		})
+	})
+	mux.HandleFunc("GET /api/top", func(w http.ResponseWriter, _ *http.Request) {
+		snap, err := top.Snapshot(top.Opts{OutRoot: outRoot, RecentFailures: 10, Now: now()})
+		if err != nil {
+			writeServeError(w, http.StatusInternalServerError, codeIO, err.Error())
+			return
+		}
+		writeServeJSON(w, snap)
+	})
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			writeServeError(w, http.StatusMethodNotAllowed, codeUsage, "read-only API: only GET is supported")
+			return
+		}
+		writeServeError(w, http.StatusNotFound, codeUsage, "unknown endpoint")
+	})
+	return mux
+}
+
+// serveRunDir resolves runs/<runId>, writing the error response itself when the id is invalid or
+// the run is not on disk (archived runs must be restored first).
+func serveRunDir(w http.ResponseWriter, outRoot string, runID string) (string, bool) {
+	if !ids.IsValidRunID(runID) {
+		writeServeError(w, http.StatusBadRequest, codeUsage, "invalid runId")
+		return "", false
+	}
+	runDir := filepath.Join(outRoot, "runs", runID)
+	if _, err := os.Stat(filepath.Join(runDir, artifacts.RunJSON)); err != nil {
+		writeServeError(w, http.StatusNotFound, codeMissingArtifact, "run not found (archived runs need zcl archive restore)")
+		return "", false
+	}
+	return runDir, true
+}
+
+func serveAttemptRows(outRoot string, runDir string) ([]attemptIndexRow, error) {
+	info, err := os.Stat(runDir)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := collectAttemptRowsForRun(outRoot, fs.FileInfoToDirEntry(info), attemptIndexFilter{Status: attemptStatusAny, OutRoot: outRoot})
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = []attemptIndexRow{}
+	}
+	return rows, nil
+}
+
+func serveCampaignRows(outRoot string) ([]serveCampaignRowV1, error) {
+	rows := []serveCampaignRowV1{}
+	entries, err := os.ReadDir(filepath.Join(outRoot, "campaigns"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rows, nil
+		}
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		st, err := campaign.LoadRunState(campaign.RunStatePath(outRoot, e.Name()))
+		if err != nil {
+			continue
+		}
+		_, reportErr := os.Stat(campaign.ReportPath(outRoot, e.Name()))
+		rows = append(rows, serveCampaignRowV1{
+			CampaignID:        st.CampaignID,
+			RunID:             st.RunID,
+			Status:            st.Status,
+			StartedAt:         st.StartedAt,
+			UpdatedAt:         st.UpdatedAt,
+			TotalMissions:     st.TotalMissions,
+			MissionsCompleted: st.MissionsCompleted,
+			ReportPresent:     reportErr == nil,
+		})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].UpdatedAt > rows[j].UpdatedAt })
+	return rows, nil
+}
+
+// isServeComponent rejects ids that could escape their parent dir.
+func isServeComponent(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, "/\\\x00")
+}
+
+func limitServeRows[T any](rows []T, req *http.Request) []T {
+	if rows == nil {
+		return []T{}
+	}
+	if n, err := strconv.Atoi(req.URL.Query().Get("limit")); err == nil && n > 0 && n < len(rows) {
+		return rows[:n]
+	}
+	return rows
+}
+
+// readServeRaw returns the file as raw JSON, or nil when missing or not valid JSON.
+func readServeRaw(path string) json.RawMessage {
+	raw, err := os.ReadFile(path)
+	if err != nil || !json.Valid(raw) {
+		return nil
+	}
+	return json.RawMessage(raw)
+}
+
+func writeServeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+}
+
+func writeServeError(w http.ResponseWriter, status int, code string, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(serveErrorV1{OK: false, Code: code, Message: msg})
+}
+
+func printServeHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl serve [--addr 127.0.0.1:8080] [--out-root .zcl] [--json]
+
+Endpoints (read-only, JSON unless noted):
+  GET /api/runs[?suite=&limit=]                     run index rows (same as runs list)
+  GET /api/runs/<runId>                             run.json, suite summary, run report, attempt rows
+  GET /api/runs/<runId>/attempts                    attempt rows for one run
+  GET /api/runs/<runId>/attempts/<attemptId>        attempt.json, attempt.report.json, feedback.json
+  GET /runs/<runId>/events[?kinds=]                 progress events as text/event-stream (SSE);
+                                                    also at /api/runs/<runId>/events
+  GET /api/attempts[?suite=&mission=&status=&tag=&limit=]
+  GET /api/campaigns                                campaign state rows
+  GET /api/campaigns/<campaignId>                   campaign.run.state.json, report, summary
+  GET /api/top                                      live snapshot (same as top --json)
+  GET /                                             embedded web dashboard
+
+Notes:
+  - Nothing is written or locked; every request re-reads artifacts from the out-root.
+  - /events follows the run's --progress-jsonl file (from run.invocation.json) and ends after
+    run_finished or when the run has no live owner. Event ids are byte offsets, so reconnecting
+    clients resume via Last-Event-ID. kinds= takes the same list as suite run --progress-events.
+  - Only runs started with suite run --progress-jsonl <path> have a stream; other runs (including
+    campaign run flows) get 404 ZCL_E_MISSING_ARTIFACT. Lines with unknown kinds are skipped.
+  - There is no authentication: the default binds to loopback. Use --addr :8080 to expose it
+    on a trusted network only.
+  - Stops on SIGINT/SIGTERM.
+`)
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/signing"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runSign(args []string) int {
+	fs := flag.NewFlagSet("sign", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	campaignID := fs.String("campaign-id", "", "campaign id (required unless --spec is provided)")
+	spec := fs.String("spec", "", "campaign spec file (.json|.yaml|.yml) (optional alternative to --campaign-id)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	keyPath := fs.String("key", "", "ed25519 private key, PEM PKCS#8 (default $ZCL_SIGNING_KEY)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("sign: invalid flags")
+	}
+	if *help {
+		printSignHelp(r.Stdout)
+		return 0
+	}
+	kp := strings.TrimSpace(*keyPath)
+	if kp == "" {
+		kp = strings.TrimSpace(os.Getenv("ZCL_SIGNING_KEY"))
+	}
+	if kp == "" {
+		printSignHelp(r.Stderr)
+		return r.failUsage("sign: missing --key")
+	}
+	key, err := signing.LoadPrivateKey(kp)
+	if err != nil {
+		return r.failUsage("sign: " + err.Error())
+	}
+	st, exit, ok := r.resolveCampaignRunState(*campaignID, *spec, *outRoot, *jsonOut, "sign", printSignHelp)
+	if !ok {
+		return exit
+	}
+	res, err := signing.Sign(signing.SignOpts{
+		OutRoot:    st.OutRoot,
+		CampaignID: st.CampaignID,
+		RunIDs:     campaignFlowRunIDs(st),
+		Key:        key,
+		Now:        r.Now(),
+	})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": sign: %s\n", err.Error())
+		return 1
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "sign: OK campaign=%s keyId=%s files=%d signature=%s\n", res.CampaignID, res.KeyID, res.Files, res.SignaturePath)
+	return 0
+}
+
+func (r Runner) runVerify(args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	campaignID := fs.String("campaign-id", "", "campaign id (required)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	pubPath := fs.String("pubkey", "", "expected ed25519 public key, PEM PKIX (recommended; without it only the embedded key is checked)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("verify: invalid flags")
+	}
+	if *help {
+		printSignHelp(r.Stdout)
+		return 0
+	}
+	if strings.TrimSpace(*campaignID) == "" {
+		printSignHelp(r.Stderr)
+		return r.failUsage("verify: missing --campaign-id")
+	}
+	opts := signing.VerifyOpts{CampaignID: *campaignID}
+	if p := strings.TrimSpace(*pubPath); p != "" {
+		pub, err := signing.LoadPublicKey(p)
+		if err != nil {
+			return r.failUsage("verify: " + err.Error())
+		}
+		opts.PublicKey = pub
+	}
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	opts.OutRoot = m.OutRoot
+	res, err := signing.Verify(opts)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": verify: %s\n", err.Error())
+		return 1
+	}
+	if *jsonOut {
+		if exit := r.writeJSON(res); exit != 0 {
+			return exit
+		}
+	} else if res.OK {
+		fmt.Fprintf(r.Stdout, "verify: OK campaign=%s keyId=%s files=%d keyPinned=%v\n", res.CampaignID, res.KeyID, res.Files, res.KeyPinned)
+		if !res.KeyPinned {
+			fmt.Fprintln(r.Stderr, "verify: warning: no --pubkey given; only integrity against the embedded key was checked")
+		}
+	} else {
+		for _, e := range res.Errors {
+			fmt.Fprintf(r.Stderr, "%s: %s\n", codeSignatureInvalid, e)
+		}
+		for _, p := range res.Mismatched {
+			fmt.Fprintf(r.Stderr, "%s: hash mismatch %s\n", codeSignatureInvalid, p)
+		}
+		for _, p := range res.Missing {
+			fmt.Fprintf(r.Stderr, "%s: missing %s\n", codeSignatureInvalid, p)
+		}
+		for _, p := range res.Unsigned {
+			fmt.Fprintf(r.Stderr, "%s: unsigned %s\n", codeSignatureInvalid, p)
+		}
+		fmt.Fprintf(r.Stderr, "verify: FAIL campaign=%s\n", res.CampaignID)
+	}
+	if res.OK {
+		return 0
+	}
+	return 2
+}
+
+func printSignHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl sign [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] --key <ed25519.pem> [--out-root .zcl] [--json]
+  zcl verify --campaign-id <id> [--pubkey <ed25519.pub.pem>] [--out-root .zcl] [--json]
+
+Keys:
+  openssl genpkey -algorithm ed25519 -out zcl-signing.pem
+  openssl pkey -in zcl-signing.pem -pubout -out zcl-signing.pub.pem
+`)
+}
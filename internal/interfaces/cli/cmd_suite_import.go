@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/app/suiteimport"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+type suiteImportResult struct {
+	OK       bool     `json:"ok"`
+	Format   string   `json:"format"`
+	Source   string   `json:"source"`
+	Out      string   `json:"out"`
+	SuiteID  string   `json:"suiteId"`
+	Samples  int      `json:"samples"`
+	Missions int      `json:"missions"`
+	Unscored []string `json:"unscored"`
+}
+
+func (r Runner) runSuiteImport(args []string) int {
+	fs := flag.NewFlagSet("suite import", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	format := fs.String("format", "", "source format: "+strings.Join(suiteimport.Formats(), "|")+" (required)")
+	suiteID := fs.String("suite-id", "", "suite id (default: source file name)")
+	match := fs.String("match", suiteimport.MatchIncludes, "answer check: includes (result contains an answer) | exact (trimmed result equals an answer)")
+	limit := fs.Int("limit", 0, "import only the first N samples (0 = all)")
+	defaults := suiteimport.DefaultFields()
+	idField := fs.String("id-field", defaults.ID, "custom-jsonl: mission id key")
+	promptField := fs.String("prompt-field", defaults.Prompt, "custom-jsonl: prompt key (string or chat messages)")
+	answerField := fs.String("answer-field", defaults.Answer, "custom-jsonl: expected answer key (string or list)")
+	tagsField := fs.String("tags-field", defaults.Tags, "custom-jsonl: tags key (string or list)")
+	out := fs.String("out", "", "suite file to write (.json; default: print the suite to stdout)")
+	force := fs.Bool("force", false, "overwrite an existing --out file")
+	jsonOut := fs.Bool("json", false, "print JSON summary (requires --out)")
+	help := fs.Bool("help", false, "show help")
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("suite import: invalid flags")
+	}
+	if *help {
+		printSuiteImportHelp(r.Stdout)
+		return 0
+	}
+	if fs.NArg() != 1 {
+		printSuiteImportHelp(r.Stderr)
+		return r.failUsage("suite import: require exactly one <path>")
+	}
+	if strings.TrimSpace(*format) == "" {
+		printSuiteImportHelp(r.Stderr)
+		return r.failUsage("suite import: missing --format")
+	}
+	if *limit < 0 {
+		return r.failUsage("suite import: --limit must be >= 0")
+	}
+	outPath := strings.TrimSpace(*out)
+	if *jsonOut && outPath == "" {
+		return r.failUsage("suite import: --json requires --out")
+	}
+	if outPath != "" && !*force {
+		if _, err := os.Stat(outPath); err == nil {
+			return r.failUsage(fmt.Sprintf("suite import: %s already exists (use --force to overwrite)", outPath))
+		}
+	}
+
+	src := fs.Arg(0)
+	f, err := os.Open(src)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	defer func() { _ = f.Close() }()
+	id := strings.TrimSpace(*suiteID)
+	if id == "" {
+		base := filepath.Base(src)
+		id = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	res, err := suiteimport.Convert(f, suiteimport.Options{
+		Format:  *format,
+		SuiteID: id,
+		Match:   *match,
+		Limit:   *limit,
+		Fields:  suiteimport.Fields{ID: *idField, Prompt: *promptField, Answer: *answerField, Tags: *tagsField},
+	})
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": suite import: %s\n", err.Error())
+		return 2
+	}
+	body, err := json.MarshalIndent(res.Suite, "", "  ")
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	body = append(body, '\n')
+
+	if outPath == "" {
+		if exit := r.checkImportedSuite(body); exit != 0 {
+			return exit
+		}
+		_, _ = r.Stdout.Write(body)
+		return 0
+	}
+	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	if err := store.WriteFileAtomic(outPath, body); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	if _, err := suite.ParseFile(outPath); err != nil {
+		_ = os.Remove(outPath)
+		fmt.Fprintf(r.Stderr, codeUsage+": suite import: converted suite does not parse: %s\n", err.Error())
+		return 2
+	}
+	summary := suiteImportResult{
+		OK:       true,
+		Format:   strings.ToLower(strings.TrimSpace(*format)),
+		Source:   src,
+		Out:      outPath,
+		SuiteID:  res.Suite.SuiteID,
+		Samples:  res.Samples,
+		Missions: len(res.Suite.Missions),
+		Unscored: res.Unscored,
+	}
+	if summary.Unscored == nil {
+		summary.Unscored = []string{}
+	}
+	if *jsonOut {
+		return r.writeJSON(summary)
+	}
+	fmt.Fprintf(r.Stdout, "suite import: OK %s -> %s (suiteId=%s missions=%d of %d samples, unscored=%d)\n", src, outPath, summary.SuiteID, summary.Missions, summary.Samples, len(summary.Unscored))
+	return 0
+}
+
+// checkImportedSuite parses the converted suite from a scratch file, so stdout output is held
+// to the same validation as --out.
+func (r Runner) checkImportedSuite(body []byte) int {
+	tmp, err := os.CreateTemp("", "zcl-suite-import-*.json")
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	_, werr := tmp.Write(body)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": write %s failed\n", tmp.Name())
+		return 1
+	}
+	if _, err := suite.ParseFile(tmp.Name()); err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": suite import: converted suite does not parse: %s\n", err.Error())
+		return 2
+	}
+	return 0
+}
+
+func printSuiteImportHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl suite import --format openai-evals|inspect-ai|custom-jsonl [--suite-id <id>] [--match includes|exact] [--limit N] [--id-field id] [--prompt-field prompt] [--answer-field answer] [--tags-field tags] [--out suite.json] [--force] [--json] <path>
+
+Notes:
+  - openai-evals: samples JSONL with input (string or chat messages) and ideal (string or list).
+  - inspect-ai: dataset JSONL or JSON array with input, target, optional id and choices (appended as A) B) ... lines).
+  - custom-jsonl: one object per line; keys come from --id-field/--prompt-field/--answer-field/--tags-field.
+  - Expected answers become expects.resultMatches (includes) or an anchored expects.result.pattern (exact); samples without an answer only check feedback ok and are listed as unscored.
+  - Without --out the suite JSON is printed to stdout.
+`)
+}
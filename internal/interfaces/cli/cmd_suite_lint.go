@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+)
+
+type suiteLintResult struct {
+	OK       bool                    `json:"ok"`
+	File     string                  `json:"file"`
+	SuiteID  string                  `json:"suiteId"`
+	Total    int                     `json:"missionsTotal"`
+	Expanded int                     `json:"matrixExpanded"`
+	Includes []string                `json:"includes"`
+	Missions []suite.MissionOriginV1 `json:"missions"`
+	Refs     []suite.FileRefV1       `json:"refs"`
+}
+
+func (r Runner) runSuiteLint(args []string) int {
+	fs := flag.NewFlagSet("suite lint", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	file := fs.String("file", "", "suite file path (.json|.yaml|.yml) (required)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("suite lint: invalid flags")
+	}
+	if *help {
+		printSuiteLintHelp(r.Stdout)
+		return 0
+	}
+	if strings.TrimSpace(*file) == "" {
+		printSuiteLintHelp(r.Stderr)
+		return r.failUsage("suite lint: missing --file")
+	}
+
+	parsed, err := suite.ParseFile(strings.TrimSpace(*file))
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": suite lint: %s\n", err.Error())
+		return 2
+	}
+	res := suiteLintResult{
+		OK:       true,
+		File:     strings.TrimSpace(*file),
+		SuiteID:  parsed.Suite.SuiteID,
+		Total:    len(parsed.Suite.Missions),
+		Includes: []string{},
+		Missions: parsed.Origins,
+		Refs:     parsed.Refs,
+	}
+	for _, o := range parsed.Origins {
+		if o.Template != "" {
+			res.Expanded++
+		}
+	}
+	for _, ref := range parsed.Refs {
+		if ref.Kind == "include" {
+			res.Includes = append(res.Includes, ref.Path)
+		}
+	}
+	if res.Refs == nil {
+		res.Refs = []suite.FileRefV1{}
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+
+	fmt.Fprintf(r.Stdout, "suite lint: OK suiteId=%s missions=%d matrixExpanded=%d includes=%d\n", res.SuiteID, res.Total, res.Expanded, len(res.Includes))
+	for _, o := range res.Missions {
+		line := "  " + o.MissionID + "  " + o.Source
+		if len(o.Matrix) > 0 {
+			keys := make([]string, 0, len(o.Matrix))
+			for k := range o.Matrix {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			pairs := make([]string, 0, len(keys))
+			for _, k := range keys {
+				pairs = append(pairs, k+"="+o.Matrix[k])
+			}
+			line += "  [" + o.Template + ": " + strings.Join(pairs, " ") + "]"
+		}
+		fmt.Fprintln(r.Stdout, line)
+	}
+	return 0
+}
+
+func printSuiteLintHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl suite lint --file <suite.(yaml|yml|json)> [--json]
+
+Notes:
+  - Parses the suite like suite plan/run do: include: entries (suite files or .md mission-pack dirs) are inlined first, matrix: missions are expanded.
+  - Lists every resulting mission with the file it came from and its matrix values.
+`)
+}
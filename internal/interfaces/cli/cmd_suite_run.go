@@ -20,21 +20,30 @@ import (
 	"sync/atomic"
 	"time"
 
+	"filippo.io/age"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/expect"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/report"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/validate"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/encrypt"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/feedback"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/vcr"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/attempt"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/home"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/remote"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/sandbox"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/infra/codex_app_server"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/blind"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
@@ -83,7 +92,7 @@ type suiteRunAttemptResult struct {
 	IsolationModel string `json:"isolationModel,omitempty"`
 
 	RunnerExitCode   *int   `json:"runnerExitCode,omitempty"`
-	RunnerErrorCode  string `json:"runnerErrorCode,omitempty"` // ZCL_E_TIMEOUT|ZCL_E_SPAWN|ZCL_E_CONTAMINATED_PROMPT
+	RunnerErrorCode  string `json:"runnerErrorCode,omitempty"` // ZCL_E_TIMEOUT|ZCL_E_SPAWN|ZCL_E_CONTAMINATED_PROMPT|ZCL_E_NETWORK_REQUIRED|ZCL_E_RESOURCE_LIMIT
 	AutoFeedback     bool   `json:"autoFeedback,omitempty"`
 	AutoFeedbackCode string `json:"autoFeedbackCode,omitempty"`
 	Skipped          bool   `json:"skipped,omitempty"`
@@ -111,6 +120,9 @@ type suiteRunSummary struct {
 	RuntimeStrategyChain []string `json:"runtimeStrategyChain,omitempty"`
 	// RuntimeStrategySelected is the resolved native runtime strategy when native mode is used.
 	RuntimeStrategySelected string `json:"runtimeStrategySelected,omitempty"`
+	// ChaosProfile is the --chaos profile faults were injected from (CampaignProfile.Chaos keeps
+	// these runs out of comparisons with clean ones).
+	ChaosProfile string `json:"chaosProfile,omitempty"`
 	// CampaignProfile captures key run-shape controls for comparability across campaigns.
 	CampaignProfile suiteRunCampaignProfile `json:"campaignProfile"`
 	// ComparabilityKey is a stable hash of CampaignProfile.
@@ -127,9 +139,22 @@ type suiteRunSummary struct {
 	Passed int `json:"passed"`
 	Failed int `json:"failed"`
 
+	// Consistency reports cross-attempt invariant violations found after all attempts finished.
+	Consistency *suiteRunConsistency `json:"consistency,omitempty"`
+
+	// Schedule records the predicted dispatch plan (--schedule longest-first).
+	Schedule *suiteRunSchedule `json:"schedule,omitempty"`
+	// NativeScheduler records adaptive in-flight tuning (ZCL_NATIVE_ADAPTIVE_INFLIGHT=1).
+	NativeScheduler *suiteRunNativeSchedulerSummary `json:"nativeScheduler,omitempty"`
+
 	CreatedAt string `json:"createdAt"`
 }
 
+type suiteRunConsistency struct {
+	OK         bool               `json:"ok"`
+	Violations []validate.Finding `json:"violations,omitempty"`
+}
+
 type suiteRunCampaignProfile struct {
 	Mode            string   `json:"mode"`
 	TimeoutMs       int64    `json:"timeoutMs"`
@@ -148,7 +173,15 @@ type suiteRunCampaignProfile struct {
 	MissionOffset   int      `json:"missionOffset,omitempty"`
 	FailFast        bool     `json:"failFast"`
 	Blind           bool     `json:"blind"`
+	BlindAction     string   `json:"blindAction,omitempty"`
+	Chaos           bool     `json:"chaos,omitempty"`
 	Shims           []string `json:"shims,omitempty"`
+	// MCPServers names the MCP servers native sessions were given; like shims, they change the
+	// agent's tool surface.
+	MCPServers []string `json:"mcpServers,omitempty"`
+	// EnvFingerprint is the env.fingerprint.json hash, so cross-host runs only compare when their
+	// environments match.
+	EnvFingerprint string `json:"envFingerprint,omitempty"`
 }
 
 type stringListFlag []string
@@ -183,14 +216,19 @@ func (r Runner) runSuiteRunWithEnvCore(args []string, extraAttemptEnv map[string
 	if done, code := r.handleSuiteRunCLIImmediate(input); done {
 		return code
 	}
+	if input.watch {
+		return r.runSuiteRunWatch(args, input, extraAttemptEnv)
+	}
 	host, ok, code := r.resolveSuiteRunHostConfig(input, extraAttemptEnv)
 	if !ok {
 		return code
 	}
+	input = applySuiteRunProfileBudgets(input, host.merged)
 	exec, ok, code := r.resolveSuiteRunExecutionPlan(input, host, extraAttemptEnv)
 	if !ok {
 		return code
 	}
+	exec.invocation = suiteRunInvocation(r, args, extraAttemptEnv)
 	return r.runSuiteRunExecution(exec)
 }
 
@@ -208,25 +246,43 @@ type suiteRunCLIInput struct {
 	resultMinTurn              int
 	blindOverride              string
 	blindTermsCSV              string
+	blindTermsPack             string
+	blindAction                string
 	sessionIsolation           string
 	runtimeStrategiesCSV       string
 	nativeModel                string
 	nativeModelReasoningEffort string
 	nativeModelReasoningPolicy string
 	parallel                   int
+	parallelSet                bool
 	total                      int
 	missionOffset              int
 	campaignID                 string
 	campaignStatePath          string
 	progressJSONL              string
+	progressEvents             string
 	outRoot                    string
 	failFast                   bool
+	schedule                   string
 	strict                     bool
 	strictExpect               bool
 	captureRunnerIO            bool
 	runnerIOMaxBytes           int64
 	runnerIORaw                bool
+	nativeEventsRaw            bool
+	chaos                      string
+	vcrMode                    string
+	vcrFrom                    string
+	sandbox                    string
+	network                    string
+	allowHosts                 []string
+	diskQuotaMB                int64
+	home                       string
+	homeTemplate               string
 	shims                      []string
+	missionIDs                 []string
+	watch                      bool
+	watchDebounce              time.Duration
 	jsonOut                    bool
 	help                       bool
 	argv                       []string
@@ -240,10 +296,20 @@ type suiteRunHostConfig struct {
 	nativeMode                    bool
 	runtimeStrategyChain          []string
 	nativeRuntimeSelection        native.ResolveResult
+	chaosProfile                  string
 	resolvedNativeModel           string
 	resolvedNativeReasoningEffort string
 	resolvedNativeReasoningPolicy string
 	runnerCwdPolicy               suiteRunRunnerCwdPolicy
+	container                     *container.Spec
+	sandbox                       string
+	network                       string
+	allowHosts                    []string
+	limits                        *container.Limits
+	home                          string
+	homeTemplate                  string
+	remote                        *remote.Spec
+	claudeCLI                     bool
 }
 
 type suiteRunSuiteSettings struct {
@@ -255,8 +321,10 @@ type suiteRunSuiteSettings struct {
 	timeoutStart     string
 	blind            bool
 	blindTerms       []string
+	blindAction      string
 	total            int
 	missions         []suite.MissionV1
+	mcpServers       map[string]suite.MCPServerV1
 }
 
 type suiteRunExecutionPlan struct {
@@ -267,6 +335,7 @@ type suiteRunExecutionPlan struct {
 	summary      suiteRunSummary
 	execOpts     suiteRunExecOpts
 	initialRunID string
+	invocation   schema.RunInvocationJSONV1
 }
 
 func (r Runner) parseSuiteRunCLIInput(args []string) (suiteRunCLIInput, bool) {
@@ -285,26 +354,45 @@ func (r Runner) parseSuiteRunCLIInput(args []string) (suiteRunCLIInput, bool) {
 	resultMinTurn := fs.Int("result-min-turn", campaign.DefaultMinResultTurn, "minimum turn index accepted for auto result finalization (default 1)")
 	blindOverride := fs.String("blind", "", "optional blind-mode override: on|off")
 	blindTermsCSV := fs.String("blind-terms", "", "optional comma-separated blind harness terms override")
+	blindTermsPack := fs.String("blind-terms-pack", "", "blind term pack: generic-harness|codex|claude or a suite blindTermPacks name (--blind-terms are added)")
+	blindAction := fs.String("blind-action", "", "contaminated prompt handling in blind mode: reject|rewrite (default from suite defaults, else reject)")
 	sessionIsolation := fs.String("session-isolation", "auto", "session isolation strategy: auto|process|native")
 	runtimeStrategiesCSV := fs.String("runtime-strategies", "", "ordered native runtime strategy chain (comma-separated; default from config/env)")
 	nativeModel := fs.String("native-model", "", "native thread/start model override")
 	nativeModelReasoningEffort := fs.String("native-model-reasoning-effort", "", "native thread/start model reasoning effort hint: none|minimal|low|medium|high|xhigh")
 	nativeModelReasoningPolicy := fs.String("native-model-reasoning-policy", "", "native reasoning policy when effort is unsupported: best_effort|required")
-	parallel := fs.Int("parallel", 1, "max concurrent attempt waves (just-in-time allocation)")
+	parallel := fs.Int("parallel", 1, "max concurrent attempts (workers pick up the next mission as soon as one finishes)")
 	total := fs.Int("total", 0, "total attempts to run (default = number of suite missions)")
 	missionOffset := fs.Int("mission-offset", 0, "0-based mission offset before scheduling (for campaign resume/canary windows)")
 	campaignID := fs.String("campaign-id", "", "campaign id for cross-run continuity (default suiteId)")
 	campaignStatePath := fs.String("campaign-state", "", "path to campaign.state.json (default <outRoot>/campaigns/<campaignId>/campaign.state.json)")
 	progressJSONL := fs.String("progress-jsonl", "", "write structured progress events to path or '-' (stderr)")
+	progressEvents := fs.String("progress-events", "", "comma-separated progress event kinds to write (default all)")
 	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
 	failFast := fs.Bool("fail-fast", true, "stop scheduling new missions after the first failed attempt and mark the remainder as skipped")
+	schedule := fs.String("schedule", suiteRunScheduleSuite, "mission dispatch order: suite|longest-first (longest-first: predicted from past passing attempts in the out-root)")
 	strict := fs.Bool("strict", true, "run finish in strict mode (enforces evidence + contract)")
 	strictExpect := fs.Bool("strict-expect", true, "strict mode for expect (missing suite.json/feedback.json fails)")
 	captureRunnerIO := fs.Bool("capture-runner-io", true, "capture runner stdout/stderr to runner.* logs under the attempt dir")
-	runnerIOMaxBytes := fs.Int64("runner-io-max-bytes", schema.CaptureMaxBytesV1, "max bytes to keep per runner stream when using --capture-runner-io (tail)")
+	runnerIOMaxBytes := fs.Int64("runner-io-max-bytes", schema.CaptureMaxBytesV1, "max bytes to keep per runner stream when using --capture-runner-io (tail; beyond 1 MiB it is held in a temp file)")
 	runnerIORaw := fs.Bool("runner-io-raw", false, "capture raw runner stdout/stderr (unsafe; may contain secrets)")
+	nativeEventsRaw := fs.Bool("native-events-raw", false, "store native runtime event payloads in the trace without redaction (unsafe; may contain secrets)")
+	chaosPath := fs.String("chaos", "", "chaos profile (.json|.yaml|.yml) injecting stream disconnects, slow turns, rate limits and crashes into native sessions (default $ZCL_CHAOS_PROFILE)")
+	vcrMode := fs.String("vcr", "", "record shim/MCP tool responses per attempt (record) or serve them from --vcr-from (replay)")
+	vcrFrom := fs.String("vcr-from", "", "replay source: run dir, attempt dir or tool.cassette.jsonl (required with --vcr replay)")
+	sandboxKind := fs.String("sandbox", "", "confine process-mode runners: none|bwrap (bwrap: attempt dir writable, repo read-only, no $HOME)")
+	network := fs.String("network", "", "network access for process/container runners: host|none|advisory-allowlist (none: no network namespace egress; advisory-allowlist: proxy env refusing and logging hosts outside --allow-host, not enforced)")
+	var allowHosts stringListFlag
+	fs.Var(&allowHosts, "allow-host", "host the --network advisory-allowlist egress proxy lets through (repeatable; *.example.com allows subdomains)")
+	diskQuotaMB := fs.Int64("disk-quota-mb", 0, "kill a process runner whose attempt dir + workspace grow past N MiB (ZCL_E_DISK_QUOTA; 0 = no quota)")
+	homeMode := fs.String("home", "", "runner HOME: inherit|ephemeral (ephemeral: fresh per-attempt HOME, XDG and tool config dirs)")
+	homeTemplate := fs.String("home-template", "", "directory copied into each ephemeral HOME (requires --home ephemeral)")
 	var shims stringListFlag
 	fs.Var(&shims, "shim", "install attempt-local shims for tool binaries (repeatable; e.g. --shim tool-cli)")
+	var missionIDs stringListFlag
+	fs.Var(&missionIDs, "mission", "only run this mission id (repeatable; default all suite missions)")
+	watch := fs.Bool("watch", false, "re-run affected missions when the suite file or files it references change")
+	watchDebounce := fs.Duration("watch-debounce", 300*time.Millisecond, "quiet period after the last change before --watch re-runs")
 	jsonOut := fs.Bool("json", false, "print JSON output (required)")
 	help := fs.Bool("help", false, "show help")
 	if err := fs.Parse(args); err != nil {
@@ -314,6 +402,8 @@ func (r Runner) parseSuiteRunCLIInput(args []string) (suiteRunCLIInput, bool) {
 	if len(argv) > 0 && argv[0] == "--" {
 		argv = argv[1:]
 	}
+	parallelSet := false
+	fs.Visit(func(f *flag.Flag) { parallelSet = parallelSet || f.Name == "parallel" })
 	return suiteRunCLIInput{
 		file:                       *file,
 		runID:                      *runID,
@@ -328,25 +418,43 @@ func (r Runner) parseSuiteRunCLIInput(args []string) (suiteRunCLIInput, bool) {
 		resultMinTurn:              *resultMinTurn,
 		blindOverride:              *blindOverride,
 		blindTermsCSV:              *blindTermsCSV,
+		blindTermsPack:             *blindTermsPack,
+		blindAction:                *blindAction,
 		sessionIsolation:           *sessionIsolation,
 		runtimeStrategiesCSV:       *runtimeStrategiesCSV,
 		nativeModel:                *nativeModel,
 		nativeModelReasoningEffort: *nativeModelReasoningEffort,
 		nativeModelReasoningPolicy: *nativeModelReasoningPolicy,
 		parallel:                   *parallel,
+		parallelSet:                parallelSet,
 		total:                      *total,
 		missionOffset:              *missionOffset,
 		campaignID:                 *campaignID,
 		campaignStatePath:          *campaignStatePath,
 		progressJSONL:              *progressJSONL,
+		progressEvents:             *progressEvents,
 		outRoot:                    *outRoot,
 		failFast:                   *failFast,
+		schedule:                   strings.TrimSpace(*schedule),
 		strict:                     *strict,
 		strictExpect:               *strictExpect,
 		captureRunnerIO:            *captureRunnerIO,
 		runnerIOMaxBytes:           *runnerIOMaxBytes,
 		runnerIORaw:                *runnerIORaw,
+		nativeEventsRaw:            *nativeEventsRaw,
+		chaos:                      *chaosPath,
+		vcrMode:                    *vcrMode,
+		vcrFrom:                    *vcrFrom,
+		sandbox:                    *sandboxKind,
+		network:                    *network,
+		allowHosts:                 append([]string(nil), allowHosts...),
+		diskQuotaMB:                *diskQuotaMB,
+		home:                       *homeMode,
+		homeTemplate:               *homeTemplate,
 		shims:                      []string(shims),
+		missionIDs:                 []string(missionIDs),
+		watch:                      *watch,
+		watchDebounce:              *watchDebounce,
 		jsonOut:                    *jsonOut,
 		help:                       *help,
 		argv:                       argv,
@@ -381,12 +489,80 @@ func validateSuiteRunCLIInput(input suiteRunCLIInput) string {
 	if input.resultMinTurn < 1 {
 		return "suite run: --result-min-turn must be >= 1"
 	}
+	if strings.TrimSpace(input.progressEvents) != "" {
+		if strings.TrimSpace(input.progressJSONL) == "" {
+			return "suite run: --progress-events requires --progress-jsonl"
+		}
+		if _, err := parseSuiteRunProgressEvents(input.progressEvents); err != nil {
+			return "suite run: invalid --progress-events: " + err.Error()
+		}
+	}
+	if !isValidSuiteRunSchedule(input.schedule) {
+		return "suite run: invalid --schedule (expected suite|longest-first)"
+	}
+	if input.watch && input.watchDebounce <= 0 {
+		return "suite run: --watch-debounce must be > 0"
+	}
 	if !schema.IsValidTimeoutStartV1(strings.TrimSpace(input.timeoutStart)) {
 		return "suite run: invalid --timeout-start (expected attempt_start|first_tool_call)"
 	}
+	vcrMode, err := vcr.ParseMode(input.vcrMode)
+	if err != nil {
+		return "suite run: invalid --vcr (expected record|replay)"
+	}
+	if vcrMode == vcr.ModeReplay && strings.TrimSpace(input.vcrFrom) == "" {
+		return "suite run: --vcr replay requires --vcr-from"
+	}
+	if vcrMode != vcr.ModeReplay && strings.TrimSpace(input.vcrFrom) != "" {
+		return "suite run: --vcr-from requires --vcr replay"
+	}
+	if _, err := sandbox.ParseKind(input.sandbox); err != nil {
+		return "suite run: invalid --sandbox (expected none|bwrap)"
+	}
+	network, err := sandbox.ParseNetwork(input.network)
+	if err != nil {
+		return "suite run: " + err.Error()
+	}
+	if network == sandbox.NetworkAdvisoryAllowlist && len(input.allowHosts) == 0 {
+		return "suite run: --network advisory-allowlist requires at least one --allow-host"
+	}
+	if network != sandbox.NetworkAdvisoryAllowlist && len(input.allowHosts) > 0 {
+		return "suite run: --allow-host requires --network advisory-allowlist"
+	}
+	if input.diskQuotaMB < 0 {
+		return "suite run: --disk-quota-mb must be >= 0"
+	}
+	homeMode, err := home.ParseMode(input.home)
+	if err != nil {
+		return "suite run: invalid --home (expected inherit|ephemeral)"
+	}
+	if homeMode != home.ModeEphemeral && strings.TrimSpace(input.homeTemplate) != "" {
+		return "suite run: --home-template requires --home ephemeral"
+	}
 	return ""
 }
 
+// suiteRunAttemptEnv adds the --vcr settings to the env exported to every attempt, so shim-routed
+// `zcl run` calls and `zcl mcp proxy` pick them up.
+func suiteRunAttemptEnv(input suiteRunCLIInput, extraAttemptEnv map[string]string) map[string]string {
+	env := copyStringMap(extraAttemptEnv)
+	mode, _ := vcr.ParseMode(input.vcrMode)
+	if mode == "" {
+		return env
+	}
+	if env == nil {
+		env = map[string]string{}
+	}
+	env[vcr.ModeEnvKey] = mode
+	if from := strings.TrimSpace(input.vcrFrom); from != "" {
+		if abs, err := filepath.Abs(from); err == nil {
+			from = abs
+		}
+		env[vcr.FromEnvKey] = from
+	}
+	return env
+}
+
 func (r Runner) resolveSuiteRunHostConfig(input suiteRunCLIInput, extraAttemptEnv map[string]string) (suiteRunHostConfig, bool, int) {
 	merged, err := config.LoadMerged(input.outRoot)
 	if err != nil {
@@ -405,6 +581,9 @@ func (r Runner) resolveSuiteRunHostConfig(input suiteRunCLIInput, extraAttemptEn
 		printSuiteRunHelp(r.Stderr)
 		return suiteRunHostConfig{}, false, r.failUsage("suite run: missing runner command (use: zcl suite run ... -- <runner-cmd> ...)")
 	}
+	if nativeMode {
+		input = applySuiteRunProfileNative(input, merged)
+	}
 	model, effort, policy, ok, msg := resolveSuiteRunNativeModelConfig(input, nativeMode)
 	if !ok {
 		return suiteRunHostConfig{}, false, r.failUsage(msg)
@@ -416,14 +595,47 @@ func (r Runner) resolveSuiteRunHostConfig(input suiteRunCLIInput, extraAttemptEn
 	if runnerCwdPolicy.Mode != campaign.RunnerCwdModeInherit && !nativeMode {
 		return suiteRunHostConfig{}, false, r.failUsage("suite run: runner cwd policy requires --session-isolation native")
 	}
+	containerSpec, err := resolveSuiteRunContainer(extraAttemptEnv, nativeMode)
+	if err != nil {
+		return suiteRunHostConfig{}, false, r.failUsage("suite run: " + err.Error())
+	}
+	sandboxKind, err := resolveSuiteRunSandbox(input.sandbox, nativeMode, containerSpec != nil)
+	if err != nil {
+		return suiteRunHostConfig{}, false, r.failUsage("suite run: " + err.Error())
+	}
+	network, containerSpec, err := resolveSuiteRunNetwork(input.network, nativeMode, containerSpec)
+	if err != nil {
+		return suiteRunHostConfig{}, false, r.failUsage("suite run: " + err.Error())
+	}
+	homeMode, homeTemplate, err := resolveSuiteRunHome(input.home, input.homeTemplate, nativeMode, containerSpec != nil)
+	if err != nil {
+		return suiteRunHostConfig{}, false, r.failUsage("suite run: " + err.Error())
+	}
+	runnerLimits, err := resolveSuiteRunLimits(extraAttemptEnv, nativeMode, containerSpec != nil)
+	if err != nil {
+		return suiteRunHostConfig{}, false, r.failUsage("suite run: " + err.Error())
+	}
+	remoteSpec, err := resolveSuiteRunRemote(extraAttemptEnv, nativeMode, containerSpec != nil, sandboxKind, network, runnerLimits != nil, homeMode)
+	if err != nil {
+		return suiteRunHostConfig{}, false, r.failUsage("suite run: " + err.Error())
+	}
+	claudeCLI, err := resolveSuiteRunClaudeCLI(extraAttemptEnv, nativeMode)
+	if err != nil {
+		return suiteRunHostConfig{}, false, r.failUsage("suite run: " + err.Error())
+	}
 	runtimeStrategyChain := config.ParseRuntimeStrategyCSV(input.runtimeStrategiesCSV)
 	if len(runtimeStrategyChain) == 0 {
 		runtimeStrategyChain = append([]string(nil), merged.RuntimeStrategyChain...)
 	}
+	chaosPath, chaosProfile, err := resolveSuiteRunChaos(input.chaos, extraAttemptEnv, nativeMode)
+	if err != nil {
+		return suiteRunHostConfig{}, false, r.failUsage("suite run: " + err.Error())
+	}
 	nativeRuntimeSelection, ok, code := r.resolveSuiteRunNativeSelection(nativeMode, runtimeStrategyChain)
 	if !ok {
 		return suiteRunHostConfig{}, false, code
 	}
+	nativeRuntimeSelection = wrapSuiteRunChaos(nativeRuntimeSelection, chaosPath, chaosProfile)
 	return suiteRunHostConfig{
 		merged:                        merged,
 		hostNativeCapable:             hostNativeCapable,
@@ -432,13 +644,49 @@ func (r Runner) resolveSuiteRunHostConfig(input suiteRunCLIInput, extraAttemptEn
 		nativeMode:                    nativeMode,
 		runtimeStrategyChain:          runtimeStrategyChain,
 		nativeRuntimeSelection:        nativeRuntimeSelection,
+		chaosProfile:                  chaosPath,
 		resolvedNativeModel:           model,
 		resolvedNativeReasoningEffort: effort,
 		resolvedNativeReasoningPolicy: policy,
 		runnerCwdPolicy:               runnerCwdPolicy,
+		container:                     containerSpec,
+		sandbox:                       sandboxKind,
+		network:                       network,
+		allowHosts:                    suiteRunAllowHosts(input.allowHosts),
+		limits:                        runnerLimits,
+		claudeCLI:                     claudeCLI,
+		home:                          homeMode,
+		homeTemplate:                  homeTemplate,
+		remote:                        remoteSpec,
 	}, true, 0
 }
 
+// applySuiteRunProfileNative fills native model flags that were not given from the selected profile.
+func applySuiteRunProfileNative(input suiteRunCLIInput, merged config.Merged) suiteRunCLIInput {
+	if strings.TrimSpace(input.nativeModel) == "" {
+		input.nativeModel = merged.NativeModel
+	}
+	if strings.TrimSpace(input.nativeModelReasoningEffort) == "" {
+		input.nativeModelReasoningEffort = merged.NativeReasoningEffort
+		if strings.TrimSpace(input.nativeModelReasoningPolicy) == "" {
+			input.nativeModelReasoningPolicy = merged.NativeReasoningPolicy
+		}
+	}
+	return input
+}
+
+// applySuiteRunProfileBudgets applies profile budgets where the flags were not given; a profile
+// timeout wins over the suite file's defaults.timeoutMs.
+func applySuiteRunProfileBudgets(input suiteRunCLIInput, merged config.Merged) suiteRunCLIInput {
+	if input.timeoutMs == 0 && merged.Budgets.TimeoutMs > 0 {
+		input.timeoutMs = merged.Budgets.TimeoutMs
+	}
+	if !input.parallelSet && merged.Budgets.Parallel > 0 {
+		input.parallel = merged.Budgets.Parallel
+	}
+	return input
+}
+
 func resolveSuiteRunIsolation(raw string, hostNativeCapable bool) (string, string, bool, bool) {
 	requested := strings.ToLower(strings.TrimSpace(raw))
 	if requested == "" {
@@ -526,11 +774,27 @@ func (r Runner) resolveSuiteRunExecutionPlan(input suiteRunCLIInput, host suiteR
 	if !ok {
 		return suiteRunExecutionPlan{}, false, code
 	}
-	summary, ok, code := r.buildSuiteRunSummary(input, host, parsed, settings)
+	settings.mcpServers, err = resolveSuiteRunMCPServers(parsed.Suite.Defaults.MCPServers, extraAttemptEnv, host.nativeMode)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": suite run: %s\n", err.Error())
+		return suiteRunExecutionPlan{}, false, 2
+	}
+	runnerCmd, runnerArgs := splitSuiteRunRunnerCommand(input.argv)
+	envFingerprint := r.suiteRunEnvFingerprint(host, runnerCmd, input.shims)
+	summary, ok, code := r.buildSuiteRunSummary(input, host, parsed, settings, envFingerprint.Hash)
 	if !ok {
 		return suiteRunExecutionPlan{}, false, code
 	}
-	runnerCmd, runnerArgs := splitSuiteRunRunnerCommand(input.argv)
+	encryptRcpt, err := host.merged.Encryption.ParsedRecipient()
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
+		return suiteRunExecutionPlan{}, false, 2
+	}
+	feedbackKeyID, err := sigkey.FeedbackKeyID(feedbackSigningKeyPath(extraAttemptEnv))
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": suite run: %s\n", err.Error())
+		return suiteRunExecutionPlan{}, false, 2
+	}
 	execOpts := suiteRunExecOpts{
 		RunnerCmd:        runnerCmd,
 		RunnerArgs:       runnerArgs,
@@ -548,13 +812,31 @@ func (r Runner) resolveSuiteRunExecutionPlan(input suiteRunCLIInput, host suiteR
 		CaptureRunnerIO:  input.captureRunnerIO,
 		RunnerIOMaxBytes: input.runnerIOMaxBytes,
 		RunnerIORaw:      input.runnerIORaw,
+		NativeEventsRaw:  input.nativeEventsRaw,
+		MCPServers:       settings.mcpServers,
 		Shims:            append([]string(nil), input.shims...),
 		ZCLExe:           resolveSuiteRunZCLExecutable(),
 		Blind:            settings.blind,
 		BlindTerms:       append([]string(nil), settings.blindTerms...),
+		BlindAction:      settings.blindAction,
 		IsolationModel:   host.effectiveIsolation,
-		ExtraEnv:         copyStringMap(extraAttemptEnv),
+		ExtraEnv:         suiteRunAttemptEnv(input, extraAttemptEnv),
 		RunnerCwdPolicy:  host.runnerCwdPolicy,
+		Container:        host.container,
+		Sandbox:          host.sandbox,
+		Network:          host.network,
+		AllowHosts:       append([]string(nil), host.allowHosts...),
+		Limits:           host.limits,
+		DiskQuotaBytes:   input.diskQuotaMB << 20,
+		Home:             host.home,
+		HomeTemplate:     host.homeTemplate,
+		Remote:           host.remote,
+		ClaudeCLI:        host.claudeCLI,
+		EnvFingerprint:   &envFingerprint,
+		NetworkRequired:  suiteRunNetworkRequiredMissions(parsed),
+		OutRoot:          host.merged.OutRoot,
+		EncryptRecipient: encryptRcpt,
+		FeedbackKeyID:    feedbackKeyID,
 	}
 	return suiteRunExecutionPlan{
 		input:        input,
@@ -608,11 +890,19 @@ func (r Runner) resolveSuiteRunSuiteSettings(input suiteRunCLIInput, parsed suit
 	if !ok {
 		return suiteRunSuiteSettings{}, false, code
 	}
+	blindAction, ok, code := r.resolveSuiteRunBlindAction(input, parsed, blind)
+	if !ok {
+		return suiteRunSuiteSettings{}, false, code
+	}
+	pool, unknown := filterSuiteRunMissions(parsed.Suite.Missions, input.missionIDs)
+	if unknown != "" {
+		return suiteRunSuiteSettings{}, false, r.failUsage(fmt.Sprintf("suite run: unknown --mission %q", unknown))
+	}
 	total := input.total
 	if total == 0 {
-		total = len(parsed.Suite.Missions)
+		total = len(pool)
 	}
-	if total <= 0 {
+	if total <= 0 || len(pool) == 0 {
 		return suiteRunSuiteSettings{}, false, r.failUsage("suite run: no missions to run")
 	}
 	return suiteRunSuiteSettings{
@@ -624,8 +914,9 @@ func (r Runner) resolveSuiteRunSuiteSettings(input suiteRunCLIInput, parsed suit
 		timeoutStart:     timeoutStart,
 		blind:            blind,
 		blindTerms:       blindTerms,
+		blindAction:      blindAction,
 		total:            total,
-		missions:         selectSuiteRunMissions(parsed.Suite.Missions, total, input.missionOffset),
+		missions:         selectSuiteRunMissions(pool, total, input.missionOffset),
 	}, true, 0
 }
 
@@ -702,9 +993,9 @@ func (r Runner) resolveSuiteRunBlindSettings(input suiteRunCLIInput, parsed suit
 	default:
 		return false, nil, false, r.failUsage("suite run: invalid --blind (expected on|off)")
 	}
-	blindTerms := append([]string(nil), parsed.Suite.Defaults.BlindTerms...)
-	if strings.TrimSpace(input.blindTermsCSV) != "" {
-		blindTerms = blind.ParseTermsCSV(input.blindTermsCSV)
+	blindTerms, err := parsed.Suite.ResolveBlindTerms(strings.ToLower(input.blindTermsPack), blind.ParseTermsCSV(input.blindTermsCSV))
+	if err != nil {
+		return false, nil, false, r.failUsage("suite run: invalid --blind-terms-pack: " + err.Error())
 	}
 	if blindMode && len(blindTerms) == 0 {
 		blindTerms = blind.DefaultHarnessTermsV1()
@@ -712,6 +1003,50 @@ func (r Runner) resolveSuiteRunBlindSettings(input suiteRunCLIInput, parsed suit
 	return blindMode, blindTerms, true, 0
 }
 
+// resolveSuiteRunBlindAction picks how blind mode handles a contaminated prompt (--blind-action,
+// else suite defaults.blindAction, else reject). It is empty when blind mode is off.
+func (r Runner) resolveSuiteRunBlindAction(input suiteRunCLIInput, parsed suite.ParsedSuite, blindMode bool) (string, bool, int) {
+	action := strings.ToLower(strings.TrimSpace(input.blindAction))
+	if action != "" && !blind.IsValidAction(action) {
+		return "", false, r.failUsage("suite run: invalid --blind-action (expected reject|rewrite)")
+	}
+	if !blindMode {
+		return "", true, 0
+	}
+	if action == "" {
+		action = parsed.Suite.Defaults.BlindAction
+	}
+	if action == "" {
+		action = blind.ActionReject
+	}
+	return action, true, 0
+}
+
+// filterSuiteRunMissions keeps the missions named by --mission (suite order); it returns the
+// first id that matches no mission.
+func filterSuiteRunMissions(all []suite.MissionV1, missionIDs []string) ([]suite.MissionV1, string) {
+	if len(missionIDs) == 0 {
+		return all, ""
+	}
+	want := map[string]bool{}
+	for _, id := range missionIDs {
+		want[ids.SanitizeComponent(id)] = true
+	}
+	out := make([]suite.MissionV1, 0, len(want))
+	for _, m := range all {
+		if want[m.MissionID] {
+			out = append(out, m)
+			delete(want, m.MissionID)
+		}
+	}
+	for _, id := range missionIDs {
+		if want[ids.SanitizeComponent(id)] {
+			return nil, id
+		}
+	}
+	return out, ""
+}
+
 func selectSuiteRunMissions(all []suite.MissionV1, total int, missionOffset int) []suite.MissionV1 {
 	missions := make([]suite.MissionV1, 0, total)
 	for i := 0; i < total; i++ {
@@ -721,7 +1056,7 @@ func selectSuiteRunMissions(all []suite.MissionV1, total int, missionOffset int)
 	return missions
 }
 
-func (r Runner) buildSuiteRunSummary(input suiteRunCLIInput, host suiteRunHostConfig, parsed suite.ParsedSuite, settings suiteRunSuiteSettings) (suiteRunSummary, bool, int) {
+func (r Runner) buildSuiteRunSummary(input suiteRunCLIInput, host suiteRunHostConfig, parsed suite.ParsedSuite, settings suiteRunSuiteSettings, envFingerprint string) (suiteRunSummary, bool, int) {
 	summary := suiteRunSummary{
 		SchemaVersion:             1,
 		OK:                        true,
@@ -738,6 +1073,7 @@ func (r Runner) buildSuiteRunSummary(input suiteRunCLIInput, host suiteRunHostCo
 	}
 	if host.nativeMode {
 		summary.RuntimeStrategySelected = string(host.nativeRuntimeSelection.Selected)
+		summary.ChaosProfile = host.chaosProfile
 	}
 	summary.CampaignProfile = suiteRunCampaignProfile{
 		Mode:            settings.mode,
@@ -757,7 +1093,11 @@ func (r Runner) buildSuiteRunSummary(input suiteRunCLIInput, host suiteRunHostCo
 		MissionOffset:   input.missionOffset,
 		FailFast:        input.failFast,
 		Blind:           settings.blind,
+		BlindAction:     settings.blindAction,
+		Chaos:           host.chaosProfile != "",
 		Shims:           dedupeSortedStrings(input.shims),
+		MCPServers:      sortedMCPServerNames(settings.mcpServers),
+		EnvFingerprint:  envFingerprint,
 	}
 	summary.ComparabilityKey = suiteRunComparabilityKey(summary.CampaignProfile)
 	summary.CampaignID = ids.SanitizeComponent(strings.TrimSpace(input.campaignID))
@@ -798,7 +1138,8 @@ func resolveSuiteRunZCLExecutable() string {
 }
 
 func (r Runner) runSuiteRunExecution(plan suiteRunExecutionPlan) int {
-	progress, err := newSuiteRunProgressEmitter(strings.TrimSpace(plan.input.progressJSONL), r.Stderr)
+	progressKinds, _ := parseSuiteRunProgressEvents(plan.input.progressEvents)
+	progress, err := newSuiteRunProgressEmitter(strings.TrimSpace(plan.input.progressJSONL), progressKinds, r.Stderr)
 	if err != nil {
 		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
 		return 1
@@ -815,9 +1156,18 @@ func (r Runner) runSuiteRunExecution(plan suiteRunExecutionPlan) int {
 		fmt.Fprintf(r.Stderr, codeIO+": suite run progress: %s\n", err.Error())
 		return 1
 	}
-	results, currentRunID, harnessErr := r.executeSuiteRunMissions(plan, errWriter)
+	owner := &suiteRunOwner{outRoot: plan.host.merged.OutRoot, invocation: plan.invocation}
+	defer owner.release()
+	if err := owner.claim(plan.initialRunID); err != nil {
+		fmt.Fprintf(r.Stderr, "%s: suite run: %s\n", suiteRunClaimErrorCode(err), err.Error())
+		return 1
+	}
+	order, schedule := planSuiteRunDispatch(plan)
+	plan.summary.Schedule = schedule
+	results, currentRunID, harnessErr := r.executeSuiteRunMissions(plan, order, owner, errWriter)
+	plan.summary.NativeScheduler = plan.execOpts.NativeScheduler.summary()
 	plan.summary = finalizeSuiteRunSummary(plan.summary, results, currentRunID)
-	harnessErr = updateSuiteRunCampaignState(r, &plan.summary, harnessErr)
+	harnessErr = updateSuiteRunCampaignState(r, &plan.summary, plan.host.merged.CampaignState, harnessErr)
 	harnessErr = emitSuiteRunFinished(r, progress, &plan.summary, harnessErr)
 	if err := encodeSuiteRunSummary(r.Stdout, plan.summary); err != nil {
 		fmt.Fprintf(r.Stderr, codeIO+": failed to encode json\n")
@@ -832,7 +1182,7 @@ func emitSuiteRunStarted(r Runner, progress *suiteRunProgressEmitter, summary su
 	}
 	return progress.Emit(suiteRunProgressEvent{
 		TS:         r.Now().UTC().Format(time.RFC3339Nano),
-		Kind:       "run_started",
+		Kind:       suiteRunProgressRunStarted,
 		RunID:      summary.RunID,
 		SuiteID:    summary.SuiteID,
 		Mode:       summary.Mode,
@@ -847,7 +1197,66 @@ func emitSuiteRunStarted(r Runner, progress *suiteRunProgressEmitter, summary su
 	})
 }
 
-func (r Runner) executeSuiteRunMissions(plan suiteRunExecutionPlan, errWriter io.Writer) ([]suiteRunAttemptResult, string, bool) {
+// suiteRunOwner holds the run's owner lock for the whole suite run, so a second `zcl suite run`
+// with the same --run-id fails fast instead of interleaving attempts and summaries. A run ID left
+// to attempt.Start is claimed right after it is allocated. The claim also records the invocation
+// (run.invocation.json) for the first suite run into the run.
+type suiteRunOwner struct {
+	outRoot    string
+	invocation schema.RunInvocationJSONV1
+	runID      string
+	unlock     func() error
+}
+
+func (o *suiteRunOwner) claim(runID string) error {
+	if runID == "" || o.unlock != nil {
+		return nil
+	}
+	runDir := filepath.Join(o.outRoot, "runs", runID)
+	unlock, err := store.AcquireRunOwner(runDir)
+	if err != nil {
+		return err
+	}
+	o.runID, o.unlock = runID, unlock
+	path := filepath.Join(runDir, artifacts.RunInvocationJSON)
+	if _, err := os.Stat(path); os.IsNotExist(err) && len(o.invocation.Argv) > 0 {
+		inv := o.invocation
+		inv.RunID = runID
+		return store.WriteJSONAtomic(path, inv)
+	}
+	return nil
+}
+
+func suiteRunInvocation(r Runner, args []string, extraAttemptEnv map[string]string) schema.RunInvocationJSONV1 {
+	inv := schema.RunInvocationJSONV1{
+		SchemaVersion: 1,
+		ZCLVersion:    r.Version,
+		CreatedAt:     r.Now().UTC().Format(time.RFC3339Nano),
+		Argv:          append([]string{"suite", "run"}, args...),
+	}
+	inv.Cwd, _ = os.Getwd()
+	if len(extraAttemptEnv) > 0 {
+		inv.Env = native.DefaultEnvPolicy().RedactForLog(extraAttemptEnv)
+	}
+	return inv
+}
+
+func (o *suiteRunOwner) release() {
+	if o.unlock != nil {
+		_ = o.unlock()
+		o.unlock = nil
+	}
+}
+
+func suiteRunClaimErrorCode(err error) string {
+	var owned store.RunOwnedError
+	if errors.As(err, &owned) {
+		return codeRunLocked
+	}
+	return codeIO
+}
+
+func (r Runner) executeSuiteRunMissions(plan suiteRunExecutionPlan, order []int, owner *suiteRunOwner, errWriter io.Writer) ([]suiteRunAttemptResult, string, bool) {
 	results := initializeSuiteRunResults(plan.settings.missions, plan.host.effectiveIsolation, plan.input.strict, plan.input.strictExpect)
 	var (
 		startMu      sync.Mutex
@@ -855,40 +1264,32 @@ func (r Runner) executeSuiteRunMissions(plan suiteRunExecutionPlan, errWriter io
 		currentRunID = plan.initialRunID
 	)
 	runState := &suiteRunMissionRunState{
+		owner:        owner,
 		startMu:      &startMu,
 		harnessErr:   &harnessErr,
 		currentRunID: &currentRunID,
 		results:      results,
 		errWriter:    errWriter,
+		throughput:   newSuiteRunThroughput(len(results)),
 	}
-	waveSize := plan.input.parallel
-	if waveSize > len(plan.settings.missions) {
-		waveSize = len(plan.settings.missions)
-	}
-	for start := 0; start < len(plan.settings.missions); start += waveSize {
-		if plan.input.failFast && hasFailedAttempt(results) {
-			markSkippedAttempts(results, start, "fail_fast_prior_failure")
-			break
-		}
-		end := start + waveSize
-		if end > len(plan.settings.missions) {
-			end = len(plan.settings.missions)
-		}
-		r.executeSuiteRunWave(plan, runState, start, end)
-		if plan.input.failFast && hasFailedAttempt(results[start:end]) {
-			markSkippedAttempts(results, end, "fail_fast_prior_failure")
-			break
-		}
+	stopTicker := r.startSuiteRunProgressTicker(plan, runState)
+	dispatched := r.executeSuiteRunWorkers(plan, runState, order)
+	stopTicker()
+	for _, idx := range order[dispatched:] {
+		results[idx].Skipped = true
+		results[idx].SkipReason = "fail_fast_prior_failure"
 	}
 	return results, currentRunID, harnessErr.Load()
 }
 
 type suiteRunMissionRunState struct {
+	owner        *suiteRunOwner
 	startMu      *sync.Mutex
 	harnessErr   *atomic.Bool
 	currentRunID *string
 	results      []suiteRunAttemptResult
 	errWriter    io.Writer
+	throughput   *suiteRunThroughput
 }
 
 func initializeSuiteRunResults(missions []suite.MissionV1, isolationModel string, strict bool, strictExpect bool) []suiteRunAttemptResult {
@@ -908,24 +1309,29 @@ func initializeSuiteRunResults(missions []suite.MissionV1, isolationModel string
 	return results
 }
 
-func (r Runner) executeSuiteRunWave(plan suiteRunExecutionPlan, state *suiteRunMissionRunState, start int, end int) {
-	var wg sync.WaitGroup
-	for idx := start; idx < end; idx++ {
-		idx := idx
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			r.executeSuiteRunMissionIndex(plan, state, idx)
-		}()
-	}
-	wg.Wait()
+// executeSuiteRunWorkers runs missions on a pool of --parallel workers (see
+// dispatchSuiteRunMissions). It returns how many entries of order were handed out.
+func (r Runner) executeSuiteRunWorkers(plan suiteRunExecutionPlan, state *suiteRunMissionRunState, order []int) int {
+	return dispatchSuiteRunMissions(order, plan.input.parallel, plan.input.failFast,
+		func(idx int) func() {
+			state.throughput.attemptStarted()
+			return r.executeSuiteRunMissionIndex(plan, state, idx)
+		},
+		func(idx int) bool {
+			state.throughput.attemptFinished()
+			emitSuiteRunProgress(r, plan, state)
+			return hasFailedAttempt(state.results[idx : idx+1])
+		})
 }
 
-func (r Runner) executeSuiteRunMissionIndex(plan suiteRunExecutionPlan, state *suiteRunMissionRunState, idx int) {
+// executeSuiteRunMissionIndex runs one mission's attempt up to the end of its runner. It returns
+// the attempt's pending finish step (nil when the result is already final); the result lands in
+// state.results once that step ran.
+func (r Runner) executeSuiteRunMissionIndex(plan suiteRunExecutionPlan, state *suiteRunMissionRunState, idx int) func() {
 	mission := plan.settings.missions[idx]
 	started, ok := startSuiteRunAttempt(r, plan, state, mission, idx)
 	if !ok {
-		return
+		return nil
 	}
 	pm := planner.PlannedMission{
 		MissionID: mission.MissionID,
@@ -936,12 +1342,25 @@ func (r Runner) executeSuiteRunMissionIndex(plan suiteRunExecutionPlan, state *s
 		Env:       started.Env,
 	}
 	emitSuiteRunAttemptStarted(r, plan.execOpts.Progress, started, mission, state)
-	ar, hard := r.executeSuiteRunMission(pm, plan.execOpts)
-	ar.IsolationModel = plan.host.effectiveIsolation
-	if hard {
-		state.harnessErr.Store(true)
+	stopHeartbeat := startSuiteRunHeartbeat(r, pm)
+	ar, hard, finish := r.executeSuiteRunMission(pm, plan.execOpts)
+	record := func() {
+		ar.IsolationModel = plan.host.effectiveIsolation
+		if hard {
+			state.harnessErr.Store(true)
+		}
+		state.results[idx] = ar
+	}
+	if finish == nil {
+		stopHeartbeat()
+		record()
+		return nil
+	}
+	return func() {
+		finish(&ar, &hard)
+		stopHeartbeat()
+		record()
 	}
-	state.results[idx] = ar
 }
 
 func startSuiteRunAttempt(r Runner, plan suiteRunExecutionPlan, state *suiteRunMissionRunState, mission suite.MissionV1, idx int) (*attempt.StartResult, bool) {
@@ -959,10 +1378,15 @@ func startSuiteRunAttempt(r Runner, plan suiteRunExecutionPlan, state *suiteRunM
 		TimeoutStart:   plan.settings.timeoutStart,
 		Blind:          plan.settings.blind,
 		BlindTerms:     plan.settings.blindTerms,
+		TraceSampling:  plan.parsed.Suite.Defaults.AttemptTraceSampling(),
 		SuiteSnapshot:  plan.parsed.CanonicalJSON,
+		ZCLVersion:     r.Version,
+		RuntimeBins:    suiteRunRuntimeBins(plan),
+		FeedbackKeyID:  plan.execOpts.FeedbackKeyID,
 	})
 	if err == nil {
 		*state.currentRunID = started.RunID
+		err = state.owner.claim(started.RunID)
 	}
 	state.startMu.Unlock()
 	if err == nil {
@@ -975,13 +1399,29 @@ func startSuiteRunAttempt(r Runner, plan suiteRunExecutionPlan, state *suiteRunM
 	return nil, false
 }
 
+// suiteRunRuntimeBins names the binary that will run each attempt, for attempt.json provenance.
+func suiteRunRuntimeBins(plan suiteRunExecutionPlan) []string {
+	if plan.host.nativeMode {
+		if plan.host.nativeRuntimeSelection.Selected == native.StrategyCodexAppServer {
+			if cmd := codexappserver.DefaultCommandFromEnv(); len(cmd) > 0 {
+				return cmd[:1]
+			}
+		}
+		return nil
+	}
+	if plan.execOpts.RunnerCmd == "" {
+		return nil
+	}
+	return []string{plan.execOpts.RunnerCmd}
+}
+
 func emitSuiteRunAttemptStarted(r Runner, progress *suiteRunProgressEmitter, started *attempt.StartResult, mission suite.MissionV1, state *suiteRunMissionRunState) {
 	if progress == nil {
 		return
 	}
 	if err := progress.Emit(suiteRunProgressEvent{
 		TS:        r.Now().UTC().Format(time.RFC3339Nano),
-		Kind:      "attempt_started",
+		Kind:      suiteRunProgressAttemptStarted,
 		RunID:     started.RunID,
 		SuiteID:   started.SuiteID,
 		MissionID: mission.MissionID,
@@ -997,6 +1437,55 @@ func emitSuiteRunAttemptStarted(r Runner, progress *suiteRunProgressEmitter, sta
 	}
 }
 
+// startSuiteRunProgressTicker emits run_progress every suiteRunProgressInterval so dashboards keep
+// a fresh ETA while long attempts run; completions emit one as well.
+func (r Runner) startSuiteRunProgressTicker(plan suiteRunExecutionPlan, state *suiteRunMissionRunState) func() {
+	if plan.execOpts.Progress == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(suiteRunProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				emitSuiteRunProgress(r, plan, state)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func emitSuiteRunProgress(r Runner, plan suiteRunExecutionPlan, state *suiteRunMissionRunState) {
+	progress := plan.execOpts.Progress
+	if progress == nil {
+		return
+	}
+	state.startMu.Lock()
+	runID := *state.currentRunID
+	state.startMu.Unlock()
+	if err := progress.Emit(suiteRunProgressEvent{
+		TS:         r.Now().UTC().Format(time.RFC3339Nano),
+		Kind:       suiteRunProgressRunProgress,
+		RunID:      runID,
+		SuiteID:    plan.summary.SuiteID,
+		Mode:       plan.summary.Mode,
+		CampaignID: plan.summary.CampaignID,
+		Details:    runProgressDetails(state.throughput.snapshot()),
+	}); err != nil {
+		state.harnessErr.Store(true)
+		fmt.Fprintf(state.errWriter, codeIO+": suite run progress: %s\n", err.Error())
+	}
+}
+
 func finalizeSuiteRunSummary(summary suiteRunSummary, results []suiteRunAttemptResult, runID string) suiteRunSummary {
 	summary.RunID = runID
 	for _, ar := range results {
@@ -1009,15 +1498,34 @@ func finalizeSuiteRunSummary(summary suiteRunSummary, results []suiteRunAttemptR
 		summary.Attempts = append(summary.Attempts, ar)
 	}
 	if summary.RunID != "" {
-		_ = store.WriteJSONAtomic(filepath.Join(summary.OutRoot, "runs", summary.RunID, artifacts.SuiteRunSummaryJSON), summary)
+		runDir := filepath.Join(summary.OutRoot, "runs", summary.RunID)
+		violations := validate.CheckRunConsistency(runDir)
+		summary.Consistency = &suiteRunConsistency{OK: len(violations) == 0, Violations: violations}
+		for _, v := range violations {
+			// Other consistency findings are reported; a native attempt that shared or switched
+			// sessions broke the zero-context guarantee, so its results cannot count.
+			if v.Code == codeSessionNotFresh {
+				summary.OK = false
+			}
+		}
+		_ = store.WriteJSONAtomic(filepath.Join(runDir, artifacts.SuiteRunSummaryJSON), summary)
 	}
 	return summary
 }
 
-func updateSuiteRunCampaignState(r Runner, summary *suiteRunSummary, harnessErr bool) bool {
+func updateSuiteRunCampaignState(r Runner, summary *suiteRunSummary, backend string, harnessErr bool) bool {
 	if summary.RunID == "" || summary.CampaignStatePath == "" {
 		return harnessErr
 	}
+	stateStore, err := openCampaignStateStore(backend, summary.OutRoot, summary.CampaignID)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": suite run campaign state: %s\n", err.Error())
+		summary.OK = false
+		return true
+	}
+	if stateStore != nil {
+		defer func() { _ = stateStore.Close() }()
+	}
 	if _, err := campaign.UpdateState(summary.CampaignStatePath, campaign.UpdateInput{
 		Now:              r.Now(),
 		CampaignID:       summary.CampaignID,
@@ -1034,6 +1542,7 @@ func updateSuiteRunCampaignState(r Runner, summary *suiteRunSummary, harnessErr
 		FailFast:         summary.CampaignProfile.FailFast,
 		Passed:           summary.Passed,
 		Failed:           summary.Failed,
+		Store:            stateStore,
 	}); err != nil {
 		fmt.Fprintf(r.Stderr, codeIO+": suite run campaign state: %s\n", err.Error())
 		summary.OK = false
@@ -1048,7 +1557,7 @@ func emitSuiteRunFinished(r Runner, progress *suiteRunProgressEmitter, summary *
 	}
 	if err := progress.Emit(suiteRunProgressEvent{
 		TS:         r.Now().UTC().Format(time.RFC3339Nano),
-		Kind:       "run_finished",
+		Kind:       suiteRunProgressRunFinished,
 		RunID:      summary.RunID,
 		SuiteID:    summary.SuiteID,
 		Mode:       summary.Mode,
@@ -1100,15 +1609,49 @@ type suiteRunExecOpts struct {
 	CaptureRunnerIO  bool
 	RunnerIOMaxBytes int64
 	RunnerIORaw      bool
-	Shims            []string
-	ZCLExe           string
-	Blind            bool
-	BlindTerms       []string
-	IsolationModel   string
-	StderrWriter     io.Writer
-	Progress         *suiteRunProgressEmitter
-	ExtraEnv         map[string]string
-	RunnerCwdPolicy  suiteRunRunnerCwdPolicy
+	NativeEventsRaw  bool
+	// MCPServers are registered with native sessions (suite defaults.mcpServers + flow runner.mcpServers).
+	MCPServers  map[string]suite.MCPServerV1
+	Shims       []string
+	ZCLExe      string
+	Blind       bool
+	BlindTerms  []string
+	BlindAction string
+	// BlindArgv is the runner argv as configured, captured before sandbox/container/ssh wrapping so
+	// blind checks scan what the adapter passes rather than the harness's wrapper args.
+	BlindArgv       []string
+	IsolationModel  string
+	StderrWriter    io.Writer
+	Progress        *suiteRunProgressEmitter
+	ExtraEnv        map[string]string
+	RunnerCwdPolicy suiteRunRunnerCwdPolicy
+	// Container, when set, runs each process-mode attempt in a fresh container (runner.type=docker).
+	Container *container.Spec
+	// Sandbox confines process-mode runners (--sandbox); "none" runs them unconfined.
+	Sandbox string
+	// Network is host, none (no network namespace egress) or advisory-allowlist (egress proxy
+	// limited to AllowHosts). NetworkRequired holds the requiresNetwork missions, blocked under none.
+	Network         string
+	AllowHosts      []string
+	NetworkRequired map[string]bool
+	// Limits, when set, run each process-mode runner in a transient cgroup scope (runner.limits).
+	Limits *container.Limits
+	// DiskQuotaBytes > 0 kills a process runner whose attempt dir + workspace exceed it.
+	DiskQuotaBytes int64
+	// Remote, when set, runs each process-mode runner on another machine over ssh (runner.type=ssh).
+	Remote *remote.Spec
+	// ClaudeCLI appends a fresh --session-id and the prompt to each attempt's runner argv
+	// (runner.type=claude_cli).
+	ClaudeCLI bool
+	// EnvFingerprint is the runner environment probed for this suite run (env.fingerprint.json).
+	EnvFingerprint *schema.EnvFingerprintJSONV1
+	// Home is inherit or ephemeral (fresh per-attempt HOME seeded from HomeTemplate).
+	Home             string
+	HomeTemplate     string
+	OutRoot          string
+	EncryptRecipient age.Recipient
+	// FeedbackKeyID is pinned into every attempt.json when runners sign feedback (ZCL_FEEDBACK_SIGNING_KEY).
+	FeedbackKeyID string
 }
 
 type suiteRunResultChannel struct {
@@ -1128,26 +1671,37 @@ type suiteRunAttemptRuntimeContext struct {
 	StartCwdMode   string
 	StartCwd       string
 	StartCwdRetain string
+	Sandbox        *sandbox.Profile
+	Home           *schema.AttemptHomeV1
 }
 
-func (r Runner) executeSuiteRunMission(pm planner.PlannedMission, opts suiteRunExecOpts) (suiteRunAttemptResult, bool) {
+// suiteRunFinishStep completes an attempt after its runner exited: finish pipeline, the
+// attempt_finished event and runner cwd cleanup.
+type suiteRunFinishStep func(ar *suiteRunAttemptResult, harnessErr *bool)
+
+func (r Runner) executeSuiteRunMission(pm planner.PlannedMission, opts suiteRunExecOpts) (suiteRunAttemptResult, bool, suiteRunFinishStep) {
 	return r.executeSuiteRunMissionImpl(pm, opts)
 }
 
-func (r Runner) executeSuiteRunMissionImpl(pm planner.PlannedMission, opts suiteRunExecOpts) (suiteRunAttemptResult, bool) {
+func (r Runner) executeSuiteRunMissionImpl(pm planner.PlannedMission, opts suiteRunExecOpts) (suiteRunAttemptResult, bool, suiteRunFinishStep) {
 	return r.executeSuiteRunMissionCore(pm, opts)
 }
 
-func (r Runner) executeSuiteRunMissionCore(pm planner.PlannedMission, opts suiteRunExecOpts) (suiteRunAttemptResult, bool) {
+func (r Runner) executeSuiteRunMissionCore(pm planner.PlannedMission, opts suiteRunExecOpts) (suiteRunAttemptResult, bool, suiteRunFinishStep) {
 	errWriter := suiteRunAttemptErrWriter(r, opts)
 	ar := newSuiteRunAttemptResult(pm, opts)
 	runtimeCtx, cleanupRunnerCwd, err := prepareSuiteRunAttemptStartCwd(pm, opts.RunnerCwdPolicy)
 	if err != nil {
 		ar.RunnerErrorCode = codeIO
 		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
-		return ar, true
+		return ar, true, nil
 	}
 	env := buildSuiteRunMissionEnv(pm, opts)
+	if err := writeSuiteRunEnvFingerprint(pm, opts); err != nil {
+		ar.RunnerErrorCode = codeIO
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+		return ar, true, nil
+	}
 
 	harnessErr := false
 	shouldFinish := true
@@ -1156,12 +1710,13 @@ func (r Runner) executeSuiteRunMissionCore(pm planner.PlannedMission, opts suite
 	} else {
 		harnessErr, shouldFinish = r.runSuiteMissionProcessPath(pm, opts, runtimeCtx, env, &ar, errWriter)
 	}
-	if shouldFinish {
-		finalizeSuiteRunAttemptResult(r, pm, opts, env, &ar)
-		emitSuiteRunAttemptFinished(r, opts, env, pm, ar)
+	return ar, harnessErr, func(ar *suiteRunAttemptResult, harnessErr *bool) {
+		if shouldFinish {
+			finalizeSuiteRunAttemptResult(r, pm, opts, env, ar)
+			emitSuiteRunAttemptFinished(r, opts, env, pm, *ar)
+		}
+		applySuiteRunRunnerCwdCleanup(cleanupRunnerCwd, harnessErr, ar, errWriter)
 	}
-	applySuiteRunRunnerCwdCleanup(cleanupRunnerCwd, &harnessErr, &ar, errWriter)
-	return ar, harnessErr
 }
 
 func suiteRunAttemptErrWriter(r Runner, opts suiteRunExecOpts) io.Writer {
@@ -1253,13 +1808,67 @@ type suiteRunProcessPathContext struct {
 
 func (r Runner) runSuiteMissionProcessPath(pm planner.PlannedMission, opts suiteRunExecOpts, runtimeCtx suiteRunAttemptRuntimeContext, env map[string]string, ar *suiteRunAttemptResult, errWriter io.Writer) (bool, bool) {
 	harnessErr, shimBinDir := installSuiteRunProcessShims(pm.OutDirAbs, opts, env, ar, errWriter)
+	runtimeCtx.Sandbox = suiteRunSandboxProfile(pm, opts, env)
+	homeRuntime, err := prepareSuiteRunHome(pm, opts, env)
+	if err != nil {
+		ar.RunnerErrorCode = codeIO
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+		return true, false
+	}
+	runtimeCtx.Home = homeRuntime
+	stopEgress, err := startSuiteRunEgressProxy(pm, opts, env)
+	if err != nil {
+		ar.RunnerErrorCode = codeIO
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+		return true, false
+	}
+	defer stopEgress()
+	if err := rewriteSuiteRunBlindPrompt(r, pm, opts); err != nil {
+		ar.RunnerErrorCode = codeIO
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+		return true, false
+	}
+	opts.BlindArgv = append([]string{opts.RunnerCmd}, opts.RunnerArgs...)
+	opts, err = appendSuiteRunClaudeCLIArgs(pm, opts, env)
+	if err != nil {
+		ar.RunnerErrorCode = codeIO
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+		return true, false
+	}
 	if err := writeAttemptRuntimeEnvArtifact(r.Now(), pm, env, opts, runtimeCtx); err != nil {
 		ar.RunnerErrorCode = codeIO
 		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
 		return true, false
 	}
+	opts = wrapSuiteRunSandboxRunner(opts, runtimeCtx.Sandbox)
+	opts = wrapSuiteRunNetworkRunner(opts, runtimeCtx.Sandbox)
+	opts = wrapSuiteRunLimitsRunner(pm, opts)
+	opts = wrapSuiteRunContainerRunner(pm, opts, env, shimBinDir)
+	defer removeSuiteRunContainer(opts, pm, ar)
+	opts, finishRemote, err := startSuiteRunRemote(pm, opts, env)
+	if err != nil {
+		ar.RunnerErrorCode = codeIO
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+		return true, false
+	}
 	pathCtx := prepareSuiteRunProcessPath(pm, opts, env, shimBinDir, ar, errWriter, &harnessErr)
-	harnessErr = executeSuiteRunProcessRunner(r, pm, opts, env, pathCtx.stdoutTB, pathCtx.stderrTB, ar, errWriter) || harnessErr
+	defer pathCtx.close()
+	ctx, stopDiskWatch := startSuiteRunDiskWatch(pm, opts, runtimeCtx)
+	ctx, stopResources := startSuiteRunResourceSampler(ctx, pm, opts)
+	harnessErr = executeSuiteRunProcessRunner(ctx, r, pm, opts, env, pathCtx.stdoutTB, pathCtx.stderrTB, ar, errWriter) || harnessErr
+	if err := stopResources(); err != nil {
+		harnessErr = true
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+	}
+	if err := finishRemote(); err != nil {
+		harnessErr = true
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+	}
+	classifySuiteRunResourceLimit(opts, ar)
+	if err := stopDiskWatch(ar); err != nil {
+		harnessErr = true
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+	}
 	pathCtx.stopRunnerLog(&harnessErr, ar)
 	if err := maybeFinalizeSuiteFeedback(r.Now(), env, ar, opts.FinalizationMode, opts.FeedbackPolicy, opts.ResultChannel, pathCtx.stdoutTB); err != nil {
 		harnessErr = true
@@ -1268,6 +1877,12 @@ func (r Runner) runSuiteMissionProcessPath(pm planner.PlannedMission, opts suite
 	return harnessErr, true
 }
 
+// close drops the runner IO buffers (and their spill files) once logs and feedback are written.
+func (c suiteRunProcessPathContext) close() {
+	c.stdoutTB.Close()
+	c.stderrTB.Close()
+}
+
 func installSuiteRunProcessShims(attemptDir string, opts suiteRunExecOpts, env map[string]string, ar *suiteRunAttemptResult, errWriter io.Writer) (bool, string) {
 	if len(opts.Shims) == 0 {
 		return false, ""
@@ -1375,25 +1990,38 @@ func ensureSuiteRunResultStdoutBuffers(opts suiteRunExecOpts, pathCtx *suiteRunP
 	}
 }
 
-func executeSuiteRunProcessRunner(r Runner, pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
+func executeSuiteRunProcessRunner(ctx context.Context, r Runner, pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
 	if err := verifyAttemptMatchesEnv(pm.OutDirAbs, env); err != nil {
 		ar.RunnerErrorCode = codeUsage
 		fmt.Fprintf(errWriter, codeUsage+": suite run: %s\n", err.Error())
 		return true
 	}
+	if blocked, harnessErr := blockSuiteRunNetworkRequired(r, pm, opts, env, ar, errWriter); blocked {
+		return harnessErr
+	}
 	if !opts.Blind {
-		return runSuiteRunner(r, pm, env, opts.RunnerCmd, opts.RunnerArgs, stdoutTB, stderrTB, ar, errWriter)
+		return runSuiteRunner(ctx, r, pm, env, opts.RunnerCmd, opts.RunnerArgs, stdoutTB, stderrTB, ar, errWriter)
 	}
-	return executeSuiteRunBlindRunner(r, pm, opts, env, stdoutTB, stderrTB, ar, errWriter)
+	return executeSuiteRunBlindRunner(ctx, r, pm, opts, env, stdoutTB, stderrTB, ar, errWriter)
 }
 
-func executeSuiteRunBlindRunner(r Runner, pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
-	found := promptContamination(pm.OutDirAbs, opts.BlindTerms)
+// rewriteSuiteRunBlindPrompt sanitizes a contaminated prompt.txt for --blind-action rewrite before
+// anything (runtime env artifact, remote push, runner) reads it; the blind check then sees it clean.
+func rewriteSuiteRunBlindPrompt(r Runner, pm planner.PlannedMission, opts suiteRunExecOpts) error {
+	if !opts.Blind || opts.BlindAction != blind.ActionRewrite {
+		return nil
+	}
+	_, _, err := attempt.RewritePrompt(r.Now(), opts.OutRoot, pm.OutDirAbs, opts.BlindTerms)
+	return err
+}
+
+func executeSuiteRunBlindRunner(ctx context.Context, r Runner, pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
+	found, sources := suiteRunBlindContamination(pm.OutDirAbs, opts, env)
 	if len(found) == 0 {
-		return runSuiteRunner(r, pm, env, opts.RunnerCmd, opts.RunnerArgs, stdoutTB, stderrTB, ar, errWriter)
+		return runSuiteRunner(ctx, r, pm, env, opts.RunnerCmd, opts.RunnerArgs, stdoutTB, stderrTB, ar, errWriter)
 	}
 	ar.RunnerErrorCode = codeContaminatedPrompt
-	msg := "prompt contamination detected: " + strings.Join(found, ",")
+	msg := "prompt contamination detected: " + strings.Join(found, ",") + " (in " + strings.Join(sources, ",") + ")"
 	envTrace := suiteRunTraceEnv(env, pm.OutDirAbs)
 	if err := trace.AppendCLIRunEvent(r.Now(), envTrace, []string{"zcl", "blind-check"}, trace.ResultForTrace{
 		SpawnError: codeContaminatedPrompt,
@@ -1423,6 +2051,16 @@ func finalizeSuiteRunAttemptResult(r Runner, pm planner.PlannedMission, opts sui
 	ar.Finish = finishAttempt(r.Now(), pm.OutDirAbs, opts.Strict, opts.StrictExpect)
 	runnerOK := ar.RunnerErrorCode == "" && ar.RunnerExitCode != nil && *ar.RunnerExitCode == 0
 	ar.OK = runnerOK && ar.Finish.OK
+	if opts.EncryptRecipient != nil {
+		// Encrypt only after finish so report/validate/expect ran on the plaintext in-process.
+		if _, err := encrypt.Attempt(opts.OutRoot, pm.OutDirAbs, opts.EncryptRecipient); err != nil {
+			ar.OK = false
+			if ar.RunnerErrorCode == "" {
+				ar.RunnerErrorCode = codeIO
+			}
+			fmt.Fprintf(suiteRunAttemptErrWriter(r, opts), codeIO+": suite run: encrypt attempt artifacts: %s\n", err.Error())
+		}
+	}
 	_ = env
 }
 
@@ -1432,7 +2070,7 @@ func emitSuiteRunAttemptFinished(r Runner, opts suiteRunExecOpts, env map[string
 	}
 	_ = opts.Progress.Emit(suiteRunProgressEvent{
 		TS:        r.Now().UTC().Format(time.RFC3339Nano),
-		Kind:      "attempt_finished",
+		Kind:      suiteRunProgressAttemptFinished,
 		RunID:     env["ZCL_RUN_ID"],
 		SuiteID:   env["ZCL_SUITE_ID"],
 		MissionID: env["ZCL_MISSION_ID"],
@@ -1512,6 +2150,13 @@ func writeAttemptRuntimeEnvArtifact(now time.Time, pm planner.PlannedMission, ex
 			StartCwdMode:   strings.TrimSpace(runtimeCtx.StartCwdMode),
 			StartCwd:       strings.TrimSpace(runtimeCtx.StartCwd),
 			StartCwdRetain: strings.TrimSpace(runtimeCtx.StartCwdRetain),
+			Container:      suiteRunContainerRuntime(opts),
+			Sandbox:        suiteRunSandboxRuntime(runtimeCtx.Sandbox),
+			Network:        suiteRunNetworkRuntime(opts),
+			AllowHosts:     append([]string(nil), opts.AllowHosts...),
+			Limits:         suiteRunLimitsRuntime(opts),
+			Home:           runtimeCtx.Home,
+			Remote:         suiteRunRemoteRuntime(opts),
 		},
 		Prompt: schema.AttemptPromptMetadataV1{
 			SourceKind:   promptSourceKind,
@@ -1571,17 +2216,17 @@ func sortedEnvKeys(m map[string]string) []string {
 	return keys
 }
 
-func runSuiteRunner(r Runner, pm planner.PlannedMission, env map[string]string, runnerCmd string, runnerArgs []string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
-	return runSuiteRunnerImpl(r, pm, env, runnerCmd, runnerArgs, stdoutTB, stderrTB, ar, errWriter)
+func runSuiteRunner(ctx context.Context, r Runner, pm planner.PlannedMission, env map[string]string, runnerCmd string, runnerArgs []string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
+	return runSuiteRunnerImpl(ctx, r, pm, env, runnerCmd, runnerArgs, stdoutTB, stderrTB, ar, errWriter)
 }
 
-func runSuiteRunnerImpl(r Runner, pm planner.PlannedMission, env map[string]string, runnerCmd string, runnerArgs []string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
-	return runSuiteRunnerCore(r, pm, env, runnerCmd, runnerArgs, stdoutTB, stderrTB, ar, errWriter)
+func runSuiteRunnerImpl(ctx context.Context, r Runner, pm planner.PlannedMission, env map[string]string, runnerCmd string, runnerArgs []string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
+	return runSuiteRunnerCore(ctx, r, pm, env, runnerCmd, runnerArgs, stdoutTB, stderrTB, ar, errWriter)
 }
 
-func runSuiteRunnerCore(r Runner, pm planner.PlannedMission, env map[string]string, runnerCmd string, runnerArgs []string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
+func runSuiteRunnerCore(ctx context.Context, r Runner, pm planner.PlannedMission, env map[string]string, runnerCmd string, runnerArgs []string, stdoutTB *tailBuffer, stderrTB *tailBuffer, ar *suiteRunAttemptResult, errWriter io.Writer) bool {
 	errWriter = defaultSuiteRunErrWriter(errWriter, r.Stderr)
-	ctx, cancel, timedOut := attemptCtxForDeadline(r.Now(), pm.OutDirAbs)
+	ctx, cancel, timedOut := attemptCtxForDeadlineFrom(ctx, r.Now(), pm.OutDirAbs)
 	if cancel != nil {
 		defer cancel()
 	}
@@ -1592,7 +2237,11 @@ func runSuiteRunnerCore(r Runner, pm planner.PlannedMission, env map[string]stri
 	fmt.Fprintf(errWriter, "suite run: mission=%s attempt=%s runner=%s\n", pm.MissionID, pm.AttemptID, filepath.Base(runnerCmd))
 
 	cmd := buildSuiteRunRunnerCommand(ctx, env, runnerCmd, runnerArgs, errWriter, stdoutTB, stderrTB)
-	err := cmd.Run()
+	err := cmd.Start()
+	if err == nil {
+		notifySuiteRunRunnerStarted(ctx, cmd.Process.Pid)
+		err = cmd.Wait()
+	}
 	setSuiteRunRunnerExitCode(ar, cmd, err)
 	return classifySuiteRunRunnerExecution(err, ctx, ar)
 }
@@ -1651,8 +2300,24 @@ func classifySuiteRunRunnerExecution(runErr error, ctx context.Context, ar *suit
 	return false
 }
 
+// suiteRunBlindContamination scans everything the agent is handed before launch: prompt.txt, the
+// runner env values and argv, since adapter scripts often leak harness instructions through env.
+// It returns the matched terms and where they were found.
+func suiteRunBlindContamination(attemptDir string, opts suiteRunExecOpts, env map[string]string) ([]string, []string) {
+	var terms, sources []string
+	if found := promptContamination(attemptDir, opts.BlindTerms); len(found) > 0 {
+		terms = append(terms, found...)
+		sources = append(sources, artifacts.PromptTXT)
+	}
+	for _, f := range append(blind.ScanEnv(env, opts.BlindTerms), blind.ScanArgv(opts.BlindArgv, opts.BlindTerms)...) {
+		terms = append(terms, f.Terms...)
+		sources = append(sources, f.Source)
+	}
+	return blind.NormalizeTerms(terms), sources
+}
+
 func promptContamination(attemptDir string, terms []string) []string {
-	b, err := os.ReadFile(filepath.Join(attemptDir, artifacts.PromptTXT))
+	b, err := store.ReadArtifactFile(filepath.Join(attemptDir, artifacts.PromptTXT))
 	if err != nil {
 		return nil
 	}
@@ -1661,7 +2326,7 @@ func promptContamination(attemptDir string, terms []string) []string {
 
 func printSuiteRunHelp(w io.Writer) {
 	fmt.Fprint(w, `Usage:
-  zcl suite run --file <suite.(yaml|yml|json)> [--run-id <runId>] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--feedback-policy strict|auto_fail] [--finalization-mode strict|auto_fail|auto_from_result_json] [--result-channel none|file_json|stdout_json] [--result-file <attempt-relative-path>] [--result-marker <prefix>] [--result-min-turn N] [--campaign-id <id>] [--campaign-state <path>] [--progress-jsonl <path|->] [--blind on|off] [--blind-terms a,b,c] [--session-isolation auto|process|native] [--runtime-strategies <csv>] [--native-model <slug>] [--native-model-reasoning-effort none|minimal|low|medium|high|xhigh] [--native-model-reasoning-policy best_effort|required] [--parallel N] [--total M] [--mission-offset N] [--out-root .zcl] [--fail-fast] [--strict] [--strict-expect] [--shim <bin>] [--capture-runner-io] --json [-- <runner-cmd> [args...]]
+  zcl suite run --file <suite.(yaml|yml|json)> [--run-id <runId>] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--feedback-policy strict|auto_fail] [--finalization-mode strict|auto_fail|auto_from_result_json] [--result-channel none|file_json|stdout_json] [--result-file <attempt-relative-path>] [--result-marker <prefix>] [--result-min-turn N] [--campaign-id <id>] [--campaign-state <path>] [--progress-jsonl <path|->] [--progress-events <csv>] [--blind on|off] [--blind-terms a,b,c] [--blind-terms-pack <name>] [--blind-action reject|rewrite] [--session-isolation auto|process|native] [--runtime-strategies <csv>] [--native-model <slug>] [--native-model-reasoning-effort none|minimal|low|medium|high|xhigh] [--native-model-reasoning-policy best_effort|required] [--chaos <profile.(yaml|yml|json)>] [--parallel N] [--schedule suite|longest-first] [--total M] [--mission-offset N] [--mission <missionId>]... [--watch] [--watch-debounce 300ms] [--out-root .zcl] [--fail-fast] [--strict] [--strict-expect] [--shim <bin>] [--capture-runner-io] [--vcr record|replay] [--vcr-from <runDir|attemptDir|cassette>] [--sandbox none|bwrap] [--network host|none|advisory-allowlist] [--allow-host <host>]... [--disk-quota-mb N] [--home inherit|ephemeral] [--home-template <dir>] --json [-- <runner-cmd> [args...]]
 
 Notes:
   - Requires --json (stdout is reserved for JSON; runner stdout/stderr is streamed to stderr).
@@ -1669,18 +2334,48 @@ Notes:
   - --session-isolation=auto chooses native mode when ZCL_HOST_NATIVE_SPAWN=1, otherwise process mode.
   - --runtime-strategies controls ordered native runtime fallback chain (default from config/env).
   - --native-model and --native-model-reasoning-* apply only in native mode and are forwarded to thread/start.
+  - --chaos <profile> (native mode; default $ZCL_CHAOS_PROFILE) injects seeded stream disconnects, slow turns, rate limits and crashes into
+    native sessions to exercise failure codes and campaign gates; the summary records chaosProfile.
   - --feedback-policy controls default finalization behavior when --finalization-mode is omitted.
   - --feedback-policy=auto_fail writes canonical infra-failure feedback when runners exit without feedback.
   - --feedback-policy=strict leaves missing feedback as a failing contract condition unless --finalization-mode overrides it.
   - --finalization-mode=auto_from_result_json consumes mission result JSON from the configured result channel and writes feedback.json automatically.
   - --result-channel=file_json reads attempt-relative JSON from --result-file (default mission.result.json); --result-channel=stdout_json scans runner stdout for --result-marker (default ZCL_RESULT_JSON:).
   - --result-min-turn N requires mission result payload field "turn" to be >= N before auto finalization accepts it (default 1).
-  - --progress-jsonl writes machine-readable run progress events for dashboard automation.
+  - --progress-jsonl writes machine-readable run progress events for dashboard automation
+    (schemaVersion 1; see SCHEMAS.md); --progress-events keeps only the listed kinds
+    (run_started,attempt_started,attempt_native_state,attempt_finished,run_progress,run_finished).
   - campaign.state.json is updated after run completion for cross-run continuity.
-  - Attempts are allocated just-in-time, in waves (--parallel), to avoid pre-expiry before execution.
+  - Attempts are allocated just-in-time by --parallel workers (each starts the next mission as soon as it frees up), to avoid pre-expiry before execution.
+  - report/validate/expect for finished runners run on a separate pool so workers start the next mission immediately;
+    --fail-fast stops dispatch once a finish verdict fails (missions already started still finish).
+  - --schedule longest-first dispatches missions by predicted duration (median of recent passing attempts in the out-root; missions without history first).
   - --mission-offset shifts scheduling start point (useful for campaign resume/canary slices).
+  - --mission limits the run to the named missions (repeatable).
+  - --watch runs once, then re-runs only the missions affected by edits to the suite file or the
+    files it references (promptFile, expects.schema, expects.golden) after --watch-debounce of quiet;
+    each run is a fresh run and stdout gets one JSON line per iteration with outcome changes.
   - When --shim is used, ZCL prepends an attempt-local bin dir to PATH so the agent can type the tool name directly and still have invocations traced via zcl run.
-  - In blind mode, contaminated prompts are rejected and recorded with typed evidence.
+  - --vcr record stores each shim (zcl run) and zcl mcp proxy tools/call response in the attempt's tool.cassette.jsonl;
+    --vcr replay serves them from --vcr-from (run dir: latest attempt of the same mission) without executing the tools.
+  - --sandbox bwrap runs process-mode runners under bubblewrap: the host fs is read-only, $HOME and /tmp are empty,
+    the repo (from the current dir) stays readable and only the attempt dir is writable. The profile is recorded in attempt.runtime.env.json.
+  - --network none runs attempts without network access (process runners: unshared network namespace via bwrap; container
+    runners: network=none). Missions with requiresNetwork: true are blocked with ZCL_E_NETWORK_REQUIRED instead of run.
+  - --network advisory-allowlist --allow-host <host> points the runner's HTTP(S)_PROXY at a per-attempt egress proxy that
+    only lets listed hosts through; refused requests get a 403 and a ZCL_E_EGRESS_DENIED trace event (campaign gate reason
+    ZCL_E_CAMPAIGN_EGRESS_VIOLATION). It is advisory: the runner is not isolated, so clients ignoring the proxy env are
+    neither blocked nor logged. Use --network none to enforce. Process runners only; allowlist is rejected.
+  - Process runners record disk usage (attempt dir + temp_empty_per_attempt workspace) in disk.usage.json, surfaced as
+    attempt.report metrics.diskBytes/diskBytesPeak. --disk-quota-mb N kills a runner that grows past it (ZCL_E_DISK_QUOTA).
+  - Process runners sample their process tree (CPU, RSS, procs/threads) and host load every second into resources.jsonl
+    (Linux), summarized as attempt.report metrics.resources.
+  - --home ephemeral gives each process-runner attempt a fresh HOME under its tmp dir (XDG_*, CODEX_HOME, CLAUDE_CONFIG_DIR,
+    GH_CONFIG_DIR, DOCKER_CONFIG, GIT_CONFIG_GLOBAL, AWS config files, ... point inside it), seeded from --home-template,
+    so agents cannot read the operator's credentials or carry state between attempts.
+  - In blind mode, contaminated prompts are rejected and recorded with typed evidence; --blind-action rewrite instead underscores the terms in prompt.txt (original kept as prompt.original.txt, replacements in prompt.contamination.json) and runs the sanitized prompt.
+  - --blind-terms-pack selects a built-in (generic-harness|codex|claude) or suite blindTermPacks term pack; --blind-terms are added to it.
+  - Blind checks also scan runner env values (except ZCL_* keys and absolute paths) and runner argv; contamination there is always rejected.
   - After the runner exits, ZCL finishes each attempt (report + validate + expect).
 `)
 }
@@ -1783,7 +2478,7 @@ func finishAttemptCore(now time.Time, attemptDir string, strict bool, strictExpe
 		AttemptDir:   attemptDir,
 	}
 
-	rep, repErr, reportErr, ioErr := buildSuiteRunFinishReport(now, attemptDir, strict)
+	rep, built, reportErr, ioErr := buildSuiteRunFinishReport(now, attemptDir, strict)
 	if ioErr != nil {
 		out.IOError = ioErr.Error()
 		return out
@@ -1791,56 +2486,39 @@ func finishAttemptCore(now time.Time, attemptDir string, strict bool, strictExpe
 	out.Report = rep
 	out.ReportError = reportErr
 
-	valRes, expRes, err := evaluateSuiteRunFinish(attemptDir, strict, strictExpect)
+	// Publish the strict report, or the non-strict fallback when only that could be built.
+	var staged *schema.AttemptReportJSONV1
+	if built {
+		staged = &rep
+	}
+	valRes, expRes, ok, err := commitAttemptFinish(now, attemptDir, strict, strictExpect, staged)
 	if err != nil {
 		out.IOError = err.Error()
 		return out
 	}
 	out.Validate = valRes
 	out.Expect = expRes
-
-	ok := valRes.OK && expRes.OK
-	if repErr == nil && rep.OK != nil && !*rep.OK {
-		ok = false
-	}
 	out.OK = ok && out.ReportError == nil
 	return out
 }
 
-func buildSuiteRunFinishReport(now time.Time, attemptDir string, strict bool) (schema.AttemptReportJSONV1, error, *suiteRunReportErr, error) {
+// buildSuiteRunFinishReport builds the strict report, falling back to a non-strict one when strict
+// evidence checks fail; built reports whether either report exists to publish.
+func buildSuiteRunFinishReport(now time.Time, attemptDir string, strict bool) (schema.AttemptReportJSONV1, bool, *suiteRunReportErr, error) {
 	rep, repErr := report.BuildAttemptReport(now, attemptDir, strict)
 	if repErr == nil {
-		return rep, nil, nil, writeSuiteRunFinishReport(attemptDir, rep)
+		return rep, true, nil, nil
 	}
 	var ce *report.CliError
 	if !errors.As(repErr, &ce) {
-		return schema.AttemptReportJSONV1{}, repErr, nil, repErr
+		return schema.AttemptReportJSONV1{}, false, nil, repErr
 	}
 	reportErr := &suiteRunReportErr{Code: ce.Code, Message: ce.Message}
 	fallback, ferr := report.BuildAttemptReport(now, attemptDir, false)
 	if ferr == nil {
-		if err := writeSuiteRunFinishReport(attemptDir, fallback); err != nil {
-			return fallback, repErr, reportErr, err
-		}
-		return fallback, repErr, reportErr, nil
-	}
-	return schema.AttemptReportJSONV1{}, repErr, reportErr, nil
-}
-
-func writeSuiteRunFinishReport(attemptDir string, rep schema.AttemptReportJSONV1) error {
-	return report.WriteAttemptReportAtomic(filepath.Join(attemptDir, artifacts.AttemptReportJSON), rep)
-}
-
-func evaluateSuiteRunFinish(attemptDir string, strict bool, strictExpect bool) (validate.Result, expect.Result, error) {
-	valRes, err := validate.ValidatePath(attemptDir, strict)
-	if err != nil {
-		return validate.Result{}, expect.Result{}, err
-	}
-	expRes, err := expect.ExpectPath(attemptDir, strictExpect)
-	if err != nil {
-		return validate.Result{}, expect.Result{}, err
+		return fallback, true, reportErr, nil
 	}
-	return valRes, expRes, nil
+	return schema.AttemptReportJSONV1{}, false, reportErr, nil
 }
 
 func isStartFailure(err error) bool {
@@ -14,6 +14,7 @@ import (
 	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/sigkey"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
@@ -93,15 +94,7 @@ func maybeWriteAutoResultFeedback(now time.Time, env map[string]string, ar *suit
 		return maybeWriteResultChannelFailureFeedback(now, env, ar, codeMissionResultInvalid, err)
 	}
 
-	envTrace := trace.Env{
-		RunID:     env["ZCL_RUN_ID"],
-		SuiteID:   env["ZCL_SUITE_ID"],
-		MissionID: env["ZCL_MISSION_ID"],
-		AttemptID: env["ZCL_ATTEMPT_ID"],
-		AgentID:   env["ZCL_AGENT_ID"],
-		OutDirAbs: outDir,
-		TmpDirAbs: env["ZCL_TMP_DIR"],
-	}
+	envTrace := suiteRunTraceEnv(env, outDir)
 	if err := ensureAutoFeedbackTrace(now, envTrace, "suite-runner-result-channel", "", "auto finalization from mission result channel"); err != nil {
 		return err
 	}
@@ -212,6 +205,9 @@ func decodeSuiteResultFeedbackCore(raw []byte, minFinalTurn int) (feedback.Write
 	if err := decodeMissionResultDecisionTags(&opts, obj); err != nil {
 		return feedback.WriteOpts{}, err
 	}
+	if err := decodeMissionResultConfidence(&opts, obj); err != nil {
+		return feedback.WriteOpts{}, err
+	}
 	if err := decodeMissionResultBody(&opts, obj); err != nil {
 		return feedback.WriteOpts{}, err
 	}
@@ -278,6 +274,19 @@ func decodeMissionResultDecisionTags(opts *feedback.WriteOpts, obj map[string]an
 	return nil
 }
 
+func decodeMissionResultConfidence(opts *feedback.WriteOpts, obj map[string]any) error {
+	raw, present := obj["confidence"]
+	if !present {
+		return nil
+	}
+	v, ok := raw.(float64)
+	if !ok {
+		return fmt.Errorf("mission result field \"confidence\" must be a number within 0..1")
+	}
+	opts.Confidence = &v
+	return nil
+}
+
 func decodeMissionResultBody(opts *feedback.WriteOpts, obj map[string]any) error {
 	if rawResult, present := obj["result"]; present {
 		resultText, ok := rawResult.(string)
@@ -319,7 +328,7 @@ func missionResultFallbackPayload(obj map[string]any) map[string]any {
 	payload := map[string]any{}
 	for k, v := range obj {
 		switch strings.TrimSpace(k) {
-		case "ok", "decisionTags", "turn":
+		case "ok", "decisionTags", "confidence", "turn":
 			continue
 		default:
 			payload[k] = v
@@ -376,15 +385,7 @@ func maybeWriteResultChannelFailureFeedback(now time.Time, env map[string]string
 	if fileExists(feedbackPath) {
 		return nil
 	}
-	envTrace := trace.Env{
-		RunID:     env["ZCL_RUN_ID"],
-		SuiteID:   env["ZCL_SUITE_ID"],
-		MissionID: env["ZCL_MISSION_ID"],
-		AttemptID: env["ZCL_ATTEMPT_ID"],
-		AgentID:   env["ZCL_AGENT_ID"],
-		OutDirAbs: outDir,
-		TmpDirAbs: env["ZCL_TMP_DIR"],
-	}
+	envTrace := suiteRunTraceEnv(env, outDir)
 	msg := strings.TrimSpace(cause.Error())
 	if msg == "" {
 		msg = "mission result channel error"
@@ -500,7 +501,18 @@ func suiteRunTraceEnv(env map[string]string, outDir string) trace.Env {
 		AgentID:   env["ZCL_AGENT_ID"],
 		OutDirAbs: outDir,
 		TmpDirAbs: env["ZCL_TMP_DIR"],
+
+		FeedbackSigningKey: feedbackSigningKeyPath(env),
+	}
+}
+
+// feedbackSigningKeyPath is the feedback signing key runners see: the attempt env (campaign
+// runner.env) wins over the inherited process env.
+func feedbackSigningKeyPath(env map[string]string) string {
+	if v, ok := env[sigkey.FeedbackKeyEnv]; ok {
+		return strings.TrimSpace(v)
 	}
+	return strings.TrimSpace(os.Getenv(sigkey.FeedbackKeyEnv))
 }
 
 func autoFailureMessage(ar suiteRunAttemptResult) string {
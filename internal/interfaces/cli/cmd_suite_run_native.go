@@ -90,11 +90,22 @@ func (s *nativeAttemptSupervisor) State() nativeAttemptState {
 }
 
 type nativeAttemptScheduler struct {
-	strategy            native.StrategyID
-	sem                 chan struct{}
+	strategy native.StrategyID
+	// limit is the current in-flight cap (maxInflight unless adaptive tuning lowered it);
+	// slotFreed is closed and replaced whenever inflight drops or limit rises.
+	maxInflight         int
+	limit               int
+	inflight            int
+	slotFreed           chan struct{}
+	adaptive            *nativeAdaptiveState
 	minStartInterval    time.Duration
 	mu                  sync.Mutex
 	nextAllowedStartUTC time.Time
+	// globalDir/globalLimit enable the host-wide broker shared with other zcl processes
+	// (ZCL_NATIVE_GLOBAL_MAX_INFLIGHT_PER_STRATEGY); globalReleases holds the claimed slots.
+	globalDir      string
+	globalLimit    int
+	globalReleases []func() error
 }
 
 func buildNativeAttemptScheduler(strategy native.StrategyID, defaultParallel int) *nativeAttemptScheduler {
@@ -110,17 +121,40 @@ func buildNativeAttemptScheduler(strategy native.StrategyID, defaultParallel int
 	}
 	minStartMs := parsePositiveIntEnv("ZCL_NATIVE_MIN_START_INTERVAL_MS", 0)
 	s := &nativeAttemptScheduler{
-		strategy: strategy,
+		strategy:    strategy,
+		maxInflight: maxInflight,
+		limit:       maxInflight,
+		slotFreed:   make(chan struct{}),
 	}
-	if maxInflight > 0 {
-		s.sem = make(chan struct{}, maxInflight)
+	if envBoolish("ZCL_NATIVE_ADAPTIVE_INFLIGHT") {
+		s.adaptive = newNativeAdaptiveInflight(strategy)
 	}
 	if minStartMs > 0 {
 		s.minStartInterval = time.Duration(minStartMs) * time.Millisecond
 	}
+	if globalMax := parsePositiveIntEnv("ZCL_NATIVE_GLOBAL_MAX_INFLIGHT_PER_STRATEGY", 0); globalMax > 0 {
+		if dir := nativeBrokerDir(); dir != "" {
+			s.globalDir = filepath.Join(dir, string(strategy))
+			s.globalLimit = globalMax
+		}
+	}
 	return s
 }
 
+// nativeBrokerDir is where concurrent zcl processes on one host coordinate native session slots.
+func nativeBrokerDir() string {
+	if p := strings.TrimSpace(os.Getenv("ZCL_NATIVE_BROKER_DIR")); p != "" {
+		return p
+	}
+	if d, err := os.UserCacheDir(); err == nil && strings.TrimSpace(d) != "" {
+		return filepath.Join(d, "zcl", "native-broker")
+	}
+	if h, err := os.UserHomeDir(); err == nil && strings.TrimSpace(h) != "" {
+		return filepath.Join(h, ".zcl", "cache", "native-broker")
+	}
+	return ""
+}
+
 func (s *nativeAttemptScheduler) Acquire(ctx context.Context) error {
 	return s.acquireImpl(ctx)
 }
@@ -137,24 +171,52 @@ func (s *nativeAttemptScheduler) acquireCore(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	return s.waitForStartSlot(ctx, acquired)
+	if err := s.acquireGlobalSlot(ctx); err != nil {
+		if acquired {
+			s.releaseSemaphore()
+		}
+		return err
+	}
+	return s.waitForStartSlot(ctx, acquired || s.globalDir != "")
 }
 
-func (s *nativeAttemptScheduler) acquireSemaphore(ctx context.Context) (bool, error) {
-	if s.sem == nil {
-		return false, nil
+func (s *nativeAttemptScheduler) acquireGlobalSlot(ctx context.Context) error {
+	if s.globalDir == "" {
+		return nil
 	}
-	select {
-	case s.sem <- struct{}{}:
-		return true, nil
-	default:
+	release, waited, err := store.AcquireSlot(ctx, s.globalDir, s.globalLimit)
+	if waited {
 		native.RecordHealth(s.strategy, native.HealthSchedulerWait)
 	}
-	select {
-	case s.sem <- struct{}{}:
-		return true, nil
-	case <-ctx.Done():
-		return false, ctx.Err()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.globalReleases = append(s.globalReleases, release)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *nativeAttemptScheduler) acquireSemaphore(ctx context.Context) (bool, error) {
+	waited := false
+	for {
+		s.mu.Lock()
+		if s.inflight < s.limit {
+			s.inflight++
+			s.mu.Unlock()
+			return true, nil
+		}
+		freed := s.slotFreed
+		s.mu.Unlock()
+		if !waited {
+			native.RecordHealth(s.strategy, native.HealthSchedulerWait)
+			waited = true
+		}
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
 	}
 }
 
@@ -162,6 +224,9 @@ func (s *nativeAttemptScheduler) waitForStartSlot(ctx context.Context, releaseOn
 	if s.minStartInterval <= 0 {
 		return nil
 	}
+	if s.globalDir != "" {
+		return s.waitForGlobalStartSlot(ctx, releaseOnCancel)
+	}
 	wait := s.nextStartWaitDuration()
 	if wait <= 0 {
 		s.markNextAllowedStart()
@@ -170,7 +235,7 @@ func (s *nativeAttemptScheduler) waitForStartSlot(ctx context.Context, releaseOn
 	native.RecordHealth(s.strategy, native.HealthSchedulerWait)
 	if err := waitWithContext(ctx, wait); err != nil {
 		if releaseOnCancel {
-			s.Release()
+			s.releaseSlots()
 		}
 		return err
 	}
@@ -178,6 +243,20 @@ func (s *nativeAttemptScheduler) waitForStartSlot(ctx context.Context, releaseOn
 	return nil
 }
 
+// waitForGlobalStartSlot spaces starts across every process sharing the broker dir: each caller
+// books the next free start time, so spacing holds even when processes race.
+func (s *nativeAttemptScheduler) waitForGlobalStartSlot(ctx context.Context, releaseOnCancel bool) error {
+	wait, err := store.ReserveStart(s.globalDir, s.minStartInterval, time.Now())
+	if err == nil && wait > 0 {
+		native.RecordHealth(s.strategy, native.HealthSchedulerWait)
+		err = waitWithContext(ctx, wait)
+	}
+	if err != nil && releaseOnCancel {
+		s.releaseSlots()
+	}
+	return err
+}
+
 func (s *nativeAttemptScheduler) nextStartWaitDuration() time.Duration {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -205,13 +284,61 @@ func waitWithContext(ctx context.Context, d time.Duration) error {
 	}
 }
 
+// Release ends an attempt's claim and, with adaptive tuning, re-evaluates the in-flight cap from
+// the health signals recorded since the previous release.
 func (s *nativeAttemptScheduler) Release() {
-	if s == nil || s.sem == nil {
+	if s == nil {
 		return
 	}
-	select {
-	case <-s.sem:
-	default:
+	s.mu.Lock()
+	if s.adaptive != nil {
+		s.limit = s.adaptive.observe(s.limit, s.maxInflight, time.Now().UTC())
+	}
+	s.mu.Unlock()
+	s.releaseSlots()
+}
+
+func (s *nativeAttemptScheduler) releaseSlots() {
+	s.releaseGlobalSlot()
+	s.releaseSemaphore()
+}
+
+func (s *nativeAttemptScheduler) releaseGlobalSlot() {
+	s.mu.Lock()
+	n := len(s.globalReleases)
+	if n == 0 {
+		s.mu.Unlock()
+		return
+	}
+	release := s.globalReleases[n-1]
+	s.globalReleases = s.globalReleases[:n-1]
+	s.mu.Unlock()
+	_ = release()
+}
+
+func (s *nativeAttemptScheduler) releaseSemaphore() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inflight > 0 {
+		s.inflight--
+	}
+	close(s.slotFreed)
+	s.slotFreed = make(chan struct{})
+}
+
+// summary reports the adaptive tuning outcome for suite.run.summary.json (nil when disabled).
+func (s *nativeAttemptScheduler) summary() *suiteRunNativeSchedulerSummary {
+	if s == nil || s.adaptive == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &suiteRunNativeSchedulerSummary{
+		Strategy:      string(s.strategy),
+		Adaptive:      true,
+		MaxInflight:   s.maxInflight,
+		FinalInflight: s.limit,
+		Adjustments:   append([]suiteRunInflightAdjustment(nil), s.adaptive.adjustments...),
 	}
 }
 
@@ -460,7 +587,7 @@ func runSuiteNativeRuntimeCore(r Runner, pm planner.PlannedMission, env map[stri
 	}
 	defer closeSuiteNativeSession(sess, opts.NativeSelection.Selected)
 
-	listener, ok, harnessErr := addSuiteNativeListener(sess, setup.envTrace, opts.NativeSelection.Selected, ar, emitNativeState)
+	listener, ok, harnessErr := addSuiteNativeListener(sess, setup.envTrace, opts.NativeSelection.Selected, opts.NativeEventsRaw, ar, emitNativeState)
 	if !ok {
 		return harnessErr
 	}
@@ -476,6 +603,8 @@ func runSuiteNativeRuntimeCore(r Runner, pm planner.PlannedMission, env map[stri
 
 	resultCollector := newNativeResultCollector()
 	observeSuiteNativeEvents(setup.ctx, sess, thread, turn, listener.events, resultCollector, opts, ar, emitNativeState)
+	// Events still arriving after this are appended directly, so finish sees the whole stream.
+	listener.traceState.Set(setup.envTrace.Batch.Close())
 	if err := listener.traceState.Err(); err != nil {
 		return failSuiteNativeTraceAppend(ar, errWriter, err, emitNativeState)
 	}
@@ -527,13 +656,13 @@ func newSuiteNativeStateEmitter(r Runner, pm planner.PlannedMission, env map[str
 		if opts.Progress == nil {
 			return
 		}
-		payload := map[string]any{"state": string(state)}
+		payload := map[string]any{"state": string(state), "strategy": string(opts.NativeSelection.Selected)}
 		for k, v := range details {
 			payload[k] = v
 		}
 		_ = opts.Progress.Emit(suiteRunProgressEvent{
 			TS:        r.Now().UTC().Format(time.RFC3339Nano),
-			Kind:      "attempt_native_state",
+			Kind:      suiteRunProgressAttemptNativeState,
 			RunID:     env["ZCL_RUN_ID"],
 			SuiteID:   env["ZCL_SUITE_ID"],
 			MissionID: env["ZCL_MISSION_ID"],
@@ -583,6 +712,14 @@ func prepareSuiteNativeRuntimeSetup(r Runner, pm planner.PlannedMission, env map
 		return setup, false, true
 	}
 	setup.envTrace = suiteRunTraceEnv(env, strings.TrimSpace(env["ZCL_OUT_DIR"]))
+	// Native runtimes emit a trace event per stream delta; batch them so each one is not an
+	// open/write/fsync of tool.calls.jsonl.
+	setup.envTrace.Batch = trace.NewBatch(filepath.Join(setup.envTrace.OutDirAbs, artifacts.ToolCallsJSONL), 0, 0)
+	cleanupScheduler := setup.cleanup
+	setup.cleanup = func() {
+		_ = setup.envTrace.Batch.Close()
+		cleanupScheduler()
+	}
 	return setup, true, false
 }
 
@@ -596,6 +733,7 @@ func startSuiteNativeSession(setup suiteNativeRuntimeSetup, pm planner.PlannedMi
 		AttemptID:  env["ZCL_ATTEMPT_ID"],
 		AttemptDir: pm.OutDirAbs,
 		Env:        env,
+		MCPServers: suiteNativeMCPServers(opts.MCPServers, env, opts.ZCLExe),
 	})
 	if err != nil {
 		native.RecordHealth(opts.NativeSelection.Selected, native.HealthSessionStartFail)
@@ -636,7 +774,7 @@ func closeSuiteNativeSession(sess native.Session, strategy native.StrategyID) {
 	_ = sess.Close(closeCtx)
 }
 
-func addSuiteNativeListener(sess native.Session, envTrace trace.Env, strategy native.StrategyID, ar *suiteRunAttemptResult, emitNativeState func(state nativeAttemptState, force bool, details map[string]any)) (suiteNativeRuntimeListener, bool, bool) {
+func addSuiteNativeListener(sess native.Session, envTrace trace.Env, strategy native.StrategyID, rawPayloads bool, ar *suiteRunAttemptResult, emitNativeState func(state nativeAttemptState, force bool, details map[string]any)) (suiteNativeRuntimeListener, bool, bool) {
 	state := &suiteNativeTraceState{}
 	events := make(chan native.Event, 128)
 	listenerID, err := sess.AddListener(func(ev native.Event) {
@@ -648,6 +786,7 @@ func addSuiteNativeListener(sess native.Session, envTrace trace.Env, strategy na
 			CallID:    ev.CallID,
 			EventName: ev.Name,
 			Payload:   ev.Payload,
+			Raw:       rawPayloads,
 		}); appendErr != nil {
 			state.Set(appendErr)
 		}
@@ -726,7 +865,7 @@ func startSuiteNativeThreadTurn(ctx context.Context, sess native.Session, pm pla
 }
 
 func writeSuiteNativeRunnerRef(pm planner.PlannedMission, env map[string]string, opts suiteRunExecOpts, sess native.Session, thread native.ThreadHandle, ar *suiteRunAttemptResult, errWriter io.Writer, emitNativeState func(state nativeAttemptState, force bool, details map[string]any)) bool {
-	if err := writeNativeRunnerRef(pm.OutDirAbs, env, opts.NativeSelection.Selected, sess.SessionID(), thread.ThreadID); err != nil {
+	if err := writeNativeRunnerRef(pm.OutDirAbs, env, opts.NativeSelection.Selected, sess.SessionID(), native.SessionRuntimeVersion(sess), thread.ThreadID); err != nil {
 		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
 		emitSuiteNativeFailure(ar, codeIO, emitNativeState, "runner_ref_write_failed")
 		return false
@@ -1405,23 +1544,24 @@ func buildNativeRuntimeRegistry() *native.Registry {
 	reg.MustRegister(codexappserver.NewRuntime(codexappserver.Config{
 		Command: codexappserver.DefaultCommandFromEnv(),
 	}))
-	reg.MustRegister(providerstub.NewRuntime())
+	reg.MustRegister(providerstub.RuntimeFromEnv())
 	return reg
 }
 
-func writeNativeRunnerRef(attemptDir string, env map[string]string, runtimeID native.StrategyID, sessionID string, threadID string) error {
+func writeNativeRunnerRef(attemptDir string, env map[string]string, runtimeID native.StrategyID, sessionID string, runtimeVersion string, threadID string) error {
 	ref := schema.RunnerRefJSONV1{
-		SchemaVersion: schema.ArtifactSchemaV1,
-		Runner:        string(runtimeID),
-		RunID:         env["ZCL_RUN_ID"],
-		SuiteID:       env["ZCL_SUITE_ID"],
-		MissionID:     env["ZCL_MISSION_ID"],
-		AttemptID:     env["ZCL_ATTEMPT_ID"],
-		AgentID:       env["ZCL_AGENT_ID"],
-		ThreadID:      strings.TrimSpace(threadID),
-		RuntimeID:     string(runtimeID),
-		SessionID:     strings.TrimSpace(sessionID),
-		Transport:     "stdio",
+		SchemaVersion:  schema.ArtifactSchemaV1,
+		Runner:         string(runtimeID),
+		RunID:          env["ZCL_RUN_ID"],
+		SuiteID:        env["ZCL_SUITE_ID"],
+		MissionID:      env["ZCL_MISSION_ID"],
+		AttemptID:      env["ZCL_ATTEMPT_ID"],
+		AgentID:        env["ZCL_AGENT_ID"],
+		ThreadID:       strings.TrimSpace(threadID),
+		RuntimeID:      string(runtimeID),
+		RuntimeVersion: strings.TrimSpace(runtimeVersion),
+		SessionID:      strings.TrimSpace(sessionID),
+		Transport:      "stdio",
 	}
 	return store.WriteJSONAtomic(filepath.Join(attemptDir, artifacts.RunnerRefJSON), ref)
 }
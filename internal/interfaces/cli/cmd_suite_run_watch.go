@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+)
+
+// suiteWatchPoll is how often --watch re-hashes the suite file and the files it references.
+// Polling keeps watch mode dependency-free and works the same on every filesystem.
+const suiteWatchPoll = 250 * time.Millisecond
+
+// suiteWatchEventV1 is one JSON line written to stdout per watch iteration.
+type suiteWatchEventV1 struct {
+	Event     string               `json:"event"` // run|no_change|error
+	Iteration int                  `json:"iteration"`
+	Trigger   []string             `json:"trigger,omitempty"`
+	Missions  []string             `json:"missions,omitempty"`
+	RunID     string               `json:"runId,omitempty"`
+	ExitCode  int                  `json:"exitCode"`
+	Passed    int                  `json:"passed"`
+	Failed    int                  `json:"failed"`
+	Changes   []suiteWatchChangeV1 `json:"changes,omitempty"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// suiteWatchChangeV1 is a mission whose outcome differs from its previous watch run.
+type suiteWatchChangeV1 struct {
+	MissionID string `json:"missionId"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+}
+
+// suiteWatchSnapshot is what a change is diffed against: file hashes plus the normalized
+// suite, so edits that do not touch a mission (whitespace, reordering) re-run nothing.
+type suiteWatchSnapshot struct {
+	files    map[string]string
+	defaults string
+	missions map[string]string
+	order    []string
+	refs     map[string][]string
+}
+
+func (r Runner) runSuiteRunWatch(args []string, input suiteRunCLIInput, extraAttemptEnv map[string]string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return r.watchSuiteRun(ctx, args, input, extraAttemptEnv, suiteWatchPoll)
+}
+
+func (r Runner) watchSuiteRun(ctx context.Context, args []string, input suiteRunCLIInput, extraAttemptEnv map[string]string, poll time.Duration) int {
+	suitePath := strings.TrimSpace(input.file)
+	parsed, err := suite.ParseFile(suitePath)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
+		return 2
+	}
+	snap := takeSuiteWatchSnapshot(suitePath, parsed)
+	fmt.Fprintf(r.Stderr, "zcl watch: watching %d file(s); Ctrl-C to stop\n", len(snap.files))
+
+	outcomes := map[string]string{}
+	iteration := 1
+	r.runSuiteWatchIteration(args, nil, nil, iteration, outcomes, extraAttemptEnv)
+	for {
+		changed, ok := waitSuiteWatchChange(ctx, snap, poll, input.watchDebounce)
+		if !ok {
+			return 0
+		}
+		iteration++
+		next, err := suite.ParseFile(suitePath)
+		if err != nil {
+			snap.files = hashSuiteWatchFiles(snap.files)
+			r.writeSuiteWatchEvent(suiteWatchEventV1{Event: "error", Iteration: iteration, Trigger: changed, Error: err.Error()})
+			fmt.Fprintf(r.Stderr, "zcl watch: %s (waiting for the next change)\n", err.Error())
+			continue
+		}
+		nextSnap := takeSuiteWatchSnapshot(suitePath, next)
+		affected := affectedSuiteWatchMissions(snap, nextSnap, changed, input.missionIDs)
+		snap = nextSnap
+		if len(affected) == 0 {
+			r.writeSuiteWatchEvent(suiteWatchEventV1{Event: "no_change", Iteration: iteration, Trigger: changed})
+			fmt.Fprintf(r.Stderr, "zcl watch: %s changed; no mission affected\n", strings.Join(changed, ", "))
+			continue
+		}
+		r.runSuiteWatchIteration(args, affected, changed, iteration, outcomes, extraAttemptEnv)
+	}
+}
+
+// runSuiteWatchIteration runs one regular `suite run` (the watch flags stripped, affected
+// missions pinned with --mission) and reports how outcomes moved since the previous run.
+func (r Runner) runSuiteWatchIteration(args []string, affected []string, trigger []string, iteration int, outcomes map[string]string, extraAttemptEnv map[string]string) {
+	inner := r
+	var stdout bytes.Buffer
+	inner.Stdout = &stdout
+	exit := inner.runSuiteRunWithEnvCore(suiteWatchRunArgs(args, affected), extraAttemptEnv)
+
+	ev := suiteWatchEventV1{Event: "run", Iteration: iteration, Trigger: trigger, Missions: affected, ExitCode: exit}
+	var sum suiteRunSummary
+	if err := json.Unmarshal(stdout.Bytes(), &sum); err == nil {
+		ev.RunID, ev.Passed, ev.Failed = sum.RunID, sum.Passed, sum.Failed
+		for _, a := range sum.Attempts {
+			after := suiteWatchOutcome(a)
+			before, seen := outcomes[a.MissionID]
+			outcomes[a.MissionID] = after
+			if !seen {
+				before = "new"
+			}
+			if before != after {
+				ev.Changes = append(ev.Changes, suiteWatchChangeV1{MissionID: a.MissionID, Before: before, After: after})
+			}
+			if affected == nil {
+				ev.Missions = append(ev.Missions, a.MissionID)
+			}
+		}
+	}
+	r.writeSuiteWatchEvent(ev)
+
+	fmt.Fprintf(r.Stderr, "zcl watch: run %d: %d passed, %d failed (runId=%s exit=%d)\n", iteration, ev.Passed, ev.Failed, dashIfEmpty(ev.RunID), exit)
+	for _, c := range ev.Changes {
+		fmt.Fprintf(r.Stderr, "  %s: %s -> %s\n", c.MissionID, c.Before, c.After)
+	}
+}
+
+func (r Runner) writeSuiteWatchEvent(ev suiteWatchEventV1) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	_, _ = r.Stdout.Write(append(b, '\n'))
+}
+
+func suiteWatchOutcome(a suiteRunAttemptResult) string {
+	switch {
+	case a.Skipped:
+		return "skipped"
+	case a.OK:
+		return "ok"
+	case a.RunnerErrorCode != "":
+		return "fail:" + a.RunnerErrorCode
+	case a.AutoFeedbackCode != "":
+		return "fail:" + a.AutoFeedbackCode
+	case len(a.Finish.Expect.Failures) > 0:
+		return "fail:" + a.Finish.Expect.Failures[0].Code
+	default:
+		return "fail"
+	}
+}
+
+// suiteWatchRunArgs drops the watch flags from the original argv; for targeted re-runs it also
+// drops mission selection (--mission/--total/--mission-offset) and pins the affected missions.
+// Everything after `--` (the runner command) is kept verbatim.
+func suiteWatchRunArgs(args []string, affected []string) []string {
+	drop := map[string]bool{"watch": true, "watch-debounce": true}
+	if affected != nil {
+		drop["mission"], drop["total"], drop["mission-offset"] = true, true, true
+	}
+	out := make([]string, 0, len(args)+2*len(affected))
+	rest := []string(nil)
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			rest = args[i:]
+			break
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(a, "-"), "=")
+		if !strings.HasPrefix(a, "-") || !drop[name] {
+			out = append(out, a)
+			continue
+		}
+		if name != "watch" && !hasValue {
+			i++
+		}
+	}
+	for _, id := range affected {
+		out = append(out, "--mission", id)
+	}
+	return append(out, rest...)
+}
+
+func takeSuiteWatchSnapshot(suitePath string, parsed suite.ParsedSuite) suiteWatchSnapshot {
+	snap := suiteWatchSnapshot{
+		files:    map[string]string{},
+		missions: map[string]string{},
+		refs:     map[string][]string{},
+	}
+	if abs, err := filepath.Abs(suitePath); err == nil {
+		suitePath = abs
+	}
+	snap.files[suitePath] = ""
+	for _, ref := range parsed.Refs {
+		snap.files[ref.Path] = ""
+		snap.refs[ref.Path] = append(snap.refs[ref.Path], ref.MissionID)
+	}
+	snap.files = hashSuiteWatchFiles(snap.files)
+
+	header := parsed.Suite
+	header.Missions = nil
+	if b, err := json.Marshal(header); err == nil {
+		snap.defaults = string(b)
+	}
+	for _, m := range parsed.Suite.Missions {
+		b, _ := json.Marshal(m)
+		snap.missions[m.MissionID] = string(b)
+		snap.order = append(snap.order, m.MissionID)
+	}
+	return snap
+}
+
+// hashSuiteWatchFiles returns the sha256 of every path; missing files hash to "".
+func hashSuiteWatchFiles(files map[string]string) map[string]string {
+	out := make(map[string]string, len(files))
+	for path := range files {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			out[path] = ""
+			continue
+		}
+		sum := sha256.Sum256(raw)
+		out[path] = hex.EncodeToString(sum[:])
+	}
+	return out
+}
+
+// waitSuiteWatchChange polls until a watched file changes and then stays quiet for debounce;
+// it returns the changed paths, or false when ctx is done.
+func waitSuiteWatchChange(ctx context.Context, snap suiteWatchSnapshot, poll time.Duration, debounce time.Duration) ([]string, bool) {
+	current := snap.files
+	changed := map[string]bool{}
+	var lastChange time.Time
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-ticker.C:
+		}
+		next := hashSuiteWatchFiles(current)
+		for path, sum := range next {
+			if sum != current[path] {
+				changed[path] = true
+				lastChange = time.Now()
+			}
+		}
+		current = next
+		if len(changed) > 0 && time.Since(lastChange) >= debounce {
+			return sortedKeysOf(changed), true
+		}
+	}
+}
+
+// affectedSuiteWatchMissions picks the missions to re-run: all of them when suite-level
+// settings changed, otherwise missions whose normalized definition changed (inlined prompt and
+// schema files included) plus missions referencing a changed file. --mission narrows the set.
+func affectedSuiteWatchMissions(prev suiteWatchSnapshot, next suiteWatchSnapshot, changed []string, only []string) []string {
+	hit := map[string]bool{}
+	for _, id := range next.order {
+		if prev.defaults != next.defaults || prev.missions[id] != next.missions[id] {
+			hit[id] = true
+		}
+	}
+	for _, path := range changed {
+		for _, id := range next.refs[path] {
+			hit[id] = true
+		}
+	}
+	allowed := map[string]bool{}
+	for _, id := range only {
+		allowed[ids.SanitizeComponent(id)] = true
+	}
+	var out []string
+	for _, id := range next.order {
+		if hit[id] && (len(allowed) == 0 || allowed[id]) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func sortedKeysOf(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/artifactsync"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+func (r Runner) runSync(args []string) int {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	campaignID := fs.String("campaign-id", "", "campaign id (required unless --spec is provided)")
+	spec := fs.String("spec", "", "campaign spec file (.json|.yaml|.yml) (optional alternative to --campaign-id)")
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	dest := fs.String("dest", "", "destination URI: s3://bucket/prefix, gs://bucket/prefix or file:///path (default config sync.dest / ZCL_SYNC_DEST)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("sync: invalid flags")
+	}
+	if *help {
+		printSyncHelp(r.Stdout)
+		return 0
+	}
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	target := strings.TrimSpace(*dest)
+	if target == "" {
+		target = m.Sync.Dest
+	}
+	if target == "" {
+		printSyncHelp(r.Stderr)
+		return r.failUsage("sync: missing --dest (or configure sync.dest)")
+	}
+	if err := config.ValidateSyncDest(target); err != nil {
+		return r.failUsage("sync: " + err.Error())
+	}
+	st, exit, ok := r.resolveCampaignRunState(*campaignID, *spec, *outRoot, *jsonOut, "sync", printSyncHelp)
+	if !ok {
+		return exit
+	}
+	res, err := r.syncCampaign(st, target)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": sync: %s\n", err.Error())
+		return 1
+	}
+	if *jsonOut {
+		return r.writeJSON(res)
+	}
+	fmt.Fprintf(r.Stdout, "sync: OK campaign=%s dest=%s uploaded=%d unchanged=%d\n", res.CampaignID, res.Dest, res.Uploaded, res.Unchanged)
+	return 0
+}
+
+func (r Runner) syncCampaign(st campaign.RunStateV1, dest string) (artifactsync.Result, error) {
+	return artifactsync.Run(artifactsync.Opts{
+		OutRoot:    st.OutRoot,
+		CampaignID: st.CampaignID,
+		RunIDs:     campaignFlowRunIDs(st),
+		Dest:       dest,
+		Now:        r.Now(),
+	})
+}
+
+// autoSyncCampaign uploads the campaign when config sync.auto is set. Failures are reported on
+// stderr but never change the campaign exit code: the local out-root stays authoritative.
+func (r Runner) autoSyncCampaign(st campaign.RunStateV1, label string) {
+	if strings.TrimSpace(st.CampaignID) == "" || strings.TrimSpace(st.OutRoot) == "" {
+		return
+	}
+	m, err := config.LoadMerged(st.OutRoot)
+	if err != nil || !m.Sync.Auto || m.Sync.Dest == "" {
+		return
+	}
+	res, err := r.syncCampaign(st, m.Sync.Dest)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s: auto-sync: %s\n", label, err.Error())
+		return
+	}
+	fmt.Fprintf(r.Stderr, "%s: synced %d files to %s (unchanged=%d)\n", label, res.Uploaded, res.Dest, res.Unchanged)
+}
+
+func printSyncHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl sync [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--dest s3://bucket/prefix|gs://bucket/prefix|file:///path] [--out-root .zcl] [--json]
+`)
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/top"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+// topClearScreen moves the cursor home and clears the terminal before each redraw.
+const topClearScreen = "\x1b[H\x1b[2J"
+
+func (r Runner) runTop(args []string) int {
+	fs := flag.NewFlagSet("top", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	outRoot := fs.String("out-root", "", "project output root (default from config/env, else .zcl)")
+	interval := fs.Duration("interval", 2*time.Second, "refresh interval")
+	once := fs.Bool("once", false, "render one snapshot and exit")
+	failures := fs.Int("failures", 10, "number of recent failures to show")
+	var progress stringListFlag
+	fs.Var(&progress, "progress", "extra suite run progress JSONL file to follow (repeatable)")
+	jsonOut := fs.Bool("json", false, "print one JSON snapshot and exit")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("top: invalid flags")
+	}
+	if *help {
+		printTopHelp(r.Stdout)
+		return 0
+	}
+	if *interval <= 0 {
+		return r.failUsage("top: --interval must be > 0")
+	}
+	m, err := config.LoadMerged(*outRoot)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": %s\n", err.Error())
+		return 1
+	}
+	for {
+		snap, err := top.Snapshot(top.Opts{OutRoot: m.OutRoot, ProgressPaths: progress, RecentFailures: *failures, Now: r.Now()})
+		if err != nil {
+			fmt.Fprintf(r.Stderr, codeIO+": top: %s\n", err.Error())
+			return 1
+		}
+		if *jsonOut {
+			return r.writeJSON(snap)
+		}
+		if !*once {
+			fmt.Fprint(r.Stdout, topClearScreen)
+		}
+		renderTop(r.Stdout, snap)
+		if *once {
+			return 0
+		}
+		time.Sleep(*interval)
+	}
+}
+
+func renderTop(w io.Writer, snap top.SnapshotV1) {
+	inFlight := 0
+	for _, run := range snap.Runs {
+		inFlight += len(run.InFlight)
+	}
+	fmt.Fprintf(w, "zcl top  outRoot=%s  runs=%d  inFlight=%d  campaigns=%d  %s\n", snap.OutRoot, len(snap.Runs), inFlight, len(snap.Campaigns), snap.GeneratedAt)
+
+	if len(snap.Campaigns) > 0 {
+		fmt.Fprintln(w, "\nCAMPAIGNS")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "CAMPAIGN\tRUN\tMISSIONS\tUPDATED")
+		for _, c := range snap.Campaigns {
+			fmt.Fprintf(tw, "%s\t%s\t%d/%d\t%s\n", c.CampaignID, c.RunID, c.MissionsCompleted, c.TotalMissions, c.UpdatedAt)
+		}
+		_ = tw.Flush()
+	}
+
+	fmt.Fprintln(w, "\nATTEMPTS IN FLIGHT")
+	if inFlight == 0 {
+		fmt.Fprintln(w, "  (none)")
+	} else {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "RUN\tATTEMPT\tMISSION\tELAPSED\tSTRATEGY\tSTATE")
+		for _, run := range snap.Runs {
+			for _, a := range run.InFlight {
+				state := a.NativeState
+				if a.WaitingMs > 0 {
+					state = fmt.Sprintf("%s (waiting %s)", state, formatTopDuration(a.WaitingMs))
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", run.RunID, a.AttemptID, a.MissionID, formatTopDuration(a.ElapsedMs), dashIfEmpty(a.Strategy), dashIfEmpty(state))
+			}
+		}
+		_ = tw.Flush()
+	}
+
+	if len(snap.Strategies) > 0 {
+		fmt.Fprintln(w, "\nSTRATEGIES")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "STRATEGY\tIN FLIGHT\tWAITING\tFAILURES\tRATE LIMITED\tLAST CODE")
+		for _, s := range snap.Strategies {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%s\n", s.Strategy, s.InFlight, s.Waiting, s.Failures, s.RateLimited, dashIfEmpty(s.LastCode))
+		}
+		_ = tw.Flush()
+	}
+
+	if len(snap.RecentFailures) > 0 {
+		fmt.Fprintln(w, "\nRECENT FAILURES")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "AT\tSOURCE\tRUN/CAMPAIGN\tMISSION\tCODE\tREASON")
+		for _, f := range snap.RecentFailures {
+			owner := f.RunID
+			if f.Source == "campaign" {
+				owner = f.CampaignID
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", f.At, f.Source, owner, dashIfEmpty(f.MissionID), dashIfEmpty(f.Code), dashIfEmpty(f.Reason))
+		}
+		_ = tw.Flush()
+	}
+}
+
+func formatTopDuration(ms int64) string {
+	if ms <= 0 {
+		return "0s"
+	}
+	return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+}
+
+func dashIfEmpty(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+func printTopHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl top [--out-root .zcl] [--interval 2s] [--once] [--failures N] [--progress <path>]... [--json]
+
+Notes:
+  - Shows runs whose owner lock is held by a live process, their attempts in flight, running
+    campaigns, per-strategy native health (in flight, waiting for a scheduler slot, failures,
+    rate limits) and recent failures. Nothing is locked or written.
+  - Native state comes from suite run progress JSONL: files named by a run's --progress-jsonl
+    (recorded in run.invocation.json) are followed automatically; add others with --progress.
+  - --json prints one snapshot and exits; --once renders one text frame without clearing.
+`)
+}
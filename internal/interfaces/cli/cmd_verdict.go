@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/verdict"
+)
+
+func (r Runner) runVerdict(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printVerdictHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "override":
+		return r.runVerdictOverride(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown verdict subcommand %q\n", args[0])
+		printVerdictHelp(r.Stderr)
+		return 2
+	}
+}
+
+func (r Runner) runVerdictOverride(args []string) int {
+	fs := flag.NewFlagSet("verdict override", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	attemptDir := fs.String("attempt", "", "attempt directory to adjudicate (required)")
+	okFlag := fs.Bool("ok", false, "adjudicated verdict (required; use --ok=true or --ok=false)")
+	reason := fs.String("reason", "", "why the automated verdict is overridden (required)")
+	by := fs.String("by", "", "who adjudicated (default $USER)")
+	jsonOut := fs.Bool("json", false, "print JSON output")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return r.failUsage("verdict override: invalid flags")
+	}
+	if *help {
+		printVerdictHelp(r.Stdout)
+		return 0
+	}
+	okSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "ok" {
+			okSet = true
+		}
+	})
+	if !okSet {
+		printVerdictHelp(r.Stderr)
+		return r.failUsage("verdict override: missing --ok=true|false")
+	}
+	if strings.TrimSpace(*attemptDir) == "" {
+		printVerdictHelp(r.Stderr)
+		return r.failUsage("verdict override: missing --attempt")
+	}
+	who := strings.TrimSpace(*by)
+	if who == "" {
+		who = strings.TrimSpace(os.Getenv("USER"))
+	}
+	ov, err := verdict.Override(r.Now(), *attemptDir, verdict.OverrideOpts{OK: *okFlag, Reason: *reason, By: who})
+	if err != nil {
+		return r.failUsage("verdict override: " + err.Error())
+	}
+	if *jsonOut {
+		return r.writeJSON(ov)
+	}
+	fmt.Fprintf(r.Stdout, "verdict override: OK attempt=%s ok=%v by=%s revisions=%d\n", ov.AttemptID, ov.OK, ov.By, len(ov.History))
+	return 0
+}
+
+func printVerdictHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl verdict override --attempt <attemptDir> --ok=true|false --reason <text> [--by <name>] [--json]
+`)
+}
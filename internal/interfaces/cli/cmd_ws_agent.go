@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/wsagent"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func (r Runner) runWS(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printWSHelp(r.Stdout)
+		return 0
+	}
+	switch args[0] {
+	case "agent":
+		return r.runWSAgent(args[1:])
+	default:
+		fmt.Fprintf(r.Stderr, codeUsage+": unknown ws subcommand %q\n", args[0])
+		printWSHelp(r.Stderr)
+		return 2
+	}
+}
+
+type wsAgentOptions struct {
+	url            string
+	header         http.Header
+	interruptGrace time.Duration
+	marker         string
+}
+
+// runWSAgent is the runner of runner.type=ws_agent flows: it sends the attempt's prompt over a
+// WebSocket, copies output messages to stdout (runner IO), traces every other agent message in
+// tool.calls.jsonl and prints the agent's result behind the result marker. The agent is sent an
+// interrupt interruptGrace before the attempt deadline (or on SIGINT/SIGTERM).
+func (r Runner) runWSAgent(args []string) int {
+	opts, exit, done := r.parseWSAgentOptions(args)
+	if done {
+		return exit
+	}
+	env, err := trace.EnvFromProcess()
+	if err != nil {
+		printWSAgentHelp(r.Stderr)
+		return r.failUsage("ws agent: missing ZCL attempt context (need ZCL_* env)")
+	}
+	promptPath := strings.TrimSpace(os.Getenv("ZCL_PROMPT_PATH"))
+	if promptPath == "" {
+		promptPath = filepath.Join(env.OutDirAbs, artifacts.PromptTXT)
+	}
+	prompt, err := os.ReadFile(promptPath)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": ws agent: read prompt: %s\n", err.Error())
+		return 1
+	}
+	ctx, cancel, timedOut, exit, done := r.prepareHTTPProxyContext(r.Now(), env.OutDirAbs)
+	if done {
+		return exit
+	}
+	if cancel != nil {
+		defer cancel()
+	}
+	if timedOut {
+		fmt.Fprintf(r.Stderr, codeTimeout+": attempt deadline exceeded\n")
+		return 1
+	}
+	if dl, ok := ctx.Deadline(); ok {
+		var cancelEarly context.CancelFunc
+		ctx, cancelEarly = context.WithDeadline(ctx, dl.Add(-opts.interruptGrace))
+		defer cancelEarly()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := wsagent.Client{
+		Header:         opts.header,
+		InterruptGrace: opts.interruptGrace,
+		Output:         r.Stdout,
+		OnMessage: func(m wsagent.Message) {
+			_ = trace.AppendAgentStreamEvent(r.Now(), env, wsAgentTraceEvent(m))
+		},
+	}
+	result, err := client.Run(ctx, opts.url, wsagent.MissionRequest{
+		SchemaVersion: schema.ArtifactSchemaV1,
+		RunID:         env.RunID,
+		SuiteID:       env.SuiteID,
+		MissionID:     env.MissionID,
+		AttemptID:     env.AttemptID,
+		AgentID:       env.AgentID,
+		Prompt:        string(prompt),
+	})
+	if errors.Is(err, wsagent.ErrInterrupted) {
+		fmt.Fprintf(r.Stderr, codeTimeout+": ws agent: %s\n", err.Error())
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": ws agent: %s\n", err.Error())
+		return 1
+	}
+	fmt.Fprintf(r.Stdout, "%s%s\n", opts.marker, result)
+	return 0
+}
+
+// wsAgentTraceEvent traces an agent message as tool=ws; named events use their name as op.
+func wsAgentTraceEvent(m wsagent.Message) trace.AgentStreamEvent {
+	ev := trace.AgentStreamEvent{Tool: "ws", Op: m.Type, Payload: m.Raw}
+	switch m.Type {
+	case wsagent.TypeEvent:
+		if strings.TrimSpace(m.Name) != "" {
+			ev.Op = m.Name
+		}
+	case wsagent.TypeError:
+		ev.Code = codeToolFailed
+	case wsagent.TypeInterrupt:
+		ev.Code = codeTimeout
+	}
+	return ev
+}
+
+func (r Runner) parseWSAgentOptions(args []string) (wsAgentOptions, int, bool) {
+	fs := flag.NewFlagSet("ws agent", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	endpoint := fs.String("url", "", "agent WebSocket endpoint (required; ws(s)://...)")
+	var headers stringListFlag
+	fs.Var(&headers, "header", "handshake header `Name: value` (repeatable; ${VAR} expands from env)")
+	graceMs := fs.Int64("interrupt-grace-ms", wsagent.DefaultInterruptGraceMs, "interrupt the agent this long before the attempt deadline")
+	marker := fs.String("result-marker", campaign.DefaultResultChannelMarker, "stdout prefix of the mission result line")
+	help := fs.Bool("help", false, "show help")
+
+	if err := fs.Parse(args); err != nil {
+		return wsAgentOptions{}, r.failUsage("ws agent: invalid flags"), true
+	}
+	if *help {
+		printWSAgentHelp(r.Stdout)
+		return wsAgentOptions{}, 0, true
+	}
+	spec, err := wsagent.Normalize(wsagent.Spec{URL: *endpoint, InterruptGraceMs: *graceMs})
+	if err != nil {
+		printWSAgentHelp(r.Stderr)
+		return wsAgentOptions{}, r.failUsage("ws agent: " + err.Error()), true
+	}
+	h := http.Header{}
+	for _, raw := range headers {
+		name, value, err := wsagent.ParseHeader(raw)
+		if err != nil {
+			return wsAgentOptions{}, r.failUsage("ws agent: " + err.Error()), true
+		}
+		h.Add(name, os.ExpandEnv(value))
+	}
+	return wsAgentOptions{
+		url:            spec.URL,
+		header:         h,
+		interruptGrace: time.Duration(spec.InterruptGraceMs) * time.Millisecond,
+		marker:         strings.TrimSpace(*marker),
+	}, 0, false
+}
+
+func printWSHelp(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  zcl ws agent --url <ws-url> [--header 'Name: value']... [--interrupt-grace-ms N] [--result-marker <prefix>]
+`)
+}
+
+func printWSAgentHelp(w io.Writer) {
+	printWSHelp(w)
+	fmt.Fprint(w, `
+Notes:
+  - Requires ZCL attempt context (ZCL_* env; the prompt is read from ZCL_PROMPT_PATH).
+  - Sends {"type":"mission",schemaVersion,runId,suiteId,missionId,attemptId,agentId,prompt}.
+  - The agent sends {"type":"output","text"} (copied to stdout), {"type":"event","name","data"}
+    (traced as tool=ws), then {"type":"result","result":{...}} or {"type":"error","message"}.
+  - Near the attempt deadline (or on SIGINT/SIGTERM) zcl sends {"type":"interrupt","reason":"timeout"}
+    and waits --interrupt-grace-ms for the agent to finish before exiting with ZCL_E_TIMEOUT.
+`)
+}
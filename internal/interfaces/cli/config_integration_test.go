@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestConfig_SetGetLint(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"init"}, "init")
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"config", "set", "campaignState", "sqlite"}, "config set")
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"config", "set", "retention.keepRuns", "-1"}, "config set invalid")
+
+	var kv struct {
+		Key    string `json:"key"`
+		Value  string `json:"value"`
+		Source string `json:"source"`
+		Origin string `json:"origin"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"config", "get", "campaignState", "--json"}, &kv, "config get")
+	if kv.Value != "sqlite" || kv.Origin != "file" || kv.Source != "zcl.config.json" {
+		t.Fatalf("unexpected value: %+v", kv)
+	}
+	t.Setenv("ZCL_OUT_ROOT", ".zcl-env")
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"config", "get", "outRoot", "--json"}, &kv, "config get env")
+	if kv.Value != ".zcl-env" || kv.Origin != "env" {
+		t.Fatalf("unexpected env value: %+v", kv)
+	}
+
+	var lint struct {
+		OK bool `json:"ok"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"config", "lint", "--json"}, &lint, "config lint")
+	if !lint.OK {
+		t.Fatalf("expected clean lint")
+	}
+	t.Setenv("ZCL_PROFILE", "missing")
+	runCLICommandJSON(t, &r, &stdout, &stderr, 1, []string{"config", "lint", "--json"}, &lint, "config lint unknown profile")
+}
+
+func TestDoctor_EnvironmentChecksAndBrokenConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	type doctorResult struct {
+		OK      bool   `json:"ok"`
+		OutRoot string `json:"outRoot"`
+		Checks  []struct {
+			ID      string `json:"id"`
+			OK      bool   `json:"ok"`
+			Message string `json:"message"`
+		} `json:"checks"`
+	}
+	var res doctorResult
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"doctor", "--json"}, &res, "doctor")
+	seen := map[string]bool{}
+	for _, c := range res.Checks {
+		if c.ID == "" || (!c.OK && c.Message == "") {
+			t.Fatalf("malformed check %+v", c)
+		}
+		seen[c.ID] = c.OK
+	}
+	// Free space, binaries and the codex runtime depend on the host: require the checks, not
+	// their outcome.
+	for _, id := range []string{"write_access", "config_lint", "disk_space", "clock_skew", "required_binary_sh", "runtime_codex_app_server"} {
+		if _, found := seen[id]; !found {
+			t.Fatalf("expected %s check, got %+v", id, res.Checks)
+		}
+	}
+	if !seen["write_access"] || !seen["config_lint"] {
+		t.Fatalf("expected write_access and config_lint to pass in a fresh dir, got %+v", res.Checks)
+	}
+
+	mustWriteFile(t, filepath.Join(dir, "zcl.config.json"), `{"schemaVersion":2,"outRoot":".zcl-project"}`)
+	res = doctorResult{}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"doctor", "--json"}, &res, "doctor broken config")
+	if res.OK || res.OutRoot != ".zcl" {
+		t.Fatalf("expected failing doctor on the default out-root, got %+v", res)
+	}
+	failed := false
+	for _, c := range res.Checks {
+		if c.ID == "config_lint" && !c.OK {
+			failed = true
+		}
+	}
+	if !failed {
+		t.Fatalf("expected config_lint to fail, got %+v", res.Checks)
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 1, []string{"doctor"}, "doctor broken config text")
+	if !strings.Contains(stderr.String(), "FAIL config_lint") {
+		t.Fatalf("expected config_lint failure on stderr, got %q", stderr.String())
+	}
+}
+
+func TestExitCodes_ConfiguredMappingPerOutcomeClass(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	attemptDir := filepath.Join(dir, "attempt")
+	if err := os.MkdirAll(attemptDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"contract"}, "default usage")
+	runCLICommand(t, &r, &stdout, &stderr, 1, []string{"validate", missing}, "default harness error")
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"init"}, "init")
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"config", "set", "exitCodes.usage", "0"}, "config set invalid exit code")
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"config", "set", "exitCodes.usage", "256"}, "config set out of range exit code")
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"config", "set", "exitCodes.usage", "64"}, "config set usage")
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"config", "set", "exitCodes.invalidRun", "3"}, "config set invalidRun")
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"config", "set", "exitCodes.harnessError", "70"}, "config set harnessError")
+
+	runCLICommand(t, &r, &stdout, &stderr, 64, []string{"contract"}, "mapped usage")
+	runCLICommand(t, &r, &stdout, &stderr, 70, []string{"validate", missing}, "mapped harness error")
+	runCLICommand(t, &r, &stdout, &stderr, 3, []string{"validate", "--json", attemptDir}, "mapped invalid run")
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"config", "get", "exitCodes.usage"}, "success is never mapped")
+
+	runCLICommand(t, &r, &stdout, &stderr, 64, []string{"--error-format", "json", "contract"}, "mapped usage envelope")
+	var env schema.ErrorEnvelopeV1
+	if err := json.Unmarshal(bytes.TrimSpace(stderr.Bytes()), &env); err != nil {
+		t.Fatalf("unmarshal envelope: %v (%q)", err, stderr.String())
+	}
+	if env.ExitCode != 64 || env.Code != codeUsage {
+		t.Fatalf("expected envelope with the mapped exit code, got %+v", env)
+	}
+
+	var kv struct {
+		Value  int    `json:"value"`
+		Source string `json:"source"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"config", "get", "exitCodes.invalidRun", "--json"}, &kv, "config get exitCodes")
+	if kv.Value != 3 || kv.Source != "zcl.config.json" {
+		t.Fatalf("unexpected exitCodes.invalidRun: %+v", kv)
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncryption_CampaignRunSealsPromptAndReportNeedsIdentity(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "enc-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "confidential fixture prompt", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-enc
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	keyPath := filepath.Join(t.TempDir(), "artifacts.key")
+	var key struct {
+		Recipient string `json:"recipient"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"encryption", "keygen", "--out", keyPath, "--json"}, &key, "encryption keygen")
+	if info, err := os.Stat(keyPath); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected 0600 identity file, got %v err=%v", info, err)
+	}
+
+	t.Setenv("ZCL_ENCRYPTION_RECIPIENT", key.Recipient)
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	attempts, _ := filepath.Glob(filepath.Join(outRoot, "runs", "*", "attempts", "*"))
+	if len(attempts) != 1 {
+		t.Fatalf("expected one attempt dir, got %v", attempts)
+	}
+	attemptDir := attempts[0]
+	if _, err := os.Stat(filepath.Join(attemptDir, "prompt.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected plaintext prompt removed, got %v", err)
+	}
+	sealed, err := os.ReadFile(filepath.Join(attemptDir, "prompt.txt.enc"))
+	if err != nil || bytes.Contains(sealed, []byte("confidential")) {
+		t.Fatalf("expected encrypted prompt, err=%v", err)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"validate", attemptDir}, "validate without identity")
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"report", attemptDir}, "report without identity")
+	if !strings.Contains(stderr.String(), codeDecryptFailed) {
+		t.Fatalf("expected %s, got %q", codeDecryptFailed, stderr.String())
+	}
+
+	t.Setenv("ZCL_ENCRYPTION_IDENTITY", keyPath)
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"report", "--json", attemptDir}, "report with identity")
+}
@@ -5,12 +5,24 @@ import "github.com/marcohefti/zero-context-lab/internal/kernel/codes"
 const (
 	codeUsage                      = codes.Usage
 	codeIO                         = codes.IO
+	codeCommandFailed              = codes.CommandFailed
 	codeMissingArtifact            = codes.MissingArtifact
+	codeInvalidJSON                = codes.InvalidJSON
+	codeSchemaUnsupported          = codes.SchemaUnsupported
+	codeContract                   = codes.Contract
 	codeTimeout                    = codes.Timeout
 	codeSpawn                      = codes.Spawn
 	codeToolFailed                 = codes.ToolFailed
 	codeContaminatedPrompt         = codes.ContaminatedPrompt
+	codeNetworkRequired            = codes.NetworkRequired
+	codeResourceLimit              = codes.ResourceLimit
+	codeDiskQuota                  = codes.DiskQuota
+	codeSecretLeak                 = codes.SecretLeak
+	codeSignatureInvalid           = codes.SignatureInvalid
+	codeDecryptFailed              = codes.DecryptFailed
+	codeRunLocked                  = codes.RunLocked
 	codeVersionFloor               = codes.VersionFloor
+	codeSessionNotFresh            = codes.SessionNotFresh
 	codeRuntimeStreamDisconnect    = codes.RuntimeStreamDisconnect
 	codeRuntimeCrash               = codes.RuntimeCrash
 	codeRuntimeProtocol            = codes.RuntimeProtocol
@@ -33,7 +45,9 @@ const (
 	codeCampaignSkipped         = codes.CampaignSkipped
 	codeCampaignStateDrift      = codes.CampaignStateDrift
 
-	codeShim = codes.Shim
+	codeShim             = codes.Shim
+	codeToolPolicyDenied = codes.ToolPolicyDenied
+	codeVCRMiss          = codes.VCRMiss
 )
 
 func campaignFlowExitCode(exitCode int) string {
@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/marcohefti/zero-context-lab/internal/interfaces/contract"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+const (
+	errorFormatText = "text"
+	errorFormatJSON = "json"
+)
+
+var (
+	errorLineRe = regexp.MustCompile(`^(ZCL_E_[A-Z0-9_]+): ?(.*)$`)
+	// errorPathRe picks absolute, home- and dot-relative paths plus key=<path> pointers out of
+	// error messages; it is best-effort by design.
+	errorPathRe = regexp.MustCompile(`(?:^|[\s"'=(])((?:/|~/|\.{1,2}/|\.zcl/)[^\s"',;:)]+)`)
+)
+
+// globalFlags are the flags accepted before the command name.
+type globalFlags struct {
+	profile     string
+	errorFormat string
+}
+
+// splitGlobalFlags strips the global `--profile <name>` and `--error-format text|json` (also in
+// `--flag=value` form, in any order) that may precede the command.
+func splitGlobalFlags(args []string) (globalFlags, []string, error) {
+	var g globalFlags
+	for len(args) > 0 {
+		var name, value string
+		switch {
+		case args[0] == "--profile" || args[0] == "--error-format":
+			if len(args) < 2 || strings.TrimSpace(args[1]) == "" {
+				return g, nil, fmt.Errorf("%s requires a value", args[0])
+			}
+			name, value, args = args[0], args[1], args[2:]
+		case strings.HasPrefix(args[0], "--profile=") || strings.HasPrefix(args[0], "--error-format="):
+			name, value, _ = strings.Cut(args[0], "=")
+			if strings.TrimSpace(value) == "" {
+				return g, nil, fmt.Errorf("%s requires a value", name)
+			}
+			args = args[1:]
+		default:
+			return g, args, nil
+		}
+		if name == "--profile" {
+			g.profile = value
+			continue
+		}
+		switch v := strings.ToLower(strings.TrimSpace(value)); v {
+		case errorFormatText, errorFormatJSON:
+			g.errorFormat = v
+		default:
+			return g, nil, fmt.Errorf("--error-format must be text or json (got %q)", value)
+		}
+	}
+	return g, args, nil
+}
+
+// buildErrorEnvelope turns the stderr text of a failed command into one envelope: the first
+// `ZCL_E_X: msg` line supplies code/message, later ones land in Errors, `hint:` lines in Hints,
+// and everything else (usage text, warnings) in Details.
+func buildErrorEnvelope(stderr string, exitCode int, version string) schema.ErrorEnvelopeV1 {
+	env := schema.ErrorEnvelopeV1{SchemaVersion: schema.ErrorEnvelopeSchemaV1, ExitCode: exitCode}
+	seenPath := map[string]bool{}
+	for _, line := range strings.Split(stderr, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if m := errorLineRe.FindStringSubmatch(line); m != nil {
+			if env.Code == "" {
+				env.Code, env.Message = m[1], m[2]
+			} else {
+				env.Errors = append(env.Errors, schema.ErrorEnvelopeEntryV1{Code: m[1], Message: m[2]})
+			}
+			for _, pm := range errorPathRe.FindAllStringSubmatch(m[2], -1) {
+				if p := strings.TrimRight(pm[1], "."); !seenPath[p] {
+					seenPath[p] = true
+					env.Paths = append(env.Paths, p)
+				}
+			}
+			continue
+		}
+		if hint, ok := strings.CutPrefix(line, "hint: "); ok {
+			env.Hints = append(env.Hints, hint)
+			continue
+		}
+		env.Details = append(env.Details, line)
+	}
+	if env.Code == "" {
+		env.Code = codeCommandFailed
+		if exitCode == 2 {
+			env.Code = codeUsage
+		}
+		env.Message = fmt.Sprintf("command exited %d", exitCode)
+		if len(env.Details) > 0 {
+			env.Message = env.Details[len(env.Details)-1]
+		}
+	}
+	for _, e := range contract.Build(version).Errors {
+		if e.Code == env.Code {
+			env.Retryable = e.Retryable
+			break
+		}
+	}
+	return env
+}
+
+// envelopeCaptureLimit caps how much stderr --error-format json holds back per command.
+const envelopeCaptureLimit = 64 << 10
+
+// liveStderrCommands stream stderr (runner passthrough, progress, request logs) while they run, so
+// with --error-format json only their ZCL_E_*/hint lines are held back for the envelope.
+var liveStderrCommands = map[string]bool{
+	"run": true, "serve": true, "top": true,
+	"suite run": true, "campaign run": true, "campaign canary": true, "campaign resume": true,
+	"mcp proxy": true, "http proxy": true, "ws agent": true, "repro run": true,
+}
+
+func streamsStderr(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if liveStderrCommands[args[0]] {
+		return true
+	}
+	return len(args) > 1 && liveStderrCommands[args[0]+" "+args[1]]
+}
+
+// runWithErrorEnvelope holds back the stderr lines the envelope is built from; on failure it writes
+// only the envelope, on success the held text is passed through unchanged. Other commands buffer
+// all of stderr up to envelopeCaptureLimit, past which (and from the start for live commands) lines
+// that are not ZCL_E_*/hint lines pass through as they are written.
+func (r Runner) runWithErrorEnvelope(args []string, run func(Runner) int) int {
+	ew := &envelopeWriter{w: r.Stderr, live: streamsStderr(args)}
+	r.Stderr = ew
+	exit := run(r)
+	held := ew.close()
+	if exit == 0 {
+		_, _ = ew.w.Write(held)
+		return exit
+	}
+	b, err := json.Marshal(buildErrorEnvelope(string(held), exit, r.Version))
+	if err != nil {
+		_, _ = ew.w.Write(held)
+		return exit
+	}
+	_, _ = ew.w.Write(append(b, '\n'))
+	return exit
+}
+
+// envelopeWriter splits stderr into lines held for the envelope and lines written through to w.
+type envelopeWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	live    bool
+	line    []byte // held part of the current line
+	passing bool   // the current line is being written through
+	held    []byte
+}
+
+func (e *envelopeWriter) Write(p []byte) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n := len(p)
+	for len(p) > 0 {
+		chunk, end := p, false
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			chunk, end = p[:i+1], true
+		}
+		p = p[len(chunk):]
+		if e.passing {
+			_, _ = e.w.Write(chunk)
+			e.passing = !end
+			continue
+		}
+		e.line = append(e.line, chunk...)
+		if !e.live && len(e.held)+len(e.line) > envelopeCaptureLimit {
+			e.goLive()
+		}
+		// A live line passes through as soon as it cannot be an envelope line, or outgrows the cap.
+		if e.live && (!mayBeEnvelopeLine(e.line) || len(e.line) > envelopeCaptureLimit) {
+			_, _ = e.w.Write(e.line)
+			e.line, e.passing = e.line[:0], !end
+			continue
+		}
+		if end {
+			e.hold(e.line)
+			e.line = e.line[:0]
+		}
+	}
+	return n, nil
+}
+
+// hold keeps a complete line for the envelope; in live mode lines past the cap pass through.
+func (e *envelopeWriter) hold(line []byte) {
+	if e.live && (!isEnvelopeLine(line) || len(e.held)+len(line) > envelopeCaptureLimit) {
+		_, _ = e.w.Write(line)
+		return
+	}
+	e.held = append(e.held, line...)
+}
+
+// goLive switches a buffering writer to live mode, writing through what it held that is not an
+// envelope line.
+func (e *envelopeWriter) goLive() {
+	e.live = true
+	held := e.held
+	e.held = nil
+	for len(held) > 0 {
+		line := held
+		if i := bytes.IndexByte(held, '\n'); i >= 0 {
+			line = held[:i+1]
+		}
+		held = held[len(line):]
+		e.hold(line)
+	}
+}
+
+// close holds an unterminated last line and returns everything held.
+func (e *envelopeWriter) close() []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.line) > 0 {
+		e.hold(e.line)
+		e.line = nil
+	}
+	return e.held
+}
+
+func isEnvelopeLine(line []byte) bool {
+	line = bytes.TrimRight(line, "\r\n")
+	return errorLineRe.Match(line) || bytes.HasPrefix(line, []byte("hint: "))
+}
+
+// mayBeEnvelopeLine reports whether a line start could still become a ZCL_E_*/hint line.
+func mayBeEnvelopeLine(start []byte) bool {
+	for _, prefix := range []string{"ZCL_E_", "hint: "} {
+		n := min(len(start), len(prefix))
+		if string(start[:n]) == prefix[:n] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+func TestErrorFormatJSON_WritesSingleEnvelope(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"--error-format", "json", "archive"}, "archive without --older-than")
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected exactly one stderr line, got %q", stderr.String())
+	}
+	var env schema.ErrorEnvelopeV1
+	if err := json.Unmarshal([]byte(lines[0]), &env); err != nil {
+		t.Fatalf("unmarshal envelope: %v", err)
+	}
+	if env.OK || env.ExitCode != 2 || env.Code != codeUsage || env.Message != "archive: require --older-than" || len(env.Details) == 0 {
+		t.Fatalf("unexpected envelope: %+v", env)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"--error-format=yaml", "version"}, "bad error format")
+	if !strings.HasPrefix(stderr.String(), codeUsage+": --error-format") {
+		t.Fatalf("expected text usage error, got %q", stderr.String())
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"--error-format", "json", "version"}, "version")
+	if stderr.Len() != 0 {
+		t.Fatalf("successful command must not write an envelope: %q", stderr.String())
+	}
+}
+
+func TestBuildErrorEnvelope(t *testing.T) {
+	env := buildErrorEnvelope(codeIO+": read /tmp/x/run.json: no such file\nhint: run zcl init\n"+codeUsage+": second\n", 1, "0.0.0-dev")
+	if env.Code != codeIO || !env.Retryable || len(env.Paths) != 1 || env.Paths[0] != "/tmp/x/run.json" {
+		t.Fatalf("unexpected envelope: %+v", env)
+	}
+	if len(env.Hints) != 1 || len(env.Errors) != 1 || env.Errors[0].Code != codeUsage {
+		t.Fatalf("unexpected hints/errors: %+v", env)
+	}
+	env = buildErrorEnvelope("", 1, "0.0.0-dev")
+	if env.Code != codeCommandFailed || env.Message != "command exited 1" {
+		t.Fatalf("unexpected fallback: %+v", env)
+	}
+}
+
+func TestEnvelopeWriter_LivePassesThroughAndHoldsErrorLines(t *testing.T) {
+	var out bytes.Buffer
+	ew := &envelopeWriter{w: &out, live: true}
+	_, _ = ew.Write([]byte("runner: step 1\nZCL_E_TI"))
+	if out.String() != "runner: step 1\n" {
+		t.Fatalf("expected passthrough before the command ends, got %q", out.String())
+	}
+	_, _ = ew.Write([]byte("MEOUT: deadline exceeded\nhint: raise --timeout\nprogress 50%"))
+	if out.String() != "runner: step 1\nprogress 50%" {
+		t.Fatalf("expected only non-envelope text passed through, got %q", out.String())
+	}
+	held := string(ew.close())
+	if held != "ZCL_E_TIMEOUT: deadline exceeded\nhint: raise --timeout\n" {
+		t.Fatalf("unexpected held lines: %q", held)
+	}
+	if env := buildErrorEnvelope(held, 1, "0.0.0-dev"); env.Code != codeTimeout || len(env.Hints) != 1 || len(env.Details) != 0 {
+		t.Fatalf("unexpected envelope: %+v", env)
+	}
+}
+
+func TestEnvelopeWriter_BufferGoesLivePastCaptureLimit(t *testing.T) {
+	var out bytes.Buffer
+	ew := &envelopeWriter{w: &out}
+	_, _ = ew.Write([]byte(codeIO + ": first\nusage text\n"))
+	if out.Len() != 0 {
+		t.Fatalf("expected buffering below the cap, got %q", out.String())
+	}
+	noise := strings.Repeat("x", 1023) + "\n"
+	for i := 0; i < envelopeCaptureLimit/len(noise)+1; i++ {
+		_, _ = ew.Write([]byte(noise))
+	}
+	if !ew.live || !strings.HasPrefix(out.String(), "usage text\n"+noise) {
+		t.Fatalf("expected held text flushed once past the cap, got %d bytes", out.Len())
+	}
+	if held := string(ew.close()); held != codeIO+": first\n" {
+		t.Fatalf("expected the error line still held, got %q", held)
+	}
+}
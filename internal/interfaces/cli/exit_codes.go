@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/config"
+)
+
+// exitPassthroughCommands exit with their wrapped command's code, which has no outcome class, so
+// they are never remapped.
+var exitPassthroughCommands = map[string]bool{"run": true}
+
+// remapExitCode applies the configured exitCodes block to a failed command. Classes follow the
+// exit code contract: 1 is a harness error, 2 with a leading ZCL_E_USAGE line is a usage error and
+// any other 2 is an invalid run (invalid campaign, failed suite/gate, failed validation). Other
+// codes and an unreadable config leave exit unchanged.
+func remapExitCode(exit int, firstCode string) int {
+	if exit != 1 && exit != 2 {
+		return exit
+	}
+	m, err := config.LoadMerged("")
+	if err != nil {
+		return exit
+	}
+	codes := m.ExitCodes.Resolved()
+	switch {
+	case exit == 1:
+		return codes.HarnessError
+	case firstCode == codeUsage:
+		return codes.Usage
+	default:
+		return codes.InvalidRun
+	}
+}
+
+// errorCodeWriter passes writes through and remembers the code of the first `ZCL_E_X: msg` line.
+type errorCodeWriter struct {
+	w    io.Writer
+	line []byte
+	code string
+}
+
+func (e *errorCodeWriter) Write(p []byte) (int, error) {
+	for rest := p; e.code == "" && len(rest) > 0; {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			// Only the line start matters; cap what a runaway line can buffer.
+			if len(e.line) < 256 {
+				e.line = append(e.line, rest...)
+			}
+			break
+		}
+		e.line = append(e.line, rest[:i]...)
+		if m := errorLineRe.FindSubmatch(bytes.TrimRight(e.line, "\r")); m != nil {
+			e.code = string(m[1])
+		}
+		e.line, rest = e.line[:0], rest[i+1:]
+	}
+	return e.w.Write(p)
+}
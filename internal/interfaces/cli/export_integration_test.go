@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExport_InspectAIAndHELMFromCampaign(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "export-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-eval-export
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	var res struct {
+		OK      bool     `json:"ok"`
+		Format  string   `json:"format"`
+		Files   []string `json:"files"`
+		Samples int      `json:"samples"`
+		Passed  int      `json:"passed"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"export", "--format", "inspect-ai", "--campaign-id", "cmp-eval-export", "--out-root", outRoot, "--json"}, &res, "export inspect-ai")
+	want := filepath.Join(outRoot, "campaigns", "cmp-eval-export", "export", "inspect-ai", "cmp-eval-export_flow-a.json")
+	if !res.OK || res.Samples != 1 || res.Passed != 1 || len(res.Files) != 1 || res.Files[0] != want {
+		t.Fatalf("unexpected inspect-ai export: %+v", res)
+	}
+	if raw, err := os.ReadFile(want); err != nil || !strings.Contains(string(raw), `"model": "zcl/process_cmd"`) {
+		t.Fatalf("expected runner-type model in eval log (err=%v): %s", err, raw)
+	}
+
+	helmDir := filepath.Join(t.TempDir(), "helm")
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"export", "--format", "helm", "--spec", specPath, "--out", helmDir, "--json"}, &res, "export helm")
+	if len(res.Files) != 4 || res.Files[3] != filepath.Join(helmDir, "cmp-eval-export_flow-a", "stats.json") {
+		t.Fatalf("unexpected helm export: %+v", res)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"export", "--format", "csv", "--campaign-id", "cmp-eval-export", "--out-root", outRoot}, "export bad format")
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInitScaffold_SuiteAndCampaign(t *testing.T) {
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	r := &Runner{Version: "0.0.0-dev", Now: func() time.Time { return time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC) }, Stdout: &stdout, Stderr: &stderr}
+
+	suitePath := filepath.Join(dir, "suite.json")
+	var suiteRes initScaffoldResult
+	runCLICommandJSON(t, r, &stdout, &stderr, 0, []string{"init", "suite", "--adapter", "codex_exec", "--suite-id", "demo", "--mission", "m1", "--mission", "m2", "--out", suitePath, "--json"}, &suiteRes, "init suite")
+	if !suiteRes.OK || suiteRes.Adapter != "codex_exec" || len(suiteRes.Next) != 2 || !strings.Contains(suiteRes.Next[1], "--result-channel file_json --result-file mission.result.json --json -- codex exec") {
+		t.Fatalf("unexpected init suite result: %+v", suiteRes)
+	}
+	runCLICommand(t, r, &stdout, &stderr, 2, []string{"init", "suite", "--adapter", "codex_exec", "--out", suitePath, "--json"}, "init suite (exists)")
+	if !strings.Contains(stderr.String(), "--force") {
+		t.Fatalf("expected overwrite hint, got %q", stderr.String())
+	}
+	runCLICommand(t, r, &stdout, &stderr, 2, []string{"init", "suite", "--adapter", "nope", "--out", filepath.Join(dir, "x.json"), "--json"}, "init suite (bad adapter)")
+
+	specPath := filepath.Join(dir, "campaigns", "campaign.yaml")
+	var campaignRes initScaffoldResult
+	runCLICommandJSON(t, r, &stdout, &stderr, 0, []string{"init", "campaign", "--adapter", "codex_app_server", "--campaign-id", "demo", "--out", specPath, "--json"}, &campaignRes, "init campaign")
+	if len(campaignRes.Created) != 2 || campaignRes.Created[0] != filepath.Join(dir, "campaigns", "suite.json") || campaignRes.Path != specPath {
+		t.Fatalf("expected suite and spec to be created, got %+v", campaignRes)
+	}
+	runCLICommand(t, r, &stdout, &stderr, 0, []string{"campaign", "lint", "--spec", specPath, "--json"}, "campaign lint")
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrate_V1ToV2KeepsRunValid(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "migrate-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-migrate
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+	runDirs, _ := filepath.Glob(filepath.Join(outRoot, "runs", "*"))
+	if len(runDirs) != 1 {
+		t.Fatalf("expected one run, got %v", runDirs)
+	}
+	runID := filepath.Base(runDirs[0])
+
+	var res struct {
+		Files []struct {
+			Path   string `json:"path"`
+			Action string `json:"action"`
+		} `json:"files"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"migrate", "--from", "1", "--to", "2", "--run-id", runID, "--out-root", outRoot, "--json"}, &res, "migrate")
+	if len(res.Files) != 1 || res.Files[0].Action != "migrated" {
+		t.Fatalf("unexpected migrate result: %+v", res)
+	}
+	var rep struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	attempts, _ := filepath.Glob(filepath.Join(runDirs[0], "attempts", "*"))
+	mustReadJSONFile(t, filepath.Join(attempts[0], "attempt.report.json"), &rep, "attempt report")
+	if rep.SchemaVersion != 2 {
+		t.Fatalf("expected schemaVersion 2, got %d", rep.SchemaVersion)
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"validate", runDirs[0]}, "validate migrated run")
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"migrate", "--from", "2", "--to", "3", "--run-id", runID, "--out-root", outRoot}, "unsupported hop")
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/domain/oracle"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+type claimVerifiedArtifact struct {
+	SchemaVersion int                    `json:"schemaVersion"`
+	CampaignID    string                 `json:"campaignId"`
+	FlowID        string                 `json:"flowId"`
+	MissionID     string                 `json:"missionId"`
+	AttemptID     string                 `json:"attemptId"`
+	EvaluatorKind string                 `json:"evaluatorKind"`
+	OK            bool                   `json:"ok"`
+	Counts        campaign.ClaimCountsV1 `json:"counts"`
+	Claims        []oracle.Claim         `json:"claims"`
+	CreatedAt     string                 `json:"createdAt"`
+}
+
+// writeClaimVerification records which fields of the attempt's answer the oracle confirmed,
+// refuted or could not check. Only a single builtin evaluator says which fields it checked; for
+// other evaluators fields without a mismatch stay unverifiable. Attempts without a readable answer
+// get no artifact.
+func (r Runner) writeClaimVerification(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, oraclePath string, out oracleEvaluatorOutput) (*campaign.ClaimCountsV1, error) {
+	answer, err := loadOracleAnswerFromAttempt(ar.AttemptDir)
+	if err != nil {
+		return nil, nil
+	}
+	var checked []string
+	if ev := parsed.Spec.Evaluation; len(ev.Evaluators) == 0 && (ev.Evaluator.Kind == campaign.EvaluatorKindBuiltin || ev.Evaluator.Kind == campaign.EvaluatorKindBuiltinCompare) && !oracleOutputErrored(out) {
+		if file, err := oracle.LoadFile(oraclePath); err == nil {
+			checked = oracle.CheckedFields(file)
+		}
+	}
+	claims := oracle.DiffClaims(answer, checked, out.Mismatches)
+	var counts campaign.ClaimCountsV1
+	for _, c := range claims {
+		switch c.Status {
+		case oracle.ClaimConfirmed:
+			counts.Confirmed++
+		case oracle.ClaimRefuted:
+			counts.Refuted++
+		default:
+			counts.Unverifiable++
+		}
+	}
+	now := time.Now().UTC()
+	if r.Now != nil {
+		now = r.Now().UTC()
+	}
+	artifact := claimVerifiedArtifact{
+		SchemaVersion: schema.ClaimVerifiedSchemaV1,
+		CampaignID:    parsed.Spec.CampaignID,
+		FlowID:        flowID,
+		MissionID:     missionID,
+		AttemptID:     ar.AttemptID,
+		EvaluatorKind: oracleVerdictEvaluatorKind(parsed, out),
+		OK:            out.OK,
+		Counts:        counts,
+		Claims:        claims,
+		CreatedAt:     now.Format(time.RFC3339Nano),
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(strings.TrimSpace(ar.AttemptDir), artifacts.ClaimVerifiedJSON), artifact); err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
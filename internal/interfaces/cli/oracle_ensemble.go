@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+)
+
+type oracleEnsembleInfo struct {
+	Policy  string                 `json:"policy"`
+	Passed  int                    `json:"passed"`
+	Failed  int                    `json:"failed"`
+	Errored int                    `json:"errored"`
+	Members []oracleEnsembleMember `json:"members"`
+}
+
+type oracleEnsembleMember struct {
+	ID          string           `json:"id"`
+	Kind        string           `json:"kind"`
+	OK          bool             `json:"ok"`
+	Error       bool             `json:"error,omitempty"`
+	ReasonCodes []string         `json:"reasonCodes,omitempty"`
+	Message     string           `json:"message,omitempty"`
+	Judge       *oracleJudgeInfo `json:"judge,omitempty"`
+}
+
+// evaluateOracleEnsembleForAttempt runs every evaluation.evaluators member and combines their votes.
+// A member votes ok when it would pass the gate on its own (format policy applied); members that
+// error abstain under majority and fail the ensemble under unanimous.
+func (r Runner) evaluateOracleEnsembleForAttempt(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, oraclePath string) oracleEvaluatorOutput {
+	policy := parsed.Spec.Evaluation.OraclePolicy
+	info := &oracleEnsembleInfo{Policy: parsed.Spec.Evaluation.EnsemblePolicy}
+	out := oracleEvaluatorOutput{PolicyDisposition: policy.FormatMismatch, Ensemble: info}
+	var failing, passing []oracleEvaluatorOutput
+	for _, ev := range parsed.Spec.Evaluation.Evaluators {
+		res := r.evaluateOracleWithEvaluator(parsed, ev, flowID, missionID, ar, oraclePath)
+		m := oracleEnsembleMember{
+			ID:          ev.ID,
+			Kind:        ev.Kind,
+			ReasonCodes: dedupeSortedStrings(res.ReasonCodes),
+			Message:     res.Message,
+			Judge:       res.Judge,
+		}
+		switch {
+		case oracleOutputErrored(res):
+			m.Error = true
+			info.Errored++
+		case len(oracleFailureReasonCodes(policy, res)) == 0:
+			m.OK = true
+			info.Passed++
+			passing = append(passing, res)
+		default:
+			info.Failed++
+			failing = append(failing, res)
+		}
+		info.Members = append(info.Members, m)
+	}
+
+	switch {
+	case info.Passed+info.Failed == 0:
+		out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
+		out.Message = "every ensemble evaluator errored"
+		return out
+	case info.Policy == campaign.EnsemblePolicyUnanimous && info.Errored > 0:
+		out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
+		out.Message = fmt.Sprintf("unanimous ensemble: %d evaluator(s) errored", info.Errored)
+		return out
+	case info.Policy == campaign.EnsemblePolicyUnanimous:
+		out.OK = info.Failed == 0
+	default:
+		out.OK = info.Passed > info.Failed
+	}
+	if out.OK {
+		for _, res := range passing {
+			out.Warnings = append(out.Warnings, res.Warnings...)
+		}
+		out.Warnings = dedupeSortedStrings(out.Warnings)
+		out.Message = fmt.Sprintf("%s ensemble passed (%d ok, %d failed, %d errored)", info.Policy, info.Passed, info.Failed, info.Errored)
+		return out
+	}
+	for _, res := range failing {
+		out.Mismatches = append(out.Mismatches, res.Mismatches...)
+		out.Warnings = append(out.Warnings, res.Warnings...)
+	}
+	out.Warnings = dedupeSortedStrings(out.Warnings)
+	out.ReasonCodes = []string{campaign.ReasonOracleEvalFailed}
+	out.Message = fmt.Sprintf("%s ensemble failed (%d ok, %d failed, %d errored)", info.Policy, info.Passed, info.Failed, info.Errored)
+	return out
+}
+
+func oracleOutputErrored(out oracleEvaluatorOutput) bool {
+	if out.OK {
+		return false
+	}
+	return containsString(out.ReasonCodes, campaign.ReasonOracleEvalError) || containsString(out.ReasonCodes, campaign.ReasonOracleEvaluator)
+}
+
+func oracleEnsembleVotes(info *oracleEnsembleInfo) []campaign.OracleVoteV1 {
+	if info == nil {
+		return nil
+	}
+	votes := make([]campaign.OracleVoteV1, 0, len(info.Members))
+	for _, m := range info.Members {
+		votes = append(votes, campaign.OracleVoteV1{EvaluatorID: m.ID, OK: m.OK, Error: m.Error})
+	}
+	return votes
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/app/judge"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evaluation/domain/oracle"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+)
+
+const llmJudgeTimeout = 3 * time.Minute
+
+type oracleJudgeInfo struct {
+	Model    string `json:"model"`
+	Strategy string `json:"strategy"`
+	CacheKey string `json:"cacheKey"`
+	Cached   bool   `json:"cached"`
+}
+
+func evaluateOracleLLMJudgeForAttempt(parsed campaign.ParsedSpec, ev campaign.EvaluatorSpec, ar *campaign.AttemptStatusV1, oraclePath string) oracleEvaluatorOutput {
+	out := defaultOracleEvaluatorOutput()
+	out.PolicyDisposition = parsed.Spec.Evaluation.OraclePolicy.FormatMismatch
+	verdict, err := runLLMJudge(ev, ar.AttemptDir, oraclePath)
+	if err != nil {
+		out.Message = trimText(err.Error(), 1024)
+		return out
+	}
+	out.OK = verdict.OK
+	out.Message = trimText(verdict.Reason, 1024)
+	out.Mismatches = verdict.Mismatches
+	out.Judge = &oracleJudgeInfo{
+		Model:    ev.Model,
+		Strategy: strings.Join(ev.RuntimeStrategies, ","),
+		CacheKey: verdict.CacheKey,
+		Cached:   verdict.Cached,
+	}
+	out.ReasonCodes = nil
+	if !verdict.OK {
+		out.ReasonCodes = []string{campaign.ReasonOracleEvalFailed}
+		if oracle.AllMismatchesClass(verdict.Mismatches, oracle.MismatchFormat) &&
+			parsed.Spec.Evaluation.OraclePolicy.FormatMismatch == campaign.OracleFormatMismatchWarn {
+			out.Warnings = append(out.Warnings, "format_only_oracle_mismatch")
+		}
+	}
+	return out
+}
+
+func runLLMJudge(ev campaign.EvaluatorSpec, attemptDir, oraclePath string) (judge.Verdict, error) {
+	raw, err := os.ReadFile(oraclePath)
+	if err != nil {
+		return judge.Verdict{}, err
+	}
+	var oracleDoc any
+	if err := json.Unmarshal(raw, &oracleDoc); err != nil {
+		return judge.Verdict{}, fmt.Errorf("invalid oracle json: %w", err)
+	}
+	answer, err := loadOracleAnswerFromAttempt(attemptDir)
+	if err != nil {
+		return judge.Verdict{}, err
+	}
+	req := judge.Request{
+		Model:        ev.Model,
+		Strategy:     strings.Join(ev.RuntimeStrategies, ","),
+		Instructions: ev.Instructions,
+		Oracle:       oracleDoc,
+		Answer:       answer,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), llmJudgeTimeout)
+	defer cancel()
+	return judge.Evaluate(ctx, req, llmJudgeCacheDir(attemptDir), askNativeJudge(ev))
+}
+
+// llmJudgeCacheDir keeps verdicts under <outRoot>/cache/llm-judge so re-reports across runs reuse them
+// (attemptDir is <outRoot>/runs/<runId>/attempts/<attemptId>).
+func llmJudgeCacheDir(attemptDir string) string {
+	outRoot := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filepath.Clean(attemptDir)))))
+	return filepath.Join(outRoot, "cache", "llm-judge")
+}
+
+func askNativeJudge(ev campaign.EvaluatorSpec) judge.AskFunc {
+	return func(ctx context.Context, prompt string) (string, error) {
+		sel, err := native.Resolve(ctx, buildNativeRuntimeRegistry(), native.ResolveInput{
+			StrategyChain:        native.NormalizeStrategyChain(ev.RuntimeStrategies),
+			RequiredCapabilities: []native.Capability{native.CapabilityThreadStart, native.CapabilityEventStream},
+		})
+		if err != nil {
+			return "", err
+		}
+		scratch, err := os.MkdirTemp("", "zcl-llm-judge-")
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = os.RemoveAll(scratch) }()
+		sess, err := sel.Runtime.StartSession(ctx, native.SessionOptions{AttemptDir: scratch})
+		if err != nil {
+			return "", err
+		}
+		defer closeSuiteNativeSession(sess, sel.Selected)
+		events := make(chan native.Event, 128)
+		listenerID, err := sess.AddListener(func(e native.Event) {
+			select {
+			case events <- e:
+			default:
+			}
+		})
+		if err != nil {
+			return "", err
+		}
+		defer removeSuiteNativeListener(sess, listenerID)
+		thread, err := sess.StartThread(ctx, native.ThreadStartRequest{Model: ev.Model, Cwd: scratch})
+		if err != nil {
+			return "", err
+		}
+		turn, err := sess.StartTurn(ctx, native.TurnStartRequest{
+			ThreadID: thread.ThreadID,
+			Input:    []native.InputItem{{Type: "text", Text: prompt}},
+		})
+		if err != nil {
+			return "", err
+		}
+		return awaitNativeJudgeAnswer(ctx, sess, thread, turn, sel.Selected, events)
+	}
+}
+
+func awaitNativeJudgeAnswer(ctx context.Context, sess native.Session, thread native.ThreadHandle, turn native.TurnHandle, strategy native.StrategyID, events <-chan native.Event) (string, error) {
+	collector := newNativeResultCollector()
+	var failure suiteRunAttemptResult
+	noState := func(nativeAttemptState, bool, map[string]any) {}
+	for {
+		select {
+		case e := <-events:
+			collector.Observe(e)
+			if nativeEventIsTurnCompleted(e, turn.TurnID) {
+				answer, _, ok := collector.ResolveFinalResult()
+				if !ok {
+					return "", fmt.Errorf("llm judge produced no final answer")
+				}
+				return answer, nil
+			}
+			if observeSuiteNativeEventFailure(e, strategy, &failure, noState, false) {
+				return "", fmt.Errorf("llm judge turn failed: %s", failure.RunnerErrorCode)
+			}
+		case <-ctx.Done():
+			_ = sess.InterruptTurn(context.Background(), native.TurnInterruptRequest{ThreadID: thread.ThreadID, TurnID: turn.TurnID})
+			return "", fmt.Errorf("llm judge timed out: %w", ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+)
+
+type oracleRubricInfo struct {
+	Score     float64                 `json:"score"`
+	Earned    float64                 `json:"earned"`
+	Total     float64                 `json:"total"`
+	PassScore float64                 `json:"passScore"`
+	Criteria  []oracleRubricCriterion `json:"criteria"`
+}
+
+type oracleRubricCriterion struct {
+	Criterion   string           `json:"criterion"`
+	Weight      float64          `json:"weight"`
+	Kind        string           `json:"kind"`
+	OK          bool             `json:"ok"`
+	Error       bool             `json:"error,omitempty"`
+	ReasonCodes []string         `json:"reasonCodes,omitempty"`
+	Message     string           `json:"message,omitempty"`
+	Judge       *oracleJudgeInfo `json:"judge,omitempty"`
+}
+
+// evaluateOracleRubricForAttempt grades every rubric criterion; errored criteria earn nothing.
+func (r Runner) evaluateOracleRubricForAttempt(parsed campaign.ParsedSpec, flowID, missionID string, ar *campaign.AttemptStatusV1, oraclePath string) *oracleRubricInfo {
+	policy := parsed.Spec.Evaluation.OraclePolicy
+	info := &oracleRubricInfo{PassScore: parsed.Spec.Evaluation.RubricPassScore}
+	for _, c := range parsed.Spec.Evaluation.Rubric {
+		res := r.evaluateOracleWithEvaluator(parsed, c.Evaluator, flowID, missionID, ar, oraclePath)
+		item := oracleRubricCriterion{
+			Criterion:   c.Criterion,
+			Weight:      c.Weight,
+			Kind:        c.Evaluator.Kind,
+			ReasonCodes: dedupeSortedStrings(res.ReasonCodes),
+			Message:     res.Message,
+			Judge:       res.Judge,
+		}
+		switch {
+		case oracleOutputErrored(res):
+			item.Error = true
+		case len(oracleFailureReasonCodes(policy, res)) == 0:
+			item.OK = true
+			info.Earned += c.Weight
+		}
+		info.Total += c.Weight
+		info.Criteria = append(info.Criteria, item)
+	}
+	if info.Total > 0 {
+		info.Score = math.Round(info.Earned/info.Total*10000) / 10000
+	}
+	return info
+}
+
+// rubricGateOutput turns a rubric into the attempt verdict when no evaluator gates the attempt.
+func rubricGateOutput(parsed campaign.ParsedSpec, info *oracleRubricInfo) oracleEvaluatorOutput {
+	out := oracleEvaluatorOutput{PolicyDisposition: parsed.Spec.Evaluation.OraclePolicy.FormatMismatch, Rubric: info}
+	errored := 0
+	for _, c := range info.Criteria {
+		if c.Error {
+			errored++
+		}
+	}
+	if errored == len(info.Criteria) {
+		out.ReasonCodes = []string{campaign.ReasonOracleEvalError}
+		out.Message = "every rubric criterion errored"
+		return out
+	}
+	out.OK = info.Score >= info.PassScore
+	out.Message = fmt.Sprintf("rubric score %.4g (pass score %.4g)", info.Score, info.PassScore)
+	if !out.OK {
+		out.ReasonCodes = []string{campaign.ReasonOracleEvalFailed}
+	}
+	return out
+}
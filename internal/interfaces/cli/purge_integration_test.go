@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPurge_RewritesAttemptAndKeepsItValid(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "purge-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-purge
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+	attempts, _ := filepath.Glob(filepath.Join(outRoot, "runs", "*", "attempts", "*"))
+	if len(attempts) != 1 {
+		t.Fatalf("expected one attempt dir, got %v", attempts)
+	}
+	attemptDir := attempts[0]
+	tracePath := filepath.Join(attemptDir, "tool.calls.jsonl")
+	before, err := os.ReadFile(tracePath)
+	if err != nil || !bytes.Contains(before, []byte("echo")) {
+		t.Fatalf("expected trace to mention echo, err=%v", err)
+	}
+
+	type purgeResult struct {
+		OK        bool `json:"ok"`
+		Confirmed bool `json:"confirmed"`
+		Record    struct {
+			Reason         string `json:"reason"`
+			PatternSHA256  string `json:"patternSha256"`
+			Matches        int    `json:"matches"`
+			TraceRechained bool   `json:"traceRechained"`
+			Files          []struct {
+				Path         string `json:"path"`
+				SHA256Before string `json:"sha256Before"`
+				SHA256After  string `json:"sha256After"`
+			} `json:"files"`
+		} `json:"record"`
+	}
+	args := []string{"purge", "--attempt", attemptDir, "--pattern", `\becho\b`, "--reason", "subject access request #12", "--json"}
+	var preview purgeResult
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, args, &preview, "purge preview")
+	if !preview.OK || preview.Confirmed || preview.Record.Matches == 0 {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+	if after, _ := os.ReadFile(tracePath); !bytes.Equal(after, before) {
+		t.Fatalf("preview must not rewrite artifacts")
+	}
+
+	var done purgeResult
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, append(args, "--confirm"), &done, "purge confirm")
+	if !done.Confirmed || !done.Record.TraceRechained || done.Record.Reason != "subject access request #12" {
+		t.Fatalf("unexpected purge result: %+v", done)
+	}
+	paths := []string{}
+	for _, f := range done.Record.Files {
+		if f.SHA256Before == f.SHA256After {
+			t.Fatalf("expected %s to change, got %+v", f.Path, f)
+		}
+		paths = append(paths, f.Path)
+	}
+	if !containsString(paths, "tool.calls.jsonl") || !containsString(paths, "attempt.report.json") {
+		t.Fatalf("expected trace and report (moved traceChainHead) to be rewritten, got %v", paths)
+	}
+	if after, _ := os.ReadFile(tracePath); bytes.Contains(after, []byte("echo")) || !bytes.Contains(after, []byte("[PURGED]")) {
+		t.Fatalf("expected echo to be purged from the trace, got %s", after)
+	}
+	log, err := os.ReadFile(filepath.Join(attemptDir, "purge.jsonl"))
+	if err != nil || bytes.Contains(log, []byte("echo")) || !bytes.Contains(log, []byte(done.Record.PatternSHA256)) {
+		t.Fatalf("expected purge.jsonl with the pattern hash only, got %s err=%v", log, err)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"validate", "--strict", "--json", attemptDir}, "validate after purge")
+
+	var again purgeResult
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, append(args, "--confirm"), &again, "purge again")
+	if again.Confirmed || again.Record.Matches != 0 {
+		t.Fatalf("expected nothing left to purge, got %+v", again)
+	}
+}
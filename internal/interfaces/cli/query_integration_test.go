@@ -3,8 +3,13 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
 )
 
 func TestAttemptListLatestAndRunsList(t *testing.T) {
@@ -288,6 +293,48 @@ func TestFeedbackHintForMissingTrace(t *testing.T) {
 	}
 }
 
+func TestFeedbackFieldErrorsAndAppendTag(t *testing.T) {
+	outRoot := t.TempDir()
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC) },
+	}
+	start := startAttemptForQuery(t, r, outRoot, "", "fb-suite", "fb-mission")
+	runOnlyForQuery(t, r, start.Env)
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	r.Stdout = &stdout
+	r.Stderr = &stderr
+	if code := r.Run([]string{"feedback", "--ok", "--result-json", `{"a":`, "--decision-tag", "nope"}); code != 2 {
+		t.Fatalf("expected feedback usage failure, got %d", code)
+	}
+	for _, want := range []string{"field decisionTags[0]: unknown tag \"nope\"", "field resultJson: invalid json at line 1, column 5"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Fatalf("expected %q in stderr, got %q", want, stderr.String())
+		}
+	}
+
+	stderr.Reset()
+	if code := r.Run([]string{"feedback", "--append-tag", "blocked", "--result", "x"}); code != 2 {
+		t.Fatalf("expected --append-tag with --result to be rejected, got %d", code)
+	}
+	if code := r.Run([]string{"feedback", "--ok", "--result-json", `{"a":1}`}); code != 0 {
+		t.Fatalf("feedback failed: stderr=%q", stderr.String())
+	}
+	if code := r.Run([]string{"feedback", "--fail", "--append-tag", "blocked", "--confidence", "1.2"}); code != 2 || !strings.Contains(stderr.String(), "field confidence:") {
+		t.Fatalf("expected confidence range error, got %d stderr=%q", code, stderr.String())
+	}
+	if code := r.Run([]string{"feedback", "--fail", "--append-tag", "blocked", "--merge-json", `{"b":2}`, "--confidence", "0.4"}); code != 0 {
+		t.Fatalf("feedback update failed: stderr=%q", stderr.String())
+	}
+	var fb schema.FeedbackJSONV1
+	mustReadJSONFile(t, filepath.Join(start.Env["ZCL_OUT_DIR"], "feedback.json"), &fb, "feedback.json")
+	if fb.OK || len(fb.DecisionTags) != 1 || fb.DecisionTags[0] != "blocked" || !strings.Contains(string(fb.ResultJSON), `"b": 2`) || fb.Confidence == nil || *fb.Confidence != 0.4 {
+		t.Fatalf("unexpected updated feedback: %+v (%s)", fb, fb.ResultJSON)
+	}
+}
+
 func TestVersionAliasCLI(t *testing.T) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -337,3 +384,69 @@ func TestRunsListStatusFilter(t *testing.T) {
 		t.Fatalf("expected one failing run row, got %+v", out)
 	}
 }
+
+func TestRunsListAndCampaignStatusFlagStaleHeartbeats(t *testing.T) {
+	outRoot := t.TempDir()
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC) },
+	}
+	start := startAttemptForQuery(t, r, outRoot, "", "hb-suite", "m-hung")
+	attemptDir := start.Env["ZCL_OUT_DIR"]
+	runDir := filepath.Join(outRoot, "runs", start.RunID)
+	mustWriteFile(t, filepath.Join(attemptDir, "attempt.heartbeat.json"), `{"schemaVersion":1,"pid":4242,"intervalMs":5000,"startedAt":"2026-02-16T11:55:00Z","beatAt":"2026-02-16T11:58:00Z"}`)
+	mustWriteFile(t, filepath.Join(runDir, "run.invocation.json"), `{"schemaVersion":1,"runId":"`+start.RunID+`","argv":["suite","run","--campaign-id","cmp-hb"]}`)
+	if err := os.MkdirAll(filepath.Join(outRoot, "campaigns", "cmp-hb"), 0o755); err != nil {
+		t.Fatalf("mkdir campaign dir: %v", err)
+	}
+	mustWriteFile(t, filepath.Join(outRoot, "campaigns", "cmp-hb", "campaign.run.state.json"), `{"schemaVersion":1,"campaignId":"cmp-hb","runId":"cmp-run-1","status":"running","totalMissions":1}`)
+
+	runsList := func(extra ...string) []struct {
+		AttemptID      string `json:"attemptId"`
+		MissionID      string `json:"missionId"`
+		HeartbeatAgeMs int64  `json:"heartbeatAgeMs"`
+	} {
+		t.Helper()
+		var out struct {
+			Runs []struct {
+				StaleAttempts []struct {
+					AttemptID      string `json:"attemptId"`
+					MissionID      string `json:"missionId"`
+					HeartbeatAgeMs int64  `json:"heartbeatAgeMs"`
+				} `json:"staleAttempts"`
+			} `json:"runs"`
+		}
+		runQueryCommandJSON(t, &r, append([]string{"runs", "list", "--out-root", outRoot, "--json"}, extra...), &out, "runs list")
+		if len(out.Runs) != 1 {
+			t.Fatalf("expected one run row, got %+v", out.Runs)
+		}
+		return out.Runs[0].StaleAttempts
+	}
+	if stale := runsList(); len(stale) != 1 || stale[0].MissionID != "m-hung" || stale[0].HeartbeatAgeMs != 120000 {
+		t.Fatalf("expected the hung attempt to be flagged, got %+v", stale)
+	}
+	if stale := runsList("--stale-after-ms", "300000"); len(stale) != 0 {
+		t.Fatalf("expected no stale attempts under a 5m threshold, got %+v", stale)
+	}
+
+	var status struct {
+		Status        string `json:"status"`
+		StaleAttempts []struct {
+			RunID     string `json:"runId"`
+			AttemptID string `json:"attemptId"`
+		} `json:"staleAttempts"`
+	}
+	runQueryCommandJSON(t, &r, []string{"campaign", "status", "--campaign-id", "cmp-hb", "--out-root", outRoot, "--json"}, &status, "campaign status")
+	if status.Status != "running" || len(status.StaleAttempts) != 1 || status.StaleAttempts[0].RunID != start.RunID {
+		t.Fatalf("unexpected campaign status: %+v", status)
+	}
+
+	var stdout, stderr bytes.Buffer
+	r.Stdout, r.Stderr = &stdout, &stderr
+	if code := r.Run([]string{"campaign", "status", "--campaign-id", "cmp-hb", "--out-root", outRoot}); code != 0 {
+		t.Fatalf("campaign status failed: code=%d stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "stale attempt (likely hung)") || !strings.Contains(stdout.String(), "heartbeatAge=2m0s") {
+		t.Fatalf("expected stale attempt line, got %q", stdout.String())
+	}
+}
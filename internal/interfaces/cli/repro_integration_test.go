@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRepro_BundleAndRerunCampaignFlow(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "repro-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-repro
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+	runDirs, _ := filepath.Glob(filepath.Join(outRoot, "runs", "*"))
+	if len(runDirs) != 1 {
+		t.Fatalf("expected one run, got %v", runDirs)
+	}
+	runID := filepath.Base(runDirs[0])
+	var inv struct {
+		RunID string   `json:"runId"`
+		Argv  []string `json:"argv"`
+	}
+	mustReadJSONFile(t, filepath.Join(runDirs[0], "run.invocation.json"), &inv, "run invocation")
+	if inv.RunID != runID || len(inv.Argv) < 2 || inv.Argv[0] != "suite" || inv.Argv[1] != "run" {
+		t.Fatalf("unexpected invocation: %+v", inv)
+	}
+
+	bundleDir := filepath.Join(t.TempDir(), "bundle")
+	var res struct {
+		OK         bool   `json:"ok"`
+		CampaignID string `json:"campaignId"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"repro", "bundle", "--run-id", runID, "--out-root", outRoot, "--out", bundleDir, "--json"}, &res, "repro bundle")
+	if !res.OK || res.CampaignID != "cmp-repro" {
+		t.Fatalf("unexpected bundle result: %+v", res)
+	}
+	for _, name := range []string{"repro.json", "suite.json", "run.invocation.json", "config.json", "env.policy.json", "campaign/campaign.yaml"} {
+		if _, err := os.Stat(filepath.Join(bundleDir, filepath.FromSlash(name))); err != nil {
+			t.Fatalf("expected bundle file %s: %v", name, err)
+		}
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"repro", "bundle", "--run-id", runID, "--out-root", outRoot, "--out", bundleDir}, "repro bundle into non-empty dir")
+
+	rerunRoot := t.TempDir()
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"repro", "run", "--out-root", rerunRoot, bundleDir}, "repro run")
+	sum := parseSuiteRunOKEndToEndSummary(t, stdout.Bytes(), stdout.String())
+	if !sum.OK || len(sum.Attempts) != 1 || !strings.HasPrefix(sum.Attempts[0].AttemptDir, rerunRoot) {
+		t.Fatalf("unexpected re-run summary: %+v", sum)
+	}
+
+	mustWriteFile(t, filepath.Join(bundleDir, "suite.json"), `{"version":1,"suiteId":"tampered","missions":[]}`)
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"repro", "run", "--out-root", rerunRoot, bundleDir}, "repro run tampered bundle")
+	if !strings.Contains(stderr.String(), "sha256 mismatch") {
+		t.Fatalf("expected sha256 mismatch, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReview_NextRecordAndCampaignReport(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "review-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-review
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	var idle struct {
+		Done    bool `json:"done"`
+		Pending int  `json:"pending"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"review", "next", "--campaign-id", "cmp-review", "--out-root", outRoot, "--json"}, &idle, "review next")
+	if !idle.Done || idle.Pending != 0 {
+		t.Fatalf("passing attempts should not need review by default, got %+v", idle)
+	}
+
+	var next struct {
+		Pending int `json:"pending"`
+		Next    struct {
+			FlowID     string `json:"flowId"`
+			AttemptDir string `json:"attemptDir"`
+			GateOK     bool   `json:"gateOk"`
+		} `json:"next"`
+		Evidence struct {
+			Prompt string   `json:"prompt"`
+			Files  []string `json:"files"`
+		} `json:"evidence"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"review", "next", "--campaign-id", "cmp-review", "--out-root", outRoot, "--all", "--json"}, &next, "review next --all")
+	if next.Pending != 1 || next.Next.FlowID != "flow-a" || !next.Next.GateOK || next.Next.AttemptDir == "" {
+		t.Fatalf("unexpected review queue head: %+v", next)
+	}
+	if next.Evidence.Prompt == "" || len(next.Evidence.Files) == 0 {
+		t.Fatalf("expected prompt and file evidence, got %+v", next.Evidence)
+	}
+
+	var rec struct {
+		OK       bool   `json:"ok"`
+		Reviewer string `json:"reviewer"`
+		Notes    string `json:"notes"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"review", "record", "--attempt", next.Next.AttemptDir, "--fail", "--reviewer", "alice", "--notes", "answer cites the wrong source", "--json"}, &rec, "review record")
+	if rec.OK || rec.Reviewer != "alice" || rec.Notes == "" {
+		t.Fatalf("unexpected review.json payload: %+v", rec)
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"review", "next", "--campaign-id", "cmp-review", "--out-root", outRoot, "--all", "--json"}, &idle, "review next after record")
+	if !idle.Done {
+		t.Fatalf("expected review queue to be drained, got %+v", idle)
+	}
+
+	var report struct {
+		Reviews struct {
+			Reviewed   int `json:"reviewed"`
+			Overturned int `json:"overturned"`
+		} `json:"reviews"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "report", "--campaign-id", "cmp-review", "--out-root", outRoot, "--json"}, &report, "campaign report")
+	if report.Reviews.Reviewed != 1 || report.Reviews.Overturned != 1 {
+		t.Fatalf("expected reviewed verdict in campaign report, got %+v", report.Reviews)
+	}
+	md, err := os.ReadFile(filepath.Join(outRoot, "campaigns", "cmp-review", "RESULTS.md"))
+	if err != nil {
+		t.Fatalf("read RESULTS.md: %v", err)
+	}
+	if !strings.Contains(string(md), "## Manual Reviews") || !strings.Contains(string(md), "reviewer=alice") {
+		t.Fatalf("expected manual reviews in RESULTS.md, got:\n%s", md)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"review", "record", "--attempt", next.Next.AttemptDir, "--ok", "--fail"}, "review record --ok --fail")
+}
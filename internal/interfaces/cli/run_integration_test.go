@@ -422,6 +422,92 @@ func TestRun_RepeatGuardBlocksNoProgressLoops(t *testing.T) {
 	}
 }
 
+func TestRun_ToolPolicyRefusesDisallowedCommand(t *testing.T) {
+	outDir := t.TempDir()
+	setAttemptEnv(t, outDir)
+	t.Setenv("ZCL_TOOL_POLICY", `{"allow":[{"namespace":"cli","prefix":"allowed-tool"}]}`)
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	code := r.Run(helperRunCommand(t, helperProcessConfig{
+		Stdout: "should-not-run\n",
+		Exit:   0,
+	}))
+	if code != 1 {
+		t.Fatalf("expected policy refusal to return 1, got %d (stderr=%q)", code, stderr.String())
+	}
+	if stdout.String() != "" {
+		t.Fatalf("expected refused command not to run, got stdout=%q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "ZCL_E_TOOL_POLICY_DENIED") {
+		t.Fatalf("expected policy denial on stderr, got %q", stderr.String())
+	}
+	ev := readSingleTraceEvent(t, filepath.Join(outDir, "tool.calls.jsonl"))
+	if ev.Result.OK || ev.Result.Code != "ZCL_E_TOOL_POLICY_DENIED" {
+		t.Fatalf("expected denied trace event, got %+v", ev.Result)
+	}
+}
+
+func TestRun_VCRRecordsThenReplaysWithoutExecuting(t *testing.T) {
+	recDir := t.TempDir()
+	setAttemptEnv(t, recDir)
+	t.Setenv("ZCL_VCR_MODE", "record")
+	t.Setenv("ZCL_VCR_FROM", "")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 15, 18, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	if code := r.Run(helperRunCommand(t, helperProcessConfig{Stdout: "recorded\n", Exit: 3})); code != 3 {
+		t.Fatalf("record: expected exit 3, got %d (stderr=%q)", code, stderr.String())
+	}
+	if stdout.String() != "recorded\n" {
+		t.Fatalf("record: expected passthrough, got %q", stdout.String())
+	}
+	if _, err := os.Stat(filepath.Join(recDir, "tool.cassette.jsonl")); err != nil {
+		t.Fatalf("record: expected tool.cassette.jsonl: %v", err)
+	}
+
+	repDir := t.TempDir()
+	setAttemptEnv(t, repDir)
+	t.Setenv("ZCL_VCR_MODE", "replay")
+	t.Setenv("ZCL_VCR_FROM", recDir)
+	stdout.Reset()
+	stderr.Reset()
+	if code := r.Run(helperRunCommand(t, helperProcessConfig{Stdout: "live\n", Exit: 0})); code != 3 {
+		t.Fatalf("replay: expected recorded exit 3, got %d (stderr=%q)", code, stderr.String())
+	}
+	if stdout.String() != "recorded\n" {
+		t.Fatalf("replay: expected recorded stdout, got %q", stdout.String())
+	}
+	ev := readSingleTraceEvent(t, filepath.Join(repDir, "tool.calls.jsonl"))
+	if ev.Result.OK || ev.Result.ExitCode == nil || *ev.Result.ExitCode != 3 || len(ev.Warnings) == 0 || ev.Warnings[len(ev.Warnings)-1].Code != "ZCL_W_VCR_REPLAYED" {
+		t.Fatalf("replay: unexpected trace event: %+v warnings=%+v", ev.Result, ev.Warnings)
+	}
+	served := mustReadFileString(t, filepath.Join(repDir, "tool.cassette.jsonl"))
+	if !strings.Contains(served, `"replayed":true`) {
+		t.Fatalf("replay: expected served entry in attempt cassette, got %s", served)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if code := r.Run(helperRunCommandWithArgs(t, helperProcessConfig{Stdout: "live\n"}, []string{"unrecorded"})); code != 1 {
+		t.Fatalf("miss: expected exit 1, got %d", code)
+	}
+	if stdout.String() != "" || !strings.Contains(stderr.String(), "ZCL_E_VCR_MISS") {
+		t.Fatalf("miss: expected no execution and ZCL_E_VCR_MISS, stdout=%q stderr=%q", stdout.String(), stderr.String())
+	}
+}
+
 func TestHelperProcess(t *testing.T) {
 	// Keep helper process execution inside an explicit test case to avoid
 	// platform-specific flakiness from exiting during package init.
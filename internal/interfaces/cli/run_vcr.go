@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/redact"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/vcr"
+	clifunnel "github.com/marcohefti/zero-context-lab/internal/kernel/cli_funnel"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// runVCRRecorder tees the wrapped command's output into bounded buffers for the cassette.
+type runVCRRecorder struct {
+	session *vcr.Session
+	out     *boundedBuffer
+	err     *boundedBuffer
+}
+
+// openRunVCR returns the VCR session exported to this attempt (nil when VCR is off).
+func (r Runner) openRunVCR(env trace.Env) (*vcr.Session, int, bool) {
+	mode, from, err := vcr.ModeFromEnv()
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
+		return nil, 2, true
+	}
+	if mode == "" {
+		return nil, 0, false
+	}
+	s, err := vcr.Open(env.OutDirAbs, env.MissionID, mode, from)
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": vcr: %s\n", err.Error())
+		return nil, 1, true
+	}
+	return s, 0, false
+}
+
+func (r Runner) prepareRunVCRRecord(s *vcr.Session, captureState *runCaptureState) *runVCRRecorder {
+	if s == nil || s.Mode != vcr.ModeRecord {
+		return nil
+	}
+	rec := &runVCRRecorder{
+		session: s,
+		out:     newBoundedBuffer(schema.CaptureMaxBytesV1),
+		err:     newBoundedBuffer(schema.CaptureMaxBytesV1),
+	}
+	captureState.outFull = teeOptionalWriter(captureState.outFull, rec.out)
+	captureState.errFull = teeOptionalWriter(captureState.errFull, rec.err)
+	return rec
+}
+
+func teeOptionalWriter(w io.Writer, extra io.Writer) io.Writer {
+	if w == nil {
+		return extra
+	}
+	return io.MultiWriter(w, extra)
+}
+
+// recordRunVCR appends the live response to the attempt cassette. Spawn failures and timeouts are
+// not responses and are never recorded.
+func (r Runner) recordRunVCR(now time.Time, rec *runVCRRecorder, argv []string, res clifunnel.Result, traceRes trace.ResultForTrace) int {
+	if rec == nil || traceRes.SpawnError != "" {
+		return 0
+	}
+	input, inApplied := vcr.CLIInput(argv)
+	stdout, outApplied := redact.Text(string(rec.out.Bytes()))
+	stderr, errApplied := redact.Text(string(rec.err.Bytes()))
+	exitCode := res.ExitCode
+	e := schema.ToolCassetteEntryV1{
+		TS:                now.UTC().Format(time.RFC3339Nano),
+		Key:               vcr.Key("cli", "exec", input),
+		Tool:              "cli",
+		Op:                "exec",
+		Input:             input,
+		ExitCode:          &exitCode,
+		Stdout:            stdout,
+		Stderr:            stderr,
+		DurationMs:        res.DurationMs,
+		Truncated:         rec.out.Truncated() || rec.err.Truncated(),
+		RedactionsApplied: sortedUniqueStrings(append(append(inApplied, outApplied.Names...), errApplied.Names...)),
+	}
+	if err := rec.session.Record(e); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": failed to append tool.cassette.jsonl: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
+
+// replayRunFromVCR serves the recorded response for argv instead of executing it. The trace event
+// is written as usual (tagged ZCL_W_VCR_REPLAYED) so validate/report treat the attempt normally.
+func (r Runner) replayRunFromVCR(env trace.Env, opts runOptions, s *vcr.Session) int {
+	now := r.Now()
+	input, _ := vcr.CLIInput(opts.argv)
+	e, ok, err := s.Replay(now.UTC().Format(time.RFC3339Nano), vcr.Key("cli", "exec", input))
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": failed to append tool.cassette.jsonl: %s\n", err.Error())
+		return 1
+	}
+	if !ok {
+		msg := fmt.Sprintf("vcr replay: no recording for cli command %q in %s", opts.argv[0], s.Source.Path)
+		traceRes := trace.ResultForTrace{
+			SpawnError: codeVCRMiss,
+			ErrBytes:   int64(len(msg)),
+			ErrPreview: msg,
+		}
+		if exit := r.appendRunTraceEvent(now, env, opts.argv, traceRes); exit != 0 {
+			return exit
+		}
+		fmt.Fprintf(r.Stderr, codeVCRMiss+": %s\n", msg)
+		return 1
+	}
+
+	exitCode := 0
+	if e.ExitCode != nil {
+		exitCode = *e.ExitCode
+	}
+	if !opts.envelope {
+		_, _ = io.WriteString(r.Stdout, e.Stdout)
+		_, _ = io.WriteString(r.Stderr, e.Stderr)
+	}
+	outPreview, outTrunc := capStringPreview(e.Stdout)
+	errPreview, errTrunc := capStringPreview(e.Stderr)
+	res := clifunnel.Result{
+		ExitCode:     exitCode,
+		OutBytes:     int64(len(e.Stdout)),
+		ErrBytes:     int64(len(e.Stderr)),
+		OutPreview:   outPreview,
+		ErrPreview:   errPreview,
+		OutTruncated: outTrunc,
+		ErrTruncated: errTrunc,
+	}
+
+	captureState, exit, done := r.prepareRunCapture(opts)
+	if done {
+		return exit
+	}
+	if captureState.outBuf != nil {
+		_, _ = io.WriteString(captureState.outBuf, e.Stdout)
+		_, _ = io.WriteString(captureState.errBuf, e.Stderr)
+	}
+	traceRes := baseRunTraceResult(opts.captureMaxBytes, res)
+	traceRes.Warnings = []schema.TraceWarningV1{{Code: "ZCL_W_VCR_REPLAYED", Message: "served from vcr cassette; the tool was not executed"}}
+	if exit := r.persistRunCaptureArtifacts(now, env, opts, &traceRes, &captureState, res); exit != 0 {
+		return exit
+	}
+	if exit := r.appendRunTraceEvent(now, env, opts.argv, traceRes); exit != 0 {
+		return exit
+	}
+	if exit := r.appendRunCaptureEvent(now, env, opts, captureState, traceRes); exit != 0 {
+		return exit
+	}
+	if opts.envelope {
+		if exit := r.writeRunEnvelope(env, opts, captureState, res, traceRes, runTraceResultCode(traceRes, exitCode)); exit != 0 {
+			return exit
+		}
+	}
+	return exitCode
+}
+
+func capStringPreview(s string) (string, bool) {
+	if len(s) <= schema.PreviewMaxBytesV1 {
+		return s, false
+	}
+	return s[:schema.PreviewMaxBytesV1], true
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunsCompact_KeepsEvidenceReadable(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "compact-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-compact
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	var runs struct {
+		Runs []runIndexRow `json:"runs"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"runs", "list", "--out-root", outRoot, "--json"}, &runs, "runs list")
+	if len(runs.Runs) != 1 {
+		t.Fatalf("expected one run, got %+v", runs.Runs)
+	}
+	runID := runs.Runs[0].RunID
+	attempts, _ := filepath.Glob(filepath.Join(outRoot, "runs", runID, "attempts", "*"))
+	if len(attempts) != 1 {
+		t.Fatalf("expected one attempt dir, got %v", attempts)
+	}
+	attemptDir := attempts[0]
+	mustWriteFile(t, filepath.Join(attemptDir, "runner.stdout.log"), "starting\nexport GH=ghp_1234567890abcdef\n")
+
+	var res struct {
+		OK         bool  `json:"ok"`
+		Attempts   int   `json:"attempts"`
+		BytesFreed int64 `json:"bytesFreed"`
+		Files      []struct {
+			Path   string `json:"path"`
+			Action string `json:"action"`
+		} `json:"files"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"runs", "compact", "--run-id", runID, "--out-root", outRoot, "--json"}, &res, "runs compact")
+	if !res.OK || res.Attempts != 1 || len(res.Files) == 0 {
+		t.Fatalf("unexpected compact result: %+v", res)
+	}
+	for _, name := range []string{"tool.calls.jsonl", "runner.stdout.log"} {
+		if _, err := os.Stat(filepath.Join(attemptDir, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be compressed away", name)
+		}
+		if _, err := os.Stat(filepath.Join(attemptDir, name+".gz")); err != nil {
+			t.Fatalf("expected %s.gz: %v", name, err)
+		}
+	}
+
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"runs", "list", "--out-root", outRoot, "--json"}, &runs, "runs list after compact")
+	if runs.Runs[0].CompactedAt == "" {
+		t.Fatalf("expected run index row to carry compactedAt, got %+v", runs.Runs[0])
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"validate", "--json", attemptDir}, "validate compacted attempt")
+	var rep struct {
+		Metrics struct {
+			ToolCallsTotal int64 `json:"toolCallsTotal"`
+		} `json:"metrics"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"report", "--json", attemptDir}, &rep, "report compacted attempt")
+	if rep.Metrics.ToolCallsTotal == 0 {
+		t.Fatalf("expected report to read the compressed trace, got %+v", rep)
+	}
+
+	var scan struct {
+		OK   bool `json:"ok"`
+		Hits []struct {
+			Path string `json:"path"`
+		} `json:"hits"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 2, []string{"scan", "secrets", "--run-id", runID, "--out-root", outRoot, "--json"}, &scan, "scan secrets after compact")
+	wantPath := "attempts/" + filepath.Base(attemptDir) + "/runner.stdout.log.gz"
+	if scan.OK || len(scan.Hits) != 1 || scan.Hits[0].Path != wantPath {
+		t.Fatalf("expected secret scan to see through the .gz copy, got %+v", scan)
+	}
+}
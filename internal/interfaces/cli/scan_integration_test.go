@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+)
+
+func TestScanSecrets_GatesCampaignPublishCheck(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "scan-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-scan
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	var clean struct {
+		SecretScan struct {
+			OK     bool     `json:"ok"`
+			RunIDs []string `json:"runIds"`
+		} `json:"secretScan"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "publish-check", "--campaign-id", "cmp-scan", "--out-root", outRoot, "--json"}, &clean, "campaign publish-check")
+	if !clean.SecretScan.OK || len(clean.SecretScan.RunIDs) != 1 {
+		t.Fatalf("expected clean secret scan over one flow run, got %+v", clean.SecretScan)
+	}
+	runID := clean.SecretScan.RunIDs[0]
+
+	attempts, _ := filepath.Glob(filepath.Join(outRoot, "runs", runID, "attempts", "*"))
+	if len(attempts) != 1 {
+		t.Fatalf("expected one attempt dir, got %v", attempts)
+	}
+	mustWriteFile(t, filepath.Join(attempts[0], "runner.stdout.log"), "starting\nexport GH=ghp_1234567890abcdef\n")
+
+	var scan struct {
+		OK   bool `json:"ok"`
+		Hits []struct {
+			Path string `json:"path"`
+			Line int    `json:"line"`
+			Rule string `json:"rule"`
+		} `json:"hits"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 2, []string{"scan", "secrets", "--run-id", runID, "--out-root", outRoot, "--json"}, &scan, "scan secrets")
+	wantPath := "attempts/" + filepath.Base(attempts[0]) + "/runner.stdout.log"
+	if scan.OK || len(scan.Hits) != 1 || scan.Hits[0].Path != wantPath || scan.Hits[0].Line != 2 || scan.Hits[0].Rule != "github_token" {
+		t.Fatalf("unexpected scan result: %+v", scan)
+	}
+
+	var gated struct {
+		OK          bool     `json:"ok"`
+		ReasonCodes []string `json:"reasonCodes"`
+		SecretScan  struct {
+			OK   bool `json:"ok"`
+			Hits []struct {
+				RunID string `json:"runId"`
+				Path  string `json:"path"`
+			} `json:"hits"`
+		} `json:"secretScan"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 2, []string{"campaign", "publish-check", "--campaign-id", "cmp-scan", "--out-root", outRoot, "--json"}, &gated, "campaign publish-check after leak")
+	if gated.OK || gated.SecretScan.OK || len(gated.SecretScan.Hits) != 1 || gated.SecretScan.Hits[0].RunID != runID {
+		t.Fatalf("expected publish-check to fail on leaked secret, got %+v", gated)
+	}
+	if !containsString(gated.ReasonCodes, campaign.ReasonSecretLeak) {
+		t.Fatalf("expected %s in reasonCodes, got %v", campaign.ReasonSecretLeak, gated.ReasonCodes)
+	}
+}
+
+func TestRedactVerify_PreconditionForCampaignPublishCheck(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "verify-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-verify
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+output:
+  requireRedactionVerify: true
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	type publishCheck struct {
+		OK              bool     `json:"ok"`
+		ReasonCodes     []string `json:"reasonCodes"`
+		RedactionVerify struct {
+			OK         bool     `json:"ok"`
+			Required   bool     `json:"required"`
+			RunIDs     []string `json:"runIds"`
+			Unverified []struct {
+				RunID  string `json:"runId"`
+				Reason string `json:"reason"`
+			} `json:"unverified"`
+		} `json:"redactionVerify"`
+	}
+	var blocked publishCheck
+	runCLICommandJSON(t, &r, &stdout, &stderr, 2, []string{"campaign", "publish-check", "--campaign-id", "cmp-verify", "--out-root", outRoot, "--json"}, &blocked, "campaign publish-check before verify")
+	if blocked.OK || !blocked.RedactionVerify.Required || len(blocked.RedactionVerify.Unverified) != 1 || !strings.Contains(blocked.RedactionVerify.Unverified[0].Reason, "missing") {
+		t.Fatalf("expected publish-check to require redaction verify, got %+v", blocked)
+	}
+	if !containsString(blocked.ReasonCodes, campaign.ReasonRedactionUnverified) {
+		t.Fatalf("expected %s in reasonCodes, got %v", campaign.ReasonRedactionUnverified, blocked.ReasonCodes)
+	}
+	runID := blocked.RedactionVerify.RunIDs[0]
+
+	var verify struct {
+		OK      bool   `json:"ok"`
+		RunID   string `json:"runId"`
+		Ruleset struct {
+			Rules  []string `json:"rules"`
+			SHA256 string   `json:"sha256"`
+		} `json:"ruleset"`
+		FilesScanned int `json:"filesScanned"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"redact", "verify", "--run-id", runID, "--out-root", outRoot, "--json"}, &verify, "redact verify")
+	if !verify.OK || verify.RunID != runID || verify.Ruleset.SHA256 == "" || !containsString(verify.Ruleset.Rules, "github_token") || verify.FilesScanned == 0 {
+		t.Fatalf("unexpected redact verify result: %+v", verify)
+	}
+	if _, err := os.Stat(filepath.Join(outRoot, "runs", runID, "redaction.verify.json")); err != nil {
+		t.Fatalf("expected redaction.verify.json: %v", err)
+	}
+
+	var passed publishCheck
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "publish-check", "--campaign-id", "cmp-verify", "--out-root", outRoot, "--json"}, &passed, "campaign publish-check after verify")
+	if !passed.OK || !passed.RedactionVerify.OK || len(passed.RedactionVerify.Unverified) != 0 {
+		t.Fatalf("expected publish-check to pass after verify, got %+v", passed)
+	}
+}
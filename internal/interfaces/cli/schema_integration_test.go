@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSchemaExport_EmitsJSONSchemaForEveryArtifact(t *testing.T) {
+	for _, name := range []string{"attempt.report", "feedback", "suite", "campaign"} {
+		h := newRunnerHarness(t, time.Date(2026, 2, 22, 20, 10, 0, 0, time.UTC))
+		code := h.Runner.Run([]string{"schema", "export", "--artifact", name, "--json-schema"})
+		if code != 0 {
+			t.Fatalf("%s: expected exit 0, got %d (stderr=%q)", name, code, h.Stderr.String())
+		}
+		var doc struct {
+			Schema     string         `json:"$schema"`
+			ID         string         `json:"$id"`
+			Type       string         `json:"type"`
+			Properties map[string]any `json:"properties"`
+			Required   []string       `json:"required"`
+		}
+		if err := json.Unmarshal(h.Stdout.Bytes(), &doc); err != nil {
+			t.Fatalf("%s: unmarshal: %v (stdout=%q)", name, err, h.Stdout.String())
+		}
+		if doc.Schema == "" || doc.ID == "" || doc.Type != "object" || len(doc.Properties) == 0 || len(doc.Required) == 0 {
+			t.Fatalf("%s: unexpected schema document: %+v", name, doc)
+		}
+	}
+}
+
+func TestSchemaExport_RejectsUnknownArtifactAndMissingFormat(t *testing.T) {
+	h := newRunnerHarness(t, time.Date(2026, 2, 22, 20, 10, 0, 0, time.UTC))
+	if code := h.Runner.Run([]string{"schema", "export", "--artifact", "nope", "--json-schema"}); code != 2 {
+		t.Fatalf("expected usage exit for unknown artifact, got %d", code)
+	}
+	if code := h.Runner.Run([]string{"schema", "export", "--artifact", "feedback"}); code != 2 {
+		t.Fatalf("expected usage exit without --json-schema, got %d", code)
+	}
+}
+
+func TestValidateFile_AcceptsArtifactsWrittenBySuiteRun(t *testing.T) {
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-validate-file",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [ { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } } ]
+}`)
+	attemptDir := runSuiteProcessParityMode(t, suitePath, t.TempDir())
+
+	for _, tc := range []struct{ kind, path string }{
+		{"suite", suitePath},
+		{"feedback", filepath.Join(attemptDir, "feedback.json")},
+		{"attempt.report", filepath.Join(attemptDir, "attempt.report.json")},
+	} {
+		h := newRunnerHarness(t, time.Date(2026, 2, 22, 20, 10, 0, 0, time.UTC))
+		if code := h.Runner.Run([]string{"validate", "file", "--kind", tc.kind, "--json", tc.path}); code != 0 {
+			t.Fatalf("%s: expected exit 0, got %d (stdout=%q stderr=%q)", tc.kind, code, h.Stdout.String(), h.Stderr.String())
+		}
+	}
+}
+
+func TestValidateFile_ReportsViolationsWithPointers(t *testing.T) {
+	dir := t.TempDir()
+	fbPath := filepath.Join(dir, "feedback.json")
+	if err := os.WriteFile(fbPath, []byte(`{"schemaVersion":1,"runId":"r","suiteId":"s","missionId":"m","attemptId":"a","ok":"yes","createdAt":"2026-02-22T20:10:00Z"}`), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	h := newRunnerHarness(t, time.Date(2026, 2, 22, 20, 10, 0, 0, time.UTC))
+	if code := h.Runner.Run([]string{"validate", "file", "--kind", "feedback", "--json", fbPath}); code != 2 {
+		t.Fatalf("expected exit 2, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	var res struct {
+		OK     bool   `json:"ok"`
+		Target string `json:"target"`
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &res); err != nil {
+		t.Fatalf("unmarshal: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if res.OK || res.Target != "file" || len(res.Errors) != 1 || res.Errors[0].Code != "ZCL_E_CONTRACT" || !strings.HasPrefix(res.Errors[0].Message, "/ok:") {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	specPath := filepath.Join(dir, "campaign.yaml")
+	if err := os.WriteFile(specPath, []byte("schemaVersion: 2\ncampaignId: c\nflows: []\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	h = newRunnerHarness(t, time.Date(2026, 2, 22, 20, 10, 0, 0, time.UTC))
+	if code := h.Runner.Run([]string{"validate", "file", "--kind", "campaign", "--json", specPath}); code != 2 {
+		t.Fatalf("expected exit 2 for unsupported schemaVersion, got %d", code)
+	}
+	if !strings.Contains(h.Stdout.String(), "ZCL_E_SCHEMA_UNSUPPORTED") {
+		t.Fatalf("expected ZCL_E_SCHEMA_UNSUPPORTED, got %s", h.Stdout.String())
+	}
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/ops/app/top"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// serveEventsPollInterval is how often a run's progress JSONL is re-read for new events;
+// serveEventsKeepAlive spaces SSE comments that keep idle proxies from closing the stream.
+var (
+	serveEventsPollInterval = 500 * time.Millisecond
+	serveEventsKeepAlive    = 15 * time.Second
+)
+
+// serveRunEvents streams a run's suite run progress events as server-sent events. Each event is
+// `event: <kind>` with the progress line as data; its id is the byte offset after the line, so a
+// reconnecting client resumes with Last-Event-ID. The stream ends after the run's run_finished
+// event, or once the run has no live owner and no unread events.
+func serveRunEvents(w http.ResponseWriter, req *http.Request, outRoot string) {
+	runID := req.PathValue("runId")
+	runDir, ok := serveRunDir(w, outRoot, runID)
+	if !ok {
+		return
+	}
+	path := top.ProgressPath(runDir)
+	if path == "" {
+		writeServeError(w, http.StatusNotFound, codeMissingArtifact, "run has no progress stream (start suite run with --progress-jsonl <path>)")
+		return
+	}
+	var kinds map[string]bool
+	if raw := req.URL.Query().Get("kinds"); strings.TrimSpace(raw) != "" {
+		list, err := parseSuiteRunProgressEvents(raw)
+		if err != nil {
+			writeServeError(w, http.StatusBadRequest, codeUsage, err.Error())
+			return
+		}
+		kinds = map[string]bool{}
+		for _, k := range list {
+			kinds[k] = true
+		}
+	}
+	var offset int64
+	if id := strings.TrimSpace(req.Header.Get("Last-Event-ID")); id != "" {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || n < 0 {
+			writeServeError(w, http.StatusBadRequest, codeUsage, "invalid Last-Event-ID")
+			return
+		}
+		offset = n
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	poll := time.NewTicker(serveEventsPollInterval)
+	defer poll.Stop()
+	lastWrite := time.Now()
+	for {
+		lines, next, err := readProgressLines(path, offset)
+		if err != nil {
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", strconv.Quote(err.Error()))
+			_ = rc.Flush()
+			return
+		}
+		finished := false
+		for _, l := range lines {
+			var ev struct {
+				Kind  string `json:"kind"`
+				RunID string `json:"runId"`
+			}
+			// A progress file may be shared by several runs (e.g. one campaign); keep this run's.
+			// The kind becomes the SSE event field, so only known progress kinds are passed on.
+			if json.Unmarshal(l.data, &ev) != nil || ev.RunID != runID || !slices.Contains(suiteRunProgressKinds, ev.Kind) {
+				continue
+			}
+			if kinds == nil || kinds[ev.Kind] {
+				fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", l.end, ev.Kind, l.data)
+				lastWrite = time.Now()
+			}
+			if ev.Kind == suiteRunProgressRunFinished {
+				finished = true
+				break
+			}
+		}
+		offset = next
+		if len(lines) > 0 {
+			_ = rc.Flush()
+		}
+		if finished {
+			return
+		}
+		if len(lines) == 0 {
+			if _, live := store.RunOwner(runDir); !live {
+				return
+			}
+		}
+		if time.Since(lastWrite) >= serveEventsKeepAlive {
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+			_ = rc.Flush()
+			lastWrite = time.Now()
+		}
+		select {
+		case <-req.Context().Done():
+			return
+		case <-poll.C:
+		}
+	}
+}
+
+type progressLine struct {
+	data []byte
+	// end is the byte offset just after the line.
+	end int64
+}
+
+// readProgressLines returns the complete lines of path from offset on and the offset after the
+// last one; a partially written last line is left for the next read. A missing file has no lines.
+func readProgressLines(path string, offset int64) ([]progressLine, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, offset, nil
+		}
+		return nil, offset, err
+	}
+	defer func() { _ = f.Close() }()
+	if info, err := f.Stat(); err == nil && info.Size() < offset {
+		// Truncated or replaced: start over.
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+	var out []progressLine
+	br := bufio.NewReader(f)
+	for {
+		line, err := br.ReadBytes('\n')
+		if err != nil {
+			// io.EOF leaves an incomplete line unread.
+			if errors.Is(err, io.EOF) {
+				return out, offset, nil
+			}
+			return out, offset, err
+		}
+		offset += int64(len(line))
+		if data := bytes.TrimSpace(line); len(data) > 0 {
+			out = append(out, progressLine{data: data, end: offset})
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+func serveGet(t *testing.T, h http.Handler, method string, path string, wantStatus int, out any) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	if rec.Code != wantStatus {
+		t.Fatalf("%s %s: status=%d want %d body=%s", method, path, rec.Code, wantStatus, rec.Body.String())
+	}
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decode: %v body=%s", method, path, err, rec.Body.String())
+		}
+	}
+	return rec.Body.String()
+}
+
+func TestServe_ReadOnlyAPIAndUI(t *testing.T) {
+	outRoot := t.TempDir()
+	now := func() time.Time { return time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC) }
+	r := Runner{Version: "0.0.0-dev", Now: now}
+
+	start := startAttemptForQuery(t, r, outRoot, "", "serve-suite", "m-one")
+	runAndFeedbackForQuery(t, r, start.Env, true)
+	attemptID := start.Env["ZCL_ATTEMPT_ID"]
+	if err := os.MkdirAll(filepath.Join(outRoot, "campaigns", "cmp-a"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	mustWriteFile(t, filepath.Join(outRoot, "campaigns", "cmp-a", "campaign.run.state.json"), `{"schemaVersion":1,"campaignId":"cmp-a","status":"valid","totalMissions":1,"missionsCompleted":1}`)
+	mustWriteFile(t, filepath.Join(outRoot, "campaigns", "cmp-a", "campaign.report.json"), `{"schemaVersion":1,"campaignId":"cmp-a"}`)
+
+	h := newServeHandler(outRoot, now)
+
+	if body := serveGet(t, h, http.MethodGet, "/", http.StatusOK, nil); !strings.Contains(body, "<title>ZCL</title>") {
+		t.Fatalf("expected embedded UI, got %q", body)
+	}
+	var runs []runIndexRow
+	serveGet(t, h, http.MethodGet, "/api/runs", http.StatusOK, &runs)
+	if len(runs) != 1 || runs[0].RunID != start.RunID || runs[0].OKTotal != 1 {
+		t.Fatalf("unexpected runs: %+v", runs)
+	}
+	var run struct {
+		Run      map[string]any    `json:"run"`
+		Attempts []attemptIndexRow `json:"attempts"`
+	}
+	serveGet(t, h, http.MethodGet, "/api/runs/"+start.RunID, http.StatusOK, &run)
+	if run.Run["runId"] != start.RunID || len(run.Attempts) != 1 || run.Attempts[0].AttemptID != attemptID {
+		t.Fatalf("unexpected run detail: %+v", run)
+	}
+	var attempt struct {
+		Attempt  map[string]any `json:"attempt"`
+		Feedback map[string]any `json:"feedback"`
+	}
+	serveGet(t, h, http.MethodGet, "/api/runs/"+start.RunID+"/attempts/"+attemptID, http.StatusOK, &attempt)
+	if attempt.Attempt["missionId"] != "m-one" || attempt.Feedback["ok"] != true {
+		t.Fatalf("unexpected attempt detail: %+v", attempt)
+	}
+	var attempts []attemptIndexRow
+	serveGet(t, h, http.MethodGet, "/api/attempts?status=ok&suite=serve-suite", http.StatusOK, &attempts)
+	if len(attempts) != 1 {
+		t.Fatalf("unexpected attempts: %+v", attempts)
+	}
+	var campaigns []serveCampaignRowV1
+	serveGet(t, h, http.MethodGet, "/api/campaigns", http.StatusOK, &campaigns)
+	if len(campaigns) != 1 || campaigns[0].CampaignID != "cmp-a" || !campaigns[0].ReportPresent {
+		t.Fatalf("unexpected campaigns: %+v", campaigns)
+	}
+	var cmp struct {
+		State  map[string]any `json:"state"`
+		Report map[string]any `json:"report"`
+	}
+	serveGet(t, h, http.MethodGet, "/api/campaigns/cmp-a", http.StatusOK, &cmp)
+	if cmp.State["status"] != "valid" || cmp.Report["campaignId"] != "cmp-a" {
+		t.Fatalf("unexpected campaign detail: %+v", cmp)
+	}
+	serveGet(t, h, http.MethodGet, "/api/top", http.StatusOK, nil)
+
+	var apiErr serveErrorV1
+	serveGet(t, h, http.MethodGet, "/api/runs/..%2f..%2fetc", http.StatusBadRequest, &apiErr)
+	if apiErr.Code != codeUsage {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+	serveGet(t, h, http.MethodGet, "/api/runs/20260101-000000Z-abcdef", http.StatusNotFound, &apiErr)
+	serveGet(t, h, http.MethodGet, "/api/campaigns/missing", http.StatusNotFound, &apiErr)
+	serveGet(t, h, http.MethodPost, "/api/runs", http.StatusMethodNotAllowed, &apiErr)
+}
+
+func TestServe_RunEventsStreamsProgress(t *testing.T) {
+	outRoot := t.TempDir()
+	now := func() time.Time { return time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC) }
+	r := Runner{Version: "0.0.0-dev", Now: now}
+	start := startAttemptForQuery(t, r, outRoot, "", "serve-suite", "m-one")
+	runDir := filepath.Join(outRoot, "runs", start.RunID)
+	progressPath := filepath.Join(t.TempDir(), "progress.jsonl")
+	mustWriteFile(t, filepath.Join(runDir, "run.invocation.json"), `{"schemaVersion":1,"runId":"`+start.RunID+`","cwd":"/","argv":["suite","run","--progress-jsonl","`+progressPath+`","--json"]}`)
+	line := func(kind string, runID string) string {
+		return `{"schemaVersion":1,"v":1,"kind":"` + kind + `","runId":"` + runID + `"}` + "\n"
+	}
+	mustWriteFile(t, progressPath, line("run_started", start.RunID)+line("run_started", "20260101-000000Z-abcdef"))
+
+	prevPoll := serveEventsPollInterval
+	serveEventsPollInterval = 10 * time.Millisecond
+	defer func() { serveEventsPollInterval = prevPoll }()
+	release, err := store.AcquireRunOwner(runDir)
+	if err != nil {
+		t.Fatalf("acquire run owner: %v", err)
+	}
+	defer func() { _ = release() }()
+
+	srv := httptest.NewServer(newServeHandler(outRoot, now))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/runs/" + start.RunID + "/events?kinds=run_started,attempt_finished,run_finished")
+	if err != nil {
+		t.Fatalf("GET events: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected events response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	// The live run appends while the client is connected; attempt_started is filtered out.
+	f, err := os.OpenFile(progressPath, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open progress: %v", err)
+	}
+	_, _ = f.WriteString(line("attempt_started", start.RunID) + line("attempt_finished", start.RunID) + line("run_finished", start.RunID) + line(`bogus\nevent: injected`, start.RunID) + line("run_started", start.RunID))
+	_ = f.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read events: %v", err)
+	}
+	var kinds []string
+	var lastID string
+	for _, l := range strings.Split(string(body), "\n") {
+		if k, ok := strings.CutPrefix(l, "event: "); ok {
+			kinds = append(kinds, k)
+		}
+		if id, ok := strings.CutPrefix(l, "id: "); ok {
+			lastID = id
+		}
+	}
+	if strings.Join(kinds, ",") != "run_started,attempt_finished,run_finished" {
+		t.Fatalf("unexpected streamed events %v:\n%s", kinds, body)
+	}
+
+	// Resuming after run_finished replays only what followed it, minus the unknown kind; the owner
+	// is gone, so it ends.
+	_ = release()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/runs/"+start.RunID+"/events", nil)
+	req.Header.Set("Last-Event-ID", lastID)
+	resp2, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET events resume: %v", err)
+	}
+	defer func() { _ = resp2.Body.Close() }()
+	rest, _ := io.ReadAll(resp2.Body)
+	if got := strings.Count(string(rest), "event: "); got != 1 || !strings.Contains(string(rest), "event: run_started") {
+		t.Fatalf("unexpected resumed stream:\n%s", rest)
+	}
+
+	h := newServeHandler(outRoot, now)
+	var apiErr serveErrorV1
+	serveGet(t, h, http.MethodGet, "/api/runs/"+start.RunID+"/events?kinds=bogus", http.StatusBadRequest, &apiErr)
+	mustWriteFile(t, filepath.Join(runDir, "run.invocation.json"), `{"schemaVersion":1,"runId":"`+start.RunID+`","argv":["suite","run","--json"]}`)
+	serveGet(t, h, http.MethodGet, "/api/runs/"+start.RunID+"/events", http.StatusNotFound, &apiErr)
+	if apiErr.Code != codeMissingArtifact {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignVerify_CampaignArtifacts(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "sign-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-sign
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	keyDir := t.TempDir()
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+	pubDER, _ := x509.MarshalPKIXPublicKey(pub)
+	keyPath := filepath.Join(keyDir, "zcl-signing.pem")
+	pubPath := filepath.Join(keyDir, "zcl-signing.pub.pem")
+	mustWriteFile(t, keyPath, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+	mustWriteFile(t, pubPath, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})))
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"sign", "--campaign-id", "cmp-sign", "--out-root", outRoot}, "sign without key")
+
+	var signed struct {
+		OK            bool   `json:"ok"`
+		KeyID         string `json:"keyId"`
+		Files         int    `json:"files"`
+		SignaturePath string `json:"signaturePath"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"sign", "--campaign-id", "cmp-sign", "--out-root", outRoot, "--key", keyPath, "--json"}, &signed, "sign")
+	if !signed.OK || signed.Files == 0 || !strings.HasPrefix(signed.KeyID, "ed25519:") {
+		t.Fatalf("unexpected sign output: %+v", signed)
+	}
+
+	var verified struct {
+		OK        bool `json:"ok"`
+		KeyPinned bool `json:"keyPinned"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"verify", "--campaign-id", "cmp-sign", "--out-root", outRoot, "--pubkey", pubPath, "--json"}, &verified, "verify")
+	if !verified.OK || !verified.KeyPinned {
+		t.Fatalf("expected verified + pinned, got %+v", verified)
+	}
+
+	summaryPath := filepath.Join(outRoot, "campaigns", "cmp-sign", "campaign.summary.json")
+	raw, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatalf("read summary: %v", err)
+	}
+	mustWriteFile(t, summaryPath, strings.Replace(string(raw), "cmp-sign", "cmp-forged", 1))
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"verify", "--campaign-id", "cmp-sign", "--out-root", outRoot, "--pubkey", pubPath}, "verify after tamper")
+	if !strings.Contains(stderr.String(), codeSignatureInvalid+": hash mismatch campaigns/cmp-sign/campaign.summary.json") {
+		t.Fatalf("expected hash mismatch on stderr, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+)
+
+func TestSuiteImport_WritesRunnableSuite(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "capitals.jsonl")
+	writeSuiteFile(t, src, `{"input": [{"role": "user", "content": "Capital of France?"}], "ideal": "Paris"}
+{"input": "Capital of Italy?", "ideal": ["Rome"]}
+`)
+	var stdout, stderr bytes.Buffer
+	r := &Runner{Version: "0.0.0-dev", Now: suiteRunNow, Stdout: &stdout, Stderr: &stderr}
+
+	out := filepath.Join(dir, "suites", "capitals.json")
+	var res suiteImportResult
+	runCLICommandJSON(t, r, &stdout, &stderr, 0, []string{"suite", "import", "--format", "openai-evals", "--out", out, "--json", src}, &res, "suite import")
+	if !res.OK || res.SuiteID != "capitals" || res.Missions != 2 || len(res.Unscored) != 0 {
+		t.Fatalf("unexpected import result: %+v", res)
+	}
+	ps, err := suite.ParseFile(out)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if ps.Suite.Missions[1].Prompt != "Capital of Italy?" || ps.Suite.Missions[1].Expects.ResultMatches != "(?:Rome)" {
+		t.Fatalf("unexpected imported mission: %+v", ps.Suite.Missions[1])
+	}
+	runCLICommand(t, r, &stdout, &stderr, 2, []string{"suite", "import", "--format", "openai-evals", "--out", out, src}, "suite import (exists)")
+
+	var printed suite.SuiteFileV1
+	runCLICommand(t, r, &stdout, &stderr, 0, []string{"suite", "import", "--format", "custom-jsonl", "--prompt-field", "input", "--suite-id", "s", "--limit", "1", src}, "suite import (stdout)")
+	if err := json.Unmarshal(stdout.Bytes(), &printed); err != nil || len(printed.Missions) != 1 || printed.Missions[0].Expects != nil {
+		t.Fatalf("unexpected stdout suite (err=%v): %s", err, stdout.String())
+	}
+
+	runCLICommand(t, r, &stdout, &stderr, 2, []string{"suite", "import", "--format", "custom-jsonl", src}, "suite import (missing prompt field)")
+	if !strings.Contains(stderr.String(), `missing "prompt" field`) {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSuiteLint_ShowsComposedMissions(t *testing.T) {
+	dir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(dir, "base.json"), `{"version":1,"suiteId":"base","missions":[{"missionId":"smoke","prompt":"p"}]}`)
+	path := filepath.Join(dir, "suite.yaml")
+	writeSuiteFile(t, path, `version: 1
+suiteId: composed
+include: [base.json]
+missions:
+  - missionId: fetch
+    matrix:
+      url: [a.test, b.test, c.test]
+    prompt: "Fetch {{url}}"
+`)
+	var stdout, stderr bytes.Buffer
+	r := &Runner{Version: "0.0.0-dev", Now: suiteRunNow, Stdout: &stdout, Stderr: &stderr}
+
+	var res suiteLintResult
+	runCLICommandJSON(t, r, &stdout, &stderr, 0, []string{"suite", "lint", "--file", path, "--json"}, &res, "suite lint")
+	if res.SuiteID != "composed" || res.Total != 4 || res.Expanded != 3 || len(res.Includes) != 1 {
+		t.Fatalf("unexpected lint result: %+v", res)
+	}
+	if m := res.Missions[3]; m.MissionID != "fetch-3" || m.Template != "fetch" || m.Matrix["url"] != "c.test" {
+		t.Fatalf("unexpected expanded mission: %+v", m)
+	}
+
+	runCLICommand(t, r, &stdout, &stderr, 0, []string{"suite", "lint", "--file", path}, "suite lint (text)")
+	if !strings.Contains(stdout.String(), "fetch-2") || !strings.Contains(stdout.String(), "[fetch: url=b.test]") {
+		t.Fatalf("unexpected text output: %q", stdout.String())
+	}
+
+	writeSuiteFile(t, path, "version: 1\nsuiteId: s\nmissions:\n  - missionId: m\n    matrix:\n      url: []\n")
+	runCLICommand(t, r, &stdout, &stderr, 2, []string{"suite", "lint", "--file", path, "--json"}, "suite lint (invalid)")
+	if !strings.Contains(stderr.String(), "matrix.url has no values") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+)
+
+// Adaptive in-flight tuning (ZCL_NATIVE_ADAPTIVE_INFLIGHT=1): the native scheduler halves its cap
+// when rate_limited or runtime_crash health counters grew since the last attempt finished, and
+// raises it by one after a cap's worth of clean attempts, never above the configured maximum.
+const (
+	inflightReasonRateLimited  = "rate_limited"
+	inflightReasonRuntimeCrash = "runtime_crash"
+	inflightReasonRecovered    = "recovered"
+)
+
+type suiteRunNativeSchedulerSummary struct {
+	Strategy      string                       `json:"strategy"`
+	Adaptive      bool                         `json:"adaptive"`
+	MaxInflight   int                          `json:"maxInflight"`
+	FinalInflight int                          `json:"finalInflight"`
+	Adjustments   []suiteRunInflightAdjustment `json:"adjustments"`
+}
+
+type suiteRunInflightAdjustment struct {
+	At     string `json:"at"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Reason string `json:"reason"`
+}
+
+type nativeAdaptiveState struct {
+	strategy     native.StrategyID
+	rateLimited  int64
+	runtimeCrash int64
+	cleanStreak  int
+	adjustments  []suiteRunInflightAdjustment
+}
+
+// newNativeAdaptiveInflight baselines the process-wide health counters so signals from earlier
+// runs in the same process do not count against this one.
+func newNativeAdaptiveInflight(strategy native.StrategyID) *nativeAdaptiveState {
+	return &nativeAdaptiveState{
+		strategy:     strategy,
+		rateLimited:  native.HealthCount(strategy, native.HealthRateLimited),
+		runtimeCrash: native.HealthCount(strategy, native.HealthRuntimeCrash),
+	}
+}
+
+// observe returns the next in-flight cap given the current one; callers hold the scheduler lock.
+func (a *nativeAdaptiveState) observe(limit int, maxInflight int, now time.Time) int {
+	rateLimited := native.HealthCount(a.strategy, native.HealthRateLimited)
+	runtimeCrash := native.HealthCount(a.strategy, native.HealthRuntimeCrash)
+	reason := ""
+	switch {
+	case rateLimited > a.rateLimited:
+		reason = inflightReasonRateLimited
+	case runtimeCrash > a.runtimeCrash:
+		reason = inflightReasonRuntimeCrash
+	}
+	a.rateLimited, a.runtimeCrash = rateLimited, runtimeCrash
+
+	next := limit
+	if reason != "" {
+		a.cleanStreak = 0
+		next = limit / 2
+		if next < 1 {
+			next = 1
+		}
+	} else {
+		a.cleanStreak++
+		if a.cleanStreak >= limit && limit < maxInflight {
+			a.cleanStreak = 0
+			next = limit + 1
+			reason = inflightReasonRecovered
+		}
+	}
+	if next != limit {
+		a.adjustments = append(a.adjustments, suiteRunInflightAdjustment{
+			At:     now.Format(time.RFC3339Nano),
+			From:   limit,
+			To:     next,
+			Reason: reason,
+		})
+	}
+	return next
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+)
+
+func TestNativeScheduler_AdaptiveInflightBacksOffAndRecovers(t *testing.T) {
+	t.Setenv("ZCL_NATIVE_MAX_INFLIGHT_PER_STRATEGY", "4")
+	t.Setenv("ZCL_NATIVE_ADAPTIVE_INFLIGHT", "1")
+	strategy := native.StrategyID("adaptive_inflight_test")
+	native.RecordHealth(strategy, native.HealthRateLimited) // before the run: must not count
+
+	s := buildNativeAttemptScheduler(strategy, 1)
+	for i := 0; i < 4; i++ {
+		if err := s.Acquire(context.Background()); err != nil {
+			t.Fatalf("acquire %d: %v", i, err)
+		}
+	}
+	native.RecordHealth(strategy, native.HealthRateLimited)
+	s.Release()
+	s.Release()
+
+	// Cap is now 2 with two attempts still in flight, so the next attempt must wait.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := s.Acquire(ctx); err == nil {
+		t.Fatalf("expected acquire to block at the reduced cap")
+	}
+
+	native.RecordHealth(strategy, native.HealthRuntimeCrash)
+	s.Release()
+	s.Release()
+	sum := s.summary()
+	if sum == nil || sum.MaxInflight != 4 {
+		t.Fatalf("unexpected summary: %+v", sum)
+	}
+	want := []suiteRunInflightAdjustment{
+		{From: 4, To: 2, Reason: inflightReasonRateLimited},
+		{From: 2, To: 1, Reason: inflightReasonRuntimeCrash},
+		{From: 1, To: 2, Reason: inflightReasonRecovered},
+	}
+	if len(sum.Adjustments) != len(want) {
+		t.Fatalf("expected %d adjustments, got %+v", len(want), sum.Adjustments)
+	}
+	for i, w := range want {
+		got := sum.Adjustments[i]
+		if got.From != w.From || got.To != w.To || got.Reason != w.Reason || got.At == "" {
+			t.Fatalf("adjustment %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+	if sum.FinalInflight != 2 {
+		t.Fatalf("expected final cap 2, got %+v", sum)
+	}
+
+	t.Setenv("ZCL_NATIVE_ADAPTIVE_INFLIGHT", "")
+	if got := buildNativeAttemptScheduler(strategy, 1).summary(); got != nil {
+		t.Fatalf("expected no scheduler summary without adaptive tuning, got %+v", got)
+	}
+}
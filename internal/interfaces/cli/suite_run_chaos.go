@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/infra/chaos"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+)
+
+// resolveSuiteRunChaos loads the --chaos profile (else ZCL_CHAOS_PROFILE from the flow env or the
+// process env) and returns its absolute path, or "" when chaos is off.
+func resolveSuiteRunChaos(raw string, extraAttemptEnv map[string]string, nativeMode bool) (string, chaos.Profile, error) {
+	path := strings.TrimSpace(raw)
+	if path == "" {
+		path = strings.TrimSpace(extraAttemptEnv[chaos.ProfileEnv])
+	}
+	if path == "" {
+		path = strings.TrimSpace(os.Getenv(chaos.ProfileEnv))
+	}
+	if path == "" {
+		return "", chaos.Profile{}, nil
+	}
+	if !nativeMode {
+		return "", chaos.Profile{}, fmt.Errorf("--chaos requires --session-isolation native")
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	profile, err := chaos.LoadProfile(path)
+	if err != nil {
+		return "", chaos.Profile{}, fmt.Errorf("--chaos: %w", err)
+	}
+	return path, profile, nil
+}
+
+// wrapSuiteRunChaos injects the profile's faults into the selected runtime's sessions.
+func wrapSuiteRunChaos(selection native.ResolveResult, profilePath string, profile chaos.Profile) native.ResolveResult {
+	if profilePath == "" || selection.Runtime == nil {
+		return selection
+	}
+	selection.Runtime = chaos.Wrap(selection.Runtime, profile)
+	return selection
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/claudecli"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/runnerid"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// resolveSuiteRunClaudeCLI reports whether a runner.type=claude_cli flow exported its marker.
+func resolveSuiteRunClaudeCLI(extraAttemptEnv map[string]string, nativeMode bool) (bool, error) {
+	if strings.TrimSpace(extraAttemptEnv[claudecli.EnvKey]) != "1" {
+		return false, nil
+	}
+	if nativeMode {
+		return false, fmt.Errorf("claude_cli runner (%s) requires --session-isolation process", claudecli.EnvKey)
+	}
+	return true, nil
+}
+
+// appendSuiteRunClaudeCLIArgs gives a claude_cli attempt a session of its own and its prompt (as
+// blind mode left it in prompt.txt), and records the session in runner.ref.json so session
+// freshness is checked like for native attempts.
+func appendSuiteRunClaudeCLIArgs(pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string) (suiteRunExecOpts, error) {
+	if !opts.ClaudeCLI {
+		return opts, nil
+	}
+	prompt, err := os.ReadFile(filepath.Join(pm.OutDirAbs, artifacts.PromptTXT))
+	if err != nil {
+		return opts, fmt.Errorf("claude_cli runner: %w", err)
+	}
+	sessionID := claudecli.SessionID(env["ZCL_RUN_ID"], pm.AttemptID)
+	opts.RunnerArgs = append(append([]string(nil), opts.RunnerArgs...), claudecli.AttemptArgs(sessionID, string(prompt))...)
+	ref := schema.RunnerRefJSONV1{
+		SchemaVersion: schema.ArtifactSchemaV1,
+		Runner:        string(runnerid.Claude),
+		RunID:         env["ZCL_RUN_ID"],
+		SuiteID:       env["ZCL_SUITE_ID"],
+		MissionID:     env["ZCL_MISSION_ID"],
+		AttemptID:     env["ZCL_ATTEMPT_ID"],
+		AgentID:       env["ZCL_AGENT_ID"],
+		SessionID:     sessionID,
+		Transport:     "stdio",
+	}
+	return opts, store.WriteJSONAtomic(filepath.Join(pm.OutDirAbs, artifacts.RunnerRefJSON), ref)
+}
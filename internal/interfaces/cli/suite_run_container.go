@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// containerRemoveTimeout bounds the best-effort `docker rm -f` after a timed-out attempt.
+const containerRemoveTimeout = 10 * time.Second
+
+// resolveSuiteRunContainer reads the container policy a runner.type=docker flow exports to suite run.
+func resolveSuiteRunContainer(extraAttemptEnv map[string]string, nativeMode bool) (*container.Spec, error) {
+	spec, ok, err := container.FromEnv(extraAttemptEnv)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if nativeMode {
+		return nil, fmt.Errorf("container runner (%s) requires --session-isolation process", container.ImageEnvKey)
+	}
+	if err := container.Preflight(spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// wrapSuiteRunContainerRunner swaps the attempt's runner command for a fresh container running it.
+// The engine client's stdout/stderr are the container logs, so runner IO capture is unchanged.
+func wrapSuiteRunContainerRunner(pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string, shimBinDir string) suiteRunExecOpts {
+	if opts.Container == nil {
+		return opts
+	}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	argv := container.RunArgv(*opts.Container, container.RunParams{
+		AttemptID:  pm.AttemptID,
+		AttemptDir: pm.OutDirAbs,
+		TmpDir:     env["ZCL_TMP_DIR"],
+		ZCLExe:     opts.ZCLExe,
+		ShimBinDir: shimBinDir,
+		EnvKeys:    keys,
+		Argv:       append([]string{opts.RunnerCmd}, opts.RunnerArgs...),
+	})
+	opts.RunnerCmd, opts.RunnerArgs = argv[0], argv[1:]
+	return opts
+}
+
+// removeSuiteRunContainer force-removes the attempt container after a timeout: killing the engine
+// client does not stop the container itself.
+func removeSuiteRunContainer(opts suiteRunExecOpts, pm planner.PlannedMission, ar *suiteRunAttemptResult) {
+	if opts.Container == nil || ar.RunnerErrorCode != codeTimeout {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), containerRemoveTimeout)
+	defer cancel()
+	argv := container.RemoveArgv(*opts.Container, pm.AttemptID)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Env = os.Environ()
+	_ = cmd.Run()
+}
+
+func suiteRunContainerRuntime(opts suiteRunExecOpts) *schema.AttemptContainerV1 {
+	if opts.Container == nil {
+		return nil
+	}
+	return &schema.AttemptContainerV1{
+		Engine:   opts.Container.Engine,
+		Image:    opts.Container.Image,
+		Network:  opts.Container.Network,
+		Mounts:   append([]string(nil), opts.Container.Mounts...),
+		Rootless: container.Rootless(*opts.Container),
+		CPU:      opts.Container.Limits.CPU,
+		MemoryMB: opts.Container.Limits.MemoryMB,
+		Pids:     opts.Container.Limits.Pids,
+	}
+}
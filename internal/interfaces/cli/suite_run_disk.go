@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/limits"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// startSuiteRunDiskWatch measures the attempt's disk footprint (attempt dir plus a
+// temp_empty_per_attempt workspace) around the runner. Under --disk-quota-mb the returned context
+// is canceled, killing the runner, once the footprint goes over the quota. stop writes
+// disk.usage.json and turns a quota kill into ZCL_E_DISK_QUOTA.
+func startSuiteRunDiskWatch(pm planner.PlannedMission, opts suiteRunExecOpts, runtimeCtx suiteRunAttemptRuntimeContext) (context.Context, func(ar *suiteRunAttemptResult) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	workspace := ""
+	if runtimeCtx.StartCwdMode == campaign.RunnerCwdModeTempEmptyPerAttempt {
+		workspace = runtimeCtx.StartCwd
+	}
+	w := limits.WatchDisk(pm.OutDirAbs, workspace, opts.DiskQuotaBytes, limits.DiskPollInterval, cancel)
+	return ctx, func(ar *suiteRunAttemptResult) error {
+		usage := w.Stop()
+		cancel()
+		if usage.QuotaExceeded {
+			ar.RunnerErrorCode = codeDiskQuota
+		}
+		return store.WriteJSONAtomic(filepath.Join(pm.OutDirAbs, artifacts.DiskUsageJSON), usage)
+	}
+}
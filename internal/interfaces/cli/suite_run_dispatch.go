@@ -0,0 +1,78 @@
+package cli
+
+import "sync"
+
+// dispatchSuiteRunMissions hands out the entries of order to a pool of workers that each take the
+// next one as soon as they free up, so one slow mission never idles the others.
+//
+// run executes a mission up to the end of its runner and returns its pending finish step (nil when
+// the result is already final). Finish steps run on a separate pool of the same size, so a worker
+// starts the next mission while report/validate/expect run. done is called once a mission's result
+// is final and reports whether it failed; with failFast no mission is handed out after that, while
+// missions already handed out still run and finish. It returns how many entries of order were
+// handed out.
+func dispatchSuiteRunMissions(order []int, workers int, failFast bool, run func(idx int) func(), done func(idx int) bool) int {
+	if workers > len(order) {
+		workers = len(order)
+	}
+	var (
+		mu       sync.Mutex
+		next     int
+		failed   bool
+		wg       sync.WaitGroup
+		finishWG sync.WaitGroup
+		finishQ  = make(chan func(), len(order))
+	)
+	// done runs under mu so a failure is recorded before any worker takes another mission.
+	completed := func(idx int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done(idx) {
+			failed = true
+		}
+	}
+	take := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if next >= len(order) || (failFast && failed) {
+			return 0, false
+		}
+		idx := order[next]
+		next++
+		return idx, true
+	}
+	for w := 0; w < workers; w++ {
+		finishWG.Add(1)
+		go func() {
+			defer finishWG.Done()
+			for job := range finishQ {
+				job()
+			}
+		}()
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				idx, ok := take()
+				if !ok {
+					return
+				}
+				finish := run(idx)
+				if finish == nil {
+					completed(idx)
+					continue
+				}
+				finishQ <- func() {
+					finish()
+					completed(idx)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(finishQ)
+	finishWG.Wait()
+	return next
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// dispatchRecorder records which missions a dispatch ran; m1's finish waits (bounded) for m2 to
+// start, which with one worker only happens when the worker moved on while m1's finish was pending.
+type dispatchRecorder struct {
+	mu         sync.Mutex
+	ran        []int
+	m2Started  chan struct{}
+	m1Done     chan struct{}
+	overlapped bool
+}
+
+func newDispatchRecorder() *dispatchRecorder {
+	return &dispatchRecorder{m2Started: make(chan struct{}), m1Done: make(chan struct{})}
+}
+
+func (d *dispatchRecorder) run(idx int) func() {
+	d.mu.Lock()
+	d.ran = append(d.ran, idx)
+	d.mu.Unlock()
+	switch idx {
+	case 0:
+		return func() {
+			select {
+			case <-d.m2Started:
+				d.mu.Lock()
+				d.overlapped = true
+				d.mu.Unlock()
+			case <-time.After(5 * time.Second):
+			}
+		}
+	case 1:
+		close(d.m2Started)
+		// Hold m2's runner until m1's verdict is in so the next take sees it.
+		select {
+		case <-d.m1Done:
+		case <-time.After(5 * time.Second):
+		}
+	}
+	return func() {}
+}
+
+func (d *dispatchRecorder) done(failIdx int) func(int) bool {
+	return func(idx int) bool {
+		if idx == 0 {
+			close(d.m1Done)
+		}
+		return idx == failIdx
+	}
+}
+
+func TestDispatchSuiteRunMissions_WorkerStartsNextMissionWhileFinishPending(t *testing.T) {
+	for _, failFast := range []bool{true, false} {
+		d := newDispatchRecorder()
+		n := dispatchSuiteRunMissions([]int{0, 1, 2}, 1, failFast, d.run, d.done(-1))
+		if n != 3 || len(d.ran) != 3 {
+			t.Fatalf("failFast=%v: expected all missions dispatched, got n=%d ran=%v", failFast, n, d.ran)
+		}
+		if !d.overlapped {
+			t.Fatalf("failFast=%v: expected the worker to start m2 while m1's finish was pending", failFast)
+		}
+	}
+}
+
+func TestDispatchSuiteRunMissions_FailFastStopsDispatchAfterFailedFinish(t *testing.T) {
+	d := newDispatchRecorder()
+	n := dispatchSuiteRunMissions([]int{0, 1, 2}, 1, true, d.run, d.done(0))
+	// m2 was handed out before m1's finish failed and still runs; m3 never starts.
+	if n != 2 || len(d.ran) != 2 || d.ran[0] != 0 || d.ran[1] != 1 {
+		t.Fatalf("expected m1 and m2 dispatched only, got n=%d ran=%v", n, d.ran)
+	}
+
+	d = newDispatchRecorder()
+	if n := dispatchSuiteRunMissions([]int{0, 1, 2}, 1, false, d.run, d.done(0)); n != 3 || len(d.ran) != 3 {
+		t.Fatalf("expected --fail-fast=false to dispatch every mission, got n=%d ran=%v", n, d.ran)
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/fingerprint"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/remote"
+	codexappserver "github.com/marcohefti/zero-context-lab/internal/contexts/runtime/infra/codex_app_server"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// suiteRunEnvFingerprint probes the environment the suite's runners execute in, once per suite run:
+// the local host, or the remote host of an ssh runner. Container runners are pinned by the image
+// digest instead of host binaries. Probing is best effort; failures land in probeError.
+func (r Runner) suiteRunEnvFingerprint(host suiteRunHostConfig, runnerCmd string, shims []string) schema.EnvFingerprintJSONV1 {
+	var bins []string
+	switch {
+	case host.nativeMode:
+		if host.nativeRuntimeSelection.Selected == native.StrategyCodexAppServer {
+			if cmd := codexappserver.DefaultCommandFromEnv(); len(cmd) > 0 {
+				bins = append(bins, cmd[0])
+			}
+		}
+	case host.container == nil:
+		if runnerCmd != "" {
+			bins = append(bins, runnerCmd)
+		}
+		bins = append(bins, dedupeSortedStrings(shims)...)
+	}
+	script := fingerprint.Script(bins)
+	argv := []string{"sh", "-c", script}
+	where := fingerprint.HostLocal
+	if host.remote != nil {
+		argv = remote.SSHArgv(*host.remote, script)
+		where = host.remote.Host
+	}
+	fp, err := fingerprint.Probe(context.Background(), argv)
+	if err != nil {
+		fp.ProbeError = err.Error()
+	}
+	fp.Host = where
+	fp.ZCLVersion = strings.TrimSpace(r.Version)
+	if host.nativeMode {
+		fp.RuntimeID = string(host.nativeRuntimeSelection.Selected)
+	}
+	if host.container != nil {
+		fp.Container = &schema.EnvContainerV1{Engine: host.container.Engine, Image: host.container.Image, Digest: suiteRunImageDigest(*host.container)}
+	}
+	fp.Hash = fingerprint.Hash(fp)
+	return fp
+}
+
+func suiteRunImageDigest(s container.Spec) string {
+	ctx, cancel := context.WithTimeout(context.Background(), fingerprint.ProbeTimeout)
+	defer cancel()
+	argv := container.InspectArgv(s)
+	out, err := exec.CommandContext(ctx, argv[0], argv[1:]...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeSuiteRunEnvFingerprint(pm planner.PlannedMission, opts suiteRunExecOpts) error {
+	if opts.EnvFingerprint == nil {
+		return nil
+	}
+	return store.WriteJSONAtomic(filepath.Join(pm.OutDirAbs, artifacts.EnvFingerprintJSON), opts.EnvFingerprint)
+}
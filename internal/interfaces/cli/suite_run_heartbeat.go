@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// suiteRunHeartbeatInterval is how often a running attempt's attempt.heartbeat.json is rewritten.
+var suiteRunHeartbeatInterval = 5 * time.Second
+
+// startSuiteRunHeartbeat beats attempt.heartbeat.json until stop, which records stoppedAt so a
+// finished attempt is never mistaken for a hung one. Write errors are ignored: the heartbeat only
+// feeds liveness reporting (`zcl runs list`, `zcl campaign status`).
+func startSuiteRunHeartbeat(r Runner, pm planner.PlannedMission) func() {
+	path := filepath.Join(pm.OutDirAbs, artifacts.AttemptHeartbeatJSON)
+	hb := schema.AttemptHeartbeatJSONV1{
+		SchemaVersion: schema.AttemptHeartbeatSchemaV1,
+		PID:           os.Getpid(),
+		IntervalMs:    suiteRunHeartbeatInterval.Milliseconds(),
+		StartedAt:     r.Now().UTC().Format(time.RFC3339Nano),
+	}
+	write := func() {
+		hb.BeatAt = r.Now().UTC().Format(time.RFC3339Nano)
+		_ = store.WriteJSONAtomic(path, hb)
+	}
+	write()
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t := time.NewTicker(suiteRunHeartbeatInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				write()
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+		hb.StoppedAt = r.Now().UTC().Format(time.RFC3339Nano)
+		write()
+	}
+}
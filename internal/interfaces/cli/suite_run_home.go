@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/home"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// resolveSuiteRunHome validates --home/--home-template. Ephemeral homes apply to host process
+// runners; containers already start from the image's home.
+func resolveSuiteRunHome(rawMode, rawTemplate string, nativeMode bool, containerized bool) (string, string, error) {
+	mode, err := home.ParseMode(rawMode)
+	if err != nil || mode == home.ModeInherit {
+		return mode, "", err
+	}
+	if nativeMode {
+		return "", "", fmt.Errorf("--home ephemeral requires --session-isolation process")
+	}
+	if containerized {
+		return "", "", fmt.Errorf("--home ephemeral cannot be combined with a container runner")
+	}
+	template := strings.TrimSpace(rawTemplate)
+	if template == "" {
+		return mode, "", nil
+	}
+	template, err = filepath.Abs(template)
+	if err != nil {
+		return "", "", err
+	}
+	if err := home.CheckTemplate(template); err != nil {
+		return "", "", err
+	}
+	return mode, template, nil
+}
+
+// prepareSuiteRunHome gives the attempt a fresh HOME under its tmp dir (seeded from the template)
+// and points the runner's HOME/XDG/tool config env at it.
+func prepareSuiteRunHome(pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string) (*schema.AttemptHomeV1, error) {
+	if opts.Home != home.ModeEphemeral {
+		return nil, nil
+	}
+	base := strings.TrimSpace(env["ZCL_TMP_DIR"])
+	if base == "" {
+		base = filepath.Join(pm.OutDirAbs, "tmp")
+	}
+	dir := filepath.Join(base, home.DirName)
+	homeEnv, err := home.Prepare(dir, opts.HomeTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("prepare ephemeral home: %w", err)
+	}
+	for k, v := range homeEnv {
+		env[k] = v
+	}
+	return &schema.AttemptHomeV1{Dir: dir, Template: opts.HomeTemplate, Env: home.EnvKeys()}, nil
+}
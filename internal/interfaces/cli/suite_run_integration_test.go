@@ -2,15 +2,28 @@ package cli
 
 import (
 	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/claudecli"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/remote"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
 )
 
 func suiteRunNow() time.Time {
@@ -53,13 +66,56 @@ func TestSuiteRun_OK_EndToEndCore(t *testing.T) {
 	sum := parseSuiteRunOKEndToEndSummary(t, h.Stdout.Bytes(), h.Stdout.String())
 	assertSuiteRunOKEndToEndSummary(t, sum)
 	assertSuiteRunOKEndToEndAttempts(t, sum.Attempts)
+	assertSuiteRunEnvFingerprint(t, sum)
+	assertSuiteRunHeartbeatStopped(t, sum)
+	assertSuiteRunResources(t, sum)
 	assertSuiteRunOKEndToEndStderr(t, h.Stderr.String())
 }
 
+func TestSuiteRun_RefusesRunOwnedByAnotherWriter(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-owned",
+  "missions": [ { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } } ]
+}`)
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+	runID := "20260216-120000Z-0a0b0c"
+	args := []string{
+		"suite", "run", "--file", suitePath, "--out-root", outRoot, "--run-id", runID, "--json",
+		"--", os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
+	}
+
+	release, err := store.AcquireRunOwner(filepath.Join(outRoot, "runs", runID))
+	if err != nil {
+		t.Fatalf("AcquireRunOwner: %v", err)
+	}
+	h := newRunnerHarness(t, suiteRunNow())
+	if code := h.Runner.Run(args); code != 1 {
+		t.Fatalf("expected exit 1 while run is owned, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	if !strings.Contains(h.Stderr.String(), codeRunLocked) {
+		t.Fatalf("expected %s, got %q", codeRunLocked, h.Stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(outRoot, "runs", runID, "attempts")); !os.IsNotExist(err) {
+		t.Fatalf("expected no attempts written into an owned run, got %v", err)
+	}
+
+	_ = release()
+	h = newRunnerHarness(t, suiteRunNow())
+	if code := h.Runner.Run(args); code != 0 {
+		t.Fatalf("expected exit 0 after release, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+}
+
 type suiteRunOKEndToEndSummary struct {
-	OK       bool                       `json:"ok"`
-	Passed   int                        `json:"passed"`
-	Failed   int                        `json:"failed"`
+	OK              bool `json:"ok"`
+	Passed          int  `json:"passed"`
+	Failed          int  `json:"failed"`
+	CampaignProfile struct {
+		EnvFingerprint string `json:"envFingerprint"`
+	} `json:"campaignProfile"`
 	Attempts []suiteRunOKEndToEndRecord `json:"attempts"`
 }
 
@@ -110,6 +166,72 @@ func assertSuiteRunOKEndToEndAttempt(t *testing.T, attempt suiteRunOKEndToEndRec
 	assertSuiteRunRunnerArtifactsExist(t, attempt.AttemptDir)
 	assertSuiteRunProcessRuntimeEnvMetadata(t, attempt.AttemptDir)
 	assertSuiteRunAttemptReportRuntimeEnvArtifact(t, attempt.AttemptDir)
+	assertSuiteRunFinishPublished(t, attempt.AttemptDir)
+}
+
+func assertSuiteRunEnvFingerprint(t *testing.T, sum suiteRunOKEndToEndSummary) {
+	t.Helper()
+	if !strings.HasPrefix(sum.CampaignProfile.EnvFingerprint, "ef-") {
+		t.Fatalf("expected campaignProfile.envFingerprint, got %q", sum.CampaignProfile.EnvFingerprint)
+	}
+	for _, attempt := range sum.Attempts {
+		var fp schema.EnvFingerprintJSONV1
+		mustReadJSONFile(t, filepath.Join(attempt.AttemptDir, "env.fingerprint.json"), &fp, "env.fingerprint.json")
+		if fp.Hash != sum.CampaignProfile.EnvFingerprint || fp.Host != "local" || fp.OS == "" {
+			t.Fatalf("unexpected env fingerprint: %+v", fp)
+		}
+		if len(fp.Binaries) != 1 || fp.Binaries[0].Name != os.Args[0] || fp.Binaries[0].SHA256 == "" {
+			t.Fatalf("expected the runner binary to be fingerprinted, got %+v", fp.Binaries)
+		}
+	}
+}
+
+func assertSuiteRunHeartbeatStopped(t *testing.T, sum suiteRunOKEndToEndSummary) {
+	t.Helper()
+	for _, attempt := range sum.Attempts {
+		var hb schema.AttemptHeartbeatJSONV1
+		mustReadJSONFile(t, filepath.Join(attempt.AttemptDir, "attempt.heartbeat.json"), &hb, "attempt.heartbeat.json")
+		if hb.SchemaVersion != 1 || hb.PID != os.Getpid() || hb.BeatAt == "" || hb.StoppedAt == "" {
+			t.Fatalf("expected a stopped heartbeat, got %+v", hb)
+		}
+	}
+}
+
+func assertSuiteRunResources(t *testing.T, sum suiteRunOKEndToEndSummary) {
+	t.Helper()
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Logf("no /proc: resource telemetry not sampled")
+		return
+	}
+	for _, attempt := range sum.Attempts {
+		raw := mustReadFileString(t, filepath.Join(attempt.AttemptDir, "resources.jsonl"))
+		var first schema.ResourceSampleV1
+		if err := json.Unmarshal([]byte(strings.SplitN(raw, "\n", 2)[0]), &first); err != nil {
+			t.Fatalf("resources.jsonl: %v (%q)", err, raw)
+		}
+		if first.V != 1 || first.Procs < 1 {
+			t.Fatalf("unexpected resource sample: %+v", first)
+		}
+		var rep schema.AttemptReportJSONV1
+		mustReadJSONFile(t, filepath.Join(attempt.AttemptDir, "attempt.report.json"), &rep, "attempt.report.json")
+		if rep.Metrics.Resources == nil || rep.Metrics.Resources.Samples < 1 || rep.Metrics.Resources.ProcsPeak < 1 {
+			t.Fatalf("expected metrics.resources in attempt.report.json, got %+v", rep.Metrics.Resources)
+		}
+	}
+}
+
+func assertSuiteRunFinishPublished(t *testing.T, attemptDir string) {
+	t.Helper()
+	var rec attemptFinishRecordV1
+	mustReadJSONFile(t, filepath.Join(attemptDir, "attempt.finish.json"), &rec, "attempt.finish.json")
+	if !rec.OK || !rec.Validate.OK || !rec.Expect.OK {
+		t.Fatalf("expected published ok finish record, got %+v", rec)
+	}
+	for _, name := range []string{".finish.staging", ".finish.commit"} {
+		if _, err := os.Stat(filepath.Join(attemptDir, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be gone after commit, got err=%v", name, err)
+		}
+	}
 }
 
 func assertSuiteRunRunnerArtifactsExist(t *testing.T, attemptDir string) {
@@ -270,7 +392,8 @@ func TestSuiteRun_FailFastSkipsRemainingMissions(t *testing.T) {
   "defaults": { "mode": "discovery", "timeoutMs": 60000 },
   "missions": [
     { "missionId": "m1", "prompt": "p1" },
-    { "missionId": "m2", "prompt": "p2" }
+    { "missionId": "m2", "prompt": "p2" },
+    { "missionId": "m3", "prompt": "p3" }
   ]
 }`)
 
@@ -302,17 +425,18 @@ func TestSuiteRun_FailFastSkipsRemainingMissions(t *testing.T) {
 	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
 		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
 	}
-	if len(sum.Attempts) != 2 {
-		t.Fatalf("expected two attempts in summary, got %+v", sum.Attempts)
+	if len(sum.Attempts) != 3 {
+		t.Fatalf("expected three attempts in summary, got %+v", sum.Attempts)
 	}
 	if sum.Attempts[0].MissionID != "m1" || sum.Attempts[0].AttemptID == "" || sum.Attempts[0].Skipped {
 		t.Fatalf("unexpected first attempt: %+v", sum.Attempts[0])
 	}
-	if sum.Attempts[1].MissionID != "m2" || !sum.Attempts[1].Skipped || sum.Attempts[1].SkipReason == "" || sum.Attempts[1].AttemptID != "" {
-		t.Fatalf("expected second attempt skipped by fail-fast, got: %+v", sum.Attempts[1])
+	// m2 starts while m1's finish is pending; once m1's failed verdict is in, nothing else starts.
+	if sum.Attempts[2].MissionID != "m3" || !sum.Attempts[2].Skipped || sum.Attempts[2].SkipReason == "" || sum.Attempts[2].AttemptID != "" {
+		t.Fatalf("expected third attempt skipped by fail-fast, got: %+v", sum.Attempts[2])
 	}
-	if sum.Failed != 2 {
-		t.Fatalf("expected failed=2 (failed + skipped), got: %+v", sum)
+	if sum.Failed != 3 {
+		t.Fatalf("expected failed=3 (failed + skipped), got: %+v", sum)
 	}
 }
 
@@ -374,6 +498,120 @@ func TestSuiteRun_BlindRejectsContaminatedPrompt(t *testing.T) {
 	}
 }
 
+func TestSuiteRun_BlindRewriteSanitizesContaminatedPrompt(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-blind-rewrite",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000, "blind": true, "blindAction": "rewrite" },
+  "missions": [
+    { "missionId": "m1", "prompt": "Use ZCL feedback to report result" }
+  ]
+}`)
+
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	h := newRunnerHarness(t, suiteRunNow())
+
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
+	})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+
+	var sum struct {
+		Attempts []struct {
+			RunnerErrorCode string `json:"runnerErrorCode"`
+			AttemptDir      string `json:"attemptDir"`
+			Finish          struct {
+				Report struct {
+					Integrity struct {
+						PromptContaminated bool `json:"promptContaminated"`
+						PromptRewritten    bool `json:"promptRewritten"`
+					} `json:"integrity"`
+				} `json:"report"`
+			} `json:"finish"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if len(sum.Attempts) != 1 || sum.Attempts[0].RunnerErrorCode != "" {
+		t.Fatalf("unexpected summary: %+v", sum)
+	}
+	integrity := sum.Attempts[0].Finish.Report.Integrity
+	if integrity.PromptContaminated || !integrity.PromptRewritten {
+		t.Fatalf("expected a clean, rewritten prompt: %+v", integrity)
+	}
+	attemptDir := sum.Attempts[0].AttemptDir
+	if got := mustReadFileString(t, filepath.Join(attemptDir, "prompt.txt")); got != "Use ____________ to report result" {
+		t.Fatalf("unexpected sanitized prompt: %q", got)
+	}
+	if got := mustReadFileString(t, filepath.Join(attemptDir, "prompt.original.txt")); got != "Use ZCL feedback to report result" {
+		t.Fatalf("unexpected original prompt: %q", got)
+	}
+	var rep schema.PromptContaminationJSONV1
+	if err := json.Unmarshal([]byte(mustReadFileString(t, filepath.Join(attemptDir, "prompt.contamination.json"))), &rep); err != nil {
+		t.Fatalf("unmarshal prompt.contamination.json: %v", err)
+	}
+	if rep.Action != "rewrite" || rep.Replacements != 1 || len(rep.Terms) != 1 || rep.Terms[0].Term != "zcl feedback" {
+		t.Fatalf("unexpected contamination report: %+v", rep)
+	}
+}
+
+func TestSuiteRun_BlindRejectsContaminatedRunnerArgv(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-blind-argv",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000, "blind": true, "blindAction": "rewrite" },
+  "missions": [
+    { "missionId": "m1", "prompt": "Report the latest blog title" }
+  ]
+}`)
+
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	h := newRunnerHarness(t, suiteRunNow())
+
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok", "finish with zcl feedback",
+	})
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+
+	var sum struct {
+		Attempts []struct {
+			RunnerErrorCode string `json:"runnerErrorCode"`
+			AttemptDir      string `json:"attemptDir"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if len(sum.Attempts) != 1 || sum.Attempts[0].RunnerErrorCode != "ZCL_E_CONTAMINATED_PROMPT" {
+		t.Fatalf("expected contamination code, got: %+v", sum.Attempts)
+	}
+	trace := mustReadFileString(t, filepath.Join(sum.Attempts[0].AttemptDir, "tool.calls.jsonl"))
+	if !strings.Contains(trace, "argv[4]") || strings.Contains(trace, "prompt.txt") {
+		t.Fatalf("expected argv source in blind-check trace, got: %s", trace)
+	}
+}
+
 func TestSuiteRun_ParallelTotal_JITAllocation(t *testing.T) {
 	outRoot := t.TempDir()
 	suitePath := filepath.Join(t.TempDir(), "suite.json")
@@ -415,6 +653,10 @@ func TestSuiteRun_ParallelTotal_JITAllocation(t *testing.T) {
 			MissionID string `json:"missionId"`
 			OK        bool   `json:"ok"`
 		} `json:"attempts"`
+		Consistency *struct {
+			OK         bool              `json:"ok"`
+			Violations []json.RawMessage `json:"violations"`
+		} `json:"consistency"`
 	}
 	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
 		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
@@ -425,6 +667,9 @@ func TestSuiteRun_ParallelTotal_JITAllocation(t *testing.T) {
 	if sum.RunID == "" {
 		t.Fatalf("expected runId in summary")
 	}
+	if sum.Consistency == nil || !sum.Consistency.OK || len(sum.Consistency.Violations) != 0 {
+		t.Fatalf("expected parallel attempts to pass cross-attempt consistency, got %+v", sum.Consistency)
+	}
 }
 
 func TestSuiteRun_RefusesImplicitProcessFallbackWhenHostIsNativeCapable(t *testing.T) {
@@ -678,73 +923,577 @@ func assertSuiteRunNativeRuntimeEnvMetadata(t *testing.T, attemptDir string) {
 			SHA256     string `json:"sha256"`
 		} `json:"prompt"`
 	}
-	if err := json.Unmarshal(runtimeEnvRaw, &runtimeEnv); err != nil {
-		t.Fatalf("unmarshal attempt.runtime.env.json: %v", err)
+	if err := json.Unmarshal(runtimeEnvRaw, &runtimeEnv); err != nil {
+		t.Fatalf("unmarshal attempt.runtime.env.json: %v", err)
+	}
+	if !runtimeEnv.Runtime.NativeMode || runtimeEnv.Runtime.RuntimeID != "codex_app_server" {
+		t.Fatalf("unexpected native runtime metadata: %+v", runtimeEnv.Runtime)
+	}
+	if runtimeEnv.Runtime.StartCwdMode != "inherit" || strings.TrimSpace(runtimeEnv.Runtime.StartCwd) == "" {
+		t.Fatalf("unexpected native start cwd metadata: %+v", runtimeEnv.Runtime)
+	}
+	if runtimeEnv.Prompt.SourceKind != "suite_prompt" || len(runtimeEnv.Prompt.SHA256) != 64 {
+		t.Fatalf("unexpected native prompt metadata: %+v", runtimeEnv.Prompt)
+	}
+}
+
+func assertSuiteRunNativeReportMetadata(t *testing.T, attemptDir string) {
+	t.Helper()
+	repRaw, err := os.ReadFile(filepath.Join(attemptDir, "attempt.report.json"))
+	if err != nil {
+		t.Fatalf("read attempt.report.json: %v", err)
+	}
+	var rep struct {
+		NativeResult struct {
+			ResultSource string `json:"resultSource"`
+			PhaseAware   bool   `json:"phaseAware"`
+		} `json:"nativeResult"`
+	}
+	if err := json.Unmarshal(repRaw, &rep); err != nil {
+		t.Fatalf("unmarshal attempt.report.json: %v", err)
+	}
+	if rep.NativeResult.ResultSource != "delta_fallback" || rep.NativeResult.PhaseAware {
+		t.Fatalf("unexpected report nativeResult: %+v", rep.NativeResult)
+	}
+}
+
+func TestSuiteRun_NativeRunnerCwdTempEmptyPerAttempt(t *testing.T) {
+	outRoot := t.TempDir()
+	basePath := filepath.Join(t.TempDir(), "runner-cwd")
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-native-cwd-temp",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "native prompt", "expects": { "ok": true } }
+  ]
+}`)
+
+	t.Setenv("ZCL_CODEX_APP_SERVER_CMD", os.Args[0]+" -test.run=TestHelperSuiteNativeAppServer$")
+	t.Setenv("ZCL_HELPER_PROCESS", "1")
+	t.Setenv("ZCL_HELPER_MODE", "smoke")
+	t.Setenv("ZCL_HELPER_EXPECT_CWD_PREFIX", filepath.Clean(basePath)+string(os.PathSeparator))
+
+	h := newRunnerHarness(t, time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC))
+
+	code := h.Runner.runSuiteRunWithEnv([]string{
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--session-isolation", "native",
+		"--json",
+	}, map[string]string{
+		suiteRunEnvRunnerCwdMode:     "temp_empty_per_attempt",
+		suiteRunEnvRunnerCwdBasePath: basePath,
+	})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+
+	var sum struct {
+		OK       bool `json:"ok"`
+		Attempts []struct {
+			AttemptDir string `json:"attemptDir"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if !sum.OK || len(sum.Attempts) != 1 {
+		t.Fatalf("unexpected summary: %+v", sum)
+	}
+	attemptDir := sum.Attempts[0].AttemptDir
+	runtimeEnvRaw, err := os.ReadFile(filepath.Join(attemptDir, "attempt.runtime.env.json"))
+	if err != nil {
+		t.Fatalf("read attempt.runtime.env.json: %v", err)
+	}
+	var runtimeEnv struct {
+		Runtime struct {
+			StartCwdMode   string `json:"startCwdMode"`
+			StartCwd       string `json:"startCwd"`
+			StartCwdRetain string `json:"startCwdRetain"`
+		} `json:"runtime"`
+	}
+	if err := json.Unmarshal(runtimeEnvRaw, &runtimeEnv); err != nil {
+		t.Fatalf("unmarshal attempt.runtime.env.json: %v", err)
+	}
+	if runtimeEnv.Runtime.StartCwdMode != "temp_empty_per_attempt" {
+		t.Fatalf("unexpected start cwd mode: %+v", runtimeEnv.Runtime)
+	}
+	if !strings.HasPrefix(runtimeEnv.Runtime.StartCwd, filepath.Clean(basePath)+string(os.PathSeparator)) {
+		t.Fatalf("start cwd %q not under %q", runtimeEnv.Runtime.StartCwd, basePath)
+	}
+	if runtimeEnv.Runtime.StartCwdRetain != "never" {
+		t.Fatalf("unexpected start cwd retain policy: %+v", runtimeEnv.Runtime)
+	}
+	if _, err := os.Stat(runtimeEnv.Runtime.StartCwd); !os.IsNotExist(err) {
+		t.Fatalf("expected temp start cwd cleanup, stat err=%v path=%q", err, runtimeEnv.Runtime.StartCwd)
+	}
+}
+
+func TestSuiteRun_ContainerRunnerWrapsAttemptInEngineRun(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-container",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+
+	// Fake engine client: log argv, then run everything after the image on the host.
+	binDir := t.TempDir()
+	argvLog := filepath.Join(t.TempDir(), "docker.argv")
+	mustWriteFile(t, filepath.Join(binDir, "docker"), `#!/bin/sh
+printf '%s\n' "$@" > "`+argvLog+`"
+while [ $# -gt 0 ]; do a=$1; shift; [ "$a" = "zcl-test-image:1" ] && exec "$@"; done
+exit 125
+`)
+	if err := os.Chmod(filepath.Join(binDir, "docker"), 0o755); err != nil {
+		t.Fatalf("chmod fake docker: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.runSuiteRunWithEnv([]string{
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
+	}, map[string]string{
+		container.ImageEnvKey:   "zcl-test-image:1",
+		container.NetworkEnvKey: "none",
+	})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	var sum struct {
+		OK       bool `json:"ok"`
+		Attempts []struct {
+			AttemptDir string `json:"attemptDir"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if !sum.OK || len(sum.Attempts) != 1 {
+		t.Fatalf("unexpected summary: %s", h.Stdout.String())
+	}
+	attemptDir := sum.Attempts[0].AttemptDir
+	argv := mustReadFileString(t, argvLog)
+	for _, want := range []string{"run\n--rm\n", "--network\nnone\n", "-v\n" + attemptDir + ":" + attemptDir + "\n", "-e\nZCL_OUT_DIR\n", "zcl-test-image:1\n" + os.Args[0] + "\n"} {
+		if !strings.Contains(argv, want) {
+			t.Fatalf("engine argv missing %q:\n%s", want, argv)
+		}
+	}
+	runtimeEnv := mustReadFileString(t, filepath.Join(attemptDir, "attempt.runtime.env.json"))
+	if !strings.Contains(runtimeEnv, `"image": "zcl-test-image:1"`) || !strings.Contains(runtimeEnv, `"network": "none"`) {
+		t.Fatalf("runtime env should record the container: %s", runtimeEnv)
+	}
+
+	// Containers wrap process runners only.
+	code = h.Runner.runSuiteRunWithEnv([]string{"--file", suitePath, "--out-root", outRoot, "--session-isolation", "native", "--json"}, map[string]string{container.ImageEnvKey: "zcl-test-image:1"})
+	if code != 2 || !strings.Contains(h.Stderr.String(), "requires --session-isolation process") {
+		t.Fatalf("expected usage error for native container run, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+}
+
+func TestSuiteRun_SSHRunnerExecutesRemotelyAndSyncsEvidence(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-ssh",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+
+	// Fake ssh client: log the host, then hand the remote command to a local shell like sshd does.
+	binDir := t.TempDir()
+	hostLog := filepath.Join(t.TempDir(), "ssh.hosts")
+	mustWriteFile(t, filepath.Join(binDir, "ssh"), `#!/bin/sh
+while [ $# -gt 0 ]; do
+  case "$1" in -T) shift ;; -o|-p|-i) shift 2 ;; *) break ;; esac
+done
+host=$1
+shift
+printf '%s %s\n' "$host" "$*" >> "`+hostLog+`"
+exec sh -c "$*"
+`)
+	if err := os.Chmod(filepath.Join(binDir, "ssh"), 0o755); err != nil {
+		t.Fatalf("chmod fake ssh: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	workDir := filepath.Join(t.TempDir(), "remote")
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.runSuiteRunWithEnv([]string{
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
+	}, map[string]string{
+		remote.HostEnvKey:    "agent@build-1",
+		remote.WorkDirEnvKey: workDir,
+	})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	var sum struct {
+		OK       bool `json:"ok"`
+		Attempts []struct {
+			AttemptDir string `json:"attemptDir"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if !sum.OK || len(sum.Attempts) != 1 {
+		t.Fatalf("unexpected summary: %s", h.Stdout.String())
+	}
+	attemptDir := sum.Attempts[0].AttemptDir
+	if calls := mustReadFileString(t, hostLog); !strings.Contains(calls, "agent@build-1 sh -c 'cd '\\''"+workDir+"/") || !strings.Contains(calls, "exec '\\''"+os.Args[0]) {
+		t.Fatalf("runner did not run in the remote attempt dir:\n%s", calls)
+	}
+	// The runner wrote its evidence under the remote attempt dir; it was synced back.
+	if feedback := mustReadFileString(t, filepath.Join(attemptDir, "feedback.json")); !strings.Contains(feedback, `"ok": true`) {
+		t.Fatalf("feedback not synced back: %s", feedback)
+	}
+	if calls := mustReadFileString(t, filepath.Join(attemptDir, "tool.calls.jsonl")); !strings.Contains(calls, `"argv":["echo","hi"]`) {
+		t.Fatalf("tool calls not synced back: %s", calls)
+	}
+	// Remote events are re-chained onto the local trace, so the attempt still validates strictly.
+	if code := h.Runner.Run([]string{"validate", "--strict", "--json", attemptDir}); code != 0 {
+		t.Fatalf("merged trace does not validate: %d (stdout=%q)", code, h.Stdout.String())
+	}
+	_ = filepath.WalkDir(workDir, func(p string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			t.Fatalf("remote attempt dir not cleaned up: %s", p)
+		}
+		return nil
+	})
+	runtimeEnv := mustReadFileString(t, filepath.Join(attemptDir, "attempt.runtime.env.json"))
+	if !strings.Contains(runtimeEnv, `"host": "agent@build-1"`) {
+		t.Fatalf("runtime env should record the remote: %s", runtimeEnv)
+	}
+
+	// Host-side confinement cannot follow a remote runner.
+	code = h.Runner.runSuiteRunWithEnv([]string{"--file", suitePath, "--out-root", outRoot, "--home", "ephemeral", "--json", "--", "true"}, map[string]string{remote.HostEnvKey: "h"})
+	if code != 2 || !strings.Contains(h.Stderr.String(), "cannot be combined with an ssh runner") {
+		t.Fatalf("expected usage error for ephemeral-home ssh run, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+}
+
+func TestSuiteRun_ClaudeCLIRunnerGetsFreshSessionAndPrompt(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-claude-cli",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000, "feedbackPolicy": "auto_fail" },
+  "missions": [
+    { "missionId": "m1", "prompt": "-p1 starts like a flag" },
+    { "missionId": "m2", "prompt": "p2" }
+  ]
+}`)
+
+	// Fake claude: log argv, one argument per line, and print a print-mode result.
+	binDir := t.TempDir()
+	argvLog := filepath.Join(t.TempDir(), "claude.argv")
+	mustWriteFile(t, filepath.Join(binDir, "claude"), `#!/bin/sh
+printf '%s\n' "$@" >> "`+argvLog+`"
+echo '{"type":"result","result":"done"}'
+`)
+	if err := os.Chmod(filepath.Join(binDir, "claude"), 0o755); err != nil {
+		t.Fatalf("chmod fake claude: %v", err)
+	}
+
+	h := newRunnerHarness(t, suiteRunNow())
+	h.Runner.runSuiteRunWithEnv([]string{
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--fail-fast=false",
+		"--json",
+		"--",
+		filepath.Join(binDir, "claude"), "--print", "--output-format", "json",
+	}, map[string]string{claudecli.EnvKey: "1"})
+	var sum struct {
+		Attempts []struct {
+			AttemptDir string `json:"attemptDir"`
+		} `json:"attempts"`
+		Consistency *struct {
+			OK bool `json:"ok"`
+		} `json:"consistency"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q stderr=%q)", err, h.Stdout.String(), h.Stderr.String())
+	}
+	if len(sum.Attempts) != 2 || sum.Consistency == nil || !sum.Consistency.OK {
+		t.Fatalf("unexpected summary: %s", h.Stdout.String())
+	}
+	calls := mustReadFileString(t, argvLog)
+	for i, a := range sum.Attempts {
+		var ref schema.RunnerRefJSONV1
+		if err := json.Unmarshal([]byte(mustReadFileString(t, filepath.Join(a.AttemptDir, "runner.ref.json"))), &ref); err != nil {
+			t.Fatalf("runner.ref.json: %v", err)
+		}
+		if ref.Runner != "claude" || ref.SessionID != claudecli.SessionID(ref.RunID, ref.AttemptID) {
+			t.Fatalf("unexpected runner ref: %+v", ref)
+		}
+		prompt := []string{"-p1 starts like a flag", "p2"}[i]
+		if want := "--print\n--output-format\njson\n--session-id\n" + ref.SessionID + "\n--\n" + prompt + "\n"; !strings.Contains(calls, want) {
+			t.Fatalf("attempt %d: expected argv %q in:\n%s", i, want, calls)
+		}
+	}
+
+	code := h.Runner.runSuiteRunWithEnv([]string{"--file", suitePath, "--out-root", outRoot, "--session-isolation", "native", "--json"}, map[string]string{claudecli.EnvKey: "1"})
+	if code != 2 || !strings.Contains(h.Stderr.String(), "requires --session-isolation process") {
+		t.Fatalf("expected usage error for native claude_cli run, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+}
+
+func TestSuiteRun_HTTPAgentRunnerMapsServiceResponses(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-http-agent",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "stream please" },
+    { "missionId": "m2", "prompt": "poll please" }
+  ]
+}`)
+
+	// m1 streams output then its result; m2 is accepted and answered on the second status poll.
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodGet {
+			if polls.Add(1) < 2 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			fmt.Fprint(w, `{"ok":false,"result":"gave up"}`)
+			return
+		}
+		var req struct {
+			MissionID string `json:"missionId"`
+			Prompt    string `json:"prompt"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.MissionID == "m2" {
+			w.Header().Set("Location", "/status/m2")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "data: working on %s\n\nevent: result\ndata: {\"ok\":true,\"result\":\"done\"}\n\n", req.Prompt)
+	}))
+	defer srv.Close()
+
+	t.Setenv("ZCL_WANT_ZCL_CLI", "1")
+	t.Setenv("AGENT_TOKEN", "s3cret")
+	h := newRunnerHarness(t, suiteRunNow())
+	h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--finalization-mode", "auto_from_result_json",
+		"--result-channel", "stdout_json",
+		"--fail-fast=false",
+		"--json",
+		"--",
+		os.Args[0], "-test.run=^TestHelperZCLCLI$", "--",
+		"http", "agent", "--url", srv.URL + "/missions", "--header", "Authorization: Bearer ${AGENT_TOKEN}", "--poll-interval-ms", "1",
+	})
+	var sum struct {
+		Attempts []struct {
+			AttemptDir string `json:"attemptDir"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil || len(sum.Attempts) != 2 {
+		t.Fatalf("unexpected suite run output: %v (stdout=%q stderr=%q)", err, h.Stdout.String(), h.Stderr.String())
+	}
+	for i, want := range []string{`"ok": true`, `"ok": false`} {
+		dir := sum.Attempts[i].AttemptDir
+		if fb := mustReadFileString(t, filepath.Join(dir, "feedback.json")); !strings.Contains(fb, want) {
+			t.Fatalf("attempt %d: expected %s in feedback.json:\n%s", i, want, fb)
+		}
+	}
+	if out := mustReadFileString(t, filepath.Join(sum.Attempts[0].AttemptDir, "runner.stdout.log")); !strings.Contains(out, "working on stream please") {
+		t.Fatalf("expected streamed output in runner.stdout.log, got %q", out)
+	}
+	if cmd := mustReadFileString(t, filepath.Join(sum.Attempts[0].AttemptDir, "runner.command.txt")); strings.Contains(cmd, "s3cret") {
+		t.Fatalf("header secret leaked into runner.command.txt: %s", cmd)
+	}
+}
+
+func TestSuiteRun_WSAgentRunnerStreamsAndTracesEvents(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-ws-agent",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "stream please" }
+  ]
+}`)
+
+	// The agent reads the mission, streams output and a named event, then sends its result.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		nc, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer func() { _ = nc.Close() }()
+		sum := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+		fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", base64.StdEncoding.EncodeToString(sum[:]))
+		_ = rw.Flush()
+		var mission struct {
+			Prompt string `json:"prompt"`
+		}
+		_ = json.Unmarshal(readWSTestFrame(rw.Reader), &mission)
+		for _, msg := range []string{
+			`{"type":"output","text":"working on ` + mission.Prompt + `"}`,
+			`{"type":"event","name":"tool_use","data":{"tool":"search"}}`,
+			`{"type":"result","result":{"ok":true,"result":"done"}}`,
+		} {
+			_, _ = rw.Write(append([]byte{0x81, byte(len(msg))}, msg...))
+		}
+		_ = rw.Flush()
+		readWSTestFrame(rw.Reader)
+	}))
+	defer srv.Close()
+
+	t.Setenv("ZCL_WANT_ZCL_CLI", "1")
+	t.Setenv("AGENT_TOKEN", "s3cret")
+	h := newRunnerHarness(t, suiteRunNow())
+	h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--finalization-mode", "auto_from_result_json",
+		"--result-channel", "stdout_json",
+		"--json",
+		"--",
+		os.Args[0], "-test.run=^TestHelperZCLCLI$", "--",
+		"ws", "agent", "--url", "ws" + strings.TrimPrefix(srv.URL, "http") + "/agent", "--header", "Authorization: Bearer ${AGENT_TOKEN}",
+	})
+	var sum struct {
+		Attempts []struct {
+			AttemptDir string `json:"attemptDir"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil || len(sum.Attempts) != 1 {
+		t.Fatalf("unexpected suite run output: %v (stdout=%q stderr=%q)", err, h.Stdout.String(), h.Stderr.String())
 	}
-	if !runtimeEnv.Runtime.NativeMode || runtimeEnv.Runtime.RuntimeID != "codex_app_server" {
-		t.Fatalf("unexpected native runtime metadata: %+v", runtimeEnv.Runtime)
+	dir := sum.Attempts[0].AttemptDir
+	if fb := mustReadFileString(t, filepath.Join(dir, "feedback.json")); !strings.Contains(fb, `"ok": true`) {
+		t.Fatalf("expected ok feedback.json:\n%s", fb)
 	}
-	if runtimeEnv.Runtime.StartCwdMode != "inherit" || strings.TrimSpace(runtimeEnv.Runtime.StartCwd) == "" {
-		t.Fatalf("unexpected native start cwd metadata: %+v", runtimeEnv.Runtime)
+	if out := mustReadFileString(t, filepath.Join(dir, "runner.stdout.log")); !strings.Contains(out, "working on stream please") {
+		t.Fatalf("expected streamed output in runner.stdout.log, got %q", out)
 	}
-	if runtimeEnv.Prompt.SourceKind != "suite_prompt" || len(runtimeEnv.Prompt.SHA256) != 64 {
-		t.Fatalf("unexpected native prompt metadata: %+v", runtimeEnv.Prompt)
+	calls := mustReadFileString(t, filepath.Join(dir, "tool.calls.jsonl"))
+	if !strings.Contains(calls, `"tool":"ws","op":"tool_use"`) || !strings.Contains(calls, `"op":"result"`) {
+		t.Fatalf("expected ws agent events in tool.calls.jsonl:\n%s", calls)
 	}
 }
 
-func assertSuiteRunNativeReportMetadata(t *testing.T, attemptDir string) {
-	t.Helper()
-	repRaw, err := os.ReadFile(filepath.Join(attemptDir, "attempt.report.json"))
-	if err != nil {
-		t.Fatalf("read attempt.report.json: %v", err)
+// readWSTestFrame reads one masked client frame (payload < 64KiB).
+func readWSTestFrame(br *bufio.Reader) []byte {
+	var hdr [2]byte
+	if _, err := io.ReadFull(br, hdr[:]); err != nil {
+		return nil
 	}
-	var rep struct {
-		NativeResult struct {
-			ResultSource string `json:"resultSource"`
-			PhaseAware   bool   `json:"phaseAware"`
-		} `json:"nativeResult"`
+	n := int(hdr[1] & 0x7F)
+	if n == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(br, ext[:]); err != nil {
+			return nil
+		}
+		n = int(ext[0])<<8 | int(ext[1])
 	}
-	if err := json.Unmarshal(repRaw, &rep); err != nil {
-		t.Fatalf("unmarshal attempt.report.json: %v", err)
+	var key [4]byte
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(br, key[:]); err != nil {
+		return nil
 	}
-	if rep.NativeResult.ResultSource != "delta_fallback" || rep.NativeResult.PhaseAware {
-		t.Fatalf("unexpected report nativeResult: %+v", rep.NativeResult)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		return nil
 	}
+	for i := range payload {
+		payload[i] ^= key[i%4]
+	}
+	return payload
 }
 
-func TestSuiteRun_NativeRunnerCwdTempEmptyPerAttempt(t *testing.T) {
+// TestHelperZCLCLI runs the zcl CLI with the args after "--", for runner commands that call back
+// into zcl (e.g. `zcl http agent`).
+func TestHelperZCLCLI(t *testing.T) {
+	if os.Getenv("ZCL_WANT_ZCL_CLI") != "1" {
+		return
+	}
+	r := Runner{Version: "0.0.0-dev", Now: time.Now, Stdout: os.Stdout, Stderr: os.Stderr}
+	os.Exit(r.Run(os.Args[indexAfterArgSeparator(os.Args):]))
+}
+
+func TestSuiteRun_SandboxBwrapWrapsRunnerAndRecordsProfile(t *testing.T) {
 	outRoot := t.TempDir()
-	basePath := filepath.Join(t.TempDir(), "runner-cwd")
 	suitePath := filepath.Join(t.TempDir(), "suite.json")
 	writeSuiteFile(t, suitePath, `{
   "version": 1,
-  "suiteId": "suite-run-native-cwd-temp",
+  "suiteId": "suite-run-sandbox",
   "defaults": { "mode": "discovery", "timeoutMs": 60000 },
   "missions": [
-    { "missionId": "m1", "prompt": "native prompt", "expects": { "ok": true } }
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
   ]
 }`)
 
-	t.Setenv("ZCL_CODEX_APP_SERVER_CMD", os.Args[0]+" -test.run=TestHelperSuiteNativeAppServer$")
-	t.Setenv("ZCL_HELPER_PROCESS", "1")
-	t.Setenv("ZCL_HELPER_MODE", "smoke")
-	t.Setenv("ZCL_HELPER_EXPECT_CWD_PREFIX", filepath.Clean(basePath)+string(os.PathSeparator))
-
-	h := newRunnerHarness(t, time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC))
+	// Fake bubblewrap: log argv, then run the command after the first "--" unconfined.
+	binDir := t.TempDir()
+	argvLog := filepath.Join(t.TempDir(), "bwrap.argv")
+	mustWriteFile(t, filepath.Join(binDir, "bwrap"), `#!/bin/sh
+printf '%s\n' "$@" > "`+argvLog+`"
+while [ $# -gt 0 ]; do a=$1; shift; [ "$a" = "--" ] && exec "$@"; done
+exit 1
+`)
+	if err := os.Chmod(filepath.Join(binDir, "bwrap"), 0o755); err != nil {
+		t.Fatalf("chmod fake bwrap: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
 
-	code := h.Runner.runSuiteRunWithEnv([]string{
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
 		"--file", suitePath,
 		"--out-root", outRoot,
-		"--session-isolation", "native",
+		"--sandbox", "bwrap",
 		"--json",
-	}, map[string]string{
-		suiteRunEnvRunnerCwdMode:     "temp_empty_per_attempt",
-		suiteRunEnvRunnerCwdBasePath: basePath,
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
 	})
 	if code != 0 {
 		t.Fatalf("expected exit code 0, got %d (stderr=%q)", code, h.Stderr.String())
 	}
-
 	var sum struct {
 		OK       bool `json:"ok"`
 		Attempts []struct {
@@ -755,34 +1504,35 @@ func TestSuiteRun_NativeRunnerCwdTempEmptyPerAttempt(t *testing.T) {
 		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
 	}
 	if !sum.OK || len(sum.Attempts) != 1 {
-		t.Fatalf("unexpected summary: %+v", sum)
+		t.Fatalf("unexpected summary: %s", h.Stdout.String())
 	}
 	attemptDir := sum.Attempts[0].AttemptDir
-	runtimeEnvRaw, err := os.ReadFile(filepath.Join(attemptDir, "attempt.runtime.env.json"))
-	if err != nil {
-		t.Fatalf("read attempt.runtime.env.json: %v", err)
+	argv := mustReadFileString(t, argvLog)
+	for _, want := range []string{"--ro-bind\n/\n/\n", "--tmpfs\n/tmp\n", "--bind\n" + attemptDir + "\n" + attemptDir + "\n", "--\n" + os.Args[0] + "\n"} {
+		if !strings.Contains(argv, want) {
+			t.Fatalf("bwrap argv missing %q:\n%s", want, argv)
+		}
 	}
 	var runtimeEnv struct {
 		Runtime struct {
-			StartCwdMode   string `json:"startCwdMode"`
-			StartCwd       string `json:"startCwd"`
-			StartCwdRetain string `json:"startCwdRetain"`
+			Sandbox *struct {
+				Kind     string   `json:"kind"`
+				Writable []string `json:"writable"`
+				Hidden   []string `json:"hidden"`
+			} `json:"sandbox"`
 		} `json:"runtime"`
 	}
-	if err := json.Unmarshal(runtimeEnvRaw, &runtimeEnv); err != nil {
+	if err := json.Unmarshal([]byte(mustReadFileString(t, filepath.Join(attemptDir, "attempt.runtime.env.json"))), &runtimeEnv); err != nil {
 		t.Fatalf("unmarshal attempt.runtime.env.json: %v", err)
 	}
-	if runtimeEnv.Runtime.StartCwdMode != "temp_empty_per_attempt" {
-		t.Fatalf("unexpected start cwd mode: %+v", runtimeEnv.Runtime)
-	}
-	if !strings.HasPrefix(runtimeEnv.Runtime.StartCwd, filepath.Clean(basePath)+string(os.PathSeparator)) {
-		t.Fatalf("start cwd %q not under %q", runtimeEnv.Runtime.StartCwd, basePath)
-	}
-	if runtimeEnv.Runtime.StartCwdRetain != "never" {
-		t.Fatalf("unexpected start cwd retain policy: %+v", runtimeEnv.Runtime)
+	sb := runtimeEnv.Runtime.Sandbox
+	if sb == nil || sb.Kind != "bwrap" || len(sb.Writable) == 0 || sb.Writable[0] != attemptDir || len(sb.Hidden) == 0 {
+		t.Fatalf("unexpected sandbox profile: %+v", sb)
 	}
-	if _, err := os.Stat(runtimeEnv.Runtime.StartCwd); !os.IsNotExist(err) {
-		t.Fatalf("expected temp start cwd cleanup, stat err=%v path=%q", err, runtimeEnv.Runtime.StartCwd)
+
+	code = h.Runner.Run([]string{"suite", "run", "--file", suitePath, "--out-root", outRoot, "--sandbox", "jail", "--json", "--", "true"})
+	if code != 2 {
+		t.Fatalf("expected usage error for unknown sandbox, got %d", code)
 	}
 }
 
@@ -1366,6 +2116,51 @@ func TestSuiteRun_NativeParallelUniqueSessionsCore(t *testing.T) {
 	assertSuiteRunNativeParallelSessionsUnique(t, sum.Attempts)
 }
 
+func TestSuiteRun_NativeReusedThreadFailsSessionFreshness(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, buildSuiteRunNativeParallelSuiteJSON(t, 2))
+
+	t.Setenv("ZCL_CODEX_APP_SERVER_CMD", os.Args[0]+" -test.run=TestHelperSuiteNativeAppServer$")
+	t.Setenv("ZCL_HELPER_PROCESS", "1")
+	t.Setenv("ZCL_HELPER_MODE", "smoke")
+	// Every session hands out the same thread, as a runtime that resumed old threads would.
+	t.Setenv("ZCL_HELPER_THREAD_ID", "thr_reused")
+
+	h := newRunnerHarness(t, time.Date(2026, 2, 16, 12, 25, 0, 0, time.UTC))
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--session-isolation", "native",
+		"--json",
+	})
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d stderr=%q", code, h.Stderr.String())
+	}
+	var sum struct {
+		OK          bool `json:"ok"`
+		Passed      int  `json:"passed"`
+		Consistency *struct {
+			OK         bool `json:"ok"`
+			Violations []struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"violations"`
+		} `json:"consistency"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if sum.OK || sum.Passed != 2 || sum.Consistency == nil || sum.Consistency.OK {
+		t.Fatalf("expected passing attempts to fail the run on session freshness, got %+v", sum)
+	}
+	v := sum.Consistency.Violations
+	if len(v) != 1 || v[0].Code != codeSessionNotFresh || !strings.Contains(v[0].Message, `native threadId "thr_reused" is shared`) {
+		t.Fatalf("unexpected freshness violations: %+v", v)
+	}
+}
+
 func buildSuiteRunNativeParallelSuiteJSON(t *testing.T, missionCount int) string {
 	t.Helper()
 	missions := make([]map[string]any, 0, missionCount)
@@ -1687,6 +2482,192 @@ func TestSuiteRun_NativeSchedulerRateLimitIsDeterministic(t *testing.T) {
 	}
 }
 
+func TestSuiteRun_ParallelWorkersDoNotWaitForSlowMission(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-work-stealing",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1" },
+    { "missionId": "m2", "prompt": "p2" },
+    { "missionId": "m3", "prompt": "p3" }
+  ]
+}`)
+
+	markerDir := t.TempDir()
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+	t.Setenv("ZCL_TEST_MARKER_DIR", markerDir)
+
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--parallel", "2",
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=slow-m1",
+	})
+	if code != 0 {
+		t.Fatalf("expected success, got code=%d stderr=%q", code, h.Stderr.String())
+	}
+	// With waves, m3 would only start once m1 finished; a worker pool starts it after m2.
+	if _, err := os.Stat(filepath.Join(markerDir, "m3.overlapped")); err != nil {
+		t.Fatalf("expected m3 to start while m1 was still running: %v", err)
+	}
+}
+
+func TestSuiteRun_ScheduleLongestFirstUsesHistory(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-schedule",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1" },
+    { "missionId": "m2", "prompt": "p2" },
+    { "missionId": "m3", "prompt": "p3" }
+  ]
+}`)
+
+	// History from an earlier run: m1 took 10s, m2 60s (a failed 500s m2 attempt is ignored), m3 never ran.
+	runDir := filepath.Join(outRoot, "runs", "20260101-000000Z-aaaaaa")
+	writeHistory := func(attemptID, missionID string, took time.Duration, ok bool) {
+		t.Helper()
+		dir := filepath.Join(runDir, "attempts", attemptID)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		if err := store.WriteJSONAtomic(filepath.Join(dir, "attempt.json"), map[string]any{
+			"runId": "20260101-000000Z-aaaaaa", "suiteId": "suite-run-schedule", "missionId": missionID, "attemptId": attemptID,
+			"startedAt": start.Format(time.RFC3339Nano),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.WriteJSONAtomic(filepath.Join(dir, "feedback.json"), map[string]any{
+			"ok": ok, "createdAt": start.Add(took).Format(time.RFC3339Nano),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.WriteJSONAtomic(filepath.Join(runDir, "run.json"), map[string]any{"runId": "20260101-000000Z-aaaaaa", "suiteId": "suite-run-schedule"}); err != nil {
+		t.Fatal(err)
+	}
+	writeHistory("001-m1-r1", "m1", 10*time.Second, true)
+	writeHistory("002-m2-r1", "m2", 60*time.Second, true)
+	writeHistory("003-m2-r2", "m2", 500*time.Second, false)
+
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--schedule", "longest-first",
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
+	})
+	if code != 0 {
+		t.Fatalf("expected success, got code=%d stderr=%q", code, h.Stderr.String())
+	}
+	var sum struct {
+		Schedule *struct {
+			Policy          string `json:"policy"`
+			PredictedWallMs int64  `json:"predictedWallMs"`
+			Missions        []struct {
+				MissionID   string `json:"missionId"`
+				PredictedMs int64  `json:"predictedMs"`
+				Samples     int    `json:"samples"`
+			} `json:"missions"`
+		} `json:"schedule"`
+		Attempts []struct {
+			MissionID string `json:"missionId"`
+			AttemptID string `json:"attemptId"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if sum.Schedule == nil || sum.Schedule.Policy != "longest-first" || len(sum.Schedule.Missions) != 3 {
+		t.Fatalf("unexpected schedule: %+v", sum.Schedule)
+	}
+	got := []string{}
+	for _, m := range sum.Schedule.Missions {
+		got = append(got, m.MissionID)
+	}
+	if strings.Join(got, ",") != "m3,m2,m1" || sum.Schedule.Missions[1].PredictedMs != 60000 || sum.Schedule.Missions[0].Samples != 0 {
+		t.Fatalf("expected m3 (no history), m2 (60s), m1 (10s), got %+v", sum.Schedule.Missions)
+	}
+	if sum.Schedule.PredictedWallMs != 70000 {
+		t.Fatalf("expected predicted wall 70000ms on one worker, got %d", sum.Schedule.PredictedWallMs)
+	}
+	// Attempts are allocated in dispatch order but reported in suite order.
+	if len(sum.Attempts) != 3 || sum.Attempts[0].MissionID != "m1" || !strings.HasPrefix(sum.Attempts[2].AttemptID, "001-") {
+		t.Fatalf("expected suite-ordered attempts with m3 allocated first, got %+v", sum.Attempts)
+	}
+}
+
+func TestSuiteRun_NativeSchedulerWaitsForHostWideSlot(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-native-scheduler-global",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+
+	brokerDir := t.TempDir()
+	t.Setenv("ZCL_CODEX_APP_SERVER_CMD", os.Args[0]+" -test.run=TestHelperSuiteNativeAppServer$")
+	t.Setenv("ZCL_HELPER_PROCESS", "1")
+	t.Setenv("ZCL_HELPER_MODE", "smoke")
+	t.Setenv("ZCL_NATIVE_BROKER_DIR", brokerDir)
+	t.Setenv("ZCL_NATIVE_GLOBAL_MAX_INFLIGHT_PER_STRATEGY", "1")
+
+	// Another zcl process on the host holds the only codex_app_server slot for a while.
+	release, _, err := store.AcquireSlot(context.Background(), filepath.Join(brokerDir, "codex_app_server"), 1)
+	if err != nil {
+		t.Fatalf("hold broker slot: %v", err)
+	}
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		_ = release()
+	}()
+
+	h := newRunnerHarnessNowFunc(t, time.Now)
+	start := time.Now()
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--session-isolation", "native",
+		"--json",
+	})
+	elapsed := time.Since(start)
+	if code != 0 {
+		t.Fatalf("expected success, got code=%d stderr=%q", code, h.Stderr.String())
+	}
+	if elapsed < 250*time.Millisecond {
+		t.Fatalf("expected suite run to wait for the host-wide slot, elapsed=%s", elapsed)
+	}
+	entries, err := os.ReadDir(filepath.Join(brokerDir, "codex_app_server"))
+	if err != nil {
+		t.Fatalf("read broker dir: %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "slot-") {
+			t.Fatalf("expected slot %s to be released after the attempt", e.Name())
+		}
+	}
+}
+
 func TestSuiteRun_AutoFeedbackOnTimeout(t *testing.T) {
 	outRoot := t.TempDir()
 	suitePath := filepath.Join(t.TempDir(), "suite.json")
@@ -1810,11 +2791,64 @@ func TestSuiteRun_FeedbackPolicyStrict_DoesNotAutoFinalize(t *testing.T) {
 	if len(sum.Attempts) != 1 {
 		t.Fatalf("expected one attempt, got %+v", sum.Attempts)
 	}
-	if sum.Attempts[0].AutoFeedback {
-		t.Fatalf("expected no auto feedback in strict policy")
+	if sum.Attempts[0].AutoFeedback {
+		t.Fatalf("expected no auto feedback in strict policy")
+	}
+	if sum.Attempts[0].Finish.ReportError == nil || sum.Attempts[0].Finish.ReportError.Code != "ZCL_E_MISSING_ARTIFACT" {
+		t.Fatalf("expected missing artifact report error, got %+v", sum.Attempts[0].Finish.ReportError)
+	}
+}
+
+func TestSuiteRun_ProgressEventsFilterKinds(t *testing.T) {
+	progressPath := filepath.Join(t.TempDir(), "suite.progress.jsonl")
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-progress-filter",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	h := newRunnerHarness(t, suiteRunNow())
+	if code := h.Runner.Run([]string{"suite", "run", "--file", suitePath, "--progress-events", "attempt_started", "--json"}); code != 2 || !strings.Contains(h.Stderr.String(), "--progress-events requires --progress-jsonl") {
+		t.Fatalf("expected usage error without --progress-jsonl, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	h = newRunnerHarness(t, suiteRunNow())
+	if code := h.Runner.Run([]string{"suite", "run", "--file", suitePath, "--progress-jsonl", progressPath, "--progress-events", "attempt_started,attempt_exploded", "--json"}); code != 2 || !strings.Contains(h.Stderr.String(), `unknown progress event "attempt_exploded"`) {
+		t.Fatalf("expected usage error for unknown kind, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+
+	h = newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", t.TempDir(),
+		"--progress-jsonl", progressPath,
+		"--progress-events", "attempt_started, attempt_finished",
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
+	})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	var kinds []string
+	for _, line := range strings.Split(strings.TrimSpace(mustReadFileString(t, progressPath)), "\n") {
+		var ev struct {
+			SchemaVersion int    `json:"schemaVersion"`
+			V             int    `json:"v"`
+			Kind          string `json:"kind"`
+		}
+		if err := json.Unmarshal([]byte(line), &ev); err != nil || ev.SchemaVersion != schema.SuiteRunProgressSchemaV1 || ev.V != ev.SchemaVersion {
+			t.Fatalf("unexpected progress line %q: %v", line, err)
+		}
+		kinds = append(kinds, ev.Kind)
 	}
-	if sum.Attempts[0].Finish.ReportError == nil || sum.Attempts[0].Finish.ReportError.Code != "ZCL_E_MISSING_ARTIFACT" {
-		t.Fatalf("expected missing artifact report error, got %+v", sum.Attempts[0].Finish.ReportError)
+	if strings.Join(kinds, ",") != "attempt_started,attempt_finished" {
+		t.Fatalf("expected only attempt_started,attempt_finished, got %v", kinds)
 	}
 }
 
@@ -1868,6 +2902,19 @@ func TestSuiteRun_WritesCampaignStateAndProgress(t *testing.T) {
 	if !strings.Contains(string(progressBytes), `"kind":"run_started"`) || !strings.Contains(string(progressBytes), `"kind":"run_finished"`) {
 		t.Fatalf("expected run_started/run_finished events, got %s", string(progressBytes))
 	}
+	var runProgress map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(progressBytes)), "\n") {
+		var ev struct {
+			Kind    string         `json:"kind"`
+			Details map[string]any `json:"details"`
+		}
+		if err := json.Unmarshal([]byte(line), &ev); err == nil && ev.Kind == "run_progress" {
+			runProgress = ev.Details
+		}
+	}
+	if runProgress == nil || runProgress["completed"] != float64(1) || runProgress["total"] != float64(1) || runProgress["inFlight"] != float64(0) || runProgress["etaMs"] != float64(0) {
+		t.Fatalf("expected a run_progress event after the attempt finished, got %+v", runProgress)
+	}
 
 	statePath := sum.CampaignStatePath
 	if statePath == "" {
@@ -2252,9 +3299,21 @@ func runSuiteRunnerProcessCase(kind string, exitCode int, r Runner) {
 		runSuiteRunnerProcessCaseResultStdout(r, exitCode)
 	case "infra-feedback-only":
 		runSuiteRunnerProcessCaseInfraFeedbackOnly(r, exitCode)
+	case "egress-blocked":
+		runSuiteRunnerProcessCaseEgressBlocked(r, exitCode)
+	case "home-check":
+		runSuiteRunnerProcessCaseHomeCheck(r, exitCode)
+	case "disk-hog":
+		if err := os.WriteFile(filepath.Join(os.Getenv("ZCL_OUT_DIR"), "hog.bin"), make([]byte, 2<<20), 0o644); err != nil {
+			os.Exit(117)
+		}
+		time.Sleep(30 * time.Second)
+		os.Exit(exitCode)
 	case "sleep":
 		time.Sleep(3 * time.Second)
 		os.Exit(exitCode)
+	case "slow-m1":
+		runSuiteRunnerProcessCaseSlowM1(r, exitCode)
 	default:
 		os.Exit(103)
 	}
@@ -2270,6 +3329,24 @@ func runSuiteRunnerProcessCaseOK(r Runner, exitCode int) {
 	os.Exit(exitCode)
 }
 
+// runSuiteRunnerProcessCaseSlowM1 keeps m1 busy until m3 starts (or 10s pass) and has every other
+// mission record whether m1 had finished when it started.
+func runSuiteRunnerProcessCaseSlowM1(r Runner, exitCode int) {
+	markerDir := os.Getenv("ZCL_TEST_MARKER_DIR")
+	mission := os.Getenv("ZCL_MISSION_ID")
+	if mission == "m1" {
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+			if _, err := os.Stat(filepath.Join(markerDir, "m3.overlapped")); err == nil {
+				break
+			}
+		}
+		_ = os.WriteFile(filepath.Join(markerDir, "m1.done"), nil, 0o644)
+	} else if _, err := os.Stat(filepath.Join(markerDir, "m1.done")); err != nil {
+		_ = os.WriteFile(filepath.Join(markerDir, mission+".overlapped"), nil, 0o644)
+	}
+	runSuiteRunnerProcessCaseOK(r, exitCode)
+}
+
 func runSuiteRunnerProcessCaseNoFeedback(r Runner, exitCode int) {
 	_ = r.Run([]string{"run", "--", "echo", "hi"})
 	os.Exit(exitCode)
@@ -2313,6 +3390,139 @@ func runSuiteRunnerProcessCaseInfraFeedbackOnly(r Runner, exitCode int) {
 	os.Exit(exitCode)
 }
 
+func runSuiteRunnerProcessCaseEgressBlocked(r Runner, exitCode int) {
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment}, Timeout: 5 * time.Second}
+	resp, err := client.Get("http://blocked.invalid/exfil")
+	if err != nil {
+		os.Exit(115)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		os.Exit(116)
+	}
+	runSuiteRunnerProcessCaseOK(r, exitCode)
+}
+
+func runSuiteRunnerProcessCaseHomeCheck(r Runner, exitCode int) {
+	home := os.Getenv("HOME")
+	if home == "" || !strings.HasPrefix(home, os.Getenv("ZCL_TMP_DIR")) || os.Getenv("XDG_CONFIG_HOME") != filepath.Join(home, ".config") {
+		os.Exit(118)
+	}
+	if raw, err := os.ReadFile(filepath.Join(os.Getenv("CODEX_HOME"), "auth.json")); err != nil || string(raw) != "template" {
+		os.Exit(119)
+	}
+	runSuiteRunnerProcessCaseOK(r, exitCode)
+}
+
+func TestSuiteRun_EphemeralHomeFromTemplate(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-home",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1" }
+  ]
+}`)
+	template := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(template, ".codex"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(template, ".codex", "auth.json"), []byte("template"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--home", "ephemeral",
+		"--home-template", template,
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=home-check",
+	})
+	var sum struct {
+		Attempts []struct {
+			AttemptDir     string `json:"attemptDir"`
+			RunnerExitCode *int   `json:"runnerExitCode"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (code=%d stdout=%q)", err, code, h.Stdout.String())
+	}
+	if code != 0 || len(sum.Attempts) != 1 || sum.Attempts[0].RunnerExitCode == nil || *sum.Attempts[0].RunnerExitCode != 0 {
+		t.Fatalf("runner did not see the ephemeral home: code=%d %s (stderr=%q)", code, h.Stdout.String(), h.Stderr.String())
+	}
+	rt := mustReadFileString(t, filepath.Join(sum.Attempts[0].AttemptDir, "attempt.runtime.env.json"))
+	if !strings.Contains(rt, `"home": {`) || !strings.Contains(rt, template) {
+		t.Fatalf("runtime env missing ephemeral home: %s", rt)
+	}
+
+	if code := h.Runner.Run([]string{"suite", "run", "--file", suitePath, "--out-root", outRoot, "--home-template", template, "--json", "--", "true"}); code != 2 {
+		t.Fatalf("expected usage error for --home-template without --home ephemeral, got %d", code)
+	}
+}
+
+func TestSuiteRun_DiskQuotaKillsRunnerAndReportsUsage(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-disk",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1" }
+  ]
+}`)
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	h := newRunnerHarness(t, suiteRunNow())
+	start := time.Now()
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--feedback-policy", "auto_fail",
+		"--disk-quota-mb", "1",
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=disk-hog",
+	})
+	if time.Since(start) > 20*time.Second {
+		t.Fatalf("runner was not killed at the disk quota")
+	}
+	var sum struct {
+		Attempts []struct {
+			AttemptDir      string `json:"attemptDir"`
+			RunnerErrorCode string `json:"runnerErrorCode"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (code=%d stdout=%q)", err, code, h.Stdout.String())
+	}
+	if len(sum.Attempts) != 1 || sum.Attempts[0].RunnerErrorCode != "ZCL_E_DISK_QUOTA" {
+		t.Fatalf("expected ZCL_E_DISK_QUOTA attempt: %s (stderr=%q)", h.Stdout.String(), h.Stderr.String())
+	}
+	var rep struct {
+		Metrics struct {
+			DiskBytes         int64 `json:"diskBytes"`
+			DiskBytesPeak     int64 `json:"diskBytesPeak"`
+			DiskQuotaExceeded bool  `json:"diskQuotaExceeded"`
+		} `json:"metrics"`
+	}
+	raw := mustReadFileString(t, filepath.Join(sum.Attempts[0].AttemptDir, "attempt.report.json"))
+	if err := json.Unmarshal([]byte(raw), &rep); err != nil {
+		t.Fatalf("unmarshal attempt report: %v", err)
+	}
+	if !rep.Metrics.DiskQuotaExceeded || rep.Metrics.DiskBytesPeak < 2<<20 || rep.Metrics.DiskBytes < 2<<20 {
+		t.Fatalf("report missing disk usage: %s", raw)
+	}
+}
+
 func TestHelperSuiteNativeAppServer(t *testing.T) {
 	if os.Getenv("ZCL_HELPER_PROCESS") != "1" {
 		return
@@ -2334,10 +3544,13 @@ func runSuiteNativeHelperCore(mode string) {
 	scanner.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
 	ctx := suiteNativeHelperContext{
 		mode:     mode,
-		threadID: "thr_native_1",
+		threadID: "thr_native_" + strconv.Itoa(os.Getpid()),
 		turnID:   "turn_native_1",
 		writer:   &suiteNativeHelperWriter{},
 	}
+	if id := os.Getenv("ZCL_HELPER_THREAD_ID"); id != "" {
+		ctx.threadID = id
+	}
 	for scanner.Scan() {
 		if stop := handleSuiteNativeHelperLine(strings.TrimSpace(scanner.Text()), &ctx); stop {
 			return
@@ -2707,3 +3920,273 @@ func writeSuiteFile(t *testing.T, path string, contents string) {
 		t.Fatalf("write suite file: %v", err)
 	}
 }
+
+func TestSuiteRun_NetworkNoneUnsharesNetAndBlocksNetworkMissions(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-offline",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } },
+    { "missionId": "m2", "prompt": "p2", "requiresNetwork": true }
+  ]
+}`)
+
+	binDir := t.TempDir()
+	argvLog := filepath.Join(t.TempDir(), "bwrap.argv")
+	mustWriteFile(t, filepath.Join(binDir, "bwrap"), `#!/bin/sh
+printf '%s\n' "$@" >> "`+argvLog+`"
+while [ $# -gt 0 ]; do a=$1; shift; [ "$a" = "--" ] && exec "$@"; done
+exit 1
+`)
+	if err := os.Chmod(filepath.Join(binDir, "bwrap"), 0o755); err != nil {
+		t.Fatalf("chmod fake bwrap: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--network", "none",
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
+	})
+	if code != 2 {
+		t.Fatalf("expected exit code 2 (blocked mission), got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	var sum struct {
+		Attempts []struct {
+			MissionID       string `json:"missionId"`
+			AttemptDir      string `json:"attemptDir"`
+			RunnerErrorCode string `json:"runnerErrorCode"`
+			OK              bool   `json:"ok"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if len(sum.Attempts) != 2 || !sum.Attempts[0].OK || sum.Attempts[1].OK || sum.Attempts[1].RunnerErrorCode != codeNetworkRequired {
+		t.Fatalf("unexpected summary: %s", h.Stdout.String())
+	}
+	argv := mustReadFileString(t, argvLog)
+	if !strings.Contains(argv, "--unshare-net\n") || strings.Count(argv, "--unshare-net\n") != 1 {
+		t.Fatalf("expected exactly one network-less runner launch:\n%s", argv)
+	}
+	if fb := mustReadFileString(t, filepath.Join(sum.Attempts[1].AttemptDir, "feedback.json")); !strings.Contains(fb, "NETWORK_REQUIRED") {
+		t.Fatalf("blocked attempt feedback missing typed result: %s", fb)
+	}
+	if calls := mustReadFileString(t, filepath.Join(sum.Attempts[1].AttemptDir, "tool.calls.jsonl")); !strings.Contains(calls, codeNetworkRequired) {
+		t.Fatalf("blocked attempt trace missing %s: %s", codeNetworkRequired, calls)
+	}
+	if rt := mustReadFileString(t, filepath.Join(sum.Attempts[0].AttemptDir, "attempt.runtime.env.json")); !strings.Contains(rt, `"network": "none"`) {
+		t.Fatalf("runtime env missing network=none: %s", rt)
+	}
+}
+
+func TestSuiteRun_NetworkAdvisoryAllowlistTracesBlockedEgress(t *testing.T) {
+	outRoot := t.TempDir()
+	suitePath := filepath.Join(t.TempDir(), "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-egress",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "p1" }
+  ]
+}`)
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--network", "advisory-allowlist",
+		"--allow-host", "api.example.com",
+		"--json",
+		"--",
+		os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=egress-blocked",
+	})
+	var sum struct {
+		Attempts []struct {
+			AttemptDir     string `json:"attemptDir"`
+			RunnerExitCode *int   `json:"runnerExitCode"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (code=%d stdout=%q)", err, code, h.Stdout.String())
+	}
+	if len(sum.Attempts) != 1 || sum.Attempts[0].RunnerExitCode == nil || *sum.Attempts[0].RunnerExitCode != 0 {
+		t.Fatalf("runner did not get a 403 from the egress proxy: %s (stderr=%q)", h.Stdout.String(), h.Stderr.String())
+	}
+	attemptDir := sum.Attempts[0].AttemptDir
+	calls := mustReadFileString(t, filepath.Join(attemptDir, "tool.calls.jsonl"))
+	if !strings.Contains(calls, `"op":"egress"`) || !strings.Contains(calls, "http://blocked.invalid/exfil") || !strings.Contains(calls, "ZCL_E_EGRESS_DENIED") {
+		t.Fatalf("trace missing egress violation:\n%s", calls)
+	}
+	rt := mustReadFileString(t, filepath.Join(attemptDir, "attempt.runtime.env.json"))
+	if !strings.Contains(rt, `"network": "advisory-allowlist"`) || !strings.Contains(rt, `"api.example.com"`) {
+		t.Fatalf("runtime env missing allowlist policy: %s", rt)
+	}
+
+	if code := h.Runner.Run([]string{"suite", "run", "--file", suitePath, "--out-root", outRoot, "--network", "advisory-allowlist", "--json", "--", "true"}); code != 2 {
+		t.Fatalf("expected usage error for advisory-allowlist without --allow-host, got %d", code)
+	}
+	// The pre-rename value is refused, not aliased, so nobody mistakes the proxy for confinement.
+	h.Stderr.Reset()
+	if code := h.Runner.Run([]string{"suite", "run", "--file", suitePath, "--out-root", outRoot, "--network", "allowlist", "--allow-host", "api.example.com", "--json", "--", "true"}); code != 2 || !strings.Contains(h.Stderr.String(), "not enforced") {
+		t.Fatalf("expected usage error for --network allowlist, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+}
+
+func TestSuiteRun_NativeProviderStubScenarioPlaysPerMissionSteps(t *testing.T) {
+	outRoot := t.TempDir()
+	dir := t.TempDir()
+	suitePath := filepath.Join(dir, "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-provider-stub-scenario",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "native prompt", "expects": { "ok": true } },
+    { "missionId": "m2", "prompt": "native prompt", "expects": { "ok": true } }
+  ]
+}`)
+	scenarioPath := filepath.Join(dir, "scenario.yaml")
+	if err := os.WriteFile(scenarioPath, []byte(`schemaVersion: 1
+default:
+  steps:
+    - event: item/completed
+      payload: {type: commandExecution, command: ls}
+      delayMs: 5
+    - message: SCENARIO_FINAL
+missions:
+  m2:
+    steps:
+      - fail: {kind: rate_limit, message: "429 too many requests"}
+`), 0o644); err != nil {
+		t.Fatalf("write scenario: %v", err)
+	}
+	t.Setenv("ZCL_PROVIDER_STUB_SCENARIO", scenarioPath)
+
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--session-isolation", "native",
+		"--runtime-strategies", "provider_stub",
+		"--fail-fast=false",
+		"--json",
+	})
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	var sum struct {
+		Attempts []struct {
+			MissionID       string `json:"missionId"`
+			AttemptDir      string `json:"attemptDir"`
+			OK              bool   `json:"ok"`
+			RunnerErrorCode string `json:"runnerErrorCode"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if len(sum.Attempts) != 2 {
+		t.Fatalf("unexpected summary: %+v", sum)
+	}
+	m1, m2 := sum.Attempts[0], sum.Attempts[1]
+	if !m1.OK || m1.RunnerErrorCode != "" {
+		t.Fatalf("expected m1 ok, got %+v", m1)
+	}
+	fbRaw, err := os.ReadFile(filepath.Join(m1.AttemptDir, "feedback.json"))
+	if err != nil || !strings.Contains(string(fbRaw), "SCENARIO_FINAL") {
+		t.Fatalf("expected scenario final answer in feedback, got %s (err=%v)", fbRaw, err)
+	}
+	traceRaw, err := os.ReadFile(filepath.Join(m1.AttemptDir, "tool.calls.jsonl"))
+	if err != nil || !strings.Contains(string(traceRaw), `"op":"item_completed"`) {
+		t.Fatalf("expected scripted event in trace, got %s (err=%v)", traceRaw, err)
+	}
+	if m2.OK || m2.RunnerErrorCode != codeRuntimeRateLimit {
+		t.Fatalf("expected m2 rate limited, got %+v", m2)
+	}
+}
+
+func TestSuiteRun_NativeChaosProfileInjectsFaults(t *testing.T) {
+	outRoot := t.TempDir()
+	dir := t.TempDir()
+	suitePath := filepath.Join(dir, "suite.json")
+	writeSuiteFile(t, suitePath, `{
+  "version": 1,
+  "suiteId": "suite-run-chaos",
+  "defaults": { "mode": "discovery", "timeoutMs": 60000 },
+  "missions": [
+    { "missionId": "m1", "prompt": "native prompt", "expects": { "ok": true } }
+  ]
+}`)
+	scenarioPath := filepath.Join(dir, "scenario.yaml")
+	if err := os.WriteFile(scenarioPath, []byte(`schemaVersion: 1
+default:
+  steps:
+    - message: SCENARIO_FINAL
+      delayMs: 2000
+`), 0o644); err != nil {
+		t.Fatalf("write scenario: %v", err)
+	}
+	t.Setenv("ZCL_PROVIDER_STUB_SCENARIO", scenarioPath)
+	chaosPath := filepath.Join(dir, "chaos.yaml")
+	if err := os.WriteFile(chaosPath, []byte("schemaVersion: 1\ncrash: {probability: 1, delayMs: 5}\n"), 0o644); err != nil {
+		t.Fatalf("write chaos profile: %v", err)
+	}
+
+	h := newRunnerHarness(t, suiteRunNow())
+	code := h.Runner.Run([]string{
+		"suite", "run",
+		"--file", suitePath,
+		"--out-root", outRoot,
+		"--session-isolation", "native",
+		"--runtime-strategies", "provider_stub",
+		"--chaos", chaosPath,
+		"--json",
+	})
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+	var sum struct {
+		ChaosProfile    string `json:"chaosProfile"`
+		CampaignProfile struct {
+			Chaos bool `json:"chaos"`
+		} `json:"campaignProfile"`
+		Attempts []struct {
+			AttemptDir      string `json:"attemptDir"`
+			OK              bool   `json:"ok"`
+			RunnerErrorCode string `json:"runnerErrorCode"`
+		} `json:"attempts"`
+	}
+	if err := json.Unmarshal(h.Stdout.Bytes(), &sum); err != nil {
+		t.Fatalf("unmarshal suite run json: %v (stdout=%q)", err, h.Stdout.String())
+	}
+	if sum.ChaosProfile != chaosPath || !sum.CampaignProfile.Chaos || len(sum.Attempts) != 1 {
+		t.Fatalf("unexpected summary: %+v", sum)
+	}
+	if a := sum.Attempts[0]; a.OK || a.RunnerErrorCode != codeRuntimeCrash {
+		t.Fatalf("expected injected runtime crash, got %+v", a)
+	}
+	traceRaw, err := os.ReadFile(filepath.Join(sum.Attempts[0].AttemptDir, "tool.calls.jsonl"))
+	if err != nil || !strings.Contains(string(traceRaw), "runtime_crashed") {
+		t.Fatalf("expected injected crash in trace, got %s (err=%v)", traceRaw, err)
+	}
+
+	h = newRunnerHarness(t, suiteRunNow())
+	code = h.Runner.Run([]string{"suite", "run", "--file", suitePath, "--out-root", outRoot, "--session-isolation", "process", "--chaos", chaosPath, "--json", "--", "true"})
+	if code != 2 || !strings.Contains(h.Stderr.String(), "--chaos requires --session-isolation native") {
+		t.Fatalf("expected usage error for process-mode chaos, got %d (stderr=%q)", code, h.Stderr.String())
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/limits"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// resolveSuiteRunLimits reads the runner.limits a process-runner flow exports to suite run.
+func resolveSuiteRunLimits(extraAttemptEnv map[string]string, nativeMode bool, containerized bool) (*container.Limits, error) {
+	l, ok, err := limits.FromEnv(extraAttemptEnv)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if nativeMode {
+		return nil, fmt.Errorf("runner limits (%s) require --session-isolation process", limits.EnvKey)
+	}
+	if containerized {
+		return nil, fmt.Errorf("runner limits (%s) cannot be combined with a container runner (use its container limits)", limits.EnvKey)
+	}
+	if err := limits.Preflight(l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// wrapSuiteRunLimitsRunner runs the attempt's runner command (including any sandbox wrapper) in a
+// transient cgroup scope, so the whole process tree shares the limits.
+func wrapSuiteRunLimitsRunner(pm planner.PlannedMission, opts suiteRunExecOpts) suiteRunExecOpts {
+	if opts.Limits == nil {
+		return opts
+	}
+	argv := limits.ScopeArgv(*opts.Limits, pm.AttemptID, append([]string{opts.RunnerCmd}, opts.RunnerArgs...))
+	opts.RunnerCmd, opts.RunnerArgs = argv[0], argv[1:]
+	return opts
+}
+
+// classifySuiteRunResourceLimit turns an OOM kill under a memory limit into ZCL_E_RESOURCE_LIMIT
+// instead of a generic non-zero runner exit.
+func classifySuiteRunResourceLimit(opts suiteRunExecOpts, ar *suiteRunAttemptResult) {
+	if ar.RunnerErrorCode != "" {
+		return
+	}
+	var l container.Limits
+	switch {
+	case opts.Limits != nil:
+		l = *opts.Limits
+	case opts.Container != nil:
+		l = opts.Container.Limits
+	}
+	if limits.KilledByLimit(l, ar.RunnerExitCode) {
+		ar.RunnerErrorCode = codeResourceLimit
+	}
+}
+
+func suiteRunLimitsRuntime(opts suiteRunExecOpts) *schema.AttemptLimitsV1 {
+	if opts.Limits == nil {
+		return nil
+	}
+	return &schema.AttemptLimitsV1{CPU: opts.Limits.CPU, MemoryMB: opts.Limits.MemoryMB, Pids: opts.Limits.Pids}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/limits"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/sandbox"
+)
+
+func TestSuiteRunLimits_WrapOutermostAndClassifyOOMKill(t *testing.T) {
+	pm := planner.PlannedMission{MissionID: "m1", AttemptID: "001-m1-r1", OutDirAbs: "/out/a1"}
+	opts := suiteRunExecOpts{
+		RunnerCmd:  "./agent.sh",
+		RunnerArgs: []string{"--go"},
+		Network:    sandbox.NetworkNone,
+		Limits:     &container.Limits{CPU: 2, MemoryMB: 2048},
+	}
+	opts = wrapSuiteRunNetworkRunner(opts, nil)
+	opts = wrapSuiteRunLimitsRunner(pm, opts)
+	joined := strings.Join(append([]string{opts.RunnerCmd}, opts.RunnerArgs...), " ")
+	if !strings.HasPrefix(joined, "systemd-run ") || !strings.Contains(joined, "-p MemoryMax=2048M") || !strings.Contains(joined, "-- bwrap ") || !strings.HasSuffix(joined, "-- ./agent.sh --go") {
+		t.Fatalf("limits scope must wrap the whole runner tree: %s", joined)
+	}
+
+	for _, tc := range []struct {
+		exit    int
+		errCode string
+		want    string
+	}{
+		{exit: 137, want: codeResourceLimit},
+		{exit: -1, want: codeResourceLimit},
+		{exit: 1, want: ""},
+		{exit: -1, errCode: codeTimeout, want: codeTimeout},
+	} {
+		exit := tc.exit
+		ar := suiteRunAttemptResult{RunnerExitCode: &exit, RunnerErrorCode: tc.errCode}
+		classifySuiteRunResourceLimit(opts, &ar)
+		if ar.RunnerErrorCode != tc.want {
+			t.Fatalf("exit=%d err=%q: got %q, want %q", tc.exit, tc.errCode, ar.RunnerErrorCode, tc.want)
+		}
+	}
+	exit := 137
+	ar := suiteRunAttemptResult{RunnerExitCode: &exit}
+	classifySuiteRunResourceLimit(suiteRunExecOpts{Container: &container.Spec{Limits: container.Limits{MemoryMB: 256}}}, &ar)
+	if ar.RunnerErrorCode != codeResourceLimit {
+		t.Fatalf("container OOM (exit 137) must classify as %s, got %q", codeResourceLimit, ar.RunnerErrorCode)
+	}
+
+	if _, err := resolveSuiteRunLimits(limits.Env(container.Limits{MemoryMB: 64}), true, false); err == nil {
+		t.Fatalf("expected native mode to reject runner limits")
+	}
+}
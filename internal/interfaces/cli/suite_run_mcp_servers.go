@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+)
+
+// suiteRunEnvMCPServers carries a campaign flow's runner.mcpServers (JSON) into suite run.
+const suiteRunEnvMCPServers = "ZCL_NATIVE_MCP_SERVERS"
+
+// resolveSuiteRunMCPServers merges the suite's defaults.mcpServers with the flow's servers (flow
+// wins by name). Process runners start their own MCP servers, so only native mode uses them.
+func resolveSuiteRunMCPServers(defaults map[string]suite.MCPServerV1, extraAttemptEnv map[string]string, nativeMode bool) (map[string]suite.MCPServerV1, error) {
+	if !nativeMode {
+		return nil, nil
+	}
+	out := map[string]suite.MCPServerV1{}
+	for name, srv := range defaults {
+		out[name] = srv
+	}
+	if raw := strings.TrimSpace(extraAttemptEnv[suiteRunEnvMCPServers]); raw != "" {
+		var flow map[string]suite.MCPServerV1
+		if err := json.Unmarshal([]byte(raw), &flow); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", suiteRunEnvMCPServers, err)
+		}
+		if err := suite.NormalizeMCPServers(flow); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", suiteRunEnvMCPServers, err)
+		}
+		for name, srv := range flow {
+			out[name] = srv
+		}
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// suiteNativeMCPServers builds an attempt's MCP servers. With a zcl executable each server runs
+// behind `zcl mcp proxy` with the attempt's ZCL_* env, so native agents' MCP calls are traced in
+// tool.calls.jsonl like those of process-mode adapters.
+func suiteNativeMCPServers(servers map[string]suite.MCPServerV1, env map[string]string, zclExe string) []native.MCPServer {
+	if len(servers) == 0 {
+		return nil
+	}
+	names := sortedMCPServerNames(servers)
+	out := make([]native.MCPServer, 0, len(names))
+	for _, name := range names {
+		srv := servers[name]
+		cmd := append([]string(nil), srv.Command...)
+		srvEnv := copyStringMap(srv.Env)
+		if strings.TrimSpace(zclExe) != "" {
+			cmd = append([]string{zclExe, "mcp", "proxy", "--"}, cmd...)
+			if srvEnv == nil {
+				srvEnv = map[string]string{}
+			}
+			for k, v := range env {
+				if strings.HasPrefix(k, "ZCL_") {
+					srvEnv[k] = v
+				}
+			}
+		}
+		out = append(out, native.MCPServer{Name: name, Command: cmd, Env: srvEnv})
+	}
+	return out
+}
+
+func sortedMCPServerNames(servers map[string]suite.MCPServerV1) []string {
+	if len(servers) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+)
+
+func TestSuiteRunMCPServersMergeFlowAndRouteThroughProxy(t *testing.T) {
+	defaults := map[string]suite.MCPServerV1{
+		"docs":  {Command: []string{"docs-mcp"}},
+		"files": {Command: []string{"files-mcp", "--root", "/srv"}},
+	}
+	flowEnv := map[string]string{suiteRunEnvMCPServers: `{"docs":{"command":["docs-mcp-v2"],"env":{"DOCS_ROOT":"/d"}}}`}
+	if got, err := resolveSuiteRunMCPServers(defaults, flowEnv, false); err != nil || got != nil {
+		t.Fatalf("process mode must not register MCP servers, got %+v (%v)", got, err)
+	}
+	servers, err := resolveSuiteRunMCPServers(defaults, flowEnv, true)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if got := servers["docs"].Command; !reflect.DeepEqual(got, []string{"docs-mcp-v2"}) {
+		t.Fatalf("flow server must replace the suite server, got %v", got)
+	}
+	if _, err := resolveSuiteRunMCPServers(nil, map[string]string{suiteRunEnvMCPServers: `{"x y":{"command":["a"]}}`}, true); err == nil {
+		t.Fatalf("expected invalid server name error")
+	}
+
+	attemptEnv := map[string]string{"ZCL_OUT_DIR": "/out/a1", "ZCL_RUN_ID": "r1", "HOME": "/home/u"}
+	got := suiteNativeMCPServers(servers, attemptEnv, "/bin/zcl")
+	if len(got) != 2 || got[0].Name != "docs" || got[1].Name != "files" {
+		t.Fatalf("expected servers sorted by name, got %+v", got)
+	}
+	if want := []string{"/bin/zcl", "mcp", "proxy", "--", "docs-mcp-v2"}; !reflect.DeepEqual(got[0].Command, want) {
+		t.Fatalf("expected proxied command %v, got %v", want, got[0].Command)
+	}
+	if want := map[string]string{"DOCS_ROOT": "/d", "ZCL_OUT_DIR": "/out/a1", "ZCL_RUN_ID": "r1"}; !reflect.DeepEqual(got[0].Env, want) {
+		t.Fatalf("expected server env plus attempt ZCL_* env, got %v", got[0].Env)
+	}
+	if direct := suiteNativeMCPServers(servers, attemptEnv, ""); !reflect.DeepEqual(direct[1].Command, []string{"files-mcp", "--root", "/srv"}) || direct[1].Env != nil {
+		t.Fatalf("without a zcl executable servers run directly, got %+v", direct[1])
+	}
+}
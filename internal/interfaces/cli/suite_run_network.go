@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/feedback"
+	httpproxy "github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/http_proxy"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/sandbox"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/spec/ports/suite"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// resolveSuiteRunNetwork validates --network and returns the effective network mode. For container
+// runners, none overrides the flow's container network; a flow already on network=none is offline
+// without the flag.
+func resolveSuiteRunNetwork(raw string, nativeMode bool, spec *container.Spec) (string, *container.Spec, error) {
+	network, err := sandbox.ParseNetwork(raw)
+	if err != nil {
+		return "", spec, err
+	}
+	if network == sandbox.NetworkAdvisoryAllowlist {
+		// The egress proxy listens on host loopback, which only host process runners can reach.
+		if nativeMode {
+			return "", spec, fmt.Errorf("--network advisory-allowlist requires --session-isolation process")
+		}
+		if spec != nil {
+			return "", spec, fmt.Errorf("--network advisory-allowlist cannot be combined with a container runner")
+		}
+		return network, spec, nil
+	}
+	if spec != nil {
+		if network == sandbox.NetworkNone && spec.Network != container.NetworkNone {
+			cp := *spec
+			cp.Network = container.NetworkNone
+			spec = &cp
+		}
+		if spec.Network == container.NetworkNone {
+			network = sandbox.NetworkNone
+		}
+		return network, spec, nil
+	}
+	if network == sandbox.NetworkHost {
+		return network, spec, nil
+	}
+	if nativeMode {
+		return "", spec, fmt.Errorf("--network none requires --session-isolation process")
+	}
+	if err := sandbox.PreflightNetwork(network); err != nil {
+		return "", spec, err
+	}
+	return network, spec, nil
+}
+
+// suiteRunNetworkRequiredMissions lists the missions that declare requiresNetwork.
+func suiteRunNetworkRequiredMissions(parsed suite.ParsedSuite) map[string]bool {
+	out := map[string]bool{}
+	for _, m := range parsed.Suite.Missions {
+		if m.RequiresNetwork {
+			out[m.MissionID] = true
+		}
+	}
+	return out
+}
+
+// wrapSuiteRunNetworkRunner removes network access from a host process runner. Sandboxed runners
+// get it from their profile (--unshare-net) and containers from the engine's network=none.
+func wrapSuiteRunNetworkRunner(opts suiteRunExecOpts, prof *sandbox.Profile) suiteRunExecOpts {
+	if opts.Network != sandbox.NetworkNone || prof != nil || opts.Container != nil {
+		return opts
+	}
+	argv := sandbox.NetworkDenyArgv(append([]string{opts.RunnerCmd}, opts.RunnerArgs...))
+	opts.RunnerCmd, opts.RunnerArgs = argv[0], argv[1:]
+	return opts
+}
+
+// blockSuiteRunNetworkRequired fails an offline attempt of a requiresNetwork mission before the
+// runner starts, so the gate reports a typed ZCL_E_NETWORK_REQUIRED instead of a flaky tool failure.
+func blockSuiteRunNetworkRequired(r Runner, pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string, ar *suiteRunAttemptResult, errWriter io.Writer) (bool, bool) {
+	if opts.Network != sandbox.NetworkNone || !opts.NetworkRequired[pm.MissionID] {
+		return false, false
+	}
+	ar.RunnerErrorCode = codeNetworkRequired
+	msg := "mission " + pm.MissionID + " requires network but the suite runs with --network none"
+	envTrace := suiteRunTraceEnv(env, pm.OutDirAbs)
+	if err := trace.AppendCLIRunEvent(r.Now(), envTrace, []string{"zcl", "network-check"}, trace.ResultForTrace{
+		SpawnError: codeNetworkRequired,
+		ErrBytes:   int64(len(msg)),
+		ErrPreview: msg,
+	}); err != nil {
+		ar.RunnerErrorCode = codeIO
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+		return true, true
+	}
+	if err := feedback.Write(r.Now(), envTrace, feedback.WriteOpts{
+		OK:                   false,
+		Result:               "NETWORK_REQUIRED",
+		DecisionTags:         []string{schema.DecisionTagBlocked},
+		SkipSuiteResultShape: true,
+	}); err != nil {
+		ar.RunnerErrorCode = codeIO
+		fmt.Fprintf(errWriter, codeIO+": suite run: %s\n", err.Error())
+		return true, true
+	}
+	return true, false
+}
+
+func suiteRunAllowHosts(hosts []string) []string {
+	if len(hosts) == 0 {
+		return nil
+	}
+	return httpproxy.NormalizeAllowHosts(hosts)
+}
+
+// startSuiteRunEgressProxy starts the attempt's advisory egress proxy and points the runner's
+// proxy env at it. Violations are traced into the attempt's tool.calls.jsonl; the runner keeps
+// host networking, so only clients that honor the proxy env are confined or logged.
+func startSuiteRunEgressProxy(pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string) (func(), error) {
+	if opts.Network != sandbox.NetworkAdvisoryAllowlist {
+		return func() {}, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h, err := httpproxy.StartEgress(ctx, suiteRunTraceEnv(env, pm.OutDirAbs), opts.AllowHosts)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("start egress proxy: %w", err)
+	}
+	proxyURL := "http://" + h.ListenAddr
+	for _, k := range []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY", "http_proxy", "https_proxy", "all_proxy"} {
+		env[k] = proxyURL
+	}
+	// Loopback is not egress; everything else must go through the proxy.
+	env["NO_PROXY"] = "localhost,127.0.0.1,::1"
+	env["no_proxy"] = env["NO_PROXY"]
+	return func() {
+		_ = h.Close()
+		cancel()
+	}, nil
+}
+
+func suiteRunNetworkRuntime(opts suiteRunExecOpts) string {
+	if opts.Network == sandbox.NetworkHost {
+		return ""
+	}
+	return opts.Network
+}
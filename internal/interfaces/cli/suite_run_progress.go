@@ -3,13 +3,45 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// suiteRunProgressInterval spaces periodic run_progress events between attempt completions.
+const suiteRunProgressInterval = 30 * time.Second
+
+// Suite run progress event kinds (`--progress-jsonl`, schema.SuiteRunProgressSchemaV1). Adding a
+// kind or a details key is compatible; renaming or removing one bumps the schema version.
+const (
+	suiteRunProgressRunStarted         = "run_started"
+	suiteRunProgressAttemptStarted     = "attempt_started"
+	suiteRunProgressAttemptNativeState = "attempt_native_state"
+	suiteRunProgressAttemptFinished    = "attempt_finished"
+	suiteRunProgressRunProgress        = campaign.ProgressStatusRunProgress
+	suiteRunProgressRunFinished        = "run_finished"
 )
 
+var suiteRunProgressKinds = []string{
+	suiteRunProgressRunStarted,
+	suiteRunProgressAttemptStarted,
+	suiteRunProgressAttemptNativeState,
+	suiteRunProgressAttemptFinished,
+	suiteRunProgressRunProgress,
+	suiteRunProgressRunFinished,
+}
+
 type suiteRunProgressEvent struct {
+	SchemaVersion int `json:"schemaVersion"`
+	// V duplicates SchemaVersion for readers written before it was added.
 	V          int            `json:"v"`
 	TS         string         `json:"ts"`
 	Kind       string         `json:"kind"`
@@ -28,30 +60,58 @@ type suiteRunProgressEmitter struct {
 	mu     sync.Mutex
 	path   string
 	stderr io.Writer
+	// kinds limits the emitted events (`--progress-events`); nil emits every kind.
+	kinds map[string]bool
 }
 
-func newSuiteRunProgressEmitter(path string, stderr io.Writer) (*suiteRunProgressEmitter, error) {
+func newSuiteRunProgressEmitter(path string, kinds []string, stderr io.Writer) (*suiteRunProgressEmitter, error) {
 	path = filepath.Clean(path)
 	if path == "." || path == "" {
 		return nil, nil
 	}
+	var filter map[string]bool
+	if len(kinds) > 0 {
+		filter = map[string]bool{}
+		for _, k := range kinds {
+			filter[k] = true
+		}
+	}
 	if path == "-" {
-		return &suiteRunProgressEmitter{path: "-", stderr: stderr}, nil
+		return &suiteRunProgressEmitter{path: "-", stderr: stderr, kinds: filter}, nil
 	}
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return nil, err
 	}
-	return &suiteRunProgressEmitter{path: path, stderr: stderr}, nil
+	return &suiteRunProgressEmitter{path: path, stderr: stderr, kinds: filter}, nil
+}
+
+// parseSuiteRunProgressEvents parses a `--progress-events` list of event kinds.
+func parseSuiteRunProgressEvents(raw string) ([]string, error) {
+	var kinds []string
+	for _, k := range strings.Split(raw, ",") {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+		if !slices.Contains(suiteRunProgressKinds, k) {
+			return nil, fmt.Errorf("unknown progress event %q (expected %s)", k, strings.Join(suiteRunProgressKinds, "|"))
+		}
+		if !slices.Contains(kinds, k) {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds, nil
 }
 
 func (e *suiteRunProgressEmitter) Emit(ev suiteRunProgressEvent) error {
-	if e == nil {
+	if e == nil || (e.kinds != nil && !e.kinds[ev.Kind]) {
 		return nil
 	}
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	ev.V = 1
+	ev.SchemaVersion = schema.SuiteRunProgressSchemaV1
+	ev.V = ev.SchemaVersion
 	var buf bytes.Buffer
 	enc := json.NewEncoder(&buf)
 	enc.SetEscapeHTML(false)
@@ -80,3 +140,50 @@ func (e *suiteRunProgressEmitter) Close() error {
 	// No persistent handle is kept; close is present for call-site symmetry.
 	return nil
 }
+
+// suiteRunThroughput counts attempts for run_progress events. Rates use wall-clock time rather
+// than Runner.Now, which tests pin.
+type suiteRunThroughput struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	total     int
+	completed int
+	inFlight  int
+}
+
+func newSuiteRunThroughput(total int) *suiteRunThroughput {
+	return &suiteRunThroughput{startedAt: time.Now(), total: total}
+}
+
+func (t *suiteRunThroughput) attemptStarted() {
+	t.mu.Lock()
+	t.inFlight++
+	t.mu.Unlock()
+}
+
+func (t *suiteRunThroughput) attemptFinished() {
+	t.mu.Lock()
+	t.inFlight--
+	t.completed++
+	t.mu.Unlock()
+}
+
+func (t *suiteRunThroughput) snapshot() campaign.RunProgressV1 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return campaign.ComputeRunProgress(t.completed, t.inFlight, t.total, time.Since(t.startedAt))
+}
+
+func runProgressDetails(p campaign.RunProgressV1) map[string]any {
+	d := map[string]any{
+		"completed":      p.Completed,
+		"inFlight":       p.InFlight,
+		"total":          p.Total,
+		"elapsedMs":      p.ElapsedMs,
+		"attemptsPerMin": p.AttemptsPerMin,
+	}
+	if p.EtaMs != nil {
+		d["etaMs"] = *p.EtaMs
+	}
+	return d
+}
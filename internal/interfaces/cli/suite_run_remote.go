@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/home"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/remote"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/sandbox"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// remoteCleanupTimeout bounds the best-effort removal of an attempt's remote dirs.
+const remoteCleanupTimeout = 30 * time.Second
+
+// remoteLocalArtifacts are written by suite run itself while the runner executes remotely; the
+// stale copies pushed to the remote attempt dir are never pulled back over them.
+var remoteLocalArtifacts = map[string]bool{
+	"runner.stdout.log":             true,
+	"runner.stderr.log":             true,
+	"runner.command.txt":            true,
+	artifacts.AttemptRuntimeEnvJSON: true,
+	artifacts.DiskUsageJSON:         true,
+	artifacts.EnvFingerprintJSON:    true,
+}
+
+// resolveSuiteRunRemote reads the remote policy a runner.type=ssh flow exports to suite run. Host-side
+// confinement (sandbox, network enforcement, cgroup limits, ephemeral home) cannot follow the runner
+// to another machine, so combining them with a remote runner is rejected.
+func resolveSuiteRunRemote(extraAttemptEnv map[string]string, nativeMode bool, containerized bool, sandboxKind, network string, limited bool, homeMode string) (*remote.Spec, error) {
+	spec, ok, err := remote.FromEnv(extraAttemptEnv)
+	if err != nil || !ok {
+		return nil, err
+	}
+	switch {
+	case nativeMode:
+		return nil, fmt.Errorf("ssh runner (%s) requires --session-isolation process", remote.HostEnvKey)
+	case containerized:
+		return nil, fmt.Errorf("ssh runner cannot be combined with a container runner")
+	case sandboxKind != sandbox.KindNone:
+		return nil, fmt.Errorf("--sandbox %s cannot be combined with an ssh runner", sandboxKind)
+	case network != sandbox.NetworkHost:
+		return nil, fmt.Errorf("--network %s cannot be combined with an ssh runner", network)
+	case limited:
+		return nil, fmt.Errorf("runner limits cannot be combined with an ssh runner")
+	case homeMode == home.ModeEphemeral:
+		return nil, fmt.Errorf("--home ephemeral cannot be combined with an ssh runner")
+	}
+	return &spec, nil
+}
+
+// startSuiteRunRemote pushes the attempt dir (with the rewritten attempt env) to the remote host and
+// swaps the runner command for ssh running it there. The ssh client's stdout/stderr are the remote
+// runner's, so runner IO capture is unchanged. finish pulls the runner's artifacts back into the
+// local attempt dir, appends the trace events the remote zcl recorded, and removes the remote dirs.
+func startSuiteRunRemote(pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string) (suiteRunExecOpts, func() error, error) {
+	noop := func() error { return nil }
+	if opts.Remote == nil {
+		return opts, noop, nil
+	}
+	s := *opts.Remote
+	dirs := remote.AttemptDirs(s, env["ZCL_RUN_ID"], pm.AttemptID)
+	remoteEnv := remote.RemoteEnv(s, env, pm.OutDirAbs, strings.TrimSpace(env["ZCL_TMP_DIR"]), dirs)
+	tracePath := filepath.Join(pm.OutDirAbs, artifacts.ToolCallsJSONL)
+	var pushedTrace int64
+	if info, err := os.Stat(tracePath); err == nil {
+		pushedTrace = info.Size()
+	}
+	if err := remote.Push(context.Background(), s, pm.OutDirAbs, dirs, remoteEnv); err != nil {
+		return opts, noop, err
+	}
+	argv := remote.SSHArgv(s, remote.RunScript(dirs, append([]string{opts.RunnerCmd}, opts.RunnerArgs...)))
+	opts.RunnerCmd, opts.RunnerArgs = argv[0], argv[1:]
+	keep := remote.SyncMatcher(suiteRunRemoteEvidence(opts), s.Sync)
+	return opts, func() error {
+		_, err := remote.Pull(context.Background(), s, dirs, pm.OutDirAbs, func(rel string) bool {
+			return !remoteLocalArtifacts[rel] && keep(rel)
+		}, map[string]func(io.Reader) error{
+			artifacts.ToolCallsJSONL: mergeRemoteTrace(tracePath, pushedTrace),
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), remoteCleanupTimeout)
+		defer cancel()
+		_ = remote.Cleanup(ctx, s, dirs)
+		return err
+	}, nil
+}
+
+// mergeRemoteTrace appends the events past the pushed prefix of the remote tool.calls.jsonl to the
+// local trace, re-chained onto its current tail, instead of replacing the harness-owned file.
+func mergeRemoteTrace(tracePath string, pushed int64) func(io.Reader) error {
+	return func(r io.Reader) error {
+		if _, err := io.CopyN(io.Discard, r, pushed); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64*1024), 16<<20)
+		for sc.Scan() {
+			line := bytes.TrimSpace(sc.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			var ev schema.TraceEventV1
+			if err := json.Unmarshal(line, &ev); err != nil {
+				return err
+			}
+			if err := trace.AppendChained(tracePath, ev); err != nil {
+				return err
+			}
+		}
+		return sc.Err()
+	}
+}
+
+// suiteRunRemoteEvidence are the attempt artifacts always pulled back, whatever the sync rules.
+// The trace is merged rather than pulled (see mergeRemoteTrace).
+func suiteRunRemoteEvidence(opts suiteRunExecOpts) []string {
+	out := []string{
+		artifacts.FeedbackJSON,
+		artifacts.FeedbackHistoryJSONL,
+		artifacts.FeedbackProgressJSONL,
+		artifacts.NotesJSONL,
+		artifacts.CapturesJSONL,
+		artifacts.TraceSamplingJSON,
+		artifacts.ToolCassetteJSONL,
+		artifacts.RunnerMetricsJSON,
+	}
+	if opts.ResultChannel.Kind == campaign.ResultChannelFileJSON && opts.ResultChannel.Path != "" {
+		out = append(out, filepath.ToSlash(filepath.Clean(opts.ResultChannel.Path)))
+	}
+	return out
+}
+
+func suiteRunRemoteRuntime(opts suiteRunExecOpts) *schema.AttemptRemoteV1 {
+	if opts.Remote == nil {
+		return nil
+	}
+	return &schema.AttemptRemoteV1{
+		Host:    opts.Remote.Host,
+		Port:    opts.Remote.Port,
+		WorkDir: opts.Remote.WorkDir,
+		ZCLPath: opts.Remote.ZCLPath,
+		Sync:    append([]string(nil), opts.Remote.Sync...),
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/telemetry"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+type suiteRunRunnerStartedKey struct{}
+
+// withSuiteRunRunnerStarted makes runSuiteRunnerCore report the runner pid to fn once it started.
+func withSuiteRunRunnerStarted(ctx context.Context, fn func(pid int)) context.Context {
+	return context.WithValue(ctx, suiteRunRunnerStartedKey{}, fn)
+}
+
+func notifySuiteRunRunnerStarted(ctx context.Context, pid int) {
+	if fn, ok := ctx.Value(suiteRunRunnerStartedKey{}).(func(pid int)); ok {
+		fn(pid)
+	}
+}
+
+// startSuiteRunResourceSampler samples the runner's process tree (CPU, RSS, process/thread counts
+// and host load) while it runs. Container and ssh runners are skipped: the local process is only
+// the engine or ssh client. stop writes resources.jsonl when anything was sampled.
+func startSuiteRunResourceSampler(ctx context.Context, pm planner.PlannedMission, opts suiteRunExecOpts) (context.Context, func() error) {
+	if opts.Container != nil || opts.Remote != nil {
+		return ctx, func() error { return nil }
+	}
+	s := telemetry.NewSampler(telemetry.SampleInterval)
+	return withSuiteRunRunnerStarted(ctx, s.Start), func() error {
+		samples := s.Stop()
+		if len(samples) == 0 {
+			return nil
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		for _, sample := range samples {
+			if err := enc.Encode(sample); err != nil {
+				return err
+			}
+		}
+		return store.WriteFileAtomic(filepath.Join(pm.OutDirAbs, artifacts.ResourcesJSONL), buf.Bytes())
+	}
+}
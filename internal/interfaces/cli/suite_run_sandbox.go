@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/sandbox"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+// resolveSuiteRunSandbox validates --sandbox against the execution mode; sandboxes wrap host
+// process runners only (containers already isolate).
+func resolveSuiteRunSandbox(raw string, nativeMode bool, containerized bool) (string, error) {
+	kind, err := sandbox.ParseKind(raw)
+	if err != nil || kind == sandbox.KindNone {
+		return kind, err
+	}
+	if nativeMode {
+		return "", fmt.Errorf("--sandbox %s requires --session-isolation process", kind)
+	}
+	if containerized {
+		return "", fmt.Errorf("--sandbox %s cannot be combined with a container runner", kind)
+	}
+	if err := sandbox.Preflight(kind); err != nil {
+		return "", err
+	}
+	return kind, nil
+}
+
+func suiteRunSandboxProfile(pm planner.PlannedMission, opts suiteRunExecOpts, env map[string]string) *sandbox.Profile {
+	if opts.Sandbox != sandbox.KindBwrap {
+		return nil
+	}
+	cwd, _ := os.Getwd()
+	runner := opts.RunnerCmd
+	if !filepath.IsAbs(runner) {
+		if p, err := exec.LookPath(runner); err == nil {
+			runner, _ = filepath.Abs(p)
+		}
+	}
+	prof := sandbox.BwrapProfile(sandbox.ProfileParams{
+		AttemptDir:  pm.OutDirAbs,
+		TmpDir:      env["ZCL_TMP_DIR"],
+		Cwd:         cwd,
+		Home:        os.Getenv("HOME"),
+		Executables: []string{opts.ZCLExe, runner},
+	})
+	prof.NoNetwork = opts.Network == sandbox.NetworkNone
+	return &prof
+}
+
+// wrapSuiteRunSandboxRunner runs the attempt's runner command under the sandbox profile.
+func wrapSuiteRunSandboxRunner(opts suiteRunExecOpts, prof *sandbox.Profile) suiteRunExecOpts {
+	if prof == nil {
+		return opts
+	}
+	argv := prof.Argv(append([]string{opts.RunnerCmd}, opts.RunnerArgs...))
+	opts.RunnerCmd, opts.RunnerArgs = argv[0], argv[1:]
+	return opts
+}
+
+func suiteRunSandboxRuntime(prof *sandbox.Profile) *schema.AttemptSandboxV1 {
+	if prof == nil {
+		return nil
+	}
+	return &schema.AttemptSandboxV1{
+		Kind:     prof.Kind,
+		Repo:     prof.Repo,
+		ReadOnly: append([]string(nil), prof.ReadOnly...),
+		Writable: append([]string(nil), prof.Writable...),
+		Hidden:   append([]string(nil), prof.Hidden...),
+	}
+}
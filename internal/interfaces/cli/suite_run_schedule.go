@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"sort"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/planner"
+)
+
+const (
+	suiteRunScheduleSuite        = "suite"
+	suiteRunScheduleLongestFirst = "longest-first"
+
+	// suiteRunScheduleSamples caps how many recent passing attempts feed a mission's prediction.
+	suiteRunScheduleSamples = 5
+)
+
+type suiteRunSchedule struct {
+	Policy          string                     `json:"policy"`
+	PredictedWallMs int64                      `json:"predictedWallMs"`
+	Missions        []suiteRunScheduledMission `json:"missions"`
+}
+
+type suiteRunScheduledMission struct {
+	MissionID   string `json:"missionId"`
+	PredictedMs int64  `json:"predictedMs"`
+	Samples     int    `json:"samples"`
+}
+
+func isValidSuiteRunSchedule(s string) bool {
+	return s == suiteRunScheduleSuite || s == suiteRunScheduleLongestFirst
+}
+
+// planSuiteRunDispatch returns the order in which workers take missions. --schedule suite keeps
+// suite order; longest-first sorts by predicted duration and reports the plan for the summary.
+func planSuiteRunDispatch(plan suiteRunExecutionPlan) ([]int, *suiteRunSchedule) {
+	missions := plan.settings.missions
+	ids := make([]string, len(missions))
+	order := make([]int, len(missions))
+	for i, m := range missions {
+		ids[i] = m.MissionID
+		order[i] = i
+	}
+	if plan.input.schedule != suiteRunScheduleLongestFirst {
+		return order, nil
+	}
+	estimates := predictSuiteMissionDurations(plan.host.merged.OutRoot, plan.parsed.Suite.SuiteID)
+	order = planner.LongestFirst(ids, estimates)
+	durations := make([]time.Duration, len(ids))
+	out := &suiteRunSchedule{Policy: suiteRunScheduleLongestFirst, Missions: make([]suiteRunScheduledMission, 0, len(order))}
+	for _, idx := range order {
+		est := estimates[ids[idx]]
+		durations[idx] = est.Predicted
+		out.Missions = append(out.Missions, suiteRunScheduledMission{
+			MissionID:   ids[idx],
+			PredictedMs: est.Predicted.Milliseconds(),
+			Samples:     est.Samples,
+		})
+	}
+	workers := plan.input.parallel
+	if workers > len(order) {
+		workers = len(order)
+	}
+	out.PredictedWallMs = planner.PredictMakespan(order, durations, workers).Milliseconds()
+	return order, out
+}
+
+// predictSuiteMissionDurations takes the median duration of each mission's most recent passing
+// attempts across the out-root's runs of this suite. Failed attempts are ignored since they often
+// end early.
+func predictSuiteMissionDurations(outRoot string, suiteID string) map[string]planner.MissionEstimate {
+	rows, err := collectAttemptRows(attemptIndexFilter{SuiteID: suiteID, Status: attemptStatusOK, OutRoot: outRoot})
+	if err != nil {
+		return nil
+	}
+	samples := map[string][]time.Duration{}
+	for _, row := range rows { // newest first
+		if len(samples[row.MissionID]) >= suiteRunScheduleSamples {
+			continue
+		}
+		start, ok1 := parseTS(row.StartedAt)
+		end, ok2 := parseTS(row.EndedAt)
+		if !ok1 || !ok2 || !end.After(start) {
+			continue
+		}
+		samples[row.MissionID] = append(samples[row.MissionID], end.Sub(start))
+	}
+	out := make(map[string]planner.MissionEstimate, len(samples))
+	for id, ds := range samples {
+		sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+		out[id] = planner.MissionEstimate{Predicted: ds[len(ds)/2], Samples: len(ds)}
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSuiteWatchRunArgs(t *testing.T) {
+	args := []string{"--file", "s.yaml", "--watch", "--watch-debounce", "1s", "--mission=m1", "--total", "3", "--json", "--", "runner", "--watch"}
+	if got, want := suiteWatchRunArgs(args, nil), []string{"--file", "s.yaml", "--mission=m1", "--total", "3", "--json", "--", "runner", "--watch"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("initial args: got %q want %q", got, want)
+	}
+	if got, want := suiteWatchRunArgs(args, []string{"m2"}), []string{"--file", "s.yaml", "--json", "--mission", "m2", "--", "runner", "--watch"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("targeted args: got %q want %q", got, want)
+	}
+}
+
+func TestSuiteRun_WatchReRunsAffectedMissions(t *testing.T) {
+	outRoot := t.TempDir()
+	dir := t.TempDir()
+	suitePath := filepath.Join(dir, "suite.json")
+	promptPath := filepath.Join(dir, "m1.md")
+	suiteBody := func(m2OK bool) string {
+		ok := "true"
+		if !m2OK {
+			ok = "false"
+		}
+		return `{
+  "version": 1,
+  "suiteId": "suite-watch",
+  "missions": [
+    { "missionId": "m1", "promptFile": "m1.md", "expects": { "ok": true } },
+    { "missionId": "m2", "prompt": "p2", "expects": { "ok": ` + ok + ` } }
+  ]
+}`
+	}
+	writeSuiteFile(t, promptPath, "p1")
+	writeSuiteFile(t, suitePath, suiteBody(true))
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr syncBuffer
+	r := Runner{Version: "0.0.0-dev", Now: suiteRunNow, Stdout: &stdout, Stderr: &stderr}
+	args := []string{
+		"--file", suitePath, "--out-root", outRoot, "--watch", "--watch-debounce", "20ms", "--fail-fast=false", "--json",
+		"--", os.Args[0], "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok",
+	}
+	input, ok := r.parseSuiteRunCLIInput(args)
+	if !ok {
+		t.Fatalf("parse args")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int, 1)
+	go func() { done <- r.watchSuiteRun(ctx, args, input, nil, 10*time.Millisecond) }()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	waitEvent := func(n int) suiteWatchEventV1 {
+		t.Helper()
+		deadline := time.Now().Add(60 * time.Second)
+		for time.Now().Before(deadline) {
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+			if len(lines) >= n && lines[0] != "" {
+				var ev suiteWatchEventV1
+				if err := json.Unmarshal([]byte(lines[n-1]), &ev); err != nil {
+					t.Fatalf("decode event %d: %v (%q)", n, err, lines[n-1])
+				}
+				return ev
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("timed out waiting for watch event %d (stdout=%q stderr=%q)", n, stdout.String(), stderr.String())
+		return suiteWatchEventV1{}
+	}
+
+	first := waitEvent(1)
+	if first.Event != "run" || first.Passed != 2 || !reflect.DeepEqual(first.Missions, []string{"m1", "m2"}) || len(first.Changes) != 2 {
+		t.Fatalf("unexpected first run: %+v", first)
+	}
+
+	writeSuiteFile(t, promptPath, "p1 (edited)")
+	second := waitEvent(2)
+	if second.Event != "run" || !reflect.DeepEqual(second.Missions, []string{"m1"}) || second.Passed != 1 || len(second.Changes) != 0 || second.RunID == first.RunID {
+		t.Fatalf("unexpected prompt-file rerun: %+v", second)
+	}
+
+	writeSuiteFile(t, suitePath, suiteBody(false))
+	third := waitEvent(3)
+	if !reflect.DeepEqual(third.Missions, []string{"m2"}) || third.Failed != 1 || len(third.Changes) != 1 || third.Changes[0].Before != "ok" || !strings.HasPrefix(third.Changes[0].After, "fail") {
+		t.Fatalf("unexpected outcome diff: %+v", third)
+	}
+	if !strings.Contains(stderr.String(), "m2: ok -> fail") {
+		t.Fatalf("expected compact diff on stderr, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSync_AutoAfterCampaignRunAndExplicitResync(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	destRoot := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "sync-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-sync
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+	t.Setenv("ZCL_SYNC_DEST", "file://"+destRoot)
+	t.Setenv("ZCL_SYNC_AUTO", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+	if !strings.Contains(stderr.String(), "campaign run: synced") {
+		t.Fatalf("expected auto-sync note on stderr, got %q", stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(destRoot, "campaigns", "cmp-sync", "sync.manifest.json")); err != nil {
+		t.Fatalf("expected manifest at dest: %v", err)
+	}
+	runs, err := os.ReadDir(filepath.Join(destRoot, "runs"))
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("expected the flow run at dest, err=%v runs=%v", err, runs)
+	}
+
+	var res struct {
+		OK        bool `json:"ok"`
+		Uploaded  int  `json:"uploaded"`
+		Unchanged int  `json:"unchanged"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"sync", "--campaign-id", "cmp-sync", "--out-root", outRoot, "--json"}, &res, "sync")
+	if !res.OK || res.Unchanged == 0 {
+		t.Fatalf("expected re-sync to skip unchanged files, got %+v", res)
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"sync", "--campaign-id", "cmp-sync", "--out-root", outRoot, "--dest", "ftp://host/x"}, "sync bad dest")
+}
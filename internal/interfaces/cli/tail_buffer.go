@@ -1,12 +1,22 @@
 package cli
 
 import (
+	"os"
 	"sync"
 	"sync/atomic"
 )
 
+// tailBufferSpillBytes is how much a tail buffer keeps in memory before it moves to a temp file.
+// With a large --runner-io-max-bytes and many parallel attempts, the retained tails would
+// otherwise all stay resident.
+const tailBufferSpillBytes int64 = 1 << 20
+
 // tailBuffer keeps the last maxBytes written to it.
 // It always reports success to callers so pipes keep draining.
+//
+// Once more than spillAt bytes are retained, the window moves into a temp file used as a ring of
+// maxBytes; Snapshot reads it back in order, so callers see the same tail either way. Close
+// removes the temp file.
 type tailBuffer struct {
 	mu sync.Mutex
 
@@ -14,30 +24,50 @@ type tailBuffer struct {
 	buf       []byte
 	truncated bool
 
+	spillAt int64
+	spill   *os.File
+	// start/size locate the retained window inside the spill ring.
+	start int64
+	size  int64
+
 	seq uint64
 }
 
 func newTailBuffer(maxBytes int64) *tailBuffer {
+	return newSpillingTailBuffer(maxBytes, tailBufferSpillBytes)
+}
+
+// newSpillingTailBuffer spills to disk beyond spillAt retained bytes (<= 0 never spills).
+func newSpillingTailBuffer(maxBytes int64, spillAt int64) *tailBuffer {
 	if maxBytes < 0 {
 		maxBytes = 0
 	}
-	return &tailBuffer{maxBytes: maxBytes}
+	return &tailBuffer{maxBytes: maxBytes, spillAt: spillAt}
 }
 
 func (tb *tailBuffer) Write(p []byte) (int, error) {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
+	defer atomic.AddUint64(&tb.seq, 1)
 
 	if tb.maxBytes <= 0 {
 		tb.truncated = true
-		atomic.AddUint64(&tb.seq, 1)
 		return len(p), nil
 	}
+	if tb.spill == nil && tb.shouldSpill(int64(len(tb.buf))+int64(len(p))) {
+		tb.startSpill()
+	}
+	if tb.spill != nil {
+		if err := tb.writeRing(p); err == nil {
+			return len(p), nil
+		}
+		// The spill file failed; fall back to memory with whatever window it still holds.
+		tb.unspill()
+	}
 	if int64(len(p)) >= tb.maxBytes {
 		// Keep only the last maxBytes of p.
 		tb.buf = append(tb.buf[:0], p[int64(len(p))-tb.maxBytes:]...)
 		tb.truncated = true
-		atomic.AddUint64(&tb.seq, 1)
 		return len(p), nil
 	}
 	// Append and drop from the head if we exceed the max.
@@ -47,14 +77,115 @@ func (tb *tailBuffer) Write(p []byte) (int, error) {
 		tb.buf = append(tb.buf[:0], tb.buf[over:]...)
 		tb.truncated = true
 	}
-	atomic.AddUint64(&tb.seq, 1)
 	return len(p), nil
 }
 
+func (tb *tailBuffer) shouldSpill(retained int64) bool {
+	return tb.spillAt > 0 && tb.maxBytes > tb.spillAt && retained > tb.spillAt
+}
+
+// startSpill moves the in-memory window into a fresh ring file. On failure the buffer stays in
+// memory and spilling is disabled for its lifetime.
+func (tb *tailBuffer) startSpill() {
+	f, err := os.CreateTemp("", "zcl-runner-io-*")
+	if err != nil {
+		tb.spillAt = 0
+		return
+	}
+	if err := f.Truncate(tb.maxBytes); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		tb.spillAt = 0
+		return
+	}
+	tb.spill, tb.start, tb.size = f, 0, 0
+	held := tb.buf
+	tb.buf = nil
+	if err := tb.writeRing(held); err != nil {
+		tb.buf = held
+		tb.closeSpill()
+		tb.spillAt = 0
+	}
+}
+
+func (tb *tailBuffer) writeRing(p []byte) error {
+	if int64(len(p)) >= tb.maxBytes {
+		tb.truncated = true
+		p = p[int64(len(p))-tb.maxBytes:]
+		tb.start, tb.size = 0, 0
+	}
+	pos := (tb.start + tb.size) % tb.maxBytes
+	first := p
+	if pos+int64(len(p)) > tb.maxBytes {
+		first = p[:tb.maxBytes-pos]
+	}
+	if _, err := tb.spill.WriteAt(first, pos); err != nil {
+		return err
+	}
+	if rest := p[len(first):]; len(rest) > 0 {
+		if _, err := tb.spill.WriteAt(rest, 0); err != nil {
+			return err
+		}
+	}
+	tb.size += int64(len(p))
+	if tb.size > tb.maxBytes {
+		tb.start = (tb.start + tb.size - tb.maxBytes) % tb.maxBytes
+		tb.size = tb.maxBytes
+		tb.truncated = true
+	}
+	return nil
+}
+
+func (tb *tailBuffer) readRing() ([]byte, error) {
+	out := make([]byte, tb.size)
+	first := tb.size
+	if tb.start+first > tb.maxBytes {
+		first = tb.maxBytes - tb.start
+	}
+	if _, err := tb.spill.ReadAt(out[:first], tb.start); err != nil {
+		return nil, err
+	}
+	if first < tb.size {
+		if _, err := tb.spill.ReadAt(out[first:], 0); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (tb *tailBuffer) unspill() {
+	if b, err := tb.readRing(); err == nil {
+		tb.buf = b
+	} else {
+		tb.buf = nil
+		tb.truncated = true
+	}
+	tb.closeSpill()
+	tb.spillAt = 0
+}
+
+func (tb *tailBuffer) closeSpill() {
+	if tb.spill == nil {
+		return
+	}
+	_ = tb.spill.Close()
+	_ = os.Remove(tb.spill.Name())
+	tb.spill, tb.start, tb.size = nil, 0, 0
+}
+
 func (tb *tailBuffer) Snapshot() (b []byte, truncated bool, seq uint64) {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
 
+	if tb.spill != nil {
+		if out, err := tb.readRing(); err == nil {
+			if len(out) == 0 {
+				out = nil
+			}
+			return out, tb.truncated, atomic.LoadUint64(&tb.seq)
+		}
+		tb.unspill()
+	}
 	if len(tb.buf) == 0 {
 		return nil, tb.truncated, atomic.LoadUint64(&tb.seq)
 	}
@@ -64,3 +195,14 @@ func (tb *tailBuffer) Snapshot() (b []byte, truncated bool, seq uint64) {
 }
 
 func (tb *tailBuffer) Seq() uint64 { return atomic.LoadUint64(&tb.seq) }
+
+// Close drops the spill file; the buffer is empty afterwards. Safe on nil and repeated calls.
+func (tb *tailBuffer) Close() {
+	if tb == nil {
+		return
+	}
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.closeSpill()
+	tb.buf = nil
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestTailBuffer_SpillKeepsSameTailAsMemory(t *testing.T) {
+	mem := newSpillingTailBuffer(64, 0)
+	spill := newSpillingTailBuffer(64, 16)
+	defer spill.Close()
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		p := make([]byte, rng.Intn(90))
+		for j := range p {
+			p[j] = byte('a' + rng.Intn(26))
+		}
+		_, _ = mem.Write(p)
+		_, _ = spill.Write(p)
+
+		wantB, wantTrunc, _ := mem.Snapshot()
+		gotB, gotTrunc, _ := spill.Snapshot()
+		if !bytes.Equal(gotB, wantB) || gotTrunc != wantTrunc {
+			t.Fatalf("write %d (%d bytes): spill=%q/%v memory=%q/%v", i, len(p), gotB, gotTrunc, wantB, wantTrunc)
+		}
+	}
+	if spill.spill == nil || len(spill.buf) != 0 {
+		t.Fatalf("expected the window to live in the spill file")
+	}
+	name := spill.spill.Name()
+	spill.Close()
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Fatalf("expected spill file removed, stat err=%v", err)
+	}
+}
+
+func TestTailBuffer_StaysInMemoryBelowSpillThreshold(t *testing.T) {
+	tb := newSpillingTailBuffer(64, 32)
+	defer tb.Close()
+	_, _ = tb.Write([]byte("hello"))
+	if tb.spill != nil {
+		t.Fatalf("unexpected spill below threshold")
+	}
+	b, truncated, _ := tb.Snapshot()
+	if string(b) != "hello" || truncated {
+		t.Fatalf("unexpected snapshot %q truncated=%v", b, truncated)
+	}
+}
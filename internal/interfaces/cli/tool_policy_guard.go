@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/contexts/evidence/app/trace"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+)
+
+// loadToolPolicyFromEnv returns the flow tool policy exported by campaign runs (if any).
+// An unparsable policy is an error: silently running unguarded would defeat the point.
+func loadToolPolicyFromEnv() (campaign.ToolPolicySpec, bool, error) {
+	raw := strings.TrimSpace(os.Getenv(campaign.ToolPolicyEnvKey))
+	if raw == "" {
+		return campaign.ToolPolicySpec{}, false, nil
+	}
+	policy, err := campaign.ParseToolPolicyJSON([]byte(raw))
+	if err != nil {
+		return campaign.ToolPolicySpec{}, false, fmt.Errorf("invalid %s: %w", campaign.ToolPolicyEnvKey, err)
+	}
+	return policy, campaign.HasToolPolicyRules(policy), nil
+}
+
+func (r Runner) applyToolPolicyGuard(env trace.Env, argv []string) (int, bool) {
+	policy, ok, err := loadToolPolicyFromEnv()
+	if err != nil {
+		fmt.Fprintf(r.Stderr, codeUsage+": %s\n", err.Error())
+		return 2, true
+	}
+	if !ok || len(argv) == 0 {
+		return 0, false
+	}
+	// Match on argv[0] exactly like the post-hoc gate so both layers agree.
+	target := strings.TrimSpace(argv[0])
+	if campaign.ToolPolicyPermits(policy, "cli", target) {
+		return 0, false
+	}
+	msg := fmt.Sprintf("tool policy: refusing cli command %q", target)
+	traceRes := trace.ResultForTrace{
+		SpawnError: codeToolPolicyDenied,
+		ErrBytes:   int64(len(msg)),
+		ErrPreview: msg,
+	}
+	if err := trace.AppendCLIRunEvent(r.Now(), env, argv, traceRes); err != nil {
+		fmt.Fprintf(r.Stderr, codeIO+": failed to append tool.calls.jsonl: %s\n", err.Error())
+		return 1, true
+	}
+	fmt.Fprintf(r.Stderr, codeToolPolicyDenied+": %s\n", msg)
+	return 1, true
+}
+
+// mcpToolPolicyPredicate adapts the env policy to the MCP proxy's per-call hook.
+func mcpToolPolicyPredicate() (func(toolName string) bool, error) {
+	policy, ok, err := loadToolPolicyFromEnv()
+	if err != nil || !ok {
+		return nil, err
+	}
+	return func(toolName string) bool {
+		return campaign.ToolPolicyPermits(policy, "mcp", toolName)
+	}, nil
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTop_OnceAndJSONOnEmptyOutRoot(t *testing.T) {
+	outRoot := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"top", "--once", "--out-root", outRoot}, "top --once")
+	if !strings.Contains(stdout.String(), "ATTEMPTS IN FLIGHT") || strings.Contains(stdout.String(), topClearScreen) {
+		t.Fatalf("unexpected frame: %q", stdout.String())
+	}
+	var snap struct {
+		SchemaVersion int   `json:"schemaVersion"`
+		Runs          []any `json:"runs"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"top", "--json", "--out-root", outRoot}, &snap, "top --json")
+	if snap.SchemaVersion != 1 || snap.Runs == nil || len(snap.Runs) != 0 {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+}
@@ -156,3 +156,60 @@ func TestValidate_JSONStableOnFailureAndExitCodes(t *testing.T) {
 		}
 	}
 }
+
+func TestValidate_RollsForwardFinishCommittedBeforeCrash(t *testing.T) {
+	outRoot := t.TempDir()
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) },
+	}
+	start := startAttemptForQuery(t, r, outRoot, "", "finish-crash-suite", "m1")
+	runAndFeedbackForQuery(t, r, start.Env, true)
+	attemptDir := start.Env["ZCL_OUT_DIR"]
+	setAttemptEnvForQuery(t, nil)
+
+	var stdout, stderr bytes.Buffer
+	r.Stdout, r.Stderr = &stdout, &stderr
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"attempt", "finish", "--json", attemptDir}, "attempt finish")
+
+	// Put the finish back at its commit point, as if the process died before publishing it.
+	commit := filepath.Join(attemptDir, ".finish.commit")
+	crash := func() {
+		t.Helper()
+		mustMkdirAll(t, commit)
+		for _, name := range []string{"attempt.report.json", "attempt.finish.json"} {
+			if err := os.Rename(filepath.Join(attemptDir, name), filepath.Join(commit, name)); err != nil {
+				t.Fatalf("rename %s: %v", name, err)
+			}
+		}
+	}
+	published := func(label string) {
+		t.Helper()
+		for _, name := range []string{"attempt.report.json", "attempt.finish.json"} {
+			if _, err := os.Stat(filepath.Join(attemptDir, name)); err != nil {
+				t.Fatalf("%s: expected %s rolled forward: %v", label, name, err)
+			}
+		}
+		if _, err := os.Stat(commit); !os.IsNotExist(err) {
+			t.Fatalf("%s: expected commit dir removed, got %v", label, err)
+		}
+	}
+
+	crash()
+	var res validate.Result
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"validate", "--strict", "--json", attemptDir}, &res, "validate")
+	if !res.OK {
+		t.Fatalf("expected rolled-forward attempt to validate: %s", stdout.String())
+	}
+	published("validate")
+
+	crash()
+	rep, err := readAttemptReport(attemptDir)
+	if err != nil {
+		t.Fatalf("campaign gate read: %v", err)
+	}
+	if rep.AttemptID != start.Env["ZCL_ATTEMPT_ID"] {
+		t.Fatalf("unexpected report read by the gate: %+v", rep)
+	}
+	published("campaign gate")
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerdictOverride_HonoredByReportsAndResults(t *testing.T) {
+	outRoot := t.TempDir()
+	specDir := t.TempDir()
+	writeSuiteFile(t, filepath.Join(specDir, "suite.json"), `{
+  "version": 1,
+  "suiteId": "verdict-suite",
+  "missions": [
+    { "missionId": "m1", "prompt": "p1", "expects": { "ok": true } }
+  ]
+}`)
+	specPath := filepath.Join(specDir, "campaign.yaml")
+	mustWriteFile(t, specPath, strings.TrimSpace(fmt.Sprintf(`
+schemaVersion: 1
+campaignId: cmp-verdict
+outRoot: %q
+totalMissions: 1
+semantic:
+  enabled: false
+flows:
+  - flowId: flow-a
+    suiteFile: suite.json
+    runner:
+      type: process_cmd
+      command: ["`+os.Args[0]+`", "-test.run=TestHelperSuiteRunnerProcess$", "--", "case=ok"]
+`, outRoot))+"\n")
+	t.Setenv("ZCL_WANT_SUITE_RUNNER", "1")
+
+	var stdout, stderr bytes.Buffer
+	r := Runner{
+		Version: "0.0.0-dev",
+		Now:     func() time.Time { return time.Date(2026, 2, 22, 12, 0, 0, 0, time.UTC) },
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	runCLICommand(t, &r, &stdout, &stderr, 0, []string{"campaign", "run", "--spec", specPath, "--out-root", outRoot, "--json"}, "campaign run")
+
+	var next struct {
+		Next struct {
+			AttemptDir string `json:"attemptDir"`
+		} `json:"next"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"review", "next", "--campaign-id", "cmp-verdict", "--out-root", outRoot, "--all", "--json"}, &next, "review next --all")
+	attemptDir := next.Next.AttemptDir
+	if attemptDir == "" {
+		t.Fatalf("expected a gated attempt")
+	}
+
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"verdict", "override", "--attempt", attemptDir, "--reason", "x", "--by", "alice"}, "verdict override without --ok")
+	runCLICommand(t, &r, &stdout, &stderr, 2, []string{"verdict", "override", "--attempt", attemptDir, "--ok=false", "--by", "alice"}, "verdict override without --reason")
+
+	var ov struct {
+		OK         bool   `json:"ok"`
+		OriginalOK *bool  `json:"originalOk"`
+		By         string `json:"by"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"verdict", "override", "--attempt", attemptDir, "--ok=false", "--reason", "answer cites the wrong source", "--by", "alice", "--json"}, &ov, "verdict override")
+	if ov.OK || ov.By != "alice" || ov.OriginalOK == nil || !*ov.OriginalOK {
+		t.Fatalf("unexpected override payload: %+v", ov)
+	}
+
+	var report struct {
+		GatesPassed int `json:"gatesPassed"`
+		GatesFailed int `json:"gatesFailed"`
+		Overrides   []struct {
+			GateOK bool   `json:"gateOk"`
+			OK     bool   `json:"ok"`
+			Reason string `json:"reason"`
+		} `json:"overrides"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"campaign", "report", "--campaign-id", "cmp-verdict", "--out-root", outRoot, "--allow-invalid", "--json"}, &report, "campaign report")
+	if report.GatesPassed != 0 || report.GatesFailed != 1 || len(report.Overrides) != 1 || !report.Overrides[0].GateOK || report.Overrides[0].OK {
+		t.Fatalf("expected override to fail the gate, got %+v", report)
+	}
+	md, err := os.ReadFile(filepath.Join(outRoot, "campaigns", "cmp-verdict", "RESULTS.md"))
+	if err != nil {
+		t.Fatalf("read RESULTS.md: %v", err)
+	}
+	for _, want := range []string{"## Verdict Overrides", "by=alice", "reason: answer cites the wrong source", "verdictOverrides: `1`"} {
+		if !strings.Contains(string(md), want) {
+			t.Fatalf("expected %q in RESULTS.md, got:\n%s", want, md)
+		}
+	}
+
+	var attemptReport struct {
+		OK              *bool `json:"ok"`
+		VerdictOverride *struct {
+			By string `json:"by"`
+		} `json:"verdictOverride"`
+	}
+	runCLICommandJSON(t, &r, &stdout, &stderr, 0, []string{"report", "--json", attemptDir}, &attemptReport, "attempt report")
+	if attemptReport.OK == nil || *attemptReport.OK || attemptReport.VerdictOverride == nil || attemptReport.VerdictOverride.By != "alice" {
+		t.Fatalf("expected attempt report to honor override, got %+v", attemptReport)
+	}
+}
@@ -2,10 +2,15 @@ package contract
 
 import (
 	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/campaign"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/container"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/home"
+	"github.com/marcohefti/zero-context-lab/internal/contexts/execution/app/remote"
 	"github.com/marcohefti/zero-context-lab/internal/contexts/runtime/ports/native"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/blind"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/codes"
 	"github.com/marcohefti/zero-context-lab/internal/kernel/runnerid"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
 )
 
 type Contract struct {
@@ -19,6 +24,15 @@ type Contract struct {
 	Errors                []Error        `json:"errors"`
 	CampaignSchema        CampaignSchema `json:"campaignSchema,omitempty"`
 	RuntimeSchema         RuntimeSchema  `json:"runtimeSchema,omitempty"`
+	DecisionTags          DecisionTags   `json:"decisionTags"`
+	// BlindTermPacks are the built-in packs selectable via --blind-terms-pack (name -> terms).
+	BlindTermPacks map[string][]string `json:"blindTermPacks"`
+}
+
+// DecisionTags is the built-in decision tag taxonomy; suites may declare more (suite decisionTags).
+type DecisionTags struct {
+	TaxonomyVersion int                       `json:"taxonomyVersion"`
+	Tags            []schema.DecisionTagDefV1 `json:"tags"`
 }
 
 type Artifact struct {
@@ -150,6 +164,14 @@ func Build(version string) Contract {
 				PathPattern:    ".zcl/runs/<runId>/" + artifacts.RunReportJSON,
 				RequiredFields: []string{"schemaVersion", "target", "runId", "suiteId", "path", "attempts", "aggregate"},
 			},
+			{
+				ID:             artifacts.RedactionVerifyJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/" + artifacts.RedactionVerifyJSON,
+				RequiredFields: []string{"schemaVersion", "runId", "ok", "ruleset", "filesScanned", "hits", "verifiedAt"},
+			},
 			{
 				ID:             artifacts.CampaignStateJSON,
 				Kind:           "json",
@@ -230,6 +252,22 @@ func Build(version string) Contract {
 				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.PromptTXT,
 				RequiredFields: []string{},
 			},
+			{
+				ID:             artifacts.PromptOriginalTXT,
+				Kind:           "text",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.PromptOriginalTXT,
+				RequiredFields: []string{},
+			},
+			{
+				ID:             artifacts.PromptContaminationJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.PromptContaminationJSON,
+				RequiredFields: []string{"schemaVersion", "runId", "attemptId", "action", "terms", "replacements", "originalSha256", "sanitizedSha256", "createdAt"},
+			},
 			{
 				ID:             artifacts.AttemptEnvSH,
 				Kind:           "text",
@@ -272,6 +310,62 @@ func Build(version string) Contract {
 				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.NotesJSONL,
 				RequiredFields: []string{},
 			},
+			{
+				ID:             artifacts.TraceSamplingJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.TraceSamplingJSON,
+				RequiredFields: []string{"schemaVersion", "rules"},
+			},
+			{
+				ID:             artifacts.DiskUsageJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.DiskUsageJSON,
+				RequiredFields: []string{"schemaVersion", "attemptDirBytes", "totalBytes", "peakBytes"},
+			},
+			{
+				ID:             artifacts.EnvFingerprintJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.EnvFingerprintJSON,
+				RequiredFields: []string{"schemaVersion", "hash", "host", "os", "arch"},
+			},
+			{
+				ID:             artifacts.ResourcesJSONL,
+				Kind:           "jsonl",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.ResourcesJSONL,
+				RequiredFields: []string{"v", "ts", "elapsedMs", "cpuPercent", "cpuSeconds", "rssBytes", "procs"},
+			},
+			{
+				ID:             artifacts.FeedbackHistoryJSONL,
+				Kind:           "jsonl",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.FeedbackHistoryJSONL,
+				RequiredFields: []string{"v", "revision", "op", "feedback"},
+			},
+			{
+				ID:             artifacts.PurgeJSONL,
+				Kind:           "jsonl",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.PurgeJSONL,
+				RequiredFields: []string{"v", "runId", "attemptId", "reason", "patternSha256", "replacement", "files", "matches", "purgedAt"},
+			},
+			{
+				ID:             artifacts.FeedbackProgressJSONL,
+				Kind:           "jsonl",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.FeedbackProgressJSONL,
+				RequiredFields: []string{"v", "ts", "runId", "missionId", "attemptId", "seq", "milestone"},
+			},
 			{
 				ID:             artifacts.CapturesJSONL,
 				Kind:           "jsonl",
@@ -296,6 +390,22 @@ func Build(version string) Contract {
 				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.OracleVerdictJSON,
 				RequiredFields: []string{"schemaVersion", "campaignId", "flowId", "missionId", "attemptId", "attemptDir", "oraclePath", "evaluatorKind", "evaluatorCommand", "promptMode", "ok", "executedAt"},
 			},
+			{
+				ID:             artifacts.ClaimVerifiedJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.ClaimVerifiedJSON,
+				RequiredFields: []string{"schemaVersion", "campaignId", "flowId", "missionId", "attemptId", "ok", "counts", "claims"},
+			},
+			{
+				ID:             artifacts.SemanticRulesJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.SemanticRulesJSON,
+				RequiredFields: []string{"schemaVersion"},
+			},
 			{
 				ID:             artifacts.RunnerRefJSON,
 				Kind:           "json",
@@ -312,6 +422,22 @@ func Build(version string) Contract {
 				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.RunnerMetricsJSON,
 				RequiredFields: []string{"schemaVersion", "runner"},
 			},
+			{
+				ID:             artifacts.AttemptHeartbeatJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.AttemptHeartbeatJSON,
+				RequiredFields: []string{"schemaVersion", "pid", "intervalMs", "startedAt", "beatAt"},
+			},
+			{
+				ID:             artifacts.AttemptFinishJSON,
+				Kind:           "json",
+				SchemaVersions: []int{1},
+				Required:       false,
+				PathPattern:    ".zcl/runs/<runId>/attempts/<attemptId>/" + artifacts.AttemptFinishJSON,
+				RequiredFields: []string{"schemaVersion", "finishedAt", "ok", "validate", "expect"},
+			},
 		},
 		Events: []Event{
 			{
@@ -336,6 +462,31 @@ func Build(version string) Contract {
 				Usage:   "zcl init [--out-root .zcl] [--config zcl.config.json] [--json]",
 				Summary: "Initialize the project output root and write the minimal project config.",
 			},
+			{
+				ID:      "init suite",
+				Usage:   "zcl init suite [--adapter process_cmd|codex_exec|codex_subagent|claude_subagent|codex_app_server] [--suite-id <id>] [--mission <id>]... [--out suite.json] [--force] [--json]",
+				Summary: "Scaffold a valid suite file whose defaults match the chosen adapter; prompts for missing values on a terminal.",
+			},
+			{
+				ID:      "init campaign",
+				Usage:   "zcl init campaign [--adapter <type>] [--campaign-id <id>] [--suite suite.json] [--out campaign.yaml] [--force] [--json]",
+				Summary: "Scaffold a lint-clean campaign spec with runner, finalization, and result-channel blocks pre-wired for the adapter (and its suite when missing).",
+			},
+			{
+				ID:      "config get",
+				Usage:   "zcl config get [<key>] [--out-root .zcl] [--json]",
+				Summary: "Print one merged config value (or all known keys) with its source and origin (default|flag|env|profile|file).",
+			},
+			{
+				ID:      "config set",
+				Usage:   "zcl config set <key> <value> [--global] [--json]",
+				Summary: "Set a config key in zcl.config.json (or the global config), keeping other fields; rejects values that would fail lint.",
+			},
+			{
+				ID:      "config lint",
+				Usage:   "zcl config lint [--json]",
+				Summary: "Validate project and global config files plus the merged view; unknown keys warn, invalid values fail (exitCodes values must be 1..255).",
+			},
 			{
 				ID:      "update status",
 				Usage:   "zcl update status [--cached] [--json]",
@@ -343,8 +494,13 @@ func Build(version string) Contract {
 			},
 			{
 				ID:      "feedback",
-				Usage:   "zcl feedback --ok|--fail --result <string>|--result-json <json> [--classification <...>] [--decision-tag <tag>] [--decision-tags <csv>]",
-				Summary: "Write the canonical attempt outcome to feedback.json (primary evidence).",
+				Usage:   "zcl feedback --ok|--fail --result <string>|--result-json <json> [--classification <...>] [--decision-tag <tag>] [--decision-tags <csv>] [--confidence <0..1>] [--append-tag <tag>] [--merge-json <json>]",
+				Summary: "Write the canonical attempt outcome to feedback.json (primary evidence); --append-tag/--merge-json amend it until the attempt is finalized.",
+			},
+			{
+				ID:      "feedback progress",
+				Usage:   "zcl feedback progress --milestone <text> [--data-json <json>]",
+				Summary: "Append a milestone checkpoint to feedback.progress.jsonl so partially completed missions stay analyzable.",
 			},
 			{
 				ID:      "note",
@@ -358,18 +514,23 @@ func Build(version string) Contract {
 			},
 			{
 				ID:      "validate",
-				Usage:   "zcl validate [--strict] [--semantic] [--semantic-rules <path>] [--json] <attemptDir|runDir>",
-				Summary: "Validate artifact integrity and optional semantic mission validity with typed error codes.",
+				Usage:   "zcl validate [--strict] [--semantic] [--semantic-rules <path>] [--consistency] [--json] <attemptDir|runDir>",
+				Summary: "Validate artifact integrity, optional semantic mission validity, and optional cross-attempt run consistency with typed error codes.",
+			},
+			{
+				ID:      "validate file",
+				Usage:   "zcl validate file --kind attempt.report|feedback|suite|campaign [--json] <path>",
+				Summary: "Validate one standalone artifact or input file against the embedded schema for its kind (see zcl schema export).",
 			},
 			{
 				ID:      "doctor",
 				Usage:   "zcl doctor [--out-root .zcl] [--json]",
-				Summary: "Check environment/config sanity (write access, config parse, optional runner availability).",
+				Summary: "Check environment/config sanity: out-root write access, config lint, free disk space, clock skew, required binaries and native runtime availability.",
 			},
 			{
 				ID:      "gc",
-				Usage:   "zcl gc [--out-root .zcl] [--max-age-days 30] [--max-total-bytes 0] [--dry-run] [--json]",
-				Summary: "Retention cleanup under .zcl/runs (age/size; respects pinned runs).",
+				Usage:   "zcl gc [--out-root .zcl] [--max-age-days 30] [--keep-runs N] [--keep-failed all|none|N] [--max-total-bytes 0] [--dry-run] [--json]",
+				Summary: "Retention cleanup under .zcl/runs (age/count/size, defaults from config retention); never deletes pinned runs, runs of published campaigns, or failed runs kept by keepFailed.",
 			},
 			{
 				ID:      "pin",
@@ -384,7 +545,7 @@ func Build(version string) Contract {
 			{
 				ID:      "mcp proxy",
 				Usage:   "zcl mcp proxy [--max-tool-calls N] [--idle-timeout-ms N] [--shutdown-on-complete] [--sequential] -- <server-cmd> [args...]",
-				Summary: "MCP stdio proxy funnel with lifecycle controls (records initialize/tools/list/tools/call).",
+				Summary: "MCP stdio proxy funnel with lifecycle controls (records initialize/tools/list/tools/call; ZCL_VCR_MODE records or replays tools/call responses).",
 			},
 			{
 				ID:      "http proxy",
@@ -394,7 +555,7 @@ func Build(version string) Contract {
 			{
 				ID:      "run",
 				Usage:   "zcl run [--capture [--capture-raw] --capture-max-bytes N] -- <cmd> [args...]",
-				Summary: "Run a command through the ZCL CLI funnel (default passthrough; bounded trace capture; optional full capture + JSON envelope).",
+				Summary: "Run a command through the ZCL CLI funnel (default passthrough; bounded trace capture; optional full capture + JSON envelope; ZCL_VCR_MODE records or replays responses).",
 			},
 			{
 				ID:      "contract",
@@ -403,7 +564,7 @@ func Build(version string) Contract {
 			},
 			{
 				ID:      "attempt start",
-				Usage:   "zcl attempt start --suite <suiteId> --mission <missionId> [--prompt <text>] [--suite-file <path>] [--run-id <runId>] [--agent-id <id>] [--isolation-model process_runner|native_spawn] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--blind] [--blind-terms <csv>] [--out-root .zcl] [--retry 1] [--env-file <path>] [--env-format sh|dotenv] [--print-env sh|dotenv] --json",
+				Usage:   "zcl attempt start --suite <suiteId> --mission <missionId> [--prompt <text>] [--suite-file <path>] [--run-id <runId>] [--agent-id <id>] [--isolation-model process_runner|native_spawn] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--blind] [--blind-terms <csv>] [--blind-terms-pack <name>] [--out-root .zcl] [--retry 1] [--env-file <path>] [--env-format sh|dotenv] [--print-env sh|dotenv] --json",
 				Summary: "Allocate a run/attempt directory and print canonical IDs + env for a fresh session attempt.",
 			},
 			{
@@ -421,6 +582,21 @@ func Build(version string) Contract {
 				Usage:   "zcl attempt explain [--strict] [--json] [--tail N] [<attemptDir>]",
 				Summary: "Fast post-mortem view: show ids/outcome, validate/expect status, and a tail of tool.calls.jsonl (uses ZCL_OUT_DIR when <attemptDir> is omitted).",
 			},
+			{
+				ID:      "attempt inspect",
+				Usage:   "zcl attempt inspect [--strict] [--tail N] [--out-root .zcl] [--json] [<attemptDir|attemptId>]",
+				Summary: "Merge attempt.json, the attempt report, validate/expect results, feedback.json, runner.ref.json and key trace stats into one view; an attemptId is resolved under <outRoot>/runs/*/attempts/.",
+			},
+			{
+				ID:      "attempt replay",
+				Usage:   "zcl attempt replay [--strict] [--strict-expect] [--feed] [--feed-timeout 10s] [--json] <attemptDir>",
+				Summary: "Re-run report/validate/expect without writing to the attempt dir and diff the verdict against attempt.finish.json; --feed re-feeds recorded tool results as a new turn via the provider_stub runtime.",
+			},
+			{
+				ID:      "attempt export",
+				Usage:   "zcl attempt export --out <attempt.tar.zst|attempt.tar.gz|attempt.tar> [--json] [<attemptDir>]",
+				Summary: "Bundle the attempt dir, its run.json/suite.json and an attempt.bundle.manifest.json (sha256 per entry) into one archive; .tar.zst uses the zstd CLI.",
+			},
 			{
 				ID:      "attempt list",
 				Usage:   "zcl attempt list [--out-root .zcl] [--suite <suiteId>] [--mission <missionId>] [--status any|ok|fail|missing_feedback] [--tag <tag>] [--limit N] --json",
@@ -433,18 +609,58 @@ func Build(version string) Contract {
 			},
 			{
 				ID:      "runs list",
-				Usage:   "zcl runs list [--out-root .zcl] [--suite <suiteId>] [--status any|ok|fail|missing_feedback] [--limit N] --json",
-				Summary: "List run-level machine-readable index rows with aggregate attempt status counts.",
+				Usage:   "zcl runs list [--out-root .zcl] [--suite <suiteId>] [--status any|ok|fail|missing_feedback] [--limit N] [--stale-after-ms N] --json",
+				Summary: "List run-level machine-readable index rows with aggregate attempt status counts and unfinished attempts whose heartbeat went stale (likely hung).",
+			},
+			{
+				ID:      "runs compact",
+				Usage:   "zcl runs compact --run-id <runId> [--out-root .zcl] [--json]",
+				Summary: "Gzip runner logs, traces and captures of a finished run, drop raw captures whose redacted output the trace already holds, and rewrite captures.jsonl/run.json.",
+			},
+			{
+				ID:      "top",
+				Usage:   "zcl top [--out-root .zcl] [--interval 2s] [--once] [--failures N] [--progress <path>]... [--json]",
+				Summary: "Live terminal view of active runs, attempts in flight, running campaigns, per-strategy native health/rate-limit waits and recent failures, fed by progress JSONL and campaign state.",
+			},
+			{
+				ID:      "serve",
+				Usage:   "zcl serve [--addr 127.0.0.1:8080] [--out-root .zcl] [--json]",
+				Summary: "Serve read-only JSON endpoints for runs, attempts, reports, campaigns and the live top snapshot, an SSE stream of run progress events, plus an embedded web dashboard, until SIGINT/SIGTERM.",
+			},
+			{
+				ID:      "bench harness",
+				Usage:   "zcl bench harness [--attempts 100] [--out-root <dir>] [--keep] [--json] [-- <noop-cmd> [args...]]",
+				Summary: "Run no-op attempts through the full pipeline and report per-stage harness overhead (attempt start, spawn, trace append, feedback, finish) as mean/p50/p95/max.",
+			},
+			{
+				ID:      "archive",
+				Usage:   "zcl archive --older-than 14d [--compression zstd|gzip] [--out-root .zcl] [--dry-run] [--json]",
+				Summary: "Move completed, unpinned runs older than the cutoff into per-run .tar.zst archives under archive/ and record them in archive.index.json; runs list keeps listing them.",
+			},
+			{
+				ID:      "archive restore",
+				Usage:   "zcl archive restore --run-id <runId> [--out-root .zcl] [--json]",
+				Summary: "Verify an archived run's sha256 against archive.index.json and extract it back into runs/<runId>.",
+			},
+			{
+				ID:      "suite lint",
+				Usage:   "zcl suite lint --file <suite.(yaml|yml|json)> [--json]",
+				Summary: "Validate a suite file and list the composed missions (include: suites and mission packs inlined, matrix: missions expanded) with their source file and matrix values.",
+			},
+			{
+				ID:      "suite import",
+				Usage:   "zcl suite import --format openai-evals|inspect-ai|custom-jsonl [--suite-id <id>] [--match includes|exact] [--limit N] [--id-field id] [--prompt-field prompt] [--answer-field answer] [--tags-field tags] [--out suite.json] [--force] [--json] <path>",
+				Summary: "Convert an external eval dataset (openai/evals samples, Inspect AI dataset, or custom JSONL) into a suite file with one mission per sample and answer expectations.",
 			},
 			{
 				ID:      "suite plan",
-				Usage:   "zcl suite plan --file <suite.(yaml|yml|json)> [--run-id <runId>] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--blind on|off] [--blind-terms <csv>] [--out-root .zcl] --json",
+				Usage:   "zcl suite plan --file <suite.(yaml|yml|json)> [--run-id <runId>] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--blind on|off] [--blind-terms <csv>] [--blind-terms-pack <name>] [--out-root .zcl] --json",
 				Summary: "Allocate attempt dirs for every mission in a suite file and print env/pointers per mission (for orchestrators).",
 			},
 			{
 				ID:      "suite run",
-				Usage:   "zcl suite run --file <suite.(yaml|yml|json)> [--run-id <runId>] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--feedback-policy strict|auto_fail] [--finalization-mode strict|auto_fail|auto_from_result_json] [--result-channel none|file_json|stdout_json] [--result-file <attempt-relative-path>] [--result-marker <prefix>] [--result-min-turn N] [--campaign-id <id>] [--campaign-state <path>] [--progress-jsonl <path|->] [--blind on|off] [--blind-terms <csv>] [--session-isolation auto|process|native] [--runtime-strategies <csv>] [--native-model <slug>] [--native-model-reasoning-effort none|minimal|low|medium|high|xhigh] [--native-model-reasoning-policy best_effort|required] [--parallel N] [--total M] [--mission-offset N] [--out-root .zcl] [--strict] [--strict-expect] [--shim <bin>] [--capture-runner-io] --json [-- <runner-cmd> [args...]]",
-				Summary: "Run a suite with capability-aware isolation, optional campaign continuity/progress stream, and deterministic finish/validate/expect per attempt.",
+				Usage:   "zcl suite run --file <suite.(yaml|yml|json)> [--run-id <runId>] [--mode discovery|ci] [--timeout-ms N] [--timeout-start attempt_start|first_tool_call] [--feedback-policy strict|auto_fail] [--finalization-mode strict|auto_fail|auto_from_result_json] [--result-channel none|file_json|stdout_json] [--result-file <attempt-relative-path>] [--result-marker <prefix>] [--result-min-turn N] [--campaign-id <id>] [--campaign-state <path>] [--progress-jsonl <path|->] [--progress-events <csv>] [--blind on|off] [--blind-terms <csv>] [--blind-terms-pack <name>] [--blind-action reject|rewrite] [--session-isolation auto|process|native] [--runtime-strategies <csv>] [--native-model <slug>] [--native-model-reasoning-effort none|minimal|low|medium|high|xhigh] [--native-model-reasoning-policy best_effort|required] [--chaos <profile.(yaml|yml|json)>] [--parallel N] [--schedule suite|longest-first] [--total M] [--mission-offset N] [--mission <missionId>]... [--watch] [--watch-debounce 300ms] [--out-root .zcl] [--strict] [--strict-expect] [--shim <bin>] [--capture-runner-io] [--vcr record|replay] [--vcr-from <runDir|attemptDir|cassette>] [--sandbox none|bwrap] [--network host|none|advisory-allowlist] [--allow-host <host>]... --json [-- <runner-cmd> [args...]]",
+				Summary: "Run a suite with capability-aware isolation, optional campaign continuity/progress stream, and deterministic finish/validate/expect per attempt; --watch re-runs affected missions on suite/prompt file changes.",
 			},
 			{
 				ID:      "campaign run",
@@ -468,8 +684,8 @@ func Build(version string) Contract {
 			},
 			{
 				ID:      "campaign status",
-				Usage:   "zcl campaign status --campaign-id <id> [--out-root .zcl] [--json]",
-				Summary: "Read the latest first-class campaign execution state.",
+				Usage:   "zcl campaign status --campaign-id <id> [--out-root .zcl] [--stale-after-ms N] [--json]",
+				Summary: "Read the latest first-class campaign execution state and flag its attempts whose heartbeat went stale (likely hung).",
 			},
 			{
 				ID:      "campaign report",
@@ -481,6 +697,26 @@ func Build(version string) Contract {
 				Usage:   "zcl campaign publish-check [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--out-root .zcl] [--force] [--json]",
 				Summary: "Refuse publish-ready benchmark output unless campaign status is valid (unless forced).",
 			},
+			{
+				ID:      "campaign regrade export",
+				Usage:   "zcl campaign regrade export [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] --out <dir> [--out-root .zcl] [--seed N] [--json]",
+				Summary: "Export campaign attempt answers for double-blind re-grading: shuffled blind ids, flow/runner/model/attempt identifiers stripped; the blindId mapping stays under the campaign dir.",
+			},
+			{
+				ID:      "campaign regrade merge",
+				Usage:   "zcl campaign regrade merge [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] --verdicts <path> [--mapping <path>] [--out-root .zcl] [--json]",
+				Summary: "Merge blinded re-grading verdicts back through the export mapping into campaign.regrade.json (per-flow original vs regraded pass counts and flips).",
+			},
+			{
+				ID:      "campaign export",
+				Usage:   "zcl campaign export [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--out <dir>] [--out-root .zcl] [--json]",
+				Summary: "Write an in-toto/SLSA provenance attestation for campaign.summary.json (spec and suite sha256, zcl and runtime versions, comparability keys); --out also copies the publishable artifacts.",
+			},
+			{
+				ID:      "export",
+				Usage:   "zcl export --format inspect-ai|helm [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--out <dir>] [--out-root .zcl] [--json]",
+				Summary: "Map campaign flow attempts and mission gate verdicts into Inspect AI eval logs or HELM run directories (one model per flow) for external benchmark dashboards.",
+			},
 			{
 				ID:      "campaign doctor",
 				Usage:   "zcl campaign doctor --spec <campaign.(yaml|yml|json)> [--out-root .zcl] [--json]",
@@ -501,23 +737,104 @@ func Build(version string) Contract {
 				Usage:   "zcl expect [--strict] --json <attemptDir|runDir>",
 				Summary: "Evaluate suite expectations against feedback.json (JSON output includes failures; exit code indicates pass/fail).",
 			},
+			{
+				ID:      "scan secrets",
+				Usage:   "zcl scan secrets --run-id <runId> [--out-root .zcl] [--json]",
+				Summary: "Scan every stored artifact of a run (including raw runner IO and captures) with the redaction detectors and report hits by file and line.",
+			},
+			{
+				ID:      "redact verify",
+				Usage:   "zcl redact verify --run-id <runId> [--out-root .zcl] [--json]",
+				Summary: "Re-scan every stored artifact of a run with the current redaction rule set, report residual hits and record redaction.verify.json (publish-check precondition with output.requireRedactionVerify).",
+			},
+			{
+				ID:      "purge",
+				Usage:   "zcl purge --attempt <attemptDir> --pattern <regex> --reason <text> [--replacement \"[PURGED]\"] [--confirm] [--json]",
+				Summary: "Rewrite an attempt's artifacts with regex matches removed (preview unless --confirm), re-chain the trace and append a purge.jsonl record with per-file hashes before/after.",
+			},
+			{
+				ID:      "review next",
+				Usage:   "zcl review next [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--all] [--out-root .zcl] [--json]",
+				Summary: "Show the next campaign attempt needing manual verification (failed gate, gate errors, non-valid status, split ensemble votes) with its evidence.",
+			},
+			{
+				ID:      "review record",
+				Usage:   "zcl review record --attempt <attemptDir> --ok|--fail [--reviewer <name>] [--notes <text>] [--json]",
+				Summary: "Write review.json (ok, notes, reviewer) for an attempt; campaign report/summary/RESULTS.md fold reviews into a reviews block.",
+			},
+			{
+				ID:      "verdict override",
+				Usage:   "zcl verdict override --attempt <attemptDir> --ok=true|false --reason <text> [--by <name>] [--json]",
+				Summary: "Adjudicate an attempt verdict by writing verdict.override.json (who/when/why, with history); attempt reports, campaign gates and RESULTS.md honor and flag it.",
+			},
+			{
+				ID:      "sync",
+				Usage:   "zcl sync [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] [--dest s3://bucket/prefix|gs://bucket/prefix|file:///path] [--out-root .zcl] [--json]",
+				Summary: "Upload campaign and referenced run artifacts to shared storage with a sync.manifest.json (sha256 per file); unchanged files are skipped. Config sync.auto syncs after campaign run/resume.",
+			},
+			{
+				ID:      "sign",
+				Usage:   "zcl sign [--campaign-id <id> | --spec <campaign.(yaml|yml|json)>] --key <ed25519.pem> [--out-root .zcl] [--json]",
+				Summary: "Hash the campaign dir and referenced run dirs into campaign.signature.json and sign it with an ed25519 key (PEM PKCS#8; default $ZCL_SIGNING_KEY).",
+			},
+			{
+				ID:      "verify",
+				Usage:   "zcl verify --campaign-id <id> [--pubkey <ed25519.pub.pem>] [--out-root .zcl] [--json]",
+				Summary: "Check the campaign.signature.json signature (optionally pinned to --pubkey) and re-hash every signed file; changed, missing or unsigned files fail.",
+			},
+			{
+				ID:      "encryption keygen",
+				Usage:   "zcl encryption keygen [--out <identity.key>] [--json]",
+				Summary: "Generate an age identity for encryption at rest and print its age1 recipient; configure encryption.recipient to seal prompt and raw runner IO at attempt finish.",
+			},
+			{
+				ID:      "migrate",
+				Usage:   "zcl migrate --from 1 --to 2 --run-id <runId> [--out-root .zcl] [--dry-run] [--json]",
+				Summary: "Rewrite a run's attempt artifacts to the next schema version (1->2: attempt.report.json); report/validate read both versions so historical runs stay comparable.",
+			},
+			{
+				ID:      "repro bundle",
+				Usage:   "zcl repro bundle --run-id <runId> --out <dir> [--out-root .zcl] [--json]",
+				Summary: "Package a suite run's suite snapshot, recorded invocation, campaign spec, merged config and env policy into a hash-pinned, re-runnable bundle.",
+			},
+			{
+				ID:      "repro run",
+				Usage:   "zcl repro run <bundleDir> [--out-root .zcl]",
+				Summary: "Verify a repro bundle and re-execute its suite run invocation under a fresh run id; config drift is reported on stderr.",
+			},
+			{
+				ID:      "schema export",
+				Usage:   "zcl schema export --artifact attempt.report|feedback|suite|campaign --json-schema",
+				Summary: "Print the canonical JSON Schema (draft 2020-12) for an artifact or input file, generated from the Go structs ZCL reads and writes.",
+			},
+			{
+				ID:      "expect update-goldens",
+				Usage:   "zcl expect update-goldens --json <attemptDir|runDir>",
+				Summary: "Regenerate expects.golden files from attempt feedback after an accepted output change.",
+			},
 		},
 		Errors: []Error{
 			{Code: codes.Usage, Summary: "Invalid CLI usage (missing/invalid flags).", Retryable: false},
 			{Code: codes.IO, Summary: "Filesystem I/O error while writing artifacts.", Retryable: true},
+			{Code: codes.CommandFailed, Summary: "Command exited non-zero without a more specific code (--error-format json envelope fallback; see stdout/details).", Retryable: false},
 			{Code: codes.MissingArtifact, Summary: "Missing required artifact(s) for the requested operation.", Retryable: true},
 			{Code: codes.MissingEvidence, Summary: "Primary evidence is missing/empty (e.g. empty tool.calls.jsonl).", Retryable: true},
 			{Code: codes.InvalidJSON, Summary: "Invalid JSON in an artifact file.", Retryable: false},
 			{Code: codes.InvalidJSONL, Summary: "Invalid JSONL stream (bad line or empty line in strict mode).", Retryable: false},
 			{Code: codes.SchemaUnsupported, Summary: "Unsupported schema version for an artifact/event.", Retryable: false},
 			{Code: codes.IDMismatch, Summary: "IDs in artifacts/events do not match expected attempt/run IDs.", Retryable: false},
+			{Code: codes.RunInconsistent, Summary: "Attempts of one run violate a cross-attempt invariant (duplicate ids, shared scratch dirs, non-monotonic timestamps).", Retryable: false},
+			{Code: codes.SessionNotFresh, Summary: "A native attempt did not run in a session/thread of its own (runner.ref.json ids reused across attempts or contradicted by its trace); fails suite run.", Retryable: false},
 			{Code: codes.Bounds, Summary: "Captured payload exceeds size bounds.", Retryable: false},
 			{Code: codes.UnsafeEvidence, Summary: "Evidence violates safety policy (for example raw captures in strict CI mode).", Retryable: false},
 			{Code: codes.Contract, Summary: "Artifact/event violates the ZCL contract shape.", Retryable: false},
+			{Code: codes.TraceChainBroken, Summary: "tool.calls.jsonl prevHash chain (or the report's traceChainHead anchor) does not verify; evidence was edited.", Retryable: false},
 			{Code: codes.Containment, Summary: "Artifact path escapes attempt/run directory (symlink traversal).", Retryable: false},
 			{Code: codes.Spawn, Summary: "Failed to spawn or execute a wrapped command in the funnel.", Retryable: true},
 			{Code: codes.ToolFailed, Summary: "Wrapped tool execution completed with a non-zero outcome.", Retryable: true},
 			{Code: codes.Timeout, Summary: "Timed out waiting for a tool operation.", Retryable: true},
+			{Code: codes.ToolPolicyDenied, Summary: "Funnel refused a call disallowed by the flow tool policy (zcl run / zcl mcp proxy).", Retryable: false},
+			{Code: codes.VCRMiss, Summary: "VCR replay found no recorded response for a tool call (zcl run / zcl mcp proxy with ZCL_VCR_MODE=replay).", Retryable: false},
 			{Code: codes.RuntimeStrategyUnsupported, Summary: "Configured runtime strategy ID is not registered.", Retryable: false},
 			{Code: codes.RuntimeStrategyUnavailable, Summary: "No runtime strategy in the fallback chain is currently available.", Retryable: true},
 			{Code: codes.RuntimeCapabilityUnsupported, Summary: "Selected runtime does not support required capabilities.", Retryable: false},
@@ -535,6 +852,14 @@ func Build(version string) Contract {
 			{Code: codes.RuntimeStall, Summary: "Native runtime attempt stalled past deadline without terminal completion.", Retryable: true},
 			{Code: codes.MCPMaxToolCalls, Summary: "MCP proxy stopped after configured max tool calls.", Retryable: true},
 			{Code: codes.ContaminatedPrompt, Summary: "Blind mode rejected a prompt containing harness terms.", Retryable: false},
+			{Code: codes.ResourceLimit, Summary: "Runner was killed by its runner.limits memory cap (OOM in the cgroup scope or container), not a mission failure.", Retryable: true},
+			{Code: codes.DiskQuota, Summary: "Runner was killed because its attempt dir + workspace grew past --disk-quota-mb (runner.diskQuotaMb); see disk.usage.json.", Retryable: false},
+			{Code: codes.EgressDenied, Summary: "The --network advisory-allowlist egress proxy refused a request to a host outside --allow-host (trace event http/egress).", Retryable: false},
+			{Code: codes.NetworkRequired, Summary: "Mission declares requiresNetwork but the suite ran with --network none; the attempt was blocked, not run.", Retryable: false},
+			{Code: codes.SecretLeak, Summary: "Stored run artifacts contain a credential matched by the redaction detectors.", Retryable: false},
+			{Code: codes.DecryptFailed, Summary: "An artifact is encrypted at rest (.enc) and no configured identity can decrypt it; set encryption.identityFile|identityCommand or ZCL_ENCRYPTION_IDENTITY.", Retryable: false},
+			{Code: codes.RunLocked, Summary: "Another live process is running a suite into the same runs/<runId> (same --run-id); wait for it or use a new run id.", Retryable: true},
+			{Code: codes.SignatureInvalid, Summary: "campaign.signature.json or a signed feedback.json does not verify: bad signature, unexpected key, or changed/missing/unsigned artifacts.", Retryable: false},
 			{Code: codes.VersionFloor, Summary: "Installed zcl version does not satisfy required minimum version.", Retryable: false},
 			{Code: codes.FunnelBypass, Summary: "Primary evidence missing/empty despite a final outcome being recorded (funnel bypass suspected).", Retryable: false},
 			{Code: codes.ExpectationFailed, Summary: "Suite expectations did not match feedback.json.", Retryable: false},
@@ -549,10 +874,14 @@ func Build(version string) Contract {
 			{Code: campaign.ReasonToolDriverShim, Summary: "Campaign flow with toolDriver.kind=cli_funnel is missing required shims.", Retryable: false},
 			{Code: campaign.ReasonToolPolicy, Summary: "Campaign flow tool policy gate detected disallowed tool namespace/prefix usage in trace evidence.", Retryable: false},
 			{Code: campaign.ReasonToolPolicyConfig, Summary: "Campaign flow tool policy configuration is invalid.", Retryable: false},
+			{Code: campaign.ReasonEgressViolation, Summary: "Attempt trace records a request the --network advisory-allowlist egress proxy blocked.", Retryable: false},
 			{Code: campaign.ReasonOracleVisibility, Summary: "Campaign oracleSource host_only visibility policy violation.", Retryable: false},
 			{Code: campaign.ReasonOracleEvaluator, Summary: "Campaign oracle evaluator configuration is missing or invalid for exam mode.", Retryable: false},
 			{Code: campaign.ReasonOracleEvalFailed, Summary: "Campaign oracle evaluator returned a failing verdict for the attempt.", Retryable: false},
 			{Code: campaign.ReasonOracleEvalError, Summary: "Campaign oracle evaluator execution or verdict parsing failed.", Retryable: true},
+			{Code: campaign.ReasonSecretLeak, Summary: "Campaign publish-check found leaked credentials in stored flow run artifacts.", Retryable: false},
+			{Code: campaign.ReasonRedactionUnverified, Summary: "Campaign publish-check requires a passing, current redaction.verify.json for every flow run (zcl redact verify).", Retryable: false},
+			{Code: campaign.ReasonVerdictOverridden, Summary: "Mission gate failed because an attempt verdict was overridden to fail.", Retryable: false},
 			{Code: codes.CampaignStateDrift, Summary: "Campaign run-state continuity drift detected (spec mission selection disagrees with persisted run-state).", Retryable: false},
 			{Code: codes.CampaignLockTimeout, Summary: "Campaign lock acquisition failed (another campaign run/resume likely owns the lock).", Retryable: true},
 		},
@@ -560,7 +889,7 @@ func Build(version string) Contract {
 			SchemaVersion:      1,
 			SpecSchemaPath:     "internal/campaign/campaign.spec.schema.json",
 			TraceProfiles:      []string{campaign.TraceProfileNone, campaign.TraceProfileStrictBrowserComp, campaign.TraceProfileMCPRequired},
-			RunnerTypes:        []string{campaign.RunnerTypeProcessCmd, campaign.RunnerTypeCodexExec, campaign.RunnerTypeCodexSub, campaign.RunnerTypeClaudeSub, campaign.RunnerTypeCodexAppSrv},
+			RunnerTypes:        []string{campaign.RunnerTypeProcessCmd, campaign.RunnerTypeCodexExec, campaign.RunnerTypeCodexSub, campaign.RunnerTypeClaudeSub, campaign.RunnerTypeClaudeCLI, campaign.RunnerTypeHTTPAgent, campaign.RunnerTypeWSAgent, campaign.RunnerTypeCodexAppSrv, campaign.RunnerTypeDocker, campaign.RunnerTypeSSH},
 			ToolDriverKinds:    []string{campaign.ToolDriverShell, campaign.ToolDriverCLIFunnel, campaign.ToolDriverMCPProxy, campaign.ToolDriverHTTPProxy},
 			FinalizationModes:  []string{campaign.FinalizationModeStrict, campaign.FinalizationModeAutoFail, campaign.FinalizationModeAutoFromResultJSON},
 			ResultChannelKinds: []string{campaign.ResultChannelNone, campaign.ResultChannelFileJSON, campaign.ResultChannelStdoutJSON},
@@ -595,6 +924,7 @@ func Build(version string) Contract {
 				campaign.ReasonToolDriverShim,
 				campaign.ReasonToolPolicy,
 				campaign.ReasonToolPolicyConfig,
+				campaign.ReasonEgressViolation,
 				campaign.ReasonGateFailed,
 				campaign.ReasonFirstMissionGate,
 				campaign.ReasonSemanticFailed,
@@ -647,7 +977,7 @@ func Build(version string) Contract {
 					Path:        "evaluation.evaluator.kind",
 					Type:        "string",
 					Required:    false,
-					Enum:        []string{campaign.EvaluatorKindScript, campaign.EvaluatorKindBuiltin},
+					Enum:        []string{campaign.EvaluatorKindScript, campaign.EvaluatorKindBuiltin, campaign.EvaluatorKindBuiltinCompare, campaign.EvaluatorKindLLMJudge},
 					Default:     campaign.EvaluatorKindScript,
 					Description: "Host-side evaluator kind for oracle mode.",
 				},
@@ -657,6 +987,58 @@ func Build(version string) Contract {
 					Required:    false,
 					Description: "Host-side evaluator argv invoked per attempt in exam mode when evaluator.kind=script.",
 				},
+				{
+					Path:        "evaluation.evaluator.model",
+					Type:        "string",
+					Required:    false,
+					Description: "Judge model for evaluator.kind=llm_judge (required for that kind; part of the verdict cache key).",
+				},
+				{
+					Path:        "evaluation.evaluator.runtimeStrategies",
+					Type:        "string[]",
+					Required:    false,
+					Default:     []string{campaign.RunnerTypeCodexAppSrv},
+					Description: "Native runtime fallback chain used to reach the judge model when evaluator.kind=llm_judge.",
+				},
+				{
+					Path:        "evaluation.evaluator.instructions",
+					Type:        "string",
+					Required:    false,
+					Description: "Extra grading instructions appended to the llm_judge prompt.",
+				},
+				{
+					Path:        "evaluation.evaluators",
+					Type:        "object[]",
+					Required:    false,
+					Description: "Evaluator ensemble (same fields as evaluation.evaluator plus id); mutually exclusive with evaluation.evaluator.",
+				},
+				{
+					Path:        "evaluation.evaluators[].id",
+					Type:        "string",
+					Required:    false,
+					Description: "Member id used in oracle.verdict.json and judge agreement metrics (default: kind, suffixed when repeated).",
+				},
+				{
+					Path:        "evaluation.ensemblePolicy",
+					Type:        "string",
+					Required:    false,
+					Enum:        []string{campaign.EnsemblePolicyMajority, campaign.EnsemblePolicyUnanimous},
+					Default:     campaign.EnsemblePolicyMajority,
+					Description: "How ensemble member verdicts combine: majority (errored members abstain, ties fail) or unanimous.",
+				},
+				{
+					Path:        "evaluation.rubric",
+					Type:        "object[]",
+					Required:    false,
+					Description: "Weighted rubric criteria {criterion, weight (default 1), evaluator}; each attempt gets a score breakdown in oracle.verdict.json.",
+				},
+				{
+					Path:        "evaluation.rubricPassScore",
+					Type:        "number",
+					Required:    false,
+					Default:     0,
+					Description: "Minimum rubric score in [0,1] for an attempt to pass when no evaluation.evaluator(s) gate it.",
+				},
 				{
 					Path:        "evaluation.oraclePolicy.mode",
 					Type:        "string",
@@ -691,6 +1073,24 @@ func Build(version string) Contract {
 					Required:    false,
 					Description: "When true, mission envelope expiry marks flow infra_failed and continues the campaign.",
 				},
+				{
+					Path:        "hooks.onEvent[].match",
+					Type:        "string",
+					Required:    false,
+					Description: "campaign.progress.jsonl status that triggers the hook: exact status, `prefix*` or `*` (gate_failed/gate_passed alias gate_fail/gate_pass).",
+				},
+				{
+					Path:        "hooks.onEvent[].command",
+					Type:        "array",
+					Required:    false,
+					Description: "Hook argv; receives the matching progress event as JSON on stdin and ZCL_HOOK_* env. Failures are recorded as event_hook_fail and never change the campaign outcome.",
+				},
+				{
+					Path:        "hooks.onEvent[].timeoutMs",
+					Type:        "integer",
+					Required:    false,
+					Description: "Per-hook timeout (default timeouts.cleanupHookTimeoutMs, else 15000).",
+				},
 				{
 					Path:        "pairGate.traceProfile",
 					Type:        "string",
@@ -767,6 +1167,127 @@ func Build(version string) Contract {
 					Default:     campaign.RunnerCwdRetainNever,
 					Description: "Retention policy for per-attempt temp cwd directories.",
 				},
+				{
+					Path:        "flows[].runner.docker.image",
+					Type:        "string",
+					Required:    false,
+					Description: "Container image for runner.type=docker; each attempt runs runner.command in a fresh container with the attempt dir mounted.",
+				},
+				{
+					Path:        "flows[].runner.docker.mounts",
+					Type:        "string[]",
+					Required:    false,
+					Description: "Extra bind mounts (host:container[:ro|rw]) for runner.type=docker; relative host paths resolve against the spec dir.",
+				},
+				{
+					Path:        "flows[].runner.docker.network",
+					Type:        "string",
+					Required:    false,
+					Default:     container.NetworkBridge,
+					Description: "Container network mode for runner.type=docker (bridge|none|host or a named network).",
+				},
+				{
+					Path:        "flows[].runner.docker.containerEngine",
+					Type:        "string",
+					Required:    false,
+					Enum:        []string{container.EngineDocker, container.EnginePodman},
+					Default:     container.EngineDocker,
+					Description: "Container engine for runner.type=docker; podman runs rootless (--userns=keep-id) when zcl is not root.",
+				},
+				{
+					Path:        "flows[].runner.docker.limits",
+					Type:        "object",
+					Required:    false,
+					Description: "Container cgroup limits {cpu, memoryMb, pids}; requires cgroup v2 on the host, 0 means unlimited.",
+				},
+				{
+					Path:        "flows[].runner.ssh.host",
+					Type:        "string",
+					Required:    false,
+					Description: "Remote host ([user@]host or ssh config alias) for runner.type=ssh; each attempt runs runner.command there with the attempt env forwarded.",
+				},
+				{
+					Path:        "flows[].runner.ssh.port",
+					Type:        "integer",
+					Required:    false,
+					Description: "ssh port for runner.type=ssh (default: ssh config / 22).",
+				},
+				{
+					Path:        "flows[].runner.ssh.identity",
+					Type:        "string",
+					Required:    false,
+					Description: "Private key for runner.type=ssh; relative paths resolve against the spec dir. Connections use BatchMode (no password prompts).",
+				},
+				{
+					Path:        "flows[].runner.ssh.workDir",
+					Type:        "string",
+					Required:    false,
+					Default:     remote.DefaultWorkDir,
+					Description: "Absolute remote root for per-attempt dirs; removed after each attempt.",
+				},
+				{
+					Path:        "flows[].runner.ssh.zcl",
+					Type:        "string",
+					Required:    false,
+					Default:     remote.DefaultZCL,
+					Description: "zcl binary on the remote host used by the runner and tool shims.",
+				},
+				{
+					Path:        "flows[].runner.ssh.sync",
+					Type:        "string[]",
+					Required:    false,
+					Description: "Extra attempt-relative globs synced back after the runner exits (evidence artifacts always are); empty syncs the whole remote attempt dir.",
+				},
+				{
+					Path:        "flows[].runner.claude",
+					Type:        "object",
+					Required:    false,
+					Description: "runner.type=claude_cli invocation {binary, model, maxTurns, permissionMode, allowedTools[], disallowedTools[], mcpConfig, appendSystemPrompt, outputFormat (json|stream-json), extraArgs[]}; zcl builds runner.command (claude --print) and gives each attempt a fresh --session-id and its prompt.",
+				},
+				{
+					Path:        "flows[].runner.http",
+					Type:        "object",
+					Required:    false,
+					Description: "runner.type=http_agent service {url, headers (values expand ${VAR} from the attempt env), pollIntervalMs}; zcl builds runner.command (zcl http agent), POSTs each attempt's prompt and reads the result from the response, an event stream or a polled status URL (defaults finalization to auto_from_result_json over stdout_json).",
+				},
+				{
+					Path:        "flows[].runner.ws",
+					Type:        "object",
+					Required:    false,
+					Description: "runner.type=ws_agent agent {url (ws|wss), headers (values expand ${VAR} from the attempt env), interruptGraceMs (default 5000)}; zcl builds runner.command (zcl ws agent), streams each attempt's mission over a WebSocket, traces agent events as tool=ws and interrupts the agent interruptGraceMs before the attempt deadline.",
+				},
+				{
+					Path:        "flows[].runner.limits",
+					Type:        "object",
+					Required:    false,
+					Description: "Per-attempt runner limits {cpu, memoryMb, pids}: a transient systemd cgroup scope for process runners, container limits for docker flows; OOM kills fail with ZCL_E_RESOURCE_LIMIT.",
+				},
+				{
+					Path:        "flows[].runner.diskQuotaMb",
+					Type:        "integer",
+					Required:    false,
+					Description: "Per-attempt disk quota in MiB over the attempt dir + workspace (suite run --disk-quota-mb); runners over it are killed with ZCL_E_DISK_QUOTA.",
+				},
+				{
+					Path:        "flows[].runner.mcpServers",
+					Type:        "object",
+					Required:    false,
+					Description: "Native flows only: MCP servers {name: {command[], env}} registered on thread/start behind zcl mcp proxy; a flow server replaces the suite defaults.mcpServers entry of the same name.",
+				},
+				{
+					Path:        "flows[].runner.home.mode",
+					Type:        "string",
+					Required:    false,
+					Enum:        []string{home.ModeInherit, home.ModeEphemeral},
+					Default:     home.ModeInherit,
+					Description: "ephemeral gives each process-runner attempt a fresh HOME under its tmp dir, with XDG and tool config dirs (CODEX_HOME, CLAUDE_CONFIG_DIR, GH_CONFIG_DIR, ...) pointed inside it.",
+				},
+				{
+					Path:        "flows[].runner.home.template",
+					Type:        "string",
+					Required:    false,
+					Description: "Directory copied into each ephemeral HOME (e.g. agent auth files); relative paths resolve against the spec dir, symlinks are skipped.",
+				},
 				{
 					Path:        "flows[].runner.model",
 					Type:        "string",
@@ -841,9 +1362,22 @@ func Build(version string) Contract {
 			HealthMetrics: native.CanonicalHealthMetrics(),
 			Strategies:    runtimeContractStrategies(),
 		},
+		DecisionTags: DecisionTags{
+			TaxonomyVersion: schema.DecisionTagTaxonomyVersionV1,
+			Tags:            schema.DecisionTagTaxonomyV1(),
+		},
+		BlindTermPacks: blindTermPacks(),
 	}
 }
 
+func blindTermPacks() map[string][]string {
+	out := map[string][]string{}
+	for _, name := range blind.PackNames() {
+		out[name], _ = blind.PackTerms(name)
+	}
+	return out
+}
+
 func runtimeContractStrategies() []RuntimeStrategySchema {
 	descriptors := native.BuiltinStrategyCatalog()
 	out := make([]RuntimeStrategySchema, 0, len(descriptors))
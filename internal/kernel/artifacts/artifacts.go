@@ -5,26 +5,58 @@ const (
 	SuiteJSON           = "suite.json"
 	SuiteRunSummaryJSON = "suite.run.summary.json"
 	RunReportJSON       = "run.report.json"
+	RunInvocationJSON   = "run.invocation.json"
+	RedactionVerifyJSON = "redaction.verify.json"
 
-	CampaignStateJSON     = "campaign.state.json"
-	CampaignRunStateJSON  = "campaign.run.state.json"
-	CampaignPlanJSON      = "campaign.plan.json"
-	CampaignProgressJSONL = "campaign.progress.jsonl"
-	CampaignReportJSON    = "campaign.report.json"
-	CampaignSummaryJSON   = "campaign.summary.json"
-	CampaignResultsMD     = "RESULTS.md"
-	MissionPromptsJSON    = "mission.prompts.json"
+	CampaignStateJSON       = "campaign.state.json"
+	CampaignRunStateJSON    = "campaign.run.state.json"
+	CampaignStateDB         = "campaign.state.db"
+	CampaignPlanJSON        = "campaign.plan.json"
+	CampaignProgressJSONL   = "campaign.progress.jsonl"
+	CampaignReportJSON      = "campaign.report.json"
+	CampaignSummaryJSON     = "campaign.summary.json"
+	CampaignResultsMD       = "RESULTS.md"
+	MissionPromptsJSON      = "mission.prompts.json"
+	CampaignRegradeJSON     = "campaign.regrade.json"
+	SyncManifestJSON        = "sync.manifest.json"
+	CampaignSignatureJSON   = "campaign.signature.json"
+	CampaignAttestationJSON = "campaign.attestation.intoto.json"
+	RegradeItemsJSON        = "regrade.items.json"
+	RegradeMappingJSON      = "regrade.mapping.json"
 
-	AttemptJSON           = "attempt.json"
-	PromptTXT             = "prompt.txt"
-	AttemptEnvSH          = "attempt.env.sh"
-	AttemptRuntimeEnvJSON = "attempt.runtime.env.json"
-	ToolCallsJSONL        = "tool.calls.jsonl"
-	FeedbackJSON          = "feedback.json"
-	NotesJSONL            = "notes.jsonl"
-	CapturesJSONL         = "captures.jsonl"
-	AttemptReportJSON     = "attempt.report.json"
-	OracleVerdictJSON     = "oracle.verdict.json"
-	RunnerRefJSON         = "runner.ref.json"
-	RunnerMetricsJSON     = "runner.metrics.json"
+	AttemptJSON             = "attempt.json"
+	PromptTXT               = "prompt.txt"
+	PromptOriginalTXT       = "prompt.original.txt"
+	PromptContaminationJSON = "prompt.contamination.json"
+	AttemptEnvSH            = "attempt.env.sh"
+	AttemptRuntimeEnvJSON   = "attempt.runtime.env.json"
+	ToolCallsJSONL          = "tool.calls.jsonl"
+	TraceSamplingJSON       = "trace.sampling.json"
+	DiskUsageJSON           = "disk.usage.json"
+	EnvFingerprintJSON      = "env.fingerprint.json"
+	ResourcesJSONL          = "resources.jsonl"
+	FeedbackJSON            = "feedback.json"
+	FeedbackHistoryJSONL    = "feedback.history.jsonl"
+	FeedbackProgressJSONL   = "feedback.progress.jsonl"
+	NotesJSONL              = "notes.jsonl"
+	CapturesJSONL           = "captures.jsonl"
+	ToolCassetteJSONL       = "tool.cassette.jsonl"
+	AttemptReportJSON       = "attempt.report.json"
+	AttemptFinishJSON       = "attempt.finish.json"
+	AttemptHeartbeatJSON    = "attempt.heartbeat.json"
+	OracleVerdictJSON       = "oracle.verdict.json"
+	ClaimVerifiedJSON       = "claim.vs.verified.json"
+	ReviewJSON              = "review.json"
+	VerdictOverrideJSON     = "verdict.override.json"
+	PurgeJSONL              = "purge.jsonl"
+	SemanticRulesJSON       = "semantic.rules.json"
+	RunnerRefJSON           = "runner.ref.json"
+	RunnerMetricsJSON       = "runner.metrics.json"
+
+	AttemptBundleManifestJSON = "attempt.bundle.manifest.json"
+	ArchiveIndexJSON          = "archive.index.json"
 )
+
+// AttemptFinishTx names the directory transaction that publishes attempt.report.json and
+// attempt.finish.json together; readers roll it forward before reading either.
+const AttemptFinishTx = "finish"
@@ -1,11 +1,27 @@
 package blind
 
 import (
-	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+	"fmt"
+	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+)
+
+// Blind actions decide what suite run does with a contaminated prompt: reject fails the attempt
+// with typed evidence, rewrite underscores the terms and runs the sanitized prompt.
+const (
+	ActionReject  = "reject"
+	ActionRewrite = "rewrite"
 )
 
+func IsValidAction(s string) bool {
+	return s == ActionReject || s == ActionRewrite
+}
+
 var defaultHarnessTermsV1 = []string{
 	"zcl",
 	"zcl feedback",
@@ -67,3 +83,83 @@ func FindContaminationTerms(prompt string, terms []string) []string {
 	}
 	return found
 }
+
+// SanitizePrompt replaces every case-insensitive occurrence of terms with underscores of the same
+// rune length and counts replacements per normalized term. Longer terms win over terms they contain
+// ("zcl feedback" before "zcl"). Empty terms fall back to the default set, like FindContaminationTerms.
+func SanitizePrompt(prompt string, terms []string) (string, map[string]int) {
+	norm := NormalizeTerms(terms)
+	if len(norm) == 0 {
+		norm = DefaultHarnessTermsV1()
+	}
+	sort.SliceStable(norm, func(i, j int) bool { return len(norm[i]) > len(norm[j]) })
+	alts := make([]string, 0, len(norm))
+	for _, t := range norm {
+		alts = append(alts, "("+regexp.QuoteMeta(t)+")")
+	}
+	re := regexp.MustCompile("(?i)" + strings.Join(alts, "|"))
+	counts := map[string]int{}
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringSubmatchIndex(prompt, -1) {
+		for g := 1; g <= len(norm); g++ {
+			if m[2*g] >= 0 {
+				counts[norm[g-1]]++
+				break
+			}
+		}
+		b.WriteString(prompt[last:m[0]])
+		b.WriteString(strings.Repeat("_", utf8.RuneCountInString(prompt[m[0]:m[1]])))
+		last = m[1]
+	}
+	if len(counts) == 0 {
+		return prompt, nil
+	}
+	b.WriteString(prompt[last:])
+	return b.String(), counts
+}
+
+// Finding is a contamination hit outside prompt.txt; Source is "env:<KEY>" or "argv[<i>]".
+type Finding struct {
+	Source string
+	Terms  []string
+}
+
+// ScanEnv finds harness terms in runner env values. ZCL_* keys are the harness's own plumbing and
+// absolute paths (PATH, HOME, out-root dirs) name files rather than instruct the agent, so both are
+// skipped. Findings are sorted by key.
+func ScanEnv(env map[string]string, terms []string) []Finding {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		if !strings.HasPrefix(k, "ZCL_") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var out []Finding
+	for _, k := range keys {
+		if found := scanValue(env[k], terms); len(found) > 0 {
+			out = append(out, Finding{Source: "env:" + k, Terms: found})
+		}
+	}
+	return out
+}
+
+// ScanArgv finds harness terms in runner arguments. argv[0] is the executable (never shown to the
+// agent) and absolute paths are skipped like in ScanEnv.
+func ScanArgv(argv []string, terms []string) []Finding {
+	var out []Finding
+	for i := 1; i < len(argv); i++ {
+		if found := scanValue(argv[i], terms); len(found) > 0 {
+			out = append(out, Finding{Source: fmt.Sprintf("argv[%d]", i), Terms: found})
+		}
+	}
+	return out
+}
+
+func scanValue(v string, terms []string) []string {
+	if filepath.IsAbs(strings.TrimSpace(v)) {
+		return nil
+	}
+	return FindContaminationTerms(v, terms)
+}
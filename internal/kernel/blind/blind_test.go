@@ -0,0 +1,68 @@
+package blind
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSanitizePrompt_PrefersLongerTerms(t *testing.T) {
+	got, counts := SanitizePrompt("Run ZCL feedback, then zcl again.", []string{"zcl", "zcl feedback"})
+	if got != "Run ____________, then ___ again." {
+		t.Fatalf("unexpected sanitized prompt: %q", got)
+	}
+	if !reflect.DeepEqual(counts, map[string]int{"zcl": 1, "zcl feedback": 1}) {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+	if clean, counts := SanitizePrompt("nothing here", []string{"zcl"}); clean != "nothing here" || counts != nil {
+		t.Fatalf("expected clean prompt untouched, got %q %+v", clean, counts)
+	}
+}
+
+func TestScanEnvAndArgv_SkipHarnessKeysAndPaths(t *testing.T) {
+	env := map[string]string{
+		"AGENT_NOTE":  "finish with zcl feedback",
+		"ZCL_OUT_DIR": "runs/x",
+		"HOME":        "/tmp/.zcl/home",
+		"CLEAN":       "ok",
+	}
+	want := []Finding{{Source: "env:AGENT_NOTE", Terms: []string{"zcl", "zcl feedback"}}}
+	if got := ScanEnv(env, []string{"zcl", "zcl feedback"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected env findings: %+v", got)
+	}
+	argv := []string{"zcl-runner", "/opt/zcl/config.toml", "--system", "call the zcl funnel"}
+	want = []Finding{{Source: "argv[3]", Terms: []string{"zcl"}}}
+	if got := ScanArgv(argv, []string{"zcl"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected argv findings: %+v", got)
+	}
+}
+
+func TestResolveTerms_MergesPackWithExplicitTerms(t *testing.T) {
+	got, err := ResolveTerms(PackCodex, nil, []string{"Acme Eval"})
+	if err != nil {
+		t.Fatalf("ResolveTerms: %v", err)
+	}
+	for _, want := range []string{"acme eval", "zcl feedback", "result marker"} {
+		if !contains(got, want) {
+			t.Fatalf("expected %q in codex pack terms: %v", want, got)
+		}
+	}
+	custom := map[string][]string{"vendor": {"vendor-harness"}}
+	if got, err := ResolveTerms("vendor", custom, nil); err != nil || !reflect.DeepEqual(got, []string{"vendor-harness"}) {
+		t.Fatalf("unexpected custom pack terms: %v (%v)", got, err)
+	}
+	if _, err := ResolveTerms("nope", custom, nil); err == nil {
+		t.Fatalf("expected unknown pack to be rejected")
+	}
+	if err := ValidateCustomPackName(PackClaude); err == nil {
+		t.Fatalf("expected built-in pack name to be rejected for suite packs")
+	}
+}
+
+func contains(in []string, s string) bool {
+	for _, v := range in {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package blind
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/artifacts"
+)
+
+// Built-in blind term packs. generic-harness is the default term set; the runner packs add the
+// evaluation vocabulary adapters tend to leak plus what the runner's own wiring exposes (codex
+// adapters read a stdout result marker, claude adapters route tools through the mcp proxy).
+const (
+	PackGenericHarness = "generic-harness"
+	PackCodex          = "codex"
+	PackClaude         = "claude"
+)
+
+var packNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+var evaluationTermsV1 = []string{
+	artifacts.AttemptEnvSH,
+	"mission result",
+	"grader",
+	"oracle",
+}
+
+var builtinPacksV1 = map[string][]string{
+	PackGenericHarness: defaultHarnessTermsV1,
+	PackCodex:          packTerms(evaluationTermsV1, "result marker"),
+	PackClaude:         packTerms(evaluationTermsV1, "mcp proxy"),
+}
+
+func packTerms(shared []string, extra ...string) []string {
+	out := append(append([]string(nil), defaultHarnessTermsV1...), shared...)
+	return append(out, extra...)
+}
+
+// PackNames lists the built-in pack names, sorted.
+func PackNames() []string {
+	out := make([]string, 0, len(builtinPacksV1))
+	for name := range builtinPacksV1 {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// PackTerms returns the normalized terms of a built-in pack.
+func PackTerms(name string) ([]string, bool) {
+	terms, ok := builtinPacksV1[name]
+	return NormalizeTerms(terms), ok
+}
+
+func IsBuiltinPack(name string) bool {
+	_, ok := builtinPacksV1[name]
+	return ok
+}
+
+// ValidateCustomPackName checks a suite-declared pack name: lowercase, at most 64 chars, and not
+// shadowing a built-in pack.
+func ValidateCustomPackName(name string) error {
+	if !packNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid blind term pack name %q (expected lowercase [a-z0-9_-], max 64 chars)", name)
+	}
+	if IsBuiltinPack(name) {
+		return fmt.Errorf("blind term pack %q is built in", name)
+	}
+	return nil
+}
+
+// ResolveTerms returns the normalized union of the named pack (built-in or from custom) and the
+// explicit terms. An empty pack yields just the explicit terms.
+func ResolveTerms(pack string, custom map[string][]string, explicit []string) ([]string, error) {
+	if pack == "" {
+		return NormalizeTerms(explicit), nil
+	}
+	terms, ok := builtinPacksV1[pack]
+	if !ok {
+		terms, ok = custom[pack]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown blind term pack %q", pack)
+	}
+	return NormalizeTerms(append(append([]string(nil), terms...), explicit...)), nil
+}
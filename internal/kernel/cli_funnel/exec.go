@@ -63,6 +63,8 @@ func (c *boundedCapture) snapshot() (preview string, bytesTotal int64, truncated
 	return c.buf.String(), c.total, c.truncated
 }
 
+const pipeDrainDelay = 2 * time.Second
+
 func Run(ctx context.Context, argv []string, stdin io.Reader, stdout io.Writer, stderr io.Writer, outFull io.Writer, errFull io.Writer, maxPreviewBytes int) (Result, error) {
 	if len(argv) == 0 {
 		return Result{}, errors.New("missing command argv")
@@ -84,38 +86,26 @@ func Run(ctx context.Context, argv []string, stdin io.Reader, stdout io.Writer,
 		cmd.Stdin = stdin
 	}
 
-	outPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return Result{}, err
-	}
-	errPipe, err := cmd.StderrPipe()
-	if err != nil {
-		return Result{}, err
-	}
-
-	start := time.Now()
-	if err := cmd.Start(); err != nil {
-		return Result{}, err
-	}
-
 	var outCap boundedCapture
 	outCap.max = maxPreviewBytes
 	var errCap boundedCapture
 	errCap.max = maxPreviewBytes
 
-	var wg sync.WaitGroup
-	wg.Add(2)
-	go func() {
-		defer wg.Done()
-		_, _ = io.Copy(multiWriter(stdout, outFull, &outCap), outPipe)
-	}()
-	go func() {
-		defer wg.Done()
-		_, _ = io.Copy(multiWriter(stderr, errFull, &errCap), errPipe)
-	}()
+	// Let exec own the copy goroutines: Wait returns only after both streams are drained, so
+	// output written right before exit is never lost (StdoutPipe + Wait races the readers).
+	cmd.Stdout = multiWriter(stdout, outFull, &outCap)
+	cmd.Stderr = multiWriter(stderr, errFull, &errCap)
+	// Background grandchildren may keep the pipes open after the command exits; stop copying then.
+	cmd.WaitDelay = pipeDrainDelay
 
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return Result{}, err
+	}
 	waitErr := cmd.Wait()
-	wg.Wait()
+	if errors.Is(waitErr, exec.ErrWaitDelay) {
+		waitErr = nil
+	}
 
 	exitCode := 0
 	if waitErr != nil {
@@ -11,19 +11,31 @@ const (
 	InvalidJSONL       = "ZCL_E_INVALID_JSONL"
 	SchemaUnsupported  = "ZCL_E_SCHEMA_UNSUPPORTED"
 	IDMismatch         = "ZCL_E_ID_MISMATCH"
+	RunInconsistent    = "ZCL_E_RUN_INCONSISTENT"
+	SessionNotFresh    = "ZCL_E_SESSION_NOT_FRESH"
 	Bounds             = "ZCL_E_BOUNDS"
 	UnsafeEvidence     = "ZCL_E_UNSAFE_EVIDENCE"
 	Contract           = "ZCL_E_CONTRACT"
 	Containment        = "ZCL_E_CONTAINMENT"
+	TraceChainBroken   = "ZCL_E_TRACE_CHAIN_BROKEN"
 	Spawn              = "ZCL_E_SPAWN"
 	ToolFailed         = "ZCL_E_TOOL_FAILED"
 	Timeout            = "ZCL_E_TIMEOUT"
 	MCPMaxToolCalls    = "ZCL_E_MCP_MAX_TOOL_CALLS"
 	ContaminatedPrompt = "ZCL_E_CONTAMINATED_PROMPT"
+	NetworkRequired    = "ZCL_E_NETWORK_REQUIRED"
+	EgressDenied       = "ZCL_E_EGRESS_DENIED"
+	ResourceLimit      = "ZCL_E_RESOURCE_LIMIT"
+	DiskQuota          = "ZCL_E_DISK_QUOTA"
+	SecretLeak         = "ZCL_E_SECRET_LEAK"
+	SignatureInvalid   = "ZCL_E_SIGNATURE_INVALID"
+	DecryptFailed      = "ZCL_E_DECRYPT"
+	RunLocked          = "ZCL_E_RUN_LOCKED"
 	VersionFloor       = "ZCL_E_VERSION_FLOOR"
 	FunnelBypass       = "ZCL_E_FUNNEL_" + "BYPASS"
 	ExpectationFailed  = "ZCL_E_EXPECTATION_FAILED"
 	Semantic           = "ZCL_E_SEMANTIC"
+	CommandFailed      = "ZCL_E_COMMAND_FAILED"
 
 	MissionResultMissing      = "ZCL_E_MISSION_RESULT_MISSING"
 	MissionResultInvalid      = "ZCL_E_MISSION_RESULT_INVALID"
@@ -41,6 +53,9 @@ const (
 	CampaignOracleEvaluatorMissing = "ZCL_E_CAMPAIGN_ORACLE_EVALUATOR_REQUIRED"
 	CampaignOracleEvalFailed       = "ZCL_E_CAMPAIGN_ORACLE_EVALUATION_FAILED"
 	CampaignOracleEvalError        = "ZCL_E_CAMPAIGN_ORACLE_EVALUATION_ERROR"
+	CampaignSecretLeak             = "ZCL_E_CAMPAIGN_SECRET_LEAK"
+	CampaignRedactionUnverified    = "ZCL_E_CAMPAIGN_REDACTION_UNVERIFIED"
+	CampaignVerdictOverridden      = "ZCL_E_CAMPAIGN_VERDICT_OVERRIDDEN"
 	CampaignLockTimeout            = "ZCL_E_CAMPAIGN_LOCK_TIMEOUT"
 	CampaignHookFailed             = "ZCL_E_CAMPAIGN_HOOK_FAILED"
 	CampaignGlobalTimeout          = "ZCL_E_CAMPAIGN_GLOBAL_TIMEOUT"
@@ -60,8 +75,11 @@ const (
 	CampaignTraceProfileBootstrapOnly       = "ZCL_E_CAMPAIGN_TRACE_PROFILE_BOOTSTRAP_ONLY"
 	CampaignToolPolicyViolation             = "ZCL_E_CAMPAIGN_TOOL_POLICY_VIOLATION"
 	CampaignToolPolicyInvalid               = "ZCL_E_CAMPAIGN_TOOL_POLICY_INVALID"
+	CampaignEgressViolation                 = "ZCL_E_CAMPAIGN_EGRESS_VIOLATION"
 
-	Shim = "ZCL_E_SHIM"
+	Shim             = "ZCL_E_SHIM"
+	ToolPolicyDenied = "ZCL_E_TOOL_POLICY_DENIED"
+	VCRMiss          = "ZCL_E_VCR_MISS"
 
 	RuntimeStrategyUnsupported   = "ZCL_E_RUNTIME_STRATEGY_UNSUPPORTED"
 	RuntimeStrategyUnavailable   = "ZCL_E_RUNTIME_STRATEGY_UNAVAILABLE"
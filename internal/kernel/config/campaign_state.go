@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Campaign state backends. "file" rewrites campaign.state.json / campaign.run.state.json in place;
+// "sqlite" keeps them in one campaign.state.db per campaign and updates them in transactions, so
+// concurrent suite runs sharing a campaign ID do not lose each other's updates.
+const (
+	CampaignStateFile   = "file"
+	CampaignStateSQLite = "sqlite"
+)
+
+// mergeCampaignStateConfig applies precedence: env (ZCL_CAMPAIGN_STATE) > project > global > file.
+func mergeCampaignStateConfig(res *Merged, project, global string, globalPath string) error {
+	res.CampaignState = CampaignStateFile
+	res.CampaignStateSource = "default"
+	switch {
+	case strings.TrimSpace(os.Getenv("ZCL_CAMPAIGN_STATE")) != "":
+		res.CampaignState = os.Getenv("ZCL_CAMPAIGN_STATE")
+		res.CampaignStateSource = "env:ZCL_CAMPAIGN_STATE"
+	case strings.TrimSpace(project) != "":
+		res.CampaignState = project
+		res.CampaignStateSource = DefaultProjectConfigPath
+	case strings.TrimSpace(global) != "":
+		res.CampaignState = global
+		res.CampaignStateSource = globalPath
+	}
+	res.CampaignState = strings.ToLower(strings.TrimSpace(res.CampaignState))
+	switch res.CampaignState {
+	case CampaignStateFile, CampaignStateSQLite:
+		return nil
+	default:
+		return fmt.Errorf("campaignState must be %q or %q (from %s)", CampaignStateFile, CampaignStateSQLite, res.CampaignStateSource)
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// EncryptionConfigV1 enables encryption at rest for raw runner IO and prompt artifacts:
+//
+//	"encryption": {"recipient": "age1...", "identityFile": "~/.zcl/artifacts.key"}
+//
+// Recipient (an age recipient, public) encrypts at attempt finish; only readers need the identity,
+// either from IdentityFile or from the stdout of IdentityCommand (e.g. a secret-manager fetch). A
+// plugin recipient ("age1<plugin>1...") wraps the file key through age-plugin-<plugin>, e.g. a KMS.
+type EncryptionConfigV1 struct {
+	Recipient       string `json:"recipient,omitempty"`
+	IdentityFile    string `json:"identityFile,omitempty"`
+	IdentityCommand string `json:"identityCommand,omitempty"`
+}
+
+// Enabled reports whether new artifacts should be encrypted.
+func (c EncryptionConfigV1) Enabled() bool {
+	return strings.TrimSpace(c.Recipient) != ""
+}
+
+// mergeEncryptionConfig applies precedence: env (ZCL_ENCRYPTION_RECIPIENT, ZCL_ENCRYPTION_IDENTITY,
+// ZCL_ENCRYPTION_IDENTITY_CMD) > project > global.
+func mergeEncryptionConfig(res *Merged, project, global *EncryptionConfigV1, globalPath string) {
+	switch {
+	case project != nil:
+		res.Encryption = *project
+		res.EncryptionSource = DefaultProjectConfigPath
+	case global != nil:
+		res.Encryption = *global
+		res.EncryptionSource = globalPath
+	}
+	if v := strings.TrimSpace(os.Getenv("ZCL_ENCRYPTION_RECIPIENT")); v != "" {
+		res.Encryption.Recipient = v
+		res.EncryptionSource = "env:ZCL_ENCRYPTION_RECIPIENT"
+	}
+	if v := strings.TrimSpace(os.Getenv("ZCL_ENCRYPTION_IDENTITY")); v != "" {
+		res.Encryption.IdentityFile = v
+		res.Encryption.IdentityCommand = ""
+	}
+	if v := strings.TrimSpace(os.Getenv("ZCL_ENCRYPTION_IDENTITY_CMD")); v != "" {
+		res.Encryption.IdentityCommand = v
+		res.Encryption.IdentityFile = ""
+	}
+	res.Encryption.Recipient = strings.TrimSpace(res.Encryption.Recipient)
+}
+
+// ParsedRecipient returns the configured recipient key, or nil when encryption is disabled.
+func (c EncryptionConfigV1) ParsedRecipient() (age.Recipient, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	k, err := store.ParseRecipient(c.Recipient)
+	if err != nil {
+		return nil, fmt.Errorf("encryption.recipient: %w", err)
+	}
+	return k, nil
+}
+
+// LoadIdentities reads the decryption identities; it returns none when no source is configured.
+func (c EncryptionConfigV1) LoadIdentities() ([]age.Identity, error) {
+	switch {
+	case strings.TrimSpace(c.IdentityCommand) != "":
+		out, err := exec.Command("sh", "-c", c.IdentityCommand).Output()
+		if err != nil {
+			return nil, fmt.Errorf("encryption.identityCommand: %w", err)
+		}
+		ids, err := store.ParseIdentities(string(out))
+		if err != nil {
+			return nil, fmt.Errorf("encryption.identityCommand: %w", err)
+		}
+		return ids, nil
+	case strings.TrimSpace(c.IdentityFile) != "":
+		path := expandHome(strings.TrimSpace(c.IdentityFile))
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("encryption.identityFile: %w", err)
+		}
+		ids, err := store.ParseIdentities(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("encryption.identityFile %s: %w", path, err)
+		}
+		return ids, nil
+	default:
+		return nil, nil
+	}
+}
+
+func expandHome(p string) string {
+	if !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return home + p[1:]
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Default process exit codes of zcl's failure outcome classes.
+const (
+	DefaultExitHarnessError = 1
+	DefaultExitInvalidRun   = 2
+	DefaultExitUsage        = 2
+)
+
+// ExitCodesConfigV1 remaps outcome classes to process exit codes for orchestrators that attach
+// meaning to specific codes:
+//
+//	"exitCodes": {"harnessError": 70, "invalidRun": 3, "usage": 64}
+//
+// Unset classes keep their defaults; success is always 0.
+type ExitCodesConfigV1 struct {
+	HarnessError int `json:"harnessError,omitempty"`
+	InvalidRun   int `json:"invalidRun,omitempty"`
+	Usage        int `json:"usage,omitempty"`
+}
+
+// Resolved fills unset classes with their default exit codes.
+func (c ExitCodesConfigV1) Resolved() ExitCodesConfigV1 {
+	if c.HarnessError == 0 {
+		c.HarnessError = DefaultExitHarnessError
+	}
+	if c.InvalidRun == 0 {
+		c.InvalidRun = DefaultExitInvalidRun
+	}
+	if c.Usage == 0 {
+		c.Usage = DefaultExitUsage
+	}
+	return c
+}
+
+// Validate rejects codes a process cannot exit with or that would read as success.
+func (c ExitCodesConfigV1) Validate() error {
+	for _, f := range []struct {
+		name string
+		code int
+	}{{"harnessError", c.HarnessError}, {"invalidRun", c.InvalidRun}, {"usage", c.Usage}} {
+		if f.code != 0 && !validExitCode(f.code) {
+			return fmt.Errorf("exitCodes.%s must be between 1 and 255 (got %d)", f.name, f.code)
+		}
+	}
+	return nil
+}
+
+func validExitCode(n int) bool {
+	return n >= 1 && n <= 255
+}
+
+func mergeExitCodesConfig(res *Merged, project, global *ExitCodesConfigV1, globalPath string) error {
+	switch {
+	case project != nil:
+		res.ExitCodes = *project
+		res.ExitCodesSource = DefaultProjectConfigPath
+	case global != nil:
+		res.ExitCodes = *global
+		res.ExitCodesSource = globalPath
+	}
+	if err := res.ExitCodes.Validate(); err != nil {
+		return fmt.Errorf("%w (from %s)", err, res.ExitCodesSource)
+	}
+	return nil
+}
+
+func parseExitCode(raw string) (any, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || !validExitCode(n) {
+		return nil, fmt.Errorf("value must be an exit code between 1 and 255 (got %q)", raw)
+	}
+	return n, nil
+}
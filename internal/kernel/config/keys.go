@@ -0,0 +1,241 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// Value origins reported by `zcl config get`.
+const (
+	OriginDefault = "default"
+	OriginFlag    = "flag"
+	OriginEnv     = "env"
+	OriginProfile = "profile"
+	OriginFile    = "file"
+)
+
+// KeyValueV1 is one merged config value plus where it came from. Source is the detailed source
+// string used by Merged (e.g. "env:ZCL_OUT_ROOT" or a config file path); Origin is its class.
+type KeyValueV1 struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+	Origin string `json:"origin"`
+}
+
+type configKey struct {
+	name string
+	get  func(Merged) (any, string)
+	// parse converts the `zcl config set` string form; nil keys are read-only.
+	parse func(string) (any, error)
+}
+
+var configKeys = []configKey{
+	{name: "outRoot", get: func(m Merged) (any, string) { return m.OutRoot, m.Source }, parse: parseNonEmpty},
+	{name: "runtime.strategyChain", get: func(m Merged) (any, string) { return m.RuntimeStrategyChain, m.RuntimeStrategySource }, parse: parseStrategyChain},
+	{name: "campaignState", get: func(m Merged) (any, string) { return m.CampaignState, m.CampaignStateSource }, parse: parseCampaignState},
+	{name: "sync.dest", get: func(m Merged) (any, string) { return m.Sync.Dest, m.SyncSource }, parse: parseSyncDest},
+	{name: "sync.auto", get: func(m Merged) (any, string) { return m.Sync.Auto, m.SyncSource }, parse: parseBool},
+	{name: "retention.keepRuns", get: func(m Merged) (any, string) { return m.Retention.KeepRuns, m.RetentionSource }, parse: parseNonNegativeInt},
+	{name: "retention.keepDays", get: func(m Merged) (any, string) { return m.Retention.KeepDays, m.RetentionSource }, parse: parseNonNegativeInt},
+	{name: "retention.keepFailed", get: func(m Merged) (any, string) { return m.Retention.KeepFailed, m.RetentionSource }, parse: parseKeepFailed},
+	{name: "encryption.recipient", get: func(m Merged) (any, string) { return m.Encryption.Recipient, m.EncryptionSource }, parse: parseString},
+	{name: "encryption.identityFile", get: func(m Merged) (any, string) { return m.Encryption.IdentityFile, m.EncryptionSource }, parse: parseString},
+	{name: "encryption.identityCommand", get: func(m Merged) (any, string) { return m.Encryption.IdentityCommand, m.EncryptionSource }, parse: parseString},
+	{name: "exitCodes.harnessError", get: func(m Merged) (any, string) { return m.ExitCodes.Resolved().HarnessError, m.ExitCodesSource }, parse: parseExitCode},
+	{name: "exitCodes.invalidRun", get: func(m Merged) (any, string) { return m.ExitCodes.Resolved().InvalidRun, m.ExitCodesSource }, parse: parseExitCode},
+	{name: "exitCodes.usage", get: func(m Merged) (any, string) { return m.ExitCodes.Resolved().Usage, m.ExitCodesSource }, parse: parseExitCode},
+	{name: "profile", get: func(m Merged) (any, string) { return m.Profile, m.ProfileSource }},
+}
+
+// ConfigKeys lists every key `zcl config get` understands, in display order.
+func ConfigKeys() []string {
+	out := make([]string, 0, len(configKeys))
+	for _, k := range configKeys {
+		out = append(out, k.name)
+	}
+	return out
+}
+
+func lookupConfigKey(key string) (configKey, error) {
+	for _, k := range configKeys {
+		if k.name == key {
+			return k, nil
+		}
+	}
+	return configKey{}, fmt.Errorf("unknown config key %q (known: %s)", key, strings.Join(ConfigKeys(), ", "))
+}
+
+// GetKey returns one merged value with its provenance.
+func GetKey(m Merged, key string) (KeyValueV1, error) {
+	k, err := lookupConfigKey(strings.TrimSpace(key))
+	if err != nil {
+		return KeyValueV1{}, err
+	}
+	v, source := k.get(m)
+	if source == "" {
+		source = OriginDefault
+	}
+	return KeyValueV1{Key: k.name, Value: v, Source: source, Origin: originOf(source)}, nil
+}
+
+// GetAll returns every known key in display order.
+func GetAll(m Merged) []KeyValueV1 {
+	out := make([]KeyValueV1, 0, len(configKeys))
+	for _, k := range configKeys {
+		kv, _ := GetKey(m, k.name)
+		out = append(out, kv)
+	}
+	return out
+}
+
+func originOf(source string) string {
+	switch {
+	case source == OriginDefault:
+		return OriginDefault
+	case source == OriginFlag, strings.HasPrefix(source, "flag ("):
+		return OriginFlag
+	case strings.HasPrefix(source, "env:"):
+		return OriginEnv
+	case strings.HasPrefix(source, "profile:"):
+		return OriginProfile
+	default:
+		return OriginFile
+	}
+}
+
+// SetResultV1 is the `zcl config set` result.
+type SetResultV1 struct {
+	OK         bool   `json:"ok"`
+	ConfigPath string `json:"configPath"`
+	Key        string `json:"key"`
+	Value      any    `json:"value"`
+	Created    bool   `json:"created"`
+}
+
+// SetKey writes key=value into the config file at path (zcl.config.json or the global config),
+// keeping every other field of the file as-is. The project config must already exist (`zcl init`);
+// a missing global config is created. The edited file must still pass Lint before it is written.
+func SetKey(path string, global bool, key, raw string) (SetResultV1, error) {
+	k, err := lookupConfigKey(strings.TrimSpace(key))
+	if err != nil {
+		return SetResultV1{}, err
+	}
+	if k.parse == nil {
+		return SetResultV1{}, fmt.Errorf("config key %q is read-only", k.name)
+	}
+	value, err := k.parse(raw)
+	if err != nil {
+		return SetResultV1{}, fmt.Errorf("%s: %w", k.name, err)
+	}
+
+	doc := map[string]any{}
+	created := false
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return SetResultV1{}, fmt.Errorf("%s: %w", path, err)
+		}
+	case os.IsNotExist(err) && global:
+		doc["schemaVersion"] = 1
+		created = true
+	case os.IsNotExist(err):
+		return SetResultV1{}, fmt.Errorf("%s does not exist (run `zcl init` first)", path)
+	default:
+		return SetResultV1{}, err
+	}
+	setDotted(doc, k.name, value)
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return SetResultV1{}, err
+	}
+	if issue := firstLintError(lintConfigBytes(path, out, global)); issue != nil {
+		return SetResultV1{}, fmt.Errorf("%s would be invalid: %s", path, issue.Message)
+	}
+	if err := store.WriteJSONAtomic(path, doc); err != nil {
+		return SetResultV1{}, err
+	}
+	return SetResultV1{OK: true, ConfigPath: path, Key: k.name, Value: value, Created: created}, nil
+}
+
+func setDotted(doc map[string]any, key string, value any) {
+	parts := strings.Split(key, ".")
+	cur := doc
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := cur[p].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			cur[p] = next
+		}
+		cur = next
+	}
+	cur[parts[len(parts)-1]] = value
+}
+
+func parseString(raw string) (any, error) {
+	return strings.TrimSpace(raw), nil
+}
+
+func parseNonEmpty(raw string) (any, error) {
+	v := strings.TrimSpace(raw)
+	if v == "" {
+		return nil, fmt.Errorf("value must not be empty")
+	}
+	return v, nil
+}
+
+func parseBool(raw string) (any, error) {
+	return strconv.ParseBool(strings.TrimSpace(raw))
+}
+
+func parseNonNegativeInt(raw string) (any, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("value must be an integer >= 0 (got %q)", raw)
+	}
+	return n, nil
+}
+
+func parseKeepFailed(raw string) (any, error) {
+	return ParseKeepFailed(raw)
+}
+
+func parseStrategyChain(raw string) (any, error) {
+	chain := ParseRuntimeStrategyCSV(raw)
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("value must be a comma-separated list of runtime strategies")
+	}
+	return chain, nil
+}
+
+func parseCampaignState(raw string) (any, error) {
+	v := strings.ToLower(strings.TrimSpace(raw))
+	if v != CampaignStateFile && v != CampaignStateSQLite {
+		return nil, fmt.Errorf("value must be %q or %q", CampaignStateFile, CampaignStateSQLite)
+	}
+	return v, nil
+}
+
+func parseSyncDest(raw string) (any, error) {
+	if err := ValidateSyncDest(raw); err != nil {
+		return nil, err
+	}
+	return strings.TrimSpace(raw), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
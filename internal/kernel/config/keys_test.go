@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func chdirTempProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd := mustGetwd(t)
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	mustNoErr(t, "chdir", os.Chdir(dir))
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+	return dir
+}
+
+func TestSetKeyAndGetKey_Provenance(t *testing.T) {
+	chdirTempProject(t)
+	if _, err := SetKey(DefaultProjectConfigPath, false, "campaignState", "sqlite"); err == nil || !strings.Contains(err.Error(), "zcl init") {
+		t.Fatalf("expected missing project config error, got %v", err)
+	}
+	mustNoErr(t, "write", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl-project","custom":{"keep":true}}`), 0o644))
+
+	res, err := SetKey(DefaultProjectConfigPath, false, "runtime.strategyChain", "codex_app_server, provider_stub")
+	if err != nil || !res.OK {
+		t.Fatalf("SetKey: %+v err=%v", res, err)
+	}
+	if _, err := SetKey(DefaultProjectConfigPath, false, "campaignState", "postgres"); err == nil {
+		t.Fatalf("expected invalid campaignState to be rejected")
+	}
+	if _, err := SetKey(DefaultProjectConfigPath, false, "profile", "x"); err == nil {
+		t.Fatalf("expected read-only key to be rejected")
+	}
+	raw, err := os.ReadFile(DefaultProjectConfigPath)
+	mustNoErr(t, "read", err)
+	if !strings.Contains(string(raw), `"custom"`) {
+		t.Fatalf("set must keep unrelated fields: %s", raw)
+	}
+
+	globalPath := mustGlobalConfigPath(t)
+	res, err = SetKey(globalPath, true, "retention.keepRuns", "7")
+	if err != nil || !res.Created {
+		t.Fatalf("SetKey global: %+v err=%v", res, err)
+	}
+
+	t.Setenv("ZCL_CAMPAIGN_STATE", "sqlite")
+	m := mustLoadMerged(t, "")
+	for key, want := range map[string]string{
+		"outRoot":               OriginFile,
+		"runtime.strategyChain": OriginFile,
+		"campaignState":         OriginEnv,
+		"retention.keepRuns":    OriginFile,
+		"sync.dest":             OriginDefault,
+	} {
+		kv, err := GetKey(m, key)
+		if err != nil || kv.Origin != want {
+			t.Fatalf("GetKey(%s) = %+v err=%v; want origin %s", key, kv, err, want)
+		}
+	}
+	if kv, _ := GetKey(m, "retention.keepRuns"); kv.Value != 7 || kv.Source != globalPath {
+		t.Fatalf("unexpected keepRuns: %+v", kv)
+	}
+	if _, err := GetKey(m, "nope"); err == nil {
+		t.Fatalf("expected unknown key error")
+	}
+}
+
+func TestLint_ReportsUnknownKeysAndInvalidValues(t *testing.T) {
+	chdirTempProject(t)
+	if res := Lint(); !res.OK || len(res.Issues) != 0 {
+		t.Fatalf("no config should lint clean: %+v", res)
+	}
+	mustNoErr(t, "write", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","runtime":{"strategyChian":["x"]},"sync":{"dest":"ftp://x"}}`), 0o644))
+	res := Lint()
+	if res.OK {
+		t.Fatalf("expected lint errors: %+v", res)
+	}
+	var sawUnknown, sawSync bool
+	for _, is := range res.Issues {
+		sawUnknown = sawUnknown || (is.Key == "runtime.strategyChian" && is.Severity == LintSeverityWarning)
+		sawSync = sawSync || (is.Key == "sync.dest" && is.Severity == LintSeverityError)
+	}
+	if !sawUnknown || !sawSync {
+		t.Fatalf("unexpected issues: %+v", res.Issues)
+	}
+
+	mustNoErr(t, "write", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","campaignState":"sqlite"}`), 0o644))
+	t.Setenv("ZCL_CAMPAIGN_STATE", "bogus")
+	res = Lint()
+	if res.OK || len(res.Issues) != 1 || res.Issues[0].Path != "merged" {
+		t.Fatalf("expected merged-view error, got %+v", res)
+	}
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+)
+
+// LintIssueV1 is one `zcl config lint` finding. Path is the config file (or "merged" for
+// problems that only show up once files, env and the selected profile are combined).
+type LintIssueV1 struct {
+	Path     string `json:"path"`
+	Key      string `json:"key,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+type LintFileV1 struct {
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+}
+
+type LintResultV1 struct {
+	OK     bool          `json:"ok"`
+	Files  []LintFileV1  `json:"files"`
+	Issues []LintIssueV1 `json:"issues"`
+}
+
+// Lint validates the project and global config files and then the merged view LoadMerged builds
+// from them. Unknown keys are warnings; anything LoadMerged or a command would reject is an error.
+func Lint() LintResultV1 {
+	res := LintResultV1{OK: true, Issues: []LintIssueV1{}}
+	globalPath, err := DefaultGlobalConfigPath()
+	if err != nil {
+		res.Issues = append(res.Issues, LintIssueV1{Path: "global", Severity: LintSeverityError, Message: err.Error()})
+	}
+	paths := []struct {
+		path   string
+		global bool
+	}{{DefaultProjectConfigPath, false}}
+	if globalPath != "" {
+		paths = append(paths, struct {
+			path   string
+			global bool
+		}{globalPath, true})
+	}
+	for _, p := range paths {
+		raw, err := os.ReadFile(p.path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				res.Issues = append(res.Issues, LintIssueV1{Path: p.path, Severity: LintSeverityError, Message: err.Error()})
+			}
+			res.Files = append(res.Files, LintFileV1{Path: p.path})
+			continue
+		}
+		res.Files = append(res.Files, LintFileV1{Path: p.path, Exists: true})
+		res.Issues = append(res.Issues, lintConfigBytes(p.path, raw, p.global)...)
+	}
+	if !hasLintErrors(res.Issues) {
+		if _, err := LoadMerged(""); err != nil {
+			res.Issues = append(res.Issues, LintIssueV1{Path: "merged", Severity: LintSeverityError, Message: err.Error()})
+		}
+		if _, err := LoadRedactionMerged(); err != nil {
+			res.Issues = append(res.Issues, LintIssueV1{Path: "merged", Key: "redaction", Severity: LintSeverityError, Message: err.Error()})
+		}
+	}
+	res.OK = !hasLintErrors(res.Issues)
+	return res
+}
+
+func lintConfigBytes(path string, raw []byte, global bool) []LintIssueV1 {
+	var issues []LintIssueV1
+	add := func(severity, key, format string, args ...any) {
+		issues = append(issues, LintIssueV1{Path: path, Key: key, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		add(LintSeverityError, "", "invalid json: %v", err)
+		return issues
+	}
+	// Global and project configs share field names; only the outRoot requirement differs.
+	for _, k := range unknownConfigKeys(reflect.TypeOf(ProjectConfigV1{}), doc, "") {
+		add(LintSeverityWarning, k, "unknown key %q is ignored", k)
+	}
+	var cfg ProjectConfigV1
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		add(LintSeverityError, "", "%v", err)
+		return issues
+	}
+	if cfg.SchemaVersion != ProjectConfigSchemaV1 {
+		add(LintSeverityError, "schemaVersion", "unsupported schemaVersion=%d", cfg.SchemaVersion)
+	}
+	if !global && strings.TrimSpace(cfg.OutRoot) == "" {
+		add(LintSeverityError, "outRoot", "project config outRoot is empty")
+	}
+	if v := strings.TrimSpace(cfg.CampaignState); v != "" {
+		if _, err := parseCampaignState(v); err != nil {
+			add(LintSeverityError, "campaignState", "%v", err)
+		}
+	}
+	if cfg.Sync != nil && strings.TrimSpace(cfg.Sync.Dest) != "" {
+		if err := ValidateSyncDest(cfg.Sync.Dest); err != nil {
+			add(LintSeverityError, "sync.dest", "%v", err)
+		}
+	}
+	if cfg.Retention != nil && (cfg.Retention.KeepRuns < 0 || cfg.Retention.KeepDays < 0) {
+		add(LintSeverityError, "retention", "keepRuns and keepDays must be >= 0")
+	}
+	if cfg.ExitCodes != nil {
+		if err := cfg.ExitCodes.Validate(); err != nil {
+			add(LintSeverityError, "exitCodes", "%v", err)
+		}
+	}
+	if cfg.Encryption != nil {
+		if _, err := cfg.Encryption.ParsedRecipient(); err != nil {
+			add(LintSeverityError, "encryption.recipient", "%v", err)
+		}
+	}
+	if cfg.Redaction != nil {
+		if err := ValidateRedactionRules(cfg.Redaction.ExtraRules); err != nil {
+			add(LintSeverityError, "redaction.extraRules", "%v", err)
+		}
+		if rulesFile := ResolveRedactionRulesFile(path, cfg.Redaction); rulesFile != "" {
+			if _, err := LoadRedactionRulesFile(rulesFile); err != nil {
+				add(LintSeverityError, "redaction.rulesFile", "%v", err)
+			}
+		}
+	}
+	for _, name := range sortedKeys(cfg.Profiles) {
+		if b := cfg.Profiles[name].Budgets; b != nil && (b.TimeoutMs < 0 || b.Parallel < 0) {
+			add(LintSeverityError, "profiles."+name+".budgets", "timeoutMs and parallel must be >= 0")
+		}
+	}
+	return issues
+}
+
+// unknownConfigKeys walks a decoded JSON value against the json tags of t and returns the dotted
+// paths of object keys t does not declare.
+func unknownConfigKeys(t reflect.Type, v any, prefix string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var out []string
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		fields := map[string]reflect.Type{}
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if name != "" && name != "-" {
+				fields[name] = t.Field(i).Type
+			}
+		}
+		for _, k := range sortedKeys(obj) {
+			ft, ok := fields[k]
+			if !ok {
+				out = append(out, prefix+k)
+				continue
+			}
+			out = append(out, unknownConfigKeys(ft, obj[k], prefix+k+".")...)
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for _, k := range sortedKeys(obj) {
+			out = append(out, unknownConfigKeys(t.Elem(), obj[k], prefix+k+".")...)
+		}
+	case reflect.Slice:
+		arr, ok := v.([]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range arr {
+			out = append(out, unknownConfigKeys(t.Elem(), item, fmt.Sprintf("%s%d.", prefix, i))...)
+		}
+	}
+	return out
+}
+
+func hasLintErrors(issues []LintIssueV1) bool {
+	return firstLintError(issues) != nil
+}
+
+func firstLintError(issues []LintIssueV1) *LintIssueV1 {
+	for i := range issues {
+		if issues[i].Severity == LintSeverityError {
+			return &issues[i]
+		}
+	}
+	return nil
+}
@@ -16,6 +16,37 @@ type Merged struct {
 
 	RuntimeStrategyChain  []string
 	RuntimeStrategySource string
+
+	// Sync is the artifact sync destination; Sync.Dest is empty when unconfigured.
+	Sync       SyncConfigV1
+	SyncSource string
+
+	// Retention holds `zcl gc` defaults; the zero value means no configured retention.
+	Retention       RetentionConfigV1
+	RetentionSource string
+
+	// Encryption enables at-rest encryption of raw runner IO and prompts; zero value means disabled.
+	Encryption       EncryptionConfigV1
+	EncryptionSource string
+
+	// CampaignState selects the campaign state backend (CampaignStateFile or CampaignStateSQLite).
+	CampaignState       string
+	CampaignStateSource string
+
+	// ExitCodes remaps failure outcome classes to process exit codes; use ExitCodes.Resolved().
+	ExitCodes       ExitCodesConfigV1
+	ExitCodesSource string
+
+	// Profile is the selected named profile ("" when none); ProfileSource names the selector and
+	// the file that defined it.
+	Profile       string
+	ProfileSource string
+
+	// Native model and budget defaults come only from the selected profile.
+	NativeModel           string
+	NativeReasoningEffort string
+	NativeReasoningPolicy string
+	Budgets               BudgetsV1
 }
 
 func DefaultGlobalConfigPath() (string, error) {
@@ -27,19 +58,26 @@ func DefaultGlobalConfigPath() (string, error) {
 }
 
 type GlobalConfigV1 struct {
-	SchemaVersion int                `json:"schemaVersion"`
-	OutRoot       string             `json:"outRoot,omitempty"`
-	Redaction     *RedactionConfigV1 `json:"redaction,omitempty"`
-	Runtime       RuntimeConfigV1    `json:"runtime,omitempty"`
+	SchemaVersion int                  `json:"schemaVersion"`
+	OutRoot       string               `json:"outRoot,omitempty"`
+	Redaction     *RedactionConfigV1   `json:"redaction,omitempty"`
+	Runtime       RuntimeConfigV1      `json:"runtime,omitempty"`
+	Sync          *SyncConfigV1        `json:"sync,omitempty"`
+	Retention     *RetentionConfigV1   `json:"retention,omitempty"`
+	Encryption    *EncryptionConfigV1  `json:"encryption,omitempty"`
+	ExitCodes     *ExitCodesConfigV1   `json:"exitCodes,omitempty"`
+	CampaignState string               `json:"campaignState,omitempty"`
+	Profiles      map[string]ProfileV1 `json:"profiles,omitempty"`
 }
 
 func LoadMerged(flagOutRoot string) (Merged, error) {
 	// Precedence:
 	// 1) CLI flags
 	// 2) env vars
-	// 3) project config (zcl.config.json)
-	// 4) global config (~/.zcl/config.json)
-	// 5) defaults
+	// 3) selected profile (--profile / ZCL_PROFILE; project profiles shadow global ones)
+	// 4) project config (zcl.config.json)
+	// 5) global config (~/.zcl/config.json)
+	// 6) defaults
 	projectCfg, hasProjectCfg, err := loadProject(DefaultProjectConfigPath)
 	if err != nil {
 		return Merged{}, err
@@ -53,6 +91,11 @@ func LoadMerged(flagOutRoot string) (Merged, error) {
 		return Merged{}, err
 	}
 
+	profileName, profileSource, profile, err := resolveProfile(projectCfg.Profiles, globalCfg.Profiles, globalPath)
+	if err != nil {
+		return Merged{}, err
+	}
+
 	res := Merged{
 		OutRoot:               ".zcl",
 		Source:                "default",
@@ -65,6 +108,9 @@ func LoadMerged(flagOutRoot string) (Merged, error) {
 	} else if v := strings.TrimSpace(os.Getenv("ZCL_OUT_ROOT")); v != "" {
 		res.OutRoot = v
 		res.Source = "env:ZCL_OUT_ROOT"
+	} else if v := strings.TrimSpace(profile.OutRoot); v != "" {
+		res.OutRoot = v
+		res.Source = "profile:" + profileName
 	} else if hasProjectCfg {
 		res.OutRoot = projectCfg.OutRoot
 		res.Source = DefaultProjectConfigPath
@@ -76,6 +122,9 @@ func LoadMerged(flagOutRoot string) (Merged, error) {
 	if v := ParseRuntimeStrategyCSV(os.Getenv("ZCL_RUNTIME_STRATEGIES")); len(v) > 0 {
 		res.RuntimeStrategyChain = v
 		res.RuntimeStrategySource = "env:ZCL_RUNTIME_STRATEGIES"
+	} else if chain := NormalizeRuntimeStrategyChain(profile.Runtime.StrategyChain); len(chain) > 0 {
+		res.RuntimeStrategyChain = chain
+		res.RuntimeStrategySource = "profile:" + profileName
 	} else if hasProjectCfg {
 		if chain := NormalizeRuntimeStrategyChain(projectCfg.Runtime.StrategyChain); len(chain) > 0 {
 			res.RuntimeStrategyChain = chain
@@ -87,6 +136,17 @@ func LoadMerged(flagOutRoot string) (Merged, error) {
 			res.RuntimeStrategySource = globalPath
 		}
 	}
+	mergeSyncConfig(&res, projectCfg.Sync, globalCfg.Sync, globalPath)
+	mergeRetentionConfig(&res, projectCfg.Retention, globalCfg.Retention, globalPath)
+	mergeEncryptionConfig(&res, projectCfg.Encryption, globalCfg.Encryption, globalPath)
+	if err := mergeExitCodesConfig(&res, projectCfg.ExitCodes, globalCfg.ExitCodes, globalPath); err != nil {
+		return Merged{}, err
+	}
+	if err := mergeCampaignStateConfig(&res, projectCfg.CampaignState, globalCfg.CampaignState, globalPath); err != nil {
+		return Merged{}, err
+	}
+	res.Profile, res.ProfileSource = profileName, profileSource
+	applyProfile(&res, profile)
 	return res, nil
 }
 
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -86,6 +87,60 @@ func TestLoadMerged_RuntimeStrategyChainPrecedence(t *testing.T) {
 	}
 }
 
+func TestLoadMerged_RetentionFromProjectOverGlobal(t *testing.T) {
+	dir := t.TempDir()
+	wd := mustGetwd(t)
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	mustNoErr(t, "chdir", os.Chdir(dir))
+
+	home := filepath.Join(dir, "home")
+	mustNoErr(t, "mkdir", os.MkdirAll(home, 0o755))
+	t.Setenv("HOME", home)
+	globalPath := mustGlobalConfigPath(t)
+	mustNoErr(t, "mkdir", os.MkdirAll(filepath.Dir(globalPath), 0o755))
+	mustNoErr(t, "write global", os.WriteFile(globalPath, []byte(`{"schemaVersion":1,"retention":{"keepRuns":10,"keepFailed":3}}`), 0o644))
+
+	m := mustLoadMerged(t, "")
+	if m.Retention.KeepRuns != 10 || m.Retention.KeepFailed != 3 || m.RetentionSource != globalPath {
+		t.Fatalf("unexpected global retention: %+v (%s)", m.Retention, m.RetentionSource)
+	}
+
+	mustNoErr(t, "write project", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","retention":{"keepRuns":50,"keepDays":30,"keepFailed":"all"}}`), 0o644))
+	m = mustLoadMerged(t, "")
+	if m.Retention.KeepRuns != 50 || m.Retention.KeepDays != 30 || m.Retention.KeepFailed != KeepFailedAll {
+		t.Fatalf("unexpected project retention: %+v", m.Retention)
+	}
+
+	mustNoErr(t, "write project", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","retention":{"keepFailed":"some"}}`), 0o644))
+	if _, err := LoadMerged(""); err == nil {
+		t.Fatalf("expected invalid keepFailed to fail config load")
+	}
+}
+
+func TestLoadMerged_ExitCodesResolveDefaultsAndRejectInvalid(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+
+	m := mustLoadMerged(t, "")
+	if got := m.ExitCodes.Resolved(); got.HarnessError != 1 || got.InvalidRun != 2 || got.Usage != 2 {
+		t.Fatalf("unexpected default exit codes: %+v", got)
+	}
+
+	mustNoErr(t, "write project", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","exitCodes":{"invalidRun":3}}`), 0o644))
+	m = mustLoadMerged(t, "")
+	if got := m.ExitCodes.Resolved(); got.HarnessError != 1 || got.InvalidRun != 3 || got.Usage != 2 || m.ExitCodesSource != DefaultProjectConfigPath {
+		t.Fatalf("unexpected project exit codes: %+v (%s)", got, m.ExitCodesSource)
+	}
+
+	mustNoErr(t, "write project", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","exitCodes":{"usage":300}}`), 0o644))
+	if _, err := LoadMerged(""); err == nil {
+		t.Fatalf("expected out-of-range exit code to fail config load")
+	}
+}
+
 func mustGetwd(t *testing.T) string {
 	t.Helper()
 	wd, err := os.Getwd()
@@ -113,3 +168,76 @@ func mustNoErr(t *testing.T, op string, err error) {
 		t.Fatalf("%s: %v", op, err)
 	}
 }
+
+func TestLoadMerged_CampaignStateBackend(t *testing.T) {
+	dir := t.TempDir()
+	wd := mustGetwd(t)
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	mustNoErr(t, "chdir", os.Chdir(dir))
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+
+	m := mustLoadMerged(t, "")
+	if m.CampaignState != CampaignStateFile || m.CampaignStateSource != "default" {
+		t.Fatalf("unexpected default: %+v", m)
+	}
+	mustNoErr(t, "write", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","campaignState":"sqlite"}`), 0o644))
+	m = mustLoadMerged(t, "")
+	if m.CampaignState != CampaignStateSQLite || m.CampaignStateSource != DefaultProjectConfigPath {
+		t.Fatalf("unexpected project: %+v", m)
+	}
+	t.Setenv("ZCL_CAMPAIGN_STATE", "postgres")
+	if _, err := LoadMerged(""); err == nil {
+		t.Fatalf("expected unknown backend to be rejected")
+	}
+}
+
+func TestLoadMerged_Profiles(t *testing.T) {
+	dir := t.TempDir()
+	wd := mustGetwd(t)
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+		SelectProfile("")
+	})
+	mustNoErr(t, "chdir", os.Chdir(dir))
+	home := filepath.Join(dir, "home")
+	t.Setenv("HOME", home)
+	mustNoErr(t, "mkdir", os.MkdirAll(filepath.Join(home, ".zcl"), 0o755))
+	mustNoErr(t, "write global", os.WriteFile(filepath.Join(home, ".zcl", "config.json"), []byte(`{"schemaVersion":1,"profiles":{
+  "staging":{"outRoot":"global-staging"},
+  "gpu":{"budgets":{"timeoutMs":900000,"parallel":4}}
+}}`), 0o644))
+	mustNoErr(t, "write project", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","profiles":{
+  "staging":{"outRoot":".zcl-staging","runtime":{"strategyChain":["provider_stub"]},"native":{"model":"gpt-5-mini","reasoningEffort":"LOW"}}
+}}`), 0o644))
+
+	m := mustLoadMerged(t, "")
+	if m.Profile != "" || m.OutRoot != ".zcl" {
+		t.Fatalf("no profile selected, got %+v", m)
+	}
+
+	SelectProfile("staging")
+	m = mustLoadMerged(t, "")
+	if m.Profile != "staging" || m.OutRoot != ".zcl-staging" || m.Source != "profile:staging" {
+		t.Fatalf("project profile should shadow the global one: %+v", m)
+	}
+	if len(m.RuntimeStrategyChain) != 1 || m.RuntimeStrategyChain[0] != "provider_stub" || m.NativeModel != "gpt-5-mini" || m.NativeReasoningEffort != "low" {
+		t.Fatalf("unexpected profile runtime/native: %+v", m)
+	}
+	if m = mustLoadMerged(t, "flag-root"); m.OutRoot != "flag-root" {
+		t.Fatalf("--out-root must win over the profile: %+v", m)
+	}
+
+	SelectProfile("")
+	t.Setenv("ZCL_PROFILE", "gpu")
+	m = mustLoadMerged(t, "")
+	if m.ProfileSource != "env:ZCL_PROFILE ("+filepath.Join(home, ".zcl", "config.json")+")" || m.Budgets.TimeoutMs != 900000 || m.Budgets.Parallel != 4 || m.OutRoot != ".zcl" {
+		t.Fatalf("unexpected env-selected global profile: %+v", m)
+	}
+
+	SelectProfile("prod")
+	if _, err := LoadMerged(""); err == nil || !strings.Contains(err.Error(), "gpu, staging") {
+		t.Fatalf("expected unknown profile error listing configured names, got %v", err)
+	}
+}
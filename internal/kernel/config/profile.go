@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProfileV1 is a named overlay on top of the base config, selected with `zcl --profile <name>` or
+// ZCL_PROFILE. Set fields win over the project/global base values; flags and the dedicated env vars
+// (ZCL_OUT_ROOT, ZCL_RUNTIME_STRATEGIES) still win over the profile.
+type ProfileV1 struct {
+	OutRoot string            `json:"outRoot,omitempty"`
+	Runtime RuntimeConfigV1   `json:"runtime,omitempty"`
+	Native  *NativeDefaultsV1 `json:"native,omitempty"`
+	Budgets *BudgetsV1        `json:"budgets,omitempty"`
+}
+
+// NativeDefaultsV1 are suite run defaults for --native-model* when those flags are not given.
+type NativeDefaultsV1 struct {
+	Model           string `json:"model,omitempty"`
+	ReasoningEffort string `json:"reasoningEffort,omitempty"`
+	ReasoningPolicy string `json:"reasoningPolicy,omitempty"`
+}
+
+// BudgetsV1 are suite run defaults for --timeout-ms and --parallel; zero means unset.
+type BudgetsV1 struct {
+	TimeoutMs int64 `json:"timeoutMs,omitempty"`
+	Parallel  int   `json:"parallel,omitempty"`
+}
+
+var selected struct {
+	mu   sync.Mutex
+	name string
+}
+
+// SelectProfile records the `--profile` flag for this process; an empty name falls back to
+// ZCL_PROFILE. The CLI calls it once per invocation before any config is loaded.
+func SelectProfile(name string) {
+	selected.mu.Lock()
+	defer selected.mu.Unlock()
+	selected.name = strings.TrimSpace(name)
+}
+
+func selectedProfile() (string, string) {
+	selected.mu.Lock()
+	name := selected.name
+	selected.mu.Unlock()
+	if name != "" {
+		return name, "flag"
+	}
+	if v := strings.TrimSpace(os.Getenv("ZCL_PROFILE")); v != "" {
+		return v, "env:ZCL_PROFILE"
+	}
+	return "", ""
+}
+
+// resolveProfile looks the selected profile up in the project config first, then the global one.
+// Naming a profile that neither file defines is an error, so a typo never silently runs against
+// the base environment.
+func resolveProfile(project, global map[string]ProfileV1, globalPath string) (string, string, ProfileV1, error) {
+	name, source := selectedProfile()
+	if name == "" {
+		return "", "", ProfileV1{}, nil
+	}
+	if p, ok := project[name]; ok {
+		return name, source + " (" + DefaultProjectConfigPath + ")", p, nil
+	}
+	if p, ok := global[name]; ok {
+		return name, source + " (" + globalPath + ")", p, nil
+	}
+	known := map[string]bool{}
+	for k := range project {
+		known[k] = true
+	}
+	for k := range global {
+		known[k] = true
+	}
+	names := make([]string, 0, len(known))
+	for k := range known {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return "", "", ProfileV1{}, fmt.Errorf("unknown profile %q (no profiles configured)", name)
+	}
+	return "", "", ProfileV1{}, fmt.Errorf("unknown profile %q (configured: %s)", name, strings.Join(names, ", "))
+}
+
+func applyProfile(res *Merged, p ProfileV1) {
+	if p.Native != nil {
+		res.NativeModel = strings.TrimSpace(p.Native.Model)
+		res.NativeReasoningEffort = strings.ToLower(strings.TrimSpace(p.Native.ReasoningEffort))
+		res.NativeReasoningPolicy = strings.ToLower(strings.TrimSpace(p.Native.ReasoningPolicy))
+	}
+	if p.Budgets != nil {
+		res.Budgets = *p.Budgets
+	}
+}
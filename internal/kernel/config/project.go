@@ -18,10 +18,16 @@ const (
 // ProjectConfigV1 is the minimal per-repo config created by `zcl init`.
 // It is intentionally tiny; richer config merge logic comes later.
 type ProjectConfigV1 struct {
-	SchemaVersion int                `json:"schemaVersion"`
-	OutRoot       string             `json:"outRoot"`
-	Redaction     *RedactionConfigV1 `json:"redaction,omitempty"`
-	Runtime       RuntimeConfigV1    `json:"runtime,omitempty"`
+	SchemaVersion int                  `json:"schemaVersion"`
+	OutRoot       string               `json:"outRoot"`
+	Redaction     *RedactionConfigV1   `json:"redaction,omitempty"`
+	Runtime       RuntimeConfigV1      `json:"runtime,omitempty"`
+	Sync          *SyncConfigV1        `json:"sync,omitempty"`
+	Retention     *RetentionConfigV1   `json:"retention,omitempty"`
+	Encryption    *EncryptionConfigV1  `json:"encryption,omitempty"`
+	ExitCodes     *ExitCodesConfigV1   `json:"exitCodes,omitempty"`
+	CampaignState string               `json:"campaignState,omitempty"`
+	Profiles      map[string]ProfileV1 `json:"profiles,omitempty"`
 }
 
 type InitResult struct {
@@ -20,29 +20,124 @@ type RedactionRuleV1 struct {
 
 type RedactionConfigV1 struct {
 	ExtraRules []RedactionRuleV1 `json:"extraRules,omitempty"`
+	// RulesFile points to a RedactionRulesFileV1 with org-specific rules and allowlists; relative
+	// paths resolve against the directory of the config file that names it.
+	RulesFile string `json:"rulesFile,omitempty"`
 }
 
-// LoadRedactionMerged loads configured extra redaction rules from:
-// - global config (~/.zcl/config.json) when present
-// - project config (zcl.config.json) when present
-//
-// Project rules override global rules when IDs collide.
+const RedactionRulesFileSchemaV1 = 1
+
+// RedactionRulesFileV1 is the redaction.rulesFile format. Its rules are applied after extraRules
+// of the same config file.
+type RedactionRulesFileV1 struct {
+	SchemaVersion int                    `json:"schemaVersion"`
+	Rules         []RedactionRuleV1      `json:"rules,omitempty"`
+	Allowlists    []RedactionAllowlistV1 `json:"allowlists,omitempty"`
+}
+
+// RedactionAllowlistV1 leaves a match unredacted when the whole match is matched by one of
+// Patterns, for documented example keys and test fixtures. Rules names the built-in rules or rule
+// ids it applies to; empty means every rule.
+type RedactionAllowlistV1 struct {
+	ID       string   `json:"id"`
+	Patterns []string `json:"patterns"`
+	Rules    []string `json:"rules,omitempty"`
+}
+
+// RedactionPolicyV1 is the merged configured redaction on top of the built-in rules.
+type RedactionPolicyV1 struct {
+	Rules      []RedactionRuleV1
+	Allowlists []RedactionAllowlistV1
+}
+
+// LoadRedactionMerged loads configured extra redaction rules; see LoadRedactionPolicyMerged.
 func LoadRedactionMerged() ([]RedactionRuleV1, error) {
+	p, err := LoadRedactionPolicyMerged()
+	return p.Rules, err
+}
+
+// LoadRedactionPolicyMerged loads configured extra redaction rules and allowlists from:
+// - global config (~/.zcl/config.json) when present, then its redaction.rulesFile
+// - project config (zcl.config.json) when present, then its redaction.rulesFile
+//
+// Later sources override earlier ones when rule or allowlist IDs collide.
+func LoadRedactionPolicyMerged() (RedactionPolicyV1, error) {
 	merged := map[string]RedactionRuleV1{}
-	if err := loadGlobalRedactionRules(merged); err != nil {
-		return nil, err
+	allow := map[string]RedactionAllowlistV1{}
+	if err := loadGlobalRedactionRules(merged, allow); err != nil {
+		return RedactionPolicyV1{}, err
+	}
+	if err := loadProjectRedactionRules(merged, allow); err != nil {
+		return RedactionPolicyV1{}, err
+	}
+	out := RedactionPolicyV1{Rules: redactionRulesFromMap(merged)}
+	if err := ValidateRedactionRules(out.Rules); err != nil {
+		return RedactionPolicyV1{}, err
 	}
-	if err := loadProjectRedactionRules(merged); err != nil {
-		return nil, err
+	for _, id := range sortedKeys(allow) {
+		out.Allowlists = append(out.Allowlists, allow[id])
 	}
-	out := redactionRulesFromMap(merged)
-	if err := ValidateRedactionRules(out); err != nil {
-		return nil, err
+	if err := ValidateRedactionAllowlists(out.Allowlists); err != nil {
+		return RedactionPolicyV1{}, err
 	}
 	return out, nil
 }
 
-func loadGlobalRedactionRules(merged map[string]RedactionRuleV1) error {
+// LoadRedactionRulesFile reads and validates a redaction rules file.
+func LoadRedactionRulesFile(path string) (RedactionRulesFileV1, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return RedactionRulesFileV1{}, fmt.Errorf("redaction rules file: %w", err)
+	}
+	var f RedactionRulesFileV1
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return RedactionRulesFileV1{}, fmt.Errorf("invalid redaction rules file json (%s): %w", path, err)
+	}
+	if f.SchemaVersion != RedactionRulesFileSchemaV1 {
+		return RedactionRulesFileV1{}, fmt.Errorf("redaction rules file %s unsupported schemaVersion=%d", path, f.SchemaVersion)
+	}
+	if err := ValidateRedactionRules(f.Rules); err != nil {
+		return RedactionRulesFileV1{}, fmt.Errorf("redaction rules file %s: %w", path, err)
+	}
+	if err := ValidateRedactionAllowlists(f.Allowlists); err != nil {
+		return RedactionRulesFileV1{}, fmt.Errorf("redaction rules file %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// ResolveRedactionRulesFile resolves cfg.rulesFile against the directory of configPath.
+func ResolveRedactionRulesFile(configPath string, cfg *RedactionConfigV1) string {
+	if cfg == nil || strings.TrimSpace(cfg.RulesFile) == "" {
+		return ""
+	}
+	p := strings.TrimSpace(cfg.RulesFile)
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(filepath.Dir(configPath), p)
+}
+
+func mergeRedactionConfig(configPath string, cfg *RedactionConfigV1, merged map[string]RedactionRuleV1, allow map[string]RedactionAllowlistV1) error {
+	if cfg == nil {
+		return nil
+	}
+	mergeRedactionRules(merged, cfg.ExtraRules)
+	path := ResolveRedactionRulesFile(configPath, cfg)
+	if path == "" {
+		return nil
+	}
+	f, err := LoadRedactionRulesFile(path)
+	if err != nil {
+		return err
+	}
+	mergeRedactionRules(merged, f.Rules)
+	for _, a := range f.Allowlists {
+		allow[strings.TrimSpace(a.ID)] = a
+	}
+	return nil
+}
+
+func loadGlobalRedactionRules(merged map[string]RedactionRuleV1, allow map[string]RedactionAllowlistV1) error {
 	p, err := DefaultGlobalConfigPath()
 	if err != nil {
 		return nil
@@ -61,13 +156,10 @@ func loadGlobalRedactionRules(merged map[string]RedactionRuleV1) error {
 	if g.SchemaVersion != 1 {
 		return fmt.Errorf("global config unsupported schemaVersion=%d", g.SchemaVersion)
 	}
-	if g.Redaction != nil {
-		mergeRedactionRules(merged, g.Redaction.ExtraRules)
-	}
-	return nil
+	return mergeRedactionConfig(p, g.Redaction, merged, allow)
 }
 
-func loadProjectRedactionRules(merged map[string]RedactionRuleV1) error {
+func loadProjectRedactionRules(merged map[string]RedactionRuleV1, allow map[string]RedactionAllowlistV1) error {
 	if raw, err := os.ReadFile(DefaultProjectConfigPath); err == nil {
 		var p ProjectConfigV1
 		if err := json.Unmarshal(raw, &p); err != nil {
@@ -76,9 +168,7 @@ func loadProjectRedactionRules(merged map[string]RedactionRuleV1) error {
 		if p.SchemaVersion != ProjectConfigSchemaV1 {
 			return fmt.Errorf("project config unsupported schemaVersion=%d", p.SchemaVersion)
 		}
-		if p.Redaction != nil {
-			mergeRedactionRules(merged, p.Redaction.ExtraRules)
-		}
+		return mergeRedactionConfig(DefaultProjectConfigPath, p.Redaction, merged, allow)
 	} else if err != nil && !os.IsNotExist(err) {
 		return err
 	}
@@ -110,6 +200,39 @@ func ValidateRedactionRules(rules []RedactionRuleV1) error {
 	return nil
 }
 
+// ValidateRedactionAllowlists checks allowlist ids and patterns like rule ids and regexes.
+func ValidateRedactionAllowlists(lists []RedactionAllowlistV1) error {
+	if len(lists) > 128 {
+		return fmt.Errorf("too many redaction allowlists (max 128)")
+	}
+	seen := map[string]bool{}
+	for _, a := range lists {
+		id := strings.TrimSpace(a.ID)
+		if id == "" {
+			return fmt.Errorf("redaction allowlist id is missing")
+		}
+		if ids.SanitizeComponent(id) != id {
+			return fmt.Errorf("redaction allowlist id %q is not canonical (use lowercase kebab-case)", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate redaction allowlist id %q", id)
+		}
+		seen[id] = true
+		if len(a.Patterns) == 0 || len(a.Patterns) > 64 {
+			return fmt.Errorf("redaction allowlist %q needs 1..64 patterns", id)
+		}
+		for _, p := range a.Patterns {
+			if len(p) > 4096 {
+				return fmt.Errorf("redaction allowlist %q pattern too long", id)
+			}
+			if _, err := regexp.Compile(p); err != nil {
+				return fmt.Errorf("redaction allowlist %q pattern invalid: %v", id, err)
+			}
+		}
+	}
+	return nil
+}
+
 func mergeRedactionRules(merged map[string]RedactionRuleV1, rules []RedactionRuleV1) {
 	for _, r := range rules {
 		merged[strings.TrimSpace(r.ID)] = r
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadRedactionPolicyMerged_ReadsRulesFiles(t *testing.T) {
+	dir := t.TempDir()
+	wd := mustGetwd(t)
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	mustNoErr(t, "chdir", os.Chdir(dir))
+
+	home := filepath.Join(dir, "home")
+	t.Setenv("HOME", home)
+	globalPath := mustGlobalConfigPath(t)
+	mustNoErr(t, "mkdir", os.MkdirAll(filepath.Dir(globalPath), 0o755))
+	mustNoErr(t, "write global", os.WriteFile(globalPath, []byte(`{"schemaVersion":1,"redaction":{"rulesFile":"org.rules.json"}}`), 0o644))
+	mustNoErr(t, "write global rules", os.WriteFile(filepath.Join(filepath.Dir(globalPath), "org.rules.json"), []byte(`{"schemaVersion":1,
+		"rules":[{"id":"acme-token","regex":"acme_[a-z0-9]{12}"}],
+		"allowlists":[{"id":"docs","patterns":["acme_0+"]}]}`), 0o644))
+	mustNoErr(t, "mkdir", os.MkdirAll("config", 0o755))
+	mustNoErr(t, "write project", os.WriteFile(DefaultProjectConfigPath, []byte(`{"schemaVersion":1,"outRoot":".zcl","redaction":{"extraRules":[{"id":"acme-token","regex":"inline"}],"rulesFile":"config/redaction.json"}}`), 0o644))
+	mustNoErr(t, "write project rules", os.WriteFile(filepath.Join("config", "redaction.json"), []byte(`{"schemaVersion":1,
+		"rules":[{"id":"acme-token","regex":"acme_[A-Z0-9]{16}"}],
+		"allowlists":[{"id":"fixtures","patterns":["sk-TEST\\w+"],"rules":["openai_key"]}]}`), 0o644))
+
+	p, err := LoadRedactionPolicyMerged()
+	mustNoErr(t, "LoadRedactionPolicyMerged", err)
+	if len(p.Rules) != 1 || p.Rules[0].Regex != "acme_[A-Z0-9]{16}" {
+		t.Fatalf("expected project rules file to override global and inline rules: %+v", p.Rules)
+	}
+	if len(p.Allowlists) != 2 || p.Allowlists[0].ID != "docs" || p.Allowlists[1].ID != "fixtures" {
+		t.Fatalf("unexpected allowlists: %+v", p.Allowlists)
+	}
+
+	mustNoErr(t, "write project rules", os.WriteFile(filepath.Join("config", "redaction.json"), []byte(`{"schemaVersion":1,"allowlists":[{"id":"bad","patterns":["("]}]}`), 0o644))
+	if _, err := LoadRedactionPolicyMerged(); err == nil || !strings.Contains(err.Error(), `allowlist "bad" pattern invalid`) {
+		t.Fatalf("expected invalid allowlist pattern error, got: %v", err)
+	}
+}
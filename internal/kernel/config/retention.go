@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KeepFailedAll keeps every run that contains a failed attempt.
+const KeepFailedAll = -1
+
+// RetentionConfigV1 configures `zcl gc` defaults:
+//
+//	"retention": {"keepRuns": 50, "keepDays": 30, "keepFailed": "all"}
+//
+// A run is pruned only when it is outside both the newest keepRuns and the last keepDays.
+type RetentionConfigV1 struct {
+	KeepRuns   int        `json:"keepRuns,omitempty"`
+	KeepDays   int        `json:"keepDays,omitempty"`
+	KeepFailed KeepFailed `json:"keepFailed,omitempty"`
+}
+
+// KeepFailed is "all" (KeepFailedAll), "none"/0, or a count of newest failed runs to keep.
+type KeepFailed int
+
+func (k *KeepFailed) UnmarshalJSON(b []byte) error {
+	var n int
+	if err := json.Unmarshal(b, &n); err == nil {
+		if n < 0 {
+			return fmt.Errorf("keepFailed must be >= 0, \"all\" or \"none\"")
+		}
+		*k = KeepFailed(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("keepFailed must be a number, \"all\" or \"none\"")
+	}
+	v, err := ParseKeepFailed(s)
+	if err != nil {
+		return err
+	}
+	*k = v
+	return nil
+}
+
+func (k KeepFailed) MarshalJSON() ([]byte, error) {
+	if k == KeepFailedAll {
+		return json.Marshal("all")
+	}
+	return json.Marshal(int(k))
+}
+
+// ParseKeepFailed parses the --keep-failed flag / config string form.
+func ParseKeepFailed(s string) (KeepFailed, error) {
+	switch v := strings.ToLower(strings.TrimSpace(s)); v {
+	case "all":
+		return KeepFailedAll, nil
+	case "", "none":
+		return 0, nil
+	default:
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("keepFailed must be a number >= 0, \"all\" or \"none\" (got %q)", s)
+		}
+		return KeepFailed(n), nil
+	}
+}
+
+func mergeRetentionConfig(res *Merged, project, global *RetentionConfigV1, globalPath string) {
+	switch {
+	case project != nil:
+		res.Retention = *project
+		res.RetentionSource = DefaultProjectConfigPath
+	case global != nil:
+		res.Retention = *global
+		res.RetentionSource = globalPath
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SyncConfigV1 configures `zcl sync`. Dest is a URI (s3://bucket/prefix, gs://bucket/prefix or
+// file:///path); Auto syncs a campaign right after `zcl campaign run|resume` finishes.
+type SyncConfigV1 struct {
+	Dest string `json:"dest,omitempty"`
+	Auto bool   `json:"auto,omitempty"`
+}
+
+var syncDestSchemes = []string{"s3://", "gs://", "file://"}
+
+// mergeSyncConfig applies sync precedence: env (ZCL_SYNC_DEST, ZCL_SYNC_AUTO) > project > global.
+func mergeSyncConfig(res *Merged, project, global *SyncConfigV1, globalPath string) {
+	switch {
+	case project != nil && strings.TrimSpace(project.Dest) != "":
+		res.Sync = *project
+		res.SyncSource = DefaultProjectConfigPath
+	case global != nil && strings.TrimSpace(global.Dest) != "":
+		res.Sync = *global
+		res.SyncSource = globalPath
+	}
+	if v := strings.TrimSpace(os.Getenv("ZCL_SYNC_DEST")); v != "" {
+		res.Sync.Dest = v
+		res.SyncSource = "env:ZCL_SYNC_DEST"
+	}
+	if v := strings.TrimSpace(os.Getenv("ZCL_SYNC_AUTO")); v != "" {
+		res.Sync.Auto = v == "1" || strings.EqualFold(v, "true")
+	}
+	res.Sync.Dest = strings.TrimSpace(res.Sync.Dest)
+}
+
+// ValidateSyncDest checks that dest uses a supported scheme and names a bucket or path.
+func ValidateSyncDest(dest string) error {
+	dest = strings.TrimSpace(dest)
+	for _, scheme := range syncDestSchemes {
+		if strings.HasPrefix(dest, scheme) {
+			if strings.Trim(strings.TrimPrefix(dest, scheme), "/") == "" {
+				return fmt.Errorf("sync dest %q is missing a bucket or path", dest)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("sync dest %q must start with one of %s", dest, strings.Join(syncDestSchemes, ", "))
+}
@@ -0,0 +1,156 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect emitted by Generate.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+	timeType       = reflect.TypeOf(time.Time{})
+)
+
+// Generate derives a JSON Schema document from the json tags of v's Go type.
+// Fields without omitempty are required; named nested structs become $defs entries.
+func Generate(v any, id, title string) map[string]any {
+	g := generator{defs: map[string]any{}}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	root := g.structSchema(t)
+	root["$schema"] = Draft
+	if id != "" {
+		root["$id"] = id
+	}
+	if title != "" {
+		root["title"] = title
+	}
+	if len(g.defs) > 0 {
+		root["$defs"] = g.defs
+	}
+	return root
+}
+
+type generator struct {
+	defs map[string]any
+}
+
+func (g *generator) typeSchema(t reflect.Type) map[string]any {
+	switch t {
+	case rawMessageType:
+		return map[string]any{}
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := t.Name()
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = map[string]any{} // placeholder breaks recursion
+			g.defs[name] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (g *generator) structSchema(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+	g.collectFields(t, props, &required)
+	out := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		sort.Strings(required)
+		out["required"] = required
+	}
+	return out
+}
+
+func (g *generator) collectFields(t reflect.Type, props map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, omitEmpty, skip := jsonFieldName(f)
+		if skip {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.collectFields(ft, props, required)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fs := g.typeSchema(f.Type)
+		if !omitEmpty {
+			*required = append(*required, name)
+			if nullable(f.Type) {
+				// encoding/json writes nil pointers, slices and maps as null.
+				fs = map[string]any{"anyOf": []any{fs, map[string]any{"type": "null"}}}
+			}
+		}
+		props[name] = fs
+	}
+}
+
+func jsonFieldName(f reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		return "", false, false
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, false
+}
+
+func nullable(t reflect.Type) bool {
+	if t == rawMessageType {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type genChild struct {
+	Name string `json:"name"`
+}
+
+type genRoot struct {
+	ID       string            `json:"id"`
+	Count    int64             `json:"count,omitempty"`
+	Ratio    float64           `json:"ratio"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Child    genChild          `json:"child"`
+	Optional *genChild         `json:"optional,omitempty"`
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Hidden   string            `json:"-"`
+	internal string
+}
+
+func TestGenerate_DerivesPropertiesRequiredAndDefs(t *testing.T) {
+	doc := Generate(genRoot{}, "https://example.test/root.json", "Root")
+	if doc["$schema"] != Draft || doc["$id"] != "https://example.test/root.json" || doc["title"] != "Root" {
+		t.Fatalf("unexpected header: %+v", doc)
+	}
+	if got, want := doc["required"], []string{"child", "id", "ratio", "tags"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("required=%v want %v", got, want)
+	}
+	props := doc["properties"].(map[string]any)
+	if _, ok := props["Hidden"]; ok {
+		t.Fatalf("json:\"-\" field must be skipped")
+	}
+	if _, ok := props["internal"]; ok {
+		t.Fatalf("unexported field must be skipped")
+	}
+	if got := props["count"]; !reflect.DeepEqual(got, map[string]any{"type": "integer"}) {
+		t.Fatalf("count schema=%v", got)
+	}
+	if got := props["child"]; !reflect.DeepEqual(got, map[string]any{"$ref": "#/$defs/genChild"}) {
+		t.Fatalf("child schema=%v", got)
+	}
+	tags := props["tags"].(map[string]any)
+	if _, ok := tags["anyOf"]; !ok {
+		t.Fatalf("required slice must allow null, got %v", tags)
+	}
+	if got := props["raw"]; !reflect.DeepEqual(got, map[string]any{}) {
+		t.Fatalf("raw schema=%v", got)
+	}
+	defs := doc["$defs"].(map[string]any)
+	child := defs["genChild"].(map[string]any)
+	if !reflect.DeepEqual(child["required"], []string{"name"}) {
+		t.Fatalf("unexpected child def: %v", child)
+	}
+}
@@ -0,0 +1,115 @@
+package provenance
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+)
+
+const probeTimeout = 3 * time.Second
+
+// versionProbes lists binaries that are safe to run with --version. Arbitrary runner commands are
+// never executed here (they may be the agent itself); they are identified by path and hash only.
+var versionProbes = map[string]bool{
+	"codex":  true,
+	"claude": true,
+}
+
+var (
+	hostOnce sync.Once
+	host     schema.AttemptProvenanceV1
+
+	binMu    sync.Mutex
+	binCache = map[string]schema.RuntimeBinaryV1{}
+)
+
+// Collect builds the attempt.json provenance block. Repo/host facts and binary lookups are cached
+// for the process: every attempt one zcl invocation starts shares them.
+func Collect(zclVersion string, runtimeBins []string) *schema.AttemptProvenanceV1 {
+	hostOnce.Do(func() {
+		host = schema.AttemptProvenanceV1{OS: runtime.GOOS, Arch: runtime.GOARCH}
+		host.Hostname, _ = os.Hostname()
+		host.GitCommit, host.GitDirty = gitHead()
+	})
+	p := host
+	p.ZCLVersion = strings.TrimSpace(zclVersion)
+	seen := map[string]bool{}
+	for _, bin := range runtimeBins {
+		bin = strings.TrimSpace(bin)
+		if bin == "" || seen[bin] {
+			continue
+		}
+		seen[bin] = true
+		p.Runtimes = append(p.Runtimes, resolveBinary(bin))
+	}
+	return &p
+}
+
+func gitHead() (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", false
+	}
+	commit := strings.TrimSpace(string(out))
+	status, err := exec.CommandContext(ctx, "git", "status", "--porcelain", "--untracked-files=no").Output()
+	return commit, err == nil && len(bytes.TrimSpace(status)) > 0
+}
+
+func resolveBinary(bin string) schema.RuntimeBinaryV1 {
+	binMu.Lock()
+	defer binMu.Unlock()
+	if v, ok := binCache[bin]; ok {
+		return v
+	}
+	name := strings.TrimSuffix(filepath.Base(bin), ".exe")
+	v := schema.RuntimeBinaryV1{Name: name}
+	if path, err := exec.LookPath(bin); err == nil {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		v.Path = path
+		v.SHA256 = fileSHA256(path)
+		if versionProbes[name] {
+			v.Version = probeVersion(path)
+		}
+	}
+	binCache[bin] = v
+	return v
+}
+
+func fileSHA256(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func probeVersion(path string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(string(out), "\n")
+	return strings.TrimSpace(line)
+}
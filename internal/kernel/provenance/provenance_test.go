@@ -0,0 +1,35 @@
+package provenance
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCollect_IdentifiesRunnerWithoutExecutingIt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script runner")
+	}
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "executed")
+	bin := filepath.Join(dir, "agent.sh")
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\ntouch "+marker+"\n"), 0o755); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	p := Collect("0.0.0-dev", []string{bin, bin, ""})
+	if p.ZCLVersion != "0.0.0-dev" || p.OS != runtime.GOOS || p.Arch != runtime.GOARCH {
+		t.Fatalf("unexpected provenance: %+v", p)
+	}
+	if len(p.Runtimes) != 1 {
+		t.Fatalf("expected one deduplicated runtime, got %+v", p.Runtimes)
+	}
+	rt := p.Runtimes[0]
+	if rt.Name != "agent.sh" || rt.Path != bin || len(rt.SHA256) != 64 || rt.Version != "" {
+		t.Fatalf("unexpected runtime entry: %+v", rt)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Fatalf("runner binary must not be executed for provenance")
+	}
+}
@@ -4,8 +4,23 @@ package schema
 // be the same number today. This lets us evolve (for example) attempt.report.json
 // without forcing a breaking change to run.json/attempt.json/feedback.json.
 const (
-	RunSchemaV1           = 1
-	AttemptSchemaV1       = 1
-	FeedbackSchemaV1      = 1
-	AttemptReportSchemaV1 = 1
+	RunSchemaV1                 = 1
+	AttemptSchemaV1             = 1
+	FeedbackSchemaV1            = 1
+	AttemptReportSchemaV1       = 1
+	TraceSamplingSchemaV1       = 1
+	ReviewSchemaV1              = 1
+	VerdictOverrideSchemaV1     = 1
+	ToolCassetteSchemaV1        = 1
+	DiskUsageSchemaV1           = 1
+	EnvFingerprintSchemaV1      = 1
+	ResourcesSchemaV1           = 1
+	FeedbackHistorySchemaV1     = 1
+	FeedbackProgressSchemaV1    = 1
+	ClaimVerifiedSchemaV1       = 1
+	PromptContaminationSchemaV1 = 1
+	RedactionVerifySchemaV1     = 1
+	PurgeSchemaV1               = 1
+	SuiteRunProgressSchemaV1    = 1
+	AttemptHeartbeatSchemaV1    = 1
 )
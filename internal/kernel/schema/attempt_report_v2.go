@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AttemptReportSchemaV2 drops the top-level failureCodeHistogram, which in v1 only mirrors
+// metrics.failuresByCode. Writers still emit v1; `zcl migrate --from 1 --to 2` upgrades runs.
+const AttemptReportSchemaV2 = 2
+
+// SupportedAttemptReportSchema reports whether readers understand an attempt.report.json version.
+func SupportedAttemptReportSchema(v int) bool {
+	return v == AttemptReportSchemaV1 || v == AttemptReportSchemaV2
+}
+
+// DecodeAttemptReport reads attempt.report.json v1 or v2 into the in-memory v1 shape, keeping
+// SchemaVersion as found so callers can still tell the versions apart.
+func DecodeAttemptReport(raw []byte) (AttemptReportJSONV1, error) {
+	var rep AttemptReportJSONV1
+	if err := json.Unmarshal(raw, &rep); err != nil {
+		return AttemptReportJSONV1{}, err
+	}
+	if rep.SchemaVersion == AttemptReportSchemaV2 && len(rep.FailureCodeHistogram) == 0 && len(rep.Metrics.FailuresByCode) > 0 {
+		rep.FailureCodeHistogram = make(map[string]int64, len(rep.Metrics.FailuresByCode))
+		for k, v := range rep.Metrics.FailuresByCode {
+			rep.FailureCodeHistogram[k] = v
+		}
+	}
+	return rep, nil
+}
+
+// UpgradeAttemptReportV1ToV2 rewrites a v1 report document as v2. Unknown fields are preserved.
+func UpgradeAttemptReportV1ToV2(raw []byte) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	var v int
+	if err := json.Unmarshal(doc["schemaVersion"], &v); err != nil || v != AttemptReportSchemaV1 {
+		return nil, fmt.Errorf("expected attempt.report.json schemaVersion %d", AttemptReportSchemaV1)
+	}
+	if hist, ok := doc["failureCodeHistogram"]; ok {
+		// Older reports may carry the histogram without metrics.failuresByCode; keep the data.
+		var metrics map[string]json.RawMessage
+		if m, ok := doc["metrics"]; ok {
+			if err := json.Unmarshal(m, &metrics); err != nil {
+				return nil, fmt.Errorf("metrics: %w", err)
+			}
+		}
+		if metrics == nil {
+			metrics = map[string]json.RawMessage{}
+		}
+		if _, ok := metrics["failuresByCode"]; !ok {
+			metrics["failuresByCode"] = hist
+			b, err := json.Marshal(metrics)
+			if err != nil {
+				return nil, err
+			}
+			doc["metrics"] = b
+		}
+		delete(doc, "failureCodeHistogram")
+	}
+	doc["schemaVersion"] = json.RawMessage(fmt.Sprint(AttemptReportSchemaV2))
+	return json.MarshalIndent(doc, "", "  ")
+}
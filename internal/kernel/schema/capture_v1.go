@@ -36,5 +36,10 @@ type CaptureEventV1 struct {
 	// RedactionsApplied is informational only; it lists rule IDs that were applied.
 	RedactionsApplied []string `json:"redactionsApplied,omitempty"`
 
+	// StdoutDropped/StderrDropped are set by `zcl runs compact` when a raw (redacted=false) capture
+	// was removed because its trace event already holds the complete redacted output.
+	StdoutDropped bool `json:"stdoutDropped,omitempty"`
+	StderrDropped bool `json:"stderrDropped,omitempty"`
+
 	MaxBytes int64 `json:"maxBytes"`
 }
@@ -1,33 +1,95 @@
 package schema
 
 import (
+	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 )
 
+// DecisionTagTaxonomyVersionV1 versions the built-in decision tag taxonomy. Bump it whenever a
+// built-in tag is added, removed or changes meaning, so cross-campaign tag analytics can tell
+// taxonomies apart.
+const DecisionTagTaxonomyVersionV1 = 1
+
 const (
 	DecisionTagSuccess            = "success"
 	DecisionTagBlocked            = "blocked"
 	DecisionTagTimeout            = "timeout"
 	DecisionTagContaminatedPrompt = "contaminated_prompt"
+	DecisionTagContaminatedOutput = "contaminated_output"
 	DecisionTagFunnelBypass       = "funnel_bypass"
 	DecisionTagMissingEvidence    = "missing_evidence"
 )
 
+// DecisionTagDefV1 describes one decision tag. Suites register custom tags with the same shape
+// (top-level decisionTags).
+type DecisionTagDefV1 struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+var decisionTagTaxonomyV1 = []DecisionTagDefV1{
+	{Name: DecisionTagSuccess, Description: "The mission outcome was reached."},
+	{Name: DecisionTagBlocked, Description: "The agent could not proceed (missing primitive, infra failure, network)."},
+	{Name: DecisionTagTimeout, Description: "The attempt ran out of time."},
+	{Name: DecisionTagContaminatedPrompt, Description: "The prompt leaked harness terms (blind mode)."},
+	{Name: DecisionTagContaminatedOutput, Description: "The answer echoed harness terms (blind mode)."},
+	{Name: DecisionTagFunnelBypass, Description: "Feedback exists without funnel evidence."},
+	{Name: DecisionTagMissingEvidence, Description: "Required evidence artifacts are missing."},
+}
+
+var customDecisionTagPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// DecisionTagTaxonomyV1 is the built-in decision tag taxonomy, in documentation order.
+func DecisionTagTaxonomyV1() []DecisionTagDefV1 {
+	return append([]DecisionTagDefV1(nil), decisionTagTaxonomyV1...)
+}
+
+// DecisionTagsV1 lists the built-in tag names, in documentation order.
+func DecisionTagsV1() []string {
+	out := make([]string, 0, len(decisionTagTaxonomyV1))
+	for _, d := range decisionTagTaxonomyV1 {
+		out = append(out, d.Name)
+	}
+	return out
+}
+
+// IsValidDecisionTagV1 reports whether s is a built-in tag.
 func IsValidDecisionTagV1(s string) bool {
-	switch strings.TrimSpace(s) {
-	case "":
-		return false
-	case DecisionTagSuccess,
-		DecisionTagBlocked,
-		DecisionTagTimeout,
-		DecisionTagContaminatedPrompt,
-		DecisionTagFunnelBypass,
-		DecisionTagMissingEvidence:
+	s = strings.TrimSpace(s)
+	for _, d := range decisionTagTaxonomyV1 {
+		if d.Name == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeclaredDecisionTagV1 reports whether s is a built-in tag or one of the suite's custom tags.
+func IsDeclaredDecisionTagV1(s string, custom []string) bool {
+	if IsValidDecisionTagV1(s) {
 		return true
-	default:
-		return false
 	}
+	s = strings.TrimSpace(s)
+	for _, c := range custom {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCustomDecisionTagV1 checks a suite-declared tag name: lowercase snake_case (max 64 bytes)
+// that does not shadow a built-in tag.
+func ValidateCustomDecisionTagV1(name string) error {
+	if !customDecisionTagPattern.MatchString(name) {
+		return fmt.Errorf("invalid decision tag name %q (expected lowercase snake_case, max 64 chars)", name)
+	}
+	if IsValidDecisionTagV1(name) {
+		return fmt.Errorf("decision tag %q is built in and cannot be redeclared", name)
+	}
+	return nil
 }
 
 func NormalizeDecisionTagsV1(tags []string) []string {
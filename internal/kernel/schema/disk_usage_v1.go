@@ -0,0 +1,14 @@
+package schema
+
+// DiskUsageJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/disk.usage.json
+// Bytes count regular files under the attempt dir and, for temp_empty_per_attempt runners, the
+// attempt workspace. PeakBytes is sampled while the runner ran (only polled under a quota).
+type DiskUsageJSONV1 struct {
+	SchemaVersion   int   `json:"schemaVersion"`
+	AttemptDirBytes int64 `json:"attemptDirBytes"`
+	WorkspaceBytes  int64 `json:"workspaceBytes,omitempty"`
+	TotalBytes      int64 `json:"totalBytes"`
+	PeakBytes       int64 `json:"peakBytes"`
+	QuotaBytes      int64 `json:"quotaBytes,omitempty"`
+	QuotaExceeded   bool  `json:"quotaExceeded,omitempty"`
+}
@@ -0,0 +1,37 @@
+package schema
+
+// EnvFingerprintJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/env.fingerprint.json
+// It is the normalized execution environment of the attempt's runner (probed once per suite run, on
+// the remote host for ssh runners). Hash covers every field except Host, binary paths and
+// ProbeError, so equal environments on different machines hash equal.
+type EnvFingerprintJSONV1 struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Hash          string `json:"hash"`
+	// Host is "local" or the ssh host the runner ran on.
+	Host       string          `json:"host"`
+	OS         string          `json:"os"`
+	Arch       string          `json:"arch"`
+	Kernel     string          `json:"kernel,omitempty"` // major.minor
+	Distro     string          `json:"distro,omitempty"` // os-release "<id> <versionId>" or "macos <version>"
+	CPUModel   string          `json:"cpuModel,omitempty"`
+	CPUs       int             `json:"cpus,omitempty"`
+	MemoryGiB  int             `json:"memoryGiB,omitempty"`
+	ZCLVersion string          `json:"zclVersion,omitempty"`
+	RuntimeID  string          `json:"runtimeId,omitempty"`
+	Binaries   []EnvBinaryV1   `json:"binaries,omitempty"`
+	Container  *EnvContainerV1 `json:"container,omitempty"`
+	ProbeError string          `json:"probeError,omitempty"`
+}
+
+type EnvBinaryV1 struct {
+	Name   string `json:"name"`
+	Path   string `json:"path,omitempty"`
+	SHA256 string `json:"sha256,omitempty"` // empty when the binary was not found
+}
+
+type EnvContainerV1 struct {
+	Engine string `json:"engine"`
+	Image  string `json:"image"`
+	// Digest is the local image ID (`<engine> image inspect --format {{.Id}}`).
+	Digest string `json:"digest,omitempty"`
+}
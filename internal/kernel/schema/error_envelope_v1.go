@@ -0,0 +1,28 @@
+package schema
+
+const ErrorEnvelopeSchemaV1 = 1
+
+// ErrorEnvelopeV1 is the single JSON object a failing command writes to stderr under
+// `zcl --error-format json`, in place of the free-text `ZCL_E_X: ...` lines.
+type ErrorEnvelopeV1 struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	OK            bool   `json:"ok"`
+	ExitCode      int    `json:"exitCode"`
+	Code          string `json:"code"`
+	Message       string `json:"message"`
+	// Retryable mirrors the contract entry for Code.
+	Retryable bool `json:"retryable"`
+	// Hints are the `hint: ...` lines the command printed.
+	Hints []string `json:"hints,omitempty"`
+	// Paths are filesystem paths mentioned in the error lines (best-effort extraction).
+	Paths []string `json:"paths,omitempty"`
+	// Errors holds every `ZCL_E_X: ...` line after the first, for commands that report several.
+	Errors []ErrorEnvelopeEntryV1 `json:"errors,omitempty"`
+	// Details is the remaining stderr text (usage help, warnings) line by line.
+	Details []string `json:"details,omitempty"`
+}
+
+type ErrorEnvelopeEntryV1 struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
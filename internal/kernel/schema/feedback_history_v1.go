@@ -0,0 +1,52 @@
+package schema
+
+import "encoding/json"
+
+// FeedbackRevisionV1 is one line in: .zcl/runs/<runId>/attempts/<attemptId>/feedback.history.jsonl
+// Every feedback write or update appends the payload it wrote; feedback.json stays canonical and
+// equals the last line.
+type FeedbackRevisionV1 struct {
+	V        int            `json:"v"`        // 1
+	Revision int            `json:"revision"` // 1-based, consecutive
+	Op       string         `json:"op"`       // write|update
+	Feedback FeedbackJSONV1 `json:"feedback"`
+}
+
+const (
+	FeedbackRevisionOpWrite  = "write"
+	FeedbackRevisionOpUpdate = "update"
+)
+
+// FeedbackChurnV1 summarizes feedback.history.jsonl in attempt.report.json.
+type FeedbackChurnV1 struct {
+	Revisions int `json:"revisions"`
+	// OKFlips counts revisions whose ok differs from the previous one; ResultChanges those whose
+	// result/resultJson differs.
+	OKFlips       int `json:"okFlips"`
+	ResultChanges int `json:"resultChanges"`
+}
+
+// FeedbackProgressEventV1 is one line in: .zcl/runs/<runId>/attempts/<attemptId>/feedback.progress.jsonl
+// Runners append milestones (`zcl feedback progress`) so long missions leave checkpoints even when
+// the final feedback.json is never written.
+type FeedbackProgressEventV1 struct {
+	V         int    `json:"v"` // 1
+	TS        string `json:"ts"`
+	RunID     string `json:"runId"`
+	SuiteID   string `json:"suiteId,omitempty"`
+	MissionID string `json:"missionId"`
+	AttemptID string `json:"attemptId"`
+	AgentID   string `json:"agentId,omitempty"`
+	Seq       int    `json:"seq"` // 1-based, consecutive
+	Milestone string `json:"milestone"`
+	// Data is an optional canonical JSON payload (bounded like note data).
+	Data              json.RawMessage `json:"data,omitempty"`
+	RedactionsApplied []string        `json:"redactionsApplied,omitempty"`
+}
+
+// FeedbackProgressV1 summarizes feedback.progress.jsonl in attempt.report.json.
+type FeedbackProgressV1 struct {
+	Milestones int    `json:"milestones"`
+	Last       string `json:"last"`
+	LastAt     string `json:"lastAt"`
+}
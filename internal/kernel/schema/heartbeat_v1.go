@@ -0,0 +1,13 @@
+package schema
+
+// AttemptHeartbeatJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/attempt.heartbeat.json
+// The suite run that owns the attempt rewrites it every IntervalMs while the attempt runs and sets
+// StoppedAt once it finished, so an old BeatAt without StoppedAt means the harness stopped beating.
+type AttemptHeartbeatJSONV1 struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	PID           int    `json:"pid"`
+	IntervalMs    int64  `json:"intervalMs"`
+	StartedAt     string `json:"startedAt"`
+	BeatAt        string `json:"beatAt"`
+	StoppedAt     string `json:"stoppedAt,omitempty"`
+}
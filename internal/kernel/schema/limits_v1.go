@@ -9,6 +9,8 @@ const (
 
 	FeedbackMaxBytesV1 = 64 * 1024
 
+	FeedbackMilestoneMaxBytesV1 = 1024
+
 	NoteMessageMaxBytesV1 = 16 * 1024
 	NoteDataMaxBytesV1    = 64 * 1024
 
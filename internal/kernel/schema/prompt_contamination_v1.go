@@ -0,0 +1,21 @@
+package schema
+
+// PromptContaminationJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/prompt.contamination.json
+// when a blind suite run rewrote a contaminated prompt (--blind-action rewrite) instead of rejecting
+// it. The untouched prompt is kept next to it as prompt.original.txt.
+type PromptContaminationJSONV1 struct {
+	SchemaVersion   int                         `json:"schemaVersion"`
+	RunID           string                      `json:"runId"`
+	AttemptID       string                      `json:"attemptId"`
+	Action          string                      `json:"action"`
+	Terms           []PromptContaminationTermV1 `json:"terms"`
+	Replacements    int                         `json:"replacements"`
+	OriginalSHA256  string                      `json:"originalSha256"`
+	SanitizedSHA256 string                      `json:"sanitizedSha256"`
+	CreatedAt       string                      `json:"createdAt"`
+}
+
+type PromptContaminationTermV1 struct {
+	Term  string `json:"term"`
+	Count int    `json:"count"`
+}
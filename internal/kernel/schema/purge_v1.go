@@ -0,0 +1,25 @@
+package schema
+
+// PurgeRecordV1 is one line in: .zcl/runs/<runId>/attempts/<attemptId>/purge.jsonl
+// Every confirmed `zcl purge` appends one record. The pattern is kept only as a hash so the log
+// never re-discloses what was removed.
+type PurgeRecordV1 struct {
+	V              int           `json:"v"` // 1
+	RunID          string        `json:"runId"`
+	AttemptID      string        `json:"attemptId"`
+	Reason         string        `json:"reason"`
+	PatternSHA256  string        `json:"patternSha256"`
+	Replacement    string        `json:"replacement"`
+	Files          []PurgeFileV1 `json:"files"`
+	Matches        int           `json:"matches"`
+	TraceRechained bool          `json:"traceRechained,omitempty"`
+	PurgedAt       string        `json:"purgedAt"` // RFC3339 UTC
+}
+
+// PurgeFileV1 is one rewritten artifact; Path is relative to the attempt dir.
+type PurgeFileV1 struct {
+	Path         string `json:"path"`
+	Matches      int    `json:"matches"`
+	SHA256Before string `json:"sha256Before"`
+	SHA256After  string `json:"sha256After"`
+}
@@ -0,0 +1,28 @@
+package schema
+
+// RedactionVerifyJSONV1 is written to: .zcl/runs/<runId>/redaction.verify.json
+// It records a re-scan of every stored artifact of the run with the rule set named in Ruleset;
+// hits carry location and rule only, never the matched text.
+type RedactionVerifyJSONV1 struct {
+	SchemaVersion int                    `json:"schemaVersion"`
+	RunID         string                 `json:"runId"`
+	OK            bool                   `json:"ok"`
+	Ruleset       RedactionRulesetV1     `json:"ruleset"`
+	FilesScanned  int                    `json:"filesScanned"`
+	Hits          []RedactionVerifyHitV1 `json:"hits"`
+	Skipped       []string               `json:"skipped,omitempty"`
+	Truncated     []string               `json:"truncated,omitempty"`
+	VerifiedAt    string                 `json:"verifiedAt"` // RFC3339 UTC
+}
+
+type RedactionRulesetV1 struct {
+	Rules      []string `json:"rules"`
+	Allowlists []string `json:"allowlists,omitempty"`
+	SHA256     string   `json:"sha256"`
+}
+
+type RedactionVerifyHitV1 struct {
+	Path string `json:"path"` // relative to the run dir, slash-separated
+	Line int    `json:"line"`
+	Rule string `json:"rule"`
+}
@@ -0,0 +1,34 @@
+package schema
+
+// ResourceSampleV1 is one line in: .zcl/runs/<runId>/attempts/<attemptId>/resources.jsonl
+// The runner's process tree (runner pid plus descendants) is sampled while it runs; host fields
+// tell a slow agent apart from a thrashing machine.
+type ResourceSampleV1 struct {
+	V         int    `json:"v"`  // 1
+	TS        string `json:"ts"` // RFC3339 UTC
+	ElapsedMs int64  `json:"elapsedMs"`
+
+	// CPUPercent is CPU time used since the previous sample over wall time (100 = one full core).
+	// CPUSeconds is cumulative, including reaped children.
+	CPUPercent float64 `json:"cpuPercent"`
+	CPUSeconds float64 `json:"cpuSeconds"`
+	RSSBytes   int64   `json:"rssBytes"`
+	Procs      int     `json:"procs"`
+	Threads    int     `json:"threads"`
+
+	HostLoad1             float64 `json:"hostLoad1"`
+	HostMemAvailableBytes int64   `json:"hostMemAvailableBytes,omitempty"`
+}
+
+// AttemptResourcesV1 summarizes resources.jsonl in attempt.report.json metrics.
+type AttemptResourcesV1 struct {
+	Samples        int     `json:"samples"`
+	CPUSeconds     float64 `json:"cpuSeconds"`
+	CPUPercentAvg  float64 `json:"cpuPercentAvg"`
+	CPUPercentPeak float64 `json:"cpuPercentPeak"`
+	RSSBytesPeak   int64   `json:"rssBytesPeak"`
+	ProcsPeak      int     `json:"procsPeak"`
+	HostLoad1Peak  float64 `json:"hostLoad1Peak"`
+	// HostMemAvailableBytesMin is the lowest MemAvailable seen (0 when the host does not report it).
+	HostMemAvailableBytesMin int64 `json:"hostMemAvailableBytesMin,omitempty"`
+}
@@ -0,0 +1,15 @@
+package schema
+
+// ReviewJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/review.json
+// It records a human reviewer's manual verification of the attempt; it never rewrites feedback.json.
+type ReviewJSONV1 struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	RunID         string `json:"runId"`
+	SuiteID       string `json:"suiteId"`
+	MissionID     string `json:"missionId"`
+	AttemptID     string `json:"attemptId"`
+	OK            bool   `json:"ok"`
+	Notes         string `json:"notes,omitempty"`
+	Reviewer      string `json:"reviewer"`
+	ReviewedAt    string `json:"reviewedAt"` // RFC3339 UTC
+}
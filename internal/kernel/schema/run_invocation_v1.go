@@ -0,0 +1,18 @@
+package schema
+
+// RunInvocationJSONV1 is written to: .zcl/runs/<runId>/run.invocation.json
+//
+// It records the `zcl suite run` invocation that created the run, so `zcl repro bundle` can
+// package it and `zcl repro run` can re-execute it.
+type RunInvocationJSONV1 struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	RunID         string `json:"runId"`
+	ZCLVersion    string `json:"zclVersion,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+	Cwd           string `json:"cwd,omitempty"`
+	// Argv is the zcl argument list without the binary name, e.g. ["suite","run","--file",...].
+	Argv []string `json:"argv"`
+	// Env holds extra attempt env injected by the caller (campaign flows), redacted by the
+	// runtime env policy.
+	Env map[string]string `json:"env,omitempty"`
+}
@@ -15,8 +15,10 @@ type RunnerRefJSONV1 struct {
 	ThreadID    string `json:"threadId,omitempty"`
 
 	RuntimeID string `json:"runtimeId,omitempty"`
-	SessionID string `json:"sessionId,omitempty"`
-	Transport string `json:"transport,omitempty"`
+	// RuntimeVersion is the version reported by the native runtime at session start, when known.
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	SessionID      string `json:"sessionId,omitempty"`
+	Transport      string `json:"transport,omitempty"`
 }
 
 // RunnerMetricsJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/runner.metrics.json
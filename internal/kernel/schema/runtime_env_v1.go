@@ -30,6 +30,64 @@ type AttemptRuntimeContextV1 struct {
 	StartCwdMode   string `json:"startCwdMode,omitempty"`
 	StartCwd       string `json:"startCwd,omitempty"`
 	StartCwdRetain string `json:"startCwdRetain,omitempty"`
+	// Container is set when the runner ran inside a per-attempt container (runner.type=docker).
+	Container *AttemptContainerV1 `json:"container,omitempty"`
+	// Sandbox is the filesystem profile a sandboxed process runner ran under (--sandbox).
+	Sandbox *AttemptSandboxV1 `json:"sandbox,omitempty"`
+	// Network is "none" when the runner ran without network access (--network none), or
+	// "advisory-allowlist" when its egress was pointed at the proxy limited to AllowHosts.
+	Network    string   `json:"network,omitempty"`
+	AllowHosts []string `json:"allowHosts,omitempty"`
+	// Limits are the runner.limits applied to a process runner's cgroup scope.
+	Limits *AttemptLimitsV1 `json:"limits,omitempty"`
+	// Home is the ephemeral per-attempt HOME the runner got (--home ephemeral).
+	Home *AttemptHomeV1 `json:"home,omitempty"`
+	// Remote is set when the runner ran on another machine over ssh (runner.type=ssh).
+	Remote *AttemptRemoteV1 `json:"remote,omitempty"`
+}
+
+type AttemptRemoteV1 struct {
+	Host    string `json:"host"`
+	Port    int    `json:"port,omitempty"`
+	WorkDir string `json:"workDir"`
+	ZCLPath string `json:"zclPath"`
+	// Sync are the extra attempt-relative globs pulled back (empty = whole remote attempt dir).
+	Sync []string `json:"sync,omitempty"`
+}
+
+type AttemptHomeV1 struct {
+	Dir      string `json:"dir"`
+	Template string `json:"template,omitempty"`
+	// Env lists the variables pointed into Dir (HOME, XDG_*, tool config dirs).
+	Env []string `json:"env"`
+}
+
+type AttemptLimitsV1 struct {
+	CPU      float64 `json:"cpu,omitempty"`
+	MemoryMB int64   `json:"memoryMb,omitempty"`
+	Pids     int64   `json:"pids,omitempty"`
+}
+
+type AttemptSandboxV1 struct {
+	Kind string `json:"kind"`
+	// Repo is the read-only repo root the runner starts in.
+	Repo     string   `json:"repo,omitempty"`
+	ReadOnly []string `json:"readOnly,omitempty"`
+	Writable []string `json:"writable"`
+	// Hidden paths are replaced by empty tmpfs (always $HOME and /tmp).
+	Hidden []string `json:"hidden,omitempty"`
+}
+
+type AttemptContainerV1 struct {
+	Engine   string   `json:"engine"`
+	Image    string   `json:"image"`
+	Network  string   `json:"network"`
+	Mounts   []string `json:"mounts,omitempty"`
+	Rootless bool     `json:"rootless,omitempty"`
+	// CPU/MemoryMB/Pids are the cgroup limits applied to the container (0 = unlimited).
+	CPU      float64 `json:"cpu,omitempty"`
+	MemoryMB int64   `json:"memoryMb,omitempty"`
+	Pids     int64   `json:"pids,omitempty"`
 }
 
 type AttemptPromptMetadataV1 struct {
@@ -0,0 +1,35 @@
+package schema
+
+import "encoding/json"
+
+// ToolCassetteEntryV1 is one line in: tool.cassette.jsonl
+// It records one funnel tool response (VCR record mode) so a later attempt can serve it
+// instead of re-running the tool (VCR replay mode).
+type ToolCassetteEntryV1 struct {
+	V  int    `json:"v"`  // 1
+	TS string `json:"ts"` // RFC3339 UTC
+
+	// Key is sha256 over the canonical {tool, op, input} tuple; replay matches on it.
+	Key  string `json:"key"`
+	Tool string `json:"tool"` // cli|mcp
+	Op   string `json:"op"`   // exec|tools/call
+
+	Input json.RawMessage `json:"input,omitempty"` // redacted argv / tools/call params (informational)
+
+	// cli/exec responses.
+	ExitCode *int   `json:"exitCode,omitempty"`
+	Stdout   string `json:"stdout,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+
+	// mcp/tools/call responses (JSON-RPC result or error object).
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+
+	DurationMs int64 `json:"durationMs"`
+	// Truncated marks stdout/stderr cut at CaptureMaxBytesV1; replay serves the bounded bytes.
+	Truncated         bool     `json:"truncated,omitempty"`
+	RedactionsApplied []string `json:"redactionsApplied,omitempty"`
+
+	// Replayed marks entries served from another cassette rather than recorded from a live tool.
+	Replayed bool `json:"replayed,omitempty"`
+}
@@ -0,0 +1,21 @@
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// TraceChainGenesisV1 is the prevHash of the first event in a hash-chained tool.calls.jsonl.
+const TraceChainGenesisV1 = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// TraceChainLinkV1 returns the prevHash the next trace event must carry, given the previous
+// event's prevHash and its exact JSONL line bytes (without the trailing newline).
+// Editing, dropping or reordering any event breaks every later link.
+func TraceChainLinkV1(prevHash string, line []byte) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(strings.TrimSpace(prevHash)))
+	_, _ = h.Write([]byte{'\n'})
+	_, _ = h.Write(line)
+	return hex.EncodeToString(h.Sum(nil))
+}
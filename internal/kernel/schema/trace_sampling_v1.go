@@ -0,0 +1,44 @@
+package schema
+
+import "strings"
+
+// TraceSamplingRuleV1 keeps 1-in-KeepEvery successful trace events matching Tool (+ optional Op/Prefix).
+// Failed events and events matching no rule are always kept, so gate evidence is never sampled away.
+type TraceSamplingRuleV1 struct {
+	Tool string `json:"tool"` // cli|mcp|http
+	Op   string `json:"op,omitempty"`
+	// Prefix matches the call target: cli argv[0], mcp tools/call params.name, http url.
+	Prefix    string `json:"prefix,omitempty"`
+	KeepEvery int64  `json:"keepEvery"`
+}
+
+// TraceSamplingJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/trace.sampling.json
+// It carries the aggregate counters for events that sampling omitted from tool.calls.jsonl.
+type TraceSamplingJSONV1 struct {
+	SchemaVersion int                        `json:"schemaVersion"`
+	Rules         []TraceSamplingRuleStateV1 `json:"rules"`
+}
+
+type TraceSamplingRuleStateV1 struct {
+	TraceSamplingRuleV1
+	Seen    int64 `json:"seen"`
+	Kept    int64 `json:"kept"`
+	Omitted int64 `json:"omitted"`
+}
+
+func (s TraceSamplingJSONV1) OmittedTotal() int64 {
+	var n int64
+	for _, r := range s.Rules {
+		n += r.Omitted
+	}
+	return n
+}
+
+func IsValidTraceSamplingToolV1(tool string) bool {
+	switch strings.TrimSpace(tool) {
+	case "cli", "mcp", "http":
+		return true
+	default:
+		return false
+	}
+}
@@ -18,6 +18,8 @@ type RunJSONV1 struct {
 	SuiteID               string `json:"suiteId"`
 	CreatedAt             string `json:"createdAt"` // RFC3339 UTC (use consistent precision)
 	Pinned                bool   `json:"pinned,omitempty"`
+	// CompactedAt is set by `zcl runs compact` once logs/traces were gzip-compressed in place.
+	CompactedAt string `json:"compactedAt,omitempty"`
 }
 
 // AttemptJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/attempt.json
@@ -43,6 +45,8 @@ type AttemptJSONV1 struct {
 	Blind bool `json:"blind,omitempty"`
 	// BlindTerms is the normalized list of harness terms used for contamination checks.
 	BlindTerms []string `json:"blindTerms,omitempty"`
+	// TraceSampling lists per-tool sampling rules applied by funnels when appending tool.calls.jsonl.
+	TraceSampling []TraceSamplingRuleV1 `json:"traceSampling,omitempty"`
 	// ScratchDir is a per-attempt scratch directory under <outRoot>/tmp.
 	// It is optional but recommended for tools that need temporary files.
 	ScratchDir string `json:"scratchDir,omitempty"`
@@ -50,6 +54,11 @@ type AttemptJSONV1 struct {
 	AttemptEnvSH string `json:"attemptEnvSh,omitempty"`
 	// NativeResult captures native codex_app_server final-answer extraction provenance.
 	NativeResult *NativeResultProvenanceV1 `json:"nativeResult,omitempty"`
+	// Provenance records the code and environment that started the attempt.
+	Provenance *AttemptProvenanceV1 `json:"provenance,omitempty"`
+	// FeedbackKeyID pins the key feedback.json must be signed with; validate rejects unsigned or
+	// differently signed feedback.
+	FeedbackKeyID string `json:"feedbackKeyId,omitempty"`
 }
 
 // FeedbackJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/feedback.json
@@ -69,9 +78,25 @@ type FeedbackJSONV1 struct {
 	Classification string `json:"classification,omitempty"`
 	// DecisionTags are normalized outcome tags for cross-run comparability.
 	DecisionTags []string `json:"decisionTags,omitempty"`
-	CreatedAt    string   `json:"createdAt"` // RFC3339 UTC (use consistent precision)
+	// Confidence is the agent's claimed probability (0..1) that the outcome is correct; campaign
+	// reports calibrate it against verified correctness.
+	Confidence *float64 `json:"confidence,omitempty"`
+	// Revision is the feedback.history.jsonl revision this payload was written as.
+	Revision  int    `json:"revision,omitempty"`
+	CreatedAt string `json:"createdAt"` // RFC3339 UTC (use consistent precision)
 	// RedactionsApplied is informational only; scoring must not depend on it.
 	RedactionsApplied []string `json:"redactionsApplied,omitempty"`
+	// Signature is set when the runner signed feedback (ZCL_FEEDBACK_SIGNING_KEY); it covers the
+	// canonical JSON of this document with signature unset.
+	Signature *FeedbackSignatureV1 `json:"signature,omitempty"`
+}
+
+// FeedbackSignatureV1 is an ed25519 signature over feedback.json. PublicKey and Value are base64.
+type FeedbackSignatureV1 struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"keyId"`
+	PublicKey string `json:"publicKey"`
+	Value     string `json:"value"`
 }
 
 // AttemptReportJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/attempt.report.json
@@ -86,7 +111,9 @@ type AttemptReportJSONV1 struct {
 	StartedAt string `json:"startedAt,omitempty"`
 	EndedAt   string `json:"endedAt,omitempty"`
 
-	OK *bool `json:"ok,omitempty"` // copied from feedback when present
+	OK *bool `json:"ok,omitempty"` // copied from feedback when present; replaced by a verdict override
+	// VerdictOverride is set when verdict.override.json adjudicated the attempt; OK then reflects the override.
+	VerdictOverride *VerdictOverrideReportV1 `json:"verdictOverride,omitempty"`
 
 	// Exactly one of Result or ResultJSON may be set (copied from feedback when present).
 	Result     string          `json:"result,omitempty"`
@@ -94,6 +121,11 @@ type AttemptReportJSONV1 struct {
 
 	Classification string   `json:"classification,omitempty"`
 	DecisionTags   []string `json:"decisionTags,omitempty"`
+	Confidence     *float64 `json:"confidence,omitempty"` // copied from feedback when present
+	// FeedbackChurn is set when feedback.history.jsonl records the attempt's feedback revisions.
+	FeedbackChurn *FeedbackChurnV1 `json:"feedbackChurn,omitempty"`
+	// Progress is set when feedback.progress.jsonl recorded milestones (also without feedback.json).
+	Progress *FeedbackProgressV1 `json:"progress,omitempty"`
 	// NativeResult mirrors attempt-native result extraction provenance.
 	NativeResult *NativeResultProvenanceV1 `json:"nativeResult,omitempty"`
 
@@ -113,6 +145,14 @@ type AttemptReportJSONV1 struct {
 	Expectations *ExpectationResultV1 `json:"expectations,omitempty"`
 }
 
+type VerdictOverrideReportV1 struct {
+	OK         bool   `json:"ok"`
+	OriginalOK *bool  `json:"originalOk,omitempty"`
+	Reason     string `json:"reason"`
+	By         string `json:"by"`
+	CreatedAt  string `json:"createdAt"`
+}
+
 type AttemptArtifactsV1 struct {
 	AttemptJSON           string `json:"attemptJson"`
 	TraceJSONL            string `json:"toolCallsJsonl"`
@@ -120,7 +160,14 @@ type AttemptArtifactsV1 struct {
 	AttemptEnvSH          string `json:"attemptEnvSh,omitempty"`
 	AttemptRuntimeEnvJSON string `json:"attemptRuntimeEnvJson,omitempty"`
 	NotesJSONL            string `json:"notesJsonl,omitempty"`
+	FeedbackHistoryJSONL  string `json:"feedbackHistoryJsonl,omitempty"`
+	FeedbackProgressJSONL string `json:"feedbackProgressJsonl,omitempty"`
 	PromptTXT             string `json:"promptTxt,omitempty"`
+	// PromptOriginal*/PromptContamination* exist when blind suite run rewrote the prompt.
+	PromptOriginalTXT       string `json:"promptOriginalTxt,omitempty"`
+	PromptContaminationJSON string `json:"promptContaminationJson,omitempty"`
+	// PurgeJSONL exists once zcl purge removed content from the attempt.
+	PurgeJSONL string `json:"purgeJsonl,omitempty"`
 	// Runner* are produced by suite orchestration when runner IO capture is enabled.
 	RunnerCommandTXT string `json:"runnerCommandTxt,omitempty"`
 	RunnerStdoutLOG  string `json:"runnerStdoutLog,omitempty"`
@@ -134,6 +181,17 @@ type AttemptIntegrityV1 struct {
 	FunnelBypassSuspected    bool     `json:"funnelBypassSuspected,omitempty"`
 	PromptContaminated       bool     `json:"promptContaminated,omitempty"`
 	PromptContaminationTerms []string `json:"promptContaminationTerms,omitempty"`
+	// PromptRewritten is set when blind suite run sanitized prompt.txt before launch
+	// (see prompt.contamination.json); the runner never saw the original terms.
+	PromptRewritten bool `json:"promptRewritten,omitempty"`
+	// OutputContaminated is set for blind attempts when runner output or the feedback result
+	// mentions harness terms (a shim or prompt leaked ZCL concepts to the model).
+	OutputContaminated         bool     `json:"outputContaminated,omitempty"`
+	OutputContaminationTerms   []string `json:"outputContaminationTerms,omitempty"`
+	OutputContaminationSources []string `json:"outputContaminationSources,omitempty"`
+	// TraceChainHead is the chain link over the last hash-chained trace event; it anchors
+	// tool.calls.jsonl so truncation or a rewritten tail is detectable later.
+	TraceChainHead string `json:"traceChainHead,omitempty"`
 }
 
 // AttemptSignalsV1 are lightweight, trace-derived “stuck/thrash” signals intended for quick triage.
@@ -151,6 +209,27 @@ type AttemptSignalsV1 struct {
 	CommandNamesSeen []string `json:"commandNamesSeen,omitempty"`
 }
 
+// AttemptProvenanceV1 is captured at attempt start. Fields that could not be resolved are omitted.
+type AttemptProvenanceV1 struct {
+	ZCLVersion string `json:"zclVersion,omitempty"`
+	// GitCommit is HEAD of the repo zcl was invoked from; GitDirty reports tracked changes on top of it.
+	GitCommit string            `json:"gitCommit,omitempty"`
+	GitDirty  bool              `json:"gitDirty,omitempty"`
+	Hostname  string            `json:"hostname,omitempty"`
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	Runtimes  []RuntimeBinaryV1 `json:"runtimes,omitempty"`
+}
+
+// RuntimeBinaryV1 identifies a runner/runtime binary by resolved path and content hash. Version is
+// the first line of `<bin> --version` and is only probed for known agent CLIs.
+type RuntimeBinaryV1 struct {
+	Name    string `json:"name"`
+	Path    string `json:"path,omitempty"`
+	SHA256  string `json:"sha256,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
 type NativeResultProvenanceV1 struct {
 	// ResultSource identifies how native codex_app_server extracted the final answer.
 	// Allowed values: task_complete_last_agent_message|phase_final_answer|delta_fallback
@@ -172,6 +251,8 @@ type ExpectationResultV1 struct {
 type ExpectationFailureV1 struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// Pointer is the RFC 6901 pointer into feedback.resultJson for schema violations.
+	Pointer string `json:"pointer,omitempty"`
 }
 
 type AttemptMetricsV1 struct {
@@ -197,6 +278,19 @@ type AttemptMetricsV1 struct {
 
 	ToolCallsByTool map[string]int64 `json:"toolCallsByTool,omitempty"`
 	ToolCallsByOp   map[string]int64 `json:"toolCallsByOp,omitempty"`
+
+	// SampledOutTotal counts successful calls omitted by trace sampling (see trace.sampling.json).
+	// They are included in ToolCallsTotal so budgets stay honest.
+	SampledOutTotal int64 `json:"sampledOutTotal,omitempty"`
+
+	// DiskBytes/DiskBytesPeak come from disk.usage.json (suite run process runners): bytes kept
+	// under the attempt dir and workspace when the runner exited, and the highest sample seen.
+	DiskBytes         int64 `json:"diskBytes,omitempty"`
+	DiskBytesPeak     int64 `json:"diskBytesPeak,omitempty"`
+	DiskQuotaExceeded bool  `json:"diskQuotaExceeded,omitempty"`
+
+	// Resources summarizes resources.jsonl (suite run process runners sampled on Linux).
+	Resources *AttemptResourcesV1 `json:"resources,omitempty"`
 }
 
 type TokenEstimatesV1 struct {
@@ -233,6 +327,9 @@ type TraceEventV1 struct {
 	Enrichment        json.RawMessage   `json:"enrichment,omitempty"`
 	Warnings          []TraceWarningV1  `json:"warnings,omitempty"`
 	Integrity         *TraceIntegrityV1 `json:"integrity,omitempty"`
+
+	// PrevHash links this event to the previous line (see TraceChainLinkV1) so edits are detectable.
+	PrevHash string `json:"prevHash,omitempty"`
 }
 
 type TraceResultV1 struct {
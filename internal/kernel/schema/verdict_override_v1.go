@@ -0,0 +1,26 @@
+package schema
+
+// VerdictOverrideJSONV1 is written to: .zcl/runs/<runId>/attempts/<attemptId>/verdict.override.json
+// The top-level fields are the override in force; History keeps every earlier override so
+// adjudications stay auditable.
+type VerdictOverrideJSONV1 struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	RunID         string `json:"runId"`
+	SuiteID       string `json:"suiteId"`
+	MissionID     string `json:"missionId"`
+	AttemptID     string `json:"attemptId"`
+	OK            bool   `json:"ok"`
+	// OriginalOK is feedback.json ok when the first override was written (nil when feedback was missing).
+	OriginalOK *bool                    `json:"originalOk,omitempty"`
+	Reason     string                   `json:"reason"`
+	By         string                   `json:"by"`
+	CreatedAt  string                   `json:"createdAt"` // RFC3339 UTC
+	History    []VerdictOverrideEntryV1 `json:"history,omitempty"`
+}
+
+type VerdictOverrideEntryV1 struct {
+	OK        bool   `json:"ok"`
+	Reason    string `json:"reason"`
+	By        string `json:"by"`
+	CreatedAt string `json:"createdAt"`
+}
@@ -0,0 +1,85 @@
+package sigkey
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/schema"
+	"github.com/marcohefti/zero-context-lab/internal/kernel/store"
+)
+
+// FeedbackKeyEnv names the PEM ed25519 private key runners sign feedback.json with. Campaign flows
+// set it per flow via runner.env; attempts started with it set pin its key id in attempt.json.
+const FeedbackKeyEnv = "ZCL_FEEDBACK_SIGNING_KEY"
+
+// LoadFeedbackKey loads the key named by FeedbackKeyEnv, wrapping errors with the variable name.
+func LoadFeedbackKey(path string) (ed25519.PrivateKey, error) {
+	priv, err := LoadPrivateKey(strings.TrimSpace(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", FeedbackKeyEnv, err)
+	}
+	return priv, nil
+}
+
+// FeedbackKeyID returns the id of the feedback signing key at path ("" when path is empty).
+func FeedbackKeyID(path string) (string, error) {
+	if strings.TrimSpace(path) == "" {
+		return "", nil
+	}
+	priv, err := LoadFeedbackKey(path)
+	if err != nil {
+		return "", err
+	}
+	return KeyID(priv.Public().(ed25519.PublicKey)), nil
+}
+
+// SignFeedback returns fb with an ed25519 signature over its canonical JSON (signature unset), so
+// any later edit of feedback.json stops verifying.
+func SignFeedback(fb schema.FeedbackJSONV1, priv ed25519.PrivateKey) (schema.FeedbackJSONV1, error) {
+	fb.Signature = nil
+	payload, err := store.CanonicalJSON(fb)
+	if err != nil {
+		return schema.FeedbackJSONV1{}, err
+	}
+	pub := priv.Public().(ed25519.PublicKey)
+	fb.Signature = &schema.FeedbackSignatureV1{
+		Algorithm: Algorithm,
+		KeyID:     KeyID(pub),
+		PublicKey: base64.StdEncoding.EncodeToString(pub),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(priv, payload)),
+	}
+	return fb, nil
+}
+
+// VerifyFeedback checks fb's embedded signature and returns the key id that produced it.
+func VerifyFeedback(fb schema.FeedbackJSONV1) (string, error) {
+	sig := fb.Signature
+	if sig == nil {
+		return "", fmt.Errorf("feedback is not signed")
+	}
+	if sig.Algorithm != Algorithm {
+		return "", fmt.Errorf("unsupported feedback signature algorithm %q", sig.Algorithm)
+	}
+	pub, err := base64.StdEncoding.DecodeString(sig.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return "", fmt.Errorf("feedback signature public key is invalid")
+	}
+	if KeyID(pub) != sig.KeyID {
+		return "", fmt.Errorf("feedback signature keyId does not match its public key")
+	}
+	value, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return "", fmt.Errorf("feedback signature is not valid base64")
+	}
+	fb.Signature = nil
+	payload, err := store.CanonicalJSON(fb)
+	if err != nil {
+		return "", err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub), payload, value) {
+		return "", fmt.Errorf("feedback signature does not match feedback.json (signed by %s)", sig.KeyID)
+	}
+	return sig.KeyID, nil
+}
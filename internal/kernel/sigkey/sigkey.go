@@ -0,0 +1,69 @@
+// Package sigkey holds the ed25519 key handling shared by campaign signing (zcl sign/verify) and
+// runner-signed feedback.json.
+package sigkey
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+const Algorithm = "ed25519"
+
+// KeyID is a short, stable fingerprint of a public key: "ed25519:" + the first 16 hex chars of its sha256.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return Algorithm + ":" + hex.EncodeToString(sum[:])[:16]
+}
+
+// LoadPrivateKey reads a PEM "PRIVATE KEY" (PKCS#8) ed25519 key, e.g. from
+// `openssl genpkey -algorithm ed25519 -out zcl-signing.pem`.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	priv, ok := k.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an ed25519 private key", path)
+	}
+	return priv, nil
+}
+
+// LoadPublicKey reads a PEM "PUBLIC KEY" (PKIX) ed25519 key, e.g. from
+// `openssl pkey -in zcl-signing.pem -pubout -out zcl-signing.pub.pem`.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	k, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	pub, ok := k.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an ed25519 public key", path)
+	}
+	return pub, nil
+}
+
+func readPEM(path, blockType string) (*pem.Block, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("%s: expected a PEM %q block", path, blockType)
+	}
+	return block, nil
+}
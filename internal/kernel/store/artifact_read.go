@@ -0,0 +1,74 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+)
+
+// ResolveArtifactPath returns path when it exists, else its compacted path+".gz" or encrypted
+// path+".enc" sibling when present, else path unchanged so callers keep reporting the canonical
+// name as missing.
+func ResolveArtifactPath(path string) string {
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	for _, suffix := range []string{GzipSuffix, EncryptedSuffix} {
+		if _, err := os.Stat(path + suffix); err == nil {
+			return path + suffix
+		}
+	}
+	return path
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g gzipReadCloser) Close() error {
+	_ = g.Reader.Close()
+	return g.f.Close()
+}
+
+// OpenArtifact opens path (or its .gz/.enc sibling) and returns the plain stream: compacted copies
+// are decompressed and encrypted copies are decrypted with the registered identities.
+func OpenArtifact(path string) (io.ReadCloser, error) {
+	p := ResolveArtifactPath(path)
+	if strings.HasSuffix(p, EncryptedSuffix) {
+		raw, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		plain, err := Decrypt(raw)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(plain)), nil
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(p, GzipSuffix) {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return gzipReadCloser{Reader: zr, f: f}, nil
+}
+
+// ReadArtifactFile is os.ReadFile for artifacts that may have been compacted or encrypted.
+func ReadArtifactFile(path string) ([]byte, error) {
+	rc, err := OpenArtifact(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+	return io.ReadAll(rc)
+}
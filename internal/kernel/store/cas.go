@@ -0,0 +1,102 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CASMinBytes is the size from which WriteFileCAS deduplicates. Below one filesystem block a
+// hardlink saves nothing, so small files are written in place.
+const CASMinBytes = 4096
+
+// CASDir is the content-addressed blob store below an out-root.
+func CASDir(outRoot string) string {
+	return filepath.Join(outRoot, "cas", "sha256")
+}
+
+// CASBlobPath returns where a blob with the given hex sha256 is stored.
+func CASBlobPath(outRoot, sum string) string {
+	return filepath.Join(CASDir(outRoot), sum[:2], sum)
+}
+
+// WriteFileCAS writes b to path like WriteFileAtomic, but stores the bytes once under
+// <outRoot>/cas/sha256/<aa>/<sha256> and hardlinks path to that blob, so identical suite snapshots
+// and prompts across thousands of attempts share one copy. Readers see a regular file. Linked files
+// must only be rewritten through WriteFileAtomic/WriteJSONAtomic, which replace the link instead of
+// writing into the shared blob.
+// When linking is not possible (e.g. a cross-device out-root) it falls back to a plain write.
+func WriteFileCAS(outRoot, path string, b []byte) error {
+	if outRoot == "" || len(b) < CASMinBytes {
+		return WriteFileAtomic(path, b)
+	}
+	h := sha256.Sum256(b)
+	blob := CASBlobPath(outRoot, hex.EncodeToString(h[:]))
+	if _, err := os.Stat(blob); os.IsNotExist(err) {
+		if err := WriteFileAtomic(blob, b); err != nil {
+			return WriteFileAtomic(path, b)
+		}
+	} else if err != nil {
+		return WriteFileAtomic(path, b)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.tmp-%d", path, time.Now().UnixNano())
+	if err := os.Link(blob, tmp); err != nil {
+		return WriteFileAtomic(path, b)
+	}
+	if err := replaceFile(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+type CASPruneResult struct {
+	Blobs      int   `json:"blobs"`
+	Pruned     int   `json:"pruned"`
+	BytesFreed int64 `json:"bytesFreed"`
+	// Supported is false when the platform cannot report hardlink counts; nothing is pruned then.
+	Supported bool `json:"supported"`
+}
+
+// PruneCAS removes blobs no attempt or run dir links to anymore (link count 1). With dryRun it
+// only reports what would be removed.
+func PruneCAS(outRoot string, dryRun bool) (CASPruneResult, error) {
+	res := CASPruneResult{Supported: linkCountSupported}
+	root := CASDir(outRoot)
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return res, nil
+	}
+	err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		res.Blobs++
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		n, ok := linkCount(info)
+		if !ok || n > 1 {
+			return nil
+		}
+		res.Pruned++
+		res.BytesFreed += info.Size()
+		if dryRun {
+			return nil
+		}
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	})
+	return res, err
+}
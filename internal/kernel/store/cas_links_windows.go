@@ -0,0 +1,14 @@
+//go:build windows
+
+package store
+
+import "os"
+
+// os.FileInfo on Windows does not expose hardlink counts; CAS blobs are kept rather than
+// risking removal of a blob that is still linked.
+const linkCountSupported = false
+
+func linkCount(info os.FileInfo) (uint64, bool) {
+	_ = info
+	return 0, false
+}
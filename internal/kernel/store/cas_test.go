@@ -0,0 +1,66 @@
+package store
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFileCAS_DedupesAndPrunes(t *testing.T) {
+	outRoot := t.TempDir()
+	big := bytes.Repeat([]byte("suite snapshot "), CASMinBytes)
+	a := filepath.Join(outRoot, "runs", "r1", "attempts", "001", "prompt.txt")
+	b := filepath.Join(outRoot, "runs", "r1", "attempts", "002", "prompt.txt")
+	small := filepath.Join(outRoot, "runs", "r1", "attempts", "003", "prompt.txt")
+	for _, p := range []string{a, b} {
+		if err := WriteFileCAS(outRoot, p, big); err != nil {
+			t.Fatalf("WriteFileCAS: %v", err)
+		}
+	}
+	if err := WriteFileCAS(outRoot, small, []byte("tiny")); err != nil {
+		t.Fatalf("WriteFileCAS small: %v", err)
+	}
+	ia, _ := os.Stat(a)
+	ib, _ := os.Stat(b)
+	if !os.SameFile(ia, ib) {
+		t.Fatalf("expected identical large files to share one blob")
+	}
+	got, err := os.ReadFile(b)
+	if err != nil || !bytes.Equal(got, big) {
+		t.Fatalf("linked file content mismatch: %v", err)
+	}
+
+	// Rewriting a linked file must not touch the shared blob.
+	if err := WriteFileAtomic(a, []byte("rewritten")); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	if got, _ := os.ReadFile(b); !bytes.Equal(got, big) {
+		t.Fatalf("rewrite leaked into sibling link")
+	}
+
+	res, err := PruneCAS(outRoot, false)
+	if err != nil {
+		t.Fatalf("PruneCAS: %v", err)
+	}
+	if res.Blobs != 1 || res.Pruned != 0 {
+		t.Fatalf("blob still linked must be kept, got %+v", res)
+	}
+	if runtime.GOOS == "windows" {
+		return
+	}
+	if err := os.Remove(b); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	res, err = PruneCAS(outRoot, true)
+	if err != nil || res.Pruned != 1 {
+		t.Fatalf("dry-run should report the orphaned blob, got %+v err=%v", res, err)
+	}
+	if res, err = PruneCAS(outRoot, false); err != nil || res.Pruned != 1 || res.BytesFreed != int64(len(big)) {
+		t.Fatalf("expected orphaned blob to be pruned, got %+v err=%v", res, err)
+	}
+	if res, _ = PruneCAS(outRoot, false); res.Blobs != 0 {
+		t.Fatalf("expected empty CAS after prune, got %+v", res)
+	}
+}
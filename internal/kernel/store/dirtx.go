@@ -0,0 +1,114 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DirTx publishes several files into one directory as a unit. Files are written to
+// <dir>/.<name>.staging; Commit renames that to <dir>/.<name>.commit (the commit point) and then
+// moves each file into place. A crash before the commit point leaves the previous files untouched;
+// a crash after it is rolled forward by the next RecoverDirTx/BeginDirTx, or by a reader's
+// RollForwardDirTx.
+type DirTx struct {
+	dir     string
+	staging string
+	commit  string
+}
+
+func dirTxPaths(dir, name string) (string, string) {
+	return filepath.Join(dir, "."+name+".staging"), filepath.Join(dir, "."+name+".commit")
+}
+
+// BeginDirTx recovers any interrupted transaction with the same name and starts a fresh one.
+func BeginDirTx(dir, name string) (*DirTx, error) {
+	if err := RecoverDirTx(dir, name); err != nil {
+		return nil, err
+	}
+	staging, commit := dirTxPaths(dir, name)
+	if err := os.MkdirAll(staging, 0o755); err != nil {
+		return nil, err
+	}
+	return &DirTx{dir: dir, staging: staging, commit: commit}, nil
+}
+
+// Path is where file is staged; readers that must see the pending version can open it directly.
+func (tx *DirTx) Path(file string) string {
+	return filepath.Join(tx.staging, file)
+}
+
+func (tx *DirTx) WriteJSON(file string, v any) error {
+	return WriteJSONAtomic(tx.Path(file), v)
+}
+
+// Commit publishes every staged file. The staging dir is removed on success.
+func (tx *DirTx) Commit() error {
+	syncDir(tx.staging)
+	if err := os.Rename(tx.staging, tx.commit); err != nil {
+		return err
+	}
+	syncDir(tx.dir)
+	return publishDirTx(tx.dir, tx.commit)
+}
+
+// Abort drops everything staged so far.
+func (tx *DirTx) Abort() {
+	_ = os.RemoveAll(tx.staging)
+}
+
+// RecoverDirTx finishes a committed transaction and discards an uncommitted one.
+func RecoverDirTx(dir, name string) error {
+	if err := RollForwardDirTx(dir, name); err != nil {
+		return err
+	}
+	staging, _ := dirTxPaths(dir, name)
+	if err := os.RemoveAll(staging); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// RollForwardDirTx finishes a transaction that crashed after its commit point. Unlike
+// RecoverDirTx it leaves uncommitted staging alone, since that may belong to a live writer, so
+// readers call it before reading the files a transaction publishes.
+func RollForwardDirTx(dir, name string) error {
+	_, commit := dirTxPaths(dir, name)
+	if _, err := os.Stat(commit); err != nil {
+		return nil
+	}
+	return publishDirTx(dir, commit)
+}
+
+// publishDirTx moves every file of commit into dir. Several processes may publish the same commit
+// at once (a reader rolling forward next to the committer); files another one already moved are
+// skipped.
+func publishDirTx(dir, commit string) error {
+	entries, err := os.ReadDir(commit)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		if err := replaceFile(filepath.Join(commit, n), filepath.Join(dir, n)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.RemoveAll(commit)
+}
+
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirTx_CommitPublishesAndRecoveryRollsForwardOrBack(t *testing.T) {
+	dir := t.TempDir()
+	read := func(name string) string {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.json"), []byte("old\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	tx, err := BeginDirTx(dir, "finish")
+	if err != nil {
+		t.Fatalf("BeginDirTx: %v", err)
+	}
+	if err := tx.WriteJSON("a.json", "new"); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if read("a.json") != "old\n" {
+		t.Fatalf("staged write must not be visible before commit")
+	}
+	// Crash before the commit point: the next transaction discards the staged files.
+	tx2, err := BeginDirTx(dir, "finish")
+	if err != nil {
+		t.Fatalf("BeginDirTx: %v", err)
+	}
+	if _, err := os.Stat(tx.Path("a.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected uncommitted staging to be discarded, got %v", err)
+	}
+	if err := tx2.WriteJSON("a.json", "new"); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if err := tx2.WriteJSON("b.json", "new"); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if err := tx2.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if read("a.json") != "\"new\"\n" || read("b.json") != "\"new\"\n" {
+		t.Fatalf("expected both files published, got %q %q", read("a.json"), read("b.json"))
+	}
+
+	// Crash after the commit point (only one file moved): recovery publishes the rest.
+	commit := filepath.Join(dir, ".finish.commit")
+	if err := os.MkdirAll(commit, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(commit, "b.json"), []byte("\"newer\"\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := RecoverDirTx(dir, "finish"); err != nil {
+		t.Fatalf("RecoverDirTx: %v", err)
+	}
+	if read("b.json") != "\"newer\"\n" {
+		t.Fatalf("expected committed file rolled forward, got %q", read("b.json"))
+	}
+	if _, err := os.Stat(commit); !os.IsNotExist(err) {
+		t.Fatalf("expected commit dir removed, got %v", err)
+	}
+}
+
+func TestRollForwardDirTx_PublishesCommitAndLeavesStaging(t *testing.T) {
+	dir := t.TempDir()
+	if err := RollForwardDirTx(dir, "finish"); err != nil {
+		t.Fatalf("RollForwardDirTx without a transaction: %v", err)
+	}
+
+	staging := filepath.Join(dir, ".finish.staging")
+	commit := filepath.Join(dir, ".finish.commit")
+	for _, d := range []string{staging, commit} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(staging, "live.json"), []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(commit, "a.json"), []byte("\"new\"\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := RollForwardDirTx(dir, "finish"); err != nil {
+		t.Fatalf("RollForwardDirTx: %v", err)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, "a.json")); err != nil || string(b) != "\"new\"\n" {
+		t.Fatalf("expected committed file published, got %q %v", b, err)
+	}
+	if _, err := os.Stat(commit); !os.IsNotExist(err) {
+		t.Fatalf("expected commit dir removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(staging, "live.json")); err != nil {
+		t.Fatalf("expected a live writer's staging to be left alone: %v", err)
+	}
+}
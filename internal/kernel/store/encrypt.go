@@ -0,0 +1,209 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+	"filippo.io/age/plugin"
+)
+
+// EncryptedSuffix marks an artifact encrypted at rest; readers resolve the .enc sibling transparently
+// when a decryption identity is available.
+const EncryptedSuffix = ".enc"
+
+// encMagic opens every age file (https://age-encryption.org/v1).
+const encMagic = "age-encryption.org/v1\n"
+
+// ErrNoIdentity is returned when an encrypted artifact is read without a configured identity.
+var ErrNoIdentity = errors.New("encrypted artifact: no decryption identity configured (set encryption.identityFile|identityCommand or ZCL_ENCRYPTION_IDENTITY)")
+
+// pluginUI lets age plugins (KMS, HSM, hardware tokens) report progress on stderr. zcl never
+// prompts, so plugins that need interactive input fail instead of blocking a run.
+var pluginUI = &plugin.ClientUI{
+	DisplayMessage: func(name, message string) error {
+		fmt.Fprintf(os.Stderr, "age-plugin-%s: %s\n", name, message)
+		return nil
+	},
+	RequestValue: func(name, prompt string, _ bool) (string, error) {
+		return "", fmt.Errorf("age-plugin-%s requested input (%s); zcl cannot prompt", name, prompt)
+	},
+	Confirm: func(name, prompt, _, _ string) (bool, error) {
+		return false, fmt.Errorf("age-plugin-%s requested confirmation (%s); zcl cannot prompt", name, prompt)
+	},
+}
+
+// GenerateIdentity returns a new age X25519 identity for artifact encryption.
+func GenerateIdentity() (*age.X25519Identity, error) {
+	return age.GenerateX25519Identity()
+}
+
+// ParseRecipient parses an age recipient: native ("age1...", "age1pq1...") or a plugin recipient
+// ("age1<plugin>1...") that wraps the file key through the age-plugin-<plugin> binary, e.g. a KMS.
+func ParseRecipient(s string) (age.Recipient, error) {
+	s = strings.TrimSpace(s)
+	if rs, err := age.ParseRecipients(strings.NewReader(s)); err == nil && len(rs) == 1 {
+		return rs[0], nil
+	}
+	if r, err := plugin.NewRecipient(s, pluginUI); err == nil {
+		return r, nil
+	}
+	return nil, fmt.Errorf("invalid recipient: expected an age recipient (age1...)")
+}
+
+// ParseIdentities reads the identities of an age identity file body: native keys
+// ("AGE-SECRET-KEY-1...") and plugin identities ("AGE-PLUGIN-..."). Blank and # lines are ignored.
+func ParseIdentities(body string) ([]age.Identity, error) {
+	var out []age.Identity
+	for n, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var (
+			id  age.Identity
+			err error
+		)
+		if strings.HasPrefix(line, "AGE-PLUGIN-") {
+			id, err = plugin.NewIdentity(line, pluginUI)
+		} else {
+			var ids []age.Identity
+			if ids, err = age.ParseIdentities(strings.NewReader(line)); err == nil {
+				id = ids[0]
+			}
+		}
+		if err != nil {
+			// Never echo the line: it holds private key material.
+			return nil, fmt.Errorf("invalid identity at line %d: %v", n+1, err)
+		}
+		out = append(out, id)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no age identity found")
+	}
+	return out, nil
+}
+
+// RecipientID is a short fingerprint of a recipient for operator output.
+func RecipientID(r age.Recipient) string {
+	s, ok := r.(fmt.Stringer)
+	if !ok {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(s.String()))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+var identities struct {
+	mu     sync.Mutex
+	loader func() ([]age.Identity, error)
+	loaded bool
+	keys   []age.Identity
+	err    error
+}
+
+// SetIdentityLoader registers how decryption identities are obtained. The loader runs at most once,
+// on the first encrypted read, so commands that never touch encrypted artifacts never invoke a KMS.
+func SetIdentityLoader(load func() ([]age.Identity, error)) {
+	identities.mu.Lock()
+	defer identities.mu.Unlock()
+	identities.loader = load
+	identities.loaded = false
+	identities.keys = nil
+	identities.err = nil
+}
+
+func loadIdentities() ([]age.Identity, error) {
+	identities.mu.Lock()
+	defer identities.mu.Unlock()
+	if !identities.loaded {
+		identities.loaded = true
+		if identities.loader != nil {
+			identities.keys, identities.err = identities.loader()
+		}
+	}
+	if identities.err != nil {
+		return nil, identities.err
+	}
+	if len(identities.keys) == 0 {
+		return nil, ErrNoIdentity
+	}
+	return identities.keys, nil
+}
+
+// Encrypt seals b for rcpt as a binary age file.
+func Encrypt(b []byte, rcpt age.Recipient) ([]byte, error) {
+	var out bytes.Buffer
+	w, err := age.Encrypt(&out, rcpt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Decrypt opens b with the registered identities.
+func Decrypt(b []byte) ([]byte, error) {
+	if !bytes.HasPrefix(b, []byte(encMagic)) {
+		return nil, fmt.Errorf("encrypted artifact: unknown format")
+	}
+	keys, err := loadIdentities()
+	if err != nil {
+		return nil, err
+	}
+	r, err := age.Decrypt(bytes.NewReader(b), keys...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, fmt.Errorf("encrypted artifact: no configured identity can decrypt it")
+		}
+		return nil, fmt.Errorf("encrypted artifact: %w", err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("encrypted artifact: %w", err)
+	}
+	return plain, nil
+}
+
+// EncryptFile replaces path with path+".enc" and returns the byte sizes before and after. When path
+// was hardlinked into the out-root CAS and no other file links the blob anymore, the plaintext blob
+// is removed as well.
+func EncryptFile(outRoot, path string, rcpt age.Recipient) (int64, int64, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	sealed, err := Encrypt(raw, rcpt)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := WriteFileAtomic(path+EncryptedSuffix, sealed); err != nil {
+		return 0, 0, err
+	}
+	if err := os.Remove(path); err != nil {
+		return 0, 0, err
+	}
+	if outRoot != "" && len(raw) >= CASMinBytes {
+		sum := sha256.Sum256(raw)
+		blob := CASBlobPath(outRoot, hex.EncodeToString(sum[:]))
+		if info, err := os.Stat(blob); err == nil {
+			if n, ok := linkCount(info); ok && n <= 1 {
+				_ = os.Remove(blob)
+			}
+		}
+	}
+	return int64(len(raw)), int64(len(sealed)), nil
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/plugin"
+)
+
+func TestEncryptFile_ReadArtifactFileDecryptsWithIdentity(t *testing.T) {
+	id, err := GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity: %v", err)
+	}
+	rcpt, err := ParseRecipient(id.Recipient().String())
+	if err != nil {
+		t.Fatalf("ParseRecipient: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "prompt.txt")
+	if err := os.WriteFile(path, []byte("confidential fixture"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, _, err := EncryptFile("", path, rcpt); err != nil {
+		t.Fatalf("EncryptFile: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected plaintext to be removed, got %v", err)
+	}
+	if got := ResolveArtifactPath(path); got != path+EncryptedSuffix {
+		t.Fatalf("expected .enc resolution, got %s", got)
+	}
+
+	SetIdentityLoader(nil)
+	if _, err := ReadArtifactFile(path); !errors.Is(err, ErrNoIdentity) {
+		t.Fatalf("expected ErrNoIdentity without identity, got %v", err)
+	}
+
+	other, _ := GenerateIdentity()
+	SetIdentityLoader(func() ([]age.Identity, error) { return []age.Identity{other}, nil })
+	if _, err := ReadArtifactFile(path); err == nil {
+		t.Fatalf("expected a foreign identity to fail")
+	}
+
+	parsed, err := ParseIdentities("# recipient: " + id.Recipient().String() + "\n" + id.String() + "\n")
+	if err != nil {
+		t.Fatalf("ParseIdentities: %v", err)
+	}
+	SetIdentityLoader(func() ([]age.Identity, error) { return append([]age.Identity{other}, parsed...), nil })
+	t.Cleanup(func() { SetIdentityLoader(nil) })
+	got, err := ReadArtifactFile(path)
+	if err != nil {
+		t.Fatalf("ReadArtifactFile: %v", err)
+	}
+	if string(got) != "confidential fixture" {
+		t.Fatalf("unexpected plaintext %q", got)
+	}
+
+	// Sealed files are plain age files, so the age CLI (or any age library) can open them too.
+	sealed, err := os.ReadFile(path + EncryptedSuffix)
+	if err != nil {
+		t.Fatalf("read sealed: %v", err)
+	}
+	r, err := age.Decrypt(bytes.NewReader(sealed), id)
+	if err != nil {
+		t.Fatalf("age.Decrypt: %v", err)
+	}
+	if plain, _ := io.ReadAll(r); string(plain) != "confidential fixture" {
+		t.Fatalf("unexpected age plaintext %q", plain)
+	}
+}
+
+func TestParseRecipient_AcceptsPluginRecipients(t *testing.T) {
+	kms := plugin.EncodeRecipient("kms", []byte("arn:aws:kms:eu-west-1:1:key/x"))
+	r, err := ParseRecipient(kms)
+	if err != nil {
+		t.Fatalf("ParseRecipient(plugin): %v", err)
+	}
+	if p, ok := r.(*plugin.Recipient); !ok || p.Name() != "kms" {
+		t.Fatalf("expected an age-plugin-kms recipient, got %T", r)
+	}
+	if _, err := ParseRecipient("zclpub1:AAAA"); err == nil {
+		t.Fatalf("expected non-age recipient to be rejected")
+	}
+	if _, err := ParseIdentities("# comment only\n"); err == nil {
+		t.Fatalf("expected an error for a file without identities")
+	}
+}
@@ -1,19 +1,27 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
 )
 
 func WriteFileAtomic(path string, b []byte) error {
+	return WriteFileAtomicFrom(path, bytes.NewReader(b), 0o644)
+}
+
+// WriteFileAtomicFrom streams r into a temp file next to path and renames it into place, so a
+// reader (or another hardlink of the old file) never sees a partial or rewritten inode.
+func WriteFileAtomicFrom(path string, r io.Reader, perm os.FileMode) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
 
 	tmp := fmt.Sprintf("%s.tmp-%d", path, time.Now().UnixNano())
-	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
 	if err != nil {
 		return err
 	}
@@ -22,7 +30,7 @@ func WriteFileAtomic(path string, b []byte) error {
 		_ = os.Remove(tmp)
 	}()
 
-	if _, err := f.Write(b); err != nil {
+	if _, err := io.Copy(f, r); err != nil {
 		return err
 	}
 	if err := f.Sync(); err != nil {
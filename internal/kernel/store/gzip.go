@@ -0,0 +1,34 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+)
+
+// GzipSuffix marks a file compressed in place by `zcl runs compact`.
+const GzipSuffix = ".gz"
+
+// GzipFile replaces path with path+".gz" and returns the byte sizes before and after.
+// The compressed copy is written atomically before the original is removed.
+func GzipFile(path string) (int64, int64, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return 0, 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err := WriteFileAtomic(path+GzipSuffix, buf.Bytes()); err != nil {
+		return 0, 0, err
+	}
+	if err := os.Remove(path); err != nil {
+		return 0, 0, err
+	}
+	return int64(len(raw)), int64(buf.Len()), nil
+}
@@ -9,15 +9,37 @@ import (
 	"time"
 )
 
-func WriteJSONAtomic(path string, v any) error {
+func encodeJSONIndented(v any) ([]byte, error) {
 	var buf bytes.Buffer
 	enc := json.NewEncoder(&buf)
 	enc.SetIndent("", "  ")
 	enc.SetEscapeHTML(false)
 	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSONIndented is the encoding WriteJSONAtomic writes, for callers that store the bytes
+// elsewhere.
+func MarshalJSONIndented(v any) ([]byte, error) {
+	return encodeJSONIndented(v)
+}
+
+// WriteJSONCAS is WriteJSONAtomic through the content-addressed store (see WriteFileCAS).
+func WriteJSONCAS(outRoot, path string, v any) error {
+	b, err := encodeJSONIndented(v)
+	if err != nil {
+		return err
+	}
+	return WriteFileCAS(outRoot, path, b)
+}
+
+func WriteJSONAtomic(path string, v any) error {
+	b, err := encodeJSONIndented(v)
+	if err != nil {
 		return err
 	}
-	b := buf.Bytes()
 
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
@@ -9,29 +9,169 @@ import (
 )
 
 func AppendJSONL(path string, v any) error {
-	var buf bytes.Buffer
-	enc := json.NewEncoder(&buf)
-	enc.SetEscapeHTML(false)
-	if err := enc.Encode(v); err != nil {
+	b, err := encodeJSONLLine(v)
+	if err != nil {
 		return err
 	}
-	b := buf.Bytes()
 
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
 
-	lockDir := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".lock")
-	return WithDirLock(lockDir, 5*time.Second, func() error {
-		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	return WithDirLock(jsonlLockDir(path), 5*time.Second, func() error {
+		return appendJSONLBytes(path, b)
+	})
+}
+
+// AppendJSONLLinked appends the value returned by build, which receives the current last
+// non-empty line (nil for an empty/missing file). Both happen under the AppendJSONL lock,
+// so concurrent writers observe a consistent tail (used for hash-chained streams).
+func AppendJSONLLinked(path string, build func(lastLine []byte) (any, error)) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return WithDirLock(jsonlLockDir(path), 5*time.Second, func() error {
+		last, err := lastJSONLLine(path)
+		if err != nil {
+			return err
+		}
+		v, err := build(last)
+		if err != nil {
+			return err
+		}
+		b, err := encodeJSONLLine(v)
 		if err != nil {
 			return err
 		}
-		defer func() { _ = f.Close() }()
+		return appendJSONLBytes(path, b)
+	})
+}
 
-		if _, err := f.Write(b); err != nil {
+// AppendJSONLLinkedBatch appends n values in one locked write. build receives the line the
+// i-th value links to: the file's last line for i=0, then the previous value's encoded line.
+// The batch is written with a single write and one fsync; if the file ends in a torn line (a
+// writer crashed mid-append) the batch starts on a fresh line so it is never glued onto it.
+func AppendJSONLLinkedBatch(path string, n int, build func(i int, prevLine []byte) (any, error)) error {
+	if n <= 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return WithDirLock(jsonlLockDir(path), 5*time.Second, func() error {
+		last, err := lastJSONLLine(path)
+		if err != nil {
 			return err
 		}
-		return f.Sync()
+		var out bytes.Buffer
+		torn, err := endsWithTornLine(path)
+		if err != nil {
+			return err
+		}
+		if torn {
+			out.WriteByte('\n')
+		}
+		for i := 0; i < n; i++ {
+			v, err := build(i, last)
+			if err != nil {
+				return err
+			}
+			b, err := encodeJSONLLine(v)
+			if err != nil {
+				return err
+			}
+			out.Write(b)
+			last = bytes.TrimRight(b, "\n")
+		}
+		return appendJSONLBytes(path, out.Bytes())
 	})
 }
+
+func endsWithTornLine(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return false, err
+	}
+	b := make([]byte, 1)
+	if _, err := f.ReadAt(b, info.Size()-1); err != nil {
+		return false, err
+	}
+	return b[0] != '\n', nil
+}
+
+func jsonlLockDir(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".lock")
+}
+
+func encodeJSONLLine(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func appendJSONLBytes(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := f.Write(b); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// lastJSONLLine reads backwards from EOF so the cost does not grow with file size.
+func lastJSONLLine(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	const chunk = 64 * 1024
+	var tail []byte
+	for off := info.Size(); off > 0; {
+		n := int64(chunk)
+		if off < n {
+			n = off
+		}
+		off -= n
+		buf := make([]byte, n)
+		if _, err := f.ReadAt(buf, off); err != nil {
+			return nil, err
+		}
+		tail = append(buf, tail...)
+		trimmed := bytes.TrimRight(tail, " \t\r\n")
+		if len(trimmed) == 0 {
+			continue
+		}
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return bytes.TrimSpace(trimmed[i+1:]), nil
+		}
+		if off == 0 {
+			return bytes.TrimSpace(trimmed), nil
+		}
+	}
+	return nil, nil
+}
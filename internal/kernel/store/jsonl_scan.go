@@ -2,13 +2,12 @@ package store
 
 import (
 	"bufio"
-	"os"
 	"strings"
 )
 
-// JSONLHasNonEmptyLine returns true if the file contains at least one non-empty line.
+// JSONLHasNonEmptyLine returns true if the file (or its compacted .gz copy) contains at least one non-empty line.
 func JSONLHasNonEmptyLine(path string) (bool, error) {
-	f, err := os.Open(path)
+	f, err := OpenArtifact(path)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/marcohefti/zero-context-lab/internal/kernel/ids"
+)
+
+// Run-level locks live inside runs/<runId>, so independent runs sharing an out-root never contend.
+const (
+	// runAllocLockName guards the short check-or-create steps of attempt allocation
+	// (run.json, suite.json, attempts/<attemptId>).
+	runAllocLockName = ".run.alloc.lock"
+	// runOwnerLockName is held by a suite run for its whole execution.
+	runOwnerLockName = ".run.owner.lock"
+
+	runAllocLockWait = 10 * time.Second
+	runIDAttempts    = 16
+)
+
+// AllocateRunDir picks a fresh run ID and claims runs/<runId> with an exclusive mkdir, so two
+// processes starting in the same second can never end up sharing a run dir.
+func AllocateRunDir(outRoot string, now time.Time) (string, string, error) {
+	runsDir := filepath.Join(outRoot, "runs")
+	if err := os.MkdirAll(runsDir, 0o755); err != nil {
+		return "", "", err
+	}
+	for i := 0; i < runIDAttempts; i++ {
+		runID, err := ids.NewRunID(now)
+		if err != nil {
+			return "", "", err
+		}
+		runDir := filepath.Join(runsDir, runID)
+		if err := os.Mkdir(runDir, 0o755); err == nil {
+			return runID, runDir, nil
+		} else if !os.IsExist(err) {
+			return "", "", err
+		}
+	}
+	return "", "", fmt.Errorf("could not allocate a unique run id under %s", runsDir)
+}
+
+// WithRunAllocLock serializes attempt allocation within one run across processes.
+func WithRunAllocLock(runDir string, fn func() error) error {
+	return WithDirLock(filepath.Join(runDir, runAllocLockName), runAllocLockWait, fn)
+}
+
+// RunOwnedError reports that another live process holds the run's owner lock.
+type RunOwnedError struct {
+	RunDir string
+	PID    int
+}
+
+func (e RunOwnedError) Error() string {
+	if e.PID > 0 {
+		return fmt.Sprintf("run %s is being written by another process (pid %d)", filepath.Base(e.RunDir), e.PID)
+	}
+	return fmt.Sprintf("run %s is being written by another process", filepath.Base(e.RunDir))
+}
+
+// RunOwner reports whether a live process holds runDir's owner lock, without claiming it. The PID
+// is 0 when the lock carries no owner metadata.
+func RunOwner(runDir string) (int, bool) {
+	lockDir := filepath.Join(runDir, runOwnerLockName)
+	if _, err := os.Stat(lockDir); err != nil {
+		return 0, false
+	}
+	if owner, ok := readLockOwner(lockDir); ok {
+		return owner.PID, processAlive(owner.PID)
+	}
+	return 0, !shouldBreakStaleLock(lockDir, 2*time.Minute, time.Now())
+}
+
+// AcquireRunOwner claims runDir for a single writer without waiting. Stale claims from crashed
+// processes are broken the same way as other dir locks.
+func AcquireRunOwner(runDir string) (func() error, error) {
+	if err := os.MkdirAll(runDir, 0o755); err != nil {
+		return nil, err
+	}
+	lockDir := filepath.Join(runDir, runOwnerLockName)
+	release, err := acquireDirLock(lockDir, 0)
+	if err != nil {
+		if IsLockTimeout(err) {
+			owner, _ := readLockOwner(lockDir)
+			return nil, RunOwnedError{RunDir: runDir, PID: owner.PID}
+		}
+		return nil, err
+	}
+	return release, nil
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAllocateRunDir_ConcurrentCallersGetDistinctRuns(t *testing.T) {
+	outRoot := t.TempDir()
+	now := time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC)
+	const n = 32
+	var (
+		mu   sync.Mutex
+		seen = map[string]bool{}
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runID, runDir, err := AllocateRunDir(outRoot, now)
+			if err != nil {
+				t.Errorf("AllocateRunDir: %v", err)
+				return
+			}
+			if info, err := os.Stat(runDir); err != nil || !info.IsDir() {
+				t.Errorf("expected run dir %s, err=%v", runDir, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[runID] {
+				t.Errorf("run id %s allocated twice", runID)
+			}
+			seen[runID] = true
+		}()
+	}
+	wg.Wait()
+	if len(seen) != n {
+		t.Fatalf("expected %d run ids, got %d", n, len(seen))
+	}
+}
+
+func TestAcquireRunOwner_SecondWriterIsRefused(t *testing.T) {
+	runDir := filepath.Join(t.TempDir(), "runs", "20260216-120000Z-abcdef")
+	release, err := AcquireRunOwner(runDir)
+	if err != nil {
+		t.Fatalf("AcquireRunOwner: %v", err)
+	}
+	_, err = AcquireRunOwner(runDir)
+	var owned RunOwnedError
+	if !errors.As(err, &owned) || owned.PID != os.Getpid() {
+		t.Fatalf("expected RunOwnedError naming this process, got %v", err)
+	}
+	if pid, ok := RunOwner(runDir); !ok || pid != os.Getpid() {
+		t.Fatalf("RunOwner = %d, %v; want this process", pid, ok)
+	}
+	if err := release(); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if _, ok := RunOwner(runDir); ok {
+		t.Fatalf("RunOwner should report no owner after release")
+	}
+	release, err = AcquireRunOwner(runDir)
+	if err != nil {
+		t.Fatalf("expected owner lock after release, got %v", err)
+	}
+	_ = release()
+}
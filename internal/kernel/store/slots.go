@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Host-wide slots let independent zcl processes share one concurrency cap. Each slot is an
+// exclusive mkdir of <dir>/slot-<n> carrying the owner pid, so a crashed holder frees its slot as
+// soon as the pid is gone instead of after the usual stale-lock age.
+const (
+	slotPollInterval = 50 * time.Millisecond
+	startLockName    = ".start.lock"
+	startNextFile    = "next-start"
+	startLockWait    = 10 * time.Second
+)
+
+// AcquireSlot claims one of limit slots under dir, waiting until a slot frees up or ctx ends.
+// waited reports whether the first pass found every slot taken.
+func AcquireSlot(ctx context.Context, dir string, limit int) (release func() error, waited bool, err error) {
+	if limit <= 0 {
+		return nil, false, fmt.Errorf("slot limit must be > 0")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, false, err
+	}
+	for {
+		for i := 0; i < limit; i++ {
+			slotDir := filepath.Join(dir, fmt.Sprintf("slot-%03d", i))
+			if release, ok, err := tryClaimSlot(slotDir); err != nil {
+				return nil, waited, err
+			} else if ok {
+				return release, waited, nil
+			}
+		}
+		waited = true
+		timer := time.NewTimer(slotPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, waited, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func tryClaimSlot(slotDir string) (func() error, bool, error) {
+	err := os.Mkdir(slotDir, 0o755)
+	if err != nil && !os.IsExist(err) {
+		return nil, false, err
+	}
+	if err != nil {
+		if !slotAbandoned(slotDir) {
+			return nil, false, nil
+		}
+		_ = os.RemoveAll(slotDir)
+		if err := os.Mkdir(slotDir, 0o755); err != nil {
+			if os.IsExist(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+	}
+	owner := lockOwnerV1{V: 1, PID: os.Getpid(), StartedAt: time.Now().UTC().Format(time.RFC3339Nano)}
+	if b, err := json.Marshal(owner); err == nil {
+		_ = os.WriteFile(filepath.Join(slotDir, "owner.json"), b, 0o644)
+	}
+	return func() error { return os.RemoveAll(slotDir) }, true, nil
+}
+
+// slotAbandoned treats a slot as free when its owner is dead. A slot without owner metadata is
+// only broken once it is older than the dir-lock stale age (the holder may still be writing it).
+func slotAbandoned(slotDir string) bool {
+	if owner, ok := readLockOwner(slotDir); ok {
+		return !processAlive(owner.PID)
+	}
+	return shouldBreakStaleLock(slotDir, 2*time.Minute, time.Now())
+}
+
+// ReserveStart books the next start time under dir so starts across processes are spaced at
+// least interval apart. It returns how long the caller must wait before starting.
+func ReserveStart(dir string, interval time.Duration, now time.Time) (time.Duration, error) {
+	if interval <= 0 {
+		return 0, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	var wait time.Duration
+	err := WithDirLock(filepath.Join(dir, startLockName), startLockWait, func() error {
+		path := filepath.Join(dir, startNextFile)
+		start := now.UTC()
+		if raw, err := os.ReadFile(path); err == nil {
+			if next, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(raw))); err == nil && next.After(start) {
+				start = next
+			}
+		}
+		wait = start.Sub(now.UTC())
+		return WriteFileAtomic(path, []byte(start.Add(interval).Format(time.RFC3339Nano)+"\n"))
+	})
+	return wait, err
+}
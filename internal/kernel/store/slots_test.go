@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAcquireSlot_CapsHoldersAndReclaimsDeadOwners(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "codex_app_server")
+	rel1, waited, err := AcquireSlot(context.Background(), dir, 2)
+	if err != nil || waited {
+		t.Fatalf("first slot: waited=%v err=%v", waited, err)
+	}
+	rel2, _, err := AcquireSlot(context.Background(), dir, 2)
+	if err != nil {
+		t.Fatalf("second slot: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	if _, waited, err := AcquireSlot(ctx, dir, 2); !errors.Is(err, context.DeadlineExceeded) || !waited {
+		t.Fatalf("expected full broker to block until ctx deadline, waited=%v err=%v", waited, err)
+	}
+
+	if err := rel1(); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	rel3, _, err := AcquireSlot(context.Background(), dir, 2)
+	if err != nil {
+		t.Fatalf("slot after release: %v", err)
+	}
+	_ = rel3()
+
+	// A slot held by a dead process is reclaimed without waiting for the stale age.
+	_ = rel2()
+	for _, name := range []string{"slot-000", "slot-001"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(lockOwnerV1{V: 1, PID: 1 << 30})
+		if err := os.WriteFile(filepath.Join(dir, name, "owner.json"), b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rel4, waited, err := AcquireSlot(context.Background(), dir, 2)
+	if err != nil || waited {
+		t.Fatalf("expected dead owner's slot to be reclaimed, waited=%v err=%v", waited, err)
+	}
+	_ = rel4()
+}
+
+func TestReserveStart_SpacesStartsAcrossCallers(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC)
+	interval := 500 * time.Millisecond
+	for i, want := range []time.Duration{0, interval, 2 * interval} {
+		wait, err := ReserveStart(dir, interval, now)
+		if err != nil {
+			t.Fatalf("reserve %d: %v", i, err)
+		}
+		if wait != want {
+			t.Fatalf("reserve %d: expected wait %s, got %s", i, want, wait)
+		}
+	}
+	if wait, err := ReserveStart(dir, interval, now.Add(time.Hour)); err != nil || wait != 0 {
+		t.Fatalf("expected no wait once the booked start has passed, got %s err=%v", wait, err)
+	}
+}